@@ -0,0 +1,412 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	mathrand "math/rand"
+	"mineral/data"
+	"mineral/pkg/dbstats"
+	"os"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// anonymizeBatchSize is how many rows are copied to the staging database at a time
+const anonymizeBatchSize = 1000
+
+// anonymizeSkippedTables aren't copied: queued work, request state, sign-in sessions and exports
+// that belong to production, and event streams, which are opened again from the anonymized records
+var anonymizeSkippedTables = map[string]bool{
+	"jobs":                 true,
+	"outbox_messages":      true,
+	"idempotent_requests":  true,
+	"rate_limit_buckets":   true,
+	"leases":               true,
+	"backups":              true,
+	"warehouse_watermarks": true,
+	"stream_events":        true,
+	"refresh_tokens":       true,
+	"revoked_tokens":       true,
+}
+
+// anonymizeRule is how a column's values are obfuscated
+type anonymizeRule int
+
+const (
+	keepValue    anonymizeRule = iota
+	personName                 // replaced with a made up name
+	companyName                // replaced with a made up business name
+	emailAddress               // replaced with an address at example.invalid
+	phoneNumber                // replaced with a made up number
+	contactInfo                // an email address or phone number, replaced with one of the same kind
+	freeText                   // notes and messages, replaced with a placeholder
+	moneyAmount                // jittered by the same random factor across the row
+	coordinate                 // shifted by the same offset across the books of a user
+	secretValue                // replaced with random characters
+	ipAddress                  // replaced with an address from the documentation range
+	clearValue                 // set to NULL
+	emptyJSON                  // replaced with an empty JSON object
+)
+
+// anonymizedColumns are the rules of columns by name, in every table. Values are replaced
+// consistently within a run, so the same customer name in sales, contacts and credit limits
+// still matches.
+var anonymizedColumns = map[string]anonymizeRule{
+	"name":                personName,
+	"customer_name":       personName,
+	"supplier_name":       personName,
+	"seller_name":         personName,
+	"miner_name":          personName,
+	"leader_name":         personName,
+	"employee_name":       personName,
+	"owner":               personName,
+	"confirmed_by":        personName,
+	"company":             companyName,
+	"insurer":             companyName,
+	"email":               emailAddress,
+	"phone":               phoneNumber,
+	"pending_phone":       phoneNumber,
+	"recipient":           contactInfo,
+	"customer_contact":    contactInfo,
+	"supplier_contact":    contactInfo,
+	"contact":             contactInfo,
+	"notes":               freeText,
+	"note":                freeText,
+	"message":             freeText,
+	"body":                freeText,
+	"details":             freeText,
+	"detail":              freeText,
+	"reason":              freeText,
+	"rejection_reason":    freeText,
+	"justification":       freeText,
+	"location":            freeText,
+	"village":             freeText,
+	"file_name":           freeText,
+	"subject":             secretValue,
+	"registration":        secretValue,
+	"policy_number":       secretValue,
+	"claim_number":        secretValue,
+	"id_number":           secretValue,
+	"mobile_money_number": secretValue,
+	"bank_account_number": secretValue,
+	"device_id":           secretValue,
+	"calendar_token":      secretValue,
+	"secret":              secretValue,
+	"code":                secretValue,
+	"otp_code":            clearValue,
+	"user_agent":          clearValue,
+	"last_error":          clearValue,
+	"ip_address":          ipAddress,
+	"diagnostics":         emptyJSON,
+	"latitude":            coordinate,
+	"longitude":           coordinate,
+	"check_in_latitude":   coordinate,
+	"check_in_longitude":  coordinate,
+	"check_out_latitude":  coordinate,
+	"check_out_longitude": coordinate,
+}
+
+// anonymizedTableColumns override anonymizedColumns for the columns of a table
+var anonymizedTableColumns = map[string]map[string]anonymizeRule{
+	"inventory_items":   {"name": keepValue},
+	"stocktakes":        {"name": keepValue},
+	"dunning_schedules": {"name": keepValue},
+	"mine_site_infos":   {"name": keepValue},
+	"organizations":     {"name": companyName},
+	"referral_codes":    {"code": keepValue},
+	"referrals":         {"code": keepValue},
+	"notifications":     {"message": freeText, "title": freeText},
+	"tasks":             {"title": freeText, "description": freeText},
+	"support_tickets":   {"subject": freeText},
+	"webhooks":          {"url": secretValue},
+	"insurance_claims":  {"incident_description": freeText},
+	"attachments":       {"storage_key": secretValue}, // miner ID scans and photos stay in the production store
+}
+
+// moneyColumnWords mark the columns holding money amounts, which are jittered
+var moneyColumnWords = []string{"amount", "price", "total", "value", "rate", "pay", "balance", "credit_limit", "advances", "deductions", "carried_forward", "outstanding", "premium", "insured"}
+
+var anonymizeFirstNames = []string{"Amina", "Brian", "Grace", "Joseph", "Sarah", "Moses", "Esther", "David", "Ruth", "Isaac", "Mary", "Peter", "Florence", "Samuel", "Agnes", "John", "Janet", "Robert", "Harriet", "Emmanuel", "Patience", "Daniel", "Rose", "Paul"}
+var anonymizeLastNames = []string{"Okello", "Namukasa", "Mugisha", "Achieng", "Ssemakula", "Nakato", "Tumusiime", "Atim", "Kato", "Nabirye", "Byaruhanga", "Auma", "Lubega", "Akello", "Mwesigwa", "Nansubuga"}
+
+// placeholderPhoto replaces photo evidence: a 1x1 grey PNG
+var placeholderPhoto, _ = hex.DecodeString("89504e470d0a1a0a0000000d4948445200000001000000010800000000" +
+	"3a7e9b550000000a49444154789c636800000082008177cd72b60000000049454e44ae426082")
+
+// anonymizer obfuscates rows. Replacements are keyed with a random key, so they are
+// consistent within a run but can't be reversed by hashing guesses.
+type anonymizer struct {
+	key      []byte
+	password string // bcrypt hash every account gets
+}
+
+// anonymize copies the database into an empty staging database with personal data obfuscated:
+// names, contacts, emails and free text are replaced, money amounts are jittered, locations
+// shifted and secrets replaced. Every account's password becomes ANONYMIZE_PASSWORD.
+func (app *Config) anonymize(ctx context.Context) error {
+	targetDSN := os.Getenv("STAGING_DSN")
+	if targetDSN == "" {
+		return errors.New("STAGING_DSN must be set to the staging database")
+	}
+	targetSettings := app.DBSettings
+	targetSettings.Driver = getEnv("STAGING_DB_DRIVER", app.DBSettings.Driver)
+	targetSettings.PartitionByYear = false
+	if targetSettings.Driver == DriverSQLite {
+		targetSettings.SQLitePath = targetDSN
+		targetDSN = sqliteDSN(targetDSN)
+	}
+	if targetSettings.Driver == app.DBSettings.Driver {
+		sourceDSN := databaseDSN()
+		if app.DBSettings.Driver == DriverSQLite {
+			sourceDSN = sqliteDSN(app.DBSettings.SQLitePath)
+		}
+		if targetDSN == sourceDSN {
+			return errors.New("STAGING_DSN is the production database")
+		}
+	}
+
+	target, err := openDB(targetDSN, targetSettings, &dbstats.Counter{})
+	if err != nil {
+		return fmt.Errorf("failed to connect to the staging database: %w", err)
+	}
+	staging := &Config{DB: target, DBSettings: targetSettings, InfoLog: app.InfoLog, ErrorLog: app.ErrorLog}
+	if err := staging.migrate(ctx); err != nil {
+		return fmt.Errorf("failed to migrate the staging database: %w", err)
+	}
+	var users int64
+	if err := target.Model(&data.User{}).Unscoped().Count(&users).Error; err != nil {
+		return err
+	}
+	if users > 0 {
+		return errors.New("the staging database already has data; anonymize into an empty database")
+	}
+
+	password, err := data.HashPassword(getEnv("ANONYMIZE_PASSWORD", "staging-password"))
+	if err != nil {
+		return err
+	}
+	a := &anonymizer{key: make([]byte, 32), password: password}
+	if _, err := rand.Read(a.key); err != nil {
+		return err
+	}
+
+	cache := &sync.Map{}
+	for _, model := range schemaModels() {
+		parsed, err := schema.Parse(model, cache, target.NamingStrategy)
+		if err != nil {
+			return err
+		}
+		if anonymizeSkippedTables[parsed.Table] {
+			continue
+		}
+		copied, err := a.copyTable(app.DB, target, parsed.Table)
+		if err != nil {
+			return fmt.Errorf("failed to copy %s: %w", parsed.Table, err)
+		}
+		app.InfoLog.Printf("Copied %d rows of %s", copied, parsed.Table)
+		if targetSettings.Driver == DriverPostgres && copied > 0 {
+			if err := target.Exec("SELECT setval(pg_get_serial_sequence(?, 'id'), (SELECT MAX(id) FROM "+parsed.Table+"))", parsed.Table).Error; err != nil {
+				return fmt.Errorf("failed to reset the ID sequence of %s: %w", parsed.Table, err)
+			}
+		}
+	}
+
+	// The event streams start again at the anonymized balances
+	if _, err := data.NewStreamRepository(target).Baseline(ctx); err != nil {
+		return fmt.Errorf("failed to open event streams: %w", err)
+	}
+
+	app.InfoLog.Println("Staging database anonymized")
+	return nil
+}
+
+// copyTable copies a table's rows, soft deleted ones included, to the target in batches and
+// returns how many were copied
+func (a *anonymizer) copyTable(source, target *gorm.DB, table string) (int64, error) {
+	var copied int64
+	var lastID interface{} = 0
+	for {
+		var rows []map[string]interface{}
+		err := source.Table(table).Where("id > ?", lastID).Order("id").Limit(anonymizeBatchSize).Find(&rows).Error
+		if err != nil {
+			return copied, err
+		}
+		if len(rows) == 0 {
+			return copied, nil
+		}
+
+		for _, row := range rows {
+			a.anonymizeRow(table, row)
+		}
+		if err := target.Table(table).Create(&rows).Error; err != nil {
+			return copied, err
+		}
+		copied += int64(len(rows))
+		lastID = rows[len(rows)-1]["id"]
+	}
+}
+
+// anonymizeRow obfuscates the personal data in a row in place
+func (a *anonymizer) anonymizeRow(table string, row map[string]interface{}) {
+	jitter := 0.85 + mathrand.Float64()*0.3
+	latShift, lngShift := a.coordinateShift(row["user_id"])
+
+	for column, value := range row {
+		if value == nil {
+			continue
+		}
+		if _, numeric := toFloat(value); numeric && (column == "id" || strings.HasSuffix(column, "_id")) {
+			continue
+		}
+
+		rule, ok := anonymizedTableColumns[table][column]
+		if !ok {
+			rule = anonymizedColumns[column]
+		}
+		if rule == keepValue && isMoneyColumn(column) {
+			rule = moneyAmount
+		}
+
+		switch rule {
+		case moneyAmount:
+			if amount, ok := toFloat(value); ok {
+				row[column] = math.Round(amount*jitter*100) / 100
+			}
+		case coordinate:
+			if degrees, ok := toFloat(value); ok {
+				if strings.HasSuffix(column, "latitude") {
+					row[column] = degrees + latShift
+				} else {
+					row[column] = degrees + lngShift
+				}
+			}
+		case clearValue:
+			row[column] = nil
+		case emptyJSON:
+			row[column] = "{}"
+		default:
+			if text, ok := toText(value); ok {
+				row[column] = a.replace(rule, column, text)
+			}
+		}
+	}
+
+	switch table {
+	case "users":
+		row["password"] = a.password
+	case "evidence_photos":
+		row["data"] = placeholderPhoto
+		row["content_type"] = "image/png"
+		row["size"] = len(placeholderPhoto)
+	}
+}
+
+// replace returns the replacement of a text value
+func (a *anonymizer) replace(rule anonymizeRule, column, value string) string {
+	if value == "" {
+		return value
+	}
+	sum := a.hash(column, value)
+	n := binary.BigEndian.Uint64(sum)
+	tag := hex.EncodeToString(sum[8:10])
+
+	switch rule {
+	case personName:
+		return fmt.Sprintf("%s %s %s", anonymizeFirstNames[n%uint64(len(anonymizeFirstNames))],
+			anonymizeLastNames[(n>>16)%uint64(len(anonymizeLastNames))], tag)
+	case companyName:
+		return fmt.Sprintf("%s Mining %s", anonymizeLastNames[n%uint64(len(anonymizeLastNames))], tag)
+	case emailAddress:
+		return "user-" + hex.EncodeToString(sum[:6]) + "@example.invalid"
+	case phoneNumber:
+		return fmt.Sprintf("+2567%08d", n%100000000)
+	case contactInfo:
+		if strings.Contains(value, "@") {
+			return a.replace(emailAddress, "email", value)
+		}
+		return a.replace(phoneNumber, "phone", value)
+	case freeText:
+		return "Anonymized " + strings.ReplaceAll(column, "_", " ") + " " + tag
+	case secretValue:
+		if column == "url" {
+			return "https://example.invalid/" + hex.EncodeToString(sum[:8])
+		}
+		return hex.EncodeToString(sum[:8])
+	case ipAddress:
+		return fmt.Sprintf("192.0.2.%d", n%254+1)
+	}
+	return value
+}
+
+// hash keys a value so equal values in columns of the same kind get equal replacements
+func (a *anonymizer) hash(column, value string) []byte {
+	kind := column
+	switch anonymizedColumns[column] {
+	case personName:
+		kind = "name"
+	case emailAddress:
+		kind = "email"
+	case phoneNumber:
+		kind = "phone"
+	}
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(kind + ":" + strings.ToLower(strings.TrimSpace(value))))
+	return mac.Sum(nil)
+}
+
+// coordinateShift returns the offset, up to about 5 km, applied to the locations in the books
+// of a user, so distances between a site and its attendance check-ins are kept
+func (a *anonymizer) coordinateShift(userID interface{}) (float64, float64) {
+	sum := a.hash("coordinates", fmt.Sprint(userID))
+	lat := float64(binary.BigEndian.Uint16(sum[0:2]))/math.MaxUint16 - 0.5
+	lng := float64(binary.BigEndian.Uint16(sum[2:4]))/math.MaxUint16 - 0.5
+	return lat * 0.09, lng * 0.09
+}
+
+// isMoneyColumn reports whether a column holds a money amount
+func isMoneyColumn(column string) bool {
+	for _, word := range moneyColumnWords {
+		if strings.Contains(column, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// toFloat returns a numeric column value as a float
+func toFloat(value interface{}) (float64, bool) {
+	switch value := value.(type) {
+	case float64:
+		return value, true
+	case float32:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case int32:
+		return float64(value), true
+	case int:
+		return float64(value), true
+	}
+	return 0, false
+}
+
+// toText returns a text column value as a string
+func toText(value interface{}) (string, bool) {
+	switch value := value.(type) {
+	case string:
+		return value, true
+	case []byte:
+		return string(value), true
+	}
+	return "", false
+}
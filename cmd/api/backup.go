@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mineral/data"
+	"mineral/pkg/backup"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// backupInterval is how often the database is backed up
+const backupInterval = 24 * time.Hour
+
+// runBackup dumps the database with pg_dump, encrypts the dump and uploads it to off-site
+// storage. It is skipped when a backup completed within the interval, e.g. before a restart.
+func (app *Config) runBackup(ctx context.Context) error {
+	last, err := app.Models.Backup.GetLastCompleted(ctx)
+	if err != nil {
+		return err
+	}
+	if last != nil && time.Since(last.StartedAt) < backupInterval-time.Hour {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	record := &data.Backup{
+		Key:       app.BackupPrefix + now.Format("20060102T150405Z") + ".dump.enc",
+		Status:    data.BackupRunning,
+		StartedAt: now,
+	}
+	if _, err := app.Models.Backup.Insert(ctx, record); err != nil {
+		return err
+	}
+
+	size, err := app.backupDatabase(record.Key)
+	completedAt := time.Now()
+	record.CompletedAt = &completedAt
+	if err != nil {
+		message := err.Error()
+		record.Status = data.BackupFailed
+		record.Error = &message
+	} else {
+		record.Status = data.BackupCompleted
+		record.SizeBytes = size
+	}
+	if updateErr := app.Models.Backup.Update(ctx, record); updateErr != nil {
+		app.ErrorLog.Printf("failed to record backup %s: %v", record.Key, updateErr)
+	}
+	return err
+}
+
+// backupDatabase encrypts the output of pg_dump into a temporary file and uploads it, returning
+// the uploaded size
+func (app *Config) backupDatabase(key string) (int64, error) {
+	file, err := os.CreateTemp("", "backup-*.dump.enc")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(app.PGDumpPath, "--format=custom", "--no-owner", "--dbname="+databaseDSN())
+	cmd.Stderr = &stderr
+	dump, err := cmd.StdoutPipe()
+	if err != nil {
+		return 0, err
+	}
+	if err := cmd.Start(); err != nil {
+		return 0, fmt.Errorf("pg_dump: %w", err)
+	}
+
+	encryptErr := backup.Encrypt(file, dump, app.BackupKey)
+	if encryptErr != nil {
+		// Drain the dump so pg_dump can exit
+		io.Copy(io.Discard, dump)
+	}
+	if err := cmd.Wait(); err != nil {
+		return 0, fmt.Errorf("pg_dump: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if encryptErr != nil {
+		return 0, fmt.Errorf("encrypt: %w", encryptErr)
+	}
+
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	if err := app.BackupUploader.Upload(key, file, size); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
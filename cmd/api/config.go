@@ -3,9 +3,20 @@ package main
 import (
 	"log"
 	"mineral/data"
+	"mineral/pkg/billing"
+	"mineral/pkg/dbstats"
 	"mineral/pkg/email"
+	"mineral/pkg/events"
+	"mineral/pkg/jobs"
+	"mineral/pkg/scheduler"
+	"mineral/pkg/sms"
+	"mineral/pkg/storage"
+	"os"
+	"strconv"
 	"sync"
+	"time"
 
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"gorm.io/gorm"
 )
 
@@ -16,6 +27,99 @@ type Config struct {
 	Wait          *sync.WaitGroup
 	Models        data.Models
 	Mailer        email.Mailer
+	SMS           sms.Sender
+	Jobs          *jobs.Runner
+	Events        *events.Bus // events published on the request
+	Outbox        *events.Bus // events published from the outbox by the worker
 	ErrorChan     chan error
 	ErrorChanDone chan bool
+	Scheduler     *scheduler.Scheduler
+	Queries       *dbstats.Counter         // every database query, for the query debug headers
+	Tracing       *sdktrace.TracerProvider // exports trace spans, nil when tracing is disabled
+
+	// DBSettings configures the database connection and pool
+	DBSettings DBSettings
+
+	// ExpiryAlertDays is how many days ahead supply expiry notifications are raised
+	ExpiryAlertDays int
+
+	// InsuranceAlertDays is how many days ahead insurance policy expiry notifications are raised
+	InsuranceAlertDays int
+
+	// ArchiveAfterYears is how old settled sales and expenses get before they are moved to the
+	// archive tables; 0 disables archiving
+	ArchiveAfterYears int
+
+	// Billing collects subscription payments for paid plans
+	Billing billing.Provider
+
+	// Daily database backups, enabled when BackupKey is set
+	BackupKey      []byte // AES-256 key backups are encrypted with
+	BackupUploader storage.Uploader
+	BackupPrefix   string // object key prefix in the backup bucket
+	PGDumpPath     string
+
+	// Attachments stores the files attached to income and expense records
+	Attachments storage.Store
+
+	// Exports of transaction data to the analytics warehouse, enabled when WarehouseUploader is set
+	WarehouseUploader storage.Uploader
+	WarehousePrefix   string // object key prefix in the warehouse bucket
+}
+
+// Database drivers
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite" // a local database file for offline deployments, e.g. on a site laptop
+)
+
+// DBSettings configures the database connection and pool
+type DBSettings struct {
+	Driver          string
+	SQLitePath      string // database file used by the SQLite driver
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration // 0 keeps idle connections until their lifetime ends
+	ConnectRetries  int           // attempts after the first before giving up at startup
+	ConnectBackoff  time.Duration // wait between connection attempts
+	PrepareStmt     bool          // cache prepared statements for the queries GORM builds
+	SimpleProtocol  bool          // disable the driver's implicit prepared statements, e.g. behind PgBouncer
+	SlowThreshold   time.Duration // queries slower than this are logged; 0 disables
+	PartitionByYear bool          // partition incomes and expenses into yearly tables (PostgreSQL only)
+}
+
+// dbSettingsFromEnv reads the database settings from the DB_* environment variables
+func dbSettingsFromEnv() DBSettings {
+	return DBSettings{
+		Driver:          getEnv("DB_DRIVER", DriverPostgres),
+		SQLitePath:      getEnv("SQLITE_PATH", "mining.db"),
+		MaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 100),
+		MaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 10),
+		ConnMaxLifetime: time.Duration(getEnvInt("DB_CONN_MAX_LIFETIME_MINUTES", 60)) * time.Minute,
+		ConnMaxIdleTime: time.Duration(getEnvInt("DB_CONN_MAX_IDLE_MINUTES", 0)) * time.Minute,
+		ConnectRetries:  getEnvInt("DB_CONNECT_RETRIES", 10),
+		ConnectBackoff:  time.Duration(getEnvInt("DB_CONNECT_BACKOFF_MS", 1000)) * time.Millisecond,
+		PrepareStmt:     os.Getenv("DB_PREPARE_STATEMENTS") == "true",
+		SimpleProtocol:  os.Getenv("DB_SIMPLE_PROTOCOL") == "true",
+		SlowThreshold:   time.Duration(getEnvInt("DB_SLOW_QUERY_MS", 200)) * time.Millisecond,
+		PartitionByYear: os.Getenv("DB_PARTITION_BY_YEAR") == "true",
+	}
+}
+
+// getEnv reads an environment variable, falling back to def when unset
+func getEnv(key, def string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return def
+}
+
+// getEnvInt reads an integer environment variable, falling back to def when unset or invalid
+func getEnvInt(key string, def int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+	return value
 }
@@ -1,107 +1,224 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"mineral/data"
+	"mineral/pkg/dbstats"
 	"os"
 	"time"
 
+	"github.com/glebarez/sqlite"
+	"github.com/uptrace/opentelemetry-go-extra/otelgorm"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 func (app *Config) initDB() *gorm.DB {
-	conn := connectToDB()
+	if app.DBSettings.Driver != DriverPostgres && app.DBSettings.Driver != DriverSQLite {
+		log.Panicf("unknown DB_DRIVER %q, use %s or %s", app.DBSettings.Driver, DriverPostgres, DriverSQLite)
+	}
+
+	app.Queries = &dbstats.Counter{}
+	conn := connectToDB(app.DBSettings, app.Queries)
 	if conn == nil {
 		log.Panic("can't connect to database")
 	}
 
-	// Auto-migrate the schema using actual model structs, not interfaces
-	if err := conn.AutoMigrate(
+	return conn
+}
+
+// schemaModels returns the model structs of every table, in the order they are migrated, so
+// tables come after the tables they reference
+func schemaModels() []interface{} {
+	return []interface{}{
 		&data.User{},
 		&data.Income{},
 		&data.Expense{},
+		&data.ExpenseSignOff{},
+		&data.ExpenseAllocation{},
+		&data.ExpenseClaim{},
+		&data.PurchaseOrder{},
+		&data.ProcurementRequest{},
 		&data.InventoryItem{},
 		&data.MineSiteInfo{},
-	); err != nil {
-		log.Panic("failed to migrate database:", err)
+		&data.Stocktake{},
+		&data.StocktakeLine{},
+		&data.StockMovement{},
+		&data.Notification{},
+		&data.Vehicle{},
+		&data.Equipment{},
+		&data.InsurancePolicy{},
+		&data.InsuranceClaim{},
+		&data.DepreciationEntry{},
+		&data.Trip{},
+		&data.Contractor{},
+		&data.WorkRecord{},
+		&data.Employee{},
+		&data.Timesheet{},
+		&data.EmployeeAdjustment{},
+		&data.PayrollRun{},
+		&data.PayrollLine{},
+		&data.OrganizationSettings{},
+		&data.Organization{},
+		&data.OrganizationMember{},
+		&data.OrganizationIPRule{},
+		&data.OrganizationRolePermission{},
+		&data.OrganizationInvitation{},
+		&data.AuditLog{},
+		&data.Job{},
+		&data.MessageDelivery{},
+		&data.InviteCode{},
+		&data.UserIdentity{},
+		&data.RefreshToken{},
+		&data.RevokedToken{},
+		&data.ShareLink{},
+		&data.ShareLinkView{},
+		&data.Receipt{},
+		&data.CreditNote{},
+		&data.DocumentSequence{},
+		&data.DocumentTemplate{},
+		&data.DunningSchedule{},
+		&data.DunningStep{},
+		&data.DunningEvent{},
+		&data.Task{},
+		&data.Attendance{},
+		&data.EvidenceRule{},
+		&data.EvidencePhoto{},
+		&data.Attachment{},
+		&data.DueDiligenceAssessment{},
+		&data.DueDiligenceResponse{},
+		&data.LotSeal{},
+		&data.Payment{},
+		&data.Assay{},
+		&data.Purchase{},
+		&data.Miner{},
+		&data.PurchasePriceOverride{},
+		&data.MarketPrice{},
+		&data.SupplyPrice{},
+		&data.CostAllocationRule{},
+		&data.CashDay{},
+		&data.CashMovement{},
+		&data.Till{},
+		&data.ShiftHandover{},
+		&data.SMSCampaign{},
+		&data.SMSCampaignRecipient{},
+		&data.SMSOptOut{},
+		&data.Contact{},
+		&data.CustomerCreditLimit{},
+		&data.CounterpartyFlag{},
+		&data.SharedSale{},
+		&data.TradeDispute{},
+		&data.TradeDisputeEvent{},
+		&data.Webhook{},
+		&data.Backup{},
+		&data.Subscription{},
+		&data.FeatureFlag{},
+		&data.ReferralCode{},
+		&data.Referral{},
+		&data.SupportTicket{},
+		&data.Lease{},
+		&data.RateLimitBucket{},
+		&data.IdempotentRequest{},
+		&data.ArchivedIncome{},
+		&data.ArchivedExpense{},
+		&data.WarehouseWatermark{},
+		&data.StreamEvent{},
+		&data.OutboxMessage{},
 	}
-	log.Println("Database migration completed successfully")
-
-	return conn
 }
 
-func connectToDB() *gorm.DB {
-	counts := 0
-
-	// Get database connection details from environment variables or use defaults
-	dbHost := os.Getenv("DB_HOST")
-	if dbHost == "" {
-		dbHost = "localhost"
+// migrate migrates the schema using actual model structs, not interfaces
+func (app *Config) migrate(ctx context.Context) error {
+	if err := app.DB.AutoMigrate(schemaModels()...); err != nil {
+		return err
 	}
 
-	dbPort := os.Getenv("DB_PORT")
-	if dbPort == "" {
-		dbPort = "5432"
+	// Records from before event streams were kept start their streams at their current balance
+	opened, err := data.NewStreamRepository(app.DB).Baseline(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open event streams: %w", err)
 	}
-
-	dbUser := os.Getenv("DB_USER")
-	if dbUser == "" {
-		dbUser = "postgres"
+	if opened > 0 {
+		log.Printf("Opened event streams of %d existing records", opened)
 	}
 
-	dbPassword := os.Getenv("DB_PASSWORD")
-	if dbPassword == "" {
-		dbPassword = "postgres"
+	if app.DBSettings.PartitionByYear {
+		if app.DBSettings.Driver == DriverSQLite {
+			log.Println("DB_PARTITION_BY_YEAR needs PostgreSQL; incomes and expenses are not partitioned with SQLite")
+		} else if err := app.partitionTables(ctx); err != nil {
+			return err
+		}
 	}
+	log.Println("Database migration completed successfully")
 
-	dbName := os.Getenv("DB_NAME")
-	if dbName == "" {
-		dbName = "mining_data"
-	}
+	return nil
+}
 
-	// Construct the DSN string
-	dsn := os.Getenv("DSN")
-	if dsn == "" {
-		dsn = fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-			dbHost, dbPort, dbUser, dbPassword, dbName)
+func connectToDB(settings DBSettings, queries *dbstats.Counter) *gorm.DB {
+	counts := 0
+
+	dsn := databaseDSN()
+	if settings.Driver == DriverSQLite {
+		dsn = sqliteDSN(settings.SQLitePath)
 	}
 
 	log.Printf("Attempting to connect to database with DSN: %s", dsn)
 
 	for {
-		connection, err := openDB(dsn)
+		connection, err := openDB(dsn, settings, queries)
 		if err != nil {
-			log.Println("postgres not yet ready...")
+			log.Println("database not yet ready...")
 			log.Printf("Connection error: %v", err)
 		} else {
 			log.Print("connected to database!")
 			return connection
 		}
 
-		if counts > 10 {
+		if counts >= settings.ConnectRetries {
 			return nil
 		}
 
-		log.Print("Backing off for 1 second")
-		time.Sleep(1 * time.Second)
+		log.Printf("Backing off for %v", settings.ConnectBackoff)
+		time.Sleep(settings.ConnectBackoff)
 		counts++
 	}
 }
 
-func openDB(dsn string) (*gorm.DB, error) {
+func openDB(dsn string, settings DBSettings, queries *dbstats.Counter) (*gorm.DB, error) {
 	config := &gorm.Config{
-		// You can add GORM configurations here
-		// For example:
-		// Logger: logger.Default.LogMode(logger.Info),
-		// PrepareStmt: true,
+		Logger:      dbstats.NewLogger(settings.SlowThreshold), // logs queries slower than the threshold with their SQL
+		PrepareStmt: settings.PrepareStmt,
+	}
+
+	var dialector gorm.Dialector
+	if settings.Driver == DriverSQLite {
+		dialector = sqlite.Open(dsn)
+	} else {
+		dialector = postgres.New(postgres.Config{
+			DSN:                  dsn,
+			PreferSimpleProtocol: settings.SimpleProtocol,
+		})
 	}
 
-	db, err := gorm.Open(postgres.Open(dsn), config)
+	db, err := gorm.Open(dialector, config)
 	if err != nil {
 		return nil, err
 	}
+	if err := db.Use(queries); err != nil {
+		return nil, err
+	}
+	// Every statement is traced as a child of the span carried by its context, with the SQL but
+	// not the values bound to it
+	system := semconv.DBSystemNamePostgreSQL
+	if settings.Driver == DriverSQLite {
+		system = semconv.DBSystemNameSQLite
+	}
+	if err := db.Use(otelgorm.NewPlugin(otelgorm.WithAttributes(system), otelgorm.WithoutQueryVariables(), otelgorm.WithoutMetrics())); err != nil {
+		return nil, err
+	}
 
 	// Get the underlying *sql.DB instance
 	sqlDB, err := db.DB()
@@ -110,9 +227,10 @@ func openDB(dsn string) (*gorm.DB, error) {
 	}
 
 	// Configure connection pool
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
-	sqlDB.SetConnMaxLifetime(time.Hour)
+	sqlDB.SetMaxIdleConns(settings.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(settings.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(settings.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(settings.ConnMaxIdleTime)
 
 	// Test the connection
 	err = sqlDB.Ping()
@@ -122,3 +240,49 @@ func openDB(dsn string) (*gorm.DB, error) {
 
 	return db, nil
 }
+
+// sqliteDSN returns the connection string of a SQLite database file. Transactions take the
+// write lock when they begin and wait for other writers, and times are stored in a format
+// SQLite's date functions understand.
+func sqliteDSN(path string) string {
+	return "file:" + path + "?_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)" +
+		"&_txlock=immediate&_time_format=sqlite"
+}
+
+// databaseDSN returns the database connection string from the DSN environment variable or the
+// DB_* variables
+func databaseDSN() string {
+	// Get database connection details from environment variables or use defaults
+	dbHost := os.Getenv("DB_HOST")
+	if dbHost == "" {
+		dbHost = "localhost"
+	}
+
+	dbPort := os.Getenv("DB_PORT")
+	if dbPort == "" {
+		dbPort = "5432"
+	}
+
+	dbUser := os.Getenv("DB_USER")
+	if dbUser == "" {
+		dbUser = "postgres"
+	}
+
+	dbPassword := os.Getenv("DB_PASSWORD")
+	if dbPassword == "" {
+		dbPassword = "postgres"
+	}
+
+	dbName := os.Getenv("DB_NAME")
+	if dbName == "" {
+		dbName = "mining_data"
+	}
+
+	// Construct the DSN string
+	dsn := os.Getenv("DSN")
+	if dsn == "" {
+		dsn = fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+			dbHost, dbPort, dbUser, dbPassword, dbName)
+	}
+	return dsn
+}
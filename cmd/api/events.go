@@ -0,0 +1,265 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"mineral/data"
+	"mineral/pkg/events"
+	"mineral/pkg/tracing"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// webhookClient posts webhook deliveries, traced as calls to the webhook service
+var webhookClient = tracing.NewClient(&http.Client{Timeout: 10 * time.Second}, "webhook")
+
+// subscribeEvents registers the subscribers of the events published by the handlers. New
+// cross-cutting features subscribe here instead of being called from every handler.
+// Subscribers run on the request after its change is made, so a crash in between loses the
+// event; deliveries that must not be lost subscribe to the outbox in subscribeOutbox instead.
+func (app *Config) subscribeEvents() {
+	app.Events.Subscribe(events.IncomeCreated, "audit", app.auditEvent)
+	app.Events.Subscribe(events.PaymentRecorded, "audit", app.auditEvent)
+}
+
+// subscribeOutbox registers the subscribers of the events stored in the outbox by the
+// transactions making the changes. The worker publishes them until every subscriber succeeds,
+// so subscribers must tolerate seeing an event more than once.
+func (app *Config) subscribeOutbox() {
+	app.Outbox.Subscribe(events.StockLow, "low-stock-notification", app.notifyLowStock)
+	app.Outbox.Subscribe(events.SignOffRequired, "sign-off-notifications", app.notifySignOff)
+	app.Outbox.Subscribe(events.ExpenseSignedOff, "sign-off-notifications", app.notifySignOff)
+	app.Outbox.SubscribeAll("webhooks", app.queueWebhooks)
+}
+
+// outboxRetention is how long published outbox messages are kept, e.g. to trace a delivery
+const outboxRetention = 7 * 24 * time.Hour
+
+// publishOutbox publishes the due outbox messages to the outbox subscribers until none is left.
+// A message a subscriber failed on is published again after a backoff, until it succeeds.
+func (app *Config) publishOutbox(ctx context.Context) error {
+	for {
+		message, err := app.Models.Outbox.ClaimNext(ctx)
+		if err != nil {
+			return err
+		}
+		if message == nil {
+			return nil
+		}
+
+		err = app.Outbox.Dispatch(ctx, events.Event{
+			Name:       events.Name(message.Event),
+			Resource:   message.Resource,
+			ResourceID: message.ResourceID,
+			Data:       json.RawMessage(message.Payload),
+			OccurredAt: message.OccurredAt,
+			UserID:     message.UserID,
+		})
+		if err != nil {
+			retryAt := time.Now().Add(outboxBackoff(message.Attempts))
+			if err := app.Models.Outbox.Fail(ctx, message.ID, err.Error(), retryAt); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := app.Models.Outbox.MarkPublished(ctx, message.ID); err != nil {
+			return err
+		}
+	}
+}
+
+// outboxBackoff returns the wait before publishing a message again after the given number of
+// attempts: 30s, 1m, 2m, ... up to an hour
+func outboxBackoff(attempts int) time.Duration {
+	if attempts > 7 {
+		return time.Hour
+	}
+	return time.Duration(1<<(attempts-1)) * 30 * time.Second
+}
+
+// pruneOutbox deletes the outbox messages published longer than outboxRetention ago
+func (app *Config) pruneOutbox(ctx context.Context) error {
+	_, err := app.Models.Outbox.DeletePublishedBefore(ctx, time.Now().Add(-outboxRetention))
+	return err
+}
+
+// auditEvent records an event in the audit log of its books
+func (app *Config) auditEvent(ctx context.Context, event events.Event) error {
+	resourceID := event.ResourceID
+	return app.Models.Audit.Insert(ctx, &data.AuditLog{
+		Action:     data.AuditAction(event.Name),
+		Resource:   event.Resource,
+		ResourceID: &resourceID,
+		ActorID:    event.ActorID,
+		IPAddress:  event.IPAddress,
+		UserID:     event.UserID,
+	})
+}
+
+// notifyLowStock notifies the books owner once a day about an item at or below its minimum
+// stock level
+func (app *Config) notifyLowStock(ctx context.Context, event events.Event) error {
+	payload, ok := event.Data.(json.RawMessage)
+	if !ok {
+		return fmt.Errorf("unexpected %s data %T", event.Name, event.Data)
+	}
+	var item data.InventoryItem
+	if err := json.Unmarshal(payload, &item); err != nil {
+		return err
+	}
+
+	_, err := app.Models.Notification.Insert(ctx, &data.Notification{
+		Kind:        data.NotificationLowStock,
+		Title:       fmt.Sprintf("%s is running low", item.Name),
+		Message:     fmt.Sprintf("%s is down to %.2f %s (minimum %.2f %s)", item.Name, item.Quantity, item.Unit, item.MinStockLevel, item.Unit),
+		ReferenceID: &item.ID,
+		Key:         fmt.Sprintf("%s:%d:%s", data.NotificationLowStock, item.ID, event.OccurredAt.Format("2006-01-02")),
+		UserID:      event.UserID,
+	})
+	return err
+}
+
+// notifySignOff fans an expense awaiting sign-off out to every approver of the books who hasn't
+// signed it off yet, in the app and by email, and notifies the books owner once it is signed off.
+// Each approver is notified once per event, however often it is published.
+func (app *Config) notifySignOff(ctx context.Context, event events.Event) error {
+	payload, ok := event.Data.(json.RawMessage)
+	if !ok {
+		return fmt.Errorf("unexpected %s data %T", event.Name, event.Data)
+	}
+	var signOff data.ExpenseSignOffEvent
+	if err := json.Unmarshal(payload, &signOff); err != nil {
+		return err
+	}
+	expense := fmt.Sprintf("%s from %s for %.2f", signOff.Description, signOff.SupplierName, signOff.Amount)
+	key := fmt.Sprintf("%s:%d:%d:%d", data.NotificationSignOff, signOff.ExpenseID, signOff.SignOffs, event.OccurredAt.Unix())
+
+	if signOff.SignOffs >= signOff.Required {
+		_, err := app.Models.Notification.Insert(ctx, &data.Notification{
+			Kind:        data.NotificationSignOff,
+			Title:       "Expense signed off",
+			Message:     fmt.Sprintf("The expense %s has been signed off and can be paid", expense),
+			ReferenceID: &signOff.ExpenseID,
+			Key:         key,
+			UserID:      event.UserID,
+		})
+		return err
+	}
+
+	approvers, err := app.Models.Organization.GetPermissionHolders(ctx, event.UserID, data.PermExpenseApprove)
+	if err != nil {
+		return err
+	}
+	message := fmt.Sprintf("The expense %s needs %d sign-offs before it can be paid; %d so far",
+		expense, signOff.Required, signOff.SignOffs)
+	for _, approver := range approvers {
+		if slices.Contains(signOff.SignedOffBy, approver.ID) {
+			continue
+		}
+		created, err := app.Models.Notification.Insert(ctx, &data.Notification{
+			Kind:        data.NotificationSignOff,
+			Title:       "Expense awaiting your sign-off",
+			Message:     message,
+			ReferenceID: &signOff.ExpenseID,
+			Key:         key,
+			UserID:      approver.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if !created {
+			continue
+		}
+
+		deliveryID, err := app.Models.Delivery.Insert(ctx, &data.MessageDelivery{
+			Purpose:   "expense_sign_off",
+			Recipient: approver.Email,
+			UserID:    &event.UserID,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = app.Models.Job.Enqueue(ctx, data.JobTypeSendMessage, data.SendMessagePayload{
+			DeliveryID: deliveryID,
+			Channel:    data.DeliveryEmail,
+			To:         approver.Email,
+			Subject:    "Expense awaiting your sign-off",
+			Body:       message + ".",
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// queueWebhooks queues a delivery of an event to every webhook of its books subscribed to it
+func (app *Config) queueWebhooks(ctx context.Context, event events.Event) error {
+	webhooks, err := app.Models.Webhook.GetSubscribed(ctx, event.UserID, string(event.Name))
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	for _, webhook := range webhooks {
+		_, err := app.Models.Job.Enqueue(ctx, data.JobTypeDeliverWebhook, data.DeliverWebhookPayload{
+			WebhookID: webhook.ID,
+			UserID:    webhook.UserID,
+			Event:     string(event.Name),
+			Body:      string(body),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliverWebhook posts an event to a webhook, signed with an HMAC-SHA256 of the body in the
+// X-Webhook-Signature header. Responses other than 2xx are retried by the job queue.
+func (app *Config) deliverWebhook(ctx context.Context, payload []byte) error {
+	var p data.DeliverWebhookPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return err
+	}
+
+	webhook, err := app.Models.Webhook.GetOne(ctx, p.WebhookID, p.UserID)
+	if err != nil {
+		// The webhook was deleted after the event was queued
+		return nil
+	}
+
+	mac := hmac.New(sha256.New, []byte(webhook.Secret))
+	mac.Write([]byte(p.Body))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewBufferString(p.Body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", p.Event)
+	req.Header.Set("X-Webhook-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %d responded %s", webhook.ID, resp.Status)
+	}
+	return nil
+}
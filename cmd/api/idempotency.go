@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"mineral/data"
+	"mineral/pkg/middleware"
+)
+
+// idempotencyStore keeps the requests made with an Idempotency-Key in the database, so every
+// API replica recognizes a retry
+type idempotencyStore struct {
+	repo data.IdempotencyInterface
+}
+
+func (s *idempotencyStore) Begin(ctx context.Context, actorID uint, key, fingerprint string) (*middleware.IdempotentResponse, error) {
+	earlier, err := s.repo.Begin(ctx, actorID, key, fingerprint)
+	if err != nil || earlier == nil {
+		return nil, err
+	}
+	return &middleware.IdempotentResponse{
+		Fingerprint: earlier.Fingerprint,
+		StatusCode:  earlier.StatusCode,
+		ContentType: earlier.ContentType,
+		Body:        earlier.Body,
+	}, nil
+}
+
+func (s *idempotencyStore) Complete(ctx context.Context, actorID uint, key string, statusCode int, contentType string, body []byte) error {
+	return s.repo.Complete(ctx, actorID, key, statusCode, contentType, body)
+}
+
+func (s *idempotencyStore) Release(ctx context.Context, actorID uint, key string) error {
+	return s.repo.Release(ctx, actorID, key)
+}
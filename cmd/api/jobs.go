@@ -0,0 +1,545 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"mineral/data"
+	"mineral/pkg/tracing"
+	"mineral/pkg/utils"
+	"strings"
+	"time"
+)
+
+// defaultDunningMessage is used by dunning steps without their own message
+const defaultDunningMessage = "Dear {customer}, a balance of {amount} for {invoice} is outstanding. Please arrange payment. {seller}"
+
+// notifyExpiringSupplies creates a notification for every supply item that
+// has expired or expires within the configured alert window
+func (app *Config) notifyExpiringSupplies(ctx context.Context) error {
+	now := time.Now()
+	items, err := app.Models.Inventory.GetAllExpiringItems(ctx, now.AddDate(0, 0, app.ExpiryAlertDays))
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		expiry := item.ExpiryDate.Format("2006-01-02")
+
+		title := fmt.Sprintf("%s expires soon", item.Name)
+		if item.ExpiryDate.Before(now) {
+			title = fmt.Sprintf("%s has expired", item.Name)
+		}
+		message := fmt.Sprintf("%s (%.2f %s) expires on %s", item.Name, item.Quantity, item.Unit, expiry)
+		if item.BatchNumber != nil && *item.BatchNumber != "" {
+			message = fmt.Sprintf("%s, batch %s (%.2f %s) expires on %s", item.Name, *item.BatchNumber, item.Quantity, item.Unit, expiry)
+		}
+
+		itemID := item.ID
+		_, err := app.Models.Notification.Insert(ctx, &data.Notification{
+			Kind:        data.NotificationSupplyExpiring,
+			Title:       title,
+			Message:     message,
+			ReferenceID: &itemID,
+			Key:         fmt.Sprintf("%s:%d:%s", data.NotificationSupplyExpiring, item.ID, expiry),
+			UserID:      item.UserID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// notifyExpiringPolicies creates a notification for every insurance policy that expires within
+// the configured alert window, once per expiry date so renewed policies are notified again
+func (app *Config) notifyExpiringPolicies(ctx context.Context) error {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	policies, err := app.Models.Insurance.GetAllExpiringPolicies(ctx, today, today.AddDate(0, 0, app.InsuranceAlertDays))
+	if err != nil {
+		return err
+	}
+
+	for _, policy := range policies {
+		expiry := policy.ExpiryDate.Format("2006-01-02")
+		policyID := policy.ID
+		_, err := app.Models.Notification.Insert(ctx, &data.Notification{
+			Kind:        data.NotificationPolicyExpiring,
+			Title:       fmt.Sprintf("Insurance policy %s expires soon", policy.PolicyNumber),
+			Message:     fmt.Sprintf("The %s cover with %s (policy %s) expires on %s", policy.CoverageType, policy.Insurer, policy.PolicyNumber, expiry),
+			ReferenceID: &policyID,
+			Key:         fmt.Sprintf("%s:%d:%s", data.NotificationPolicyExpiring, policy.ID, expiry),
+			UserID:      policy.UserID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sendOTP delivers a password reset OTP by email, falling back to SMS when the email fails
+// and the user has a phone number. Phone login and verification codes go by SMS only, and email
+// verification codes by email only. The delivery record is updated after every attempt.
+func (app *Config) sendOTP(ctx context.Context, payload []byte) error {
+	var p data.SendOTPPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return err
+	}
+
+	if p.Purpose == data.OTPPurposePhoneLogin || p.Purpose == data.OTPPurposePhoneLink {
+		message := fmt.Sprintf("Your login code is %s. It expires in 10 minutes.", p.OTP)
+		if p.Purpose == data.OTPPurposePhoneLink {
+			message = fmt.Sprintf("Your phone verification code is %s. It expires in 10 minutes.", p.OTP)
+		}
+
+		err := fmt.Errorf("no phone number")
+		if p.Phone != nil && *p.Phone != "" {
+			err = app.sendSMS(ctx, *p.Phone, message)
+		}
+		if err == nil {
+			return app.Models.Delivery.MarkSent(ctx, p.DeliveryID, data.DeliverySMS)
+		}
+		if markErr := app.Models.Delivery.MarkFailed(ctx, p.DeliveryID, err.Error()); markErr != nil {
+			app.ErrorLog.Printf("failed to record OTP delivery %d: %v", p.DeliveryID, markErr)
+		}
+		return err
+	}
+
+	if p.Purpose == data.OTPPurposeEmailVerify {
+		body := fmt.Sprintf("Your email verification code is %s.\r\n\r\nIt expires in 10 minutes. If it has expired, request a new one from the app.", p.OTP)
+		err := tracing.Call(ctx, "email", "send_verification", func() error {
+			return app.Mailer.Send(p.Email, "Verify your email", body)
+		})
+		if err == nil {
+			return app.Models.Delivery.MarkSent(ctx, p.DeliveryID, data.DeliveryEmail)
+		}
+		if markErr := app.Models.Delivery.MarkFailed(ctx, p.DeliveryID, err.Error()); markErr != nil {
+			app.ErrorLog.Printf("failed to record OTP delivery %d: %v", p.DeliveryID, markErr)
+		}
+		return err
+	}
+
+	err := tracing.Call(ctx, "email", "send_otp", func() error {
+		return app.Mailer.SendOTP(p.Email, p.OTP)
+	})
+	if err == nil {
+		return app.Models.Delivery.MarkSent(ctx, p.DeliveryID, data.DeliveryEmail)
+	}
+
+	if p.Phone != nil && *p.Phone != "" {
+		smsErr := app.sendSMS(ctx, *p.Phone, fmt.Sprintf("Your password reset code is %s. It expires in 10 minutes.", p.OTP))
+		if smsErr == nil {
+			return app.Models.Delivery.MarkSent(ctx, p.DeliveryID, data.DeliverySMS)
+		}
+		err = fmt.Errorf("email: %v; sms: %v", err, smsErr)
+	}
+
+	if markErr := app.Models.Delivery.MarkFailed(ctx, p.DeliveryID, err.Error()); markErr != nil {
+		app.ErrorLog.Printf("failed to record OTP delivery %d: %v", p.DeliveryID, markErr)
+	}
+	return err
+}
+
+// sendMessage delivers a message by email or SMS and records the result on its delivery
+func (app *Config) sendMessage(ctx context.Context, payload []byte) error {
+	var p data.SendMessagePayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return err
+	}
+
+	var err error
+	switch p.Channel {
+	case data.DeliveryEmail:
+		err = tracing.Call(ctx, "email", "send", func() error {
+			return app.Mailer.Send(p.To, p.Subject, p.Body)
+		})
+	case data.DeliverySMS:
+		err = app.sendSMS(ctx, p.To, p.Body)
+	default:
+		err = fmt.Errorf("unknown channel %q", p.Channel)
+	}
+	if err == nil {
+		return app.Models.Delivery.MarkSent(ctx, p.DeliveryID, p.Channel)
+	}
+
+	if markErr := app.Models.Delivery.MarkFailed(ctx, p.DeliveryID, err.Error()); markErr != nil {
+		app.ErrorLog.Printf("failed to record message delivery %d: %v", p.DeliveryID, markErr)
+	}
+	return err
+}
+
+// sendSMS sends an SMS in a span of the job's trace
+func (app *Config) sendSMS(ctx context.Context, phone, message string) error {
+	return tracing.Call(ctx, "sms", "send", func() error {
+		return app.SMS.Send(phone, message)
+	})
+}
+
+// notifyOverdueTasks notifies about open tasks whose due date has passed, once per task. The
+// books owner is notified, and the assignee too when someone else is assigned.
+func (app *Config) notifyOverdueTasks(ctx context.Context) error {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	tasks, err := app.Models.Task.GetOverdue(ctx, today)
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		taskID := task.ID
+		title := fmt.Sprintf("Task overdue: %s", task.Title)
+		message := fmt.Sprintf("%s was due on %s", task.Title, task.DueDate.Format("2006-01-02"))
+
+		recipients := []uint{task.UserID}
+		if task.AssigneeID != nil && *task.AssigneeID != task.UserID {
+			recipients = append(recipients, *task.AssigneeID)
+		}
+		for _, userID := range recipients {
+			_, err := app.Models.Notification.Insert(ctx, &data.Notification{
+				Kind:        data.NotificationTaskOverdue,
+				Title:       title,
+				Message:     message,
+				ReferenceID: &taskID,
+				Key:         fmt.Sprintf("%s:%d:%d", data.NotificationTaskOverdue, task.ID, userID),
+				UserID:      userID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// trialReminderDays are the days before a trial ends that its owner is reminded
+var trialReminderDays = []int{3, 1}
+
+// checkTrials reminds owners of trials ending soon and expires trials that have ended, which
+// moves the books to the free plan or makes them read-only
+func (app *Config) checkTrials(ctx context.Context) error {
+	now := time.Now()
+	trials, err := app.Models.Usage.GetTrials(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, trial := range trials {
+		if trial.TrialEndsAt == nil {
+			continue
+		}
+
+		if !now.Before(*trial.TrialEndsAt) {
+			trial.Status = data.SubscriptionExpired
+			if err := app.Models.Usage.SaveSubscription(ctx, trial); err != nil {
+				return err
+			}
+			_, err := app.Models.Notification.Insert(ctx, &data.Notification{
+				Kind:    data.NotificationTrialEnded,
+				Title:   "Your trial has ended",
+				Message: fmt.Sprintf("Your %s trial ended on %s. Subscribe to a plan to keep using all features.", trial.Plan, trial.TrialEndsAt.Format("2006-01-02")),
+				Key:     fmt.Sprintf("%s:%d", data.NotificationTrialEnded, trial.ID),
+				UserID:  trial.UserID,
+			})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		daysLeft := int(math.Ceil(trial.TrialEndsAt.Sub(now).Hours() / 24))
+		for _, days := range trialReminderDays {
+			if daysLeft != days {
+				continue
+			}
+			title := fmt.Sprintf("Your trial ends in %d days", days)
+			if days == 1 {
+				title = "Your trial ends tomorrow"
+			}
+			_, err := app.Models.Notification.Insert(ctx, &data.Notification{
+				Kind:    data.NotificationTrialEnding,
+				Title:   title,
+				Message: fmt.Sprintf("Your %s trial ends on %s. Subscribe to a plan to keep using all features.", trial.Plan, trial.TrialEndsAt.Format("2006-01-02")),
+				Key:     fmt.Sprintf("%s:%d:%d", data.NotificationTrialEnding, trial.ID, days),
+				UserID:  trial.UserID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// runDunning executes the dunning steps that have fallen due for unpaid sales. A step is due
+// its day offset after the sale date and runs once per sale. Steps that fell due before they
+// were added to a schedule are skipped, so a new schedule does not flood customers with
+// reminders for old sales.
+func (app *Config) runDunning(ctx context.Context) error {
+	schedules, err := app.Models.Dunning.GetActiveSchedules(ctx)
+	if err != nil {
+		return err
+	}
+
+	byUser := map[uint][]*data.DunningSchedule{}
+	for _, schedule := range schedules {
+		byUser[schedule.UserID] = append(byUser[schedule.UserID], schedule)
+	}
+
+	now := time.Now()
+	for userID, userSchedules := range byUser {
+		if err := app.runUserDunning(ctx, userID, userSchedules, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runUserDunning executes due dunning steps for one user's unpaid sales, using the customer's
+// own schedule or else the user's default schedule
+func (app *Config) runUserDunning(ctx context.Context, userID uint, schedules []*data.DunningSchedule, now time.Time) error {
+	var fallback *data.DunningSchedule
+	byCustomer := map[string]*data.DunningSchedule{}
+	for _, schedule := range schedules {
+		if schedule.CustomerName == nil {
+			fallback = schedule
+		} else {
+			byCustomer[*schedule.CustomerName] = schedule
+		}
+	}
+
+	incomes, err := app.Models.Income.GetOutstanding(ctx, userID)
+	if err != nil || len(incomes) == 0 {
+		return err
+	}
+	settings, err := app.Models.Settings.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	seller := ""
+	if user, err := app.Models.User.GetOne(ctx, userID); err == nil {
+		seller = user.Name
+	}
+
+	for _, income := range incomes {
+		schedule := byCustomer[income.CustomerName]
+		if schedule == nil {
+			schedule = fallback
+		}
+		if schedule == nil {
+			continue
+		}
+
+		for i := range schedule.Steps {
+			step := &schedule.Steps[i]
+			due := step.DueAt(income)
+			if due.After(now) {
+				break // steps are ordered by day
+			}
+			added := step.CreatedAt.Truncate(24 * time.Hour)
+			if due.Before(added) {
+				continue
+			}
+
+			event := &data.DunningEvent{
+				IncomeID:   income.ID,
+				ScheduleID: schedule.ID,
+				StepID:     step.ID,
+				DayOffset:  step.DayOffset,
+				Action:     step.Action,
+				UserID:     userID,
+			}
+			claimed, err := app.Models.Dunning.ClaimStep(ctx, event)
+			if err != nil {
+				return err
+			}
+			if !claimed {
+				continue
+			}
+
+			app.executeDunningStep(ctx, event, step, income, settings.DefaultCurrency, seller)
+			if err := app.Models.Dunning.UpdateEvent(ctx, event); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// executeDunningStep sends the reminder of a dunning step, or creates a call task for the
+// user, and records the outcome on the event
+func (app *Config) executeDunningStep(ctx context.Context, event *data.DunningEvent, step *data.DunningStep, income *data.Income, currency, seller string) {
+	message := renderDunningMessage(step, income, currency, seller)
+	contact := strings.TrimSpace(income.CustomerContact)
+
+	setOutcome := func(status data.DunningEventStatus, detail string) {
+		event.Status = status
+		if detail != "" {
+			event.Detail = &detail
+		}
+	}
+
+	if step.Action == data.DunningCall {
+		title := fmt.Sprintf("Call %s about an unpaid balance", income.CustomerName)
+		body := fmt.Sprintf("%s owes %s for the sale of %s.", income.CustomerName,
+			utils.FormatMoney(currency, income.AmountDue), income.Date.Format("2006-01-02"))
+		if contact != "" {
+			body += " Contact: " + contact
+		}
+		incomeID := income.ID
+		now := time.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		linkedType := data.TaskLinkIncome
+		_, err := app.Models.Task.Insert(ctx, &data.Task{
+			Title:       title,
+			Status:      data.TaskOpen,
+			Description: &body,
+			DueDate:     &today,
+			LinkedType:  &linkedType,
+			LinkedID:    &incomeID,
+			UserID:      event.UserID,
+		})
+		if err != nil {
+			setOutcome(data.DunningEventFailed, err.Error())
+			return
+		}
+		_, err = app.Models.Notification.Insert(ctx, &data.Notification{
+			Kind:        data.NotificationDunningCall,
+			Title:       title,
+			Message:     body,
+			ReferenceID: &incomeID,
+			Key:         fmt.Sprintf("%s:%d", data.NotificationDunningCall, event.ID),
+			UserID:      event.UserID,
+		})
+		if err != nil {
+			setOutcome(data.DunningEventFailed, err.Error())
+			return
+		}
+		setOutcome(data.DunningEventCreated, title)
+		return
+	}
+
+	payload := data.SendMessagePayload{Body: message}
+	switch step.Action {
+	case data.DunningSMS:
+		phone := utils.NormalizePhone(contact)
+		if phone == "" || !utils.ValidatePhone(phone) {
+			setOutcome(data.DunningEventSkipped, "Customer contact is not a phone number")
+			return
+		}
+		payload.Channel, payload.To = data.DeliverySMS, phone
+	case data.DunningEmail:
+		if !utils.ValidateEmail(contact) {
+			setOutcome(data.DunningEventSkipped, "Customer contact is not an email address")
+			return
+		}
+		payload.Channel, payload.To = data.DeliveryEmail, contact
+		payload.Subject = "Payment reminder"
+		if seller != "" {
+			payload.Subject += " from " + seller
+		}
+	default:
+		setOutcome(data.DunningEventSkipped, fmt.Sprintf("Unknown action %q", step.Action))
+		return
+	}
+
+	deliveryID, err := app.Models.Delivery.Insert(ctx, &data.MessageDelivery{
+		Purpose:   "dunning",
+		Recipient: payload.To,
+		UserID:    &event.UserID,
+	})
+	if err != nil {
+		setOutcome(data.DunningEventFailed, err.Error())
+		return
+	}
+	payload.DeliveryID = deliveryID
+	if _, err := app.Models.Job.Enqueue(ctx, data.JobTypeSendMessage, payload); err != nil {
+		setOutcome(data.DunningEventFailed, err.Error())
+		return
+	}
+
+	event.Recipient = &payload.To
+	event.DeliveryID = &deliveryID
+	setOutcome(data.DunningEventQueued, message)
+}
+
+// renderDunningMessage fills in a dunning step's message for a sale. Supported placeholders
+// are {customer}, {invoice}, {amount} (amount due), {date} (sale date) and {seller}.
+func renderDunningMessage(step *data.DunningStep, income *data.Income, currency, seller string) string {
+	message := defaultDunningMessage
+	if step.Message != nil && strings.TrimSpace(*step.Message) != "" {
+		message = *step.Message
+	}
+
+	invoice := "your purchase of " + income.Date.Format("2006-01-02")
+	if income.InvoiceNumber != nil {
+		invoice = "invoice " + *income.InvoiceNumber
+	}
+
+	return strings.TrimSpace(strings.NewReplacer(
+		"{customer}", income.CustomerName,
+		"{invoice}", invoice,
+		"{amount}", utils.FormatMoney(currency, income.AmountDue),
+		"{date}", income.Date.Format("2006-01-02"),
+		"{seller}", seller,
+	).Replace(message))
+}
+
+// idempotencyKeyTTL is how long the response to a request made with an Idempotency-Key is kept
+// for retries
+const idempotencyKeyTTL = 24 * time.Hour
+
+// pruneRequestState deletes the rate limit counters of past windows, the idempotency keys
+// older than idempotencyKeyTTL and the expired refresh and revoked tokens
+func (app *Config) pruneRequestState(ctx context.Context) error {
+	if _, err := app.Models.RateLimit.DeleteBefore(ctx, time.Now().Add(-time.Hour).Unix()); err != nil {
+		return err
+	}
+	if _, err := app.Models.RefreshToken.DeleteExpiredBefore(ctx, time.Now()); err != nil {
+		return err
+	}
+	if _, err := app.Models.RevokedToken.DeleteExpiredBefore(ctx, time.Now()); err != nil {
+		return err
+	}
+	_, err := app.Models.Idempotency.DeleteBefore(ctx, time.Now().Add(-idempotencyKeyTTL))
+	return err
+}
+
+// archiveBatchSize is how many records of a table are moved to the archive per transaction, so
+// archiving years of records doesn't hold locks for long
+const archiveBatchSize = 500
+
+// archiveTransactions moves the settled sales and expenses older than ArchiveAfterYears to the
+// archive tables
+func (app *Config) archiveTransactions(ctx context.Context) error {
+	before := time.Now().AddDate(-app.ArchiveAfterYears, 0, 0)
+	for _, archive := range []struct {
+		name string
+		move func(context.Context, time.Time, int) (int64, error)
+	}{
+		{"sales", app.Models.Archive.ArchiveIncomes},
+		{"expenses", app.Models.Archive.ArchiveExpenses},
+	} {
+		var total int64
+		for {
+			moved, err := archive.move(ctx, before, archiveBatchSize)
+			if err != nil {
+				return err
+			}
+			total += moved
+			if moved < archiveBatchSize {
+				break
+			}
+		}
+		if total > 0 {
+			app.InfoLog.Printf("Archived %d %s dated before %s", total, archive.name, before.Format("2006-01-02"))
+		}
+	}
+	return nil
+}
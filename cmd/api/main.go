@@ -1,13 +1,26 @@
+// Command api runs the Mining Finance backend. The subcommand picks what the process does, so
+// a deployment can migrate the database in an init step and run background work in its own
+// process:
+//
+//	api serve     serve the HTTP API (the default)
+//	api migrate   migrate the database schema and exit
+//	api seed      create the bootstrap data and exit
+//	api worker    run background jobs and scheduled tasks without the HTTP API
+//	api anonymize copy the database into an empty staging database with personal data obfuscated
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"mineral/data"
-	"mineral/handlers"
+	"mineral/pkg/backup"
 	"mineral/pkg/email"
-	"mineral/pkg/utils"
-	"mineral/routes"
-	"net/http"
+	"mineral/pkg/events"
+	"mineral/pkg/jobs"
+	"mineral/pkg/scheduler"
+	"mineral/pkg/sms"
+	"mineral/pkg/storage"
 	"os"
 	"os/signal"
 	"sync"
@@ -17,12 +30,27 @@ import (
 	"github.com/joho/godotenv"
 )
 
+const usage = `Usage: api [command]
+
+Commands:
+  serve      serve the HTTP API (default)
+  migrate    migrate the database schema and exit
+  seed       create the bootstrap data and exit
+  worker     run background jobs and scheduled tasks without the HTTP API
+  anonymize  copy the database into the empty STAGING_DSN database with personal data obfuscated
+`
+
 func main() {
 	// Load environment variables
 	if err := godotenv.Load(); err != nil {
 		log.Println("No .env file found, using system environment variables")
 	}
 
+	command := "serve"
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+
 	// Initialize configuration
 	app := &Config{
 		InfoLog:       log.New(os.Stdout, "INFO: ", log.Ldate|log.Ltime|log.Lshortfile),
@@ -30,77 +58,264 @@ func main() {
 		Wait:          &sync.WaitGroup{},
 		ErrorChan:     make(chan error),
 		ErrorChanDone: make(chan bool),
+
+		ExpiryAlertDays:    getEnvInt("EXPIRY_ALERT_DAYS", 30),
+		InsuranceAlertDays: getEnvInt("INSURANCE_ALERT_DAYS", 30),
+		ArchiveAfterYears:  getEnvInt("ARCHIVE_AFTER_YEARS", 0),
+		DBSettings:         dbSettingsFromEnv(),
 	}
 
-	// Initialize database
-	app.DB = app.initDB()
-	app.InfoLog.Println("Database connection established")
+	switch command {
+	case "serve":
+		app.serve()
+	case "migrate":
+		app.DB = app.initDB()
+		if err := app.migrate(context.Background()); err != nil {
+			app.ErrorLog.Fatalf("Failed to migrate database: %v", err)
+		}
+	case "seed":
+		app.DB = app.initDB()
+		app.initModels()
+		if err := app.seed(context.Background()); err != nil {
+			app.ErrorLog.Fatal(err)
+		}
+	case "worker":
+		app.work()
+	case "anonymize":
+		app.DB = app.initDB()
+		if err := app.anonymize(context.Background()); err != nil {
+			app.ErrorLog.Fatalf("Failed to anonymize database: %v", err)
+		}
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", command, usage)
+		os.Exit(2)
+	}
+}
 
-	// Initialize repositories
+// initModels initializes the repositories
+func (app *Config) initModels() {
 	app.Models = data.Models{
-		User:      data.NewUserRepository(app.DB),
-		Income:    data.NewIncomeRepository(app.DB),
-		Expense:   data.NewExpenseRepository(app.DB),
-		Inventory: data.NewInventoryRepository(app.DB),
-		MineSite:  data.NewMineSiteRepository(app.DB),
+		User:         data.NewUserRepository(app.DB),
+		Income:       data.NewIncomeRepository(app.DB),
+		Expense:      data.NewExpenseRepository(app.DB),
+		Claim:        data.NewClaimRepository(app.DB),
+		Procurement:  data.NewProcurementRepository(app.DB),
+		Inventory:    data.NewInventoryRepository(app.DB),
+		MineSite:     data.NewMineSiteRepository(app.DB),
+		Stocktake:    data.NewStocktakeRepository(app.DB),
+		Notification: data.NewNotificationRepository(app.DB),
+		Vehicle:      data.NewVehicleRepository(app.DB),
+		Equipment:    data.NewEquipmentRepository(app.DB),
+		Insurance:    data.NewInsuranceRepository(app.DB),
+		SupplyPrice:  data.NewSupplyPriceRepository(app.DB),
+		Allocation:   data.NewCostAllocationRepository(app.DB),
+		Trip:         data.NewTripRepository(app.DB),
+		Contractor:   data.NewContractorRepository(app.DB),
+		Employee:     data.NewEmployeeRepository(app.DB),
+		Timesheet:    data.NewTimesheetRepository(app.DB),
+		Payroll:      data.NewPayrollRepository(app.DB),
+		Settings:     data.NewSettingsRepository(app.DB),
+		Organization: data.NewOrganizationRepository(app.DB),
+		Audit:        data.NewAuditRepository(app.DB),
+		Job:          data.NewJobRepository(app.DB),
+		Delivery:     data.NewDeliveryRepository(app.DB),
+		InviteCode:   data.NewInviteCodeRepository(app.DB),
+		Identity:     data.NewIdentityRepository(app.DB),
+		RefreshToken: data.NewRefreshTokenRepository(app.DB),
+		RevokedToken: data.NewRevokedTokenRepository(app.DB),
+		ShareLink:    data.NewShareLinkRepository(app.DB),
+		Receipt:      data.NewReceiptRepository(app.DB),
+		CreditNote:   data.NewCreditNoteRepository(app.DB),
+		Template:     data.NewDocumentTemplateRepository(app.DB),
+		Dunning:      data.NewDunningRepository(app.DB),
+		Task:         data.NewTaskRepository(app.DB),
+		Attendance:   data.NewAttendanceRepository(app.DB),
+		Evidence:     data.NewEvidenceRepository(app.DB),
+		Attachment:   data.NewAttachmentRepository(app.DB),
+		DueDiligence: data.NewDueDiligenceRepository(app.DB),
+		LotSeal:      data.NewLotSealRepository(app.DB),
+		Payment:      data.NewPaymentRepository(app.DB),
+		Assay:        data.NewAssayRepository(app.DB),
+		Purchase:     data.NewPurchaseRepository(app.DB),
+		Miner:        data.NewMinerRepository(app.DB),
+		MarketPrice:  data.NewMarketPriceRepository(app.DB),
+		CashDay:      data.NewCashDayRepository(app.DB),
+		Till:         data.NewTillRepository(app.DB),
+		Handover:     data.NewShiftHandoverRepository(app.DB),
+		BulkSMS:      data.NewBulkSMSRepository(app.DB),
+		Contact:      data.NewContactRepository(app.DB),
+		CreditLimit:  data.NewCreditLimitRepository(app.DB),
+		Flag:         data.NewFlagRepository(app.DB),
+		Benchmark:    data.NewBenchmarkRepository(app.DB),
+		Trade:        data.NewTradeRepository(app.DB),
+		Webhook:      data.NewWebhookRepository(app.DB),
+		Backup:       data.NewBackupRepository(app.DB),
+		Usage:        data.NewUsageRepository(app.DB),
+		Feature:      data.NewFeatureRepository(app.DB),
+		Referral:     data.NewReferralRepository(app.DB),
+		Support:      data.NewSupportRepository(app.DB),
+		Lease:        data.NewLeaseRepository(app.DB),
+		RateLimit:    data.NewRateLimitRepository(app.DB),
+		Idempotency:  data.NewIdempotencyRepository(app.DB),
+		Archive:      data.NewArchiveRepository(app.DB),
+		Warehouse:    data.NewWarehouseRepository(app.DB),
+		Stream:       data.NewStreamRepository(app.DB),
+		Outbox:       data.NewOutboxRepository(app.DB),
+	}
+}
+
+// seed creates the bootstrap data. It is safe to run on every deploy.
+func (app *Config) seed(ctx context.Context) error {
+	// A bootstrap admin invite code so the first admin can register
+	if adminCode := os.Getenv("ADMIN_INVITE_CODE"); adminCode != "" {
+		if err := app.Models.InviteCode.EnsureCode(ctx, adminCode, data.RoleAdmin); err != nil {
+			return fmt.Errorf("failed to seed admin invite code: %w", err)
+		}
+		app.InfoLog.Println("Admin invite code seeded")
 	}
+	return nil
+}
+
+// work runs the background jobs and scheduled tasks until the process is stopped
+func (app *Config) work() {
+	app.initTracing()
+	app.DB = app.initDB()
+	app.InfoLog.Println("Database connection established")
+	app.initModels()
+
+	app.startWorker()
+	app.InfoLog.Println("Worker started")
+
+	waitForShutdown()
+	app.InfoLog.Println("Worker is shutting down...")
 
-	// Initialize mailer (mock for development)
-	app.Mailer = &email.MockMailer{}
+	app.Scheduler.Stop()
+	app.Wait.Wait()
+	app.stopTracing()
 
-	// Set JWT secret from environment
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		jwtSecret = "your-secret-key" // Default for development
+	app.InfoLog.Println("Worker exited")
+}
+
+// startWorker sets up the senders and backups the background work needs and starts the job
+// runner and scheduled tasks
+func (app *Config) startWorker() {
+	// Initialize mailer (mock for development unless SMTP is configured)
+	if smtpHost := os.Getenv("SMTP_HOST"); smtpHost != "" {
+		app.Mailer = &email.SMTPMailer{
+			Host:     smtpHost,
+			Port:     getEnv("SMTP_PORT", "587"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     getEnv("SMTP_FROM", "noreply@miningfinance.com"),
+		}
+	} else {
+		app.Mailer = &email.MockMailer{}
 	}
-	utils.SetJWTSecret(jwtSecret)
-
-	// Initialize handlers
-	authHandler := handlers.NewAuthHandler(app.Models.User)
-	incomeHandler := handlers.NewIncomeHandler(app.Models.Income)
-	expenseHandler := handlers.NewExpenseHandler(app.Models.Expense)
-	inventoryHandler := handlers.NewInventoryHandler(app.Models.Inventory)
-	analyticsHandler := handlers.NewAnalyticsHandler(app.Models.Income, app.Models.Expense)
-	mineSiteHandler := handlers.NewMineSiteHandler(app.Models.MineSite)
-
-	// Setup routes
-	router := routes.SetupRoutes(
-		authHandler,
-		incomeHandler,
-		expenseHandler,
-		inventoryHandler,
-		analyticsHandler,
-		mineSiteHandler,
-	)
-
-	// Create server
-	server := &http.Server{
-		Addr:         ":9006",
-		Handler:      router,
-		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 30 * time.Second,
-		IdleTimeout:  120 * time.Second,
+
+	// Initialize SMS sender (mock for development)
+	app.SMS = &sms.MockSender{}
+
+	// Initialize database backups (uploads are mocked unless S3-compatible storage is configured)
+	if encodedKey := os.Getenv("BACKUP_ENCRYPTION_KEY"); encodedKey != "" && app.DBSettings.Driver == DriverSQLite {
+		app.InfoLog.Println("Database backups use pg_dump and are disabled with SQLite; copy the database file instead")
+	} else if encodedKey != "" {
+		key, err := backup.ParseKey(encodedKey)
+		if err != nil {
+			app.ErrorLog.Fatalf("Invalid BACKUP_ENCRYPTION_KEY: %v", err)
+		}
+		app.BackupKey = key
+		app.BackupPrefix = getEnv("BACKUP_S3_PREFIX", "backups/")
+		app.PGDumpPath = getEnv("PG_DUMP_PATH", "pg_dump")
+		if bucket := os.Getenv("BACKUP_S3_BUCKET"); bucket != "" {
+			app.BackupUploader = storage.NewS3Uploader(
+				getEnv("BACKUP_S3_ENDPOINT", "https://s3.amazonaws.com"),
+				getEnv("BACKUP_S3_REGION", "us-east-1"),
+				bucket,
+				os.Getenv("BACKUP_S3_ACCESS_KEY"),
+				os.Getenv("BACKUP_S3_SECRET_KEY"),
+			)
+		} else {
+			app.BackupUploader = &storage.MockUploader{}
+		}
+	} else {
+		app.InfoLog.Println("Database backups disabled: BACKUP_ENCRYPTION_KEY is not set")
 	}
 
-	// Start server in a goroutine
-	go func() {
-		app.InfoLog.Printf("Starting server on port %s", server.Addr)
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			app.ErrorLog.Fatalf("Server failed to start: %v", err)
+	// Initialize analytics warehouse exports (uploads are mocked unless a bucket is configured)
+	if os.Getenv("WAREHOUSE_EXPORT") == "true" {
+		app.WarehousePrefix = getEnv("WAREHOUSE_PREFIX", "warehouse/")
+		if bucket := os.Getenv("WAREHOUSE_S3_BUCKET"); bucket != "" {
+			app.WarehouseUploader = storage.NewS3Uploader(
+				getEnv("WAREHOUSE_S3_ENDPOINT", "https://storage.googleapis.com"),
+				getEnv("WAREHOUSE_S3_REGION", "auto"),
+				bucket,
+				os.Getenv("WAREHOUSE_S3_ACCESS_KEY"),
+				os.Getenv("WAREHOUSE_S3_SECRET_KEY"),
+			)
+		} else {
+			app.WarehouseUploader = &storage.MockUploader{}
 		}
-	}()
+	}
 
-	// Wait for interrupt signal to gracefully shutdown the server
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	// Start background jobs
+	app.Jobs = jobs.NewRunner(app.Models.Job, app.ErrorLog)
+	app.Jobs.Register(data.JobTypeSendOTP, app.sendOTP)
+	app.Jobs.Register(data.JobTypeSendMessage, app.sendMessage)
+	app.Jobs.Register(data.JobTypeDeliverWebhook, app.deliverWebhook)
+	app.Outbox = events.NewBus(app.ErrorLog)
+	app.subscribeOutbox()
 
-	app.InfoLog.Println("Server is shutting down...")
+	// Every worker claims queued jobs and outbox messages; the scheduled tasks only run on the
+	// worker holding the scheduler lease so reminders and backups aren't repeated by each worker
+	app.Scheduler = scheduler.New(app.Wait, app.ErrorLog)
+	app.Scheduler.Elect(scheduler.NewElection(app.Models.Lease, "scheduler", workerID(), schedulerLeaseTTL, app.ErrorLog))
+	app.Scheduler.Every("job-queue", 5*time.Second, app.Jobs.RunPending)
+	app.Scheduler.Every("outbox", 2*time.Second, app.publishOutbox)
+	app.Scheduler.EveryOnLeader("expiring-supplies", 24*time.Hour, app.notifyExpiringSupplies)
+	app.Scheduler.EveryOnLeader("expiring-policies", 24*time.Hour, app.notifyExpiringPolicies)
+	app.Scheduler.EveryOnLeader("dunning", time.Hour, app.runDunning)
+	app.Scheduler.EveryOnLeader("overdue-tasks", time.Hour, app.notifyOverdueTasks)
+	app.Scheduler.EveryOnLeader("trials", time.Hour, app.checkTrials)
+	app.Scheduler.EveryOnLeader("request-state", time.Hour, app.pruneRequestState)
+	app.Scheduler.EveryOnLeader("outbox-prune", 24*time.Hour, app.pruneOutbox)
+	if app.DBSettings.PartitionByYear && app.DBSettings.Driver != DriverSQLite {
+		app.Scheduler.EveryOnLeader("partitions", 24*time.Hour, app.ensurePartitions)
+	}
+	if app.ArchiveAfterYears > 0 {
+		app.Scheduler.EveryOnLeader("archive", 24*time.Hour, app.archiveTransactions)
+	}
+	if app.WarehouseUploader != nil {
+		interval := time.Duration(getEnvInt("WAREHOUSE_EXPORT_INTERVAL_MINUTES", 60)) * time.Minute
+		app.Scheduler.EveryOnLeader("warehouse-export", interval, app.exportWarehouse)
+	}
+	if app.BackupKey != nil {
+		app.Scheduler.EveryOnLeader("database-backup", time.Hour, app.runBackup)
+	}
+	app.Scheduler.Start()
+}
+
+// schedulerLeaseTTL is how long the scheduler leader holds its lease without renewing it, and
+// so how long the scheduled tasks pause when the leader stops without releasing it
+const schedulerLeaseTTL = 30 * time.Second
 
-	// Graceful shutdown
-	if err := server.Shutdown(nil); err != nil {
-		app.ErrorLog.Fatalf("Server forced to shutdown: %v", err)
+// workerID identifies this process in leader elections, from WORKER_ID or the host name and
+// process ID; container host names are unique per replica
+func workerID() string {
+	if id := os.Getenv("WORKER_ID"); id != "" {
+		return id
 	}
+	host, err := os.Hostname()
+	if err != nil {
+		host = "worker"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
 
-	app.InfoLog.Println("Server exited")
+// waitForShutdown blocks until the process receives an interrupt or termination signal
+func waitForShutdown() {
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
 }
@@ -0,0 +1,361 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"mineral/data"
+	"mineral/data/mocks"
+	"mineral/handlers"
+	"mineral/pkg/utils"
+	"mineral/routes"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/parquet-go/parquet-go"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm/schema"
+)
+
+// TestHealthEndpoint tests the health check endpoint
+func TestHealthEndpoint(t *testing.T) {
+	// Create a test router
+	router := routes.SetupRoutes(routes.Handlers{})
+
+	// Create a request
+	req, err := http.NewRequest("GET", "/health", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a response recorder
+	rr := httptest.NewRecorder()
+
+	// Serve the request
+	router.ServeHTTP(rr, req)
+
+	// Check the status code
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	// Check the response body
+	expected := "OK"
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got %v want %v", rr.Body.String(), expected)
+	}
+}
+
+// TestSignupEndpoint tests the signup endpoint
+func TestSignupEndpoint(t *testing.T) {
+	// Create mock user repository
+	userRepo := mocks.NewMockUserInterface(gomock.NewController(t))
+	userRepo.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(nil, fmt.Errorf("user not found"))
+	userRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(1, nil)
+
+	// Create auth handler
+	authHandler := handlers.NewAuthHandler(userRepo, nil, nil, nil, nil)
+
+	// Create a test router
+	router := routes.SetupRoutes(routes.Handlers{Auth: authHandler})
+
+	// Create signup request
+	signupReq := handlers.SignupRequest{
+		Email:    "test@example.com",
+		Name:     "Test User",
+		Password: "password123",
+	}
+
+	jsonData, err := json.Marshal(signupReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a request
+	req, err := http.NewRequest("POST", "/api/v1/auth/signup", bytes.NewBuffer(jsonData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Create a response recorder
+	rr := httptest.NewRecorder()
+
+	// Serve the request
+	router.ServeHTTP(rr, req)
+
+	// Check the status code
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+}
+
+// TestLoginLockout tests that logins to a locked account are refused with a distinct error, and
+// that the failure locking an account gets it too
+func TestLoginLockout(t *testing.T) {
+	var lockedUntil *time.Time
+	failures := 0
+	userRepo := mocks.NewMockUserInterface(gomock.NewController(t))
+	userRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, email string) (*data.User, error) {
+		return &data.User{Email: email, LockedUntil: lockedUntil}, nil
+	}).AnyTimes()
+	userRepo.EXPECT().PasswordMatches(gomock.Any(), gomock.Any()).DoAndReturn(func(user *data.User, plainText string) (bool, error) {
+		return plainText == "password123", nil
+	}).AnyTimes()
+	userRepo.EXPECT().RecordFailedLogin(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, userID uint, maxFailures int, lockout time.Duration) (*time.Time, error) {
+		failures++
+		if failures < maxFailures {
+			return nil, nil
+		}
+		until := time.Now().Add(lockout)
+		lockedUntil = &until
+		return lockedUntil, nil
+	}).Times(3)
+
+	authHandler := handlers.NewAuthHandler(userRepo, nil, nil, nil, nil)
+	authHandler.MaxFailedLogins = 3
+	authHandler.LockoutDuration = 15 * time.Minute
+	router := routes.SetupRoutes(routes.Handlers{Auth: authHandler})
+
+	login := func(password string) *httptest.ResponseRecorder {
+		jsonData, err := json.Marshal(handlers.LoginRequest{Email: "test@example.com", Password: password})
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, err := http.NewRequest("POST", "/api/v1/auth/login", bytes.NewBuffer(jsonData))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	for i := 1; i < authHandler.MaxFailedLogins; i++ {
+		if status := login("wrong-password").Code; status != http.StatusUnauthorized {
+			t.Errorf("failed login %d returned wrong status code: got %v want %v", i, status, http.StatusUnauthorized)
+		}
+	}
+	if status := login("wrong-password").Code; status != http.StatusLocked {
+		t.Errorf("failed login locking the account returned wrong status code: got %v want %v", status, http.StatusLocked)
+	}
+
+	// The right password doesn't get into a locked account
+	rr := login("password123")
+	if rr.Code != http.StatusLocked {
+		t.Errorf("login to a locked account returned wrong status code: got %v want %v", rr.Code, http.StatusLocked)
+	}
+	if !strings.Contains(rr.Body.String(), "Account locked") {
+		t.Errorf("login to a locked account returned unexpected body: %s", rr.Body.String())
+	}
+}
+
+// TestOpenAPIDocument tests that the served OpenAPI document describes every route, so it is
+// regenerated when routes change
+func TestOpenAPIDocument(t *testing.T) {
+	// Create a test router
+	router := routes.SetupRoutes(routes.Handlers{})
+
+	req, err := http.NewRequest("GET", "/api/v1/openapi.json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	var document struct {
+		OpenAPI string                            `json:"openapi"`
+		Paths   map[string]map[string]interface{} `json:"paths"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &document); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(document.OpenAPI, "3.") {
+		t.Errorf("document has OpenAPI version %q, want 3.x", document.OpenAPI)
+	}
+
+	// Every route is in the document, run go generate ./routes when this fails
+	err = chi.Walk(router.(chi.Routes), func(method, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		path := strings.TrimSuffix(strings.TrimSuffix(route, "/*"), "/")
+		if path == "" {
+			path = "/"
+		}
+		if _, ok := document.Paths[path][strings.ToLower(method)]; !ok {
+			t.Errorf("route %s %s is not in the OpenAPI document", method, path)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestNoForeignKeysToPartitionedTables tests that no model migrates a foreign key to a table
+// partitioned with DB_PARTITION_BY_YEAR. Partitioned tables have a primary key of (id, date), so
+// PostgreSQL refuses a foreign key to their id and every migration after partitioning would fail.
+// Tag such relations with -:migration.
+func TestNoForeignKeysToPartitionedTables(t *testing.T) {
+	cache := &sync.Map{}
+	for _, model := range schemaModels() {
+		s, err := schema.Parse(model, cache, schema.NamingStrategy{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, rel := range s.Relationships.Relations {
+			if rel.Field.IgnoreMigration {
+				continue
+			}
+			constraint := rel.ParseConstraint()
+			if constraint == nil || constraint.Schema != s {
+				continue
+			}
+			for _, table := range partitionedTables {
+				if constraint.ReferenceSchema.Table == table {
+					t.Errorf("%s.%s migrates a foreign key to %s", s.Name, rel.Name, table)
+				}
+			}
+		}
+	}
+}
+
+// TestWriteTransactions reads a warehouse file back with a Parquet reader
+func TestWriteTransactions(t *testing.T) {
+	org, unit := "Kasese Gold", "grams"
+	quantity := 12.5
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	deleted := date.Add(36 * time.Hour)
+	rows := []transactionRow{
+		{TransactionType: "income", TransactionID: 7, TenantID: 42, Organization: &org, Currency: "UGX", Date: date,
+			Category: "mineral_sales", Counterparty: "Buyer", Quantity: &quantity, Unit: &unit, Amount: 900000,
+			AmountPaid: 500000, AmountDue: 400000, PaymentStatus: "partial", CreatedAt: date, UpdatedAt: date.Add(time.Hour)},
+		{TransactionType: "expense", TransactionID: 8, TenantID: 42, Currency: "UGX", Date: date, Category: "fuel",
+			Counterparty: "Shell", Amount: 50000, AmountPaid: 50000, PaymentStatus: "paid", CreatedAt: date, UpdatedAt: date,
+			DeletedAt: deleted.UnixMilli()},
+	}
+
+	var file bytes.Buffer
+	if err := writeTransactions(&file, rows, map[string]string{"dataset": transactionsDataset, "tenant_id": "42"}); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := bytes.NewReader(file.Bytes())
+	got, err := parquet.Read[transactionRow](reader, reader.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(rows) {
+		t.Fatalf("read %d rows, want %d", len(got), len(rows))
+	}
+	for i := range rows {
+		if !reflect.DeepEqual(got[i], rows[i]) {
+			t.Errorf("row %d = %+v, want %+v", i, got[i], rows[i])
+		}
+	}
+
+	f, err := parquet.OpenFile(reader, reader.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dataset, _ := f.Lookup("dataset"); dataset != transactionsDataset {
+		t.Errorf("dataset metadata = %q, want %q", dataset, transactionsDataset)
+	}
+	if tenant, _ := f.Lookup("tenant_id"); tenant != "42" {
+		t.Errorf("tenant_id metadata = %q, want 42", tenant)
+	}
+}
+
+// TestRoutePermissions tests that routes paying for and approving work are refused to a clerk
+// whose role has every permission but the one the route needs
+func TestRoutePermissions(t *testing.T) {
+	var permissions []data.Permission
+	organizationRepo := mocks.NewMockOrganizationInterface(gomock.NewController(t))
+	organizationRepo.EXPECT().GetMembership(gomock.Any(), uint(5), uint(2)).Return(&data.OrganizationMember{
+		OrganizationID: 5, Organization: &data.Organization{OwnerID: 1}, UserID: 2, Role: data.OrgRoleClerk,
+	}, nil).AnyTimes()
+	organizationRepo.EXPECT().GetRolePermissions(gomock.Any(), uint(5), data.OrgRoleClerk).DoAndReturn(func(ctx context.Context, organizationID uint, role data.OrganizationRole) (*data.RolePermissions, error) {
+		return &data.RolePermissions{Role: role, Permissions: permissions}, nil
+	}).AnyTimes()
+	organizationRepo.EXPECT().GetIPRules(gomock.Any(), uint(5)).Return(nil, nil).AnyTimes()
+
+	router := routes.SetupRoutes(routes.Handlers{
+		Organization: handlers.NewOrganizationHandler(organizationRepo, nil),
+		Subscription: &handlers.SubscriptionHandler{},
+	})
+	token, err := utils.GenerateJWT("2", "clerk@example.com", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		method, path string
+		permission   data.Permission
+	}{
+		{http.MethodPost, "/api/v1/contractors/3/work", data.PermExpenseCreate},
+		{http.MethodDelete, "/api/v1/contractors/3/work/4", data.PermExpenseDelete},
+		{http.MethodPost, "/api/v1/trades/purchases/3/accept", data.PermExpenseCreate},
+		{http.MethodPost, "/api/v1/stocktakes/3/approve", data.PermInventoryUpdate},
+		{http.MethodPost, "/api/v1/timesheets/3/approve", data.PermPayrollApprove},
+		{http.MethodPost, "/api/v1/timesheets/3/reject", data.PermPayrollApprove},
+		{http.MethodPost, "/api/v1/payroll", data.PermPayrollApprove},
+		{http.MethodDelete, "/api/v1/payroll/3", data.PermPayrollApprove},
+		{http.MethodPost, "/api/v1/trades/3/disputes/accept", data.PermIncomeUpdate},
+		{http.MethodPost, "/api/v1/trades/3/disputes/accept", data.PermIncomeDelete},
+		{http.MethodPost, "/api/v1/trades/purchases/3/decline", data.PermExpenseCreate},
+		{http.MethodPost, "/api/v1/employees", data.PermPayrollApprove},
+		{http.MethodPut, "/api/v1/employees/3", data.PermPayrollApprove},
+		{http.MethodDelete, "/api/v1/employees/3", data.PermPayrollApprove},
+		{http.MethodPost, "/api/v1/employees/3/adjustments", data.PermPayrollApprove},
+		{http.MethodDelete, "/api/v1/employees/3/adjustments/4", data.PermPayrollApprove},
+		{http.MethodPost, "/api/v1/contractors", data.PermExpenseCreate},
+		{http.MethodPut, "/api/v1/contractors/3", data.PermExpenseUpdate},
+		{http.MethodDelete, "/api/v1/contractors/3", data.PermExpenseDelete},
+		{http.MethodPost, "/api/v1/stocktakes", data.PermInventoryUpdate},
+		{http.MethodPut, "/api/v1/stocktakes/3/counts", data.PermInventoryUpdate},
+		{http.MethodPost, "/api/v1/stocktakes/3/cancel", data.PermInventoryUpdate},
+		{http.MethodPut, "/api/v1/minesite", data.PermSettingsManage},
+		{http.MethodPost, "/api/v1/minesite/sites", data.PermSettingsManage},
+		{http.MethodPut, "/api/v1/minesite/sites/3", data.PermSettingsManage},
+		{http.MethodDelete, "/api/v1/minesite/sites/3", data.PermSettingsManage},
+		{http.MethodPost, "/api/v1/miners", data.PermPurchaseCreate},
+		{http.MethodPut, "/api/v1/miners/3", data.PermPurchaseUpdate},
+		{http.MethodDelete, "/api/v1/miners/3", data.PermPurchaseDelete},
+		{http.MethodPost, "/api/v1/dunning/schedules", data.PermSettingsManage},
+		{http.MethodPut, "/api/v1/dunning/schedules/3", data.PermSettingsManage},
+		{http.MethodDelete, "/api/v1/dunning/schedules/3", data.PermSettingsManage},
+		{http.MethodPost, "/api/v1/share-links/statement", data.PermIncomeUpdate},
+		{http.MethodDelete, "/api/v1/share-links/3", data.PermIncomeUpdate},
+		{http.MethodPost, "/api/v1/income/3/share", data.PermIncomeUpdate},
+		{http.MethodPost, "/api/v1/receipts/3/send", data.PermPaymentRecord},
+		{http.MethodPost, "/api/v1/income/3/seal", data.PermIncomeUpdate},
+		{http.MethodPost, "/api/v1/income/3/send-to-buyer", data.PermIncomeUpdate},
+	}
+	for _, tt := range tests {
+		permissions = nil
+		for _, permission := range data.AllPermissions {
+			if permission != tt.permission {
+				permissions = append(permissions, permission)
+			}
+		}
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("X-Organization-ID", "5")
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		if rr.Code != http.StatusForbidden || !strings.Contains(rr.Body.String(), string(tt.permission)) {
+			t.Errorf("%s %s without %s returned status %d, want %d: %s", tt.method, tt.path, tt.permission, rr.Code, http.StatusForbidden, rr.Body.String())
+		}
+	}
+}
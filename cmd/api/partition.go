@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"mineral/data"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// partitionedTables are the tables split into yearly partitions on their date column with
+// DB_PARTITION_BY_YEAR. Reports and lists filter them by date, so PostgreSQL only scans the
+// partitions of the years asked for, however many years of records a cooperative keeps.
+var partitionedTables = []string{"incomes", "expenses"}
+
+// partitionTables converts the partitioned tables to yearly partitions if they aren't yet and
+// creates the partitions through next year. Converting copies the table, blocking writes to it
+// meanwhile, so the first migration with partitioning enabled is best run during a quiet hour.
+func (app *Config) partitionTables(ctx context.Context) error {
+	for _, table := range partitionedTables {
+		partitioned, err := isPartitioned(app.DB, table)
+		if err != nil {
+			return err
+		}
+		if !partitioned {
+			app.InfoLog.Printf("Partitioning %s by year...", table)
+			if err := convertToPartitioned(app.DB, table); err != nil {
+				return fmt.Errorf("failed to partition %s: %w", table, err)
+			}
+		}
+	}
+
+	// Recreate the indexes and foreign keys the old tables took with them
+	if err := app.DB.AutoMigrate(&data.Income{}, &data.Expense{}); err != nil {
+		return err
+	}
+	return app.ensurePartitions(ctx)
+}
+
+// ensurePartitions creates the partitions of this year and next year, so new records never
+// fall into the default partition. Creating a partition fails when the default partition
+// already holds records of its year, e.g. sales dated years ahead by mistake; move them out of
+// the default partition and run it again.
+func (app *Config) ensurePartitions(ctx context.Context) error {
+	year := time.Now().UTC().Year()
+	for _, table := range partitionedTables {
+		partitioned, err := isPartitioned(app.DB.WithContext(ctx), table)
+		if err != nil {
+			return err
+		}
+		if !partitioned {
+			continue
+		}
+		for y := year; y <= year+1; y++ {
+			if err := app.DB.WithContext(ctx).Exec(createPartition(table, y)).Error; err != nil {
+				return fmt.Errorf("failed to create partition %s_%d: %w", table, y, err)
+			}
+		}
+	}
+	return nil
+}
+
+// isPartitioned reports whether a table is a partitioned table
+func isPartitioned(db *gorm.DB, table string) (bool, error) {
+	var kind string
+	err := db.Raw(`SELECT c.relkind::text FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = current_schema() AND c.relname = ?`, table).Scan(&kind).Error
+	return kind == "p", err
+}
+
+// convertToPartitioned replaces a table with a copy partitioned by year on its date column, with
+// a partition per year of its records and a default partition for dates out of range. The
+// primary key becomes (id, date) since it must include the partition column, and foreign keys
+// referencing the table are dropped since PostgreSQL can't enforce them on a partitioned table.
+func convertToPartitioned(db *gorm.DB, table string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(fmt.Sprintf("LOCK TABLE %s IN ACCESS EXCLUSIVE MODE", table)).Error; err != nil {
+			return err
+		}
+
+		var first, last sql.NullTime
+		if err := tx.Raw(fmt.Sprintf("SELECT MIN(date), MAX(date) FROM %s", table)).Row().Scan(&first, &last); err != nil {
+			return err
+		}
+		from, to := time.Now().UTC().Year(), time.Now().UTC().Year()+1
+		if first.Valid && first.Time.UTC().Year() < from {
+			from = first.Time.UTC().Year()
+		}
+		if last.Valid && last.Time.UTC().Year() > to {
+			to = last.Time.UTC().Year()
+		}
+
+		var sequence string
+		if err := tx.Raw("SELECT COALESCE(pg_get_serial_sequence(?, 'id'), '')", table).Scan(&sequence).Error; err != nil {
+			return err
+		}
+
+		staging := table + "_partitioned"
+		statements := []string{
+			fmt.Sprintf(`DO $$ DECLARE fk record; BEGIN
+				FOR fk IN SELECT conrelid::regclass AS tbl, conname FROM pg_constraint WHERE contype = 'f' AND confrelid = '%s'::regclass LOOP
+					EXECUTE format('ALTER TABLE %%s DROP CONSTRAINT %%I', fk.tbl, fk.conname);
+				END LOOP;
+			END $$`, table),
+			fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING DEFAULTS INCLUDING CONSTRAINTS) PARTITION BY RANGE (date)", staging, table),
+			fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s_pkey PRIMARY KEY (id, date)", staging, staging),
+			fmt.Sprintf("CREATE TABLE %s_default PARTITION OF %s DEFAULT", table, staging),
+		}
+		for y := from; y <= to; y++ {
+			statements = append(statements, fmt.Sprintf("CREATE TABLE %s_%d PARTITION OF %s FOR VALUES FROM ('%d-01-01 00:00:00+00') TO ('%d-01-01 00:00:00+00')",
+				table, y, staging, y, y+1))
+		}
+		statements = append(statements, fmt.Sprintf("INSERT INTO %s SELECT * FROM %s", staging, table))
+		if sequence != "" {
+			// Keep the ID sequence when the old table is dropped
+			statements = append(statements, fmt.Sprintf("ALTER SEQUENCE %s OWNED BY NONE", sequence))
+		}
+		statements = append(statements,
+			fmt.Sprintf("DROP TABLE %s", table),
+			fmt.Sprintf("ALTER TABLE %s RENAME TO %s", staging, table),
+			fmt.Sprintf("ALTER TABLE %s RENAME CONSTRAINT %s_pkey TO %s_pkey", table, staging, table),
+		)
+		if sequence != "" {
+			statements = append(statements, fmt.Sprintf("ALTER SEQUENCE %s OWNED BY %s.id", sequence, table))
+		}
+
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// createPartition returns the statement creating the partition of a year, if it doesn't exist
+func createPartition(table string, year int) string {
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s_%d PARTITION OF %s FOR VALUES FROM ('%d-01-01 00:00:00+00') TO ('%d-01-01 00:00:00+00')",
+		table, year, table, year, year+1)
+}
@@ -0,0 +1,402 @@
+package main
+
+import (
+	"context"
+	"mineral/data"
+	"mineral/handlers"
+	"mineral/pkg/billing"
+	"mineral/pkg/events"
+	"mineral/pkg/middleware"
+	"mineral/pkg/oauth"
+	"mineral/pkg/storage"
+	"mineral/pkg/tracing"
+	"mineral/pkg/utils"
+	"mineral/routes"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// serve serves the HTTP API until the process is stopped. Unless AUTO_MIGRATE is false it
+// migrates and seeds the database first, and unless RUN_WORKER is false it also runs the
+// background jobs and scheduled tasks in this process.
+func (app *Config) serve() {
+	ctx := context.Background()
+	app.initTracing()
+
+	// Initialize database
+	app.DB = app.initDB()
+	app.InfoLog.Println("Database connection established")
+	app.initModels()
+
+	if os.Getenv("AUTO_MIGRATE") != "false" {
+		if err := app.migrate(ctx); err != nil {
+			app.ErrorLog.Fatalf("Failed to migrate database: %v", err)
+		}
+		if err := app.seed(ctx); err != nil {
+			app.ErrorLog.Println(err)
+		}
+	}
+
+	// Initialize payment provider (mock for development)
+	if checkoutURL := os.Getenv("BILLING_CHECKOUT_URL"); checkoutURL != "" {
+		secret := os.Getenv("BILLING_WEBHOOK_SECRET")
+		if secret == "" {
+			app.ErrorLog.Fatal("BILLING_WEBHOOK_SECRET is required with BILLING_CHECKOUT_URL")
+		}
+		app.Billing = billing.NewHostedProvider(checkoutURL, secret)
+	} else {
+		app.Billing = &billing.MockProvider{}
+	}
+
+	// Set JWT secret from environment
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		jwtSecret = "your-secret-key" // Default for development
+	}
+	utils.SetJWTSecret(jwtSecret)
+	utils.SetLinkSecret(getEnv("LINK_SIGNING_SECRET", jwtSecret))
+	utils.SetSessionCookieOptions(os.Getenv("SESSION_COOKIE_SECURE") != "false", os.Getenv("SESSION_COOKIE_DOMAIN"))
+
+	// Only trust proxy headers for client IPs when running behind a reverse proxy
+	middleware.SetTrustProxyHeaders(os.Getenv("TRUST_PROXY_HEADERS") == "true")
+
+	// Request bodies in the request log, with passwords, codes and tokens masked
+	if os.Getenv("LOG_REQUEST_BODIES") == "true" {
+		redactor, err := middleware.NewRedactor(os.Getenv("LOG_REDACT_FIELDS"))
+		if err != nil {
+			app.ErrorLog.Fatalf("Invalid LOG_REDACT_FIELDS: %v", err)
+		}
+		middleware.SetBodyLogging(redactor, int64(getEnvInt("LOG_BODY_MAX_BYTES", 16<<10)))
+	}
+
+	// Browsers are told to only use HTTPS for a year, and request bodies are limited
+	middleware.SetHSTS(getEnvInt("HSTS_MAX_AGE", 365*24*60*60))
+	middleware.SetBodyLimits(int64(getEnvInt("MAX_BODY_MB", 1))<<20, int64(getEnvInt("MAX_UPLOAD_MB", 16))<<20)
+
+	// Apps older than the minimum version are asked to upgrade
+	if err := middleware.SetMinClientVersion(os.Getenv("MIN_APP_VERSION"), os.Getenv("APP_UPGRADE_URL")); err != nil {
+		app.ErrorLog.Fatalf("Invalid MIN_APP_VERSION: %v", err)
+	}
+
+	// Rate limits and idempotency keys are kept in the database so they hold across replicas
+	middleware.SetRateLimits(app.Models.RateLimit.Hit, map[string]int{
+		"auth":   getEnvInt("AUTH_RATE_LIMIT", 20),
+		"public": getEnvInt("PUBLIC_RATE_LIMIT", 120),
+	})
+	middleware.SetIdempotencyStore(&idempotencyStore{repo: app.Models.Idempotency})
+	// Tokens revoked on logout are kept in the database so they are rejected by every replica
+	middleware.SetTokenRevocation(app.Models.RevokedToken.IsRevoked)
+
+	// Query counts in response headers, for finding N+1 queries during development
+	if os.Getenv("DB_DEBUG_HEADERS") == "true" {
+		middleware.SetQueryStats(app.Queries.Snapshot, getEnvInt("DB_QUERY_WARN", 50))
+		app.InfoLog.Println("Database query debug headers enabled; requests are served one at a time")
+	}
+
+	// Initialize attachment storage (files are kept on disk unless a bucket is configured)
+	if bucket := os.Getenv("ATTACHMENT_S3_BUCKET"); bucket != "" {
+		app.Attachments = storage.NewS3Uploader(
+			getEnv("ATTACHMENT_S3_ENDPOINT", "https://s3.amazonaws.com"),
+			getEnv("ATTACHMENT_S3_REGION", "us-east-1"),
+			bucket,
+			os.Getenv("ATTACHMENT_S3_ACCESS_KEY"),
+			os.Getenv("ATTACHMENT_S3_SECRET_KEY"),
+		)
+	} else {
+		store, err := storage.NewDiskStore(getEnv("ATTACHMENT_DIR", "attachments"))
+		if err != nil {
+			app.ErrorLog.Fatalf("Failed to create the attachment directory: %v", err)
+		}
+		app.Attachments = store
+	}
+
+	// Initialize the event bus
+	app.Events = events.NewBus(app.ErrorLog)
+	app.subscribeEvents()
+
+	// Initialize handlers
+	authHandler := handlers.NewAuthHandler(app.Models.User, app.Models.Delivery, app.Models.Job, app.Models.InviteCode, app.Models.Identity)
+	authHandler.RequireInviteCode = os.Getenv("SIGNUP_REQUIRES_INVITE") == "true"
+	if clientID := os.Getenv("GOOGLE_CLIENT_ID"); clientID != "" {
+		google := oauth.NewGoogleVerifier(clientID)
+		google.Client = tracing.NewClient(google.Client, "google")
+		authHandler.Google = google
+	}
+	authHandler.UsageRepo = app.Models.Usage
+	authHandler.TrialPlan = data.PlanPro
+	authHandler.TrialDays = getEnvInt("TRIAL_DAYS", 14)
+	authHandler.ReferralRepo = app.Models.Referral
+	authHandler.RefreshRepo = app.Models.RefreshToken
+	authHandler.RefreshTokenTTL = time.Duration(getEnvInt("REFRESH_TOKEN_DAYS", 30)) * 24 * time.Hour
+	authHandler.RevokedRepo = app.Models.RevokedToken
+	authHandler.MaxFailedLogins = getEnvInt("MAX_FAILED_LOGINS", 5)
+	authHandler.LockoutDuration = time.Duration(getEnvInt("LOCKOUT_MINUTES", 15)) * time.Minute
+	authHandler.VerifyEmails = os.Getenv("EMAIL_VERIFICATION") != "false"
+	authHandler.RequireVerifiedEmail = os.Getenv("REQUIRE_EMAIL_VERIFICATION") == "true"
+	incomeHandler := handlers.NewIncomeHandler(app.Models.Income, app.Models.Settings, app.Models.Receipt, app.Models.CreditLimit, app.Models.Flag, app.Events)
+	expenseHandler := handlers.NewExpenseHandler(app.Models.Expense, app.Models.Evidence)
+	inventoryHandler := handlers.NewInventoryHandler(app.Models.Inventory, app.Models.Notification, app.Models.Evidence, app.Events)
+	incomeHandler.MineSiteRepo = app.Models.MineSite
+	incomeHandler.PaymentRepo = app.Models.Payment
+	expenseHandler.MineSiteRepo = app.Models.MineSite
+	expenseHandler.TripRepo = app.Models.Trip
+	expenseHandler.PaymentRepo = app.Models.Payment
+	inventoryHandler.MineSiteRepo = app.Models.MineSite
+	inventoryHandler.AuditRepo = app.Models.Audit
+	inventoryHandler.SettingsRepo = app.Models.Settings
+	inventoryHandler.SupplyPriceRepo = app.Models.SupplyPrice
+	analyticsHandler := handlers.NewAnalyticsHandler(app.Models.Income, app.Models.Expense, app.Models.Settings)
+	mineSiteHandler := handlers.NewMineSiteHandler(app.Models.MineSite)
+	stocktakeHandler := handlers.NewStocktakeHandler(app.Models.Stocktake)
+	notificationHandler := handlers.NewNotificationHandler(app.Models.Notification)
+	transportHandler := handlers.NewTransportHandler(app.Models.Vehicle, app.Models.Trip, app.Models.Income)
+	contractorHandler := handlers.NewContractorHandler(app.Models.Contractor)
+	employeeHandler := handlers.NewEmployeeHandler(app.Models.Employee)
+	timesheetHandler := handlers.NewTimesheetHandler(app.Models.Timesheet, app.Models.Employee)
+	payrollHandler := handlers.NewPayrollHandler(app.Models.Payroll, app.Models.Employee)
+	settingsHandler := handlers.NewSettingsHandler(app.Models.Settings)
+	organizationHandler := handlers.NewOrganizationHandler(app.Models.Organization, app.Models.User)
+	organizationHandler.JobRepo = app.Models.Job
+	organizationHandler.DeliveryRepo = app.Models.Delivery
+	exportHandler := handlers.NewExportHandler(app.Models.Income, app.Models.Expense, app.Models.Inventory, app.Models.Audit, app.Models.Flag)
+	auditHandler := handlers.NewAuditHandler(app.Models.Audit)
+	shareLinkHandler := handlers.NewShareLinkHandler(app.Models.ShareLink, app.Models.Income, app.Models.Settings, app.Models.User)
+	shareLinkHandler.BaseURL = strings.TrimSuffix(os.Getenv("PUBLIC_BASE_URL"), "/")
+	organizationHandler.BaseURL = shareLinkHandler.BaseURL
+	receiptHandler := handlers.NewReceiptHandler(app.Models.Receipt, app.Models.User, app.Models.Delivery, app.Models.Job)
+	receiptHandler.BaseURL = shareLinkHandler.BaseURL
+	dunningHandler := handlers.NewDunningHandler(app.Models.Dunning, app.Models.Income)
+	taskHandler := handlers.NewTaskHandler(app.Models.Task, app.Models.Organization)
+	calendarHandler := handlers.NewCalendarHandler(app.Models.Income, app.Models.MineSite, app.Models.Vehicle, app.Models.Task, app.Models.Settings)
+	calendarHandler.BaseURL = shareLinkHandler.BaseURL
+	attendanceHandler := handlers.NewAttendanceHandler(app.Models.Attendance, app.Models.Employee, app.Models.MineSite)
+	evidenceHandler := handlers.NewEvidenceHandler(app.Models.Evidence)
+	bulkSMSHandler := handlers.NewBulkSMSHandler(app.Models.BulkSMS, app.Models.Delivery, app.Models.Job, app.Models.User)
+	bulkSMSHandler.BaseURL = shareLinkHandler.BaseURL
+	bulkSMSHandler.MonthlyQuota = int64(getEnvInt("BULK_SMS_MONTHLY_QUOTA", 1000))
+	contactHandler := handlers.NewContactHandler(app.Models.Contact, app.Models.Audit)
+	creditLimitHandler := handlers.NewCreditLimitHandler(app.Models.CreditLimit)
+	flagHandler := handlers.NewFlagHandler(app.Models.Flag)
+	benchmarkHandler := handlers.NewBenchmarkHandler(app.Models.Benchmark, app.Models.Settings, app.Models.MineSite)
+	referenceHandler := handlers.NewReferenceHandler()
+	formHandler := handlers.NewFormHandler(app.Models.Settings, app.Models.Evidence)
+	webhookHandler := handlers.NewWebhookHandler(app.Models.Webhook)
+	backupHandler := handlers.NewBackupHandler(app.Models.Backup)
+	defaultPlan := data.Plan(getEnv("DEFAULT_PLAN", string(data.PlanUnlimited)))
+	if _, ok := data.Plans[defaultPlan]; !ok {
+		app.ErrorLog.Fatalf("Invalid DEFAULT_PLAN %q", defaultPlan)
+	}
+	attachmentQuota := handlers.NewAttachmentQuota(app.Models.Evidence, app.Models.Attachment, app.Models.Usage, app.Models.Settings)
+	attachmentQuota.DefaultPlan = defaultPlan
+	attachmentQuota.GracePercent = getEnvInt("ATTACHMENT_QUOTA_GRACE_PERCENT", 10)
+	expenseHandler.Quota = attachmentQuota
+	inventoryHandler.Quota = attachmentQuota
+	settingsHandler.Quota = attachmentQuota
+	usageHandler := handlers.NewUsageHandler(app.Models.Usage, app.Models.User)
+	usageHandler.DefaultPlan = defaultPlan
+	usageHandler.Quota = attachmentQuota
+	subscriptionHandler := handlers.NewSubscriptionHandler(app.Models.Usage, app.Models.Feature, app.Models.User, app.Billing)
+	subscriptionHandler.DefaultPlan = defaultPlan
+	switch trialExpiry := getEnv("TRIAL_EXPIRY", "free"); trialExpiry {
+	case "free":
+	case "read_only":
+		subscriptionHandler.ReadOnlyAfterTrial = true
+	default:
+		app.ErrorLog.Fatalf("Invalid TRIAL_EXPIRY %q, must be free or read_only", trialExpiry)
+	}
+	referralHandler := handlers.NewReferralHandler(app.Models.Referral)
+	supportHandler := handlers.NewSupportHandler(app.Models.Support, app.Models.User, app.Models.Delivery, app.Models.Job)
+	supportHandler.SupportEmail = os.Getenv("SUPPORT_EMAIL")
+	sqlDB, err := app.DB.DB()
+	if err != nil {
+		app.ErrorLog.Fatalf("Failed to access the database pool: %v", err)
+	}
+	metricsHandler := handlers.NewMetricsHandler(sqlDB.Stats, app.Queries.Snapshot)
+	archiveHandler := handlers.NewArchiveHandler(app.Models.Archive)
+	streamHandler := handlers.NewStreamHandler(app.Models.Stream)
+	tradeHandler := handlers.NewTradeHandler(app.Models.Trade, app.Models.Income, app.Models.Identity, app.Models.User, app.Models.Settings, app.Models.Notification, app.Models.Evidence, app.Models.Audit)
+	tradeHandler.Quota = attachmentQuota
+	attachmentHandler := handlers.NewAttachmentHandler(app.Models.Attachment, app.Models.Income, app.Models.Expense, app.Attachments)
+	attachmentHandler.Quota = attachmentQuota
+	attachmentHandler.DueDiligenceRepo = app.Models.DueDiligence
+	attachmentHandler.AssayRepo = app.Models.Assay
+	regulatorReportHandler := handlers.NewRegulatorReportHandler(app.Models.MineSite, app.Models.Income, app.Models.Inventory,
+		app.Models.Employee, app.Models.Contractor, app.Models.Settings, app.Models.User)
+	lotSealHandler := handlers.NewLotSealHandler(app.Models.LotSeal, app.Models.Income, app.Models.Inventory, app.Models.MineSite, app.Models.User)
+	lotSealHandler.BaseURL = shareLinkHandler.BaseURL
+	shareLinkHandler.SealRepo = app.Models.LotSeal
+	receiptHandler.SealRepo = app.Models.LotSeal
+	dueDiligenceHandler := handlers.NewDueDiligenceHandler(app.Models.DueDiligence, app.Models.MineSite, app.Models.Attachment, app.Models.User)
+	assayHandler := handlers.NewAssayHandler(app.Models.Assay, app.Models.Inventory, app.Models.Income, app.Models.Attachment)
+	purchaseHandler := handlers.NewPurchaseHandler(app.Models.Purchase, app.Models.Inventory, app.Models.Payment, app.Models.MineSite)
+	purchaseHandler.MinerRepo = app.Models.Miner
+	purchaseHandler.MarketPriceRepo = app.Models.MarketPrice
+	minerHandler := handlers.NewMinerHandler(app.Models.Miner, app.Models.Purchase, app.Models.MineSite)
+	marketPriceHandler := handlers.NewMarketPriceHandler(app.Models.MarketPrice, app.Models.MineSite)
+	cashDayHandler := handlers.NewCashDayHandler(app.Models.CashDay, app.Models.MineSite, app.Models.Notification)
+	cashDayHandler.TillRepo = app.Models.Till
+	tillHandler := handlers.NewTillHandler(app.Models.Till, app.Models.MineSite, app.Models.Organization)
+	shiftHandoverHandler := handlers.NewShiftHandoverHandler(app.Models.Handover, app.Models.CashDay, app.Models.Till,
+		app.Models.MineSite, app.Models.Organization, app.Models.Audit)
+	creditNoteHandler := handlers.NewCreditNoteHandler(app.Models.CreditNote, app.Models.Settings)
+	documentTemplateHandler := handlers.NewDocumentTemplateHandler(app.Models.Template, app.Attachments)
+	receiptHandler.TemplateRepo = app.Models.Template
+	receiptHandler.Store = app.Attachments
+	shareLinkHandler.TemplateRepo = app.Models.Template
+	shareLinkHandler.Store = app.Attachments
+	incomeHandler.TillRepo = app.Models.Till
+	expenseHandler.TillRepo = app.Models.Till
+	purchaseHandler.TillRepo = app.Models.Till
+	incomeHandler.AuditRepo = app.Models.Audit
+	expenseHandler.SettingsRepo = app.Models.Settings
+	expenseHandler.AuditRepo = app.Models.Audit
+	expenseHandler.SupplyPriceRepo = app.Models.SupplyPrice
+	attachmentHandler.MinerRepo = app.Models.Miner
+	exportHandler.MinerRepo = app.Models.Miner
+	claimHandler := handlers.NewClaimHandler(app.Models.Claim, app.Models.Payment)
+	claimHandler.MineSiteRepo = app.Models.MineSite
+	claimHandler.TillRepo = app.Models.Till
+	claimHandler.SettingsRepo = app.Models.Settings
+	attachmentHandler.ClaimRepo = app.Models.Claim
+	equipmentHandler := handlers.NewEquipmentHandler(app.Models.Equipment, app.Models.Expense)
+	equipmentHandler.MineSiteRepo = app.Models.MineSite
+	equipmentHandler.AuditRepo = app.Models.Audit
+	insuranceHandler := handlers.NewInsuranceHandler(app.Models.Insurance, app.Models.Equipment, app.Models.Vehicle)
+	insuranceHandler.MineSiteRepo = app.Models.MineSite
+	supplyPriceHandler := handlers.NewSupplyPriceHandler(app.Models.SupplyPrice)
+	procurementHandler := handlers.NewProcurementHandler(app.Models.Procurement)
+	procurementHandler.MineSiteRepo = app.Models.MineSite
+	procurementHandler.InventoryRepo = app.Models.Inventory
+	procurementHandler.SettingsRepo = app.Models.Settings
+	costAllocationHandler := handlers.NewCostAllocationHandler(app.Models.Allocation, app.Models.MineSite)
+
+	// Setup routes
+	router := routes.SetupRoutes(routes.Handlers{
+		Auth:             authHandler,
+		Income:           incomeHandler,
+		Expense:          expenseHandler,
+		Inventory:        inventoryHandler,
+		Analytics:        analyticsHandler,
+		MineSite:         mineSiteHandler,
+		Stocktake:        stocktakeHandler,
+		Notification:     notificationHandler,
+		Transport:        transportHandler,
+		Contractor:       contractorHandler,
+		Employee:         employeeHandler,
+		Timesheet:        timesheetHandler,
+		Payroll:          payrollHandler,
+		Settings:         settingsHandler,
+		Organization:     organizationHandler,
+		Export:           exportHandler,
+		Audit:            auditHandler,
+		ShareLink:        shareLinkHandler,
+		Receipt:          receiptHandler,
+		Dunning:          dunningHandler,
+		Task:             taskHandler,
+		Calendar:         calendarHandler,
+		Attendance:       attendanceHandler,
+		Evidence:         evidenceHandler,
+		BulkSMS:          bulkSMSHandler,
+		Contact:          contactHandler,
+		CreditLimit:      creditLimitHandler,
+		Flag:             flagHandler,
+		Benchmark:        benchmarkHandler,
+		Reference:        referenceHandler,
+		Form:             formHandler,
+		Trade:            tradeHandler,
+		Webhook:          webhookHandler,
+		Backup:           backupHandler,
+		Usage:            usageHandler,
+		Subscription:     subscriptionHandler,
+		Referral:         referralHandler,
+		Support:          supportHandler,
+		Metrics:          metricsHandler,
+		Archive:          archiveHandler,
+		Stream:           streamHandler,
+		Attachment:       attachmentHandler,
+		RegulatorReport:  regulatorReportHandler,
+		DueDiligence:     dueDiligenceHandler,
+		LotSeal:          lotSealHandler,
+		Assay:            assayHandler,
+		Purchase:         purchaseHandler,
+		Miner:            minerHandler,
+		MarketPrice:      marketPriceHandler,
+		CashDay:          cashDayHandler,
+		Till:             tillHandler,
+		ShiftHandover:    shiftHandoverHandler,
+		CreditNote:       creditNoteHandler,
+		DocumentTemplate: documentTemplateHandler,
+		Claim:            claimHandler,
+		Equipment:        equipmentHandler,
+		Insurance:        insuranceHandler,
+		SupplyPrice:      supplyPriceHandler,
+		Procurement:      procurementHandler,
+		CostAllocation:   costAllocationHandler,
+	})
+
+	// Run background work here unless a separate worker process does
+	if os.Getenv("RUN_WORKER") != "false" {
+		app.startWorker()
+	}
+
+	// Create server
+	server := &http.Server{
+		Addr:              getEnv("HTTP_ADDR", ":9006"),
+		Handler:           router,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       120 * time.Second,
+		MaxHeaderBytes:    64 << 10,
+	}
+	certFile, keyFile, redirect, useTLS := app.configureTLS(server)
+
+	// Start server in a goroutine
+	go func() {
+		var err error
+		if useTLS {
+			app.InfoLog.Printf("Starting HTTPS server on port %s", server.Addr)
+			err = server.ListenAndServeTLS(certFile, keyFile)
+		} else {
+			app.InfoLog.Printf("Starting server on port %s", server.Addr)
+			err = server.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			app.ErrorLog.Fatalf("Server failed to start: %v", err)
+		}
+	}()
+	if redirect != nil {
+		go func() {
+			app.InfoLog.Printf("Starting ACME challenge and HTTPS redirect server on port %s", redirect.Addr)
+			if err := redirect.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				app.ErrorLog.Fatalf("Redirect server failed to start: %v", err)
+			}
+		}()
+	}
+
+	// Wait for interrupt signal to gracefully shutdown the server
+	waitForShutdown()
+
+	app.InfoLog.Println("Server is shutting down...")
+
+	// Graceful shutdown, letting requests in flight finish
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		app.ErrorLog.Fatalf("Server forced to shutdown: %v", err)
+	}
+	if redirect != nil {
+		redirect.Shutdown(ctx)
+	}
+
+	// Stop background jobs
+	if app.Scheduler != nil {
+		app.Scheduler.Stop()
+	}
+	app.Wait.Wait()
+	app.stopTracing()
+
+	app.InfoLog.Println("Server exited")
+}
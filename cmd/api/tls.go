@@ -0,0 +1,62 @@
+package main
+
+import (
+	"crypto/tls"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// configureTLS sets the server up to terminate TLS itself, for deployments without a reverse
+// proxy in front, with either a certificate from files or certificates issued by Let's Encrypt:
+//
+//	TLS_CERT_FILE, TLS_KEY_FILE  PEM certificate chain and private key
+//	TLS_ACME_DOMAINS             comma separated domains to request certificates for
+//	TLS_ACME_EMAIL               contact address for the ACME account
+//	TLS_ACME_CACHE_DIR           where issued certificates are kept across restarts
+//
+// It returns the certificate files to serve with, empty with ACME, and with ACME a plain HTTP
+// server answering the ACME challenges and redirecting everything else to HTTPS. ok is false
+// when TLS isn't configured and the server serves plain HTTP.
+func (app *Config) configureTLS(server *http.Server) (certFile, keyFile string, redirect *http.Server, ok bool) {
+	certFile, keyFile = os.Getenv("TLS_CERT_FILE"), os.Getenv("TLS_KEY_FILE")
+	domains := os.Getenv("TLS_ACME_DOMAINS")
+	if certFile == "" && keyFile == "" && domains == "" {
+		return "", "", nil, false
+	}
+
+	server.Addr = getEnv("TLS_ADDR", ":443")
+	server.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			app.ErrorLog.Fatal("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+		}
+		return certFile, keyFile, nil, true
+	}
+
+	var hosts []string
+	for _, domain := range strings.Split(domains, ",") {
+		if domain = strings.TrimSpace(domain); domain != "" {
+			hosts = append(hosts, domain)
+		}
+	}
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(hosts...),
+		Cache:      autocert.DirCache(getEnv("TLS_ACME_CACHE_DIR", "acme-cache")),
+		Email:      os.Getenv("TLS_ACME_EMAIL"),
+	}
+	server.TLSConfig = manager.TLSConfig()
+	server.TLSConfig.MinVersion = tls.VersionTLS12
+
+	redirect = &http.Server{
+		Addr:              getEnv("TLS_HTTP_ADDR", ":80"),
+		Handler:           manager.HTTPHandler(nil),
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       30 * time.Second,
+	}
+	return "", "", redirect, true
+}
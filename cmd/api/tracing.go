@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// initTracing enables tracing when an OTLP endpoint is configured, with the standard
+// OpenTelemetry environment variables:
+//
+//	OTEL_EXPORTER_OTLP_TRACES_ENDPOINT  the OTLP/HTTP traces URL, e.g. http://collector:4318/v1/traces
+//	OTEL_EXPORTER_OTLP_ENDPOINT         the collector base URL, used when the above is unset
+//	OTEL_EXPORTER_OTLP_HEADERS          headers sent with every export, e.g. x-api-key=secret
+//	OTEL_SERVICE_NAME                   the service name spans are exported under
+//	OTEL_TRACES_SAMPLER_ARG             the fraction of new traces recorded, 1 by default
+//
+// Requests that arrive with a trace keep the caller's sampling decision.
+func (app *Config) initTracing() {
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")
+	if endpoint == "" {
+		endpoint = os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	}
+	if endpoint == "" {
+		return
+	}
+
+	ratio := 1.0
+	if arg := os.Getenv("OTEL_TRACES_SAMPLER_ARG"); arg != "" {
+		parsed, err := strconv.ParseFloat(arg, 64)
+		if err != nil || parsed < 0 || parsed > 1 {
+			app.ErrorLog.Fatalf("Invalid OTEL_TRACES_SAMPLER_ARG %q, must be between 0 and 1", arg)
+		}
+		ratio = parsed
+	}
+
+	// The exporter reads the endpoint and headers from the environment itself
+	exporter, err := otlptracehttp.New(context.Background())
+	if err != nil {
+		app.ErrorLog.Fatalf("Failed to create trace exporter: %v", err)
+	}
+	service := resource.NewSchemaless(semconv.ServiceName(getEnv("OTEL_SERVICE_NAME", "mining-finance-backend")))
+	res, err := resource.Merge(resource.Default(), service)
+	if err != nil {
+		app.ErrorLog.Fatalf("Failed to describe the traced service: %v", err)
+	}
+
+	app.Tracing = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	otel.SetTracerProvider(app.Tracing)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	app.InfoLog.Printf("Tracing enabled, exporting %.0f%% of traces to %s", ratio*100, endpoint)
+}
+
+// stopTracing sends the spans still queued for export
+func (app *Config) stopTracing() {
+	if app.Tracing == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := app.Tracing.Shutdown(ctx); err != nil {
+		app.ErrorLog.Printf("Failed to export the remaining trace spans: %v", err)
+	}
+}
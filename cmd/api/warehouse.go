@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// The transactions dataset exported to the analytics warehouse. Bump transactionsSchemaVersion
+// when the columns change: files of the new version go under a new prefix and every tenant is
+// exported again from scratch, so warehouse tables never mix layouts.
+const (
+	transactionsDataset       = "transactions"
+	transactionsSchemaVersion = 1
+)
+
+// transactionRow is a row of the transactions dataset, laid out as its Parquet columns
+type transactionRow struct {
+	TransactionType string    `parquet:"transaction_type"`
+	TransactionID   int64     `parquet:"transaction_id"`
+	TenantID        int64     `parquet:"tenant_id"`
+	Organization    *string   `parquet:"organization,optional"`
+	Currency        string    `parquet:"currency"`
+	Date            time.Time `parquet:"date,timestamp(millisecond)"`
+	Category        string    `parquet:"category"`
+	Item            *string   `parquet:"item,optional"`
+	Counterparty    string    `parquet:"counterparty"`
+	Quantity        *float64  `parquet:"quantity,optional"`
+	Unit            *string   `parquet:"unit,optional"`
+	Amount          float64   `parquet:"amount"`
+	AmountPaid      float64   `parquet:"amount_paid"`
+	AmountDue       float64   `parquet:"amount_due"`
+	PaymentStatus   string    `parquet:"payment_status"`
+	CreatedAt       time.Time `parquet:"created_at,timestamp(millisecond)"`
+	UpdatedAt       time.Time `parquet:"updated_at,timestamp(millisecond)"`
+	DeletedAt       int64     `parquet:"deleted_at,optional,timestamp(millisecond)"` // Unix milliseconds, null unless deleted
+}
+
+// writeTransactions writes rows of the transactions dataset as a gzip compressed Parquet file,
+// with metadata as the file's key-value metadata
+func writeTransactions(w io.Writer, rows []transactionRow, metadata map[string]string) error {
+	options := []parquet.WriterOption{parquet.Compression(&parquet.Gzip)}
+	for key, value := range metadata {
+		options = append(options, parquet.KeyValueMetadata(key, value))
+	}
+	writer := parquet.NewGenericWriter[transactionRow](w, options...)
+	if _, err := writer.Write(rows); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+const (
+	// warehouseFileRows is the most rows written to one Parquet file
+	warehouseFileRows = 50000
+	// warehouseExportLag keeps the most recent changes for the next export, so transactions
+	// committing while an export runs aren't skipped
+	warehouseExportLag = time.Minute
+)
+
+// exportWarehouse exports every tenant's transactions changed since their watermark to the
+// warehouse bucket
+func (app *Config) exportWarehouse(ctx context.Context) error {
+	tenants, err := app.Models.Warehouse.GetTenants(ctx)
+	if err != nil {
+		return err
+	}
+	until := time.Now().Add(-warehouseExportLag)
+	for _, tenantID := range tenants {
+		if err := app.exportTenantTransactions(ctx, tenantID, until); err != nil {
+			return fmt.Errorf("tenant %d: %w", tenantID, err)
+		}
+	}
+	return nil
+}
+
+// exportTenantTransactions writes a tenant's changed transactions as Parquet files under
+// <prefix>transactions/v<version>/tenant_id=<id>/, advancing the watermark after each file. A
+// file that uploaded but whose watermark wasn't saved is exported again, and an updated or deleted
+// transaction is exported again in a later file, so the warehouse should keep the row per
+// transaction_type and transaction_id from the latest file.
+func (app *Config) exportTenantTransactions(ctx context.Context, tenantID uint, until time.Time) error {
+	watermark, err := app.Models.Warehouse.GetWatermark(ctx, tenantID, transactionsDataset, transactionsSchemaVersion)
+	if err != nil {
+		return err
+	}
+
+	for {
+		transactions, err := app.Models.Warehouse.GetTransactions(ctx, tenantID, watermark, until, warehouseFileRows)
+		if err != nil {
+			return err
+		}
+		if len(transactions) == 0 {
+			return nil
+		}
+
+		rows := make([]transactionRow, len(transactions))
+		for i, t := range transactions {
+			rows[i] = transactionRow{
+				TransactionType: string(t.TransactionType),
+				TransactionID:   int64(t.TransactionID),
+				TenantID:        int64(t.TenantID),
+				Organization:    t.Organization,
+				Currency:        t.Currency,
+				Date:            t.Date.UTC(),
+				Category:        t.Category,
+				Item:            t.Item,
+				Counterparty:    t.Counterparty,
+				Quantity:        t.Quantity,
+				Unit:            t.Unit,
+				Amount:          t.Amount,
+				AmountPaid:      t.AmountPaid,
+				AmountDue:       t.AmountDue,
+				PaymentStatus:   t.PaymentStatus,
+				CreatedAt:       t.CreatedAt.UTC(),
+				UpdatedAt:       t.UpdatedAt.UTC(),
+			}
+			if t.DeletedAt != nil {
+				rows[i].DeletedAt = t.DeletedAt.UnixMilli()
+			}
+		}
+		var file bytes.Buffer
+		err = writeTransactions(&file, rows, map[string]string{
+			"dataset":        transactionsDataset,
+			"schema_version": strconv.Itoa(transactionsSchemaVersion),
+			"tenant_id":      strconv.FormatUint(uint64(tenantID), 10),
+		})
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		key := fmt.Sprintf("%s%s/v%d/tenant_id=%d/%s-%d.parquet", app.WarehousePrefix, transactionsDataset,
+			transactionsSchemaVersion, tenantID, now.Format("20060102T150405Z"), watermark.Files+1)
+		if err := app.WarehouseUploader.Upload(key, &file, int64(file.Len())); err != nil {
+			return err
+		}
+
+		last := transactions[len(transactions)-1]
+		watermark.WatermarkAt = last.ChangedAt()
+		watermark.WatermarkType = string(last.TransactionType)
+		watermark.WatermarkID = last.TransactionID
+		watermark.ExportedRows += int64(len(transactions))
+		watermark.Files++
+		watermark.LastExportAt = &now
+		if err := app.Models.Warehouse.SaveWatermark(ctx, watermark); err != nil {
+			return err
+		}
+
+		if len(transactions) < warehouseFileRows {
+			return nil
+		}
+	}
+}
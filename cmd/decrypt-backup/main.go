@@ -0,0 +1,27 @@
+// Command decrypt-backup decrypts a database backup read from stdin to stdout with the key in
+// BACKUP_ENCRYPTION_KEY, e.g.
+//
+//	decrypt-backup < 20240101T020000Z.dump.enc | pg_restore -d mining_data
+package main
+
+import (
+	"bufio"
+	"log"
+	"mineral/pkg/backup"
+	"os"
+)
+
+func main() {
+	key, err := backup.ParseKey(os.Getenv("BACKUP_ENCRYPTION_KEY"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	if err := backup.Decrypt(out, bufio.NewReader(os.Stdin), key); err != nil {
+		log.Fatal(err)
+	}
+	if err := out.Flush(); err != nil {
+		log.Fatal(err)
+	}
+}
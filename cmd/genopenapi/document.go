@@ -0,0 +1,341 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// pathParam matches the parameters of a route pattern, e.g. {id} or {token:[a-z]+}
+var pathParam = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+// handlerFunc is the function handling a route
+type handlerFunc struct {
+	tag   string
+	name  string
+	doc   string
+	scope *scope
+}
+
+// document returns the OpenAPI document of the routes of SetupRoutes
+func (prog *program) document() (schema, error) {
+	routes, setup, err := prog.routes()
+	if err != nil {
+		return nil, err
+	}
+	routesPkg := prog.packages[prog.module+"/routes"]
+	params := prog.newScope(routesPkg, nil, setup.Type, nil)
+
+	handlers := make([]*handlerFunc, len(routes))
+	names := map[string]int{}
+	for i, rt := range routes {
+		h, err := prog.handlerFunc(params, rt)
+		if err != nil {
+			return nil, err
+		}
+		handlers[i] = h
+		names[h.name]++
+	}
+
+	paths := schema{}
+	var tags []interface{}
+	seenTags := map[string]bool{}
+	for i, rt := range routes {
+		h := handlers[i]
+		operationID := lowerFirst(h.name)
+		if names[h.name] > 1 {
+			operationID = lowerFirst(strings.ReplaceAll(h.tag, " ", "")) + h.name
+		}
+		if !seenTags[h.tag] {
+			seenTags[h.tag] = true
+			tags = append(tags, schema{"name": h.tag})
+		}
+		pattern := pathParam.ReplaceAllString(rt.path, "{$1}")
+		item, _ := paths[pattern].(schema)
+		if item == nil {
+			item = schema{}
+			paths[pattern] = item
+		}
+		item[rt.method] = prog.operation(rt, h, operationID)
+	}
+
+	prog.schemaOf(&typeRef{expr: &ast.Ident{Name: "Pagination"}, pkg: prog.packages[prog.module+"/pkg/utils"]})
+	prog.schemas["ErrorResponse"] = schema{
+		"type": "object",
+		"properties": schema{
+			"success":    schema{"type": "boolean", "example": false},
+			"error":      schema{"type": "string"},
+			"request_id": schema{"type": "string", "description": "ID of the request in the server logs"},
+			"data":       schema{"description": "Details of the error, for some errors"},
+		},
+	}
+
+	return schema{
+		"openapi": "3.0.3",
+		"info": schema{
+			"title":       "Mining Finance System API",
+			"description": "API for managing mining finance operations including income tracking, expense management, inventory control, and financial analytics. Responses are wrapped in an envelope with success, message and data; errors carry the error message and the request ID.",
+			"version":     "1.0.0",
+		},
+		"tags":  tags,
+		"paths": paths,
+		"components": schema{
+			"schemas": prog.schemas,
+			"responses": schema{
+				"Error": schema{
+					"description": "Error",
+					"content":     schema{"application/json": schema{"schema": schema{"$ref": "#/components/schemas/ErrorResponse"}}},
+				},
+			},
+			"parameters": schema{
+				"OrganizationID": schema{
+					"name":        "X-Organization-ID",
+					"in":          "header",
+					"description": "ID of the organization whose books the request works on, instead of the user's own",
+					"schema":      schema{"type": "integer"},
+				},
+			},
+			"securitySchemes": schema{
+				"bearerAuth": schema{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+		},
+	}, nil
+}
+
+// handlerFunc returns the function handling a route: a handler method, a function of the routes
+// package or a function literal
+func (prog *program) handlerFunc(params *scope, rt *route) (*handlerFunc, error) {
+	routesPkg := params.pkg
+	switch h := rt.handler.(type) {
+	case *ast.SelectorExpr:
+		recv := params.infer(h.X)
+		_, decl, p := prog.method(recv, h.Sel.Name)
+		if decl == nil {
+			break
+		}
+		tag := strings.TrimSuffix(receiverName(selectorName(recv.expr)), "Handler")
+		return &handlerFunc{tag: splitWords(tag), name: decl.Name.Name, doc: decl.Doc.Text(), scope: prog.scopeOf(p, decl)}, nil
+	case *ast.Ident:
+		decl := routesPkg.funcs[h.Name]
+		if decl == nil {
+			break
+		}
+		return &handlerFunc{tag: "System", name: decl.Name.Name, doc: decl.Doc.Text(), scope: prog.scopeOf(routesPkg, decl)}, nil
+	case *ast.FuncLit:
+		name := rt.method
+		for _, part := range strings.Split(rt.path, "/") {
+			if part != "" && !strings.HasPrefix(part, "{") {
+				name += upperFirst(part)
+			}
+		}
+		return &handlerFunc{tag: "System", name: upperFirst(name), scope: prog.newScope(routesPkg, nil, h.Type, h.Body)}, nil
+	}
+	return nil, fmt.Errorf("%s %s: handler %T not found", strings.ToUpper(rt.method), rt.path, rt.handler)
+}
+
+// operation returns the OpenAPI operation of a route
+func (prog *program) operation(rt *route, h *handlerFunc, operationID string) schema {
+	op := prog.analyze(h.scope)
+	result := schema{
+		"tags":        []string{h.tag},
+		"operationId": operationID,
+	}
+	summary, description := summarize(h.name, h.doc)
+	if summary == "" {
+		summary = strings.ToUpper(rt.method) + " " + rt.path
+	}
+	result["summary"] = summary
+	if requirements := rt.requirements(); requirements != "" {
+		description = strings.TrimSpace(description + "\n\n" + requirements)
+	}
+	if description != "" {
+		result["description"] = description
+	}
+
+	var parameters []interface{}
+	for _, match := range pathParam.FindAllStringSubmatch(rt.path, -1) {
+		parameters = append(parameters, schema{"name": match[1], "in": "path", "required": true, "schema": schema{"type": "string"}})
+	}
+	for _, name := range op.query {
+		s := schema{"type": "string"}
+		if name == "page" || name == "per_page" {
+			s = schema{"type": "integer", "minimum": 1}
+		}
+		parameters = append(parameters, schema{"name": name, "in": "query", "schema": s})
+	}
+	if rt.auth {
+		parameters = append(parameters, schema{"$ref": "#/components/parameters/OrganizationID"})
+		result["security"] = []interface{}{schema{"bearerAuth": []string{}}}
+	}
+	if len(parameters) > 0 {
+		result["parameters"] = parameters
+	}
+
+	if body := prog.requestBody(op); body != nil {
+		result["requestBody"] = body
+		op.errors[400] = true
+		op.errors[413] = true
+	}
+
+	responses := schema{}
+	status := op.status
+	if status == 0 {
+		status = 200
+	}
+	responses[strconv.Itoa(status)] = prog.successResponse(op, status)
+	for _, code := range rt.errorStatuses(op.errors) {
+		responses[strconv.Itoa(code)] = schema{"$ref": "#/components/responses/Error"}
+	}
+	result["responses"] = responses
+	return result
+}
+
+// requestBody returns the request body of an operation, or nil when it has none
+func (prog *program) requestBody(op *operation) schema {
+	content := schema{}
+	if op.body != nil {
+		content["application/json"] = schema{"schema": prog.schemaOf(op.body)}
+	}
+	if len(op.form) > 0 {
+		properties := schema{}
+		for _, name := range op.form {
+			properties[name] = schema{"type": "string"}
+		}
+		content["application/x-www-form-urlencoded"] = schema{"schema": schema{"type": "object", "properties": properties}}
+	}
+	if op.rawBody && len(content) == 0 {
+		content["application/octet-stream"] = schema{"schema": schema{"type": "string", "format": "binary"}}
+	}
+	if len(content) == 0 {
+		return nil
+	}
+	return schema{"required": true, "content": content}
+}
+
+// successResponse returns the successful response of an operation
+func (prog *program) successResponse(op *operation, status int) schema {
+	response := schema{"description": "Success"}
+	content := schema{}
+	for _, contentType := range op.contentTypes {
+		if contentType == "application/json" {
+			content[contentType] = schema{"schema": schema{"type": "object"}}
+			continue
+		}
+		content[contentType] = schema{"schema": schema{"type": "string", "format": "binary"}}
+	}
+	if op.responds {
+		properties := schema{
+			"success": schema{"type": "boolean", "example": true},
+			"message": schema{"type": "string"},
+			"data":    prog.schemaOf(op.data),
+		}
+		if op.data == nil {
+			properties["data"] = schema{"nullable": true}
+		}
+		if op.paginated {
+			properties["pagination"] = describe(schema{"$ref": "#/components/schemas/Pagination"}, "Only when the request sets the page or per_page query parameters")
+		}
+		content["application/json"] = schema{"schema": schema{"type": "object", "properties": properties}}
+	}
+	if status >= 300 && status < 400 {
+		response["description"] = "Redirect"
+	}
+	if len(content) > 0 {
+		response["content"] = content
+	}
+	return response
+}
+
+// requirements describes what the middleware in front of a route requires beyond authentication
+func (g guard) requirements() string {
+	var sentences []string
+	if g.admin {
+		sentences = append(sentences, "Requires an admin account.")
+	}
+	for _, permission := range g.permissions {
+		sentences = append(sentences, fmt.Sprintf("Requires the `%s` permission in the organization.", permission))
+	}
+	for _, feature := range g.features {
+		sentences = append(sentences, fmt.Sprintf("Requires the `%s` feature of the organization's plan.", feature))
+	}
+	if g.usageLimit {
+		sentences = append(sentences, "Counts against the plan's usage limits.")
+	}
+	return strings.Join(sentences, " ")
+}
+
+// errorStatuses returns the statuses of the error responses of a route, sorted
+func (g guard) errorStatuses(handler map[int]bool) []int {
+	statuses := map[int]bool{}
+	for status := range handler {
+		statuses[status] = true
+	}
+	if g.auth {
+		statuses[401] = true
+		statuses[403] = true
+	}
+	if g.admin || len(g.permissions) > 0 {
+		statuses[403] = true
+	}
+	if len(g.features) > 0 || g.usageLimit {
+		statuses[402] = true
+	}
+	if g.rateLimited {
+		statuses[429] = true
+	}
+	if g.upload {
+		statuses[413] = true
+	}
+	codes := make([]int, 0, len(statuses))
+	for status := range statuses {
+		codes = append(codes, status)
+	}
+	sort.Ints(codes)
+	return codes
+}
+
+// summarize splits the doc comment of a handler into a summary, its first sentence, and a
+// description of the rest, dropping the handler's name the comment starts with
+func summarize(name, doc string) (string, string) {
+	doc = oneLine(doc)
+	if doc == "" {
+		return "", ""
+	}
+	if rest, ok := strings.CutPrefix(doc, name+" "); ok {
+		doc = upperFirst(rest)
+	}
+	summary, description, _ := strings.Cut(doc, ". ")
+	return strings.TrimSuffix(summary, "."), description
+}
+
+// splitWords splits a camel-case name into words, keeping acronyms together, e.g. BulkSMS into
+// Bulk SMS
+func splitWords(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			b.WriteRune(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
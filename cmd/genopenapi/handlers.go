@@ -0,0 +1,244 @@
+package main
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+// maxCallDepth bounds how deep helpers the request is passed to are followed
+const maxCallDepth = 4
+
+// utilsResponses are the statuses of the error responses written by the utils package
+var utilsResponses = map[string]int{
+	"WriteValidationError":     400,
+	"WriteUnauthorizedError":   401,
+	"WriteForbiddenError":      403,
+	"WriteNotFoundError":       404,
+	"WriteInternalServerError": 500,
+}
+
+// statusCodes are the codes of the net/http status constants
+var statusCodes = map[string]int{
+	"StatusOK": 200, "StatusCreated": 201, "StatusAccepted": 202, "StatusNoContent": 204,
+	"StatusMovedPermanently": 301, "StatusFound": 302, "StatusSeeOther": 303, "StatusNotModified": 304,
+	"StatusTemporaryRedirect": 307, "StatusBadRequest": 400, "StatusUnauthorized": 401,
+	"StatusPaymentRequired": 402, "StatusForbidden": 403, "StatusNotFound": 404,
+	"StatusMethodNotAllowed": 405, "StatusConflict": 409, "StatusGone": 410,
+	"StatusRequestEntityTooLarge": 413, "StatusUnsupportedMediaType": 415,
+	"StatusUnprocessableEntity": 422, "StatusLocked": 423, "StatusUpgradeRequired": 426, "StatusTooManyRequests": 429,
+	"StatusInternalServerError": 500, "StatusNotImplemented": 501, "StatusBadGateway": 502,
+	"StatusServiceUnavailable": 503,
+}
+
+// operation is what a handler and the helpers it passes the request to do with it
+type operation struct {
+	body         *typeRef     // value the JSON body is decoded into
+	rawBody      bool         // whether the body is read as is
+	form         []string     // form fields read
+	query        []string     // query parameters read
+	data         *typeRef     // data of the success response
+	responds     bool         // whether a JSON success response is written
+	paginated    bool         // whether the success response can be a page of a list
+	status       int          // status of the success response, when it isn't 200
+	contentTypes []string     // content types of responses written without the utils package
+	errors       map[int]bool // statuses of error responses
+
+	seen    map[*ast.FuncDecl]bool
+	decoded map[ast.Expr]bool // bodies decoded as JSON
+}
+
+// analyze collects what a handler does with the request
+func (prog *program) analyze(s *scope) *operation {
+	op := &operation{errors: map[int]bool{}, seen: map[*ast.FuncDecl]bool{}, decoded: map[ast.Expr]bool{}}
+	prog.analyzeScope(op, s, 0)
+	return op
+}
+
+func (prog *program) analyzeScope(op *operation, s *scope, depth int) {
+	if s.body == nil {
+		return
+	}
+	utils := prog.module + "/pkg/utils"
+	ast.Inspect(s.body, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if sel.Sel.Name == "Body" && !op.decoded[sel] && s.infer(sel.X).String() == "*http.Request" {
+				op.rawBody = true
+			}
+			return true
+		}
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, _ := call.Fun.(*ast.SelectorExpr)
+		if sel != nil {
+			if ident, ok := sel.X.(*ast.Ident); ok && s.pkg.imports[ident.Name] == utils && s.lookup(ident.Name, ident.Pos()) == nil {
+				if prog.utilsResponse(op, s, sel.Sel.Name, call) {
+					return true
+				}
+			}
+			if prog.requestUse(op, s, sel, call) {
+				return true
+			}
+		}
+		if depth < maxCallDepth && passesRequest(s, call) {
+			if decl, p := prog.callee(s, call); decl != nil && !op.seen[decl] {
+				op.seen[decl] = true
+				prog.analyzeScope(op, prog.scopeOf(p, decl), depth+1)
+			}
+		}
+		return true
+	})
+}
+
+// utilsResponse records a response written by the utils package, reporting whether it was one
+func (prog *program) utilsResponse(op *operation, s *scope, name string, call *ast.CallExpr) bool {
+	if status, ok := utilsResponses[name]; ok {
+		op.errors[status] = true
+		return true
+	}
+	switch name {
+	case "WriteSuccessResponse", "WriteListResponse", "WritePaginatedResponse":
+		if len(call.Args) >= 3 && (!op.responds || op.data == nil) {
+			op.data = s.infer(call.Args[2])
+		}
+		op.responds = true
+		op.paginated = op.paginated || name != "WriteSuccessResponse"
+		return true
+	case "WriteErrorResponse", "WriteErrorResponseWithData":
+		if len(call.Args) >= 3 {
+			if status := statusCode(call.Args[2]); status != 0 {
+				op.errors[status] = true
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// requestUse records a use of the request or the response writer, reporting whether it was one
+func (prog *program) requestUse(op *operation, s *scope, sel *ast.SelectorExpr, call *ast.CallExpr) bool {
+	switch sel.Sel.Name {
+	case "Decode":
+		decoder, ok := sel.X.(*ast.CallExpr)
+		if !ok || types.ExprString(decoder.Fun) != "json.NewDecoder" || len(call.Args) != 1 {
+			return false
+		}
+		for _, arg := range decoder.Args {
+			op.decoded[arg] = true
+		}
+		if op.body == nil {
+			op.body = deref(s.infer(call.Args[0]))
+		}
+		return true
+	case "Get":
+		if len(call.Args) != 1 || literal(call.Args[0]) == "" {
+			return false
+		}
+		if !strings.HasSuffix(types.ExprString(sel.X), "URL.Query()") && s.infer(sel.X).String() != "url.Values" {
+			return false
+		}
+		op.query = appendUnique(op.query, literal(call.Args[0]))
+		return true
+	case "FormValue", "PostFormValue":
+		if len(call.Args) == 1 && literal(call.Args[0]) != "" {
+			op.form = appendUnique(op.form, literal(call.Args[0]))
+			return true
+		}
+	case "Set":
+		if len(call.Args) != 2 || literal(call.Args[0]) != "Content-Type" || !strings.HasSuffix(types.ExprString(sel.X), "Header()") {
+			return false
+		}
+		contentType := strings.TrimSpace(strings.Split(literal(call.Args[1]), ";")[0])
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		op.contentTypes = appendUnique(op.contentTypes, contentType)
+		return true
+	case "WriteHeader":
+		if len(call.Args) != 1 {
+			return false
+		}
+		switch status := statusCode(call.Args[0]); {
+		case status >= 400:
+			op.errors[status] = true
+		case status > 200:
+			op.status = status
+		}
+		return true
+	case "Redirect":
+		if types.ExprString(sel.X) == "http" && len(call.Args) == 4 {
+			op.status = statusCode(call.Args[3])
+			return true
+		}
+	}
+	return false
+}
+
+// passesRequest reports whether a call is passed the request or the response writer
+func passesRequest(s *scope, call *ast.CallExpr) bool {
+	for _, arg := range call.Args {
+		switch s.infer(arg).String() {
+		case "*http.Request", "http.ResponseWriter":
+			return true
+		}
+	}
+	return false
+}
+
+// callee returns the declaration of the function of the module a call calls, if known
+func (prog *program) callee(s *scope, call *ast.CallExpr) (*ast.FuncDecl, *pkg) {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		if s.lookup(fun.Name, fun.Pos()) == nil && s.pkg.funcs[fun.Name] != nil {
+			return s.pkg.funcs[fun.Name], s.pkg
+		}
+	case *ast.SelectorExpr:
+		if p := s.importedPackage(fun.X); p != nil {
+			return p.funcs[fun.Sel.Name], p
+		}
+		if _, decl, p := prog.method(s.infer(fun.X), fun.Sel.Name); decl != nil {
+			return decl, p
+		}
+	}
+	return nil, nil
+}
+
+// statusCode returns the status code a net/http constant or an integer literal stands for
+func statusCode(expr ast.Expr) int {
+	if sel, ok := expr.(*ast.SelectorExpr); ok && types.ExprString(sel.X) == "http" {
+		return statusCodes[sel.Sel.Name]
+	}
+	if lit := types.ExprString(expr); len(lit) == 3 {
+		code := 0
+		for _, c := range lit {
+			if c < '0' || c > '9' {
+				return 0
+			}
+			code = code*10 + int(c-'0')
+		}
+		return code
+	}
+	return 0
+}
+
+// deref returns the type a pointer points to, or the type itself
+func deref(t *typeRef) *typeRef {
+	if t == nil {
+		return nil
+	}
+	if star, ok := t.expr.(*ast.StarExpr); ok {
+		return &typeRef{expr: star.X, pkg: t.pkg, lit: t.lit, scope: t.scope}
+	}
+	return t
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, existing := range list {
+		if existing == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
@@ -0,0 +1,247 @@
+// Command genopenapi generates the OpenAPI 3 document of the API from its source. Operations are
+// the routes of SetupRoutes, summarized by the doc comments of their handlers. Request bodies are
+// the values handlers decode the body into, responses the values they pass to the utils response
+// writers, and query parameters the ones they read, following the helpers they pass the request
+// to. Schemas are generated from the json tags of the types involved.
+// It is run with go generate from the routes package:
+//
+//	//go:generate go run ../cmd/genopenapi -out openapi.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	root := flag.String("root", "..", "root directory of the module")
+	module := flag.String("module", "mineral", "module path")
+	out := flag.String("out", "openapi.json", "file to write the document to")
+	flag.Parse()
+
+	prog, err := load(*root, *module)
+	if err != nil {
+		log.Fatal(err)
+	}
+	doc, err := prog.document()
+	if err != nil {
+		log.Fatal(err)
+	}
+	encoded, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, append(encoded, '\n'), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// program is the parsed source of the packages of the module
+type program struct {
+	module   string
+	packages map[string]*pkg // by import path
+
+	schemas    map[string]interface{} // component schemas by name
+	components map[string]string      // component names by qualified type name
+
+	depth int // nesting of type inference, bounded against cyclic declarations
+}
+
+// pkg indexes the declarations of a package
+type pkg struct {
+	path    string
+	imports map[string]string                   // import paths by the name they are imported as
+	types   map[string]*ast.TypeSpec            // type declarations
+	docs    map[string]string                   // doc comments of the types
+	funcs   map[string]*ast.FuncDecl            // package-level functions
+	methods map[string]map[string]*ast.FuncDecl // methods by receiver type
+	values  map[string]*value                   // package-level variables and constants
+	enums   map[string][]string                 // values of the string constants of named types
+}
+
+// value is a package-level variable or constant
+type value struct {
+	typ  ast.Expr
+	init ast.Expr
+}
+
+// load parses the non-test files of the packages of the module rooted at root, skipping commands
+func load(root, module string) (*program, error) {
+	prog := &program{
+		module:     module,
+		packages:   map[string]*pkg{},
+		schemas:    map[string]interface{}{},
+		components: map[string]string{},
+	}
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(root, func(dir string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, dir)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == "cmd" || strings.HasPrefix(rel, "cmd/") || strings.HasPrefix(entry.Name(), ".") && rel != "." {
+			return filepath.SkipDir
+		}
+		pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+			return !strings.HasSuffix(info.Name(), "_test.go")
+		}, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+		importPath := module
+		if rel != "." {
+			importPath = path.Join(module, rel)
+		}
+		for _, parsed := range pkgs {
+			prog.packages[importPath] = index(importPath, parsed)
+		}
+		return nil
+	})
+	return prog, err
+}
+
+// index indexes the declarations of a parsed package
+func index(importPath string, parsed *ast.Package) *pkg {
+	p := &pkg{
+		path:    importPath,
+		imports: map[string]string{},
+		types:   map[string]*ast.TypeSpec{},
+		docs:    map[string]string{},
+		funcs:   map[string]*ast.FuncDecl{},
+		methods: map[string]map[string]*ast.FuncDecl{},
+		values:  map[string]*value{},
+		enums:   map[string][]string{},
+	}
+	names := make([]string, 0, len(parsed.Files))
+	for name := range parsed.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		file := parsed.Files[name]
+		for _, spec := range file.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			name := importName(importPath)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			p.imports[name] = importPath
+		}
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					p.funcs[decl.Name.Name] = decl
+					continue
+				}
+				recv := receiverName(decl.Recv.List[0].Type)
+				if p.methods[recv] == nil {
+					p.methods[recv] = map[string]*ast.FuncDecl{}
+				}
+				p.methods[recv][decl.Name.Name] = decl
+			case *ast.GenDecl:
+				p.indexGenDecl(decl)
+			}
+		}
+	}
+	return p
+}
+
+func (p *pkg) indexGenDecl(decl *ast.GenDecl) {
+	for _, spec := range decl.Specs {
+		switch spec := spec.(type) {
+		case *ast.TypeSpec:
+			p.types[spec.Name.Name] = spec
+			doc := spec.Doc
+			if doc == nil && len(decl.Specs) == 1 {
+				doc = decl.Doc
+			}
+			if doc != nil {
+				p.docs[spec.Name.Name] = strings.TrimSpace(doc.Text())
+			}
+		case *ast.ValueSpec:
+			for i, name := range spec.Names {
+				v := &value{typ: spec.Type}
+				if i < len(spec.Values) {
+					v.init = spec.Values[i]
+				}
+				p.values[name.Name] = v
+
+				typ, ok := spec.Type.(*ast.Ident)
+				lit, isString := v.init.(*ast.BasicLit)
+				if decl.Tok == token.CONST && ok && isString && lit.Kind == token.STRING {
+					s, _ := strconv.Unquote(lit.Value)
+					p.enums[typ.Name] = append(p.enums[typ.Name], s)
+				}
+			}
+		}
+	}
+}
+
+// importName returns the name a package is imported as when the import isn't named, skipping
+// major version suffixes
+func importName(importPath string) string {
+	parts := strings.Split(importPath, "/")
+	name := parts[len(parts)-1]
+	if len(parts) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = parts[len(parts)-2]
+	}
+	return strings.TrimPrefix(name, "go-")
+}
+
+// receiverName returns the name of the type of a method receiver
+func receiverName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(expr.X)
+	case *ast.IndexExpr:
+		return receiverName(expr.X)
+	case *ast.Ident:
+		return expr.Name
+	}
+	return ""
+}
+
+// constString returns the value of a string constant of a package, following conversions
+func (prog *program) constString(p *pkg, expr ast.Expr) (string, bool) {
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		if expr.Kind == token.STRING {
+			s, err := strconv.Unquote(expr.Value)
+			return s, err == nil
+		}
+	case *ast.CallExpr:
+		if len(expr.Args) == 1 {
+			return prog.constString(p, expr.Args[0])
+		}
+	case *ast.Ident:
+		if v, ok := p.values[expr.Name]; ok && v.init != nil {
+			return prog.constString(p, v.init)
+		}
+	case *ast.SelectorExpr:
+		if x, ok := expr.X.(*ast.Ident); ok {
+			if other := prog.packages[p.imports[x.Name]]; other != nil {
+				return prog.constString(other, expr.Sel)
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// route is an operation registered in SetupRoutes
+type route struct {
+	method  string
+	path    string
+	handler ast.Expr
+	guard
+}
+
+// guard is what the middleware in front of a route requires of requests
+type guard struct {
+	auth        bool     // an access token
+	admin       bool     // an admin account
+	rateLimited bool     // at most so many requests per client
+	upload      bool     // a larger body for uploaded files
+	usageLimit  bool     // room in the plan limits
+	permissions []string // organization permissions
+	features    []string // plan features
+}
+
+// with returns the guard with the requirements of middleware added
+func (g guard) with(prog *program, locals map[string]ast.Expr, routes *pkg, middleware []ast.Expr) guard {
+	g.permissions = append([]string(nil), g.permissions...)
+	g.features = append([]string(nil), g.features...)
+	for _, mw := range middleware {
+		if ident, ok := mw.(*ast.Ident); ok && locals[ident.Name] != nil {
+			mw = locals[ident.Name]
+		}
+		call, _ := mw.(*ast.CallExpr)
+		switch name := types.ExprString(mw); {
+		case name == "middleware.AuthMiddleware":
+			g.auth = true
+		case name == "middleware.AdminMiddleware":
+			g.admin = true
+		case name == "middleware.AllowUpload":
+			g.upload = true
+		case name == "middleware.RequireExportPermission":
+			g.permissions = append(g.permissions, "export")
+		case strings.HasPrefix(name, "middleware.RateLimit("):
+			g.rateLimited = true
+		case strings.HasPrefix(name, "middleware.EnforceUsageLimits("):
+			g.usageLimit = true
+		case strings.HasPrefix(name, "middleware.RequireFeature(") && len(call.Args) == 2:
+			if feature, ok := prog.constString(routes, call.Args[1]); ok {
+				g.features = append(g.features, feature)
+			}
+		case strings.HasPrefix(name, "can(") && len(call.Args) == 1,
+			strings.HasPrefix(name, "middleware.PermissionMiddleware(") && len(call.Args) == 1:
+			if permission, ok := prog.constString(routes, call.Args[0]); ok {
+				g.permissions = append(g.permissions, permission)
+			}
+		}
+	}
+	return g
+}
+
+// routeWalker collects the routes registered on the routers of SetupRoutes
+type routeWalker struct {
+	prog   *program
+	pkg    *pkg
+	locals map[string]ast.Expr // middleware assigned to variables
+	routes []*route
+}
+
+// routes returns the routes registered by SetupRoutes in registration order
+func (prog *program) routes() ([]*route, *ast.FuncDecl, error) {
+	routes := prog.packages[prog.module+"/routes"]
+	if routes == nil || routes.funcs["SetupRoutes"] == nil {
+		return nil, nil, fmt.Errorf("SetupRoutes not found in %s/routes", prog.module)
+	}
+	setup := routes.funcs["SetupRoutes"]
+	walker := &routeWalker{prog: prog, pkg: routes, locals: map[string]ast.Expr{}}
+	walker.walk(setup.Body, "", guard{})
+	return walker.routes, setup, nil
+}
+
+// walk collects the routes registered in a block on a router mounted at prefix
+func (rw *routeWalker) walk(body *ast.BlockStmt, prefix string, g guard) {
+	for _, stmt := range body.List {
+		switch stmt := stmt.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range stmt.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && i < len(stmt.Rhs) {
+					rw.locals[ident.Name] = stmt.Rhs[i]
+				}
+			}
+		case *ast.ExprStmt:
+			call, ok := stmt.X.(*ast.CallExpr)
+			if !ok {
+				continue
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				continue
+			}
+			scoped := g.with(rw.prog, rw.locals, rw.pkg, withMiddleware(sel.X))
+			switch method := sel.Sel.Name; method {
+			case "Use":
+				g = g.with(rw.prog, rw.locals, rw.pkg, call.Args)
+			case "Route":
+				if len(call.Args) == 2 {
+					if fn, ok := call.Args[1].(*ast.FuncLit); ok {
+						rw.walk(fn.Body, joinPath(prefix, literal(call.Args[0])), scoped)
+					}
+				}
+			case "Group":
+				if len(call.Args) == 1 {
+					if fn, ok := call.Args[0].(*ast.FuncLit); ok {
+						rw.walk(fn.Body, prefix, scoped)
+					}
+				}
+			case "Get", "Post", "Put", "Patch", "Delete", "Head", "Options":
+				if len(call.Args) == 2 {
+					rw.routes = append(rw.routes, &route{
+						method:  strings.ToLower(method),
+						path:    joinPath(prefix, literal(call.Args[0])),
+						handler: call.Args[1],
+						guard:   scoped,
+					})
+				}
+			}
+		}
+	}
+}
+
+// withMiddleware returns the middleware a route is registered with through r.With
+func withMiddleware(expr ast.Expr) []ast.Expr {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "With" {
+		return nil
+	}
+	return append(withMiddleware(sel.X), call.Args...)
+}
+
+// literal returns the value of a string literal
+func literal(expr ast.Expr) string {
+	if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		s, _ := strconv.Unquote(lit.Value)
+		return s
+	}
+	return ""
+}
+
+// joinPath joins a route pattern to the prefix it is mounted at, without trailing slashes
+func joinPath(prefix, pattern string) string {
+	joined := strings.TrimSuffix(prefix+pattern, "/")
+	if joined == "" {
+		return "/"
+	}
+	return joined
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// schema is an OpenAPI schema object
+type schema = map[string]interface{}
+
+// externalSchemas are the schemas of the types of other packages the API responds with
+var externalSchemas = map[string]schema{
+	"time.Time":       {"type": "string", "format": "date-time"},
+	"time.Duration":   {"type": "integer", "format": "int64", "description": "Nanoseconds"},
+	"gorm.DeletedAt":  {"type": "string", "format": "date-time", "nullable": true},
+	"json.RawMessage": {},
+	"datatypes.JSON":  {},
+	"url.Values":      {"type": "object", "additionalProperties": schema{"type": "array", "items": schema{"type": "string"}}},
+}
+
+// builtinSchemas are the schemas of the predeclared types
+var builtinSchemas = map[string]schema{
+	"bool":    {"type": "boolean"},
+	"string":  {"type": "string"},
+	"error":   {"type": "string"},
+	"any":     {},
+	"byte":    {"type": "integer"},
+	"rune":    {"type": "integer"},
+	"int":     {"type": "integer"},
+	"int8":    {"type": "integer"},
+	"int16":   {"type": "integer"},
+	"int32":   {"type": "integer", "format": "int32"},
+	"int64":   {"type": "integer", "format": "int64"},
+	"uint":    {"type": "integer", "minimum": 0},
+	"uint8":   {"type": "integer", "minimum": 0},
+	"uint16":  {"type": "integer", "minimum": 0},
+	"uint32":  {"type": "integer", "format": "int32", "minimum": 0},
+	"uint64":  {"type": "integer", "format": "int64", "minimum": 0},
+	"uintptr": {"type": "integer", "minimum": 0},
+	"float32": {"type": "number", "format": "float"},
+	"float64": {"type": "number", "format": "double"},
+}
+
+// schemaOf returns the schema of the JSON encoding of a type. Named types of the module become
+// component schemas referenced by name.
+func (prog *program) schemaOf(t *typeRef) schema {
+	if t == nil {
+		return schema{}
+	}
+	if t.lit != nil {
+		return prog.literalSchema(t.lit, t.scope)
+	}
+	switch e := t.expr.(type) {
+	case *ast.ParenExpr:
+		return prog.schemaOf(&typeRef{expr: e.X, pkg: t.pkg})
+	case *ast.StarExpr:
+		return prog.schemaOf(&typeRef{expr: e.X, pkg: t.pkg})
+	case *ast.ArrayType:
+		if types.ExprString(e.Elt) == "byte" {
+			return schema{"type": "string", "format": "byte"}
+		}
+		return schema{"type": "array", "items": prog.schemaOf(&typeRef{expr: e.Elt, pkg: t.pkg})}
+	case *ast.MapType:
+		return schema{"type": "object", "additionalProperties": prog.schemaOf(&typeRef{expr: e.Value, pkg: t.pkg})}
+	case *ast.StructType:
+		return prog.structSchema(e, t.pkg)
+	case *ast.Ident, *ast.SelectorExpr:
+		if p, spec := prog.named(t); spec != nil {
+			return prog.component(p, spec)
+		}
+		if s, ok := builtinSchemas[t.String()]; ok {
+			return copySchema(s)
+		}
+		if s, ok := externalSchemas[t.String()]; ok {
+			return copySchema(s)
+		}
+	}
+	return schema{}
+}
+
+// component returns a reference to the component schema of a named type of the module, adding it
+// to the components when it is first referenced
+func (prog *program) component(p *pkg, spec *ast.TypeSpec) schema {
+	if _, ok := spec.Type.(*ast.InterfaceType); ok {
+		return schema{}
+	}
+	qualified := p.path + "." + spec.Name.Name
+	name, ok := prog.components[qualified]
+	if !ok {
+		name = spec.Name.Name
+		if _, taken := prog.schemas[name]; taken {
+			pkgName := importName(p.path)
+			name = strings.ToUpper(pkgName[:1]) + pkgName[1:] + name
+		}
+		prog.components[qualified] = name
+		prog.schemas[name] = schema{}
+
+		var s schema
+		if values := p.enums[spec.Name.Name]; len(values) > 0 && types.ExprString(spec.Type) == "string" {
+			s = schema{"type": "string", "enum": values}
+		} else {
+			s = prog.schemaOf(&typeRef{expr: spec.Type, pkg: p})
+		}
+		if _, isRef := s["$ref"]; isRef {
+			s = schema{"allOf": []interface{}{s}}
+		}
+		if doc := p.docs[spec.Name.Name]; doc != "" {
+			s["description"] = oneLine(doc)
+		}
+		prog.schemas[name] = s
+	}
+	return schema{"$ref": "#/components/schemas/" + name}
+}
+
+// structSchema returns the schema of the JSON encoding of a struct type
+func (prog *program) structSchema(st *ast.StructType, p *pkg) schema {
+	properties := schema{}
+	promoted := schema{}
+	for _, field := range st.Fields.List {
+		var tag string
+		if field.Tag != nil {
+			unquoted, _ := strconv.Unquote(field.Tag.Value)
+			tag = reflect.StructTag(unquoted).Get("json")
+		}
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		fieldType := &typeRef{expr: field.Type, pkg: p}
+
+		names := make([]string, 0, len(field.Names))
+		for _, ident := range field.Names {
+			if ident.IsExported() {
+				names = append(names, ident.Name)
+			}
+		}
+		if len(field.Names) == 0 {
+			if name == "" {
+				for k, v := range prog.embeddedProperties(fieldType) {
+					promoted[k] = v
+				}
+				continue
+			}
+			names = append(names, receiverName(selectorName(field.Type)))
+		}
+		for _, fieldName := range names {
+			if name != "" {
+				fieldName = name
+			}
+			s := prog.schemaOf(fieldType)
+			if _, isPointer := field.Type.(*ast.StarExpr); isPointer {
+				s = nullable(s)
+			}
+			if strings.Contains(options, "string") {
+				s = schema{"type": "string"}
+			}
+			doc := field.Doc
+			if doc == nil {
+				doc = field.Comment
+			}
+			if doc != nil {
+				s = describe(s, oneLine(doc.Text()))
+			}
+			properties[fieldName] = s
+		}
+	}
+	for k, v := range promoted {
+		if _, ok := properties[k]; !ok {
+			properties[k] = v
+		}
+	}
+	return schema{"type": "object", "properties": properties}
+}
+
+// embeddedProperties returns the properties an embedded struct promotes
+func (prog *program) embeddedProperties(t *typeRef) schema {
+	if types.ExprString(deref(t).expr) == "gorm.Model" {
+		properties := schema{}
+		for name, typ := range gormModelFields {
+			properties[name] = prog.schemaOf(external(typ))
+		}
+		return properties
+	}
+	st, p := prog.structOf(t)
+	if st == nil {
+		return nil
+	}
+	properties, _ := prog.structSchema(st, p)["properties"].(schema)
+	return properties
+}
+
+// literalSchema returns the schema of a map literal with string keys, describing its entries
+func (prog *program) literalSchema(lit *ast.CompositeLit, s *scope) schema {
+	properties := schema{}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key := literal(kv.Key)
+		if key == "" {
+			key, _ = prog.constString(s.pkg, kv.Key)
+		}
+		if key == "" {
+			continue
+		}
+		properties[key] = prog.schemaOf(s.infer(kv.Value))
+	}
+	return schema{"type": "object", "properties": properties}
+}
+
+// nullable returns a schema that also allows null
+func nullable(s schema) schema {
+	if _, isRef := s["$ref"]; isRef {
+		return schema{"allOf": []interface{}{s}, "nullable": true}
+	}
+	if len(s) == 0 {
+		return s
+	}
+	s = copySchema(s)
+	s["nullable"] = true
+	return s
+}
+
+// describe returns a schema with a description
+func describe(s schema, description string) schema {
+	if description == "" {
+		return s
+	}
+	if _, isRef := s["$ref"]; isRef {
+		return schema{"allOf": []interface{}{s}, "description": description}
+	}
+	s = copySchema(s)
+	s["description"] = description
+	return s
+}
+
+func copySchema(s schema) schema {
+	c := make(schema, len(s))
+	for k, v := range s {
+		c[k] = v
+	}
+	return c
+}
+
+// oneLine joins the lines of a comment
+func oneLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,645 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+)
+
+// typeRef is a type expression with the package it is written in. Map literals keep the literal so
+// their keys can describe the value.
+type typeRef struct {
+	expr  ast.Expr
+	pkg   *pkg
+	lit   *ast.CompositeLit
+	scope *scope
+}
+
+// external returns a reference to a type declared outside the module, e.g. time.Time
+func external(expr string) *typeRef {
+	parsed, err := parser.ParseExpr(expr)
+	if err != nil {
+		return nil
+	}
+	return &typeRef{expr: parsed}
+}
+
+// String returns the type as written, e.g. *data.Expense
+func (t *typeRef) String() string {
+	if t == nil {
+		return ""
+	}
+	return types.ExprString(t.expr)
+}
+
+var builtinTypes = map[string]bool{
+	"bool": true, "string": true, "error": true, "any": true, "byte": true, "rune": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+	"float32": true, "float64": true,
+}
+
+// stdlibResults are the result types of the functions of other packages the handlers use
+var stdlibResults = map[string]string{
+	"chi.URLParam":        "string",
+	"fmt.Sprint":          "string",
+	"fmt.Sprintf":         "string",
+	"math.Abs":            "float64",
+	"math.Ceil":           "float64",
+	"math.Floor":          "float64",
+	"math.Max":            "float64",
+	"math.Min":            "float64",
+	"math.Round":          "float64",
+	"strconv.Atoi":        "int",
+	"strconv.FormatFloat": "string",
+	"strconv.FormatInt":   "string",
+	"strconv.FormatUint":  "string",
+	"strconv.Itoa":        "string",
+	"strconv.ParseBool":   "bool",
+	"strconv.ParseFloat":  "float64",
+	"strconv.ParseInt":    "int64",
+	"strconv.ParseUint":   "uint64",
+	"strings.Fields":      "[]string",
+	"strings.Join":        "string",
+	"strings.Split":       "[]string",
+	"strings.ToLower":     "string",
+	"strings.ToUpper":     "string",
+	"strings.TrimSpace":   "string",
+	"time.Date":           "time.Time",
+	"time.Now":            "time.Time",
+	"time.Parse":          "time.Time",
+	"time.Since":          "time.Duration",
+	"time.Unix":           "time.Time",
+}
+
+// timeMethods are the result types of the methods of time.Time the handlers use
+var timeMethods = map[string]string{
+	"Add": "time.Time", "AddDate": "time.Time", "Truncate": "time.Time", "UTC": "time.Time",
+	"In": "time.Time", "Format": "string", "Sub": "time.Duration", "Unix": "int64",
+	"Before": "bool", "After": "bool", "IsZero": "bool",
+}
+
+// scope is a function whose identifiers resolve to their nearest declaration before a use
+type scope struct {
+	prog  *program
+	pkg   *pkg
+	fn    *ast.FuncType
+	recv  *ast.FieldList
+	body  *ast.BlockStmt
+	decls map[string][]*declaration
+	local map[string]*ast.TypeSpec // types declared in the function
+}
+
+// declaration declares an identifier in a function
+type declaration struct {
+	pos   token.Pos
+	typ   ast.Expr // declared type, if any
+	value ast.Expr // value the identifier is initialized from otherwise
+	index int      // result of value the identifier is initialized from
+	rng   bool     // whether value is ranged over, with index 0 for the key and 1 for the value
+}
+
+func (prog *program) newScope(p *pkg, recv *ast.FieldList, fn *ast.FuncType, body *ast.BlockStmt) *scope {
+	s := &scope{prog: prog, pkg: p, fn: fn, recv: recv, body: body, decls: map[string][]*declaration{}, local: map[string]*ast.TypeSpec{}}
+	s.declareFields(recv)
+	if fn != nil {
+		s.declareFields(fn.Params)
+		s.declareFields(fn.Results)
+	}
+	if body == nil {
+		return s
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			s.declareFields(n.Type.Params)
+		case *ast.GenDecl:
+			for _, spec := range n.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					s.local[spec.Name.Name] = spec
+				case *ast.ValueSpec:
+					for i, name := range spec.Names {
+						d := &declaration{pos: name.Pos(), typ: spec.Type}
+						if i < len(spec.Values) {
+							d.value = spec.Values[i]
+						} else if len(spec.Values) == 1 {
+							d.value, d.index = spec.Values[0], i
+						}
+						s.declare(name, d)
+					}
+				}
+			}
+		case *ast.AssignStmt:
+			if n.Tok != token.DEFINE {
+				return true
+			}
+			for i, lhs := range n.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				d := &declaration{pos: ident.Pos()}
+				if len(n.Rhs) == len(n.Lhs) {
+					d.value = n.Rhs[i]
+				} else {
+					d.value, d.index = n.Rhs[0], i
+				}
+				s.declare(ident, d)
+			}
+		case *ast.RangeStmt:
+			if n.Tok != token.DEFINE {
+				return true
+			}
+			for i, expr := range []ast.Expr{n.Key, n.Value} {
+				if ident, ok := expr.(*ast.Ident); ok {
+					s.declare(ident, &declaration{pos: ident.Pos(), value: n.X, index: i, rng: true})
+				}
+			}
+		}
+		return true
+	})
+	return s
+}
+
+// scopeOf returns the scope of a function declared in a package
+func (prog *program) scopeOf(p *pkg, decl *ast.FuncDecl) *scope {
+	return prog.newScope(p, decl.Recv, decl.Type, decl.Body)
+}
+
+func (s *scope) declareFields(fields *ast.FieldList) {
+	if fields == nil {
+		return
+	}
+	for _, field := range fields.List {
+		for _, name := range field.Names {
+			s.declare(name, &declaration{pos: name.Pos(), typ: field.Type})
+		}
+	}
+}
+
+func (s *scope) declare(name *ast.Ident, d *declaration) {
+	if name.Name != "_" {
+		s.decls[name.Name] = append(s.decls[name.Name], d)
+	}
+}
+
+// lookup returns the nearest declaration of an identifier before a position
+func (s *scope) lookup(name string, pos token.Pos) *declaration {
+	var nearest *declaration
+	for _, d := range s.decls[name] {
+		if d.pos < pos && (nearest == nil || d.pos > nearest.pos) {
+			nearest = d
+		}
+	}
+	return nearest
+}
+
+// ref returns a reference to a type expression written in the function, substituting the types
+// declared in the function
+func (s *scope) ref(expr ast.Expr) *typeRef {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if spec := s.local[e.Name]; spec != nil {
+			return &typeRef{expr: spec.Type, pkg: s.pkg}
+		}
+	case *ast.StarExpr:
+		if elem := s.ref(e.X); elem != nil {
+			return &typeRef{expr: &ast.StarExpr{X: elem.expr}, pkg: elem.pkg}
+		}
+	case *ast.ArrayType:
+		if elem := s.ref(e.Elt); elem != nil {
+			return &typeRef{expr: &ast.ArrayType{Len: e.Len, Elt: elem.expr}, pkg: elem.pkg}
+		}
+	}
+	return &typeRef{expr: expr, pkg: s.pkg}
+}
+
+// infer returns the type of an expression, or nil when it can't be inferred
+func (s *scope) infer(expr ast.Expr) *typeRef {
+	return s.inferResult(expr, 0)
+}
+
+// inferResult returns the type of a result of a multi-valued expression
+func (s *scope) inferResult(expr ast.Expr, index int) *typeRef {
+	prog := s.prog
+	if prog.depth > 64 {
+		return nil
+	}
+	prog.depth++
+	defer func() { prog.depth-- }()
+
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return s.inferResult(e.X, index)
+	case *ast.Ident:
+		switch e.Name {
+		case "nil":
+			return nil
+		case "true", "false":
+			return external("bool")
+		}
+		if d := s.lookup(e.Name, e.Pos()); d != nil {
+			return s.inferDeclaration(d)
+		}
+		if v := s.pkg.values[e.Name]; v != nil {
+			return s.packageValue(s.pkg, v)
+		}
+		if fn := s.pkg.funcs[e.Name]; fn != nil {
+			return &typeRef{expr: fn.Type, pkg: s.pkg}
+		}
+	case *ast.BasicLit:
+		switch e.Kind {
+		case token.INT:
+			return external("int")
+		case token.FLOAT:
+			return external("float64")
+		case token.STRING:
+			return external("string")
+		}
+	case *ast.CompositeLit:
+		if e.Type == nil {
+			return nil
+		}
+		t := s.ref(e.Type)
+		if _, ok := e.Type.(*ast.MapType); ok {
+			t.lit, t.scope = e, s
+		}
+		return t
+	case *ast.FuncLit:
+		return &typeRef{expr: e.Type, pkg: s.pkg}
+	case *ast.UnaryExpr:
+		t := s.infer(e.X)
+		if t == nil {
+			return nil
+		}
+		switch e.Op {
+		case token.AND:
+			return &typeRef{expr: &ast.StarExpr{X: t.expr}, pkg: t.pkg, lit: t.lit, scope: t.scope}
+		case token.NOT:
+			return external("bool")
+		}
+		return t
+	case *ast.StarExpr:
+		if t := prog.underlying(s.infer(e.X)); t != nil {
+			if star, ok := t.expr.(*ast.StarExpr); ok {
+				return &typeRef{expr: star.X, pkg: t.pkg}
+			}
+		}
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ, token.LAND, token.LOR:
+			return external("bool")
+		}
+		if t := s.infer(e.X); t != nil {
+			return t
+		}
+		return s.infer(e.Y)
+	case *ast.SelectorExpr:
+		if p := s.importedPackage(e.X); p != nil {
+			if v := p.values[e.Sel.Name]; v != nil {
+				return s.packageValue(p, v)
+			}
+			if fn := p.funcs[e.Sel.Name]; fn != nil {
+				return &typeRef{expr: fn.Type, pkg: p}
+			}
+			return nil
+		}
+		x := s.infer(e.X)
+		if field := prog.field(x, e.Sel.Name); field != nil {
+			return field
+		}
+		if fn, _, _ := prog.method(x, e.Sel.Name); fn != nil {
+			return fn
+		}
+	case *ast.IndexExpr:
+		t := prog.underlying(s.infer(e.X))
+		if t == nil {
+			return nil
+		}
+		switch x := t.expr.(type) {
+		case *ast.ArrayType:
+			return &typeRef{expr: x.Elt, pkg: t.pkg}
+		case *ast.MapType:
+			if index == 1 {
+				return external("bool")
+			}
+			return &typeRef{expr: x.Value, pkg: t.pkg}
+		}
+	case *ast.SliceExpr:
+		return s.infer(e.X)
+	case *ast.TypeAssertExpr:
+		if index == 1 {
+			return external("bool")
+		}
+		if e.Type != nil {
+			return s.ref(e.Type)
+		}
+	case *ast.CallExpr:
+		return s.inferCall(e, index)
+	}
+	return nil
+}
+
+// inferDeclaration returns the type of an identifier declared in the function
+func (s *scope) inferDeclaration(d *declaration) *typeRef {
+	if d.typ != nil {
+		return s.ref(d.typ)
+	}
+	if d.value == nil {
+		return nil
+	}
+	if !d.rng {
+		return s.inferResult(d.value, d.index)
+	}
+	t := s.prog.underlying(s.infer(d.value))
+	if t == nil {
+		return nil
+	}
+	switch x := t.expr.(type) {
+	case *ast.MapType:
+		if d.index == 0 {
+			return &typeRef{expr: x.Key, pkg: t.pkg}
+		}
+		return &typeRef{expr: x.Value, pkg: t.pkg}
+	case *ast.ArrayType:
+		if d.index == 0 {
+			return external("int")
+		}
+		return &typeRef{expr: x.Elt, pkg: t.pkg}
+	}
+	if d.index == 0 {
+		return external("int")
+	}
+	return nil
+}
+
+// packageValue returns the type of a package-level variable or constant
+func (s *scope) packageValue(p *pkg, v *value) *typeRef {
+	if v.typ != nil {
+		return &typeRef{expr: v.typ, pkg: p}
+	}
+	if v.init == nil {
+		return nil
+	}
+	return s.prog.newScope(p, nil, nil, nil).infer(v.init)
+}
+
+// importedPackage returns the package of the module an identifier names, unless it is shadowed
+func (s *scope) importedPackage(expr ast.Expr) *pkg {
+	ident, ok := expr.(*ast.Ident)
+	if !ok || s.lookup(ident.Name, ident.Pos()) != nil {
+		return nil
+	}
+	return s.prog.packages[s.pkg.imports[ident.Name]]
+}
+
+// importName returns the name of the package an identifier names, unless it is shadowed
+func (s *scope) importName(expr ast.Expr) (string, bool) {
+	ident, ok := expr.(*ast.Ident)
+	if !ok || s.lookup(ident.Name, ident.Pos()) != nil {
+		return "", false
+	}
+	importPath, ok := s.pkg.imports[ident.Name]
+	if !ok {
+		return "", false
+	}
+	return importName(importPath), true
+}
+
+// inferCall returns the type of a result of a call
+func (s *scope) inferCall(call *ast.CallExpr, index int) *typeRef {
+	prog := s.prog
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		if s.lookup(fun.Name, fun.Pos()) == nil {
+			switch fun.Name {
+			case "len", "cap", "copy":
+				return external("int")
+			case "append", "min", "max":
+				if len(call.Args) > 0 {
+					return s.infer(call.Args[0])
+				}
+			case "make":
+				if len(call.Args) > 0 {
+					return s.ref(call.Args[0])
+				}
+			case "new":
+				if len(call.Args) > 0 {
+					return s.ref(&ast.StarExpr{X: call.Args[0]})
+				}
+			}
+			if builtinTypes[fun.Name] || s.local[fun.Name] != nil || s.pkg.types[fun.Name] != nil {
+				return s.ref(fun)
+			}
+		}
+	case *ast.SelectorExpr:
+		if p := s.importedPackage(fun.X); p != nil {
+			if p.types[fun.Sel.Name] != nil {
+				return &typeRef{expr: fun, pkg: s.pkg}
+			}
+			if fn := p.funcs[fun.Sel.Name]; fn != nil {
+				return prog.result(&typeRef{expr: fn.Type, pkg: p}, index)
+			}
+			return nil
+		}
+		if name, ok := s.importName(fun.X); ok {
+			if result, ok := stdlibResults[name+"."+fun.Sel.Name]; ok && index == 0 {
+				return external(result)
+			}
+			return nil
+		}
+		switch fun.Sel.Name {
+		case "Query":
+			return external("url.Values")
+		case "String", "Error":
+			return external("string")
+		}
+		x := s.infer(fun.X)
+		if x.String() == "time.Time" || x.String() == "*time.Time" {
+			if result, ok := timeMethods[fun.Sel.Name]; ok {
+				return external(result)
+			}
+			return nil
+		}
+	case *ast.ArrayType, *ast.MapType, *ast.StarExpr, *ast.InterfaceType:
+		return s.ref(fun)
+	case *ast.ParenExpr:
+		return s.ref(fun.X)
+	}
+	return prog.result(s.infer(call.Fun), index)
+}
+
+// result returns the type of a result of a function type
+func (prog *program) result(fn *typeRef, index int) *typeRef {
+	fn = prog.underlying(fn)
+	if fn == nil {
+		return nil
+	}
+	ft, ok := fn.expr.(*ast.FuncType)
+	if !ok || ft.Results == nil {
+		return nil
+	}
+	i := 0
+	for _, field := range ft.Results.List {
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		if index < i+n {
+			return &typeRef{expr: field.Type, pkg: fn.pkg}
+		}
+		i += n
+	}
+	return nil
+}
+
+// named returns the declaration of a named type of the module, and the package it is declared in
+func (prog *program) named(t *typeRef) (*pkg, *ast.TypeSpec) {
+	if t == nil || t.pkg == nil {
+		return nil, nil
+	}
+	switch e := t.expr.(type) {
+	case *ast.ParenExpr:
+		return prog.named(&typeRef{expr: e.X, pkg: t.pkg})
+	case *ast.Ident:
+		if spec := t.pkg.types[e.Name]; spec != nil {
+			return t.pkg, spec
+		}
+	case *ast.SelectorExpr:
+		x, ok := e.X.(*ast.Ident)
+		if !ok {
+			return nil, nil
+		}
+		if p := prog.packages[t.pkg.imports[x.Name]]; p != nil && p.types[e.Sel.Name] != nil {
+			return p, p.types[e.Sel.Name]
+		}
+	}
+	return nil, nil
+}
+
+// underlying returns the underlying type of a type, following the named types of the module
+func (prog *program) underlying(t *typeRef) *typeRef {
+	for i := 0; t != nil && i < 16; i++ {
+		p, spec := prog.named(t)
+		if spec == nil {
+			return t
+		}
+		t = &typeRef{expr: spec.Type, pkg: p}
+	}
+	return t
+}
+
+// structOf returns the struct type underlying a type or a pointer to it
+func (prog *program) structOf(t *typeRef) (*ast.StructType, *pkg) {
+	t = prog.underlying(t)
+	if t == nil {
+		return nil, nil
+	}
+	if star, ok := t.expr.(*ast.StarExpr); ok {
+		t = prog.underlying(&typeRef{expr: star.X, pkg: t.pkg})
+	}
+	st, ok := t.expr.(*ast.StructType)
+	if !ok {
+		return nil, nil
+	}
+	return st, t.pkg
+}
+
+// gormModelFields are the fields of gorm.Model
+var gormModelFields = map[string]string{
+	"ID": "uint", "CreatedAt": "time.Time", "UpdatedAt": "time.Time", "DeletedAt": "gorm.DeletedAt",
+}
+
+// field returns the type of a field of a struct or a pointer to one, including promoted fields
+func (prog *program) field(t *typeRef, name string) *typeRef {
+	st, p := prog.structOf(t)
+	if st == nil {
+		return nil
+	}
+	var embedded []*typeRef
+	for _, f := range st.Fields.List {
+		for _, ident := range f.Names {
+			if ident.Name == name {
+				return &typeRef{expr: f.Type, pkg: p}
+			}
+		}
+		if len(f.Names) > 0 {
+			continue
+		}
+		if types.ExprString(f.Type) == "gorm.Model" {
+			if typ, ok := gormModelFields[name]; ok {
+				return external(typ)
+			}
+			continue
+		}
+		if receiverName(selectorName(f.Type)) == name {
+			return &typeRef{expr: f.Type, pkg: p}
+		}
+		embedded = append(embedded, &typeRef{expr: f.Type, pkg: p})
+	}
+	for _, e := range embedded {
+		if field := prog.field(e, name); field != nil {
+			return field
+		}
+	}
+	return nil
+}
+
+// selectorName strips the package of a qualified type name
+func selectorName(expr ast.Expr) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return selectorName(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel
+	}
+	return expr
+}
+
+// method returns the type of a method of a type, and its declaration when the method of a named
+// type is called rather than the method of an interface
+func (prog *program) method(t *typeRef, name string) (*typeRef, *ast.FuncDecl, *pkg) {
+	if t == nil {
+		return nil, nil, nil
+	}
+	elem := t
+	if star, ok := t.expr.(*ast.StarExpr); ok {
+		elem = &typeRef{expr: star.X, pkg: t.pkg}
+	}
+	p, spec := prog.named(elem)
+	if spec != nil {
+		if decl := p.methods[spec.Name.Name][name]; decl != nil {
+			return &typeRef{expr: decl.Type, pkg: p}, decl, p
+		}
+	}
+	u := prog.underlying(elem)
+	if u == nil {
+		return nil, nil, nil
+	}
+	switch x := u.expr.(type) {
+	case *ast.InterfaceType:
+		for _, m := range x.Methods.List {
+			for _, ident := range m.Names {
+				if ident.Name == name {
+					return &typeRef{expr: m.Type, pkg: u.pkg}, nil, nil
+				}
+			}
+			if len(m.Names) == 0 {
+				if fn, decl, p := prog.method(&typeRef{expr: m.Type, pkg: u.pkg}, name); fn != nil {
+					return fn, decl, p
+				}
+			}
+		}
+	case *ast.StructType:
+		for _, f := range x.Fields.List {
+			if len(f.Names) == 0 {
+				if fn, decl, p := prog.method(&typeRef{expr: f.Type, pkg: u.pkg}, name); fn != nil {
+					return fn, decl, p
+				}
+			}
+		}
+	}
+	return nil, nil, nil
+}
@@ -0,0 +1,119 @@
+package data
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// amortizationStart returns the first month a prepaid expense is expensed in: the month it starts
+// amortizing in when set, the month of its date otherwise
+func amortizationStart(expense *Expense) time.Time {
+	start := expense.Date
+	if expense.AmortizationStart != nil {
+		start = *expense.AmortizationStart
+	}
+	return time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// allocateExpense generates the monthly allocations of a prepaid expense, replacing any it had.
+// The amount is split evenly to the cent, the last month taking the rounding difference.
+func allocateExpense(tx *gorm.DB, expense *Expense) error {
+	if err := tx.Where("expense_id = ?", expense.ID).Delete(&ExpenseAllocation{}).Error; err != nil {
+		return err
+	}
+	if expense.PrepaidMonths == nil || *expense.PrepaidMonths < 1 {
+		return nil
+	}
+
+	months := *expense.PrepaidMonths
+	start := amortizationStart(expense)
+	monthly := math.Round(expense.Amount/float64(months)*100) / 100
+	allocations := make([]*ExpenseAllocation, months)
+	var allocated float64
+	for i := range allocations {
+		amount := monthly
+		if i == months-1 {
+			amount = math.Round((expense.Amount-allocated)*100) / 100
+		}
+		allocated += amount
+		allocations[i] = &ExpenseAllocation{
+			ExpenseID: expense.ID,
+			Date:      start.AddDate(0, i, 0),
+			Category:  expense.Category,
+			Amount:    amount,
+			UserID:    expense.UserID,
+		}
+	}
+	return tx.Create(&allocations).Error
+}
+
+// GetPrepaid retrieves the amortization schedules of a user's prepaid expenses, newest first
+func (r *ExpenseRepository) GetPrepaid(ctx context.Context, userID uint) ([]*AmortizationSchedule, error) {
+	var expenses []*Expense
+	err := r.db.WithContext(ctx).Where("user_id = ? AND prepaid_months IS NOT NULL", userID).Order("date DESC, id DESC").Find(&expenses).Error
+	if err != nil || len(expenses) == 0 {
+		return []*AmortizationSchedule{}, err
+	}
+
+	ids := make([]uint, len(expenses))
+	for i, expense := range expenses {
+		ids[i] = expense.ID
+	}
+	var allocations []*ExpenseAllocation
+	if err := r.db.WithContext(ctx).Where("expense_id IN ?", ids).Order("date ASC").Find(&allocations).Error; err != nil {
+		return nil, err
+	}
+	byExpense := make(map[uint][]*ExpenseAllocation, len(expenses))
+	for _, allocation := range allocations {
+		byExpense[allocation.ExpenseID] = append(byExpense[allocation.ExpenseID], allocation)
+	}
+
+	schedules := make([]*AmortizationSchedule, len(expenses))
+	for i, expense := range expenses {
+		schedules[i] = newAmortizationSchedule(expense, byExpense[expense.ID])
+	}
+	return schedules, nil
+}
+
+// GetAmortization retrieves the amortization schedule of a prepaid expense. It returns
+// gorm.ErrRecordNotFound when the expense isn't prepaid.
+func (r *ExpenseRepository) GetAmortization(ctx context.Context, id uint, userID uint) (*AmortizationSchedule, error) {
+	var expense Expense
+	err := r.db.WithContext(ctx).Where("id = ? AND user_id = ? AND prepaid_months IS NOT NULL", id, userID).First(&expense).Error
+	if err != nil {
+		return nil, err
+	}
+	var allocations []*ExpenseAllocation
+	if err := r.db.WithContext(ctx).Where("expense_id = ?", id).Order("date ASC").Find(&allocations).Error; err != nil {
+		return nil, err
+	}
+	return newAmortizationSchedule(&expense, allocations), nil
+}
+
+// newAmortizationSchedule builds the schedule of a prepaid expense from its allocations, counting
+// the allocations up to the current month as amortized
+func newAmortizationSchedule(expense *Expense, allocations []*ExpenseAllocation) *AmortizationSchedule {
+	if allocations == nil {
+		allocations = []*ExpenseAllocation{}
+	}
+	schedule := &AmortizationSchedule{
+		ExpenseID:   expense.ID,
+		Description: expense.Description,
+		Category:    expense.Category,
+		Amount:      expense.Amount,
+		Months:      *expense.PrepaidMonths,
+		Allocations: allocations,
+	}
+	now := time.Now()
+	for _, allocation := range allocations {
+		if !allocation.Date.After(now) {
+			schedule.Amortized += allocation.Amount
+		}
+	}
+	schedule.Amortized = math.Round(schedule.Amortized*100) / 100
+	schedule.Remaining = math.Round((expense.Amount-schedule.Amortized)*100) / 100
+	return schedule
+}
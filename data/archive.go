@@ -0,0 +1,126 @@
+package data
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ArchiveRepository implements ArchiveInterface using GORM
+type ArchiveRepository struct {
+	db *gorm.DB
+}
+
+// NewArchiveRepository creates a new instance of ArchiveRepository
+func NewArchiveRepository(db *gorm.DB) ArchiveInterface {
+	return &ArchiveRepository{db: db}
+}
+
+// ArchiveIncomes moves up to limit paid sales dated before the given time, soft deleted ones
+// included, to the archived_incomes table and returns how many were moved. Unpaid sales stay
+// so receivables and dunning keep seeing them.
+func (r *ArchiveRepository) ArchiveIncomes(ctx context.Context, before time.Time, limit int) (int64, error) {
+	var moved int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var incomes []*Income
+		err := tx.Unscoped().Where("date < ? AND payment_status = ?", before, PaymentPaid).
+			Order("id").Limit(limit).Find(&incomes).Error
+		if err != nil || len(incomes) == 0 {
+			return err
+		}
+
+		now := time.Now()
+		archived := make([]*ArchivedIncome, len(incomes))
+		ids := make([]uint, len(incomes))
+		for i, income := range incomes {
+			archived[i] = &ArchivedIncome{Income: *income, ArchivedAt: now}
+			ids[i] = income.ID
+		}
+		if err := tx.Omit(clause.Associations).CreateInBatches(archived, 100).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().Where("id IN ? AND date < ?", ids, before).Delete(&Income{})
+		moved = result.RowsAffected
+		return result.Error
+	})
+	return moved, err
+}
+
+// ArchiveExpenses moves up to limit paid expenses dated before the given time, soft deleted ones
+// included, to the archived_expenses table and returns how many were moved
+func (r *ArchiveRepository) ArchiveExpenses(ctx context.Context, before time.Time, limit int) (int64, error) {
+	var moved int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var expenses []*Expense
+		err := tx.Unscoped().Where("date < ? AND payment_status = ?", before, PaymentPaid).
+			Order("id").Limit(limit).Find(&expenses).Error
+		if err != nil || len(expenses) == 0 {
+			return err
+		}
+
+		now := time.Now()
+		archived := make([]*ArchivedExpense, len(expenses))
+		ids := make([]uint, len(expenses))
+		for i, expense := range expenses {
+			archived[i] = &ArchivedExpense{Expense: *expense, ArchivedAt: now}
+			ids[i] = expense.ID
+		}
+		if err := tx.Omit(clause.Associations).CreateInBatches(archived, 100).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().Where("id IN ? AND date < ?", ids, before).Delete(&Expense{})
+		moved = result.RowsAffected
+		return result.Error
+	})
+	return moved, err
+}
+
+// GetPeriods summarizes a user's archived records per year, latest first
+func (r *ArchiveRepository) GetPeriods(ctx context.Context, userID uint) ([]*ArchivedPeriod, error) {
+	type yearTotal struct {
+		Year    int
+		Records int64
+		Total   float64
+	}
+	year := yearExpr(r.db.WithContext(ctx), "date")
+
+	var incomes, expenses []yearTotal
+	err := r.db.WithContext(ctx).Model(&ArchivedIncome{}).
+		Select(year+" AS year, COUNT(*) AS records, COALESCE(SUM(total_amount), 0) AS total").
+		Where("user_id = ?", userID).Group(year).Scan(&incomes).Error
+	if err != nil {
+		return nil, err
+	}
+	err = r.db.WithContext(ctx).Model(&ArchivedExpense{}).
+		Select(year+" AS year, COUNT(*) AS records, COALESCE(SUM(amount), 0) AS total").
+		Where("user_id = ?", userID).Group(year).Scan(&expenses).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byYear := make(map[int]*ArchivedPeriod)
+	period := func(y int) *ArchivedPeriod {
+		if byYear[y] == nil {
+			byYear[y] = &ArchivedPeriod{Year: y}
+		}
+		return byYear[y]
+	}
+	for _, t := range incomes {
+		p := period(t.Year)
+		p.IncomeRecords, p.TotalIncome = t.Records, t.Total
+	}
+	for _, t := range expenses {
+		p := period(t.Year)
+		p.ExpenseRecords, p.TotalExpenses = t.Records, t.Total
+	}
+
+	periods := make([]*ArchivedPeriod, 0, len(byYear))
+	for _, p := range byYear {
+		periods = append(periods, p)
+	}
+	sort.Slice(periods, func(i, j int) bool { return periods[i].Year > periods[j].Year })
+	return periods, nil
+}
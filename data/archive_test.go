@@ -0,0 +1,67 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestArchiveIncomes(t *testing.T) {
+	db := newTestDB(t, &Income{}, &ArchivedIncome{}, &Payment{}, &StreamEvent{}, &OutboxMessage{})
+	date := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	paid := &Income{Date: date, Quantity: 2, Unit: "g", PricePerUnit: 100, TotalAmount: 200,
+		CustomerName: "Buyer", PaymentStatus: PaymentUnpaid, AmountDue: 200, UserID: 1}
+	unpaid := &Income{Date: date, Quantity: 1, Unit: "g", PricePerUnit: 100, TotalAmount: 100,
+		CustomerName: "Buyer", PaymentStatus: PaymentUnpaid, AmountDue: 100, UserID: 1}
+	if err := db.Create([]*Income{paid, unpaid}).Error; err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	payments := NewPaymentRepository(db)
+	if _, err := payments.RecordIncomePayment(ctx, &Payment{RecordID: paid.ID, Amount: 200, Date: date, UserID: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	moved, err := NewArchiveRepository(db).ArchiveIncomes(ctx, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved != 1 {
+		t.Fatalf("%d sales archived, want only the paid one", moved)
+	}
+
+	incomes := NewIncomeRepository(db)
+	if _, err := incomes.GetOne(ctx, paid.ID, 1); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("archived sale still in the books: %v", err)
+	}
+	if _, err := incomes.GetOne(ctx, unpaid.ID, 1); err != nil {
+		t.Errorf("unpaid sale archived: %v", err)
+	}
+
+	// The archived sale keeps its ID, so it and its payments can be read back by it
+	archived, err := incomes.GetArchivedOne(ctx, paid.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if archived.AmountPaid != 200 || archived.PaymentStatus != PaymentPaid || archived.ArchivedAt.IsZero() {
+		t.Errorf("archived sale paid %g, %s, archived at %v", archived.AmountPaid, archived.PaymentStatus, archived.ArchivedAt)
+	}
+	if _, err := incomes.GetArchivedOne(ctx, paid.ID, 2); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("another user read the archived sale: %v", err)
+	}
+	inRange, err := incomes.GetArchived(ctx, 1, "2019-01-01", "2019-12-31")
+	if err != nil || len(inRange) != 1 || inRange[0].ID != paid.ID {
+		t.Errorf("archived sales in 2019: %v, %v", inRange, err)
+	}
+	recorded, err := payments.GetForRecord(ctx, 1, TransactionIncome, paid.ID)
+	if err != nil || len(recorded) != 1 || recorded[0].Amount != 200 {
+		t.Errorf("payments of the archived sale: %v, %v", recorded, err)
+	}
+
+	if _, err := payments.RecordIncomePayment(ctx, &Payment{RecordID: paid.ID, Amount: 1, Date: date, UserID: 1}); err == nil {
+		t.Error("recorded a payment on an archived sale")
+	}
+}
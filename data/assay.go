@@ -0,0 +1,90 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AssayRepository implements AssayInterface using GORM
+type AssayRepository struct {
+	db *gorm.DB
+}
+
+// NewAssayRepository creates a new instance of AssayRepository
+func NewAssayRepository(db *gorm.DB) AssayInterface {
+	return &AssayRepository{db: db}
+}
+
+// GetAll retrieves the assays of a user matching a filter, latest first
+func (r *AssayRepository) GetAll(ctx context.Context, userID uint, filter AssayFilter) ([]*Assay, error) {
+	var assays []*Assay
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if filter.InventoryItemID != nil {
+		query = query.Where("inventory_item_id = ?", *filter.InventoryItemID)
+	}
+	if filter.IncomeID != nil {
+		query = query.Where("income_id = ?", *filter.IncomeID)
+	}
+	if filter.MineralType != "" {
+		query = query.Where("mineral_type = ?", filter.MineralType)
+	}
+	result := query.Order("assay_date DESC, id DESC").Find(&assays)
+	return assays, result.Error
+}
+
+// GetOne retrieves an assay of a user
+func (r *AssayRepository) GetOne(ctx context.Context, id uint, userID uint) (*Assay, error) {
+	var assay Assay
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&assay)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &assay, nil
+}
+
+// Insert creates an assay
+func (r *AssayRepository) Insert(ctx context.Context, assay *Assay) (uint, error) {
+	result := r.db.WithContext(ctx).Create(assay)
+	return assay.ID, result.Error
+}
+
+// Update updates an assay
+func (r *AssayRepository) Update(ctx context.Context, assay *Assay) error {
+	return r.db.WithContext(ctx).Save(assay).Error
+}
+
+// Delete soft deletes an assay
+func (r *AssayRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	return r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&Assay{}).Error
+}
+
+// GetAssayedSales retrieves the sales of a mineral since a date that have an assay, with the
+// grade of their latest assay, oldest sale first. Sales to flagged customers that a manager
+// hasn't approved are left out.
+func (r *AssayRepository) GetAssayedSales(ctx context.Context, userID uint, mineralType MineralType, since time.Time) ([]*AssayedSale, error) {
+	var rows []*AssayedSale
+	err := r.db.WithContext(ctx).Table("assays").
+		Select("incomes.id AS income_id, incomes.date, incomes.customer_name, incomes.quantity, incomes.unit, "+
+			"incomes.price_per_unit, assays.grade, assays.grade_unit, assays.laboratory").
+		Joins("JOIN incomes ON incomes.id = assays.income_id AND incomes.deleted_at IS NULL").
+		Where("assays.user_id = ? AND assays.deleted_at IS NULL AND incomes.mineral_type = ?", userID, mineralType).
+		Where("incomes.date >= ?", since).
+		Where("(incomes.approval_status IS NULL OR incomes.approval_status = ?)", SaleApproved).
+		Order("incomes.date, incomes.id, assays.assay_date DESC, assays.id DESC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the latest assay of each sale
+	sales := make([]*AssayedSale, 0, len(rows))
+	for _, row := range rows {
+		if len(sales) > 0 && sales[len(sales)-1].IncomeID == row.IncomeID {
+			continue
+		}
+		sales = append(sales, row)
+	}
+	return sales, nil
+}
@@ -0,0 +1,59 @@
+package data
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+// AttachmentRepository implements AttachmentInterface using GORM
+type AttachmentRepository struct {
+	db *gorm.DB
+}
+
+// NewAttachmentRepository creates a new instance of AttachmentRepository
+func NewAttachmentRepository(db *gorm.DB) AttachmentInterface {
+	return &AttachmentRepository{db: db}
+}
+
+// Insert stores the details of a file attached to a record
+func (r *AttachmentRepository) Insert(ctx context.Context, attachment *Attachment) error {
+	return r.db.WithContext(ctx).Create(attachment).Error
+}
+
+// GetOne retrieves a specific attachment of a user
+func (r *AttachmentRepository) GetOne(ctx context.Context, id uint, userID uint) (*Attachment, error) {
+	var attachment Attachment
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&attachment)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &attachment, nil
+}
+
+// GetForRecord retrieves the files attached to a record, oldest first
+func (r *AttachmentRepository) GetForRecord(ctx context.Context, userID uint, recordType AttachmentRecordType, recordID uint) ([]*Attachment, error) {
+	var attachments []*Attachment
+	result := r.db.WithContext(ctx).Where("user_id = ? AND record_type = ? AND record_id = ?", userID, recordType, recordID).
+		Order("id ASC").Find(&attachments)
+	return attachments, result.Error
+}
+
+// Delete deletes the details of an attachment; its file is removed from the store by the caller
+func (r *AttachmentRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&Attachment{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetStorage returns the storage used by a user's attached files
+func (r *AttachmentRepository) GetStorage(ctx context.Context, userID uint) (*AttachmentStorage, error) {
+	storage := &AttachmentStorage{}
+	result := r.db.WithContext(ctx).Model(&Attachment{}).Where("user_id = ?", userID).
+		Select("COALESCE(SUM(size), 0) AS used_bytes, COUNT(*) AS files").Scan(storage)
+	return storage, result.Error
+}
@@ -0,0 +1,140 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrAlreadyCheckedIn is returned when checking in an employee who has not checked out
+	ErrAlreadyCheckedIn = errors.New("employee is already checked in")
+	// ErrNotCheckedIn is returned when checking out an employee who is not checked in
+	ErrNotCheckedIn = errors.New("employee is not checked in")
+)
+
+// AttendanceRepository implements AttendanceInterface using GORM
+type AttendanceRepository struct {
+	db *gorm.DB
+}
+
+// NewAttendanceRepository creates a new instance of AttendanceRepository
+func NewAttendanceRepository(db *gorm.DB) AttendanceInterface {
+	return &AttendanceRepository{db: db}
+}
+
+// GetAll retrieves attendance records for a user, latest check-in first
+func (r *AttendanceRepository) GetAll(ctx context.Context, userID uint, filter AttendanceFilter) ([]*Attendance, error) {
+	query := r.db.WithContext(ctx).Preload("Employee").Where("user_id = ?", userID)
+	if filter.EmployeeID != 0 {
+		query = query.Where("employee_id = ?", filter.EmployeeID)
+	}
+	if filter.Start != nil && filter.End != nil {
+		query = query.Where("check_in_at >= ? AND check_in_at < ?", *filter.Start, *filter.End)
+	}
+	if filter.Flagged {
+		query = query.Where("flagged = ?", true)
+	}
+	if filter.Open {
+		query = query.Where("check_out_at IS NULL")
+	}
+
+	var records []*Attendance
+	result := query.Order("check_in_at DESC").Find(&records)
+	return records, result.Error
+}
+
+// GetOne retrieves a specific attendance record by ID for a user
+func (r *AttendanceRepository) GetOne(ctx context.Context, id uint, userID uint) (*Attendance, error) {
+	var attendance Attendance
+	result := r.db.WithContext(ctx).Preload("Employee").Where("id = ? AND user_id = ?", id, userID).First(&attendance)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &attendance, nil
+}
+
+// GetLatest retrieves the most recent attendance record of an employee, or nil if there is none
+func (r *AttendanceRepository) GetLatest(ctx context.Context, employeeID uint, userID uint) (*Attendance, error) {
+	var attendance Attendance
+	result := r.db.WithContext(ctx).Preload("Employee").Where("employee_id = ? AND user_id = ?", employeeID, userID).
+		Order("check_in_at DESC").First(&attendance)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &attendance, nil
+}
+
+// CheckIn records a check-in. The employee row is locked so concurrent check-ins from two
+// devices cannot both open a record.
+func (r *AttendanceRepository) CheckIn(ctx context.Context, attendance *Attendance) (uint, error) {
+	attendance.Flagged = len(attendance.Flags) > 0
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var employee Employee
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND user_id = ?", attendance.EmployeeID, attendance.UserID).First(&employee).Error; err != nil {
+			return err
+		}
+
+		var open int64
+		if err := tx.Model(&Attendance{}).
+			Where("employee_id = ? AND user_id = ? AND check_out_at IS NULL", attendance.EmployeeID, attendance.UserID).
+			Count(&open).Error; err != nil {
+			return err
+		}
+		if open > 0 {
+			return ErrAlreadyCheckedIn
+		}
+
+		return tx.Omit("Employee").Create(attendance).Error
+	})
+	return attendance.ID, err
+}
+
+// CheckOut records the check-out of an open attendance record and submits the timesheet for
+// the hours on site, if any, for approval. The record must be loaded with its employee, whose
+// rate and pit the timesheet uses.
+func (r *AttendanceRepository) CheckOut(ctx context.Context, attendance *Attendance) error {
+	attendance.Flagged = len(attendance.Flags) > 0
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(attendance).Where("user_id = ? AND check_out_at IS NULL", attendance.UserID).
+			Select("check_out_at", "check_out_latitude", "check_out_longitude", "check_out_accuracy",
+				"check_out_distance", "hours", "flags", "flagged", "checked_out_by_id").
+			Updates(attendance)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotCheckedIn
+		}
+
+		if attendance.Hours <= 0 {
+			return nil
+		}
+		checkIn := attendance.CheckInAt
+		notes := fmt.Sprintf("Site attendance %s-%s", checkIn.Format("15:04"), attendance.CheckOutAt.Format("15:04"))
+		timesheet := &Timesheet{
+			EmployeeID: attendance.EmployeeID,
+			Date:       time.Date(checkIn.Year(), checkIn.Month(), checkIn.Day(), 0, 0, 0, 0, checkIn.Location()),
+			Hours:      attendance.Hours,
+			HourlyRate: attendance.Employee.HourlyRate,
+			Amount:     attendance.Hours * attendance.Employee.HourlyRate,
+			PitNumber:  attendance.Employee.PitNumber,
+			Status:     TimesheetSubmitted,
+			Notes:      &notes,
+			UserID:     attendance.UserID,
+		}
+		if err := tx.Omit("Employee").Create(timesheet).Error; err != nil {
+			return err
+		}
+		attendance.TimesheetID = &timesheet.ID
+		return tx.Model(&Attendance{}).Where("id = ?", attendance.ID).Update("timesheet_id", timesheet.ID).Error
+	})
+}
@@ -0,0 +1,33 @@
+package data
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+// AuditRepository implements AuditInterface using GORM
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository creates a new instance of AuditRepository
+func NewAuditRepository(db *gorm.DB) AuditInterface {
+	return &AuditRepository{db: db}
+}
+
+// Insert records an audit log entry
+func (r *AuditRepository) Insert(ctx context.Context, entry *AuditLog) error {
+	result := r.db.WithContext(ctx).Create(entry)
+	return result.Error
+}
+
+// GetAll retrieves the audit log of a user's books, optionally filtered by action
+func (r *AuditRepository) GetAll(ctx context.Context, userID uint, action string) ([]*AuditLog, error) {
+	var entries []*AuditLog
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if action != "" {
+		query = query.Where("action = ?", action)
+	}
+	result := query.Order("created_at DESC").Limit(500).Find(&entries)
+	return entries, result.Error
+}
@@ -0,0 +1,49 @@
+package data
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// BackupRepository implements BackupInterface using GORM
+type BackupRepository struct {
+	db *gorm.DB
+}
+
+// NewBackupRepository creates a new instance of BackupRepository
+func NewBackupRepository(db *gorm.DB) BackupInterface {
+	return &BackupRepository{db: db}
+}
+
+// GetRecent retrieves the most recent backups, newest first
+func (r *BackupRepository) GetRecent(ctx context.Context, limit int) ([]*Backup, error) {
+	var backups []*Backup
+	result := r.db.WithContext(ctx).Order("started_at DESC").Limit(limit).Find(&backups)
+	return backups, result.Error
+}
+
+// GetLastCompleted retrieves the most recent completed backup, or nil when there is none
+func (r *BackupRepository) GetLastCompleted(ctx context.Context) (*Backup, error) {
+	var backup Backup
+	err := r.db.WithContext(ctx).Where("status = ?", BackupCompleted).Order("started_at DESC").First(&backup).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &backup, nil
+}
+
+// Insert creates a new backup record
+func (r *BackupRepository) Insert(ctx context.Context, backup *Backup) (uint, error) {
+	result := r.db.WithContext(ctx).Create(backup)
+	return backup.ID, result.Error
+}
+
+// Update saves the status of a backup
+func (r *BackupRepository) Update(ctx context.Context, backup *Backup) error {
+	return r.db.WithContext(ctx).Save(backup).Error
+}
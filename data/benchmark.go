@@ -0,0 +1,78 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MinBenchmarkSellers is the number of organizations a benchmark needs so that no single
+// seller's prices can be inferred from it
+const MinBenchmarkSellers = 5
+
+// BenchmarkRepository implements BenchmarkInterface using GORM
+type BenchmarkRepository struct {
+	db *gorm.DB
+}
+
+// NewBenchmarkRepository creates a new instance of BenchmarkRepository
+func NewBenchmarkRepository(db *gorm.DB) BenchmarkInterface {
+	return &BenchmarkRepository{db: db}
+}
+
+// GetPriceBenchmark computes selling price statistics of a mineral since a date across the
+// organizations that consented to share benchmark data, limited to a region unless it is
+// empty. It returns nil when fewer than MinBenchmarkSellers organizations sold the mineral.
+func (r *BenchmarkRepository) GetPriceBenchmark(ctx context.Context, mineralType MineralType, unit string, region string, since time.Time) (*PriceBenchmark, error) {
+	query := `
+		SELECT
+			COUNT(DISTINCT i.user_id) AS sellers,
+			COUNT(*) AS sales,
+			COALESCE(AVG(i.price_per_unit), 0) AS average,
+			COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY i.price_per_unit), 0) AS median,
+			COALESCE(PERCENTILE_CONT(0.25) WITHIN GROUP (ORDER BY i.price_per_unit), 0) AS p25,
+			COALESCE(PERCENTILE_CONT(0.75) WITHIN GROUP (ORDER BY i.price_per_unit), 0) AS p75
+		FROM incomes i
+		JOIN organization_settings s ON s.user_id = i.user_id AND s.deleted_at IS NULL AND s.share_benchmark_data
+		WHERE i.deleted_at IS NULL
+			AND i.mineral_type = ?
+			AND LOWER(i.unit) = LOWER(?)
+			AND i.date >= ?
+			AND i.price_per_unit > 0
+			AND (i.approval_status IS NULL OR i.approval_status = ?)`
+	args := []interface{}{mineralType, unit, since, SaleApproved}
+	if region != "" {
+		query += `
+			AND i.user_id IN (
+				SELECT user_id FROM mine_site_infos
+				WHERE deleted_at IS NULL AND LOWER(TRIM(region)) = LOWER(TRIM(?))
+			)`
+		args = append(args, region)
+	}
+
+	var benchmark PriceBenchmark
+	if err := r.db.WithContext(ctx).Raw(query, args...).Scan(&benchmark).Error; err != nil {
+		return nil, err
+	}
+	if benchmark.Sellers < MinBenchmarkSellers {
+		return nil, nil
+	}
+	benchmark.Region = region
+	return &benchmark, nil
+}
+
+// GetAveragePrice returns a user's average selling price of a mineral since a date and the
+// number of sales it is based on
+func (r *BenchmarkRepository) GetAveragePrice(ctx context.Context, userID uint, mineralType MineralType, unit string, since time.Time) (float64, int64, error) {
+	var result struct {
+		Average float64
+		Sales   int64
+	}
+	err := r.db.WithContext(ctx).Model(&Income{}).
+		Select("COALESCE(AVG(price_per_unit), 0) AS average, COUNT(*) AS sales").
+		Where("user_id = ? AND mineral_type = ? AND LOWER(unit) = LOWER(?) AND date >= ? AND price_per_unit > 0",
+			userID, mineralType, unit, since).
+		Scan(&result).Error
+	return result.Average, result.Sales, err
+}
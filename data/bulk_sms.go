@@ -0,0 +1,162 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BulkSMSRepository implements BulkSMSInterface using GORM
+type BulkSMSRepository struct {
+	db *gorm.DB
+}
+
+// NewBulkSMSRepository creates a new instance of BulkSMSRepository
+func NewBulkSMSRepository(db *gorm.DB) BulkSMSInterface {
+	return &BulkSMSRepository{db: db}
+}
+
+// GetCampaigns retrieves the SMS campaigns of a user, newest first
+func (r *BulkSMSRepository) GetCampaigns(ctx context.Context, userID uint) ([]*SMSCampaign, error) {
+	var campaigns []*SMSCampaign
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&campaigns)
+	return campaigns, result.Error
+}
+
+// GetCampaign retrieves an SMS campaign with its recipients and their delivery status
+func (r *BulkSMSRepository) GetCampaign(ctx context.Context, id uint, userID uint) (*SMSCampaign, error) {
+	var campaign SMSCampaign
+	result := r.db.WithContext(ctx).Preload("Recipients", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Preload("Recipients.Delivery").Where("id = ? AND user_id = ?", id, userID).First(&campaign)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &campaign, nil
+}
+
+// InsertCampaign creates an SMS campaign with its recipients
+func (r *BulkSMSRepository) InsertCampaign(ctx context.Context, campaign *SMSCampaign) (uint, error) {
+	result := r.db.WithContext(ctx).Create(campaign)
+	return campaign.ID, result.Error
+}
+
+// SetRecipientDelivery links a queued recipient to the delivery of its message and saves the
+// final message text
+func (r *BulkSMSRepository) SetRecipientDelivery(ctx context.Context, id uint, deliveryID uint, body string) error {
+	result := r.db.WithContext(ctx).Model(&SMSCampaignRecipient{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"delivery_id": deliveryID,
+		"body":        body,
+	})
+	return result.Error
+}
+
+// GetRecipient retrieves a campaign recipient, e.g. from an opt-out link
+func (r *BulkSMSRepository) GetRecipient(ctx context.Context, id uint) (*SMSCampaignRecipient, error) {
+	var recipient SMSCampaignRecipient
+	result := r.db.WithContext(ctx).First(&recipient, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &recipient, nil
+}
+
+// CountSentSince counts the campaign messages a user queued since a time, for the monthly quota
+func (r *BulkSMSRepository) CountSentSince(ctx context.Context, userID uint, since time.Time) (int64, error) {
+	var count int64
+	result := r.db.WithContext(ctx).Model(&SMSCampaignRecipient{}).
+		Where("user_id = ? AND status = ? AND created_at >= ?", userID, SMSRecipientQueued, since).Count(&count)
+	return count, result.Error
+}
+
+// GetContacts retrieves the phone number of each customer and supplier of a user from the
+// contact book, falling back to the contact on their latest sale or expense. Contacts are
+// returned as recorded, which may include email addresses.
+func (r *BulkSMSRepository) GetContacts(ctx context.Context, userID uint) ([]*SMSContact, error) {
+	var customers []*SMSContact
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT name, phone, type FROM (
+			SELECT customer_name AS name, customer_contact AS phone, 'customer' AS type,
+				ROW_NUMBER() OVER (PARTITION BY customer_name ORDER BY date DESC) AS latest
+			FROM incomes
+			WHERE user_id = ? AND deleted_at IS NULL AND customer_contact <> ''
+		) contacts
+		WHERE latest = 1
+		ORDER BY name`, userID).Scan(&customers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var suppliers []*SMSContact
+	err = r.db.WithContext(ctx).Raw(`
+		SELECT name, phone, type FROM (
+			SELECT supplier_name AS name, supplier_contact AS phone, 'supplier' AS type,
+				ROW_NUMBER() OVER (PARTITION BY supplier_name ORDER BY date DESC) AS latest
+			FROM expenses
+			WHERE user_id = ? AND deleted_at IS NULL AND supplier_contact IS NOT NULL AND supplier_contact <> ''
+		) contacts
+		WHERE latest = 1
+		ORDER BY name`, userID).Scan(&suppliers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var book []*SMSContact
+	err = r.db.WithContext(ctx).Model(&Contact{}).Select("name, phone, type").Where("user_id = ?", userID).
+		Order("name ASC").Scan(&book).Error
+	if err != nil {
+		return nil, err
+	}
+
+	contacts := book
+	inBook := make(map[string]bool, len(book))
+	for _, contact := range book {
+		inBook[contact.Type+":"+contact.Name] = true
+	}
+	for _, contact := range append(customers, suppliers...) {
+		if !inBook[contact.Type+":"+contact.Name] {
+			contacts = append(contacts, contact)
+		}
+	}
+	return contacts, nil
+}
+
+// GetOptOuts retrieves the opted out phone numbers of a user
+func (r *BulkSMSRepository) GetOptOuts(ctx context.Context, userID uint) ([]*SMSOptOut, error) {
+	var optOuts []*SMSOptOut
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&optOuts)
+	return optOuts, result.Error
+}
+
+// GetOptedOutPhones returns the set of phone numbers opted out of a user's campaigns
+func (r *BulkSMSRepository) GetOptedOutPhones(ctx context.Context, userID uint) (map[string]bool, error) {
+	var phones []string
+	if err := r.db.WithContext(ctx).Model(&SMSOptOut{}).Where("user_id = ?", userID).Pluck("phone", &phones).Error; err != nil {
+		return nil, err
+	}
+	optedOut := make(map[string]bool, len(phones))
+	for _, phone := range phones {
+		optedOut[phone] = true
+	}
+	return optedOut, nil
+}
+
+// OptOut records an opt-out; opting out a number twice keeps the first record
+func (r *BulkSMSRepository) OptOut(ctx context.Context, optOut *SMSOptOut) error {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(optOut)
+	return result.Error
+}
+
+// RemoveOptOut removes an opt-out so the number receives campaigns again
+func (r *BulkSMSRepository) RemoveOptOut(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Where("id = ? AND user_id = ?", id, userID).Delete(&SMSOptOut{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
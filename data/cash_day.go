@@ -0,0 +1,323 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrCashDayOpen is returned when opening a cash day while another day of the till or station
+	// is open
+	ErrCashDayOpen = errors.New("a cash day of the till or station is already open")
+	// ErrCashDayExists is returned when opening a cash day for a date the till or station already
+	// had
+	ErrCashDayExists = errors.New("the till or station already had a cash day on this date")
+	// ErrCashDayClosed is returned when changing a cash day that has been closed
+	ErrCashDayClosed = errors.New("the cash day is closed")
+)
+
+// CashDayRepository implements CashDayInterface using GORM
+type CashDayRepository struct {
+	db *gorm.DB
+}
+
+// NewCashDayRepository creates a new instance of CashDayRepository
+func NewCashDayRepository(db *gorm.DB) CashDayInterface {
+	return &CashDayRepository{db: db}
+}
+
+// GetAll retrieves the cash days of a user, newest first. Only the days of the station siteID, of
+// the till tillID and with status are included when they are set.
+func (r *CashDayRepository) GetAll(ctx context.Context, userID uint, siteID *uint, tillID *uint, status CashDayStatus) ([]*CashDay, error) {
+	var days []*CashDay
+	query := scopeToSite(r.db.WithContext(ctx).Where("user_id = ?", userID), siteID)
+	if tillID != nil {
+		query = query.Where("till_id = ?", *tillID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	result := query.Order("date DESC, id DESC").Find(&days)
+	return days, result.Error
+}
+
+// GetOne retrieves a cash day of a user with its cash movements. The position of an open day is
+// computed from the day's cash payments so far.
+func (r *CashDayRepository) GetOne(ctx context.Context, id uint, userID uint) (*CashDay, error) {
+	var day CashDay
+	result := r.db.WithContext(ctx).Preload("Movements", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC, id ASC")
+	}).Where("id = ? AND user_id = ?", id, userID).First(&day)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if day.Status == CashDayOpen {
+		if err := computeCashPosition(r.db.WithContext(ctx), &day); err != nil {
+			return nil, err
+		}
+	}
+	return &day, nil
+}
+
+// Open opens a cash day with its opening float. It returns ErrCashDayOpen when another day of the
+// till, or of the station's cash not in a till, is still open, and ErrCashDayExists when it
+// already had a day on the date.
+func (r *CashDayRepository) Open(ctx context.Context, day *CashDay) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var open int64
+		err := scopeToTill(tx.Model(&CashDay{}), day).
+			Where("user_id = ? AND status = ?", day.UserID, CashDayOpen).Count(&open).Error
+		if err != nil {
+			return err
+		}
+		if open > 0 {
+			return ErrCashDayOpen
+		}
+		var existing int64
+		err = scopeToTill(tx.Model(&CashDay{}), day).
+			Where("user_id = ? AND date = ?", day.UserID, day.Date).Count(&existing).Error
+		if err != nil {
+			return err
+		}
+		if existing > 0 {
+			return ErrCashDayExists
+		}
+		day.Status = CashDayOpen
+		return tx.Create(day).Error
+	})
+}
+
+// AddMovement records cash put into or taken out of the till of an open cash day. It returns
+// ErrCashDayClosed when the day has been closed.
+func (r *CashDayRepository) AddMovement(ctx context.Context, movement *CashMovement) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var day CashDay
+		if err := tx.Where("id = ? AND user_id = ?", movement.CashDayID, movement.UserID).First(&day).Error; err != nil {
+			return err
+		}
+		if day.Status != CashDayOpen {
+			return ErrCashDayClosed
+		}
+		return tx.Create(movement).Error
+	})
+}
+
+// Close closes an open cash day with the cash counted, reconciling it against the cash expected
+// from the opening float, the day's cash payments and the cash movements. It returns
+// ErrCashDayClosed when the day has already been closed.
+func (r *CashDayRepository) Close(ctx context.Context, day *CashDay) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := computeCashPosition(tx, day); err != nil {
+			return err
+		}
+		discrepancy := math.Round((*day.CountedCash-day.ExpectedCash)*100) / 100
+		day.Discrepancy = &discrepancy
+		day.Status = CashDayClosed
+
+		result := tx.Model(&CashDay{}).Where("id = ? AND user_id = ? AND status = ?", day.ID, day.UserID, CashDayOpen).
+			Updates(map[string]interface{}{
+				"status":             day.Status,
+				"sales_received":     day.SalesReceived,
+				"purchases_paid":     day.PurchasesPaid,
+				"expenses_paid":      day.ExpensesPaid,
+				"movements_in":       day.MovementsIn,
+				"movements_out":      day.MovementsOut,
+				"expected_cash":      day.ExpectedCash,
+				"counted_cash":       day.CountedCash,
+				"discrepancy":        day.Discrepancy,
+				"discrepancy_reason": day.DiscrepancyReason,
+				"closed_by_id":       day.ClosedByID,
+				"closed_at":          day.ClosedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrCashDayClosed
+		}
+		return nil
+	})
+}
+
+// GetDiscrepancyReport sums the discrepancies of the cash days closed between from and to,
+// inclusive, of the station siteID when it is set
+func (r *CashDayRepository) GetDiscrepancyReport(ctx context.Context, userID uint, siteID *uint, from, to time.Time) (*CashDiscrepancyReport, error) {
+	var days []*CashDay
+	query := scopeToSite(r.db.WithContext(ctx).Where("user_id = ? AND status = ? AND date >= ? AND date <= ?", userID, CashDayClosed, from, to), siteID)
+	if err := query.Order("date ASC, id ASC").Find(&days).Error; err != nil {
+		return nil, err
+	}
+
+	report := &CashDiscrepancyReport{From: from, To: to, DaysClosed: len(days), Discrepant: []*CashDay{}}
+	for _, day := range days {
+		if day.Discrepancy == nil || math.Abs(*day.Discrepancy) < paymentTolerance {
+			continue
+		}
+		if *day.Discrepancy < 0 {
+			report.Shortages -= *day.Discrepancy
+		} else {
+			report.Overages += *day.Discrepancy
+		}
+		report.Discrepant = append(report.Discrepant, day)
+	}
+	report.Net = math.Round((report.Overages-report.Shortages)*100) / 100
+	return report, nil
+}
+
+// computeCashPosition sets the cash a day's till is expected to hold: the opening float, plus
+// cash received on sales, less cash paid on purchases and expenses, plus or less the cash
+// movements. Payments count as cash unless another method was recorded; amounts paid on the spot
+// when a sale or expense was recorded count as cash on the record's date. A till's day counts
+// what went through the till; a station's day counts what went through none.
+func computeCashPosition(tx *gorm.DB, day *CashDay) error {
+	start := day.Date
+	end := start.AddDate(0, 0, 1)
+
+	var payments []*Payment
+	query := tx.Where("user_id = ? AND date >= ? AND date < ?", day.UserID, start, end)
+	if day.TillID != nil {
+		query = query.Where("till_id = ?", *day.TillID)
+	} else {
+		query = query.Where("till_id IS NULL")
+	}
+	if err := query.Find(&payments).Error; err != nil {
+		return err
+	}
+	paid := map[TransactionType]map[uint]float64{}
+	for _, payment := range payments {
+		if !isCashMethod(payment.Method) {
+			continue
+		}
+		if paid[payment.RecordType] == nil {
+			paid[payment.RecordType] = map[uint]float64{}
+		}
+		paid[payment.RecordType][payment.RecordID] += payment.Amount
+	}
+
+	sales, err := stationCash(tx, &Income{}, TransactionIncome, day, paid[TransactionIncome])
+	if err != nil {
+		return err
+	}
+	expenses, err := stationCash(tx, &Expense{}, TransactionExpense, day, paid[TransactionExpense])
+	if err != nil {
+		return err
+	}
+	purchases, err := stationCash(tx, &Purchase{}, TransactionPurchase, day, paid[TransactionPurchase])
+	if err != nil {
+		return err
+	}
+
+	var movements []*CashMovement
+	if err := tx.Where("cash_day_id = ?", day.ID).Find(&movements).Error; err != nil {
+		return err
+	}
+	day.MovementsIn, day.MovementsOut = 0, 0
+	for _, movement := range movements {
+		if movement.Direction == CashIn {
+			day.MovementsIn += movement.Amount
+		} else {
+			day.MovementsOut += movement.Amount
+		}
+	}
+
+	day.SalesReceived = math.Round(sales*100) / 100
+	day.ExpensesPaid = math.Round(expenses*100) / 100
+	day.PurchasesPaid = math.Round(purchases*100) / 100
+	expected := day.OpeningFloat + day.SalesReceived - day.ExpensesPaid - day.PurchasesPaid + day.MovementsIn - day.MovementsOut
+	day.ExpectedCash = math.Round(expected*100) / 100
+	return nil
+}
+
+// stationCash returns the cash paid on the records of a type at a day's till or station: the
+// cash payments of the day on them, and what was paid on the spot on those dated that day
+func stationCash(tx *gorm.DB, model interface{}, recordType TransactionType, day *CashDay, paid map[uint]float64) (float64, error) {
+	total := 0.0
+	if day.TillID != nil {
+		// The payments of a till's day all went through the till
+		for _, amount := range paid {
+			total += amount
+		}
+	} else if len(paid) > 0 {
+		ids := make([]uint, 0, len(paid))
+		for id := range paid {
+			ids = append(ids, id)
+		}
+		var atStation []uint
+		err := scopeToStation(tx.Model(model), day.MineSiteID).Where("user_id = ? AND id IN ?", day.UserID, ids).
+			Pluck("id", &atStation).Error
+		if err != nil {
+			return 0, err
+		}
+		for _, id := range atStation {
+			total += paid[id]
+		}
+	}
+
+	// Purchases record what was paid on the spot as their first payment
+	if recordType == TransactionPurchase {
+		return total, nil
+	}
+	var records []struct {
+		ID         uint
+		AmountPaid float64
+	}
+	err := scopeToTill(tx.Model(model), day).
+		Where("user_id = ? AND date >= ? AND date < ? AND amount_paid > 0", day.UserID, day.Date, day.Date.AddDate(0, 0, 1)).
+		Select("id, amount_paid").Find(&records).Error
+	if err != nil || len(records) == 0 {
+		return total, err
+	}
+	ids := make([]uint, len(records))
+	for i, record := range records {
+		ids[i] = record.ID
+	}
+	var payments []struct {
+		RecordID uint
+		Total    float64
+	}
+	err = tx.Model(&Payment{}).Select("record_id, SUM(amount) AS total").
+		Where("user_id = ? AND record_type = ? AND record_id IN ?", day.UserID, recordType, ids).
+		Group("record_id").Find(&payments).Error
+	if err != nil {
+		return 0, err
+	}
+	appended := make(map[uint]float64, len(payments))
+	for _, payment := range payments {
+		appended[payment.RecordID] = payment.Total
+	}
+	for _, record := range records {
+		if onTheSpot := record.AmountPaid - appended[record.ID]; onTheSpot > paymentTolerance {
+			total += onTheSpot
+		}
+	}
+	return total, nil
+}
+
+// isCashMethod reports whether a payment method is cash. Payments without a method are taken to
+// be in cash.
+func isCashMethod(method *string) bool {
+	return method == nil || strings.TrimSpace(*method) == "" || strings.EqualFold(strings.TrimSpace(*method), "cash")
+}
+
+// scopeToStation narrows a query to the records of a buying station, or to those not at any
+// station when siteID is nil
+func scopeToStation(query *gorm.DB, siteID *uint) *gorm.DB {
+	if siteID == nil {
+		return query.Where("mine_site_id IS NULL")
+	}
+	return query.Where("mine_site_id = ?", *siteID)
+}
+
+// scopeToTill narrows a query to the records of a cash day's till, or to those of the day's
+// station that went through no till when the day isn't a till's
+func scopeToTill(query *gorm.DB, day *CashDay) *gorm.DB {
+	if day.TillID != nil {
+		return query.Where("till_id = ?", *day.TillID)
+	}
+	return scopeToStation(query, day.MineSiteID).Where("till_id IS NULL")
+}
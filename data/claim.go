@@ -0,0 +1,238 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrClaimReviewed is returned when changing or reviewing a claim that is no longer submitted
+	ErrClaimReviewed = errors.New("claim has already been reviewed")
+	// ErrClaimNoReceipt is returned when approving a claim without a receipt attached
+	ErrClaimNoReceipt = errors.New("claim has no receipt attached")
+)
+
+// ClaimRepository implements ClaimInterface using GORM
+type ClaimRepository struct {
+	db *gorm.DB
+}
+
+// NewClaimRepository creates a new instance of ClaimRepository
+func NewClaimRepository(db *gorm.DB) ClaimInterface {
+	return &ClaimRepository{db: db}
+}
+
+// GetAll retrieves the reimbursement claims of a user's books, newest first, with the expenses
+// approved claims are booked as. Only the claims of claimantID and in status are included when
+// they are set.
+func (r *ClaimRepository) GetAll(ctx context.Context, userID uint, claimantID *uint, status *ClaimStatus) ([]*ExpenseClaim, error) {
+	query := r.db.WithContext(ctx).Preload("Expense").Where("user_id = ?", userID)
+	if claimantID != nil {
+		query = query.Where("claimant_id = ?", *claimantID)
+	}
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+	var claims []*ExpenseClaim
+	result := query.Order("date DESC, id DESC").Find(&claims)
+	return claims, result.Error
+}
+
+// GetOne retrieves a reimbursement claim by ID for a user, with the expense it is booked as
+func (r *ClaimRepository) GetOne(ctx context.Context, id uint, userID uint) (*ExpenseClaim, error) {
+	var claim ExpenseClaim
+	result := r.db.WithContext(ctx).Preload("Expense").Where("id = ? AND user_id = ?", id, userID).First(&claim)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &claim, nil
+}
+
+// Insert submits a new reimbursement claim
+func (r *ClaimRepository) Insert(ctx context.Context, claim *ExpenseClaim) (uint, error) {
+	claim.Status = ClaimSubmitted
+	result := r.db.WithContext(ctx).Create(claim)
+	return claim.ID, result.Error
+}
+
+// Update updates a reimbursement claim. It returns ErrClaimReviewed when the claim has been
+// reviewed since it was read.
+func (r *ClaimRepository) Update(ctx context.Context, claim *ExpenseClaim) error {
+	result := r.db.WithContext(ctx).Model(&ExpenseClaim{}).
+		Where("id = ? AND user_id = ? AND status = ?", claim.ID, claim.UserID, ClaimSubmitted).
+		Updates(map[string]interface{}{
+			"date":          claim.Date,
+			"category":      claim.Category,
+			"description":   claim.Description,
+			"amount":        claim.Amount,
+			"supplier_name": claim.SupplierName,
+			"notes":         claim.Notes,
+			"mine_site_id":  claim.MineSiteID,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrClaimReviewed
+	}
+	return nil
+}
+
+// Delete soft deletes a reimbursement claim awaiting review, returning ErrClaimReviewed when it
+// has been reviewed
+func (r *ClaimRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ? AND status = ?", id, userID, ClaimSubmitted).Delete(&ExpenseClaim{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrClaimReviewed
+	}
+	return nil
+}
+
+// Approve approves a submitted reimbursement claim, booking it as an unpaid expense owed to the
+// claimant. The expense awaits sign-off when signOff is pending. It returns ErrClaimReviewed when
+// the claim isn't submitted and ErrClaimNoReceipt when it has no receipt attached.
+func (r *ClaimRepository) Approve(ctx context.Context, id uint, userID uint, reviewerID uint, signOff *SignOffStatus) (*ExpenseClaim, error) {
+	var claim ExpenseClaim
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND user_id = ?", id, userID).First(&claim).Error; err != nil {
+			return err
+		}
+		if claim.Status != ClaimSubmitted {
+			return ErrClaimReviewed
+		}
+		var receipts int64
+		err := tx.Model(&Attachment{}).
+			Where("user_id = ? AND record_type = ? AND record_id = ? AND kind = ?", userID, AttachmentRecordClaim, id, AttachmentReceipt).
+			Count(&receipts).Error
+		if err != nil {
+			return err
+		}
+		if receipts == 0 {
+			return ErrClaimNoReceipt
+		}
+
+		var claimant User
+		if err := tx.Select("id, name, phone").First(&claimant, claim.ClaimantID).Error; err != nil {
+			return err
+		}
+		notes := fmt.Sprintf("Reimbursement claim %d", claim.ID)
+		if claim.SupplierName != nil {
+			notes += fmt.Sprintf(", paid at %s", *claim.SupplierName)
+		}
+		expense := &Expense{
+			Date:            claim.Date,
+			Category:        claim.Category,
+			Description:     claim.Description,
+			Amount:          claim.Amount,
+			SupplierName:    claimant.Name,
+			SupplierContact: claimant.Phone,
+			PaymentStatus:   PaymentUnpaid,
+			AmountDue:       claim.Amount,
+			Notes:           &notes,
+			MineSiteID:      claim.MineSiteID,
+			UserID:          userID,
+			SignOffStatus:   signOff,
+		}
+		if err := createExpense(tx, expense); err != nil {
+			return err
+		}
+		if expense.AwaitingSignOff() {
+			if err := storeSignOffEvent(tx, OutboxSignOffRequired, expense); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		claim.Status = ClaimApproved
+		claim.ReviewedByID = &reviewerID
+		claim.ReviewedAt = &now
+		claim.ExpenseID = &expense.ID
+		if err := tx.Omit("Expense").Save(&claim).Error; err != nil {
+			return err
+		}
+		claim.Expense = expense
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &claim, nil
+}
+
+// Reject rejects a submitted reimbursement claim with a reason. It returns ErrClaimReviewed when
+// the claim isn't submitted.
+func (r *ClaimRepository) Reject(ctx context.Context, id uint, userID uint, reviewerID uint, reason string) error {
+	result := r.db.WithContext(ctx).Model(&ExpenseClaim{}).
+		Where("id = ? AND user_id = ? AND status = ?", id, userID, ClaimSubmitted).
+		Updates(map[string]interface{}{
+			"status":           ClaimRejected,
+			"reviewed_by_id":   reviewerID,
+			"reviewed_at":      time.Now(),
+			"rejection_reason": reason,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrClaimReviewed
+	}
+	return nil
+}
+
+// GetStatement builds the reimbursement statement of a staff member: their claims and the
+// payouts made on the approved ones
+func (r *ClaimRepository) GetStatement(ctx context.Context, userID uint, claimantID uint) (*ClaimantStatement, error) {
+	var claims []*ExpenseClaim
+	err := r.db.WithContext(ctx).Preload("Expense").Where("user_id = ? AND claimant_id = ?", userID, claimantID).
+		Order("date ASC, id ASC").Find(&claims).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(claims) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	var claimant User
+	if err := r.db.WithContext(ctx).Select("id, name").First(&claimant, claimantID).Error; err != nil {
+		return nil, err
+	}
+
+	statement := &ClaimantStatement{
+		ClaimantID:   claimantID,
+		ClaimantName: claimant.Name,
+		Claims:       claims,
+		Payments:     []*Payment{},
+	}
+	var expenseIDs []uint
+	for _, claim := range claims {
+		switch claim.Status {
+		case ClaimSubmitted:
+			statement.Submitted += claim.Amount
+		case ClaimApproved:
+			// Claims whose expense has since been deleted are no longer owed
+			if claim.Expense != nil {
+				statement.TotalClaimed += claim.Expense.Amount
+				statement.TotalPaid += claim.Expense.AmountPaid
+				expenseIDs = append(expenseIDs, claim.Expense.ID)
+			}
+		}
+	}
+	statement.Balance = statement.TotalClaimed - statement.TotalPaid
+
+	if len(expenseIDs) > 0 {
+		err = r.db.WithContext(ctx).Where("user_id = ? AND record_type = ? AND record_id IN ?", userID, TransactionExpense, expenseIDs).
+			Order("date ASC, id ASC").Find(&statement.Payments).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	return statement, nil
+}
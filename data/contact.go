@@ -0,0 +1,141 @@
+package data
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrDuplicatePhone is returned when another contact already has the phone number
+var ErrDuplicatePhone = errors.New("a contact with this phone number already exists")
+
+// ContactRepository implements ContactInterface using GORM
+type ContactRepository struct {
+	db *gorm.DB
+}
+
+// NewContactRepository creates a new instance of ContactRepository
+func NewContactRepository(db *gorm.DB) ContactInterface {
+	return &ContactRepository{db: db}
+}
+
+// GetAll retrieves the contacts of a user by name, optionally only customers or suppliers
+func (r *ContactRepository) GetAll(ctx context.Context, userID uint, contactType ContactType) ([]*Contact, error) {
+	var contacts []*Contact
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if contactType != "" {
+		query = query.Where("type = ?", contactType)
+	}
+	result := query.Order("name ASC").Find(&contacts)
+	return contacts, result.Error
+}
+
+// GetOne retrieves a contact by ID for a user
+func (r *ContactRepository) GetOne(ctx context.Context, id uint, userID uint) (*Contact, error) {
+	var contact Contact
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&contact)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &contact, nil
+}
+
+// Insert creates a contact, rejecting a phone number already in the contact book
+func (r *ContactRepository) Insert(ctx context.Context, contact *Contact) (uint, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkContactPhone(tx, contact); err != nil {
+			return err
+		}
+		return tx.Create(contact).Error
+	})
+	return contact.ID, err
+}
+
+// Update updates a contact, rejecting a phone number used by another contact
+func (r *ContactRepository) Update(ctx context.Context, contact *Contact) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkContactPhone(tx, contact); err != nil {
+			return err
+		}
+		return tx.Save(contact).Error
+	})
+}
+
+// Delete removes a contact. Contacts are deleted permanently so the phone number can be added again.
+func (r *ContactRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Where("id = ? AND user_id = ?", id, userID).Delete(&Contact{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Import adds contacts to the contact book, deduplicating by phone number. A contact whose
+// phone number is already in the contact book only fills in the existing contact's missing
+// email, company and notes.
+func (r *ContactRepository) Import(ctx context.Context, userID uint, contacts []*Contact) (*ContactImportResult, error) {
+	result := &ContactImportResult{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing []*Contact
+		if err := tx.Where("user_id = ?", userID).Find(&existing).Error; err != nil {
+			return err
+		}
+		byPhone := make(map[string]*Contact, len(existing))
+		for _, contact := range existing {
+			byPhone[contact.Phone] = contact
+		}
+
+		for _, contact := range contacts {
+			contact.UserID = userID
+			current, found := byPhone[contact.Phone]
+			if !found {
+				if err := tx.Create(contact).Error; err != nil {
+					return err
+				}
+				byPhone[contact.Phone] = contact
+				result.Created++
+				continue
+			}
+
+			changed := false
+			if current.Email == nil && contact.Email != nil {
+				current.Email, changed = contact.Email, true
+			}
+			if current.Company == nil && contact.Company != nil {
+				current.Company, changed = contact.Company, true
+			}
+			if current.Notes == nil && contact.Notes != nil {
+				current.Notes, changed = contact.Notes, true
+			}
+			if changed {
+				if err := tx.Save(current).Error; err != nil {
+					return err
+				}
+			}
+			result.Merged++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// checkContactPhone returns ErrDuplicatePhone when another contact of the user has the phone number
+func checkContactPhone(tx *gorm.DB, contact *Contact) error {
+	var count int64
+	err := tx.Model(&Contact{}).Where("user_id = ? AND phone = ? AND id <> ?", contact.UserID, contact.Phone, contact.ID).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrDuplicatePhone
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package data
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+// ContractorRepository implements ContractorInterface using GORM
+type ContractorRepository struct {
+	db *gorm.DB
+}
+
+// NewContractorRepository creates a new instance of ContractorRepository
+func NewContractorRepository(db *gorm.DB) ContractorInterface {
+	return &ContractorRepository{db: db}
+}
+
+// GetAll retrieves all contractors for a user
+func (r *ContractorRepository) GetAll(ctx context.Context, userID uint) ([]*Contractor, error) {
+	var contractors []*Contractor
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("name ASC").Find(&contractors)
+	return contractors, result.Error
+}
+
+// GetOne retrieves a specific contractor by ID for a user
+func (r *ContractorRepository) GetOne(ctx context.Context, id uint, userID uint) (*Contractor, error) {
+	var contractor Contractor
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&contractor)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &contractor, nil
+}
+
+// Insert creates a new contractor
+func (r *ContractorRepository) Insert(ctx context.Context, contractor *Contractor) (uint, error) {
+	result := r.db.WithContext(ctx).Create(contractor)
+	return contractor.ID, result.Error
+}
+
+// Update updates an existing contractor
+func (r *ContractorRepository) Update(ctx context.Context, contractor *Contractor) error {
+	result := r.db.WithContext(ctx).Save(contractor)
+	return result.Error
+}
+
+// Delete soft deletes a contractor
+func (r *ContractorRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&Contractor{})
+	return result.Error
+}
+
+// RecordWork records work done together with the labor expense it generates
+func (r *ContractorRepository) RecordWork(ctx context.Context, work *WorkRecord, expense *Expense) (uint, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		expense.AmountDue = expense.Amount - expense.AmountPaid
+		if err := createExpense(tx, expense); err != nil {
+			return err
+		}
+
+		work.ExpenseID = expense.ID
+		return tx.Omit("Expense").Create(work).Error
+	})
+	work.Expense = *expense
+	return work.ID, err
+}
+
+// GetWork retrieves the work records of a contractor with their labor expenses
+func (r *ContractorRepository) GetWork(ctx context.Context, contractorID uint, userID uint) ([]*WorkRecord, error) {
+	var work []*WorkRecord
+	result := r.db.WithContext(ctx).Preload("Expense").Where("contractor_id = ? AND user_id = ?", contractorID, userID).
+		Order("date DESC").Find(&work)
+	return work, result.Error
+}
+
+// DeleteWork soft deletes a work record and its generated labor expense
+func (r *ContractorRepository) DeleteWork(ctx context.Context, id uint, contractorID uint, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var work WorkRecord
+		err := tx.Where("id = ? AND contractor_id = ? AND user_id = ?", id, contractorID, userID).First(&work).Error
+		if err != nil {
+			return err
+		}
+		if err := deleteExpense(tx, work.ExpenseID, userID); err != nil {
+			return err
+		}
+		return tx.Delete(&work).Error
+	})
+}
+
+// GetStatement builds the work done versus paid statement of a contractor
+func (r *ContractorRepository) GetStatement(ctx context.Context, id uint, userID uint) (*ContractorStatement, error) {
+	contractor, err := r.GetOne(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	work, err := r.GetWork(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &ContractorStatement{
+		Contractor: contractor,
+		Lines:      work,
+	}
+	for _, line := range work {
+		statement.TotalWork += line.Quantity
+		statement.TotalAmount += line.Amount
+		statement.TotalPaid += line.Expense.AmountPaid
+	}
+	statement.Balance = statement.TotalAmount - statement.TotalPaid
+
+	return statement, nil
+}
@@ -0,0 +1,91 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CostAllocationRepository implements CostAllocationInterface using GORM
+type CostAllocationRepository struct {
+	db *gorm.DB
+}
+
+// NewCostAllocationRepository creates a new instance of CostAllocationRepository
+func NewCostAllocationRepository(db *gorm.DB) CostAllocationInterface {
+	return &CostAllocationRepository{db: db}
+}
+
+// GetAll retrieves the cost allocation rules of a user, oldest first
+func (r *CostAllocationRepository) GetAll(ctx context.Context, userID uint) ([]*CostAllocationRule, error) {
+	var rules []*CostAllocationRule
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id ASC").Find(&rules)
+	return rules, result.Error
+}
+
+// GetOne retrieves a cost allocation rule by ID for a user
+func (r *CostAllocationRepository) GetOne(ctx context.Context, id uint, userID uint) (*CostAllocationRule, error) {
+	var rule CostAllocationRule
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&rule)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &rule, nil
+}
+
+// Insert creates a new cost allocation rule
+func (r *CostAllocationRepository) Insert(ctx context.Context, rule *CostAllocationRule) (uint, error) {
+	result := r.db.WithContext(ctx).Create(rule)
+	return rule.ID, result.Error
+}
+
+// Update updates a cost allocation rule
+func (r *CostAllocationRepository) Update(ctx context.Context, rule *CostAllocationRule) error {
+	return r.db.WithContext(ctx).Save(rule).Error
+}
+
+// Delete soft deletes a cost allocation rule of a user
+func (r *CostAllocationRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	return r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&CostAllocationRule{}).Error
+}
+
+// GetSiteIncome retrieves the income and mineral sales of each mine site in the period [start, end)
+func (r *CostAllocationRepository) GetSiteIncome(ctx context.Context, userID uint, start, end time.Time) ([]*SiteIncome, error) {
+	var income []*SiteIncome
+	result := r.db.WithContext(ctx).Raw(`
+		SELECT
+			mine_site_id,
+			COALESCE(SUM(total_amount), 0) as income,
+			COALESCE(SUM(CASE WHEN sales_type = ? THEN total_amount ELSE 0 END), 0) as mineral_sales
+		FROM incomes
+		WHERE user_id = ? AND date >= ? AND date < ? AND deleted_at IS NULL
+		GROUP BY mine_site_id
+	`, SalesTypeMineral, userID, start, end).Scan(&income)
+	return income, result.Error
+}
+
+// GetSiteExpenses retrieves the expenses of each mine site and category recognized in the period
+// [start, end), as in the monthly data: prepaid expenses through their allocations and equipment
+// through its depreciation
+func (r *CostAllocationRepository) GetSiteExpenses(ctx context.Context, userID uint, start, end time.Time) ([]*SiteExpense, error) {
+	var expenses []*SiteExpense
+	result := r.db.WithContext(ctx).Raw(`
+		SELECT mine_site_id, category, COALESCE(SUM(amount), 0) as amount
+		FROM (
+			SELECT mine_site_id, category, amount FROM expenses
+			WHERE user_id = ? AND date >= ? AND date < ? AND deleted_at IS NULL AND prepaid_months IS NULL
+				AND id NOT IN (`+capitalizedExpenses+`)
+			UNION ALL
+			SELECT expenses.mine_site_id, expense_allocations.category, expense_allocations.amount
+			FROM expense_allocations JOIN expenses ON expenses.id = expense_allocations.expense_id
+			WHERE expense_allocations.user_id = ? AND expense_allocations.date >= ? AND expense_allocations.date < ?
+			UNION ALL
+			SELECT equipment.mine_site_id, '`+string(ExpenseEquipment)+`', depreciation_entries.amount
+			FROM depreciation_entries JOIN equipment ON equipment.id = depreciation_entries.equipment_id
+			WHERE depreciation_entries.user_id = ? AND depreciation_entries.date >= ? AND depreciation_entries.date < ?
+		) recognized
+		GROUP BY mine_site_id, category
+	`, userID, start, end, userID, userID, start, end, userID, start, end).Scan(&expenses)
+	return expenses, result.Error
+}
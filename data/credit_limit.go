@@ -0,0 +1,84 @@
+package data
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreditLimitRepository implements CreditLimitInterface using GORM
+type CreditLimitRepository struct {
+	db *gorm.DB
+}
+
+// NewCreditLimitRepository creates a new instance of CreditLimitRepository
+func NewCreditLimitRepository(db *gorm.DB) CreditLimitInterface {
+	return &CreditLimitRepository{db: db}
+}
+
+// GetAll retrieves the credit limits of a user with each customer's outstanding balance
+func (r *CreditLimitRepository) GetAll(ctx context.Context, userID uint) ([]*CustomerCreditLimit, error) {
+	var limits []*CustomerCreditLimit
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("customer_name ASC").Find(&limits).Error; err != nil {
+		return nil, err
+	}
+
+	var balances []struct {
+		CustomerName string
+		Outstanding  float64
+	}
+	err := r.db.WithContext(ctx).Model(&Income{}).Select("customer_name, COALESCE(SUM(amount_due), 0) AS outstanding").
+		Where("user_id = ? AND amount_due > 0 AND payment_status <> ?", userID, PaymentPaid).
+		Group("customer_name").Scan(&balances).Error
+	if err != nil {
+		return nil, err
+	}
+	outstanding := make(map[string]float64, len(balances))
+	for _, balance := range balances {
+		outstanding[balance.CustomerName] = balance.Outstanding
+	}
+	for _, limit := range limits {
+		limit.Outstanding = outstanding[limit.CustomerName]
+	}
+	return limits, nil
+}
+
+// GetByCustomer retrieves the credit limit of a customer
+func (r *CreditLimitRepository) GetByCustomer(ctx context.Context, userID uint, customerName string) (*CustomerCreditLimit, error) {
+	var limit CustomerCreditLimit
+	result := r.db.WithContext(ctx).Where("user_id = ? AND customer_name = ?", userID, customerName).First(&limit)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &limit, nil
+}
+
+// Save creates or replaces the credit limit of a customer
+func (r *CreditLimitRepository) Save(ctx context.Context, limit *CustomerCreditLimit) error {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "customer_name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"credit_limit", "updated_at"}),
+	}).Create(limit)
+	return result.Error
+}
+
+// Delete removes a credit limit. Limits are deleted permanently so the customer can get a new one.
+func (r *CreditLimitRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Where("id = ? AND user_id = ?", id, userID).Delete(&CustomerCreditLimit{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetOutstanding returns the unpaid balance of a customer's sales
+func (r *CreditLimitRepository) GetOutstanding(ctx context.Context, userID uint, customerName string) (float64, error) {
+	var outstanding float64
+	result := r.db.WithContext(ctx).Model(&Income{}).Select("COALESCE(SUM(amount_due), 0)").
+		Where("user_id = ? AND customer_name = ? AND amount_due > 0 AND payment_status <> ?", userID, customerName, PaymentPaid).
+		Scan(&outstanding)
+	return outstanding, result.Error
+}
@@ -0,0 +1,95 @@
+package data
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrCreditExceedsDue is returned when a credit note is for more than the amount due on the sale
+var ErrCreditExceedsDue = errors.New("credit note is for more than the amount due")
+
+// CreditNoteRepository implements CreditNoteInterface using GORM
+type CreditNoteRepository struct {
+	db *gorm.DB
+}
+
+// NewCreditNoteRepository creates a new instance of CreditNoteRepository
+func NewCreditNoteRepository(db *gorm.DB) CreditNoteInterface {
+	return &CreditNoteRepository{db: db}
+}
+
+// GetAll retrieves all credit notes of a user, newest first
+func (r *CreditNoteRepository) GetAll(ctx context.Context, userID uint) ([]*CreditNote, error) {
+	var notes []*CreditNote
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("issued_at DESC, id DESC").Find(&notes)
+	return notes, result.Error
+}
+
+// GetOne retrieves a credit note by ID for a user
+func (r *CreditNoteRepository) GetOne(ctx context.Context, id uint, userID uint) (*CreditNote, error) {
+	var note CreditNote
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&note)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &note, nil
+}
+
+// GetByIncome retrieves the credit notes issued against an income record
+func (r *CreditNoteRepository) GetByIncome(ctx context.Context, incomeID uint, userID uint) ([]*CreditNote, error) {
+	var notes []*CreditNote
+	result := r.db.WithContext(ctx).Where("income_id = ? AND user_id = ?", incomeID, userID).Order("issued_at, id").Find(&notes)
+	return notes, result.Error
+}
+
+// Insert numbers and issues a credit note against a sale and returns the sale with its total
+// reduced by the credit and its amount due and payment status recomputed. It returns
+// ErrCreditExceedsDue when the credit is more than the amount due.
+func (r *CreditNoteRepository) Insert(ctx context.Context, note *CreditNote) (*Income, error) {
+	var income Income
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND user_id = ?", note.IncomeID, note.UserID).First(&income).Error
+		if err != nil {
+			return err
+		}
+		if note.Amount > income.AmountDue+paymentTolerance {
+			return ErrCreditExceedsDue
+		}
+
+		number, err := nextDocumentNumber(tx, note.UserID, DocumentCreditNote, note.IssuedAt)
+		if err != nil {
+			return err
+		}
+		note.CreditNoteNumber = number
+		note.InvoiceNumber = income.InvoiceNumber
+		note.CustomerName = income.CustomerName
+		if err := tx.Create(note).Error; err != nil {
+			return err
+		}
+
+		before := income
+		total := income.TotalAmount - note.Amount
+		pricePerUnit := income.PricePerUnit
+		if income.Quantity > 0 {
+			pricePerUnit = total / income.Quantity
+		}
+		status, due := settlement(total, income.AmountPaid)
+		if err := tx.Model(&income).Updates(map[string]interface{}{
+			"total_amount":   total,
+			"price_per_unit": pricePerUnit,
+			"amount_due":     due,
+			"payment_status": status,
+		}).Error; err != nil {
+			return err
+		}
+		income.TotalAmount, income.PricePerUnit, income.AmountDue, income.PaymentStatus = total, pricePerUnit, due, status
+		return recordIncome(tx, EventIncomeUpdated, &income, &before)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &income, nil
+}
@@ -0,0 +1,57 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DeliveryRepository implements DeliveryInterface using GORM
+type DeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewDeliveryRepository creates a new instance of DeliveryRepository
+func NewDeliveryRepository(db *gorm.DB) DeliveryInterface {
+	return &DeliveryRepository{db: db}
+}
+
+// GetAll retrieves the most recent deliveries, optionally for one recipient
+func (r *DeliveryRepository) GetAll(ctx context.Context, recipient string) ([]*MessageDelivery, error) {
+	var deliveries []*MessageDelivery
+	query := r.db.WithContext(ctx).Order("created_at DESC").Limit(200)
+	if recipient != "" {
+		query = query.Where("recipient = ?", recipient)
+	}
+	result := query.Find(&deliveries)
+	return deliveries, result.Error
+}
+
+// Insert records a new queued delivery
+func (r *DeliveryRepository) Insert(ctx context.Context, delivery *MessageDelivery) (uint, error) {
+	delivery.Status = DeliveryQueued
+	result := r.db.WithContext(ctx).Create(delivery)
+	return delivery.ID, result.Error
+}
+
+// MarkSent records a successful delivery over a channel
+func (r *DeliveryRepository) MarkSent(ctx context.Context, id uint, channel DeliveryChannel) error {
+	result := r.db.WithContext(ctx).Model(&MessageDelivery{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":   DeliverySent,
+		"channel":  channel,
+		"attempts": gorm.Expr("attempts + 1"),
+		"sent_at":  time.Now(),
+	})
+	return result.Error
+}
+
+// MarkFailed records a failed delivery attempt
+func (r *DeliveryRepository) MarkFailed(ctx context.Context, id uint, message string) error {
+	result := r.db.WithContext(ctx).Model(&MessageDelivery{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     DeliveryFailed,
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": message,
+	})
+	return result.Error
+}
@@ -0,0 +1,21 @@
+package data
+
+import "gorm.io/gorm"
+
+// monthExpr returns a SQL expression formatting a date column as YYYY-MM in the dialect of the
+// database, so reports work on both Postgres and SQLite
+func monthExpr(db *gorm.DB, column string) string {
+	if db.Dialector.Name() == "sqlite" {
+		return "strftime('%Y-%m', " + column + ")"
+	}
+	return "TO_CHAR(" + column + ", 'YYYY-MM')"
+}
+
+// yearExpr returns a SQL expression extracting the year of a date column as an integer in the
+// dialect of the database
+func yearExpr(db *gorm.DB, column string) string {
+	if db.Dialector.Name() == "sqlite" {
+		return "CAST(strftime('%Y', " + column + ") AS INTEGER)"
+	}
+	return "CAST(EXTRACT(YEAR FROM " + column + ") AS INTEGER)"
+}
@@ -0,0 +1,60 @@
+package data
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// DefaultDocumentTemplate returns the template used until an organization saves its own
+func DefaultDocumentTemplate(userID uint, kind DocumentKind) *DocumentTemplate {
+	return &DocumentTemplate{
+		Kind:         kind,
+		Language:     "en",
+		HiddenFields: []string{},
+		UserID:       userID,
+	}
+}
+
+// Hides reports whether the template leaves a field out
+func (t *DocumentTemplate) Hides(field string) bool {
+	for _, hidden := range t.HiddenFields {
+		if hidden == field {
+			return true
+		}
+	}
+	return false
+}
+
+// DocumentTemplateRepository implements DocumentTemplateInterface using GORM
+type DocumentTemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewDocumentTemplateRepository creates a new instance of DocumentTemplateRepository
+func NewDocumentTemplateRepository(db *gorm.DB) DocumentTemplateInterface {
+	return &DocumentTemplateRepository{db: db}
+}
+
+// Get retrieves the template of a user's documents of a kind, falling back to the default
+func (r *DocumentTemplateRepository) Get(ctx context.Context, userID uint, kind DocumentKind) (*DocumentTemplate, error) {
+	var template DocumentTemplate
+	err := r.db.WithContext(ctx).Where("user_id = ? AND kind = ?", userID, kind).First(&template).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return DefaultDocumentTemplate(userID, kind), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if template.HiddenFields == nil {
+		template.HiddenFields = []string{}
+	}
+	template.HasLogo = template.LogoKey != nil
+	return &template, nil
+}
+
+// Save creates or updates a document template
+func (r *DocumentTemplateRepository) Save(ctx context.Context, template *DocumentTemplate) error {
+	return r.db.WithContext(ctx).Save(template).Error
+}
@@ -0,0 +1,120 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrAssessmentCompleted is returned when changing a due-diligence assessment that is completed
+var ErrAssessmentCompleted = errors.New("due-diligence assessment is completed")
+
+// DueDiligenceRepository implements DueDiligenceInterface using GORM
+type DueDiligenceRepository struct {
+	db *gorm.DB
+}
+
+// NewDueDiligenceRepository creates a new instance of DueDiligenceRepository
+func NewDueDiligenceRepository(db *gorm.DB) DueDiligenceInterface {
+	return &DueDiligenceRepository{db: db}
+}
+
+// GetAll retrieves the due-diligence assessments of a user, or of one of their sites, latest
+// period first and without their responses
+func (r *DueDiligenceRepository) GetAll(ctx context.Context, userID uint, siteID *uint) ([]*DueDiligenceAssessment, error) {
+	var assessments []*DueDiligenceAssessment
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if siteID != nil {
+		query = query.Where("mine_site_id = ?", *siteID)
+	}
+	result := query.Order("period_end DESC, id DESC").Find(&assessments)
+	return assessments, result.Error
+}
+
+// GetOne retrieves a due-diligence assessment with its responses
+func (r *DueDiligenceRepository) GetOne(ctx context.Context, id uint, userID uint) (*DueDiligenceAssessment, error) {
+	var assessment DueDiligenceAssessment
+	result := r.db.WithContext(ctx).Preload("Responses", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Where("id = ? AND user_id = ?", id, userID).First(&assessment)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &assessment, nil
+}
+
+// Insert creates a draft due-diligence assessment with its responses
+func (r *DueDiligenceRepository) Insert(ctx context.Context, assessment *DueDiligenceAssessment) (uint, error) {
+	assessment.Status = DueDiligenceDraft
+	result := r.db.WithContext(ctx).Create(assessment)
+	return assessment.ID, result.Error
+}
+
+// Update updates a draft due-diligence assessment, replacing its responses. It returns
+// ErrAssessmentCompleted when the assessment was completed.
+func (r *DueDiligenceRepository) Update(ctx context.Context, assessment *DueDiligenceAssessment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&DueDiligenceAssessment{}).
+			Where("id = ? AND user_id = ? AND status = ?", assessment.ID, assessment.UserID, DueDiligenceDraft).
+			Updates(map[string]interface{}{
+				"mine_site_id": assessment.MineSiteID,
+				"period_start": assessment.PeriodStart,
+				"period_end":   assessment.PeriodEnd,
+				"notes":        assessment.Notes,
+				"red_flags":    assessment.RedFlags,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrAssessmentCompleted
+		}
+
+		if err := tx.Unscoped().Where("assessment_id = ?", assessment.ID).Delete(&DueDiligenceResponse{}).Error; err != nil {
+			return err
+		}
+		for i := range assessment.Responses {
+			assessment.Responses[i].ID = 0
+			assessment.Responses[i].AssessmentID = assessment.ID
+		}
+		if len(assessment.Responses) > 0 {
+			return tx.Create(&assessment.Responses).Error
+		}
+		return nil
+	})
+}
+
+// Complete marks a draft due-diligence assessment as completed, after which it can no longer be
+// changed. It returns ErrAssessmentCompleted when it already was.
+func (r *DueDiligenceRepository) Complete(ctx context.Context, id uint, userID uint, completedByID uint) error {
+	result := r.db.WithContext(ctx).Model(&DueDiligenceAssessment{}).
+		Where("id = ? AND user_id = ? AND status = ?", id, userID, DueDiligenceDraft).
+		Updates(map[string]interface{}{
+			"status":          DueDiligenceCompleted,
+			"completed_at":    time.Now(),
+			"completed_by_id": completedByID,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAssessmentCompleted
+	}
+	return nil
+}
+
+// Delete deletes a due-diligence assessment with its responses
+func (r *DueDiligenceRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND user_id = ?", id, userID).Delete(&DueDiligenceAssessment{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Where("assessment_id = ?", id).Delete(&DueDiligenceResponse{}).Error
+	})
+}
@@ -0,0 +1,168 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrDunningScheduleExists is returned when the customer, or the default, already has a schedule
+var ErrDunningScheduleExists = errors.New("a dunning schedule already exists for this customer")
+
+// DueAt returns when the step is due for an invoice
+func (s *DunningStep) DueAt(income *Income) time.Time {
+	return income.Date.AddDate(0, 0, s.DayOffset)
+}
+
+// DunningRepository implements DunningInterface using GORM
+type DunningRepository struct {
+	db *gorm.DB
+}
+
+// NewDunningRepository creates a new instance of DunningRepository
+func NewDunningRepository(db *gorm.DB) DunningInterface {
+	return &DunningRepository{db: db}
+}
+
+// GetSchedules retrieves all dunning schedules for a user with their steps
+func (r *DunningRepository) GetSchedules(ctx context.Context, userID uint) ([]*DunningSchedule, error) {
+	var schedules []*DunningSchedule
+	result := r.db.WithContext(ctx).Preload("Steps", orderSteps).Where("user_id = ?", userID).
+		Order("customer_name NULLS FIRST, name").Find(&schedules)
+	return schedules, result.Error
+}
+
+// GetSchedule retrieves a dunning schedule by ID for a user
+func (r *DunningRepository) GetSchedule(ctx context.Context, id uint, userID uint) (*DunningSchedule, error) {
+	var schedule DunningSchedule
+	result := r.db.WithContext(ctx).Preload("Steps", orderSteps).Where("id = ? AND user_id = ?", id, userID).First(&schedule)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &schedule, nil
+}
+
+// CreateSchedule creates a dunning schedule with its steps
+func (r *DunningRepository) CreateSchedule(ctx context.Context, schedule *DunningSchedule) (uint, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkScheduleUnique(tx, schedule); err != nil {
+			return err
+		}
+		return tx.Create(schedule).Error
+	})
+	return schedule.ID, err
+}
+
+// UpdateSchedule updates a dunning schedule, replacing its steps. Steps that are unchanged keep
+// their identity so they do not run again for invoices they already ran for.
+func (r *DunningRepository) UpdateSchedule(ctx context.Context, schedule *DunningSchedule) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkScheduleUnique(tx, schedule); err != nil {
+			return err
+		}
+
+		var existing []DunningStep
+		if err := tx.Where("schedule_id = ?", schedule.ID).Find(&existing).Error; err != nil {
+			return err
+		}
+		kept := map[uint]bool{}
+		for i := range schedule.Steps {
+			step := &schedule.Steps[i]
+			step.ScheduleID = schedule.ID
+			for _, old := range existing {
+				if !kept[old.ID] && old.DayOffset == step.DayOffset && old.Action == step.Action {
+					step.ID = old.ID
+					step.CreatedAt = old.CreatedAt
+					kept[old.ID] = true
+					break
+				}
+			}
+		}
+		for _, old := range existing {
+			if !kept[old.ID] {
+				if err := tx.Delete(&DunningStep{}, old.ID).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(schedule).Error
+	})
+}
+
+// DeleteSchedule soft deletes a dunning schedule and its steps
+func (r *DunningRepository) DeleteSchedule(ctx context.Context, id uint, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND user_id = ?", id, userID).Delete(&DunningSchedule{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Where("schedule_id = ?", id).Delete(&DunningStep{}).Error
+	})
+}
+
+// GetActiveSchedules retrieves the active dunning schedules of all users
+func (r *DunningRepository) GetActiveSchedules(ctx context.Context) ([]*DunningSchedule, error) {
+	var schedules []*DunningSchedule
+	result := r.db.WithContext(ctx).Preload("Steps", orderSteps).Where("active = ?", true).Order("user_id").Find(&schedules)
+	return schedules, result.Error
+}
+
+// GetHistory retrieves the dunning steps executed for an invoice
+func (r *DunningRepository) GetHistory(ctx context.Context, incomeID uint, userID uint) ([]*DunningEvent, error) {
+	var events []*DunningEvent
+	result := r.db.WithContext(ctx).Where("income_id = ? AND user_id = ?", incomeID, userID).Order("created_at").Find(&events)
+	return events, result.Error
+}
+
+// ClaimStep records a pending event for a step and invoice. It returns false when the step
+// already ran for the invoice, so each step executes at most once.
+func (r *DunningRepository) ClaimStep(ctx context.Context, event *DunningEvent) (bool, error) {
+	event.Status = DunningEventPending
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(event)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateEvent saves the outcome of a claimed step
+func (r *DunningRepository) UpdateEvent(ctx context.Context, event *DunningEvent) error {
+	result := r.db.WithContext(ctx).Model(&DunningEvent{}).Where("id = ?", event.ID).Updates(map[string]interface{}{
+		"status":      event.Status,
+		"recipient":   event.Recipient,
+		"detail":      event.Detail,
+		"delivery_id": event.DeliveryID,
+	})
+	return result.Error
+}
+
+// checkScheduleUnique ensures there is at most one schedule per customer and one default schedule
+func checkScheduleUnique(tx *gorm.DB, schedule *DunningSchedule) error {
+	query := tx.Model(&DunningSchedule{}).Where("user_id = ? AND id <> ?", schedule.UserID, schedule.ID)
+	if schedule.CustomerName == nil {
+		query = query.Where("customer_name IS NULL")
+	} else {
+		query = query.Where("customer_name = ?", *schedule.CustomerName)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrDunningScheduleExists
+	}
+	return nil
+}
+
+// orderSteps orders preloaded dunning steps by day
+func orderSteps(db *gorm.DB) *gorm.DB {
+	return db.Order("day_offset, id")
+}
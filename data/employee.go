@@ -0,0 +1,51 @@
+package data
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+// EmployeeRepository implements EmployeeInterface using GORM
+type EmployeeRepository struct {
+	db *gorm.DB
+}
+
+// NewEmployeeRepository creates a new instance of EmployeeRepository
+func NewEmployeeRepository(db *gorm.DB) EmployeeInterface {
+	return &EmployeeRepository{db: db}
+}
+
+// GetAll retrieves all employees for a user
+func (r *EmployeeRepository) GetAll(ctx context.Context, userID uint) ([]*Employee, error) {
+	var employees []*Employee
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("name ASC").Find(&employees)
+	return employees, result.Error
+}
+
+// GetOne retrieves a specific employee by ID for a user
+func (r *EmployeeRepository) GetOne(ctx context.Context, id uint, userID uint) (*Employee, error) {
+	var employee Employee
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&employee)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &employee, nil
+}
+
+// Insert creates a new employee
+func (r *EmployeeRepository) Insert(ctx context.Context, employee *Employee) (uint, error) {
+	result := r.db.WithContext(ctx).Create(employee)
+	return employee.ID, result.Error
+}
+
+// Update updates an existing employee
+func (r *EmployeeRepository) Update(ctx context.Context, employee *Employee) error {
+	result := r.db.WithContext(ctx).Save(employee)
+	return result.Error
+}
+
+// Delete soft deletes an employee
+func (r *EmployeeRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&Employee{})
+	return result.Error
+}
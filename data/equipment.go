@@ -0,0 +1,280 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrExpenseCapitalized is returned when linking an expense another equipment item was bought with
+	ErrExpenseCapitalized = errors.New("expense is already linked to an equipment item")
+	// ErrEquipmentDisposed is returned when disposing of equipment that has already been disposed of
+	ErrEquipmentDisposed = errors.New("equipment has already been disposed of")
+)
+
+// capitalizedExpenses selects the expenses of a user equipment items were bought with, which count
+// in the profit and loss through depreciation instead
+const capitalizedExpenses = `SELECT expense_id FROM equipment WHERE user_id = ? AND expense_id IS NOT NULL AND deleted_at IS NULL`
+
+// EquipmentRepository implements EquipmentInterface using GORM
+type EquipmentRepository struct {
+	db *gorm.DB
+}
+
+// NewEquipmentRepository creates a new instance of EquipmentRepository
+func NewEquipmentRepository(db *gorm.DB) EquipmentInterface {
+	return &EquipmentRepository{db: db}
+}
+
+// GetAll retrieves all equipment items of a user
+func (r *EquipmentRepository) GetAll(ctx context.Context, userID uint) ([]*Equipment, error) {
+	var equipment []*Equipment
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("name ASC").Find(&equipment)
+	return equipment, result.Error
+}
+
+// GetOne retrieves a specific equipment item by ID for a user
+func (r *EquipmentRepository) GetOne(ctx context.Context, id uint, userID uint) (*Equipment, error) {
+	var equipment Equipment
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&equipment)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &equipment, nil
+}
+
+// Insert adds an equipment item to the register with its depreciation entries. It returns
+// ErrExpenseCapitalized when its expense is linked to another item.
+func (r *EquipmentRepository) Insert(ctx context.Context, equipment *Equipment) (uint, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkCapitalized(tx, equipment); err != nil {
+			return err
+		}
+		if err := tx.Create(equipment).Error; err != nil {
+			return err
+		}
+		return depreciateEquipment(tx, equipment)
+	})
+	return equipment.ID, err
+}
+
+// Update updates an equipment item, generating its depreciation entries again. It returns
+// ErrExpenseCapitalized when its expense is linked to another item.
+func (r *EquipmentRepository) Update(ctx context.Context, equipment *Equipment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkCapitalized(tx, equipment); err != nil {
+			return err
+		}
+		if err := tx.Save(equipment).Error; err != nil {
+			return err
+		}
+		return depreciateEquipment(tx, equipment)
+	})
+}
+
+// Delete soft deletes an equipment item and removes its depreciation entries
+func (r *EquipmentRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND user_id = ?", id, userID).Delete(&Equipment{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return tx.Where("equipment_id = ?", id).Delete(&DepreciationEntry{}).Error
+	})
+}
+
+// GetDepreciation retrieves the depreciation entries of an equipment item by month
+func (r *EquipmentRepository) GetDepreciation(ctx context.Context, id uint, userID uint) ([]*DepreciationEntry, error) {
+	var entries []*DepreciationEntry
+	result := r.db.WithContext(ctx).Where("equipment_id = ? AND user_id = ?", id, userID).Order("date ASC").Find(&entries)
+	return entries, result.Error
+}
+
+// GetRegister builds the asset register of a user at a date: the equipment items bought and not
+// disposed of by then with their depreciation up to the month of the date and their book values
+func (r *EquipmentRepository) GetRegister(ctx context.Context, userID uint, asOf time.Time) (*AssetRegister, error) {
+	var equipment []*Equipment
+	err := r.db.WithContext(ctx).Where("user_id = ? AND purchase_date <= ? AND (disposed_at IS NULL OR disposed_at > ?)", userID, asOf, asOf).
+		Order("purchase_date ASC, id ASC").Find(&equipment).Error
+	if err != nil {
+		return nil, err
+	}
+
+	register := &AssetRegister{AsOf: asOf, Items: make([]*EquipmentBookValue, len(equipment))}
+	if len(equipment) == 0 {
+		return register, nil
+	}
+	ids := make([]uint, len(equipment))
+	for i, item := range equipment {
+		ids[i] = item.ID
+	}
+	var entries []*DepreciationEntry
+	if err := r.db.WithContext(ctx).Where("equipment_id IN ?", ids).Order("date ASC").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	byEquipment := make(map[uint][]*DepreciationEntry, len(equipment))
+	for _, entry := range entries {
+		byEquipment[entry.EquipmentID] = append(byEquipment[entry.EquipmentID], entry)
+	}
+
+	for i, item := range equipment {
+		value := &EquipmentBookValue{
+			EquipmentID:  item.ID,
+			Name:         item.Name,
+			SerialNumber: item.SerialNumber,
+			PurchaseDate: item.PurchaseDate,
+			Cost:         item.Cost,
+			SalvageValue: item.SalvageValue,
+		}
+		for _, entry := range byEquipment[item.ID] {
+			if entry.Disposal {
+				// Disposed of later in the month of the date
+				continue
+			}
+			if value.MonthlyDepreciation == 0 {
+				value.MonthlyDepreciation = entry.Amount
+			}
+			if entry.Date.After(asOf) {
+				value.RemainingMonths++
+			} else {
+				value.AccumulatedDepreciation += entry.Amount
+			}
+		}
+		value.AccumulatedDepreciation = math.Round(value.AccumulatedDepreciation*100) / 100
+		value.BookValue = math.Round((item.Cost-value.AccumulatedDepreciation)*100) / 100
+		register.Items[i] = value
+		register.TotalCost += value.Cost
+		register.AccumulatedDepreciation += value.AccumulatedDepreciation
+		register.BookValue += value.BookValue
+	}
+	register.TotalCost = math.Round(register.TotalCost*100) / 100
+	register.AccumulatedDepreciation = math.Round(register.AccumulatedDepreciation*100) / 100
+	register.BookValue = math.Round(register.BookValue*100) / 100
+	return register, nil
+}
+
+// Dispose disposes of an equipment item by sale or write-off. It is depreciated up to the month
+// of the disposal, when the book value left is written off, and the proceeds of a sale are booked
+// as the sale of the disposal. It returns ErrEquipmentDisposed when the item has been disposed of.
+func (r *EquipmentRepository) Dispose(ctx context.Context, id uint, userID uint, disposal *EquipmentDisposal) (*Equipment, error) {
+	var equipment Equipment
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND user_id = ?", id, userID).First(&equipment).Error; err != nil {
+			return err
+		}
+		if equipment.DisposedAt != nil {
+			return ErrEquipmentDisposed
+		}
+
+		month := time.Date(disposal.Date.Year(), disposal.Date.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if err := tx.Where("equipment_id = ? AND date > ?", id, month).Delete(&DepreciationEntry{}).Error; err != nil {
+			return err
+		}
+		var depreciated float64
+		err := tx.Model(&DepreciationEntry{}).Where("equipment_id = ?", id).Select("COALESCE(SUM(amount), 0)").Scan(&depreciated).Error
+		if err != nil {
+			return err
+		}
+		bookValue := math.Round((equipment.Cost-depreciated)*100) / 100
+		if bookValue > 0 {
+			err := tx.Create(&DepreciationEntry{
+				EquipmentID: id,
+				Date:        month,
+				Amount:      bookValue,
+				Disposal:    true,
+				UserID:      userID,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if sale := disposal.Sale; sale != nil {
+			sale.TotalAmount = sale.Quantity * sale.PricePerUnit
+			sale.AmountDue = sale.TotalAmount - sale.AmountPaid
+			sale.PaymentStatus = settledStatus(sale.AmountPaid, sale.AmountDue)
+			if err := tx.Create(sale).Error; err != nil {
+				return err
+			}
+			if err := recordIncome(tx, EventIncomeCreated, sale, nil); err != nil {
+				return err
+			}
+			equipment.DisposalIncomeID = &sale.ID
+		}
+
+		gain := math.Round((disposal.Proceeds-bookValue)*100) / 100
+		equipment.DisposedAt = &disposal.Date
+		equipment.DisposalType = &disposal.Type
+		equipment.DisposalProceeds = disposal.Proceeds
+		equipment.DisposalGain = &gain
+		equipment.DisposalReason = disposal.Reason
+		return tx.Model(&Equipment{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"disposed_at":        equipment.DisposedAt,
+			"disposal_type":      equipment.DisposalType,
+			"disposal_proceeds":  equipment.DisposalProceeds,
+			"disposal_gain":      equipment.DisposalGain,
+			"disposal_income_id": equipment.DisposalIncomeID,
+			"disposal_reason":    equipment.DisposalReason,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &equipment, nil
+}
+
+// checkCapitalized returns ErrExpenseCapitalized when the expense of an equipment item is linked
+// to another item
+func checkCapitalized(tx *gorm.DB, equipment *Equipment) error {
+	if equipment.ExpenseID == nil {
+		return nil
+	}
+	var linked int64
+	err := tx.Model(&Equipment{}).
+		Where("user_id = ? AND expense_id = ? AND id <> ?", equipment.UserID, *equipment.ExpenseID, equipment.ID).
+		Count(&linked).Error
+	if err != nil {
+		return err
+	}
+	if linked > 0 {
+		return ErrExpenseCapitalized
+	}
+	return nil
+}
+
+// depreciateEquipment generates the monthly depreciation entries of an equipment item, replacing
+// any it had. The cost less the salvage value is split evenly to the cent over its useful life,
+// the last month taking the rounding difference.
+func depreciateEquipment(tx *gorm.DB, equipment *Equipment) error {
+	if err := tx.Where("equipment_id = ?", equipment.ID).Delete(&DepreciationEntry{}).Error; err != nil {
+		return err
+	}
+	months := equipment.UsefulLifeMonths
+	depreciable := equipment.Cost - equipment.SalvageValue
+	if months < 1 || depreciable <= 0 {
+		return nil
+	}
+
+	monthly := math.Round(depreciable/float64(months)*100) / 100
+	entries := make([]*DepreciationEntry, months)
+	var depreciated float64
+	for i := range entries {
+		amount := monthly
+		if i == months-1 {
+			amount = math.Round((depreciable-depreciated)*100) / 100
+		}
+		depreciated += amount
+		entries[i] = &DepreciationEntry{
+			EquipmentID: equipment.ID,
+			Date:        equipment.DepreciationStart.AddDate(0, i, 0),
+			Amount:      amount,
+			UserID:      equipment.UserID,
+		}
+	}
+	return tx.CreateInBatches(&entries, 100).Error
+}
@@ -0,0 +1,114 @@
+package data
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EvidenceRepository implements EvidenceInterface using GORM
+type EvidenceRepository struct {
+	db *gorm.DB
+}
+
+// NewEvidenceRepository creates a new instance of EvidenceRepository
+func NewEvidenceRepository(db *gorm.DB) EvidenceInterface {
+	return &EvidenceRepository{db: db}
+}
+
+// GetRules retrieves the photo evidence rules of a user
+func (r *EvidenceRepository) GetRules(ctx context.Context, userID uint) ([]*EvidenceRule, error) {
+	var rules []*EvidenceRule
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("operation ASC").Find(&rules)
+	return rules, result.Error
+}
+
+// SaveRule creates or replaces the rule of a user for an operation
+func (r *EvidenceRepository) SaveRule(ctx context.Context, rule *EvidenceRule) error {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "operation"}},
+		DoUpdates: clause.AssignmentColumns([]string{"min_amount", "active", "updated_at"}),
+	}).Create(rule)
+	return result.Error
+}
+
+// DeleteRule removes the rule of a user for an operation
+func (r *EvidenceRepository) DeleteRule(ctx context.Context, userID uint, operation EvidenceOperation) error {
+	result := r.db.WithContext(ctx).Unscoped().Where("user_id = ? AND operation = ?", userID, operation).Delete(&EvidenceRule{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// RequiresPhoto reports whether an active rule requires a photo for an operation of an amount
+func (r *EvidenceRepository) RequiresPhoto(ctx context.Context, userID uint, operation EvidenceOperation, amount float64) (bool, error) {
+	var rule EvidenceRule
+	err := r.db.WithContext(ctx).Where("user_id = ? AND operation = ? AND active = ?", userID, operation, true).First(&rule).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return rule.MinAmount == nil || amount >= *rule.MinAmount, nil
+}
+
+// InsertPhoto saves a photo, usually before the record it is evidence for exists
+func (r *EvidenceRepository) InsertPhoto(ctx context.Context, photo *EvidencePhoto) (uint, error) {
+	photo.Size = len(photo.Data)
+	result := r.db.WithContext(ctx).Create(photo)
+	return photo.ID, result.Error
+}
+
+// LinkPhoto links a saved photo to the record it is evidence for
+func (r *EvidenceRepository) LinkPhoto(ctx context.Context, id uint, userID uint, recordType EvidenceRecordType, recordID uint) error {
+	result := r.db.WithContext(ctx).Model(&EvidencePhoto{}).Where("id = ? AND user_id = ?", id, userID).
+		Updates(map[string]interface{}{"record_type": recordType, "record_id": recordID})
+	return result.Error
+}
+
+// DeletePhoto removes a photo, e.g. when the record it was uploaded with failed to save
+func (r *EvidenceRepository) DeletePhoto(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Where("id = ? AND user_id = ?", id, userID).Delete(&EvidencePhoto{})
+	return result.Error
+}
+
+// GetPhoto retrieves a photo with its image data
+func (r *EvidenceRepository) GetPhoto(ctx context.Context, id uint, userID uint) (*EvidencePhoto, error) {
+	var photo EvidencePhoto
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&photo)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &photo, nil
+}
+
+// GetPhotos retrieves the photos of a record without their image data
+func (r *EvidenceRepository) GetPhotos(ctx context.Context, userID uint, recordType EvidenceRecordType, recordID uint) ([]*EvidencePhoto, error) {
+	var photos []*EvidencePhoto
+	result := r.db.WithContext(ctx).Omit("data").Where("user_id = ? AND record_type = ? AND record_id = ?", userID, recordType, recordID).
+		Order("created_at ASC").Find(&photos)
+	return photos, result.Error
+}
+
+// HasPhoto reports whether a record has at least one photo
+func (r *EvidenceRepository) HasPhoto(ctx context.Context, userID uint, recordType EvidenceRecordType, recordID uint) (bool, error) {
+	var count int64
+	result := r.db.WithContext(ctx).Model(&EvidencePhoto{}).
+		Where("user_id = ? AND record_type = ? AND record_id = ?", userID, recordType, recordID).Count(&count)
+	return count > 0, result.Error
+}
+
+// GetStorage meters the attachment storage used by a user's books, leaving the quota to the caller
+func (r *EvidenceRepository) GetStorage(ctx context.Context, userID uint) (*AttachmentStorage, error) {
+	storage := &AttachmentStorage{}
+	result := r.db.WithContext(ctx).Model(&EvidencePhoto{}).Where("user_id = ?", userID).
+		Select("COALESCE(SUM(size), 0) AS used_bytes, COUNT(*) AS files").Scan(storage)
+	return storage, result.Error
+}
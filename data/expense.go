@@ -1,9 +1,33 @@
 package data
 
 import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrExpenseReviewed is returned when reviewing an expense that is not pending approval
+var ErrExpenseReviewed = errors.New("expense is not pending approval")
+
+var (
+	// ErrAwaitingSignOff is returned when paying an expense that hasn't been signed off
+	ErrAwaitingSignOff = errors.New("expense must be signed off before it is paid")
+	// ErrNoSignOffPending is returned when signing off an expense that isn't awaiting sign-off
+	ErrNoSignOffPending = errors.New("expense is not awaiting sign-off")
+	// ErrAlreadySignedOff is returned when an approver signs off an expense a second time
+	ErrAlreadySignedOff = errors.New("approver has already signed off the expense")
 )
 
+// AwaitingSignOff reports whether an expense above the sign-off amount still needs sign-offs
+// before it can be paid
+func (e *Expense) AwaitingSignOff() bool {
+	return e.SignOffStatus != nil && *e.SignOffStatus == SignOffPending
+}
+
 // ExpenseRepository implements ExpenseInterface using GORM
 type ExpenseRepository struct {
 	db *gorm.DB
@@ -15,56 +39,348 @@ func NewExpenseRepository(db *gorm.DB) ExpenseInterface {
 }
 
 // GetAll retrieves all expense records for a user
-func (r *ExpenseRepository) GetAll(userID uint) ([]*Expense, error) {
+func (r *ExpenseRepository) GetAll(ctx context.Context, userID uint) ([]*Expense, error) {
 	var expenses []*Expense
-	result := r.db.Where("user_id = ?", userID).Order("date DESC").Find(&expenses)
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("date DESC").Find(&expenses)
 	return expenses, result.Error
 }
 
+// GetPage retrieves a page of a user's expense records matching filter, in its order, with how
+// many there are
+func (r *ExpenseRepository) GetPage(ctx context.Context, userID uint, filter ExpenseFilter, offset, limit int) ([]*Expense, int64, error) {
+	var expenses []*Expense
+	query := scopeToSite(r.db.WithContext(ctx).Model(&Expense{}).Where("user_id = ?", userID), filter.SiteID)
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+	if filter.SupplierName != "" {
+		query = query.Where("supplier_name = ?", filter.SupplierName)
+	}
+	if filter.PaymentStatus != "" {
+		query = query.Where("payment_status = ?", filter.PaymentStatus)
+	}
+	order := sortOrder(filter.Sort, filter.Descending, ExpenseSortColumns, "date DESC, id DESC")
+	total, err := findPage(query, order, offset, limit, &expenses)
+	return expenses, total, err
+}
+
 // GetOne retrieves a specific expense record by ID for a user
-func (r *ExpenseRepository) GetOne(id uint, userID uint) (*Expense, error) {
+func (r *ExpenseRepository) GetOne(ctx context.Context, id uint, userID uint) (*Expense, error) {
 	var expense Expense
-	result := r.db.Where("id = ? AND user_id = ?", id, userID).First(&expense)
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&expense)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return &expense, nil
 }
 
-// Insert creates a new expense record
-func (r *ExpenseRepository) Insert(expense *Expense) (uint, error) {
+// Insert creates a new expense record. It returns ErrAwaitingSignOff when an expense awaiting
+// sign-off is recorded as paid.
+func (r *ExpenseRepository) Insert(ctx context.Context, expense *Expense) (uint, error) {
 	// Calculate amount due
 	expense.AmountDue = expense.Amount - expense.AmountPaid
 
-	result := r.db.Create(expense)
-	return expense.ID, result.Error
+	if expense.AwaitingSignOff() && (expense.AmountPaid > 0 || expense.PaymentStatus == PaymentPaid) {
+		return 0, ErrAwaitingSignOff
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := createExpense(tx, expense); err != nil {
+			return err
+		}
+		if !expense.AwaitingSignOff() {
+			return nil
+		}
+		return storeSignOffEvent(tx, OutboxSignOffRequired, expense)
+	})
+	return expense.ID, err
 }
 
-// Update updates an existing expense record
-func (r *ExpenseRepository) Update(expense *Expense) error {
+// Update updates an existing expense record, recording an event when its payment balance changes.
+// The sign-offs of an expense start over when it newly awaits sign-off or its amount changes while
+// it does, and it returns ErrAwaitingSignOff when more of an expense awaiting sign-off is paid. The
+// allocations of a prepaid expense are generated again.
+func (r *ExpenseRepository) Update(ctx context.Context, expense *Expense) error {
 	// Recalculate amount due
 	expense.AmountDue = expense.Amount - expense.AmountPaid
 
-	result := r.db.Save(expense)
-	return result.Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before Expense
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", expense.ID).First(&before).Error; err != nil {
+			return err
+		}
+
+		restart := expense.AwaitingSignOff() && (!before.AwaitingSignOff() || before.Amount != expense.Amount)
+		if restart || expense.SignOffStatus == nil {
+			if err := tx.Where("expense_id = ?", expense.ID).Delete(&ExpenseSignOff{}).Error; err != nil {
+				return err
+			}
+			expense.SignOffs = 0
+		} else {
+			// Keep the sign-offs made since the expense was read
+			expense.SignOffStatus, expense.SignOffs = before.SignOffStatus, before.SignOffs
+		}
+		if expense.AwaitingSignOff() && (expense.AmountPaid > before.AmountPaid+paymentTolerance ||
+			(expense.PaymentStatus == PaymentPaid && before.PaymentStatus != PaymentPaid)) {
+			return ErrAwaitingSignOff
+		}
+
+		if err := tx.Save(expense).Error; err != nil {
+			return err
+		}
+		if expense.PrepaidMonths != nil || before.PrepaidMonths != nil {
+			if err := allocateExpense(tx, expense); err != nil {
+				return err
+			}
+		}
+		if restart {
+			if err := storeSignOffEvent(tx, OutboxSignOffRequired, expense); err != nil {
+				return err
+			}
+		}
+		if !paymentChanged(expenseBalance(&before, 0), expenseBalance(expense, 0)) {
+			return nil
+		}
+		return recordExpense(tx, EventExpenseUpdated, expense, &before)
+	})
 }
 
 // Delete soft deletes an expense record
-func (r *ExpenseRepository) Delete(id uint, userID uint) error {
-	result := r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&Expense{})
-	return result.Error
+func (r *ExpenseRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return deleteExpense(tx, id, userID)
+	})
+}
+
+// createExpense creates an expense record and opens its event stream
+func createExpense(tx *gorm.DB, expense *Expense) error {
+	if err := tx.Create(expense).Error; err != nil {
+		return err
+	}
+	if err := allocateExpense(tx, expense); err != nil {
+		return err
+	}
+	return recordExpense(tx, EventExpenseCreated, expense, nil)
+}
+
+// deleteExpense soft deletes an expense record of a user if it exists, with the allocations of a
+// prepaid one, and closes its event stream
+func deleteExpense(tx *gorm.DB, id uint, userID uint) error {
+	var expense Expense
+	err := tx.Where("id = ? AND user_id = ?", id, userID).First(&expense).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := tx.Delete(&expense).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("expense_id = ?", expense.ID).Delete(&ExpenseAllocation{}).Error; err != nil {
+		return err
+	}
+	return recordExpense(tx, EventExpenseDeleted, &expense, &expense)
+}
+
+// GetDeleted retrieves the soft deleted expense records of a user, most recently deleted first
+func (r *ExpenseRepository) GetDeleted(ctx context.Context, userID uint) ([]*Expense, error) {
+	var expenses []*Expense
+	result := r.db.WithContext(ctx).Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Order("deleted_at DESC").Find(&expenses)
+	return expenses, result.Error
+}
+
+// Restore brings back a soft deleted expense record, regenerating the allocations of a prepaid
+// one, and reopens its event stream. It returns gorm.ErrRecordNotFound when the user has no such
+// deleted record.
+func (r *ExpenseRepository) Restore(ctx context.Context, id uint, userID uint) (*Expense, error) {
+	var expense Expense
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL", id, userID).First(&expense).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&expense).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		expense.DeletedAt = gorm.DeletedAt{}
+		if err := allocateExpense(tx, &expense); err != nil {
+			return err
+		}
+		return recordExpense(tx, EventExpenseRestored, &expense, &expense)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &expense, nil
+}
+
+// Purge permanently deletes a soft deleted expense record with its payments and allocations. Its
+// event stream is kept. It returns gorm.ErrRecordNotFound when the user has no such deleted
+// record.
+func (r *ExpenseRepository) Purge(ctx context.Context, id uint, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL", id, userID).Delete(&Expense{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Unscoped().Where("expense_id = ?", id).Delete(&ExpenseAllocation{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("record_type = ? AND record_id = ?", TransactionExpense, id).Delete(&Payment{}).Error
+	})
+}
+
+// GetPendingApproval retrieves backdated expenses awaiting approval
+func (r *ExpenseRepository) GetPendingApproval(ctx context.Context, userID uint) ([]*Expense, error) {
+	var expenses []*Expense
+	result := r.db.WithContext(ctx).Where("user_id = ? AND approval_status = ?", userID, SalePendingApproval).
+		Order("date").Find(&expenses)
+	return expenses, result.Error
+}
+
+// Review approves or rejects an expense pending approval. A rejected expense is deleted so it no
+// longer counts in the books, keeping the rejection reason on the deleted record.
+func (r *ExpenseRepository) Review(ctx context.Context, id uint, userID uint, status SaleApproval, reviewerID uint, reason *string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Expense{}).
+			Where("id = ? AND user_id = ? AND approval_status = ?", id, userID, SalePendingApproval).
+			Updates(map[string]interface{}{
+				"approval_status":  status,
+				"reviewed_by_id":   reviewerID,
+				"reviewed_at":      time.Now(),
+				"rejection_reason": reason,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrExpenseReviewed
+		}
+		if status == SaleRejected {
+			return deleteExpense(tx, id, userID)
+		}
+		return nil
+	})
+}
+
+// GetAwaitingSignOff retrieves expenses above the sign-off amount awaiting sign-off
+func (r *ExpenseRepository) GetAwaitingSignOff(ctx context.Context, userID uint) ([]*Expense, error) {
+	var expenses []*Expense
+	result := r.db.WithContext(ctx).Where("user_id = ? AND sign_off_status = ?", userID, SignOffPending).
+		Order("date").Find(&expenses)
+	return expenses, result.Error
+}
+
+// GetSignOffs retrieves the sign-offs of an expense, oldest first
+func (r *ExpenseRepository) GetSignOffs(ctx context.Context, id uint, userID uint) ([]*ExpenseSignOff, error) {
+	var signOffs []*ExpenseSignOff
+	result := r.db.WithContext(ctx).Where("expense_id = ? AND user_id = ?", id, userID).Order("id").Find(&signOffs)
+	return signOffs, result.Error
+}
+
+// SignOff records an approver's sign-off of an expense awaiting sign-off and returns the expense,
+// signed off once RequiredSignOffs distinct approvers have signed it off. It returns
+// ErrNoSignOffPending when the expense isn't awaiting sign-off and ErrAlreadySignedOff when the
+// approver has signed it off before.
+func (r *ExpenseRepository) SignOff(ctx context.Context, id uint, userID uint, approverID uint) (*Expense, error) {
+	var expense Expense
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND user_id = ?", id, userID).First(&expense).Error
+		if err != nil {
+			return err
+		}
+		if !expense.AwaitingSignOff() {
+			return ErrNoSignOffPending
+		}
+
+		var signedOff int64
+		err = tx.Model(&ExpenseSignOff{}).Where("expense_id = ? AND approver_id = ?", id, approverID).Count(&signedOff).Error
+		if err != nil {
+			return err
+		}
+		if signedOff > 0 {
+			return ErrAlreadySignedOff
+		}
+		if err := tx.Create(&ExpenseSignOff{ExpenseID: id, ApproverID: approverID, UserID: userID}).Error; err != nil {
+			return err
+		}
+
+		expense.SignOffs++
+		status := SignOffPending
+		if expense.SignOffs >= RequiredSignOffs {
+			status = SignOffComplete
+		}
+		expense.SignOffStatus = &status
+		err = tx.Model(&expense).Updates(map[string]interface{}{
+			"sign_offs":       expense.SignOffs,
+			"sign_off_status": status,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return storeSignOffEvent(tx, OutboxExpenseSignedOff, &expense)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &expense, nil
+}
+
+// storeSignOffEvent stores a sign-off event of an expense in the outbox of the transaction, with
+// the approvers who have signed it off
+func storeSignOffEvent(tx *gorm.DB, event string, expense *Expense) error {
+	approverIDs := []uint{}
+	err := tx.Model(&ExpenseSignOff{}).Where("expense_id = ?", expense.ID).Order("id").Pluck("approver_id", &approverIDs).Error
+	if err != nil {
+		return err
+	}
+	return storeOutbox(tx, event, "expense", expense.ID, expense.UserID, ExpenseSignOffEvent{
+		ExpenseID:    expense.ID,
+		Description:  expense.Description,
+		SupplierName: expense.SupplierName,
+		Amount:       expense.Amount,
+		SignOffs:     expense.SignOffs,
+		Required:     RequiredSignOffs,
+		SignedOffBy:  approverIDs,
+	})
 }
 
 // GetByDateRange retrieves expense records within a date range
-func (r *ExpenseRepository) GetByDateRange(userID uint, startDate, endDate string) ([]*Expense, error) {
+func (r *ExpenseRepository) GetByDateRange(ctx context.Context, userID uint, startDate, endDate string) ([]*Expense, error) {
 	var expenses []*Expense
-	result := r.db.Where("user_id = ? AND date BETWEEN ? AND ?", userID, startDate, endDate).
+	result := r.db.WithContext(ctx).Where("user_id = ? AND date BETWEEN ? AND ?", userID, startDate, endDate).
 		Order("date DESC").Find(&expenses)
 	return expenses, result.Error
 }
 
+// GetArchivedOne retrieves an archived expense record by the ID it had before it was archived
+func (r *ExpenseRepository) GetArchivedOne(ctx context.Context, id uint, userID uint) (*ArchivedExpense, error) {
+	var expense ArchivedExpense
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&expense)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &expense, nil
+}
+
+// GetArchived retrieves archived expense records within a date range, or all of them when the
+// dates are empty
+func (r *ExpenseRepository) GetArchived(ctx context.Context, userID uint, startDate, endDate string) ([]*ArchivedExpense, error) {
+	var expenses []*ArchivedExpense
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if startDate != "" {
+		query = query.Where("date BETWEEN ? AND ?", startDate, endDate)
+	}
+	result := query.Order("date DESC").Find(&expenses)
+	return expenses, result.Error
+}
+
 // GetCategoryBreakdown retrieves expense breakdown by category
-func (r *ExpenseRepository) GetCategoryBreakdown(userID uint) ([]*CategoryBreakdown, error) {
+func (r *ExpenseRepository) GetCategoryBreakdown(ctx context.Context, userID uint) ([]*CategoryBreakdown, error) {
 	var breakdown []*CategoryBreakdown
 
 	query := `
@@ -77,7 +393,7 @@ func (r *ExpenseRepository) GetCategoryBreakdown(userID uint) ([]*CategoryBreakd
 		ORDER BY amount DESC
 	`
 
-	result := r.db.Raw(query, userID).Scan(&breakdown)
+	result := r.db.WithContext(ctx).Raw(query, userID).Scan(&breakdown)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -98,20 +414,38 @@ func (r *ExpenseRepository) GetCategoryBreakdown(userID uint) ([]*CategoryBreakd
 }
 
 // GetMonthlyData retrieves monthly expense data for a year
-func (r *ExpenseRepository) GetMonthlyData(userID uint, year int) ([]*MonthlyData, error) {
+func (r *ExpenseRepository) GetMonthlyData(ctx context.Context, userID uint, year int) ([]*MonthlyData, error) {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return r.GetMonthlyDataBetween(ctx, userID, start, start.AddDate(1, 0, 0))
+}
+
+// GetMonthlyDataBetween retrieves monthly expense data for the period [start, end). Prepaid
+// expenses count in the months they are allocated to rather than the month they were paid in, and
+// equipment through its monthly depreciation rather than the expense it was bought with.
+func (r *ExpenseRepository) GetMonthlyDataBetween(ctx context.Context, userID uint, start, end time.Time) ([]*MonthlyData, error) {
 	var monthlyData []*MonthlyData
 
+	month := monthExpr(r.db.WithContext(ctx), "date")
 	query := `
 		SELECT 
-			TO_CHAR(date, 'YYYY-MM') as month,
+			` + month + ` as month,
 			COALESCE(SUM(amount), 0) as expenses
-		FROM expenses 
-		WHERE user_id = ? AND EXTRACT(YEAR FROM date) = ?
-		GROUP BY TO_CHAR(date, 'YYYY-MM')
+		FROM (
+			SELECT date, amount FROM expenses
+			WHERE user_id = ? AND date >= ? AND date < ? AND deleted_at IS NULL AND prepaid_months IS NULL
+				AND id NOT IN (` + capitalizedExpenses + `)
+			UNION ALL
+			SELECT date, amount FROM expense_allocations
+			WHERE user_id = ? AND date >= ? AND date < ?
+			UNION ALL
+			SELECT date, amount FROM depreciation_entries
+			WHERE user_id = ? AND date >= ? AND date < ?
+		) recognized
+		GROUP BY ` + month + `
 		ORDER BY month
 	`
 
-	result := r.db.Raw(query, userID, year).Scan(&monthlyData)
+	result := r.db.WithContext(ctx).Raw(query, userID, start, end, userID, userID, start, end, userID, start, end).Scan(&monthlyData)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -119,21 +453,51 @@ func (r *ExpenseRepository) GetMonthlyData(userID uint, year int) ([]*MonthlyDat
 	return monthlyData, nil
 }
 
-// GetFinancialSummary calculates financial summary for expenses
-func (r *ExpenseRepository) GetFinancialSummary(userID uint) (*FinancialSummary, error) {
+// GetFinancialSummary calculates financial summary for expenses. Prepaid expenses count as far as
+// they have been amortized, the rest being the prepaid balance, and equipment as far as it has
+// been depreciated. When start or end is set only the expenses, allocations and depreciation
+// dated in [start, end) count, as in the monthly data, and payables are what is still due on
+// those expenses; the prepaid balance is always the current one.
+func (r *ExpenseRepository) GetFinancialSummary(ctx context.Context, userID uint, start, end *time.Time) (*FinancialSummary, error) {
 	var summary FinancialSummary
 
 	// Get total expenses
 	var totalExpenses float64
-	result := r.db.Model(&Expense{}).Where("user_id = ? AND deleted_at IS NULL", userID).Select("COALESCE(SUM(amount), 0)").Scan(&totalExpenses)
+	result := scopeToPeriod(r.db.WithContext(ctx).Model(&Expense{}), "date", start, end).
+		Where("user_id = ? AND deleted_at IS NULL AND prepaid_months IS NULL", userID).
+		Where("id NOT IN ("+capitalizedExpenses+")", userID).
+		Select("COALESCE(SUM(amount), 0)").Scan(&totalExpenses)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	// Without an end, allocations and depreciation count up to today
+	recognized := func(query *gorm.DB) *gorm.DB {
+		if end == nil {
+			query = query.Where("date <= ?", time.Now())
+		}
+		return scopeToPeriod(query, "date", start, end).Where("user_id = ?", userID)
+	}
+	var amortized, depreciated, prepaid float64
+	result = recognized(r.db.WithContext(ctx).Model(&ExpenseAllocation{})).Select("COALESCE(SUM(amount), 0)").Scan(&amortized)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	result = recognized(r.db.WithContext(ctx).Model(&DepreciationEntry{})).Select("COALESCE(SUM(amount), 0)").Scan(&depreciated)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	result = r.db.WithContext(ctx).Model(&ExpenseAllocation{}).Where("user_id = ? AND date > ?", userID, time.Now()).
+		Select("COALESCE(SUM(amount), 0)").Scan(&prepaid)
 	if result.Error != nil {
 		return nil, result.Error
 	}
-	summary.TotalExpenses = totalExpenses
+	summary.TotalExpenses = math.Round((totalExpenses+amortized+depreciated)*100) / 100
+	summary.PrepaidBalance = prepaid
 
 	// Get total payables (unpaid amounts)
 	var totalPayables float64
-	result = r.db.Model(&Expense{}).Where("user_id = ? AND deleted_at IS NULL AND payment_status IN (?, ?)", userID, PaymentUnpaid, PaymentPartial).
+	result = scopeToPeriod(r.db.WithContext(ctx).Model(&Expense{}), "date", start, end).
+		Where("user_id = ? AND deleted_at IS NULL AND payment_status IN (?, ?)", userID, PaymentUnpaid, PaymentPartial).
 		Select("COALESCE(SUM(amount_due), 0)").Scan(&totalPayables)
 	if result.Error != nil {
 		return nil, result.Error
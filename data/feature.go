@@ -0,0 +1,48 @@
+package data
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+// FeatureRepository implements FeatureInterface using GORM
+type FeatureRepository struct {
+	db *gorm.DB
+}
+
+// NewFeatureRepository creates a new instance of FeatureRepository
+func NewFeatureRepository(db *gorm.DB) FeatureInterface {
+	return &FeatureRepository{db: db}
+}
+
+// GetFlags retrieves the feature flags of a user's books
+func (r *FeatureRepository) GetFlags(ctx context.Context, userID uint) ([]*FeatureFlag, error) {
+	var flags []*FeatureFlag
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("feature ASC").Find(&flags)
+	return flags, result.Error
+}
+
+// SaveFlag creates or changes the feature flag of a user's books for a feature
+func (r *FeatureRepository) SaveFlag(ctx context.Context, flag *FeatureFlag) error {
+	var existing FeatureFlag
+	err := r.db.WithContext(ctx).Where("user_id = ? AND feature = ?", flag.UserID, flag.Feature).First(&existing).Error
+	if err == nil {
+		flag.ID = existing.ID
+		flag.CreatedAt = existing.CreatedAt
+	} else if err != gorm.ErrRecordNotFound {
+		return err
+	}
+	return r.db.WithContext(ctx).Save(flag).Error
+}
+
+// DeleteFlag removes the feature flag of a user's books for a feature, returning them to their plan
+func (r *FeatureRepository) DeleteFlag(ctx context.Context, userID uint, feature Feature) error {
+	result := r.db.WithContext(ctx).Unscoped().Where("user_id = ? AND feature = ?", userID, feature).Delete(&FeatureFlag{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
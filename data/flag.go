@@ -0,0 +1,59 @@
+package data
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FlagRepository implements FlagInterface using GORM
+type FlagRepository struct {
+	db *gorm.DB
+}
+
+// NewFlagRepository creates a new instance of FlagRepository
+func NewFlagRepository(db *gorm.DB) FlagInterface {
+	return &FlagRepository{db: db}
+}
+
+// GetAll retrieves the flagged customers and suppliers of a user, optionally of one type
+func (r *FlagRepository) GetAll(ctx context.Context, userID uint, flagType ContactType) ([]*CounterpartyFlag, error) {
+	var flags []*CounterpartyFlag
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if flagType != "" {
+		query = query.Where("type = ?", flagType)
+	}
+	result := query.Order("name ASC").Find(&flags)
+	return flags, result.Error
+}
+
+// GetFlag retrieves the flag of a customer or supplier by name
+func (r *FlagRepository) GetFlag(ctx context.Context, userID uint, flagType ContactType, name string) (*CounterpartyFlag, error) {
+	var flag CounterpartyFlag
+	result := r.db.WithContext(ctx).Where("user_id = ? AND type = ? AND name = ?", userID, flagType, name).First(&flag)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &flag, nil
+}
+
+// Save creates or replaces the flag of a customer or supplier
+func (r *FlagRepository) Save(ctx context.Context, flag *CounterpartyFlag) error {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}, {Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"level", "reason", "flagged_by_id", "updated_at"}),
+	}).Create(flag)
+	return result.Error
+}
+
+// Delete removes a flag. Flags are deleted permanently so the counterparty can be flagged again.
+func (r *FlagRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Where("id = ? AND user_id = ?", id, userID).Delete(&CounterpartyFlag{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
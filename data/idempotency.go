@@ -0,0 +1,77 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// staleRequestTimeout is how long a request may be processed before a retry with its key takes
+// it over, e.g. after the replica processing it stopped mid-request
+const staleRequestTimeout = 2 * time.Minute
+
+// IdempotencyRepository implements IdempotencyInterface using GORM
+type IdempotencyRepository struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyRepository creates a new instance of IdempotencyRepository
+func NewIdempotencyRepository(db *gorm.DB) IdempotencyInterface {
+	return &IdempotencyRepository{db: db}
+}
+
+// Begin records a request about to be processed. It returns nil when the key is new, or the
+// earlier request made with the key, whose StatusCode is 0 while it is still being processed.
+// An identical request left unfinished for staleRequestTimeout is taken over and also returns nil.
+func (r *IdempotencyRepository) Begin(ctx context.Context, actorID uint, key, fingerprint string) (*IdempotentRequest, error) {
+	request := &IdempotentRequest{ActorID: actorID, IdempotencyKey: key, Fingerprint: fingerprint}
+	created := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(request)
+	if created.Error != nil {
+		return nil, created.Error
+	}
+	if created.RowsAffected == 1 {
+		return nil, nil
+	}
+
+	var earlier IdempotentRequest
+	if err := r.db.WithContext(ctx).Where("actor_id = ? AND idempotency_key = ?", actorID, key).First(&earlier).Error; err != nil {
+		return nil, err
+	}
+	if earlier.StatusCode == 0 && earlier.Fingerprint == fingerprint {
+		taken := r.db.WithContext(ctx).Model(&IdempotentRequest{}).
+			Where("id = ? AND status_code = 0 AND updated_at < ?", earlier.ID, time.Now().Add(-staleRequestTimeout)).
+			Update("updated_at", time.Now())
+		if taken.Error != nil {
+			return nil, taken.Error
+		}
+		if taken.RowsAffected == 1 {
+			return nil, nil
+		}
+	}
+	return &earlier, nil
+}
+
+// Complete stores the response to a request so retries get it back
+func (r *IdempotencyRepository) Complete(ctx context.Context, actorID uint, key string, statusCode int, contentType string, body []byte) error {
+	result := r.db.WithContext(ctx).Model(&IdempotentRequest{}).Where("actor_id = ? AND idempotency_key = ?", actorID, key).
+		Updates(map[string]interface{}{
+			"status_code":  statusCode,
+			"content_type": contentType,
+			"body":         body,
+		})
+	return result.Error
+}
+
+// Release forgets a request that failed, so it can be retried with the same key
+func (r *IdempotencyRepository) Release(ctx context.Context, actorID uint, key string) error {
+	result := r.db.WithContext(ctx).Unscoped().Where("actor_id = ? AND idempotency_key = ?", actorID, key).Delete(&IdempotentRequest{})
+	return result.Error
+}
+
+// DeleteBefore removes the requests made before t, after which their keys can be reused
+func (r *IdempotencyRepository) DeleteBefore(ctx context.Context, t time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().Where("created_at < ?", t).Delete(&IdempotentRequest{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,74 @@
+package data
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrIdentityLinked is returned when a Google account or phone number is already linked to another user
+var ErrIdentityLinked = errors.New("identity is already linked to another account")
+
+// IdentityRepository implements IdentityInterface using GORM
+type IdentityRepository struct {
+	db *gorm.DB
+}
+
+// NewIdentityRepository creates a new instance of IdentityRepository
+func NewIdentityRepository(db *gorm.DB) IdentityInterface {
+	return &IdentityRepository{db: db}
+}
+
+// GetByProvider retrieves the identity for a provider subject, e.g. a Google account ID
+func (r *IdentityRepository) GetByProvider(ctx context.Context, provider IdentityProvider, subject string) (*UserIdentity, error) {
+	var identity UserIdentity
+	result := r.db.WithContext(ctx).Where("provider = ? AND subject = ?", provider, subject).First(&identity)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &identity, nil
+}
+
+// GetByUserID retrieves all identities linked to a user
+func (r *IdentityRepository) GetByUserID(ctx context.Context, userID uint) ([]*UserIdentity, error) {
+	var identities []*UserIdentity
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("provider").Find(&identities)
+	return identities, result.Error
+}
+
+// Link links an identity to a user, replacing the user's previous identity for the same provider
+func (r *IdentityRepository) Link(ctx context.Context, identity *UserIdentity) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing UserIdentity
+		err := tx.Where("provider = ? AND subject = ?", identity.Provider, identity.Subject).First(&existing).Error
+		if err == nil {
+			if existing.UserID != identity.UserID {
+				return ErrIdentityLinked
+			}
+			*identity = existing
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if err := tx.Unscoped().Where("user_id = ? AND provider = ?", identity.UserID, identity.Provider).
+			Delete(&UserIdentity{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(identity).Error
+	})
+}
+
+// Unlink removes a user's identity for a provider
+func (r *IdentityRepository) Unlink(ctx context.Context, userID uint, provider IdentityProvider) error {
+	result := r.db.WithContext(ctx).Unscoped().Where("user_id = ? AND provider = ?", userID, provider).Delete(&UserIdentity{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -1,11 +1,18 @@
 package data
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrSaleReviewed is returned when reviewing a sale that is not pending approval
+var ErrSaleReviewed = errors.New("sale is not pending approval")
+
 // IncomeRepository implements IncomeInterface using GORM
 type IncomeRepository struct {
 	db *gorm.DB
@@ -17,16 +24,32 @@ func NewIncomeRepository(db *gorm.DB) IncomeInterface {
 }
 
 // GetAll retrieves all income records for a user
-func (r *IncomeRepository) GetAll(userID uint) ([]*Income, error) {
+func (r *IncomeRepository) GetAll(ctx context.Context, userID uint) ([]*Income, error) {
 	var incomes []*Income
-	result := r.db.Where("user_id = ?", userID).Order("date DESC").Find(&incomes)
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("date DESC").Find(&incomes)
 	return incomes, result.Error
 }
 
+// GetPage retrieves a page of a user's income records matching filter, in its order, with how
+// many there are
+func (r *IncomeRepository) GetPage(ctx context.Context, userID uint, filter IncomeFilter, offset, limit int) ([]*Income, int64, error) {
+	var incomes []*Income
+	query := scopeToSite(r.db.WithContext(ctx).Model(&Income{}).Where("user_id = ?", userID), filter.SiteID)
+	if filter.MineralType != "" {
+		query = query.Where("mineral_type = ?", filter.MineralType)
+	}
+	if filter.PaymentStatus != "" {
+		query = query.Where("payment_status = ?", filter.PaymentStatus)
+	}
+	order := sortOrder(filter.Sort, filter.Descending, IncomeSortColumns, "date DESC, id DESC")
+	total, err := findPage(query, order, offset, limit, &incomes)
+	return incomes, total, err
+}
+
 // GetOne retrieves a specific income record by ID for a user
-func (r *IncomeRepository) GetOne(id uint, userID uint) (*Income, error) {
+func (r *IncomeRepository) GetOne(ctx context.Context, id uint, userID uint) (*Income, error) {
 	var income Income
-	result := r.db.Where("id = ? AND user_id = ?", id, userID).First(&income)
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&income)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -34,48 +57,221 @@ func (r *IncomeRepository) GetOne(id uint, userID uint) (*Income, error) {
 }
 
 // Insert creates a new income record
-func (r *IncomeRepository) Insert(income *Income) (uint, error) {
+func (r *IncomeRepository) Insert(ctx context.Context, income *Income) (uint, error) {
 	// Calculate total amount
 	income.TotalAmount = income.Quantity * income.PricePerUnit
 
 	// Calculate amount due
 	income.AmountDue = income.TotalAmount - income.AmountPaid
 
-	result := r.db.Create(income)
-	return income.ID, result.Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(income).Error; err != nil {
+			return err
+		}
+		return recordIncome(tx, EventIncomeCreated, income, nil)
+	})
+	return income.ID, err
 }
 
-// Update updates an existing income record
-func (r *IncomeRepository) Update(income *Income) error {
+// Update updates an existing income record, recording an event when its payment balance changes
+func (r *IncomeRepository) Update(ctx context.Context, income *Income) error {
 	// Recalculate total amount and amount due
 	income.TotalAmount = income.Quantity * income.PricePerUnit
 	income.AmountDue = income.TotalAmount - income.AmountPaid
 
-	result := r.db.Save(income)
-	return result.Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before Income
+		if err := tx.Where("id = ?", income.ID).First(&before).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(income).Error; err != nil {
+			return err
+		}
+		if !paymentChanged(incomeBalance(&before, 0), incomeBalance(income, 0)) {
+			return nil
+		}
+		return recordIncome(tx, EventIncomeUpdated, income, &before)
+	})
 }
 
 // Delete soft deletes an income record
-func (r *IncomeRepository) Delete(id uint, userID uint) error {
-	result := r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&Income{})
-	return result.Error
+func (r *IncomeRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return deleteIncome(tx, id, userID)
+	})
+}
+
+// deleteIncome soft deletes an income record of a user if it exists and closes its event stream
+func deleteIncome(tx *gorm.DB, id uint, userID uint) error {
+	var income Income
+	err := tx.Where("id = ? AND user_id = ?", id, userID).First(&income).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := tx.Delete(&income).Error; err != nil {
+		return err
+	}
+	return recordIncome(tx, EventIncomeDeleted, &income, &income)
+}
+
+// GetDeleted retrieves the soft deleted income records of a user, most recently deleted first
+func (r *IncomeRepository) GetDeleted(ctx context.Context, userID uint) ([]*Income, error) {
+	var incomes []*Income
+	result := r.db.WithContext(ctx).Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Order("deleted_at DESC").Find(&incomes)
+	return incomes, result.Error
+}
+
+// Restore brings back a soft deleted income record and reopens its event stream. It returns
+// gorm.ErrRecordNotFound when the user has no such deleted record.
+func (r *IncomeRepository) Restore(ctx context.Context, id uint, userID uint) (*Income, error) {
+	var income Income
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL", id, userID).First(&income).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&income).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		income.DeletedAt = gorm.DeletedAt{}
+		return recordIncome(tx, EventIncomeRestored, &income, &income)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &income, nil
+}
+
+// Purge permanently deletes a soft deleted income record with its payments. Its event stream is
+// kept. It returns gorm.ErrRecordNotFound when the user has no such deleted record.
+func (r *IncomeRepository) Purge(ctx context.Context, id uint, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL", id, userID).Delete(&Income{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Unscoped().Where("record_type = ? AND record_id = ?", TransactionIncome, id).Delete(&Payment{}).Error
+	})
+}
+
+// GetByCustomer retrieves income records for a customer
+func (r *IncomeRepository) GetByCustomer(ctx context.Context, userID uint, customerName string) ([]*Income, error) {
+	var incomes []*Income
+	result := r.db.WithContext(ctx).Where("user_id = ? AND customer_name = ?", userID, customerName).
+		Order("date").Find(&incomes)
+	return incomes, result.Error
+}
+
+// GetOutstanding retrieves income records with an amount still due
+func (r *IncomeRepository) GetOutstanding(ctx context.Context, userID uint) ([]*Income, error) {
+	var incomes []*Income
+	result := r.db.WithContext(ctx).Where("user_id = ? AND amount_due > 0 AND payment_status <> ?", userID, PaymentPaid).
+		Order("date").Find(&incomes)
+	return incomes, result.Error
+}
+
+// AssignInvoiceNumber numbers the invoice of an income record that does not have one yet and
+// returns its invoice number. The sale is locked while it is numbered so it is only numbered once.
+func (r *IncomeRepository) AssignInvoiceNumber(ctx context.Context, id uint, userID uint) (string, error) {
+	var number string
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var income Income
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND user_id = ?", id, userID).First(&income).Error
+		if err != nil {
+			return err
+		}
+		if income.InvoiceNumber != nil {
+			number = *income.InvoiceNumber
+			return nil
+		}
+
+		number, err = nextDocumentNumber(tx, userID, DocumentInvoice, income.Date)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&income).Update("invoice_number", number).Error
+	})
+	return number, err
+}
+
+// GetPendingApproval retrieves sales to flagged customers and backdated sales awaiting approval
+func (r *IncomeRepository) GetPendingApproval(ctx context.Context, userID uint) ([]*Income, error) {
+	var incomes []*Income
+	result := r.db.WithContext(ctx).Where("user_id = ? AND approval_status = ?", userID, SalePendingApproval).
+		Order("date").Find(&incomes)
+	return incomes, result.Error
+}
+
+// Review approves or rejects a sale pending approval. A rejected sale is deleted so it no
+// longer counts in the books, keeping the rejection reason on the deleted record.
+func (r *IncomeRepository) Review(ctx context.Context, id uint, userID uint, status SaleApproval, reviewerID uint, reason *string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Income{}).
+			Where("id = ? AND user_id = ? AND approval_status = ?", id, userID, SalePendingApproval).
+			Updates(map[string]interface{}{
+				"approval_status":  status,
+				"reviewed_by_id":   reviewerID,
+				"reviewed_at":      time.Now(),
+				"rejection_reason": reason,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrSaleReviewed
+		}
+		if status == SaleRejected {
+			return deleteIncome(tx, id, userID)
+		}
+		return nil
+	})
 }
 
 // GetByDateRange retrieves income records within a date range
-func (r *IncomeRepository) GetByDateRange(userID uint, startDate, endDate string) ([]*Income, error) {
+func (r *IncomeRepository) GetByDateRange(ctx context.Context, userID uint, startDate, endDate string) ([]*Income, error) {
 	var incomes []*Income
-	result := r.db.Where("user_id = ? AND date BETWEEN ? AND ?", userID, startDate, endDate).
+	result := r.db.WithContext(ctx).Where("user_id = ? AND date BETWEEN ? AND ?", userID, startDate, endDate).
 		Order("date DESC").Find(&incomes)
 	return incomes, result.Error
 }
 
-// GetFinancialSummary calculates financial summary for a user
-func (r *IncomeRepository) GetFinancialSummary(userID uint) (*FinancialSummary, error) {
+// GetArchivedOne retrieves an archived income record by the ID it had before it was archived
+func (r *IncomeRepository) GetArchivedOne(ctx context.Context, id uint, userID uint) (*ArchivedIncome, error) {
+	var income ArchivedIncome
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&income)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &income, nil
+}
+
+// GetArchived retrieves archived income records within a date range, or all of them when the
+// dates are empty
+func (r *IncomeRepository) GetArchived(ctx context.Context, userID uint, startDate, endDate string) ([]*ArchivedIncome, error) {
+	var incomes []*ArchivedIncome
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if startDate != "" {
+		query = query.Where("date BETWEEN ? AND ?", startDate, endDate)
+	}
+	result := query.Order("date DESC").Find(&incomes)
+	return incomes, result.Error
+}
+
+// GetFinancialSummary calculates financial summary for a user, of the sales dated in [start, end)
+// when either is set. Receivables are what is still due on those sales.
+func (r *IncomeRepository) GetFinancialSummary(ctx context.Context, userID uint, start, end *time.Time) (*FinancialSummary, error) {
 	var summary FinancialSummary
 
 	// Get total income
 	var totalIncome float64
-	result := r.db.Model(&Income{}).Where("user_id = ? AND deleted_at IS NULL", userID).Select("COALESCE(SUM(total_amount), 0)").Scan(&totalIncome)
+	result := scopeToPeriod(r.db.WithContext(ctx).Model(&Income{}), "date", start, end).Where("user_id = ? AND deleted_at IS NULL", userID).
+		Select("COALESCE(SUM(total_amount), 0)").Scan(&totalIncome)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -83,7 +279,8 @@ func (r *IncomeRepository) GetFinancialSummary(userID uint) (*FinancialSummary,
 
 	// Get total receivables (unpaid amounts)
 	var totalReceivables float64
-	result = r.db.Model(&Income{}).Where("user_id = ? AND deleted_at IS NULL AND payment_status IN (?, ?)", userID, PaymentUnpaid, PaymentPartial).
+	result = scopeToPeriod(r.db.WithContext(ctx).Model(&Income{}), "date", start, end).
+		Where("user_id = ? AND deleted_at IS NULL AND payment_status IN (?, ?)", userID, PaymentUnpaid, PaymentPartial).
 		Select("COALESCE(SUM(amount_due), 0)").Scan(&totalReceivables)
 	if result.Error != nil {
 		return nil, result.Error
@@ -91,7 +288,7 @@ func (r *IncomeRepository) GetFinancialSummary(userID uint) (*FinancialSummary,
 
 	// Debug: Check what records exist for this user
 	var debugRecords []Income
-	r.db.Where("user_id = ? AND deleted_at IS NULL", userID).Find(&debugRecords)
+	r.db.WithContext(ctx).Where("user_id = ? AND deleted_at IS NULL", userID).Find(&debugRecords)
 	fmt.Printf("DEBUG: User %d has %d income records\n", userID, len(debugRecords))
 	for i, record := range debugRecords {
 		fmt.Printf("DEBUG: Record %d - PaymentStatus: %s, AmountDue: %.2f\n", i+1, record.PaymentStatus, record.AmountDue)
@@ -104,20 +301,45 @@ func (r *IncomeRepository) GetFinancialSummary(userID uint) (*FinancialSummary,
 }
 
 // GetMonthlyData retrieves monthly income data for a year
-func (r *IncomeRepository) GetMonthlyData(userID uint, year int) ([]*MonthlyData, error) {
+func (r *IncomeRepository) GetMonthlyData(ctx context.Context, userID uint, year int) ([]*MonthlyData, error) {
+	var monthlyData []*MonthlyData
+
+	month := monthExpr(r.db.WithContext(ctx), "date")
+	query := `
+		SELECT 
+			` + month + ` as month,
+			COALESCE(SUM(total_amount), 0) as income
+		FROM incomes 
+		WHERE user_id = ? AND date >= ? AND date < ?
+		GROUP BY ` + month + `
+		ORDER BY month
+	`
+
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	result := r.db.WithContext(ctx).Raw(query, userID, start, start.AddDate(1, 0, 0)).Scan(&monthlyData)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return monthlyData, nil
+}
+
+// GetMonthlyDataBetween retrieves monthly income data for the period [start, end)
+func (r *IncomeRepository) GetMonthlyDataBetween(ctx context.Context, userID uint, start, end time.Time) ([]*MonthlyData, error) {
 	var monthlyData []*MonthlyData
 
+	month := monthExpr(r.db.WithContext(ctx), "date")
 	query := `
 		SELECT 
-			TO_CHAR(date, 'YYYY-MM') as month,
+			` + month + ` as month,
 			COALESCE(SUM(total_amount), 0) as income
 		FROM incomes 
-		WHERE user_id = ? AND EXTRACT(YEAR FROM date) = ?
-		GROUP BY TO_CHAR(date, 'YYYY-MM')
+		WHERE user_id = ? AND date >= ? AND date < ? AND deleted_at IS NULL
+		GROUP BY ` + month + `
 		ORDER BY month
 	`
 
-	result := r.db.Raw(query, userID, year).Scan(&monthlyData)
+	result := r.db.WithContext(ctx).Raw(query, userID, start, end).Scan(&monthlyData)
 	if result.Error != nil {
 		return nil, result.Error
 	}
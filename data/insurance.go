@@ -0,0 +1,196 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrPolicyHasClaims is returned when deleting an insurance policy claims have been made on
+	ErrPolicyHasClaims = errors.New("policy has claims")
+	// ErrInsuranceClaimPaid is returned when changing a claim whose payout has been received
+	ErrInsuranceClaimPaid = errors.New("claim has been paid out")
+	// ErrInsuranceClaimRejected is returned when recording the payout of a rejected claim
+	ErrInsuranceClaimRejected = errors.New("claim has been rejected")
+)
+
+// InsuranceRepository implements InsuranceInterface using GORM
+type InsuranceRepository struct {
+	db *gorm.DB
+}
+
+// NewInsuranceRepository creates a new instance of InsuranceRepository
+func NewInsuranceRepository(db *gorm.DB) InsuranceInterface {
+	return &InsuranceRepository{db: db}
+}
+
+// GetPolicies retrieves the insurance policies of a user, soonest expiry first
+func (r *InsuranceRepository) GetPolicies(ctx context.Context, userID uint) ([]*InsurancePolicy, error) {
+	var policies []*InsurancePolicy
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("expiry_date ASC, id ASC").Find(&policies)
+	return policies, result.Error
+}
+
+// GetPolicy retrieves an insurance policy by ID for a user, with the claims made on it
+func (r *InsuranceRepository) GetPolicy(ctx context.Context, id uint, userID uint) (*InsurancePolicy, error) {
+	var policy InsurancePolicy
+	result := r.db.WithContext(ctx).Preload("Claims", func(db *gorm.DB) *gorm.DB {
+		return db.Order("incident_date DESC, id DESC")
+	}).Where("id = ? AND user_id = ?", id, userID).First(&policy)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &policy, nil
+}
+
+// InsertPolicy creates a new insurance policy
+func (r *InsuranceRepository) InsertPolicy(ctx context.Context, policy *InsurancePolicy) (uint, error) {
+	result := r.db.WithContext(ctx).Omit("Claims").Create(policy)
+	return policy.ID, result.Error
+}
+
+// UpdatePolicy updates an existing insurance policy
+func (r *InsuranceRepository) UpdatePolicy(ctx context.Context, policy *InsurancePolicy) error {
+	result := r.db.WithContext(ctx).Omit("Claims").Save(policy)
+	return result.Error
+}
+
+// DeletePolicy soft deletes an insurance policy, returning ErrPolicyHasClaims when claims have
+// been made on it
+func (r *InsuranceRepository) DeletePolicy(ctx context.Context, id uint, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var claims int64
+		if err := tx.Model(&InsuranceClaim{}).Where("policy_id = ? AND user_id = ?", id, userID).Count(&claims).Error; err != nil {
+			return err
+		}
+		if claims > 0 {
+			return ErrPolicyHasClaims
+		}
+		return tx.Where("id = ? AND user_id = ?", id, userID).Delete(&InsurancePolicy{}).Error
+	})
+}
+
+// GetAllExpiringPolicies retrieves the insurance policies of all users that expire between the
+// given times
+func (r *InsuranceRepository) GetAllExpiringPolicies(ctx context.Context, from, to time.Time) ([]*InsurancePolicy, error) {
+	var policies []*InsurancePolicy
+	result := r.db.WithContext(ctx).Where("expiry_date >= ? AND expiry_date <= ?", from, to).Order("expiry_date ASC").Find(&policies)
+	return policies, result.Error
+}
+
+// GetClaims retrieves the insurance claims of a user, newest incident first. Only the claims on
+// policyID and in status are included when they are set.
+func (r *InsuranceRepository) GetClaims(ctx context.Context, userID uint, policyID *uint, status *InsuranceClaimStatus) ([]*InsuranceClaim, error) {
+	query := r.db.WithContext(ctx).Preload("Policy").Where("user_id = ?", userID)
+	if policyID != nil {
+		query = query.Where("policy_id = ?", *policyID)
+	}
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+	var claims []*InsuranceClaim
+	result := query.Order("incident_date DESC, id DESC").Find(&claims)
+	return claims, result.Error
+}
+
+// GetClaim retrieves an insurance claim by ID for a user, with its policy
+func (r *InsuranceRepository) GetClaim(ctx context.Context, id uint, userID uint) (*InsuranceClaim, error) {
+	var claim InsuranceClaim
+	result := r.db.WithContext(ctx).Preload("Policy").Where("id = ? AND user_id = ?", id, userID).First(&claim)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &claim, nil
+}
+
+// InsertClaim opens a new insurance claim
+func (r *InsuranceRepository) InsertClaim(ctx context.Context, claim *InsuranceClaim) (uint, error) {
+	claim.Status = InsuranceClaimOpen
+	result := r.db.WithContext(ctx).Omit("Policy").Create(claim)
+	return claim.ID, result.Error
+}
+
+// UpdateClaim updates an insurance claim and its status. It returns ErrInsuranceClaimPaid when
+// the payout has been received since it was read.
+func (r *InsuranceRepository) UpdateClaim(ctx context.Context, claim *InsuranceClaim) error {
+	result := r.db.WithContext(ctx).Model(&InsuranceClaim{}).
+		Where("id = ? AND user_id = ? AND status <> ?", claim.ID, claim.UserID, InsuranceClaimPaid).
+		Updates(map[string]interface{}{
+			"policy_id":            claim.PolicyID,
+			"claim_number":         claim.ClaimNumber,
+			"incident_date":        claim.IncidentDate,
+			"incident_description": claim.IncidentDescription,
+			"equipment_id":         claim.EquipmentID,
+			"amount_claimed":       claim.AmountClaimed,
+			"status":               claim.Status,
+			"notes":                claim.Notes,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInsuranceClaimPaid
+	}
+	return nil
+}
+
+// DeleteClaim soft deletes an insurance claim, returning ErrInsuranceClaimPaid when its payout
+// has been received
+func (r *InsuranceRepository) DeleteClaim(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ? AND status <> ?", id, userID, InsuranceClaimPaid).Delete(&InsuranceClaim{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInsuranceClaimPaid
+	}
+	return nil
+}
+
+// RecordPayout records the payout of an insurance claim, booking it as a sale to the insurer paid
+// in full. It returns ErrInsuranceClaimPaid when the payout has already been recorded and
+// ErrInsuranceClaimRejected when the claim was rejected.
+func (r *InsuranceRepository) RecordPayout(ctx context.Context, id uint, userID uint, payout *Income) (*InsuranceClaim, error) {
+	var claim InsuranceClaim
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND user_id = ?", id, userID).First(&claim).Error; err != nil {
+			return err
+		}
+		switch claim.Status {
+		case InsuranceClaimPaid:
+			return ErrInsuranceClaimPaid
+		case InsuranceClaimRejected:
+			return ErrInsuranceClaimRejected
+		}
+
+		payout.TotalAmount = payout.Quantity * payout.PricePerUnit
+		payout.AmountPaid = payout.TotalAmount
+		payout.AmountDue = 0
+		payout.PaymentStatus = PaymentPaid
+		if err := tx.Create(payout).Error; err != nil {
+			return err
+		}
+		if err := recordIncome(tx, EventIncomeCreated, payout, nil); err != nil {
+			return err
+		}
+
+		claim.Status = InsuranceClaimPaid
+		claim.Payout = payout.TotalAmount
+		claim.PaidAt = &payout.Date
+		claim.IncomeID = &payout.ID
+		return tx.Model(&InsuranceClaim{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"status":    claim.Status,
+			"payout":    claim.Payout,
+			"paid_at":   claim.PaidAt,
+			"income_id": claim.IncomeID,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &claim, nil
+}
@@ -1,63 +1,783 @@
 package data
 
+//go:generate go tool mockgen -typed -source=interfaces.go -destination=mocks/mocks.go -package=mocks
+
+import (
+	"context"
+	"time"
+)
+
 // UserInterface defines the methods that must be implemented by a User repository
 type UserInterface interface {
-	GetAll() ([]*User, error)
-	GetByEmail(email string) (*User, error)
-	GetOne(id uint) (*User, error)
-	Insert(user *User) (uint, error)
-	Update(user *User) error
-	Delete(user *User) error
-	DeleteByID(id uint) error
-	ResetPassword(userID uint, newPassword string) error
+	GetAll(ctx context.Context) ([]*User, error)
+	GetByEmail(ctx context.Context, email string) (*User, error)
+	GetOne(ctx context.Context, id uint) (*User, error)
+	Insert(ctx context.Context, user *User) (uint, error)
+	Update(ctx context.Context, user *User) error
+	Delete(ctx context.Context, user *User) error
+	DeleteByID(ctx context.Context, id uint) error
+	ResetPassword(ctx context.Context, userID uint, newPassword string) error
 	PasswordMatches(user *User, plainText string) (bool, error)
+	RecordFailedLogin(ctx context.Context, userID uint, maxFailures int, lockout time.Duration) (*time.Time, error)
+	Unlock(ctx context.Context, userID uint) error
 	// OTP Related methods
-	GenerateAndSaveOTP(email string) (string, error)
-	VerifyOTP(email, otp string) (bool, error)
-	ResetPasswordWithOTP(email, otp, newPassword string) error
+	GenerateAndSaveOTP(ctx context.Context, email, purpose string) (string, error)
+	ResetPasswordWithOTP(ctx context.Context, email, otp, newPassword string) error
+	LoginWithOTP(ctx context.Context, email, otp string) (*User, error)
+	VerifyEmail(ctx context.Context, email, otp string) (*User, error)
+	SetPendingPhone(ctx context.Context, userID uint, phone string) error
+	ConfirmPendingPhone(ctx context.Context, email, otp string) (string, error)
 }
 
 // IncomeInterface defines the methods for income transactions
 type IncomeInterface interface {
-	GetAll(userID uint) ([]*Income, error)
-	GetOne(id uint, userID uint) (*Income, error)
-	Insert(income *Income) (uint, error)
-	Update(income *Income) error
-	Delete(id uint, userID uint) error
-	GetByDateRange(userID uint, startDate, endDate string) ([]*Income, error)
-	GetFinancialSummary(userID uint) (*FinancialSummary, error)
-	GetMonthlyData(userID uint, year int) ([]*MonthlyData, error)
+	GetAll(ctx context.Context, userID uint) ([]*Income, error)
+	GetPage(ctx context.Context, userID uint, filter IncomeFilter, offset, limit int) ([]*Income, int64, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*Income, error)
+	Insert(ctx context.Context, income *Income) (uint, error)
+	Update(ctx context.Context, income *Income) error
+	Delete(ctx context.Context, id uint, userID uint) error
+	GetDeleted(ctx context.Context, userID uint) ([]*Income, error)
+	Restore(ctx context.Context, id uint, userID uint) (*Income, error)
+	Purge(ctx context.Context, id uint, userID uint) error
+	GetByDateRange(ctx context.Context, userID uint, startDate, endDate string) ([]*Income, error)
+	GetFinancialSummary(ctx context.Context, userID uint, start, end *time.Time) (*FinancialSummary, error)
+	GetMonthlyData(ctx context.Context, userID uint, year int) ([]*MonthlyData, error)
+	GetMonthlyDataBetween(ctx context.Context, userID uint, start, end time.Time) ([]*MonthlyData, error)
+	GetByCustomer(ctx context.Context, userID uint, customerName string) ([]*Income, error)
+	GetOutstanding(ctx context.Context, userID uint) ([]*Income, error)
+	AssignInvoiceNumber(ctx context.Context, id uint, userID uint) (string, error)
+	GetPendingApproval(ctx context.Context, userID uint) ([]*Income, error)
+	Review(ctx context.Context, id uint, userID uint, status SaleApproval, reviewerID uint, reason *string) error
+	GetArchived(ctx context.Context, userID uint, startDate, endDate string) ([]*ArchivedIncome, error)
+	GetArchivedOne(ctx context.Context, id uint, userID uint) (*ArchivedIncome, error)
 }
 
 // ExpenseInterface defines the methods for expense transactions
 type ExpenseInterface interface {
-	GetAll(userID uint) ([]*Expense, error)
-	GetOne(id uint, userID uint) (*Expense, error)
-	Insert(expense *Expense) (uint, error)
-	Update(expense *Expense) error
-	Delete(id uint, userID uint) error
-	GetByDateRange(userID uint, startDate, endDate string) ([]*Expense, error)
-	GetCategoryBreakdown(userID uint) ([]*CategoryBreakdown, error)
-	GetMonthlyData(userID uint, year int) ([]*MonthlyData, error)
-	GetFinancialSummary(userID uint) (*FinancialSummary, error)
+	GetAll(ctx context.Context, userID uint) ([]*Expense, error)
+	GetPage(ctx context.Context, userID uint, filter ExpenseFilter, offset, limit int) ([]*Expense, int64, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*Expense, error)
+	Insert(ctx context.Context, expense *Expense) (uint, error)
+	Update(ctx context.Context, expense *Expense) error
+	Delete(ctx context.Context, id uint, userID uint) error
+	GetDeleted(ctx context.Context, userID uint) ([]*Expense, error)
+	Restore(ctx context.Context, id uint, userID uint) (*Expense, error)
+	Purge(ctx context.Context, id uint, userID uint) error
+	GetPendingApproval(ctx context.Context, userID uint) ([]*Expense, error)
+	Review(ctx context.Context, id uint, userID uint, status SaleApproval, reviewerID uint, reason *string) error
+	GetAwaitingSignOff(ctx context.Context, userID uint) ([]*Expense, error)
+	GetSignOffs(ctx context.Context, id uint, userID uint) ([]*ExpenseSignOff, error)
+	SignOff(ctx context.Context, id uint, userID uint, approverID uint) (*Expense, error)
+	GetPrepaid(ctx context.Context, userID uint) ([]*AmortizationSchedule, error)
+	GetAmortization(ctx context.Context, id uint, userID uint) (*AmortizationSchedule, error)
+	GetByDateRange(ctx context.Context, userID uint, startDate, endDate string) ([]*Expense, error)
+	GetCategoryBreakdown(ctx context.Context, userID uint) ([]*CategoryBreakdown, error)
+	GetMonthlyData(ctx context.Context, userID uint, year int) ([]*MonthlyData, error)
+	GetMonthlyDataBetween(ctx context.Context, userID uint, start, end time.Time) ([]*MonthlyData, error)
+	GetFinancialSummary(ctx context.Context, userID uint, start, end *time.Time) (*FinancialSummary, error)
+	GetArchived(ctx context.Context, userID uint, startDate, endDate string) ([]*ArchivedExpense, error)
+	GetArchivedOne(ctx context.Context, id uint, userID uint) (*ArchivedExpense, error)
+}
+
+// ClaimInterface defines the methods for staff reimbursement claims
+type ClaimInterface interface {
+	GetAll(ctx context.Context, userID uint, claimantID *uint, status *ClaimStatus) ([]*ExpenseClaim, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*ExpenseClaim, error)
+	Insert(ctx context.Context, claim *ExpenseClaim) (uint, error)
+	Update(ctx context.Context, claim *ExpenseClaim) error
+	Delete(ctx context.Context, id uint, userID uint) error
+	Approve(ctx context.Context, id uint, userID uint, reviewerID uint, signOff *SignOffStatus) (*ExpenseClaim, error)
+	Reject(ctx context.Context, id uint, userID uint, reviewerID uint, reason string) error
+	GetStatement(ctx context.Context, userID uint, claimantID uint) (*ClaimantStatement, error)
 }
 
 // InventoryInterface defines the methods for inventory management
 type InventoryInterface interface {
-	GetAll(userID uint) ([]*InventoryItem, error)
-	GetOne(id uint, userID uint) (*InventoryItem, error)
-	Insert(item *InventoryItem) (uint, error)
-	Update(item *InventoryItem) error
-	Delete(id uint, userID uint) error
-	GetLowStockItems(userID uint) ([]*InventoryItem, error)
-	UpdateQuantity(id uint, userID uint, quantity float64) error
+	GetAll(ctx context.Context, userID uint) ([]*InventoryItem, error)
+	GetPage(ctx context.Context, userID uint, siteID *uint, offset, limit int) ([]*InventoryItem, int64, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*InventoryItem, error)
+	Insert(ctx context.Context, item *InventoryItem) (uint, error)
+	Update(ctx context.Context, item *InventoryItem) error
+	Delete(ctx context.Context, id uint, userID uint) error
+	GetDeleted(ctx context.Context, userID uint) ([]*InventoryItem, error)
+	Restore(ctx context.Context, id uint, userID uint) (*InventoryItem, error)
+	Purge(ctx context.Context, id uint, userID uint) error
+	GetLowStockItems(ctx context.Context, userID uint) ([]*InventoryItem, error)
+	UpdateQuantity(ctx context.Context, id uint, userID uint, quantity float64) error
+	GetMovements(ctx context.Context, id uint, userID uint) ([]*StockMovement, error)
+	GetExpiringItems(ctx context.Context, userID uint, before time.Time) ([]*InventoryItem, error)
+	GetAllExpiringItems(ctx context.Context, before time.Time) ([]*InventoryItem, error)
+	GetHazardousItems(ctx context.Context, userID uint) ([]*InventoryItem, error)
+	RecordUsage(ctx context.Context, id uint, userID uint, quantity float64, reason *string) (*StockMovement, error)
+	WriteOff(ctx context.Context, id uint, userID uint, quantity float64, reason string) (*StockMovement, error)
+	GetUsageSince(ctx context.Context, id uint, userID uint, since time.Time) (float64, error)
+	GetMonthlyUsage(ctx context.Context, userID uint, since time.Time) ([]*MonthlyUsage, error)
+	SetPrimaryImage(ctx context.Context, id uint, userID uint, imageID *uint) error
+}
+
+// StocktakeInterface defines the methods for stocktake/cycle count sessions
+type StocktakeInterface interface {
+	GetAll(ctx context.Context, userID uint) ([]*Stocktake, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*Stocktake, error)
+	Create(ctx context.Context, stocktake *Stocktake, itemIDs []uint) (uint, error)
+	RecordCounts(ctx context.Context, id uint, userID uint, counts map[uint]float64) error
+	Approve(ctx context.Context, id uint, userID uint) error
+	Cancel(ctx context.Context, id uint, userID uint) error
+	GetVarianceReport(ctx context.Context, id uint, userID uint) (*VarianceReport, error)
 }
 
 // Models wraps all repository interfaces
 type Models struct {
-	User      UserInterface
-	Income    IncomeInterface
-	Expense   ExpenseInterface
-	Inventory InventoryInterface
-	MineSite  MineSiteInterface
+	User         UserInterface
+	Income       IncomeInterface
+	Expense      ExpenseInterface
+	Claim        ClaimInterface
+	Procurement  ProcurementInterface
+	Inventory    InventoryInterface
+	MineSite     MineSiteInterface
+	Stocktake    StocktakeInterface
+	Notification NotificationInterface
+	Vehicle      VehicleInterface
+	Equipment    EquipmentInterface
+	Insurance    InsuranceInterface
+	SupplyPrice  SupplyPriceInterface
+	Allocation   CostAllocationInterface
+	Trip         TripInterface
+	Contractor   ContractorInterface
+	Employee     EmployeeInterface
+	Timesheet    TimesheetInterface
+	Payroll      PayrollInterface
+	Settings     SettingsInterface
+	Organization OrganizationInterface
+	Audit        AuditInterface
+	Job          JobInterface
+	Delivery     DeliveryInterface
+	InviteCode   InviteCodeInterface
+	Identity     IdentityInterface
+	RefreshToken RefreshTokenInterface
+	RevokedToken RevokedTokenInterface
+	ShareLink    ShareLinkInterface
+	Receipt      ReceiptInterface
+	CreditNote   CreditNoteInterface
+	Template     DocumentTemplateInterface
+	Dunning      DunningInterface
+	Task         TaskInterface
+	Attendance   AttendanceInterface
+	Evidence     EvidenceInterface
+	Attachment   AttachmentInterface
+	DueDiligence DueDiligenceInterface
+	LotSeal      LotSealInterface
+	Payment      PaymentInterface
+	Assay        AssayInterface
+	Purchase     PurchaseInterface
+	Miner        MinerInterface
+	MarketPrice  MarketPriceInterface
+	CashDay      CashDayInterface
+	Till         TillInterface
+	Handover     ShiftHandoverInterface
+	BulkSMS      BulkSMSInterface
+	Contact      ContactInterface
+	CreditLimit  CreditLimitInterface
+	Flag         FlagInterface
+	Benchmark    BenchmarkInterface
+	Trade        TradeInterface
+	Webhook      WebhookInterface
+	Backup       BackupInterface
+	Usage        UsageInterface
+	Feature      FeatureInterface
+	Referral     ReferralInterface
+	Support      SupportInterface
+	Lease        LeaseInterface
+	RateLimit    RateLimitInterface
+	Idempotency  IdempotencyInterface
+	Archive      ArchiveInterface
+	Warehouse    WarehouseInterface
+	Stream       StreamInterface
+	Outbox       OutboxInterface
+}
+
+// NotificationInterface defines the methods for in-app notifications
+type NotificationInterface interface {
+	GetAll(ctx context.Context, userID uint, unreadOnly bool) ([]*Notification, error)
+	Insert(ctx context.Context, notification *Notification) (bool, error)
+	MarkRead(ctx context.Context, id uint, userID uint) error
+	MarkAllRead(ctx context.Context, userID uint) error
+}
+
+// EquipmentInterface defines the methods for the equipment asset register
+type EquipmentInterface interface {
+	GetAll(ctx context.Context, userID uint) ([]*Equipment, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*Equipment, error)
+	Insert(ctx context.Context, equipment *Equipment) (uint, error)
+	Update(ctx context.Context, equipment *Equipment) error
+	Delete(ctx context.Context, id uint, userID uint) error
+	GetDepreciation(ctx context.Context, id uint, userID uint) ([]*DepreciationEntry, error)
+	GetRegister(ctx context.Context, userID uint, asOf time.Time) (*AssetRegister, error)
+	Dispose(ctx context.Context, id uint, userID uint, disposal *EquipmentDisposal) (*Equipment, error)
+}
+
+// CostAllocationInterface defines the methods for the rules splitting shared expenses across
+// mine sites, and the site totals they are applied to
+type CostAllocationInterface interface {
+	GetAll(ctx context.Context, userID uint) ([]*CostAllocationRule, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*CostAllocationRule, error)
+	Insert(ctx context.Context, rule *CostAllocationRule) (uint, error)
+	Update(ctx context.Context, rule *CostAllocationRule) error
+	Delete(ctx context.Context, id uint, userID uint) error
+	GetSiteIncome(ctx context.Context, userID uint, start, end time.Time) ([]*SiteIncome, error)
+	GetSiteExpenses(ctx context.Context, userID uint, start, end time.Time) ([]*SiteExpense, error)
+}
+
+// SupplyPriceInterface defines the methods for the shared supply price list
+type SupplyPriceInterface interface {
+	GetAll(ctx context.Context, userID uint) ([]*SupplyPrice, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*SupplyPrice, error)
+	Insert(ctx context.Context, price *SupplyPrice) (uint, error)
+	Update(ctx context.Context, price *SupplyPrice) error
+	Delete(ctx context.Context, id uint, userID uint) error
+	GetFlaggedExpenses(ctx context.Context, userID uint) ([]*Expense, error)
+}
+
+// ProcurementInterface defines the methods for procurement requests and the purchase orders
+// they are bought on
+type ProcurementInterface interface {
+	GetRequests(ctx context.Context, userID uint, requesterID *uint, status *ProcurementStatus) ([]*ProcurementRequest, error)
+	GetRequest(ctx context.Context, id uint, userID uint) (*ProcurementRequest, error)
+	InsertRequest(ctx context.Context, request *ProcurementRequest) (uint, error)
+	UpdateRequest(ctx context.Context, request *ProcurementRequest) error
+	DeleteRequest(ctx context.Context, id uint, userID uint) error
+	ReviewRequest(ctx context.Context, id uint, userID uint, status ProcurementStatus, reviewerID uint, reason *string) error
+	GetOrders(ctx context.Context, userID uint, status *PurchaseOrderStatus) ([]*PurchaseOrder, error)
+	GetOrder(ctx context.Context, id uint, userID uint) (*PurchaseOrder, error)
+	InsertOrder(ctx context.Context, order *PurchaseOrder, prices map[uint]float64) (uint, error)
+	CancelOrder(ctx context.Context, id uint, userID uint) error
+	ReceiveOrder(ctx context.Context, id uint, userID uint, expense *Expense) (*PurchaseOrder, error)
+}
+
+// InsuranceInterface defines the methods for insurance policies and claims
+type InsuranceInterface interface {
+	GetPolicies(ctx context.Context, userID uint) ([]*InsurancePolicy, error)
+	GetPolicy(ctx context.Context, id uint, userID uint) (*InsurancePolicy, error)
+	InsertPolicy(ctx context.Context, policy *InsurancePolicy) (uint, error)
+	UpdatePolicy(ctx context.Context, policy *InsurancePolicy) error
+	DeletePolicy(ctx context.Context, id uint, userID uint) error
+	GetAllExpiringPolicies(ctx context.Context, from, to time.Time) ([]*InsurancePolicy, error)
+	GetClaims(ctx context.Context, userID uint, policyID *uint, status *InsuranceClaimStatus) ([]*InsuranceClaim, error)
+	GetClaim(ctx context.Context, id uint, userID uint) (*InsuranceClaim, error)
+	InsertClaim(ctx context.Context, claim *InsuranceClaim) (uint, error)
+	UpdateClaim(ctx context.Context, claim *InsuranceClaim) error
+	DeleteClaim(ctx context.Context, id uint, userID uint) error
+	RecordPayout(ctx context.Context, id uint, userID uint, payout *Income) (*InsuranceClaim, error)
+}
+
+// VehicleInterface defines the methods for vehicle management
+type VehicleInterface interface {
+	GetAll(ctx context.Context, userID uint) ([]*Vehicle, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*Vehicle, error)
+	Insert(ctx context.Context, vehicle *Vehicle) (uint, error)
+	Update(ctx context.Context, vehicle *Vehicle) error
+	Delete(ctx context.Context, id uint, userID uint) error
+}
+
+// TripInterface defines the methods for trip logging and transport analytics
+type TripInterface interface {
+	GetAll(ctx context.Context, userID uint) ([]*Trip, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*Trip, error)
+	Insert(ctx context.Context, trip *Trip) (uint, error)
+	Update(ctx context.Context, trip *Trip) error
+	Delete(ctx context.Context, id uint, userID uint) error
+	GetVehicleCosts(ctx context.Context, userID uint) ([]*VehicleCost, error)
+	GetDeliveryCosts(ctx context.Context, userID uint) ([]*DeliveryCost, error)
+}
+
+// ContractorInterface defines the methods for contractor and labor gang management
+type ContractorInterface interface {
+	GetAll(ctx context.Context, userID uint) ([]*Contractor, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*Contractor, error)
+	Insert(ctx context.Context, contractor *Contractor) (uint, error)
+	Update(ctx context.Context, contractor *Contractor) error
+	Delete(ctx context.Context, id uint, userID uint) error
+	RecordWork(ctx context.Context, work *WorkRecord, expense *Expense) (uint, error)
+	GetWork(ctx context.Context, contractorID uint, userID uint) ([]*WorkRecord, error)
+	DeleteWork(ctx context.Context, id uint, contractorID uint, userID uint) error
+	GetStatement(ctx context.Context, id uint, userID uint) (*ContractorStatement, error)
+}
+
+// EmployeeInterface defines the methods for employee management
+type EmployeeInterface interface {
+	GetAll(ctx context.Context, userID uint) ([]*Employee, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*Employee, error)
+	Insert(ctx context.Context, employee *Employee) (uint, error)
+	Update(ctx context.Context, employee *Employee) error
+	Delete(ctx context.Context, id uint, userID uint) error
+}
+
+// AttendanceInterface defines the methods for geofenced check-in and check-out
+type AttendanceInterface interface {
+	GetAll(ctx context.Context, userID uint, filter AttendanceFilter) ([]*Attendance, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*Attendance, error)
+	GetLatest(ctx context.Context, employeeID uint, userID uint) (*Attendance, error)
+	CheckIn(ctx context.Context, attendance *Attendance) (uint, error)
+	CheckOut(ctx context.Context, attendance *Attendance) error
+}
+
+// TimesheetInterface defines the methods for timesheet submission, approval and labor costing
+type TimesheetInterface interface {
+	GetAll(ctx context.Context, userID uint, status string, start, end *time.Time) ([]*Timesheet, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*Timesheet, error)
+	Insert(ctx context.Context, timesheet *Timesheet) (uint, error)
+	Delete(ctx context.Context, id uint, userID uint) error
+	Review(ctx context.Context, id uint, userID uint, status TimesheetStatus, reason *string) error
+	GetApprovedHours(ctx context.Context, userID uint, start, end time.Time) ([]*EmployeeHours, error)
+	GetLaborCostByPit(ctx context.Context, userID uint, start, end time.Time) ([]*PitLaborCost, error)
+}
+
+// PayrollInterface defines the methods for advances, deductions and payroll runs
+type PayrollInterface interface {
+	GetAdjustments(ctx context.Context, employeeID uint, userID uint) ([]*EmployeeAdjustment, error)
+	InsertAdjustment(ctx context.Context, adjustment *EmployeeAdjustment) (uint, error)
+	DeleteAdjustment(ctx context.Context, id uint, employeeID uint, userID uint) error
+	GetAllRuns(ctx context.Context, userID uint) ([]*PayrollRun, error)
+	GetRun(ctx context.Context, id uint, userID uint) (*PayrollRun, error)
+	CreateRun(ctx context.Context, run *PayrollRun) error
+	DeleteRun(ctx context.Context, id uint, userID uint) error
+	GetStatement(ctx context.Context, employeeID uint, userID uint) (*EmployeeStatement, error)
+}
+
+// SettingsInterface defines the methods for organization settings
+type SettingsInterface interface {
+	GetByUserID(ctx context.Context, userID uint) (*OrganizationSettings, error)
+	Save(ctx context.Context, settings *OrganizationSettings) error
+	GetSequences(ctx context.Context, userID uint) (map[DocumentKind]int, error)
+	GetByCalendarToken(ctx context.Context, token string) (*OrganizationSettings, error)
+}
+
+// OrganizationInterface defines the methods for organizations and their members
+type OrganizationInterface interface {
+	GetMemberships(ctx context.Context, userID uint) ([]*OrganizationMember, error)
+	GetMembership(ctx context.Context, organizationID uint, userID uint) (*OrganizationMember, error)
+	GetOne(ctx context.Context, id uint) (*Organization, error)
+	Create(ctx context.Context, organization *Organization) (uint, error)
+	Update(ctx context.Context, organization *Organization) error
+	AddMember(ctx context.Context, member *OrganizationMember) (uint, error)
+	UpdateMember(ctx context.Context, organizationID uint, userID uint, role OrganizationRole, canExport bool) error
+	RemoveMember(ctx context.Context, organizationID uint, userID uint) error
+	GetIPRules(ctx context.Context, organizationID uint) ([]*OrganizationIPRule, error)
+	AddIPRule(ctx context.Context, rule *OrganizationIPRule) (uint, error)
+	DeleteIPRule(ctx context.Context, id uint, organizationID uint) error
+	HasMember(ctx context.Context, ownerID uint, userID uint) (bool, error)
+	GetPermissionHolders(ctx context.Context, ownerID uint, permission Permission) ([]*User, error)
+	GetRolePermissions(ctx context.Context, organizationID uint, role OrganizationRole) (*RolePermissions, error)
+	SetRolePermissions(ctx context.Context, organizationID uint, role OrganizationRole, permissions []Permission) error
+	ResetRolePermissions(ctx context.Context, organizationID uint, role OrganizationRole) error
+	CreateInvitation(ctx context.Context, invitation *OrganizationInvitation) (uint, error)
+	SetInvitationDelivery(ctx context.Context, id uint, deliveryID uint) error
+	GetInvitations(ctx context.Context, organizationID uint) ([]*OrganizationInvitation, error)
+	GetInvitationByToken(ctx context.Context, tokenHash string) (*OrganizationInvitation, error)
+	AcceptInvitation(ctx context.Context, tokenHash string, userID uint) (*OrganizationMember, error)
+	DeleteInvitation(ctx context.Context, id uint, organizationID uint) error
+}
+
+// AuditInterface defines the methods for the audit log
+type AuditInterface interface {
+	Insert(ctx context.Context, entry *AuditLog) error
+	GetAll(ctx context.Context, userID uint, action string) ([]*AuditLog, error)
+}
+
+// JobInterface defines the methods for the background job queue
+type JobInterface interface {
+	Enqueue(ctx context.Context, jobType string, payload interface{}) (uint, error)
+	ClaimNext(ctx context.Context) (*Job, error)
+	Complete(ctx context.Context, id uint) error
+	Fail(ctx context.Context, id uint, message string, retryAt *time.Time) error
+}
+
+// DeliveryInterface defines the methods for tracking outgoing emails and SMS
+type DeliveryInterface interface {
+	GetAll(ctx context.Context, recipient string) ([]*MessageDelivery, error)
+	Insert(ctx context.Context, delivery *MessageDelivery) (uint, error)
+	MarkSent(ctx context.Context, id uint, channel DeliveryChannel) error
+	MarkFailed(ctx context.Context, id uint, message string) error
+}
+
+// InviteCodeInterface defines the methods for signup invite codes
+type InviteCodeInterface interface {
+	GetAll(ctx context.Context) ([]*InviteCode, error)
+	Insert(ctx context.Context, code *InviteCode) (uint, error)
+	EnsureCode(ctx context.Context, code string, role UserRole) error
+	Revoke(ctx context.Context, id uint) error
+	Redeem(ctx context.Context, code string) (*InviteCode, error)
+	Release(ctx context.Context, id uint) error
+}
+
+// IdentityInterface defines the methods for linked sign-in identities
+type IdentityInterface interface {
+	GetByProvider(ctx context.Context, provider IdentityProvider, subject string) (*UserIdentity, error)
+	GetByUserID(ctx context.Context, userID uint) ([]*UserIdentity, error)
+	Link(ctx context.Context, identity *UserIdentity) error
+	Unlink(ctx context.Context, userID uint, provider IdentityProvider) error
+}
+
+// RefreshTokenInterface defines the methods for the refresh tokens of signed in clients
+type RefreshTokenInterface interface {
+	Insert(ctx context.Context, token *RefreshToken) error
+	Rotate(ctx context.Context, tokenHash string, replacement *RefreshToken) (*RefreshToken, error)
+	Revoke(ctx context.Context, tokenHash string, userID uint) error
+	RevokeAllForUser(ctx context.Context, userID uint) error
+	DeleteExpiredBefore(ctx context.Context, t time.Time) (int64, error)
+}
+
+// RevokedTokenInterface defines the methods for access tokens revoked before they expire
+type RevokedTokenInterface interface {
+	Revoke(ctx context.Context, token *RevokedToken) error
+	IsRevoked(ctx context.Context, tokenHash string) (bool, error)
+	DeleteExpiredBefore(ctx context.Context, t time.Time) (int64, error)
+}
+
+// ShareLinkInterface defines the methods for public document links
+type ShareLinkInterface interface {
+	GetAll(ctx context.Context, userID uint) ([]*ShareLink, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*ShareLink, error)
+	GetActive(ctx context.Context, id uint) (*ShareLink, error)
+	Insert(ctx context.Context, link *ShareLink) (uint, error)
+	Revoke(ctx context.Context, id uint, userID uint) error
+	RecordView(ctx context.Context, id uint, ipAddress string, userAgent *string) error
+	GetViews(ctx context.Context, id uint, userID uint) ([]*ShareLinkView, error)
+	Confirm(ctx context.Context, id uint, confirmedBy string) error
+}
+
+// ReceiptInterface defines the methods for payment receipts
+type ReceiptInterface interface {
+	GetAll(ctx context.Context, userID uint) ([]*Receipt, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*Receipt, error)
+	GetByID(ctx context.Context, id uint) (*Receipt, error)
+	GetByIncome(ctx context.Context, incomeID uint, userID uint) ([]*Receipt, error)
+	Insert(ctx context.Context, receipt *Receipt) (uint, error)
+	MarkSent(ctx context.Context, id uint, userID uint) error
+}
+
+// CreditNoteInterface defines the methods for credit notes issued against sales
+type CreditNoteInterface interface {
+	GetAll(ctx context.Context, userID uint) ([]*CreditNote, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*CreditNote, error)
+	GetByIncome(ctx context.Context, incomeID uint, userID uint) ([]*CreditNote, error)
+	Insert(ctx context.Context, note *CreditNote) (*Income, error)
+}
+
+// DocumentTemplateInterface defines the methods for the templates of invoice and receipt PDFs
+type DocumentTemplateInterface interface {
+	Get(ctx context.Context, userID uint, kind DocumentKind) (*DocumentTemplate, error)
+	Save(ctx context.Context, template *DocumentTemplate) error
+}
+
+// DunningInterface defines the methods for payment reminder schedules and their history
+type DunningInterface interface {
+	GetSchedules(ctx context.Context, userID uint) ([]*DunningSchedule, error)
+	GetSchedule(ctx context.Context, id uint, userID uint) (*DunningSchedule, error)
+	CreateSchedule(ctx context.Context, schedule *DunningSchedule) (uint, error)
+	UpdateSchedule(ctx context.Context, schedule *DunningSchedule) error
+	DeleteSchedule(ctx context.Context, id uint, userID uint) error
+	GetActiveSchedules(ctx context.Context) ([]*DunningSchedule, error)
+	GetHistory(ctx context.Context, incomeID uint, userID uint) ([]*DunningEvent, error)
+	ClaimStep(ctx context.Context, event *DunningEvent) (bool, error)
+	UpdateEvent(ctx context.Context, event *DunningEvent) error
+}
+
+// TaskInterface defines the methods for tasks
+type TaskInterface interface {
+	GetAll(ctx context.Context, userID uint, filter TaskFilter) ([]*Task, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*Task, error)
+	Insert(ctx context.Context, task *Task) (uint, error)
+	Update(ctx context.Context, task *Task) error
+	Delete(ctx context.Context, id uint, userID uint) error
+	Complete(ctx context.Context, id uint, userID uint, completedByID uint) error
+	Reopen(ctx context.Context, id uint, userID uint) error
+	GetOverdue(ctx context.Context, before time.Time) ([]*Task, error)
+}
+
+// EvidenceInterface defines the methods for photo evidence rules and photos
+type EvidenceInterface interface {
+	GetRules(ctx context.Context, userID uint) ([]*EvidenceRule, error)
+	SaveRule(ctx context.Context, rule *EvidenceRule) error
+	DeleteRule(ctx context.Context, userID uint, operation EvidenceOperation) error
+	RequiresPhoto(ctx context.Context, userID uint, operation EvidenceOperation, amount float64) (bool, error)
+	InsertPhoto(ctx context.Context, photo *EvidencePhoto) (uint, error)
+	LinkPhoto(ctx context.Context, id uint, userID uint, recordType EvidenceRecordType, recordID uint) error
+	DeletePhoto(ctx context.Context, id uint, userID uint) error
+	GetPhoto(ctx context.Context, id uint, userID uint) (*EvidencePhoto, error)
+	GetPhotos(ctx context.Context, userID uint, recordType EvidenceRecordType, recordID uint) ([]*EvidencePhoto, error)
+	HasPhoto(ctx context.Context, userID uint, recordType EvidenceRecordType, recordID uint) (bool, error)
+	GetStorage(ctx context.Context, userID uint) (*AttachmentStorage, error)
+}
+
+// AttachmentInterface defines the methods for files attached to income and expense records
+type AttachmentInterface interface {
+	Insert(ctx context.Context, attachment *Attachment) error
+	GetOne(ctx context.Context, id uint, userID uint) (*Attachment, error)
+	GetForRecord(ctx context.Context, userID uint, recordType AttachmentRecordType, recordID uint) ([]*Attachment, error)
+	Delete(ctx context.Context, id uint, userID uint) error
+	GetStorage(ctx context.Context, userID uint) (*AttachmentStorage, error)
+}
+
+// DueDiligenceInterface defines the methods for due-diligence self-assessments of mine sites
+type DueDiligenceInterface interface {
+	GetAll(ctx context.Context, userID uint, siteID *uint) ([]*DueDiligenceAssessment, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*DueDiligenceAssessment, error)
+	Insert(ctx context.Context, assessment *DueDiligenceAssessment) (uint, error)
+	Update(ctx context.Context, assessment *DueDiligenceAssessment) error
+	Complete(ctx context.Context, id uint, userID uint, completedByID uint) error
+	Delete(ctx context.Context, id uint, userID uint) error
+}
+
+// LotSealInterface defines the methods for chain-of-custody seals of sold lots
+type LotSealInterface interface {
+	Insert(ctx context.Context, seal *LotSeal) error
+	GetByID(ctx context.Context, id uint) (*LotSeal, error)
+	GetByIncome(ctx context.Context, incomeID uint, userID uint) (*LotSeal, error)
+}
+
+// PaymentInterface defines the methods for the payments of income and expense records
+type PaymentInterface interface {
+	GetForRecord(ctx context.Context, userID uint, recordType TransactionType, recordID uint) ([]*Payment, error)
+	RecordIncomePayment(ctx context.Context, payment *Payment) (*Income, error)
+	RecordExpensePayment(ctx context.Context, payment *Payment) (*Expense, error)
+	RecordPurchasePayment(ctx context.Context, payment *Payment) (*Purchase, error)
+}
+
+// PurchaseInterface defines the methods for purchases of ore from miners and what is owed to them
+type PurchaseInterface interface {
+	GetAll(ctx context.Context, userID uint) ([]*Purchase, error)
+	GetPage(ctx context.Context, userID uint, siteID *uint, offset, limit int) ([]*Purchase, int64, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*Purchase, error)
+	Insert(ctx context.Context, purchase *Purchase) (uint, error)
+	Update(ctx context.Context, purchase *Purchase) error
+	Delete(ctx context.Context, id uint, userID uint) error
+	GetPayables(ctx context.Context, userID uint, outstandingOnly bool) ([]*MinerPayable, error)
+	GetStatement(ctx context.Context, userID uint, minerName string) (*MinerStatement, error)
+	GetForMiner(ctx context.Context, userID uint, minerID uint) ([]*Purchase, error)
+	GetPriceOverrides(ctx context.Context, userID uint, purchaseID uint) ([]*PurchasePriceOverride, error)
+}
+
+// MinerInterface defines the methods for the registry of miners supplying a buying station
+type MinerInterface interface {
+	GetAll(ctx context.Context, userID uint, siteID *uint) ([]*Miner, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*Miner, error)
+	Insert(ctx context.Context, miner *Miner) (uint, error)
+	Update(ctx context.Context, miner *Miner) error
+	Delete(ctx context.Context, id uint, userID uint) error
+}
+
+// MarketPriceInterface defines the methods for the market prices purchases are priced from
+type MarketPriceInterface interface {
+	GetAll(ctx context.Context, userID uint, mineralType MineralType) ([]*MarketPrice, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*MarketPrice, error)
+	GetCurrent(ctx context.Context, userID uint, mineralType MineralType, unit string, siteID *uint, date time.Time) (*MarketPrice, error)
+	Insert(ctx context.Context, price *MarketPrice) (uint, error)
+	Delete(ctx context.Context, id uint, userID uint) error
+}
+
+// CashDayInterface defines the methods for the daily cash position of buying stations
+type CashDayInterface interface {
+	GetAll(ctx context.Context, userID uint, siteID *uint, tillID *uint, status CashDayStatus) ([]*CashDay, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*CashDay, error)
+	Open(ctx context.Context, day *CashDay) error
+	AddMovement(ctx context.Context, movement *CashMovement) error
+	Close(ctx context.Context, day *CashDay) error
+	GetDiscrepancyReport(ctx context.Context, userID uint, siteID *uint, from, to time.Time) (*CashDiscrepancyReport, error)
+}
+
+// TillInterface defines the methods for the tills of buying stations and their clerks
+type TillInterface interface {
+	GetAll(ctx context.Context, userID uint, siteID *uint) ([]*Till, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*Till, error)
+	GetForClerk(ctx context.Context, userID uint, clerkID uint) (*Till, error)
+	Insert(ctx context.Context, till *Till) (uint, error)
+	Update(ctx context.Context, till *Till) error
+	Delete(ctx context.Context, id uint, userID uint) error
+	GetVarianceReport(ctx context.Context, userID uint, siteID *uint, from, to time.Time) (*TillVarianceReport, error)
+}
+
+// ShiftHandoverInterface defines the methods for the shift handovers between clerks
+type ShiftHandoverInterface interface {
+	GetAll(ctx context.Context, userID uint, siteID *uint, tillID *uint, status ShiftHandoverStatus) ([]*ShiftHandover, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*ShiftHandover, error)
+	Insert(ctx context.Context, handover *ShiftHandover) (uint, error)
+	SignOff(ctx context.Context, handover *ShiftHandover) error
+}
+
+// AssayInterface defines the methods for the assay results of production batches and sales
+type AssayInterface interface {
+	GetAll(ctx context.Context, userID uint, filter AssayFilter) ([]*Assay, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*Assay, error)
+	Insert(ctx context.Context, assay *Assay) (uint, error)
+	Update(ctx context.Context, assay *Assay) error
+	Delete(ctx context.Context, id uint, userID uint) error
+	GetAssayedSales(ctx context.Context, userID uint, mineralType MineralType, since time.Time) ([]*AssayedSale, error)
+}
+
+// BulkSMSInterface defines the methods for SMS campaigns, their recipients and opt-outs
+type BulkSMSInterface interface {
+	GetCampaigns(ctx context.Context, userID uint) ([]*SMSCampaign, error)
+	GetCampaign(ctx context.Context, id uint, userID uint) (*SMSCampaign, error)
+	InsertCampaign(ctx context.Context, campaign *SMSCampaign) (uint, error)
+	SetRecipientDelivery(ctx context.Context, id uint, deliveryID uint, body string) error
+	GetRecipient(ctx context.Context, id uint) (*SMSCampaignRecipient, error)
+	CountSentSince(ctx context.Context, userID uint, since time.Time) (int64, error)
+	GetContacts(ctx context.Context, userID uint) ([]*SMSContact, error)
+	GetOptOuts(ctx context.Context, userID uint) ([]*SMSOptOut, error)
+	GetOptedOutPhones(ctx context.Context, userID uint) (map[string]bool, error)
+	OptOut(ctx context.Context, optOut *SMSOptOut) error
+	RemoveOptOut(ctx context.Context, id uint, userID uint) error
+}
+
+// ContactInterface defines the methods for the customer and supplier contact book
+type ContactInterface interface {
+	GetAll(ctx context.Context, userID uint, contactType ContactType) ([]*Contact, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*Contact, error)
+	Insert(ctx context.Context, contact *Contact) (uint, error)
+	Update(ctx context.Context, contact *Contact) error
+	Delete(ctx context.Context, id uint, userID uint) error
+	Import(ctx context.Context, userID uint, contacts []*Contact) (*ContactImportResult, error)
+}
+
+// CreditLimitInterface defines the methods for customer credit limits
+type CreditLimitInterface interface {
+	GetAll(ctx context.Context, userID uint) ([]*CustomerCreditLimit, error)
+	GetByCustomer(ctx context.Context, userID uint, customerName string) (*CustomerCreditLimit, error)
+	Save(ctx context.Context, limit *CustomerCreditLimit) error
+	Delete(ctx context.Context, id uint, userID uint) error
+	GetOutstanding(ctx context.Context, userID uint, customerName string) (float64, error)
+}
+
+// FlagInterface defines the methods for high-risk and blacklisted customers and suppliers
+type FlagInterface interface {
+	GetAll(ctx context.Context, userID uint, flagType ContactType) ([]*CounterpartyFlag, error)
+	GetFlag(ctx context.Context, userID uint, flagType ContactType, name string) (*CounterpartyFlag, error)
+	Save(ctx context.Context, flag *CounterpartyFlag) error
+	Delete(ctx context.Context, id uint, userID uint) error
+}
+
+// BenchmarkInterface defines the methods for anonymous price benchmarks
+type BenchmarkInterface interface {
+	GetPriceBenchmark(ctx context.Context, mineralType MineralType, unit string, region string, since time.Time) (*PriceBenchmark, error)
+	GetAveragePrice(ctx context.Context, userID uint, mineralType MineralType, unit string, since time.Time) (float64, int64, error)
+}
+
+// TradeInterface defines the methods for sales shared between trading partners
+type TradeInterface interface {
+	Share(ctx context.Context, sale *SharedSale) error
+	GetIncoming(ctx context.Context, buyerID uint, status TradeStatus) ([]*SharedSale, error)
+	GetPurchase(ctx context.Context, id uint, buyerID uint) (*SharedSale, error)
+	GetOutgoing(ctx context.Context, sellerID uint) ([]*SharedSale, error)
+	Accept(ctx context.Context, id uint, buyerID uint, expense *Expense) (*SharedSale, error)
+	Decline(ctx context.Context, id uint, buyerID uint) (*SharedSale, error)
+	GetSharedSale(ctx context.Context, id uint, userID uint) (*SharedSale, error)
+	GetDisputes(ctx context.Context, sharedSaleID uint) ([]*TradeDispute, error)
+	OpenDispute(ctx context.Context, dispute *TradeDispute, event *TradeDisputeEvent) error
+	AddDisputeEvent(ctx context.Context, sharedSaleID uint, event *TradeDisputeEvent) (*TradeDispute, error)
+	ResolveDispute(ctx context.Context, sharedSaleID uint, event *TradeDisputeEvent) (*TradeDispute, error)
+}
+
+// WebhookInterface defines the methods for webhook endpoints
+type WebhookInterface interface {
+	GetAll(ctx context.Context, userID uint) ([]*Webhook, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*Webhook, error)
+	GetSubscribed(ctx context.Context, userID uint, event string) ([]*Webhook, error)
+	Insert(ctx context.Context, webhook *Webhook) (uint, error)
+	Delete(ctx context.Context, id uint, userID uint) error
+}
+
+// BackupInterface defines the methods for database backup records
+type BackupInterface interface {
+	GetRecent(ctx context.Context, limit int) ([]*Backup, error)
+	GetLastCompleted(ctx context.Context) (*Backup, error)
+	Insert(ctx context.Context, backup *Backup) (uint, error)
+	Update(ctx context.Context, backup *Backup) error
+}
+
+// UsageInterface defines the methods for usage metering and plan subscriptions
+type UsageInterface interface {
+	GetCounts(ctx context.Context, userID uint, since time.Time) (*UsageCounts, error)
+	GetAllCounts(ctx context.Context, since time.Time) ([]*UsageCounts, error)
+	GetSubscription(ctx context.Context, userID uint) (*Subscription, error)
+	GetSubscriptions(ctx context.Context) ([]*Subscription, error)
+	GetTrials(ctx context.Context) ([]*Subscription, error)
+	SaveSubscription(ctx context.Context, subscription *Subscription) error
+}
+
+// FeatureInterface defines the methods for feature flags
+type FeatureInterface interface {
+	GetFlags(ctx context.Context, userID uint) ([]*FeatureFlag, error)
+	SaveFlag(ctx context.Context, flag *FeatureFlag) error
+	DeleteFlag(ctx context.Context, userID uint, feature Feature) error
+}
+
+// ReferralInterface defines the methods for referral codes and attribution
+type ReferralInterface interface {
+	GetOrCreateCode(ctx context.Context, userID uint) (*ReferralCode, error)
+	GetByCode(ctx context.Context, code string) (*ReferralCode, error)
+	Attribute(ctx context.Context, referral *Referral) error
+	GetReferredUsers(ctx context.Context, referrerID uint) ([]*ReferredUser, error)
+	GetReferrers(ctx context.Context, from, to *time.Time) ([]*ReferrerStats, error)
+}
+
+// SupportInterface defines the methods for support tickets
+type SupportInterface interface {
+	GetAll(ctx context.Context, userID uint) ([]*SupportTicket, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*SupportTicket, error)
+	GetRecent(ctx context.Context, status string, limit int) ([]*SupportTicket, error)
+	Insert(ctx context.Context, ticket *SupportTicket) (uint, error)
+	SetDelivery(ctx context.Context, id uint, deliveryID uint) error
+	Resolve(ctx context.Context, id uint) (*SupportTicket, error)
+}
+
+// LeaseInterface defines the methods for leases shared by workers
+type LeaseInterface interface {
+	Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, name, holder string) error
+}
+
+// RateLimitInterface defines the methods for rate limit counters shared by API replicas
+type RateLimitInterface interface {
+	Hit(ctx context.Context, name string, windowStart int64) (int64, error)
+	DeleteBefore(ctx context.Context, windowStart int64) (int64, error)
+}
+
+// IdempotencyInterface defines the methods for idempotency keys shared by API replicas
+type IdempotencyInterface interface {
+	Begin(ctx context.Context, actorID uint, key, fingerprint string) (*IdempotentRequest, error)
+	Complete(ctx context.Context, actorID uint, key string, statusCode int, contentType string, body []byte) error
+	Release(ctx context.Context, actorID uint, key string) error
+	DeleteBefore(ctx context.Context, t time.Time) (int64, error)
+}
+
+// ArchiveInterface defines the methods for moving old transactions to the archive tables
+type ArchiveInterface interface {
+	ArchiveIncomes(ctx context.Context, before time.Time, limit int) (int64, error)
+	ArchiveExpenses(ctx context.Context, before time.Time, limit int) (int64, error)
+	GetPeriods(ctx context.Context, userID uint) ([]*ArchivedPeriod, error)
+}
+
+// WarehouseInterface defines the methods for incremental exports to the analytics warehouse
+type WarehouseInterface interface {
+	GetTenants(ctx context.Context) ([]uint, error)
+	GetWatermark(ctx context.Context, tenantID uint, dataset string, schemaVersion int) (*WarehouseWatermark, error)
+	SaveWatermark(ctx context.Context, watermark *WarehouseWatermark) error
+	GetTransactions(ctx context.Context, tenantID uint, after *WarehouseWatermark, until time.Time, limit int) ([]*WarehouseTransaction, error)
+}
+
+// StreamInterface defines the methods for the event streams of inventory and payment changes
+type StreamInterface interface {
+	GetEvents(ctx context.Context, userID uint, stream StreamType, streamID uint, after uint, limit int) ([]*StreamEvent, error)
+	Rebuild(ctx context.Context, userID uint, stream StreamType, streamID uint) (*RebuiltBalance, error)
+	Baseline(ctx context.Context) (int64, error)
+}
+
+// OutboxInterface defines the methods for publishing the events stored in the outbox
+type OutboxInterface interface {
+	ClaimNext(ctx context.Context) (*OutboxMessage, error)
+	MarkPublished(ctx context.Context, id uint) error
+	Fail(ctx context.Context, id uint, message string, retryAt time.Time) error
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
 }
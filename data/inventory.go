@@ -1,11 +1,18 @@
 package data
 
 import (
+	"context"
+	"errors"
+	"math"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrInsufficientStock is returned when more stock is consumed than is on hand
+var ErrInsufficientStock = errors.New("insufficient stock")
+
 // InventoryRepository implements InventoryInterface using GORM
 type InventoryRepository struct {
 	db *gorm.DB
@@ -17,60 +24,300 @@ func NewInventoryRepository(db *gorm.DB) InventoryInterface {
 }
 
 // GetAll retrieves all inventory items for a user
-func (r *InventoryRepository) GetAll(userID uint) ([]*InventoryItem, error) {
+func (r *InventoryRepository) GetAll(ctx context.Context, userID uint) ([]*InventoryItem, error) {
 	var items []*InventoryItem
-	result := r.db.Where("user_id = ?", userID).Order("name ASC").Find(&items)
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("name ASC").Find(&items)
 	return items, result.Error
 }
 
+// GetPage retrieves a page of a user's inventory items, by name, with how many there are. Only the
+// records of the site siteID are included when it is set.
+func (r *InventoryRepository) GetPage(ctx context.Context, userID uint, siteID *uint, offset, limit int) ([]*InventoryItem, int64, error) {
+	var items []*InventoryItem
+	query := scopeToSite(r.db.WithContext(ctx).Model(&InventoryItem{}).Where("user_id = ?", userID), siteID)
+	total, err := findPage(query, "name ASC, id ASC", offset, limit, &items)
+	return items, total, err
+}
+
 // GetOne retrieves a specific inventory item by ID for a user
-func (r *InventoryRepository) GetOne(id uint, userID uint) (*InventoryItem, error) {
+func (r *InventoryRepository) GetOne(ctx context.Context, id uint, userID uint) (*InventoryItem, error) {
 	var item InventoryItem
-	result := r.db.Where("id = ? AND user_id = ?", id, userID).First(&item)
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&item)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return &item, nil
 }
 
-// Insert creates a new inventory item
-func (r *InventoryRepository) Insert(item *InventoryItem) (uint, error) {
+// Insert creates a new inventory item and opens its event stream
+func (r *InventoryRepository) Insert(ctx context.Context, item *InventoryItem) (uint, error) {
 	item.LastUpdated = time.Now()
-	result := r.db.Create(item)
-	return item.ID, result.Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(item).Error; err != nil {
+			return err
+		}
+		return recordStock(tx, EventInventoryCreated, item, item.Quantity, nil)
+	})
+	return item.ID, err
 }
 
-// Update updates an existing inventory item
-func (r *InventoryRepository) Update(item *InventoryItem) error {
+// Update updates an existing inventory item, recording an event when its stock or value changes
+func (r *InventoryRepository) Update(ctx context.Context, item *InventoryItem) error {
 	item.LastUpdated = time.Now()
-	result := r.db.Save(item)
-	return result.Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before InventoryItem
+		if err := tx.Where("id = ?", item.ID).First(&before).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(item).Error; err != nil {
+			return err
+		}
+		if !stockChanged(&before, item) {
+			return nil
+		}
+		return recordStock(tx, EventInventoryUpdated, item, item.Quantity-before.Quantity, nil)
+	})
+}
+
+// Delete soft deletes an inventory item and closes its event stream
+func (r *InventoryRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var item InventoryItem
+		err := tx.Where("id = ? AND user_id = ?", id, userID).First(&item).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&item).Error; err != nil {
+			return err
+		}
+		return recordStock(tx, EventInventoryDeleted, &item, 0, nil)
+	})
+}
+
+// GetDeleted retrieves the soft deleted inventory items of a user, most recently deleted first
+func (r *InventoryRepository) GetDeleted(ctx context.Context, userID uint) ([]*InventoryItem, error) {
+	var items []*InventoryItem
+	result := r.db.WithContext(ctx).Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Order("deleted_at DESC").Find(&items)
+	return items, result.Error
+}
+
+// Restore brings back a soft deleted inventory item and reopens its event stream. It returns
+// gorm.ErrRecordNotFound when the user has no such deleted item.
+func (r *InventoryRepository) Restore(ctx context.Context, id uint, userID uint) (*InventoryItem, error) {
+	var item InventoryItem
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL", id, userID).First(&item).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&item).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		item.DeletedAt = gorm.DeletedAt{}
+		return recordStock(tx, EventInventoryRestored, &item, 0, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
 }
 
-// Delete soft deletes an inventory item
-func (r *InventoryRepository) Delete(id uint, userID uint) error {
-	result := r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&InventoryItem{})
-	return result.Error
+// Purge permanently deletes a soft deleted inventory item with its stock movements. Its event
+// stream is kept. It returns gorm.ErrRecordNotFound when the user has no such deleted item.
+func (r *InventoryRepository) Purge(ctx context.Context, id uint, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL", id, userID).Delete(&InventoryItem{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Unscoped().Where("inventory_item_id = ?", id).Delete(&StockMovement{}).Error
+	})
 }
 
 // GetLowStockItems retrieves items that are below minimum stock level
-func (r *InventoryRepository) GetLowStockItems(userID uint) ([]*InventoryItem, error) {
+func (r *InventoryRepository) GetLowStockItems(ctx context.Context, userID uint) ([]*InventoryItem, error) {
 	var items []*InventoryItem
-	result := r.db.Where("user_id = ? AND quantity <= min_stock_level", userID).
+	result := r.db.WithContext(ctx).Where("user_id = ? AND quantity <= min_stock_level", userID).
 		Order("quantity ASC").Find(&items)
 	return items, result.Error
 }
 
 // UpdateQuantity updates the quantity of an inventory item
-func (r *InventoryRepository) UpdateQuantity(id uint, userID uint, quantity float64) error {
-	result := r.db.Model(&InventoryItem{}).
-		Where("id = ? AND user_id = ?", id, userID).
-		Updates(map[string]interface{}{
-			"quantity":     quantity,
-			"last_updated": time.Now(),
-		})
-	return result.Error
+func (r *InventoryRepository) UpdateQuantity(ctx context.Context, id uint, userID uint, quantity float64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var item InventoryItem
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND user_id = ?", id, userID).First(&item).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		change := quantity - item.Quantity
+		item.Quantity = quantity
+		item.LastUpdated = time.Now()
+		err = tx.Model(&InventoryItem{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+			"quantity":     item.Quantity,
+			"last_updated": item.LastUpdated,
+		}).Error
+		if err != nil || change == 0 {
+			return err
+		}
+		return recordStock(tx, EventInventoryUpdated, &item, change, nil)
+	})
 }
 
+// GetMovements retrieves the stock movement history of an inventory item
+func (r *InventoryRepository) GetMovements(ctx context.Context, id uint, userID uint) ([]*StockMovement, error) {
+	var movements []*StockMovement
+	result := r.db.WithContext(ctx).Where("inventory_item_id = ? AND user_id = ?", id, userID).
+		Order("created_at DESC").Find(&movements)
+	return movements, result.Error
+}
 
+// GetExpiringItems retrieves supply items of a user that expire on or before the given time
+func (r *InventoryRepository) GetExpiringItems(ctx context.Context, userID uint, before time.Time) ([]*InventoryItem, error) {
+	var items []*InventoryItem
+	result := r.db.WithContext(ctx).Where("user_id = ? AND type = ? AND expiry_date IS NOT NULL AND expiry_date <= ?", userID, "supply", before).
+		Order("expiry_date ASC").Find(&items)
+	return items, result.Error
+}
 
+// GetAllExpiringItems retrieves supply items of all users that expire on or before the given time
+func (r *InventoryRepository) GetAllExpiringItems(ctx context.Context, before time.Time) ([]*InventoryItem, error) {
+	var items []*InventoryItem
+	result := r.db.WithContext(ctx).Where("type = ? AND expiry_date IS NOT NULL AND expiry_date <= ?", "supply", before).
+		Order("expiry_date ASC").Find(&items)
+	return items, result.Error
+}
+
+// GetHazardousItems retrieves the hazardous material register of a user
+func (r *InventoryRepository) GetHazardousItems(ctx context.Context, userID uint) ([]*InventoryItem, error) {
+	var items []*InventoryItem
+	result := r.db.WithContext(ctx).Where("user_id = ? AND is_hazardous = ?", userID, true).Order("name ASC").Find(&items)
+	return items, result.Error
+}
+
+// RecordUsage deducts consumed stock from an item and records it as a usage movement
+func (r *InventoryRepository) RecordUsage(ctx context.Context, id uint, userID uint, quantity float64, reason *string) (*StockMovement, error) {
+	var movement *StockMovement
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var item InventoryItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND user_id = ?", id, userID).First(&item).Error; err != nil {
+			return err
+		}
+		if quantity > item.Quantity {
+			return ErrInsufficientStock
+		}
+
+		movement = &StockMovement{
+			InventoryItemID: item.ID,
+			Type:            StockMovementUsage,
+			Quantity:        -quantity,
+			QuantityBefore:  item.Quantity,
+			QuantityAfter:   item.Quantity - quantity,
+			Reason:          reason,
+			UserID:          userID,
+		}
+		if err := tx.Create(movement).Error; err != nil {
+			return err
+		}
+
+		item.Quantity = movement.QuantityAfter
+		item.LastUpdated = time.Now()
+		err := tx.Model(&InventoryItem{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+			"quantity":     item.Quantity,
+			"last_updated": item.LastUpdated,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return recordStock(tx, EventInventoryUsed, &item, movement.Quantity, &movement.ID)
+	})
+	return movement, err
+}
+
+// WriteOff removes damaged, expired, lost or stolen stock from an item, reducing its value in
+// proportion, and records it as a write-off movement with the value lost
+func (r *InventoryRepository) WriteOff(ctx context.Context, id uint, userID uint, quantity float64, reason string) (*StockMovement, error) {
+	var movement *StockMovement
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var item InventoryItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND user_id = ?", id, userID).First(&item).Error; err != nil {
+			return err
+		}
+		if quantity > item.Quantity {
+			return ErrInsufficientStock
+		}
+
+		value := math.Round(item.CurrentValue*quantity/item.Quantity*100) / 100
+		movement = &StockMovement{
+			InventoryItemID: item.ID,
+			Type:            StockMovementWriteOff,
+			Quantity:        -quantity,
+			QuantityBefore:  item.Quantity,
+			QuantityAfter:   item.Quantity - quantity,
+			Reason:          &reason,
+			Value:           &value,
+			UserID:          userID,
+		}
+		if err := tx.Create(movement).Error; err != nil {
+			return err
+		}
+
+		item.Quantity = movement.QuantityAfter
+		item.CurrentValue = math.Max(item.CurrentValue-value, 0)
+		item.LastUpdated = time.Now()
+		err := tx.Model(&InventoryItem{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+			"quantity":      item.Quantity,
+			"current_value": item.CurrentValue,
+			"last_updated":  item.LastUpdated,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return recordStock(tx, EventInventoryWrittenOff, &item, movement.Quantity, &movement.ID)
+	})
+	return movement, err
+}
+
+// GetUsageSince returns the total quantity of an item used since the given time
+func (r *InventoryRepository) GetUsageSince(ctx context.Context, id uint, userID uint, since time.Time) (float64, error) {
+	var used float64
+	result := r.db.WithContext(ctx).Model(&StockMovement{}).
+		Where("inventory_item_id = ? AND user_id = ? AND type = ? AND created_at >= ?", id, userID, StockMovementUsage, since).
+		Select("COALESCE(SUM(-quantity), 0)").Scan(&used)
+	return used, result.Error
+}
+
+// GetMonthlyUsage returns the quantity of each of a user's items used per month since the given
+// time, by item and month
+func (r *InventoryRepository) GetMonthlyUsage(ctx context.Context, userID uint, since time.Time) ([]*MonthlyUsage, error) {
+	var usage []*MonthlyUsage
+	month := monthExpr(r.db.WithContext(ctx), "created_at")
+	result := r.db.WithContext(ctx).Model(&StockMovement{}).
+		Select("inventory_item_id, "+month+" AS month, COALESCE(SUM(-quantity), 0) AS quantity").
+		Where("user_id = ? AND type = ? AND created_at >= ?", userID, StockMovementUsage, since).
+		Group("inventory_item_id, " + month).
+		Order("inventory_item_id, month").
+		Scan(&usage)
+	return usage, result.Error
+}
+
+// SetPrimaryImage sets the photo shown for an item when browsing stock; nil clears it
+func (r *InventoryRepository) SetPrimaryImage(ctx context.Context, id uint, userID uint, imageID *uint) error {
+	result := r.db.WithContext(ctx).Model(&InventoryItem{}).Where("id = ? AND user_id = ?", id, userID).Update("primary_image_id", imageID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package data
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrInviteCodeInvalid is returned when an invite code is unknown, revoked, expired or used up
+var ErrInviteCodeInvalid = errors.New("invalid or expired invite code")
+
+// InviteCodeRepository implements InviteCodeInterface using GORM
+type InviteCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewInviteCodeRepository creates a new instance of InviteCodeRepository
+func NewInviteCodeRepository(db *gorm.DB) InviteCodeInterface {
+	return &InviteCodeRepository{db: db}
+}
+
+// GetAll retrieves all invite codes
+func (r *InviteCodeRepository) GetAll(ctx context.Context) ([]*InviteCode, error) {
+	var codes []*InviteCode
+	result := r.db.WithContext(ctx).Order("created_at DESC").Find(&codes)
+	return codes, result.Error
+}
+
+// Insert creates a new invite code, generating the code when empty
+func (r *InviteCodeRepository) Insert(ctx context.Context, code *InviteCode) (uint, error) {
+	if code.Code == "" {
+		generated, err := generateInviteCode()
+		if err != nil {
+			return 0, err
+		}
+		code.Code = generated
+	}
+	result := r.db.WithContext(ctx).Create(code)
+	return code.ID, result.Error
+}
+
+// EnsureCode creates an unlimited invite code for a role if it does not exist yet
+func (r *InviteCodeRepository) EnsureCode(ctx context.Context, code string, role UserRole) error {
+	invite := InviteCode{Code: code, Role: role}
+	result := r.db.WithContext(ctx).Where(InviteCode{Code: code}).FirstOrCreate(&invite)
+	return result.Error
+}
+
+// Revoke revokes an invite code so it can no longer be used
+func (r *InviteCodeRepository) Revoke(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Model(&InviteCode{}).Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Redeem uses an invite code once, returning it when valid. The usage check and increment
+// happen in a single statement so concurrent signups cannot exceed the usage limit.
+func (r *InviteCodeRepository) Redeem(ctx context.Context, code string) (*InviteCode, error) {
+	result := r.db.WithContext(ctx).Model(&InviteCode{}).
+		Where("code = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?) AND (max_uses = 0 OR uses < max_uses)",
+			code, time.Now()).
+		Update("uses", gorm.Expr("uses + 1"))
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrInviteCodeInvalid
+	}
+
+	var invite InviteCode
+	if err := r.db.WithContext(ctx).Where("code = ?", code).First(&invite).Error; err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// Release gives back a use of an invite code, e.g. when the signup that redeemed it failed
+func (r *InviteCodeRepository) Release(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Model(&InviteCode{}).Where("id = ? AND uses > 0", id).Update("uses", gorm.Expr("uses - 1"))
+	return result.Error
+}
+
+// generateInviteCode generates a random 10 character code
+func generateInviteCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b)[:10], nil
+}
@@ -0,0 +1,150 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// JobTypeSendOTP delivers an OTP; password reset codes go by email, falling back to SMS
+const JobTypeSendOTP = "send_otp"
+
+// OTP purposes, recorded with the code so it is only accepted by the flow it was sent for, and
+// on the message delivery
+const (
+	OTPPurposePasswordReset = "password_reset"
+	OTPPurposePhoneLogin    = "phone_login"  // sent by SMS only
+	OTPPurposePhoneLink     = "phone_verify" // sent by SMS only
+	OTPPurposeEmailVerify   = "email_verify" // sent by email only
+)
+
+// SendOTPPayload is the payload of a JobTypeSendOTP job
+type SendOTPPayload struct {
+	DeliveryID uint    `json:"delivery_id"`
+	Purpose    string  `json:"purpose,omitempty"` // defaults to OTPPurposePasswordReset
+	Email      string  `json:"email"`
+	Phone      *string `json:"phone,omitempty"`
+	OTP        string  `json:"otp"`
+}
+
+// JobTypeSendMessage delivers a message to a customer or supplier over a single channel
+const JobTypeSendMessage = "send_message"
+
+// SendMessagePayload is the payload of a JobTypeSendMessage job
+type SendMessagePayload struct {
+	DeliveryID uint            `json:"delivery_id"`
+	Channel    DeliveryChannel `json:"channel"`
+	To         string          `json:"to"` // email address or phone number
+	Subject    string          `json:"subject,omitempty"`
+	Body       string          `json:"body"`
+}
+
+// JobTypeDeliverWebhook posts an event to a webhook endpoint
+const JobTypeDeliverWebhook = "deliver_webhook"
+
+// DeliverWebhookPayload is the payload of a JobTypeDeliverWebhook job
+type DeliverWebhookPayload struct {
+	WebhookID uint   `json:"webhook_id"`
+	UserID    uint   `json:"user_id"`
+	Event     string `json:"event"`
+	Body      string `json:"body"` // JSON event, signed as sent
+}
+
+// staleJobTimeout is how long a running job may go without finishing before it is retried,
+// e.g. after the server stopped mid-run
+const staleJobTimeout = 10 * time.Minute
+
+// JobRepository implements JobInterface using GORM
+type JobRepository struct {
+	db *gorm.DB
+}
+
+// NewJobRepository creates a new instance of JobRepository
+func NewJobRepository(db *gorm.DB) JobInterface {
+	return &JobRepository{db: db}
+}
+
+// Enqueue adds a job of the given type with a JSON encoded payload, due immediately
+func (r *JobRepository) Enqueue(ctx context.Context, jobType string, payload interface{}) (uint, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return 0, err
+	}
+
+	job := &Job{
+		Type:        jobType,
+		Payload:     string(encoded),
+		Status:      JobPending,
+		MaxAttempts: 5,
+		RunAt:       time.Now(),
+	}
+	result := r.db.WithContext(ctx).Create(job)
+	return job.ID, result.Error
+}
+
+// ClaimNext marks the next due job as running and returns it, or nil when none is due.
+// Rows are locked with SKIP LOCKED so several workers never claim the same job; databases
+// without row locks, such as SQLite, rely on the claim only updating the job as it was read.
+func (r *JobRepository) ClaimNext(ctx context.Context) (*Job, error) {
+	var job Job
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("(status = ? AND run_at <= ?) OR (status = ? AND updated_at < ?)",
+				JobPending, now, JobRunning, now.Add(-staleJobTimeout)).
+			Order("run_at ASC").First(&job).Error
+		if err != nil {
+			return err
+		}
+
+		claimed := tx.Model(&job).Where("status = ? AND attempts = ?", job.Status, job.Attempts).
+			Updates(map[string]interface{}{
+				"status":   JobRunning,
+				"attempts": job.Attempts + 1,
+			})
+		if claimed.Error != nil {
+			return claimed.Error
+		}
+		if claimed.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound // claimed by another worker
+		}
+		job.Status = JobRunning
+		job.Attempts++
+		return nil
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Complete marks a job as done
+func (r *JobRepository) Complete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Model(&Job{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       JobDone,
+		"completed_at": time.Now(),
+		"last_error":   nil,
+	})
+	return result.Error
+}
+
+// Fail records a failed run, rescheduling the job at retryAt or marking it failed when nil
+func (r *JobRepository) Fail(ctx context.Context, id uint, message string, retryAt *time.Time) error {
+	updates := map[string]interface{}{
+		"status":     JobFailed,
+		"last_error": message,
+	}
+	if retryAt != nil {
+		updates["status"] = JobPending
+		updates["run_at"] = *retryAt
+	}
+	result := r.db.WithContext(ctx).Model(&Job{}).Where("id = ?", id).Updates(updates)
+	return result.Error
+}
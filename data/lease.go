@@ -0,0 +1,51 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LeaseRepository implements LeaseInterface using GORM
+type LeaseRepository struct {
+	db *gorm.DB
+}
+
+// NewLeaseRepository creates a new instance of LeaseRepository
+func NewLeaseRepository(db *gorm.DB) LeaseInterface {
+	return &LeaseRepository{db: db}
+}
+
+// Acquire takes a lease for ttl, or extends it when the holder already has it, and reports
+// whether the holder has the lease. A lease held by another worker is only taken once it has
+// expired. Each step is a single statement, so two workers never both acquire a lease.
+func (r *LeaseRepository) Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	lease := &Lease{Name: name, Holder: holder, ExpiresAt: now.Add(ttl)}
+	created := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(lease)
+	if created.Error != nil {
+		return false, created.Error
+	}
+	if created.RowsAffected == 1 {
+		return true, nil
+	}
+
+	result := r.db.WithContext(ctx).Model(&Lease{}).Where("name = ? AND (holder = ? OR expires_at < ?)", name, holder, now).
+		Updates(map[string]interface{}{
+			"holder":     holder,
+			"expires_at": lease.ExpiresAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Release expires a lease held by the holder so another worker can take it over at once
+func (r *LeaseRepository) Release(ctx context.Context, name, holder string) error {
+	result := r.db.WithContext(ctx).Model(&Lease{}).Where("name = ? AND holder = ?", name, holder).
+		Update("expires_at", time.Now())
+	return result.Error
+}
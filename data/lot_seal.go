@@ -0,0 +1,56 @@
+package data
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrLotSealExists is returned when sealing a sale that is already sealed
+var ErrLotSealExists = errors.New("sale is already sealed")
+
+// LotSealRepository implements LotSealInterface using GORM
+type LotSealRepository struct {
+	db *gorm.DB
+}
+
+// NewLotSealRepository creates a new instance of LotSealRepository
+func NewLotSealRepository(db *gorm.DB) LotSealInterface {
+	return &LotSealRepository{db: db}
+}
+
+// Insert stores the seal of a sale. It returns ErrLotSealExists when the sale is already sealed,
+// as a seal is never replaced.
+func (r *LotSealRepository) Insert(ctx context.Context, seal *LotSeal) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&LotSeal{}).Where("income_id = ?", seal.IncomeID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrLotSealExists
+		}
+		return tx.Create(seal).Error
+	})
+}
+
+// GetByID retrieves a seal by ID, for public verification
+func (r *LotSealRepository) GetByID(ctx context.Context, id uint) (*LotSeal, error) {
+	var seal LotSeal
+	result := r.db.WithContext(ctx).Where("id = ?", id).First(&seal)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &seal, nil
+}
+
+// GetByIncome retrieves the seal of a sale
+func (r *LotSealRepository) GetByIncome(ctx context.Context, incomeID uint, userID uint) (*LotSeal, error) {
+	var seal LotSeal
+	result := r.db.WithContext(ctx).Where("income_id = ? AND user_id = ?", incomeID, userID).First(&seal)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &seal, nil
+}
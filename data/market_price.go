@@ -0,0 +1,71 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MarketPriceRepository implements MarketPriceInterface using GORM
+type MarketPriceRepository struct {
+	db *gorm.DB
+}
+
+// NewMarketPriceRepository creates a new instance of MarketPriceRepository
+func NewMarketPriceRepository(db *gorm.DB) MarketPriceInterface {
+	return &MarketPriceRepository{db: db}
+}
+
+// GetAll retrieves the market prices of a user, newest first. Only the prices of mineralType are
+// included when it is set.
+func (r *MarketPriceRepository) GetAll(ctx context.Context, userID uint, mineralType MineralType) ([]*MarketPrice, error) {
+	var prices []*MarketPrice
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if mineralType != "" {
+		query = query.Where("mineral_type = ?", mineralType)
+	}
+	result := query.Order("effective_date DESC, id DESC").Find(&prices)
+	return prices, result.Error
+}
+
+// GetOne retrieves a market price of a user
+func (r *MarketPriceRepository) GetOne(ctx context.Context, id uint, userID uint) (*MarketPrice, error) {
+	var price MarketPrice
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&price)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &price, nil
+}
+
+// GetCurrent retrieves the market price of a mineral in a unit on a date: the latest price
+// effective by then, preferring a price of the buying station siteID when it is set. It returns
+// gorm.ErrRecordNotFound when there is none.
+func (r *MarketPriceRepository) GetCurrent(ctx context.Context, userID uint, mineralType MineralType, unit string, siteID *uint, date time.Time) (*MarketPrice, error) {
+	var price MarketPrice
+	query := r.db.WithContext(ctx).Where("user_id = ? AND mineral_type = ? AND unit = ? AND effective_date <= ?", userID, mineralType, unit, date)
+	order := "effective_date DESC, id DESC"
+	if siteID != nil {
+		query = query.Where("(mine_site_id = ? OR mine_site_id IS NULL)", *siteID)
+		order = "mine_site_id IS NULL, " + order
+	} else {
+		query = query.Where("mine_site_id IS NULL")
+	}
+	result := query.Order(order).First(&price)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &price, nil
+}
+
+// Insert records a market price
+func (r *MarketPriceRepository) Insert(ctx context.Context, price *MarketPrice) (uint, error) {
+	result := r.db.WithContext(ctx).Create(price)
+	return price.ID, result.Error
+}
+
+// Delete soft deletes a market price of a user
+func (r *MarketPriceRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	return r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&MarketPrice{}).Error
+}
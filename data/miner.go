@@ -0,0 +1,153 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ErrMinerHasPurchases is returned when deleting a registered miner that purchases were made from
+var ErrMinerHasPurchases = errors.New("purchases have been made from the miner")
+
+// MinerRepository implements MinerInterface using GORM
+type MinerRepository struct {
+	db *gorm.DB
+}
+
+// NewMinerRepository creates a new instance of MinerRepository
+func NewMinerRepository(db *gorm.DB) MinerInterface {
+	return &MinerRepository{db: db}
+}
+
+// GetAll retrieves the registered miners of a user by name, with their KYC completeness. Only
+// the miners of the site siteID are included when it is set.
+func (r *MinerRepository) GetAll(ctx context.Context, userID uint, siteID *uint) ([]*Miner, error) {
+	var miners []*Miner
+	query := scopeToSite(r.db.WithContext(ctx).Where("user_id = ?", userID), siteID)
+	if err := query.Order("name, id").Find(&miners).Error; err != nil {
+		return nil, err
+	}
+	return miners, r.checkKYC(ctx, userID, miners)
+}
+
+// GetOne retrieves a registered miner of a user with their KYC completeness
+func (r *MinerRepository) GetOne(ctx context.Context, id uint, userID uint) (*Miner, error) {
+	var miner Miner
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&miner)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &miner, r.checkKYC(ctx, userID, []*Miner{&miner})
+}
+
+// Insert registers a miner
+func (r *MinerRepository) Insert(ctx context.Context, miner *Miner) (uint, error) {
+	if err := r.db.WithContext(ctx).Create(miner).Error; err != nil {
+		return 0, err
+	}
+	return miner.ID, r.checkKYC(ctx, miner.UserID, []*Miner{miner})
+}
+
+// Update updates a registered miner, renaming the purchases made from them so that their
+// payables and statement stay together
+func (r *MinerRepository) Update(ctx context.Context, miner *Miner) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(miner).Error; err != nil {
+			return err
+		}
+		return tx.Model(&Purchase{}).Where("user_id = ? AND miner_id = ?", miner.UserID, miner.ID).
+			Update("miner_name", miner.Name).Error
+	})
+	if err != nil {
+		return err
+	}
+	return r.checkKYC(ctx, miner.UserID, []*Miner{miner})
+}
+
+// Delete soft deletes a registered miner. It returns ErrMinerHasPurchases when purchases were
+// made from the miner, whose records are kept for compliance audits.
+func (r *MinerRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var purchases int64
+		err := tx.Model(&Purchase{}).Where("user_id = ? AND miner_id = ?", userID, id).Count(&purchases).Error
+		if err != nil {
+			return err
+		}
+		if purchases > 0 {
+			return ErrMinerHasPurchases
+		}
+		return tx.Where("id = ? AND user_id = ?", id, userID).Delete(&Miner{}).Error
+	})
+}
+
+// checkKYC fills in which KYC requirements each miner is missing, looking up the ID document
+// scans and photos attached to them
+func (r *MinerRepository) checkKYC(ctx context.Context, userID uint, miners []*Miner) error {
+	if len(miners) == 0 {
+		return nil
+	}
+	ids := make([]uint, len(miners))
+	for i, miner := range miners {
+		ids[i] = miner.ID
+	}
+	var attachments []*Attachment
+	err := r.db.WithContext(ctx).Select("record_id, kind").
+		Where("user_id = ? AND record_type = ? AND record_id IN ? AND kind IN ?", userID, AttachmentRecordMiner, ids,
+			[]AttachmentKind{AttachmentIDDocument, AttachmentPhoto}).
+		Find(&attachments).Error
+	if err != nil {
+		return err
+	}
+	documents := make(map[uint]map[AttachmentKind]bool)
+	for _, attachment := range attachments {
+		if documents[attachment.RecordID] == nil {
+			documents[attachment.RecordID] = make(map[AttachmentKind]bool)
+		}
+		documents[attachment.RecordID][attachment.Kind] = true
+	}
+
+	for _, miner := range miners {
+		miner.KYCMissing = missingKYC(miner, documents[miner.ID])
+		miner.KYCComplete = len(miner.KYCMissing) == 0
+	}
+	return nil
+}
+
+// missingKYC returns the KYC requirements a miner doesn't meet, given the kinds of documents
+// attached to them
+func missingKYC(miner *Miner, documents map[AttachmentKind]bool) []string {
+	missing := make([]string, 0)
+	if isBlank(miner.IDDocumentType) || isBlank(miner.IDNumber) {
+		missing = append(missing, KYCIDNumber)
+	}
+	if !documents[AttachmentIDDocument] {
+		missing = append(missing, KYCIDDocument)
+	}
+	if !documents[AttachmentPhoto] {
+		missing = append(missing, KYCPhoto)
+	}
+	if isBlank(miner.Phone) {
+		missing = append(missing, KYCPhone)
+	}
+	if miner.MineSiteID == nil {
+		missing = append(missing, KYCMineSite)
+	}
+	switch miner.PaymentMethod {
+	case MinerPaidMobileMoney:
+		if isBlank(miner.MobileMoneyNumber) {
+			missing = append(missing, KYCPaymentDetails)
+		}
+	case MinerPaidBank:
+		if isBlank(miner.BankName) || isBlank(miner.BankAccountNumber) {
+			missing = append(missing, KYCPaymentDetails)
+		}
+	}
+	return missing
+}
+
+// isBlank reports whether an optional string is unset or empty
+func isBlank(value *string) bool {
+	return value == nil || strings.TrimSpace(*value) == ""
+}
@@ -1,14 +1,20 @@
 package data
 
+//go:generate go tool mockgen -typed -source=minesite.go -destination=mocks/minesite.go -package=mocks
+
 import (
+	"context"
 	"gorm.io/gorm"
 )
 
 // MineSiteInterface defines the methods for mine site information
 type MineSiteInterface interface {
-	GetByUserID(userID uint) (*MineSiteInfo, error)
-	Insert(info *MineSiteInfo) (uint, error)
-	Update(info *MineSiteInfo) error
+	GetByUserID(ctx context.Context, userID uint) (*MineSiteInfo, error)
+	GetAll(ctx context.Context, userID uint) ([]*MineSiteInfo, error)
+	GetOne(ctx context.Context, id uint, userID uint) (*MineSiteInfo, error)
+	Insert(ctx context.Context, info *MineSiteInfo) (uint, error)
+	Update(ctx context.Context, info *MineSiteInfo) error
+	Delete(ctx context.Context, id uint, userID uint) error
 }
 
 // MineSiteRepository implements MineSiteInterface using GORM
@@ -21,10 +27,11 @@ func NewMineSiteRepository(db *gorm.DB) MineSiteInterface {
 	return &MineSiteRepository{db: db}
 }
 
-// GetByUserID retrieves mine site information for a user
-func (r *MineSiteRepository) GetByUserID(userID uint) (*MineSiteInfo, error) {
+// GetByUserID retrieves the first mine site of a user, the one used for check-in, the license
+// calendar and price benchmarks
+func (r *MineSiteRepository) GetByUserID(ctx context.Context, userID uint) (*MineSiteInfo, error) {
 	var info MineSiteInfo
-	result := r.db.Where("user_id = ?", userID).First(&info)
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id ASC").First(&info)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, nil // Return nil if not found (not an error)
@@ -34,14 +41,43 @@ func (r *MineSiteRepository) GetByUserID(userID uint) (*MineSiteInfo, error) {
 	return &info, nil
 }
 
+// GetAll retrieves all mine sites of a user, oldest first
+func (r *MineSiteRepository) GetAll(ctx context.Context, userID uint) ([]*MineSiteInfo, error) {
+	var sites []*MineSiteInfo
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id ASC").Find(&sites)
+	return sites, result.Error
+}
+
+// GetOne retrieves a specific mine site of a user
+func (r *MineSiteRepository) GetOne(ctx context.Context, id uint, userID uint) (*MineSiteInfo, error) {
+	var info MineSiteInfo
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&info)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &info, nil
+}
+
 // Insert creates a new mine site information record
-func (r *MineSiteRepository) Insert(info *MineSiteInfo) (uint, error) {
-	result := r.db.Create(info)
+func (r *MineSiteRepository) Insert(ctx context.Context, info *MineSiteInfo) (uint, error) {
+	result := r.db.WithContext(ctx).Create(info)
 	return info.ID, result.Error
 }
 
 // Update updates an existing mine site information record
-func (r *MineSiteRepository) Update(info *MineSiteInfo) error {
-	result := r.db.Save(info)
+func (r *MineSiteRepository) Update(ctx context.Context, info *MineSiteInfo) error {
+	result := r.db.WithContext(ctx).Save(info)
 	return result.Error
 }
+
+// Delete deletes a mine site of a user. Records assigned to it keep its ID.
+func (r *MineSiteRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&MineSiteInfo{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: minesite.go
+//
+// Generated by this command:
+//
+//	mockgen -typed -source=minesite.go -destination=mocks/minesite.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	data "mineral/data"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMineSiteInterface is a mock of MineSiteInterface interface.
+type MockMineSiteInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockMineSiteInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockMineSiteInterfaceMockRecorder is the mock recorder for MockMineSiteInterface.
+type MockMineSiteInterfaceMockRecorder struct {
+	mock *MockMineSiteInterface
+}
+
+// NewMockMineSiteInterface creates a new mock instance.
+func NewMockMineSiteInterface(ctrl *gomock.Controller) *MockMineSiteInterface {
+	mock := &MockMineSiteInterface{ctrl: ctrl}
+	mock.recorder = &MockMineSiteInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMineSiteInterface) EXPECT() *MockMineSiteInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockMineSiteInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMineSiteInterfaceMockRecorder) Delete(ctx, id, userID any) *MockMineSiteInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMineSiteInterface)(nil).Delete), ctx, id, userID)
+	return &MockMineSiteInterfaceDeleteCall{Call: call}
+}
+
+// MockMineSiteInterfaceDeleteCall wrap *gomock.Call
+type MockMineSiteInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMineSiteInterfaceDeleteCall) Return(arg0 error) *MockMineSiteInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMineSiteInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockMineSiteInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMineSiteInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockMineSiteInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockMineSiteInterface) GetAll(ctx context.Context, userID uint) ([]*data.MineSiteInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
+	ret0, _ := ret[0].([]*data.MineSiteInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockMineSiteInterfaceMockRecorder) GetAll(ctx, userID any) *MockMineSiteInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockMineSiteInterface)(nil).GetAll), ctx, userID)
+	return &MockMineSiteInterfaceGetAllCall{Call: call}
+}
+
+// MockMineSiteInterfaceGetAllCall wrap *gomock.Call
+type MockMineSiteInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMineSiteInterfaceGetAllCall) Return(arg0 []*data.MineSiteInfo, arg1 error) *MockMineSiteInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMineSiteInterfaceGetAllCall) Do(f func(context.Context, uint) ([]*data.MineSiteInfo, error)) *MockMineSiteInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMineSiteInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint) ([]*data.MineSiteInfo, error)) *MockMineSiteInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByUserID mocks base method.
+func (m *MockMineSiteInterface) GetByUserID(ctx context.Context, userID uint) (*data.MineSiteInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", ctx, userID)
+	ret0, _ := ret[0].(*data.MineSiteInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockMineSiteInterfaceMockRecorder) GetByUserID(ctx, userID any) *MockMineSiteInterfaceGetByUserIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockMineSiteInterface)(nil).GetByUserID), ctx, userID)
+	return &MockMineSiteInterfaceGetByUserIDCall{Call: call}
+}
+
+// MockMineSiteInterfaceGetByUserIDCall wrap *gomock.Call
+type MockMineSiteInterfaceGetByUserIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMineSiteInterfaceGetByUserIDCall) Return(arg0 *data.MineSiteInfo, arg1 error) *MockMineSiteInterfaceGetByUserIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMineSiteInterfaceGetByUserIDCall) Do(f func(context.Context, uint) (*data.MineSiteInfo, error)) *MockMineSiteInterfaceGetByUserIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMineSiteInterfaceGetByUserIDCall) DoAndReturn(f func(context.Context, uint) (*data.MineSiteInfo, error)) *MockMineSiteInterfaceGetByUserIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockMineSiteInterface) GetOne(ctx context.Context, id, userID uint) (*data.MineSiteInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.MineSiteInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockMineSiteInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockMineSiteInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockMineSiteInterface)(nil).GetOne), ctx, id, userID)
+	return &MockMineSiteInterfaceGetOneCall{Call: call}
+}
+
+// MockMineSiteInterfaceGetOneCall wrap *gomock.Call
+type MockMineSiteInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMineSiteInterfaceGetOneCall) Return(arg0 *data.MineSiteInfo, arg1 error) *MockMineSiteInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMineSiteInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.MineSiteInfo, error)) *MockMineSiteInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMineSiteInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.MineSiteInfo, error)) *MockMineSiteInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockMineSiteInterface) Insert(ctx context.Context, info *data.MineSiteInfo) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, info)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockMineSiteInterfaceMockRecorder) Insert(ctx, info any) *MockMineSiteInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockMineSiteInterface)(nil).Insert), ctx, info)
+	return &MockMineSiteInterfaceInsertCall{Call: call}
+}
+
+// MockMineSiteInterfaceInsertCall wrap *gomock.Call
+type MockMineSiteInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMineSiteInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockMineSiteInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMineSiteInterfaceInsertCall) Do(f func(context.Context, *data.MineSiteInfo) (uint, error)) *MockMineSiteInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMineSiteInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.MineSiteInfo) (uint, error)) *MockMineSiteInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockMineSiteInterface) Update(ctx context.Context, info *data.MineSiteInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, info)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMineSiteInterfaceMockRecorder) Update(ctx, info any) *MockMineSiteInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMineSiteInterface)(nil).Update), ctx, info)
+	return &MockMineSiteInterfaceUpdateCall{Call: call}
+}
+
+// MockMineSiteInterfaceUpdateCall wrap *gomock.Call
+type MockMineSiteInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMineSiteInterfaceUpdateCall) Return(arg0 error) *MockMineSiteInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMineSiteInterfaceUpdateCall) Do(f func(context.Context, *data.MineSiteInfo) error) *MockMineSiteInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMineSiteInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.MineSiteInfo) error) *MockMineSiteInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,18683 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -typed -source=interfaces.go -destination=mocks/mocks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	data "mineral/data"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserInterface is a mock of UserInterface interface.
+type MockUserInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockUserInterfaceMockRecorder is the mock recorder for MockUserInterface.
+type MockUserInterfaceMockRecorder struct {
+	mock *MockUserInterface
+}
+
+// NewMockUserInterface creates a new mock instance.
+func NewMockUserInterface(ctrl *gomock.Controller) *MockUserInterface {
+	mock := &MockUserInterface{ctrl: ctrl}
+	mock.recorder = &MockUserInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserInterface) EXPECT() *MockUserInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ConfirmPendingPhone mocks base method.
+func (m *MockUserInterface) ConfirmPendingPhone(ctx context.Context, email, otp string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmPendingPhone", ctx, email, otp)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmPendingPhone indicates an expected call of ConfirmPendingPhone.
+func (mr *MockUserInterfaceMockRecorder) ConfirmPendingPhone(ctx, email, otp any) *MockUserInterfaceConfirmPendingPhoneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPendingPhone", reflect.TypeOf((*MockUserInterface)(nil).ConfirmPendingPhone), ctx, email, otp)
+	return &MockUserInterfaceConfirmPendingPhoneCall{Call: call}
+}
+
+// MockUserInterfaceConfirmPendingPhoneCall wrap *gomock.Call
+type MockUserInterfaceConfirmPendingPhoneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserInterfaceConfirmPendingPhoneCall) Return(arg0 string, arg1 error) *MockUserInterfaceConfirmPendingPhoneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserInterfaceConfirmPendingPhoneCall) Do(f func(context.Context, string, string) (string, error)) *MockUserInterfaceConfirmPendingPhoneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserInterfaceConfirmPendingPhoneCall) DoAndReturn(f func(context.Context, string, string) (string, error)) *MockUserInterfaceConfirmPendingPhoneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Delete mocks base method.
+func (m *MockUserInterface) Delete(ctx context.Context, user *data.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUserInterfaceMockRecorder) Delete(ctx, user any) *MockUserInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserInterface)(nil).Delete), ctx, user)
+	return &MockUserInterfaceDeleteCall{Call: call}
+}
+
+// MockUserInterfaceDeleteCall wrap *gomock.Call
+type MockUserInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserInterfaceDeleteCall) Return(arg0 error) *MockUserInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserInterfaceDeleteCall) Do(f func(context.Context, *data.User) error) *MockUserInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserInterfaceDeleteCall) DoAndReturn(f func(context.Context, *data.User) error) *MockUserInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteByID mocks base method.
+func (m *MockUserInterface) DeleteByID(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockUserInterfaceMockRecorder) DeleteByID(ctx, id any) *MockUserInterfaceDeleteByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockUserInterface)(nil).DeleteByID), ctx, id)
+	return &MockUserInterfaceDeleteByIDCall{Call: call}
+}
+
+// MockUserInterfaceDeleteByIDCall wrap *gomock.Call
+type MockUserInterfaceDeleteByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserInterfaceDeleteByIDCall) Return(arg0 error) *MockUserInterfaceDeleteByIDCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserInterfaceDeleteByIDCall) Do(f func(context.Context, uint) error) *MockUserInterfaceDeleteByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserInterfaceDeleteByIDCall) DoAndReturn(f func(context.Context, uint) error) *MockUserInterfaceDeleteByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GenerateAndSaveOTP mocks base method.
+func (m *MockUserInterface) GenerateAndSaveOTP(ctx context.Context, email, purpose string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateAndSaveOTP", ctx, email, purpose)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateAndSaveOTP indicates an expected call of GenerateAndSaveOTP.
+func (mr *MockUserInterfaceMockRecorder) GenerateAndSaveOTP(ctx, email, purpose any) *MockUserInterfaceGenerateAndSaveOTPCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateAndSaveOTP", reflect.TypeOf((*MockUserInterface)(nil).GenerateAndSaveOTP), ctx, email, purpose)
+	return &MockUserInterfaceGenerateAndSaveOTPCall{Call: call}
+}
+
+// MockUserInterfaceGenerateAndSaveOTPCall wrap *gomock.Call
+type MockUserInterfaceGenerateAndSaveOTPCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserInterfaceGenerateAndSaveOTPCall) Return(arg0 string, arg1 error) *MockUserInterfaceGenerateAndSaveOTPCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserInterfaceGenerateAndSaveOTPCall) Do(f func(context.Context, string, string) (string, error)) *MockUserInterfaceGenerateAndSaveOTPCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserInterfaceGenerateAndSaveOTPCall) DoAndReturn(f func(context.Context, string, string) (string, error)) *MockUserInterfaceGenerateAndSaveOTPCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockUserInterface) GetAll(ctx context.Context) ([]*data.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*data.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockUserInterfaceMockRecorder) GetAll(ctx any) *MockUserInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockUserInterface)(nil).GetAll), ctx)
+	return &MockUserInterfaceGetAllCall{Call: call}
+}
+
+// MockUserInterfaceGetAllCall wrap *gomock.Call
+type MockUserInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserInterfaceGetAllCall) Return(arg0 []*data.User, arg1 error) *MockUserInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserInterfaceGetAllCall) Do(f func(context.Context) ([]*data.User, error)) *MockUserInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserInterfaceGetAllCall) DoAndReturn(f func(context.Context) ([]*data.User, error)) *MockUserInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByEmail mocks base method.
+func (m *MockUserInterface) GetByEmail(ctx context.Context, email string) (*data.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, email)
+	ret0, _ := ret[0].(*data.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockUserInterfaceMockRecorder) GetByEmail(ctx, email any) *MockUserInterfaceGetByEmailCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockUserInterface)(nil).GetByEmail), ctx, email)
+	return &MockUserInterfaceGetByEmailCall{Call: call}
+}
+
+// MockUserInterfaceGetByEmailCall wrap *gomock.Call
+type MockUserInterfaceGetByEmailCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserInterfaceGetByEmailCall) Return(arg0 *data.User, arg1 error) *MockUserInterfaceGetByEmailCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserInterfaceGetByEmailCall) Do(f func(context.Context, string) (*data.User, error)) *MockUserInterfaceGetByEmailCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserInterfaceGetByEmailCall) DoAndReturn(f func(context.Context, string) (*data.User, error)) *MockUserInterfaceGetByEmailCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockUserInterface) GetOne(ctx context.Context, id uint) (*data.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id)
+	ret0, _ := ret[0].(*data.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockUserInterfaceMockRecorder) GetOne(ctx, id any) *MockUserInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockUserInterface)(nil).GetOne), ctx, id)
+	return &MockUserInterfaceGetOneCall{Call: call}
+}
+
+// MockUserInterfaceGetOneCall wrap *gomock.Call
+type MockUserInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserInterfaceGetOneCall) Return(arg0 *data.User, arg1 error) *MockUserInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserInterfaceGetOneCall) Do(f func(context.Context, uint) (*data.User, error)) *MockUserInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint) (*data.User, error)) *MockUserInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockUserInterface) Insert(ctx context.Context, user *data.User) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, user)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockUserInterfaceMockRecorder) Insert(ctx, user any) *MockUserInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockUserInterface)(nil).Insert), ctx, user)
+	return &MockUserInterfaceInsertCall{Call: call}
+}
+
+// MockUserInterfaceInsertCall wrap *gomock.Call
+type MockUserInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockUserInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserInterfaceInsertCall) Do(f func(context.Context, *data.User) (uint, error)) *MockUserInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.User) (uint, error)) *MockUserInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// LoginWithOTP mocks base method.
+func (m *MockUserInterface) LoginWithOTP(ctx context.Context, email, otp string) (*data.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginWithOTP", ctx, email, otp)
+	ret0, _ := ret[0].(*data.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginWithOTP indicates an expected call of LoginWithOTP.
+func (mr *MockUserInterfaceMockRecorder) LoginWithOTP(ctx, email, otp any) *MockUserInterfaceLoginWithOTPCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginWithOTP", reflect.TypeOf((*MockUserInterface)(nil).LoginWithOTP), ctx, email, otp)
+	return &MockUserInterfaceLoginWithOTPCall{Call: call}
+}
+
+// MockUserInterfaceLoginWithOTPCall wrap *gomock.Call
+type MockUserInterfaceLoginWithOTPCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserInterfaceLoginWithOTPCall) Return(arg0 *data.User, arg1 error) *MockUserInterfaceLoginWithOTPCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserInterfaceLoginWithOTPCall) Do(f func(context.Context, string, string) (*data.User, error)) *MockUserInterfaceLoginWithOTPCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserInterfaceLoginWithOTPCall) DoAndReturn(f func(context.Context, string, string) (*data.User, error)) *MockUserInterfaceLoginWithOTPCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// PasswordMatches mocks base method.
+func (m *MockUserInterface) PasswordMatches(user *data.User, plainText string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PasswordMatches", user, plainText)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PasswordMatches indicates an expected call of PasswordMatches.
+func (mr *MockUserInterfaceMockRecorder) PasswordMatches(user, plainText any) *MockUserInterfacePasswordMatchesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordMatches", reflect.TypeOf((*MockUserInterface)(nil).PasswordMatches), user, plainText)
+	return &MockUserInterfacePasswordMatchesCall{Call: call}
+}
+
+// MockUserInterfacePasswordMatchesCall wrap *gomock.Call
+type MockUserInterfacePasswordMatchesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserInterfacePasswordMatchesCall) Return(arg0 bool, arg1 error) *MockUserInterfacePasswordMatchesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserInterfacePasswordMatchesCall) Do(f func(*data.User, string) (bool, error)) *MockUserInterfacePasswordMatchesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserInterfacePasswordMatchesCall) DoAndReturn(f func(*data.User, string) (bool, error)) *MockUserInterfacePasswordMatchesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RecordFailedLogin mocks base method.
+func (m *MockUserInterface) RecordFailedLogin(ctx context.Context, userID uint, maxFailures int, lockout time.Duration) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailedLogin", ctx, userID, maxFailures, lockout)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordFailedLogin indicates an expected call of RecordFailedLogin.
+func (mr *MockUserInterfaceMockRecorder) RecordFailedLogin(ctx, userID, maxFailures, lockout any) *MockUserInterfaceRecordFailedLoginCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailedLogin", reflect.TypeOf((*MockUserInterface)(nil).RecordFailedLogin), ctx, userID, maxFailures, lockout)
+	return &MockUserInterfaceRecordFailedLoginCall{Call: call}
+}
+
+// MockUserInterfaceRecordFailedLoginCall wrap *gomock.Call
+type MockUserInterfaceRecordFailedLoginCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserInterfaceRecordFailedLoginCall) Return(arg0 *time.Time, arg1 error) *MockUserInterfaceRecordFailedLoginCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserInterfaceRecordFailedLoginCall) Do(f func(context.Context, uint, int, time.Duration) (*time.Time, error)) *MockUserInterfaceRecordFailedLoginCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserInterfaceRecordFailedLoginCall) DoAndReturn(f func(context.Context, uint, int, time.Duration) (*time.Time, error)) *MockUserInterfaceRecordFailedLoginCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ResetPassword mocks base method.
+func (m *MockUserInterface) ResetPassword(ctx context.Context, userID uint, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, userID, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockUserInterfaceMockRecorder) ResetPassword(ctx, userID, newPassword any) *MockUserInterfaceResetPasswordCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUserInterface)(nil).ResetPassword), ctx, userID, newPassword)
+	return &MockUserInterfaceResetPasswordCall{Call: call}
+}
+
+// MockUserInterfaceResetPasswordCall wrap *gomock.Call
+type MockUserInterfaceResetPasswordCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserInterfaceResetPasswordCall) Return(arg0 error) *MockUserInterfaceResetPasswordCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserInterfaceResetPasswordCall) Do(f func(context.Context, uint, string) error) *MockUserInterfaceResetPasswordCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserInterfaceResetPasswordCall) DoAndReturn(f func(context.Context, uint, string) error) *MockUserInterfaceResetPasswordCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ResetPasswordWithOTP mocks base method.
+func (m *MockUserInterface) ResetPasswordWithOTP(ctx context.Context, email, otp, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPasswordWithOTP", ctx, email, otp, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPasswordWithOTP indicates an expected call of ResetPasswordWithOTP.
+func (mr *MockUserInterfaceMockRecorder) ResetPasswordWithOTP(ctx, email, otp, newPassword any) *MockUserInterfaceResetPasswordWithOTPCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPasswordWithOTP", reflect.TypeOf((*MockUserInterface)(nil).ResetPasswordWithOTP), ctx, email, otp, newPassword)
+	return &MockUserInterfaceResetPasswordWithOTPCall{Call: call}
+}
+
+// MockUserInterfaceResetPasswordWithOTPCall wrap *gomock.Call
+type MockUserInterfaceResetPasswordWithOTPCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserInterfaceResetPasswordWithOTPCall) Return(arg0 error) *MockUserInterfaceResetPasswordWithOTPCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserInterfaceResetPasswordWithOTPCall) Do(f func(context.Context, string, string, string) error) *MockUserInterfaceResetPasswordWithOTPCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserInterfaceResetPasswordWithOTPCall) DoAndReturn(f func(context.Context, string, string, string) error) *MockUserInterfaceResetPasswordWithOTPCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SetPendingPhone mocks base method.
+func (m *MockUserInterface) SetPendingPhone(ctx context.Context, userID uint, phone string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPendingPhone", ctx, userID, phone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPendingPhone indicates an expected call of SetPendingPhone.
+func (mr *MockUserInterfaceMockRecorder) SetPendingPhone(ctx, userID, phone any) *MockUserInterfaceSetPendingPhoneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPendingPhone", reflect.TypeOf((*MockUserInterface)(nil).SetPendingPhone), ctx, userID, phone)
+	return &MockUserInterfaceSetPendingPhoneCall{Call: call}
+}
+
+// MockUserInterfaceSetPendingPhoneCall wrap *gomock.Call
+type MockUserInterfaceSetPendingPhoneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserInterfaceSetPendingPhoneCall) Return(arg0 error) *MockUserInterfaceSetPendingPhoneCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserInterfaceSetPendingPhoneCall) Do(f func(context.Context, uint, string) error) *MockUserInterfaceSetPendingPhoneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserInterfaceSetPendingPhoneCall) DoAndReturn(f func(context.Context, uint, string) error) *MockUserInterfaceSetPendingPhoneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Unlock mocks base method.
+func (m *MockUserInterface) Unlock(ctx context.Context, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlock", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlock indicates an expected call of Unlock.
+func (mr *MockUserInterfaceMockRecorder) Unlock(ctx, userID any) *MockUserInterfaceUnlockCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockUserInterface)(nil).Unlock), ctx, userID)
+	return &MockUserInterfaceUnlockCall{Call: call}
+}
+
+// MockUserInterfaceUnlockCall wrap *gomock.Call
+type MockUserInterfaceUnlockCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserInterfaceUnlockCall) Return(arg0 error) *MockUserInterfaceUnlockCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserInterfaceUnlockCall) Do(f func(context.Context, uint) error) *MockUserInterfaceUnlockCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserInterfaceUnlockCall) DoAndReturn(f func(context.Context, uint) error) *MockUserInterfaceUnlockCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockUserInterface) Update(ctx context.Context, user *data.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockUserInterfaceMockRecorder) Update(ctx, user any) *MockUserInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserInterface)(nil).Update), ctx, user)
+	return &MockUserInterfaceUpdateCall{Call: call}
+}
+
+// MockUserInterfaceUpdateCall wrap *gomock.Call
+type MockUserInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserInterfaceUpdateCall) Return(arg0 error) *MockUserInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserInterfaceUpdateCall) Do(f func(context.Context, *data.User) error) *MockUserInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.User) error) *MockUserInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// VerifyEmail mocks base method.
+func (m *MockUserInterface) VerifyEmail(ctx context.Context, email, otp string) (*data.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, email, otp)
+	ret0, _ := ret[0].(*data.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockUserInterfaceMockRecorder) VerifyEmail(ctx, email, otp any) *MockUserInterfaceVerifyEmailCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUserInterface)(nil).VerifyEmail), ctx, email, otp)
+	return &MockUserInterfaceVerifyEmailCall{Call: call}
+}
+
+// MockUserInterfaceVerifyEmailCall wrap *gomock.Call
+type MockUserInterfaceVerifyEmailCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUserInterfaceVerifyEmailCall) Return(arg0 *data.User, arg1 error) *MockUserInterfaceVerifyEmailCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUserInterfaceVerifyEmailCall) Do(f func(context.Context, string, string) (*data.User, error)) *MockUserInterfaceVerifyEmailCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUserInterfaceVerifyEmailCall) DoAndReturn(f func(context.Context, string, string) (*data.User, error)) *MockUserInterfaceVerifyEmailCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockIncomeInterface is a mock of IncomeInterface interface.
+type MockIncomeInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockIncomeInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockIncomeInterfaceMockRecorder is the mock recorder for MockIncomeInterface.
+type MockIncomeInterfaceMockRecorder struct {
+	mock *MockIncomeInterface
+}
+
+// NewMockIncomeInterface creates a new mock instance.
+func NewMockIncomeInterface(ctrl *gomock.Controller) *MockIncomeInterface {
+	mock := &MockIncomeInterface{ctrl: ctrl}
+	mock.recorder = &MockIncomeInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIncomeInterface) EXPECT() *MockIncomeInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AssignInvoiceNumber mocks base method.
+func (m *MockIncomeInterface) AssignInvoiceNumber(ctx context.Context, id, userID uint) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignInvoiceNumber", ctx, id, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignInvoiceNumber indicates an expected call of AssignInvoiceNumber.
+func (mr *MockIncomeInterfaceMockRecorder) AssignInvoiceNumber(ctx, id, userID any) *MockIncomeInterfaceAssignInvoiceNumberCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignInvoiceNumber", reflect.TypeOf((*MockIncomeInterface)(nil).AssignInvoiceNumber), ctx, id, userID)
+	return &MockIncomeInterfaceAssignInvoiceNumberCall{Call: call}
+}
+
+// MockIncomeInterfaceAssignInvoiceNumberCall wrap *gomock.Call
+type MockIncomeInterfaceAssignInvoiceNumberCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIncomeInterfaceAssignInvoiceNumberCall) Return(arg0 string, arg1 error) *MockIncomeInterfaceAssignInvoiceNumberCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIncomeInterfaceAssignInvoiceNumberCall) Do(f func(context.Context, uint, uint) (string, error)) *MockIncomeInterfaceAssignInvoiceNumberCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIncomeInterfaceAssignInvoiceNumberCall) DoAndReturn(f func(context.Context, uint, uint) (string, error)) *MockIncomeInterfaceAssignInvoiceNumberCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Delete mocks base method.
+func (m *MockIncomeInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIncomeInterfaceMockRecorder) Delete(ctx, id, userID any) *MockIncomeInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIncomeInterface)(nil).Delete), ctx, id, userID)
+	return &MockIncomeInterfaceDeleteCall{Call: call}
+}
+
+// MockIncomeInterfaceDeleteCall wrap *gomock.Call
+type MockIncomeInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIncomeInterfaceDeleteCall) Return(arg0 error) *MockIncomeInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIncomeInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockIncomeInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIncomeInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockIncomeInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockIncomeInterface) GetAll(ctx context.Context, userID uint) ([]*data.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
+	ret0, _ := ret[0].([]*data.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockIncomeInterfaceMockRecorder) GetAll(ctx, userID any) *MockIncomeInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockIncomeInterface)(nil).GetAll), ctx, userID)
+	return &MockIncomeInterfaceGetAllCall{Call: call}
+}
+
+// MockIncomeInterfaceGetAllCall wrap *gomock.Call
+type MockIncomeInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIncomeInterfaceGetAllCall) Return(arg0 []*data.Income, arg1 error) *MockIncomeInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIncomeInterfaceGetAllCall) Do(f func(context.Context, uint) ([]*data.Income, error)) *MockIncomeInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIncomeInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint) ([]*data.Income, error)) *MockIncomeInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetArchived mocks base method.
+func (m *MockIncomeInterface) GetArchived(ctx context.Context, userID uint, startDate, endDate string) ([]*data.ArchivedIncome, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchived", ctx, userID, startDate, endDate)
+	ret0, _ := ret[0].([]*data.ArchivedIncome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchived indicates an expected call of GetArchived.
+func (mr *MockIncomeInterfaceMockRecorder) GetArchived(ctx, userID, startDate, endDate any) *MockIncomeInterfaceGetArchivedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchived", reflect.TypeOf((*MockIncomeInterface)(nil).GetArchived), ctx, userID, startDate, endDate)
+	return &MockIncomeInterfaceGetArchivedCall{Call: call}
+}
+
+// MockIncomeInterfaceGetArchivedCall wrap *gomock.Call
+type MockIncomeInterfaceGetArchivedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIncomeInterfaceGetArchivedCall) Return(arg0 []*data.ArchivedIncome, arg1 error) *MockIncomeInterfaceGetArchivedCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIncomeInterfaceGetArchivedCall) Do(f func(context.Context, uint, string, string) ([]*data.ArchivedIncome, error)) *MockIncomeInterfaceGetArchivedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIncomeInterfaceGetArchivedCall) DoAndReturn(f func(context.Context, uint, string, string) ([]*data.ArchivedIncome, error)) *MockIncomeInterfaceGetArchivedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetArchivedOne mocks base method.
+func (m *MockIncomeInterface) GetArchivedOne(ctx context.Context, id, userID uint) (*data.ArchivedIncome, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchivedOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.ArchivedIncome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchivedOne indicates an expected call of GetArchivedOne.
+func (mr *MockIncomeInterfaceMockRecorder) GetArchivedOne(ctx, id, userID any) *MockIncomeInterfaceGetArchivedOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedOne", reflect.TypeOf((*MockIncomeInterface)(nil).GetArchivedOne), ctx, id, userID)
+	return &MockIncomeInterfaceGetArchivedOneCall{Call: call}
+}
+
+// MockIncomeInterfaceGetArchivedOneCall wrap *gomock.Call
+type MockIncomeInterfaceGetArchivedOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIncomeInterfaceGetArchivedOneCall) Return(arg0 *data.ArchivedIncome, arg1 error) *MockIncomeInterfaceGetArchivedOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIncomeInterfaceGetArchivedOneCall) Do(f func(context.Context, uint, uint) (*data.ArchivedIncome, error)) *MockIncomeInterfaceGetArchivedOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIncomeInterfaceGetArchivedOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.ArchivedIncome, error)) *MockIncomeInterfaceGetArchivedOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByCustomer mocks base method.
+func (m *MockIncomeInterface) GetByCustomer(ctx context.Context, userID uint, customerName string) ([]*data.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCustomer", ctx, userID, customerName)
+	ret0, _ := ret[0].([]*data.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCustomer indicates an expected call of GetByCustomer.
+func (mr *MockIncomeInterfaceMockRecorder) GetByCustomer(ctx, userID, customerName any) *MockIncomeInterfaceGetByCustomerCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCustomer", reflect.TypeOf((*MockIncomeInterface)(nil).GetByCustomer), ctx, userID, customerName)
+	return &MockIncomeInterfaceGetByCustomerCall{Call: call}
+}
+
+// MockIncomeInterfaceGetByCustomerCall wrap *gomock.Call
+type MockIncomeInterfaceGetByCustomerCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIncomeInterfaceGetByCustomerCall) Return(arg0 []*data.Income, arg1 error) *MockIncomeInterfaceGetByCustomerCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIncomeInterfaceGetByCustomerCall) Do(f func(context.Context, uint, string) ([]*data.Income, error)) *MockIncomeInterfaceGetByCustomerCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIncomeInterfaceGetByCustomerCall) DoAndReturn(f func(context.Context, uint, string) ([]*data.Income, error)) *MockIncomeInterfaceGetByCustomerCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByDateRange mocks base method.
+func (m *MockIncomeInterface) GetByDateRange(ctx context.Context, userID uint, startDate, endDate string) ([]*data.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDateRange", ctx, userID, startDate, endDate)
+	ret0, _ := ret[0].([]*data.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDateRange indicates an expected call of GetByDateRange.
+func (mr *MockIncomeInterfaceMockRecorder) GetByDateRange(ctx, userID, startDate, endDate any) *MockIncomeInterfaceGetByDateRangeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDateRange", reflect.TypeOf((*MockIncomeInterface)(nil).GetByDateRange), ctx, userID, startDate, endDate)
+	return &MockIncomeInterfaceGetByDateRangeCall{Call: call}
+}
+
+// MockIncomeInterfaceGetByDateRangeCall wrap *gomock.Call
+type MockIncomeInterfaceGetByDateRangeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIncomeInterfaceGetByDateRangeCall) Return(arg0 []*data.Income, arg1 error) *MockIncomeInterfaceGetByDateRangeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIncomeInterfaceGetByDateRangeCall) Do(f func(context.Context, uint, string, string) ([]*data.Income, error)) *MockIncomeInterfaceGetByDateRangeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIncomeInterfaceGetByDateRangeCall) DoAndReturn(f func(context.Context, uint, string, string) ([]*data.Income, error)) *MockIncomeInterfaceGetByDateRangeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetDeleted mocks base method.
+func (m *MockIncomeInterface) GetDeleted(ctx context.Context, userID uint) ([]*data.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeleted", ctx, userID)
+	ret0, _ := ret[0].([]*data.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeleted indicates an expected call of GetDeleted.
+func (mr *MockIncomeInterfaceMockRecorder) GetDeleted(ctx, userID any) *MockIncomeInterfaceGetDeletedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeleted", reflect.TypeOf((*MockIncomeInterface)(nil).GetDeleted), ctx, userID)
+	return &MockIncomeInterfaceGetDeletedCall{Call: call}
+}
+
+// MockIncomeInterfaceGetDeletedCall wrap *gomock.Call
+type MockIncomeInterfaceGetDeletedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIncomeInterfaceGetDeletedCall) Return(arg0 []*data.Income, arg1 error) *MockIncomeInterfaceGetDeletedCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIncomeInterfaceGetDeletedCall) Do(f func(context.Context, uint) ([]*data.Income, error)) *MockIncomeInterfaceGetDeletedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIncomeInterfaceGetDeletedCall) DoAndReturn(f func(context.Context, uint) ([]*data.Income, error)) *MockIncomeInterfaceGetDeletedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetFinancialSummary mocks base method.
+func (m *MockIncomeInterface) GetFinancialSummary(ctx context.Context, userID uint, start, end *time.Time) (*data.FinancialSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFinancialSummary", ctx, userID, start, end)
+	ret0, _ := ret[0].(*data.FinancialSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFinancialSummary indicates an expected call of GetFinancialSummary.
+func (mr *MockIncomeInterfaceMockRecorder) GetFinancialSummary(ctx, userID, start, end any) *MockIncomeInterfaceGetFinancialSummaryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFinancialSummary", reflect.TypeOf((*MockIncomeInterface)(nil).GetFinancialSummary), ctx, userID, start, end)
+	return &MockIncomeInterfaceGetFinancialSummaryCall{Call: call}
+}
+
+// MockIncomeInterfaceGetFinancialSummaryCall wrap *gomock.Call
+type MockIncomeInterfaceGetFinancialSummaryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIncomeInterfaceGetFinancialSummaryCall) Return(arg0 *data.FinancialSummary, arg1 error) *MockIncomeInterfaceGetFinancialSummaryCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIncomeInterfaceGetFinancialSummaryCall) Do(f func(context.Context, uint, *time.Time, *time.Time) (*data.FinancialSummary, error)) *MockIncomeInterfaceGetFinancialSummaryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIncomeInterfaceGetFinancialSummaryCall) DoAndReturn(f func(context.Context, uint, *time.Time, *time.Time) (*data.FinancialSummary, error)) *MockIncomeInterfaceGetFinancialSummaryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetMonthlyData mocks base method.
+func (m *MockIncomeInterface) GetMonthlyData(ctx context.Context, userID uint, year int) ([]*data.MonthlyData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMonthlyData", ctx, userID, year)
+	ret0, _ := ret[0].([]*data.MonthlyData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMonthlyData indicates an expected call of GetMonthlyData.
+func (mr *MockIncomeInterfaceMockRecorder) GetMonthlyData(ctx, userID, year any) *MockIncomeInterfaceGetMonthlyDataCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMonthlyData", reflect.TypeOf((*MockIncomeInterface)(nil).GetMonthlyData), ctx, userID, year)
+	return &MockIncomeInterfaceGetMonthlyDataCall{Call: call}
+}
+
+// MockIncomeInterfaceGetMonthlyDataCall wrap *gomock.Call
+type MockIncomeInterfaceGetMonthlyDataCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIncomeInterfaceGetMonthlyDataCall) Return(arg0 []*data.MonthlyData, arg1 error) *MockIncomeInterfaceGetMonthlyDataCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIncomeInterfaceGetMonthlyDataCall) Do(f func(context.Context, uint, int) ([]*data.MonthlyData, error)) *MockIncomeInterfaceGetMonthlyDataCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIncomeInterfaceGetMonthlyDataCall) DoAndReturn(f func(context.Context, uint, int) ([]*data.MonthlyData, error)) *MockIncomeInterfaceGetMonthlyDataCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetMonthlyDataBetween mocks base method.
+func (m *MockIncomeInterface) GetMonthlyDataBetween(ctx context.Context, userID uint, start, end time.Time) ([]*data.MonthlyData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMonthlyDataBetween", ctx, userID, start, end)
+	ret0, _ := ret[0].([]*data.MonthlyData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMonthlyDataBetween indicates an expected call of GetMonthlyDataBetween.
+func (mr *MockIncomeInterfaceMockRecorder) GetMonthlyDataBetween(ctx, userID, start, end any) *MockIncomeInterfaceGetMonthlyDataBetweenCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMonthlyDataBetween", reflect.TypeOf((*MockIncomeInterface)(nil).GetMonthlyDataBetween), ctx, userID, start, end)
+	return &MockIncomeInterfaceGetMonthlyDataBetweenCall{Call: call}
+}
+
+// MockIncomeInterfaceGetMonthlyDataBetweenCall wrap *gomock.Call
+type MockIncomeInterfaceGetMonthlyDataBetweenCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIncomeInterfaceGetMonthlyDataBetweenCall) Return(arg0 []*data.MonthlyData, arg1 error) *MockIncomeInterfaceGetMonthlyDataBetweenCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIncomeInterfaceGetMonthlyDataBetweenCall) Do(f func(context.Context, uint, time.Time, time.Time) ([]*data.MonthlyData, error)) *MockIncomeInterfaceGetMonthlyDataBetweenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIncomeInterfaceGetMonthlyDataBetweenCall) DoAndReturn(f func(context.Context, uint, time.Time, time.Time) ([]*data.MonthlyData, error)) *MockIncomeInterfaceGetMonthlyDataBetweenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockIncomeInterface) GetOne(ctx context.Context, id, userID uint) (*data.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockIncomeInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockIncomeInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockIncomeInterface)(nil).GetOne), ctx, id, userID)
+	return &MockIncomeInterfaceGetOneCall{Call: call}
+}
+
+// MockIncomeInterfaceGetOneCall wrap *gomock.Call
+type MockIncomeInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIncomeInterfaceGetOneCall) Return(arg0 *data.Income, arg1 error) *MockIncomeInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIncomeInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.Income, error)) *MockIncomeInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIncomeInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Income, error)) *MockIncomeInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOutstanding mocks base method.
+func (m *MockIncomeInterface) GetOutstanding(ctx context.Context, userID uint) ([]*data.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutstanding", ctx, userID)
+	ret0, _ := ret[0].([]*data.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutstanding indicates an expected call of GetOutstanding.
+func (mr *MockIncomeInterfaceMockRecorder) GetOutstanding(ctx, userID any) *MockIncomeInterfaceGetOutstandingCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutstanding", reflect.TypeOf((*MockIncomeInterface)(nil).GetOutstanding), ctx, userID)
+	return &MockIncomeInterfaceGetOutstandingCall{Call: call}
+}
+
+// MockIncomeInterfaceGetOutstandingCall wrap *gomock.Call
+type MockIncomeInterfaceGetOutstandingCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIncomeInterfaceGetOutstandingCall) Return(arg0 []*data.Income, arg1 error) *MockIncomeInterfaceGetOutstandingCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIncomeInterfaceGetOutstandingCall) Do(f func(context.Context, uint) ([]*data.Income, error)) *MockIncomeInterfaceGetOutstandingCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIncomeInterfaceGetOutstandingCall) DoAndReturn(f func(context.Context, uint) ([]*data.Income, error)) *MockIncomeInterfaceGetOutstandingCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPage mocks base method.
+func (m *MockIncomeInterface) GetPage(ctx context.Context, userID uint, filter data.IncomeFilter, offset, limit int) ([]*data.Income, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", ctx, userID, filter, offset, limit)
+	ret0, _ := ret[0].([]*data.Income)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockIncomeInterfaceMockRecorder) GetPage(ctx, userID, filter, offset, limit any) *MockIncomeInterfaceGetPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockIncomeInterface)(nil).GetPage), ctx, userID, filter, offset, limit)
+	return &MockIncomeInterfaceGetPageCall{Call: call}
+}
+
+// MockIncomeInterfaceGetPageCall wrap *gomock.Call
+type MockIncomeInterfaceGetPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIncomeInterfaceGetPageCall) Return(arg0 []*data.Income, arg1 int64, arg2 error) *MockIncomeInterfaceGetPageCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIncomeInterfaceGetPageCall) Do(f func(context.Context, uint, data.IncomeFilter, int, int) ([]*data.Income, int64, error)) *MockIncomeInterfaceGetPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIncomeInterfaceGetPageCall) DoAndReturn(f func(context.Context, uint, data.IncomeFilter, int, int) ([]*data.Income, int64, error)) *MockIncomeInterfaceGetPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPendingApproval mocks base method.
+func (m *MockIncomeInterface) GetPendingApproval(ctx context.Context, userID uint) ([]*data.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingApproval", ctx, userID)
+	ret0, _ := ret[0].([]*data.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingApproval indicates an expected call of GetPendingApproval.
+func (mr *MockIncomeInterfaceMockRecorder) GetPendingApproval(ctx, userID any) *MockIncomeInterfaceGetPendingApprovalCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingApproval", reflect.TypeOf((*MockIncomeInterface)(nil).GetPendingApproval), ctx, userID)
+	return &MockIncomeInterfaceGetPendingApprovalCall{Call: call}
+}
+
+// MockIncomeInterfaceGetPendingApprovalCall wrap *gomock.Call
+type MockIncomeInterfaceGetPendingApprovalCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIncomeInterfaceGetPendingApprovalCall) Return(arg0 []*data.Income, arg1 error) *MockIncomeInterfaceGetPendingApprovalCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIncomeInterfaceGetPendingApprovalCall) Do(f func(context.Context, uint) ([]*data.Income, error)) *MockIncomeInterfaceGetPendingApprovalCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIncomeInterfaceGetPendingApprovalCall) DoAndReturn(f func(context.Context, uint) ([]*data.Income, error)) *MockIncomeInterfaceGetPendingApprovalCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockIncomeInterface) Insert(ctx context.Context, income *data.Income) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, income)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockIncomeInterfaceMockRecorder) Insert(ctx, income any) *MockIncomeInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockIncomeInterface)(nil).Insert), ctx, income)
+	return &MockIncomeInterfaceInsertCall{Call: call}
+}
+
+// MockIncomeInterfaceInsertCall wrap *gomock.Call
+type MockIncomeInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIncomeInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockIncomeInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIncomeInterfaceInsertCall) Do(f func(context.Context, *data.Income) (uint, error)) *MockIncomeInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIncomeInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.Income) (uint, error)) *MockIncomeInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Purge mocks base method.
+func (m *MockIncomeInterface) Purge(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockIncomeInterfaceMockRecorder) Purge(ctx, id, userID any) *MockIncomeInterfacePurgeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockIncomeInterface)(nil).Purge), ctx, id, userID)
+	return &MockIncomeInterfacePurgeCall{Call: call}
+}
+
+// MockIncomeInterfacePurgeCall wrap *gomock.Call
+type MockIncomeInterfacePurgeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIncomeInterfacePurgeCall) Return(arg0 error) *MockIncomeInterfacePurgeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIncomeInterfacePurgeCall) Do(f func(context.Context, uint, uint) error) *MockIncomeInterfacePurgeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIncomeInterfacePurgeCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockIncomeInterfacePurgeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Restore mocks base method.
+func (m *MockIncomeInterface) Restore(ctx context.Context, id, userID uint) (*data.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, userID)
+	ret0, _ := ret[0].(*data.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockIncomeInterfaceMockRecorder) Restore(ctx, id, userID any) *MockIncomeInterfaceRestoreCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockIncomeInterface)(nil).Restore), ctx, id, userID)
+	return &MockIncomeInterfaceRestoreCall{Call: call}
+}
+
+// MockIncomeInterfaceRestoreCall wrap *gomock.Call
+type MockIncomeInterfaceRestoreCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIncomeInterfaceRestoreCall) Return(arg0 *data.Income, arg1 error) *MockIncomeInterfaceRestoreCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIncomeInterfaceRestoreCall) Do(f func(context.Context, uint, uint) (*data.Income, error)) *MockIncomeInterfaceRestoreCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIncomeInterfaceRestoreCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Income, error)) *MockIncomeInterfaceRestoreCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Review mocks base method.
+func (m *MockIncomeInterface) Review(ctx context.Context, id, userID uint, status data.SaleApproval, reviewerID uint, reason *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Review", ctx, id, userID, status, reviewerID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Review indicates an expected call of Review.
+func (mr *MockIncomeInterfaceMockRecorder) Review(ctx, id, userID, status, reviewerID, reason any) *MockIncomeInterfaceReviewCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Review", reflect.TypeOf((*MockIncomeInterface)(nil).Review), ctx, id, userID, status, reviewerID, reason)
+	return &MockIncomeInterfaceReviewCall{Call: call}
+}
+
+// MockIncomeInterfaceReviewCall wrap *gomock.Call
+type MockIncomeInterfaceReviewCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIncomeInterfaceReviewCall) Return(arg0 error) *MockIncomeInterfaceReviewCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIncomeInterfaceReviewCall) Do(f func(context.Context, uint, uint, data.SaleApproval, uint, *string) error) *MockIncomeInterfaceReviewCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIncomeInterfaceReviewCall) DoAndReturn(f func(context.Context, uint, uint, data.SaleApproval, uint, *string) error) *MockIncomeInterfaceReviewCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockIncomeInterface) Update(ctx context.Context, income *data.Income) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, income)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIncomeInterfaceMockRecorder) Update(ctx, income any) *MockIncomeInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIncomeInterface)(nil).Update), ctx, income)
+	return &MockIncomeInterfaceUpdateCall{Call: call}
+}
+
+// MockIncomeInterfaceUpdateCall wrap *gomock.Call
+type MockIncomeInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIncomeInterfaceUpdateCall) Return(arg0 error) *MockIncomeInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIncomeInterfaceUpdateCall) Do(f func(context.Context, *data.Income) error) *MockIncomeInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIncomeInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.Income) error) *MockIncomeInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockExpenseInterface is a mock of ExpenseInterface interface.
+type MockExpenseInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockExpenseInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockExpenseInterfaceMockRecorder is the mock recorder for MockExpenseInterface.
+type MockExpenseInterfaceMockRecorder struct {
+	mock *MockExpenseInterface
+}
+
+// NewMockExpenseInterface creates a new mock instance.
+func NewMockExpenseInterface(ctrl *gomock.Controller) *MockExpenseInterface {
+	mock := &MockExpenseInterface{ctrl: ctrl}
+	mock.recorder = &MockExpenseInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExpenseInterface) EXPECT() *MockExpenseInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockExpenseInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockExpenseInterfaceMockRecorder) Delete(ctx, id, userID any) *MockExpenseInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockExpenseInterface)(nil).Delete), ctx, id, userID)
+	return &MockExpenseInterfaceDeleteCall{Call: call}
+}
+
+// MockExpenseInterfaceDeleteCall wrap *gomock.Call
+type MockExpenseInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceDeleteCall) Return(arg0 error) *MockExpenseInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockExpenseInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockExpenseInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockExpenseInterface) GetAll(ctx context.Context, userID uint) ([]*data.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
+	ret0, _ := ret[0].([]*data.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockExpenseInterfaceMockRecorder) GetAll(ctx, userID any) *MockExpenseInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockExpenseInterface)(nil).GetAll), ctx, userID)
+	return &MockExpenseInterfaceGetAllCall{Call: call}
+}
+
+// MockExpenseInterfaceGetAllCall wrap *gomock.Call
+type MockExpenseInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceGetAllCall) Return(arg0 []*data.Expense, arg1 error) *MockExpenseInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceGetAllCall) Do(f func(context.Context, uint) ([]*data.Expense, error)) *MockExpenseInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint) ([]*data.Expense, error)) *MockExpenseInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAmortization mocks base method.
+func (m *MockExpenseInterface) GetAmortization(ctx context.Context, id, userID uint) (*data.AmortizationSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAmortization", ctx, id, userID)
+	ret0, _ := ret[0].(*data.AmortizationSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAmortization indicates an expected call of GetAmortization.
+func (mr *MockExpenseInterfaceMockRecorder) GetAmortization(ctx, id, userID any) *MockExpenseInterfaceGetAmortizationCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAmortization", reflect.TypeOf((*MockExpenseInterface)(nil).GetAmortization), ctx, id, userID)
+	return &MockExpenseInterfaceGetAmortizationCall{Call: call}
+}
+
+// MockExpenseInterfaceGetAmortizationCall wrap *gomock.Call
+type MockExpenseInterfaceGetAmortizationCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceGetAmortizationCall) Return(arg0 *data.AmortizationSchedule, arg1 error) *MockExpenseInterfaceGetAmortizationCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceGetAmortizationCall) Do(f func(context.Context, uint, uint) (*data.AmortizationSchedule, error)) *MockExpenseInterfaceGetAmortizationCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceGetAmortizationCall) DoAndReturn(f func(context.Context, uint, uint) (*data.AmortizationSchedule, error)) *MockExpenseInterfaceGetAmortizationCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetArchived mocks base method.
+func (m *MockExpenseInterface) GetArchived(ctx context.Context, userID uint, startDate, endDate string) ([]*data.ArchivedExpense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchived", ctx, userID, startDate, endDate)
+	ret0, _ := ret[0].([]*data.ArchivedExpense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchived indicates an expected call of GetArchived.
+func (mr *MockExpenseInterfaceMockRecorder) GetArchived(ctx, userID, startDate, endDate any) *MockExpenseInterfaceGetArchivedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchived", reflect.TypeOf((*MockExpenseInterface)(nil).GetArchived), ctx, userID, startDate, endDate)
+	return &MockExpenseInterfaceGetArchivedCall{Call: call}
+}
+
+// MockExpenseInterfaceGetArchivedCall wrap *gomock.Call
+type MockExpenseInterfaceGetArchivedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceGetArchivedCall) Return(arg0 []*data.ArchivedExpense, arg1 error) *MockExpenseInterfaceGetArchivedCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceGetArchivedCall) Do(f func(context.Context, uint, string, string) ([]*data.ArchivedExpense, error)) *MockExpenseInterfaceGetArchivedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceGetArchivedCall) DoAndReturn(f func(context.Context, uint, string, string) ([]*data.ArchivedExpense, error)) *MockExpenseInterfaceGetArchivedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetArchivedOne mocks base method.
+func (m *MockExpenseInterface) GetArchivedOne(ctx context.Context, id, userID uint) (*data.ArchivedExpense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchivedOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.ArchivedExpense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchivedOne indicates an expected call of GetArchivedOne.
+func (mr *MockExpenseInterfaceMockRecorder) GetArchivedOne(ctx, id, userID any) *MockExpenseInterfaceGetArchivedOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedOne", reflect.TypeOf((*MockExpenseInterface)(nil).GetArchivedOne), ctx, id, userID)
+	return &MockExpenseInterfaceGetArchivedOneCall{Call: call}
+}
+
+// MockExpenseInterfaceGetArchivedOneCall wrap *gomock.Call
+type MockExpenseInterfaceGetArchivedOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceGetArchivedOneCall) Return(arg0 *data.ArchivedExpense, arg1 error) *MockExpenseInterfaceGetArchivedOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceGetArchivedOneCall) Do(f func(context.Context, uint, uint) (*data.ArchivedExpense, error)) *MockExpenseInterfaceGetArchivedOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceGetArchivedOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.ArchivedExpense, error)) *MockExpenseInterfaceGetArchivedOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAwaitingSignOff mocks base method.
+func (m *MockExpenseInterface) GetAwaitingSignOff(ctx context.Context, userID uint) ([]*data.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAwaitingSignOff", ctx, userID)
+	ret0, _ := ret[0].([]*data.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAwaitingSignOff indicates an expected call of GetAwaitingSignOff.
+func (mr *MockExpenseInterfaceMockRecorder) GetAwaitingSignOff(ctx, userID any) *MockExpenseInterfaceGetAwaitingSignOffCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAwaitingSignOff", reflect.TypeOf((*MockExpenseInterface)(nil).GetAwaitingSignOff), ctx, userID)
+	return &MockExpenseInterfaceGetAwaitingSignOffCall{Call: call}
+}
+
+// MockExpenseInterfaceGetAwaitingSignOffCall wrap *gomock.Call
+type MockExpenseInterfaceGetAwaitingSignOffCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceGetAwaitingSignOffCall) Return(arg0 []*data.Expense, arg1 error) *MockExpenseInterfaceGetAwaitingSignOffCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceGetAwaitingSignOffCall) Do(f func(context.Context, uint) ([]*data.Expense, error)) *MockExpenseInterfaceGetAwaitingSignOffCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceGetAwaitingSignOffCall) DoAndReturn(f func(context.Context, uint) ([]*data.Expense, error)) *MockExpenseInterfaceGetAwaitingSignOffCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByDateRange mocks base method.
+func (m *MockExpenseInterface) GetByDateRange(ctx context.Context, userID uint, startDate, endDate string) ([]*data.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDateRange", ctx, userID, startDate, endDate)
+	ret0, _ := ret[0].([]*data.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDateRange indicates an expected call of GetByDateRange.
+func (mr *MockExpenseInterfaceMockRecorder) GetByDateRange(ctx, userID, startDate, endDate any) *MockExpenseInterfaceGetByDateRangeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDateRange", reflect.TypeOf((*MockExpenseInterface)(nil).GetByDateRange), ctx, userID, startDate, endDate)
+	return &MockExpenseInterfaceGetByDateRangeCall{Call: call}
+}
+
+// MockExpenseInterfaceGetByDateRangeCall wrap *gomock.Call
+type MockExpenseInterfaceGetByDateRangeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceGetByDateRangeCall) Return(arg0 []*data.Expense, arg1 error) *MockExpenseInterfaceGetByDateRangeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceGetByDateRangeCall) Do(f func(context.Context, uint, string, string) ([]*data.Expense, error)) *MockExpenseInterfaceGetByDateRangeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceGetByDateRangeCall) DoAndReturn(f func(context.Context, uint, string, string) ([]*data.Expense, error)) *MockExpenseInterfaceGetByDateRangeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetCategoryBreakdown mocks base method.
+func (m *MockExpenseInterface) GetCategoryBreakdown(ctx context.Context, userID uint) ([]*data.CategoryBreakdown, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryBreakdown", ctx, userID)
+	ret0, _ := ret[0].([]*data.CategoryBreakdown)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryBreakdown indicates an expected call of GetCategoryBreakdown.
+func (mr *MockExpenseInterfaceMockRecorder) GetCategoryBreakdown(ctx, userID any) *MockExpenseInterfaceGetCategoryBreakdownCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryBreakdown", reflect.TypeOf((*MockExpenseInterface)(nil).GetCategoryBreakdown), ctx, userID)
+	return &MockExpenseInterfaceGetCategoryBreakdownCall{Call: call}
+}
+
+// MockExpenseInterfaceGetCategoryBreakdownCall wrap *gomock.Call
+type MockExpenseInterfaceGetCategoryBreakdownCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceGetCategoryBreakdownCall) Return(arg0 []*data.CategoryBreakdown, arg1 error) *MockExpenseInterfaceGetCategoryBreakdownCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceGetCategoryBreakdownCall) Do(f func(context.Context, uint) ([]*data.CategoryBreakdown, error)) *MockExpenseInterfaceGetCategoryBreakdownCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceGetCategoryBreakdownCall) DoAndReturn(f func(context.Context, uint) ([]*data.CategoryBreakdown, error)) *MockExpenseInterfaceGetCategoryBreakdownCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetDeleted mocks base method.
+func (m *MockExpenseInterface) GetDeleted(ctx context.Context, userID uint) ([]*data.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeleted", ctx, userID)
+	ret0, _ := ret[0].([]*data.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeleted indicates an expected call of GetDeleted.
+func (mr *MockExpenseInterfaceMockRecorder) GetDeleted(ctx, userID any) *MockExpenseInterfaceGetDeletedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeleted", reflect.TypeOf((*MockExpenseInterface)(nil).GetDeleted), ctx, userID)
+	return &MockExpenseInterfaceGetDeletedCall{Call: call}
+}
+
+// MockExpenseInterfaceGetDeletedCall wrap *gomock.Call
+type MockExpenseInterfaceGetDeletedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceGetDeletedCall) Return(arg0 []*data.Expense, arg1 error) *MockExpenseInterfaceGetDeletedCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceGetDeletedCall) Do(f func(context.Context, uint) ([]*data.Expense, error)) *MockExpenseInterfaceGetDeletedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceGetDeletedCall) DoAndReturn(f func(context.Context, uint) ([]*data.Expense, error)) *MockExpenseInterfaceGetDeletedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetFinancialSummary mocks base method.
+func (m *MockExpenseInterface) GetFinancialSummary(ctx context.Context, userID uint, start, end *time.Time) (*data.FinancialSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFinancialSummary", ctx, userID, start, end)
+	ret0, _ := ret[0].(*data.FinancialSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFinancialSummary indicates an expected call of GetFinancialSummary.
+func (mr *MockExpenseInterfaceMockRecorder) GetFinancialSummary(ctx, userID, start, end any) *MockExpenseInterfaceGetFinancialSummaryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFinancialSummary", reflect.TypeOf((*MockExpenseInterface)(nil).GetFinancialSummary), ctx, userID, start, end)
+	return &MockExpenseInterfaceGetFinancialSummaryCall{Call: call}
+}
+
+// MockExpenseInterfaceGetFinancialSummaryCall wrap *gomock.Call
+type MockExpenseInterfaceGetFinancialSummaryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceGetFinancialSummaryCall) Return(arg0 *data.FinancialSummary, arg1 error) *MockExpenseInterfaceGetFinancialSummaryCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceGetFinancialSummaryCall) Do(f func(context.Context, uint, *time.Time, *time.Time) (*data.FinancialSummary, error)) *MockExpenseInterfaceGetFinancialSummaryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceGetFinancialSummaryCall) DoAndReturn(f func(context.Context, uint, *time.Time, *time.Time) (*data.FinancialSummary, error)) *MockExpenseInterfaceGetFinancialSummaryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetMonthlyData mocks base method.
+func (m *MockExpenseInterface) GetMonthlyData(ctx context.Context, userID uint, year int) ([]*data.MonthlyData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMonthlyData", ctx, userID, year)
+	ret0, _ := ret[0].([]*data.MonthlyData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMonthlyData indicates an expected call of GetMonthlyData.
+func (mr *MockExpenseInterfaceMockRecorder) GetMonthlyData(ctx, userID, year any) *MockExpenseInterfaceGetMonthlyDataCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMonthlyData", reflect.TypeOf((*MockExpenseInterface)(nil).GetMonthlyData), ctx, userID, year)
+	return &MockExpenseInterfaceGetMonthlyDataCall{Call: call}
+}
+
+// MockExpenseInterfaceGetMonthlyDataCall wrap *gomock.Call
+type MockExpenseInterfaceGetMonthlyDataCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceGetMonthlyDataCall) Return(arg0 []*data.MonthlyData, arg1 error) *MockExpenseInterfaceGetMonthlyDataCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceGetMonthlyDataCall) Do(f func(context.Context, uint, int) ([]*data.MonthlyData, error)) *MockExpenseInterfaceGetMonthlyDataCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceGetMonthlyDataCall) DoAndReturn(f func(context.Context, uint, int) ([]*data.MonthlyData, error)) *MockExpenseInterfaceGetMonthlyDataCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetMonthlyDataBetween mocks base method.
+func (m *MockExpenseInterface) GetMonthlyDataBetween(ctx context.Context, userID uint, start, end time.Time) ([]*data.MonthlyData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMonthlyDataBetween", ctx, userID, start, end)
+	ret0, _ := ret[0].([]*data.MonthlyData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMonthlyDataBetween indicates an expected call of GetMonthlyDataBetween.
+func (mr *MockExpenseInterfaceMockRecorder) GetMonthlyDataBetween(ctx, userID, start, end any) *MockExpenseInterfaceGetMonthlyDataBetweenCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMonthlyDataBetween", reflect.TypeOf((*MockExpenseInterface)(nil).GetMonthlyDataBetween), ctx, userID, start, end)
+	return &MockExpenseInterfaceGetMonthlyDataBetweenCall{Call: call}
+}
+
+// MockExpenseInterfaceGetMonthlyDataBetweenCall wrap *gomock.Call
+type MockExpenseInterfaceGetMonthlyDataBetweenCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceGetMonthlyDataBetweenCall) Return(arg0 []*data.MonthlyData, arg1 error) *MockExpenseInterfaceGetMonthlyDataBetweenCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceGetMonthlyDataBetweenCall) Do(f func(context.Context, uint, time.Time, time.Time) ([]*data.MonthlyData, error)) *MockExpenseInterfaceGetMonthlyDataBetweenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceGetMonthlyDataBetweenCall) DoAndReturn(f func(context.Context, uint, time.Time, time.Time) ([]*data.MonthlyData, error)) *MockExpenseInterfaceGetMonthlyDataBetweenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockExpenseInterface) GetOne(ctx context.Context, id, userID uint) (*data.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockExpenseInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockExpenseInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockExpenseInterface)(nil).GetOne), ctx, id, userID)
+	return &MockExpenseInterfaceGetOneCall{Call: call}
+}
+
+// MockExpenseInterfaceGetOneCall wrap *gomock.Call
+type MockExpenseInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceGetOneCall) Return(arg0 *data.Expense, arg1 error) *MockExpenseInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.Expense, error)) *MockExpenseInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Expense, error)) *MockExpenseInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPage mocks base method.
+func (m *MockExpenseInterface) GetPage(ctx context.Context, userID uint, filter data.ExpenseFilter, offset, limit int) ([]*data.Expense, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", ctx, userID, filter, offset, limit)
+	ret0, _ := ret[0].([]*data.Expense)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockExpenseInterfaceMockRecorder) GetPage(ctx, userID, filter, offset, limit any) *MockExpenseInterfaceGetPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockExpenseInterface)(nil).GetPage), ctx, userID, filter, offset, limit)
+	return &MockExpenseInterfaceGetPageCall{Call: call}
+}
+
+// MockExpenseInterfaceGetPageCall wrap *gomock.Call
+type MockExpenseInterfaceGetPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceGetPageCall) Return(arg0 []*data.Expense, arg1 int64, arg2 error) *MockExpenseInterfaceGetPageCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceGetPageCall) Do(f func(context.Context, uint, data.ExpenseFilter, int, int) ([]*data.Expense, int64, error)) *MockExpenseInterfaceGetPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceGetPageCall) DoAndReturn(f func(context.Context, uint, data.ExpenseFilter, int, int) ([]*data.Expense, int64, error)) *MockExpenseInterfaceGetPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPendingApproval mocks base method.
+func (m *MockExpenseInterface) GetPendingApproval(ctx context.Context, userID uint) ([]*data.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingApproval", ctx, userID)
+	ret0, _ := ret[0].([]*data.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingApproval indicates an expected call of GetPendingApproval.
+func (mr *MockExpenseInterfaceMockRecorder) GetPendingApproval(ctx, userID any) *MockExpenseInterfaceGetPendingApprovalCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingApproval", reflect.TypeOf((*MockExpenseInterface)(nil).GetPendingApproval), ctx, userID)
+	return &MockExpenseInterfaceGetPendingApprovalCall{Call: call}
+}
+
+// MockExpenseInterfaceGetPendingApprovalCall wrap *gomock.Call
+type MockExpenseInterfaceGetPendingApprovalCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceGetPendingApprovalCall) Return(arg0 []*data.Expense, arg1 error) *MockExpenseInterfaceGetPendingApprovalCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceGetPendingApprovalCall) Do(f func(context.Context, uint) ([]*data.Expense, error)) *MockExpenseInterfaceGetPendingApprovalCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceGetPendingApprovalCall) DoAndReturn(f func(context.Context, uint) ([]*data.Expense, error)) *MockExpenseInterfaceGetPendingApprovalCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPrepaid mocks base method.
+func (m *MockExpenseInterface) GetPrepaid(ctx context.Context, userID uint) ([]*data.AmortizationSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrepaid", ctx, userID)
+	ret0, _ := ret[0].([]*data.AmortizationSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrepaid indicates an expected call of GetPrepaid.
+func (mr *MockExpenseInterfaceMockRecorder) GetPrepaid(ctx, userID any) *MockExpenseInterfaceGetPrepaidCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrepaid", reflect.TypeOf((*MockExpenseInterface)(nil).GetPrepaid), ctx, userID)
+	return &MockExpenseInterfaceGetPrepaidCall{Call: call}
+}
+
+// MockExpenseInterfaceGetPrepaidCall wrap *gomock.Call
+type MockExpenseInterfaceGetPrepaidCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceGetPrepaidCall) Return(arg0 []*data.AmortizationSchedule, arg1 error) *MockExpenseInterfaceGetPrepaidCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceGetPrepaidCall) Do(f func(context.Context, uint) ([]*data.AmortizationSchedule, error)) *MockExpenseInterfaceGetPrepaidCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceGetPrepaidCall) DoAndReturn(f func(context.Context, uint) ([]*data.AmortizationSchedule, error)) *MockExpenseInterfaceGetPrepaidCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetSignOffs mocks base method.
+func (m *MockExpenseInterface) GetSignOffs(ctx context.Context, id, userID uint) ([]*data.ExpenseSignOff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSignOffs", ctx, id, userID)
+	ret0, _ := ret[0].([]*data.ExpenseSignOff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSignOffs indicates an expected call of GetSignOffs.
+func (mr *MockExpenseInterfaceMockRecorder) GetSignOffs(ctx, id, userID any) *MockExpenseInterfaceGetSignOffsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSignOffs", reflect.TypeOf((*MockExpenseInterface)(nil).GetSignOffs), ctx, id, userID)
+	return &MockExpenseInterfaceGetSignOffsCall{Call: call}
+}
+
+// MockExpenseInterfaceGetSignOffsCall wrap *gomock.Call
+type MockExpenseInterfaceGetSignOffsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceGetSignOffsCall) Return(arg0 []*data.ExpenseSignOff, arg1 error) *MockExpenseInterfaceGetSignOffsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceGetSignOffsCall) Do(f func(context.Context, uint, uint) ([]*data.ExpenseSignOff, error)) *MockExpenseInterfaceGetSignOffsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceGetSignOffsCall) DoAndReturn(f func(context.Context, uint, uint) ([]*data.ExpenseSignOff, error)) *MockExpenseInterfaceGetSignOffsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockExpenseInterface) Insert(ctx context.Context, expense *data.Expense) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, expense)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockExpenseInterfaceMockRecorder) Insert(ctx, expense any) *MockExpenseInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockExpenseInterface)(nil).Insert), ctx, expense)
+	return &MockExpenseInterfaceInsertCall{Call: call}
+}
+
+// MockExpenseInterfaceInsertCall wrap *gomock.Call
+type MockExpenseInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockExpenseInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceInsertCall) Do(f func(context.Context, *data.Expense) (uint, error)) *MockExpenseInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.Expense) (uint, error)) *MockExpenseInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Purge mocks base method.
+func (m *MockExpenseInterface) Purge(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockExpenseInterfaceMockRecorder) Purge(ctx, id, userID any) *MockExpenseInterfacePurgeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockExpenseInterface)(nil).Purge), ctx, id, userID)
+	return &MockExpenseInterfacePurgeCall{Call: call}
+}
+
+// MockExpenseInterfacePurgeCall wrap *gomock.Call
+type MockExpenseInterfacePurgeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfacePurgeCall) Return(arg0 error) *MockExpenseInterfacePurgeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfacePurgeCall) Do(f func(context.Context, uint, uint) error) *MockExpenseInterfacePurgeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfacePurgeCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockExpenseInterfacePurgeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Restore mocks base method.
+func (m *MockExpenseInterface) Restore(ctx context.Context, id, userID uint) (*data.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, userID)
+	ret0, _ := ret[0].(*data.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockExpenseInterfaceMockRecorder) Restore(ctx, id, userID any) *MockExpenseInterfaceRestoreCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockExpenseInterface)(nil).Restore), ctx, id, userID)
+	return &MockExpenseInterfaceRestoreCall{Call: call}
+}
+
+// MockExpenseInterfaceRestoreCall wrap *gomock.Call
+type MockExpenseInterfaceRestoreCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceRestoreCall) Return(arg0 *data.Expense, arg1 error) *MockExpenseInterfaceRestoreCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceRestoreCall) Do(f func(context.Context, uint, uint) (*data.Expense, error)) *MockExpenseInterfaceRestoreCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceRestoreCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Expense, error)) *MockExpenseInterfaceRestoreCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Review mocks base method.
+func (m *MockExpenseInterface) Review(ctx context.Context, id, userID uint, status data.SaleApproval, reviewerID uint, reason *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Review", ctx, id, userID, status, reviewerID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Review indicates an expected call of Review.
+func (mr *MockExpenseInterfaceMockRecorder) Review(ctx, id, userID, status, reviewerID, reason any) *MockExpenseInterfaceReviewCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Review", reflect.TypeOf((*MockExpenseInterface)(nil).Review), ctx, id, userID, status, reviewerID, reason)
+	return &MockExpenseInterfaceReviewCall{Call: call}
+}
+
+// MockExpenseInterfaceReviewCall wrap *gomock.Call
+type MockExpenseInterfaceReviewCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceReviewCall) Return(arg0 error) *MockExpenseInterfaceReviewCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceReviewCall) Do(f func(context.Context, uint, uint, data.SaleApproval, uint, *string) error) *MockExpenseInterfaceReviewCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceReviewCall) DoAndReturn(f func(context.Context, uint, uint, data.SaleApproval, uint, *string) error) *MockExpenseInterfaceReviewCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SignOff mocks base method.
+func (m *MockExpenseInterface) SignOff(ctx context.Context, id, userID, approverID uint) (*data.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignOff", ctx, id, userID, approverID)
+	ret0, _ := ret[0].(*data.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignOff indicates an expected call of SignOff.
+func (mr *MockExpenseInterfaceMockRecorder) SignOff(ctx, id, userID, approverID any) *MockExpenseInterfaceSignOffCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignOff", reflect.TypeOf((*MockExpenseInterface)(nil).SignOff), ctx, id, userID, approverID)
+	return &MockExpenseInterfaceSignOffCall{Call: call}
+}
+
+// MockExpenseInterfaceSignOffCall wrap *gomock.Call
+type MockExpenseInterfaceSignOffCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceSignOffCall) Return(arg0 *data.Expense, arg1 error) *MockExpenseInterfaceSignOffCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceSignOffCall) Do(f func(context.Context, uint, uint, uint) (*data.Expense, error)) *MockExpenseInterfaceSignOffCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceSignOffCall) DoAndReturn(f func(context.Context, uint, uint, uint) (*data.Expense, error)) *MockExpenseInterfaceSignOffCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockExpenseInterface) Update(ctx context.Context, expense *data.Expense) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, expense)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockExpenseInterfaceMockRecorder) Update(ctx, expense any) *MockExpenseInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockExpenseInterface)(nil).Update), ctx, expense)
+	return &MockExpenseInterfaceUpdateCall{Call: call}
+}
+
+// MockExpenseInterfaceUpdateCall wrap *gomock.Call
+type MockExpenseInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExpenseInterfaceUpdateCall) Return(arg0 error) *MockExpenseInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExpenseInterfaceUpdateCall) Do(f func(context.Context, *data.Expense) error) *MockExpenseInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExpenseInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.Expense) error) *MockExpenseInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockClaimInterface is a mock of ClaimInterface interface.
+type MockClaimInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockClaimInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockClaimInterfaceMockRecorder is the mock recorder for MockClaimInterface.
+type MockClaimInterfaceMockRecorder struct {
+	mock *MockClaimInterface
+}
+
+// NewMockClaimInterface creates a new mock instance.
+func NewMockClaimInterface(ctrl *gomock.Controller) *MockClaimInterface {
+	mock := &MockClaimInterface{ctrl: ctrl}
+	mock.recorder = &MockClaimInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClaimInterface) EXPECT() *MockClaimInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockClaimInterface) Approve(ctx context.Context, id, userID, reviewerID uint, signOff *data.SignOffStatus) (*data.ExpenseClaim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, id, userID, reviewerID, signOff)
+	ret0, _ := ret[0].(*data.ExpenseClaim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockClaimInterfaceMockRecorder) Approve(ctx, id, userID, reviewerID, signOff any) *MockClaimInterfaceApproveCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockClaimInterface)(nil).Approve), ctx, id, userID, reviewerID, signOff)
+	return &MockClaimInterfaceApproveCall{Call: call}
+}
+
+// MockClaimInterfaceApproveCall wrap *gomock.Call
+type MockClaimInterfaceApproveCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockClaimInterfaceApproveCall) Return(arg0 *data.ExpenseClaim, arg1 error) *MockClaimInterfaceApproveCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockClaimInterfaceApproveCall) Do(f func(context.Context, uint, uint, uint, *data.SignOffStatus) (*data.ExpenseClaim, error)) *MockClaimInterfaceApproveCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockClaimInterfaceApproveCall) DoAndReturn(f func(context.Context, uint, uint, uint, *data.SignOffStatus) (*data.ExpenseClaim, error)) *MockClaimInterfaceApproveCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Delete mocks base method.
+func (m *MockClaimInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockClaimInterfaceMockRecorder) Delete(ctx, id, userID any) *MockClaimInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockClaimInterface)(nil).Delete), ctx, id, userID)
+	return &MockClaimInterfaceDeleteCall{Call: call}
+}
+
+// MockClaimInterfaceDeleteCall wrap *gomock.Call
+type MockClaimInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockClaimInterfaceDeleteCall) Return(arg0 error) *MockClaimInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockClaimInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockClaimInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockClaimInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockClaimInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockClaimInterface) GetAll(ctx context.Context, userID uint, claimantID *uint, status *data.ClaimStatus) ([]*data.ExpenseClaim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID, claimantID, status)
+	ret0, _ := ret[0].([]*data.ExpenseClaim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockClaimInterfaceMockRecorder) GetAll(ctx, userID, claimantID, status any) *MockClaimInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockClaimInterface)(nil).GetAll), ctx, userID, claimantID, status)
+	return &MockClaimInterfaceGetAllCall{Call: call}
+}
+
+// MockClaimInterfaceGetAllCall wrap *gomock.Call
+type MockClaimInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockClaimInterfaceGetAllCall) Return(arg0 []*data.ExpenseClaim, arg1 error) *MockClaimInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockClaimInterfaceGetAllCall) Do(f func(context.Context, uint, *uint, *data.ClaimStatus) ([]*data.ExpenseClaim, error)) *MockClaimInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockClaimInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint, *uint, *data.ClaimStatus) ([]*data.ExpenseClaim, error)) *MockClaimInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockClaimInterface) GetOne(ctx context.Context, id, userID uint) (*data.ExpenseClaim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.ExpenseClaim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockClaimInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockClaimInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockClaimInterface)(nil).GetOne), ctx, id, userID)
+	return &MockClaimInterfaceGetOneCall{Call: call}
+}
+
+// MockClaimInterfaceGetOneCall wrap *gomock.Call
+type MockClaimInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockClaimInterfaceGetOneCall) Return(arg0 *data.ExpenseClaim, arg1 error) *MockClaimInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockClaimInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.ExpenseClaim, error)) *MockClaimInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockClaimInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.ExpenseClaim, error)) *MockClaimInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetStatement mocks base method.
+func (m *MockClaimInterface) GetStatement(ctx context.Context, userID, claimantID uint) (*data.ClaimantStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatement", ctx, userID, claimantID)
+	ret0, _ := ret[0].(*data.ClaimantStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatement indicates an expected call of GetStatement.
+func (mr *MockClaimInterfaceMockRecorder) GetStatement(ctx, userID, claimantID any) *MockClaimInterfaceGetStatementCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatement", reflect.TypeOf((*MockClaimInterface)(nil).GetStatement), ctx, userID, claimantID)
+	return &MockClaimInterfaceGetStatementCall{Call: call}
+}
+
+// MockClaimInterfaceGetStatementCall wrap *gomock.Call
+type MockClaimInterfaceGetStatementCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockClaimInterfaceGetStatementCall) Return(arg0 *data.ClaimantStatement, arg1 error) *MockClaimInterfaceGetStatementCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockClaimInterfaceGetStatementCall) Do(f func(context.Context, uint, uint) (*data.ClaimantStatement, error)) *MockClaimInterfaceGetStatementCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockClaimInterfaceGetStatementCall) DoAndReturn(f func(context.Context, uint, uint) (*data.ClaimantStatement, error)) *MockClaimInterfaceGetStatementCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockClaimInterface) Insert(ctx context.Context, claim *data.ExpenseClaim) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, claim)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockClaimInterfaceMockRecorder) Insert(ctx, claim any) *MockClaimInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockClaimInterface)(nil).Insert), ctx, claim)
+	return &MockClaimInterfaceInsertCall{Call: call}
+}
+
+// MockClaimInterfaceInsertCall wrap *gomock.Call
+type MockClaimInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockClaimInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockClaimInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockClaimInterfaceInsertCall) Do(f func(context.Context, *data.ExpenseClaim) (uint, error)) *MockClaimInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockClaimInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.ExpenseClaim) (uint, error)) *MockClaimInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Reject mocks base method.
+func (m *MockClaimInterface) Reject(ctx context.Context, id, userID, reviewerID uint, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, id, userID, reviewerID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockClaimInterfaceMockRecorder) Reject(ctx, id, userID, reviewerID, reason any) *MockClaimInterfaceRejectCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockClaimInterface)(nil).Reject), ctx, id, userID, reviewerID, reason)
+	return &MockClaimInterfaceRejectCall{Call: call}
+}
+
+// MockClaimInterfaceRejectCall wrap *gomock.Call
+type MockClaimInterfaceRejectCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockClaimInterfaceRejectCall) Return(arg0 error) *MockClaimInterfaceRejectCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockClaimInterfaceRejectCall) Do(f func(context.Context, uint, uint, uint, string) error) *MockClaimInterfaceRejectCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockClaimInterfaceRejectCall) DoAndReturn(f func(context.Context, uint, uint, uint, string) error) *MockClaimInterfaceRejectCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockClaimInterface) Update(ctx context.Context, claim *data.ExpenseClaim) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, claim)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockClaimInterfaceMockRecorder) Update(ctx, claim any) *MockClaimInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockClaimInterface)(nil).Update), ctx, claim)
+	return &MockClaimInterfaceUpdateCall{Call: call}
+}
+
+// MockClaimInterfaceUpdateCall wrap *gomock.Call
+type MockClaimInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockClaimInterfaceUpdateCall) Return(arg0 error) *MockClaimInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockClaimInterfaceUpdateCall) Do(f func(context.Context, *data.ExpenseClaim) error) *MockClaimInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockClaimInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.ExpenseClaim) error) *MockClaimInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockInventoryInterface is a mock of InventoryInterface interface.
+type MockInventoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockInventoryInterfaceMockRecorder is the mock recorder for MockInventoryInterface.
+type MockInventoryInterfaceMockRecorder struct {
+	mock *MockInventoryInterface
+}
+
+// NewMockInventoryInterface creates a new mock instance.
+func NewMockInventoryInterface(ctrl *gomock.Controller) *MockInventoryInterface {
+	mock := &MockInventoryInterface{ctrl: ctrl}
+	mock.recorder = &MockInventoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryInterface) EXPECT() *MockInventoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockInventoryInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockInventoryInterfaceMockRecorder) Delete(ctx, id, userID any) *MockInventoryInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockInventoryInterface)(nil).Delete), ctx, id, userID)
+	return &MockInventoryInterfaceDeleteCall{Call: call}
+}
+
+// MockInventoryInterfaceDeleteCall wrap *gomock.Call
+type MockInventoryInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInventoryInterfaceDeleteCall) Return(arg0 error) *MockInventoryInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInventoryInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockInventoryInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInventoryInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockInventoryInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockInventoryInterface) GetAll(ctx context.Context, userID uint) ([]*data.InventoryItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
+	ret0, _ := ret[0].([]*data.InventoryItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockInventoryInterfaceMockRecorder) GetAll(ctx, userID any) *MockInventoryInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockInventoryInterface)(nil).GetAll), ctx, userID)
+	return &MockInventoryInterfaceGetAllCall{Call: call}
+}
+
+// MockInventoryInterfaceGetAllCall wrap *gomock.Call
+type MockInventoryInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInventoryInterfaceGetAllCall) Return(arg0 []*data.InventoryItem, arg1 error) *MockInventoryInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInventoryInterfaceGetAllCall) Do(f func(context.Context, uint) ([]*data.InventoryItem, error)) *MockInventoryInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInventoryInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint) ([]*data.InventoryItem, error)) *MockInventoryInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAllExpiringItems mocks base method.
+func (m *MockInventoryInterface) GetAllExpiringItems(ctx context.Context, before time.Time) ([]*data.InventoryItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllExpiringItems", ctx, before)
+	ret0, _ := ret[0].([]*data.InventoryItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllExpiringItems indicates an expected call of GetAllExpiringItems.
+func (mr *MockInventoryInterfaceMockRecorder) GetAllExpiringItems(ctx, before any) *MockInventoryInterfaceGetAllExpiringItemsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllExpiringItems", reflect.TypeOf((*MockInventoryInterface)(nil).GetAllExpiringItems), ctx, before)
+	return &MockInventoryInterfaceGetAllExpiringItemsCall{Call: call}
+}
+
+// MockInventoryInterfaceGetAllExpiringItemsCall wrap *gomock.Call
+type MockInventoryInterfaceGetAllExpiringItemsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInventoryInterfaceGetAllExpiringItemsCall) Return(arg0 []*data.InventoryItem, arg1 error) *MockInventoryInterfaceGetAllExpiringItemsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInventoryInterfaceGetAllExpiringItemsCall) Do(f func(context.Context, time.Time) ([]*data.InventoryItem, error)) *MockInventoryInterfaceGetAllExpiringItemsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInventoryInterfaceGetAllExpiringItemsCall) DoAndReturn(f func(context.Context, time.Time) ([]*data.InventoryItem, error)) *MockInventoryInterfaceGetAllExpiringItemsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetDeleted mocks base method.
+func (m *MockInventoryInterface) GetDeleted(ctx context.Context, userID uint) ([]*data.InventoryItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeleted", ctx, userID)
+	ret0, _ := ret[0].([]*data.InventoryItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeleted indicates an expected call of GetDeleted.
+func (mr *MockInventoryInterfaceMockRecorder) GetDeleted(ctx, userID any) *MockInventoryInterfaceGetDeletedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeleted", reflect.TypeOf((*MockInventoryInterface)(nil).GetDeleted), ctx, userID)
+	return &MockInventoryInterfaceGetDeletedCall{Call: call}
+}
+
+// MockInventoryInterfaceGetDeletedCall wrap *gomock.Call
+type MockInventoryInterfaceGetDeletedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInventoryInterfaceGetDeletedCall) Return(arg0 []*data.InventoryItem, arg1 error) *MockInventoryInterfaceGetDeletedCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInventoryInterfaceGetDeletedCall) Do(f func(context.Context, uint) ([]*data.InventoryItem, error)) *MockInventoryInterfaceGetDeletedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInventoryInterfaceGetDeletedCall) DoAndReturn(f func(context.Context, uint) ([]*data.InventoryItem, error)) *MockInventoryInterfaceGetDeletedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetExpiringItems mocks base method.
+func (m *MockInventoryInterface) GetExpiringItems(ctx context.Context, userID uint, before time.Time) ([]*data.InventoryItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiringItems", ctx, userID, before)
+	ret0, _ := ret[0].([]*data.InventoryItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiringItems indicates an expected call of GetExpiringItems.
+func (mr *MockInventoryInterfaceMockRecorder) GetExpiringItems(ctx, userID, before any) *MockInventoryInterfaceGetExpiringItemsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiringItems", reflect.TypeOf((*MockInventoryInterface)(nil).GetExpiringItems), ctx, userID, before)
+	return &MockInventoryInterfaceGetExpiringItemsCall{Call: call}
+}
+
+// MockInventoryInterfaceGetExpiringItemsCall wrap *gomock.Call
+type MockInventoryInterfaceGetExpiringItemsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInventoryInterfaceGetExpiringItemsCall) Return(arg0 []*data.InventoryItem, arg1 error) *MockInventoryInterfaceGetExpiringItemsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInventoryInterfaceGetExpiringItemsCall) Do(f func(context.Context, uint, time.Time) ([]*data.InventoryItem, error)) *MockInventoryInterfaceGetExpiringItemsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInventoryInterfaceGetExpiringItemsCall) DoAndReturn(f func(context.Context, uint, time.Time) ([]*data.InventoryItem, error)) *MockInventoryInterfaceGetExpiringItemsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetHazardousItems mocks base method.
+func (m *MockInventoryInterface) GetHazardousItems(ctx context.Context, userID uint) ([]*data.InventoryItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHazardousItems", ctx, userID)
+	ret0, _ := ret[0].([]*data.InventoryItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHazardousItems indicates an expected call of GetHazardousItems.
+func (mr *MockInventoryInterfaceMockRecorder) GetHazardousItems(ctx, userID any) *MockInventoryInterfaceGetHazardousItemsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHazardousItems", reflect.TypeOf((*MockInventoryInterface)(nil).GetHazardousItems), ctx, userID)
+	return &MockInventoryInterfaceGetHazardousItemsCall{Call: call}
+}
+
+// MockInventoryInterfaceGetHazardousItemsCall wrap *gomock.Call
+type MockInventoryInterfaceGetHazardousItemsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInventoryInterfaceGetHazardousItemsCall) Return(arg0 []*data.InventoryItem, arg1 error) *MockInventoryInterfaceGetHazardousItemsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInventoryInterfaceGetHazardousItemsCall) Do(f func(context.Context, uint) ([]*data.InventoryItem, error)) *MockInventoryInterfaceGetHazardousItemsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInventoryInterfaceGetHazardousItemsCall) DoAndReturn(f func(context.Context, uint) ([]*data.InventoryItem, error)) *MockInventoryInterfaceGetHazardousItemsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetLowStockItems mocks base method.
+func (m *MockInventoryInterface) GetLowStockItems(ctx context.Context, userID uint) ([]*data.InventoryItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLowStockItems", ctx, userID)
+	ret0, _ := ret[0].([]*data.InventoryItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLowStockItems indicates an expected call of GetLowStockItems.
+func (mr *MockInventoryInterfaceMockRecorder) GetLowStockItems(ctx, userID any) *MockInventoryInterfaceGetLowStockItemsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLowStockItems", reflect.TypeOf((*MockInventoryInterface)(nil).GetLowStockItems), ctx, userID)
+	return &MockInventoryInterfaceGetLowStockItemsCall{Call: call}
+}
+
+// MockInventoryInterfaceGetLowStockItemsCall wrap *gomock.Call
+type MockInventoryInterfaceGetLowStockItemsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInventoryInterfaceGetLowStockItemsCall) Return(arg0 []*data.InventoryItem, arg1 error) *MockInventoryInterfaceGetLowStockItemsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInventoryInterfaceGetLowStockItemsCall) Do(f func(context.Context, uint) ([]*data.InventoryItem, error)) *MockInventoryInterfaceGetLowStockItemsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInventoryInterfaceGetLowStockItemsCall) DoAndReturn(f func(context.Context, uint) ([]*data.InventoryItem, error)) *MockInventoryInterfaceGetLowStockItemsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetMonthlyUsage mocks base method.
+func (m *MockInventoryInterface) GetMonthlyUsage(ctx context.Context, userID uint, since time.Time) ([]*data.MonthlyUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMonthlyUsage", ctx, userID, since)
+	ret0, _ := ret[0].([]*data.MonthlyUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMonthlyUsage indicates an expected call of GetMonthlyUsage.
+func (mr *MockInventoryInterfaceMockRecorder) GetMonthlyUsage(ctx, userID, since any) *MockInventoryInterfaceGetMonthlyUsageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMonthlyUsage", reflect.TypeOf((*MockInventoryInterface)(nil).GetMonthlyUsage), ctx, userID, since)
+	return &MockInventoryInterfaceGetMonthlyUsageCall{Call: call}
+}
+
+// MockInventoryInterfaceGetMonthlyUsageCall wrap *gomock.Call
+type MockInventoryInterfaceGetMonthlyUsageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInventoryInterfaceGetMonthlyUsageCall) Return(arg0 []*data.MonthlyUsage, arg1 error) *MockInventoryInterfaceGetMonthlyUsageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInventoryInterfaceGetMonthlyUsageCall) Do(f func(context.Context, uint, time.Time) ([]*data.MonthlyUsage, error)) *MockInventoryInterfaceGetMonthlyUsageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInventoryInterfaceGetMonthlyUsageCall) DoAndReturn(f func(context.Context, uint, time.Time) ([]*data.MonthlyUsage, error)) *MockInventoryInterfaceGetMonthlyUsageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetMovements mocks base method.
+func (m *MockInventoryInterface) GetMovements(ctx context.Context, id, userID uint) ([]*data.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovements", ctx, id, userID)
+	ret0, _ := ret[0].([]*data.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovements indicates an expected call of GetMovements.
+func (mr *MockInventoryInterfaceMockRecorder) GetMovements(ctx, id, userID any) *MockInventoryInterfaceGetMovementsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovements", reflect.TypeOf((*MockInventoryInterface)(nil).GetMovements), ctx, id, userID)
+	return &MockInventoryInterfaceGetMovementsCall{Call: call}
+}
+
+// MockInventoryInterfaceGetMovementsCall wrap *gomock.Call
+type MockInventoryInterfaceGetMovementsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInventoryInterfaceGetMovementsCall) Return(arg0 []*data.StockMovement, arg1 error) *MockInventoryInterfaceGetMovementsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInventoryInterfaceGetMovementsCall) Do(f func(context.Context, uint, uint) ([]*data.StockMovement, error)) *MockInventoryInterfaceGetMovementsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInventoryInterfaceGetMovementsCall) DoAndReturn(f func(context.Context, uint, uint) ([]*data.StockMovement, error)) *MockInventoryInterfaceGetMovementsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockInventoryInterface) GetOne(ctx context.Context, id, userID uint) (*data.InventoryItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.InventoryItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockInventoryInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockInventoryInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockInventoryInterface)(nil).GetOne), ctx, id, userID)
+	return &MockInventoryInterfaceGetOneCall{Call: call}
+}
+
+// MockInventoryInterfaceGetOneCall wrap *gomock.Call
+type MockInventoryInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInventoryInterfaceGetOneCall) Return(arg0 *data.InventoryItem, arg1 error) *MockInventoryInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInventoryInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.InventoryItem, error)) *MockInventoryInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInventoryInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.InventoryItem, error)) *MockInventoryInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPage mocks base method.
+func (m *MockInventoryInterface) GetPage(ctx context.Context, userID uint, siteID *uint, offset, limit int) ([]*data.InventoryItem, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", ctx, userID, siteID, offset, limit)
+	ret0, _ := ret[0].([]*data.InventoryItem)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockInventoryInterfaceMockRecorder) GetPage(ctx, userID, siteID, offset, limit any) *MockInventoryInterfaceGetPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockInventoryInterface)(nil).GetPage), ctx, userID, siteID, offset, limit)
+	return &MockInventoryInterfaceGetPageCall{Call: call}
+}
+
+// MockInventoryInterfaceGetPageCall wrap *gomock.Call
+type MockInventoryInterfaceGetPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInventoryInterfaceGetPageCall) Return(arg0 []*data.InventoryItem, arg1 int64, arg2 error) *MockInventoryInterfaceGetPageCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInventoryInterfaceGetPageCall) Do(f func(context.Context, uint, *uint, int, int) ([]*data.InventoryItem, int64, error)) *MockInventoryInterfaceGetPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInventoryInterfaceGetPageCall) DoAndReturn(f func(context.Context, uint, *uint, int, int) ([]*data.InventoryItem, int64, error)) *MockInventoryInterfaceGetPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetUsageSince mocks base method.
+func (m *MockInventoryInterface) GetUsageSince(ctx context.Context, id, userID uint, since time.Time) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsageSince", ctx, id, userID, since)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsageSince indicates an expected call of GetUsageSince.
+func (mr *MockInventoryInterfaceMockRecorder) GetUsageSince(ctx, id, userID, since any) *MockInventoryInterfaceGetUsageSinceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsageSince", reflect.TypeOf((*MockInventoryInterface)(nil).GetUsageSince), ctx, id, userID, since)
+	return &MockInventoryInterfaceGetUsageSinceCall{Call: call}
+}
+
+// MockInventoryInterfaceGetUsageSinceCall wrap *gomock.Call
+type MockInventoryInterfaceGetUsageSinceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInventoryInterfaceGetUsageSinceCall) Return(arg0 float64, arg1 error) *MockInventoryInterfaceGetUsageSinceCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInventoryInterfaceGetUsageSinceCall) Do(f func(context.Context, uint, uint, time.Time) (float64, error)) *MockInventoryInterfaceGetUsageSinceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInventoryInterfaceGetUsageSinceCall) DoAndReturn(f func(context.Context, uint, uint, time.Time) (float64, error)) *MockInventoryInterfaceGetUsageSinceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockInventoryInterface) Insert(ctx context.Context, item *data.InventoryItem) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, item)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockInventoryInterfaceMockRecorder) Insert(ctx, item any) *MockInventoryInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockInventoryInterface)(nil).Insert), ctx, item)
+	return &MockInventoryInterfaceInsertCall{Call: call}
+}
+
+// MockInventoryInterfaceInsertCall wrap *gomock.Call
+type MockInventoryInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInventoryInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockInventoryInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInventoryInterfaceInsertCall) Do(f func(context.Context, *data.InventoryItem) (uint, error)) *MockInventoryInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInventoryInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.InventoryItem) (uint, error)) *MockInventoryInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Purge mocks base method.
+func (m *MockInventoryInterface) Purge(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockInventoryInterfaceMockRecorder) Purge(ctx, id, userID any) *MockInventoryInterfacePurgeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockInventoryInterface)(nil).Purge), ctx, id, userID)
+	return &MockInventoryInterfacePurgeCall{Call: call}
+}
+
+// MockInventoryInterfacePurgeCall wrap *gomock.Call
+type MockInventoryInterfacePurgeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInventoryInterfacePurgeCall) Return(arg0 error) *MockInventoryInterfacePurgeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInventoryInterfacePurgeCall) Do(f func(context.Context, uint, uint) error) *MockInventoryInterfacePurgeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInventoryInterfacePurgeCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockInventoryInterfacePurgeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RecordUsage mocks base method.
+func (m *MockInventoryInterface) RecordUsage(ctx context.Context, id, userID uint, quantity float64, reason *string) (*data.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordUsage", ctx, id, userID, quantity, reason)
+	ret0, _ := ret[0].(*data.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordUsage indicates an expected call of RecordUsage.
+func (mr *MockInventoryInterfaceMockRecorder) RecordUsage(ctx, id, userID, quantity, reason any) *MockInventoryInterfaceRecordUsageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordUsage", reflect.TypeOf((*MockInventoryInterface)(nil).RecordUsage), ctx, id, userID, quantity, reason)
+	return &MockInventoryInterfaceRecordUsageCall{Call: call}
+}
+
+// MockInventoryInterfaceRecordUsageCall wrap *gomock.Call
+type MockInventoryInterfaceRecordUsageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInventoryInterfaceRecordUsageCall) Return(arg0 *data.StockMovement, arg1 error) *MockInventoryInterfaceRecordUsageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInventoryInterfaceRecordUsageCall) Do(f func(context.Context, uint, uint, float64, *string) (*data.StockMovement, error)) *MockInventoryInterfaceRecordUsageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInventoryInterfaceRecordUsageCall) DoAndReturn(f func(context.Context, uint, uint, float64, *string) (*data.StockMovement, error)) *MockInventoryInterfaceRecordUsageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Restore mocks base method.
+func (m *MockInventoryInterface) Restore(ctx context.Context, id, userID uint) (*data.InventoryItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, userID)
+	ret0, _ := ret[0].(*data.InventoryItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockInventoryInterfaceMockRecorder) Restore(ctx, id, userID any) *MockInventoryInterfaceRestoreCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockInventoryInterface)(nil).Restore), ctx, id, userID)
+	return &MockInventoryInterfaceRestoreCall{Call: call}
+}
+
+// MockInventoryInterfaceRestoreCall wrap *gomock.Call
+type MockInventoryInterfaceRestoreCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInventoryInterfaceRestoreCall) Return(arg0 *data.InventoryItem, arg1 error) *MockInventoryInterfaceRestoreCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInventoryInterfaceRestoreCall) Do(f func(context.Context, uint, uint) (*data.InventoryItem, error)) *MockInventoryInterfaceRestoreCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInventoryInterfaceRestoreCall) DoAndReturn(f func(context.Context, uint, uint) (*data.InventoryItem, error)) *MockInventoryInterfaceRestoreCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SetPrimaryImage mocks base method.
+func (m *MockInventoryInterface) SetPrimaryImage(ctx context.Context, id, userID uint, imageID *uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrimaryImage", ctx, id, userID, imageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPrimaryImage indicates an expected call of SetPrimaryImage.
+func (mr *MockInventoryInterfaceMockRecorder) SetPrimaryImage(ctx, id, userID, imageID any) *MockInventoryInterfaceSetPrimaryImageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrimaryImage", reflect.TypeOf((*MockInventoryInterface)(nil).SetPrimaryImage), ctx, id, userID, imageID)
+	return &MockInventoryInterfaceSetPrimaryImageCall{Call: call}
+}
+
+// MockInventoryInterfaceSetPrimaryImageCall wrap *gomock.Call
+type MockInventoryInterfaceSetPrimaryImageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInventoryInterfaceSetPrimaryImageCall) Return(arg0 error) *MockInventoryInterfaceSetPrimaryImageCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInventoryInterfaceSetPrimaryImageCall) Do(f func(context.Context, uint, uint, *uint) error) *MockInventoryInterfaceSetPrimaryImageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInventoryInterfaceSetPrimaryImageCall) DoAndReturn(f func(context.Context, uint, uint, *uint) error) *MockInventoryInterfaceSetPrimaryImageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockInventoryInterface) Update(ctx context.Context, item *data.InventoryItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockInventoryInterfaceMockRecorder) Update(ctx, item any) *MockInventoryInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockInventoryInterface)(nil).Update), ctx, item)
+	return &MockInventoryInterfaceUpdateCall{Call: call}
+}
+
+// MockInventoryInterfaceUpdateCall wrap *gomock.Call
+type MockInventoryInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInventoryInterfaceUpdateCall) Return(arg0 error) *MockInventoryInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInventoryInterfaceUpdateCall) Do(f func(context.Context, *data.InventoryItem) error) *MockInventoryInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInventoryInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.InventoryItem) error) *MockInventoryInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateQuantity mocks base method.
+func (m *MockInventoryInterface) UpdateQuantity(ctx context.Context, id, userID uint, quantity float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQuantity", ctx, id, userID, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateQuantity indicates an expected call of UpdateQuantity.
+func (mr *MockInventoryInterfaceMockRecorder) UpdateQuantity(ctx, id, userID, quantity any) *MockInventoryInterfaceUpdateQuantityCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuantity", reflect.TypeOf((*MockInventoryInterface)(nil).UpdateQuantity), ctx, id, userID, quantity)
+	return &MockInventoryInterfaceUpdateQuantityCall{Call: call}
+}
+
+// MockInventoryInterfaceUpdateQuantityCall wrap *gomock.Call
+type MockInventoryInterfaceUpdateQuantityCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInventoryInterfaceUpdateQuantityCall) Return(arg0 error) *MockInventoryInterfaceUpdateQuantityCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInventoryInterfaceUpdateQuantityCall) Do(f func(context.Context, uint, uint, float64) error) *MockInventoryInterfaceUpdateQuantityCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInventoryInterfaceUpdateQuantityCall) DoAndReturn(f func(context.Context, uint, uint, float64) error) *MockInventoryInterfaceUpdateQuantityCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// WriteOff mocks base method.
+func (m *MockInventoryInterface) WriteOff(ctx context.Context, id, userID uint, quantity float64, reason string) (*data.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteOff", ctx, id, userID, quantity, reason)
+	ret0, _ := ret[0].(*data.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WriteOff indicates an expected call of WriteOff.
+func (mr *MockInventoryInterfaceMockRecorder) WriteOff(ctx, id, userID, quantity, reason any) *MockInventoryInterfaceWriteOffCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteOff", reflect.TypeOf((*MockInventoryInterface)(nil).WriteOff), ctx, id, userID, quantity, reason)
+	return &MockInventoryInterfaceWriteOffCall{Call: call}
+}
+
+// MockInventoryInterfaceWriteOffCall wrap *gomock.Call
+type MockInventoryInterfaceWriteOffCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInventoryInterfaceWriteOffCall) Return(arg0 *data.StockMovement, arg1 error) *MockInventoryInterfaceWriteOffCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInventoryInterfaceWriteOffCall) Do(f func(context.Context, uint, uint, float64, string) (*data.StockMovement, error)) *MockInventoryInterfaceWriteOffCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInventoryInterfaceWriteOffCall) DoAndReturn(f func(context.Context, uint, uint, float64, string) (*data.StockMovement, error)) *MockInventoryInterfaceWriteOffCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockStocktakeInterface is a mock of StocktakeInterface interface.
+type MockStocktakeInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockStocktakeInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockStocktakeInterfaceMockRecorder is the mock recorder for MockStocktakeInterface.
+type MockStocktakeInterfaceMockRecorder struct {
+	mock *MockStocktakeInterface
+}
+
+// NewMockStocktakeInterface creates a new mock instance.
+func NewMockStocktakeInterface(ctrl *gomock.Controller) *MockStocktakeInterface {
+	mock := &MockStocktakeInterface{ctrl: ctrl}
+	mock.recorder = &MockStocktakeInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStocktakeInterface) EXPECT() *MockStocktakeInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockStocktakeInterface) Approve(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockStocktakeInterfaceMockRecorder) Approve(ctx, id, userID any) *MockStocktakeInterfaceApproveCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockStocktakeInterface)(nil).Approve), ctx, id, userID)
+	return &MockStocktakeInterfaceApproveCall{Call: call}
+}
+
+// MockStocktakeInterfaceApproveCall wrap *gomock.Call
+type MockStocktakeInterfaceApproveCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStocktakeInterfaceApproveCall) Return(arg0 error) *MockStocktakeInterfaceApproveCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStocktakeInterfaceApproveCall) Do(f func(context.Context, uint, uint) error) *MockStocktakeInterfaceApproveCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStocktakeInterfaceApproveCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockStocktakeInterfaceApproveCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Cancel mocks base method.
+func (m *MockStocktakeInterface) Cancel(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockStocktakeInterfaceMockRecorder) Cancel(ctx, id, userID any) *MockStocktakeInterfaceCancelCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockStocktakeInterface)(nil).Cancel), ctx, id, userID)
+	return &MockStocktakeInterfaceCancelCall{Call: call}
+}
+
+// MockStocktakeInterfaceCancelCall wrap *gomock.Call
+type MockStocktakeInterfaceCancelCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStocktakeInterfaceCancelCall) Return(arg0 error) *MockStocktakeInterfaceCancelCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStocktakeInterfaceCancelCall) Do(f func(context.Context, uint, uint) error) *MockStocktakeInterfaceCancelCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStocktakeInterfaceCancelCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockStocktakeInterfaceCancelCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Create mocks base method.
+func (m *MockStocktakeInterface) Create(ctx context.Context, stocktake *data.Stocktake, itemIDs []uint) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, stocktake, itemIDs)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStocktakeInterfaceMockRecorder) Create(ctx, stocktake, itemIDs any) *MockStocktakeInterfaceCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStocktakeInterface)(nil).Create), ctx, stocktake, itemIDs)
+	return &MockStocktakeInterfaceCreateCall{Call: call}
+}
+
+// MockStocktakeInterfaceCreateCall wrap *gomock.Call
+type MockStocktakeInterfaceCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStocktakeInterfaceCreateCall) Return(arg0 uint, arg1 error) *MockStocktakeInterfaceCreateCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStocktakeInterfaceCreateCall) Do(f func(context.Context, *data.Stocktake, []uint) (uint, error)) *MockStocktakeInterfaceCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStocktakeInterfaceCreateCall) DoAndReturn(f func(context.Context, *data.Stocktake, []uint) (uint, error)) *MockStocktakeInterfaceCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockStocktakeInterface) GetAll(ctx context.Context, userID uint) ([]*data.Stocktake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
+	ret0, _ := ret[0].([]*data.Stocktake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockStocktakeInterfaceMockRecorder) GetAll(ctx, userID any) *MockStocktakeInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockStocktakeInterface)(nil).GetAll), ctx, userID)
+	return &MockStocktakeInterfaceGetAllCall{Call: call}
+}
+
+// MockStocktakeInterfaceGetAllCall wrap *gomock.Call
+type MockStocktakeInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStocktakeInterfaceGetAllCall) Return(arg0 []*data.Stocktake, arg1 error) *MockStocktakeInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStocktakeInterfaceGetAllCall) Do(f func(context.Context, uint) ([]*data.Stocktake, error)) *MockStocktakeInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStocktakeInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint) ([]*data.Stocktake, error)) *MockStocktakeInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockStocktakeInterface) GetOne(ctx context.Context, id, userID uint) (*data.Stocktake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.Stocktake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockStocktakeInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockStocktakeInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockStocktakeInterface)(nil).GetOne), ctx, id, userID)
+	return &MockStocktakeInterfaceGetOneCall{Call: call}
+}
+
+// MockStocktakeInterfaceGetOneCall wrap *gomock.Call
+type MockStocktakeInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStocktakeInterfaceGetOneCall) Return(arg0 *data.Stocktake, arg1 error) *MockStocktakeInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStocktakeInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.Stocktake, error)) *MockStocktakeInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStocktakeInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Stocktake, error)) *MockStocktakeInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetVarianceReport mocks base method.
+func (m *MockStocktakeInterface) GetVarianceReport(ctx context.Context, id, userID uint) (*data.VarianceReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVarianceReport", ctx, id, userID)
+	ret0, _ := ret[0].(*data.VarianceReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVarianceReport indicates an expected call of GetVarianceReport.
+func (mr *MockStocktakeInterfaceMockRecorder) GetVarianceReport(ctx, id, userID any) *MockStocktakeInterfaceGetVarianceReportCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVarianceReport", reflect.TypeOf((*MockStocktakeInterface)(nil).GetVarianceReport), ctx, id, userID)
+	return &MockStocktakeInterfaceGetVarianceReportCall{Call: call}
+}
+
+// MockStocktakeInterfaceGetVarianceReportCall wrap *gomock.Call
+type MockStocktakeInterfaceGetVarianceReportCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStocktakeInterfaceGetVarianceReportCall) Return(arg0 *data.VarianceReport, arg1 error) *MockStocktakeInterfaceGetVarianceReportCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStocktakeInterfaceGetVarianceReportCall) Do(f func(context.Context, uint, uint) (*data.VarianceReport, error)) *MockStocktakeInterfaceGetVarianceReportCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStocktakeInterfaceGetVarianceReportCall) DoAndReturn(f func(context.Context, uint, uint) (*data.VarianceReport, error)) *MockStocktakeInterfaceGetVarianceReportCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RecordCounts mocks base method.
+func (m *MockStocktakeInterface) RecordCounts(ctx context.Context, id, userID uint, counts map[uint]float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordCounts", ctx, id, userID, counts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordCounts indicates an expected call of RecordCounts.
+func (mr *MockStocktakeInterfaceMockRecorder) RecordCounts(ctx, id, userID, counts any) *MockStocktakeInterfaceRecordCountsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordCounts", reflect.TypeOf((*MockStocktakeInterface)(nil).RecordCounts), ctx, id, userID, counts)
+	return &MockStocktakeInterfaceRecordCountsCall{Call: call}
+}
+
+// MockStocktakeInterfaceRecordCountsCall wrap *gomock.Call
+type MockStocktakeInterfaceRecordCountsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStocktakeInterfaceRecordCountsCall) Return(arg0 error) *MockStocktakeInterfaceRecordCountsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStocktakeInterfaceRecordCountsCall) Do(f func(context.Context, uint, uint, map[uint]float64) error) *MockStocktakeInterfaceRecordCountsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStocktakeInterfaceRecordCountsCall) DoAndReturn(f func(context.Context, uint, uint, map[uint]float64) error) *MockStocktakeInterfaceRecordCountsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockNotificationInterface is a mock of NotificationInterface interface.
+type MockNotificationInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationInterfaceMockRecorder is the mock recorder for MockNotificationInterface.
+type MockNotificationInterfaceMockRecorder struct {
+	mock *MockNotificationInterface
+}
+
+// NewMockNotificationInterface creates a new mock instance.
+func NewMockNotificationInterface(ctrl *gomock.Controller) *MockNotificationInterface {
+	mock := &MockNotificationInterface{ctrl: ctrl}
+	mock.recorder = &MockNotificationInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationInterface) EXPECT() *MockNotificationInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockNotificationInterface) GetAll(ctx context.Context, userID uint, unreadOnly bool) ([]*data.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID, unreadOnly)
+	ret0, _ := ret[0].([]*data.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockNotificationInterfaceMockRecorder) GetAll(ctx, userID, unreadOnly any) *MockNotificationInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockNotificationInterface)(nil).GetAll), ctx, userID, unreadOnly)
+	return &MockNotificationInterfaceGetAllCall{Call: call}
+}
+
+// MockNotificationInterfaceGetAllCall wrap *gomock.Call
+type MockNotificationInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockNotificationInterfaceGetAllCall) Return(arg0 []*data.Notification, arg1 error) *MockNotificationInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockNotificationInterfaceGetAllCall) Do(f func(context.Context, uint, bool) ([]*data.Notification, error)) *MockNotificationInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockNotificationInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint, bool) ([]*data.Notification, error)) *MockNotificationInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockNotificationInterface) Insert(ctx context.Context, notification *data.Notification) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, notification)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockNotificationInterfaceMockRecorder) Insert(ctx, notification any) *MockNotificationInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockNotificationInterface)(nil).Insert), ctx, notification)
+	return &MockNotificationInterfaceInsertCall{Call: call}
+}
+
+// MockNotificationInterfaceInsertCall wrap *gomock.Call
+type MockNotificationInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockNotificationInterfaceInsertCall) Return(arg0 bool, arg1 error) *MockNotificationInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockNotificationInterfaceInsertCall) Do(f func(context.Context, *data.Notification) (bool, error)) *MockNotificationInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockNotificationInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.Notification) (bool, error)) *MockNotificationInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MarkAllRead mocks base method.
+func (m *MockNotificationInterface) MarkAllRead(ctx context.Context, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockNotificationInterfaceMockRecorder) MarkAllRead(ctx, userID any) *MockNotificationInterfaceMarkAllReadCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockNotificationInterface)(nil).MarkAllRead), ctx, userID)
+	return &MockNotificationInterfaceMarkAllReadCall{Call: call}
+}
+
+// MockNotificationInterfaceMarkAllReadCall wrap *gomock.Call
+type MockNotificationInterfaceMarkAllReadCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockNotificationInterfaceMarkAllReadCall) Return(arg0 error) *MockNotificationInterfaceMarkAllReadCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockNotificationInterfaceMarkAllReadCall) Do(f func(context.Context, uint) error) *MockNotificationInterfaceMarkAllReadCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockNotificationInterfaceMarkAllReadCall) DoAndReturn(f func(context.Context, uint) error) *MockNotificationInterfaceMarkAllReadCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MarkRead mocks base method.
+func (m *MockNotificationInterface) MarkRead(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationInterfaceMockRecorder) MarkRead(ctx, id, userID any) *MockNotificationInterfaceMarkReadCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotificationInterface)(nil).MarkRead), ctx, id, userID)
+	return &MockNotificationInterfaceMarkReadCall{Call: call}
+}
+
+// MockNotificationInterfaceMarkReadCall wrap *gomock.Call
+type MockNotificationInterfaceMarkReadCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockNotificationInterfaceMarkReadCall) Return(arg0 error) *MockNotificationInterfaceMarkReadCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockNotificationInterfaceMarkReadCall) Do(f func(context.Context, uint, uint) error) *MockNotificationInterfaceMarkReadCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockNotificationInterfaceMarkReadCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockNotificationInterfaceMarkReadCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockEquipmentInterface is a mock of EquipmentInterface interface.
+type MockEquipmentInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockEquipmentInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockEquipmentInterfaceMockRecorder is the mock recorder for MockEquipmentInterface.
+type MockEquipmentInterfaceMockRecorder struct {
+	mock *MockEquipmentInterface
+}
+
+// NewMockEquipmentInterface creates a new mock instance.
+func NewMockEquipmentInterface(ctrl *gomock.Controller) *MockEquipmentInterface {
+	mock := &MockEquipmentInterface{ctrl: ctrl}
+	mock.recorder = &MockEquipmentInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEquipmentInterface) EXPECT() *MockEquipmentInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockEquipmentInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockEquipmentInterfaceMockRecorder) Delete(ctx, id, userID any) *MockEquipmentInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockEquipmentInterface)(nil).Delete), ctx, id, userID)
+	return &MockEquipmentInterfaceDeleteCall{Call: call}
+}
+
+// MockEquipmentInterfaceDeleteCall wrap *gomock.Call
+type MockEquipmentInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEquipmentInterfaceDeleteCall) Return(arg0 error) *MockEquipmentInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEquipmentInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockEquipmentInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEquipmentInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockEquipmentInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Dispose mocks base method.
+func (m *MockEquipmentInterface) Dispose(ctx context.Context, id, userID uint, disposal *data.EquipmentDisposal) (*data.Equipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dispose", ctx, id, userID, disposal)
+	ret0, _ := ret[0].(*data.Equipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dispose indicates an expected call of Dispose.
+func (mr *MockEquipmentInterfaceMockRecorder) Dispose(ctx, id, userID, disposal any) *MockEquipmentInterfaceDisposeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dispose", reflect.TypeOf((*MockEquipmentInterface)(nil).Dispose), ctx, id, userID, disposal)
+	return &MockEquipmentInterfaceDisposeCall{Call: call}
+}
+
+// MockEquipmentInterfaceDisposeCall wrap *gomock.Call
+type MockEquipmentInterfaceDisposeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEquipmentInterfaceDisposeCall) Return(arg0 *data.Equipment, arg1 error) *MockEquipmentInterfaceDisposeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEquipmentInterfaceDisposeCall) Do(f func(context.Context, uint, uint, *data.EquipmentDisposal) (*data.Equipment, error)) *MockEquipmentInterfaceDisposeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEquipmentInterfaceDisposeCall) DoAndReturn(f func(context.Context, uint, uint, *data.EquipmentDisposal) (*data.Equipment, error)) *MockEquipmentInterfaceDisposeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockEquipmentInterface) GetAll(ctx context.Context, userID uint) ([]*data.Equipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
+	ret0, _ := ret[0].([]*data.Equipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockEquipmentInterfaceMockRecorder) GetAll(ctx, userID any) *MockEquipmentInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockEquipmentInterface)(nil).GetAll), ctx, userID)
+	return &MockEquipmentInterfaceGetAllCall{Call: call}
+}
+
+// MockEquipmentInterfaceGetAllCall wrap *gomock.Call
+type MockEquipmentInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEquipmentInterfaceGetAllCall) Return(arg0 []*data.Equipment, arg1 error) *MockEquipmentInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEquipmentInterfaceGetAllCall) Do(f func(context.Context, uint) ([]*data.Equipment, error)) *MockEquipmentInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEquipmentInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint) ([]*data.Equipment, error)) *MockEquipmentInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetDepreciation mocks base method.
+func (m *MockEquipmentInterface) GetDepreciation(ctx context.Context, id, userID uint) ([]*data.DepreciationEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepreciation", ctx, id, userID)
+	ret0, _ := ret[0].([]*data.DepreciationEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepreciation indicates an expected call of GetDepreciation.
+func (mr *MockEquipmentInterfaceMockRecorder) GetDepreciation(ctx, id, userID any) *MockEquipmentInterfaceGetDepreciationCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepreciation", reflect.TypeOf((*MockEquipmentInterface)(nil).GetDepreciation), ctx, id, userID)
+	return &MockEquipmentInterfaceGetDepreciationCall{Call: call}
+}
+
+// MockEquipmentInterfaceGetDepreciationCall wrap *gomock.Call
+type MockEquipmentInterfaceGetDepreciationCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEquipmentInterfaceGetDepreciationCall) Return(arg0 []*data.DepreciationEntry, arg1 error) *MockEquipmentInterfaceGetDepreciationCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEquipmentInterfaceGetDepreciationCall) Do(f func(context.Context, uint, uint) ([]*data.DepreciationEntry, error)) *MockEquipmentInterfaceGetDepreciationCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEquipmentInterfaceGetDepreciationCall) DoAndReturn(f func(context.Context, uint, uint) ([]*data.DepreciationEntry, error)) *MockEquipmentInterfaceGetDepreciationCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockEquipmentInterface) GetOne(ctx context.Context, id, userID uint) (*data.Equipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.Equipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockEquipmentInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockEquipmentInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockEquipmentInterface)(nil).GetOne), ctx, id, userID)
+	return &MockEquipmentInterfaceGetOneCall{Call: call}
+}
+
+// MockEquipmentInterfaceGetOneCall wrap *gomock.Call
+type MockEquipmentInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEquipmentInterfaceGetOneCall) Return(arg0 *data.Equipment, arg1 error) *MockEquipmentInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEquipmentInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.Equipment, error)) *MockEquipmentInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEquipmentInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Equipment, error)) *MockEquipmentInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetRegister mocks base method.
+func (m *MockEquipmentInterface) GetRegister(ctx context.Context, userID uint, asOf time.Time) (*data.AssetRegister, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRegister", ctx, userID, asOf)
+	ret0, _ := ret[0].(*data.AssetRegister)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRegister indicates an expected call of GetRegister.
+func (mr *MockEquipmentInterfaceMockRecorder) GetRegister(ctx, userID, asOf any) *MockEquipmentInterfaceGetRegisterCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegister", reflect.TypeOf((*MockEquipmentInterface)(nil).GetRegister), ctx, userID, asOf)
+	return &MockEquipmentInterfaceGetRegisterCall{Call: call}
+}
+
+// MockEquipmentInterfaceGetRegisterCall wrap *gomock.Call
+type MockEquipmentInterfaceGetRegisterCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEquipmentInterfaceGetRegisterCall) Return(arg0 *data.AssetRegister, arg1 error) *MockEquipmentInterfaceGetRegisterCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEquipmentInterfaceGetRegisterCall) Do(f func(context.Context, uint, time.Time) (*data.AssetRegister, error)) *MockEquipmentInterfaceGetRegisterCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEquipmentInterfaceGetRegisterCall) DoAndReturn(f func(context.Context, uint, time.Time) (*data.AssetRegister, error)) *MockEquipmentInterfaceGetRegisterCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockEquipmentInterface) Insert(ctx context.Context, equipment *data.Equipment) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, equipment)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockEquipmentInterfaceMockRecorder) Insert(ctx, equipment any) *MockEquipmentInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockEquipmentInterface)(nil).Insert), ctx, equipment)
+	return &MockEquipmentInterfaceInsertCall{Call: call}
+}
+
+// MockEquipmentInterfaceInsertCall wrap *gomock.Call
+type MockEquipmentInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEquipmentInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockEquipmentInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEquipmentInterfaceInsertCall) Do(f func(context.Context, *data.Equipment) (uint, error)) *MockEquipmentInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEquipmentInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.Equipment) (uint, error)) *MockEquipmentInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockEquipmentInterface) Update(ctx context.Context, equipment *data.Equipment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, equipment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockEquipmentInterfaceMockRecorder) Update(ctx, equipment any) *MockEquipmentInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEquipmentInterface)(nil).Update), ctx, equipment)
+	return &MockEquipmentInterfaceUpdateCall{Call: call}
+}
+
+// MockEquipmentInterfaceUpdateCall wrap *gomock.Call
+type MockEquipmentInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEquipmentInterfaceUpdateCall) Return(arg0 error) *MockEquipmentInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEquipmentInterfaceUpdateCall) Do(f func(context.Context, *data.Equipment) error) *MockEquipmentInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEquipmentInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.Equipment) error) *MockEquipmentInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockCostAllocationInterface is a mock of CostAllocationInterface interface.
+type MockCostAllocationInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCostAllocationInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCostAllocationInterfaceMockRecorder is the mock recorder for MockCostAllocationInterface.
+type MockCostAllocationInterfaceMockRecorder struct {
+	mock *MockCostAllocationInterface
+}
+
+// NewMockCostAllocationInterface creates a new mock instance.
+func NewMockCostAllocationInterface(ctrl *gomock.Controller) *MockCostAllocationInterface {
+	mock := &MockCostAllocationInterface{ctrl: ctrl}
+	mock.recorder = &MockCostAllocationInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCostAllocationInterface) EXPECT() *MockCostAllocationInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockCostAllocationInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCostAllocationInterfaceMockRecorder) Delete(ctx, id, userID any) *MockCostAllocationInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCostAllocationInterface)(nil).Delete), ctx, id, userID)
+	return &MockCostAllocationInterfaceDeleteCall{Call: call}
+}
+
+// MockCostAllocationInterfaceDeleteCall wrap *gomock.Call
+type MockCostAllocationInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCostAllocationInterfaceDeleteCall) Return(arg0 error) *MockCostAllocationInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCostAllocationInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockCostAllocationInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCostAllocationInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockCostAllocationInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockCostAllocationInterface) GetAll(ctx context.Context, userID uint) ([]*data.CostAllocationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
+	ret0, _ := ret[0].([]*data.CostAllocationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCostAllocationInterfaceMockRecorder) GetAll(ctx, userID any) *MockCostAllocationInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCostAllocationInterface)(nil).GetAll), ctx, userID)
+	return &MockCostAllocationInterfaceGetAllCall{Call: call}
+}
+
+// MockCostAllocationInterfaceGetAllCall wrap *gomock.Call
+type MockCostAllocationInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCostAllocationInterfaceGetAllCall) Return(arg0 []*data.CostAllocationRule, arg1 error) *MockCostAllocationInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCostAllocationInterfaceGetAllCall) Do(f func(context.Context, uint) ([]*data.CostAllocationRule, error)) *MockCostAllocationInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCostAllocationInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint) ([]*data.CostAllocationRule, error)) *MockCostAllocationInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockCostAllocationInterface) GetOne(ctx context.Context, id, userID uint) (*data.CostAllocationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.CostAllocationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockCostAllocationInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockCostAllocationInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockCostAllocationInterface)(nil).GetOne), ctx, id, userID)
+	return &MockCostAllocationInterfaceGetOneCall{Call: call}
+}
+
+// MockCostAllocationInterfaceGetOneCall wrap *gomock.Call
+type MockCostAllocationInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCostAllocationInterfaceGetOneCall) Return(arg0 *data.CostAllocationRule, arg1 error) *MockCostAllocationInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCostAllocationInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.CostAllocationRule, error)) *MockCostAllocationInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCostAllocationInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.CostAllocationRule, error)) *MockCostAllocationInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetSiteExpenses mocks base method.
+func (m *MockCostAllocationInterface) GetSiteExpenses(ctx context.Context, userID uint, start, end time.Time) ([]*data.SiteExpense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteExpenses", ctx, userID, start, end)
+	ret0, _ := ret[0].([]*data.SiteExpense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteExpenses indicates an expected call of GetSiteExpenses.
+func (mr *MockCostAllocationInterfaceMockRecorder) GetSiteExpenses(ctx, userID, start, end any) *MockCostAllocationInterfaceGetSiteExpensesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteExpenses", reflect.TypeOf((*MockCostAllocationInterface)(nil).GetSiteExpenses), ctx, userID, start, end)
+	return &MockCostAllocationInterfaceGetSiteExpensesCall{Call: call}
+}
+
+// MockCostAllocationInterfaceGetSiteExpensesCall wrap *gomock.Call
+type MockCostAllocationInterfaceGetSiteExpensesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCostAllocationInterfaceGetSiteExpensesCall) Return(arg0 []*data.SiteExpense, arg1 error) *MockCostAllocationInterfaceGetSiteExpensesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCostAllocationInterfaceGetSiteExpensesCall) Do(f func(context.Context, uint, time.Time, time.Time) ([]*data.SiteExpense, error)) *MockCostAllocationInterfaceGetSiteExpensesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCostAllocationInterfaceGetSiteExpensesCall) DoAndReturn(f func(context.Context, uint, time.Time, time.Time) ([]*data.SiteExpense, error)) *MockCostAllocationInterfaceGetSiteExpensesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetSiteIncome mocks base method.
+func (m *MockCostAllocationInterface) GetSiteIncome(ctx context.Context, userID uint, start, end time.Time) ([]*data.SiteIncome, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteIncome", ctx, userID, start, end)
+	ret0, _ := ret[0].([]*data.SiteIncome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteIncome indicates an expected call of GetSiteIncome.
+func (mr *MockCostAllocationInterfaceMockRecorder) GetSiteIncome(ctx, userID, start, end any) *MockCostAllocationInterfaceGetSiteIncomeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteIncome", reflect.TypeOf((*MockCostAllocationInterface)(nil).GetSiteIncome), ctx, userID, start, end)
+	return &MockCostAllocationInterfaceGetSiteIncomeCall{Call: call}
+}
+
+// MockCostAllocationInterfaceGetSiteIncomeCall wrap *gomock.Call
+type MockCostAllocationInterfaceGetSiteIncomeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCostAllocationInterfaceGetSiteIncomeCall) Return(arg0 []*data.SiteIncome, arg1 error) *MockCostAllocationInterfaceGetSiteIncomeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCostAllocationInterfaceGetSiteIncomeCall) Do(f func(context.Context, uint, time.Time, time.Time) ([]*data.SiteIncome, error)) *MockCostAllocationInterfaceGetSiteIncomeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCostAllocationInterfaceGetSiteIncomeCall) DoAndReturn(f func(context.Context, uint, time.Time, time.Time) ([]*data.SiteIncome, error)) *MockCostAllocationInterfaceGetSiteIncomeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockCostAllocationInterface) Insert(ctx context.Context, rule *data.CostAllocationRule) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, rule)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockCostAllocationInterfaceMockRecorder) Insert(ctx, rule any) *MockCostAllocationInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockCostAllocationInterface)(nil).Insert), ctx, rule)
+	return &MockCostAllocationInterfaceInsertCall{Call: call}
+}
+
+// MockCostAllocationInterfaceInsertCall wrap *gomock.Call
+type MockCostAllocationInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCostAllocationInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockCostAllocationInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCostAllocationInterfaceInsertCall) Do(f func(context.Context, *data.CostAllocationRule) (uint, error)) *MockCostAllocationInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCostAllocationInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.CostAllocationRule) (uint, error)) *MockCostAllocationInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockCostAllocationInterface) Update(ctx context.Context, rule *data.CostAllocationRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCostAllocationInterfaceMockRecorder) Update(ctx, rule any) *MockCostAllocationInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCostAllocationInterface)(nil).Update), ctx, rule)
+	return &MockCostAllocationInterfaceUpdateCall{Call: call}
+}
+
+// MockCostAllocationInterfaceUpdateCall wrap *gomock.Call
+type MockCostAllocationInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCostAllocationInterfaceUpdateCall) Return(arg0 error) *MockCostAllocationInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCostAllocationInterfaceUpdateCall) Do(f func(context.Context, *data.CostAllocationRule) error) *MockCostAllocationInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCostAllocationInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.CostAllocationRule) error) *MockCostAllocationInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockSupplyPriceInterface is a mock of SupplyPriceInterface interface.
+type MockSupplyPriceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSupplyPriceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSupplyPriceInterfaceMockRecorder is the mock recorder for MockSupplyPriceInterface.
+type MockSupplyPriceInterfaceMockRecorder struct {
+	mock *MockSupplyPriceInterface
+}
+
+// NewMockSupplyPriceInterface creates a new mock instance.
+func NewMockSupplyPriceInterface(ctrl *gomock.Controller) *MockSupplyPriceInterface {
+	mock := &MockSupplyPriceInterface{ctrl: ctrl}
+	mock.recorder = &MockSupplyPriceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSupplyPriceInterface) EXPECT() *MockSupplyPriceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSupplyPriceInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSupplyPriceInterfaceMockRecorder) Delete(ctx, id, userID any) *MockSupplyPriceInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSupplyPriceInterface)(nil).Delete), ctx, id, userID)
+	return &MockSupplyPriceInterfaceDeleteCall{Call: call}
+}
+
+// MockSupplyPriceInterfaceDeleteCall wrap *gomock.Call
+type MockSupplyPriceInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSupplyPriceInterfaceDeleteCall) Return(arg0 error) *MockSupplyPriceInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSupplyPriceInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockSupplyPriceInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSupplyPriceInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockSupplyPriceInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockSupplyPriceInterface) GetAll(ctx context.Context, userID uint) ([]*data.SupplyPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
+	ret0, _ := ret[0].([]*data.SupplyPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockSupplyPriceInterfaceMockRecorder) GetAll(ctx, userID any) *MockSupplyPriceInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockSupplyPriceInterface)(nil).GetAll), ctx, userID)
+	return &MockSupplyPriceInterfaceGetAllCall{Call: call}
+}
+
+// MockSupplyPriceInterfaceGetAllCall wrap *gomock.Call
+type MockSupplyPriceInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSupplyPriceInterfaceGetAllCall) Return(arg0 []*data.SupplyPrice, arg1 error) *MockSupplyPriceInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSupplyPriceInterfaceGetAllCall) Do(f func(context.Context, uint) ([]*data.SupplyPrice, error)) *MockSupplyPriceInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSupplyPriceInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint) ([]*data.SupplyPrice, error)) *MockSupplyPriceInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetFlaggedExpenses mocks base method.
+func (m *MockSupplyPriceInterface) GetFlaggedExpenses(ctx context.Context, userID uint) ([]*data.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlaggedExpenses", ctx, userID)
+	ret0, _ := ret[0].([]*data.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlaggedExpenses indicates an expected call of GetFlaggedExpenses.
+func (mr *MockSupplyPriceInterfaceMockRecorder) GetFlaggedExpenses(ctx, userID any) *MockSupplyPriceInterfaceGetFlaggedExpensesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlaggedExpenses", reflect.TypeOf((*MockSupplyPriceInterface)(nil).GetFlaggedExpenses), ctx, userID)
+	return &MockSupplyPriceInterfaceGetFlaggedExpensesCall{Call: call}
+}
+
+// MockSupplyPriceInterfaceGetFlaggedExpensesCall wrap *gomock.Call
+type MockSupplyPriceInterfaceGetFlaggedExpensesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSupplyPriceInterfaceGetFlaggedExpensesCall) Return(arg0 []*data.Expense, arg1 error) *MockSupplyPriceInterfaceGetFlaggedExpensesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSupplyPriceInterfaceGetFlaggedExpensesCall) Do(f func(context.Context, uint) ([]*data.Expense, error)) *MockSupplyPriceInterfaceGetFlaggedExpensesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSupplyPriceInterfaceGetFlaggedExpensesCall) DoAndReturn(f func(context.Context, uint) ([]*data.Expense, error)) *MockSupplyPriceInterfaceGetFlaggedExpensesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockSupplyPriceInterface) GetOne(ctx context.Context, id, userID uint) (*data.SupplyPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.SupplyPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockSupplyPriceInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockSupplyPriceInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockSupplyPriceInterface)(nil).GetOne), ctx, id, userID)
+	return &MockSupplyPriceInterfaceGetOneCall{Call: call}
+}
+
+// MockSupplyPriceInterfaceGetOneCall wrap *gomock.Call
+type MockSupplyPriceInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSupplyPriceInterfaceGetOneCall) Return(arg0 *data.SupplyPrice, arg1 error) *MockSupplyPriceInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSupplyPriceInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.SupplyPrice, error)) *MockSupplyPriceInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSupplyPriceInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.SupplyPrice, error)) *MockSupplyPriceInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockSupplyPriceInterface) Insert(ctx context.Context, price *data.SupplyPrice) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, price)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockSupplyPriceInterfaceMockRecorder) Insert(ctx, price any) *MockSupplyPriceInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockSupplyPriceInterface)(nil).Insert), ctx, price)
+	return &MockSupplyPriceInterfaceInsertCall{Call: call}
+}
+
+// MockSupplyPriceInterfaceInsertCall wrap *gomock.Call
+type MockSupplyPriceInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSupplyPriceInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockSupplyPriceInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSupplyPriceInterfaceInsertCall) Do(f func(context.Context, *data.SupplyPrice) (uint, error)) *MockSupplyPriceInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSupplyPriceInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.SupplyPrice) (uint, error)) *MockSupplyPriceInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockSupplyPriceInterface) Update(ctx context.Context, price *data.SupplyPrice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, price)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSupplyPriceInterfaceMockRecorder) Update(ctx, price any) *MockSupplyPriceInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSupplyPriceInterface)(nil).Update), ctx, price)
+	return &MockSupplyPriceInterfaceUpdateCall{Call: call}
+}
+
+// MockSupplyPriceInterfaceUpdateCall wrap *gomock.Call
+type MockSupplyPriceInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSupplyPriceInterfaceUpdateCall) Return(arg0 error) *MockSupplyPriceInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSupplyPriceInterfaceUpdateCall) Do(f func(context.Context, *data.SupplyPrice) error) *MockSupplyPriceInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSupplyPriceInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.SupplyPrice) error) *MockSupplyPriceInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockProcurementInterface is a mock of ProcurementInterface interface.
+type MockProcurementInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockProcurementInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockProcurementInterfaceMockRecorder is the mock recorder for MockProcurementInterface.
+type MockProcurementInterfaceMockRecorder struct {
+	mock *MockProcurementInterface
+}
+
+// NewMockProcurementInterface creates a new mock instance.
+func NewMockProcurementInterface(ctrl *gomock.Controller) *MockProcurementInterface {
+	mock := &MockProcurementInterface{ctrl: ctrl}
+	mock.recorder = &MockProcurementInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProcurementInterface) EXPECT() *MockProcurementInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CancelOrder mocks base method.
+func (m *MockProcurementInterface) CancelOrder(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockProcurementInterfaceMockRecorder) CancelOrder(ctx, id, userID any) *MockProcurementInterfaceCancelOrderCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockProcurementInterface)(nil).CancelOrder), ctx, id, userID)
+	return &MockProcurementInterfaceCancelOrderCall{Call: call}
+}
+
+// MockProcurementInterfaceCancelOrderCall wrap *gomock.Call
+type MockProcurementInterfaceCancelOrderCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockProcurementInterfaceCancelOrderCall) Return(arg0 error) *MockProcurementInterfaceCancelOrderCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockProcurementInterfaceCancelOrderCall) Do(f func(context.Context, uint, uint) error) *MockProcurementInterfaceCancelOrderCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockProcurementInterfaceCancelOrderCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockProcurementInterfaceCancelOrderCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteRequest mocks base method.
+func (m *MockProcurementInterface) DeleteRequest(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRequest", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRequest indicates an expected call of DeleteRequest.
+func (mr *MockProcurementInterfaceMockRecorder) DeleteRequest(ctx, id, userID any) *MockProcurementInterfaceDeleteRequestCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRequest", reflect.TypeOf((*MockProcurementInterface)(nil).DeleteRequest), ctx, id, userID)
+	return &MockProcurementInterfaceDeleteRequestCall{Call: call}
+}
+
+// MockProcurementInterfaceDeleteRequestCall wrap *gomock.Call
+type MockProcurementInterfaceDeleteRequestCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockProcurementInterfaceDeleteRequestCall) Return(arg0 error) *MockProcurementInterfaceDeleteRequestCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockProcurementInterfaceDeleteRequestCall) Do(f func(context.Context, uint, uint) error) *MockProcurementInterfaceDeleteRequestCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockProcurementInterfaceDeleteRequestCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockProcurementInterfaceDeleteRequestCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOrder mocks base method.
+func (m *MockProcurementInterface) GetOrder(ctx context.Context, id, userID uint) (*data.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", ctx, id, userID)
+	ret0, _ := ret[0].(*data.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockProcurementInterfaceMockRecorder) GetOrder(ctx, id, userID any) *MockProcurementInterfaceGetOrderCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockProcurementInterface)(nil).GetOrder), ctx, id, userID)
+	return &MockProcurementInterfaceGetOrderCall{Call: call}
+}
+
+// MockProcurementInterfaceGetOrderCall wrap *gomock.Call
+type MockProcurementInterfaceGetOrderCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockProcurementInterfaceGetOrderCall) Return(arg0 *data.PurchaseOrder, arg1 error) *MockProcurementInterfaceGetOrderCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockProcurementInterfaceGetOrderCall) Do(f func(context.Context, uint, uint) (*data.PurchaseOrder, error)) *MockProcurementInterfaceGetOrderCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockProcurementInterfaceGetOrderCall) DoAndReturn(f func(context.Context, uint, uint) (*data.PurchaseOrder, error)) *MockProcurementInterfaceGetOrderCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOrders mocks base method.
+func (m *MockProcurementInterface) GetOrders(ctx context.Context, userID uint, status *data.PurchaseOrderStatus) ([]*data.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrders", ctx, userID, status)
+	ret0, _ := ret[0].([]*data.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrders indicates an expected call of GetOrders.
+func (mr *MockProcurementInterfaceMockRecorder) GetOrders(ctx, userID, status any) *MockProcurementInterfaceGetOrdersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockProcurementInterface)(nil).GetOrders), ctx, userID, status)
+	return &MockProcurementInterfaceGetOrdersCall{Call: call}
+}
+
+// MockProcurementInterfaceGetOrdersCall wrap *gomock.Call
+type MockProcurementInterfaceGetOrdersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockProcurementInterfaceGetOrdersCall) Return(arg0 []*data.PurchaseOrder, arg1 error) *MockProcurementInterfaceGetOrdersCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockProcurementInterfaceGetOrdersCall) Do(f func(context.Context, uint, *data.PurchaseOrderStatus) ([]*data.PurchaseOrder, error)) *MockProcurementInterfaceGetOrdersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockProcurementInterfaceGetOrdersCall) DoAndReturn(f func(context.Context, uint, *data.PurchaseOrderStatus) ([]*data.PurchaseOrder, error)) *MockProcurementInterfaceGetOrdersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetRequest mocks base method.
+func (m *MockProcurementInterface) GetRequest(ctx context.Context, id, userID uint) (*data.ProcurementRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRequest", ctx, id, userID)
+	ret0, _ := ret[0].(*data.ProcurementRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRequest indicates an expected call of GetRequest.
+func (mr *MockProcurementInterfaceMockRecorder) GetRequest(ctx, id, userID any) *MockProcurementInterfaceGetRequestCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRequest", reflect.TypeOf((*MockProcurementInterface)(nil).GetRequest), ctx, id, userID)
+	return &MockProcurementInterfaceGetRequestCall{Call: call}
+}
+
+// MockProcurementInterfaceGetRequestCall wrap *gomock.Call
+type MockProcurementInterfaceGetRequestCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockProcurementInterfaceGetRequestCall) Return(arg0 *data.ProcurementRequest, arg1 error) *MockProcurementInterfaceGetRequestCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockProcurementInterfaceGetRequestCall) Do(f func(context.Context, uint, uint) (*data.ProcurementRequest, error)) *MockProcurementInterfaceGetRequestCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockProcurementInterfaceGetRequestCall) DoAndReturn(f func(context.Context, uint, uint) (*data.ProcurementRequest, error)) *MockProcurementInterfaceGetRequestCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetRequests mocks base method.
+func (m *MockProcurementInterface) GetRequests(ctx context.Context, userID uint, requesterID *uint, status *data.ProcurementStatus) ([]*data.ProcurementRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRequests", ctx, userID, requesterID, status)
+	ret0, _ := ret[0].([]*data.ProcurementRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRequests indicates an expected call of GetRequests.
+func (mr *MockProcurementInterfaceMockRecorder) GetRequests(ctx, userID, requesterID, status any) *MockProcurementInterfaceGetRequestsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRequests", reflect.TypeOf((*MockProcurementInterface)(nil).GetRequests), ctx, userID, requesterID, status)
+	return &MockProcurementInterfaceGetRequestsCall{Call: call}
+}
+
+// MockProcurementInterfaceGetRequestsCall wrap *gomock.Call
+type MockProcurementInterfaceGetRequestsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockProcurementInterfaceGetRequestsCall) Return(arg0 []*data.ProcurementRequest, arg1 error) *MockProcurementInterfaceGetRequestsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockProcurementInterfaceGetRequestsCall) Do(f func(context.Context, uint, *uint, *data.ProcurementStatus) ([]*data.ProcurementRequest, error)) *MockProcurementInterfaceGetRequestsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockProcurementInterfaceGetRequestsCall) DoAndReturn(f func(context.Context, uint, *uint, *data.ProcurementStatus) ([]*data.ProcurementRequest, error)) *MockProcurementInterfaceGetRequestsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// InsertOrder mocks base method.
+func (m *MockProcurementInterface) InsertOrder(ctx context.Context, order *data.PurchaseOrder, prices map[uint]float64) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertOrder", ctx, order, prices)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertOrder indicates an expected call of InsertOrder.
+func (mr *MockProcurementInterfaceMockRecorder) InsertOrder(ctx, order, prices any) *MockProcurementInterfaceInsertOrderCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOrder", reflect.TypeOf((*MockProcurementInterface)(nil).InsertOrder), ctx, order, prices)
+	return &MockProcurementInterfaceInsertOrderCall{Call: call}
+}
+
+// MockProcurementInterfaceInsertOrderCall wrap *gomock.Call
+type MockProcurementInterfaceInsertOrderCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockProcurementInterfaceInsertOrderCall) Return(arg0 uint, arg1 error) *MockProcurementInterfaceInsertOrderCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockProcurementInterfaceInsertOrderCall) Do(f func(context.Context, *data.PurchaseOrder, map[uint]float64) (uint, error)) *MockProcurementInterfaceInsertOrderCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockProcurementInterfaceInsertOrderCall) DoAndReturn(f func(context.Context, *data.PurchaseOrder, map[uint]float64) (uint, error)) *MockProcurementInterfaceInsertOrderCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// InsertRequest mocks base method.
+func (m *MockProcurementInterface) InsertRequest(ctx context.Context, request *data.ProcurementRequest) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRequest", ctx, request)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertRequest indicates an expected call of InsertRequest.
+func (mr *MockProcurementInterfaceMockRecorder) InsertRequest(ctx, request any) *MockProcurementInterfaceInsertRequestCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRequest", reflect.TypeOf((*MockProcurementInterface)(nil).InsertRequest), ctx, request)
+	return &MockProcurementInterfaceInsertRequestCall{Call: call}
+}
+
+// MockProcurementInterfaceInsertRequestCall wrap *gomock.Call
+type MockProcurementInterfaceInsertRequestCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockProcurementInterfaceInsertRequestCall) Return(arg0 uint, arg1 error) *MockProcurementInterfaceInsertRequestCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockProcurementInterfaceInsertRequestCall) Do(f func(context.Context, *data.ProcurementRequest) (uint, error)) *MockProcurementInterfaceInsertRequestCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockProcurementInterfaceInsertRequestCall) DoAndReturn(f func(context.Context, *data.ProcurementRequest) (uint, error)) *MockProcurementInterfaceInsertRequestCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ReceiveOrder mocks base method.
+func (m *MockProcurementInterface) ReceiveOrder(ctx context.Context, id, userID uint, expense *data.Expense) (*data.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveOrder", ctx, id, userID, expense)
+	ret0, _ := ret[0].(*data.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiveOrder indicates an expected call of ReceiveOrder.
+func (mr *MockProcurementInterfaceMockRecorder) ReceiveOrder(ctx, id, userID, expense any) *MockProcurementInterfaceReceiveOrderCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveOrder", reflect.TypeOf((*MockProcurementInterface)(nil).ReceiveOrder), ctx, id, userID, expense)
+	return &MockProcurementInterfaceReceiveOrderCall{Call: call}
+}
+
+// MockProcurementInterfaceReceiveOrderCall wrap *gomock.Call
+type MockProcurementInterfaceReceiveOrderCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockProcurementInterfaceReceiveOrderCall) Return(arg0 *data.PurchaseOrder, arg1 error) *MockProcurementInterfaceReceiveOrderCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockProcurementInterfaceReceiveOrderCall) Do(f func(context.Context, uint, uint, *data.Expense) (*data.PurchaseOrder, error)) *MockProcurementInterfaceReceiveOrderCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockProcurementInterfaceReceiveOrderCall) DoAndReturn(f func(context.Context, uint, uint, *data.Expense) (*data.PurchaseOrder, error)) *MockProcurementInterfaceReceiveOrderCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ReviewRequest mocks base method.
+func (m *MockProcurementInterface) ReviewRequest(ctx context.Context, id, userID uint, status data.ProcurementStatus, reviewerID uint, reason *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewRequest", ctx, id, userID, status, reviewerID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReviewRequest indicates an expected call of ReviewRequest.
+func (mr *MockProcurementInterfaceMockRecorder) ReviewRequest(ctx, id, userID, status, reviewerID, reason any) *MockProcurementInterfaceReviewRequestCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewRequest", reflect.TypeOf((*MockProcurementInterface)(nil).ReviewRequest), ctx, id, userID, status, reviewerID, reason)
+	return &MockProcurementInterfaceReviewRequestCall{Call: call}
+}
+
+// MockProcurementInterfaceReviewRequestCall wrap *gomock.Call
+type MockProcurementInterfaceReviewRequestCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockProcurementInterfaceReviewRequestCall) Return(arg0 error) *MockProcurementInterfaceReviewRequestCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockProcurementInterfaceReviewRequestCall) Do(f func(context.Context, uint, uint, data.ProcurementStatus, uint, *string) error) *MockProcurementInterfaceReviewRequestCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockProcurementInterfaceReviewRequestCall) DoAndReturn(f func(context.Context, uint, uint, data.ProcurementStatus, uint, *string) error) *MockProcurementInterfaceReviewRequestCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateRequest mocks base method.
+func (m *MockProcurementInterface) UpdateRequest(ctx context.Context, request *data.ProcurementRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRequest", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRequest indicates an expected call of UpdateRequest.
+func (mr *MockProcurementInterfaceMockRecorder) UpdateRequest(ctx, request any) *MockProcurementInterfaceUpdateRequestCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRequest", reflect.TypeOf((*MockProcurementInterface)(nil).UpdateRequest), ctx, request)
+	return &MockProcurementInterfaceUpdateRequestCall{Call: call}
+}
+
+// MockProcurementInterfaceUpdateRequestCall wrap *gomock.Call
+type MockProcurementInterfaceUpdateRequestCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockProcurementInterfaceUpdateRequestCall) Return(arg0 error) *MockProcurementInterfaceUpdateRequestCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockProcurementInterfaceUpdateRequestCall) Do(f func(context.Context, *data.ProcurementRequest) error) *MockProcurementInterfaceUpdateRequestCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockProcurementInterfaceUpdateRequestCall) DoAndReturn(f func(context.Context, *data.ProcurementRequest) error) *MockProcurementInterfaceUpdateRequestCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockInsuranceInterface is a mock of InsuranceInterface interface.
+type MockInsuranceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockInsuranceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockInsuranceInterfaceMockRecorder is the mock recorder for MockInsuranceInterface.
+type MockInsuranceInterfaceMockRecorder struct {
+	mock *MockInsuranceInterface
+}
+
+// NewMockInsuranceInterface creates a new mock instance.
+func NewMockInsuranceInterface(ctrl *gomock.Controller) *MockInsuranceInterface {
+	mock := &MockInsuranceInterface{ctrl: ctrl}
+	mock.recorder = &MockInsuranceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInsuranceInterface) EXPECT() *MockInsuranceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteClaim mocks base method.
+func (m *MockInsuranceInterface) DeleteClaim(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClaim", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteClaim indicates an expected call of DeleteClaim.
+func (mr *MockInsuranceInterfaceMockRecorder) DeleteClaim(ctx, id, userID any) *MockInsuranceInterfaceDeleteClaimCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClaim", reflect.TypeOf((*MockInsuranceInterface)(nil).DeleteClaim), ctx, id, userID)
+	return &MockInsuranceInterfaceDeleteClaimCall{Call: call}
+}
+
+// MockInsuranceInterfaceDeleteClaimCall wrap *gomock.Call
+type MockInsuranceInterfaceDeleteClaimCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInsuranceInterfaceDeleteClaimCall) Return(arg0 error) *MockInsuranceInterfaceDeleteClaimCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInsuranceInterfaceDeleteClaimCall) Do(f func(context.Context, uint, uint) error) *MockInsuranceInterfaceDeleteClaimCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInsuranceInterfaceDeleteClaimCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockInsuranceInterfaceDeleteClaimCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeletePolicy mocks base method.
+func (m *MockInsuranceInterface) DeletePolicy(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePolicy", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePolicy indicates an expected call of DeletePolicy.
+func (mr *MockInsuranceInterfaceMockRecorder) DeletePolicy(ctx, id, userID any) *MockInsuranceInterfaceDeletePolicyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePolicy", reflect.TypeOf((*MockInsuranceInterface)(nil).DeletePolicy), ctx, id, userID)
+	return &MockInsuranceInterfaceDeletePolicyCall{Call: call}
+}
+
+// MockInsuranceInterfaceDeletePolicyCall wrap *gomock.Call
+type MockInsuranceInterfaceDeletePolicyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInsuranceInterfaceDeletePolicyCall) Return(arg0 error) *MockInsuranceInterfaceDeletePolicyCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInsuranceInterfaceDeletePolicyCall) Do(f func(context.Context, uint, uint) error) *MockInsuranceInterfaceDeletePolicyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInsuranceInterfaceDeletePolicyCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockInsuranceInterfaceDeletePolicyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAllExpiringPolicies mocks base method.
+func (m *MockInsuranceInterface) GetAllExpiringPolicies(ctx context.Context, from, to time.Time) ([]*data.InsurancePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllExpiringPolicies", ctx, from, to)
+	ret0, _ := ret[0].([]*data.InsurancePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllExpiringPolicies indicates an expected call of GetAllExpiringPolicies.
+func (mr *MockInsuranceInterfaceMockRecorder) GetAllExpiringPolicies(ctx, from, to any) *MockInsuranceInterfaceGetAllExpiringPoliciesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllExpiringPolicies", reflect.TypeOf((*MockInsuranceInterface)(nil).GetAllExpiringPolicies), ctx, from, to)
+	return &MockInsuranceInterfaceGetAllExpiringPoliciesCall{Call: call}
+}
+
+// MockInsuranceInterfaceGetAllExpiringPoliciesCall wrap *gomock.Call
+type MockInsuranceInterfaceGetAllExpiringPoliciesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInsuranceInterfaceGetAllExpiringPoliciesCall) Return(arg0 []*data.InsurancePolicy, arg1 error) *MockInsuranceInterfaceGetAllExpiringPoliciesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInsuranceInterfaceGetAllExpiringPoliciesCall) Do(f func(context.Context, time.Time, time.Time) ([]*data.InsurancePolicy, error)) *MockInsuranceInterfaceGetAllExpiringPoliciesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInsuranceInterfaceGetAllExpiringPoliciesCall) DoAndReturn(f func(context.Context, time.Time, time.Time) ([]*data.InsurancePolicy, error)) *MockInsuranceInterfaceGetAllExpiringPoliciesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetClaim mocks base method.
+func (m *MockInsuranceInterface) GetClaim(ctx context.Context, id, userID uint) (*data.InsuranceClaim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClaim", ctx, id, userID)
+	ret0, _ := ret[0].(*data.InsuranceClaim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClaim indicates an expected call of GetClaim.
+func (mr *MockInsuranceInterfaceMockRecorder) GetClaim(ctx, id, userID any) *MockInsuranceInterfaceGetClaimCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClaim", reflect.TypeOf((*MockInsuranceInterface)(nil).GetClaim), ctx, id, userID)
+	return &MockInsuranceInterfaceGetClaimCall{Call: call}
+}
+
+// MockInsuranceInterfaceGetClaimCall wrap *gomock.Call
+type MockInsuranceInterfaceGetClaimCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInsuranceInterfaceGetClaimCall) Return(arg0 *data.InsuranceClaim, arg1 error) *MockInsuranceInterfaceGetClaimCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInsuranceInterfaceGetClaimCall) Do(f func(context.Context, uint, uint) (*data.InsuranceClaim, error)) *MockInsuranceInterfaceGetClaimCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInsuranceInterfaceGetClaimCall) DoAndReturn(f func(context.Context, uint, uint) (*data.InsuranceClaim, error)) *MockInsuranceInterfaceGetClaimCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetClaims mocks base method.
+func (m *MockInsuranceInterface) GetClaims(ctx context.Context, userID uint, policyID *uint, status *data.InsuranceClaimStatus) ([]*data.InsuranceClaim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClaims", ctx, userID, policyID, status)
+	ret0, _ := ret[0].([]*data.InsuranceClaim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClaims indicates an expected call of GetClaims.
+func (mr *MockInsuranceInterfaceMockRecorder) GetClaims(ctx, userID, policyID, status any) *MockInsuranceInterfaceGetClaimsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClaims", reflect.TypeOf((*MockInsuranceInterface)(nil).GetClaims), ctx, userID, policyID, status)
+	return &MockInsuranceInterfaceGetClaimsCall{Call: call}
+}
+
+// MockInsuranceInterfaceGetClaimsCall wrap *gomock.Call
+type MockInsuranceInterfaceGetClaimsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInsuranceInterfaceGetClaimsCall) Return(arg0 []*data.InsuranceClaim, arg1 error) *MockInsuranceInterfaceGetClaimsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInsuranceInterfaceGetClaimsCall) Do(f func(context.Context, uint, *uint, *data.InsuranceClaimStatus) ([]*data.InsuranceClaim, error)) *MockInsuranceInterfaceGetClaimsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInsuranceInterfaceGetClaimsCall) DoAndReturn(f func(context.Context, uint, *uint, *data.InsuranceClaimStatus) ([]*data.InsuranceClaim, error)) *MockInsuranceInterfaceGetClaimsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPolicies mocks base method.
+func (m *MockInsuranceInterface) GetPolicies(ctx context.Context, userID uint) ([]*data.InsurancePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicies", ctx, userID)
+	ret0, _ := ret[0].([]*data.InsurancePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicies indicates an expected call of GetPolicies.
+func (mr *MockInsuranceInterfaceMockRecorder) GetPolicies(ctx, userID any) *MockInsuranceInterfaceGetPoliciesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicies", reflect.TypeOf((*MockInsuranceInterface)(nil).GetPolicies), ctx, userID)
+	return &MockInsuranceInterfaceGetPoliciesCall{Call: call}
+}
+
+// MockInsuranceInterfaceGetPoliciesCall wrap *gomock.Call
+type MockInsuranceInterfaceGetPoliciesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInsuranceInterfaceGetPoliciesCall) Return(arg0 []*data.InsurancePolicy, arg1 error) *MockInsuranceInterfaceGetPoliciesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInsuranceInterfaceGetPoliciesCall) Do(f func(context.Context, uint) ([]*data.InsurancePolicy, error)) *MockInsuranceInterfaceGetPoliciesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInsuranceInterfaceGetPoliciesCall) DoAndReturn(f func(context.Context, uint) ([]*data.InsurancePolicy, error)) *MockInsuranceInterfaceGetPoliciesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPolicy mocks base method.
+func (m *MockInsuranceInterface) GetPolicy(ctx context.Context, id, userID uint) (*data.InsurancePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicy", ctx, id, userID)
+	ret0, _ := ret[0].(*data.InsurancePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicy indicates an expected call of GetPolicy.
+func (mr *MockInsuranceInterfaceMockRecorder) GetPolicy(ctx, id, userID any) *MockInsuranceInterfaceGetPolicyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicy", reflect.TypeOf((*MockInsuranceInterface)(nil).GetPolicy), ctx, id, userID)
+	return &MockInsuranceInterfaceGetPolicyCall{Call: call}
+}
+
+// MockInsuranceInterfaceGetPolicyCall wrap *gomock.Call
+type MockInsuranceInterfaceGetPolicyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInsuranceInterfaceGetPolicyCall) Return(arg0 *data.InsurancePolicy, arg1 error) *MockInsuranceInterfaceGetPolicyCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInsuranceInterfaceGetPolicyCall) Do(f func(context.Context, uint, uint) (*data.InsurancePolicy, error)) *MockInsuranceInterfaceGetPolicyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInsuranceInterfaceGetPolicyCall) DoAndReturn(f func(context.Context, uint, uint) (*data.InsurancePolicy, error)) *MockInsuranceInterfaceGetPolicyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// InsertClaim mocks base method.
+func (m *MockInsuranceInterface) InsertClaim(ctx context.Context, claim *data.InsuranceClaim) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertClaim", ctx, claim)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertClaim indicates an expected call of InsertClaim.
+func (mr *MockInsuranceInterfaceMockRecorder) InsertClaim(ctx, claim any) *MockInsuranceInterfaceInsertClaimCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertClaim", reflect.TypeOf((*MockInsuranceInterface)(nil).InsertClaim), ctx, claim)
+	return &MockInsuranceInterfaceInsertClaimCall{Call: call}
+}
+
+// MockInsuranceInterfaceInsertClaimCall wrap *gomock.Call
+type MockInsuranceInterfaceInsertClaimCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInsuranceInterfaceInsertClaimCall) Return(arg0 uint, arg1 error) *MockInsuranceInterfaceInsertClaimCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInsuranceInterfaceInsertClaimCall) Do(f func(context.Context, *data.InsuranceClaim) (uint, error)) *MockInsuranceInterfaceInsertClaimCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInsuranceInterfaceInsertClaimCall) DoAndReturn(f func(context.Context, *data.InsuranceClaim) (uint, error)) *MockInsuranceInterfaceInsertClaimCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// InsertPolicy mocks base method.
+func (m *MockInsuranceInterface) InsertPolicy(ctx context.Context, policy *data.InsurancePolicy) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPolicy", ctx, policy)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertPolicy indicates an expected call of InsertPolicy.
+func (mr *MockInsuranceInterfaceMockRecorder) InsertPolicy(ctx, policy any) *MockInsuranceInterfaceInsertPolicyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPolicy", reflect.TypeOf((*MockInsuranceInterface)(nil).InsertPolicy), ctx, policy)
+	return &MockInsuranceInterfaceInsertPolicyCall{Call: call}
+}
+
+// MockInsuranceInterfaceInsertPolicyCall wrap *gomock.Call
+type MockInsuranceInterfaceInsertPolicyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInsuranceInterfaceInsertPolicyCall) Return(arg0 uint, arg1 error) *MockInsuranceInterfaceInsertPolicyCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInsuranceInterfaceInsertPolicyCall) Do(f func(context.Context, *data.InsurancePolicy) (uint, error)) *MockInsuranceInterfaceInsertPolicyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInsuranceInterfaceInsertPolicyCall) DoAndReturn(f func(context.Context, *data.InsurancePolicy) (uint, error)) *MockInsuranceInterfaceInsertPolicyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RecordPayout mocks base method.
+func (m *MockInsuranceInterface) RecordPayout(ctx context.Context, id, userID uint, payout *data.Income) (*data.InsuranceClaim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordPayout", ctx, id, userID, payout)
+	ret0, _ := ret[0].(*data.InsuranceClaim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordPayout indicates an expected call of RecordPayout.
+func (mr *MockInsuranceInterfaceMockRecorder) RecordPayout(ctx, id, userID, payout any) *MockInsuranceInterfaceRecordPayoutCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordPayout", reflect.TypeOf((*MockInsuranceInterface)(nil).RecordPayout), ctx, id, userID, payout)
+	return &MockInsuranceInterfaceRecordPayoutCall{Call: call}
+}
+
+// MockInsuranceInterfaceRecordPayoutCall wrap *gomock.Call
+type MockInsuranceInterfaceRecordPayoutCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInsuranceInterfaceRecordPayoutCall) Return(arg0 *data.InsuranceClaim, arg1 error) *MockInsuranceInterfaceRecordPayoutCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInsuranceInterfaceRecordPayoutCall) Do(f func(context.Context, uint, uint, *data.Income) (*data.InsuranceClaim, error)) *MockInsuranceInterfaceRecordPayoutCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInsuranceInterfaceRecordPayoutCall) DoAndReturn(f func(context.Context, uint, uint, *data.Income) (*data.InsuranceClaim, error)) *MockInsuranceInterfaceRecordPayoutCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateClaim mocks base method.
+func (m *MockInsuranceInterface) UpdateClaim(ctx context.Context, claim *data.InsuranceClaim) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateClaim", ctx, claim)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateClaim indicates an expected call of UpdateClaim.
+func (mr *MockInsuranceInterfaceMockRecorder) UpdateClaim(ctx, claim any) *MockInsuranceInterfaceUpdateClaimCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClaim", reflect.TypeOf((*MockInsuranceInterface)(nil).UpdateClaim), ctx, claim)
+	return &MockInsuranceInterfaceUpdateClaimCall{Call: call}
+}
+
+// MockInsuranceInterfaceUpdateClaimCall wrap *gomock.Call
+type MockInsuranceInterfaceUpdateClaimCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInsuranceInterfaceUpdateClaimCall) Return(arg0 error) *MockInsuranceInterfaceUpdateClaimCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInsuranceInterfaceUpdateClaimCall) Do(f func(context.Context, *data.InsuranceClaim) error) *MockInsuranceInterfaceUpdateClaimCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInsuranceInterfaceUpdateClaimCall) DoAndReturn(f func(context.Context, *data.InsuranceClaim) error) *MockInsuranceInterfaceUpdateClaimCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdatePolicy mocks base method.
+func (m *MockInsuranceInterface) UpdatePolicy(ctx context.Context, policy *data.InsurancePolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePolicy", ctx, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePolicy indicates an expected call of UpdatePolicy.
+func (mr *MockInsuranceInterfaceMockRecorder) UpdatePolicy(ctx, policy any) *MockInsuranceInterfaceUpdatePolicyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePolicy", reflect.TypeOf((*MockInsuranceInterface)(nil).UpdatePolicy), ctx, policy)
+	return &MockInsuranceInterfaceUpdatePolicyCall{Call: call}
+}
+
+// MockInsuranceInterfaceUpdatePolicyCall wrap *gomock.Call
+type MockInsuranceInterfaceUpdatePolicyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInsuranceInterfaceUpdatePolicyCall) Return(arg0 error) *MockInsuranceInterfaceUpdatePolicyCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInsuranceInterfaceUpdatePolicyCall) Do(f func(context.Context, *data.InsurancePolicy) error) *MockInsuranceInterfaceUpdatePolicyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInsuranceInterfaceUpdatePolicyCall) DoAndReturn(f func(context.Context, *data.InsurancePolicy) error) *MockInsuranceInterfaceUpdatePolicyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockVehicleInterface is a mock of VehicleInterface interface.
+type MockVehicleInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockVehicleInterfaceMockRecorder is the mock recorder for MockVehicleInterface.
+type MockVehicleInterfaceMockRecorder struct {
+	mock *MockVehicleInterface
+}
+
+// NewMockVehicleInterface creates a new mock instance.
+func NewMockVehicleInterface(ctrl *gomock.Controller) *MockVehicleInterface {
+	mock := &MockVehicleInterface{ctrl: ctrl}
+	mock.recorder = &MockVehicleInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleInterface) EXPECT() *MockVehicleInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockVehicleInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockVehicleInterfaceMockRecorder) Delete(ctx, id, userID any) *MockVehicleInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVehicleInterface)(nil).Delete), ctx, id, userID)
+	return &MockVehicleInterfaceDeleteCall{Call: call}
+}
+
+// MockVehicleInterfaceDeleteCall wrap *gomock.Call
+type MockVehicleInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockVehicleInterfaceDeleteCall) Return(arg0 error) *MockVehicleInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockVehicleInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockVehicleInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockVehicleInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockVehicleInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockVehicleInterface) GetAll(ctx context.Context, userID uint) ([]*data.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
+	ret0, _ := ret[0].([]*data.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockVehicleInterfaceMockRecorder) GetAll(ctx, userID any) *MockVehicleInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockVehicleInterface)(nil).GetAll), ctx, userID)
+	return &MockVehicleInterfaceGetAllCall{Call: call}
+}
+
+// MockVehicleInterfaceGetAllCall wrap *gomock.Call
+type MockVehicleInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockVehicleInterfaceGetAllCall) Return(arg0 []*data.Vehicle, arg1 error) *MockVehicleInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockVehicleInterfaceGetAllCall) Do(f func(context.Context, uint) ([]*data.Vehicle, error)) *MockVehicleInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockVehicleInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint) ([]*data.Vehicle, error)) *MockVehicleInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockVehicleInterface) GetOne(ctx context.Context, id, userID uint) (*data.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockVehicleInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockVehicleInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockVehicleInterface)(nil).GetOne), ctx, id, userID)
+	return &MockVehicleInterfaceGetOneCall{Call: call}
+}
+
+// MockVehicleInterfaceGetOneCall wrap *gomock.Call
+type MockVehicleInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockVehicleInterfaceGetOneCall) Return(arg0 *data.Vehicle, arg1 error) *MockVehicleInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockVehicleInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.Vehicle, error)) *MockVehicleInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockVehicleInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Vehicle, error)) *MockVehicleInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockVehicleInterface) Insert(ctx context.Context, vehicle *data.Vehicle) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, vehicle)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockVehicleInterfaceMockRecorder) Insert(ctx, vehicle any) *MockVehicleInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockVehicleInterface)(nil).Insert), ctx, vehicle)
+	return &MockVehicleInterfaceInsertCall{Call: call}
+}
+
+// MockVehicleInterfaceInsertCall wrap *gomock.Call
+type MockVehicleInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockVehicleInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockVehicleInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockVehicleInterfaceInsertCall) Do(f func(context.Context, *data.Vehicle) (uint, error)) *MockVehicleInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockVehicleInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.Vehicle) (uint, error)) *MockVehicleInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockVehicleInterface) Update(ctx context.Context, vehicle *data.Vehicle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, vehicle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockVehicleInterfaceMockRecorder) Update(ctx, vehicle any) *MockVehicleInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVehicleInterface)(nil).Update), ctx, vehicle)
+	return &MockVehicleInterfaceUpdateCall{Call: call}
+}
+
+// MockVehicleInterfaceUpdateCall wrap *gomock.Call
+type MockVehicleInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockVehicleInterfaceUpdateCall) Return(arg0 error) *MockVehicleInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockVehicleInterfaceUpdateCall) Do(f func(context.Context, *data.Vehicle) error) *MockVehicleInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockVehicleInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.Vehicle) error) *MockVehicleInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockTripInterface is a mock of TripInterface interface.
+type MockTripInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTripInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockTripInterfaceMockRecorder is the mock recorder for MockTripInterface.
+type MockTripInterfaceMockRecorder struct {
+	mock *MockTripInterface
+}
+
+// NewMockTripInterface creates a new mock instance.
+func NewMockTripInterface(ctrl *gomock.Controller) *MockTripInterface {
+	mock := &MockTripInterface{ctrl: ctrl}
+	mock.recorder = &MockTripInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTripInterface) EXPECT() *MockTripInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockTripInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTripInterfaceMockRecorder) Delete(ctx, id, userID any) *MockTripInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTripInterface)(nil).Delete), ctx, id, userID)
+	return &MockTripInterfaceDeleteCall{Call: call}
+}
+
+// MockTripInterfaceDeleteCall wrap *gomock.Call
+type MockTripInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTripInterfaceDeleteCall) Return(arg0 error) *MockTripInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTripInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockTripInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTripInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockTripInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockTripInterface) GetAll(ctx context.Context, userID uint) ([]*data.Trip, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
+	ret0, _ := ret[0].([]*data.Trip)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTripInterfaceMockRecorder) GetAll(ctx, userID any) *MockTripInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTripInterface)(nil).GetAll), ctx, userID)
+	return &MockTripInterfaceGetAllCall{Call: call}
+}
+
+// MockTripInterfaceGetAllCall wrap *gomock.Call
+type MockTripInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTripInterfaceGetAllCall) Return(arg0 []*data.Trip, arg1 error) *MockTripInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTripInterfaceGetAllCall) Do(f func(context.Context, uint) ([]*data.Trip, error)) *MockTripInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTripInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint) ([]*data.Trip, error)) *MockTripInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetDeliveryCosts mocks base method.
+func (m *MockTripInterface) GetDeliveryCosts(ctx context.Context, userID uint) ([]*data.DeliveryCost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveryCosts", ctx, userID)
+	ret0, _ := ret[0].([]*data.DeliveryCost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveryCosts indicates an expected call of GetDeliveryCosts.
+func (mr *MockTripInterfaceMockRecorder) GetDeliveryCosts(ctx, userID any) *MockTripInterfaceGetDeliveryCostsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveryCosts", reflect.TypeOf((*MockTripInterface)(nil).GetDeliveryCosts), ctx, userID)
+	return &MockTripInterfaceGetDeliveryCostsCall{Call: call}
+}
+
+// MockTripInterfaceGetDeliveryCostsCall wrap *gomock.Call
+type MockTripInterfaceGetDeliveryCostsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTripInterfaceGetDeliveryCostsCall) Return(arg0 []*data.DeliveryCost, arg1 error) *MockTripInterfaceGetDeliveryCostsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTripInterfaceGetDeliveryCostsCall) Do(f func(context.Context, uint) ([]*data.DeliveryCost, error)) *MockTripInterfaceGetDeliveryCostsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTripInterfaceGetDeliveryCostsCall) DoAndReturn(f func(context.Context, uint) ([]*data.DeliveryCost, error)) *MockTripInterfaceGetDeliveryCostsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockTripInterface) GetOne(ctx context.Context, id, userID uint) (*data.Trip, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.Trip)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockTripInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockTripInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockTripInterface)(nil).GetOne), ctx, id, userID)
+	return &MockTripInterfaceGetOneCall{Call: call}
+}
+
+// MockTripInterfaceGetOneCall wrap *gomock.Call
+type MockTripInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTripInterfaceGetOneCall) Return(arg0 *data.Trip, arg1 error) *MockTripInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTripInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.Trip, error)) *MockTripInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTripInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Trip, error)) *MockTripInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetVehicleCosts mocks base method.
+func (m *MockTripInterface) GetVehicleCosts(ctx context.Context, userID uint) ([]*data.VehicleCost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVehicleCosts", ctx, userID)
+	ret0, _ := ret[0].([]*data.VehicleCost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVehicleCosts indicates an expected call of GetVehicleCosts.
+func (mr *MockTripInterfaceMockRecorder) GetVehicleCosts(ctx, userID any) *MockTripInterfaceGetVehicleCostsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicleCosts", reflect.TypeOf((*MockTripInterface)(nil).GetVehicleCosts), ctx, userID)
+	return &MockTripInterfaceGetVehicleCostsCall{Call: call}
+}
+
+// MockTripInterfaceGetVehicleCostsCall wrap *gomock.Call
+type MockTripInterfaceGetVehicleCostsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTripInterfaceGetVehicleCostsCall) Return(arg0 []*data.VehicleCost, arg1 error) *MockTripInterfaceGetVehicleCostsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTripInterfaceGetVehicleCostsCall) Do(f func(context.Context, uint) ([]*data.VehicleCost, error)) *MockTripInterfaceGetVehicleCostsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTripInterfaceGetVehicleCostsCall) DoAndReturn(f func(context.Context, uint) ([]*data.VehicleCost, error)) *MockTripInterfaceGetVehicleCostsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockTripInterface) Insert(ctx context.Context, trip *data.Trip) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, trip)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockTripInterfaceMockRecorder) Insert(ctx, trip any) *MockTripInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockTripInterface)(nil).Insert), ctx, trip)
+	return &MockTripInterfaceInsertCall{Call: call}
+}
+
+// MockTripInterfaceInsertCall wrap *gomock.Call
+type MockTripInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTripInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockTripInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTripInterfaceInsertCall) Do(f func(context.Context, *data.Trip) (uint, error)) *MockTripInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTripInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.Trip) (uint, error)) *MockTripInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockTripInterface) Update(ctx context.Context, trip *data.Trip) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, trip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTripInterfaceMockRecorder) Update(ctx, trip any) *MockTripInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTripInterface)(nil).Update), ctx, trip)
+	return &MockTripInterfaceUpdateCall{Call: call}
+}
+
+// MockTripInterfaceUpdateCall wrap *gomock.Call
+type MockTripInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTripInterfaceUpdateCall) Return(arg0 error) *MockTripInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTripInterfaceUpdateCall) Do(f func(context.Context, *data.Trip) error) *MockTripInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTripInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.Trip) error) *MockTripInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockContractorInterface is a mock of ContractorInterface interface.
+type MockContractorInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockContractorInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockContractorInterfaceMockRecorder is the mock recorder for MockContractorInterface.
+type MockContractorInterfaceMockRecorder struct {
+	mock *MockContractorInterface
+}
+
+// NewMockContractorInterface creates a new mock instance.
+func NewMockContractorInterface(ctrl *gomock.Controller) *MockContractorInterface {
+	mock := &MockContractorInterface{ctrl: ctrl}
+	mock.recorder = &MockContractorInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContractorInterface) EXPECT() *MockContractorInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockContractorInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockContractorInterfaceMockRecorder) Delete(ctx, id, userID any) *MockContractorInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockContractorInterface)(nil).Delete), ctx, id, userID)
+	return &MockContractorInterfaceDeleteCall{Call: call}
+}
+
+// MockContractorInterfaceDeleteCall wrap *gomock.Call
+type MockContractorInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockContractorInterfaceDeleteCall) Return(arg0 error) *MockContractorInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockContractorInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockContractorInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockContractorInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockContractorInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteWork mocks base method.
+func (m *MockContractorInterface) DeleteWork(ctx context.Context, id, contractorID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWork", ctx, id, contractorID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWork indicates an expected call of DeleteWork.
+func (mr *MockContractorInterfaceMockRecorder) DeleteWork(ctx, id, contractorID, userID any) *MockContractorInterfaceDeleteWorkCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWork", reflect.TypeOf((*MockContractorInterface)(nil).DeleteWork), ctx, id, contractorID, userID)
+	return &MockContractorInterfaceDeleteWorkCall{Call: call}
+}
+
+// MockContractorInterfaceDeleteWorkCall wrap *gomock.Call
+type MockContractorInterfaceDeleteWorkCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockContractorInterfaceDeleteWorkCall) Return(arg0 error) *MockContractorInterfaceDeleteWorkCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockContractorInterfaceDeleteWorkCall) Do(f func(context.Context, uint, uint, uint) error) *MockContractorInterfaceDeleteWorkCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockContractorInterfaceDeleteWorkCall) DoAndReturn(f func(context.Context, uint, uint, uint) error) *MockContractorInterfaceDeleteWorkCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockContractorInterface) GetAll(ctx context.Context, userID uint) ([]*data.Contractor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
+	ret0, _ := ret[0].([]*data.Contractor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockContractorInterfaceMockRecorder) GetAll(ctx, userID any) *MockContractorInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockContractorInterface)(nil).GetAll), ctx, userID)
+	return &MockContractorInterfaceGetAllCall{Call: call}
+}
+
+// MockContractorInterfaceGetAllCall wrap *gomock.Call
+type MockContractorInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockContractorInterfaceGetAllCall) Return(arg0 []*data.Contractor, arg1 error) *MockContractorInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockContractorInterfaceGetAllCall) Do(f func(context.Context, uint) ([]*data.Contractor, error)) *MockContractorInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockContractorInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint) ([]*data.Contractor, error)) *MockContractorInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockContractorInterface) GetOne(ctx context.Context, id, userID uint) (*data.Contractor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.Contractor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockContractorInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockContractorInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockContractorInterface)(nil).GetOne), ctx, id, userID)
+	return &MockContractorInterfaceGetOneCall{Call: call}
+}
+
+// MockContractorInterfaceGetOneCall wrap *gomock.Call
+type MockContractorInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockContractorInterfaceGetOneCall) Return(arg0 *data.Contractor, arg1 error) *MockContractorInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockContractorInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.Contractor, error)) *MockContractorInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockContractorInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Contractor, error)) *MockContractorInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetStatement mocks base method.
+func (m *MockContractorInterface) GetStatement(ctx context.Context, id, userID uint) (*data.ContractorStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatement", ctx, id, userID)
+	ret0, _ := ret[0].(*data.ContractorStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatement indicates an expected call of GetStatement.
+func (mr *MockContractorInterfaceMockRecorder) GetStatement(ctx, id, userID any) *MockContractorInterfaceGetStatementCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatement", reflect.TypeOf((*MockContractorInterface)(nil).GetStatement), ctx, id, userID)
+	return &MockContractorInterfaceGetStatementCall{Call: call}
+}
+
+// MockContractorInterfaceGetStatementCall wrap *gomock.Call
+type MockContractorInterfaceGetStatementCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockContractorInterfaceGetStatementCall) Return(arg0 *data.ContractorStatement, arg1 error) *MockContractorInterfaceGetStatementCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockContractorInterfaceGetStatementCall) Do(f func(context.Context, uint, uint) (*data.ContractorStatement, error)) *MockContractorInterfaceGetStatementCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockContractorInterfaceGetStatementCall) DoAndReturn(f func(context.Context, uint, uint) (*data.ContractorStatement, error)) *MockContractorInterfaceGetStatementCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetWork mocks base method.
+func (m *MockContractorInterface) GetWork(ctx context.Context, contractorID, userID uint) ([]*data.WorkRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWork", ctx, contractorID, userID)
+	ret0, _ := ret[0].([]*data.WorkRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWork indicates an expected call of GetWork.
+func (mr *MockContractorInterfaceMockRecorder) GetWork(ctx, contractorID, userID any) *MockContractorInterfaceGetWorkCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWork", reflect.TypeOf((*MockContractorInterface)(nil).GetWork), ctx, contractorID, userID)
+	return &MockContractorInterfaceGetWorkCall{Call: call}
+}
+
+// MockContractorInterfaceGetWorkCall wrap *gomock.Call
+type MockContractorInterfaceGetWorkCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockContractorInterfaceGetWorkCall) Return(arg0 []*data.WorkRecord, arg1 error) *MockContractorInterfaceGetWorkCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockContractorInterfaceGetWorkCall) Do(f func(context.Context, uint, uint) ([]*data.WorkRecord, error)) *MockContractorInterfaceGetWorkCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockContractorInterfaceGetWorkCall) DoAndReturn(f func(context.Context, uint, uint) ([]*data.WorkRecord, error)) *MockContractorInterfaceGetWorkCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockContractorInterface) Insert(ctx context.Context, contractor *data.Contractor) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, contractor)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockContractorInterfaceMockRecorder) Insert(ctx, contractor any) *MockContractorInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockContractorInterface)(nil).Insert), ctx, contractor)
+	return &MockContractorInterfaceInsertCall{Call: call}
+}
+
+// MockContractorInterfaceInsertCall wrap *gomock.Call
+type MockContractorInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockContractorInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockContractorInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockContractorInterfaceInsertCall) Do(f func(context.Context, *data.Contractor) (uint, error)) *MockContractorInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockContractorInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.Contractor) (uint, error)) *MockContractorInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RecordWork mocks base method.
+func (m *MockContractorInterface) RecordWork(ctx context.Context, work *data.WorkRecord, expense *data.Expense) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordWork", ctx, work, expense)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordWork indicates an expected call of RecordWork.
+func (mr *MockContractorInterfaceMockRecorder) RecordWork(ctx, work, expense any) *MockContractorInterfaceRecordWorkCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordWork", reflect.TypeOf((*MockContractorInterface)(nil).RecordWork), ctx, work, expense)
+	return &MockContractorInterfaceRecordWorkCall{Call: call}
+}
+
+// MockContractorInterfaceRecordWorkCall wrap *gomock.Call
+type MockContractorInterfaceRecordWorkCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockContractorInterfaceRecordWorkCall) Return(arg0 uint, arg1 error) *MockContractorInterfaceRecordWorkCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockContractorInterfaceRecordWorkCall) Do(f func(context.Context, *data.WorkRecord, *data.Expense) (uint, error)) *MockContractorInterfaceRecordWorkCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockContractorInterfaceRecordWorkCall) DoAndReturn(f func(context.Context, *data.WorkRecord, *data.Expense) (uint, error)) *MockContractorInterfaceRecordWorkCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockContractorInterface) Update(ctx context.Context, contractor *data.Contractor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, contractor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockContractorInterfaceMockRecorder) Update(ctx, contractor any) *MockContractorInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockContractorInterface)(nil).Update), ctx, contractor)
+	return &MockContractorInterfaceUpdateCall{Call: call}
+}
+
+// MockContractorInterfaceUpdateCall wrap *gomock.Call
+type MockContractorInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockContractorInterfaceUpdateCall) Return(arg0 error) *MockContractorInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockContractorInterfaceUpdateCall) Do(f func(context.Context, *data.Contractor) error) *MockContractorInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockContractorInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.Contractor) error) *MockContractorInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockEmployeeInterface is a mock of EmployeeInterface interface.
+type MockEmployeeInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmployeeInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockEmployeeInterfaceMockRecorder is the mock recorder for MockEmployeeInterface.
+type MockEmployeeInterfaceMockRecorder struct {
+	mock *MockEmployeeInterface
+}
+
+// NewMockEmployeeInterface creates a new mock instance.
+func NewMockEmployeeInterface(ctrl *gomock.Controller) *MockEmployeeInterface {
+	mock := &MockEmployeeInterface{ctrl: ctrl}
+	mock.recorder = &MockEmployeeInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmployeeInterface) EXPECT() *MockEmployeeInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockEmployeeInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockEmployeeInterfaceMockRecorder) Delete(ctx, id, userID any) *MockEmployeeInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockEmployeeInterface)(nil).Delete), ctx, id, userID)
+	return &MockEmployeeInterfaceDeleteCall{Call: call}
+}
+
+// MockEmployeeInterfaceDeleteCall wrap *gomock.Call
+type MockEmployeeInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEmployeeInterfaceDeleteCall) Return(arg0 error) *MockEmployeeInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEmployeeInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockEmployeeInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEmployeeInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockEmployeeInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockEmployeeInterface) GetAll(ctx context.Context, userID uint) ([]*data.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
+	ret0, _ := ret[0].([]*data.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockEmployeeInterfaceMockRecorder) GetAll(ctx, userID any) *MockEmployeeInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockEmployeeInterface)(nil).GetAll), ctx, userID)
+	return &MockEmployeeInterfaceGetAllCall{Call: call}
+}
+
+// MockEmployeeInterfaceGetAllCall wrap *gomock.Call
+type MockEmployeeInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEmployeeInterfaceGetAllCall) Return(arg0 []*data.Employee, arg1 error) *MockEmployeeInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEmployeeInterfaceGetAllCall) Do(f func(context.Context, uint) ([]*data.Employee, error)) *MockEmployeeInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEmployeeInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint) ([]*data.Employee, error)) *MockEmployeeInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockEmployeeInterface) GetOne(ctx context.Context, id, userID uint) (*data.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockEmployeeInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockEmployeeInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockEmployeeInterface)(nil).GetOne), ctx, id, userID)
+	return &MockEmployeeInterfaceGetOneCall{Call: call}
+}
+
+// MockEmployeeInterfaceGetOneCall wrap *gomock.Call
+type MockEmployeeInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEmployeeInterfaceGetOneCall) Return(arg0 *data.Employee, arg1 error) *MockEmployeeInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEmployeeInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.Employee, error)) *MockEmployeeInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEmployeeInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Employee, error)) *MockEmployeeInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockEmployeeInterface) Insert(ctx context.Context, employee *data.Employee) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, employee)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockEmployeeInterfaceMockRecorder) Insert(ctx, employee any) *MockEmployeeInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockEmployeeInterface)(nil).Insert), ctx, employee)
+	return &MockEmployeeInterfaceInsertCall{Call: call}
+}
+
+// MockEmployeeInterfaceInsertCall wrap *gomock.Call
+type MockEmployeeInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEmployeeInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockEmployeeInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEmployeeInterfaceInsertCall) Do(f func(context.Context, *data.Employee) (uint, error)) *MockEmployeeInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEmployeeInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.Employee) (uint, error)) *MockEmployeeInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockEmployeeInterface) Update(ctx context.Context, employee *data.Employee) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, employee)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockEmployeeInterfaceMockRecorder) Update(ctx, employee any) *MockEmployeeInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEmployeeInterface)(nil).Update), ctx, employee)
+	return &MockEmployeeInterfaceUpdateCall{Call: call}
+}
+
+// MockEmployeeInterfaceUpdateCall wrap *gomock.Call
+type MockEmployeeInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEmployeeInterfaceUpdateCall) Return(arg0 error) *MockEmployeeInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEmployeeInterfaceUpdateCall) Do(f func(context.Context, *data.Employee) error) *MockEmployeeInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEmployeeInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.Employee) error) *MockEmployeeInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockAttendanceInterface is a mock of AttendanceInterface interface.
+type MockAttendanceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttendanceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockAttendanceInterfaceMockRecorder is the mock recorder for MockAttendanceInterface.
+type MockAttendanceInterfaceMockRecorder struct {
+	mock *MockAttendanceInterface
+}
+
+// NewMockAttendanceInterface creates a new mock instance.
+func NewMockAttendanceInterface(ctrl *gomock.Controller) *MockAttendanceInterface {
+	mock := &MockAttendanceInterface{ctrl: ctrl}
+	mock.recorder = &MockAttendanceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttendanceInterface) EXPECT() *MockAttendanceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CheckIn mocks base method.
+func (m *MockAttendanceInterface) CheckIn(ctx context.Context, attendance *data.Attendance) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckIn", ctx, attendance)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckIn indicates an expected call of CheckIn.
+func (mr *MockAttendanceInterfaceMockRecorder) CheckIn(ctx, attendance any) *MockAttendanceInterfaceCheckInCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIn", reflect.TypeOf((*MockAttendanceInterface)(nil).CheckIn), ctx, attendance)
+	return &MockAttendanceInterfaceCheckInCall{Call: call}
+}
+
+// MockAttendanceInterfaceCheckInCall wrap *gomock.Call
+type MockAttendanceInterfaceCheckInCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAttendanceInterfaceCheckInCall) Return(arg0 uint, arg1 error) *MockAttendanceInterfaceCheckInCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAttendanceInterfaceCheckInCall) Do(f func(context.Context, *data.Attendance) (uint, error)) *MockAttendanceInterfaceCheckInCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAttendanceInterfaceCheckInCall) DoAndReturn(f func(context.Context, *data.Attendance) (uint, error)) *MockAttendanceInterfaceCheckInCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CheckOut mocks base method.
+func (m *MockAttendanceInterface) CheckOut(ctx context.Context, attendance *data.Attendance) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckOut", ctx, attendance)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckOut indicates an expected call of CheckOut.
+func (mr *MockAttendanceInterfaceMockRecorder) CheckOut(ctx, attendance any) *MockAttendanceInterfaceCheckOutCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckOut", reflect.TypeOf((*MockAttendanceInterface)(nil).CheckOut), ctx, attendance)
+	return &MockAttendanceInterfaceCheckOutCall{Call: call}
+}
+
+// MockAttendanceInterfaceCheckOutCall wrap *gomock.Call
+type MockAttendanceInterfaceCheckOutCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAttendanceInterfaceCheckOutCall) Return(arg0 error) *MockAttendanceInterfaceCheckOutCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAttendanceInterfaceCheckOutCall) Do(f func(context.Context, *data.Attendance) error) *MockAttendanceInterfaceCheckOutCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAttendanceInterfaceCheckOutCall) DoAndReturn(f func(context.Context, *data.Attendance) error) *MockAttendanceInterfaceCheckOutCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockAttendanceInterface) GetAll(ctx context.Context, userID uint, filter data.AttendanceFilter) ([]*data.Attendance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID, filter)
+	ret0, _ := ret[0].([]*data.Attendance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAttendanceInterfaceMockRecorder) GetAll(ctx, userID, filter any) *MockAttendanceInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAttendanceInterface)(nil).GetAll), ctx, userID, filter)
+	return &MockAttendanceInterfaceGetAllCall{Call: call}
+}
+
+// MockAttendanceInterfaceGetAllCall wrap *gomock.Call
+type MockAttendanceInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAttendanceInterfaceGetAllCall) Return(arg0 []*data.Attendance, arg1 error) *MockAttendanceInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAttendanceInterfaceGetAllCall) Do(f func(context.Context, uint, data.AttendanceFilter) ([]*data.Attendance, error)) *MockAttendanceInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAttendanceInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint, data.AttendanceFilter) ([]*data.Attendance, error)) *MockAttendanceInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetLatest mocks base method.
+func (m *MockAttendanceInterface) GetLatest(ctx context.Context, employeeID, userID uint) (*data.Attendance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatest", ctx, employeeID, userID)
+	ret0, _ := ret[0].(*data.Attendance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatest indicates an expected call of GetLatest.
+func (mr *MockAttendanceInterfaceMockRecorder) GetLatest(ctx, employeeID, userID any) *MockAttendanceInterfaceGetLatestCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatest", reflect.TypeOf((*MockAttendanceInterface)(nil).GetLatest), ctx, employeeID, userID)
+	return &MockAttendanceInterfaceGetLatestCall{Call: call}
+}
+
+// MockAttendanceInterfaceGetLatestCall wrap *gomock.Call
+type MockAttendanceInterfaceGetLatestCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAttendanceInterfaceGetLatestCall) Return(arg0 *data.Attendance, arg1 error) *MockAttendanceInterfaceGetLatestCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAttendanceInterfaceGetLatestCall) Do(f func(context.Context, uint, uint) (*data.Attendance, error)) *MockAttendanceInterfaceGetLatestCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAttendanceInterfaceGetLatestCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Attendance, error)) *MockAttendanceInterfaceGetLatestCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockAttendanceInterface) GetOne(ctx context.Context, id, userID uint) (*data.Attendance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.Attendance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockAttendanceInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockAttendanceInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockAttendanceInterface)(nil).GetOne), ctx, id, userID)
+	return &MockAttendanceInterfaceGetOneCall{Call: call}
+}
+
+// MockAttendanceInterfaceGetOneCall wrap *gomock.Call
+type MockAttendanceInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAttendanceInterfaceGetOneCall) Return(arg0 *data.Attendance, arg1 error) *MockAttendanceInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAttendanceInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.Attendance, error)) *MockAttendanceInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAttendanceInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Attendance, error)) *MockAttendanceInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockTimesheetInterface is a mock of TimesheetInterface interface.
+type MockTimesheetInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimesheetInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockTimesheetInterfaceMockRecorder is the mock recorder for MockTimesheetInterface.
+type MockTimesheetInterfaceMockRecorder struct {
+	mock *MockTimesheetInterface
+}
+
+// NewMockTimesheetInterface creates a new mock instance.
+func NewMockTimesheetInterface(ctrl *gomock.Controller) *MockTimesheetInterface {
+	mock := &MockTimesheetInterface{ctrl: ctrl}
+	mock.recorder = &MockTimesheetInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimesheetInterface) EXPECT() *MockTimesheetInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockTimesheetInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTimesheetInterfaceMockRecorder) Delete(ctx, id, userID any) *MockTimesheetInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTimesheetInterface)(nil).Delete), ctx, id, userID)
+	return &MockTimesheetInterfaceDeleteCall{Call: call}
+}
+
+// MockTimesheetInterfaceDeleteCall wrap *gomock.Call
+type MockTimesheetInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTimesheetInterfaceDeleteCall) Return(arg0 error) *MockTimesheetInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTimesheetInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockTimesheetInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTimesheetInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockTimesheetInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockTimesheetInterface) GetAll(ctx context.Context, userID uint, status string, start, end *time.Time) ([]*data.Timesheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID, status, start, end)
+	ret0, _ := ret[0].([]*data.Timesheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTimesheetInterfaceMockRecorder) GetAll(ctx, userID, status, start, end any) *MockTimesheetInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTimesheetInterface)(nil).GetAll), ctx, userID, status, start, end)
+	return &MockTimesheetInterfaceGetAllCall{Call: call}
+}
+
+// MockTimesheetInterfaceGetAllCall wrap *gomock.Call
+type MockTimesheetInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTimesheetInterfaceGetAllCall) Return(arg0 []*data.Timesheet, arg1 error) *MockTimesheetInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTimesheetInterfaceGetAllCall) Do(f func(context.Context, uint, string, *time.Time, *time.Time) ([]*data.Timesheet, error)) *MockTimesheetInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTimesheetInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint, string, *time.Time, *time.Time) ([]*data.Timesheet, error)) *MockTimesheetInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetApprovedHours mocks base method.
+func (m *MockTimesheetInterface) GetApprovedHours(ctx context.Context, userID uint, start, end time.Time) ([]*data.EmployeeHours, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApprovedHours", ctx, userID, start, end)
+	ret0, _ := ret[0].([]*data.EmployeeHours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApprovedHours indicates an expected call of GetApprovedHours.
+func (mr *MockTimesheetInterfaceMockRecorder) GetApprovedHours(ctx, userID, start, end any) *MockTimesheetInterfaceGetApprovedHoursCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApprovedHours", reflect.TypeOf((*MockTimesheetInterface)(nil).GetApprovedHours), ctx, userID, start, end)
+	return &MockTimesheetInterfaceGetApprovedHoursCall{Call: call}
+}
+
+// MockTimesheetInterfaceGetApprovedHoursCall wrap *gomock.Call
+type MockTimesheetInterfaceGetApprovedHoursCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTimesheetInterfaceGetApprovedHoursCall) Return(arg0 []*data.EmployeeHours, arg1 error) *MockTimesheetInterfaceGetApprovedHoursCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTimesheetInterfaceGetApprovedHoursCall) Do(f func(context.Context, uint, time.Time, time.Time) ([]*data.EmployeeHours, error)) *MockTimesheetInterfaceGetApprovedHoursCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTimesheetInterfaceGetApprovedHoursCall) DoAndReturn(f func(context.Context, uint, time.Time, time.Time) ([]*data.EmployeeHours, error)) *MockTimesheetInterfaceGetApprovedHoursCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetLaborCostByPit mocks base method.
+func (m *MockTimesheetInterface) GetLaborCostByPit(ctx context.Context, userID uint, start, end time.Time) ([]*data.PitLaborCost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLaborCostByPit", ctx, userID, start, end)
+	ret0, _ := ret[0].([]*data.PitLaborCost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLaborCostByPit indicates an expected call of GetLaborCostByPit.
+func (mr *MockTimesheetInterfaceMockRecorder) GetLaborCostByPit(ctx, userID, start, end any) *MockTimesheetInterfaceGetLaborCostByPitCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLaborCostByPit", reflect.TypeOf((*MockTimesheetInterface)(nil).GetLaborCostByPit), ctx, userID, start, end)
+	return &MockTimesheetInterfaceGetLaborCostByPitCall{Call: call}
+}
+
+// MockTimesheetInterfaceGetLaborCostByPitCall wrap *gomock.Call
+type MockTimesheetInterfaceGetLaborCostByPitCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTimesheetInterfaceGetLaborCostByPitCall) Return(arg0 []*data.PitLaborCost, arg1 error) *MockTimesheetInterfaceGetLaborCostByPitCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTimesheetInterfaceGetLaborCostByPitCall) Do(f func(context.Context, uint, time.Time, time.Time) ([]*data.PitLaborCost, error)) *MockTimesheetInterfaceGetLaborCostByPitCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTimesheetInterfaceGetLaborCostByPitCall) DoAndReturn(f func(context.Context, uint, time.Time, time.Time) ([]*data.PitLaborCost, error)) *MockTimesheetInterfaceGetLaborCostByPitCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockTimesheetInterface) GetOne(ctx context.Context, id, userID uint) (*data.Timesheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.Timesheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockTimesheetInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockTimesheetInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockTimesheetInterface)(nil).GetOne), ctx, id, userID)
+	return &MockTimesheetInterfaceGetOneCall{Call: call}
+}
+
+// MockTimesheetInterfaceGetOneCall wrap *gomock.Call
+type MockTimesheetInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTimesheetInterfaceGetOneCall) Return(arg0 *data.Timesheet, arg1 error) *MockTimesheetInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTimesheetInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.Timesheet, error)) *MockTimesheetInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTimesheetInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Timesheet, error)) *MockTimesheetInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockTimesheetInterface) Insert(ctx context.Context, timesheet *data.Timesheet) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, timesheet)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockTimesheetInterfaceMockRecorder) Insert(ctx, timesheet any) *MockTimesheetInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockTimesheetInterface)(nil).Insert), ctx, timesheet)
+	return &MockTimesheetInterfaceInsertCall{Call: call}
+}
+
+// MockTimesheetInterfaceInsertCall wrap *gomock.Call
+type MockTimesheetInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTimesheetInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockTimesheetInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTimesheetInterfaceInsertCall) Do(f func(context.Context, *data.Timesheet) (uint, error)) *MockTimesheetInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTimesheetInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.Timesheet) (uint, error)) *MockTimesheetInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Review mocks base method.
+func (m *MockTimesheetInterface) Review(ctx context.Context, id, userID uint, status data.TimesheetStatus, reason *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Review", ctx, id, userID, status, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Review indicates an expected call of Review.
+func (mr *MockTimesheetInterfaceMockRecorder) Review(ctx, id, userID, status, reason any) *MockTimesheetInterfaceReviewCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Review", reflect.TypeOf((*MockTimesheetInterface)(nil).Review), ctx, id, userID, status, reason)
+	return &MockTimesheetInterfaceReviewCall{Call: call}
+}
+
+// MockTimesheetInterfaceReviewCall wrap *gomock.Call
+type MockTimesheetInterfaceReviewCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTimesheetInterfaceReviewCall) Return(arg0 error) *MockTimesheetInterfaceReviewCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTimesheetInterfaceReviewCall) Do(f func(context.Context, uint, uint, data.TimesheetStatus, *string) error) *MockTimesheetInterfaceReviewCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTimesheetInterfaceReviewCall) DoAndReturn(f func(context.Context, uint, uint, data.TimesheetStatus, *string) error) *MockTimesheetInterfaceReviewCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockPayrollInterface is a mock of PayrollInterface interface.
+type MockPayrollInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPayrollInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockPayrollInterfaceMockRecorder is the mock recorder for MockPayrollInterface.
+type MockPayrollInterfaceMockRecorder struct {
+	mock *MockPayrollInterface
+}
+
+// NewMockPayrollInterface creates a new mock instance.
+func NewMockPayrollInterface(ctrl *gomock.Controller) *MockPayrollInterface {
+	mock := &MockPayrollInterface{ctrl: ctrl}
+	mock.recorder = &MockPayrollInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPayrollInterface) EXPECT() *MockPayrollInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateRun mocks base method.
+func (m *MockPayrollInterface) CreateRun(ctx context.Context, run *data.PayrollRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRun", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRun indicates an expected call of CreateRun.
+func (mr *MockPayrollInterfaceMockRecorder) CreateRun(ctx, run any) *MockPayrollInterfaceCreateRunCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRun", reflect.TypeOf((*MockPayrollInterface)(nil).CreateRun), ctx, run)
+	return &MockPayrollInterfaceCreateRunCall{Call: call}
+}
+
+// MockPayrollInterfaceCreateRunCall wrap *gomock.Call
+type MockPayrollInterfaceCreateRunCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPayrollInterfaceCreateRunCall) Return(arg0 error) *MockPayrollInterfaceCreateRunCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPayrollInterfaceCreateRunCall) Do(f func(context.Context, *data.PayrollRun) error) *MockPayrollInterfaceCreateRunCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPayrollInterfaceCreateRunCall) DoAndReturn(f func(context.Context, *data.PayrollRun) error) *MockPayrollInterfaceCreateRunCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteAdjustment mocks base method.
+func (m *MockPayrollInterface) DeleteAdjustment(ctx context.Context, id, employeeID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAdjustment", ctx, id, employeeID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAdjustment indicates an expected call of DeleteAdjustment.
+func (mr *MockPayrollInterfaceMockRecorder) DeleteAdjustment(ctx, id, employeeID, userID any) *MockPayrollInterfaceDeleteAdjustmentCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAdjustment", reflect.TypeOf((*MockPayrollInterface)(nil).DeleteAdjustment), ctx, id, employeeID, userID)
+	return &MockPayrollInterfaceDeleteAdjustmentCall{Call: call}
+}
+
+// MockPayrollInterfaceDeleteAdjustmentCall wrap *gomock.Call
+type MockPayrollInterfaceDeleteAdjustmentCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPayrollInterfaceDeleteAdjustmentCall) Return(arg0 error) *MockPayrollInterfaceDeleteAdjustmentCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPayrollInterfaceDeleteAdjustmentCall) Do(f func(context.Context, uint, uint, uint) error) *MockPayrollInterfaceDeleteAdjustmentCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPayrollInterfaceDeleteAdjustmentCall) DoAndReturn(f func(context.Context, uint, uint, uint) error) *MockPayrollInterfaceDeleteAdjustmentCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteRun mocks base method.
+func (m *MockPayrollInterface) DeleteRun(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRun", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRun indicates an expected call of DeleteRun.
+func (mr *MockPayrollInterfaceMockRecorder) DeleteRun(ctx, id, userID any) *MockPayrollInterfaceDeleteRunCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRun", reflect.TypeOf((*MockPayrollInterface)(nil).DeleteRun), ctx, id, userID)
+	return &MockPayrollInterfaceDeleteRunCall{Call: call}
+}
+
+// MockPayrollInterfaceDeleteRunCall wrap *gomock.Call
+type MockPayrollInterfaceDeleteRunCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPayrollInterfaceDeleteRunCall) Return(arg0 error) *MockPayrollInterfaceDeleteRunCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPayrollInterfaceDeleteRunCall) Do(f func(context.Context, uint, uint) error) *MockPayrollInterfaceDeleteRunCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPayrollInterfaceDeleteRunCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockPayrollInterfaceDeleteRunCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAdjustments mocks base method.
+func (m *MockPayrollInterface) GetAdjustments(ctx context.Context, employeeID, userID uint) ([]*data.EmployeeAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdjustments", ctx, employeeID, userID)
+	ret0, _ := ret[0].([]*data.EmployeeAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdjustments indicates an expected call of GetAdjustments.
+func (mr *MockPayrollInterfaceMockRecorder) GetAdjustments(ctx, employeeID, userID any) *MockPayrollInterfaceGetAdjustmentsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdjustments", reflect.TypeOf((*MockPayrollInterface)(nil).GetAdjustments), ctx, employeeID, userID)
+	return &MockPayrollInterfaceGetAdjustmentsCall{Call: call}
+}
+
+// MockPayrollInterfaceGetAdjustmentsCall wrap *gomock.Call
+type MockPayrollInterfaceGetAdjustmentsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPayrollInterfaceGetAdjustmentsCall) Return(arg0 []*data.EmployeeAdjustment, arg1 error) *MockPayrollInterfaceGetAdjustmentsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPayrollInterfaceGetAdjustmentsCall) Do(f func(context.Context, uint, uint) ([]*data.EmployeeAdjustment, error)) *MockPayrollInterfaceGetAdjustmentsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPayrollInterfaceGetAdjustmentsCall) DoAndReturn(f func(context.Context, uint, uint) ([]*data.EmployeeAdjustment, error)) *MockPayrollInterfaceGetAdjustmentsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAllRuns mocks base method.
+func (m *MockPayrollInterface) GetAllRuns(ctx context.Context, userID uint) ([]*data.PayrollRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllRuns", ctx, userID)
+	ret0, _ := ret[0].([]*data.PayrollRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllRuns indicates an expected call of GetAllRuns.
+func (mr *MockPayrollInterfaceMockRecorder) GetAllRuns(ctx, userID any) *MockPayrollInterfaceGetAllRunsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllRuns", reflect.TypeOf((*MockPayrollInterface)(nil).GetAllRuns), ctx, userID)
+	return &MockPayrollInterfaceGetAllRunsCall{Call: call}
+}
+
+// MockPayrollInterfaceGetAllRunsCall wrap *gomock.Call
+type MockPayrollInterfaceGetAllRunsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPayrollInterfaceGetAllRunsCall) Return(arg0 []*data.PayrollRun, arg1 error) *MockPayrollInterfaceGetAllRunsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPayrollInterfaceGetAllRunsCall) Do(f func(context.Context, uint) ([]*data.PayrollRun, error)) *MockPayrollInterfaceGetAllRunsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPayrollInterfaceGetAllRunsCall) DoAndReturn(f func(context.Context, uint) ([]*data.PayrollRun, error)) *MockPayrollInterfaceGetAllRunsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetRun mocks base method.
+func (m *MockPayrollInterface) GetRun(ctx context.Context, id, userID uint) (*data.PayrollRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRun", ctx, id, userID)
+	ret0, _ := ret[0].(*data.PayrollRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRun indicates an expected call of GetRun.
+func (mr *MockPayrollInterfaceMockRecorder) GetRun(ctx, id, userID any) *MockPayrollInterfaceGetRunCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRun", reflect.TypeOf((*MockPayrollInterface)(nil).GetRun), ctx, id, userID)
+	return &MockPayrollInterfaceGetRunCall{Call: call}
+}
+
+// MockPayrollInterfaceGetRunCall wrap *gomock.Call
+type MockPayrollInterfaceGetRunCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPayrollInterfaceGetRunCall) Return(arg0 *data.PayrollRun, arg1 error) *MockPayrollInterfaceGetRunCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPayrollInterfaceGetRunCall) Do(f func(context.Context, uint, uint) (*data.PayrollRun, error)) *MockPayrollInterfaceGetRunCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPayrollInterfaceGetRunCall) DoAndReturn(f func(context.Context, uint, uint) (*data.PayrollRun, error)) *MockPayrollInterfaceGetRunCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetStatement mocks base method.
+func (m *MockPayrollInterface) GetStatement(ctx context.Context, employeeID, userID uint) (*data.EmployeeStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatement", ctx, employeeID, userID)
+	ret0, _ := ret[0].(*data.EmployeeStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatement indicates an expected call of GetStatement.
+func (mr *MockPayrollInterfaceMockRecorder) GetStatement(ctx, employeeID, userID any) *MockPayrollInterfaceGetStatementCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatement", reflect.TypeOf((*MockPayrollInterface)(nil).GetStatement), ctx, employeeID, userID)
+	return &MockPayrollInterfaceGetStatementCall{Call: call}
+}
+
+// MockPayrollInterfaceGetStatementCall wrap *gomock.Call
+type MockPayrollInterfaceGetStatementCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPayrollInterfaceGetStatementCall) Return(arg0 *data.EmployeeStatement, arg1 error) *MockPayrollInterfaceGetStatementCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPayrollInterfaceGetStatementCall) Do(f func(context.Context, uint, uint) (*data.EmployeeStatement, error)) *MockPayrollInterfaceGetStatementCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPayrollInterfaceGetStatementCall) DoAndReturn(f func(context.Context, uint, uint) (*data.EmployeeStatement, error)) *MockPayrollInterfaceGetStatementCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// InsertAdjustment mocks base method.
+func (m *MockPayrollInterface) InsertAdjustment(ctx context.Context, adjustment *data.EmployeeAdjustment) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAdjustment", ctx, adjustment)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertAdjustment indicates an expected call of InsertAdjustment.
+func (mr *MockPayrollInterfaceMockRecorder) InsertAdjustment(ctx, adjustment any) *MockPayrollInterfaceInsertAdjustmentCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAdjustment", reflect.TypeOf((*MockPayrollInterface)(nil).InsertAdjustment), ctx, adjustment)
+	return &MockPayrollInterfaceInsertAdjustmentCall{Call: call}
+}
+
+// MockPayrollInterfaceInsertAdjustmentCall wrap *gomock.Call
+type MockPayrollInterfaceInsertAdjustmentCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPayrollInterfaceInsertAdjustmentCall) Return(arg0 uint, arg1 error) *MockPayrollInterfaceInsertAdjustmentCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPayrollInterfaceInsertAdjustmentCall) Do(f func(context.Context, *data.EmployeeAdjustment) (uint, error)) *MockPayrollInterfaceInsertAdjustmentCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPayrollInterfaceInsertAdjustmentCall) DoAndReturn(f func(context.Context, *data.EmployeeAdjustment) (uint, error)) *MockPayrollInterfaceInsertAdjustmentCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockSettingsInterface is a mock of SettingsInterface interface.
+type MockSettingsInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSettingsInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSettingsInterfaceMockRecorder is the mock recorder for MockSettingsInterface.
+type MockSettingsInterfaceMockRecorder struct {
+	mock *MockSettingsInterface
+}
+
+// NewMockSettingsInterface creates a new mock instance.
+func NewMockSettingsInterface(ctrl *gomock.Controller) *MockSettingsInterface {
+	mock := &MockSettingsInterface{ctrl: ctrl}
+	mock.recorder = &MockSettingsInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSettingsInterface) EXPECT() *MockSettingsInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetByCalendarToken mocks base method.
+func (m *MockSettingsInterface) GetByCalendarToken(ctx context.Context, token string) (*data.OrganizationSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCalendarToken", ctx, token)
+	ret0, _ := ret[0].(*data.OrganizationSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCalendarToken indicates an expected call of GetByCalendarToken.
+func (mr *MockSettingsInterfaceMockRecorder) GetByCalendarToken(ctx, token any) *MockSettingsInterfaceGetByCalendarTokenCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCalendarToken", reflect.TypeOf((*MockSettingsInterface)(nil).GetByCalendarToken), ctx, token)
+	return &MockSettingsInterfaceGetByCalendarTokenCall{Call: call}
+}
+
+// MockSettingsInterfaceGetByCalendarTokenCall wrap *gomock.Call
+type MockSettingsInterfaceGetByCalendarTokenCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSettingsInterfaceGetByCalendarTokenCall) Return(arg0 *data.OrganizationSettings, arg1 error) *MockSettingsInterfaceGetByCalendarTokenCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSettingsInterfaceGetByCalendarTokenCall) Do(f func(context.Context, string) (*data.OrganizationSettings, error)) *MockSettingsInterfaceGetByCalendarTokenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSettingsInterfaceGetByCalendarTokenCall) DoAndReturn(f func(context.Context, string) (*data.OrganizationSettings, error)) *MockSettingsInterfaceGetByCalendarTokenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByUserID mocks base method.
+func (m *MockSettingsInterface) GetByUserID(ctx context.Context, userID uint) (*data.OrganizationSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", ctx, userID)
+	ret0, _ := ret[0].(*data.OrganizationSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockSettingsInterfaceMockRecorder) GetByUserID(ctx, userID any) *MockSettingsInterfaceGetByUserIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockSettingsInterface)(nil).GetByUserID), ctx, userID)
+	return &MockSettingsInterfaceGetByUserIDCall{Call: call}
+}
+
+// MockSettingsInterfaceGetByUserIDCall wrap *gomock.Call
+type MockSettingsInterfaceGetByUserIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSettingsInterfaceGetByUserIDCall) Return(arg0 *data.OrganizationSettings, arg1 error) *MockSettingsInterfaceGetByUserIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSettingsInterfaceGetByUserIDCall) Do(f func(context.Context, uint) (*data.OrganizationSettings, error)) *MockSettingsInterfaceGetByUserIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSettingsInterfaceGetByUserIDCall) DoAndReturn(f func(context.Context, uint) (*data.OrganizationSettings, error)) *MockSettingsInterfaceGetByUserIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetSequences mocks base method.
+func (m *MockSettingsInterface) GetSequences(ctx context.Context, userID uint) (map[data.DocumentKind]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSequences", ctx, userID)
+	ret0, _ := ret[0].(map[data.DocumentKind]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSequences indicates an expected call of GetSequences.
+func (mr *MockSettingsInterfaceMockRecorder) GetSequences(ctx, userID any) *MockSettingsInterfaceGetSequencesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSequences", reflect.TypeOf((*MockSettingsInterface)(nil).GetSequences), ctx, userID)
+	return &MockSettingsInterfaceGetSequencesCall{Call: call}
+}
+
+// MockSettingsInterfaceGetSequencesCall wrap *gomock.Call
+type MockSettingsInterfaceGetSequencesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSettingsInterfaceGetSequencesCall) Return(arg0 map[data.DocumentKind]int, arg1 error) *MockSettingsInterfaceGetSequencesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSettingsInterfaceGetSequencesCall) Do(f func(context.Context, uint) (map[data.DocumentKind]int, error)) *MockSettingsInterfaceGetSequencesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSettingsInterfaceGetSequencesCall) DoAndReturn(f func(context.Context, uint) (map[data.DocumentKind]int, error)) *MockSettingsInterfaceGetSequencesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Save mocks base method.
+func (m *MockSettingsInterface) Save(ctx context.Context, settings *data.OrganizationSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSettingsInterfaceMockRecorder) Save(ctx, settings any) *MockSettingsInterfaceSaveCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSettingsInterface)(nil).Save), ctx, settings)
+	return &MockSettingsInterfaceSaveCall{Call: call}
+}
+
+// MockSettingsInterfaceSaveCall wrap *gomock.Call
+type MockSettingsInterfaceSaveCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSettingsInterfaceSaveCall) Return(arg0 error) *MockSettingsInterfaceSaveCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSettingsInterfaceSaveCall) Do(f func(context.Context, *data.OrganizationSettings) error) *MockSettingsInterfaceSaveCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSettingsInterfaceSaveCall) DoAndReturn(f func(context.Context, *data.OrganizationSettings) error) *MockSettingsInterfaceSaveCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockOrganizationInterface is a mock of OrganizationInterface interface.
+type MockOrganizationInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockOrganizationInterfaceMockRecorder is the mock recorder for MockOrganizationInterface.
+type MockOrganizationInterfaceMockRecorder struct {
+	mock *MockOrganizationInterface
+}
+
+// NewMockOrganizationInterface creates a new mock instance.
+func NewMockOrganizationInterface(ctrl *gomock.Controller) *MockOrganizationInterface {
+	mock := &MockOrganizationInterface{ctrl: ctrl}
+	mock.recorder = &MockOrganizationInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationInterface) EXPECT() *MockOrganizationInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockOrganizationInterface) AcceptInvitation(ctx context.Context, tokenHash string, userID uint) (*data.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, tokenHash, userID)
+	ret0, _ := ret[0].(*data.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockOrganizationInterfaceMockRecorder) AcceptInvitation(ctx, tokenHash, userID any) *MockOrganizationInterfaceAcceptInvitationCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockOrganizationInterface)(nil).AcceptInvitation), ctx, tokenHash, userID)
+	return &MockOrganizationInterfaceAcceptInvitationCall{Call: call}
+}
+
+// MockOrganizationInterfaceAcceptInvitationCall wrap *gomock.Call
+type MockOrganizationInterfaceAcceptInvitationCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceAcceptInvitationCall) Return(arg0 *data.OrganizationMember, arg1 error) *MockOrganizationInterfaceAcceptInvitationCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceAcceptInvitationCall) Do(f func(context.Context, string, uint) (*data.OrganizationMember, error)) *MockOrganizationInterfaceAcceptInvitationCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceAcceptInvitationCall) DoAndReturn(f func(context.Context, string, uint) (*data.OrganizationMember, error)) *MockOrganizationInterfaceAcceptInvitationCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// AddIPRule mocks base method.
+func (m *MockOrganizationInterface) AddIPRule(ctx context.Context, rule *data.OrganizationIPRule) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddIPRule", ctx, rule)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddIPRule indicates an expected call of AddIPRule.
+func (mr *MockOrganizationInterfaceMockRecorder) AddIPRule(ctx, rule any) *MockOrganizationInterfaceAddIPRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddIPRule", reflect.TypeOf((*MockOrganizationInterface)(nil).AddIPRule), ctx, rule)
+	return &MockOrganizationInterfaceAddIPRuleCall{Call: call}
+}
+
+// MockOrganizationInterfaceAddIPRuleCall wrap *gomock.Call
+type MockOrganizationInterfaceAddIPRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceAddIPRuleCall) Return(arg0 uint, arg1 error) *MockOrganizationInterfaceAddIPRuleCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceAddIPRuleCall) Do(f func(context.Context, *data.OrganizationIPRule) (uint, error)) *MockOrganizationInterfaceAddIPRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceAddIPRuleCall) DoAndReturn(f func(context.Context, *data.OrganizationIPRule) (uint, error)) *MockOrganizationInterfaceAddIPRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// AddMember mocks base method.
+func (m *MockOrganizationInterface) AddMember(ctx context.Context, member *data.OrganizationMember) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", ctx, member)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockOrganizationInterfaceMockRecorder) AddMember(ctx, member any) *MockOrganizationInterfaceAddMemberCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockOrganizationInterface)(nil).AddMember), ctx, member)
+	return &MockOrganizationInterfaceAddMemberCall{Call: call}
+}
+
+// MockOrganizationInterfaceAddMemberCall wrap *gomock.Call
+type MockOrganizationInterfaceAddMemberCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceAddMemberCall) Return(arg0 uint, arg1 error) *MockOrganizationInterfaceAddMemberCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceAddMemberCall) Do(f func(context.Context, *data.OrganizationMember) (uint, error)) *MockOrganizationInterfaceAddMemberCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceAddMemberCall) DoAndReturn(f func(context.Context, *data.OrganizationMember) (uint, error)) *MockOrganizationInterfaceAddMemberCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Create mocks base method.
+func (m *MockOrganizationInterface) Create(ctx context.Context, organization *data.Organization) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, organization)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrganizationInterfaceMockRecorder) Create(ctx, organization any) *MockOrganizationInterfaceCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrganizationInterface)(nil).Create), ctx, organization)
+	return &MockOrganizationInterfaceCreateCall{Call: call}
+}
+
+// MockOrganizationInterfaceCreateCall wrap *gomock.Call
+type MockOrganizationInterfaceCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceCreateCall) Return(arg0 uint, arg1 error) *MockOrganizationInterfaceCreateCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceCreateCall) Do(f func(context.Context, *data.Organization) (uint, error)) *MockOrganizationInterfaceCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceCreateCall) DoAndReturn(f func(context.Context, *data.Organization) (uint, error)) *MockOrganizationInterfaceCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateInvitation mocks base method.
+func (m *MockOrganizationInterface) CreateInvitation(ctx context.Context, invitation *data.OrganizationInvitation) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitation", ctx, invitation)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvitation indicates an expected call of CreateInvitation.
+func (mr *MockOrganizationInterfaceMockRecorder) CreateInvitation(ctx, invitation any) *MockOrganizationInterfaceCreateInvitationCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitation", reflect.TypeOf((*MockOrganizationInterface)(nil).CreateInvitation), ctx, invitation)
+	return &MockOrganizationInterfaceCreateInvitationCall{Call: call}
+}
+
+// MockOrganizationInterfaceCreateInvitationCall wrap *gomock.Call
+type MockOrganizationInterfaceCreateInvitationCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceCreateInvitationCall) Return(arg0 uint, arg1 error) *MockOrganizationInterfaceCreateInvitationCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceCreateInvitationCall) Do(f func(context.Context, *data.OrganizationInvitation) (uint, error)) *MockOrganizationInterfaceCreateInvitationCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceCreateInvitationCall) DoAndReturn(f func(context.Context, *data.OrganizationInvitation) (uint, error)) *MockOrganizationInterfaceCreateInvitationCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteIPRule mocks base method.
+func (m *MockOrganizationInterface) DeleteIPRule(ctx context.Context, id, organizationID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIPRule", ctx, id, organizationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIPRule indicates an expected call of DeleteIPRule.
+func (mr *MockOrganizationInterfaceMockRecorder) DeleteIPRule(ctx, id, organizationID any) *MockOrganizationInterfaceDeleteIPRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIPRule", reflect.TypeOf((*MockOrganizationInterface)(nil).DeleteIPRule), ctx, id, organizationID)
+	return &MockOrganizationInterfaceDeleteIPRuleCall{Call: call}
+}
+
+// MockOrganizationInterfaceDeleteIPRuleCall wrap *gomock.Call
+type MockOrganizationInterfaceDeleteIPRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceDeleteIPRuleCall) Return(arg0 error) *MockOrganizationInterfaceDeleteIPRuleCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceDeleteIPRuleCall) Do(f func(context.Context, uint, uint) error) *MockOrganizationInterfaceDeleteIPRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceDeleteIPRuleCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockOrganizationInterfaceDeleteIPRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteInvitation mocks base method.
+func (m *MockOrganizationInterface) DeleteInvitation(ctx context.Context, id, organizationID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInvitation", ctx, id, organizationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInvitation indicates an expected call of DeleteInvitation.
+func (mr *MockOrganizationInterfaceMockRecorder) DeleteInvitation(ctx, id, organizationID any) *MockOrganizationInterfaceDeleteInvitationCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInvitation", reflect.TypeOf((*MockOrganizationInterface)(nil).DeleteInvitation), ctx, id, organizationID)
+	return &MockOrganizationInterfaceDeleteInvitationCall{Call: call}
+}
+
+// MockOrganizationInterfaceDeleteInvitationCall wrap *gomock.Call
+type MockOrganizationInterfaceDeleteInvitationCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceDeleteInvitationCall) Return(arg0 error) *MockOrganizationInterfaceDeleteInvitationCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceDeleteInvitationCall) Do(f func(context.Context, uint, uint) error) *MockOrganizationInterfaceDeleteInvitationCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceDeleteInvitationCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockOrganizationInterfaceDeleteInvitationCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetIPRules mocks base method.
+func (m *MockOrganizationInterface) GetIPRules(ctx context.Context, organizationID uint) ([]*data.OrganizationIPRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIPRules", ctx, organizationID)
+	ret0, _ := ret[0].([]*data.OrganizationIPRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIPRules indicates an expected call of GetIPRules.
+func (mr *MockOrganizationInterfaceMockRecorder) GetIPRules(ctx, organizationID any) *MockOrganizationInterfaceGetIPRulesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIPRules", reflect.TypeOf((*MockOrganizationInterface)(nil).GetIPRules), ctx, organizationID)
+	return &MockOrganizationInterfaceGetIPRulesCall{Call: call}
+}
+
+// MockOrganizationInterfaceGetIPRulesCall wrap *gomock.Call
+type MockOrganizationInterfaceGetIPRulesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceGetIPRulesCall) Return(arg0 []*data.OrganizationIPRule, arg1 error) *MockOrganizationInterfaceGetIPRulesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceGetIPRulesCall) Do(f func(context.Context, uint) ([]*data.OrganizationIPRule, error)) *MockOrganizationInterfaceGetIPRulesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceGetIPRulesCall) DoAndReturn(f func(context.Context, uint) ([]*data.OrganizationIPRule, error)) *MockOrganizationInterfaceGetIPRulesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetInvitationByToken mocks base method.
+func (m *MockOrganizationInterface) GetInvitationByToken(ctx context.Context, tokenHash string) (*data.OrganizationInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitationByToken", ctx, tokenHash)
+	ret0, _ := ret[0].(*data.OrganizationInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitationByToken indicates an expected call of GetInvitationByToken.
+func (mr *MockOrganizationInterfaceMockRecorder) GetInvitationByToken(ctx, tokenHash any) *MockOrganizationInterfaceGetInvitationByTokenCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitationByToken", reflect.TypeOf((*MockOrganizationInterface)(nil).GetInvitationByToken), ctx, tokenHash)
+	return &MockOrganizationInterfaceGetInvitationByTokenCall{Call: call}
+}
+
+// MockOrganizationInterfaceGetInvitationByTokenCall wrap *gomock.Call
+type MockOrganizationInterfaceGetInvitationByTokenCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceGetInvitationByTokenCall) Return(arg0 *data.OrganizationInvitation, arg1 error) *MockOrganizationInterfaceGetInvitationByTokenCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceGetInvitationByTokenCall) Do(f func(context.Context, string) (*data.OrganizationInvitation, error)) *MockOrganizationInterfaceGetInvitationByTokenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceGetInvitationByTokenCall) DoAndReturn(f func(context.Context, string) (*data.OrganizationInvitation, error)) *MockOrganizationInterfaceGetInvitationByTokenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetInvitations mocks base method.
+func (m *MockOrganizationInterface) GetInvitations(ctx context.Context, organizationID uint) ([]*data.OrganizationInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitations", ctx, organizationID)
+	ret0, _ := ret[0].([]*data.OrganizationInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitations indicates an expected call of GetInvitations.
+func (mr *MockOrganizationInterfaceMockRecorder) GetInvitations(ctx, organizationID any) *MockOrganizationInterfaceGetInvitationsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitations", reflect.TypeOf((*MockOrganizationInterface)(nil).GetInvitations), ctx, organizationID)
+	return &MockOrganizationInterfaceGetInvitationsCall{Call: call}
+}
+
+// MockOrganizationInterfaceGetInvitationsCall wrap *gomock.Call
+type MockOrganizationInterfaceGetInvitationsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceGetInvitationsCall) Return(arg0 []*data.OrganizationInvitation, arg1 error) *MockOrganizationInterfaceGetInvitationsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceGetInvitationsCall) Do(f func(context.Context, uint) ([]*data.OrganizationInvitation, error)) *MockOrganizationInterfaceGetInvitationsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceGetInvitationsCall) DoAndReturn(f func(context.Context, uint) ([]*data.OrganizationInvitation, error)) *MockOrganizationInterfaceGetInvitationsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetMembership mocks base method.
+func (m *MockOrganizationInterface) GetMembership(ctx context.Context, organizationID, userID uint) (*data.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembership", ctx, organizationID, userID)
+	ret0, _ := ret[0].(*data.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembership indicates an expected call of GetMembership.
+func (mr *MockOrganizationInterfaceMockRecorder) GetMembership(ctx, organizationID, userID any) *MockOrganizationInterfaceGetMembershipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembership", reflect.TypeOf((*MockOrganizationInterface)(nil).GetMembership), ctx, organizationID, userID)
+	return &MockOrganizationInterfaceGetMembershipCall{Call: call}
+}
+
+// MockOrganizationInterfaceGetMembershipCall wrap *gomock.Call
+type MockOrganizationInterfaceGetMembershipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceGetMembershipCall) Return(arg0 *data.OrganizationMember, arg1 error) *MockOrganizationInterfaceGetMembershipCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceGetMembershipCall) Do(f func(context.Context, uint, uint) (*data.OrganizationMember, error)) *MockOrganizationInterfaceGetMembershipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceGetMembershipCall) DoAndReturn(f func(context.Context, uint, uint) (*data.OrganizationMember, error)) *MockOrganizationInterfaceGetMembershipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetMemberships mocks base method.
+func (m *MockOrganizationInterface) GetMemberships(ctx context.Context, userID uint) ([]*data.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberships", ctx, userID)
+	ret0, _ := ret[0].([]*data.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberships indicates an expected call of GetMemberships.
+func (mr *MockOrganizationInterfaceMockRecorder) GetMemberships(ctx, userID any) *MockOrganizationInterfaceGetMembershipsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberships", reflect.TypeOf((*MockOrganizationInterface)(nil).GetMemberships), ctx, userID)
+	return &MockOrganizationInterfaceGetMembershipsCall{Call: call}
+}
+
+// MockOrganizationInterfaceGetMembershipsCall wrap *gomock.Call
+type MockOrganizationInterfaceGetMembershipsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceGetMembershipsCall) Return(arg0 []*data.OrganizationMember, arg1 error) *MockOrganizationInterfaceGetMembershipsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceGetMembershipsCall) Do(f func(context.Context, uint) ([]*data.OrganizationMember, error)) *MockOrganizationInterfaceGetMembershipsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceGetMembershipsCall) DoAndReturn(f func(context.Context, uint) ([]*data.OrganizationMember, error)) *MockOrganizationInterfaceGetMembershipsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockOrganizationInterface) GetOne(ctx context.Context, id uint) (*data.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id)
+	ret0, _ := ret[0].(*data.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockOrganizationInterfaceMockRecorder) GetOne(ctx, id any) *MockOrganizationInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockOrganizationInterface)(nil).GetOne), ctx, id)
+	return &MockOrganizationInterfaceGetOneCall{Call: call}
+}
+
+// MockOrganizationInterfaceGetOneCall wrap *gomock.Call
+type MockOrganizationInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceGetOneCall) Return(arg0 *data.Organization, arg1 error) *MockOrganizationInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceGetOneCall) Do(f func(context.Context, uint) (*data.Organization, error)) *MockOrganizationInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint) (*data.Organization, error)) *MockOrganizationInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPermissionHolders mocks base method.
+func (m *MockOrganizationInterface) GetPermissionHolders(ctx context.Context, ownerID uint, permission data.Permission) ([]*data.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPermissionHolders", ctx, ownerID, permission)
+	ret0, _ := ret[0].([]*data.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPermissionHolders indicates an expected call of GetPermissionHolders.
+func (mr *MockOrganizationInterfaceMockRecorder) GetPermissionHolders(ctx, ownerID, permission any) *MockOrganizationInterfaceGetPermissionHoldersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermissionHolders", reflect.TypeOf((*MockOrganizationInterface)(nil).GetPermissionHolders), ctx, ownerID, permission)
+	return &MockOrganizationInterfaceGetPermissionHoldersCall{Call: call}
+}
+
+// MockOrganizationInterfaceGetPermissionHoldersCall wrap *gomock.Call
+type MockOrganizationInterfaceGetPermissionHoldersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceGetPermissionHoldersCall) Return(arg0 []*data.User, arg1 error) *MockOrganizationInterfaceGetPermissionHoldersCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceGetPermissionHoldersCall) Do(f func(context.Context, uint, data.Permission) ([]*data.User, error)) *MockOrganizationInterfaceGetPermissionHoldersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceGetPermissionHoldersCall) DoAndReturn(f func(context.Context, uint, data.Permission) ([]*data.User, error)) *MockOrganizationInterfaceGetPermissionHoldersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetRolePermissions mocks base method.
+func (m *MockOrganizationInterface) GetRolePermissions(ctx context.Context, organizationID uint, role data.OrganizationRole) (*data.RolePermissions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRolePermissions", ctx, organizationID, role)
+	ret0, _ := ret[0].(*data.RolePermissions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRolePermissions indicates an expected call of GetRolePermissions.
+func (mr *MockOrganizationInterfaceMockRecorder) GetRolePermissions(ctx, organizationID, role any) *MockOrganizationInterfaceGetRolePermissionsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolePermissions", reflect.TypeOf((*MockOrganizationInterface)(nil).GetRolePermissions), ctx, organizationID, role)
+	return &MockOrganizationInterfaceGetRolePermissionsCall{Call: call}
+}
+
+// MockOrganizationInterfaceGetRolePermissionsCall wrap *gomock.Call
+type MockOrganizationInterfaceGetRolePermissionsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceGetRolePermissionsCall) Return(arg0 *data.RolePermissions, arg1 error) *MockOrganizationInterfaceGetRolePermissionsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceGetRolePermissionsCall) Do(f func(context.Context, uint, data.OrganizationRole) (*data.RolePermissions, error)) *MockOrganizationInterfaceGetRolePermissionsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceGetRolePermissionsCall) DoAndReturn(f func(context.Context, uint, data.OrganizationRole) (*data.RolePermissions, error)) *MockOrganizationInterfaceGetRolePermissionsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// HasMember mocks base method.
+func (m *MockOrganizationInterface) HasMember(ctx context.Context, ownerID, userID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasMember", ctx, ownerID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasMember indicates an expected call of HasMember.
+func (mr *MockOrganizationInterfaceMockRecorder) HasMember(ctx, ownerID, userID any) *MockOrganizationInterfaceHasMemberCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasMember", reflect.TypeOf((*MockOrganizationInterface)(nil).HasMember), ctx, ownerID, userID)
+	return &MockOrganizationInterfaceHasMemberCall{Call: call}
+}
+
+// MockOrganizationInterfaceHasMemberCall wrap *gomock.Call
+type MockOrganizationInterfaceHasMemberCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceHasMemberCall) Return(arg0 bool, arg1 error) *MockOrganizationInterfaceHasMemberCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceHasMemberCall) Do(f func(context.Context, uint, uint) (bool, error)) *MockOrganizationInterfaceHasMemberCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceHasMemberCall) DoAndReturn(f func(context.Context, uint, uint) (bool, error)) *MockOrganizationInterfaceHasMemberCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RemoveMember mocks base method.
+func (m *MockOrganizationInterface) RemoveMember(ctx context.Context, organizationID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, organizationID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockOrganizationInterfaceMockRecorder) RemoveMember(ctx, organizationID, userID any) *MockOrganizationInterfaceRemoveMemberCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockOrganizationInterface)(nil).RemoveMember), ctx, organizationID, userID)
+	return &MockOrganizationInterfaceRemoveMemberCall{Call: call}
+}
+
+// MockOrganizationInterfaceRemoveMemberCall wrap *gomock.Call
+type MockOrganizationInterfaceRemoveMemberCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceRemoveMemberCall) Return(arg0 error) *MockOrganizationInterfaceRemoveMemberCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceRemoveMemberCall) Do(f func(context.Context, uint, uint) error) *MockOrganizationInterfaceRemoveMemberCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceRemoveMemberCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockOrganizationInterfaceRemoveMemberCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ResetRolePermissions mocks base method.
+func (m *MockOrganizationInterface) ResetRolePermissions(ctx context.Context, organizationID uint, role data.OrganizationRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetRolePermissions", ctx, organizationID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetRolePermissions indicates an expected call of ResetRolePermissions.
+func (mr *MockOrganizationInterfaceMockRecorder) ResetRolePermissions(ctx, organizationID, role any) *MockOrganizationInterfaceResetRolePermissionsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetRolePermissions", reflect.TypeOf((*MockOrganizationInterface)(nil).ResetRolePermissions), ctx, organizationID, role)
+	return &MockOrganizationInterfaceResetRolePermissionsCall{Call: call}
+}
+
+// MockOrganizationInterfaceResetRolePermissionsCall wrap *gomock.Call
+type MockOrganizationInterfaceResetRolePermissionsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceResetRolePermissionsCall) Return(arg0 error) *MockOrganizationInterfaceResetRolePermissionsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceResetRolePermissionsCall) Do(f func(context.Context, uint, data.OrganizationRole) error) *MockOrganizationInterfaceResetRolePermissionsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceResetRolePermissionsCall) DoAndReturn(f func(context.Context, uint, data.OrganizationRole) error) *MockOrganizationInterfaceResetRolePermissionsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SetInvitationDelivery mocks base method.
+func (m *MockOrganizationInterface) SetInvitationDelivery(ctx context.Context, id, deliveryID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetInvitationDelivery", ctx, id, deliveryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetInvitationDelivery indicates an expected call of SetInvitationDelivery.
+func (mr *MockOrganizationInterfaceMockRecorder) SetInvitationDelivery(ctx, id, deliveryID any) *MockOrganizationInterfaceSetInvitationDeliveryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInvitationDelivery", reflect.TypeOf((*MockOrganizationInterface)(nil).SetInvitationDelivery), ctx, id, deliveryID)
+	return &MockOrganizationInterfaceSetInvitationDeliveryCall{Call: call}
+}
+
+// MockOrganizationInterfaceSetInvitationDeliveryCall wrap *gomock.Call
+type MockOrganizationInterfaceSetInvitationDeliveryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceSetInvitationDeliveryCall) Return(arg0 error) *MockOrganizationInterfaceSetInvitationDeliveryCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceSetInvitationDeliveryCall) Do(f func(context.Context, uint, uint) error) *MockOrganizationInterfaceSetInvitationDeliveryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceSetInvitationDeliveryCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockOrganizationInterfaceSetInvitationDeliveryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SetRolePermissions mocks base method.
+func (m *MockOrganizationInterface) SetRolePermissions(ctx context.Context, organizationID uint, role data.OrganizationRole, permissions []data.Permission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRolePermissions", ctx, organizationID, role, permissions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRolePermissions indicates an expected call of SetRolePermissions.
+func (mr *MockOrganizationInterfaceMockRecorder) SetRolePermissions(ctx, organizationID, role, permissions any) *MockOrganizationInterfaceSetRolePermissionsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRolePermissions", reflect.TypeOf((*MockOrganizationInterface)(nil).SetRolePermissions), ctx, organizationID, role, permissions)
+	return &MockOrganizationInterfaceSetRolePermissionsCall{Call: call}
+}
+
+// MockOrganizationInterfaceSetRolePermissionsCall wrap *gomock.Call
+type MockOrganizationInterfaceSetRolePermissionsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceSetRolePermissionsCall) Return(arg0 error) *MockOrganizationInterfaceSetRolePermissionsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceSetRolePermissionsCall) Do(f func(context.Context, uint, data.OrganizationRole, []data.Permission) error) *MockOrganizationInterfaceSetRolePermissionsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceSetRolePermissionsCall) DoAndReturn(f func(context.Context, uint, data.OrganizationRole, []data.Permission) error) *MockOrganizationInterfaceSetRolePermissionsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockOrganizationInterface) Update(ctx context.Context, organization *data.Organization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, organization)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockOrganizationInterfaceMockRecorder) Update(ctx, organization any) *MockOrganizationInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOrganizationInterface)(nil).Update), ctx, organization)
+	return &MockOrganizationInterfaceUpdateCall{Call: call}
+}
+
+// MockOrganizationInterfaceUpdateCall wrap *gomock.Call
+type MockOrganizationInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceUpdateCall) Return(arg0 error) *MockOrganizationInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceUpdateCall) Do(f func(context.Context, *data.Organization) error) *MockOrganizationInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.Organization) error) *MockOrganizationInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateMember mocks base method.
+func (m *MockOrganizationInterface) UpdateMember(ctx context.Context, organizationID, userID uint, role data.OrganizationRole, canExport bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMember", ctx, organizationID, userID, role, canExport)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMember indicates an expected call of UpdateMember.
+func (mr *MockOrganizationInterfaceMockRecorder) UpdateMember(ctx, organizationID, userID, role, canExport any) *MockOrganizationInterfaceUpdateMemberCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMember", reflect.TypeOf((*MockOrganizationInterface)(nil).UpdateMember), ctx, organizationID, userID, role, canExport)
+	return &MockOrganizationInterfaceUpdateMemberCall{Call: call}
+}
+
+// MockOrganizationInterfaceUpdateMemberCall wrap *gomock.Call
+type MockOrganizationInterfaceUpdateMemberCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOrganizationInterfaceUpdateMemberCall) Return(arg0 error) *MockOrganizationInterfaceUpdateMemberCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOrganizationInterfaceUpdateMemberCall) Do(f func(context.Context, uint, uint, data.OrganizationRole, bool) error) *MockOrganizationInterfaceUpdateMemberCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOrganizationInterfaceUpdateMemberCall) DoAndReturn(f func(context.Context, uint, uint, data.OrganizationRole, bool) error) *MockOrganizationInterfaceUpdateMemberCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockAuditInterface is a mock of AuditInterface interface.
+type MockAuditInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditInterfaceMockRecorder is the mock recorder for MockAuditInterface.
+type MockAuditInterfaceMockRecorder struct {
+	mock *MockAuditInterface
+}
+
+// NewMockAuditInterface creates a new mock instance.
+func NewMockAuditInterface(ctrl *gomock.Controller) *MockAuditInterface {
+	mock := &MockAuditInterface{ctrl: ctrl}
+	mock.recorder = &MockAuditInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditInterface) EXPECT() *MockAuditInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockAuditInterface) GetAll(ctx context.Context, userID uint, action string) ([]*data.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID, action)
+	ret0, _ := ret[0].([]*data.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAuditInterfaceMockRecorder) GetAll(ctx, userID, action any) *MockAuditInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAuditInterface)(nil).GetAll), ctx, userID, action)
+	return &MockAuditInterfaceGetAllCall{Call: call}
+}
+
+// MockAuditInterfaceGetAllCall wrap *gomock.Call
+type MockAuditInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAuditInterfaceGetAllCall) Return(arg0 []*data.AuditLog, arg1 error) *MockAuditInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAuditInterfaceGetAllCall) Do(f func(context.Context, uint, string) ([]*data.AuditLog, error)) *MockAuditInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAuditInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint, string) ([]*data.AuditLog, error)) *MockAuditInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockAuditInterface) Insert(ctx context.Context, entry *data.AuditLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockAuditInterfaceMockRecorder) Insert(ctx, entry any) *MockAuditInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockAuditInterface)(nil).Insert), ctx, entry)
+	return &MockAuditInterfaceInsertCall{Call: call}
+}
+
+// MockAuditInterfaceInsertCall wrap *gomock.Call
+type MockAuditInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAuditInterfaceInsertCall) Return(arg0 error) *MockAuditInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAuditInterfaceInsertCall) Do(f func(context.Context, *data.AuditLog) error) *MockAuditInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAuditInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.AuditLog) error) *MockAuditInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockJobInterface is a mock of JobInterface interface.
+type MockJobInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockJobInterfaceMockRecorder is the mock recorder for MockJobInterface.
+type MockJobInterfaceMockRecorder struct {
+	mock *MockJobInterface
+}
+
+// NewMockJobInterface creates a new mock instance.
+func NewMockJobInterface(ctrl *gomock.Controller) *MockJobInterface {
+	mock := &MockJobInterface{ctrl: ctrl}
+	mock.recorder = &MockJobInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobInterface) EXPECT() *MockJobInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ClaimNext mocks base method.
+func (m *MockJobInterface) ClaimNext(ctx context.Context) (*data.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimNext", ctx)
+	ret0, _ := ret[0].(*data.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimNext indicates an expected call of ClaimNext.
+func (mr *MockJobInterfaceMockRecorder) ClaimNext(ctx any) *MockJobInterfaceClaimNextCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimNext", reflect.TypeOf((*MockJobInterface)(nil).ClaimNext), ctx)
+	return &MockJobInterfaceClaimNextCall{Call: call}
+}
+
+// MockJobInterfaceClaimNextCall wrap *gomock.Call
+type MockJobInterfaceClaimNextCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockJobInterfaceClaimNextCall) Return(arg0 *data.Job, arg1 error) *MockJobInterfaceClaimNextCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockJobInterfaceClaimNextCall) Do(f func(context.Context) (*data.Job, error)) *MockJobInterfaceClaimNextCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockJobInterfaceClaimNextCall) DoAndReturn(f func(context.Context) (*data.Job, error)) *MockJobInterfaceClaimNextCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Complete mocks base method.
+func (m *MockJobInterface) Complete(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockJobInterfaceMockRecorder) Complete(ctx, id any) *MockJobInterfaceCompleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockJobInterface)(nil).Complete), ctx, id)
+	return &MockJobInterfaceCompleteCall{Call: call}
+}
+
+// MockJobInterfaceCompleteCall wrap *gomock.Call
+type MockJobInterfaceCompleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockJobInterfaceCompleteCall) Return(arg0 error) *MockJobInterfaceCompleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockJobInterfaceCompleteCall) Do(f func(context.Context, uint) error) *MockJobInterfaceCompleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockJobInterfaceCompleteCall) DoAndReturn(f func(context.Context, uint) error) *MockJobInterfaceCompleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Enqueue mocks base method.
+func (m *MockJobInterface) Enqueue(ctx context.Context, jobType string, payload any) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", ctx, jobType, payload)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockJobInterfaceMockRecorder) Enqueue(ctx, jobType, payload any) *MockJobInterfaceEnqueueCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockJobInterface)(nil).Enqueue), ctx, jobType, payload)
+	return &MockJobInterfaceEnqueueCall{Call: call}
+}
+
+// MockJobInterfaceEnqueueCall wrap *gomock.Call
+type MockJobInterfaceEnqueueCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockJobInterfaceEnqueueCall) Return(arg0 uint, arg1 error) *MockJobInterfaceEnqueueCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockJobInterfaceEnqueueCall) Do(f func(context.Context, string, any) (uint, error)) *MockJobInterfaceEnqueueCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockJobInterfaceEnqueueCall) DoAndReturn(f func(context.Context, string, any) (uint, error)) *MockJobInterfaceEnqueueCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Fail mocks base method.
+func (m *MockJobInterface) Fail(ctx context.Context, id uint, message string, retryAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", ctx, id, message, retryAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockJobInterfaceMockRecorder) Fail(ctx, id, message, retryAt any) *MockJobInterfaceFailCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockJobInterface)(nil).Fail), ctx, id, message, retryAt)
+	return &MockJobInterfaceFailCall{Call: call}
+}
+
+// MockJobInterfaceFailCall wrap *gomock.Call
+type MockJobInterfaceFailCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockJobInterfaceFailCall) Return(arg0 error) *MockJobInterfaceFailCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockJobInterfaceFailCall) Do(f func(context.Context, uint, string, *time.Time) error) *MockJobInterfaceFailCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockJobInterfaceFailCall) DoAndReturn(f func(context.Context, uint, string, *time.Time) error) *MockJobInterfaceFailCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockDeliveryInterface is a mock of DeliveryInterface interface.
+type MockDeliveryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockDeliveryInterfaceMockRecorder is the mock recorder for MockDeliveryInterface.
+type MockDeliveryInterfaceMockRecorder struct {
+	mock *MockDeliveryInterface
+}
+
+// NewMockDeliveryInterface creates a new mock instance.
+func NewMockDeliveryInterface(ctrl *gomock.Controller) *MockDeliveryInterface {
+	mock := &MockDeliveryInterface{ctrl: ctrl}
+	mock.recorder = &MockDeliveryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryInterface) EXPECT() *MockDeliveryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockDeliveryInterface) GetAll(ctx context.Context, recipient string) ([]*data.MessageDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, recipient)
+	ret0, _ := ret[0].([]*data.MessageDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockDeliveryInterfaceMockRecorder) GetAll(ctx, recipient any) *MockDeliveryInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockDeliveryInterface)(nil).GetAll), ctx, recipient)
+	return &MockDeliveryInterfaceGetAllCall{Call: call}
+}
+
+// MockDeliveryInterfaceGetAllCall wrap *gomock.Call
+type MockDeliveryInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDeliveryInterfaceGetAllCall) Return(arg0 []*data.MessageDelivery, arg1 error) *MockDeliveryInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDeliveryInterfaceGetAllCall) Do(f func(context.Context, string) ([]*data.MessageDelivery, error)) *MockDeliveryInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDeliveryInterfaceGetAllCall) DoAndReturn(f func(context.Context, string) ([]*data.MessageDelivery, error)) *MockDeliveryInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockDeliveryInterface) Insert(ctx context.Context, delivery *data.MessageDelivery) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, delivery)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockDeliveryInterfaceMockRecorder) Insert(ctx, delivery any) *MockDeliveryInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockDeliveryInterface)(nil).Insert), ctx, delivery)
+	return &MockDeliveryInterfaceInsertCall{Call: call}
+}
+
+// MockDeliveryInterfaceInsertCall wrap *gomock.Call
+type MockDeliveryInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDeliveryInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockDeliveryInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDeliveryInterfaceInsertCall) Do(f func(context.Context, *data.MessageDelivery) (uint, error)) *MockDeliveryInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDeliveryInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.MessageDelivery) (uint, error)) *MockDeliveryInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MarkFailed mocks base method.
+func (m *MockDeliveryInterface) MarkFailed(ctx context.Context, id uint, message string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, id, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockDeliveryInterfaceMockRecorder) MarkFailed(ctx, id, message any) *MockDeliveryInterfaceMarkFailedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockDeliveryInterface)(nil).MarkFailed), ctx, id, message)
+	return &MockDeliveryInterfaceMarkFailedCall{Call: call}
+}
+
+// MockDeliveryInterfaceMarkFailedCall wrap *gomock.Call
+type MockDeliveryInterfaceMarkFailedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDeliveryInterfaceMarkFailedCall) Return(arg0 error) *MockDeliveryInterfaceMarkFailedCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDeliveryInterfaceMarkFailedCall) Do(f func(context.Context, uint, string) error) *MockDeliveryInterfaceMarkFailedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDeliveryInterfaceMarkFailedCall) DoAndReturn(f func(context.Context, uint, string) error) *MockDeliveryInterfaceMarkFailedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MarkSent mocks base method.
+func (m *MockDeliveryInterface) MarkSent(ctx context.Context, id uint, channel data.DeliveryChannel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSent", ctx, id, channel)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSent indicates an expected call of MarkSent.
+func (mr *MockDeliveryInterfaceMockRecorder) MarkSent(ctx, id, channel any) *MockDeliveryInterfaceMarkSentCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSent", reflect.TypeOf((*MockDeliveryInterface)(nil).MarkSent), ctx, id, channel)
+	return &MockDeliveryInterfaceMarkSentCall{Call: call}
+}
+
+// MockDeliveryInterfaceMarkSentCall wrap *gomock.Call
+type MockDeliveryInterfaceMarkSentCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDeliveryInterfaceMarkSentCall) Return(arg0 error) *MockDeliveryInterfaceMarkSentCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDeliveryInterfaceMarkSentCall) Do(f func(context.Context, uint, data.DeliveryChannel) error) *MockDeliveryInterfaceMarkSentCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDeliveryInterfaceMarkSentCall) DoAndReturn(f func(context.Context, uint, data.DeliveryChannel) error) *MockDeliveryInterfaceMarkSentCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockInviteCodeInterface is a mock of InviteCodeInterface interface.
+type MockInviteCodeInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockInviteCodeInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockInviteCodeInterfaceMockRecorder is the mock recorder for MockInviteCodeInterface.
+type MockInviteCodeInterfaceMockRecorder struct {
+	mock *MockInviteCodeInterface
+}
+
+// NewMockInviteCodeInterface creates a new mock instance.
+func NewMockInviteCodeInterface(ctrl *gomock.Controller) *MockInviteCodeInterface {
+	mock := &MockInviteCodeInterface{ctrl: ctrl}
+	mock.recorder = &MockInviteCodeInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInviteCodeInterface) EXPECT() *MockInviteCodeInterfaceMockRecorder {
+	return m.recorder
+}
+
+// EnsureCode mocks base method.
+func (m *MockInviteCodeInterface) EnsureCode(ctx context.Context, code string, role data.UserRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureCode", ctx, code, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureCode indicates an expected call of EnsureCode.
+func (mr *MockInviteCodeInterfaceMockRecorder) EnsureCode(ctx, code, role any) *MockInviteCodeInterfaceEnsureCodeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureCode", reflect.TypeOf((*MockInviteCodeInterface)(nil).EnsureCode), ctx, code, role)
+	return &MockInviteCodeInterfaceEnsureCodeCall{Call: call}
+}
+
+// MockInviteCodeInterfaceEnsureCodeCall wrap *gomock.Call
+type MockInviteCodeInterfaceEnsureCodeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInviteCodeInterfaceEnsureCodeCall) Return(arg0 error) *MockInviteCodeInterfaceEnsureCodeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInviteCodeInterfaceEnsureCodeCall) Do(f func(context.Context, string, data.UserRole) error) *MockInviteCodeInterfaceEnsureCodeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInviteCodeInterfaceEnsureCodeCall) DoAndReturn(f func(context.Context, string, data.UserRole) error) *MockInviteCodeInterfaceEnsureCodeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockInviteCodeInterface) GetAll(ctx context.Context) ([]*data.InviteCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*data.InviteCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockInviteCodeInterfaceMockRecorder) GetAll(ctx any) *MockInviteCodeInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockInviteCodeInterface)(nil).GetAll), ctx)
+	return &MockInviteCodeInterfaceGetAllCall{Call: call}
+}
+
+// MockInviteCodeInterfaceGetAllCall wrap *gomock.Call
+type MockInviteCodeInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInviteCodeInterfaceGetAllCall) Return(arg0 []*data.InviteCode, arg1 error) *MockInviteCodeInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInviteCodeInterfaceGetAllCall) Do(f func(context.Context) ([]*data.InviteCode, error)) *MockInviteCodeInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInviteCodeInterfaceGetAllCall) DoAndReturn(f func(context.Context) ([]*data.InviteCode, error)) *MockInviteCodeInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockInviteCodeInterface) Insert(ctx context.Context, code *data.InviteCode) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, code)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockInviteCodeInterfaceMockRecorder) Insert(ctx, code any) *MockInviteCodeInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockInviteCodeInterface)(nil).Insert), ctx, code)
+	return &MockInviteCodeInterfaceInsertCall{Call: call}
+}
+
+// MockInviteCodeInterfaceInsertCall wrap *gomock.Call
+type MockInviteCodeInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInviteCodeInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockInviteCodeInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInviteCodeInterfaceInsertCall) Do(f func(context.Context, *data.InviteCode) (uint, error)) *MockInviteCodeInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInviteCodeInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.InviteCode) (uint, error)) *MockInviteCodeInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Redeem mocks base method.
+func (m *MockInviteCodeInterface) Redeem(ctx context.Context, code string) (*data.InviteCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeem", ctx, code)
+	ret0, _ := ret[0].(*data.InviteCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeem indicates an expected call of Redeem.
+func (mr *MockInviteCodeInterfaceMockRecorder) Redeem(ctx, code any) *MockInviteCodeInterfaceRedeemCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeem", reflect.TypeOf((*MockInviteCodeInterface)(nil).Redeem), ctx, code)
+	return &MockInviteCodeInterfaceRedeemCall{Call: call}
+}
+
+// MockInviteCodeInterfaceRedeemCall wrap *gomock.Call
+type MockInviteCodeInterfaceRedeemCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInviteCodeInterfaceRedeemCall) Return(arg0 *data.InviteCode, arg1 error) *MockInviteCodeInterfaceRedeemCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInviteCodeInterfaceRedeemCall) Do(f func(context.Context, string) (*data.InviteCode, error)) *MockInviteCodeInterfaceRedeemCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInviteCodeInterfaceRedeemCall) DoAndReturn(f func(context.Context, string) (*data.InviteCode, error)) *MockInviteCodeInterfaceRedeemCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Release mocks base method.
+func (m *MockInviteCodeInterface) Release(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockInviteCodeInterfaceMockRecorder) Release(ctx, id any) *MockInviteCodeInterfaceReleaseCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockInviteCodeInterface)(nil).Release), ctx, id)
+	return &MockInviteCodeInterfaceReleaseCall{Call: call}
+}
+
+// MockInviteCodeInterfaceReleaseCall wrap *gomock.Call
+type MockInviteCodeInterfaceReleaseCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInviteCodeInterfaceReleaseCall) Return(arg0 error) *MockInviteCodeInterfaceReleaseCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInviteCodeInterfaceReleaseCall) Do(f func(context.Context, uint) error) *MockInviteCodeInterfaceReleaseCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInviteCodeInterfaceReleaseCall) DoAndReturn(f func(context.Context, uint) error) *MockInviteCodeInterfaceReleaseCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Revoke mocks base method.
+func (m *MockInviteCodeInterface) Revoke(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockInviteCodeInterfaceMockRecorder) Revoke(ctx, id any) *MockInviteCodeInterfaceRevokeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockInviteCodeInterface)(nil).Revoke), ctx, id)
+	return &MockInviteCodeInterfaceRevokeCall{Call: call}
+}
+
+// MockInviteCodeInterfaceRevokeCall wrap *gomock.Call
+type MockInviteCodeInterfaceRevokeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockInviteCodeInterfaceRevokeCall) Return(arg0 error) *MockInviteCodeInterfaceRevokeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockInviteCodeInterfaceRevokeCall) Do(f func(context.Context, uint) error) *MockInviteCodeInterfaceRevokeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockInviteCodeInterfaceRevokeCall) DoAndReturn(f func(context.Context, uint) error) *MockInviteCodeInterfaceRevokeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockIdentityInterface is a mock of IdentityInterface interface.
+type MockIdentityInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockIdentityInterfaceMockRecorder is the mock recorder for MockIdentityInterface.
+type MockIdentityInterfaceMockRecorder struct {
+	mock *MockIdentityInterface
+}
+
+// NewMockIdentityInterface creates a new mock instance.
+func NewMockIdentityInterface(ctrl *gomock.Controller) *MockIdentityInterface {
+	mock := &MockIdentityInterface{ctrl: ctrl}
+	mock.recorder = &MockIdentityInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentityInterface) EXPECT() *MockIdentityInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetByProvider mocks base method.
+func (m *MockIdentityInterface) GetByProvider(ctx context.Context, provider data.IdentityProvider, subject string) (*data.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProvider", ctx, provider, subject)
+	ret0, _ := ret[0].(*data.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProvider indicates an expected call of GetByProvider.
+func (mr *MockIdentityInterfaceMockRecorder) GetByProvider(ctx, provider, subject any) *MockIdentityInterfaceGetByProviderCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProvider", reflect.TypeOf((*MockIdentityInterface)(nil).GetByProvider), ctx, provider, subject)
+	return &MockIdentityInterfaceGetByProviderCall{Call: call}
+}
+
+// MockIdentityInterfaceGetByProviderCall wrap *gomock.Call
+type MockIdentityInterfaceGetByProviderCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIdentityInterfaceGetByProviderCall) Return(arg0 *data.UserIdentity, arg1 error) *MockIdentityInterfaceGetByProviderCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIdentityInterfaceGetByProviderCall) Do(f func(context.Context, data.IdentityProvider, string) (*data.UserIdentity, error)) *MockIdentityInterfaceGetByProviderCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIdentityInterfaceGetByProviderCall) DoAndReturn(f func(context.Context, data.IdentityProvider, string) (*data.UserIdentity, error)) *MockIdentityInterfaceGetByProviderCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByUserID mocks base method.
+func (m *MockIdentityInterface) GetByUserID(ctx context.Context, userID uint) ([]*data.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*data.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockIdentityInterfaceMockRecorder) GetByUserID(ctx, userID any) *MockIdentityInterfaceGetByUserIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockIdentityInterface)(nil).GetByUserID), ctx, userID)
+	return &MockIdentityInterfaceGetByUserIDCall{Call: call}
+}
+
+// MockIdentityInterfaceGetByUserIDCall wrap *gomock.Call
+type MockIdentityInterfaceGetByUserIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIdentityInterfaceGetByUserIDCall) Return(arg0 []*data.UserIdentity, arg1 error) *MockIdentityInterfaceGetByUserIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIdentityInterfaceGetByUserIDCall) Do(f func(context.Context, uint) ([]*data.UserIdentity, error)) *MockIdentityInterfaceGetByUserIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIdentityInterfaceGetByUserIDCall) DoAndReturn(f func(context.Context, uint) ([]*data.UserIdentity, error)) *MockIdentityInterfaceGetByUserIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Link mocks base method.
+func (m *MockIdentityInterface) Link(ctx context.Context, identity *data.UserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Link", ctx, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Link indicates an expected call of Link.
+func (mr *MockIdentityInterfaceMockRecorder) Link(ctx, identity any) *MockIdentityInterfaceLinkCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Link", reflect.TypeOf((*MockIdentityInterface)(nil).Link), ctx, identity)
+	return &MockIdentityInterfaceLinkCall{Call: call}
+}
+
+// MockIdentityInterfaceLinkCall wrap *gomock.Call
+type MockIdentityInterfaceLinkCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIdentityInterfaceLinkCall) Return(arg0 error) *MockIdentityInterfaceLinkCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIdentityInterfaceLinkCall) Do(f func(context.Context, *data.UserIdentity) error) *MockIdentityInterfaceLinkCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIdentityInterfaceLinkCall) DoAndReturn(f func(context.Context, *data.UserIdentity) error) *MockIdentityInterfaceLinkCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Unlink mocks base method.
+func (m *MockIdentityInterface) Unlink(ctx context.Context, userID uint, provider data.IdentityProvider) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlink", ctx, userID, provider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlink indicates an expected call of Unlink.
+func (mr *MockIdentityInterfaceMockRecorder) Unlink(ctx, userID, provider any) *MockIdentityInterfaceUnlinkCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlink", reflect.TypeOf((*MockIdentityInterface)(nil).Unlink), ctx, userID, provider)
+	return &MockIdentityInterfaceUnlinkCall{Call: call}
+}
+
+// MockIdentityInterfaceUnlinkCall wrap *gomock.Call
+type MockIdentityInterfaceUnlinkCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIdentityInterfaceUnlinkCall) Return(arg0 error) *MockIdentityInterfaceUnlinkCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIdentityInterfaceUnlinkCall) Do(f func(context.Context, uint, data.IdentityProvider) error) *MockIdentityInterfaceUnlinkCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIdentityInterfaceUnlinkCall) DoAndReturn(f func(context.Context, uint, data.IdentityProvider) error) *MockIdentityInterfaceUnlinkCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockRefreshTokenInterface is a mock of RefreshTokenInterface interface.
+type MockRefreshTokenInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefreshTokenInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockRefreshTokenInterfaceMockRecorder is the mock recorder for MockRefreshTokenInterface.
+type MockRefreshTokenInterfaceMockRecorder struct {
+	mock *MockRefreshTokenInterface
+}
+
+// NewMockRefreshTokenInterface creates a new mock instance.
+func NewMockRefreshTokenInterface(ctrl *gomock.Controller) *MockRefreshTokenInterface {
+	mock := &MockRefreshTokenInterface{ctrl: ctrl}
+	mock.recorder = &MockRefreshTokenInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefreshTokenInterface) EXPECT() *MockRefreshTokenInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteExpiredBefore mocks base method.
+func (m *MockRefreshTokenInterface) DeleteExpiredBefore(ctx context.Context, t time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredBefore", ctx, t)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredBefore indicates an expected call of DeleteExpiredBefore.
+func (mr *MockRefreshTokenInterfaceMockRecorder) DeleteExpiredBefore(ctx, t any) *MockRefreshTokenInterfaceDeleteExpiredBeforeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredBefore", reflect.TypeOf((*MockRefreshTokenInterface)(nil).DeleteExpiredBefore), ctx, t)
+	return &MockRefreshTokenInterfaceDeleteExpiredBeforeCall{Call: call}
+}
+
+// MockRefreshTokenInterfaceDeleteExpiredBeforeCall wrap *gomock.Call
+type MockRefreshTokenInterfaceDeleteExpiredBeforeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRefreshTokenInterfaceDeleteExpiredBeforeCall) Return(arg0 int64, arg1 error) *MockRefreshTokenInterfaceDeleteExpiredBeforeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRefreshTokenInterfaceDeleteExpiredBeforeCall) Do(f func(context.Context, time.Time) (int64, error)) *MockRefreshTokenInterfaceDeleteExpiredBeforeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRefreshTokenInterfaceDeleteExpiredBeforeCall) DoAndReturn(f func(context.Context, time.Time) (int64, error)) *MockRefreshTokenInterfaceDeleteExpiredBeforeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockRefreshTokenInterface) Insert(ctx context.Context, token *data.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockRefreshTokenInterfaceMockRecorder) Insert(ctx, token any) *MockRefreshTokenInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockRefreshTokenInterface)(nil).Insert), ctx, token)
+	return &MockRefreshTokenInterfaceInsertCall{Call: call}
+}
+
+// MockRefreshTokenInterfaceInsertCall wrap *gomock.Call
+type MockRefreshTokenInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRefreshTokenInterfaceInsertCall) Return(arg0 error) *MockRefreshTokenInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRefreshTokenInterfaceInsertCall) Do(f func(context.Context, *data.RefreshToken) error) *MockRefreshTokenInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRefreshTokenInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.RefreshToken) error) *MockRefreshTokenInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Revoke mocks base method.
+func (m *MockRefreshTokenInterface) Revoke(ctx context.Context, tokenHash string, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, tokenHash, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockRefreshTokenInterfaceMockRecorder) Revoke(ctx, tokenHash, userID any) *MockRefreshTokenInterfaceRevokeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockRefreshTokenInterface)(nil).Revoke), ctx, tokenHash, userID)
+	return &MockRefreshTokenInterfaceRevokeCall{Call: call}
+}
+
+// MockRefreshTokenInterfaceRevokeCall wrap *gomock.Call
+type MockRefreshTokenInterfaceRevokeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRefreshTokenInterfaceRevokeCall) Return(arg0 error) *MockRefreshTokenInterfaceRevokeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRefreshTokenInterfaceRevokeCall) Do(f func(context.Context, string, uint) error) *MockRefreshTokenInterfaceRevokeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRefreshTokenInterfaceRevokeCall) DoAndReturn(f func(context.Context, string, uint) error) *MockRefreshTokenInterfaceRevokeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeAllForUser mocks base method.
+func (m *MockRefreshTokenInterface) RevokeAllForUser(ctx context.Context, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllForUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAllForUser indicates an expected call of RevokeAllForUser.
+func (mr *MockRefreshTokenInterfaceMockRecorder) RevokeAllForUser(ctx, userID any) *MockRefreshTokenInterfaceRevokeAllForUserCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllForUser", reflect.TypeOf((*MockRefreshTokenInterface)(nil).RevokeAllForUser), ctx, userID)
+	return &MockRefreshTokenInterfaceRevokeAllForUserCall{Call: call}
+}
+
+// MockRefreshTokenInterfaceRevokeAllForUserCall wrap *gomock.Call
+type MockRefreshTokenInterfaceRevokeAllForUserCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRefreshTokenInterfaceRevokeAllForUserCall) Return(arg0 error) *MockRefreshTokenInterfaceRevokeAllForUserCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRefreshTokenInterfaceRevokeAllForUserCall) Do(f func(context.Context, uint) error) *MockRefreshTokenInterfaceRevokeAllForUserCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRefreshTokenInterfaceRevokeAllForUserCall) DoAndReturn(f func(context.Context, uint) error) *MockRefreshTokenInterfaceRevokeAllForUserCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Rotate mocks base method.
+func (m *MockRefreshTokenInterface) Rotate(ctx context.Context, tokenHash string, replacement *data.RefreshToken) (*data.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", ctx, tokenHash, replacement)
+	ret0, _ := ret[0].(*data.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockRefreshTokenInterfaceMockRecorder) Rotate(ctx, tokenHash, replacement any) *MockRefreshTokenInterfaceRotateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockRefreshTokenInterface)(nil).Rotate), ctx, tokenHash, replacement)
+	return &MockRefreshTokenInterfaceRotateCall{Call: call}
+}
+
+// MockRefreshTokenInterfaceRotateCall wrap *gomock.Call
+type MockRefreshTokenInterfaceRotateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRefreshTokenInterfaceRotateCall) Return(arg0 *data.RefreshToken, arg1 error) *MockRefreshTokenInterfaceRotateCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRefreshTokenInterfaceRotateCall) Do(f func(context.Context, string, *data.RefreshToken) (*data.RefreshToken, error)) *MockRefreshTokenInterfaceRotateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRefreshTokenInterfaceRotateCall) DoAndReturn(f func(context.Context, string, *data.RefreshToken) (*data.RefreshToken, error)) *MockRefreshTokenInterfaceRotateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockRevokedTokenInterface is a mock of RevokedTokenInterface interface.
+type MockRevokedTokenInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevokedTokenInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockRevokedTokenInterfaceMockRecorder is the mock recorder for MockRevokedTokenInterface.
+type MockRevokedTokenInterfaceMockRecorder struct {
+	mock *MockRevokedTokenInterface
+}
+
+// NewMockRevokedTokenInterface creates a new mock instance.
+func NewMockRevokedTokenInterface(ctrl *gomock.Controller) *MockRevokedTokenInterface {
+	mock := &MockRevokedTokenInterface{ctrl: ctrl}
+	mock.recorder = &MockRevokedTokenInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevokedTokenInterface) EXPECT() *MockRevokedTokenInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteExpiredBefore mocks base method.
+func (m *MockRevokedTokenInterface) DeleteExpiredBefore(ctx context.Context, t time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredBefore", ctx, t)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredBefore indicates an expected call of DeleteExpiredBefore.
+func (mr *MockRevokedTokenInterfaceMockRecorder) DeleteExpiredBefore(ctx, t any) *MockRevokedTokenInterfaceDeleteExpiredBeforeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredBefore", reflect.TypeOf((*MockRevokedTokenInterface)(nil).DeleteExpiredBefore), ctx, t)
+	return &MockRevokedTokenInterfaceDeleteExpiredBeforeCall{Call: call}
+}
+
+// MockRevokedTokenInterfaceDeleteExpiredBeforeCall wrap *gomock.Call
+type MockRevokedTokenInterfaceDeleteExpiredBeforeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRevokedTokenInterfaceDeleteExpiredBeforeCall) Return(arg0 int64, arg1 error) *MockRevokedTokenInterfaceDeleteExpiredBeforeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRevokedTokenInterfaceDeleteExpiredBeforeCall) Do(f func(context.Context, time.Time) (int64, error)) *MockRevokedTokenInterfaceDeleteExpiredBeforeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRevokedTokenInterfaceDeleteExpiredBeforeCall) DoAndReturn(f func(context.Context, time.Time) (int64, error)) *MockRevokedTokenInterfaceDeleteExpiredBeforeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// IsRevoked mocks base method.
+func (m *MockRevokedTokenInterface) IsRevoked(ctx context.Context, tokenHash string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRevoked", ctx, tokenHash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsRevoked indicates an expected call of IsRevoked.
+func (mr *MockRevokedTokenInterfaceMockRecorder) IsRevoked(ctx, tokenHash any) *MockRevokedTokenInterfaceIsRevokedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRevoked", reflect.TypeOf((*MockRevokedTokenInterface)(nil).IsRevoked), ctx, tokenHash)
+	return &MockRevokedTokenInterfaceIsRevokedCall{Call: call}
+}
+
+// MockRevokedTokenInterfaceIsRevokedCall wrap *gomock.Call
+type MockRevokedTokenInterfaceIsRevokedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRevokedTokenInterfaceIsRevokedCall) Return(arg0 bool, arg1 error) *MockRevokedTokenInterfaceIsRevokedCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRevokedTokenInterfaceIsRevokedCall) Do(f func(context.Context, string) (bool, error)) *MockRevokedTokenInterfaceIsRevokedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRevokedTokenInterfaceIsRevokedCall) DoAndReturn(f func(context.Context, string) (bool, error)) *MockRevokedTokenInterfaceIsRevokedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Revoke mocks base method.
+func (m *MockRevokedTokenInterface) Revoke(ctx context.Context, token *data.RevokedToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockRevokedTokenInterfaceMockRecorder) Revoke(ctx, token any) *MockRevokedTokenInterfaceRevokeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockRevokedTokenInterface)(nil).Revoke), ctx, token)
+	return &MockRevokedTokenInterfaceRevokeCall{Call: call}
+}
+
+// MockRevokedTokenInterfaceRevokeCall wrap *gomock.Call
+type MockRevokedTokenInterfaceRevokeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRevokedTokenInterfaceRevokeCall) Return(arg0 error) *MockRevokedTokenInterfaceRevokeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRevokedTokenInterfaceRevokeCall) Do(f func(context.Context, *data.RevokedToken) error) *MockRevokedTokenInterfaceRevokeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRevokedTokenInterfaceRevokeCall) DoAndReturn(f func(context.Context, *data.RevokedToken) error) *MockRevokedTokenInterfaceRevokeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockShareLinkInterface is a mock of ShareLinkInterface interface.
+type MockShareLinkInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockShareLinkInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockShareLinkInterfaceMockRecorder is the mock recorder for MockShareLinkInterface.
+type MockShareLinkInterfaceMockRecorder struct {
+	mock *MockShareLinkInterface
+}
+
+// NewMockShareLinkInterface creates a new mock instance.
+func NewMockShareLinkInterface(ctrl *gomock.Controller) *MockShareLinkInterface {
+	mock := &MockShareLinkInterface{ctrl: ctrl}
+	mock.recorder = &MockShareLinkInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShareLinkInterface) EXPECT() *MockShareLinkInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockShareLinkInterface) Confirm(ctx context.Context, id uint, confirmedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", ctx, id, confirmedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockShareLinkInterfaceMockRecorder) Confirm(ctx, id, confirmedBy any) *MockShareLinkInterfaceConfirmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockShareLinkInterface)(nil).Confirm), ctx, id, confirmedBy)
+	return &MockShareLinkInterfaceConfirmCall{Call: call}
+}
+
+// MockShareLinkInterfaceConfirmCall wrap *gomock.Call
+type MockShareLinkInterfaceConfirmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockShareLinkInterfaceConfirmCall) Return(arg0 error) *MockShareLinkInterfaceConfirmCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockShareLinkInterfaceConfirmCall) Do(f func(context.Context, uint, string) error) *MockShareLinkInterfaceConfirmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockShareLinkInterfaceConfirmCall) DoAndReturn(f func(context.Context, uint, string) error) *MockShareLinkInterfaceConfirmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetActive mocks base method.
+func (m *MockShareLinkInterface) GetActive(ctx context.Context, id uint) (*data.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActive", ctx, id)
+	ret0, _ := ret[0].(*data.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActive indicates an expected call of GetActive.
+func (mr *MockShareLinkInterfaceMockRecorder) GetActive(ctx, id any) *MockShareLinkInterfaceGetActiveCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActive", reflect.TypeOf((*MockShareLinkInterface)(nil).GetActive), ctx, id)
+	return &MockShareLinkInterfaceGetActiveCall{Call: call}
+}
+
+// MockShareLinkInterfaceGetActiveCall wrap *gomock.Call
+type MockShareLinkInterfaceGetActiveCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockShareLinkInterfaceGetActiveCall) Return(arg0 *data.ShareLink, arg1 error) *MockShareLinkInterfaceGetActiveCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockShareLinkInterfaceGetActiveCall) Do(f func(context.Context, uint) (*data.ShareLink, error)) *MockShareLinkInterfaceGetActiveCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockShareLinkInterfaceGetActiveCall) DoAndReturn(f func(context.Context, uint) (*data.ShareLink, error)) *MockShareLinkInterfaceGetActiveCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockShareLinkInterface) GetAll(ctx context.Context, userID uint) ([]*data.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
+	ret0, _ := ret[0].([]*data.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockShareLinkInterfaceMockRecorder) GetAll(ctx, userID any) *MockShareLinkInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockShareLinkInterface)(nil).GetAll), ctx, userID)
+	return &MockShareLinkInterfaceGetAllCall{Call: call}
+}
+
+// MockShareLinkInterfaceGetAllCall wrap *gomock.Call
+type MockShareLinkInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockShareLinkInterfaceGetAllCall) Return(arg0 []*data.ShareLink, arg1 error) *MockShareLinkInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockShareLinkInterfaceGetAllCall) Do(f func(context.Context, uint) ([]*data.ShareLink, error)) *MockShareLinkInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockShareLinkInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint) ([]*data.ShareLink, error)) *MockShareLinkInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockShareLinkInterface) GetOne(ctx context.Context, id, userID uint) (*data.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockShareLinkInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockShareLinkInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockShareLinkInterface)(nil).GetOne), ctx, id, userID)
+	return &MockShareLinkInterfaceGetOneCall{Call: call}
+}
+
+// MockShareLinkInterfaceGetOneCall wrap *gomock.Call
+type MockShareLinkInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockShareLinkInterfaceGetOneCall) Return(arg0 *data.ShareLink, arg1 error) *MockShareLinkInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockShareLinkInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.ShareLink, error)) *MockShareLinkInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockShareLinkInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.ShareLink, error)) *MockShareLinkInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetViews mocks base method.
+func (m *MockShareLinkInterface) GetViews(ctx context.Context, id, userID uint) ([]*data.ShareLinkView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetViews", ctx, id, userID)
+	ret0, _ := ret[0].([]*data.ShareLinkView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetViews indicates an expected call of GetViews.
+func (mr *MockShareLinkInterfaceMockRecorder) GetViews(ctx, id, userID any) *MockShareLinkInterfaceGetViewsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetViews", reflect.TypeOf((*MockShareLinkInterface)(nil).GetViews), ctx, id, userID)
+	return &MockShareLinkInterfaceGetViewsCall{Call: call}
+}
+
+// MockShareLinkInterfaceGetViewsCall wrap *gomock.Call
+type MockShareLinkInterfaceGetViewsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockShareLinkInterfaceGetViewsCall) Return(arg0 []*data.ShareLinkView, arg1 error) *MockShareLinkInterfaceGetViewsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockShareLinkInterfaceGetViewsCall) Do(f func(context.Context, uint, uint) ([]*data.ShareLinkView, error)) *MockShareLinkInterfaceGetViewsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockShareLinkInterfaceGetViewsCall) DoAndReturn(f func(context.Context, uint, uint) ([]*data.ShareLinkView, error)) *MockShareLinkInterfaceGetViewsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockShareLinkInterface) Insert(ctx context.Context, link *data.ShareLink) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, link)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockShareLinkInterfaceMockRecorder) Insert(ctx, link any) *MockShareLinkInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockShareLinkInterface)(nil).Insert), ctx, link)
+	return &MockShareLinkInterfaceInsertCall{Call: call}
+}
+
+// MockShareLinkInterfaceInsertCall wrap *gomock.Call
+type MockShareLinkInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockShareLinkInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockShareLinkInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockShareLinkInterfaceInsertCall) Do(f func(context.Context, *data.ShareLink) (uint, error)) *MockShareLinkInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockShareLinkInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.ShareLink) (uint, error)) *MockShareLinkInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RecordView mocks base method.
+func (m *MockShareLinkInterface) RecordView(ctx context.Context, id uint, ipAddress string, userAgent *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordView", ctx, id, ipAddress, userAgent)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordView indicates an expected call of RecordView.
+func (mr *MockShareLinkInterfaceMockRecorder) RecordView(ctx, id, ipAddress, userAgent any) *MockShareLinkInterfaceRecordViewCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordView", reflect.TypeOf((*MockShareLinkInterface)(nil).RecordView), ctx, id, ipAddress, userAgent)
+	return &MockShareLinkInterfaceRecordViewCall{Call: call}
+}
+
+// MockShareLinkInterfaceRecordViewCall wrap *gomock.Call
+type MockShareLinkInterfaceRecordViewCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockShareLinkInterfaceRecordViewCall) Return(arg0 error) *MockShareLinkInterfaceRecordViewCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockShareLinkInterfaceRecordViewCall) Do(f func(context.Context, uint, string, *string) error) *MockShareLinkInterfaceRecordViewCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockShareLinkInterfaceRecordViewCall) DoAndReturn(f func(context.Context, uint, string, *string) error) *MockShareLinkInterfaceRecordViewCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Revoke mocks base method.
+func (m *MockShareLinkInterface) Revoke(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockShareLinkInterfaceMockRecorder) Revoke(ctx, id, userID any) *MockShareLinkInterfaceRevokeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockShareLinkInterface)(nil).Revoke), ctx, id, userID)
+	return &MockShareLinkInterfaceRevokeCall{Call: call}
+}
+
+// MockShareLinkInterfaceRevokeCall wrap *gomock.Call
+type MockShareLinkInterfaceRevokeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockShareLinkInterfaceRevokeCall) Return(arg0 error) *MockShareLinkInterfaceRevokeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockShareLinkInterfaceRevokeCall) Do(f func(context.Context, uint, uint) error) *MockShareLinkInterfaceRevokeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockShareLinkInterfaceRevokeCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockShareLinkInterfaceRevokeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockReceiptInterface is a mock of ReceiptInterface interface.
+type MockReceiptInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockReceiptInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockReceiptInterfaceMockRecorder is the mock recorder for MockReceiptInterface.
+type MockReceiptInterfaceMockRecorder struct {
+	mock *MockReceiptInterface
+}
+
+// NewMockReceiptInterface creates a new mock instance.
+func NewMockReceiptInterface(ctrl *gomock.Controller) *MockReceiptInterface {
+	mock := &MockReceiptInterface{ctrl: ctrl}
+	mock.recorder = &MockReceiptInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReceiptInterface) EXPECT() *MockReceiptInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockReceiptInterface) GetAll(ctx context.Context, userID uint) ([]*data.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
+	ret0, _ := ret[0].([]*data.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockReceiptInterfaceMockRecorder) GetAll(ctx, userID any) *MockReceiptInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockReceiptInterface)(nil).GetAll), ctx, userID)
+	return &MockReceiptInterfaceGetAllCall{Call: call}
+}
+
+// MockReceiptInterfaceGetAllCall wrap *gomock.Call
+type MockReceiptInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockReceiptInterfaceGetAllCall) Return(arg0 []*data.Receipt, arg1 error) *MockReceiptInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockReceiptInterfaceGetAllCall) Do(f func(context.Context, uint) ([]*data.Receipt, error)) *MockReceiptInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockReceiptInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint) ([]*data.Receipt, error)) *MockReceiptInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByID mocks base method.
+func (m *MockReceiptInterface) GetByID(ctx context.Context, id uint) (*data.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*data.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockReceiptInterfaceMockRecorder) GetByID(ctx, id any) *MockReceiptInterfaceGetByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockReceiptInterface)(nil).GetByID), ctx, id)
+	return &MockReceiptInterfaceGetByIDCall{Call: call}
+}
+
+// MockReceiptInterfaceGetByIDCall wrap *gomock.Call
+type MockReceiptInterfaceGetByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockReceiptInterfaceGetByIDCall) Return(arg0 *data.Receipt, arg1 error) *MockReceiptInterfaceGetByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockReceiptInterfaceGetByIDCall) Do(f func(context.Context, uint) (*data.Receipt, error)) *MockReceiptInterfaceGetByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockReceiptInterfaceGetByIDCall) DoAndReturn(f func(context.Context, uint) (*data.Receipt, error)) *MockReceiptInterfaceGetByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByIncome mocks base method.
+func (m *MockReceiptInterface) GetByIncome(ctx context.Context, incomeID, userID uint) ([]*data.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIncome", ctx, incomeID, userID)
+	ret0, _ := ret[0].([]*data.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIncome indicates an expected call of GetByIncome.
+func (mr *MockReceiptInterfaceMockRecorder) GetByIncome(ctx, incomeID, userID any) *MockReceiptInterfaceGetByIncomeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIncome", reflect.TypeOf((*MockReceiptInterface)(nil).GetByIncome), ctx, incomeID, userID)
+	return &MockReceiptInterfaceGetByIncomeCall{Call: call}
+}
+
+// MockReceiptInterfaceGetByIncomeCall wrap *gomock.Call
+type MockReceiptInterfaceGetByIncomeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockReceiptInterfaceGetByIncomeCall) Return(arg0 []*data.Receipt, arg1 error) *MockReceiptInterfaceGetByIncomeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockReceiptInterfaceGetByIncomeCall) Do(f func(context.Context, uint, uint) ([]*data.Receipt, error)) *MockReceiptInterfaceGetByIncomeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockReceiptInterfaceGetByIncomeCall) DoAndReturn(f func(context.Context, uint, uint) ([]*data.Receipt, error)) *MockReceiptInterfaceGetByIncomeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockReceiptInterface) GetOne(ctx context.Context, id, userID uint) (*data.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockReceiptInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockReceiptInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockReceiptInterface)(nil).GetOne), ctx, id, userID)
+	return &MockReceiptInterfaceGetOneCall{Call: call}
+}
+
+// MockReceiptInterfaceGetOneCall wrap *gomock.Call
+type MockReceiptInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockReceiptInterfaceGetOneCall) Return(arg0 *data.Receipt, arg1 error) *MockReceiptInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockReceiptInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.Receipt, error)) *MockReceiptInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockReceiptInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Receipt, error)) *MockReceiptInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockReceiptInterface) Insert(ctx context.Context, receipt *data.Receipt) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, receipt)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockReceiptInterfaceMockRecorder) Insert(ctx, receipt any) *MockReceiptInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockReceiptInterface)(nil).Insert), ctx, receipt)
+	return &MockReceiptInterfaceInsertCall{Call: call}
+}
+
+// MockReceiptInterfaceInsertCall wrap *gomock.Call
+type MockReceiptInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockReceiptInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockReceiptInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockReceiptInterfaceInsertCall) Do(f func(context.Context, *data.Receipt) (uint, error)) *MockReceiptInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockReceiptInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.Receipt) (uint, error)) *MockReceiptInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MarkSent mocks base method.
+func (m *MockReceiptInterface) MarkSent(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSent", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSent indicates an expected call of MarkSent.
+func (mr *MockReceiptInterfaceMockRecorder) MarkSent(ctx, id, userID any) *MockReceiptInterfaceMarkSentCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSent", reflect.TypeOf((*MockReceiptInterface)(nil).MarkSent), ctx, id, userID)
+	return &MockReceiptInterfaceMarkSentCall{Call: call}
+}
+
+// MockReceiptInterfaceMarkSentCall wrap *gomock.Call
+type MockReceiptInterfaceMarkSentCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockReceiptInterfaceMarkSentCall) Return(arg0 error) *MockReceiptInterfaceMarkSentCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockReceiptInterfaceMarkSentCall) Do(f func(context.Context, uint, uint) error) *MockReceiptInterfaceMarkSentCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockReceiptInterfaceMarkSentCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockReceiptInterfaceMarkSentCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockCreditNoteInterface is a mock of CreditNoteInterface interface.
+type MockCreditNoteInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCreditNoteInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCreditNoteInterfaceMockRecorder is the mock recorder for MockCreditNoteInterface.
+type MockCreditNoteInterfaceMockRecorder struct {
+	mock *MockCreditNoteInterface
+}
+
+// NewMockCreditNoteInterface creates a new mock instance.
+func NewMockCreditNoteInterface(ctrl *gomock.Controller) *MockCreditNoteInterface {
+	mock := &MockCreditNoteInterface{ctrl: ctrl}
+	mock.recorder = &MockCreditNoteInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCreditNoteInterface) EXPECT() *MockCreditNoteInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockCreditNoteInterface) GetAll(ctx context.Context, userID uint) ([]*data.CreditNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
+	ret0, _ := ret[0].([]*data.CreditNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCreditNoteInterfaceMockRecorder) GetAll(ctx, userID any) *MockCreditNoteInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCreditNoteInterface)(nil).GetAll), ctx, userID)
+	return &MockCreditNoteInterfaceGetAllCall{Call: call}
+}
+
+// MockCreditNoteInterfaceGetAllCall wrap *gomock.Call
+type MockCreditNoteInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCreditNoteInterfaceGetAllCall) Return(arg0 []*data.CreditNote, arg1 error) *MockCreditNoteInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCreditNoteInterfaceGetAllCall) Do(f func(context.Context, uint) ([]*data.CreditNote, error)) *MockCreditNoteInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCreditNoteInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint) ([]*data.CreditNote, error)) *MockCreditNoteInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByIncome mocks base method.
+func (m *MockCreditNoteInterface) GetByIncome(ctx context.Context, incomeID, userID uint) ([]*data.CreditNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIncome", ctx, incomeID, userID)
+	ret0, _ := ret[0].([]*data.CreditNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIncome indicates an expected call of GetByIncome.
+func (mr *MockCreditNoteInterfaceMockRecorder) GetByIncome(ctx, incomeID, userID any) *MockCreditNoteInterfaceGetByIncomeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIncome", reflect.TypeOf((*MockCreditNoteInterface)(nil).GetByIncome), ctx, incomeID, userID)
+	return &MockCreditNoteInterfaceGetByIncomeCall{Call: call}
+}
+
+// MockCreditNoteInterfaceGetByIncomeCall wrap *gomock.Call
+type MockCreditNoteInterfaceGetByIncomeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCreditNoteInterfaceGetByIncomeCall) Return(arg0 []*data.CreditNote, arg1 error) *MockCreditNoteInterfaceGetByIncomeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCreditNoteInterfaceGetByIncomeCall) Do(f func(context.Context, uint, uint) ([]*data.CreditNote, error)) *MockCreditNoteInterfaceGetByIncomeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCreditNoteInterfaceGetByIncomeCall) DoAndReturn(f func(context.Context, uint, uint) ([]*data.CreditNote, error)) *MockCreditNoteInterfaceGetByIncomeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockCreditNoteInterface) GetOne(ctx context.Context, id, userID uint) (*data.CreditNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.CreditNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockCreditNoteInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockCreditNoteInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockCreditNoteInterface)(nil).GetOne), ctx, id, userID)
+	return &MockCreditNoteInterfaceGetOneCall{Call: call}
+}
+
+// MockCreditNoteInterfaceGetOneCall wrap *gomock.Call
+type MockCreditNoteInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCreditNoteInterfaceGetOneCall) Return(arg0 *data.CreditNote, arg1 error) *MockCreditNoteInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCreditNoteInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.CreditNote, error)) *MockCreditNoteInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCreditNoteInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.CreditNote, error)) *MockCreditNoteInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockCreditNoteInterface) Insert(ctx context.Context, note *data.CreditNote) (*data.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, note)
+	ret0, _ := ret[0].(*data.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockCreditNoteInterfaceMockRecorder) Insert(ctx, note any) *MockCreditNoteInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockCreditNoteInterface)(nil).Insert), ctx, note)
+	return &MockCreditNoteInterfaceInsertCall{Call: call}
+}
+
+// MockCreditNoteInterfaceInsertCall wrap *gomock.Call
+type MockCreditNoteInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCreditNoteInterfaceInsertCall) Return(arg0 *data.Income, arg1 error) *MockCreditNoteInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCreditNoteInterfaceInsertCall) Do(f func(context.Context, *data.CreditNote) (*data.Income, error)) *MockCreditNoteInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCreditNoteInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.CreditNote) (*data.Income, error)) *MockCreditNoteInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockDocumentTemplateInterface is a mock of DocumentTemplateInterface interface.
+type MockDocumentTemplateInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockDocumentTemplateInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockDocumentTemplateInterfaceMockRecorder is the mock recorder for MockDocumentTemplateInterface.
+type MockDocumentTemplateInterfaceMockRecorder struct {
+	mock *MockDocumentTemplateInterface
+}
+
+// NewMockDocumentTemplateInterface creates a new mock instance.
+func NewMockDocumentTemplateInterface(ctrl *gomock.Controller) *MockDocumentTemplateInterface {
+	mock := &MockDocumentTemplateInterface{ctrl: ctrl}
+	mock.recorder = &MockDocumentTemplateInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDocumentTemplateInterface) EXPECT() *MockDocumentTemplateInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockDocumentTemplateInterface) Get(ctx context.Context, userID uint, kind data.DocumentKind) (*data.DocumentTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, userID, kind)
+	ret0, _ := ret[0].(*data.DocumentTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDocumentTemplateInterfaceMockRecorder) Get(ctx, userID, kind any) *MockDocumentTemplateInterfaceGetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDocumentTemplateInterface)(nil).Get), ctx, userID, kind)
+	return &MockDocumentTemplateInterfaceGetCall{Call: call}
+}
+
+// MockDocumentTemplateInterfaceGetCall wrap *gomock.Call
+type MockDocumentTemplateInterfaceGetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDocumentTemplateInterfaceGetCall) Return(arg0 *data.DocumentTemplate, arg1 error) *MockDocumentTemplateInterfaceGetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDocumentTemplateInterfaceGetCall) Do(f func(context.Context, uint, data.DocumentKind) (*data.DocumentTemplate, error)) *MockDocumentTemplateInterfaceGetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDocumentTemplateInterfaceGetCall) DoAndReturn(f func(context.Context, uint, data.DocumentKind) (*data.DocumentTemplate, error)) *MockDocumentTemplateInterfaceGetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Save mocks base method.
+func (m *MockDocumentTemplateInterface) Save(ctx context.Context, template *data.DocumentTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockDocumentTemplateInterfaceMockRecorder) Save(ctx, template any) *MockDocumentTemplateInterfaceSaveCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockDocumentTemplateInterface)(nil).Save), ctx, template)
+	return &MockDocumentTemplateInterfaceSaveCall{Call: call}
+}
+
+// MockDocumentTemplateInterfaceSaveCall wrap *gomock.Call
+type MockDocumentTemplateInterfaceSaveCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDocumentTemplateInterfaceSaveCall) Return(arg0 error) *MockDocumentTemplateInterfaceSaveCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDocumentTemplateInterfaceSaveCall) Do(f func(context.Context, *data.DocumentTemplate) error) *MockDocumentTemplateInterfaceSaveCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDocumentTemplateInterfaceSaveCall) DoAndReturn(f func(context.Context, *data.DocumentTemplate) error) *MockDocumentTemplateInterfaceSaveCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockDunningInterface is a mock of DunningInterface interface.
+type MockDunningInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockDunningInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockDunningInterfaceMockRecorder is the mock recorder for MockDunningInterface.
+type MockDunningInterfaceMockRecorder struct {
+	mock *MockDunningInterface
+}
+
+// NewMockDunningInterface creates a new mock instance.
+func NewMockDunningInterface(ctrl *gomock.Controller) *MockDunningInterface {
+	mock := &MockDunningInterface{ctrl: ctrl}
+	mock.recorder = &MockDunningInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDunningInterface) EXPECT() *MockDunningInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ClaimStep mocks base method.
+func (m *MockDunningInterface) ClaimStep(ctx context.Context, event *data.DunningEvent) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimStep", ctx, event)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimStep indicates an expected call of ClaimStep.
+func (mr *MockDunningInterfaceMockRecorder) ClaimStep(ctx, event any) *MockDunningInterfaceClaimStepCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimStep", reflect.TypeOf((*MockDunningInterface)(nil).ClaimStep), ctx, event)
+	return &MockDunningInterfaceClaimStepCall{Call: call}
+}
+
+// MockDunningInterfaceClaimStepCall wrap *gomock.Call
+type MockDunningInterfaceClaimStepCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDunningInterfaceClaimStepCall) Return(arg0 bool, arg1 error) *MockDunningInterfaceClaimStepCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDunningInterfaceClaimStepCall) Do(f func(context.Context, *data.DunningEvent) (bool, error)) *MockDunningInterfaceClaimStepCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDunningInterfaceClaimStepCall) DoAndReturn(f func(context.Context, *data.DunningEvent) (bool, error)) *MockDunningInterfaceClaimStepCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateSchedule mocks base method.
+func (m *MockDunningInterface) CreateSchedule(ctx context.Context, schedule *data.DunningSchedule) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSchedule", ctx, schedule)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSchedule indicates an expected call of CreateSchedule.
+func (mr *MockDunningInterfaceMockRecorder) CreateSchedule(ctx, schedule any) *MockDunningInterfaceCreateScheduleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchedule", reflect.TypeOf((*MockDunningInterface)(nil).CreateSchedule), ctx, schedule)
+	return &MockDunningInterfaceCreateScheduleCall{Call: call}
+}
+
+// MockDunningInterfaceCreateScheduleCall wrap *gomock.Call
+type MockDunningInterfaceCreateScheduleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDunningInterfaceCreateScheduleCall) Return(arg0 uint, arg1 error) *MockDunningInterfaceCreateScheduleCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDunningInterfaceCreateScheduleCall) Do(f func(context.Context, *data.DunningSchedule) (uint, error)) *MockDunningInterfaceCreateScheduleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDunningInterfaceCreateScheduleCall) DoAndReturn(f func(context.Context, *data.DunningSchedule) (uint, error)) *MockDunningInterfaceCreateScheduleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteSchedule mocks base method.
+func (m *MockDunningInterface) DeleteSchedule(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSchedule", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSchedule indicates an expected call of DeleteSchedule.
+func (mr *MockDunningInterfaceMockRecorder) DeleteSchedule(ctx, id, userID any) *MockDunningInterfaceDeleteScheduleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchedule", reflect.TypeOf((*MockDunningInterface)(nil).DeleteSchedule), ctx, id, userID)
+	return &MockDunningInterfaceDeleteScheduleCall{Call: call}
+}
+
+// MockDunningInterfaceDeleteScheduleCall wrap *gomock.Call
+type MockDunningInterfaceDeleteScheduleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDunningInterfaceDeleteScheduleCall) Return(arg0 error) *MockDunningInterfaceDeleteScheduleCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDunningInterfaceDeleteScheduleCall) Do(f func(context.Context, uint, uint) error) *MockDunningInterfaceDeleteScheduleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDunningInterfaceDeleteScheduleCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockDunningInterfaceDeleteScheduleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetActiveSchedules mocks base method.
+func (m *MockDunningInterface) GetActiveSchedules(ctx context.Context) ([]*data.DunningSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveSchedules", ctx)
+	ret0, _ := ret[0].([]*data.DunningSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveSchedules indicates an expected call of GetActiveSchedules.
+func (mr *MockDunningInterfaceMockRecorder) GetActiveSchedules(ctx any) *MockDunningInterfaceGetActiveSchedulesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveSchedules", reflect.TypeOf((*MockDunningInterface)(nil).GetActiveSchedules), ctx)
+	return &MockDunningInterfaceGetActiveSchedulesCall{Call: call}
+}
+
+// MockDunningInterfaceGetActiveSchedulesCall wrap *gomock.Call
+type MockDunningInterfaceGetActiveSchedulesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDunningInterfaceGetActiveSchedulesCall) Return(arg0 []*data.DunningSchedule, arg1 error) *MockDunningInterfaceGetActiveSchedulesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDunningInterfaceGetActiveSchedulesCall) Do(f func(context.Context) ([]*data.DunningSchedule, error)) *MockDunningInterfaceGetActiveSchedulesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDunningInterfaceGetActiveSchedulesCall) DoAndReturn(f func(context.Context) ([]*data.DunningSchedule, error)) *MockDunningInterfaceGetActiveSchedulesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetHistory mocks base method.
+func (m *MockDunningInterface) GetHistory(ctx context.Context, incomeID, userID uint) ([]*data.DunningEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, incomeID, userID)
+	ret0, _ := ret[0].([]*data.DunningEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockDunningInterfaceMockRecorder) GetHistory(ctx, incomeID, userID any) *MockDunningInterfaceGetHistoryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockDunningInterface)(nil).GetHistory), ctx, incomeID, userID)
+	return &MockDunningInterfaceGetHistoryCall{Call: call}
+}
+
+// MockDunningInterfaceGetHistoryCall wrap *gomock.Call
+type MockDunningInterfaceGetHistoryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDunningInterfaceGetHistoryCall) Return(arg0 []*data.DunningEvent, arg1 error) *MockDunningInterfaceGetHistoryCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDunningInterfaceGetHistoryCall) Do(f func(context.Context, uint, uint) ([]*data.DunningEvent, error)) *MockDunningInterfaceGetHistoryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDunningInterfaceGetHistoryCall) DoAndReturn(f func(context.Context, uint, uint) ([]*data.DunningEvent, error)) *MockDunningInterfaceGetHistoryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetSchedule mocks base method.
+func (m *MockDunningInterface) GetSchedule(ctx context.Context, id, userID uint) (*data.DunningSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", ctx, id, userID)
+	ret0, _ := ret[0].(*data.DunningSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockDunningInterfaceMockRecorder) GetSchedule(ctx, id, userID any) *MockDunningInterfaceGetScheduleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockDunningInterface)(nil).GetSchedule), ctx, id, userID)
+	return &MockDunningInterfaceGetScheduleCall{Call: call}
+}
+
+// MockDunningInterfaceGetScheduleCall wrap *gomock.Call
+type MockDunningInterfaceGetScheduleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDunningInterfaceGetScheduleCall) Return(arg0 *data.DunningSchedule, arg1 error) *MockDunningInterfaceGetScheduleCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDunningInterfaceGetScheduleCall) Do(f func(context.Context, uint, uint) (*data.DunningSchedule, error)) *MockDunningInterfaceGetScheduleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDunningInterfaceGetScheduleCall) DoAndReturn(f func(context.Context, uint, uint) (*data.DunningSchedule, error)) *MockDunningInterfaceGetScheduleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetSchedules mocks base method.
+func (m *MockDunningInterface) GetSchedules(ctx context.Context, userID uint) ([]*data.DunningSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedules", ctx, userID)
+	ret0, _ := ret[0].([]*data.DunningSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedules indicates an expected call of GetSchedules.
+func (mr *MockDunningInterfaceMockRecorder) GetSchedules(ctx, userID any) *MockDunningInterfaceGetSchedulesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedules", reflect.TypeOf((*MockDunningInterface)(nil).GetSchedules), ctx, userID)
+	return &MockDunningInterfaceGetSchedulesCall{Call: call}
+}
+
+// MockDunningInterfaceGetSchedulesCall wrap *gomock.Call
+type MockDunningInterfaceGetSchedulesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDunningInterfaceGetSchedulesCall) Return(arg0 []*data.DunningSchedule, arg1 error) *MockDunningInterfaceGetSchedulesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDunningInterfaceGetSchedulesCall) Do(f func(context.Context, uint) ([]*data.DunningSchedule, error)) *MockDunningInterfaceGetSchedulesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDunningInterfaceGetSchedulesCall) DoAndReturn(f func(context.Context, uint) ([]*data.DunningSchedule, error)) *MockDunningInterfaceGetSchedulesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateEvent mocks base method.
+func (m *MockDunningInterface) UpdateEvent(ctx context.Context, event *data.DunningEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEvent indicates an expected call of UpdateEvent.
+func (mr *MockDunningInterfaceMockRecorder) UpdateEvent(ctx, event any) *MockDunningInterfaceUpdateEventCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEvent", reflect.TypeOf((*MockDunningInterface)(nil).UpdateEvent), ctx, event)
+	return &MockDunningInterfaceUpdateEventCall{Call: call}
+}
+
+// MockDunningInterfaceUpdateEventCall wrap *gomock.Call
+type MockDunningInterfaceUpdateEventCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDunningInterfaceUpdateEventCall) Return(arg0 error) *MockDunningInterfaceUpdateEventCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDunningInterfaceUpdateEventCall) Do(f func(context.Context, *data.DunningEvent) error) *MockDunningInterfaceUpdateEventCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDunningInterfaceUpdateEventCall) DoAndReturn(f func(context.Context, *data.DunningEvent) error) *MockDunningInterfaceUpdateEventCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateSchedule mocks base method.
+func (m *MockDunningInterface) UpdateSchedule(ctx context.Context, schedule *data.DunningSchedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSchedule", ctx, schedule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSchedule indicates an expected call of UpdateSchedule.
+func (mr *MockDunningInterfaceMockRecorder) UpdateSchedule(ctx, schedule any) *MockDunningInterfaceUpdateScheduleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSchedule", reflect.TypeOf((*MockDunningInterface)(nil).UpdateSchedule), ctx, schedule)
+	return &MockDunningInterfaceUpdateScheduleCall{Call: call}
+}
+
+// MockDunningInterfaceUpdateScheduleCall wrap *gomock.Call
+type MockDunningInterfaceUpdateScheduleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDunningInterfaceUpdateScheduleCall) Return(arg0 error) *MockDunningInterfaceUpdateScheduleCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDunningInterfaceUpdateScheduleCall) Do(f func(context.Context, *data.DunningSchedule) error) *MockDunningInterfaceUpdateScheduleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDunningInterfaceUpdateScheduleCall) DoAndReturn(f func(context.Context, *data.DunningSchedule) error) *MockDunningInterfaceUpdateScheduleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockTaskInterface is a mock of TaskInterface interface.
+type MockTaskInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockTaskInterfaceMockRecorder is the mock recorder for MockTaskInterface.
+type MockTaskInterfaceMockRecorder struct {
+	mock *MockTaskInterface
+}
+
+// NewMockTaskInterface creates a new mock instance.
+func NewMockTaskInterface(ctrl *gomock.Controller) *MockTaskInterface {
+	mock := &MockTaskInterface{ctrl: ctrl}
+	mock.recorder = &MockTaskInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskInterface) EXPECT() *MockTaskInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockTaskInterface) Complete(ctx context.Context, id, userID, completedByID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, id, userID, completedByID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockTaskInterfaceMockRecorder) Complete(ctx, id, userID, completedByID any) *MockTaskInterfaceCompleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockTaskInterface)(nil).Complete), ctx, id, userID, completedByID)
+	return &MockTaskInterfaceCompleteCall{Call: call}
+}
+
+// MockTaskInterfaceCompleteCall wrap *gomock.Call
+type MockTaskInterfaceCompleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskInterfaceCompleteCall) Return(arg0 error) *MockTaskInterfaceCompleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskInterfaceCompleteCall) Do(f func(context.Context, uint, uint, uint) error) *MockTaskInterfaceCompleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskInterfaceCompleteCall) DoAndReturn(f func(context.Context, uint, uint, uint) error) *MockTaskInterfaceCompleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Delete mocks base method.
+func (m *MockTaskInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTaskInterfaceMockRecorder) Delete(ctx, id, userID any) *MockTaskInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTaskInterface)(nil).Delete), ctx, id, userID)
+	return &MockTaskInterfaceDeleteCall{Call: call}
+}
+
+// MockTaskInterfaceDeleteCall wrap *gomock.Call
+type MockTaskInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskInterfaceDeleteCall) Return(arg0 error) *MockTaskInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockTaskInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockTaskInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockTaskInterface) GetAll(ctx context.Context, userID uint, filter data.TaskFilter) ([]*data.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID, filter)
+	ret0, _ := ret[0].([]*data.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTaskInterfaceMockRecorder) GetAll(ctx, userID, filter any) *MockTaskInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTaskInterface)(nil).GetAll), ctx, userID, filter)
+	return &MockTaskInterfaceGetAllCall{Call: call}
+}
+
+// MockTaskInterfaceGetAllCall wrap *gomock.Call
+type MockTaskInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskInterfaceGetAllCall) Return(arg0 []*data.Task, arg1 error) *MockTaskInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskInterfaceGetAllCall) Do(f func(context.Context, uint, data.TaskFilter) ([]*data.Task, error)) *MockTaskInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint, data.TaskFilter) ([]*data.Task, error)) *MockTaskInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockTaskInterface) GetOne(ctx context.Context, id, userID uint) (*data.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockTaskInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockTaskInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockTaskInterface)(nil).GetOne), ctx, id, userID)
+	return &MockTaskInterfaceGetOneCall{Call: call}
+}
+
+// MockTaskInterfaceGetOneCall wrap *gomock.Call
+type MockTaskInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskInterfaceGetOneCall) Return(arg0 *data.Task, arg1 error) *MockTaskInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.Task, error)) *MockTaskInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Task, error)) *MockTaskInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOverdue mocks base method.
+func (m *MockTaskInterface) GetOverdue(ctx context.Context, before time.Time) ([]*data.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdue", ctx, before)
+	ret0, _ := ret[0].([]*data.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdue indicates an expected call of GetOverdue.
+func (mr *MockTaskInterfaceMockRecorder) GetOverdue(ctx, before any) *MockTaskInterfaceGetOverdueCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdue", reflect.TypeOf((*MockTaskInterface)(nil).GetOverdue), ctx, before)
+	return &MockTaskInterfaceGetOverdueCall{Call: call}
+}
+
+// MockTaskInterfaceGetOverdueCall wrap *gomock.Call
+type MockTaskInterfaceGetOverdueCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskInterfaceGetOverdueCall) Return(arg0 []*data.Task, arg1 error) *MockTaskInterfaceGetOverdueCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskInterfaceGetOverdueCall) Do(f func(context.Context, time.Time) ([]*data.Task, error)) *MockTaskInterfaceGetOverdueCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskInterfaceGetOverdueCall) DoAndReturn(f func(context.Context, time.Time) ([]*data.Task, error)) *MockTaskInterfaceGetOverdueCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockTaskInterface) Insert(ctx context.Context, task *data.Task) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, task)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockTaskInterfaceMockRecorder) Insert(ctx, task any) *MockTaskInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockTaskInterface)(nil).Insert), ctx, task)
+	return &MockTaskInterfaceInsertCall{Call: call}
+}
+
+// MockTaskInterfaceInsertCall wrap *gomock.Call
+type MockTaskInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockTaskInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskInterfaceInsertCall) Do(f func(context.Context, *data.Task) (uint, error)) *MockTaskInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.Task) (uint, error)) *MockTaskInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Reopen mocks base method.
+func (m *MockTaskInterface) Reopen(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reopen", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reopen indicates an expected call of Reopen.
+func (mr *MockTaskInterfaceMockRecorder) Reopen(ctx, id, userID any) *MockTaskInterfaceReopenCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reopen", reflect.TypeOf((*MockTaskInterface)(nil).Reopen), ctx, id, userID)
+	return &MockTaskInterfaceReopenCall{Call: call}
+}
+
+// MockTaskInterfaceReopenCall wrap *gomock.Call
+type MockTaskInterfaceReopenCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskInterfaceReopenCall) Return(arg0 error) *MockTaskInterfaceReopenCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskInterfaceReopenCall) Do(f func(context.Context, uint, uint) error) *MockTaskInterfaceReopenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskInterfaceReopenCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockTaskInterfaceReopenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockTaskInterface) Update(ctx context.Context, task *data.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTaskInterfaceMockRecorder) Update(ctx, task any) *MockTaskInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTaskInterface)(nil).Update), ctx, task)
+	return &MockTaskInterfaceUpdateCall{Call: call}
+}
+
+// MockTaskInterfaceUpdateCall wrap *gomock.Call
+type MockTaskInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskInterfaceUpdateCall) Return(arg0 error) *MockTaskInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskInterfaceUpdateCall) Do(f func(context.Context, *data.Task) error) *MockTaskInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.Task) error) *MockTaskInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockEvidenceInterface is a mock of EvidenceInterface interface.
+type MockEvidenceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockEvidenceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockEvidenceInterfaceMockRecorder is the mock recorder for MockEvidenceInterface.
+type MockEvidenceInterfaceMockRecorder struct {
+	mock *MockEvidenceInterface
+}
+
+// NewMockEvidenceInterface creates a new mock instance.
+func NewMockEvidenceInterface(ctrl *gomock.Controller) *MockEvidenceInterface {
+	mock := &MockEvidenceInterface{ctrl: ctrl}
+	mock.recorder = &MockEvidenceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEvidenceInterface) EXPECT() *MockEvidenceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeletePhoto mocks base method.
+func (m *MockEvidenceInterface) DeletePhoto(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePhoto", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePhoto indicates an expected call of DeletePhoto.
+func (mr *MockEvidenceInterfaceMockRecorder) DeletePhoto(ctx, id, userID any) *MockEvidenceInterfaceDeletePhotoCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePhoto", reflect.TypeOf((*MockEvidenceInterface)(nil).DeletePhoto), ctx, id, userID)
+	return &MockEvidenceInterfaceDeletePhotoCall{Call: call}
+}
+
+// MockEvidenceInterfaceDeletePhotoCall wrap *gomock.Call
+type MockEvidenceInterfaceDeletePhotoCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEvidenceInterfaceDeletePhotoCall) Return(arg0 error) *MockEvidenceInterfaceDeletePhotoCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEvidenceInterfaceDeletePhotoCall) Do(f func(context.Context, uint, uint) error) *MockEvidenceInterfaceDeletePhotoCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEvidenceInterfaceDeletePhotoCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockEvidenceInterfaceDeletePhotoCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteRule mocks base method.
+func (m *MockEvidenceInterface) DeleteRule(ctx context.Context, userID uint, operation data.EvidenceOperation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", ctx, userID, operation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockEvidenceInterfaceMockRecorder) DeleteRule(ctx, userID, operation any) *MockEvidenceInterfaceDeleteRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockEvidenceInterface)(nil).DeleteRule), ctx, userID, operation)
+	return &MockEvidenceInterfaceDeleteRuleCall{Call: call}
+}
+
+// MockEvidenceInterfaceDeleteRuleCall wrap *gomock.Call
+type MockEvidenceInterfaceDeleteRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEvidenceInterfaceDeleteRuleCall) Return(arg0 error) *MockEvidenceInterfaceDeleteRuleCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEvidenceInterfaceDeleteRuleCall) Do(f func(context.Context, uint, data.EvidenceOperation) error) *MockEvidenceInterfaceDeleteRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEvidenceInterfaceDeleteRuleCall) DoAndReturn(f func(context.Context, uint, data.EvidenceOperation) error) *MockEvidenceInterfaceDeleteRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPhoto mocks base method.
+func (m *MockEvidenceInterface) GetPhoto(ctx context.Context, id, userID uint) (*data.EvidencePhoto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPhoto", ctx, id, userID)
+	ret0, _ := ret[0].(*data.EvidencePhoto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPhoto indicates an expected call of GetPhoto.
+func (mr *MockEvidenceInterfaceMockRecorder) GetPhoto(ctx, id, userID any) *MockEvidenceInterfaceGetPhotoCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPhoto", reflect.TypeOf((*MockEvidenceInterface)(nil).GetPhoto), ctx, id, userID)
+	return &MockEvidenceInterfaceGetPhotoCall{Call: call}
+}
+
+// MockEvidenceInterfaceGetPhotoCall wrap *gomock.Call
+type MockEvidenceInterfaceGetPhotoCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEvidenceInterfaceGetPhotoCall) Return(arg0 *data.EvidencePhoto, arg1 error) *MockEvidenceInterfaceGetPhotoCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEvidenceInterfaceGetPhotoCall) Do(f func(context.Context, uint, uint) (*data.EvidencePhoto, error)) *MockEvidenceInterfaceGetPhotoCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEvidenceInterfaceGetPhotoCall) DoAndReturn(f func(context.Context, uint, uint) (*data.EvidencePhoto, error)) *MockEvidenceInterfaceGetPhotoCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPhotos mocks base method.
+func (m *MockEvidenceInterface) GetPhotos(ctx context.Context, userID uint, recordType data.EvidenceRecordType, recordID uint) ([]*data.EvidencePhoto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPhotos", ctx, userID, recordType, recordID)
+	ret0, _ := ret[0].([]*data.EvidencePhoto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPhotos indicates an expected call of GetPhotos.
+func (mr *MockEvidenceInterfaceMockRecorder) GetPhotos(ctx, userID, recordType, recordID any) *MockEvidenceInterfaceGetPhotosCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPhotos", reflect.TypeOf((*MockEvidenceInterface)(nil).GetPhotos), ctx, userID, recordType, recordID)
+	return &MockEvidenceInterfaceGetPhotosCall{Call: call}
+}
+
+// MockEvidenceInterfaceGetPhotosCall wrap *gomock.Call
+type MockEvidenceInterfaceGetPhotosCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEvidenceInterfaceGetPhotosCall) Return(arg0 []*data.EvidencePhoto, arg1 error) *MockEvidenceInterfaceGetPhotosCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEvidenceInterfaceGetPhotosCall) Do(f func(context.Context, uint, data.EvidenceRecordType, uint) ([]*data.EvidencePhoto, error)) *MockEvidenceInterfaceGetPhotosCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEvidenceInterfaceGetPhotosCall) DoAndReturn(f func(context.Context, uint, data.EvidenceRecordType, uint) ([]*data.EvidencePhoto, error)) *MockEvidenceInterfaceGetPhotosCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetRules mocks base method.
+func (m *MockEvidenceInterface) GetRules(ctx context.Context, userID uint) ([]*data.EvidenceRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRules", ctx, userID)
+	ret0, _ := ret[0].([]*data.EvidenceRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRules indicates an expected call of GetRules.
+func (mr *MockEvidenceInterfaceMockRecorder) GetRules(ctx, userID any) *MockEvidenceInterfaceGetRulesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRules", reflect.TypeOf((*MockEvidenceInterface)(nil).GetRules), ctx, userID)
+	return &MockEvidenceInterfaceGetRulesCall{Call: call}
+}
+
+// MockEvidenceInterfaceGetRulesCall wrap *gomock.Call
+type MockEvidenceInterfaceGetRulesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEvidenceInterfaceGetRulesCall) Return(arg0 []*data.EvidenceRule, arg1 error) *MockEvidenceInterfaceGetRulesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEvidenceInterfaceGetRulesCall) Do(f func(context.Context, uint) ([]*data.EvidenceRule, error)) *MockEvidenceInterfaceGetRulesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEvidenceInterfaceGetRulesCall) DoAndReturn(f func(context.Context, uint) ([]*data.EvidenceRule, error)) *MockEvidenceInterfaceGetRulesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetStorage mocks base method.
+func (m *MockEvidenceInterface) GetStorage(ctx context.Context, userID uint) (*data.AttachmentStorage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStorage", ctx, userID)
+	ret0, _ := ret[0].(*data.AttachmentStorage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStorage indicates an expected call of GetStorage.
+func (mr *MockEvidenceInterfaceMockRecorder) GetStorage(ctx, userID any) *MockEvidenceInterfaceGetStorageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorage", reflect.TypeOf((*MockEvidenceInterface)(nil).GetStorage), ctx, userID)
+	return &MockEvidenceInterfaceGetStorageCall{Call: call}
+}
+
+// MockEvidenceInterfaceGetStorageCall wrap *gomock.Call
+type MockEvidenceInterfaceGetStorageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEvidenceInterfaceGetStorageCall) Return(arg0 *data.AttachmentStorage, arg1 error) *MockEvidenceInterfaceGetStorageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEvidenceInterfaceGetStorageCall) Do(f func(context.Context, uint) (*data.AttachmentStorage, error)) *MockEvidenceInterfaceGetStorageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEvidenceInterfaceGetStorageCall) DoAndReturn(f func(context.Context, uint) (*data.AttachmentStorage, error)) *MockEvidenceInterfaceGetStorageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// HasPhoto mocks base method.
+func (m *MockEvidenceInterface) HasPhoto(ctx context.Context, userID uint, recordType data.EvidenceRecordType, recordID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPhoto", ctx, userID, recordType, recordID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPhoto indicates an expected call of HasPhoto.
+func (mr *MockEvidenceInterfaceMockRecorder) HasPhoto(ctx, userID, recordType, recordID any) *MockEvidenceInterfaceHasPhotoCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPhoto", reflect.TypeOf((*MockEvidenceInterface)(nil).HasPhoto), ctx, userID, recordType, recordID)
+	return &MockEvidenceInterfaceHasPhotoCall{Call: call}
+}
+
+// MockEvidenceInterfaceHasPhotoCall wrap *gomock.Call
+type MockEvidenceInterfaceHasPhotoCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEvidenceInterfaceHasPhotoCall) Return(arg0 bool, arg1 error) *MockEvidenceInterfaceHasPhotoCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEvidenceInterfaceHasPhotoCall) Do(f func(context.Context, uint, data.EvidenceRecordType, uint) (bool, error)) *MockEvidenceInterfaceHasPhotoCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEvidenceInterfaceHasPhotoCall) DoAndReturn(f func(context.Context, uint, data.EvidenceRecordType, uint) (bool, error)) *MockEvidenceInterfaceHasPhotoCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// InsertPhoto mocks base method.
+func (m *MockEvidenceInterface) InsertPhoto(ctx context.Context, photo *data.EvidencePhoto) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPhoto", ctx, photo)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertPhoto indicates an expected call of InsertPhoto.
+func (mr *MockEvidenceInterfaceMockRecorder) InsertPhoto(ctx, photo any) *MockEvidenceInterfaceInsertPhotoCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPhoto", reflect.TypeOf((*MockEvidenceInterface)(nil).InsertPhoto), ctx, photo)
+	return &MockEvidenceInterfaceInsertPhotoCall{Call: call}
+}
+
+// MockEvidenceInterfaceInsertPhotoCall wrap *gomock.Call
+type MockEvidenceInterfaceInsertPhotoCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEvidenceInterfaceInsertPhotoCall) Return(arg0 uint, arg1 error) *MockEvidenceInterfaceInsertPhotoCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEvidenceInterfaceInsertPhotoCall) Do(f func(context.Context, *data.EvidencePhoto) (uint, error)) *MockEvidenceInterfaceInsertPhotoCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEvidenceInterfaceInsertPhotoCall) DoAndReturn(f func(context.Context, *data.EvidencePhoto) (uint, error)) *MockEvidenceInterfaceInsertPhotoCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// LinkPhoto mocks base method.
+func (m *MockEvidenceInterface) LinkPhoto(ctx context.Context, id, userID uint, recordType data.EvidenceRecordType, recordID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkPhoto", ctx, id, userID, recordType, recordID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkPhoto indicates an expected call of LinkPhoto.
+func (mr *MockEvidenceInterfaceMockRecorder) LinkPhoto(ctx, id, userID, recordType, recordID any) *MockEvidenceInterfaceLinkPhotoCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkPhoto", reflect.TypeOf((*MockEvidenceInterface)(nil).LinkPhoto), ctx, id, userID, recordType, recordID)
+	return &MockEvidenceInterfaceLinkPhotoCall{Call: call}
+}
+
+// MockEvidenceInterfaceLinkPhotoCall wrap *gomock.Call
+type MockEvidenceInterfaceLinkPhotoCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEvidenceInterfaceLinkPhotoCall) Return(arg0 error) *MockEvidenceInterfaceLinkPhotoCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEvidenceInterfaceLinkPhotoCall) Do(f func(context.Context, uint, uint, data.EvidenceRecordType, uint) error) *MockEvidenceInterfaceLinkPhotoCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEvidenceInterfaceLinkPhotoCall) DoAndReturn(f func(context.Context, uint, uint, data.EvidenceRecordType, uint) error) *MockEvidenceInterfaceLinkPhotoCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RequiresPhoto mocks base method.
+func (m *MockEvidenceInterface) RequiresPhoto(ctx context.Context, userID uint, operation data.EvidenceOperation, amount float64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequiresPhoto", ctx, userID, operation, amount)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequiresPhoto indicates an expected call of RequiresPhoto.
+func (mr *MockEvidenceInterfaceMockRecorder) RequiresPhoto(ctx, userID, operation, amount any) *MockEvidenceInterfaceRequiresPhotoCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequiresPhoto", reflect.TypeOf((*MockEvidenceInterface)(nil).RequiresPhoto), ctx, userID, operation, amount)
+	return &MockEvidenceInterfaceRequiresPhotoCall{Call: call}
+}
+
+// MockEvidenceInterfaceRequiresPhotoCall wrap *gomock.Call
+type MockEvidenceInterfaceRequiresPhotoCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEvidenceInterfaceRequiresPhotoCall) Return(arg0 bool, arg1 error) *MockEvidenceInterfaceRequiresPhotoCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEvidenceInterfaceRequiresPhotoCall) Do(f func(context.Context, uint, data.EvidenceOperation, float64) (bool, error)) *MockEvidenceInterfaceRequiresPhotoCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEvidenceInterfaceRequiresPhotoCall) DoAndReturn(f func(context.Context, uint, data.EvidenceOperation, float64) (bool, error)) *MockEvidenceInterfaceRequiresPhotoCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SaveRule mocks base method.
+func (m *MockEvidenceInterface) SaveRule(ctx context.Context, rule *data.EvidenceRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRule", ctx, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRule indicates an expected call of SaveRule.
+func (mr *MockEvidenceInterfaceMockRecorder) SaveRule(ctx, rule any) *MockEvidenceInterfaceSaveRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRule", reflect.TypeOf((*MockEvidenceInterface)(nil).SaveRule), ctx, rule)
+	return &MockEvidenceInterfaceSaveRuleCall{Call: call}
+}
+
+// MockEvidenceInterfaceSaveRuleCall wrap *gomock.Call
+type MockEvidenceInterfaceSaveRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEvidenceInterfaceSaveRuleCall) Return(arg0 error) *MockEvidenceInterfaceSaveRuleCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEvidenceInterfaceSaveRuleCall) Do(f func(context.Context, *data.EvidenceRule) error) *MockEvidenceInterfaceSaveRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEvidenceInterfaceSaveRuleCall) DoAndReturn(f func(context.Context, *data.EvidenceRule) error) *MockEvidenceInterfaceSaveRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockAttachmentInterface is a mock of AttachmentInterface interface.
+type MockAttachmentInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockAttachmentInterfaceMockRecorder is the mock recorder for MockAttachmentInterface.
+type MockAttachmentInterfaceMockRecorder struct {
+	mock *MockAttachmentInterface
+}
+
+// NewMockAttachmentInterface creates a new mock instance.
+func NewMockAttachmentInterface(ctrl *gomock.Controller) *MockAttachmentInterface {
+	mock := &MockAttachmentInterface{ctrl: ctrl}
+	mock.recorder = &MockAttachmentInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentInterface) EXPECT() *MockAttachmentInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockAttachmentInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAttachmentInterfaceMockRecorder) Delete(ctx, id, userID any) *MockAttachmentInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAttachmentInterface)(nil).Delete), ctx, id, userID)
+	return &MockAttachmentInterfaceDeleteCall{Call: call}
+}
+
+// MockAttachmentInterfaceDeleteCall wrap *gomock.Call
+type MockAttachmentInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAttachmentInterfaceDeleteCall) Return(arg0 error) *MockAttachmentInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAttachmentInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockAttachmentInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAttachmentInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockAttachmentInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetForRecord mocks base method.
+func (m *MockAttachmentInterface) GetForRecord(ctx context.Context, userID uint, recordType data.AttachmentRecordType, recordID uint) ([]*data.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForRecord", ctx, userID, recordType, recordID)
+	ret0, _ := ret[0].([]*data.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForRecord indicates an expected call of GetForRecord.
+func (mr *MockAttachmentInterfaceMockRecorder) GetForRecord(ctx, userID, recordType, recordID any) *MockAttachmentInterfaceGetForRecordCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForRecord", reflect.TypeOf((*MockAttachmentInterface)(nil).GetForRecord), ctx, userID, recordType, recordID)
+	return &MockAttachmentInterfaceGetForRecordCall{Call: call}
+}
+
+// MockAttachmentInterfaceGetForRecordCall wrap *gomock.Call
+type MockAttachmentInterfaceGetForRecordCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAttachmentInterfaceGetForRecordCall) Return(arg0 []*data.Attachment, arg1 error) *MockAttachmentInterfaceGetForRecordCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAttachmentInterfaceGetForRecordCall) Do(f func(context.Context, uint, data.AttachmentRecordType, uint) ([]*data.Attachment, error)) *MockAttachmentInterfaceGetForRecordCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAttachmentInterfaceGetForRecordCall) DoAndReturn(f func(context.Context, uint, data.AttachmentRecordType, uint) ([]*data.Attachment, error)) *MockAttachmentInterfaceGetForRecordCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockAttachmentInterface) GetOne(ctx context.Context, id, userID uint) (*data.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockAttachmentInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockAttachmentInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockAttachmentInterface)(nil).GetOne), ctx, id, userID)
+	return &MockAttachmentInterfaceGetOneCall{Call: call}
+}
+
+// MockAttachmentInterfaceGetOneCall wrap *gomock.Call
+type MockAttachmentInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAttachmentInterfaceGetOneCall) Return(arg0 *data.Attachment, arg1 error) *MockAttachmentInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAttachmentInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.Attachment, error)) *MockAttachmentInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAttachmentInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Attachment, error)) *MockAttachmentInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetStorage mocks base method.
+func (m *MockAttachmentInterface) GetStorage(ctx context.Context, userID uint) (*data.AttachmentStorage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStorage", ctx, userID)
+	ret0, _ := ret[0].(*data.AttachmentStorage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStorage indicates an expected call of GetStorage.
+func (mr *MockAttachmentInterfaceMockRecorder) GetStorage(ctx, userID any) *MockAttachmentInterfaceGetStorageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorage", reflect.TypeOf((*MockAttachmentInterface)(nil).GetStorage), ctx, userID)
+	return &MockAttachmentInterfaceGetStorageCall{Call: call}
+}
+
+// MockAttachmentInterfaceGetStorageCall wrap *gomock.Call
+type MockAttachmentInterfaceGetStorageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAttachmentInterfaceGetStorageCall) Return(arg0 *data.AttachmentStorage, arg1 error) *MockAttachmentInterfaceGetStorageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAttachmentInterfaceGetStorageCall) Do(f func(context.Context, uint) (*data.AttachmentStorage, error)) *MockAttachmentInterfaceGetStorageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAttachmentInterfaceGetStorageCall) DoAndReturn(f func(context.Context, uint) (*data.AttachmentStorage, error)) *MockAttachmentInterfaceGetStorageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockAttachmentInterface) Insert(ctx context.Context, attachment *data.Attachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, attachment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockAttachmentInterfaceMockRecorder) Insert(ctx, attachment any) *MockAttachmentInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockAttachmentInterface)(nil).Insert), ctx, attachment)
+	return &MockAttachmentInterfaceInsertCall{Call: call}
+}
+
+// MockAttachmentInterfaceInsertCall wrap *gomock.Call
+type MockAttachmentInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAttachmentInterfaceInsertCall) Return(arg0 error) *MockAttachmentInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAttachmentInterfaceInsertCall) Do(f func(context.Context, *data.Attachment) error) *MockAttachmentInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAttachmentInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.Attachment) error) *MockAttachmentInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockDueDiligenceInterface is a mock of DueDiligenceInterface interface.
+type MockDueDiligenceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockDueDiligenceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockDueDiligenceInterfaceMockRecorder is the mock recorder for MockDueDiligenceInterface.
+type MockDueDiligenceInterfaceMockRecorder struct {
+	mock *MockDueDiligenceInterface
+}
+
+// NewMockDueDiligenceInterface creates a new mock instance.
+func NewMockDueDiligenceInterface(ctrl *gomock.Controller) *MockDueDiligenceInterface {
+	mock := &MockDueDiligenceInterface{ctrl: ctrl}
+	mock.recorder = &MockDueDiligenceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDueDiligenceInterface) EXPECT() *MockDueDiligenceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockDueDiligenceInterface) Complete(ctx context.Context, id, userID, completedByID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, id, userID, completedByID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockDueDiligenceInterfaceMockRecorder) Complete(ctx, id, userID, completedByID any) *MockDueDiligenceInterfaceCompleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockDueDiligenceInterface)(nil).Complete), ctx, id, userID, completedByID)
+	return &MockDueDiligenceInterfaceCompleteCall{Call: call}
+}
+
+// MockDueDiligenceInterfaceCompleteCall wrap *gomock.Call
+type MockDueDiligenceInterfaceCompleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDueDiligenceInterfaceCompleteCall) Return(arg0 error) *MockDueDiligenceInterfaceCompleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDueDiligenceInterfaceCompleteCall) Do(f func(context.Context, uint, uint, uint) error) *MockDueDiligenceInterfaceCompleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDueDiligenceInterfaceCompleteCall) DoAndReturn(f func(context.Context, uint, uint, uint) error) *MockDueDiligenceInterfaceCompleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Delete mocks base method.
+func (m *MockDueDiligenceInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDueDiligenceInterfaceMockRecorder) Delete(ctx, id, userID any) *MockDueDiligenceInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDueDiligenceInterface)(nil).Delete), ctx, id, userID)
+	return &MockDueDiligenceInterfaceDeleteCall{Call: call}
+}
+
+// MockDueDiligenceInterfaceDeleteCall wrap *gomock.Call
+type MockDueDiligenceInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDueDiligenceInterfaceDeleteCall) Return(arg0 error) *MockDueDiligenceInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDueDiligenceInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockDueDiligenceInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDueDiligenceInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockDueDiligenceInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockDueDiligenceInterface) GetAll(ctx context.Context, userID uint, siteID *uint) ([]*data.DueDiligenceAssessment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID, siteID)
+	ret0, _ := ret[0].([]*data.DueDiligenceAssessment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockDueDiligenceInterfaceMockRecorder) GetAll(ctx, userID, siteID any) *MockDueDiligenceInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockDueDiligenceInterface)(nil).GetAll), ctx, userID, siteID)
+	return &MockDueDiligenceInterfaceGetAllCall{Call: call}
+}
+
+// MockDueDiligenceInterfaceGetAllCall wrap *gomock.Call
+type MockDueDiligenceInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDueDiligenceInterfaceGetAllCall) Return(arg0 []*data.DueDiligenceAssessment, arg1 error) *MockDueDiligenceInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDueDiligenceInterfaceGetAllCall) Do(f func(context.Context, uint, *uint) ([]*data.DueDiligenceAssessment, error)) *MockDueDiligenceInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDueDiligenceInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint, *uint) ([]*data.DueDiligenceAssessment, error)) *MockDueDiligenceInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockDueDiligenceInterface) GetOne(ctx context.Context, id, userID uint) (*data.DueDiligenceAssessment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.DueDiligenceAssessment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockDueDiligenceInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockDueDiligenceInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockDueDiligenceInterface)(nil).GetOne), ctx, id, userID)
+	return &MockDueDiligenceInterfaceGetOneCall{Call: call}
+}
+
+// MockDueDiligenceInterfaceGetOneCall wrap *gomock.Call
+type MockDueDiligenceInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDueDiligenceInterfaceGetOneCall) Return(arg0 *data.DueDiligenceAssessment, arg1 error) *MockDueDiligenceInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDueDiligenceInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.DueDiligenceAssessment, error)) *MockDueDiligenceInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDueDiligenceInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.DueDiligenceAssessment, error)) *MockDueDiligenceInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockDueDiligenceInterface) Insert(ctx context.Context, assessment *data.DueDiligenceAssessment) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, assessment)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockDueDiligenceInterfaceMockRecorder) Insert(ctx, assessment any) *MockDueDiligenceInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockDueDiligenceInterface)(nil).Insert), ctx, assessment)
+	return &MockDueDiligenceInterfaceInsertCall{Call: call}
+}
+
+// MockDueDiligenceInterfaceInsertCall wrap *gomock.Call
+type MockDueDiligenceInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDueDiligenceInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockDueDiligenceInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDueDiligenceInterfaceInsertCall) Do(f func(context.Context, *data.DueDiligenceAssessment) (uint, error)) *MockDueDiligenceInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDueDiligenceInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.DueDiligenceAssessment) (uint, error)) *MockDueDiligenceInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockDueDiligenceInterface) Update(ctx context.Context, assessment *data.DueDiligenceAssessment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, assessment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDueDiligenceInterfaceMockRecorder) Update(ctx, assessment any) *MockDueDiligenceInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDueDiligenceInterface)(nil).Update), ctx, assessment)
+	return &MockDueDiligenceInterfaceUpdateCall{Call: call}
+}
+
+// MockDueDiligenceInterfaceUpdateCall wrap *gomock.Call
+type MockDueDiligenceInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDueDiligenceInterfaceUpdateCall) Return(arg0 error) *MockDueDiligenceInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDueDiligenceInterfaceUpdateCall) Do(f func(context.Context, *data.DueDiligenceAssessment) error) *MockDueDiligenceInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDueDiligenceInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.DueDiligenceAssessment) error) *MockDueDiligenceInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockLotSealInterface is a mock of LotSealInterface interface.
+type MockLotSealInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockLotSealInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockLotSealInterfaceMockRecorder is the mock recorder for MockLotSealInterface.
+type MockLotSealInterfaceMockRecorder struct {
+	mock *MockLotSealInterface
+}
+
+// NewMockLotSealInterface creates a new mock instance.
+func NewMockLotSealInterface(ctrl *gomock.Controller) *MockLotSealInterface {
+	mock := &MockLotSealInterface{ctrl: ctrl}
+	mock.recorder = &MockLotSealInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLotSealInterface) EXPECT() *MockLotSealInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockLotSealInterface) GetByID(ctx context.Context, id uint) (*data.LotSeal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*data.LotSeal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockLotSealInterfaceMockRecorder) GetByID(ctx, id any) *MockLotSealInterfaceGetByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockLotSealInterface)(nil).GetByID), ctx, id)
+	return &MockLotSealInterfaceGetByIDCall{Call: call}
+}
+
+// MockLotSealInterfaceGetByIDCall wrap *gomock.Call
+type MockLotSealInterfaceGetByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockLotSealInterfaceGetByIDCall) Return(arg0 *data.LotSeal, arg1 error) *MockLotSealInterfaceGetByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockLotSealInterfaceGetByIDCall) Do(f func(context.Context, uint) (*data.LotSeal, error)) *MockLotSealInterfaceGetByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockLotSealInterfaceGetByIDCall) DoAndReturn(f func(context.Context, uint) (*data.LotSeal, error)) *MockLotSealInterfaceGetByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByIncome mocks base method.
+func (m *MockLotSealInterface) GetByIncome(ctx context.Context, incomeID, userID uint) (*data.LotSeal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIncome", ctx, incomeID, userID)
+	ret0, _ := ret[0].(*data.LotSeal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIncome indicates an expected call of GetByIncome.
+func (mr *MockLotSealInterfaceMockRecorder) GetByIncome(ctx, incomeID, userID any) *MockLotSealInterfaceGetByIncomeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIncome", reflect.TypeOf((*MockLotSealInterface)(nil).GetByIncome), ctx, incomeID, userID)
+	return &MockLotSealInterfaceGetByIncomeCall{Call: call}
+}
+
+// MockLotSealInterfaceGetByIncomeCall wrap *gomock.Call
+type MockLotSealInterfaceGetByIncomeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockLotSealInterfaceGetByIncomeCall) Return(arg0 *data.LotSeal, arg1 error) *MockLotSealInterfaceGetByIncomeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockLotSealInterfaceGetByIncomeCall) Do(f func(context.Context, uint, uint) (*data.LotSeal, error)) *MockLotSealInterfaceGetByIncomeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockLotSealInterfaceGetByIncomeCall) DoAndReturn(f func(context.Context, uint, uint) (*data.LotSeal, error)) *MockLotSealInterfaceGetByIncomeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockLotSealInterface) Insert(ctx context.Context, seal *data.LotSeal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, seal)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockLotSealInterfaceMockRecorder) Insert(ctx, seal any) *MockLotSealInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockLotSealInterface)(nil).Insert), ctx, seal)
+	return &MockLotSealInterfaceInsertCall{Call: call}
+}
+
+// MockLotSealInterfaceInsertCall wrap *gomock.Call
+type MockLotSealInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockLotSealInterfaceInsertCall) Return(arg0 error) *MockLotSealInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockLotSealInterfaceInsertCall) Do(f func(context.Context, *data.LotSeal) error) *MockLotSealInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockLotSealInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.LotSeal) error) *MockLotSealInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockPaymentInterface is a mock of PaymentInterface interface.
+type MockPaymentInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentInterfaceMockRecorder is the mock recorder for MockPaymentInterface.
+type MockPaymentInterfaceMockRecorder struct {
+	mock *MockPaymentInterface
+}
+
+// NewMockPaymentInterface creates a new mock instance.
+func NewMockPaymentInterface(ctrl *gomock.Controller) *MockPaymentInterface {
+	mock := &MockPaymentInterface{ctrl: ctrl}
+	mock.recorder = &MockPaymentInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentInterface) EXPECT() *MockPaymentInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetForRecord mocks base method.
+func (m *MockPaymentInterface) GetForRecord(ctx context.Context, userID uint, recordType data.TransactionType, recordID uint) ([]*data.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForRecord", ctx, userID, recordType, recordID)
+	ret0, _ := ret[0].([]*data.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForRecord indicates an expected call of GetForRecord.
+func (mr *MockPaymentInterfaceMockRecorder) GetForRecord(ctx, userID, recordType, recordID any) *MockPaymentInterfaceGetForRecordCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForRecord", reflect.TypeOf((*MockPaymentInterface)(nil).GetForRecord), ctx, userID, recordType, recordID)
+	return &MockPaymentInterfaceGetForRecordCall{Call: call}
+}
+
+// MockPaymentInterfaceGetForRecordCall wrap *gomock.Call
+type MockPaymentInterfaceGetForRecordCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPaymentInterfaceGetForRecordCall) Return(arg0 []*data.Payment, arg1 error) *MockPaymentInterfaceGetForRecordCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPaymentInterfaceGetForRecordCall) Do(f func(context.Context, uint, data.TransactionType, uint) ([]*data.Payment, error)) *MockPaymentInterfaceGetForRecordCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPaymentInterfaceGetForRecordCall) DoAndReturn(f func(context.Context, uint, data.TransactionType, uint) ([]*data.Payment, error)) *MockPaymentInterfaceGetForRecordCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RecordExpensePayment mocks base method.
+func (m *MockPaymentInterface) RecordExpensePayment(ctx context.Context, payment *data.Payment) (*data.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordExpensePayment", ctx, payment)
+	ret0, _ := ret[0].(*data.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordExpensePayment indicates an expected call of RecordExpensePayment.
+func (mr *MockPaymentInterfaceMockRecorder) RecordExpensePayment(ctx, payment any) *MockPaymentInterfaceRecordExpensePaymentCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordExpensePayment", reflect.TypeOf((*MockPaymentInterface)(nil).RecordExpensePayment), ctx, payment)
+	return &MockPaymentInterfaceRecordExpensePaymentCall{Call: call}
+}
+
+// MockPaymentInterfaceRecordExpensePaymentCall wrap *gomock.Call
+type MockPaymentInterfaceRecordExpensePaymentCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPaymentInterfaceRecordExpensePaymentCall) Return(arg0 *data.Expense, arg1 error) *MockPaymentInterfaceRecordExpensePaymentCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPaymentInterfaceRecordExpensePaymentCall) Do(f func(context.Context, *data.Payment) (*data.Expense, error)) *MockPaymentInterfaceRecordExpensePaymentCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPaymentInterfaceRecordExpensePaymentCall) DoAndReturn(f func(context.Context, *data.Payment) (*data.Expense, error)) *MockPaymentInterfaceRecordExpensePaymentCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RecordIncomePayment mocks base method.
+func (m *MockPaymentInterface) RecordIncomePayment(ctx context.Context, payment *data.Payment) (*data.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordIncomePayment", ctx, payment)
+	ret0, _ := ret[0].(*data.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordIncomePayment indicates an expected call of RecordIncomePayment.
+func (mr *MockPaymentInterfaceMockRecorder) RecordIncomePayment(ctx, payment any) *MockPaymentInterfaceRecordIncomePaymentCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordIncomePayment", reflect.TypeOf((*MockPaymentInterface)(nil).RecordIncomePayment), ctx, payment)
+	return &MockPaymentInterfaceRecordIncomePaymentCall{Call: call}
+}
+
+// MockPaymentInterfaceRecordIncomePaymentCall wrap *gomock.Call
+type MockPaymentInterfaceRecordIncomePaymentCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPaymentInterfaceRecordIncomePaymentCall) Return(arg0 *data.Income, arg1 error) *MockPaymentInterfaceRecordIncomePaymentCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPaymentInterfaceRecordIncomePaymentCall) Do(f func(context.Context, *data.Payment) (*data.Income, error)) *MockPaymentInterfaceRecordIncomePaymentCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPaymentInterfaceRecordIncomePaymentCall) DoAndReturn(f func(context.Context, *data.Payment) (*data.Income, error)) *MockPaymentInterfaceRecordIncomePaymentCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RecordPurchasePayment mocks base method.
+func (m *MockPaymentInterface) RecordPurchasePayment(ctx context.Context, payment *data.Payment) (*data.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordPurchasePayment", ctx, payment)
+	ret0, _ := ret[0].(*data.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordPurchasePayment indicates an expected call of RecordPurchasePayment.
+func (mr *MockPaymentInterfaceMockRecorder) RecordPurchasePayment(ctx, payment any) *MockPaymentInterfaceRecordPurchasePaymentCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordPurchasePayment", reflect.TypeOf((*MockPaymentInterface)(nil).RecordPurchasePayment), ctx, payment)
+	return &MockPaymentInterfaceRecordPurchasePaymentCall{Call: call}
+}
+
+// MockPaymentInterfaceRecordPurchasePaymentCall wrap *gomock.Call
+type MockPaymentInterfaceRecordPurchasePaymentCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPaymentInterfaceRecordPurchasePaymentCall) Return(arg0 *data.Purchase, arg1 error) *MockPaymentInterfaceRecordPurchasePaymentCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPaymentInterfaceRecordPurchasePaymentCall) Do(f func(context.Context, *data.Payment) (*data.Purchase, error)) *MockPaymentInterfaceRecordPurchasePaymentCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPaymentInterfaceRecordPurchasePaymentCall) DoAndReturn(f func(context.Context, *data.Payment) (*data.Purchase, error)) *MockPaymentInterfaceRecordPurchasePaymentCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockPurchaseInterface is a mock of PurchaseInterface interface.
+type MockPurchaseInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurchaseInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockPurchaseInterfaceMockRecorder is the mock recorder for MockPurchaseInterface.
+type MockPurchaseInterfaceMockRecorder struct {
+	mock *MockPurchaseInterface
+}
+
+// NewMockPurchaseInterface creates a new mock instance.
+func NewMockPurchaseInterface(ctrl *gomock.Controller) *MockPurchaseInterface {
+	mock := &MockPurchaseInterface{ctrl: ctrl}
+	mock.recorder = &MockPurchaseInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurchaseInterface) EXPECT() *MockPurchaseInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockPurchaseInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPurchaseInterfaceMockRecorder) Delete(ctx, id, userID any) *MockPurchaseInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPurchaseInterface)(nil).Delete), ctx, id, userID)
+	return &MockPurchaseInterfaceDeleteCall{Call: call}
+}
+
+// MockPurchaseInterfaceDeleteCall wrap *gomock.Call
+type MockPurchaseInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPurchaseInterfaceDeleteCall) Return(arg0 error) *MockPurchaseInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPurchaseInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockPurchaseInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPurchaseInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockPurchaseInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockPurchaseInterface) GetAll(ctx context.Context, userID uint) ([]*data.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
+	ret0, _ := ret[0].([]*data.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPurchaseInterfaceMockRecorder) GetAll(ctx, userID any) *MockPurchaseInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPurchaseInterface)(nil).GetAll), ctx, userID)
+	return &MockPurchaseInterfaceGetAllCall{Call: call}
+}
+
+// MockPurchaseInterfaceGetAllCall wrap *gomock.Call
+type MockPurchaseInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPurchaseInterfaceGetAllCall) Return(arg0 []*data.Purchase, arg1 error) *MockPurchaseInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPurchaseInterfaceGetAllCall) Do(f func(context.Context, uint) ([]*data.Purchase, error)) *MockPurchaseInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPurchaseInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint) ([]*data.Purchase, error)) *MockPurchaseInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetForMiner mocks base method.
+func (m *MockPurchaseInterface) GetForMiner(ctx context.Context, userID, minerID uint) ([]*data.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForMiner", ctx, userID, minerID)
+	ret0, _ := ret[0].([]*data.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForMiner indicates an expected call of GetForMiner.
+func (mr *MockPurchaseInterfaceMockRecorder) GetForMiner(ctx, userID, minerID any) *MockPurchaseInterfaceGetForMinerCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForMiner", reflect.TypeOf((*MockPurchaseInterface)(nil).GetForMiner), ctx, userID, minerID)
+	return &MockPurchaseInterfaceGetForMinerCall{Call: call}
+}
+
+// MockPurchaseInterfaceGetForMinerCall wrap *gomock.Call
+type MockPurchaseInterfaceGetForMinerCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPurchaseInterfaceGetForMinerCall) Return(arg0 []*data.Purchase, arg1 error) *MockPurchaseInterfaceGetForMinerCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPurchaseInterfaceGetForMinerCall) Do(f func(context.Context, uint, uint) ([]*data.Purchase, error)) *MockPurchaseInterfaceGetForMinerCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPurchaseInterfaceGetForMinerCall) DoAndReturn(f func(context.Context, uint, uint) ([]*data.Purchase, error)) *MockPurchaseInterfaceGetForMinerCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockPurchaseInterface) GetOne(ctx context.Context, id, userID uint) (*data.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockPurchaseInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockPurchaseInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockPurchaseInterface)(nil).GetOne), ctx, id, userID)
+	return &MockPurchaseInterfaceGetOneCall{Call: call}
+}
+
+// MockPurchaseInterfaceGetOneCall wrap *gomock.Call
+type MockPurchaseInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPurchaseInterfaceGetOneCall) Return(arg0 *data.Purchase, arg1 error) *MockPurchaseInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPurchaseInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.Purchase, error)) *MockPurchaseInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPurchaseInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Purchase, error)) *MockPurchaseInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPage mocks base method.
+func (m *MockPurchaseInterface) GetPage(ctx context.Context, userID uint, siteID *uint, offset, limit int) ([]*data.Purchase, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", ctx, userID, siteID, offset, limit)
+	ret0, _ := ret[0].([]*data.Purchase)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockPurchaseInterfaceMockRecorder) GetPage(ctx, userID, siteID, offset, limit any) *MockPurchaseInterfaceGetPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockPurchaseInterface)(nil).GetPage), ctx, userID, siteID, offset, limit)
+	return &MockPurchaseInterfaceGetPageCall{Call: call}
+}
+
+// MockPurchaseInterfaceGetPageCall wrap *gomock.Call
+type MockPurchaseInterfaceGetPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPurchaseInterfaceGetPageCall) Return(arg0 []*data.Purchase, arg1 int64, arg2 error) *MockPurchaseInterfaceGetPageCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPurchaseInterfaceGetPageCall) Do(f func(context.Context, uint, *uint, int, int) ([]*data.Purchase, int64, error)) *MockPurchaseInterfaceGetPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPurchaseInterfaceGetPageCall) DoAndReturn(f func(context.Context, uint, *uint, int, int) ([]*data.Purchase, int64, error)) *MockPurchaseInterfaceGetPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPayables mocks base method.
+func (m *MockPurchaseInterface) GetPayables(ctx context.Context, userID uint, outstandingOnly bool) ([]*data.MinerPayable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayables", ctx, userID, outstandingOnly)
+	ret0, _ := ret[0].([]*data.MinerPayable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayables indicates an expected call of GetPayables.
+func (mr *MockPurchaseInterfaceMockRecorder) GetPayables(ctx, userID, outstandingOnly any) *MockPurchaseInterfaceGetPayablesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayables", reflect.TypeOf((*MockPurchaseInterface)(nil).GetPayables), ctx, userID, outstandingOnly)
+	return &MockPurchaseInterfaceGetPayablesCall{Call: call}
+}
+
+// MockPurchaseInterfaceGetPayablesCall wrap *gomock.Call
+type MockPurchaseInterfaceGetPayablesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPurchaseInterfaceGetPayablesCall) Return(arg0 []*data.MinerPayable, arg1 error) *MockPurchaseInterfaceGetPayablesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPurchaseInterfaceGetPayablesCall) Do(f func(context.Context, uint, bool) ([]*data.MinerPayable, error)) *MockPurchaseInterfaceGetPayablesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPurchaseInterfaceGetPayablesCall) DoAndReturn(f func(context.Context, uint, bool) ([]*data.MinerPayable, error)) *MockPurchaseInterfaceGetPayablesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPriceOverrides mocks base method.
+func (m *MockPurchaseInterface) GetPriceOverrides(ctx context.Context, userID, purchaseID uint) ([]*data.PurchasePriceOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceOverrides", ctx, userID, purchaseID)
+	ret0, _ := ret[0].([]*data.PurchasePriceOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceOverrides indicates an expected call of GetPriceOverrides.
+func (mr *MockPurchaseInterfaceMockRecorder) GetPriceOverrides(ctx, userID, purchaseID any) *MockPurchaseInterfaceGetPriceOverridesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceOverrides", reflect.TypeOf((*MockPurchaseInterface)(nil).GetPriceOverrides), ctx, userID, purchaseID)
+	return &MockPurchaseInterfaceGetPriceOverridesCall{Call: call}
+}
+
+// MockPurchaseInterfaceGetPriceOverridesCall wrap *gomock.Call
+type MockPurchaseInterfaceGetPriceOverridesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPurchaseInterfaceGetPriceOverridesCall) Return(arg0 []*data.PurchasePriceOverride, arg1 error) *MockPurchaseInterfaceGetPriceOverridesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPurchaseInterfaceGetPriceOverridesCall) Do(f func(context.Context, uint, uint) ([]*data.PurchasePriceOverride, error)) *MockPurchaseInterfaceGetPriceOverridesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPurchaseInterfaceGetPriceOverridesCall) DoAndReturn(f func(context.Context, uint, uint) ([]*data.PurchasePriceOverride, error)) *MockPurchaseInterfaceGetPriceOverridesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetStatement mocks base method.
+func (m *MockPurchaseInterface) GetStatement(ctx context.Context, userID uint, minerName string) (*data.MinerStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatement", ctx, userID, minerName)
+	ret0, _ := ret[0].(*data.MinerStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatement indicates an expected call of GetStatement.
+func (mr *MockPurchaseInterfaceMockRecorder) GetStatement(ctx, userID, minerName any) *MockPurchaseInterfaceGetStatementCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatement", reflect.TypeOf((*MockPurchaseInterface)(nil).GetStatement), ctx, userID, minerName)
+	return &MockPurchaseInterfaceGetStatementCall{Call: call}
+}
+
+// MockPurchaseInterfaceGetStatementCall wrap *gomock.Call
+type MockPurchaseInterfaceGetStatementCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPurchaseInterfaceGetStatementCall) Return(arg0 *data.MinerStatement, arg1 error) *MockPurchaseInterfaceGetStatementCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPurchaseInterfaceGetStatementCall) Do(f func(context.Context, uint, string) (*data.MinerStatement, error)) *MockPurchaseInterfaceGetStatementCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPurchaseInterfaceGetStatementCall) DoAndReturn(f func(context.Context, uint, string) (*data.MinerStatement, error)) *MockPurchaseInterfaceGetStatementCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockPurchaseInterface) Insert(ctx context.Context, purchase *data.Purchase) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, purchase)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockPurchaseInterfaceMockRecorder) Insert(ctx, purchase any) *MockPurchaseInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockPurchaseInterface)(nil).Insert), ctx, purchase)
+	return &MockPurchaseInterfaceInsertCall{Call: call}
+}
+
+// MockPurchaseInterfaceInsertCall wrap *gomock.Call
+type MockPurchaseInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPurchaseInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockPurchaseInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPurchaseInterfaceInsertCall) Do(f func(context.Context, *data.Purchase) (uint, error)) *MockPurchaseInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPurchaseInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.Purchase) (uint, error)) *MockPurchaseInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockPurchaseInterface) Update(ctx context.Context, purchase *data.Purchase) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, purchase)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPurchaseInterfaceMockRecorder) Update(ctx, purchase any) *MockPurchaseInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPurchaseInterface)(nil).Update), ctx, purchase)
+	return &MockPurchaseInterfaceUpdateCall{Call: call}
+}
+
+// MockPurchaseInterfaceUpdateCall wrap *gomock.Call
+type MockPurchaseInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPurchaseInterfaceUpdateCall) Return(arg0 error) *MockPurchaseInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPurchaseInterfaceUpdateCall) Do(f func(context.Context, *data.Purchase) error) *MockPurchaseInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPurchaseInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.Purchase) error) *MockPurchaseInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockMinerInterface is a mock of MinerInterface interface.
+type MockMinerInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockMinerInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockMinerInterfaceMockRecorder is the mock recorder for MockMinerInterface.
+type MockMinerInterfaceMockRecorder struct {
+	mock *MockMinerInterface
+}
+
+// NewMockMinerInterface creates a new mock instance.
+func NewMockMinerInterface(ctrl *gomock.Controller) *MockMinerInterface {
+	mock := &MockMinerInterface{ctrl: ctrl}
+	mock.recorder = &MockMinerInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMinerInterface) EXPECT() *MockMinerInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockMinerInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMinerInterfaceMockRecorder) Delete(ctx, id, userID any) *MockMinerInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMinerInterface)(nil).Delete), ctx, id, userID)
+	return &MockMinerInterfaceDeleteCall{Call: call}
+}
+
+// MockMinerInterfaceDeleteCall wrap *gomock.Call
+type MockMinerInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMinerInterfaceDeleteCall) Return(arg0 error) *MockMinerInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMinerInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockMinerInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMinerInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockMinerInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockMinerInterface) GetAll(ctx context.Context, userID uint, siteID *uint) ([]*data.Miner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID, siteID)
+	ret0, _ := ret[0].([]*data.Miner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockMinerInterfaceMockRecorder) GetAll(ctx, userID, siteID any) *MockMinerInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockMinerInterface)(nil).GetAll), ctx, userID, siteID)
+	return &MockMinerInterfaceGetAllCall{Call: call}
+}
+
+// MockMinerInterfaceGetAllCall wrap *gomock.Call
+type MockMinerInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMinerInterfaceGetAllCall) Return(arg0 []*data.Miner, arg1 error) *MockMinerInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMinerInterfaceGetAllCall) Do(f func(context.Context, uint, *uint) ([]*data.Miner, error)) *MockMinerInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMinerInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint, *uint) ([]*data.Miner, error)) *MockMinerInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockMinerInterface) GetOne(ctx context.Context, id, userID uint) (*data.Miner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.Miner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockMinerInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockMinerInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockMinerInterface)(nil).GetOne), ctx, id, userID)
+	return &MockMinerInterfaceGetOneCall{Call: call}
+}
+
+// MockMinerInterfaceGetOneCall wrap *gomock.Call
+type MockMinerInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMinerInterfaceGetOneCall) Return(arg0 *data.Miner, arg1 error) *MockMinerInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMinerInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.Miner, error)) *MockMinerInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMinerInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Miner, error)) *MockMinerInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockMinerInterface) Insert(ctx context.Context, miner *data.Miner) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, miner)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockMinerInterfaceMockRecorder) Insert(ctx, miner any) *MockMinerInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockMinerInterface)(nil).Insert), ctx, miner)
+	return &MockMinerInterfaceInsertCall{Call: call}
+}
+
+// MockMinerInterfaceInsertCall wrap *gomock.Call
+type MockMinerInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMinerInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockMinerInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMinerInterfaceInsertCall) Do(f func(context.Context, *data.Miner) (uint, error)) *MockMinerInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMinerInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.Miner) (uint, error)) *MockMinerInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockMinerInterface) Update(ctx context.Context, miner *data.Miner) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, miner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMinerInterfaceMockRecorder) Update(ctx, miner any) *MockMinerInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMinerInterface)(nil).Update), ctx, miner)
+	return &MockMinerInterfaceUpdateCall{Call: call}
+}
+
+// MockMinerInterfaceUpdateCall wrap *gomock.Call
+type MockMinerInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMinerInterfaceUpdateCall) Return(arg0 error) *MockMinerInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMinerInterfaceUpdateCall) Do(f func(context.Context, *data.Miner) error) *MockMinerInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMinerInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.Miner) error) *MockMinerInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockMarketPriceInterface is a mock of MarketPriceInterface interface.
+type MockMarketPriceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockMarketPriceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockMarketPriceInterfaceMockRecorder is the mock recorder for MockMarketPriceInterface.
+type MockMarketPriceInterfaceMockRecorder struct {
+	mock *MockMarketPriceInterface
+}
+
+// NewMockMarketPriceInterface creates a new mock instance.
+func NewMockMarketPriceInterface(ctrl *gomock.Controller) *MockMarketPriceInterface {
+	mock := &MockMarketPriceInterface{ctrl: ctrl}
+	mock.recorder = &MockMarketPriceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMarketPriceInterface) EXPECT() *MockMarketPriceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockMarketPriceInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMarketPriceInterfaceMockRecorder) Delete(ctx, id, userID any) *MockMarketPriceInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMarketPriceInterface)(nil).Delete), ctx, id, userID)
+	return &MockMarketPriceInterfaceDeleteCall{Call: call}
+}
+
+// MockMarketPriceInterfaceDeleteCall wrap *gomock.Call
+type MockMarketPriceInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMarketPriceInterfaceDeleteCall) Return(arg0 error) *MockMarketPriceInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMarketPriceInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockMarketPriceInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMarketPriceInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockMarketPriceInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockMarketPriceInterface) GetAll(ctx context.Context, userID uint, mineralType data.MineralType) ([]*data.MarketPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID, mineralType)
+	ret0, _ := ret[0].([]*data.MarketPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockMarketPriceInterfaceMockRecorder) GetAll(ctx, userID, mineralType any) *MockMarketPriceInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockMarketPriceInterface)(nil).GetAll), ctx, userID, mineralType)
+	return &MockMarketPriceInterfaceGetAllCall{Call: call}
+}
+
+// MockMarketPriceInterfaceGetAllCall wrap *gomock.Call
+type MockMarketPriceInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMarketPriceInterfaceGetAllCall) Return(arg0 []*data.MarketPrice, arg1 error) *MockMarketPriceInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMarketPriceInterfaceGetAllCall) Do(f func(context.Context, uint, data.MineralType) ([]*data.MarketPrice, error)) *MockMarketPriceInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMarketPriceInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint, data.MineralType) ([]*data.MarketPrice, error)) *MockMarketPriceInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetCurrent mocks base method.
+func (m *MockMarketPriceInterface) GetCurrent(ctx context.Context, userID uint, mineralType data.MineralType, unit string, siteID *uint, date time.Time) (*data.MarketPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrent", ctx, userID, mineralType, unit, siteID, date)
+	ret0, _ := ret[0].(*data.MarketPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrent indicates an expected call of GetCurrent.
+func (mr *MockMarketPriceInterfaceMockRecorder) GetCurrent(ctx, userID, mineralType, unit, siteID, date any) *MockMarketPriceInterfaceGetCurrentCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrent", reflect.TypeOf((*MockMarketPriceInterface)(nil).GetCurrent), ctx, userID, mineralType, unit, siteID, date)
+	return &MockMarketPriceInterfaceGetCurrentCall{Call: call}
+}
+
+// MockMarketPriceInterfaceGetCurrentCall wrap *gomock.Call
+type MockMarketPriceInterfaceGetCurrentCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMarketPriceInterfaceGetCurrentCall) Return(arg0 *data.MarketPrice, arg1 error) *MockMarketPriceInterfaceGetCurrentCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMarketPriceInterfaceGetCurrentCall) Do(f func(context.Context, uint, data.MineralType, string, *uint, time.Time) (*data.MarketPrice, error)) *MockMarketPriceInterfaceGetCurrentCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMarketPriceInterfaceGetCurrentCall) DoAndReturn(f func(context.Context, uint, data.MineralType, string, *uint, time.Time) (*data.MarketPrice, error)) *MockMarketPriceInterfaceGetCurrentCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockMarketPriceInterface) GetOne(ctx context.Context, id, userID uint) (*data.MarketPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.MarketPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockMarketPriceInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockMarketPriceInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockMarketPriceInterface)(nil).GetOne), ctx, id, userID)
+	return &MockMarketPriceInterfaceGetOneCall{Call: call}
+}
+
+// MockMarketPriceInterfaceGetOneCall wrap *gomock.Call
+type MockMarketPriceInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMarketPriceInterfaceGetOneCall) Return(arg0 *data.MarketPrice, arg1 error) *MockMarketPriceInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMarketPriceInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.MarketPrice, error)) *MockMarketPriceInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMarketPriceInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.MarketPrice, error)) *MockMarketPriceInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockMarketPriceInterface) Insert(ctx context.Context, price *data.MarketPrice) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, price)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockMarketPriceInterfaceMockRecorder) Insert(ctx, price any) *MockMarketPriceInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockMarketPriceInterface)(nil).Insert), ctx, price)
+	return &MockMarketPriceInterfaceInsertCall{Call: call}
+}
+
+// MockMarketPriceInterfaceInsertCall wrap *gomock.Call
+type MockMarketPriceInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMarketPriceInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockMarketPriceInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMarketPriceInterfaceInsertCall) Do(f func(context.Context, *data.MarketPrice) (uint, error)) *MockMarketPriceInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMarketPriceInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.MarketPrice) (uint, error)) *MockMarketPriceInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockCashDayInterface is a mock of CashDayInterface interface.
+type MockCashDayInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCashDayInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCashDayInterfaceMockRecorder is the mock recorder for MockCashDayInterface.
+type MockCashDayInterfaceMockRecorder struct {
+	mock *MockCashDayInterface
+}
+
+// NewMockCashDayInterface creates a new mock instance.
+func NewMockCashDayInterface(ctrl *gomock.Controller) *MockCashDayInterface {
+	mock := &MockCashDayInterface{ctrl: ctrl}
+	mock.recorder = &MockCashDayInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCashDayInterface) EXPECT() *MockCashDayInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddMovement mocks base method.
+func (m *MockCashDayInterface) AddMovement(ctx context.Context, movement *data.CashMovement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMovement", ctx, movement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMovement indicates an expected call of AddMovement.
+func (mr *MockCashDayInterfaceMockRecorder) AddMovement(ctx, movement any) *MockCashDayInterfaceAddMovementCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMovement", reflect.TypeOf((*MockCashDayInterface)(nil).AddMovement), ctx, movement)
+	return &MockCashDayInterfaceAddMovementCall{Call: call}
+}
+
+// MockCashDayInterfaceAddMovementCall wrap *gomock.Call
+type MockCashDayInterfaceAddMovementCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCashDayInterfaceAddMovementCall) Return(arg0 error) *MockCashDayInterfaceAddMovementCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCashDayInterfaceAddMovementCall) Do(f func(context.Context, *data.CashMovement) error) *MockCashDayInterfaceAddMovementCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCashDayInterfaceAddMovementCall) DoAndReturn(f func(context.Context, *data.CashMovement) error) *MockCashDayInterfaceAddMovementCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Close mocks base method.
+func (m *MockCashDayInterface) Close(ctx context.Context, day *data.CashDay) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx, day)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockCashDayInterfaceMockRecorder) Close(ctx, day any) *MockCashDayInterfaceCloseCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockCashDayInterface)(nil).Close), ctx, day)
+	return &MockCashDayInterfaceCloseCall{Call: call}
+}
+
+// MockCashDayInterfaceCloseCall wrap *gomock.Call
+type MockCashDayInterfaceCloseCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCashDayInterfaceCloseCall) Return(arg0 error) *MockCashDayInterfaceCloseCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCashDayInterfaceCloseCall) Do(f func(context.Context, *data.CashDay) error) *MockCashDayInterfaceCloseCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCashDayInterfaceCloseCall) DoAndReturn(f func(context.Context, *data.CashDay) error) *MockCashDayInterfaceCloseCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockCashDayInterface) GetAll(ctx context.Context, userID uint, siteID, tillID *uint, status data.CashDayStatus) ([]*data.CashDay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID, siteID, tillID, status)
+	ret0, _ := ret[0].([]*data.CashDay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCashDayInterfaceMockRecorder) GetAll(ctx, userID, siteID, tillID, status any) *MockCashDayInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCashDayInterface)(nil).GetAll), ctx, userID, siteID, tillID, status)
+	return &MockCashDayInterfaceGetAllCall{Call: call}
+}
+
+// MockCashDayInterfaceGetAllCall wrap *gomock.Call
+type MockCashDayInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCashDayInterfaceGetAllCall) Return(arg0 []*data.CashDay, arg1 error) *MockCashDayInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCashDayInterfaceGetAllCall) Do(f func(context.Context, uint, *uint, *uint, data.CashDayStatus) ([]*data.CashDay, error)) *MockCashDayInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCashDayInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint, *uint, *uint, data.CashDayStatus) ([]*data.CashDay, error)) *MockCashDayInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetDiscrepancyReport mocks base method.
+func (m *MockCashDayInterface) GetDiscrepancyReport(ctx context.Context, userID uint, siteID *uint, from, to time.Time) (*data.CashDiscrepancyReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiscrepancyReport", ctx, userID, siteID, from, to)
+	ret0, _ := ret[0].(*data.CashDiscrepancyReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDiscrepancyReport indicates an expected call of GetDiscrepancyReport.
+func (mr *MockCashDayInterfaceMockRecorder) GetDiscrepancyReport(ctx, userID, siteID, from, to any) *MockCashDayInterfaceGetDiscrepancyReportCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiscrepancyReport", reflect.TypeOf((*MockCashDayInterface)(nil).GetDiscrepancyReport), ctx, userID, siteID, from, to)
+	return &MockCashDayInterfaceGetDiscrepancyReportCall{Call: call}
+}
+
+// MockCashDayInterfaceGetDiscrepancyReportCall wrap *gomock.Call
+type MockCashDayInterfaceGetDiscrepancyReportCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCashDayInterfaceGetDiscrepancyReportCall) Return(arg0 *data.CashDiscrepancyReport, arg1 error) *MockCashDayInterfaceGetDiscrepancyReportCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCashDayInterfaceGetDiscrepancyReportCall) Do(f func(context.Context, uint, *uint, time.Time, time.Time) (*data.CashDiscrepancyReport, error)) *MockCashDayInterfaceGetDiscrepancyReportCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCashDayInterfaceGetDiscrepancyReportCall) DoAndReturn(f func(context.Context, uint, *uint, time.Time, time.Time) (*data.CashDiscrepancyReport, error)) *MockCashDayInterfaceGetDiscrepancyReportCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockCashDayInterface) GetOne(ctx context.Context, id, userID uint) (*data.CashDay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.CashDay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockCashDayInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockCashDayInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockCashDayInterface)(nil).GetOne), ctx, id, userID)
+	return &MockCashDayInterfaceGetOneCall{Call: call}
+}
+
+// MockCashDayInterfaceGetOneCall wrap *gomock.Call
+type MockCashDayInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCashDayInterfaceGetOneCall) Return(arg0 *data.CashDay, arg1 error) *MockCashDayInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCashDayInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.CashDay, error)) *MockCashDayInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCashDayInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.CashDay, error)) *MockCashDayInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Open mocks base method.
+func (m *MockCashDayInterface) Open(ctx context.Context, day *data.CashDay) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, day)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockCashDayInterfaceMockRecorder) Open(ctx, day any) *MockCashDayInterfaceOpenCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockCashDayInterface)(nil).Open), ctx, day)
+	return &MockCashDayInterfaceOpenCall{Call: call}
+}
+
+// MockCashDayInterfaceOpenCall wrap *gomock.Call
+type MockCashDayInterfaceOpenCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCashDayInterfaceOpenCall) Return(arg0 error) *MockCashDayInterfaceOpenCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCashDayInterfaceOpenCall) Do(f func(context.Context, *data.CashDay) error) *MockCashDayInterfaceOpenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCashDayInterfaceOpenCall) DoAndReturn(f func(context.Context, *data.CashDay) error) *MockCashDayInterfaceOpenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockTillInterface is a mock of TillInterface interface.
+type MockTillInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTillInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockTillInterfaceMockRecorder is the mock recorder for MockTillInterface.
+type MockTillInterfaceMockRecorder struct {
+	mock *MockTillInterface
+}
+
+// NewMockTillInterface creates a new mock instance.
+func NewMockTillInterface(ctrl *gomock.Controller) *MockTillInterface {
+	mock := &MockTillInterface{ctrl: ctrl}
+	mock.recorder = &MockTillInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTillInterface) EXPECT() *MockTillInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockTillInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTillInterfaceMockRecorder) Delete(ctx, id, userID any) *MockTillInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTillInterface)(nil).Delete), ctx, id, userID)
+	return &MockTillInterfaceDeleteCall{Call: call}
+}
+
+// MockTillInterfaceDeleteCall wrap *gomock.Call
+type MockTillInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTillInterfaceDeleteCall) Return(arg0 error) *MockTillInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTillInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockTillInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTillInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockTillInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockTillInterface) GetAll(ctx context.Context, userID uint, siteID *uint) ([]*data.Till, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID, siteID)
+	ret0, _ := ret[0].([]*data.Till)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTillInterfaceMockRecorder) GetAll(ctx, userID, siteID any) *MockTillInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTillInterface)(nil).GetAll), ctx, userID, siteID)
+	return &MockTillInterfaceGetAllCall{Call: call}
+}
+
+// MockTillInterfaceGetAllCall wrap *gomock.Call
+type MockTillInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTillInterfaceGetAllCall) Return(arg0 []*data.Till, arg1 error) *MockTillInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTillInterfaceGetAllCall) Do(f func(context.Context, uint, *uint) ([]*data.Till, error)) *MockTillInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTillInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint, *uint) ([]*data.Till, error)) *MockTillInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetForClerk mocks base method.
+func (m *MockTillInterface) GetForClerk(ctx context.Context, userID, clerkID uint) (*data.Till, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForClerk", ctx, userID, clerkID)
+	ret0, _ := ret[0].(*data.Till)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForClerk indicates an expected call of GetForClerk.
+func (mr *MockTillInterfaceMockRecorder) GetForClerk(ctx, userID, clerkID any) *MockTillInterfaceGetForClerkCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForClerk", reflect.TypeOf((*MockTillInterface)(nil).GetForClerk), ctx, userID, clerkID)
+	return &MockTillInterfaceGetForClerkCall{Call: call}
+}
+
+// MockTillInterfaceGetForClerkCall wrap *gomock.Call
+type MockTillInterfaceGetForClerkCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTillInterfaceGetForClerkCall) Return(arg0 *data.Till, arg1 error) *MockTillInterfaceGetForClerkCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTillInterfaceGetForClerkCall) Do(f func(context.Context, uint, uint) (*data.Till, error)) *MockTillInterfaceGetForClerkCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTillInterfaceGetForClerkCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Till, error)) *MockTillInterfaceGetForClerkCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockTillInterface) GetOne(ctx context.Context, id, userID uint) (*data.Till, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.Till)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockTillInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockTillInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockTillInterface)(nil).GetOne), ctx, id, userID)
+	return &MockTillInterfaceGetOneCall{Call: call}
+}
+
+// MockTillInterfaceGetOneCall wrap *gomock.Call
+type MockTillInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTillInterfaceGetOneCall) Return(arg0 *data.Till, arg1 error) *MockTillInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTillInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.Till, error)) *MockTillInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTillInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Till, error)) *MockTillInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetVarianceReport mocks base method.
+func (m *MockTillInterface) GetVarianceReport(ctx context.Context, userID uint, siteID *uint, from, to time.Time) (*data.TillVarianceReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVarianceReport", ctx, userID, siteID, from, to)
+	ret0, _ := ret[0].(*data.TillVarianceReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVarianceReport indicates an expected call of GetVarianceReport.
+func (mr *MockTillInterfaceMockRecorder) GetVarianceReport(ctx, userID, siteID, from, to any) *MockTillInterfaceGetVarianceReportCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVarianceReport", reflect.TypeOf((*MockTillInterface)(nil).GetVarianceReport), ctx, userID, siteID, from, to)
+	return &MockTillInterfaceGetVarianceReportCall{Call: call}
+}
+
+// MockTillInterfaceGetVarianceReportCall wrap *gomock.Call
+type MockTillInterfaceGetVarianceReportCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTillInterfaceGetVarianceReportCall) Return(arg0 *data.TillVarianceReport, arg1 error) *MockTillInterfaceGetVarianceReportCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTillInterfaceGetVarianceReportCall) Do(f func(context.Context, uint, *uint, time.Time, time.Time) (*data.TillVarianceReport, error)) *MockTillInterfaceGetVarianceReportCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTillInterfaceGetVarianceReportCall) DoAndReturn(f func(context.Context, uint, *uint, time.Time, time.Time) (*data.TillVarianceReport, error)) *MockTillInterfaceGetVarianceReportCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockTillInterface) Insert(ctx context.Context, till *data.Till) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, till)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockTillInterfaceMockRecorder) Insert(ctx, till any) *MockTillInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockTillInterface)(nil).Insert), ctx, till)
+	return &MockTillInterfaceInsertCall{Call: call}
+}
+
+// MockTillInterfaceInsertCall wrap *gomock.Call
+type MockTillInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTillInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockTillInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTillInterfaceInsertCall) Do(f func(context.Context, *data.Till) (uint, error)) *MockTillInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTillInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.Till) (uint, error)) *MockTillInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockTillInterface) Update(ctx context.Context, till *data.Till) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, till)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTillInterfaceMockRecorder) Update(ctx, till any) *MockTillInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTillInterface)(nil).Update), ctx, till)
+	return &MockTillInterfaceUpdateCall{Call: call}
+}
+
+// MockTillInterfaceUpdateCall wrap *gomock.Call
+type MockTillInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTillInterfaceUpdateCall) Return(arg0 error) *MockTillInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTillInterfaceUpdateCall) Do(f func(context.Context, *data.Till) error) *MockTillInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTillInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.Till) error) *MockTillInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockShiftHandoverInterface is a mock of ShiftHandoverInterface interface.
+type MockShiftHandoverInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockShiftHandoverInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockShiftHandoverInterfaceMockRecorder is the mock recorder for MockShiftHandoverInterface.
+type MockShiftHandoverInterfaceMockRecorder struct {
+	mock *MockShiftHandoverInterface
+}
+
+// NewMockShiftHandoverInterface creates a new mock instance.
+func NewMockShiftHandoverInterface(ctrl *gomock.Controller) *MockShiftHandoverInterface {
+	mock := &MockShiftHandoverInterface{ctrl: ctrl}
+	mock.recorder = &MockShiftHandoverInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShiftHandoverInterface) EXPECT() *MockShiftHandoverInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockShiftHandoverInterface) GetAll(ctx context.Context, userID uint, siteID, tillID *uint, status data.ShiftHandoverStatus) ([]*data.ShiftHandover, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID, siteID, tillID, status)
+	ret0, _ := ret[0].([]*data.ShiftHandover)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockShiftHandoverInterfaceMockRecorder) GetAll(ctx, userID, siteID, tillID, status any) *MockShiftHandoverInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockShiftHandoverInterface)(nil).GetAll), ctx, userID, siteID, tillID, status)
+	return &MockShiftHandoverInterfaceGetAllCall{Call: call}
+}
+
+// MockShiftHandoverInterfaceGetAllCall wrap *gomock.Call
+type MockShiftHandoverInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockShiftHandoverInterfaceGetAllCall) Return(arg0 []*data.ShiftHandover, arg1 error) *MockShiftHandoverInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockShiftHandoverInterfaceGetAllCall) Do(f func(context.Context, uint, *uint, *uint, data.ShiftHandoverStatus) ([]*data.ShiftHandover, error)) *MockShiftHandoverInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockShiftHandoverInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint, *uint, *uint, data.ShiftHandoverStatus) ([]*data.ShiftHandover, error)) *MockShiftHandoverInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockShiftHandoverInterface) GetOne(ctx context.Context, id, userID uint) (*data.ShiftHandover, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.ShiftHandover)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockShiftHandoverInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockShiftHandoverInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockShiftHandoverInterface)(nil).GetOne), ctx, id, userID)
+	return &MockShiftHandoverInterfaceGetOneCall{Call: call}
+}
+
+// MockShiftHandoverInterfaceGetOneCall wrap *gomock.Call
+type MockShiftHandoverInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockShiftHandoverInterfaceGetOneCall) Return(arg0 *data.ShiftHandover, arg1 error) *MockShiftHandoverInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockShiftHandoverInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.ShiftHandover, error)) *MockShiftHandoverInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockShiftHandoverInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.ShiftHandover, error)) *MockShiftHandoverInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockShiftHandoverInterface) Insert(ctx context.Context, handover *data.ShiftHandover) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, handover)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockShiftHandoverInterfaceMockRecorder) Insert(ctx, handover any) *MockShiftHandoverInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockShiftHandoverInterface)(nil).Insert), ctx, handover)
+	return &MockShiftHandoverInterfaceInsertCall{Call: call}
+}
+
+// MockShiftHandoverInterfaceInsertCall wrap *gomock.Call
+type MockShiftHandoverInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockShiftHandoverInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockShiftHandoverInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockShiftHandoverInterfaceInsertCall) Do(f func(context.Context, *data.ShiftHandover) (uint, error)) *MockShiftHandoverInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockShiftHandoverInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.ShiftHandover) (uint, error)) *MockShiftHandoverInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SignOff mocks base method.
+func (m *MockShiftHandoverInterface) SignOff(ctx context.Context, handover *data.ShiftHandover) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignOff", ctx, handover)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SignOff indicates an expected call of SignOff.
+func (mr *MockShiftHandoverInterfaceMockRecorder) SignOff(ctx, handover any) *MockShiftHandoverInterfaceSignOffCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignOff", reflect.TypeOf((*MockShiftHandoverInterface)(nil).SignOff), ctx, handover)
+	return &MockShiftHandoverInterfaceSignOffCall{Call: call}
+}
+
+// MockShiftHandoverInterfaceSignOffCall wrap *gomock.Call
+type MockShiftHandoverInterfaceSignOffCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockShiftHandoverInterfaceSignOffCall) Return(arg0 error) *MockShiftHandoverInterfaceSignOffCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockShiftHandoverInterfaceSignOffCall) Do(f func(context.Context, *data.ShiftHandover) error) *MockShiftHandoverInterfaceSignOffCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockShiftHandoverInterfaceSignOffCall) DoAndReturn(f func(context.Context, *data.ShiftHandover) error) *MockShiftHandoverInterfaceSignOffCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockAssayInterface is a mock of AssayInterface interface.
+type MockAssayInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAssayInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockAssayInterfaceMockRecorder is the mock recorder for MockAssayInterface.
+type MockAssayInterfaceMockRecorder struct {
+	mock *MockAssayInterface
+}
+
+// NewMockAssayInterface creates a new mock instance.
+func NewMockAssayInterface(ctrl *gomock.Controller) *MockAssayInterface {
+	mock := &MockAssayInterface{ctrl: ctrl}
+	mock.recorder = &MockAssayInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAssayInterface) EXPECT() *MockAssayInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockAssayInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAssayInterfaceMockRecorder) Delete(ctx, id, userID any) *MockAssayInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAssayInterface)(nil).Delete), ctx, id, userID)
+	return &MockAssayInterfaceDeleteCall{Call: call}
+}
+
+// MockAssayInterfaceDeleteCall wrap *gomock.Call
+type MockAssayInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAssayInterfaceDeleteCall) Return(arg0 error) *MockAssayInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAssayInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockAssayInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAssayInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockAssayInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockAssayInterface) GetAll(ctx context.Context, userID uint, filter data.AssayFilter) ([]*data.Assay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID, filter)
+	ret0, _ := ret[0].([]*data.Assay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAssayInterfaceMockRecorder) GetAll(ctx, userID, filter any) *MockAssayInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAssayInterface)(nil).GetAll), ctx, userID, filter)
+	return &MockAssayInterfaceGetAllCall{Call: call}
+}
+
+// MockAssayInterfaceGetAllCall wrap *gomock.Call
+type MockAssayInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAssayInterfaceGetAllCall) Return(arg0 []*data.Assay, arg1 error) *MockAssayInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAssayInterfaceGetAllCall) Do(f func(context.Context, uint, data.AssayFilter) ([]*data.Assay, error)) *MockAssayInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAssayInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint, data.AssayFilter) ([]*data.Assay, error)) *MockAssayInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAssayedSales mocks base method.
+func (m *MockAssayInterface) GetAssayedSales(ctx context.Context, userID uint, mineralType data.MineralType, since time.Time) ([]*data.AssayedSale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssayedSales", ctx, userID, mineralType, since)
+	ret0, _ := ret[0].([]*data.AssayedSale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssayedSales indicates an expected call of GetAssayedSales.
+func (mr *MockAssayInterfaceMockRecorder) GetAssayedSales(ctx, userID, mineralType, since any) *MockAssayInterfaceGetAssayedSalesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssayedSales", reflect.TypeOf((*MockAssayInterface)(nil).GetAssayedSales), ctx, userID, mineralType, since)
+	return &MockAssayInterfaceGetAssayedSalesCall{Call: call}
+}
+
+// MockAssayInterfaceGetAssayedSalesCall wrap *gomock.Call
+type MockAssayInterfaceGetAssayedSalesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAssayInterfaceGetAssayedSalesCall) Return(arg0 []*data.AssayedSale, arg1 error) *MockAssayInterfaceGetAssayedSalesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAssayInterfaceGetAssayedSalesCall) Do(f func(context.Context, uint, data.MineralType, time.Time) ([]*data.AssayedSale, error)) *MockAssayInterfaceGetAssayedSalesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAssayInterfaceGetAssayedSalesCall) DoAndReturn(f func(context.Context, uint, data.MineralType, time.Time) ([]*data.AssayedSale, error)) *MockAssayInterfaceGetAssayedSalesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockAssayInterface) GetOne(ctx context.Context, id, userID uint) (*data.Assay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.Assay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockAssayInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockAssayInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockAssayInterface)(nil).GetOne), ctx, id, userID)
+	return &MockAssayInterfaceGetOneCall{Call: call}
+}
+
+// MockAssayInterfaceGetOneCall wrap *gomock.Call
+type MockAssayInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAssayInterfaceGetOneCall) Return(arg0 *data.Assay, arg1 error) *MockAssayInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAssayInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.Assay, error)) *MockAssayInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAssayInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Assay, error)) *MockAssayInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockAssayInterface) Insert(ctx context.Context, assay *data.Assay) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, assay)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockAssayInterfaceMockRecorder) Insert(ctx, assay any) *MockAssayInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockAssayInterface)(nil).Insert), ctx, assay)
+	return &MockAssayInterfaceInsertCall{Call: call}
+}
+
+// MockAssayInterfaceInsertCall wrap *gomock.Call
+type MockAssayInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAssayInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockAssayInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAssayInterfaceInsertCall) Do(f func(context.Context, *data.Assay) (uint, error)) *MockAssayInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAssayInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.Assay) (uint, error)) *MockAssayInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockAssayInterface) Update(ctx context.Context, assay *data.Assay) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, assay)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAssayInterfaceMockRecorder) Update(ctx, assay any) *MockAssayInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAssayInterface)(nil).Update), ctx, assay)
+	return &MockAssayInterfaceUpdateCall{Call: call}
+}
+
+// MockAssayInterfaceUpdateCall wrap *gomock.Call
+type MockAssayInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAssayInterfaceUpdateCall) Return(arg0 error) *MockAssayInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAssayInterfaceUpdateCall) Do(f func(context.Context, *data.Assay) error) *MockAssayInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAssayInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.Assay) error) *MockAssayInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockBulkSMSInterface is a mock of BulkSMSInterface interface.
+type MockBulkSMSInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockBulkSMSInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockBulkSMSInterfaceMockRecorder is the mock recorder for MockBulkSMSInterface.
+type MockBulkSMSInterfaceMockRecorder struct {
+	mock *MockBulkSMSInterface
+}
+
+// NewMockBulkSMSInterface creates a new mock instance.
+func NewMockBulkSMSInterface(ctrl *gomock.Controller) *MockBulkSMSInterface {
+	mock := &MockBulkSMSInterface{ctrl: ctrl}
+	mock.recorder = &MockBulkSMSInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBulkSMSInterface) EXPECT() *MockBulkSMSInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CountSentSince mocks base method.
+func (m *MockBulkSMSInterface) CountSentSince(ctx context.Context, userID uint, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSentSince", ctx, userID, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSentSince indicates an expected call of CountSentSince.
+func (mr *MockBulkSMSInterfaceMockRecorder) CountSentSince(ctx, userID, since any) *MockBulkSMSInterfaceCountSentSinceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSentSince", reflect.TypeOf((*MockBulkSMSInterface)(nil).CountSentSince), ctx, userID, since)
+	return &MockBulkSMSInterfaceCountSentSinceCall{Call: call}
+}
+
+// MockBulkSMSInterfaceCountSentSinceCall wrap *gomock.Call
+type MockBulkSMSInterfaceCountSentSinceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBulkSMSInterfaceCountSentSinceCall) Return(arg0 int64, arg1 error) *MockBulkSMSInterfaceCountSentSinceCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBulkSMSInterfaceCountSentSinceCall) Do(f func(context.Context, uint, time.Time) (int64, error)) *MockBulkSMSInterfaceCountSentSinceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBulkSMSInterfaceCountSentSinceCall) DoAndReturn(f func(context.Context, uint, time.Time) (int64, error)) *MockBulkSMSInterfaceCountSentSinceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetCampaign mocks base method.
+func (m *MockBulkSMSInterface) GetCampaign(ctx context.Context, id, userID uint) (*data.SMSCampaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaign", ctx, id, userID)
+	ret0, _ := ret[0].(*data.SMSCampaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaign indicates an expected call of GetCampaign.
+func (mr *MockBulkSMSInterfaceMockRecorder) GetCampaign(ctx, id, userID any) *MockBulkSMSInterfaceGetCampaignCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaign", reflect.TypeOf((*MockBulkSMSInterface)(nil).GetCampaign), ctx, id, userID)
+	return &MockBulkSMSInterfaceGetCampaignCall{Call: call}
+}
+
+// MockBulkSMSInterfaceGetCampaignCall wrap *gomock.Call
+type MockBulkSMSInterfaceGetCampaignCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBulkSMSInterfaceGetCampaignCall) Return(arg0 *data.SMSCampaign, arg1 error) *MockBulkSMSInterfaceGetCampaignCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBulkSMSInterfaceGetCampaignCall) Do(f func(context.Context, uint, uint) (*data.SMSCampaign, error)) *MockBulkSMSInterfaceGetCampaignCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBulkSMSInterfaceGetCampaignCall) DoAndReturn(f func(context.Context, uint, uint) (*data.SMSCampaign, error)) *MockBulkSMSInterfaceGetCampaignCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetCampaigns mocks base method.
+func (m *MockBulkSMSInterface) GetCampaigns(ctx context.Context, userID uint) ([]*data.SMSCampaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaigns", ctx, userID)
+	ret0, _ := ret[0].([]*data.SMSCampaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaigns indicates an expected call of GetCampaigns.
+func (mr *MockBulkSMSInterfaceMockRecorder) GetCampaigns(ctx, userID any) *MockBulkSMSInterfaceGetCampaignsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaigns", reflect.TypeOf((*MockBulkSMSInterface)(nil).GetCampaigns), ctx, userID)
+	return &MockBulkSMSInterfaceGetCampaignsCall{Call: call}
+}
+
+// MockBulkSMSInterfaceGetCampaignsCall wrap *gomock.Call
+type MockBulkSMSInterfaceGetCampaignsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBulkSMSInterfaceGetCampaignsCall) Return(arg0 []*data.SMSCampaign, arg1 error) *MockBulkSMSInterfaceGetCampaignsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBulkSMSInterfaceGetCampaignsCall) Do(f func(context.Context, uint) ([]*data.SMSCampaign, error)) *MockBulkSMSInterfaceGetCampaignsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBulkSMSInterfaceGetCampaignsCall) DoAndReturn(f func(context.Context, uint) ([]*data.SMSCampaign, error)) *MockBulkSMSInterfaceGetCampaignsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetContacts mocks base method.
+func (m *MockBulkSMSInterface) GetContacts(ctx context.Context, userID uint) ([]*data.SMSContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContacts", ctx, userID)
+	ret0, _ := ret[0].([]*data.SMSContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContacts indicates an expected call of GetContacts.
+func (mr *MockBulkSMSInterfaceMockRecorder) GetContacts(ctx, userID any) *MockBulkSMSInterfaceGetContactsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContacts", reflect.TypeOf((*MockBulkSMSInterface)(nil).GetContacts), ctx, userID)
+	return &MockBulkSMSInterfaceGetContactsCall{Call: call}
+}
+
+// MockBulkSMSInterfaceGetContactsCall wrap *gomock.Call
+type MockBulkSMSInterfaceGetContactsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBulkSMSInterfaceGetContactsCall) Return(arg0 []*data.SMSContact, arg1 error) *MockBulkSMSInterfaceGetContactsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBulkSMSInterfaceGetContactsCall) Do(f func(context.Context, uint) ([]*data.SMSContact, error)) *MockBulkSMSInterfaceGetContactsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBulkSMSInterfaceGetContactsCall) DoAndReturn(f func(context.Context, uint) ([]*data.SMSContact, error)) *MockBulkSMSInterfaceGetContactsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOptOuts mocks base method.
+func (m *MockBulkSMSInterface) GetOptOuts(ctx context.Context, userID uint) ([]*data.SMSOptOut, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOptOuts", ctx, userID)
+	ret0, _ := ret[0].([]*data.SMSOptOut)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOptOuts indicates an expected call of GetOptOuts.
+func (mr *MockBulkSMSInterfaceMockRecorder) GetOptOuts(ctx, userID any) *MockBulkSMSInterfaceGetOptOutsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOptOuts", reflect.TypeOf((*MockBulkSMSInterface)(nil).GetOptOuts), ctx, userID)
+	return &MockBulkSMSInterfaceGetOptOutsCall{Call: call}
+}
+
+// MockBulkSMSInterfaceGetOptOutsCall wrap *gomock.Call
+type MockBulkSMSInterfaceGetOptOutsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBulkSMSInterfaceGetOptOutsCall) Return(arg0 []*data.SMSOptOut, arg1 error) *MockBulkSMSInterfaceGetOptOutsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBulkSMSInterfaceGetOptOutsCall) Do(f func(context.Context, uint) ([]*data.SMSOptOut, error)) *MockBulkSMSInterfaceGetOptOutsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBulkSMSInterfaceGetOptOutsCall) DoAndReturn(f func(context.Context, uint) ([]*data.SMSOptOut, error)) *MockBulkSMSInterfaceGetOptOutsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOptedOutPhones mocks base method.
+func (m *MockBulkSMSInterface) GetOptedOutPhones(ctx context.Context, userID uint) (map[string]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOptedOutPhones", ctx, userID)
+	ret0, _ := ret[0].(map[string]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOptedOutPhones indicates an expected call of GetOptedOutPhones.
+func (mr *MockBulkSMSInterfaceMockRecorder) GetOptedOutPhones(ctx, userID any) *MockBulkSMSInterfaceGetOptedOutPhonesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOptedOutPhones", reflect.TypeOf((*MockBulkSMSInterface)(nil).GetOptedOutPhones), ctx, userID)
+	return &MockBulkSMSInterfaceGetOptedOutPhonesCall{Call: call}
+}
+
+// MockBulkSMSInterfaceGetOptedOutPhonesCall wrap *gomock.Call
+type MockBulkSMSInterfaceGetOptedOutPhonesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBulkSMSInterfaceGetOptedOutPhonesCall) Return(arg0 map[string]bool, arg1 error) *MockBulkSMSInterfaceGetOptedOutPhonesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBulkSMSInterfaceGetOptedOutPhonesCall) Do(f func(context.Context, uint) (map[string]bool, error)) *MockBulkSMSInterfaceGetOptedOutPhonesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBulkSMSInterfaceGetOptedOutPhonesCall) DoAndReturn(f func(context.Context, uint) (map[string]bool, error)) *MockBulkSMSInterfaceGetOptedOutPhonesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetRecipient mocks base method.
+func (m *MockBulkSMSInterface) GetRecipient(ctx context.Context, id uint) (*data.SMSCampaignRecipient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipient", ctx, id)
+	ret0, _ := ret[0].(*data.SMSCampaignRecipient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipient indicates an expected call of GetRecipient.
+func (mr *MockBulkSMSInterfaceMockRecorder) GetRecipient(ctx, id any) *MockBulkSMSInterfaceGetRecipientCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipient", reflect.TypeOf((*MockBulkSMSInterface)(nil).GetRecipient), ctx, id)
+	return &MockBulkSMSInterfaceGetRecipientCall{Call: call}
+}
+
+// MockBulkSMSInterfaceGetRecipientCall wrap *gomock.Call
+type MockBulkSMSInterfaceGetRecipientCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBulkSMSInterfaceGetRecipientCall) Return(arg0 *data.SMSCampaignRecipient, arg1 error) *MockBulkSMSInterfaceGetRecipientCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBulkSMSInterfaceGetRecipientCall) Do(f func(context.Context, uint) (*data.SMSCampaignRecipient, error)) *MockBulkSMSInterfaceGetRecipientCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBulkSMSInterfaceGetRecipientCall) DoAndReturn(f func(context.Context, uint) (*data.SMSCampaignRecipient, error)) *MockBulkSMSInterfaceGetRecipientCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// InsertCampaign mocks base method.
+func (m *MockBulkSMSInterface) InsertCampaign(ctx context.Context, campaign *data.SMSCampaign) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCampaign", ctx, campaign)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertCampaign indicates an expected call of InsertCampaign.
+func (mr *MockBulkSMSInterfaceMockRecorder) InsertCampaign(ctx, campaign any) *MockBulkSMSInterfaceInsertCampaignCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCampaign", reflect.TypeOf((*MockBulkSMSInterface)(nil).InsertCampaign), ctx, campaign)
+	return &MockBulkSMSInterfaceInsertCampaignCall{Call: call}
+}
+
+// MockBulkSMSInterfaceInsertCampaignCall wrap *gomock.Call
+type MockBulkSMSInterfaceInsertCampaignCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBulkSMSInterfaceInsertCampaignCall) Return(arg0 uint, arg1 error) *MockBulkSMSInterfaceInsertCampaignCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBulkSMSInterfaceInsertCampaignCall) Do(f func(context.Context, *data.SMSCampaign) (uint, error)) *MockBulkSMSInterfaceInsertCampaignCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBulkSMSInterfaceInsertCampaignCall) DoAndReturn(f func(context.Context, *data.SMSCampaign) (uint, error)) *MockBulkSMSInterfaceInsertCampaignCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// OptOut mocks base method.
+func (m *MockBulkSMSInterface) OptOut(ctx context.Context, optOut *data.SMSOptOut) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OptOut", ctx, optOut)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OptOut indicates an expected call of OptOut.
+func (mr *MockBulkSMSInterfaceMockRecorder) OptOut(ctx, optOut any) *MockBulkSMSInterfaceOptOutCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OptOut", reflect.TypeOf((*MockBulkSMSInterface)(nil).OptOut), ctx, optOut)
+	return &MockBulkSMSInterfaceOptOutCall{Call: call}
+}
+
+// MockBulkSMSInterfaceOptOutCall wrap *gomock.Call
+type MockBulkSMSInterfaceOptOutCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBulkSMSInterfaceOptOutCall) Return(arg0 error) *MockBulkSMSInterfaceOptOutCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBulkSMSInterfaceOptOutCall) Do(f func(context.Context, *data.SMSOptOut) error) *MockBulkSMSInterfaceOptOutCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBulkSMSInterfaceOptOutCall) DoAndReturn(f func(context.Context, *data.SMSOptOut) error) *MockBulkSMSInterfaceOptOutCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RemoveOptOut mocks base method.
+func (m *MockBulkSMSInterface) RemoveOptOut(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveOptOut", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveOptOut indicates an expected call of RemoveOptOut.
+func (mr *MockBulkSMSInterfaceMockRecorder) RemoveOptOut(ctx, id, userID any) *MockBulkSMSInterfaceRemoveOptOutCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveOptOut", reflect.TypeOf((*MockBulkSMSInterface)(nil).RemoveOptOut), ctx, id, userID)
+	return &MockBulkSMSInterfaceRemoveOptOutCall{Call: call}
+}
+
+// MockBulkSMSInterfaceRemoveOptOutCall wrap *gomock.Call
+type MockBulkSMSInterfaceRemoveOptOutCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBulkSMSInterfaceRemoveOptOutCall) Return(arg0 error) *MockBulkSMSInterfaceRemoveOptOutCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBulkSMSInterfaceRemoveOptOutCall) Do(f func(context.Context, uint, uint) error) *MockBulkSMSInterfaceRemoveOptOutCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBulkSMSInterfaceRemoveOptOutCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockBulkSMSInterfaceRemoveOptOutCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SetRecipientDelivery mocks base method.
+func (m *MockBulkSMSInterface) SetRecipientDelivery(ctx context.Context, id, deliveryID uint, body string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRecipientDelivery", ctx, id, deliveryID, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRecipientDelivery indicates an expected call of SetRecipientDelivery.
+func (mr *MockBulkSMSInterfaceMockRecorder) SetRecipientDelivery(ctx, id, deliveryID, body any) *MockBulkSMSInterfaceSetRecipientDeliveryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecipientDelivery", reflect.TypeOf((*MockBulkSMSInterface)(nil).SetRecipientDelivery), ctx, id, deliveryID, body)
+	return &MockBulkSMSInterfaceSetRecipientDeliveryCall{Call: call}
+}
+
+// MockBulkSMSInterfaceSetRecipientDeliveryCall wrap *gomock.Call
+type MockBulkSMSInterfaceSetRecipientDeliveryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBulkSMSInterfaceSetRecipientDeliveryCall) Return(arg0 error) *MockBulkSMSInterfaceSetRecipientDeliveryCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBulkSMSInterfaceSetRecipientDeliveryCall) Do(f func(context.Context, uint, uint, string) error) *MockBulkSMSInterfaceSetRecipientDeliveryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBulkSMSInterfaceSetRecipientDeliveryCall) DoAndReturn(f func(context.Context, uint, uint, string) error) *MockBulkSMSInterfaceSetRecipientDeliveryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockContactInterface is a mock of ContactInterface interface.
+type MockContactInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockContactInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockContactInterfaceMockRecorder is the mock recorder for MockContactInterface.
+type MockContactInterfaceMockRecorder struct {
+	mock *MockContactInterface
+}
+
+// NewMockContactInterface creates a new mock instance.
+func NewMockContactInterface(ctrl *gomock.Controller) *MockContactInterface {
+	mock := &MockContactInterface{ctrl: ctrl}
+	mock.recorder = &MockContactInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContactInterface) EXPECT() *MockContactInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockContactInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockContactInterfaceMockRecorder) Delete(ctx, id, userID any) *MockContactInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockContactInterface)(nil).Delete), ctx, id, userID)
+	return &MockContactInterfaceDeleteCall{Call: call}
+}
+
+// MockContactInterfaceDeleteCall wrap *gomock.Call
+type MockContactInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockContactInterfaceDeleteCall) Return(arg0 error) *MockContactInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockContactInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockContactInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockContactInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockContactInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockContactInterface) GetAll(ctx context.Context, userID uint, contactType data.ContactType) ([]*data.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID, contactType)
+	ret0, _ := ret[0].([]*data.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockContactInterfaceMockRecorder) GetAll(ctx, userID, contactType any) *MockContactInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockContactInterface)(nil).GetAll), ctx, userID, contactType)
+	return &MockContactInterfaceGetAllCall{Call: call}
+}
+
+// MockContactInterfaceGetAllCall wrap *gomock.Call
+type MockContactInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockContactInterfaceGetAllCall) Return(arg0 []*data.Contact, arg1 error) *MockContactInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockContactInterfaceGetAllCall) Do(f func(context.Context, uint, data.ContactType) ([]*data.Contact, error)) *MockContactInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockContactInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint, data.ContactType) ([]*data.Contact, error)) *MockContactInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockContactInterface) GetOne(ctx context.Context, id, userID uint) (*data.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockContactInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockContactInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockContactInterface)(nil).GetOne), ctx, id, userID)
+	return &MockContactInterfaceGetOneCall{Call: call}
+}
+
+// MockContactInterfaceGetOneCall wrap *gomock.Call
+type MockContactInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockContactInterfaceGetOneCall) Return(arg0 *data.Contact, arg1 error) *MockContactInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockContactInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.Contact, error)) *MockContactInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockContactInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Contact, error)) *MockContactInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Import mocks base method.
+func (m *MockContactInterface) Import(ctx context.Context, userID uint, contacts []*data.Contact) (*data.ContactImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, userID, contacts)
+	ret0, _ := ret[0].(*data.ContactImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockContactInterfaceMockRecorder) Import(ctx, userID, contacts any) *MockContactInterfaceImportCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockContactInterface)(nil).Import), ctx, userID, contacts)
+	return &MockContactInterfaceImportCall{Call: call}
+}
+
+// MockContactInterfaceImportCall wrap *gomock.Call
+type MockContactInterfaceImportCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockContactInterfaceImportCall) Return(arg0 *data.ContactImportResult, arg1 error) *MockContactInterfaceImportCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockContactInterfaceImportCall) Do(f func(context.Context, uint, []*data.Contact) (*data.ContactImportResult, error)) *MockContactInterfaceImportCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockContactInterfaceImportCall) DoAndReturn(f func(context.Context, uint, []*data.Contact) (*data.ContactImportResult, error)) *MockContactInterfaceImportCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockContactInterface) Insert(ctx context.Context, contact *data.Contact) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, contact)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockContactInterfaceMockRecorder) Insert(ctx, contact any) *MockContactInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockContactInterface)(nil).Insert), ctx, contact)
+	return &MockContactInterfaceInsertCall{Call: call}
+}
+
+// MockContactInterfaceInsertCall wrap *gomock.Call
+type MockContactInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockContactInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockContactInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockContactInterfaceInsertCall) Do(f func(context.Context, *data.Contact) (uint, error)) *MockContactInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockContactInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.Contact) (uint, error)) *MockContactInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockContactInterface) Update(ctx context.Context, contact *data.Contact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, contact)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockContactInterfaceMockRecorder) Update(ctx, contact any) *MockContactInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockContactInterface)(nil).Update), ctx, contact)
+	return &MockContactInterfaceUpdateCall{Call: call}
+}
+
+// MockContactInterfaceUpdateCall wrap *gomock.Call
+type MockContactInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockContactInterfaceUpdateCall) Return(arg0 error) *MockContactInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockContactInterfaceUpdateCall) Do(f func(context.Context, *data.Contact) error) *MockContactInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockContactInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.Contact) error) *MockContactInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockCreditLimitInterface is a mock of CreditLimitInterface interface.
+type MockCreditLimitInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCreditLimitInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCreditLimitInterfaceMockRecorder is the mock recorder for MockCreditLimitInterface.
+type MockCreditLimitInterfaceMockRecorder struct {
+	mock *MockCreditLimitInterface
+}
+
+// NewMockCreditLimitInterface creates a new mock instance.
+func NewMockCreditLimitInterface(ctrl *gomock.Controller) *MockCreditLimitInterface {
+	mock := &MockCreditLimitInterface{ctrl: ctrl}
+	mock.recorder = &MockCreditLimitInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCreditLimitInterface) EXPECT() *MockCreditLimitInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockCreditLimitInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCreditLimitInterfaceMockRecorder) Delete(ctx, id, userID any) *MockCreditLimitInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCreditLimitInterface)(nil).Delete), ctx, id, userID)
+	return &MockCreditLimitInterfaceDeleteCall{Call: call}
+}
+
+// MockCreditLimitInterfaceDeleteCall wrap *gomock.Call
+type MockCreditLimitInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCreditLimitInterfaceDeleteCall) Return(arg0 error) *MockCreditLimitInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCreditLimitInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockCreditLimitInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCreditLimitInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockCreditLimitInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockCreditLimitInterface) GetAll(ctx context.Context, userID uint) ([]*data.CustomerCreditLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
+	ret0, _ := ret[0].([]*data.CustomerCreditLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCreditLimitInterfaceMockRecorder) GetAll(ctx, userID any) *MockCreditLimitInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCreditLimitInterface)(nil).GetAll), ctx, userID)
+	return &MockCreditLimitInterfaceGetAllCall{Call: call}
+}
+
+// MockCreditLimitInterfaceGetAllCall wrap *gomock.Call
+type MockCreditLimitInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCreditLimitInterfaceGetAllCall) Return(arg0 []*data.CustomerCreditLimit, arg1 error) *MockCreditLimitInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCreditLimitInterfaceGetAllCall) Do(f func(context.Context, uint) ([]*data.CustomerCreditLimit, error)) *MockCreditLimitInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCreditLimitInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint) ([]*data.CustomerCreditLimit, error)) *MockCreditLimitInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByCustomer mocks base method.
+func (m *MockCreditLimitInterface) GetByCustomer(ctx context.Context, userID uint, customerName string) (*data.CustomerCreditLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCustomer", ctx, userID, customerName)
+	ret0, _ := ret[0].(*data.CustomerCreditLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCustomer indicates an expected call of GetByCustomer.
+func (mr *MockCreditLimitInterfaceMockRecorder) GetByCustomer(ctx, userID, customerName any) *MockCreditLimitInterfaceGetByCustomerCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCustomer", reflect.TypeOf((*MockCreditLimitInterface)(nil).GetByCustomer), ctx, userID, customerName)
+	return &MockCreditLimitInterfaceGetByCustomerCall{Call: call}
+}
+
+// MockCreditLimitInterfaceGetByCustomerCall wrap *gomock.Call
+type MockCreditLimitInterfaceGetByCustomerCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCreditLimitInterfaceGetByCustomerCall) Return(arg0 *data.CustomerCreditLimit, arg1 error) *MockCreditLimitInterfaceGetByCustomerCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCreditLimitInterfaceGetByCustomerCall) Do(f func(context.Context, uint, string) (*data.CustomerCreditLimit, error)) *MockCreditLimitInterfaceGetByCustomerCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCreditLimitInterfaceGetByCustomerCall) DoAndReturn(f func(context.Context, uint, string) (*data.CustomerCreditLimit, error)) *MockCreditLimitInterfaceGetByCustomerCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOutstanding mocks base method.
+func (m *MockCreditLimitInterface) GetOutstanding(ctx context.Context, userID uint, customerName string) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutstanding", ctx, userID, customerName)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutstanding indicates an expected call of GetOutstanding.
+func (mr *MockCreditLimitInterfaceMockRecorder) GetOutstanding(ctx, userID, customerName any) *MockCreditLimitInterfaceGetOutstandingCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutstanding", reflect.TypeOf((*MockCreditLimitInterface)(nil).GetOutstanding), ctx, userID, customerName)
+	return &MockCreditLimitInterfaceGetOutstandingCall{Call: call}
+}
+
+// MockCreditLimitInterfaceGetOutstandingCall wrap *gomock.Call
+type MockCreditLimitInterfaceGetOutstandingCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCreditLimitInterfaceGetOutstandingCall) Return(arg0 float64, arg1 error) *MockCreditLimitInterfaceGetOutstandingCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCreditLimitInterfaceGetOutstandingCall) Do(f func(context.Context, uint, string) (float64, error)) *MockCreditLimitInterfaceGetOutstandingCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCreditLimitInterfaceGetOutstandingCall) DoAndReturn(f func(context.Context, uint, string) (float64, error)) *MockCreditLimitInterfaceGetOutstandingCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Save mocks base method.
+func (m *MockCreditLimitInterface) Save(ctx context.Context, limit *data.CustomerCreditLimit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, limit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockCreditLimitInterfaceMockRecorder) Save(ctx, limit any) *MockCreditLimitInterfaceSaveCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockCreditLimitInterface)(nil).Save), ctx, limit)
+	return &MockCreditLimitInterfaceSaveCall{Call: call}
+}
+
+// MockCreditLimitInterfaceSaveCall wrap *gomock.Call
+type MockCreditLimitInterfaceSaveCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCreditLimitInterfaceSaveCall) Return(arg0 error) *MockCreditLimitInterfaceSaveCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCreditLimitInterfaceSaveCall) Do(f func(context.Context, *data.CustomerCreditLimit) error) *MockCreditLimitInterfaceSaveCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCreditLimitInterfaceSaveCall) DoAndReturn(f func(context.Context, *data.CustomerCreditLimit) error) *MockCreditLimitInterfaceSaveCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockFlagInterface is a mock of FlagInterface interface.
+type MockFlagInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockFlagInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockFlagInterfaceMockRecorder is the mock recorder for MockFlagInterface.
+type MockFlagInterfaceMockRecorder struct {
+	mock *MockFlagInterface
+}
+
+// NewMockFlagInterface creates a new mock instance.
+func NewMockFlagInterface(ctrl *gomock.Controller) *MockFlagInterface {
+	mock := &MockFlagInterface{ctrl: ctrl}
+	mock.recorder = &MockFlagInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFlagInterface) EXPECT() *MockFlagInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockFlagInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFlagInterfaceMockRecorder) Delete(ctx, id, userID any) *MockFlagInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFlagInterface)(nil).Delete), ctx, id, userID)
+	return &MockFlagInterfaceDeleteCall{Call: call}
+}
+
+// MockFlagInterfaceDeleteCall wrap *gomock.Call
+type MockFlagInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockFlagInterfaceDeleteCall) Return(arg0 error) *MockFlagInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockFlagInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockFlagInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockFlagInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockFlagInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockFlagInterface) GetAll(ctx context.Context, userID uint, flagType data.ContactType) ([]*data.CounterpartyFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID, flagType)
+	ret0, _ := ret[0].([]*data.CounterpartyFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockFlagInterfaceMockRecorder) GetAll(ctx, userID, flagType any) *MockFlagInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockFlagInterface)(nil).GetAll), ctx, userID, flagType)
+	return &MockFlagInterfaceGetAllCall{Call: call}
+}
+
+// MockFlagInterfaceGetAllCall wrap *gomock.Call
+type MockFlagInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockFlagInterfaceGetAllCall) Return(arg0 []*data.CounterpartyFlag, arg1 error) *MockFlagInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockFlagInterfaceGetAllCall) Do(f func(context.Context, uint, data.ContactType) ([]*data.CounterpartyFlag, error)) *MockFlagInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockFlagInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint, data.ContactType) ([]*data.CounterpartyFlag, error)) *MockFlagInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetFlag mocks base method.
+func (m *MockFlagInterface) GetFlag(ctx context.Context, userID uint, flagType data.ContactType, name string) (*data.CounterpartyFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlag", ctx, userID, flagType, name)
+	ret0, _ := ret[0].(*data.CounterpartyFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlag indicates an expected call of GetFlag.
+func (mr *MockFlagInterfaceMockRecorder) GetFlag(ctx, userID, flagType, name any) *MockFlagInterfaceGetFlagCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlag", reflect.TypeOf((*MockFlagInterface)(nil).GetFlag), ctx, userID, flagType, name)
+	return &MockFlagInterfaceGetFlagCall{Call: call}
+}
+
+// MockFlagInterfaceGetFlagCall wrap *gomock.Call
+type MockFlagInterfaceGetFlagCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockFlagInterfaceGetFlagCall) Return(arg0 *data.CounterpartyFlag, arg1 error) *MockFlagInterfaceGetFlagCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockFlagInterfaceGetFlagCall) Do(f func(context.Context, uint, data.ContactType, string) (*data.CounterpartyFlag, error)) *MockFlagInterfaceGetFlagCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockFlagInterfaceGetFlagCall) DoAndReturn(f func(context.Context, uint, data.ContactType, string) (*data.CounterpartyFlag, error)) *MockFlagInterfaceGetFlagCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Save mocks base method.
+func (m *MockFlagInterface) Save(ctx context.Context, flag *data.CounterpartyFlag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, flag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockFlagInterfaceMockRecorder) Save(ctx, flag any) *MockFlagInterfaceSaveCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockFlagInterface)(nil).Save), ctx, flag)
+	return &MockFlagInterfaceSaveCall{Call: call}
+}
+
+// MockFlagInterfaceSaveCall wrap *gomock.Call
+type MockFlagInterfaceSaveCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockFlagInterfaceSaveCall) Return(arg0 error) *MockFlagInterfaceSaveCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockFlagInterfaceSaveCall) Do(f func(context.Context, *data.CounterpartyFlag) error) *MockFlagInterfaceSaveCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockFlagInterfaceSaveCall) DoAndReturn(f func(context.Context, *data.CounterpartyFlag) error) *MockFlagInterfaceSaveCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockBenchmarkInterface is a mock of BenchmarkInterface interface.
+type MockBenchmarkInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockBenchmarkInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockBenchmarkInterfaceMockRecorder is the mock recorder for MockBenchmarkInterface.
+type MockBenchmarkInterfaceMockRecorder struct {
+	mock *MockBenchmarkInterface
+}
+
+// NewMockBenchmarkInterface creates a new mock instance.
+func NewMockBenchmarkInterface(ctrl *gomock.Controller) *MockBenchmarkInterface {
+	mock := &MockBenchmarkInterface{ctrl: ctrl}
+	mock.recorder = &MockBenchmarkInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBenchmarkInterface) EXPECT() *MockBenchmarkInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetAveragePrice mocks base method.
+func (m *MockBenchmarkInterface) GetAveragePrice(ctx context.Context, userID uint, mineralType data.MineralType, unit string, since time.Time) (float64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAveragePrice", ctx, userID, mineralType, unit, since)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAveragePrice indicates an expected call of GetAveragePrice.
+func (mr *MockBenchmarkInterfaceMockRecorder) GetAveragePrice(ctx, userID, mineralType, unit, since any) *MockBenchmarkInterfaceGetAveragePriceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAveragePrice", reflect.TypeOf((*MockBenchmarkInterface)(nil).GetAveragePrice), ctx, userID, mineralType, unit, since)
+	return &MockBenchmarkInterfaceGetAveragePriceCall{Call: call}
+}
+
+// MockBenchmarkInterfaceGetAveragePriceCall wrap *gomock.Call
+type MockBenchmarkInterfaceGetAveragePriceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBenchmarkInterfaceGetAveragePriceCall) Return(arg0 float64, arg1 int64, arg2 error) *MockBenchmarkInterfaceGetAveragePriceCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBenchmarkInterfaceGetAveragePriceCall) Do(f func(context.Context, uint, data.MineralType, string, time.Time) (float64, int64, error)) *MockBenchmarkInterfaceGetAveragePriceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBenchmarkInterfaceGetAveragePriceCall) DoAndReturn(f func(context.Context, uint, data.MineralType, string, time.Time) (float64, int64, error)) *MockBenchmarkInterfaceGetAveragePriceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPriceBenchmark mocks base method.
+func (m *MockBenchmarkInterface) GetPriceBenchmark(ctx context.Context, mineralType data.MineralType, unit, region string, since time.Time) (*data.PriceBenchmark, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceBenchmark", ctx, mineralType, unit, region, since)
+	ret0, _ := ret[0].(*data.PriceBenchmark)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceBenchmark indicates an expected call of GetPriceBenchmark.
+func (mr *MockBenchmarkInterfaceMockRecorder) GetPriceBenchmark(ctx, mineralType, unit, region, since any) *MockBenchmarkInterfaceGetPriceBenchmarkCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceBenchmark", reflect.TypeOf((*MockBenchmarkInterface)(nil).GetPriceBenchmark), ctx, mineralType, unit, region, since)
+	return &MockBenchmarkInterfaceGetPriceBenchmarkCall{Call: call}
+}
+
+// MockBenchmarkInterfaceGetPriceBenchmarkCall wrap *gomock.Call
+type MockBenchmarkInterfaceGetPriceBenchmarkCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBenchmarkInterfaceGetPriceBenchmarkCall) Return(arg0 *data.PriceBenchmark, arg1 error) *MockBenchmarkInterfaceGetPriceBenchmarkCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBenchmarkInterfaceGetPriceBenchmarkCall) Do(f func(context.Context, data.MineralType, string, string, time.Time) (*data.PriceBenchmark, error)) *MockBenchmarkInterfaceGetPriceBenchmarkCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBenchmarkInterfaceGetPriceBenchmarkCall) DoAndReturn(f func(context.Context, data.MineralType, string, string, time.Time) (*data.PriceBenchmark, error)) *MockBenchmarkInterfaceGetPriceBenchmarkCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockTradeInterface is a mock of TradeInterface interface.
+type MockTradeInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTradeInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockTradeInterfaceMockRecorder is the mock recorder for MockTradeInterface.
+type MockTradeInterfaceMockRecorder struct {
+	mock *MockTradeInterface
+}
+
+// NewMockTradeInterface creates a new mock instance.
+func NewMockTradeInterface(ctrl *gomock.Controller) *MockTradeInterface {
+	mock := &MockTradeInterface{ctrl: ctrl}
+	mock.recorder = &MockTradeInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTradeInterface) EXPECT() *MockTradeInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockTradeInterface) Accept(ctx context.Context, id, buyerID uint, expense *data.Expense) (*data.SharedSale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", ctx, id, buyerID, expense)
+	ret0, _ := ret[0].(*data.SharedSale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockTradeInterfaceMockRecorder) Accept(ctx, id, buyerID, expense any) *MockTradeInterfaceAcceptCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockTradeInterface)(nil).Accept), ctx, id, buyerID, expense)
+	return &MockTradeInterfaceAcceptCall{Call: call}
+}
+
+// MockTradeInterfaceAcceptCall wrap *gomock.Call
+type MockTradeInterfaceAcceptCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTradeInterfaceAcceptCall) Return(arg0 *data.SharedSale, arg1 error) *MockTradeInterfaceAcceptCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTradeInterfaceAcceptCall) Do(f func(context.Context, uint, uint, *data.Expense) (*data.SharedSale, error)) *MockTradeInterfaceAcceptCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTradeInterfaceAcceptCall) DoAndReturn(f func(context.Context, uint, uint, *data.Expense) (*data.SharedSale, error)) *MockTradeInterfaceAcceptCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// AddDisputeEvent mocks base method.
+func (m *MockTradeInterface) AddDisputeEvent(ctx context.Context, sharedSaleID uint, event *data.TradeDisputeEvent) (*data.TradeDispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDisputeEvent", ctx, sharedSaleID, event)
+	ret0, _ := ret[0].(*data.TradeDispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDisputeEvent indicates an expected call of AddDisputeEvent.
+func (mr *MockTradeInterfaceMockRecorder) AddDisputeEvent(ctx, sharedSaleID, event any) *MockTradeInterfaceAddDisputeEventCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDisputeEvent", reflect.TypeOf((*MockTradeInterface)(nil).AddDisputeEvent), ctx, sharedSaleID, event)
+	return &MockTradeInterfaceAddDisputeEventCall{Call: call}
+}
+
+// MockTradeInterfaceAddDisputeEventCall wrap *gomock.Call
+type MockTradeInterfaceAddDisputeEventCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTradeInterfaceAddDisputeEventCall) Return(arg0 *data.TradeDispute, arg1 error) *MockTradeInterfaceAddDisputeEventCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTradeInterfaceAddDisputeEventCall) Do(f func(context.Context, uint, *data.TradeDisputeEvent) (*data.TradeDispute, error)) *MockTradeInterfaceAddDisputeEventCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTradeInterfaceAddDisputeEventCall) DoAndReturn(f func(context.Context, uint, *data.TradeDisputeEvent) (*data.TradeDispute, error)) *MockTradeInterfaceAddDisputeEventCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Decline mocks base method.
+func (m *MockTradeInterface) Decline(ctx context.Context, id, buyerID uint) (*data.SharedSale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decline", ctx, id, buyerID)
+	ret0, _ := ret[0].(*data.SharedSale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decline indicates an expected call of Decline.
+func (mr *MockTradeInterfaceMockRecorder) Decline(ctx, id, buyerID any) *MockTradeInterfaceDeclineCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decline", reflect.TypeOf((*MockTradeInterface)(nil).Decline), ctx, id, buyerID)
+	return &MockTradeInterfaceDeclineCall{Call: call}
+}
+
+// MockTradeInterfaceDeclineCall wrap *gomock.Call
+type MockTradeInterfaceDeclineCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTradeInterfaceDeclineCall) Return(arg0 *data.SharedSale, arg1 error) *MockTradeInterfaceDeclineCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTradeInterfaceDeclineCall) Do(f func(context.Context, uint, uint) (*data.SharedSale, error)) *MockTradeInterfaceDeclineCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTradeInterfaceDeclineCall) DoAndReturn(f func(context.Context, uint, uint) (*data.SharedSale, error)) *MockTradeInterfaceDeclineCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetDisputes mocks base method.
+func (m *MockTradeInterface) GetDisputes(ctx context.Context, sharedSaleID uint) ([]*data.TradeDispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDisputes", ctx, sharedSaleID)
+	ret0, _ := ret[0].([]*data.TradeDispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDisputes indicates an expected call of GetDisputes.
+func (mr *MockTradeInterfaceMockRecorder) GetDisputes(ctx, sharedSaleID any) *MockTradeInterfaceGetDisputesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDisputes", reflect.TypeOf((*MockTradeInterface)(nil).GetDisputes), ctx, sharedSaleID)
+	return &MockTradeInterfaceGetDisputesCall{Call: call}
+}
+
+// MockTradeInterfaceGetDisputesCall wrap *gomock.Call
+type MockTradeInterfaceGetDisputesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTradeInterfaceGetDisputesCall) Return(arg0 []*data.TradeDispute, arg1 error) *MockTradeInterfaceGetDisputesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTradeInterfaceGetDisputesCall) Do(f func(context.Context, uint) ([]*data.TradeDispute, error)) *MockTradeInterfaceGetDisputesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTradeInterfaceGetDisputesCall) DoAndReturn(f func(context.Context, uint) ([]*data.TradeDispute, error)) *MockTradeInterfaceGetDisputesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetIncoming mocks base method.
+func (m *MockTradeInterface) GetIncoming(ctx context.Context, buyerID uint, status data.TradeStatus) ([]*data.SharedSale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncoming", ctx, buyerID, status)
+	ret0, _ := ret[0].([]*data.SharedSale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncoming indicates an expected call of GetIncoming.
+func (mr *MockTradeInterfaceMockRecorder) GetIncoming(ctx, buyerID, status any) *MockTradeInterfaceGetIncomingCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncoming", reflect.TypeOf((*MockTradeInterface)(nil).GetIncoming), ctx, buyerID, status)
+	return &MockTradeInterfaceGetIncomingCall{Call: call}
+}
+
+// MockTradeInterfaceGetIncomingCall wrap *gomock.Call
+type MockTradeInterfaceGetIncomingCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTradeInterfaceGetIncomingCall) Return(arg0 []*data.SharedSale, arg1 error) *MockTradeInterfaceGetIncomingCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTradeInterfaceGetIncomingCall) Do(f func(context.Context, uint, data.TradeStatus) ([]*data.SharedSale, error)) *MockTradeInterfaceGetIncomingCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTradeInterfaceGetIncomingCall) DoAndReturn(f func(context.Context, uint, data.TradeStatus) ([]*data.SharedSale, error)) *MockTradeInterfaceGetIncomingCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOutgoing mocks base method.
+func (m *MockTradeInterface) GetOutgoing(ctx context.Context, sellerID uint) ([]*data.SharedSale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutgoing", ctx, sellerID)
+	ret0, _ := ret[0].([]*data.SharedSale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutgoing indicates an expected call of GetOutgoing.
+func (mr *MockTradeInterfaceMockRecorder) GetOutgoing(ctx, sellerID any) *MockTradeInterfaceGetOutgoingCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoing", reflect.TypeOf((*MockTradeInterface)(nil).GetOutgoing), ctx, sellerID)
+	return &MockTradeInterfaceGetOutgoingCall{Call: call}
+}
+
+// MockTradeInterfaceGetOutgoingCall wrap *gomock.Call
+type MockTradeInterfaceGetOutgoingCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTradeInterfaceGetOutgoingCall) Return(arg0 []*data.SharedSale, arg1 error) *MockTradeInterfaceGetOutgoingCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTradeInterfaceGetOutgoingCall) Do(f func(context.Context, uint) ([]*data.SharedSale, error)) *MockTradeInterfaceGetOutgoingCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTradeInterfaceGetOutgoingCall) DoAndReturn(f func(context.Context, uint) ([]*data.SharedSale, error)) *MockTradeInterfaceGetOutgoingCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPurchase mocks base method.
+func (m *MockTradeInterface) GetPurchase(ctx context.Context, id, buyerID uint) (*data.SharedSale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchase", ctx, id, buyerID)
+	ret0, _ := ret[0].(*data.SharedSale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchase indicates an expected call of GetPurchase.
+func (mr *MockTradeInterfaceMockRecorder) GetPurchase(ctx, id, buyerID any) *MockTradeInterfaceGetPurchaseCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchase", reflect.TypeOf((*MockTradeInterface)(nil).GetPurchase), ctx, id, buyerID)
+	return &MockTradeInterfaceGetPurchaseCall{Call: call}
+}
+
+// MockTradeInterfaceGetPurchaseCall wrap *gomock.Call
+type MockTradeInterfaceGetPurchaseCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTradeInterfaceGetPurchaseCall) Return(arg0 *data.SharedSale, arg1 error) *MockTradeInterfaceGetPurchaseCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTradeInterfaceGetPurchaseCall) Do(f func(context.Context, uint, uint) (*data.SharedSale, error)) *MockTradeInterfaceGetPurchaseCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTradeInterfaceGetPurchaseCall) DoAndReturn(f func(context.Context, uint, uint) (*data.SharedSale, error)) *MockTradeInterfaceGetPurchaseCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetSharedSale mocks base method.
+func (m *MockTradeInterface) GetSharedSale(ctx context.Context, id, userID uint) (*data.SharedSale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedSale", ctx, id, userID)
+	ret0, _ := ret[0].(*data.SharedSale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedSale indicates an expected call of GetSharedSale.
+func (mr *MockTradeInterfaceMockRecorder) GetSharedSale(ctx, id, userID any) *MockTradeInterfaceGetSharedSaleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedSale", reflect.TypeOf((*MockTradeInterface)(nil).GetSharedSale), ctx, id, userID)
+	return &MockTradeInterfaceGetSharedSaleCall{Call: call}
+}
+
+// MockTradeInterfaceGetSharedSaleCall wrap *gomock.Call
+type MockTradeInterfaceGetSharedSaleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTradeInterfaceGetSharedSaleCall) Return(arg0 *data.SharedSale, arg1 error) *MockTradeInterfaceGetSharedSaleCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTradeInterfaceGetSharedSaleCall) Do(f func(context.Context, uint, uint) (*data.SharedSale, error)) *MockTradeInterfaceGetSharedSaleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTradeInterfaceGetSharedSaleCall) DoAndReturn(f func(context.Context, uint, uint) (*data.SharedSale, error)) *MockTradeInterfaceGetSharedSaleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// OpenDispute mocks base method.
+func (m *MockTradeInterface) OpenDispute(ctx context.Context, dispute *data.TradeDispute, event *data.TradeDisputeEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenDispute", ctx, dispute, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OpenDispute indicates an expected call of OpenDispute.
+func (mr *MockTradeInterfaceMockRecorder) OpenDispute(ctx, dispute, event any) *MockTradeInterfaceOpenDisputeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenDispute", reflect.TypeOf((*MockTradeInterface)(nil).OpenDispute), ctx, dispute, event)
+	return &MockTradeInterfaceOpenDisputeCall{Call: call}
+}
+
+// MockTradeInterfaceOpenDisputeCall wrap *gomock.Call
+type MockTradeInterfaceOpenDisputeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTradeInterfaceOpenDisputeCall) Return(arg0 error) *MockTradeInterfaceOpenDisputeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTradeInterfaceOpenDisputeCall) Do(f func(context.Context, *data.TradeDispute, *data.TradeDisputeEvent) error) *MockTradeInterfaceOpenDisputeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTradeInterfaceOpenDisputeCall) DoAndReturn(f func(context.Context, *data.TradeDispute, *data.TradeDisputeEvent) error) *MockTradeInterfaceOpenDisputeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ResolveDispute mocks base method.
+func (m *MockTradeInterface) ResolveDispute(ctx context.Context, sharedSaleID uint, event *data.TradeDisputeEvent) (*data.TradeDispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveDispute", ctx, sharedSaleID, event)
+	ret0, _ := ret[0].(*data.TradeDispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveDispute indicates an expected call of ResolveDispute.
+func (mr *MockTradeInterfaceMockRecorder) ResolveDispute(ctx, sharedSaleID, event any) *MockTradeInterfaceResolveDisputeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveDispute", reflect.TypeOf((*MockTradeInterface)(nil).ResolveDispute), ctx, sharedSaleID, event)
+	return &MockTradeInterfaceResolveDisputeCall{Call: call}
+}
+
+// MockTradeInterfaceResolveDisputeCall wrap *gomock.Call
+type MockTradeInterfaceResolveDisputeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTradeInterfaceResolveDisputeCall) Return(arg0 *data.TradeDispute, arg1 error) *MockTradeInterfaceResolveDisputeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTradeInterfaceResolveDisputeCall) Do(f func(context.Context, uint, *data.TradeDisputeEvent) (*data.TradeDispute, error)) *MockTradeInterfaceResolveDisputeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTradeInterfaceResolveDisputeCall) DoAndReturn(f func(context.Context, uint, *data.TradeDisputeEvent) (*data.TradeDispute, error)) *MockTradeInterfaceResolveDisputeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Share mocks base method.
+func (m *MockTradeInterface) Share(ctx context.Context, sale *data.SharedSale) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Share", ctx, sale)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Share indicates an expected call of Share.
+func (mr *MockTradeInterfaceMockRecorder) Share(ctx, sale any) *MockTradeInterfaceShareCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Share", reflect.TypeOf((*MockTradeInterface)(nil).Share), ctx, sale)
+	return &MockTradeInterfaceShareCall{Call: call}
+}
+
+// MockTradeInterfaceShareCall wrap *gomock.Call
+type MockTradeInterfaceShareCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTradeInterfaceShareCall) Return(arg0 error) *MockTradeInterfaceShareCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTradeInterfaceShareCall) Do(f func(context.Context, *data.SharedSale) error) *MockTradeInterfaceShareCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTradeInterfaceShareCall) DoAndReturn(f func(context.Context, *data.SharedSale) error) *MockTradeInterfaceShareCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockWebhookInterface is a mock of WebhookInterface interface.
+type MockWebhookInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookInterfaceMockRecorder is the mock recorder for MockWebhookInterface.
+type MockWebhookInterfaceMockRecorder struct {
+	mock *MockWebhookInterface
+}
+
+// NewMockWebhookInterface creates a new mock instance.
+func NewMockWebhookInterface(ctrl *gomock.Controller) *MockWebhookInterface {
+	mock := &MockWebhookInterface{ctrl: ctrl}
+	mock.recorder = &MockWebhookInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookInterface) EXPECT() *MockWebhookInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockWebhookInterface) Delete(ctx context.Context, id, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookInterfaceMockRecorder) Delete(ctx, id, userID any) *MockWebhookInterfaceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhookInterface)(nil).Delete), ctx, id, userID)
+	return &MockWebhookInterfaceDeleteCall{Call: call}
+}
+
+// MockWebhookInterfaceDeleteCall wrap *gomock.Call
+type MockWebhookInterfaceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWebhookInterfaceDeleteCall) Return(arg0 error) *MockWebhookInterfaceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWebhookInterfaceDeleteCall) Do(f func(context.Context, uint, uint) error) *MockWebhookInterfaceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWebhookInterfaceDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockWebhookInterfaceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockWebhookInterface) GetAll(ctx context.Context, userID uint) ([]*data.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
+	ret0, _ := ret[0].([]*data.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockWebhookInterfaceMockRecorder) GetAll(ctx, userID any) *MockWebhookInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockWebhookInterface)(nil).GetAll), ctx, userID)
+	return &MockWebhookInterfaceGetAllCall{Call: call}
+}
+
+// MockWebhookInterfaceGetAllCall wrap *gomock.Call
+type MockWebhookInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWebhookInterfaceGetAllCall) Return(arg0 []*data.Webhook, arg1 error) *MockWebhookInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWebhookInterfaceGetAllCall) Do(f func(context.Context, uint) ([]*data.Webhook, error)) *MockWebhookInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWebhookInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint) ([]*data.Webhook, error)) *MockWebhookInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockWebhookInterface) GetOne(ctx context.Context, id, userID uint) (*data.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockWebhookInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockWebhookInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockWebhookInterface)(nil).GetOne), ctx, id, userID)
+	return &MockWebhookInterfaceGetOneCall{Call: call}
+}
+
+// MockWebhookInterfaceGetOneCall wrap *gomock.Call
+type MockWebhookInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWebhookInterfaceGetOneCall) Return(arg0 *data.Webhook, arg1 error) *MockWebhookInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWebhookInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.Webhook, error)) *MockWebhookInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWebhookInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.Webhook, error)) *MockWebhookInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetSubscribed mocks base method.
+func (m *MockWebhookInterface) GetSubscribed(ctx context.Context, userID uint, event string) ([]*data.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscribed", ctx, userID, event)
+	ret0, _ := ret[0].([]*data.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscribed indicates an expected call of GetSubscribed.
+func (mr *MockWebhookInterfaceMockRecorder) GetSubscribed(ctx, userID, event any) *MockWebhookInterfaceGetSubscribedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscribed", reflect.TypeOf((*MockWebhookInterface)(nil).GetSubscribed), ctx, userID, event)
+	return &MockWebhookInterfaceGetSubscribedCall{Call: call}
+}
+
+// MockWebhookInterfaceGetSubscribedCall wrap *gomock.Call
+type MockWebhookInterfaceGetSubscribedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWebhookInterfaceGetSubscribedCall) Return(arg0 []*data.Webhook, arg1 error) *MockWebhookInterfaceGetSubscribedCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWebhookInterfaceGetSubscribedCall) Do(f func(context.Context, uint, string) ([]*data.Webhook, error)) *MockWebhookInterfaceGetSubscribedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWebhookInterfaceGetSubscribedCall) DoAndReturn(f func(context.Context, uint, string) ([]*data.Webhook, error)) *MockWebhookInterfaceGetSubscribedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockWebhookInterface) Insert(ctx context.Context, webhook *data.Webhook) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, webhook)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockWebhookInterfaceMockRecorder) Insert(ctx, webhook any) *MockWebhookInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockWebhookInterface)(nil).Insert), ctx, webhook)
+	return &MockWebhookInterfaceInsertCall{Call: call}
+}
+
+// MockWebhookInterfaceInsertCall wrap *gomock.Call
+type MockWebhookInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWebhookInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockWebhookInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWebhookInterfaceInsertCall) Do(f func(context.Context, *data.Webhook) (uint, error)) *MockWebhookInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWebhookInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.Webhook) (uint, error)) *MockWebhookInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockBackupInterface is a mock of BackupInterface interface.
+type MockBackupInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackupInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockBackupInterfaceMockRecorder is the mock recorder for MockBackupInterface.
+type MockBackupInterfaceMockRecorder struct {
+	mock *MockBackupInterface
+}
+
+// NewMockBackupInterface creates a new mock instance.
+func NewMockBackupInterface(ctrl *gomock.Controller) *MockBackupInterface {
+	mock := &MockBackupInterface{ctrl: ctrl}
+	mock.recorder = &MockBackupInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackupInterface) EXPECT() *MockBackupInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetLastCompleted mocks base method.
+func (m *MockBackupInterface) GetLastCompleted(ctx context.Context) (*data.Backup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastCompleted", ctx)
+	ret0, _ := ret[0].(*data.Backup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastCompleted indicates an expected call of GetLastCompleted.
+func (mr *MockBackupInterfaceMockRecorder) GetLastCompleted(ctx any) *MockBackupInterfaceGetLastCompletedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastCompleted", reflect.TypeOf((*MockBackupInterface)(nil).GetLastCompleted), ctx)
+	return &MockBackupInterfaceGetLastCompletedCall{Call: call}
+}
+
+// MockBackupInterfaceGetLastCompletedCall wrap *gomock.Call
+type MockBackupInterfaceGetLastCompletedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBackupInterfaceGetLastCompletedCall) Return(arg0 *data.Backup, arg1 error) *MockBackupInterfaceGetLastCompletedCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBackupInterfaceGetLastCompletedCall) Do(f func(context.Context) (*data.Backup, error)) *MockBackupInterfaceGetLastCompletedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBackupInterfaceGetLastCompletedCall) DoAndReturn(f func(context.Context) (*data.Backup, error)) *MockBackupInterfaceGetLastCompletedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetRecent mocks base method.
+func (m *MockBackupInterface) GetRecent(ctx context.Context, limit int) ([]*data.Backup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecent", ctx, limit)
+	ret0, _ := ret[0].([]*data.Backup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecent indicates an expected call of GetRecent.
+func (mr *MockBackupInterfaceMockRecorder) GetRecent(ctx, limit any) *MockBackupInterfaceGetRecentCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecent", reflect.TypeOf((*MockBackupInterface)(nil).GetRecent), ctx, limit)
+	return &MockBackupInterfaceGetRecentCall{Call: call}
+}
+
+// MockBackupInterfaceGetRecentCall wrap *gomock.Call
+type MockBackupInterfaceGetRecentCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBackupInterfaceGetRecentCall) Return(arg0 []*data.Backup, arg1 error) *MockBackupInterfaceGetRecentCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBackupInterfaceGetRecentCall) Do(f func(context.Context, int) ([]*data.Backup, error)) *MockBackupInterfaceGetRecentCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBackupInterfaceGetRecentCall) DoAndReturn(f func(context.Context, int) ([]*data.Backup, error)) *MockBackupInterfaceGetRecentCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockBackupInterface) Insert(ctx context.Context, backup *data.Backup) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, backup)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockBackupInterfaceMockRecorder) Insert(ctx, backup any) *MockBackupInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockBackupInterface)(nil).Insert), ctx, backup)
+	return &MockBackupInterfaceInsertCall{Call: call}
+}
+
+// MockBackupInterfaceInsertCall wrap *gomock.Call
+type MockBackupInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBackupInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockBackupInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBackupInterfaceInsertCall) Do(f func(context.Context, *data.Backup) (uint, error)) *MockBackupInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBackupInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.Backup) (uint, error)) *MockBackupInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockBackupInterface) Update(ctx context.Context, backup *data.Backup) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, backup)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBackupInterfaceMockRecorder) Update(ctx, backup any) *MockBackupInterfaceUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBackupInterface)(nil).Update), ctx, backup)
+	return &MockBackupInterfaceUpdateCall{Call: call}
+}
+
+// MockBackupInterfaceUpdateCall wrap *gomock.Call
+type MockBackupInterfaceUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBackupInterfaceUpdateCall) Return(arg0 error) *MockBackupInterfaceUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBackupInterfaceUpdateCall) Do(f func(context.Context, *data.Backup) error) *MockBackupInterfaceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBackupInterfaceUpdateCall) DoAndReturn(f func(context.Context, *data.Backup) error) *MockBackupInterfaceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockUsageInterface is a mock of UsageInterface interface.
+type MockUsageInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsageInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockUsageInterfaceMockRecorder is the mock recorder for MockUsageInterface.
+type MockUsageInterfaceMockRecorder struct {
+	mock *MockUsageInterface
+}
+
+// NewMockUsageInterface creates a new mock instance.
+func NewMockUsageInterface(ctrl *gomock.Controller) *MockUsageInterface {
+	mock := &MockUsageInterface{ctrl: ctrl}
+	mock.recorder = &MockUsageInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsageInterface) EXPECT() *MockUsageInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetAllCounts mocks base method.
+func (m *MockUsageInterface) GetAllCounts(ctx context.Context, since time.Time) ([]*data.UsageCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCounts", ctx, since)
+	ret0, _ := ret[0].([]*data.UsageCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCounts indicates an expected call of GetAllCounts.
+func (mr *MockUsageInterfaceMockRecorder) GetAllCounts(ctx, since any) *MockUsageInterfaceGetAllCountsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCounts", reflect.TypeOf((*MockUsageInterface)(nil).GetAllCounts), ctx, since)
+	return &MockUsageInterfaceGetAllCountsCall{Call: call}
+}
+
+// MockUsageInterfaceGetAllCountsCall wrap *gomock.Call
+type MockUsageInterfaceGetAllCountsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUsageInterfaceGetAllCountsCall) Return(arg0 []*data.UsageCounts, arg1 error) *MockUsageInterfaceGetAllCountsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUsageInterfaceGetAllCountsCall) Do(f func(context.Context, time.Time) ([]*data.UsageCounts, error)) *MockUsageInterfaceGetAllCountsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUsageInterfaceGetAllCountsCall) DoAndReturn(f func(context.Context, time.Time) ([]*data.UsageCounts, error)) *MockUsageInterfaceGetAllCountsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetCounts mocks base method.
+func (m *MockUsageInterface) GetCounts(ctx context.Context, userID uint, since time.Time) (*data.UsageCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCounts", ctx, userID, since)
+	ret0, _ := ret[0].(*data.UsageCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCounts indicates an expected call of GetCounts.
+func (mr *MockUsageInterfaceMockRecorder) GetCounts(ctx, userID, since any) *MockUsageInterfaceGetCountsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCounts", reflect.TypeOf((*MockUsageInterface)(nil).GetCounts), ctx, userID, since)
+	return &MockUsageInterfaceGetCountsCall{Call: call}
+}
+
+// MockUsageInterfaceGetCountsCall wrap *gomock.Call
+type MockUsageInterfaceGetCountsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUsageInterfaceGetCountsCall) Return(arg0 *data.UsageCounts, arg1 error) *MockUsageInterfaceGetCountsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUsageInterfaceGetCountsCall) Do(f func(context.Context, uint, time.Time) (*data.UsageCounts, error)) *MockUsageInterfaceGetCountsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUsageInterfaceGetCountsCall) DoAndReturn(f func(context.Context, uint, time.Time) (*data.UsageCounts, error)) *MockUsageInterfaceGetCountsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetSubscription mocks base method.
+func (m *MockUsageInterface) GetSubscription(ctx context.Context, userID uint) (*data.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscription", ctx, userID)
+	ret0, _ := ret[0].(*data.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscription indicates an expected call of GetSubscription.
+func (mr *MockUsageInterfaceMockRecorder) GetSubscription(ctx, userID any) *MockUsageInterfaceGetSubscriptionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscription", reflect.TypeOf((*MockUsageInterface)(nil).GetSubscription), ctx, userID)
+	return &MockUsageInterfaceGetSubscriptionCall{Call: call}
+}
+
+// MockUsageInterfaceGetSubscriptionCall wrap *gomock.Call
+type MockUsageInterfaceGetSubscriptionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUsageInterfaceGetSubscriptionCall) Return(arg0 *data.Subscription, arg1 error) *MockUsageInterfaceGetSubscriptionCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUsageInterfaceGetSubscriptionCall) Do(f func(context.Context, uint) (*data.Subscription, error)) *MockUsageInterfaceGetSubscriptionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUsageInterfaceGetSubscriptionCall) DoAndReturn(f func(context.Context, uint) (*data.Subscription, error)) *MockUsageInterfaceGetSubscriptionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetSubscriptions mocks base method.
+func (m *MockUsageInterface) GetSubscriptions(ctx context.Context) ([]*data.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptions", ctx)
+	ret0, _ := ret[0].([]*data.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptions indicates an expected call of GetSubscriptions.
+func (mr *MockUsageInterfaceMockRecorder) GetSubscriptions(ctx any) *MockUsageInterfaceGetSubscriptionsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptions", reflect.TypeOf((*MockUsageInterface)(nil).GetSubscriptions), ctx)
+	return &MockUsageInterfaceGetSubscriptionsCall{Call: call}
+}
+
+// MockUsageInterfaceGetSubscriptionsCall wrap *gomock.Call
+type MockUsageInterfaceGetSubscriptionsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUsageInterfaceGetSubscriptionsCall) Return(arg0 []*data.Subscription, arg1 error) *MockUsageInterfaceGetSubscriptionsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUsageInterfaceGetSubscriptionsCall) Do(f func(context.Context) ([]*data.Subscription, error)) *MockUsageInterfaceGetSubscriptionsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUsageInterfaceGetSubscriptionsCall) DoAndReturn(f func(context.Context) ([]*data.Subscription, error)) *MockUsageInterfaceGetSubscriptionsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetTrials mocks base method.
+func (m *MockUsageInterface) GetTrials(ctx context.Context) ([]*data.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrials", ctx)
+	ret0, _ := ret[0].([]*data.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrials indicates an expected call of GetTrials.
+func (mr *MockUsageInterfaceMockRecorder) GetTrials(ctx any) *MockUsageInterfaceGetTrialsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrials", reflect.TypeOf((*MockUsageInterface)(nil).GetTrials), ctx)
+	return &MockUsageInterfaceGetTrialsCall{Call: call}
+}
+
+// MockUsageInterfaceGetTrialsCall wrap *gomock.Call
+type MockUsageInterfaceGetTrialsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUsageInterfaceGetTrialsCall) Return(arg0 []*data.Subscription, arg1 error) *MockUsageInterfaceGetTrialsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUsageInterfaceGetTrialsCall) Do(f func(context.Context) ([]*data.Subscription, error)) *MockUsageInterfaceGetTrialsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUsageInterfaceGetTrialsCall) DoAndReturn(f func(context.Context) ([]*data.Subscription, error)) *MockUsageInterfaceGetTrialsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SaveSubscription mocks base method.
+func (m *MockUsageInterface) SaveSubscription(ctx context.Context, subscription *data.Subscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSubscription", ctx, subscription)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSubscription indicates an expected call of SaveSubscription.
+func (mr *MockUsageInterfaceMockRecorder) SaveSubscription(ctx, subscription any) *MockUsageInterfaceSaveSubscriptionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSubscription", reflect.TypeOf((*MockUsageInterface)(nil).SaveSubscription), ctx, subscription)
+	return &MockUsageInterfaceSaveSubscriptionCall{Call: call}
+}
+
+// MockUsageInterfaceSaveSubscriptionCall wrap *gomock.Call
+type MockUsageInterfaceSaveSubscriptionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUsageInterfaceSaveSubscriptionCall) Return(arg0 error) *MockUsageInterfaceSaveSubscriptionCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUsageInterfaceSaveSubscriptionCall) Do(f func(context.Context, *data.Subscription) error) *MockUsageInterfaceSaveSubscriptionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUsageInterfaceSaveSubscriptionCall) DoAndReturn(f func(context.Context, *data.Subscription) error) *MockUsageInterfaceSaveSubscriptionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockFeatureInterface is a mock of FeatureInterface interface.
+type MockFeatureInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeatureInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockFeatureInterfaceMockRecorder is the mock recorder for MockFeatureInterface.
+type MockFeatureInterfaceMockRecorder struct {
+	mock *MockFeatureInterface
+}
+
+// NewMockFeatureInterface creates a new mock instance.
+func NewMockFeatureInterface(ctrl *gomock.Controller) *MockFeatureInterface {
+	mock := &MockFeatureInterface{ctrl: ctrl}
+	mock.recorder = &MockFeatureInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeatureInterface) EXPECT() *MockFeatureInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteFlag mocks base method.
+func (m *MockFeatureInterface) DeleteFlag(ctx context.Context, userID uint, feature data.Feature) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFlag", ctx, userID, feature)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFlag indicates an expected call of DeleteFlag.
+func (mr *MockFeatureInterfaceMockRecorder) DeleteFlag(ctx, userID, feature any) *MockFeatureInterfaceDeleteFlagCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFlag", reflect.TypeOf((*MockFeatureInterface)(nil).DeleteFlag), ctx, userID, feature)
+	return &MockFeatureInterfaceDeleteFlagCall{Call: call}
+}
+
+// MockFeatureInterfaceDeleteFlagCall wrap *gomock.Call
+type MockFeatureInterfaceDeleteFlagCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockFeatureInterfaceDeleteFlagCall) Return(arg0 error) *MockFeatureInterfaceDeleteFlagCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockFeatureInterfaceDeleteFlagCall) Do(f func(context.Context, uint, data.Feature) error) *MockFeatureInterfaceDeleteFlagCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockFeatureInterfaceDeleteFlagCall) DoAndReturn(f func(context.Context, uint, data.Feature) error) *MockFeatureInterfaceDeleteFlagCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetFlags mocks base method.
+func (m *MockFeatureInterface) GetFlags(ctx context.Context, userID uint) ([]*data.FeatureFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlags", ctx, userID)
+	ret0, _ := ret[0].([]*data.FeatureFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlags indicates an expected call of GetFlags.
+func (mr *MockFeatureInterfaceMockRecorder) GetFlags(ctx, userID any) *MockFeatureInterfaceGetFlagsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlags", reflect.TypeOf((*MockFeatureInterface)(nil).GetFlags), ctx, userID)
+	return &MockFeatureInterfaceGetFlagsCall{Call: call}
+}
+
+// MockFeatureInterfaceGetFlagsCall wrap *gomock.Call
+type MockFeatureInterfaceGetFlagsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockFeatureInterfaceGetFlagsCall) Return(arg0 []*data.FeatureFlag, arg1 error) *MockFeatureInterfaceGetFlagsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockFeatureInterfaceGetFlagsCall) Do(f func(context.Context, uint) ([]*data.FeatureFlag, error)) *MockFeatureInterfaceGetFlagsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockFeatureInterfaceGetFlagsCall) DoAndReturn(f func(context.Context, uint) ([]*data.FeatureFlag, error)) *MockFeatureInterfaceGetFlagsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SaveFlag mocks base method.
+func (m *MockFeatureInterface) SaveFlag(ctx context.Context, flag *data.FeatureFlag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveFlag", ctx, flag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveFlag indicates an expected call of SaveFlag.
+func (mr *MockFeatureInterfaceMockRecorder) SaveFlag(ctx, flag any) *MockFeatureInterfaceSaveFlagCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFlag", reflect.TypeOf((*MockFeatureInterface)(nil).SaveFlag), ctx, flag)
+	return &MockFeatureInterfaceSaveFlagCall{Call: call}
+}
+
+// MockFeatureInterfaceSaveFlagCall wrap *gomock.Call
+type MockFeatureInterfaceSaveFlagCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockFeatureInterfaceSaveFlagCall) Return(arg0 error) *MockFeatureInterfaceSaveFlagCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockFeatureInterfaceSaveFlagCall) Do(f func(context.Context, *data.FeatureFlag) error) *MockFeatureInterfaceSaveFlagCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockFeatureInterfaceSaveFlagCall) DoAndReturn(f func(context.Context, *data.FeatureFlag) error) *MockFeatureInterfaceSaveFlagCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockReferralInterface is a mock of ReferralInterface interface.
+type MockReferralInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockReferralInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockReferralInterfaceMockRecorder is the mock recorder for MockReferralInterface.
+type MockReferralInterfaceMockRecorder struct {
+	mock *MockReferralInterface
+}
+
+// NewMockReferralInterface creates a new mock instance.
+func NewMockReferralInterface(ctrl *gomock.Controller) *MockReferralInterface {
+	mock := &MockReferralInterface{ctrl: ctrl}
+	mock.recorder = &MockReferralInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReferralInterface) EXPECT() *MockReferralInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Attribute mocks base method.
+func (m *MockReferralInterface) Attribute(ctx context.Context, referral *data.Referral) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attribute", ctx, referral)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Attribute indicates an expected call of Attribute.
+func (mr *MockReferralInterfaceMockRecorder) Attribute(ctx, referral any) *MockReferralInterfaceAttributeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attribute", reflect.TypeOf((*MockReferralInterface)(nil).Attribute), ctx, referral)
+	return &MockReferralInterfaceAttributeCall{Call: call}
+}
+
+// MockReferralInterfaceAttributeCall wrap *gomock.Call
+type MockReferralInterfaceAttributeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockReferralInterfaceAttributeCall) Return(arg0 error) *MockReferralInterfaceAttributeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockReferralInterfaceAttributeCall) Do(f func(context.Context, *data.Referral) error) *MockReferralInterfaceAttributeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockReferralInterfaceAttributeCall) DoAndReturn(f func(context.Context, *data.Referral) error) *MockReferralInterfaceAttributeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByCode mocks base method.
+func (m *MockReferralInterface) GetByCode(ctx context.Context, code string) (*data.ReferralCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCode", ctx, code)
+	ret0, _ := ret[0].(*data.ReferralCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCode indicates an expected call of GetByCode.
+func (mr *MockReferralInterfaceMockRecorder) GetByCode(ctx, code any) *MockReferralInterfaceGetByCodeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCode", reflect.TypeOf((*MockReferralInterface)(nil).GetByCode), ctx, code)
+	return &MockReferralInterfaceGetByCodeCall{Call: call}
+}
+
+// MockReferralInterfaceGetByCodeCall wrap *gomock.Call
+type MockReferralInterfaceGetByCodeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockReferralInterfaceGetByCodeCall) Return(arg0 *data.ReferralCode, arg1 error) *MockReferralInterfaceGetByCodeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockReferralInterfaceGetByCodeCall) Do(f func(context.Context, string) (*data.ReferralCode, error)) *MockReferralInterfaceGetByCodeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockReferralInterfaceGetByCodeCall) DoAndReturn(f func(context.Context, string) (*data.ReferralCode, error)) *MockReferralInterfaceGetByCodeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOrCreateCode mocks base method.
+func (m *MockReferralInterface) GetOrCreateCode(ctx context.Context, userID uint) (*data.ReferralCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrCreateCode", ctx, userID)
+	ret0, _ := ret[0].(*data.ReferralCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrCreateCode indicates an expected call of GetOrCreateCode.
+func (mr *MockReferralInterfaceMockRecorder) GetOrCreateCode(ctx, userID any) *MockReferralInterfaceGetOrCreateCodeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrCreateCode", reflect.TypeOf((*MockReferralInterface)(nil).GetOrCreateCode), ctx, userID)
+	return &MockReferralInterfaceGetOrCreateCodeCall{Call: call}
+}
+
+// MockReferralInterfaceGetOrCreateCodeCall wrap *gomock.Call
+type MockReferralInterfaceGetOrCreateCodeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockReferralInterfaceGetOrCreateCodeCall) Return(arg0 *data.ReferralCode, arg1 error) *MockReferralInterfaceGetOrCreateCodeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockReferralInterfaceGetOrCreateCodeCall) Do(f func(context.Context, uint) (*data.ReferralCode, error)) *MockReferralInterfaceGetOrCreateCodeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockReferralInterfaceGetOrCreateCodeCall) DoAndReturn(f func(context.Context, uint) (*data.ReferralCode, error)) *MockReferralInterfaceGetOrCreateCodeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetReferredUsers mocks base method.
+func (m *MockReferralInterface) GetReferredUsers(ctx context.Context, referrerID uint) ([]*data.ReferredUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReferredUsers", ctx, referrerID)
+	ret0, _ := ret[0].([]*data.ReferredUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReferredUsers indicates an expected call of GetReferredUsers.
+func (mr *MockReferralInterfaceMockRecorder) GetReferredUsers(ctx, referrerID any) *MockReferralInterfaceGetReferredUsersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReferredUsers", reflect.TypeOf((*MockReferralInterface)(nil).GetReferredUsers), ctx, referrerID)
+	return &MockReferralInterfaceGetReferredUsersCall{Call: call}
+}
+
+// MockReferralInterfaceGetReferredUsersCall wrap *gomock.Call
+type MockReferralInterfaceGetReferredUsersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockReferralInterfaceGetReferredUsersCall) Return(arg0 []*data.ReferredUser, arg1 error) *MockReferralInterfaceGetReferredUsersCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockReferralInterfaceGetReferredUsersCall) Do(f func(context.Context, uint) ([]*data.ReferredUser, error)) *MockReferralInterfaceGetReferredUsersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockReferralInterfaceGetReferredUsersCall) DoAndReturn(f func(context.Context, uint) ([]*data.ReferredUser, error)) *MockReferralInterfaceGetReferredUsersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetReferrers mocks base method.
+func (m *MockReferralInterface) GetReferrers(ctx context.Context, from, to *time.Time) ([]*data.ReferrerStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReferrers", ctx, from, to)
+	ret0, _ := ret[0].([]*data.ReferrerStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReferrers indicates an expected call of GetReferrers.
+func (mr *MockReferralInterfaceMockRecorder) GetReferrers(ctx, from, to any) *MockReferralInterfaceGetReferrersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReferrers", reflect.TypeOf((*MockReferralInterface)(nil).GetReferrers), ctx, from, to)
+	return &MockReferralInterfaceGetReferrersCall{Call: call}
+}
+
+// MockReferralInterfaceGetReferrersCall wrap *gomock.Call
+type MockReferralInterfaceGetReferrersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockReferralInterfaceGetReferrersCall) Return(arg0 []*data.ReferrerStats, arg1 error) *MockReferralInterfaceGetReferrersCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockReferralInterfaceGetReferrersCall) Do(f func(context.Context, *time.Time, *time.Time) ([]*data.ReferrerStats, error)) *MockReferralInterfaceGetReferrersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockReferralInterfaceGetReferrersCall) DoAndReturn(f func(context.Context, *time.Time, *time.Time) ([]*data.ReferrerStats, error)) *MockReferralInterfaceGetReferrersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockSupportInterface is a mock of SupportInterface interface.
+type MockSupportInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSupportInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSupportInterfaceMockRecorder is the mock recorder for MockSupportInterface.
+type MockSupportInterfaceMockRecorder struct {
+	mock *MockSupportInterface
+}
+
+// NewMockSupportInterface creates a new mock instance.
+func NewMockSupportInterface(ctrl *gomock.Controller) *MockSupportInterface {
+	mock := &MockSupportInterface{ctrl: ctrl}
+	mock.recorder = &MockSupportInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSupportInterface) EXPECT() *MockSupportInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockSupportInterface) GetAll(ctx context.Context, userID uint) ([]*data.SupportTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
+	ret0, _ := ret[0].([]*data.SupportTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockSupportInterfaceMockRecorder) GetAll(ctx, userID any) *MockSupportInterfaceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockSupportInterface)(nil).GetAll), ctx, userID)
+	return &MockSupportInterfaceGetAllCall{Call: call}
+}
+
+// MockSupportInterfaceGetAllCall wrap *gomock.Call
+type MockSupportInterfaceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSupportInterfaceGetAllCall) Return(arg0 []*data.SupportTicket, arg1 error) *MockSupportInterfaceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSupportInterfaceGetAllCall) Do(f func(context.Context, uint) ([]*data.SupportTicket, error)) *MockSupportInterfaceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSupportInterfaceGetAllCall) DoAndReturn(f func(context.Context, uint) ([]*data.SupportTicket, error)) *MockSupportInterfaceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOne mocks base method.
+func (m *MockSupportInterface) GetOne(ctx context.Context, id, userID uint) (*data.SupportTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", ctx, id, userID)
+	ret0, _ := ret[0].(*data.SupportTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockSupportInterfaceMockRecorder) GetOne(ctx, id, userID any) *MockSupportInterfaceGetOneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockSupportInterface)(nil).GetOne), ctx, id, userID)
+	return &MockSupportInterfaceGetOneCall{Call: call}
+}
+
+// MockSupportInterfaceGetOneCall wrap *gomock.Call
+type MockSupportInterfaceGetOneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSupportInterfaceGetOneCall) Return(arg0 *data.SupportTicket, arg1 error) *MockSupportInterfaceGetOneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSupportInterfaceGetOneCall) Do(f func(context.Context, uint, uint) (*data.SupportTicket, error)) *MockSupportInterfaceGetOneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSupportInterfaceGetOneCall) DoAndReturn(f func(context.Context, uint, uint) (*data.SupportTicket, error)) *MockSupportInterfaceGetOneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetRecent mocks base method.
+func (m *MockSupportInterface) GetRecent(ctx context.Context, status string, limit int) ([]*data.SupportTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecent", ctx, status, limit)
+	ret0, _ := ret[0].([]*data.SupportTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecent indicates an expected call of GetRecent.
+func (mr *MockSupportInterfaceMockRecorder) GetRecent(ctx, status, limit any) *MockSupportInterfaceGetRecentCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecent", reflect.TypeOf((*MockSupportInterface)(nil).GetRecent), ctx, status, limit)
+	return &MockSupportInterfaceGetRecentCall{Call: call}
+}
+
+// MockSupportInterfaceGetRecentCall wrap *gomock.Call
+type MockSupportInterfaceGetRecentCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSupportInterfaceGetRecentCall) Return(arg0 []*data.SupportTicket, arg1 error) *MockSupportInterfaceGetRecentCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSupportInterfaceGetRecentCall) Do(f func(context.Context, string, int) ([]*data.SupportTicket, error)) *MockSupportInterfaceGetRecentCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSupportInterfaceGetRecentCall) DoAndReturn(f func(context.Context, string, int) ([]*data.SupportTicket, error)) *MockSupportInterfaceGetRecentCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Insert mocks base method.
+func (m *MockSupportInterface) Insert(ctx context.Context, ticket *data.SupportTicket) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, ticket)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockSupportInterfaceMockRecorder) Insert(ctx, ticket any) *MockSupportInterfaceInsertCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockSupportInterface)(nil).Insert), ctx, ticket)
+	return &MockSupportInterfaceInsertCall{Call: call}
+}
+
+// MockSupportInterfaceInsertCall wrap *gomock.Call
+type MockSupportInterfaceInsertCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSupportInterfaceInsertCall) Return(arg0 uint, arg1 error) *MockSupportInterfaceInsertCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSupportInterfaceInsertCall) Do(f func(context.Context, *data.SupportTicket) (uint, error)) *MockSupportInterfaceInsertCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSupportInterfaceInsertCall) DoAndReturn(f func(context.Context, *data.SupportTicket) (uint, error)) *MockSupportInterfaceInsertCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Resolve mocks base method.
+func (m *MockSupportInterface) Resolve(ctx context.Context, id uint) (*data.SupportTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, id)
+	ret0, _ := ret[0].(*data.SupportTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockSupportInterfaceMockRecorder) Resolve(ctx, id any) *MockSupportInterfaceResolveCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockSupportInterface)(nil).Resolve), ctx, id)
+	return &MockSupportInterfaceResolveCall{Call: call}
+}
+
+// MockSupportInterfaceResolveCall wrap *gomock.Call
+type MockSupportInterfaceResolveCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSupportInterfaceResolveCall) Return(arg0 *data.SupportTicket, arg1 error) *MockSupportInterfaceResolveCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSupportInterfaceResolveCall) Do(f func(context.Context, uint) (*data.SupportTicket, error)) *MockSupportInterfaceResolveCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSupportInterfaceResolveCall) DoAndReturn(f func(context.Context, uint) (*data.SupportTicket, error)) *MockSupportInterfaceResolveCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SetDelivery mocks base method.
+func (m *MockSupportInterface) SetDelivery(ctx context.Context, id, deliveryID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDelivery", ctx, id, deliveryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDelivery indicates an expected call of SetDelivery.
+func (mr *MockSupportInterfaceMockRecorder) SetDelivery(ctx, id, deliveryID any) *MockSupportInterfaceSetDeliveryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelivery", reflect.TypeOf((*MockSupportInterface)(nil).SetDelivery), ctx, id, deliveryID)
+	return &MockSupportInterfaceSetDeliveryCall{Call: call}
+}
+
+// MockSupportInterfaceSetDeliveryCall wrap *gomock.Call
+type MockSupportInterfaceSetDeliveryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSupportInterfaceSetDeliveryCall) Return(arg0 error) *MockSupportInterfaceSetDeliveryCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSupportInterfaceSetDeliveryCall) Do(f func(context.Context, uint, uint) error) *MockSupportInterfaceSetDeliveryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSupportInterfaceSetDeliveryCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockSupportInterfaceSetDeliveryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockLeaseInterface is a mock of LeaseInterface interface.
+type MockLeaseInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockLeaseInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockLeaseInterfaceMockRecorder is the mock recorder for MockLeaseInterface.
+type MockLeaseInterfaceMockRecorder struct {
+	mock *MockLeaseInterface
+}
+
+// NewMockLeaseInterface creates a new mock instance.
+func NewMockLeaseInterface(ctrl *gomock.Controller) *MockLeaseInterface {
+	mock := &MockLeaseInterface{ctrl: ctrl}
+	mock.recorder = &MockLeaseInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLeaseInterface) EXPECT() *MockLeaseInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Acquire mocks base method.
+func (m *MockLeaseInterface) Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acquire", ctx, name, holder, ttl)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Acquire indicates an expected call of Acquire.
+func (mr *MockLeaseInterfaceMockRecorder) Acquire(ctx, name, holder, ttl any) *MockLeaseInterfaceAcquireCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acquire", reflect.TypeOf((*MockLeaseInterface)(nil).Acquire), ctx, name, holder, ttl)
+	return &MockLeaseInterfaceAcquireCall{Call: call}
+}
+
+// MockLeaseInterfaceAcquireCall wrap *gomock.Call
+type MockLeaseInterfaceAcquireCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockLeaseInterfaceAcquireCall) Return(arg0 bool, arg1 error) *MockLeaseInterfaceAcquireCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockLeaseInterfaceAcquireCall) Do(f func(context.Context, string, string, time.Duration) (bool, error)) *MockLeaseInterfaceAcquireCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockLeaseInterfaceAcquireCall) DoAndReturn(f func(context.Context, string, string, time.Duration) (bool, error)) *MockLeaseInterfaceAcquireCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Release mocks base method.
+func (m *MockLeaseInterface) Release(ctx context.Context, name, holder string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, name, holder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockLeaseInterfaceMockRecorder) Release(ctx, name, holder any) *MockLeaseInterfaceReleaseCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockLeaseInterface)(nil).Release), ctx, name, holder)
+	return &MockLeaseInterfaceReleaseCall{Call: call}
+}
+
+// MockLeaseInterfaceReleaseCall wrap *gomock.Call
+type MockLeaseInterfaceReleaseCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockLeaseInterfaceReleaseCall) Return(arg0 error) *MockLeaseInterfaceReleaseCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockLeaseInterfaceReleaseCall) Do(f func(context.Context, string, string) error) *MockLeaseInterfaceReleaseCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockLeaseInterfaceReleaseCall) DoAndReturn(f func(context.Context, string, string) error) *MockLeaseInterfaceReleaseCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockRateLimitInterface is a mock of RateLimitInterface interface.
+type MockRateLimitInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimitInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockRateLimitInterfaceMockRecorder is the mock recorder for MockRateLimitInterface.
+type MockRateLimitInterfaceMockRecorder struct {
+	mock *MockRateLimitInterface
+}
+
+// NewMockRateLimitInterface creates a new mock instance.
+func NewMockRateLimitInterface(ctrl *gomock.Controller) *MockRateLimitInterface {
+	mock := &MockRateLimitInterface{ctrl: ctrl}
+	mock.recorder = &MockRateLimitInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimitInterface) EXPECT() *MockRateLimitInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteBefore mocks base method.
+func (m *MockRateLimitInterface) DeleteBefore(ctx context.Context, windowStart int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBefore", ctx, windowStart)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBefore indicates an expected call of DeleteBefore.
+func (mr *MockRateLimitInterfaceMockRecorder) DeleteBefore(ctx, windowStart any) *MockRateLimitInterfaceDeleteBeforeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBefore", reflect.TypeOf((*MockRateLimitInterface)(nil).DeleteBefore), ctx, windowStart)
+	return &MockRateLimitInterfaceDeleteBeforeCall{Call: call}
+}
+
+// MockRateLimitInterfaceDeleteBeforeCall wrap *gomock.Call
+type MockRateLimitInterfaceDeleteBeforeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRateLimitInterfaceDeleteBeforeCall) Return(arg0 int64, arg1 error) *MockRateLimitInterfaceDeleteBeforeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRateLimitInterfaceDeleteBeforeCall) Do(f func(context.Context, int64) (int64, error)) *MockRateLimitInterfaceDeleteBeforeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRateLimitInterfaceDeleteBeforeCall) DoAndReturn(f func(context.Context, int64) (int64, error)) *MockRateLimitInterfaceDeleteBeforeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Hit mocks base method.
+func (m *MockRateLimitInterface) Hit(ctx context.Context, name string, windowStart int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hit", ctx, name, windowStart)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hit indicates an expected call of Hit.
+func (mr *MockRateLimitInterfaceMockRecorder) Hit(ctx, name, windowStart any) *MockRateLimitInterfaceHitCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hit", reflect.TypeOf((*MockRateLimitInterface)(nil).Hit), ctx, name, windowStart)
+	return &MockRateLimitInterfaceHitCall{Call: call}
+}
+
+// MockRateLimitInterfaceHitCall wrap *gomock.Call
+type MockRateLimitInterfaceHitCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRateLimitInterfaceHitCall) Return(arg0 int64, arg1 error) *MockRateLimitInterfaceHitCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRateLimitInterfaceHitCall) Do(f func(context.Context, string, int64) (int64, error)) *MockRateLimitInterfaceHitCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRateLimitInterfaceHitCall) DoAndReturn(f func(context.Context, string, int64) (int64, error)) *MockRateLimitInterfaceHitCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockIdempotencyInterface is a mock of IdempotencyInterface interface.
+type MockIdempotencyInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockIdempotencyInterfaceMockRecorder is the mock recorder for MockIdempotencyInterface.
+type MockIdempotencyInterfaceMockRecorder struct {
+	mock *MockIdempotencyInterface
+}
+
+// NewMockIdempotencyInterface creates a new mock instance.
+func NewMockIdempotencyInterface(ctrl *gomock.Controller) *MockIdempotencyInterface {
+	mock := &MockIdempotencyInterface{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyInterface) EXPECT() *MockIdempotencyInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Begin mocks base method.
+func (m *MockIdempotencyInterface) Begin(ctx context.Context, actorID uint, key, fingerprint string) (*data.IdempotentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin", ctx, actorID, key, fingerprint)
+	ret0, _ := ret[0].(*data.IdempotentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Begin indicates an expected call of Begin.
+func (mr *MockIdempotencyInterfaceMockRecorder) Begin(ctx, actorID, key, fingerprint any) *MockIdempotencyInterfaceBeginCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockIdempotencyInterface)(nil).Begin), ctx, actorID, key, fingerprint)
+	return &MockIdempotencyInterfaceBeginCall{Call: call}
+}
+
+// MockIdempotencyInterfaceBeginCall wrap *gomock.Call
+type MockIdempotencyInterfaceBeginCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIdempotencyInterfaceBeginCall) Return(arg0 *data.IdempotentRequest, arg1 error) *MockIdempotencyInterfaceBeginCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIdempotencyInterfaceBeginCall) Do(f func(context.Context, uint, string, string) (*data.IdempotentRequest, error)) *MockIdempotencyInterfaceBeginCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIdempotencyInterfaceBeginCall) DoAndReturn(f func(context.Context, uint, string, string) (*data.IdempotentRequest, error)) *MockIdempotencyInterfaceBeginCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Complete mocks base method.
+func (m *MockIdempotencyInterface) Complete(ctx context.Context, actorID uint, key string, statusCode int, contentType string, body []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, actorID, key, statusCode, contentType, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockIdempotencyInterfaceMockRecorder) Complete(ctx, actorID, key, statusCode, contentType, body any) *MockIdempotencyInterfaceCompleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIdempotencyInterface)(nil).Complete), ctx, actorID, key, statusCode, contentType, body)
+	return &MockIdempotencyInterfaceCompleteCall{Call: call}
+}
+
+// MockIdempotencyInterfaceCompleteCall wrap *gomock.Call
+type MockIdempotencyInterfaceCompleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIdempotencyInterfaceCompleteCall) Return(arg0 error) *MockIdempotencyInterfaceCompleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIdempotencyInterfaceCompleteCall) Do(f func(context.Context, uint, string, int, string, []byte) error) *MockIdempotencyInterfaceCompleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIdempotencyInterfaceCompleteCall) DoAndReturn(f func(context.Context, uint, string, int, string, []byte) error) *MockIdempotencyInterfaceCompleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteBefore mocks base method.
+func (m *MockIdempotencyInterface) DeleteBefore(ctx context.Context, t time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBefore", ctx, t)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBefore indicates an expected call of DeleteBefore.
+func (mr *MockIdempotencyInterfaceMockRecorder) DeleteBefore(ctx, t any) *MockIdempotencyInterfaceDeleteBeforeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBefore", reflect.TypeOf((*MockIdempotencyInterface)(nil).DeleteBefore), ctx, t)
+	return &MockIdempotencyInterfaceDeleteBeforeCall{Call: call}
+}
+
+// MockIdempotencyInterfaceDeleteBeforeCall wrap *gomock.Call
+type MockIdempotencyInterfaceDeleteBeforeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIdempotencyInterfaceDeleteBeforeCall) Return(arg0 int64, arg1 error) *MockIdempotencyInterfaceDeleteBeforeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIdempotencyInterfaceDeleteBeforeCall) Do(f func(context.Context, time.Time) (int64, error)) *MockIdempotencyInterfaceDeleteBeforeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIdempotencyInterfaceDeleteBeforeCall) DoAndReturn(f func(context.Context, time.Time) (int64, error)) *MockIdempotencyInterfaceDeleteBeforeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Release mocks base method.
+func (m *MockIdempotencyInterface) Release(ctx context.Context, actorID uint, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, actorID, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockIdempotencyInterfaceMockRecorder) Release(ctx, actorID, key any) *MockIdempotencyInterfaceReleaseCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockIdempotencyInterface)(nil).Release), ctx, actorID, key)
+	return &MockIdempotencyInterfaceReleaseCall{Call: call}
+}
+
+// MockIdempotencyInterfaceReleaseCall wrap *gomock.Call
+type MockIdempotencyInterfaceReleaseCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIdempotencyInterfaceReleaseCall) Return(arg0 error) *MockIdempotencyInterfaceReleaseCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIdempotencyInterfaceReleaseCall) Do(f func(context.Context, uint, string) error) *MockIdempotencyInterfaceReleaseCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIdempotencyInterfaceReleaseCall) DoAndReturn(f func(context.Context, uint, string) error) *MockIdempotencyInterfaceReleaseCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockArchiveInterface is a mock of ArchiveInterface interface.
+type MockArchiveInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockArchiveInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockArchiveInterfaceMockRecorder is the mock recorder for MockArchiveInterface.
+type MockArchiveInterfaceMockRecorder struct {
+	mock *MockArchiveInterface
+}
+
+// NewMockArchiveInterface creates a new mock instance.
+func NewMockArchiveInterface(ctrl *gomock.Controller) *MockArchiveInterface {
+	mock := &MockArchiveInterface{ctrl: ctrl}
+	mock.recorder = &MockArchiveInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArchiveInterface) EXPECT() *MockArchiveInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ArchiveExpenses mocks base method.
+func (m *MockArchiveInterface) ArchiveExpenses(ctx context.Context, before time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveExpenses", ctx, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveExpenses indicates an expected call of ArchiveExpenses.
+func (mr *MockArchiveInterfaceMockRecorder) ArchiveExpenses(ctx, before, limit any) *MockArchiveInterfaceArchiveExpensesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveExpenses", reflect.TypeOf((*MockArchiveInterface)(nil).ArchiveExpenses), ctx, before, limit)
+	return &MockArchiveInterfaceArchiveExpensesCall{Call: call}
+}
+
+// MockArchiveInterfaceArchiveExpensesCall wrap *gomock.Call
+type MockArchiveInterfaceArchiveExpensesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockArchiveInterfaceArchiveExpensesCall) Return(arg0 int64, arg1 error) *MockArchiveInterfaceArchiveExpensesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockArchiveInterfaceArchiveExpensesCall) Do(f func(context.Context, time.Time, int) (int64, error)) *MockArchiveInterfaceArchiveExpensesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockArchiveInterfaceArchiveExpensesCall) DoAndReturn(f func(context.Context, time.Time, int) (int64, error)) *MockArchiveInterfaceArchiveExpensesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ArchiveIncomes mocks base method.
+func (m *MockArchiveInterface) ArchiveIncomes(ctx context.Context, before time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveIncomes", ctx, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveIncomes indicates an expected call of ArchiveIncomes.
+func (mr *MockArchiveInterfaceMockRecorder) ArchiveIncomes(ctx, before, limit any) *MockArchiveInterfaceArchiveIncomesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveIncomes", reflect.TypeOf((*MockArchiveInterface)(nil).ArchiveIncomes), ctx, before, limit)
+	return &MockArchiveInterfaceArchiveIncomesCall{Call: call}
+}
+
+// MockArchiveInterfaceArchiveIncomesCall wrap *gomock.Call
+type MockArchiveInterfaceArchiveIncomesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockArchiveInterfaceArchiveIncomesCall) Return(arg0 int64, arg1 error) *MockArchiveInterfaceArchiveIncomesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockArchiveInterfaceArchiveIncomesCall) Do(f func(context.Context, time.Time, int) (int64, error)) *MockArchiveInterfaceArchiveIncomesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockArchiveInterfaceArchiveIncomesCall) DoAndReturn(f func(context.Context, time.Time, int) (int64, error)) *MockArchiveInterfaceArchiveIncomesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPeriods mocks base method.
+func (m *MockArchiveInterface) GetPeriods(ctx context.Context, userID uint) ([]*data.ArchivedPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPeriods", ctx, userID)
+	ret0, _ := ret[0].([]*data.ArchivedPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPeriods indicates an expected call of GetPeriods.
+func (mr *MockArchiveInterfaceMockRecorder) GetPeriods(ctx, userID any) *MockArchiveInterfaceGetPeriodsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeriods", reflect.TypeOf((*MockArchiveInterface)(nil).GetPeriods), ctx, userID)
+	return &MockArchiveInterfaceGetPeriodsCall{Call: call}
+}
+
+// MockArchiveInterfaceGetPeriodsCall wrap *gomock.Call
+type MockArchiveInterfaceGetPeriodsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockArchiveInterfaceGetPeriodsCall) Return(arg0 []*data.ArchivedPeriod, arg1 error) *MockArchiveInterfaceGetPeriodsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockArchiveInterfaceGetPeriodsCall) Do(f func(context.Context, uint) ([]*data.ArchivedPeriod, error)) *MockArchiveInterfaceGetPeriodsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockArchiveInterfaceGetPeriodsCall) DoAndReturn(f func(context.Context, uint) ([]*data.ArchivedPeriod, error)) *MockArchiveInterfaceGetPeriodsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockWarehouseInterface is a mock of WarehouseInterface interface.
+type MockWarehouseInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockWarehouseInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockWarehouseInterfaceMockRecorder is the mock recorder for MockWarehouseInterface.
+type MockWarehouseInterfaceMockRecorder struct {
+	mock *MockWarehouseInterface
+}
+
+// NewMockWarehouseInterface creates a new mock instance.
+func NewMockWarehouseInterface(ctrl *gomock.Controller) *MockWarehouseInterface {
+	mock := &MockWarehouseInterface{ctrl: ctrl}
+	mock.recorder = &MockWarehouseInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWarehouseInterface) EXPECT() *MockWarehouseInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetTenants mocks base method.
+func (m *MockWarehouseInterface) GetTenants(ctx context.Context) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenants", ctx)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenants indicates an expected call of GetTenants.
+func (mr *MockWarehouseInterfaceMockRecorder) GetTenants(ctx any) *MockWarehouseInterfaceGetTenantsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenants", reflect.TypeOf((*MockWarehouseInterface)(nil).GetTenants), ctx)
+	return &MockWarehouseInterfaceGetTenantsCall{Call: call}
+}
+
+// MockWarehouseInterfaceGetTenantsCall wrap *gomock.Call
+type MockWarehouseInterfaceGetTenantsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWarehouseInterfaceGetTenantsCall) Return(arg0 []uint, arg1 error) *MockWarehouseInterfaceGetTenantsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWarehouseInterfaceGetTenantsCall) Do(f func(context.Context) ([]uint, error)) *MockWarehouseInterfaceGetTenantsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWarehouseInterfaceGetTenantsCall) DoAndReturn(f func(context.Context) ([]uint, error)) *MockWarehouseInterfaceGetTenantsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetTransactions mocks base method.
+func (m *MockWarehouseInterface) GetTransactions(ctx context.Context, tenantID uint, after *data.WarehouseWatermark, until time.Time, limit int) ([]*data.WarehouseTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactions", ctx, tenantID, after, until, limit)
+	ret0, _ := ret[0].([]*data.WarehouseTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactions indicates an expected call of GetTransactions.
+func (mr *MockWarehouseInterfaceMockRecorder) GetTransactions(ctx, tenantID, after, until, limit any) *MockWarehouseInterfaceGetTransactionsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactions", reflect.TypeOf((*MockWarehouseInterface)(nil).GetTransactions), ctx, tenantID, after, until, limit)
+	return &MockWarehouseInterfaceGetTransactionsCall{Call: call}
+}
+
+// MockWarehouseInterfaceGetTransactionsCall wrap *gomock.Call
+type MockWarehouseInterfaceGetTransactionsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWarehouseInterfaceGetTransactionsCall) Return(arg0 []*data.WarehouseTransaction, arg1 error) *MockWarehouseInterfaceGetTransactionsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWarehouseInterfaceGetTransactionsCall) Do(f func(context.Context, uint, *data.WarehouseWatermark, time.Time, int) ([]*data.WarehouseTransaction, error)) *MockWarehouseInterfaceGetTransactionsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWarehouseInterfaceGetTransactionsCall) DoAndReturn(f func(context.Context, uint, *data.WarehouseWatermark, time.Time, int) ([]*data.WarehouseTransaction, error)) *MockWarehouseInterfaceGetTransactionsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetWatermark mocks base method.
+func (m *MockWarehouseInterface) GetWatermark(ctx context.Context, tenantID uint, dataset string, schemaVersion int) (*data.WarehouseWatermark, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatermark", ctx, tenantID, dataset, schemaVersion)
+	ret0, _ := ret[0].(*data.WarehouseWatermark)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatermark indicates an expected call of GetWatermark.
+func (mr *MockWarehouseInterfaceMockRecorder) GetWatermark(ctx, tenantID, dataset, schemaVersion any) *MockWarehouseInterfaceGetWatermarkCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatermark", reflect.TypeOf((*MockWarehouseInterface)(nil).GetWatermark), ctx, tenantID, dataset, schemaVersion)
+	return &MockWarehouseInterfaceGetWatermarkCall{Call: call}
+}
+
+// MockWarehouseInterfaceGetWatermarkCall wrap *gomock.Call
+type MockWarehouseInterfaceGetWatermarkCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWarehouseInterfaceGetWatermarkCall) Return(arg0 *data.WarehouseWatermark, arg1 error) *MockWarehouseInterfaceGetWatermarkCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWarehouseInterfaceGetWatermarkCall) Do(f func(context.Context, uint, string, int) (*data.WarehouseWatermark, error)) *MockWarehouseInterfaceGetWatermarkCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWarehouseInterfaceGetWatermarkCall) DoAndReturn(f func(context.Context, uint, string, int) (*data.WarehouseWatermark, error)) *MockWarehouseInterfaceGetWatermarkCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SaveWatermark mocks base method.
+func (m *MockWarehouseInterface) SaveWatermark(ctx context.Context, watermark *data.WarehouseWatermark) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWatermark", ctx, watermark)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveWatermark indicates an expected call of SaveWatermark.
+func (mr *MockWarehouseInterfaceMockRecorder) SaveWatermark(ctx, watermark any) *MockWarehouseInterfaceSaveWatermarkCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWatermark", reflect.TypeOf((*MockWarehouseInterface)(nil).SaveWatermark), ctx, watermark)
+	return &MockWarehouseInterfaceSaveWatermarkCall{Call: call}
+}
+
+// MockWarehouseInterfaceSaveWatermarkCall wrap *gomock.Call
+type MockWarehouseInterfaceSaveWatermarkCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockWarehouseInterfaceSaveWatermarkCall) Return(arg0 error) *MockWarehouseInterfaceSaveWatermarkCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockWarehouseInterfaceSaveWatermarkCall) Do(f func(context.Context, *data.WarehouseWatermark) error) *MockWarehouseInterfaceSaveWatermarkCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockWarehouseInterfaceSaveWatermarkCall) DoAndReturn(f func(context.Context, *data.WarehouseWatermark) error) *MockWarehouseInterfaceSaveWatermarkCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockStreamInterface is a mock of StreamInterface interface.
+type MockStreamInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockStreamInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockStreamInterfaceMockRecorder is the mock recorder for MockStreamInterface.
+type MockStreamInterfaceMockRecorder struct {
+	mock *MockStreamInterface
+}
+
+// NewMockStreamInterface creates a new mock instance.
+func NewMockStreamInterface(ctrl *gomock.Controller) *MockStreamInterface {
+	mock := &MockStreamInterface{ctrl: ctrl}
+	mock.recorder = &MockStreamInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStreamInterface) EXPECT() *MockStreamInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Baseline mocks base method.
+func (m *MockStreamInterface) Baseline(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Baseline", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Baseline indicates an expected call of Baseline.
+func (mr *MockStreamInterfaceMockRecorder) Baseline(ctx any) *MockStreamInterfaceBaselineCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Baseline", reflect.TypeOf((*MockStreamInterface)(nil).Baseline), ctx)
+	return &MockStreamInterfaceBaselineCall{Call: call}
+}
+
+// MockStreamInterfaceBaselineCall wrap *gomock.Call
+type MockStreamInterfaceBaselineCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStreamInterfaceBaselineCall) Return(arg0 int64, arg1 error) *MockStreamInterfaceBaselineCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStreamInterfaceBaselineCall) Do(f func(context.Context) (int64, error)) *MockStreamInterfaceBaselineCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStreamInterfaceBaselineCall) DoAndReturn(f func(context.Context) (int64, error)) *MockStreamInterfaceBaselineCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetEvents mocks base method.
+func (m *MockStreamInterface) GetEvents(ctx context.Context, userID uint, stream data.StreamType, streamID, after uint, limit int) ([]*data.StreamEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvents", ctx, userID, stream, streamID, after, limit)
+	ret0, _ := ret[0].([]*data.StreamEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvents indicates an expected call of GetEvents.
+func (mr *MockStreamInterfaceMockRecorder) GetEvents(ctx, userID, stream, streamID, after, limit any) *MockStreamInterfaceGetEventsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockStreamInterface)(nil).GetEvents), ctx, userID, stream, streamID, after, limit)
+	return &MockStreamInterfaceGetEventsCall{Call: call}
+}
+
+// MockStreamInterfaceGetEventsCall wrap *gomock.Call
+type MockStreamInterfaceGetEventsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStreamInterfaceGetEventsCall) Return(arg0 []*data.StreamEvent, arg1 error) *MockStreamInterfaceGetEventsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStreamInterfaceGetEventsCall) Do(f func(context.Context, uint, data.StreamType, uint, uint, int) ([]*data.StreamEvent, error)) *MockStreamInterfaceGetEventsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStreamInterfaceGetEventsCall) DoAndReturn(f func(context.Context, uint, data.StreamType, uint, uint, int) ([]*data.StreamEvent, error)) *MockStreamInterfaceGetEventsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Rebuild mocks base method.
+func (m *MockStreamInterface) Rebuild(ctx context.Context, userID uint, stream data.StreamType, streamID uint) (*data.RebuiltBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rebuild", ctx, userID, stream, streamID)
+	ret0, _ := ret[0].(*data.RebuiltBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rebuild indicates an expected call of Rebuild.
+func (mr *MockStreamInterfaceMockRecorder) Rebuild(ctx, userID, stream, streamID any) *MockStreamInterfaceRebuildCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebuild", reflect.TypeOf((*MockStreamInterface)(nil).Rebuild), ctx, userID, stream, streamID)
+	return &MockStreamInterfaceRebuildCall{Call: call}
+}
+
+// MockStreamInterfaceRebuildCall wrap *gomock.Call
+type MockStreamInterfaceRebuildCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStreamInterfaceRebuildCall) Return(arg0 *data.RebuiltBalance, arg1 error) *MockStreamInterfaceRebuildCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStreamInterfaceRebuildCall) Do(f func(context.Context, uint, data.StreamType, uint) (*data.RebuiltBalance, error)) *MockStreamInterfaceRebuildCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStreamInterfaceRebuildCall) DoAndReturn(f func(context.Context, uint, data.StreamType, uint) (*data.RebuiltBalance, error)) *MockStreamInterfaceRebuildCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockOutboxInterface is a mock of OutboxInterface interface.
+type MockOutboxInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxInterfaceMockRecorder is the mock recorder for MockOutboxInterface.
+type MockOutboxInterfaceMockRecorder struct {
+	mock *MockOutboxInterface
+}
+
+// NewMockOutboxInterface creates a new mock instance.
+func NewMockOutboxInterface(ctrl *gomock.Controller) *MockOutboxInterface {
+	mock := &MockOutboxInterface{ctrl: ctrl}
+	mock.recorder = &MockOutboxInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxInterface) EXPECT() *MockOutboxInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ClaimNext mocks base method.
+func (m *MockOutboxInterface) ClaimNext(ctx context.Context) (*data.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimNext", ctx)
+	ret0, _ := ret[0].(*data.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimNext indicates an expected call of ClaimNext.
+func (mr *MockOutboxInterfaceMockRecorder) ClaimNext(ctx any) *MockOutboxInterfaceClaimNextCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimNext", reflect.TypeOf((*MockOutboxInterface)(nil).ClaimNext), ctx)
+	return &MockOutboxInterfaceClaimNextCall{Call: call}
+}
+
+// MockOutboxInterfaceClaimNextCall wrap *gomock.Call
+type MockOutboxInterfaceClaimNextCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOutboxInterfaceClaimNextCall) Return(arg0 *data.OutboxMessage, arg1 error) *MockOutboxInterfaceClaimNextCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOutboxInterfaceClaimNextCall) Do(f func(context.Context) (*data.OutboxMessage, error)) *MockOutboxInterfaceClaimNextCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOutboxInterfaceClaimNextCall) DoAndReturn(f func(context.Context) (*data.OutboxMessage, error)) *MockOutboxInterfaceClaimNextCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeletePublishedBefore mocks base method.
+func (m *MockOutboxInterface) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePublishedBefore", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePublishedBefore indicates an expected call of DeletePublishedBefore.
+func (mr *MockOutboxInterfaceMockRecorder) DeletePublishedBefore(ctx, before any) *MockOutboxInterfaceDeletePublishedBeforeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePublishedBefore", reflect.TypeOf((*MockOutboxInterface)(nil).DeletePublishedBefore), ctx, before)
+	return &MockOutboxInterfaceDeletePublishedBeforeCall{Call: call}
+}
+
+// MockOutboxInterfaceDeletePublishedBeforeCall wrap *gomock.Call
+type MockOutboxInterfaceDeletePublishedBeforeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOutboxInterfaceDeletePublishedBeforeCall) Return(arg0 int64, arg1 error) *MockOutboxInterfaceDeletePublishedBeforeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOutboxInterfaceDeletePublishedBeforeCall) Do(f func(context.Context, time.Time) (int64, error)) *MockOutboxInterfaceDeletePublishedBeforeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOutboxInterfaceDeletePublishedBeforeCall) DoAndReturn(f func(context.Context, time.Time) (int64, error)) *MockOutboxInterfaceDeletePublishedBeforeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Fail mocks base method.
+func (m *MockOutboxInterface) Fail(ctx context.Context, id uint, message string, retryAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", ctx, id, message, retryAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockOutboxInterfaceMockRecorder) Fail(ctx, id, message, retryAt any) *MockOutboxInterfaceFailCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockOutboxInterface)(nil).Fail), ctx, id, message, retryAt)
+	return &MockOutboxInterfaceFailCall{Call: call}
+}
+
+// MockOutboxInterfaceFailCall wrap *gomock.Call
+type MockOutboxInterfaceFailCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOutboxInterfaceFailCall) Return(arg0 error) *MockOutboxInterfaceFailCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOutboxInterfaceFailCall) Do(f func(context.Context, uint, string, time.Time) error) *MockOutboxInterfaceFailCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOutboxInterfaceFailCall) DoAndReturn(f func(context.Context, uint, string, time.Time) error) *MockOutboxInterfaceFailCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MarkPublished mocks base method.
+func (m *MockOutboxInterface) MarkPublished(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPublished", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPublished indicates an expected call of MarkPublished.
+func (mr *MockOutboxInterfaceMockRecorder) MarkPublished(ctx, id any) *MockOutboxInterfaceMarkPublishedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPublished", reflect.TypeOf((*MockOutboxInterface)(nil).MarkPublished), ctx, id)
+	return &MockOutboxInterfaceMarkPublishedCall{Call: call}
+}
+
+// MockOutboxInterfaceMarkPublishedCall wrap *gomock.Call
+type MockOutboxInterfaceMarkPublishedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockOutboxInterfaceMarkPublishedCall) Return(arg0 error) *MockOutboxInterfaceMarkPublishedCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockOutboxInterfaceMarkPublishedCall) Do(f func(context.Context, uint) error) *MockOutboxInterfaceMarkPublishedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockOutboxInterfaceMarkPublishedCall) DoAndReturn(f func(context.Context, uint) error) *MockOutboxInterfaceMarkPublishedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -18,8 +18,9 @@ const (
 type TransactionType string
 
 const (
-	TransactionIncome  TransactionType = "income"
-	TransactionExpense TransactionType = "expense"
+	TransactionIncome   TransactionType = "income"
+	TransactionExpense  TransactionType = "expense"
+	TransactionPurchase TransactionType = "purchase" // ore bought from a miner
 )
 
 // PaymentStatus represents the payment status
@@ -97,6 +98,8 @@ const (
 	SalesTypeSupply       SalesType = "supply"
 	SalesTypeConcentrates SalesType = "concentrates"
 	SalesTypeTailings     SalesType = "tailings"
+	SalesTypeAsset        SalesType = "asset"     // equipment sold on disposal
+	SalesTypeInsurance    SalesType = "insurance" // insurance claim payouts
 )
 
 // ExpenseCategory represents the category of expense
@@ -128,12 +131,24 @@ type User struct {
 	// OTP fields for password reset
 	OTPCode      string     `gorm:"type:varchar(6)" json:"-"`
 	OTPExpiresAt *time.Time `json:"-"`
+	OTPAttempts  int        `gorm:"default:0" json:"-"`        // failed verifications since a code was last verified
+	OTPRetryAt   *time.Time `json:"-"`                         // no verification allowed before this time
+	OTPPurpose   string     `gorm:"type:varchar(20)" json:"-"` // flow the code was sent for, one of the OTPPurpose constants
+	PendingPhone *string    `gorm:"type:varchar(20)" json:"-"` // phone awaiting OTP confirmation before linking
+
+	// Lockout after repeated failed password logins
+	FailedLogins int        `gorm:"not null;default:0" json:"failed_logins,omitempty"` // consecutive failures since the last login or lockout
+	LockedUntil  *time.Time `json:"locked_until,omitempty"`                            // no password login before this time
+
+	// Set once the user enters the code emailed on signup, or signs up with a provider that
+	// verified the email
+	EmailVerified bool `gorm:"not null;default:false" json:"email_verified"`
 }
 
 // Income represents an income transaction (Sales)
 type Income struct {
 	gorm.Model
-	Date            time.Time      `gorm:"not null" json:"date"`
+	Date            time.Time      `gorm:"not null;index:,composite:user_date,priority:2" json:"date"`
 	ItemName        *string        `gorm:"type:varchar(255)" json:"item_name,omitempty"`
 	MineralType     MineralType    `gorm:"type:varchar(50);not null;default:'other'" json:"mineral_type"`
 	GemstoneType    *GemstoneType  `gorm:"type:varchar(50)" json:"gemstone_type,omitempty"`
@@ -148,17 +163,39 @@ type Income struct {
 	AmountPaid      float64        `gorm:"default:0" json:"amount_paid"`
 	AmountDue       float64        `gorm:"default:0" json:"amount_due"`
 	Notes           *string        `gorm:"type:text" json:"notes,omitempty"`
-	UserID          uint           `gorm:"not null" json:"user_id"`
+	InvoiceNumber   *string        `gorm:"type:varchar(50);index" json:"invoice_number,omitempty"`  // assigned when the invoice is first shared
+	DueDate         *time.Time     `json:"due_date,omitempty"`                                      // payment due date of the invoice
+	RiskFlag        *RiskLevel     `gorm:"type:varchar(20)" json:"risk_flag,omitempty"`             // flag of the customer when the sale was recorded
+	ApprovalStatus  *SaleApproval  `gorm:"type:varchar(20);index" json:"approval_status,omitempty"` // set for sales to flagged customers
+	ReviewedByID    *uint          `json:"reviewed_by_id,omitempty"`
+	ReviewedAt      *time.Time     `json:"reviewed_at,omitempty"`
+	RejectionReason *string        `gorm:"type:varchar(255)" json:"rejection_reason,omitempty"`
+	BackdatedDays   *int           `json:"backdated_days,omitempty"`            // days before it was recorded, when past the backdating limit
+	MineSiteID      *uint          `gorm:"index" json:"mine_site_id,omitempty"` // site whose books the sale is in
+	TillID          *uint          `gorm:"index" json:"till_id,omitempty"`      // till the amount paid on the spot went through
+	UserID          uint           `gorm:"not null;index:,composite:user_date,priority:1" json:"user_id"`
 	User            User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	CreatedAt       time.Time      `json:"created_at"`
 	UpdatedAt       time.Time      `json:"updated_at"`
 	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
+// IncomeFilter narrows down and orders a list of sales; zero values are ignored
+type IncomeFilter struct {
+	SiteID        *uint
+	MineralType   MineralType
+	PaymentStatus PaymentStatus
+	Sort          string // one of IncomeSortColumns; newest first when empty
+	Descending    bool
+}
+
+// IncomeSortColumns are the columns lists of sales can be sorted by
+var IncomeSortColumns = []string{"date", "total_amount", "amount_due", "quantity", "price_per_unit", "customer_name"}
+
 // Expense represents an expense transaction
 type Expense struct {
 	gorm.Model
-	Date            time.Time       `gorm:"not null" json:"date"`
+	Date            time.Time       `gorm:"not null;index:,composite:user_date,priority:2" json:"date"`
 	Category        ExpenseCategory `gorm:"type:varchar(50);not null" json:"category"`
 	Description     string          `gorm:"type:varchar(255);not null" json:"description"`
 	Amount          float64         `gorm:"not null" json:"amount"`
@@ -168,11 +205,352 @@ type Expense struct {
 	AmountPaid      float64         `gorm:"default:0" json:"amount_paid"`
 	AmountDue       float64         `gorm:"default:0" json:"amount_due"`
 	Notes           *string         `gorm:"type:text" json:"notes,omitempty"`
-	UserID          uint            `gorm:"not null" json:"user_id"`
+	TripID          *uint           `gorm:"index" json:"trip_id,omitempty"`
+	MineSiteID      *uint           `gorm:"index" json:"mine_site_id,omitempty"` // site whose books the expense is in
+	TillID          *uint           `gorm:"index" json:"till_id,omitempty"`      // till the amount paid on the spot went through
+	UserID          uint            `gorm:"not null;index:,composite:user_date,priority:1" json:"user_id"`
 	User            User            `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	CreatedAt       time.Time       `json:"created_at"`
 	UpdatedAt       time.Time       `json:"updated_at"`
 	DeletedAt       gorm.DeletedAt  `gorm:"index" json:"-"`
+
+	// Approval of expenses dated further back than the backdating limit
+	ApprovalStatus  *SaleApproval `gorm:"type:varchar(20);index" json:"approval_status,omitempty"`
+	ReviewedByID    *uint         `json:"reviewed_by_id,omitempty"`
+	ReviewedAt      *time.Time    `json:"reviewed_at,omitempty"`
+	RejectionReason *string       `gorm:"type:varchar(255)" json:"rejection_reason,omitempty"`
+	BackdatedDays   *int          `json:"backdated_days,omitempty"` // days before it was recorded
+
+	// Sign-off of expenses above the sign-off amount, which can't be paid until it is complete
+	SignOffStatus *SignOffStatus `gorm:"type:varchar(20);index" json:"sign_off_status,omitempty"`
+	SignOffs      int            `gorm:"not null;default:0" json:"sign_offs,omitempty"` // distinct approvers who signed it off
+
+	// Amortization of prepaid expenses, such as annual insurance, which count in the profit and
+	// loss through their monthly allocations instead of on their date
+	PrepaidMonths     *int       `json:"prepaid_months,omitempty"`     // months the amount is spread over
+	AmortizationStart *time.Time `json:"amortization_start,omitempty"` // first day of the first month
+
+	// Supply bought from the shared price list, flagged when its unit price is above the list
+	// price by more than the variance set in settings
+	SupplyPriceID *uint    `gorm:"index" json:"supply_price_id,omitempty"`
+	Quantity      *float64 `json:"quantity,omitempty"`
+	PriceVariance *float64 `json:"price_variance,omitempty"` // percent above the list price, when flagged
+}
+
+// ExpenseFilter narrows down and orders a list of expenses; zero values are ignored
+type ExpenseFilter struct {
+	SiteID        *uint
+	Category      ExpenseCategory
+	SupplierName  string
+	PaymentStatus PaymentStatus
+	Sort          string // one of ExpenseSortColumns; newest first when empty
+	Descending    bool
+}
+
+// ExpenseSortColumns are the columns lists of expenses can be sorted by
+var ExpenseSortColumns = []string{"date", "amount", "amount_due", "category", "supplier_name"}
+
+// Payment is one payment received on a sale or made on an expense or a purchase. A record's amount paid is
+// the sum of its payments.
+type Payment struct {
+	gorm.Model
+	RecordType   TransactionType `gorm:"type:varchar(20);not null;index:,composite:payment_record" json:"record_type"`
+	RecordID     uint            `gorm:"not null;index:,composite:payment_record" json:"record_id"`
+	Amount       float64         `gorm:"not null" json:"amount"`
+	Date         time.Time       `gorm:"not null" json:"date"`
+	Method       *string         `gorm:"type:varchar(50)" json:"method,omitempty"` // e.g. cash, mobile money, bank transfer
+	Reference    *string         `gorm:"type:varchar(100)" json:"reference,omitempty"`
+	Notes        *string         `gorm:"type:text" json:"notes,omitempty"`
+	RecordedByID *uint           `json:"recorded_by_id,omitempty"`
+	TillID       *uint           `gorm:"index" json:"till_id,omitempty"` // till the cash went through
+	UserID       uint            `gorm:"not null;index" json:"user_id"`
+}
+
+// Purchase represents ore or mineral bought from an individual miner at a buying station. The
+// material is received into an inventory item, and what is still owed to the miner is a payable.
+type Purchase struct {
+	gorm.Model
+	Date            time.Time      `gorm:"not null;index:,composite:user_date,priority:2" json:"date"`
+	MinerID         *uint          `gorm:"index" json:"miner_id,omitempty"` // miner in the registry the material was bought from
+	MinerName       string         `gorm:"type:varchar(100);not null;index" json:"miner_name"`
+	MinerContact    *string        `gorm:"type:varchar(100)" json:"miner_contact,omitempty"`
+	MineralType     MineralType    `gorm:"type:varchar(50);not null" json:"mineral_type"`
+	Quantity        float64        `gorm:"not null" json:"quantity"`
+	Unit            string         `gorm:"type:varchar(20);not null" json:"unit"`
+	PricePerUnit    float64        `gorm:"not null" json:"price_per_unit"`
+	TotalAmount     float64        `gorm:"not null" json:"total_amount"`
+	PaymentStatus   PaymentStatus  `gorm:"type:varchar(20);default:'unpaid'" json:"payment_status"`
+	AmountPaid      float64        `gorm:"default:0" json:"amount_paid"`
+	AmountDue       float64        `gorm:"default:0" json:"amount_due"`
+	InventoryItemID uint           `gorm:"not null;index" json:"inventory_item_id"` // stock the material was received into
+	PitNumber       *string        `gorm:"type:varchar(100)" json:"pit_number,omitempty"`
+	Notes           *string        `gorm:"type:text" json:"notes,omitempty"`
+	MineSiteID      *uint          `gorm:"index" json:"mine_site_id,omitempty"` // buying station whose books the purchase is in
+	TillID          *uint          `gorm:"index" json:"till_id,omitempty"`      // till the amount paid on the spot went through
+	UserID          uint           `gorm:"not null;index:,composite:user_date,priority:1" json:"user_id"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Weighbridge readings; the quantity is the net weight when they are recorded
+	GrossWeight       *float64 `json:"gross_weight,omitempty"`
+	TareWeight        *float64 `json:"tare_weight,omitempty"`
+	WeighbridgeTicket *string  `gorm:"type:varchar(50)" json:"weighbridge_ticket,omitempty"`
+
+	// Price calculation from the market price, purity and agreed discount. The price per unit is
+	// the calculated price unless it was overridden, see PurchasePriceOverride.
+	MarketPrice            *float64 `json:"market_price,omitempty"`     // per unit of pure mineral
+	Purity                 *float64 `json:"purity,omitempty"`           // percent
+	DiscountPercent        *float64 `json:"discount_percent,omitempty"` // agreed with the miner
+	CalculatedPricePerUnit *float64 `json:"calculated_price_per_unit,omitempty"`
+	PriceOverridden        bool     `gorm:"not null;default:false" json:"price_overridden"`
+
+	// Override is recorded in the override trail when the purchase is saved
+	Override *PurchasePriceOverride `gorm:"-" json:"-"`
+}
+
+// PurchasePriceOverride records a purchase being priced differently from its calculated price
+type PurchasePriceOverride struct {
+	ID                     uint      `gorm:"primarykey" json:"id"`
+	PurchaseID             uint      `gorm:"not null;index" json:"purchase_id"`
+	CalculatedPricePerUnit float64   `gorm:"not null" json:"calculated_price_per_unit"`
+	PricePerUnit           float64   `gorm:"not null" json:"price_per_unit"`
+	CalculatedAmount       float64   `gorm:"not null" json:"calculated_amount"`
+	TotalAmount            float64   `gorm:"not null" json:"total_amount"`
+	Reason                 string    `gorm:"type:text;not null" json:"reason"`
+	OverriddenByID         uint      `gorm:"not null" json:"overridden_by_id"`
+	UserID                 uint      `gorm:"not null;index" json:"user_id"`
+	CreatedAt              time.Time `json:"created_at"`
+}
+
+// MarketPrice is the price of a mineral at full purity from a date on, used to calculate the
+// price of purchases. A price for a buying station takes precedence over the general price.
+type MarketPrice struct {
+	gorm.Model
+	MineralType   MineralType `gorm:"type:varchar(50);not null;index:,composite:mineral_date,priority:2" json:"mineral_type"`
+	Unit          string      `gorm:"type:varchar(20);not null" json:"unit"`
+	Price         float64     `gorm:"not null" json:"price"`
+	EffectiveDate time.Time   `gorm:"not null;index:,composite:mineral_date,priority:3" json:"effective_date"`
+	Source        *string     `gorm:"type:varchar(100)" json:"source,omitempty"` // e.g. LBMA, local dealer
+	MineSiteID    *uint       `gorm:"index" json:"mine_site_id,omitempty"`       // buying station the price applies to
+	UserID        uint        `gorm:"not null;index:,composite:mineral_date,priority:1" json:"user_id"`
+}
+
+// SupplyPrice is an entry of the reference price list of common supplies, such as fuel and
+// reagents, that a cooperative's admins publish to its members. Expenses buying a listed supply
+// are checked against it.
+type SupplyPrice struct {
+	gorm.Model
+	Name          string          `gorm:"type:varchar(100);not null" json:"name"`
+	Category      ExpenseCategory `gorm:"type:varchar(50);not null" json:"category"`
+	Unit          string          `gorm:"type:varchar(20);not null" json:"unit"`
+	Price         float64         `gorm:"not null" json:"price"` // per unit
+	Supplier      *string         `gorm:"type:varchar(100)" json:"supplier,omitempty"`
+	EffectiveDate time.Time       `gorm:"not null" json:"effective_date"`
+	Notes         *string         `gorm:"type:text" json:"notes,omitempty"`
+	UserID        uint            `gorm:"not null;index" json:"user_id"`
+}
+
+// CashDayStatus represents the state of a buying station's cash day
+type CashDayStatus string
+
+const (
+	CashDayOpen   CashDayStatus = "open"
+	CashDayClosed CashDayStatus = "closed"
+)
+
+// CashDay is a buying station's cash position for a day: the opening float declared when the
+// day is opened, and the cash counted when it is closed against the cash expected from the
+// day's cash payments and movements. Only one day of a till, or of a station's cash not in a
+// till, can be open at a time.
+type CashDay struct {
+	gorm.Model
+	Date         time.Time      `gorm:"not null;index" json:"date"`
+	MineSiteID   *uint          `gorm:"index" json:"mine_site_id,omitempty"` // buying station whose cash it is
+	TillID       *uint          `gorm:"index" json:"till_id,omitempty"`      // till whose cash it is, if not the whole station's
+	ClerkID      *uint          `gorm:"index" json:"clerk_id,omitempty"`     // clerk of the till when the day was opened
+	Status       CashDayStatus  `gorm:"type:varchar(20);not null;default:'open';index" json:"status"`
+	OpeningFloat float64        `gorm:"not null" json:"opening_float"`
+	OpenedByID   uint           `gorm:"not null" json:"opened_by_id"`
+	OpenedAt     time.Time      `gorm:"not null" json:"opened_at"`
+	Notes        *string        `gorm:"type:text" json:"notes,omitempty"`
+	Movements    []CashMovement `gorm:"foreignKey:CashDayID" json:"movements,omitempty"`
+
+	// Closing reconciliation
+	SalesReceived     float64    `gorm:"not null;default:0" json:"sales_received"`
+	PurchasesPaid     float64    `gorm:"not null;default:0" json:"purchases_paid"`
+	ExpensesPaid      float64    `gorm:"not null;default:0" json:"expenses_paid"`
+	MovementsIn       float64    `gorm:"not null;default:0" json:"movements_in"`
+	MovementsOut      float64    `gorm:"not null;default:0" json:"movements_out"`
+	ExpectedCash      float64    `gorm:"not null;default:0" json:"expected_cash"`
+	CountedCash       *float64   `json:"counted_cash,omitempty"`
+	Discrepancy       *float64   `json:"discrepancy,omitempty"` // counted less expected; negative when cash is short
+	DiscrepancyReason *string    `gorm:"type:text" json:"discrepancy_reason,omitempty"`
+	ClosedByID        *uint      `json:"closed_by_id,omitempty"`
+	ClosedAt          *time.Time `json:"closed_at,omitempty"`
+
+	UserID    uint           `gorm:"not null;index" json:"user_id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// Till is a cash drawer at a buying station, assigned to the clerk accountable for its cash.
+// Payments and amounts paid on the spot are attributed to the till they went through.
+type Till struct {
+	gorm.Model
+	Name       string `gorm:"type:varchar(100);not null" json:"name"`
+	MineSiteID *uint  `gorm:"index" json:"mine_site_id,omitempty"` // buying station the till is at
+	ClerkID    *uint  `gorm:"index" json:"clerk_id,omitempty"`     // the owner or a member of the organization
+	Active     bool   `gorm:"not null;default:true" json:"active"`
+	UserID     uint   `gorm:"not null;index" json:"user_id"`
+}
+
+// CashVariance sums the discrepancies of the closed cash days of a till or a clerk
+type CashVariance struct {
+	ID         uint    `json:"id"` // of the till or the clerk
+	Name       string  `json:"name"`
+	DaysClosed int     `json:"days_closed"`
+	Discrepant int     `json:"discrepant"` // days whose count didn't match
+	Shortages  float64 `json:"shortages"`  // total cash short, as a positive amount
+	Overages   float64 `json:"overages"`
+	Net        float64 `json:"net"`
+}
+
+// TillVarianceReport compares the cash variances of tills and of the clerks accountable for
+// them in a period
+type TillVarianceReport struct {
+	From   time.Time       `json:"from"`
+	To     time.Time       `json:"to"`
+	Tills  []*CashVariance `json:"tills"`
+	Clerks []*CashVariance `json:"clerks"`
+}
+
+// CashMovementDirection is whether a cash movement adds cash to the till or takes it out
+type CashMovementDirection string
+
+const (
+	CashIn  CashMovementDirection = "in"  // e.g. a float top-up from the bank
+	CashOut CashMovementDirection = "out" // e.g. a bank deposit or the owner's drawings
+)
+
+// CashMovement is cash put into or taken out of a buying station's till during a cash day other
+// than payments on sales, expenses and purchases
+type CashMovement struct {
+	ID           uint                  `gorm:"primarykey" json:"id"`
+	CashDayID    uint                  `gorm:"not null;index" json:"cash_day_id"`
+	Direction    CashMovementDirection `gorm:"type:varchar(10);not null" json:"direction"`
+	Amount       float64               `gorm:"not null" json:"amount"`
+	Description  string                `gorm:"type:varchar(255);not null" json:"description"`
+	RecordedByID uint                  `gorm:"not null" json:"recorded_by_id"`
+	UserID       uint                  `gorm:"not null;index" json:"user_id"`
+	CreatedAt    time.Time             `json:"created_at"`
+}
+
+// ShiftHandoverStatus represents whether the incoming clerk has signed off a shift handover
+type ShiftHandoverStatus string
+
+const (
+	ShiftHandoverPending   ShiftHandoverStatus = "pending"
+	ShiftHandoverSignedOff ShiftHandoverStatus = "signed_off"
+)
+
+// ShiftHandover is the cash of a till, or of a station's cash not in a till, handed over from one
+// clerk to the next mid-day, with the cash counted against the cash expected by the open cash
+// day. The outgoing clerk signs it by recording it and the incoming clerk by signing it off.
+type ShiftHandover struct {
+	gorm.Model
+	TillID          *uint               `gorm:"index" json:"till_id,omitempty"`
+	MineSiteID      *uint               `gorm:"index" json:"mine_site_id,omitempty"`
+	CashDayID       *uint               `gorm:"index" json:"cash_day_id,omitempty"` // the cash day open at the handover
+	OutgoingClerkID uint                `gorm:"not null;index" json:"outgoing_clerk_id"`
+	IncomingClerkID uint                `gorm:"not null;index" json:"incoming_clerk_id"`
+	Status          ShiftHandoverStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	CountedCash     float64             `gorm:"not null" json:"counted_cash"`
+	ExpectedCash    *float64            `json:"expected_cash,omitempty"` // without an open cash day, unknown
+	Difference      *float64            `json:"difference,omitempty"`    // counted less expected; negative when cash is short
+	PendingIssues   *string             `gorm:"type:text" json:"pending_issues,omitempty"`
+	Notes           *string             `gorm:"type:text" json:"notes,omitempty"`
+	HandedOverAt    time.Time           `gorm:"not null" json:"handed_over_at"`
+	SignedOffAt     *time.Time          `json:"signed_off_at,omitempty"`
+	SignOffNotes    *string             `gorm:"type:text" json:"sign_off_notes,omitempty"`
+	UserID          uint                `gorm:"not null;index" json:"user_id"`
+}
+
+// CashDiscrepancyReport sums the discrepancies of closed cash days in a period
+type CashDiscrepancyReport struct {
+	From       time.Time  `json:"from"`
+	To         time.Time  `json:"to"`
+	DaysClosed int        `json:"days_closed"`
+	Shortages  float64    `json:"shortages"` // total cash short, as a positive amount
+	Overages   float64    `json:"overages"`
+	Net        float64    `json:"net"`
+	Discrepant []*CashDay `json:"discrepant_days"` // closed days whose count didn't match
+}
+
+// MinerPaymentMethod is how a registered miner is paid
+type MinerPaymentMethod string
+
+const (
+	MinerPaidCash        MinerPaymentMethod = "cash"
+	MinerPaidMobileMoney MinerPaymentMethod = "mobile_money"
+	MinerPaidBank        MinerPaymentMethod = "bank"
+)
+
+// KYC requirements a registered miner can be missing
+const (
+	KYCIDNumber       = "id_number"
+	KYCIDDocument     = "id_document"
+	KYCPhoto          = "photo"
+	KYCPhone          = "phone"
+	KYCMineSite       = "mine_site"
+	KYCPaymentDetails = "payment_details"
+)
+
+// Miner represents an individual miner registered as a supplier of a buying station. The scan of
+// their ID document and their photo are attachments of the miner.
+type Miner struct {
+	gorm.Model
+	Name              string             `gorm:"type:varchar(100);not null;index" json:"name"`
+	Phone             *string            `gorm:"type:varchar(20)" json:"phone,omitempty"`
+	IDDocumentType    *string            `gorm:"type:varchar(30)" json:"id_document_type,omitempty"` // e.g. national_id, passport
+	IDNumber          *string            `gorm:"type:varchar(50);index" json:"id_number,omitempty"`
+	Village           *string            `gorm:"type:varchar(100)" json:"village,omitempty"`
+	MineSiteID        *uint              `gorm:"index" json:"mine_site_id,omitempty"` // site the miner works at
+	PitNumber         *string            `gorm:"type:varchar(100)" json:"pit_number,omitempty"`
+	PaymentMethod     MinerPaymentMethod `gorm:"type:varchar(20);default:'cash'" json:"payment_method"`
+	MobileMoneyNumber *string            `gorm:"type:varchar(20)" json:"mobile_money_number,omitempty"`
+	BankName          *string            `gorm:"type:varchar(100)" json:"bank_name,omitempty"`
+	BankAccountNumber *string            `gorm:"type:varchar(50)" json:"bank_account_number,omitempty"`
+	Active            bool               `gorm:"default:true" json:"active"`
+	Notes             *string            `gorm:"type:text" json:"notes,omitempty"`
+	KYCComplete       bool               `gorm:"-" json:"kyc_complete"`
+	KYCMissing        []string           `gorm:"-" json:"kyc_missing"` // KYC requirements not met yet
+	UserID            uint               `gorm:"not null;index" json:"user_id"`
+	CreatedAt         time.Time          `json:"created_at"`
+	UpdatedAt         time.Time          `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt     `gorm:"index" json:"-"`
+}
+
+// MinerPayable is what is owed to a miner for their purchases
+type MinerPayable struct {
+	MinerName    string    `json:"miner_name"`
+	Purchases    int64     `json:"purchases"`
+	TotalAmount  float64   `json:"total_amount"`
+	AmountPaid   float64   `json:"amount_paid"`
+	AmountDue    float64   `json:"amount_due"`
+	LastPurchase time.Time `json:"last_purchase"`
+}
+
+// MinerStatement represents the purchases from a miner and the payments made to them, oldest
+// first
+type MinerStatement struct {
+	MinerName   string      `json:"miner_name"`
+	TotalAmount float64     `json:"total_amount"`
+	TotalPaid   float64     `json:"total_paid"`
+	Balance     float64     `json:"balance"` // still owed to the miner
+	Purchases   []*Purchase `json:"purchases"`
+	Payments    []*Payment  `json:"payments"`
 }
 
 // ProductionFrom represents the source of production
@@ -209,33 +587,105 @@ const (
 // InventoryItem represents an inventory/production item
 type InventoryItem struct {
 	gorm.Model
-	Name             string            `gorm:"type:varchar(100);not null" json:"name"`
-	Type             string            `gorm:"type:varchar(20);not null" json:"type"`  // "mineral" or "supply"
-	From             *ProductionFrom   `gorm:"type:varchar(20)" json:"from,omitempty"` // "mine" or "processing"
-	PitNumber        *string           `gorm:"type:varchar(100)" json:"pit_number,omitempty"`
-	MinerName        *string           `gorm:"type:varchar(100)" json:"miner_name,omitempty"`
-	BatchNumber      *string           `gorm:"type:varchar(100)" json:"batch_number,omitempty"`
-	ProcessingMethod *ProcessingMethod `gorm:"type:varchar(50)" json:"processing_method,omitempty"`
-	Quantity         float64           `gorm:"not null" json:"quantity"`
-	Unit             string            `gorm:"type:varchar(20);not null" json:"unit"`
-	MinStockLevel    float64           `gorm:"not null" json:"min_stock_level"`
-	CurrentValue     float64           `gorm:"not null" json:"current_value"`
-	LastUpdated      time.Time         `gorm:"not null" json:"last_updated"`
-	UserID           uint              `gorm:"not null" json:"user_id"`
-	User             User              `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	CreatedAt        time.Time         `json:"created_at"`
-	UpdatedAt        time.Time         `json:"updated_at"`
-	DeletedAt        gorm.DeletedAt    `gorm:"index" json:"-"`
+	Name              string            `gorm:"type:varchar(100);not null" json:"name"`
+	Type              string            `gorm:"type:varchar(20);not null" json:"type"`  // "mineral" or "supply"
+	From              *ProductionFrom   `gorm:"type:varchar(20)" json:"from,omitempty"` // "mine" or "processing"
+	PitNumber         *string           `gorm:"type:varchar(100)" json:"pit_number,omitempty"`
+	MinerName         *string           `gorm:"type:varchar(100)" json:"miner_name,omitempty"`
+	BatchNumber       *string           `gorm:"type:varchar(100)" json:"batch_number,omitempty"`
+	ProcessingMethod  *ProcessingMethod `gorm:"type:varchar(50)" json:"processing_method,omitempty"`
+	Quantity          float64           `gorm:"not null" json:"quantity"`
+	Unit              string            `gorm:"type:varchar(20);not null" json:"unit"`
+	MinStockLevel     float64           `gorm:"not null" json:"min_stock_level"`
+	CurrentValue      float64           `gorm:"not null" json:"current_value"`
+	LastUpdated       time.Time         `gorm:"not null" json:"last_updated"`
+	ExpiryDate        *time.Time        `gorm:"index" json:"expiry_date,omitempty"` // supply items only
+	IsHazardous       bool              `gorm:"default:false" json:"is_hazardous"`
+	HazardClass       *string           `gorm:"type:varchar(100)" json:"hazard_class,omitempty"`
+	PermittedQuantity *float64          `json:"permitted_quantity,omitempty"`        // licensed maximum stock on site
+	MonthlyUsageLimit *float64          `json:"monthly_usage_limit,omitempty"`       // licensed maximum usage per month
+	PrimaryImageID    *uint             `json:"primary_image_id,omitempty"`          // attached photo shown when browsing stock
+	MineSiteID        *uint             `gorm:"index" json:"mine_site_id,omitempty"` // site the stock is kept at
+	UserID            uint              `gorm:"not null" json:"user_id"`
+	User              User              `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	CreatedAt         time.Time         `json:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt    `gorm:"index" json:"-"`
+}
+
+// MonthlyUsage is the quantity of an inventory item used in a month
+type MonthlyUsage struct {
+	InventoryItemID uint    `json:"inventory_item_id"`
+	Month           string  `json:"month"` // YYYY-MM
+	Quantity        float64 `json:"quantity"`
+}
+
+// SeasonalStockBasis is the consumption a seasonal stock plan expects an item to follow
+type SeasonalStockBasis string
+
+const (
+	SeasonalBasisRainy   SeasonalStockBasis = "rainy_months" // usage in the rainy months of the past year
+	SeasonalBasisAll     SeasonalStockBasis = "all_months"   // usage over the past year, for items not used in rainy months
+	SeasonalBasisNoUsage SeasonalStockBasis = "no_usage"     // not used in the past year; only the minimum stock level is planned
+)
+
+// SeasonalStockPlan is how much of each supply to buy before a rainy season so stock lasts it
+// out, from consumption over the past year
+type SeasonalStockPlan struct {
+	SeasonStart   string               `json:"season_start"` // YYYY-MM
+	SeasonEnd     string               `json:"season_end"`   // YYYY-MM, the last month of the season
+	Months        int                  `json:"months"`
+	HistoryFrom   string               `json:"history_from"` // YYYY-MM, the first month of the consumption history
+	Items         []*SeasonalStockLine `json:"items"`
+	EstimatedCost float64              `json:"estimated_cost"` // of the items with a known unit cost
+}
+
+// SeasonalStockLine is the stock of a supply planned for a rainy season
+type SeasonalStockLine struct {
+	InventoryItemID uint               `json:"inventory_item_id"`
+	ItemName        string             `json:"item_name"`
+	Unit            string             `json:"unit"`
+	Basis           SeasonalStockBasis `json:"basis"`
+	MonthlyUsage    float64            `json:"monthly_usage"`   // average over the months of the basis
+	SeasonUsage     float64            `json:"season_usage"`    // expected over the season
+	MinStockLevel   float64            `json:"min_stock_level"` // kept in stock on top of the season's usage
+	InStock         float64            `json:"in_stock"`
+	ToBuy           float64            `json:"to_buy"`
+	UnitCost        *float64           `json:"unit_cost,omitempty"` // list price, or the average cost of the stock on hand
+	EstimatedCost   *float64           `json:"estimated_cost,omitempty"`
+}
+
+// ComplianceWarningType represents which licensed limit a hazardous item exceeds
+type ComplianceWarningType string
+
+const (
+	ComplianceStockLimit ComplianceWarningType = "stock_limit"
+	ComplianceUsageLimit ComplianceWarningType = "usage_limit"
+)
+
+// ComplianceWarning represents a hazardous item exceeding a licensed limit
+type ComplianceWarning struct {
+	InventoryItemID uint                  `json:"inventory_item_id"`
+	ItemName        string                `json:"item_name"`
+	Type            ComplianceWarningType `json:"type"`
+	Limit           float64               `json:"limit"`
+	Actual          float64               `json:"actual"`
+	Unit            string                `json:"unit"`
+	Message         string                `json:"message"`
 }
 
 // FinancialSummary represents financial summary data
 type FinancialSummary struct {
-	TotalIncome      float64 `json:"total_income"`
-	TotalExpenses    float64 `json:"total_expenses"`
-	NetProfit        float64 `json:"net_profit"`
-	TotalReceivables float64 `json:"total_receivables"`
-	TotalPayables    float64 `json:"total_payables"`
-	ProfitMargin     float64 `json:"profit_margin"`
+	TotalIncome      float64    `json:"total_income"`
+	TotalExpenses    float64    `json:"total_expenses"`
+	NetProfit        float64    `json:"net_profit"`
+	TotalReceivables float64    `json:"total_receivables"`
+	TotalPayables    float64    `json:"total_payables"`
+	ProfitMargin     float64    `json:"profit_margin"`
+	PrepaidBalance   float64    `json:"prepaid_balance"`  // prepaid expenses not expensed yet
+	Period           string     `json:"period,omitempty"` // preset or custom range the summary covers; all time when empty
+	StartDate        *time.Time `json:"start_date,omitempty"`
+	EndDate          *time.Time `json:"end_date,omitempty"` // inclusive
 }
 
 // MonthlyData represents monthly financial data
@@ -253,22 +703,2619 @@ type CategoryBreakdown struct {
 	Percentage float64 `json:"percentage"`
 }
 
-// MineSiteInfo represents mine site information
+// MineSiteInfo represents a mine site. Operators running more than one pit keep a site for each,
+// and records can be assigned to a site to keep its books separate.
 type MineSiteInfo struct {
 	gorm.Model
+	Name            *string        `gorm:"type:varchar(100)" json:"name,omitempty"` // tells the sites of an operator apart, e.g. "North pit"
 	Owner           string         `gorm:"type:varchar(255);not null" json:"owner"`
 	License         *string        `gorm:"type:varchar(100)" json:"license,omitempty"`
+	LicenseExpiry   *time.Time     `json:"license_expiry,omitempty"`
 	Location        string         `gorm:"type:varchar(255);not null" json:"location"`
-	Size            *float64       `gorm:"type:decimal(10,2)" json:"size,omitempty"` // hectares
+	Region          *string        `gorm:"type:varchar(100)" json:"region,omitempty"` // district or region for anonymous price benchmarks
+	Size            *float64       `gorm:"type:decimal(10,2)" json:"size,omitempty"`  // hectares
 	NumberOfPits    *int           `gorm:"type:integer" json:"number_of_pits,omitempty"`
 	Commodities     *string        `gorm:"type:text" json:"commodities,omitempty"`
 	Equipment       *string        `gorm:"type:text" json:"equipment,omitempty"`
 	Employees       *int           `gorm:"type:integer" json:"employees,omitempty"`
 	EstablishedYear *int           `gorm:"type:integer" json:"established_year,omitempty"`
 	Contact         *string        `gorm:"type:varchar(255)" json:"contact,omitempty"`
+	Latitude        *float64       `json:"latitude,omitempty"`
+	Longitude       *float64       `json:"longitude,omitempty"`
+	GeofenceRadius  *float64       `json:"geofence_radius,omitempty"` // meters around the coordinates accepted for check-in
+	UserID          uint           `gorm:"not null" json:"user_id"`
+	User            User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// StocktakeStatus represents the lifecycle state of a stocktake session
+type StocktakeStatus string
+
+const (
+	StocktakeOpen      StocktakeStatus = "open"
+	StocktakeApproved  StocktakeStatus = "approved"
+	StocktakeCancelled StocktakeStatus = "cancelled"
+)
+
+// StockMovementType represents the reason an inventory quantity changed
+type StockMovementType string
+
+const (
+	StockMovementAdjustment StockMovementType = "adjustment"
+	StockMovementUsage      StockMovementType = "usage"
+	StockMovementPurchase   StockMovementType = "purchase"
+	StockMovementWriteOff   StockMovementType = "write_off" // damaged, expired, lost or stolen stock
+)
+
+// Stocktake represents a stocktake/cycle count session
+type Stocktake struct {
+	gorm.Model
+	Name       string          `gorm:"type:varchar(100);not null" json:"name"`
+	Status     StocktakeStatus `gorm:"type:varchar(20);not null;default:'open'" json:"status"`
+	Notes      *string         `gorm:"type:text" json:"notes,omitempty"`
+	StartedAt  time.Time       `gorm:"not null" json:"started_at"`
+	ApprovedAt *time.Time      `json:"approved_at,omitempty"`
+	Lines      []StocktakeLine `gorm:"foreignKey:StocktakeID" json:"lines,omitempty"`
+	UserID     uint            `gorm:"not null" json:"user_id"`
+	User       User            `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt  `gorm:"index" json:"-"`
+}
+
+// StocktakeLine represents the expected and counted quantity of one item in a stocktake
+type StocktakeLine struct {
+	gorm.Model
+	StocktakeID      uint           `gorm:"not null;index" json:"stocktake_id"`
+	InventoryItemID  uint           `gorm:"not null" json:"inventory_item_id"`
+	ItemName         string         `gorm:"type:varchar(100);not null" json:"item_name"`
+	Unit             string         `gorm:"type:varchar(20);not null" json:"unit"`
+	ExpectedQuantity float64        `gorm:"not null" json:"expected_quantity"`
+	CountedQuantity  *float64       `json:"counted_quantity,omitempty"`
+	Variance         float64        `gorm:"default:0" json:"variance"`
+	UnitValue        float64        `gorm:"default:0" json:"unit_value"`
+	VarianceValue    float64        `gorm:"default:0" json:"variance_value"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// StockMovement represents a recorded change to an inventory item's quantity
+type StockMovement struct {
+	gorm.Model
+	InventoryItemID uint              `gorm:"not null;index" json:"inventory_item_id"`
+	Type            StockMovementType `gorm:"type:varchar(20);not null" json:"type"`
+	Quantity        float64           `gorm:"not null" json:"quantity"` // signed change
+	QuantityBefore  float64           `gorm:"not null" json:"quantity_before"`
+	QuantityAfter   float64           `gorm:"not null" json:"quantity_after"`
+	StocktakeID     *uint             `gorm:"index" json:"stocktake_id,omitempty"`
+	PurchaseID      *uint             `gorm:"index" json:"purchase_id,omitempty"`
+	Reason          *string           `gorm:"type:varchar(255)" json:"reason,omitempty"`
+	Value           *float64          `json:"value,omitempty"` // value of the stock written off
+	UserID          uint              `gorm:"not null" json:"user_id"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt    `gorm:"index" json:"-"`
+}
+
+// VarianceReport summarises the differences found in a stocktake
+type VarianceReport struct {
+	StocktakeID        uint             `json:"stocktake_id"`
+	Status             StocktakeStatus  `json:"status"`
+	TotalLines         int              `json:"total_lines"`
+	CountedLines       int              `json:"counted_lines"`
+	LinesWithVariance  int              `json:"lines_with_variance"`
+	TotalVarianceValue float64          `json:"total_variance_value"`
+	Lines              []*StocktakeLine `json:"lines"`
+}
+
+// NotificationKind represents the type of an in-app notification
+type NotificationKind string
+
+const (
+	NotificationSupplyExpiring NotificationKind = "supply_expiring"
+	NotificationHazardLimit    NotificationKind = "hazard_limit"
+	NotificationDunningCall    NotificationKind = "dunning_call"
+	NotificationTaskOverdue    NotificationKind = "task_overdue"
+	NotificationSharedSale     NotificationKind = "shared_sale"
+	NotificationTradeDispute   NotificationKind = "trade_dispute"
+	NotificationLowStock       NotificationKind = "low_stock"
+	NotificationTrialEnding    NotificationKind = "trial_ending"
+	NotificationTrialEnded     NotificationKind = "trial_ended"
+	NotificationCashMismatch   NotificationKind = "cash_discrepancy"
+	NotificationSignOff        NotificationKind = "expense_sign_off"
+	NotificationPolicyExpiring NotificationKind = "insurance_expiring"
+)
+
+// Notification represents an in-app notification for a user
+type Notification struct {
+	gorm.Model
+	Kind        NotificationKind `gorm:"type:varchar(50);not null" json:"kind"`
+	Title       string           `gorm:"type:varchar(255);not null" json:"title"`
+	Message     string           `gorm:"type:text;not null" json:"message"`
+	ReferenceID *uint            `json:"reference_id,omitempty"`
+	Key         string           `gorm:"type:varchar(255);not null;uniqueIndex:idx_notifications_user_key" json:"-"` // de-duplicates repeated alerts
+	ReadAt      *time.Time       `json:"read_at,omitempty"`
+	UserID      uint             `gorm:"not null;uniqueIndex:idx_notifications_user_key" json:"user_id"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt   `gorm:"index" json:"-"`
+}
+
+// Equipment represents an equipment item on the asset register, such as a generator or a crusher,
+// depreciated on a straight line over its useful life. Its purchase expense, when linked, counts
+// in the profit and loss through the depreciation instead of on its date.
+type Equipment struct {
+	gorm.Model
+	Name              string         `gorm:"type:varchar(100);not null" json:"name"`
+	SerialNumber      *string        `gorm:"type:varchar(100)" json:"serial_number,omitempty"`
+	PurchaseDate      time.Time      `gorm:"not null" json:"purchase_date"`
+	Cost              float64        `gorm:"not null" json:"cost"`
+	SalvageValue      float64        `gorm:"not null;default:0" json:"salvage_value"` // value at the end of its useful life
+	UsefulLifeMonths  int            `gorm:"not null" json:"useful_life_months"`      // months it is depreciated over
+	DepreciationStart time.Time      `gorm:"not null" json:"depreciation_start"`      // first day of the first month
+	ExpenseID         *uint          `gorm:"index" json:"expense_id,omitempty"`       // expense it was bought with
+	MineSiteID        *uint          `gorm:"index" json:"mine_site_id,omitempty"`     // site it is used at
+	Notes             *string        `gorm:"type:text" json:"notes,omitempty"`
+	UserID            uint           `gorm:"not null" json:"user_id"`
+	User              User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Disposal, after which it is no longer depreciated nor on the asset register
+	DisposedAt       *time.Time    `json:"disposed_at,omitempty"`
+	DisposalType     *DisposalType `gorm:"type:varchar(20)" json:"disposal_type,omitempty"`
+	DisposalProceeds float64       `gorm:"not null;default:0" json:"disposal_proceeds,omitempty"`
+	DisposalGain     *float64      `json:"disposal_gain,omitempty"`      // proceeds less the book value; negative for a loss
+	DisposalIncomeID *uint         `json:"disposal_income_id,omitempty"` // sale the proceeds were booked as
+	DisposalReason   *string       `gorm:"type:varchar(255)" json:"disposal_reason,omitempty"`
+}
+
+// DisposalType represents how equipment left the books
+type DisposalType string
+
+const (
+	DisposalSale     DisposalType = "sale"
+	DisposalWriteOff DisposalType = "write_off" // scrapped, lost or stolen
+)
+
+// EquipmentDisposal represents the disposal of an equipment item. The sale its proceeds are booked
+// as is set for sales with proceeds.
+type EquipmentDisposal struct {
+	Date     time.Time
+	Type     DisposalType
+	Proceeds float64
+	Reason   *string
+	Sale     *Income
+}
+
+// DepreciationEntry is the depreciation of an equipment item in a month. The entries of an item
+// are generated from its cost, salvage value and useful life and add up to the difference.
+type DepreciationEntry struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	EquipmentID uint      `gorm:"not null;index" json:"equipment_id"`
+	Date        time.Time `gorm:"not null;index:,composite:user_date,priority:2" json:"date"` // first day of the month
+	Amount      float64   `gorm:"not null" json:"amount"`
+	Disposal    bool      `gorm:"not null;default:false" json:"disposal,omitempty"` // book value written off on disposal
+	UserID      uint      `gorm:"not null;index:,composite:user_date,priority:1" json:"user_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// EquipmentBookValue represents the depreciation and book value of an equipment item at a date
+type EquipmentBookValue struct {
+	EquipmentID             uint      `json:"equipment_id"`
+	Name                    string    `json:"name"`
+	SerialNumber            *string   `json:"serial_number,omitempty"`
+	PurchaseDate            time.Time `json:"purchase_date"`
+	Cost                    float64   `json:"cost"`
+	SalvageValue            float64   `json:"salvage_value"`
+	MonthlyDepreciation     float64   `json:"monthly_depreciation"`
+	AccumulatedDepreciation float64   `json:"accumulated_depreciation"`
+	BookValue               float64   `json:"book_value"`
+	RemainingMonths         int       `json:"remaining_months"`
+}
+
+// AssetRegister represents the book values of the equipment items at a date
+type AssetRegister struct {
+	AsOf                    time.Time             `json:"as_of"`
+	Items                   []*EquipmentBookValue `json:"items"`
+	TotalCost               float64               `json:"total_cost"`
+	AccumulatedDepreciation float64               `json:"accumulated_depreciation"`
+	BookValue               float64               `json:"book_value"`
+}
+
+// CoverageType represents what an insurance policy covers
+type CoverageType string
+
+const (
+	CoverageEquipment CoverageType = "equipment"
+	CoverageVehicle   CoverageType = "vehicle"
+	CoverageProperty  CoverageType = "property"  // buildings and stock at a site
+	CoverageLiability CoverageType = "liability" // injuries and damage to others
+	CoverageOther     CoverageType = "other"
+)
+
+// InsurancePolicy represents an insurance policy, optionally covering an equipment item, a
+// vehicle or a mine site
+type InsurancePolicy struct {
+	gorm.Model
+	Insurer      string            `gorm:"type:varchar(100);not null" json:"insurer"`
+	PolicyNumber string            `gorm:"type:varchar(100);not null" json:"policy_number"`
+	CoverageType CoverageType      `gorm:"type:varchar(20);not null" json:"coverage_type"`
+	SumInsured   float64           `gorm:"not null;default:0" json:"sum_insured"`
+	Premium      float64           `gorm:"not null;default:0" json:"premium"` // payable for the policy period
+	StartDate    time.Time         `gorm:"not null" json:"start_date"`
+	ExpiryDate   time.Time         `gorm:"not null;index" json:"expiry_date"`
+	EquipmentID  *uint             `gorm:"index" json:"equipment_id,omitempty"`
+	VehicleID    *uint             `gorm:"index" json:"vehicle_id,omitempty"`
+	MineSiteID   *uint             `gorm:"index" json:"mine_site_id,omitempty"`
+	Notes        *string           `gorm:"type:text" json:"notes,omitempty"`
+	Claims       []*InsuranceClaim `gorm:"foreignKey:PolicyID" json:"claims,omitempty"`
+	UserID       uint              `gorm:"not null;index" json:"user_id"`
+	User         User              `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt    `gorm:"index" json:"-"`
+}
+
+// InsuranceClaimStatus represents where an insurance claim is with the insurer
+type InsuranceClaimStatus string
+
+const (
+	InsuranceClaimOpen     InsuranceClaimStatus = "open"
+	InsuranceClaimApproved InsuranceClaimStatus = "approved"
+	InsuranceClaimRejected InsuranceClaimStatus = "rejected"
+	InsuranceClaimPaid     InsuranceClaimStatus = "paid" // payout received and booked as a sale
+)
+
+// InsuranceClaim represents a claim made on an insurance policy for an incident. The payout,
+// once received, is booked as a sale to the insurer.
+type InsuranceClaim struct {
+	gorm.Model
+	PolicyID            uint                 `gorm:"not null;index" json:"policy_id"`
+	Policy              *InsurancePolicy     `gorm:"foreignKey:PolicyID" json:"policy,omitempty"`
+	ClaimNumber         *string              `gorm:"type:varchar(100)" json:"claim_number,omitempty"` // reference given by the insurer
+	IncidentDate        time.Time            `gorm:"not null" json:"incident_date"`
+	IncidentDescription string               `gorm:"type:text;not null" json:"incident_description"`
+	EquipmentID         *uint                `gorm:"index" json:"equipment_id,omitempty"` // equipment damaged or lost
+	AmountClaimed       float64              `gorm:"not null" json:"amount_claimed"`
+	Status              InsuranceClaimStatus `gorm:"type:varchar(20);not null;default:'open';index" json:"status"`
+	Payout              float64              `gorm:"not null;default:0" json:"payout"`
+	PaidAt              *time.Time           `json:"paid_at,omitempty"`
+	IncomeID            *uint                `json:"income_id,omitempty"` // sale the payout was booked as
+	Notes               *string              `gorm:"type:text" json:"notes,omitempty"`
+	UserID              uint                 `gorm:"not null;index" json:"user_id"`
+	CreatedAt           time.Time            `json:"created_at"`
+	UpdatedAt           time.Time            `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt       `gorm:"index" json:"-"`
+}
+
+// Vehicle represents a vehicle used to move ore, supplies or sold minerals
+type Vehicle struct {
+	gorm.Model
+	Registration    string         `gorm:"type:varchar(50);not null" json:"registration"`
+	Description     *string        `gorm:"type:varchar(255)" json:"description,omitempty"`
+	Type            *string        `gorm:"type:varchar(50)" json:"type,omitempty"` // e.g. truck, pickup, motorcycle
+	NextServiceDate *time.Time     `json:"next_service_date,omitempty"`            // scheduled maintenance
+	Notes           *string        `gorm:"type:text" json:"notes,omitempty"`
 	UserID          uint           `gorm:"not null" json:"user_id"`
 	User            User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	CreatedAt       time.Time      `json:"created_at"`
 	UpdatedAt       time.Time      `json:"updated_at"`
 	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
 }
+
+// Trip represents a single vehicle journey, optionally delivering a sale
+type Trip struct {
+	gorm.Model
+	VehicleID     uint           `gorm:"not null;index" json:"vehicle_id"`
+	Vehicle       Vehicle        `gorm:"foreignKey:VehicleID" json:"vehicle,omitempty"`
+	Date          time.Time      `gorm:"not null" json:"date"`
+	Origin        string         `gorm:"type:varchar(255);not null" json:"origin"`
+	Destination   string         `gorm:"type:varchar(255);not null" json:"destination"`
+	Cargo         *string        `gorm:"type:varchar(255)" json:"cargo,omitempty"`
+	CargoQuantity *float64       `json:"cargo_quantity,omitempty"`
+	CargoUnit     *string        `gorm:"type:varchar(20)" json:"cargo_unit,omitempty"`
+	DistanceKm    float64        `gorm:"not null;default:0" json:"distance_km"`
+	IncomeID      *uint          `gorm:"index" json:"income_id,omitempty"` // sale delivered by this trip
+	Notes         *string        `gorm:"type:text" json:"notes,omitempty"`
+	UserID        uint           `gorm:"not null" json:"user_id"`
+	User          User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// VehicleCost represents transport cost analytics for one vehicle
+type VehicleCost struct {
+	VehicleID       uint    `json:"vehicle_id"`
+	Registration    string  `json:"registration"`
+	Trips           int     `json:"trips"`
+	Deliveries      int     `json:"deliveries"`
+	TotalDistance   float64 `json:"total_distance_km"`
+	TotalCost       float64 `json:"total_cost"`
+	CostPerKm       float64 `json:"cost_per_km"`
+	CostPerDelivery float64 `json:"cost_per_delivery"`
+}
+
+// DeliveryCost represents the transport cost of delivering a sale
+type DeliveryCost struct {
+	TripID        uint      `json:"trip_id"`
+	IncomeID      uint      `json:"income_id"`
+	Date          time.Time `json:"date"`
+	Registration  string    `json:"registration"`
+	CustomerName  string    `json:"customer_name"`
+	SaleAmount    float64   `json:"sale_amount"`
+	TransportCost float64   `json:"transport_cost"`
+	CostRatio     float64   `json:"cost_ratio"` // transport cost as a percentage of the sale
+}
+
+// RateBasis represents how a contractor or gang is paid
+type RateBasis string
+
+const (
+	RatePerTonne RateBasis = "per_tonne"
+	RatePerDay   RateBasis = "per_day"
+)
+
+// Contractor represents a contractor or casual labor gang
+type Contractor struct {
+	gorm.Model
+	Name       string         `gorm:"type:varchar(100);not null" json:"name"`
+	LeaderName *string        `gorm:"type:varchar(100)" json:"leader_name,omitempty"`
+	Phone      *string        `gorm:"type:varchar(20)" json:"phone,omitempty"`
+	RateBasis  RateBasis      `gorm:"type:varchar(20);not null" json:"rate_basis"`
+	Rate       float64        `gorm:"not null" json:"rate"`
+	Notes      *string        `gorm:"type:text" json:"notes,omitempty"`
+	UserID     uint           `gorm:"not null" json:"user_id"`
+	User       User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// WorkRecord represents work done by a contractor, billed as a labor expense
+type WorkRecord struct {
+	gorm.Model
+	ContractorID uint           `gorm:"not null;index" json:"contractor_id"`
+	Date         time.Time      `gorm:"not null" json:"date"`
+	Quantity     float64        `gorm:"not null" json:"quantity"` // tonnes or days depending on rate basis
+	RateBasis    RateBasis      `gorm:"type:varchar(20);not null" json:"rate_basis"`
+	Rate         float64        `gorm:"not null" json:"rate"`
+	Amount       float64        `gorm:"not null" json:"amount"`
+	Description  *string        `gorm:"type:varchar(255)" json:"description,omitempty"`
+	PitNumber    *string        `gorm:"type:varchar(100)" json:"pit_number,omitempty"`
+	ExpenseID    uint           `gorm:"not null;index" json:"expense_id"`
+	Expense      Expense        `gorm:"foreignKey:ExpenseID;-:migration" json:"expense,omitempty"` // no foreign key, so expenses can be partitioned
+	UserID       uint           `gorm:"not null" json:"user_id"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// ContractorStatement represents work done versus payments made for a contractor
+type ContractorStatement struct {
+	Contractor  *Contractor   `json:"contractor"`
+	TotalWork   float64       `json:"total_work"` // tonnes or days
+	TotalAmount float64       `json:"total_amount"`
+	TotalPaid   float64       `json:"total_paid"`
+	Balance     float64       `json:"balance"`
+	Lines       []*WorkRecord `json:"lines"`
+}
+
+// Employee represents a worker paid through payroll
+type Employee struct {
+	gorm.Model
+	Name       string         `gorm:"type:varchar(100);not null" json:"name"`
+	Phone      *string        `gorm:"type:varchar(20)" json:"phone,omitempty"`
+	Position   *string        `gorm:"type:varchar(100)" json:"position,omitempty"`
+	HourlyRate float64        `gorm:"not null" json:"hourly_rate"`
+	PitNumber  *string        `gorm:"type:varchar(100)" json:"pit_number,omitempty"` // default pit for timesheets
+	Active     bool           `gorm:"default:true" json:"active"`
+	UserID     uint           `gorm:"not null" json:"user_id"`
+	User       User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TimesheetStatus represents the approval state of a timesheet entry
+type TimesheetStatus string
+
+const (
+	TimesheetSubmitted TimesheetStatus = "submitted"
+	TimesheetApproved  TimesheetStatus = "approved"
+	TimesheetRejected  TimesheetStatus = "rejected"
+)
+
+// Timesheet represents hours worked by an employee on a day
+type Timesheet struct {
+	gorm.Model
+	EmployeeID      uint            `gorm:"not null;index" json:"employee_id"`
+	Employee        Employee        `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+	Date            time.Time       `gorm:"not null;index" json:"date"`
+	Hours           float64         `gorm:"not null" json:"hours"`
+	HourlyRate      float64         `gorm:"not null" json:"hourly_rate"` // rate at submission time
+	Amount          float64         `gorm:"not null" json:"amount"`
+	PitNumber       *string         `gorm:"type:varchar(100)" json:"pit_number,omitempty"`
+	Status          TimesheetStatus `gorm:"type:varchar(20);not null;default:'submitted'" json:"status"`
+	Notes           *string         `gorm:"type:text" json:"notes,omitempty"`
+	RejectionReason *string         `gorm:"type:varchar(255)" json:"rejection_reason,omitempty"`
+	ReviewedAt      *time.Time      `json:"reviewed_at,omitempty"`
+	UserID          uint            `gorm:"not null" json:"user_id"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt  `gorm:"index" json:"-"`
+}
+
+// AttendanceFlag represents an anti-spoofing heuristic tripped by a check-in or check-out
+type AttendanceFlag string
+
+const (
+	AttendanceMockLocation     AttendanceFlag = "mock_location"      // the device reported a mock location provider
+	AttendanceMissingAccuracy  AttendanceFlag = "missing_accuracy"   // no or zero accuracy, typical of injected fixes
+	AttendanceLowAccuracy      AttendanceFlag = "low_accuracy"       // accuracy too coarse to place the device on site
+	AttendanceCoarseCoordinate AttendanceFlag = "coarse_coordinates" // too few decimals, typical of typed coordinates
+	AttendanceClockSkew        AttendanceFlag = "clock_skew"         // device clock far from server time
+	AttendanceRepeatedLocation AttendanceFlag = "repeated_location"  // identical fix to the previous record
+	AttendanceImpossibleTravel AttendanceFlag = "impossible_travel"  // implied speed from the previous fix is not plausible
+	AttendanceOutsideSite      AttendanceFlag = "outside_site"       // checked out outside the geofence
+)
+
+// Attendance represents an employee's geofenced check-in and check-out at the mine site.
+// Checking out submits a timesheet for the hours on site.
+type Attendance struct {
+	gorm.Model
+	EmployeeID        uint             `gorm:"not null;index" json:"employee_id"`
+	Employee          Employee         `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+	CheckInAt         time.Time        `gorm:"not null;index" json:"check_in_at"`
+	CheckInLatitude   float64          `gorm:"not null" json:"check_in_latitude"`
+	CheckInLongitude  float64          `gorm:"not null" json:"check_in_longitude"`
+	CheckInAccuracy   *float64         `json:"check_in_accuracy,omitempty"`       // meters, as reported by the device
+	CheckInDistance   float64          `gorm:"not null" json:"check_in_distance"` // meters from the site
+	CheckOutAt        *time.Time       `json:"check_out_at,omitempty"`
+	CheckOutLatitude  *float64         `json:"check_out_latitude,omitempty"`
+	CheckOutLongitude *float64         `json:"check_out_longitude,omitempty"`
+	CheckOutAccuracy  *float64         `json:"check_out_accuracy,omitempty"`
+	CheckOutDistance  *float64         `json:"check_out_distance,omitempty"`
+	Hours             float64          `gorm:"default:0" json:"hours"`
+	DeviceID          *string          `gorm:"type:varchar(100)" json:"device_id,omitempty"`
+	Flags             []AttendanceFlag `gorm:"type:jsonb;serializer:json" json:"flags"`
+	Flagged           bool             `gorm:"default:false;index" json:"flagged"`
+	TimesheetID       *uint            `json:"timesheet_id,omitempty"`
+	CheckedInByID     *uint            `json:"checked_in_by_id,omitempty"`
+	CheckedOutByID    *uint            `json:"checked_out_by_id,omitempty"`
+	UserID            uint             `gorm:"not null;index" json:"user_id"`
+	CreatedAt         time.Time        `json:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt   `gorm:"index" json:"-"`
+}
+
+// AttendanceFilter narrows down attendance records; zero values are ignored
+type AttendanceFilter struct {
+	EmployeeID uint
+	Start      *time.Time
+	End        *time.Time
+	Flagged    bool
+	Open       bool
+}
+
+// EmployeeHours represents approved hours and pay of an employee for a period
+type EmployeeHours struct {
+	EmployeeID uint    `json:"employee_id"`
+	Name       string  `json:"name"`
+	Hours      float64 `json:"hours"`
+	Amount     float64 `json:"amount"`
+}
+
+// PitLaborCost represents labor cost attributed to a pit for a period
+type PitLaborCost struct {
+	PitNumber      string  `json:"pit_number"`
+	Hours          float64 `json:"hours"`
+	Employees      int     `json:"employees"`
+	TimesheetCost  float64 `json:"timesheet_cost"`
+	ContractorCost float64 `json:"contractor_cost"`
+	TotalCost      float64 `json:"total_cost"`
+}
+
+// LaborCostSummary represents the monthly labor cost roll-up
+type LaborCostSummary struct {
+	Month      string           `json:"month"`
+	TotalHours float64          `json:"total_hours"`
+	TotalCost  float64          `json:"total_cost"`
+	ByPit      []*PitLaborCost  `json:"by_pit"`
+	ByEmployee []*EmployeeHours `json:"by_employee"`
+}
+
+// AdjustmentType represents the kind of pay adjustment recorded against an employee
+type AdjustmentType string
+
+const (
+	AdjustmentAdvance   AdjustmentType = "advance"
+	AdjustmentDeduction AdjustmentType = "deduction"
+)
+
+// EmployeeAdjustment represents a salary advance or deduction netted off in the next payroll run
+type EmployeeAdjustment struct {
+	gorm.Model
+	EmployeeID       uint           `gorm:"not null;index" json:"employee_id"`
+	Employee         Employee       `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+	Type             AdjustmentType `gorm:"type:varchar(20);not null" json:"type"`
+	Amount           float64        `gorm:"not null" json:"amount"`
+	Date             time.Time      `gorm:"not null" json:"date"`
+	Description      string         `gorm:"type:varchar(255);not null" json:"description"`
+	PayrollRunID     *uint          `gorm:"index" json:"payroll_run_id,omitempty"`      // set once netted off
+	CarriedFromRunID *uint          `gorm:"index" json:"carried_from_run_id,omitempty"` // balance carried forward by a payroll run
+	UserID           uint           `gorm:"not null" json:"user_id"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// PayrollRun represents the monthly payroll netting approved timesheet pay against advances and deductions
+type PayrollRun struct {
+	gorm.Model
+	Month           string         `gorm:"type:varchar(7);not null;uniqueIndex:idx_payroll_runs_user_month" json:"month"` // YYYY-MM
+	PeriodStart     time.Time      `gorm:"not null" json:"period_start"`
+	PeriodEnd       time.Time      `gorm:"not null" json:"period_end"`
+	TotalGross      float64        `gorm:"not null" json:"total_gross"`
+	TotalAdvances   float64        `gorm:"not null" json:"total_advances"`
+	TotalDeductions float64        `gorm:"not null" json:"total_deductions"`
+	TotalNet        float64        `gorm:"not null" json:"total_net"`
+	Notes           *string        `gorm:"type:text" json:"notes,omitempty"`
+	Lines           []PayrollLine  `gorm:"foreignKey:PayrollRunID" json:"lines,omitempty"`
+	UserID          uint           `gorm:"not null;uniqueIndex:idx_payroll_runs_user_month" json:"user_id"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// PayrollLine represents the payslip of one employee in a payroll run
+type PayrollLine struct {
+	gorm.Model
+	PayrollRunID   uint           `gorm:"not null;index" json:"payroll_run_id"`
+	EmployeeID     uint           `gorm:"not null;index" json:"employee_id"`
+	EmployeeName   string         `gorm:"type:varchar(100);not null" json:"employee_name"`
+	Hours          float64        `gorm:"not null" json:"hours"`
+	GrossPay       float64        `gorm:"not null" json:"gross_pay"`
+	Advances       float64        `gorm:"not null" json:"advances"`
+	Deductions     float64        `gorm:"not null" json:"deductions"`
+	NetPay         float64        `gorm:"not null" json:"net_pay"`
+	CarriedForward float64        `gorm:"not null" json:"carried_forward"` // owed by the employee into the next run
+	UserID         uint           `gorm:"not null" json:"user_id"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// EmployeeStatement represents the earnings, advances, deductions and balance of a worker
+type EmployeeStatement struct {
+	Employee        *Employee             `json:"employee"`
+	TotalEarned     float64               `json:"total_earned"`
+	TotalAdvances   float64               `json:"total_advances"`
+	TotalDeductions float64               `json:"total_deductions"`
+	TotalPaid       float64               `json:"total_paid"`
+	Balance         float64               `json:"balance"` // outstanding advances and deductions still to be recovered
+	Adjustments     []*EmployeeAdjustment `json:"adjustments"`
+	Payslips        []*PayrollLine        `json:"payslips"`
+}
+
+// OrganizationSettings represents organization-wide preferences used by analytics and invoicing
+type OrganizationSettings struct {
+	gorm.Model
+	FiscalYearStartMonth int                `gorm:"not null;default:1" json:"fiscal_year_start_month"` // 1 = January
+	DefaultCurrency      string             `gorm:"type:varchar(3);not null;default:'UGX'" json:"default_currency"`
+	DefaultUnits         map[string]string  `gorm:"type:jsonb;serializer:json" json:"default_units"` // mineral type -> unit
+	InvoiceNumberFormat  string             `gorm:"type:varchar(50);not null;default:'INV-{YYYY}-{SEQ:4}'" json:"invoice_number_format"`
+	NextInvoiceNumber    int                `gorm:"not null;default:1" json:"next_invoice_number"`
+	ReceiptNumberFormat  string             `gorm:"type:varchar(50);not null;default:'RCT-{YYYY}-{SEQ:4}'" json:"receipt_number_format"`
+	NextReceiptNumber    int                `gorm:"not null;default:1" json:"next_receipt_number"`
+	CreditNoteFormat     string             `gorm:"type:varchar(50);not null;default:'CN-{YYYY}-{SEQ:4}'" json:"credit_note_number_format"`
+	NextCreditNoteNumber int                `gorm:"not null;default:1" json:"next_credit_note_number"`
+	CalendarToken        *string            `gorm:"type:varchar(64);uniqueIndex" json:"-"` // secret of the calendar feed URL
+	CreditLimitMode      CreditLimitMode    `gorm:"type:varchar(10);not null;default:'warn'" json:"credit_limit_mode"`
+	ShareBenchmarkData   bool               `gorm:"not null;default:false" json:"share_benchmark_data"`        // consent to include sales in anonymous price benchmarks
+	AttachmentQuotaMB    *int               `json:"attachment_quota_mb,omitempty"`                             // set by admins; nil uses the plan's storage limit
+	RoyaltyRates         map[string]float64 `gorm:"type:jsonb;serializer:json" json:"royalty_rates,omitempty"` // mineral type -> percent of sale value, for royalty estimates
+	BackdateApprovalDays int                `gorm:"not null;default:0" json:"backdate_approval_days"`          // sales and expenses dated further back need approval; 0 turns it off
+	SignOffAmount        float64            `gorm:"not null;default:0" json:"sign_off_amount"`                 // expenses above it need two sign-offs before they are paid; 0 turns it off
+	PriceVariancePercent float64            `gorm:"not null;default:10" json:"price_variance_percent"`         // supplies bought further above the list price are flagged; 0 turns it off
+	RainySeasonMonths    []int              `gorm:"type:jsonb;serializer:json" json:"rainy_season_months"`     // 1 = January; DefaultRainySeasonMonths when empty
+	UserID               uint               `gorm:"not null;uniqueIndex" json:"user_id"`
+	CreatedAt            time.Time          `json:"created_at"`
+	UpdatedAt            time.Time          `json:"updated_at"`
+	DeletedAt            gorm.DeletedAt     `gorm:"index" json:"-"`
+}
+
+// DocumentKind represents a kind of financial document numbered in its own sequence
+type DocumentKind string
+
+const (
+	DocumentInvoice       DocumentKind = "invoice"
+	DocumentReceipt       DocumentKind = "receipt"
+	DocumentCreditNote    DocumentKind = "credit_note"
+	DocumentPurchaseOrder DocumentKind = "purchase_order"
+)
+
+// DocumentSequence represents the gapless numbering of an organization's documents of a kind.
+// A row is created, starting at the next number in settings, when the first document of the kind
+// is numbered. Numbers are taken with the row locked, in the transaction that stores the document,
+// so a number is never skipped or reused.
+type DocumentSequence struct {
+	ID         uint         `gorm:"primarykey" json:"id"`
+	Kind       DocumentKind `gorm:"type:varchar(20);not null;uniqueIndex:idx_sequence_user_kind" json:"kind"`
+	NextNumber int          `gorm:"not null" json:"next_number"`
+	UserID     uint         `gorm:"not null;uniqueIndex:idx_sequence_user_kind" json:"user_id"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+// Fields of documents that templates can hide
+const (
+	TemplateFieldInvoiceNumber = "invoice_number" // receipts
+	TemplateFieldDescription   = "description"    // receipts
+	TemplateFieldBalanceDue    = "balance_due"    // receipts
+	TemplateFieldVerifyLink    = "verify_link"    // receipts
+	TemplateFieldPricePerUnit  = "price_per_unit" // invoices
+	TemplateFieldAmountPaid    = "amount_paid"    // invoices
+	TemplateFieldPaymentStatus = "payment_status" // invoices
+	TemplateFieldSeal          = "seal"           // invoices and receipts
+)
+
+// TemplateFields lists the fields each kind of document template can hide
+var TemplateFields = map[DocumentKind][]string{
+	DocumentInvoice: {TemplateFieldPricePerUnit, TemplateFieldAmountPaid, TemplateFieldPaymentStatus, TemplateFieldSeal},
+	DocumentReceipt: {TemplateFieldInvoiceNumber, TemplateFieldDescription, TemplateFieldBalanceDue, TemplateFieldVerifyLink, TemplateFieldSeal},
+}
+
+// DocumentTemplate represents how an organization's invoice or receipt PDFs look: their
+// language, logo, footer and the fields left out
+type DocumentTemplate struct {
+	gorm.Model
+	Kind         DocumentKind   `gorm:"type:varchar(20);not null;uniqueIndex:idx_template_user_kind" json:"kind"` // invoice (also statements) or receipt
+	Language     string         `gorm:"type:varchar(5);not null;default:'en'" json:"language"`
+	FooterText   *string        `gorm:"type:text" json:"footer_text,omitempty"`
+	HiddenFields []string       `gorm:"type:jsonb;serializer:json" json:"hidden_fields"`
+	LogoKey      *string        `gorm:"type:varchar(255)" json:"-"` // storage key of the logo, a JPEG
+	LogoWidth    int            `gorm:"not null;default:0" json:"-"`
+	LogoHeight   int            `gorm:"not null;default:0" json:"-"`
+	HasLogo      bool           `gorm:"-" json:"has_logo"`
+	UserID       uint           `gorm:"not null;uniqueIndex:idx_template_user_kind" json:"user_id"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// PeriodSummary represents income, expenses and profit for a reporting period
+type PeriodSummary struct {
+	Label        string    `json:"label"`
+	StartDate    time.Time `json:"start_date"`
+	EndDate      time.Time `json:"end_date"` // inclusive
+	Income       float64   `json:"income"`
+	Expenses     float64   `json:"expenses"`
+	NetProfit    float64   `json:"net_profit"`
+	ProfitMargin float64   `json:"profit_margin"`
+}
+
+// FiscalYearReport represents a fiscal year broken down by quarter and month
+type FiscalYearReport struct {
+	Summary  *PeriodSummary   `json:"summary"`
+	Quarters []*PeriodSummary `json:"quarters"`
+	Months   []*MonthlyData   `json:"months"`
+}
+
+// FiscalYTDSummary represents fiscal year-to-date results against the same period of the prior fiscal year
+type FiscalYTDSummary struct {
+	Current   *PeriodSummary `json:"current"`
+	PriorYear *PeriodSummary `json:"prior_year"`
+}
+
+// CostAllocationMethod represents how a cost allocation rule splits shared expenses across sites
+type CostAllocationMethod string
+
+const (
+	AllocateByPercentage CostAllocationMethod = "percentage" // a fixed percent to each site
+	AllocateByProduction CostAllocationMethod = "production" // each site's share of the mineral sales of the period
+)
+
+// CostAllocationShare is a site a cost allocation rule splits expenses to, with its percent for
+// percentage rules
+type CostAllocationShare struct {
+	MineSiteID uint    `json:"mine_site_id"`
+	Percent    float64 `json:"percent,omitempty"`
+}
+
+// CostAllocationRule splits the expenses shared by mine sites, those not assigned to a site, across
+// sites in pit profitability reports. A rule applies to the shared expenses of its category, or to
+// those of every category without a rule of its own when it has none.
+type CostAllocationRule struct {
+	gorm.Model
+	Name      string                `gorm:"type:varchar(100);not null" json:"name"`
+	Category  *ExpenseCategory      `gorm:"type:varchar(50)" json:"category,omitempty"`
+	Method    CostAllocationMethod  `gorm:"type:varchar(20);not null" json:"method"`
+	Shares    []CostAllocationShare `gorm:"type:jsonb;serializer:json" json:"shares"`
+	UserID    uint                  `gorm:"not null;index" json:"user_id"`
+	CreatedAt time.Time             `json:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at"`
+	DeletedAt gorm.DeletedAt        `gorm:"index" json:"-"`
+}
+
+// SiteIncome represents the income of a mine site in a period, or of the books outside any site
+// when MineSiteID is nil
+type SiteIncome struct {
+	MineSiteID   *uint   `json:"mine_site_id"`
+	Income       float64 `json:"income"`
+	MineralSales float64 `json:"mineral_sales"`
+}
+
+// SiteExpense represents the expenses of a category recognized in a period at a mine site, or
+// shared by the sites when MineSiteID is nil
+type SiteExpense struct {
+	MineSiteID *uint           `json:"mine_site_id"`
+	Category   ExpenseCategory `json:"category"`
+	Amount     float64         `json:"amount"`
+}
+
+// SiteProfit represents the profitability of a mine site in a period, with its part of the shared
+// expenses
+type SiteProfit struct {
+	MineSiteID     uint    `json:"mine_site_id"`
+	Name           *string `json:"name,omitempty"`
+	Income         float64 `json:"income"`
+	MineralSales   float64 `json:"mineral_sales"`
+	DirectExpenses float64 `json:"direct_expenses"`
+	SharedExpenses float64 `json:"shared_expenses"` // allocated by the cost allocation rules
+	Profit         float64 `json:"profit"`
+}
+
+// SiteProfitability represents the profitability of each mine site in a period. Shared expenses no
+// rule applies to, or split by production when none of the rule's sites sold minerals, are left
+// unallocated.
+type SiteProfitability struct {
+	StartDate        string        `json:"start_date"`
+	EndDate          string        `json:"end_date"` // inclusive
+	Sites            []*SiteProfit `json:"sites"`
+	UnassignedIncome float64       `json:"unassigned_income"` // sales not assigned to a site
+	Unallocated      float64       `json:"unallocated"`
+}
+
+// PitProfit represents the profitability of a pit (mine site) in a period, with its part of the
+// shared expenses
+type PitProfit struct {
+	MineSiteID     uint    `json:"mine_site_id"`
+	Name           *string `json:"name,omitempty"`
+	Revenue        float64 `json:"revenue"`
+	MineralSales   float64 `json:"mineral_sales"`
+	DirectCosts    float64 `json:"direct_costs"`
+	AllocatedCosts float64 `json:"allocated_costs"` // shared expenses split by the cost allocation rules
+	Profit         float64 `json:"profit"`
+	Margin         float64 `json:"margin"` // profit as a percent of revenue
+}
+
+// PitProfitability represents the profitability of each pit (mine site) in a period. Shared
+// expenses no rule applies to, or split by production when none of the rule's sites sold
+// minerals, are left unallocated.
+type PitProfitability struct {
+	Period            string       `json:"period"` // preset period, or custom
+	StartDate         string       `json:"start_date"`
+	EndDate           string       `json:"end_date"` // inclusive
+	Pits              []*PitProfit `json:"pits"`
+	UnassignedRevenue float64      `json:"unassigned_revenue"` // sales not assigned to a site
+	Unallocated       float64      `json:"unallocated"`
+}
+
+// OrganizationRole represents the role of a member within an organization
+type OrganizationRole string
+
+const (
+	OrgRoleOwner      OrganizationRole = "owner"
+	OrgRoleManager    OrganizationRole = "manager"
+	OrgRoleAccountant OrganizationRole = "accountant"
+	OrgRoleClerk      OrganizationRole = "clerk"
+	OrgRoleAuditor    OrganizationRole = "auditor" // reads records, exports and reports but cannot change anything
+	OrgRoleViewer     OrganizationRole = "viewer"  // reads records but cannot change or export anything
+)
+
+// Permission is something a member of an organization may do with its books. Owners hold every
+// permission; what other roles hold is stored per organization, falling back to
+// DefaultRolePermissions.
+type Permission string
+
+const (
+	PermIncomeCreate    Permission = "income.create"
+	PermIncomeUpdate    Permission = "income.update"
+	PermIncomeDelete    Permission = "income.delete"
+	PermIncomeApprove   Permission = "income.approve" // review sales to flagged customers and backdated sales
+	PermExpenseCreate   Permission = "expense.create"
+	PermExpenseUpdate   Permission = "expense.update"
+	PermExpenseDelete   Permission = "expense.delete"
+	PermExpenseApprove  Permission = "expense.approve" // review backdated expenses
+	PermPayrollApprove  Permission = "payroll.approve" // employees, their pay adjustments, timesheet approval and payroll runs
+	PermInventoryCreate Permission = "inventory.create"
+	PermInventoryUpdate Permission = "inventory.update" // including stock usage and adjustments
+	PermInventoryDelete Permission = "inventory.delete"
+	PermPurchaseCreate  Permission = "purchase.create"
+	PermPurchaseUpdate  Permission = "purchase.update"
+	PermPurchaseDelete  Permission = "purchase.delete"
+	PermPriceManage     Permission = "price.manage"   // market prices for purchases and the supply price list
+	PermPriceOverride   Permission = "price.override" // pricing purchases differently from the calculated price
+	PermCashManage      Permission = "cash.manage"    // opening and closing cash days
+	PermTillManage      Permission = "till.manage"    // tills and the clerks assigned to them
+	PermPaymentRecord   Permission = "payment.record"
+	PermRiskManage      Permission = "risk.manage" // customer and supplier flags and credit limits
+	PermSettingsManage  Permission = "settings.manage"
+	PermWebhookManage   Permission = "webhook.manage"
+	PermSMSSend         Permission = "sms.send"
+	PermAuditView       Permission = "audit.view" // the audit log and event streams
+)
+
+// AllPermissions lists every permission
+var AllPermissions = []Permission{
+	PermIncomeCreate, PermIncomeUpdate, PermIncomeDelete, PermIncomeApprove,
+	PermExpenseCreate, PermExpenseUpdate, PermExpenseDelete, PermExpenseApprove, PermPayrollApprove,
+	PermInventoryCreate, PermInventoryUpdate, PermInventoryDelete,
+	PermPurchaseCreate, PermPurchaseUpdate, PermPurchaseDelete, PermPriceManage, PermPriceOverride,
+	PermCashManage, PermTillManage, PermPaymentRecord, PermRiskManage,
+	PermSettingsManage, PermWebhookManage, PermSMSSend, PermAuditView,
+}
+
+// DefaultRolePermissions are the permissions of the roles an organization hasn't customized.
+// Auditors and viewers only read, so they hold at most read permissions.
+var DefaultRolePermissions = map[OrganizationRole][]Permission{
+	OrgRoleManager: AllPermissions,
+	OrgRoleAccountant: {
+		PermIncomeCreate, PermIncomeUpdate, PermIncomeDelete,
+		PermExpenseCreate, PermExpenseUpdate, PermExpenseDelete, PermPayrollApprove,
+		PermPurchaseCreate, PermPurchaseUpdate, PermPurchaseDelete, PermPriceManage, PermPriceOverride,
+		PermCashManage, PermPaymentRecord, PermRiskManage, PermAuditView,
+	},
+	OrgRoleClerk: {
+		PermIncomeCreate, PermIncomeUpdate,
+		PermExpenseCreate, PermExpenseUpdate,
+		PermInventoryCreate, PermInventoryUpdate,
+		PermPurchaseCreate, PermPaymentRecord, PermCashManage,
+	},
+	OrgRoleAuditor: {PermAuditView},
+	OrgRoleViewer:  {},
+}
+
+// OrganizationRolePermission grants a permission to a role in an organization. Roles without
+// any grants have their default permissions.
+type OrganizationRolePermission struct {
+	ID             uint             `gorm:"primarykey" json:"id"`
+	OrganizationID uint             `gorm:"not null;uniqueIndex:idx_org_role_permission" json:"organization_id"`
+	Role           OrganizationRole `gorm:"type:varchar(20);not null;uniqueIndex:idx_org_role_permission" json:"role"`
+	Permission     Permission       `gorm:"type:varchar(50);not null;uniqueIndex:idx_org_role_permission" json:"permission"`
+	CreatedAt      time.Time        `json:"created_at"`
+}
+
+// RolePermissions are the permissions of a role in an organization
+type RolePermissions struct {
+	Role        OrganizationRole `json:"role"`
+	Permissions []Permission     `json:"permissions"`
+	Customized  bool             `json:"customized"` // false when the role has its default permissions
+}
+
+// Organization represents a mine's books shared with other users. Records stay keyed by
+// the owner's user ID, so members acting in the organization work on the owner's data.
+type Organization struct {
+	gorm.Model
+	Name      string               `gorm:"type:varchar(255);not null" json:"name"`
+	OwnerID   uint                 `gorm:"not null;uniqueIndex" json:"owner_id"`
+	Members   []OrganizationMember `gorm:"foreignKey:OrganizationID" json:"members,omitempty"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+	DeletedAt gorm.DeletedAt       `gorm:"index" json:"-"`
+}
+
+// OrganizationMember represents a user's membership of an organization
+type OrganizationMember struct {
+	gorm.Model
+	OrganizationID uint             `gorm:"not null;uniqueIndex:idx_organization_members_org_user" json:"organization_id"`
+	Organization   *Organization    `gorm:"foreignKey:OrganizationID" json:"organization,omitempty"`
+	UserID         uint             `gorm:"not null;uniqueIndex:idx_organization_members_org_user;index" json:"user_id"`
+	User           *User            `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Role           OrganizationRole `gorm:"type:varchar(20);not null" json:"role"`
+	CanExport      bool             `gorm:"not null;default:false" json:"can_export"` // owners can always export
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt   `gorm:"index" json:"-"`
+}
+
+// OrganizationIPRule represents an address range members with a role may connect from.
+// Roles without rules are unrestricted.
+type OrganizationIPRule struct {
+	gorm.Model
+	OrganizationID uint             `gorm:"not null;index" json:"organization_id"`
+	Role           OrganizationRole `gorm:"type:varchar(20);not null" json:"role"`
+	CIDR           string           `gorm:"type:varchar(50);not null" json:"cidr"`
+	Description    *string          `gorm:"type:varchar(255)" json:"description,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt   `gorm:"index" json:"-"`
+}
+
+// OrganizationInvitation invites someone by email to join an organization with a role. It is
+// accepted with its token, by the invitee signed in with their own account.
+type OrganizationInvitation struct {
+	gorm.Model
+	OrganizationID uint             `gorm:"not null;index" json:"organization_id"`
+	Organization   *Organization    `gorm:"foreignKey:OrganizationID" json:"organization,omitempty"`
+	Email          string           `gorm:"type:varchar(255);not null;index" json:"email"`
+	Role           OrganizationRole `gorm:"type:varchar(20);not null" json:"role"`
+	CanExport      bool             `gorm:"not null;default:false" json:"can_export"`
+	TokenHash      string           `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	InvitedByID    uint             `gorm:"not null" json:"invited_by_id"`
+	ExpiresAt      time.Time        `gorm:"not null" json:"expires_at"`
+	AcceptedAt     *time.Time       `json:"accepted_at,omitempty"`
+	AcceptedByID   *uint            `json:"accepted_by_id,omitempty"`
+	DeliveryID     *uint            `json:"delivery_id,omitempty"` // email of the invitation
+}
+
+// AuditAction represents an action recorded in the audit log
+type AuditAction string
+
+const (
+	AuditExport           AuditAction = "export"
+	AuditDisputeOpened    AuditAction = "dispute_opened"
+	AuditDisputeProposed  AuditAction = "dispute_proposed"
+	AuditDisputeResolved  AuditAction = "dispute_resolved"
+	AuditDisputeWithdrawn AuditAction = "dispute_withdrawn"
+	AuditIncomeCreated    AuditAction = "income.created"
+	AuditPaymentRecorded  AuditAction = "payment.recorded"
+	AuditShiftHandedOver  AuditAction = "shift.handed_over"
+	AuditShiftSignedOff   AuditAction = "shift.signed_off"
+	AuditBackdatedEntry   AuditAction = "backdated_entry"
+	AuditAssetDisposed    AuditAction = "asset.disposed"
+	AuditStockWrittenOff  AuditAction = "stock.written_off"
+	AuditPriceVariance    AuditAction = "price_variance"
+	AuditRecordRestored   AuditAction = "record.restored"
+	AuditRecordPurged     AuditAction = "record.purged" // permanently deleted from the trash
+)
+
+// AuditLog represents an auditable action performed on an organization's books
+type AuditLog struct {
+	gorm.Model
+	Action     AuditAction    `gorm:"type:varchar(50);not null;index" json:"action"`
+	Resource   string         `gorm:"type:varchar(50);not null" json:"resource"`
+	ResourceID *uint          `json:"resource_id,omitempty"`
+	Details    *string        `gorm:"type:text" json:"details,omitempty"`
+	RowCount   *int           `json:"row_count,omitempty"`
+	ActorID    uint           `gorm:"not null;index" json:"actor_id"` // user who performed the action
+	IPAddress  string         `gorm:"type:varchar(45)" json:"ip_address"`
+	UserID     uint           `gorm:"not null;index" json:"user_id"` // owner of the books
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// JobStatus represents the state of a background job
+type JobStatus string
+
+const (
+	JobPending JobStatus = "pending"
+	JobRunning JobStatus = "running"
+	JobDone    JobStatus = "done"
+	JobFailed  JobStatus = "failed"
+)
+
+// Job represents a unit of background work in the database-backed job queue
+type Job struct {
+	gorm.Model
+	Type        string         `gorm:"type:varchar(50);not null;index" json:"type"`
+	Payload     string         `gorm:"type:text;not null" json:"-"` // JSON encoded
+	Status      JobStatus      `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	Attempts    int            `gorm:"not null;default:0" json:"attempts"`
+	MaxAttempts int            `gorm:"not null;default:5" json:"max_attempts"`
+	RunAt       time.Time      `gorm:"not null;index" json:"run_at"`
+	LastError   *string        `gorm:"type:text" json:"last_error,omitempty"`
+	CompletedAt *time.Time     `json:"completed_at,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// DeliveryChannel represents how a message was delivered
+type DeliveryChannel string
+
+const (
+	DeliveryEmail DeliveryChannel = "email"
+	DeliverySMS   DeliveryChannel = "sms"
+)
+
+// DeliveryStatus represents the delivery state of an outgoing message
+type DeliveryStatus string
+
+const (
+	DeliveryQueued DeliveryStatus = "queued"
+	DeliverySent   DeliveryStatus = "sent"
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// MessageDelivery records an outgoing email or SMS so support can trace whether it arrived
+type MessageDelivery struct {
+	gorm.Model
+	Purpose   string           `gorm:"type:varchar(50);not null;index" json:"purpose"`
+	Recipient string           `gorm:"type:varchar(100);not null;index" json:"recipient"` // email address, or phone number for SMS-only messages
+	Phone     *string          `gorm:"type:varchar(20)" json:"phone,omitempty"`
+	Channel   *DeliveryChannel `gorm:"type:varchar(20)" json:"channel,omitempty"` // channel that succeeded
+	Status    DeliveryStatus   `gorm:"type:varchar(20);not null;default:'queued'" json:"status"`
+	Attempts  int              `gorm:"not null;default:0" json:"attempts"`
+	LastError *string          `gorm:"type:text" json:"last_error,omitempty"`
+	SentAt    *time.Time       `json:"sent_at,omitempty"`
+	UserID    *uint            `gorm:"index" json:"user_id,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+	DeletedAt gorm.DeletedAt   `gorm:"index" json:"-"`
+}
+
+// InviteCode represents an admin-issued registration code granting a role at signup
+type InviteCode struct {
+	gorm.Model
+	Code        string         `gorm:"type:varchar(50);not null;uniqueIndex" json:"code"`
+	Role        UserRole       `gorm:"type:varchar(50);not null" json:"role"`
+	ExpiresAt   *time.Time     `json:"expires_at,omitempty"`
+	MaxUses     int            `gorm:"not null;default:0" json:"max_uses"` // 0 means unlimited
+	Uses        int            `gorm:"not null;default:0" json:"uses"`
+	Note        *string        `gorm:"type:varchar(255)" json:"note,omitempty"`
+	RevokedAt   *time.Time     `json:"revoked_at,omitempty"`
+	CreatedByID *uint          `json:"created_by_id,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// IdentityProvider represents an alternative way of signing in
+type IdentityProvider string
+
+const (
+	IdentityGoogle IdentityProvider = "google"
+	IdentityPhone  IdentityProvider = "phone"
+)
+
+// UserIdentity links a user account to a Google account or verified phone number
+type UserIdentity struct {
+	gorm.Model
+	UserID    uint             `gorm:"not null;index" json:"user_id"`
+	Provider  IdentityProvider `gorm:"type:varchar(20);not null;uniqueIndex:idx_identity_provider_subject" json:"provider"`
+	Subject   string           `gorm:"type:varchar(255);not null;uniqueIndex:idx_identity_provider_subject" json:"subject"` // Google account ID or phone number
+	Email     *string          `gorm:"type:varchar(100)" json:"email,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+	DeletedAt gorm.DeletedAt   `gorm:"index" json:"-"`
+}
+
+// RefreshToken is a long-lived token exchanged for new access tokens, so clients stay signed in
+// past the 24 hour lifetime of an access token. Only a hash of the token is stored. Each token
+// is used once: refreshing revokes it and issues its replacement.
+type RefreshToken struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	UserID       uint       `gorm:"not null;index" json:"user_id"`
+	TokenHash    string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	UserAgent    string     `gorm:"type:varchar(255)" json:"user_agent"`
+	ExpiresAt    time.Time  `gorm:"not null;index" json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	ReplacedByID *uint      `json:"replaced_by_id,omitempty"` // the token issued when this one was used
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// RevokedToken is an access token revoked before it expires, e.g. on logout, which is rejected
+// until then. Only a hash of the token is stored.
+type RevokedToken struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	TokenHash string    `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"` // when the token expires and no longer needs to be kept
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ShareLinkKind represents the document a public link shows
+type ShareLinkKind string
+
+const (
+	ShareInvoice   ShareLinkKind = "invoice"   // a single sale
+	ShareStatement ShareLinkKind = "statement" // all sales to a customer
+)
+
+// ShareLink is a signed public link that lets a customer view and confirm a document without an account
+type ShareLink struct {
+	gorm.Model
+	Kind         ShareLinkKind  `gorm:"type:varchar(20);not null" json:"kind"`
+	IncomeID     *uint          `gorm:"index" json:"income_id,omitempty"`
+	CustomerName *string        `gorm:"type:varchar(100)" json:"customer_name,omitempty"`
+	ExpiresAt    *time.Time     `json:"expires_at,omitempty"`
+	RevokedAt    *time.Time     `json:"revoked_at,omitempty"`
+	ViewCount    int            `gorm:"not null;default:0" json:"view_count"`
+	LastViewedAt *time.Time     `json:"last_viewed_at,omitempty"`
+	ConfirmedAt  *time.Time     `json:"confirmed_at,omitempty"`
+	ConfirmedBy  *string        `gorm:"type:varchar(100)" json:"confirmed_by,omitempty"`
+	URL          string         `gorm:"-" json:"url,omitempty"`
+	UserID       uint           `gorm:"not null;index" json:"user_id"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// ShareLinkView records a single view of a public link
+type ShareLinkView struct {
+	gorm.Model
+	ShareLinkID uint           `gorm:"not null;index" json:"share_link_id"`
+	IPAddress   string         `gorm:"type:varchar(45)" json:"ip_address"`
+	UserAgent   *string        `gorm:"type:varchar(255)" json:"user_agent,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// Receipt is a numbered receipt issued to a customer for a payment received against a sale
+type Receipt struct {
+	gorm.Model
+	ReceiptNumber   string         `gorm:"type:varchar(50);not null;uniqueIndex:idx_receipt_user_number" json:"receipt_number"`
+	IncomeID        uint           `gorm:"not null;index" json:"income_id"`
+	InvoiceNumber   *string        `gorm:"type:varchar(50)" json:"invoice_number,omitempty"`
+	CustomerName    string         `gorm:"type:varchar(100);not null" json:"customer_name"`
+	CustomerContact string         `gorm:"type:varchar(100)" json:"customer_contact"`
+	Description     string         `gorm:"type:varchar(255)" json:"description"`
+	Amount          float64        `gorm:"not null" json:"amount"`
+	BalanceDue      float64        `gorm:"not null;default:0" json:"balance_due"`
+	Currency        string         `gorm:"type:varchar(3);not null" json:"currency"`
+	PaidAt          time.Time      `gorm:"not null" json:"paid_at"`
+	SentAt          *time.Time     `json:"sent_at,omitempty"`
+	VerifyURL       string         `gorm:"-" json:"verify_url,omitempty"`
+	UserID          uint           `gorm:"not null;uniqueIndex:idx_receipt_user_number" json:"user_id"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// CreditNote represents a credit issued against a sale, reducing what the customer owes
+type CreditNote struct {
+	gorm.Model
+	CreditNoteNumber string         `gorm:"type:varchar(50);not null;uniqueIndex:idx_credit_note_user_number" json:"credit_note_number"`
+	IncomeID         uint           `gorm:"not null;index" json:"income_id"`
+	InvoiceNumber    *string        `gorm:"type:varchar(50)" json:"invoice_number,omitempty"`
+	CustomerName     string         `gorm:"type:varchar(100);not null" json:"customer_name"`
+	Amount           float64        `gorm:"not null" json:"amount"`
+	Reason           string         `gorm:"type:varchar(255);not null" json:"reason"`
+	Currency         string         `gorm:"type:varchar(3);not null" json:"currency"`
+	IssuedAt         time.Time      `gorm:"not null" json:"issued_at"`
+	IssuedByID       uint           `gorm:"not null" json:"issued_by_id"`
+	UserID           uint           `gorm:"not null;uniqueIndex:idx_credit_note_user_number" json:"user_id"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// DunningAction represents what a dunning step does
+type DunningAction string
+
+const (
+	DunningSMS   DunningAction = "sms"
+	DunningEmail DunningAction = "email"
+	DunningCall  DunningAction = "call" // reminds the user to call the customer
+)
+
+// DunningSchedule is a sequence of payment reminders for unpaid sales, either for one
+// customer or, without a customer name, for all customers without their own schedule
+type DunningSchedule struct {
+	gorm.Model
+	Name         string         `gorm:"type:varchar(100);not null" json:"name"`
+	CustomerName *string        `gorm:"type:varchar(100)" json:"customer_name,omitempty"`
+	Active       bool           `gorm:"not null;default:true" json:"active"`
+	Steps        []DunningStep  `gorm:"foreignKey:ScheduleID" json:"steps"`
+	UserID       uint           `gorm:"not null;index" json:"user_id"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// DunningStep is a reminder sent a number of days after the sale date
+type DunningStep struct {
+	gorm.Model
+	ScheduleID uint           `gorm:"not null;index" json:"schedule_id"`
+	DayOffset  int            `gorm:"not null" json:"day_offset"`
+	Action     DunningAction  `gorm:"type:varchar(20);not null" json:"action"`
+	Message    *string        `gorm:"type:text" json:"message,omitempty"` // template with {customer}, {invoice}, {amount}, {date} and {seller}
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// DunningEventStatus represents the outcome of a dunning step for an invoice
+type DunningEventStatus string
+
+const (
+	DunningEventPending DunningEventStatus = "pending"
+	DunningEventQueued  DunningEventStatus = "queued"  // message handed to the delivery queue
+	DunningEventCreated DunningEventStatus = "created" // call reminder created
+	DunningEventSkipped DunningEventStatus = "skipped"
+	DunningEventFailed  DunningEventStatus = "failed"
+)
+
+// DunningEvent records a dunning step executed for an invoice; each step runs once per invoice
+type DunningEvent struct {
+	gorm.Model
+	IncomeID   uint               `gorm:"not null;uniqueIndex:idx_dunning_income_step" json:"income_id"`
+	ScheduleID uint               `gorm:"not null" json:"schedule_id"`
+	StepID     uint               `gorm:"not null;uniqueIndex:idx_dunning_income_step" json:"step_id"`
+	DayOffset  int                `gorm:"not null" json:"day_offset"`
+	Action     DunningAction      `gorm:"type:varchar(20);not null" json:"action"`
+	Status     DunningEventStatus `gorm:"type:varchar(20);not null" json:"status"`
+	Recipient  *string            `gorm:"type:varchar(100)" json:"recipient,omitempty"`
+	Detail     *string            `gorm:"type:text" json:"detail,omitempty"`
+	DeliveryID *uint              `json:"delivery_id,omitempty"`
+	UserID     uint               `gorm:"not null;index" json:"user_id"`
+	CreatedAt  time.Time          `json:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt     `gorm:"index" json:"-"`
+}
+
+// TaskStatus represents the status of a task
+type TaskStatus string
+
+const (
+	TaskOpen TaskStatus = "open"
+	TaskDone TaskStatus = "done"
+)
+
+// TaskLinkType represents the kind of record a task is about
+type TaskLinkType string
+
+const (
+	TaskLinkIncome     TaskLinkType = "income"
+	TaskLinkExpense    TaskLinkType = "expense"
+	TaskLinkInventory  TaskLinkType = "inventory"
+	TaskLinkVehicle    TaskLinkType = "vehicle"
+	TaskLinkEmployee   TaskLinkType = "employee"
+	TaskLinkContractor TaskLinkType = "contractor"
+	TaskLinkMineSite   TaskLinkType = "mine_site"
+)
+
+// Task represents a to-do item, e.g. "collect payment from Musa" or "renew license"
+type Task struct {
+	gorm.Model
+	Title         string         `gorm:"type:varchar(255);not null" json:"title"`
+	Description   *string        `gorm:"type:text" json:"description,omitempty"`
+	DueDate       *time.Time     `gorm:"index" json:"due_date,omitempty"`
+	Status        TaskStatus     `gorm:"type:varchar(20);not null;default:'open';index" json:"status"`
+	AssigneeID    *uint          `gorm:"index" json:"assignee_id,omitempty"`
+	Assignee      *User          `gorm:"foreignKey:AssigneeID" json:"assignee,omitempty"`
+	LinkedType    *TaskLinkType  `gorm:"type:varchar(20)" json:"linked_type,omitempty"`
+	LinkedID      *uint          `json:"linked_id,omitempty"`
+	CompletedAt   *time.Time     `json:"completed_at,omitempty"`
+	CompletedByID *uint          `json:"completed_by_id,omitempty"`
+	CreatedByID   *uint          `json:"created_by_id,omitempty"`
+	UserID        uint           `gorm:"not null;index" json:"user_id"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TaskFilter narrows down a task list; zero values are ignored
+type TaskFilter struct {
+	Status     TaskStatus
+	AssigneeID uint
+	Overdue    bool
+	LinkedType TaskLinkType
+	LinkedID   uint
+}
+
+// EvidenceOperation represents an operation that can require photo evidence
+type EvidenceOperation string
+
+const (
+	EvidenceExpense         EvidenceOperation = "expense"          // recording or raising an expense
+	EvidenceStockAdjustment EvidenceOperation = "stock_adjustment" // setting an inventory quantity
+	EvidenceStockUsage      EvidenceOperation = "stock_usage"      // recording consumption of an item
+	EvidenceItemPhoto       EvidenceOperation = "item_photo"       // a photo of an inventory item, for browsing stock
+	EvidenceItemDocument    EvidenceOperation = "item_document"    // a spec sheet, manual or certificate of an inventory item
+)
+
+// EvidenceRule requires a photo for an operation, optionally only at or above an amount. The
+// amount is the expense amount for expenses and the quantity changed for stock operations.
+type EvidenceRule struct {
+	gorm.Model
+	Operation EvidenceOperation `gorm:"type:varchar(30);not null;uniqueIndex:idx_evidence_rules_user_operation" json:"operation"`
+	MinAmount *float64          `json:"min_amount,omitempty"` // omit to always require a photo
+	Active    bool              `gorm:"default:true" json:"active"`
+	UserID    uint              `gorm:"not null;uniqueIndex:idx_evidence_rules_user_operation" json:"user_id"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	DeletedAt gorm.DeletedAt    `gorm:"index" json:"-"`
+}
+
+// EvidenceRecordType represents the kind of record a photo is evidence for
+type EvidenceRecordType string
+
+const (
+	EvidenceRecordExpense       EvidenceRecordType = "expense"
+	EvidenceRecordInventoryItem EvidenceRecordType = "inventory_item"
+	EvidenceRecordStockMovement EvidenceRecordType = "stock_movement"
+)
+
+// EvidencePhoto represents a photo attached as evidence to a record, or a photo or document
+// attached to an inventory item. Evidence is saved before its record and linked once the record
+// exists.
+type EvidencePhoto struct {
+	gorm.Model
+	RecordType   *EvidenceRecordType `gorm:"type:varchar(30);index:idx_evidence_photos_record" json:"record_type,omitempty"`
+	RecordID     *uint               `gorm:"index:idx_evidence_photos_record" json:"record_id,omitempty"`
+	Operation    EvidenceOperation   `gorm:"type:varchar(30);not null" json:"operation"`
+	ContentType  string              `gorm:"type:varchar(50);not null" json:"content_type"`
+	Size         int                 `gorm:"not null" json:"size"`
+	FileName     *string             `gorm:"type:varchar(255)" json:"file_name,omitempty"`
+	Data         []byte              `gorm:"not null" json:"-"`
+	UploadedByID *uint               `json:"uploaded_by_id,omitempty"`
+	UserID       uint                `gorm:"not null;index" json:"user_id"`
+	CreatedAt    time.Time           `json:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt      `gorm:"index" json:"-"`
+}
+
+// AttachmentRecordType identifies the kind of record a file is attached to
+type AttachmentRecordType string
+
+const (
+	AttachmentRecordIncome       AttachmentRecordType = "income"
+	AttachmentRecordExpense      AttachmentRecordType = "expense"
+	AttachmentRecordDueDiligence AttachmentRecordType = "due_diligence"
+	AttachmentRecordAssay        AttachmentRecordType = "assay"
+	AttachmentRecordMiner        AttachmentRecordType = "miner"
+	AttachmentRecordClaim        AttachmentRecordType = "claim" // receipts of a reimbursement claim
+)
+
+// AttachmentKind describes what an attached file is
+type AttachmentKind string
+
+const (
+	AttachmentReceipt         AttachmentKind = "receipt"
+	AttachmentWeighbridgeSlip AttachmentKind = "weighbridge_slip"
+	AttachmentInvoice         AttachmentKind = "invoice"
+	AttachmentCertificate     AttachmentKind = "certificate" // e.g. an assay certificate
+	AttachmentIDDocument      AttachmentKind = "id_document" // scan of a miner's ID document
+	AttachmentPhoto           AttachmentKind = "photo"       // photo of a miner
+	AttachmentOther           AttachmentKind = "other"
+)
+
+// Attachment represents a file, such as a receipt or a weighbridge slip, attached to an income
+// or expense record. The file itself is kept in the attachment store under StorageKey.
+type Attachment struct {
+	ID           uint                 `gorm:"primarykey" json:"id"`
+	RecordType   AttachmentRecordType `gorm:"type:varchar(30);not null;index:idx_attachments_record" json:"record_type"`
+	RecordID     uint                 `gorm:"not null;index:idx_attachments_record" json:"record_id"`
+	Kind         AttachmentKind       `gorm:"type:varchar(30);not null" json:"kind"`
+	FileName     string               `gorm:"type:varchar(255);not null" json:"file_name"`
+	ContentType  string               `gorm:"type:varchar(50);not null" json:"content_type"`
+	Size         int64                `gorm:"not null" json:"size"`
+	StorageKey   string               `gorm:"type:varchar(255);not null;uniqueIndex" json:"-"`
+	UploadedByID *uint                `json:"uploaded_by_id,omitempty"`
+	UserID       uint                 `gorm:"not null;index" json:"user_id"`
+	CreatedAt    time.Time            `json:"created_at"`
+}
+
+// DueDiligenceCategory groups the risks of the OECD Due Diligence Guidance Annex II
+type DueDiligenceCategory string
+
+const (
+	DueDiligenceSeriousAbuses   DueDiligenceCategory = "serious_abuses"
+	DueDiligenceArmedGroups     DueDiligenceCategory = "armed_groups"
+	DueDiligenceSecurityForces  DueDiligenceCategory = "security_forces"
+	DueDiligenceBribery         DueDiligenceCategory = "bribery"
+	DueDiligenceMoneyLaundering DueDiligenceCategory = "money_laundering"
+	DueDiligenceTaxes           DueDiligenceCategory = "taxes"
+)
+
+// DueDiligenceQuestion is a question of the Annex II risk checklist. Questions are worded so
+// that answering "yes" raises a red flag.
+type DueDiligenceQuestion struct {
+	ID       string               `json:"id"`
+	Category DueDiligenceCategory `json:"category"`
+	Text     string               `json:"text"`
+}
+
+// DueDiligenceQuestions is the Annex II risk checklist in display order
+var DueDiligenceQuestions = []DueDiligenceQuestion{
+	{"forced_labour", DueDiligenceSeriousAbuses, "Is any forced or compulsory labour used at the site or on its transport routes?"},
+	{"child_labour", DueDiligenceSeriousAbuses, "Do children under 18 work at the site, underground, with mercury or in other hazardous work?"},
+	{"inhuman_treatment", DueDiligenceSeriousAbuses, "Has anyone at or around the site suffered torture or cruel, inhuman or degrading treatment?"},
+	{"human_rights_abuses", DueDiligenceSeriousAbuses, "Have sexual violence, war crimes or other gross human rights abuses been reported at or around the site?"},
+	{"armed_group_control", DueDiligenceArmedGroups, "Does a non-state armed group control the site, its access routes or the traders buying from it?"},
+	{"armed_group_payments", DueDiligenceArmedGroups, "Are money, minerals or fees given to a non-state armed group, directly or through intermediaries?"},
+	{"security_force_abuses", DueDiligenceSecurityForces, "Do public or private security forces illegally control the site, tax its miners or traders, or extort from them?"},
+	{"bribery", DueDiligenceBribery, "Have bribes been offered, asked for or paid for licences, permits or to hide the origin of minerals?"},
+	{"origin_misrepresentation", DueDiligenceBribery, "Have minerals from another source been mixed in or sold as production of this site?"},
+	{"money_laundering", DueDiligenceMoneyLaundering, "Is there reason to suspect that sales from the site, or their proceeds, are used to launder money?"},
+	{"unpaid_taxes", DueDiligenceTaxes, "Are any taxes, fees or royalties due to government for extraction, trade or export unpaid or undeclared?"},
+}
+
+// DueDiligenceAnswer represents the answer to a checklist question
+type DueDiligenceAnswer string
+
+const (
+	DueDiligenceYes           DueDiligenceAnswer = "yes" // a red flag
+	DueDiligenceNo            DueDiligenceAnswer = "no"
+	DueDiligenceNotApplicable DueDiligenceAnswer = "not_applicable"
+)
+
+// DueDiligenceStatus represents the state of a due-diligence self-assessment
+type DueDiligenceStatus string
+
+const (
+	DueDiligenceDraft     DueDiligenceStatus = "draft"
+	DueDiligenceCompleted DueDiligenceStatus = "completed"
+)
+
+// DueDiligenceAssessment represents a periodic self-assessment of a mine site against the
+// Annex II risk checklist, which exporters ask of their upstream suppliers
+type DueDiligenceAssessment struct {
+	gorm.Model
+	MineSiteID    uint                   `gorm:"not null;index" json:"mine_site_id"`
+	PeriodStart   time.Time              `gorm:"not null" json:"period_start"`
+	PeriodEnd     time.Time              `gorm:"not null" json:"period_end"`
+	Status        DueDiligenceStatus     `gorm:"type:varchar(20);not null;default:'draft'" json:"status"`
+	Notes         *string                `gorm:"type:text" json:"notes,omitempty"`
+	RedFlags      int                    `gorm:"not null;default:0" json:"red_flags"` // questions answered "yes"
+	Responses     []DueDiligenceResponse `gorm:"foreignKey:AssessmentID" json:"responses,omitempty"`
+	CompletedAt   *time.Time             `json:"completed_at,omitempty"`
+	CompletedByID *uint                  `json:"completed_by_id,omitempty"`
+	UserID        uint                   `gorm:"not null;index" json:"user_id"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt         `gorm:"index" json:"-"`
+}
+
+// DueDiligenceResponse represents the answer to one question of a due-diligence assessment
+type DueDiligenceResponse struct {
+	gorm.Model
+	AssessmentID uint               `gorm:"not null;index" json:"assessment_id"`
+	QuestionID   string             `gorm:"type:varchar(50);not null" json:"question_id"`
+	Answer       DueDiligenceAnswer `gorm:"type:varchar(20);not null" json:"answer"`
+	Notes        *string            `gorm:"type:text" json:"notes,omitempty"` // evidence, or the mitigation of a red flag
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt     `gorm:"index" json:"-"`
+}
+
+// LotSeal is the tamper-evident chain-of-custody seal of a lot sold in a sale. It keeps the
+// lineage of the lot and the sale as JSON with its SHA-256 digest and a server signature of the
+// digest, so any change to the lineage no longer matches and a digest can't be forged.
+type LotSeal struct {
+	ID              uint      `gorm:"primarykey" json:"id"`
+	IncomeID        uint      `gorm:"not null;uniqueIndex" json:"income_id"`
+	InventoryItemID uint      `gorm:"not null;index" json:"inventory_item_id"`
+	Lineage         string    `gorm:"type:text;not null" json:"-"` // JSON encoded SealedLineage
+	Digest          string    `gorm:"type:varchar(64);not null;uniqueIndex" json:"digest"`
+	Signature       string    `gorm:"type:varchar(64);not null" json:"signature"`
+	SealedByID      *uint     `json:"sealed_by_id,omitempty"`
+	UserID          uint      `gorm:"not null;index" json:"user_id"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// SealedLineage is the chain of custody of a lot up to its sale, as sealed
+type SealedLineage struct {
+	Seller    string           `json:"seller"`
+	Site      *SealedSite      `json:"site,omitempty"`
+	Lot       SealedLot        `json:"lot"`
+	Movements []SealedMovement `json:"movements"`
+	Sale      SealedSale       `json:"sale"`
+	SealedAt  time.Time        `json:"sealed_at"`
+}
+
+// SealedSite is the mine site a sealed lot comes from
+type SealedSite struct {
+	Name      *string  `json:"name,omitempty"`
+	License   *string  `json:"license,omitempty"`
+	Location  string   `json:"location"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
+// SealedLot is the origin of a sealed lot
+type SealedLot struct {
+	ID               uint              `json:"id"`
+	Name             string            `json:"name"`
+	BatchNumber      *string           `json:"batch_number,omitempty"`
+	From             *ProductionFrom   `json:"from,omitempty"`
+	PitNumber        *string           `json:"pit_number,omitempty"`
+	MinerName        *string           `json:"miner_name,omitempty"`
+	ProcessingMethod *ProcessingMethod `json:"processing_method,omitempty"`
+	Unit             string            `json:"unit"`
+	RecordedAt       time.Time         `json:"recorded_at"`
+}
+
+// SealedMovement is a recorded change to the quantity of a sealed lot
+type SealedMovement struct {
+	Date     time.Time         `json:"date"`
+	Type     StockMovementType `json:"type"`
+	Quantity float64           `json:"quantity"`
+	Reason   *string           `json:"reason,omitempty"`
+}
+
+// SealedSale is the sale a lot was sealed for
+type SealedSale struct {
+	IncomeID      uint        `json:"income_id"`
+	Date          time.Time   `json:"date"`
+	MineralType   MineralType `json:"mineral_type"`
+	Quantity      float64     `json:"quantity"`
+	Unit          string      `json:"unit"`
+	CustomerName  string      `json:"customer_name"`
+	InvoiceNumber *string     `json:"invoice_number,omitempty"`
+}
+
+// AssayGradeUnit is the unit an assay grade is measured in
+type AssayGradeUnit string
+
+const (
+	GradePercent       AssayGradeUnit = "percent" // purity or metal content, e.g. 92.5
+	GradeGramsPerTonne AssayGradeUnit = "g_per_t"
+)
+
+// Assay is a laboratory's grade result for a production batch (a mineral lot in inventory), a
+// sale, or both. Its certificate is kept as an attachment of the assay.
+type Assay struct {
+	gorm.Model
+	InventoryItemID   *uint          `gorm:"index" json:"inventory_item_id,omitempty"`
+	IncomeID          *uint          `gorm:"index" json:"income_id,omitempty"`
+	Laboratory        string         `gorm:"type:varchar(150);not null" json:"laboratory"`
+	AssayDate         time.Time      `gorm:"not null" json:"assay_date"`
+	MineralType       MineralType    `gorm:"type:varchar(50);not null" json:"mineral_type"`
+	Grade             float64        `gorm:"not null" json:"grade"`
+	GradeUnit         AssayGradeUnit `gorm:"type:varchar(20);not null" json:"grade_unit"`
+	CertificateNumber *string        `gorm:"type:varchar(100)" json:"certificate_number,omitempty"`
+	Notes             *string        `gorm:"type:text" json:"notes,omitempty"`
+	RecordedByID      *uint          `json:"recorded_by_id,omitempty"`
+	UserID            uint           `gorm:"not null;index" json:"user_id"`
+}
+
+// AssayFilter narrows the assays listed to a batch, a sale or a mineral
+type AssayFilter struct {
+	InventoryItemID *uint
+	IncomeID        *uint
+	MineralType     MineralType
+}
+
+// AssayedSale is a sale with the grade of its assay
+type AssayedSale struct {
+	IncomeID     uint           `json:"income_id"`
+	Date         time.Time      `json:"date"`
+	CustomerName string         `json:"customer_name"`
+	Quantity     float64        `json:"quantity"`
+	Unit         string         `json:"unit"`
+	PricePerUnit float64        `json:"price_per_unit"`
+	Grade        float64        `json:"grade"`
+	GradeUnit    AssayGradeUnit `json:"grade_unit"`
+	Laboratory   string         `json:"laboratory"`
+}
+
+// SMSCampaign represents a templated SMS blast to selected customers and suppliers
+type SMSCampaign struct {
+	gorm.Model
+	Message        string                 `gorm:"type:text;not null" json:"message"` // template with {name} and {seller} placeholders
+	OptOutLink     bool                   `gorm:"default:true" json:"opt_out_link"`
+	RecipientCount int                    `gorm:"not null;default:0" json:"recipient_count"`
+	QueuedCount    int                    `gorm:"not null;default:0" json:"queued_count"`
+	SkippedCount   int                    `gorm:"not null;default:0" json:"skipped_count"`
+	Recipients     []SMSCampaignRecipient `gorm:"foreignKey:CampaignID" json:"recipients,omitempty"`
+	CreatedByID    *uint                  `json:"created_by_id,omitempty"`
+	UserID         uint                   `gorm:"not null;index" json:"user_id"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt         `gorm:"index" json:"-"`
+}
+
+// SMSRecipientStatus represents whether a campaign message was queued or skipped
+type SMSRecipientStatus string
+
+const (
+	SMSRecipientQueued    SMSRecipientStatus = "queued"
+	SMSRecipientOptedOut  SMSRecipientStatus = "opted_out"
+	SMSRecipientInvalid   SMSRecipientStatus = "invalid_phone"
+	SMSRecipientDuplicate SMSRecipientStatus = "duplicate"
+)
+
+// SMSCampaignRecipient represents one recipient of an SMS campaign. The delivery records
+// whether a queued message was sent.
+type SMSCampaignRecipient struct {
+	gorm.Model
+	CampaignID uint               `gorm:"not null;index" json:"campaign_id"`
+	Name       string             `gorm:"type:varchar(100)" json:"name"`
+	Phone      string             `gorm:"type:varchar(20);not null" json:"phone"`
+	Body       string             `gorm:"type:text" json:"body,omitempty"`
+	Status     SMSRecipientStatus `gorm:"type:varchar(20);not null" json:"status"`
+	DeliveryID *uint              `json:"delivery_id,omitempty"`
+	Delivery   *MessageDelivery   `gorm:"foreignKey:DeliveryID" json:"delivery,omitempty"`
+	UserID     uint               `gorm:"not null;index" json:"user_id"`
+	CreatedAt  time.Time          `gorm:"index" json:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt     `gorm:"index" json:"-"`
+}
+
+// SMSOptOut represents a phone number that no longer receives SMS campaigns
+type SMSOptOut struct {
+	gorm.Model
+	Phone     string         `gorm:"type:varchar(20);not null;uniqueIndex:idx_sms_opt_outs_user_phone" json:"phone"`
+	Source    string         `gorm:"type:varchar(20);not null" json:"source"` // "manual" or "link"
+	Reason    *string        `gorm:"type:varchar(255)" json:"reason,omitempty"`
+	UserID    uint           `gorm:"not null;uniqueIndex:idx_sms_opt_outs_user_phone" json:"user_id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// SMSContact represents a customer or supplier phone number taken from past transactions
+type SMSContact struct {
+	Name     string `json:"name"`
+	Phone    string `json:"phone"`
+	Type     string `json:"type"` // "customer" or "supplier"
+	OptedOut bool   `json:"opted_out"`
+}
+
+// SMSUsage represents the SMS campaign messages sent in a month against the monthly quota
+type SMSUsage struct {
+	Month     string `json:"month"`
+	Used      int64  `json:"used"`
+	Quota     int64  `json:"quota"`
+	Remaining int64  `json:"remaining"`
+}
+
+// ContactType represents whether a contact is a customer or a supplier
+type ContactType string
+
+const (
+	ContactCustomer ContactType = "customer"
+	ContactSupplier ContactType = "supplier"
+)
+
+// Contact represents a customer or supplier in the contact book, unique per phone number
+type Contact struct {
+	gorm.Model
+	Name      string         `gorm:"type:varchar(100);not null" json:"name"`
+	Phone     string         `gorm:"type:varchar(20);not null;uniqueIndex:idx_contacts_user_phone" json:"phone"`
+	Email     *string        `gorm:"type:varchar(100)" json:"email,omitempty"`
+	Type      ContactType    `gorm:"type:varchar(20);not null;index" json:"type"`
+	Company   *string        `gorm:"type:varchar(100)" json:"company,omitempty"`
+	Notes     *string        `gorm:"type:text" json:"notes,omitempty"`
+	UserID    uint           `gorm:"not null;uniqueIndex:idx_contacts_user_phone" json:"user_id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// ContactImportResult summarizes a contact import. Contacts whose phone number is already in
+// the contact book are merged into the existing contact.
+type ContactImportResult struct {
+	Created int      `json:"created"`
+	Merged  int      `json:"merged"`
+	Skipped int      `json:"skipped"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// CreditLimitMode represents what happens when a sale pushes a customer past their credit limit
+type CreditLimitMode string
+
+const (
+	CreditLimitWarn  CreditLimitMode = "warn"  // the sale is saved with a warning
+	CreditLimitBlock CreditLimitMode = "block" // the sale is rejected
+)
+
+// CustomerCreditLimit represents the maximum outstanding balance allowed for a customer
+type CustomerCreditLimit struct {
+	gorm.Model
+	CustomerName string         `gorm:"type:varchar(100);not null;uniqueIndex:idx_credit_limits_user_customer" json:"customer_name"`
+	CreditLimit  float64        `gorm:"not null" json:"credit_limit"`
+	Outstanding  float64        `gorm:"-" json:"outstanding"` // current unpaid balance, filled in when listing limits
+	UserID       uint           `gorm:"not null;uniqueIndex:idx_credit_limits_user_customer" json:"user_id"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// CreditLimitWarning represents a sale that pushes a customer's outstanding balance past their credit limit
+type CreditLimitWarning struct {
+	CustomerName string  `json:"customer_name"`
+	CreditLimit  float64 `json:"credit_limit"`
+	Outstanding  float64 `json:"outstanding"` // unpaid balance before the sale
+	NewBalance   float64 `json:"new_balance"` // unpaid balance including the sale
+	Message      string  `json:"message"`
+}
+
+// RiskLevel represents how a customer or supplier is flagged
+type RiskLevel string
+
+const (
+	RiskHigh        RiskLevel = "high_risk"
+	RiskBlacklisted RiskLevel = "blacklisted"
+)
+
+// SaleApproval represents the manager approval state of a sale to a flagged customer, or of a
+// sale or expense dated further back than the organization's backdating limit
+type SaleApproval string
+
+const (
+	SalePendingApproval SaleApproval = "pending"
+	SaleApproved        SaleApproval = "approved"
+	SaleRejected        SaleApproval = "rejected"
+)
+
+// SignOffStatus represents the sign-off of an expense above the sign-off amount
+type SignOffStatus string
+
+const (
+	SignOffPending  SignOffStatus = "pending"
+	SignOffComplete SignOffStatus = "signed_off"
+)
+
+// RequiredSignOffs is how many distinct approvers must sign off an expense above the sign-off
+// amount before it can be paid
+const RequiredSignOffs = 2
+
+// ExpenseSignOff records an approver's sign-off of an expense above the sign-off amount. An
+// approver signs an expense off once; the sign-offs start over when its amount changes.
+type ExpenseSignOff struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	ExpenseID  uint      `gorm:"not null;uniqueIndex:idx_expense_sign_off" json:"expense_id"`
+	ApproverID uint      `gorm:"not null;uniqueIndex:idx_expense_sign_off" json:"approver_id"`
+	UserID     uint      `gorm:"not null;index" json:"user_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ExpenseAllocation is the part of a prepaid expense expensed in a month. The allocations of an
+// expense are generated from its amount and prepaid months and add up to its amount.
+type ExpenseAllocation struct {
+	ID        uint            `gorm:"primarykey" json:"id"`
+	ExpenseID uint            `gorm:"not null;index" json:"expense_id"`
+	Date      time.Time       `gorm:"not null;index:,composite:user_date,priority:2" json:"date"` // first day of the month
+	Category  ExpenseCategory `gorm:"type:varchar(50);not null" json:"category"`
+	Amount    float64         `gorm:"not null" json:"amount"`
+	UserID    uint            `gorm:"not null;index:,composite:user_date,priority:1" json:"user_id"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// AmortizationSchedule represents how a prepaid expense is expensed month by month
+type AmortizationSchedule struct {
+	ExpenseID   uint                 `json:"expense_id"`
+	Description string               `json:"description"`
+	Category    ExpenseCategory      `json:"category"`
+	Amount      float64              `json:"amount"`
+	Months      int                  `json:"months"`
+	Amortized   float64              `json:"amortized"` // expensed up to the current month
+	Remaining   float64              `json:"remaining"` // still prepaid
+	Allocations []*ExpenseAllocation `json:"allocations"`
+}
+
+// ClaimStatus represents the review of a reimbursement claim
+type ClaimStatus string
+
+const (
+	ClaimSubmitted ClaimStatus = "submitted"
+	ClaimApproved  ClaimStatus = "approved" // booked as an expense owed to the claimant
+	ClaimRejected  ClaimStatus = "rejected"
+)
+
+// ExpenseClaim represents an expense a staff member paid personally and claims back. An
+// approved claim is booked as an expense owed to the claimant, and the payouts reimbursing them
+// are the payments of that expense.
+type ExpenseClaim struct {
+	gorm.Model
+	ClaimantID      uint            `gorm:"not null;index" json:"claimant_id"` // member who paid
+	Date            time.Time       `gorm:"not null" json:"date"`
+	Category        ExpenseCategory `gorm:"type:varchar(50);not null" json:"category"`
+	Description     string          `gorm:"type:varchar(255);not null" json:"description"`
+	Amount          float64         `gorm:"not null" json:"amount"`
+	SupplierName    *string         `gorm:"type:varchar(100)" json:"supplier_name,omitempty"` // where the claimant paid
+	Notes           *string         `gorm:"type:text" json:"notes,omitempty"`
+	MineSiteID      *uint           `gorm:"index" json:"mine_site_id,omitempty"`
+	Status          ClaimStatus     `gorm:"type:varchar(20);not null;default:'submitted';index" json:"status"`
+	ReviewedByID    *uint           `json:"reviewed_by_id,omitempty"`
+	ReviewedAt      *time.Time      `json:"reviewed_at,omitempty"`
+	RejectionReason *string         `gorm:"type:varchar(255)" json:"rejection_reason,omitempty"`
+	ExpenseID       *uint           `gorm:"index" json:"expense_id,omitempty"`
+	Expense         *Expense        `gorm:"foreignKey:ExpenseID;-:migration" json:"expense,omitempty"` // with what has been reimbursed and what is still due; no foreign key, so expenses can be partitioned
+	UserID          uint            `gorm:"not null;index" json:"user_id"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt  `gorm:"index" json:"-"`
+}
+
+// ClaimantStatement represents the reimbursement claims of a staff member and the payouts made
+// to them, oldest first
+type ClaimantStatement struct {
+	ClaimantID   uint            `json:"claimant_id"`
+	ClaimantName string          `json:"claimant_name"`
+	Submitted    float64         `json:"submitted"`     // claimed and awaiting review
+	TotalClaimed float64         `json:"total_claimed"` // approved
+	TotalPaid    float64         `json:"total_paid"`
+	Balance      float64         `json:"balance"` // still owed to the claimant
+	Claims       []*ExpenseClaim `json:"claims"`
+	Payments     []*Payment      `json:"payments"`
+}
+
+// ProcurementStatus represents where a procurement request is on its way from a site to the store
+type ProcurementStatus string
+
+const (
+	ProcurementPending  ProcurementStatus = "pending"
+	ProcurementApproved ProcurementStatus = "approved" // waiting to be put on a purchase order
+	ProcurementRejected ProcurementStatus = "rejected"
+	ProcurementOrdered  ProcurementStatus = "ordered"
+	ProcurementReceived ProcurementStatus = "received"
+)
+
+// ProcurementRequest represents supplies a member of site staff asks to be bought. Approved
+// requests are put on purchase orders, and are received with them.
+type ProcurementRequest struct {
+	gorm.Model
+	RequesterID     uint              `gorm:"not null;index" json:"requester_id"` // member who asked
+	ItemName        string            `gorm:"type:varchar(100);not null" json:"item_name"`
+	Quantity        float64           `gorm:"not null" json:"quantity"`
+	Unit            string            `gorm:"type:varchar(20);not null" json:"unit"`
+	Justification   string            `gorm:"type:text;not null" json:"justification"`
+	InventoryItemID *uint             `gorm:"index" json:"inventory_item_id,omitempty"` // restocked when the order is received
+	NeededBy        *time.Time        `json:"needed_by,omitempty"`
+	MineSiteID      *uint             `gorm:"index" json:"mine_site_id,omitempty"`
+	Status          ProcurementStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	ReviewedByID    *uint             `json:"reviewed_by_id,omitempty"`
+	ReviewedAt      *time.Time        `json:"reviewed_at,omitempty"`
+	RejectionReason *string           `gorm:"type:varchar(255)" json:"rejection_reason,omitempty"`
+	PurchaseOrderID *uint             `gorm:"index" json:"purchase_order_id,omitempty"`
+	UnitPrice       *float64          `json:"unit_price,omitempty"` // agreed on the purchase order
+	UserID          uint              `gorm:"not null;index" json:"user_id"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt    `gorm:"index" json:"-"`
+}
+
+// PurchaseOrderStatus represents where a purchase order is with the supplier
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderOpen      PurchaseOrderStatus = "open"
+	PurchaseOrderReceived  PurchaseOrderStatus = "received" // booked as an expense
+	PurchaseOrderCancelled PurchaseOrderStatus = "cancelled"
+)
+
+// PurchaseOrder represents approved procurement requests ordered from a supplier. Receiving the
+// order books it as an expense and restocks the inventory items the requests are for.
+type PurchaseOrder struct {
+	gorm.Model
+	OrderNumber     string                `gorm:"type:varchar(50);index" json:"order_number"`
+	SupplierName    string                `gorm:"type:varchar(100);not null" json:"supplier_name"`
+	SupplierContact *string               `gorm:"type:varchar(100)" json:"supplier_contact,omitempty"`
+	Category        ExpenseCategory       `gorm:"type:varchar(50);not null" json:"category"`
+	OrderDate       time.Time             `gorm:"not null" json:"order_date"`
+	ExpectedDate    *time.Time            `json:"expected_date,omitempty"`
+	Status          PurchaseOrderStatus   `gorm:"type:varchar(20);not null;default:'open';index" json:"status"`
+	TotalAmount     float64               `gorm:"not null" json:"total_amount"`
+	Notes           *string               `gorm:"type:text" json:"notes,omitempty"`
+	Requests        []*ProcurementRequest `gorm:"foreignKey:PurchaseOrderID" json:"requests,omitempty"`
+	MineSiteID      *uint                 `gorm:"index" json:"mine_site_id,omitempty"`
+	CreatedByID     uint                  `gorm:"not null" json:"created_by_id"`
+	ReceivedAt      *time.Time            `json:"received_at,omitempty"`
+	ExpenseID       *uint                 `gorm:"index" json:"expense_id,omitempty"`
+	Expense         *Expense              `gorm:"foreignKey:ExpenseID;-:migration" json:"expense,omitempty"` // no foreign key, so expenses can be partitioned
+	UserID          uint                  `gorm:"not null;index" json:"user_id"`
+	CreatedAt       time.Time             `json:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt        `gorm:"index" json:"-"`
+}
+
+// CounterpartyFlag represents a customer or supplier flagged as high-risk or blacklisted.
+// Sales to flagged customers need the approval of an owner or manager.
+type CounterpartyFlag struct {
+	gorm.Model
+	Name        string         `gorm:"type:varchar(100);not null;uniqueIndex:idx_counterparty_flags_user_name" json:"name"`
+	Type        ContactType    `gorm:"type:varchar(20);not null;uniqueIndex:idx_counterparty_flags_user_name" json:"type"`
+	Level       RiskLevel      `gorm:"type:varchar(20);not null" json:"level"`
+	Reason      string         `gorm:"type:varchar(255);not null" json:"reason"`
+	FlaggedByID *uint          `json:"flagged_by_id,omitempty"`
+	UserID      uint           `gorm:"not null;uniqueIndex:idx_counterparty_flags_user_name" json:"user_id"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// PriceBenchmark represents anonymized selling prices of a mineral across organizations that
+// share benchmark data, in a region or across the platform
+type PriceBenchmark struct {
+	Region  string  `json:"region,omitempty"` // empty for the whole platform
+	Sellers int64   `json:"sellers"`
+	Sales   int64   `json:"sales"`
+	Average float64 `json:"average"`
+	Median  float64 `json:"median"`
+	P25     float64 `json:"p25"`
+	P75     float64 `json:"p75"`
+}
+
+// PriceComparison represents an organization's average selling price against the benchmarks
+type PriceComparison struct {
+	MineralType MineralType     `json:"mineral_type"`
+	Unit        string          `json:"unit"`
+	Since       time.Time       `json:"since"`
+	Regional    *PriceBenchmark `json:"regional,omitempty"` // omitted when too few sellers share data in the region
+	Platform    *PriceBenchmark `json:"platform,omitempty"` // omitted when too few sellers share data
+	YourAverage *float64        `json:"your_average,omitempty"`
+	YourSales   int64           `json:"your_sales"`
+	BelowMedian *bool           `json:"below_median,omitempty"` // against the regional median, or the platform median without one
+	DiffPercent *float64        `json:"diff_percent,omitempty"` // your average relative to that median
+	Message     string          `json:"message"`
+}
+
+// ReferenceOption represents a value clients can pick, with a label in the requested language
+type ReferenceOption struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+// ReferenceData represents the values of the pickers clients render
+type ReferenceData struct {
+	Language          string            `json:"language"`
+	Minerals          []ReferenceOption `json:"minerals"`
+	GemstoneTypes     []ReferenceOption `json:"gemstone_types"`
+	SalesTypes        []ReferenceOption `json:"sales_types"`
+	ExpenseCategories []ReferenceOption `json:"expense_categories"`
+	Units             []ReferenceOption `json:"units"`
+	PaymentStatuses   []ReferenceOption `json:"payment_statuses"`
+	ProductionSources []ReferenceOption `json:"production_sources"`
+	ProcessingMethods []ReferenceOption `json:"processing_methods"`
+}
+
+// FormRecordType represents a kind of record clients show an entry form for
+type FormRecordType string
+
+const (
+	FormIncome          FormRecordType = "income"
+	FormExpense         FormRecordType = "expense"
+	FormInventoryItem   FormRecordType = "inventory_item"
+	FormStockAdjustment FormRecordType = "stock_adjustment"
+	FormStockUsage      FormRecordType = "stock_usage"
+)
+
+// FormRecordTypes lists the record types with form metadata in display order
+var FormRecordTypes = []FormRecordType{FormIncome, FormExpense, FormInventoryItem, FormStockAdjustment, FormStockUsage}
+
+// FormFieldType represents how a client renders a form field
+type FormFieldType string
+
+const (
+	FormFieldText    FormFieldType = "text"
+	FormFieldNumber  FormFieldType = "number"
+	FormFieldDate    FormFieldType = "date" // YYYY-MM-DD
+	FormFieldSelect  FormFieldType = "select"
+	FormFieldBoolean FormFieldType = "boolean"
+	FormFieldPhone   FormFieldType = "phone"
+	FormFieldPhoto   FormFieldType = "photo"
+)
+
+// FormCondition holds when another field of the form has one of the values, or a number at or
+// above the minimum
+type FormCondition struct {
+	Field  string   `json:"field"`
+	Values []string `json:"values,omitempty"`
+	Min    *float64 `json:"min,omitempty"`
+}
+
+// FormField describes a field of an entry form
+type FormField struct {
+	Name         string            `json:"name"`
+	Label        string            `json:"label"`
+	Type         FormFieldType     `json:"type"`
+	Required     bool              `json:"required"`
+	RequiredWhen *FormCondition    `json:"required_when,omitempty"` // required only when the condition holds
+	VisibleWhen  *FormCondition    `json:"visible_when,omitempty"`  // hidden unless the condition holds
+	Options      []ReferenceOption `json:"options,omitempty"`       // choices of select fields
+	AllowOther   bool              `json:"allow_other,omitempty"`   // free text is accepted besides the options
+	Min          *float64          `json:"min,omitempty"`
+	Default      string            `json:"default,omitempty"`
+	DefaultFrom  string            `json:"default_from,omitempty"` // field whose value picks the default from Defaults
+	Defaults     map[string]string `json:"defaults,omitempty"`
+	Help         string            `json:"help,omitempty"`
+}
+
+// FormMetadata describes the entry form of a record type for a user's configuration
+type FormMetadata struct {
+	RecordType FormRecordType `json:"record_type"`
+	Language   string         `json:"language"`
+	Fields     []FormField    `json:"fields"`
+}
+
+// TradeStatus represents the buyer's response to a sale shared with them
+type TradeStatus string
+
+const (
+	TradePending  TradeStatus = "pending"
+	TradeAccepted TradeStatus = "accepted"
+	TradeDeclined TradeStatus = "declined"
+	TradeDisputed TradeStatus = "disputed" // accepted, with an open dispute
+	TradeVoided   TradeStatus = "voided"   // voided by resolving a dispute; both records are removed
+)
+
+// SharedSale represents a sale shared with a buyer who also uses the platform. The buyer sees
+// it as a pending purchase; accepting it records a linked expense for the buyer.
+type SharedSale struct {
+	gorm.Model
+	IncomeID      uint           `gorm:"not null;uniqueIndex" json:"income_id"`
+	SellerID      uint           `gorm:"not null;index" json:"seller_id"`
+	SellerName    string         `gorm:"type:varchar(100);not null" json:"seller_name"`
+	BuyerID       uint           `gorm:"not null;index" json:"buyer_id"`
+	Date          time.Time      `gorm:"not null" json:"date"`
+	MineralType   MineralType    `gorm:"type:varchar(50);not null" json:"mineral_type"`
+	Quantity      float64        `gorm:"not null" json:"quantity"`
+	Unit          string         `gorm:"type:varchar(20);not null" json:"unit"`
+	PricePerUnit  float64        `gorm:"not null" json:"price_per_unit"`
+	TotalAmount   float64        `gorm:"not null" json:"total_amount"`
+	Currency      string         `gorm:"type:varchar(3);not null" json:"currency"` // seller's currency
+	PaymentStatus PaymentStatus  `gorm:"type:varchar(20);not null" json:"payment_status"`
+	AmountPaid    float64        `gorm:"default:0" json:"amount_paid"`
+	Status        TradeStatus    `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	ExpenseID     *uint          `json:"expense_id,omitempty"` // buyer's expense once accepted
+	RespondedAt   *time.Time     `json:"responded_at,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TradeSide represents the party of a shared sale
+type TradeSide string
+
+const (
+	TradeSeller TradeSide = "seller"
+	TradeBuyer  TradeSide = "buyer"
+)
+
+// DisputeStatus represents the state of a dispute over a shared sale
+type DisputeStatus string
+
+const (
+	DisputeOpen      DisputeStatus = "open"
+	DisputeResolved  DisputeStatus = "resolved"
+	DisputeWithdrawn DisputeStatus = "withdrawn"
+)
+
+// DisputeAction represents a resolution of a dispute, applied to both sides' records
+type DisputeAction string
+
+const (
+	DisputeAdjustAmount DisputeAction = "adjust_amount" // change the total amount of the sale and purchase
+	DisputeVoid         DisputeAction = "void"          // remove the sale and purchase from both books
+)
+
+// DisputeEventKind represents an entry in the history of a dispute
+type DisputeEventKind string
+
+const (
+	DisputeEventOpened    DisputeEventKind = "opened"
+	DisputeEventMessage   DisputeEventKind = "message"
+	DisputeEventProposal  DisputeEventKind = "proposal"
+	DisputeEventAccepted  DisputeEventKind = "accepted"
+	DisputeEventWithdrawn DisputeEventKind = "withdrawn"
+)
+
+// TradeDispute represents a dispute between the seller and buyer of an accepted shared sale.
+// Either side proposes a resolution, which is applied once the other side accepts it.
+type TradeDispute struct {
+	gorm.Model
+	SharedSaleID   uint                `gorm:"not null;index" json:"shared_sale_id"`
+	OpenedBy       TradeSide           `gorm:"type:varchar(10);not null" json:"opened_by"`
+	Reason         string              `gorm:"type:text;not null" json:"reason"`
+	Status         DisputeStatus       `gorm:"type:varchar(20);not null;default:'open'" json:"status"`
+	ProposedAction *DisputeAction      `gorm:"type:varchar(20)" json:"proposed_action,omitempty"`
+	ProposedAmount *float64            `json:"proposed_amount,omitempty"` // new total amount for adjust_amount
+	ProposedBy     *TradeSide          `gorm:"type:varchar(10)" json:"proposed_by,omitempty"`
+	Resolution     *DisputeAction      `gorm:"type:varchar(20)" json:"resolution,omitempty"`
+	ResolvedAt     *time.Time          `json:"resolved_at,omitempty"`
+	Events         []TradeDisputeEvent `gorm:"foreignKey:DisputeID" json:"events,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt      `gorm:"index" json:"-"`
+}
+
+// TradeDisputeEvent represents a message, proposal or resolution in the history of a dispute
+type TradeDisputeEvent struct {
+	gorm.Model
+	DisputeID uint             `gorm:"not null;index" json:"dispute_id"`
+	Kind      DisputeEventKind `gorm:"type:varchar(20);not null" json:"kind"`
+	Side      TradeSide        `gorm:"type:varchar(10);not null" json:"side"`
+	Message   *string          `gorm:"type:text" json:"message,omitempty"`
+	Action    *DisputeAction   `gorm:"type:varchar(20)" json:"action,omitempty"`
+	Amount    *float64         `json:"amount,omitempty"`
+	ActorID   uint             `gorm:"not null" json:"actor_id"` // user who wrote the entry
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+	DeletedAt gorm.DeletedAt   `gorm:"index" json:"-"`
+}
+
+// Webhook represents an HTTPS endpoint that receives events of an organization's books
+type Webhook struct {
+	gorm.Model
+	URL       string         `gorm:"type:varchar(500);not null" json:"url"`
+	Events    []string       `gorm:"type:jsonb;serializer:json" json:"events"` // event names, e.g. income.created
+	Secret    string         `gorm:"type:varchar(64);not null" json:"-"`       // signs deliveries
+	Active    bool           `gorm:"default:true" json:"active"`
+	UserID    uint           `gorm:"not null;index" json:"user_id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// BackupStatus represents the state of a database backup
+type BackupStatus string
+
+const (
+	BackupRunning   BackupStatus = "running"
+	BackupCompleted BackupStatus = "completed"
+	BackupFailed    BackupStatus = "failed"
+)
+
+// Backup represents an encrypted database backup uploaded to off-site storage
+type Backup struct {
+	gorm.Model
+	Key         string         `gorm:"type:varchar(255);not null" json:"key"` // object key in the backup bucket
+	Status      BackupStatus   `gorm:"type:varchar(20);not null;index" json:"status"`
+	SizeBytes   int64          `json:"size_bytes"` // encrypted size
+	Error       *string        `gorm:"type:text" json:"error,omitempty"`
+	StartedAt   time.Time      `gorm:"not null" json:"started_at"`
+	CompletedAt *time.Time     `json:"completed_at,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// Plan represents a billing tier, which sets the usage limits of an organization's books
+type Plan string
+
+const (
+	PlanFree      Plan = "free"
+	PlanPro       Plan = "pro"
+	PlanUnlimited Plan = "unlimited"
+)
+
+// UsageMetric represents a metered resource
+type UsageMetric string
+
+const (
+	UsageRecords UsageMetric = "records" // sales, expenses, inventory items and trips created this month
+	UsageStorage UsageMetric = "storage" // photo evidence bytes stored
+	UsageSMS     UsageMetric = "sms"     // campaign messages sent this month
+)
+
+// PlanLimits represents the usage limits of a plan; zero means unlimited
+type PlanLimits struct {
+	RecordsPerMonth int64 `json:"records_per_month"`
+	StorageBytes    int64 `json:"storage_bytes"`
+	SMSPerMonth     int64 `json:"sms_per_month"`
+}
+
+// SubscriptionStatus represents the state of a subscription
+type SubscriptionStatus string
+
+const (
+	SubscriptionTrialing SubscriptionStatus = "trialing" // started on signup; the plan is kept until TrialEndsAt
+	SubscriptionExpired  SubscriptionStatus = "expired"  // trial ended without a subscription
+	SubscriptionActive   SubscriptionStatus = "active"
+	SubscriptionPastDue  SubscriptionStatus = "past_due" // payment failed; the plan is kept while the provider retries
+	SubscriptionCanceled SubscriptionStatus = "canceled"
+)
+
+// Subscription represents the plan of a user's books, set by an admin or activated through the
+// payment provider. Books without an active subscription are on the default plan.
+type Subscription struct {
+	gorm.Model
+	Plan                   Plan               `gorm:"type:varchar(20);not null" json:"plan"`
+	Status                 SubscriptionStatus `gorm:"type:varchar(20);not null;default:'active'" json:"status"`
+	ProviderSubscriptionID *string            `gorm:"type:varchar(100);index" json:"provider_subscription_id,omitempty"`
+	CurrentPeriodEnd       *time.Time         `json:"current_period_end,omitempty"`
+	TrialEndsAt            *time.Time         `gorm:"index" json:"trial_ends_at,omitempty"`
+	UpdatedByID            *uint              `json:"updated_by_id,omitempty"` // admin who set the plan
+	UserID                 uint               `gorm:"not null;uniqueIndex" json:"user_id"`
+	CreatedAt              time.Time          `json:"created_at"`
+	UpdatedAt              time.Time          `json:"updated_at"`
+	DeletedAt              gorm.DeletedAt     `gorm:"index" json:"-"`
+}
+
+// UsageCounts represents the metered usage of a user's books since the start of a month
+type UsageCounts struct {
+	UserID       uint  `json:"user_id"`
+	Records      int64 `json:"records"`
+	StorageBytes int64 `json:"storage_bytes"`
+	SMS          int64 `json:"sms"`
+}
+
+// AttachmentStorage represents the attachment storage used by a user's books against their soft
+// quota. Uploads may take storage past the quota, with a warning, up to the limit.
+type AttachmentStorage struct {
+	UsedBytes  int64 `json:"used_bytes"`
+	Files      int64 `json:"files"`
+	QuotaBytes int64 `json:"quota_bytes"` // 0 is unlimited
+	LimitBytes int64 `json:"limit_bytes"` // 0 is unlimited
+	OverQuota  bool  `json:"over_quota"`
+}
+
+// Usage represents the usage of a user's books this month against their plan limits
+type Usage struct {
+	UsageCounts
+	Month    string     `json:"month"`
+	Plan     Plan       `json:"plan"`
+	Limits   PlanLimits `json:"limits"`
+	Exceeded []string   `json:"exceeded,omitempty"` // metrics at or over their limit
+}
+
+// Feature represents a part of the platform that plans include or leave out
+type Feature string
+
+const (
+	FeatureReports  Feature = "reports"  // exports and fiscal reports
+	FeatureSMS      Feature = "sms"      // SMS campaigns and payment reminders
+	FeatureTeam     Feature = "team"     // organization members
+	FeatureWebhooks Feature = "webhooks" // webhook endpoints
+)
+
+// FeatureFlag represents an admin override that enables or disables a feature for a user's books
+// regardless of their plan
+type FeatureFlag struct {
+	gorm.Model
+	Feature     Feature        `gorm:"type:varchar(30);not null;uniqueIndex:idx_feature_flags_user_feature" json:"feature"`
+	Enabled     bool           `gorm:"not null" json:"enabled"`
+	UpdatedByID *uint          `json:"updated_by_id,omitempty"`
+	UserID      uint           `gorm:"not null;uniqueIndex:idx_feature_flags_user_feature" json:"user_id"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// PlanInfo represents a plan offered to organizations
+type PlanInfo struct {
+	Plan     Plan       `json:"plan"`
+	Limits   PlanLimits `json:"limits"`
+	Features []Feature  `json:"features"`
+}
+
+// SubscriptionInfo represents the plan of a user's books with the features it enables
+type SubscriptionInfo struct {
+	Plan         Plan           `json:"plan"`
+	Subscription *Subscription  `json:"subscription,omitempty"` // nil on the default plan
+	Limits       PlanLimits     `json:"limits"`
+	Features     []Feature      `json:"features"`  // enabled by the plan and feature flags
+	ReadOnly     bool           `json:"read_only"` // trial ended and records can only be viewed
+	Flags        []*FeatureFlag `json:"flags,omitempty"`
+}
+
+// ReferralCode represents the code a user shares to refer peers to the platform
+type ReferralCode struct {
+	gorm.Model
+	Code      string         `gorm:"type:varchar(20);not null;uniqueIndex" json:"code"`
+	UserID    uint           `gorm:"not null;uniqueIndex" json:"user_id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// Referral represents a signup attributed to a referral code
+type Referral struct {
+	gorm.Model
+	Code           string         `gorm:"type:varchar(20);not null" json:"code"`
+	ReferrerID     uint           `gorm:"not null;index" json:"referrer_id"`
+	ReferredUserID uint           `gorm:"not null;uniqueIndex" json:"referred_user_id"`
+	CreatedAt      time.Time      `gorm:"index" json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// ReferredUser represents a user who signed up with a referral code. Active users have recorded
+// a sale or expense.
+type ReferredUser struct {
+	UserID   uint      `json:"user_id"`
+	Name     string    `json:"name"`
+	JoinedAt time.Time `json:"joined_at"`
+	Active   bool      `json:"active"`
+}
+
+// ReferralStats represents a user's referral code and the peers who signed up with it
+type ReferralStats struct {
+	Code      string          `json:"code"`
+	Referred  int             `json:"referred"`
+	Active    int             `json:"active"`
+	Referrals []*ReferredUser `json:"referrals"`
+}
+
+// ReferrerStats represents the referrals of a user in a period, for rewarding referrers
+type ReferrerStats struct {
+	UserID   uint   `json:"user_id"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Phone    string `json:"phone,omitempty"`
+	Code     string `json:"code"`
+	Referred int64  `json:"referred"`
+	Active   int64  `json:"active"`
+}
+
+// SupportTicketStatus represents whether a support ticket awaits an answer
+type SupportTicketStatus string
+
+const (
+	SupportTicketOpen     SupportTicketStatus = "open"
+	SupportTicketResolved SupportTicketStatus = "resolved"
+)
+
+// SupportDiagnostics represents the diagnostic bundle a client attaches to a support ticket
+type SupportDiagnostics struct {
+	AppVersion string   `json:"app_version,omitempty"`
+	Platform   string   `json:"platform,omitempty"`    // e.g. android 13, web
+	RequestIDs []string `json:"request_ids,omitempty"` // X-Request-ID of recent requests
+}
+
+// SupportTicket represents a support request raised from the app and forwarded to the support email
+type SupportTicket struct {
+	gorm.Model
+	Subject     string              `gorm:"type:varchar(200);not null" json:"subject"`
+	Message     string              `gorm:"type:text;not null" json:"message"`
+	Status      SupportTicketStatus `gorm:"type:varchar(20);not null;default:'open';index" json:"status"`
+	Diagnostics *SupportDiagnostics `gorm:"type:jsonb;serializer:json" json:"diagnostics,omitempty"`
+	DeliveryID  *uint               `json:"delivery_id,omitempty"`         // email to the support address
+	BooksUserID uint                `gorm:"not null" json:"books_user_id"` // books the user worked on when raising the ticket
+	ResolvedAt  *time.Time          `json:"resolved_at,omitempty"`
+	UserID      uint                `gorm:"not null;index" json:"user_id"` // user who raised the ticket
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt      `gorm:"index" json:"-"`
+}
+
+// Lease represents a named lock held by one worker until it expires, e.g. the scheduler leader
+// lease that keeps scheduled reminders from being sent by every worker
+type Lease struct {
+	gorm.Model
+	Name      string         `gorm:"type:varchar(100);not null;uniqueIndex" json:"name"`
+	Holder    string         `gorm:"type:varchar(255);not null" json:"holder"` // worker that holds the lease
+	ExpiresAt time.Time      `gorm:"not null" json:"expires_at"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// RateLimitBucket represents the requests counted against a rate limit in one window, shared by
+// every API replica
+type RateLimitBucket struct {
+	gorm.Model
+	Name        string         `gorm:"type:varchar(255);not null;uniqueIndex:idx_rate_limit_window" json:"name"` // limit and client, e.g. auth:203.0.113.7
+	WindowStart int64          `gorm:"not null;uniqueIndex:idx_rate_limit_window;index" json:"window_start"`     // unix time
+	Hits        int64          `gorm:"not null;default:0" json:"hits"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// IdempotentRequest represents a request made with an Idempotency-Key and the response it got,
+// so a retried request gets the same response instead of running twice
+type IdempotentRequest struct {
+	gorm.Model
+	ActorID        uint           `gorm:"not null;uniqueIndex:idx_idempotency_key" json:"actor_id"` // user who made the request
+	IdempotencyKey string         `gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_key" json:"idempotency_key"`
+	Fingerprint    string         `gorm:"type:varchar(64);not null" json:"fingerprint"` // hash of the method, path, books and body
+	StatusCode     int            `gorm:"not null;default:0" json:"status_code"`        // 0 while the request is processed
+	ContentType    string         `gorm:"type:varchar(100)" json:"content_type"`
+	Body           []byte         `json:"-"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// ArchivedIncome represents a settled sale moved out of the incomes table once it is older than
+// the archive age, so the tables reports read stay small. It keeps the ID it had as an income.
+type ArchivedIncome struct {
+	Income
+	ArchivedAt time.Time `gorm:"not null" json:"archived_at"`
+}
+
+// ArchivedExpense represents a settled expense moved out of the expenses table once it is older
+// than the archive age. It keeps the ID it had as an expense.
+type ArchivedExpense struct {
+	Expense
+	ArchivedAt time.Time `gorm:"not null" json:"archived_at"`
+}
+
+// ArchivedPeriod summarizes the archived records of a year
+type ArchivedPeriod struct {
+	Year           int     `json:"year"`
+	IncomeRecords  int64   `json:"income_records"`
+	TotalIncome    float64 `json:"total_income"`
+	ExpenseRecords int64   `json:"expense_records"`
+	TotalExpenses  float64 `json:"total_expenses"`
+}
+
+// WarehouseWatermark records how far a tenant's dataset has been exported to the analytics
+// warehouse: the position of the last exported row in change time, type and ID order. A new
+// schema version starts from scratch so the warehouse gets a full export in the new layout.
+type WarehouseWatermark struct {
+	gorm.Model
+	TenantID      uint           `gorm:"not null;uniqueIndex:idx_warehouse_watermark" json:"tenant_id"` // books owner
+	Dataset       string         `gorm:"type:varchar(50);not null;uniqueIndex:idx_warehouse_watermark" json:"dataset"`
+	SchemaVersion int            `gorm:"not null;uniqueIndex:idx_warehouse_watermark" json:"schema_version"`
+	WatermarkAt   time.Time      `json:"watermark_at"`
+	WatermarkType string         `gorm:"type:varchar(20)" json:"watermark_type"`
+	WatermarkID   uint           `json:"watermark_id"`
+	ExportedRows  int64          `gorm:"not null;default:0" json:"exported_rows"`
+	Files         int64          `gorm:"not null;default:0" json:"files"`
+	LastExportAt  *time.Time     `json:"last_export_at,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// WarehouseTransaction is a sale or expense denormalized with its organization for the analytics
+// warehouse. Soft deleted records are included with DeletedAt set so deletions reach the warehouse.
+type WarehouseTransaction struct {
+	TransactionType TransactionType
+	TransactionID   uint
+	TenantID        uint
+	Organization    *string
+	Currency        string
+	Date            time.Time
+	Category        string // mineral type of a sale, category of an expense
+	Item            *string
+	Counterparty    string // customer of a sale, supplier of an expense
+	Quantity        *float64
+	Unit            *string
+	Amount          float64
+	AmountPaid      float64
+	AmountDue       float64
+	PaymentStatus   string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       *time.Time
+}
+
+// ChangedAt returns when the transaction last changed: the later of its update and deletion
+func (t *WarehouseTransaction) ChangedAt() time.Time {
+	if t.DeletedAt != nil && t.DeletedAt.After(t.UpdatedAt) {
+		return *t.DeletedAt
+	}
+	return t.UpdatedAt
+}
+
+// StreamType names an event stream: the kind of record whose changes it holds
+type StreamType string
+
+const (
+	StreamInventory StreamType = "inventory_item"
+	StreamIncome    StreamType = "income"
+	StreamExpense   StreamType = "expense"
+)
+
+// StreamEventType represents the kind of change a stream event records
+type StreamEventType string
+
+// Baseline events open the streams of records that existed before event streams were kept,
+// with their balance at the time
+const (
+	EventInventoryBaseline StreamEventType = "inventory.baseline"
+	EventIncomeBaseline    StreamEventType = "income.baseline"
+	EventExpenseBaseline   StreamEventType = "expense.baseline"
+)
+
+const (
+	EventInventoryCreated    StreamEventType = "inventory.created"
+	EventInventoryUpdated    StreamEventType = "inventory.updated" // quantity or value edited
+	EventInventoryUsed       StreamEventType = "inventory.used"
+	EventInventoryCounted    StreamEventType = "inventory.counted"   // adjusted by a stocktake
+	EventInventoryPurchased  StreamEventType = "inventory.purchased" // received from a miner or supplier
+	EventInventoryWrittenOff StreamEventType = "inventory.written_off"
+	EventInventoryDeleted    StreamEventType = "inventory.deleted"
+	EventInventoryRestored   StreamEventType = "inventory.restored" // brought back from the trash
+	EventIncomeCreated       StreamEventType = "income.created"
+	EventIncomeUpdated       StreamEventType = "income.updated" // amount or payment changed
+	EventIncomePaid          StreamEventType = "income.paid"    // payment appended
+	EventIncomeDeleted       StreamEventType = "income.deleted"
+	EventIncomeRestored      StreamEventType = "income.restored"
+	EventExpenseCreated      StreamEventType = "expense.created"
+	EventExpenseUpdated      StreamEventType = "expense.updated" // amount or payment changed
+	EventExpensePaid         StreamEventType = "expense.paid"    // payment appended
+	EventExpenseDeleted      StreamEventType = "expense.deleted"
+	EventExpenseRestored     StreamEventType = "expense.restored"
+)
+
+// StreamEvent represents a change to an inventory item's stock or to the payment balance of a
+// sale or expense. Events are appended in the transaction making the change and never updated,
+// so a record's balance at any point can be rebuilt from its stream. The ID orders events across
+// streams for consumers replaying them.
+type StreamEvent struct {
+	gorm.Model
+	Stream    StreamType      `gorm:"type:varchar(20);not null;uniqueIndex:idx_stream_event_version" json:"stream"`
+	StreamID  uint            `gorm:"not null;uniqueIndex:idx_stream_event_version" json:"stream_id"` // ID of the record
+	Version   int             `gorm:"not null;uniqueIndex:idx_stream_event_version" json:"version"`   // 1 for the first event of the stream
+	Type      StreamEventType `gorm:"type:varchar(30);not null" json:"type"`
+	Data      StreamEventData `gorm:"type:jsonb;serializer:json" json:"data"`
+	UserID    uint            `gorm:"not null;index" json:"user_id"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	DeletedAt gorm.DeletedAt  `gorm:"index" json:"-"`
+}
+
+// StreamEventData holds the balance of the record after a change and the change itself
+type StreamEventData struct {
+	Stock   *StockBalance   `json:"stock,omitempty"`   // inventory item events
+	Payment *PaymentBalance `json:"payment,omitempty"` // sale and expense events
+}
+
+// StockBalance is the stock of an inventory item after a change
+type StockBalance struct {
+	Quantity     float64 `json:"quantity"`
+	Change       float64 `json:"change"` // signed change of the quantity
+	Unit         string  `json:"unit"`
+	CurrentValue float64 `json:"current_value"`
+	MovementID   *uint   `json:"movement_id,omitempty"` // stock movement recording the usage or count
+}
+
+// PaymentBalance is the payment balance of a sale or expense after a change
+type PaymentBalance struct {
+	Amount        float64       `json:"amount"` // total of the sale or expense
+	AmountPaid    float64       `json:"amount_paid"`
+	AmountDue     float64       `json:"amount_due"`
+	PaymentStatus PaymentStatus `json:"payment_status"`
+	Paid          float64       `json:"paid"` // signed change of the amount paid
+}
+
+// RebuiltBalance is a record's balance rebuilt from its event stream next to the balance in its
+// table, so drift between the two shows
+type RebuiltBalance struct {
+	Stream   StreamType      `json:"stream"`
+	StreamID uint            `json:"stream_id"`
+	Version  int             `json:"version"` // events folded
+	Deleted  bool            `json:"deleted"`
+	Rebuilt  StreamEventData `json:"rebuilt"`
+	Current  StreamEventData `json:"current"` // empty when the record no longer exists
+	Matches  bool            `json:"matches"`
+}
+
+// Outbox events, published by the worker under the names of the matching pkg/events events
+const (
+	OutboxIncomeCreated    = "income.created"            // Payload is the Income
+	OutboxPaymentRecorded  = "payment.recorded"          // Payload is a PaymentRecorded
+	OutboxStockLow         = "stock.low"                 // Payload is the InventoryItem
+	OutboxSignOffRequired  = "expense.sign_off_required" // Payload is an ExpenseSignOffEvent
+	OutboxExpenseSignedOff = "expense.signed_off"        // Payload is an ExpenseSignOffEvent
+)
+
+// OutboxMessage is an event stored in the transaction of the change it describes, so it exists
+// exactly when the change does. The worker publishes pending messages to the subscribers that
+// deliver webhooks and notifications and retries them until they succeed, so subscribers may see
+// a message more than once.
+type OutboxMessage struct {
+	gorm.Model
+	Event       string         `gorm:"type:varchar(50);not null" json:"event"`
+	Resource    string         `gorm:"type:varchar(50);not null" json:"resource"`
+	ResourceID  uint           `gorm:"not null" json:"resource_id"`
+	Payload     string         `gorm:"type:text;not null" json:"-"` // JSON encoded
+	UserID      uint           `gorm:"not null;index" json:"user_id"`
+	OccurredAt  time.Time      `gorm:"not null" json:"occurred_at"`
+	Attempts    int            `gorm:"not null;default:0" json:"attempts"`
+	RunAt       time.Time      `gorm:"not null;index" json:"run_at"` // next attempt; pushed back while claimed
+	PublishedAt *time.Time     `gorm:"index" json:"published_at,omitempty"`
+	LastError   *string        `gorm:"type:text" json:"last_error,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// ExpenseSignOffEvent is the payload of the expense sign-off events: an expense above the
+// sign-off amount needing sign-off, or signed off by one more approver
+type ExpenseSignOffEvent struct {
+	ExpenseID    uint    `json:"expense_id"`
+	Description  string  `json:"description"`
+	SupplierName string  `json:"supplier_name"`
+	Amount       float64 `json:"amount"`
+	SignOffs     int     `json:"sign_offs"`
+	Required     int     `json:"required"`
+	SignedOffBy  []uint  `json:"signed_off_by"` // approvers who signed it off
+}
+
+// PaymentRecorded is the payload of a payment.recorded event: a payment received on a sale
+type PaymentRecorded struct {
+	IncomeID      uint    `json:"income_id"`
+	CustomerName  string  `json:"customer_name"`
+	Amount        float64 `json:"amount"` // received in this payment
+	AmountPaid    float64 `json:"amount_paid"`
+	AmountDue     float64 `json:"amount_due"`
+	PaymentStatus string  `json:"payment_status"`
+}
@@ -0,0 +1,56 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationRepository implements NotificationInterface using GORM
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new instance of NotificationRepository
+func NewNotificationRepository(db *gorm.DB) NotificationInterface {
+	return &NotificationRepository{db: db}
+}
+
+// GetAll retrieves notifications for a user, newest first
+func (r *NotificationRepository) GetAll(ctx context.Context, userID uint, unreadOnly bool) ([]*Notification, error) {
+	var notifications []*Notification
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	result := query.Order("created_at DESC").Find(&notifications)
+	return notifications, result.Error
+}
+
+// Insert creates a notification unless one with the same key already exists.
+// It reports whether a new notification was created.
+func (r *NotificationRepository) Insert(ctx context.Context, notification *Notification) (bool, error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(notification)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// MarkRead marks a notification as read
+func (r *NotificationRepository) MarkRead(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Model(&Notification{}).
+		Where("id = ? AND user_id = ? AND read_at IS NULL", id, userID).
+		Update("read_at", time.Now())
+	return result.Error
+}
+
+// MarkAllRead marks all notifications of a user as read
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID uint) error {
+	result := r.db.WithContext(ctx).Model(&Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now())
+	return result.Error
+}
@@ -0,0 +1,390 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrOrganizationExists is returned when a user who already owns an organization creates another
+	ErrOrganizationExists = errors.New("user already owns an organization")
+	// ErrAlreadyMember is returned when adding a user who is already a member
+	ErrAlreadyMember = errors.New("user is already a member of this organization")
+	// ErrOwnerMembership is returned when changing or removing the owner's membership
+	ErrOwnerMembership = errors.New("the organization owner's membership cannot be changed")
+	// ErrInvitationInvalid is returned when an invitation is unknown, expired, revoked or was
+	// already accepted
+	ErrInvitationInvalid = errors.New("invalid or expired invitation")
+)
+
+// OrganizationRepository implements OrganizationInterface using GORM
+type OrganizationRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationRepository creates a new instance of OrganizationRepository
+func NewOrganizationRepository(db *gorm.DB) OrganizationInterface {
+	return &OrganizationRepository{db: db}
+}
+
+// GetMemberships retrieves the organizations a user belongs to
+func (r *OrganizationRepository) GetMemberships(ctx context.Context, userID uint) ([]*OrganizationMember, error) {
+	var memberships []*OrganizationMember
+	result := r.db.WithContext(ctx).Preload("Organization").Where("user_id = ?", userID).Order("created_at ASC").Find(&memberships)
+	return memberships, result.Error
+}
+
+// GetMembership retrieves the membership of a user in an organization
+func (r *OrganizationRepository) GetMembership(ctx context.Context, organizationID uint, userID uint) (*OrganizationMember, error) {
+	var membership OrganizationMember
+	result := r.db.WithContext(ctx).Preload("Organization").
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).First(&membership)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &membership, nil
+}
+
+// GetOne retrieves an organization with its members
+func (r *OrganizationRepository) GetOne(ctx context.Context, id uint) (*Organization, error) {
+	var organization Organization
+	result := r.db.WithContext(ctx).Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC")
+	}).Preload("Members.User").First(&organization, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &organization, nil
+}
+
+// Create creates an organization with its owner as the first member
+func (r *OrganizationRepository) Create(ctx context.Context, organization *Organization) (uint, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		if err := tx.Model(&Organization{}).Where("owner_id = ?", organization.OwnerID).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return ErrOrganizationExists
+		}
+
+		if err := tx.Omit("Members").Create(organization).Error; err != nil {
+			return err
+		}
+		return tx.Create(&OrganizationMember{
+			OrganizationID: organization.ID,
+			UserID:         organization.OwnerID,
+			Role:           OrgRoleOwner,
+			CanExport:      true,
+		}).Error
+	})
+	return organization.ID, err
+}
+
+// Update updates an organization
+func (r *OrganizationRepository) Update(ctx context.Context, organization *Organization) error {
+	result := r.db.WithContext(ctx).Omit("Members").Save(organization)
+	return result.Error
+}
+
+// AddMember adds a user to an organization
+func (r *OrganizationRepository) AddMember(ctx context.Context, member *OrganizationMember) (uint, error) {
+	var existing int64
+	err := r.db.WithContext(ctx).Model(&OrganizationMember{}).
+		Where("organization_id = ? AND user_id = ?", member.OrganizationID, member.UserID).Count(&existing).Error
+	if err != nil {
+		return 0, err
+	}
+	if existing > 0 {
+		return 0, ErrAlreadyMember
+	}
+
+	result := r.db.WithContext(ctx).Omit("Organization", "User").Create(member)
+	return member.ID, result.Error
+}
+
+// UpdateMember changes the role and export permission of a member other than the owner
+func (r *OrganizationRepository) UpdateMember(ctx context.Context, organizationID uint, userID uint, role OrganizationRole, canExport bool) error {
+	result := r.db.WithContext(ctx).Model(&OrganizationMember{}).
+		Where("organization_id = ? AND user_id = ? AND role <> ?", organizationID, userID, OrgRoleOwner).
+		Updates(map[string]interface{}{
+			"role":       role,
+			"can_export": canExport,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrOwnerMembership
+	}
+	return nil
+}
+
+// RemoveMember removes a member other than the owner. Memberships are removed permanently
+// so the user can be added again later.
+func (r *OrganizationRepository) RemoveMember(ctx context.Context, organizationID uint, userID uint) error {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("organization_id = ? AND user_id = ? AND role <> ?", organizationID, userID, OrgRoleOwner).
+		Delete(&OrganizationMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrOwnerMembership
+	}
+	return nil
+}
+
+// GetIPRules retrieves the IP allowlist rules of an organization
+func (r *OrganizationRepository) GetIPRules(ctx context.Context, organizationID uint) ([]*OrganizationIPRule, error) {
+	var rules []*OrganizationIPRule
+	result := r.db.WithContext(ctx).Where("organization_id = ?", organizationID).Order("role ASC, created_at ASC").Find(&rules)
+	return rules, result.Error
+}
+
+// AddIPRule adds an IP allowlist rule to an organization
+func (r *OrganizationRepository) AddIPRule(ctx context.Context, rule *OrganizationIPRule) (uint, error) {
+	result := r.db.WithContext(ctx).Create(rule)
+	return rule.ID, result.Error
+}
+
+// DeleteIPRule soft deletes an IP allowlist rule of an organization
+func (r *OrganizationRepository) DeleteIPRule(ctx context.Context, id uint, organizationID uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND organization_id = ?", id, organizationID).Delete(&OrganizationIPRule{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// HasMember reports whether a user is a member of the organization owned by ownerID
+func (r *OrganizationRepository) HasMember(ctx context.Context, ownerID uint, userID uint) (bool, error) {
+	var count int64
+	result := r.db.WithContext(ctx).Model(&OrganizationMember{}).
+		Joins("JOIN organizations ON organizations.id = organization_members.organization_id AND organizations.deleted_at IS NULL").
+		Where("organizations.owner_id = ? AND organization_members.user_id = ?", ownerID, userID).
+		Count(&count)
+	return count > 0, result.Error
+}
+
+// GetPermissionHolders retrieves the owner of the books ownerID and the members of their
+// organization whose role holds a permission
+func (r *OrganizationRepository) GetPermissionHolders(ctx context.Context, ownerID uint, permission Permission) ([]*User, error) {
+	var owner User
+	if err := r.db.WithContext(ctx).First(&owner, ownerID).Error; err != nil {
+		return nil, err
+	}
+	holders := []*User{&owner}
+
+	var organization Organization
+	err := r.db.WithContext(ctx).Preload("Members.User").Where("owner_id = ?", ownerID).First(&organization).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return holders, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	granted := map[OrganizationRole]bool{}
+	for _, member := range organization.Members {
+		holds, ok := granted[member.Role]
+		if !ok {
+			permissions, err := r.GetRolePermissions(ctx, organization.ID, member.Role)
+			if err != nil {
+				return nil, err
+			}
+			holds = slices.Contains(permissions.Permissions, permission)
+			granted[member.Role] = holds
+		}
+		if holds && member.User != nil && member.UserID != ownerID {
+			holders = append(holders, member.User)
+		}
+	}
+	return holders, nil
+}
+
+// GetRolePermissions retrieves the permissions of a role in an organization: all of them for the
+// owner, those granted to the role, or its defaults when none are granted
+func (r *OrganizationRepository) GetRolePermissions(ctx context.Context, organizationID uint, role OrganizationRole) (*RolePermissions, error) {
+	if role == OrgRoleOwner {
+		return &RolePermissions{Role: role, Permissions: AllPermissions}, nil
+	}
+
+	var grants []*OrganizationRolePermission
+	err := r.db.WithContext(ctx).Where("organization_id = ? AND role = ?", organizationID, role).Order("id").Find(&grants).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(grants) == 0 {
+		permissions := DefaultRolePermissions[role]
+		if permissions == nil {
+			permissions = []Permission{}
+		}
+		return &RolePermissions{Role: role, Permissions: permissions}, nil
+	}
+
+	permissions := make([]Permission, len(grants))
+	for i, grant := range grants {
+		permissions[i] = grant.Permission
+	}
+	return &RolePermissions{Role: role, Permissions: permissions, Customized: true}, nil
+}
+
+// SetRolePermissions replaces the permissions granted to a role other than the owner in an
+// organization
+func (r *OrganizationRepository) SetRolePermissions(ctx context.Context, organizationID uint, role OrganizationRole, permissions []Permission) error {
+	if role == OrgRoleOwner {
+		return ErrOwnerMembership
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("organization_id = ? AND role = ?", organizationID, role).Delete(&OrganizationRolePermission{}).Error
+		if err != nil {
+			return err
+		}
+		for _, permission := range permissions {
+			err := tx.Create(&OrganizationRolePermission{
+				OrganizationID: organizationID,
+				Role:           role,
+				Permission:     permission,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ResetRolePermissions removes the permissions granted to a role in an organization, so it has
+// its default permissions again
+func (r *OrganizationRepository) ResetRolePermissions(ctx context.Context, organizationID uint, role OrganizationRole) error {
+	return r.db.WithContext(ctx).Where("organization_id = ? AND role = ?", organizationID, role).Delete(&OrganizationRolePermission{}).Error
+}
+
+// CreateInvitation stores an invitation to an organization, replacing any open invitation of the
+// same email address. It returns ErrAlreadyMember when a user with the address is a member.
+func (r *OrganizationRepository) CreateInvitation(ctx context.Context, invitation *OrganizationInvitation) (uint, error) {
+	invitation.Email = strings.ToLower(invitation.Email)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var members int64
+		err := tx.Model(&OrganizationMember{}).
+			Joins("JOIN users ON users.id = organization_members.user_id AND users.deleted_at IS NULL").
+			Where("organization_members.organization_id = ? AND LOWER(users.email) = ?", invitation.OrganizationID, invitation.Email).
+			Count(&members).Error
+		if err != nil {
+			return err
+		}
+		if members > 0 {
+			return ErrAlreadyMember
+		}
+
+		err = tx.Where("organization_id = ? AND email = ? AND accepted_at IS NULL", invitation.OrganizationID, invitation.Email).
+			Delete(&OrganizationInvitation{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Omit("Organization").Create(invitation).Error
+	})
+	return invitation.ID, err
+}
+
+// SetInvitationDelivery records the delivery of the email of an invitation
+func (r *OrganizationRepository) SetInvitationDelivery(ctx context.Context, id uint, deliveryID uint) error {
+	return r.db.WithContext(ctx).Model(&OrganizationInvitation{}).Where("id = ?", id).Update("delivery_id", deliveryID).Error
+}
+
+// GetInvitations retrieves the invitations of an organization that haven't been accepted, newest
+// first
+func (r *OrganizationRepository) GetInvitations(ctx context.Context, organizationID uint) ([]*OrganizationInvitation, error) {
+	var invitations []*OrganizationInvitation
+	result := r.db.WithContext(ctx).Where("organization_id = ? AND accepted_at IS NULL", organizationID).
+		Order("created_at DESC").Find(&invitations)
+	return invitations, result.Error
+}
+
+// GetInvitationByToken retrieves an open invitation by the hash of its token, with its
+// organization. It returns ErrInvitationInvalid when there is none.
+func (r *OrganizationRepository) GetInvitationByToken(ctx context.Context, tokenHash string) (*OrganizationInvitation, error) {
+	var invitation OrganizationInvitation
+	err := r.db.WithContext(ctx).Preload("Organization").
+		Where("token_hash = ? AND accepted_at IS NULL AND expires_at > ?", tokenHash, time.Now()).
+		First(&invitation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvitationInvalid
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// AcceptInvitation makes a user a member of an organization with the role of the open invitation
+// with the hash tokenHash. It returns ErrInvitationInvalid when there is no such invitation and
+// ErrAlreadyMember when the user is already a member.
+func (r *OrganizationRepository) AcceptInvitation(ctx context.Context, tokenHash string, userID uint) (*OrganizationMember, error) {
+	var member *OrganizationMember
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var invitation OrganizationInvitation
+		err := tx.Where("token_hash = ? AND accepted_at IS NULL AND expires_at > ?", tokenHash, time.Now()).
+			First(&invitation).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvitationInvalid
+			}
+			return err
+		}
+
+		var existing int64
+		err = tx.Model(&OrganizationMember{}).
+			Where("organization_id = ? AND user_id = ?", invitation.OrganizationID, userID).Count(&existing).Error
+		if err != nil {
+			return err
+		}
+		if existing > 0 {
+			return ErrAlreadyMember
+		}
+
+		// The acceptance is conditional so the invitation can't be used twice
+		result := tx.Model(&OrganizationInvitation{}).Where("id = ? AND accepted_at IS NULL", invitation.ID).
+			Updates(map[string]interface{}{"accepted_at": time.Now(), "accepted_by_id": userID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInvitationInvalid
+		}
+
+		member = &OrganizationMember{
+			OrganizationID: invitation.OrganizationID,
+			UserID:         userID,
+			Role:           invitation.Role,
+			CanExport:      invitation.CanExport,
+		}
+		return tx.Omit("Organization", "User").Create(member).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// DeleteInvitation revokes an invitation of an organization that hasn't been accepted
+func (r *OrganizationRepository) DeleteInvitation(ctx context.Context, id uint, organizationID uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND organization_id = ? AND accepted_at IS NULL", id, organizationID).
+		Delete(&OrganizationInvitation{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// outboxClaimTimeout is how long a claimed message is hidden from other workers. A worker that
+// stops mid-publish leaves the message to be claimed again once it passes.
+const outboxClaimTimeout = 5 * time.Minute
+
+// OutboxRepository implements OutboxInterface using GORM
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates a new instance of OutboxRepository
+func NewOutboxRepository(db *gorm.DB) OutboxInterface {
+	return &OutboxRepository{db: db}
+}
+
+// ClaimNext claims the oldest due message for publishing and returns it, or nil when none is
+// due. Like job claims, rows are locked with SKIP LOCKED where supported and the claim only
+// updates the message as it was read, so several workers never claim the same message.
+func (r *OutboxRepository) ClaimNext(ctx context.Context) (*OutboxMessage, error) {
+	var message OutboxMessage
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("published_at IS NULL AND run_at <= ?", now).
+			Order("id").First(&message).Error
+		if err != nil {
+			return err
+		}
+
+		claimed := tx.Model(&message).Where("attempts = ?", message.Attempts).
+			Updates(map[string]interface{}{
+				"attempts": message.Attempts + 1,
+				"run_at":   now.Add(outboxClaimTimeout),
+			})
+		if claimed.Error != nil {
+			return claimed.Error
+		}
+		if claimed.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound // claimed by another worker
+		}
+		message.Attempts++
+		return nil
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// MarkPublished marks a message as published to all its subscribers
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Model(&OutboxMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"published_at": time.Now(),
+		"last_error":   nil,
+	})
+	return result.Error
+}
+
+// Fail records a failed publish and makes the message due again at retryAt
+func (r *OutboxRepository) Fail(ctx context.Context, id uint, message string, retryAt time.Time) error {
+	result := r.db.WithContext(ctx).Model(&OutboxMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"last_error": message,
+		"run_at":     retryAt,
+	})
+	return result.Error
+}
+
+// DeletePublishedBefore permanently deletes the messages published before the given time and
+// returns how many were deleted
+func (r *OutboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().Where("published_at < ?", before).Delete(&OutboxMessage{})
+	return result.RowsAffected, result.Error
+}
+
+// storeOutbox stores an event about a resource in the outbox of the transaction changing it
+func storeOutbox(tx *gorm.DB, event string, resource string, resourceID uint, userID uint, payload interface{}) error {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	return tx.Create(&OutboxMessage{
+		Event:      event,
+		Resource:   resource,
+		ResourceID: resourceID,
+		Payload:    string(encoded),
+		UserID:     userID,
+		OccurredAt: now,
+		RunAt:      now,
+	}).Error
+}
@@ -0,0 +1,59 @@
+package data
+
+import (
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// findPage fills dest with limit rows of query, in order, starting at offset, returning how many
+// rows the query matches in all. A zero limit fills dest with every row.
+func findPage(query *gorm.DB, order string, offset, limit int, dest interface{}) (int64, error) {
+	// A new session lets the count and the find each build on the query
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return 0, err
+	}
+	if limit > 0 {
+		query = query.Offset(offset).Limit(limit)
+	}
+	if err := query.Order(order).Find(dest).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// scopeToSite narrows a query of site-scoped records to the mine site siteID, when it is set
+func scopeToSite(query *gorm.DB, siteID *uint) *gorm.DB {
+	if siteID == nil {
+		return query
+	}
+	return query.Where("mine_site_id = ?", *siteID)
+}
+
+// scopeToPeriod narrows a query to the records whose column falls in [start, end), each bound
+// only applying when it is set
+func scopeToPeriod(query *gorm.DB, column string, start, end *time.Time) *gorm.DB {
+	if start != nil {
+		query = query.Where(column+" >= ?", *start)
+	}
+	if end != nil {
+		query = query.Where(column+" < ?", *end)
+	}
+	return query
+}
+
+// sortOrder returns the order of a list sorted by column, ties broken newest first, or fallback
+// when column is empty or not one of columns
+func sortOrder(column string, descending bool, columns []string, fallback string) string {
+	if column == "" || !slices.Contains(columns, column) {
+		return fallback
+	}
+	if descending {
+		return column + " DESC, id DESC"
+	}
+	return column + " ASC, id DESC"
+}
@@ -0,0 +1,146 @@
+package data
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrOverpayment is returned when a payment is more than the amount due on a record
+var ErrOverpayment = errors.New("payment is more than the amount due")
+
+// paymentTolerance absorbs floating point rounding when comparing amounts, e.g. the last of
+// three equal instalments of a third
+const paymentTolerance = 0.005
+
+// PaymentRepository implements PaymentInterface using GORM
+type PaymentRepository struct {
+	db *gorm.DB
+}
+
+// NewPaymentRepository creates a new instance of PaymentRepository
+func NewPaymentRepository(db *gorm.DB) PaymentInterface {
+	return &PaymentRepository{db: db}
+}
+
+// GetForRecord retrieves the payments of an income or expense record, oldest first
+func (r *PaymentRepository) GetForRecord(ctx context.Context, userID uint, recordType TransactionType, recordID uint) ([]*Payment, error) {
+	var payments []*Payment
+	result := r.db.WithContext(ctx).Where("user_id = ? AND record_type = ? AND record_id = ?", userID, recordType, recordID).
+		Order("date ASC, id ASC").Find(&payments)
+	return payments, result.Error
+}
+
+// RecordIncomePayment appends a payment received on a sale and returns the sale with its amount
+// paid, amount due and payment status recomputed. It returns ErrOverpayment when the payment is
+// more than the amount due. The sale is locked until the payment is recorded, so concurrent
+// payments and credit notes are checked against each other's amount due.
+func (r *PaymentRepository) RecordIncomePayment(ctx context.Context, payment *Payment) (*Income, error) {
+	var income Income
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND user_id = ?", payment.RecordID, payment.UserID).First(&income).Error
+		if err != nil {
+			return err
+		}
+		if payment.Amount > income.AmountDue+paymentTolerance {
+			return ErrOverpayment
+		}
+		payment.RecordType = TransactionIncome
+		if err := tx.Create(payment).Error; err != nil {
+			return err
+		}
+
+		before := income
+		income.AmountPaid += payment.Amount
+		income.AmountDue = income.TotalAmount - income.AmountPaid
+		income.PaymentStatus = settledStatus(income.AmountPaid, income.AmountDue)
+		if err := tx.Save(&income).Error; err != nil {
+			return err
+		}
+		return recordIncome(tx, EventIncomePaid, &income, &before)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &income, nil
+}
+
+// RecordExpensePayment appends a payment made on an expense and returns the expense with its
+// amount paid, amount due and payment status recomputed. It returns ErrOverpayment when the
+// payment is more than the amount due, and ErrAwaitingSignOff when the expense hasn't been
+// signed off.
+func (r *PaymentRepository) RecordExpensePayment(ctx context.Context, payment *Payment) (*Expense, error) {
+	var expense Expense
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND user_id = ?", payment.RecordID, payment.UserID).First(&expense).Error
+		if err != nil {
+			return err
+		}
+		if expense.AwaitingSignOff() {
+			return ErrAwaitingSignOff
+		}
+		if payment.Amount > expense.AmountDue+paymentTolerance {
+			return ErrOverpayment
+		}
+		payment.RecordType = TransactionExpense
+		if err := tx.Create(payment).Error; err != nil {
+			return err
+		}
+
+		before := expense
+		expense.AmountPaid += payment.Amount
+		expense.AmountDue = expense.Amount - expense.AmountPaid
+		expense.PaymentStatus = settledStatus(expense.AmountPaid, expense.AmountDue)
+		if err := tx.Save(&expense).Error; err != nil {
+			return err
+		}
+		return recordExpense(tx, EventExpensePaid, &expense, &before)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &expense, nil
+}
+
+// RecordPurchasePayment appends a payment made to a miner on a purchase and returns the purchase
+// with its amount paid, amount due and payment status recomputed. It returns ErrOverpayment when
+// the payment is more than the amount due.
+func (r *PaymentRepository) RecordPurchasePayment(ctx context.Context, payment *Payment) (*Purchase, error) {
+	var purchase Purchase
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND user_id = ?", payment.RecordID, payment.UserID).First(&purchase).Error
+		if err != nil {
+			return err
+		}
+		if payment.Amount > purchase.AmountDue+paymentTolerance {
+			return ErrOverpayment
+		}
+		payment.RecordType = TransactionPurchase
+		if err := tx.Create(payment).Error; err != nil {
+			return err
+		}
+
+		purchase.AmountPaid += payment.Amount
+		purchase.AmountDue = purchase.TotalAmount - purchase.AmountPaid
+		purchase.PaymentStatus = settledStatus(purchase.AmountPaid, purchase.AmountDue)
+		return tx.Save(&purchase).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &purchase, nil
+}
+
+// settledStatus returns the payment status of a record from what has been paid and what is due
+func settledStatus(paid, due float64) PaymentStatus {
+	switch {
+	case due <= paymentTolerance:
+		return PaymentPaid
+	case paid > 0:
+		return PaymentPartial
+	default:
+		return PaymentUnpaid
+	}
+}
@@ -0,0 +1,112 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRecordIncomePayment(t *testing.T) {
+	db := newTestDB(t, &Income{}, &Payment{}, &StreamEvent{}, &OutboxMessage{})
+	income := &Income{Date: time.Now(), Quantity: 3, Unit: "g", PricePerUnit: 100, TotalAmount: 300,
+		CustomerName: "Buyer", PaymentStatus: PaymentUnpaid, AmountDue: 300, UserID: 1}
+	if err := db.Create(income).Error; err != nil {
+		t.Fatal(err)
+	}
+	repo := NewPaymentRepository(db)
+	ctx := context.Background()
+
+	tests := []struct {
+		amount     float64
+		err        error
+		wantPaid   float64
+		wantStatus PaymentStatus
+	}{
+		{100, nil, 100, PaymentPartial},
+		{250, ErrOverpayment, 100, PaymentPartial}, // only 200 is due
+		{200, nil, 300, PaymentPaid},
+		{0.01, ErrOverpayment, 300, PaymentPaid},
+	}
+	for _, tt := range tests {
+		_, err := repo.RecordIncomePayment(ctx, &Payment{RecordID: income.ID, Amount: tt.amount, Date: time.Now(), UserID: 1})
+		if !errors.Is(err, tt.err) {
+			t.Fatalf("paying %g returned %v, want %v", tt.amount, err, tt.err)
+		}
+		var stored Income
+		if err := db.First(&stored, income.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+		if stored.AmountPaid != tt.wantPaid || stored.AmountDue != 300-tt.wantPaid || stored.PaymentStatus != tt.wantStatus {
+			t.Errorf("after paying %g: paid %g, due %g, status %s; want %g, %g, %s", tt.amount,
+				stored.AmountPaid, stored.AmountDue, stored.PaymentStatus, tt.wantPaid, 300-tt.wantPaid, tt.wantStatus)
+		}
+	}
+
+	payments, err := repo.GetForRecord(ctx, 1, TransactionIncome, income.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(payments) != 2 {
+		t.Errorf("got %d payments, want 2; rejected payments must not be stored", len(payments))
+	}
+}
+
+func TestRecordExpensePayment(t *testing.T) {
+	db := newTestDB(t, &Expense{}, &Payment{}, &StreamEvent{})
+	pending := SignOffPending
+	expense := &Expense{Date: time.Now(), Category: ExpenseFuel, Description: "Diesel", Amount: 500,
+		SupplierName: "Depot", PaymentStatus: PaymentUnpaid, AmountDue: 500, SignOffStatus: &pending, UserID: 1}
+	if err := db.Create(expense).Error; err != nil {
+		t.Fatal(err)
+	}
+	repo := NewPaymentRepository(db)
+	ctx := context.Background()
+	pay := func(amount float64) (*Expense, error) {
+		return repo.RecordExpensePayment(ctx, &Payment{RecordID: expense.ID, Amount: amount, Date: time.Now(), UserID: 1})
+	}
+
+	if _, err := pay(100); !errors.Is(err, ErrAwaitingSignOff) {
+		t.Fatalf("paying an expense awaiting sign-off returned %v, want %v", err, ErrAwaitingSignOff)
+	}
+	if err := db.Model(expense).Update("sign_off_status", SignOffComplete).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := pay(600); !errors.Is(err, ErrOverpayment) {
+		t.Fatalf("overpaying returned %v, want %v", err, ErrOverpayment)
+	}
+	paid, err := pay(499.999) // within the rounding tolerance of the amount due
+	if err != nil {
+		t.Fatal(err)
+	}
+	if paid.PaymentStatus != PaymentPaid {
+		t.Errorf("status %s after paying the amount due, want %s", paid.PaymentStatus, PaymentPaid)
+	}
+}
+
+func TestRecordPurchasePayment(t *testing.T) {
+	db := newTestDB(t, &Purchase{}, &Payment{})
+	purchase := &Purchase{Date: time.Now(), MinerName: "Miner", MineralType: MineralGold, Quantity: 2, Unit: "g",
+		PricePerUnit: 150, TotalAmount: 300, PaymentStatus: PaymentUnpaid, AmountDue: 300, InventoryItemID: 1, UserID: 1}
+	if err := db.Create(purchase).Error; err != nil {
+		t.Fatal(err)
+	}
+	repo := NewPaymentRepository(db)
+	ctx := context.Background()
+
+	paid, err := repo.RecordPurchasePayment(ctx, &Payment{RecordID: purchase.ID, Amount: 120, Date: time.Now(), UserID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if paid.AmountPaid != 120 || paid.AmountDue != 180 || paid.PaymentStatus != PaymentPartial {
+		t.Errorf("paid %g, due %g, status %s; want 120, 180, %s", paid.AmountPaid, paid.AmountDue, paid.PaymentStatus, PaymentPartial)
+	}
+	if _, err := repo.RecordPurchasePayment(ctx, &Payment{RecordID: purchase.ID, Amount: 200, Date: time.Now(), UserID: 1}); !errors.Is(err, ErrOverpayment) {
+		t.Errorf("overpaying returned %v, want %v", err, ErrOverpayment)
+	}
+	// Another organization's payment doesn't find the purchase
+	if _, err := repo.RecordPurchasePayment(ctx, &Payment{RecordID: purchase.ID, Amount: 10, Date: time.Now(), UserID: 2}); err == nil {
+		t.Error("paying another user's purchase succeeded")
+	}
+}
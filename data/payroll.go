@@ -0,0 +1,265 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrPayrollRunExists is returned when a payroll run already exists for the month
+	ErrPayrollRunExists = errors.New("payroll run already exists for this month")
+	// ErrPayrollRunEmpty is returned when there is nothing to pay or recover for the month
+	ErrPayrollRunEmpty = errors.New("no approved timesheets or outstanding adjustments for this month")
+	// ErrPayrollRunLocked is returned when a later run already recovered balances carried forward by this run
+	ErrPayrollRunLocked = errors.New("balances carried forward by this payroll run were settled by a later run")
+	// ErrAdjustmentSettled is returned when changing an adjustment already netted off in a payroll run
+	ErrAdjustmentSettled = errors.New("adjustment has already been settled by a payroll run")
+)
+
+// PayrollRepository implements PayrollInterface using GORM
+type PayrollRepository struct {
+	db *gorm.DB
+}
+
+// NewPayrollRepository creates a new instance of PayrollRepository
+func NewPayrollRepository(db *gorm.DB) PayrollInterface {
+	return &PayrollRepository{db: db}
+}
+
+// GetAdjustments retrieves the advances and deductions of an employee
+func (r *PayrollRepository) GetAdjustments(ctx context.Context, employeeID uint, userID uint) ([]*EmployeeAdjustment, error) {
+	var adjustments []*EmployeeAdjustment
+	result := r.db.WithContext(ctx).Where("employee_id = ? AND user_id = ?", employeeID, userID).
+		Order("date DESC").Find(&adjustments)
+	return adjustments, result.Error
+}
+
+// InsertAdjustment records a new advance or deduction
+func (r *PayrollRepository) InsertAdjustment(ctx context.Context, adjustment *EmployeeAdjustment) (uint, error) {
+	result := r.db.WithContext(ctx).Omit("Employee").Create(adjustment)
+	return adjustment.ID, result.Error
+}
+
+// DeleteAdjustment soft deletes an adjustment that has not been netted off yet
+func (r *PayrollRepository) DeleteAdjustment(ctx context.Context, id uint, employeeID uint, userID uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND employee_id = ? AND user_id = ? AND payroll_run_id IS NULL AND carried_from_run_id IS NULL",
+		id, employeeID, userID).Delete(&EmployeeAdjustment{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAdjustmentSettled
+	}
+	return nil
+}
+
+// GetAllRuns retrieves all payroll runs for a user
+func (r *PayrollRepository) GetAllRuns(ctx context.Context, userID uint) ([]*PayrollRun, error) {
+	var runs []*PayrollRun
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("period_start DESC").Find(&runs)
+	return runs, result.Error
+}
+
+// GetRun retrieves a payroll run with its payslips
+func (r *PayrollRepository) GetRun(ctx context.Context, id uint, userID uint) (*PayrollRun, error) {
+	var run PayrollRun
+	result := r.db.WithContext(ctx).Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("employee_name ASC")
+	}).Where("id = ? AND user_id = ?", id, userID).First(&run)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &run, nil
+}
+
+// CreateRun nets approved timesheet pay for the period against outstanding advances and
+// deductions. Amounts that exceed an employee's pay are carried forward to the next run.
+func (r *PayrollRepository) CreateRun(ctx context.Context, run *PayrollRun) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		err := tx.Model(&PayrollRun{}).Where("user_id = ? AND month = ?", run.UserID, run.Month).Count(&existing).Error
+		if err != nil {
+			return err
+		}
+		if existing > 0 {
+			return ErrPayrollRunExists
+		}
+
+		hours, err := approvedHours(tx, run.UserID, run.PeriodStart, run.PeriodEnd)
+		if err != nil {
+			return err
+		}
+
+		var adjustments []*EmployeeAdjustment
+		err = tx.Preload("Employee").
+			Where("user_id = ? AND payroll_run_id IS NULL AND date < ?", run.UserID, run.PeriodEnd).
+			Find(&adjustments).Error
+		if err != nil {
+			return err
+		}
+
+		lines := make(map[uint]*PayrollLine)
+		for _, h := range hours {
+			lines[h.EmployeeID] = &PayrollLine{
+				EmployeeID:   h.EmployeeID,
+				EmployeeName: h.Name,
+				Hours:        h.Hours,
+				GrossPay:     h.Amount,
+			}
+		}
+		for _, adjustment := range adjustments {
+			line, ok := lines[adjustment.EmployeeID]
+			if !ok {
+				line = &PayrollLine{
+					EmployeeID:   adjustment.EmployeeID,
+					EmployeeName: adjustment.Employee.Name,
+				}
+				lines[adjustment.EmployeeID] = line
+			}
+			if adjustment.Type == AdjustmentDeduction {
+				line.Deductions += adjustment.Amount
+			} else {
+				line.Advances += adjustment.Amount
+			}
+		}
+		if len(lines) == 0 {
+			return ErrPayrollRunEmpty
+		}
+
+		run.Lines = make([]PayrollLine, 0, len(lines))
+		for _, line := range lines {
+			line.UserID = run.UserID
+			line.NetPay = line.GrossPay - line.Advances - line.Deductions
+			if line.NetPay < 0 {
+				line.CarriedForward = -line.NetPay
+				line.NetPay = 0
+			}
+
+			run.TotalGross += line.GrossPay
+			run.TotalAdvances += line.Advances
+			run.TotalDeductions += line.Deductions
+			run.TotalNet += line.NetPay
+			run.Lines = append(run.Lines, *line)
+		}
+		sort.Slice(run.Lines, func(i, j int) bool {
+			return run.Lines[i].EmployeeName < run.Lines[j].EmployeeName
+		})
+
+		if err := tx.Create(run).Error; err != nil {
+			return err
+		}
+
+		if len(adjustments) > 0 {
+			ids := make([]uint, 0, len(adjustments))
+			for _, adjustment := range adjustments {
+				ids = append(ids, adjustment.ID)
+			}
+			err = tx.Model(&EmployeeAdjustment{}).Where("id IN ?", ids).Update("payroll_run_id", run.ID).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, line := range run.Lines {
+			if line.CarriedForward == 0 {
+				continue
+			}
+			carried := &EmployeeAdjustment{
+				EmployeeID:       line.EmployeeID,
+				Type:             AdjustmentAdvance,
+				Amount:           line.CarriedForward,
+				Date:             run.PeriodEnd,
+				Description:      fmt.Sprintf("Balance carried forward from payroll %s", run.Month),
+				CarriedFromRunID: &run.ID,
+				UserID:           run.UserID,
+			}
+			if err := tx.Omit("Employee").Create(carried).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// DeleteRun reverses a payroll run, releasing the adjustments it settled. Runs are removed
+// permanently so the month can be run again.
+func (r *PayrollRepository) DeleteRun(ctx context.Context, id uint, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var run PayrollRun
+		if err := tx.Where("id = ? AND user_id = ?", id, userID).First(&run).Error; err != nil {
+			return err
+		}
+
+		var settled int64
+		err := tx.Model(&EmployeeAdjustment{}).
+			Where("carried_from_run_id = ? AND payroll_run_id IS NOT NULL", run.ID).Count(&settled).Error
+		if err != nil {
+			return err
+		}
+		if settled > 0 {
+			return ErrPayrollRunLocked
+		}
+
+		if err := tx.Unscoped().Where("carried_from_run_id = ?", run.ID).Delete(&EmployeeAdjustment{}).Error; err != nil {
+			return err
+		}
+		err = tx.Model(&EmployeeAdjustment{}).Where("payroll_run_id = ?", run.ID).Update("payroll_run_id", nil).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("payroll_run_id = ?", run.ID).Delete(&PayrollLine{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&run).Error
+	})
+}
+
+// GetStatement builds the earnings, advances and deductions statement of an employee
+func (r *PayrollRepository) GetStatement(ctx context.Context, employeeID uint, userID uint) (*EmployeeStatement, error) {
+	var employee Employee
+	if err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", employeeID, userID).First(&employee).Error; err != nil {
+		return nil, err
+	}
+
+	adjustments, err := r.GetAdjustments(ctx, employeeID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var payslips []*PayrollLine
+	err = r.db.WithContext(ctx).Where("employee_id = ? AND user_id = ?", employeeID, userID).
+		Order("created_at DESC").Find(&payslips).Error
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &EmployeeStatement{
+		Employee:    &employee,
+		Adjustments: adjustments,
+		Payslips:    payslips,
+	}
+	for _, adjustment := range adjustments {
+		if adjustment.PayrollRunID == nil {
+			statement.Balance += adjustment.Amount
+		}
+		if adjustment.CarriedFromRunID != nil {
+			continue
+		}
+		if adjustment.Type == AdjustmentDeduction {
+			statement.TotalDeductions += adjustment.Amount
+		} else {
+			statement.TotalAdvances += adjustment.Amount
+		}
+	}
+	for _, payslip := range payslips {
+		statement.TotalEarned += payslip.GrossPay
+		statement.TotalPaid += payslip.NetPay
+	}
+
+	return statement, nil
+}
@@ -0,0 +1,325 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrProcurementReviewed is returned when changing or reviewing a request that is no longer pending
+	ErrProcurementReviewed = errors.New("procurement request has already been reviewed")
+	// ErrProcurementNotApproved is returned when ordering a request that isn't approved, or is
+	// already on an order
+	ErrProcurementNotApproved = errors.New("procurement request is not approved")
+	// ErrPurchaseOrderClosed is returned when cancelling or receiving an order that isn't open
+	ErrPurchaseOrderClosed = errors.New("purchase order is no longer open")
+)
+
+// ProcurementRepository implements ProcurementInterface using GORM
+type ProcurementRepository struct {
+	db *gorm.DB
+}
+
+// NewProcurementRepository creates a new instance of ProcurementRepository
+func NewProcurementRepository(db *gorm.DB) ProcurementInterface {
+	return &ProcurementRepository{db: db}
+}
+
+// GetRequests retrieves the procurement requests of a user's books, newest first. Only the
+// requests of requesterID and in status are included when they are set.
+func (r *ProcurementRepository) GetRequests(ctx context.Context, userID uint, requesterID *uint, status *ProcurementStatus) ([]*ProcurementRequest, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if requesterID != nil {
+		query = query.Where("requester_id = ?", *requesterID)
+	}
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+	var requests []*ProcurementRequest
+	result := query.Order("created_at DESC, id DESC").Find(&requests)
+	return requests, result.Error
+}
+
+// GetRequest retrieves a procurement request by ID for a user
+func (r *ProcurementRepository) GetRequest(ctx context.Context, id uint, userID uint) (*ProcurementRequest, error) {
+	var request ProcurementRequest
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&request)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &request, nil
+}
+
+// InsertRequest submits a new procurement request
+func (r *ProcurementRepository) InsertRequest(ctx context.Context, request *ProcurementRequest) (uint, error) {
+	request.Status = ProcurementPending
+	result := r.db.WithContext(ctx).Create(request)
+	return request.ID, result.Error
+}
+
+// UpdateRequest updates a procurement request. It returns ErrProcurementReviewed when the request
+// has been reviewed since it was read.
+func (r *ProcurementRepository) UpdateRequest(ctx context.Context, request *ProcurementRequest) error {
+	result := r.db.WithContext(ctx).Model(&ProcurementRequest{}).
+		Where("id = ? AND user_id = ? AND status = ?", request.ID, request.UserID, ProcurementPending).
+		Updates(map[string]interface{}{
+			"item_name":         request.ItemName,
+			"quantity":          request.Quantity,
+			"unit":              request.Unit,
+			"justification":     request.Justification,
+			"inventory_item_id": request.InventoryItemID,
+			"needed_by":         request.NeededBy,
+			"mine_site_id":      request.MineSiteID,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrProcurementReviewed
+	}
+	return nil
+}
+
+// DeleteRequest soft deletes a procurement request awaiting review, returning
+// ErrProcurementReviewed when it has been reviewed
+func (r *ProcurementRepository) DeleteRequest(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ? AND status = ?", id, userID, ProcurementPending).Delete(&ProcurementRequest{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrProcurementReviewed
+	}
+	return nil
+}
+
+// ReviewRequest approves or rejects a pending procurement request, with a reason when it is
+// rejected. It returns ErrProcurementReviewed when the request isn't pending.
+func (r *ProcurementRepository) ReviewRequest(ctx context.Context, id uint, userID uint, status ProcurementStatus, reviewerID uint, reason *string) error {
+	result := r.db.WithContext(ctx).Model(&ProcurementRequest{}).
+		Where("id = ? AND user_id = ? AND status = ?", id, userID, ProcurementPending).
+		Updates(map[string]interface{}{
+			"status":           status,
+			"reviewed_by_id":   reviewerID,
+			"reviewed_at":      time.Now(),
+			"rejection_reason": reason,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrProcurementReviewed
+	}
+	return nil
+}
+
+// GetOrders retrieves the purchase orders of a user's books, newest first, with their requests.
+// Only the orders in status are included when it is set.
+func (r *ProcurementRepository) GetOrders(ctx context.Context, userID uint, status *PurchaseOrderStatus) ([]*PurchaseOrder, error) {
+	query := r.db.WithContext(ctx).Preload("Requests").Where("user_id = ?", userID)
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+	var orders []*PurchaseOrder
+	result := query.Order("order_date DESC, id DESC").Find(&orders)
+	return orders, result.Error
+}
+
+// GetOrder retrieves a purchase order by ID for a user, with its requests and the expense it is
+// booked as
+func (r *ProcurementRepository) GetOrder(ctx context.Context, id uint, userID uint) (*PurchaseOrder, error) {
+	var order PurchaseOrder
+	result := r.db.WithContext(ctx).Preload("Requests").Preload("Expense").Where("id = ? AND user_id = ?", id, userID).First(&order)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &order, nil
+}
+
+// InsertOrder numbers and stores a purchase order for approved procurement requests, at the unit
+// prices agreed with the supplier, keyed by request ID. The requests are marked ordered and the
+// order totals them. It returns ErrProcurementNotApproved when a request isn't approved.
+func (r *ProcurementRepository) InsertOrder(ctx context.Context, order *PurchaseOrder, prices map[uint]float64) (uint, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ids := make([]uint, 0, len(prices))
+		for id := range prices {
+			ids = append(ids, id)
+		}
+		var requests []*ProcurementRequest
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ? AND user_id = ?", ids, order.UserID).Order("id ASC").Find(&requests).Error
+		if err != nil {
+			return err
+		}
+		if len(requests) != len(ids) {
+			return gorm.ErrRecordNotFound
+		}
+
+		order.TotalAmount = 0
+		for _, request := range requests {
+			if request.Status != ProcurementApproved {
+				return ErrProcurementNotApproved
+			}
+			order.TotalAmount += request.Quantity * prices[request.ID]
+		}
+		order.TotalAmount = math.Round(order.TotalAmount*100) / 100
+
+		number, err := nextDocumentNumber(tx, order.UserID, DocumentPurchaseOrder, order.OrderDate)
+		if err != nil {
+			return err
+		}
+		order.OrderNumber = number
+		order.Status = PurchaseOrderOpen
+		if err := tx.Omit("Requests", "Expense").Create(order).Error; err != nil {
+			return err
+		}
+
+		for _, request := range requests {
+			price := prices[request.ID]
+			request.Status = ProcurementOrdered
+			request.PurchaseOrderID = &order.ID
+			request.UnitPrice = &price
+			err := tx.Model(&ProcurementRequest{}).Where("id = ?", request.ID).Updates(map[string]interface{}{
+				"status":            request.Status,
+				"purchase_order_id": request.PurchaseOrderID,
+				"unit_price":        request.UnitPrice,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		order.Requests = requests
+		return nil
+	})
+	return order.ID, err
+}
+
+// CancelOrder cancels an open purchase order, returning its requests to approved so they can be
+// ordered again. It returns ErrPurchaseOrderClosed when the order isn't open.
+func (r *ProcurementRepository) CancelOrder(ctx context.Context, id uint, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&PurchaseOrder{}).
+			Where("id = ? AND user_id = ? AND status = ?", id, userID, PurchaseOrderOpen).
+			Update("status", PurchaseOrderCancelled)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPurchaseOrderClosed
+		}
+		return tx.Model(&ProcurementRequest{}).Where("purchase_order_id = ? AND user_id = ?", id, userID).
+			Updates(map[string]interface{}{
+				"status":            ProcurementApproved,
+				"purchase_order_id": nil,
+				"unit_price":        nil,
+			}).Error
+	})
+}
+
+// ReceiveOrder receives an open purchase order, booking it as an expense, which awaits sign-off
+// when its sign-off status is pending. The inventory items the requests are for are restocked
+// with the quantities ordered, at the prices agreed. It returns ErrPurchaseOrderClosed when the
+// order isn't open.
+func (r *ProcurementRepository) ReceiveOrder(ctx context.Context, id uint, userID uint, expense *Expense) (*PurchaseOrder, error) {
+	var order PurchaseOrder
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND user_id = ?", id, userID).First(&order).Error; err != nil {
+			return err
+		}
+		if order.Status != PurchaseOrderOpen {
+			return ErrPurchaseOrderClosed
+		}
+		if err := tx.Where("purchase_order_id = ?", order.ID).Order("id ASC").Find(&order.Requests).Error; err != nil {
+			return err
+		}
+
+		expense.Category = order.Category
+		expense.Description = fmt.Sprintf("Purchase order %s", order.OrderNumber)
+		expense.SupplierName = order.SupplierName
+		expense.SupplierContact = order.SupplierContact
+		expense.MineSiteID = order.MineSiteID
+		expense.UserID = userID
+		if err := createExpense(tx, expense); err != nil {
+			return err
+		}
+		if expense.AwaitingSignOff() {
+			if err := storeSignOffEvent(tx, OutboxSignOffRequired, expense); err != nil {
+				return err
+			}
+		}
+
+		for _, request := range order.Requests {
+			if request.InventoryItemID != nil {
+				value := 0.0
+				if request.UnitPrice != nil {
+					value = request.Quantity * *request.UnitPrice
+				}
+				if err := restockOrdered(tx, request, &order, value); err != nil {
+					return err
+				}
+			}
+			request.Status = ProcurementReceived
+		}
+		err := tx.Model(&ProcurementRequest{}).Where("purchase_order_id = ?", order.ID).
+			Update("status", ProcurementReceived).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		order.Status = PurchaseOrderReceived
+		order.ReceivedAt = &now
+		order.ExpenseID = &expense.ID
+		if err := tx.Omit("Requests", "Expense").Save(&order).Error; err != nil {
+			return err
+		}
+		order.Expense = expense
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// restockOrdered adds the quantity of a procurement request to the inventory item it is for, with
+// a purchase stock movement, when its order is received
+func restockOrdered(tx *gorm.DB, request *ProcurementRequest, order *PurchaseOrder, value float64) error {
+	var item InventoryItem
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND user_id = ?", *request.InventoryItemID, request.UserID).First(&item).Error; err != nil {
+		return err
+	}
+
+	reason := fmt.Sprintf("Purchase order %s", order.OrderNumber)
+	movement := &StockMovement{
+		InventoryItemID: item.ID,
+		Type:            StockMovementPurchase,
+		Quantity:        request.Quantity,
+		QuantityBefore:  item.Quantity,
+		QuantityAfter:   item.Quantity + request.Quantity,
+		Reason:          &reason,
+		UserID:          request.UserID,
+	}
+	if err := tx.Create(movement).Error; err != nil {
+		return err
+	}
+
+	item.Quantity = movement.QuantityAfter
+	item.CurrentValue += value
+	item.LastUpdated = time.Now()
+	err := tx.Model(&InventoryItem{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+		"quantity":      item.Quantity,
+		"current_value": item.CurrentValue,
+		"last_updated":  item.LastUpdated,
+	}).Error
+	if err != nil {
+		return err
+	}
+	return recordStock(tx, EventInventoryPurchased, &item, request.Quantity, &movement.ID)
+}
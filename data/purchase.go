@@ -0,0 +1,288 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PurchaseRepository implements PurchaseInterface using GORM
+type PurchaseRepository struct {
+	db *gorm.DB
+}
+
+// NewPurchaseRepository creates a new instance of PurchaseRepository
+func NewPurchaseRepository(db *gorm.DB) PurchaseInterface {
+	return &PurchaseRepository{db: db}
+}
+
+// GetAll retrieves all purchases of a user
+func (r *PurchaseRepository) GetAll(ctx context.Context, userID uint) ([]*Purchase, error) {
+	var purchases []*Purchase
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("date DESC, id DESC").Find(&purchases)
+	return purchases, result.Error
+}
+
+// GetPage retrieves a page of a user's purchases, newest first, with how many there are. Only the
+// purchases of the site siteID are included when it is set.
+func (r *PurchaseRepository) GetPage(ctx context.Context, userID uint, siteID *uint, offset, limit int) ([]*Purchase, int64, error) {
+	var purchases []*Purchase
+	query := scopeToSite(r.db.WithContext(ctx).Model(&Purchase{}).Where("user_id = ?", userID), siteID)
+	total, err := findPage(query, "date DESC, id DESC", offset, limit, &purchases)
+	return purchases, total, err
+}
+
+// GetForMiner retrieves the purchases from a registered miner, newest first
+func (r *PurchaseRepository) GetForMiner(ctx context.Context, userID uint, minerID uint) ([]*Purchase, error) {
+	var purchases []*Purchase
+	result := r.db.WithContext(ctx).Where("user_id = ? AND miner_id = ?", userID, minerID).Order("date DESC, id DESC").Find(&purchases)
+	return purchases, result.Error
+}
+
+// GetOne retrieves a purchase of a user
+func (r *PurchaseRepository) GetOne(ctx context.Context, id uint, userID uint) (*Purchase, error) {
+	var purchase Purchase
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&purchase)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &purchase, nil
+}
+
+// Insert records a purchase and receives its material into its inventory item. An amount paid
+// on the spot is recorded as the purchase's first payment, and a price override in the override
+// trail.
+func (r *PurchaseRepository) Insert(ctx context.Context, purchase *Purchase) (uint, error) {
+	purchase.TotalAmount = purchase.Quantity * purchase.PricePerUnit
+	purchase.AmountDue = purchase.TotalAmount - purchase.AmountPaid
+	purchase.PaymentStatus = settledStatus(purchase.AmountPaid, purchase.AmountDue)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(purchase).Error; err != nil {
+			return err
+		}
+		if err := recordPriceOverride(tx, purchase); err != nil {
+			return err
+		}
+		if purchase.AmountPaid > 0 {
+			err := tx.Create(&Payment{
+				RecordType: TransactionPurchase,
+				RecordID:   purchase.ID,
+				Amount:     purchase.AmountPaid,
+				Date:       purchase.Date,
+				TillID:     purchase.TillID,
+				UserID:     purchase.UserID,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return receiveStock(tx, purchase, purchase.Quantity, purchase.TotalAmount, StockMovementPurchase, nil, EventInventoryPurchased)
+	})
+	return purchase.ID, err
+}
+
+// Update updates a purchase, adjusting its inventory item by the change in quantity and value
+// and recording a price override in the override trail.
+// It returns ErrInsufficientStock when less is left in stock than the quantity removed, and
+// ErrOverpayment when the new total is less than what was already paid.
+func (r *PurchaseRepository) Update(ctx context.Context, purchase *Purchase) error {
+	purchase.TotalAmount = purchase.Quantity * purchase.PricePerUnit
+	purchase.AmountDue = purchase.TotalAmount - purchase.AmountPaid
+	if purchase.AmountDue < -paymentTolerance {
+		return ErrOverpayment
+	}
+	purchase.PaymentStatus = settledStatus(purchase.AmountPaid, purchase.AmountDue)
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before Purchase
+		if err := tx.Where("id = ? AND user_id = ?", purchase.ID, purchase.UserID).First(&before).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(purchase).Error; err != nil {
+			return err
+		}
+		if err := recordPriceOverride(tx, purchase); err != nil {
+			return err
+		}
+		change := purchase.Quantity - before.Quantity
+		value := purchase.TotalAmount - before.TotalAmount
+		if change == 0 && value == 0 {
+			return nil
+		}
+		reason := fmt.Sprintf("Purchase #%d edited", purchase.ID)
+		return receiveStock(tx, purchase, change, value, StockMovementAdjustment, &reason, EventInventoryUpdated)
+	})
+}
+
+// GetPriceOverrides retrieves the override trail of a purchase, newest first
+func (r *PurchaseRepository) GetPriceOverrides(ctx context.Context, userID uint, purchaseID uint) ([]*PurchasePriceOverride, error) {
+	var overrides []*PurchasePriceOverride
+	result := r.db.WithContext(ctx).Where("user_id = ? AND purchase_id = ?", userID, purchaseID).Order("created_at DESC, id DESC").Find(&overrides)
+	return overrides, result.Error
+}
+
+// recordPriceOverride records the override of a purchase being saved in its override trail
+func recordPriceOverride(tx *gorm.DB, purchase *Purchase) error {
+	override := purchase.Override
+	if override == nil || purchase.CalculatedPricePerUnit == nil {
+		return nil
+	}
+	override.PurchaseID = purchase.ID
+	override.CalculatedPricePerUnit = *purchase.CalculatedPricePerUnit
+	override.PricePerUnit = purchase.PricePerUnit
+	override.CalculatedAmount = purchase.Quantity * *purchase.CalculatedPricePerUnit
+	override.TotalAmount = purchase.TotalAmount
+	override.UserID = purchase.UserID
+	return tx.Create(override).Error
+}
+
+// Delete soft deletes a purchase and takes its material back out of its inventory item. It
+// returns ErrInsufficientStock when less is left in stock than was purchased.
+func (r *PurchaseRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var purchase Purchase
+		if err := tx.Where("id = ? AND user_id = ?", id, userID).First(&purchase).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&purchase).Error; err != nil {
+			return err
+		}
+		reason := fmt.Sprintf("Purchase #%d deleted", purchase.ID)
+		return receiveStock(tx, &purchase, -purchase.Quantity, -purchase.TotalAmount, StockMovementAdjustment, &reason, EventInventoryUpdated)
+	})
+}
+
+// GetPayables retrieves what is owed to each miner a user bought from, most owed first. With
+// outstandingOnly, miners who have been paid in full are left out.
+func (r *PurchaseRepository) GetPayables(ctx context.Context, userID uint, outstandingOnly bool) ([]*MinerPayable, error) {
+	var purchases []*Purchase
+	err := r.db.WithContext(ctx).Select("miner_name, date, total_amount, amount_paid, amount_due").
+		Where("user_id = ?", userID).Find(&purchases).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Totalled here rather than in SQL, as SQLite returns MAX(date) as text
+	byMiner := make(map[string]*MinerPayable)
+	payables := make([]*MinerPayable, 0)
+	for _, purchase := range purchases {
+		payable, ok := byMiner[purchase.MinerName]
+		if !ok {
+			payable = &MinerPayable{MinerName: purchase.MinerName}
+			byMiner[purchase.MinerName] = payable
+			payables = append(payables, payable)
+		}
+		payable.Purchases++
+		payable.TotalAmount += purchase.TotalAmount
+		payable.AmountPaid += purchase.AmountPaid
+		payable.AmountDue += purchase.AmountDue
+		if purchase.Date.After(payable.LastPurchase) {
+			payable.LastPurchase = purchase.Date
+		}
+	}
+
+	if outstandingOnly {
+		outstanding := payables[:0]
+		for _, payable := range payables {
+			if payable.AmountDue > paymentTolerance {
+				outstanding = append(outstanding, payable)
+			}
+		}
+		payables = outstanding
+	}
+	sort.Slice(payables, func(i, j int) bool {
+		if payables[i].AmountDue != payables[j].AmountDue {
+			return payables[i].AmountDue > payables[j].AmountDue
+		}
+		return payables[i].MinerName < payables[j].MinerName
+	})
+	return payables, nil
+}
+
+// GetStatement builds the statement of the purchases from a miner and the payments made on them
+func (r *PurchaseRepository) GetStatement(ctx context.Context, userID uint, minerName string) (*MinerStatement, error) {
+	var purchases []*Purchase
+	err := r.db.WithContext(ctx).Where("user_id = ? AND miner_name = ?", userID, minerName).
+		Order("date ASC, id ASC").Find(&purchases).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(purchases) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	ids := make([]uint, len(purchases))
+	for i, purchase := range purchases {
+		ids[i] = purchase.ID
+	}
+	var payments []*Payment
+	err = r.db.WithContext(ctx).Where("user_id = ? AND record_type = ? AND record_id IN ?", userID, TransactionPurchase, ids).
+		Order("date ASC, id ASC").Find(&payments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &MinerStatement{
+		MinerName: minerName,
+		Purchases: purchases,
+		Payments:  payments,
+	}
+	for _, purchase := range purchases {
+		statement.TotalAmount += purchase.TotalAmount
+		statement.TotalPaid += purchase.AmountPaid
+	}
+	statement.Balance = statement.TotalAmount - statement.TotalPaid
+	return statement, nil
+}
+
+// receiveStock changes the quantity and value of a purchase's inventory item, recording the
+// stock movement and its inventory event. Stock can't go below zero; the value is floored at
+// zero, as stock may have been revalued since the purchase.
+func receiveStock(tx *gorm.DB, purchase *Purchase, change float64, value float64, movementType StockMovementType, reason *string, eventType StreamEventType) error {
+	var item InventoryItem
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND user_id = ?", purchase.InventoryItemID, purchase.UserID).First(&item).Error; err != nil {
+		return err
+	}
+	if item.Quantity+change < 0 {
+		return ErrInsufficientStock
+	}
+
+	movement := &StockMovement{
+		InventoryItemID: item.ID,
+		Type:            movementType,
+		Quantity:        change,
+		QuantityBefore:  item.Quantity,
+		QuantityAfter:   item.Quantity + change,
+		PurchaseID:      &purchase.ID,
+		Reason:          reason,
+		UserID:          purchase.UserID,
+	}
+	if change != 0 {
+		if err := tx.Create(movement).Error; err != nil {
+			return err
+		}
+	}
+
+	item.Quantity = movement.QuantityAfter
+	item.CurrentValue = math.Max(item.CurrentValue+value, 0)
+	item.LastUpdated = time.Now()
+	err := tx.Model(&InventoryItem{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+		"quantity":      item.Quantity,
+		"current_value": item.CurrentValue,
+		"last_updated":  item.LastUpdated,
+	}).Error
+	if err != nil {
+		return err
+	}
+	var movementID *uint
+	if movement.ID != 0 {
+		movementID = &movement.ID
+	}
+	return recordStock(tx, eventType, &item, change, movementID)
+}
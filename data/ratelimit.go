@@ -0,0 +1,43 @@
+package data
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RateLimitRepository implements RateLimitInterface using GORM
+type RateLimitRepository struct {
+	db *gorm.DB
+}
+
+// NewRateLimitRepository creates a new instance of RateLimitRepository
+func NewRateLimitRepository(db *gorm.DB) RateLimitInterface {
+	return &RateLimitRepository{db: db}
+}
+
+// Hit adds a hit to the named counter for the window starting at windowStart (unix time) and
+// returns the hits counted in the window so far. The upsert locks the counter until the
+// transaction ends, so concurrent hits from every replica are all counted.
+func (r *RateLimitRepository) Hit(ctx context.Context, name string, windowStart int64) (int64, error) {
+	var hits int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		bucket := &RateLimitBucket{Name: name, WindowStart: windowStart, Hits: 1}
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}, {Name: "window_start"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"hits": gorm.Expr("rate_limit_buckets.hits + 1")}),
+		}).Create(bucket).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&RateLimitBucket{}).Where("name = ? AND window_start = ?", name, windowStart).
+			Select("hits").Scan(&hits).Error
+	})
+	return hits, err
+}
+
+// DeleteBefore removes the counters of windows that started before windowStart (unix time)
+func (r *RateLimitRepository) DeleteBefore(ctx context.Context, windowStart int64) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().Where("window_start < ?", windowStart).Delete(&RateLimitBucket{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,72 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReceiptRepository implements ReceiptInterface using GORM
+type ReceiptRepository struct {
+	db *gorm.DB
+}
+
+// NewReceiptRepository creates a new instance of ReceiptRepository
+func NewReceiptRepository(db *gorm.DB) ReceiptInterface {
+	return &ReceiptRepository{db: db}
+}
+
+// GetAll retrieves all receipts for a user
+func (r *ReceiptRepository) GetAll(ctx context.Context, userID uint) ([]*Receipt, error) {
+	var receipts []*Receipt
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("paid_at DESC, id DESC").Find(&receipts)
+	return receipts, result.Error
+}
+
+// GetOne retrieves a receipt by ID for a user
+func (r *ReceiptRepository) GetOne(ctx context.Context, id uint, userID uint) (*Receipt, error) {
+	var receipt Receipt
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&receipt)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &receipt, nil
+}
+
+// GetByID retrieves a receipt by ID regardless of owner, for public verification
+func (r *ReceiptRepository) GetByID(ctx context.Context, id uint) (*Receipt, error) {
+	var receipt Receipt
+	result := r.db.WithContext(ctx).First(&receipt, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &receipt, nil
+}
+
+// GetByIncome retrieves the receipts issued for an income record
+func (r *ReceiptRepository) GetByIncome(ctx context.Context, incomeID uint, userID uint) ([]*Receipt, error) {
+	var receipts []*Receipt
+	result := r.db.WithContext(ctx).Where("income_id = ? AND user_id = ?", incomeID, userID).Order("paid_at, id").Find(&receipts)
+	return receipts, result.Error
+}
+
+// Insert numbers and creates a new receipt, taking the next receipt number in the same
+// transaction so a failed insert leaves no gap in the numbering
+func (r *ReceiptRepository) Insert(ctx context.Context, receipt *Receipt) (uint, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		number, err := nextDocumentNumber(tx, receipt.UserID, DocumentReceipt, receipt.PaidAt)
+		if err != nil {
+			return err
+		}
+		receipt.ReceiptNumber = number
+		return tx.Create(receipt).Error
+	})
+	return receipt.ID, err
+}
+
+// MarkSent records that a receipt was sent to the customer
+func (r *ReceiptRepository) MarkSent(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Model(&Receipt{}).Where("id = ? AND user_id = ?", id, userID).Update("sent_at", time.Now())
+	return result.Error
+}
@@ -0,0 +1,47 @@
+package data
+
+// Values offered to clients by the reference data endpoint. New enum values must be added
+// here to appear in client pickers.
+var (
+	MineralTypes = []MineralType{
+		MineralGold, MineralCopper, MineralCobalt, MineralDiamond, MineralIronOre, MineralLead,
+		MineralZinc, MineralLithium, MineralNickel, MineralColtan, MineralTin, MineralWolfram,
+		MineralTitanium, MineralManganese, MineralRareEarthElements, MineralUranium,
+		MineralBentonite, MineralDiatomite, MineralGraphite, MineralGypsum, MineralFeldspar,
+		MineralLimestone, MineralMarble, MineralKaolin, MineralPhosphates, MineralPozzolana,
+		MineralSalt, MineralSand, MineralVermiculite, MineralSilver, MineralGranite,
+		MineralChromite, MineralGemstones, MineralOther,
+	}
+	GemstoneTypes = []GemstoneType{
+		GemstoneApatite, GemstoneBeryl, GemstoneAquamarine, GemstoneRuby, GemstoneSapphire,
+		GemstoneFlourite, GemstoneGarnet, GemstoneOpal, GemstoneQuartz, GemstoneTopaz,
+		GemstoneTourmaline, GemstoneZircon,
+	}
+	SalesTypes = []SalesType{
+		SalesTypeMineral, SalesTypeSupply, SalesTypeConcentrates, SalesTypeTailings,
+	}
+	ExpenseCategories = []ExpenseCategory{
+		ExpenseEquipment, ExpenseLabor, ExpenseChemicals, ExpenseFuel, ExpenseMaintenance,
+		ExpenseTransport, ExpenseOther,
+	}
+	PaymentStatuses = []PaymentStatus{
+		PaymentPaid, PaymentUnpaid, PaymentPartial,
+	}
+	InventoryTypes = []string{
+		"mineral", "supply",
+	}
+	ProductionSources = []ProductionFrom{
+		ProductionFromMine, ProductionFromProcessing,
+	}
+	ProcessingMethods = []ProcessingMethod{
+		ProcessingCrushing, ProcessingMilling, ProcessingSieving, ProcessingGrading,
+		ProcessingSorting, ProcessingCutting, ProcessingDressing, ProcessingLeaching,
+		ProcessingElution, ProcessingRefining, ProcessingFloatation, ProcessingGrinding,
+		ProcessingScreening, ProcessingDrying, ProcessingExfoliation, ProcessingPolishing,
+		ProcessingWashing,
+	}
+	// Units are free text on records; these are the suggested ones
+	Units = []string{
+		"grams", "kilograms", "tonnes", "carats", "troy_ounces", "pieces", "liters", "bags",
+	}
+)
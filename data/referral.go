@@ -0,0 +1,114 @@
+package data
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrReferralCodeInvalid is returned when a referral code is unknown
+var ErrReferralCodeInvalid = errors.New("invalid referral code")
+
+// activeUserQuery reports whether a user has recorded a sale or expense
+const activeUserQuery = `(EXISTS (SELECT 1 FROM incomes WHERE incomes.user_id = referrals.referred_user_id AND incomes.deleted_at IS NULL)
+	OR EXISTS (SELECT 1 FROM expenses WHERE expenses.user_id = referrals.referred_user_id AND expenses.deleted_at IS NULL))`
+
+// ReferralRepository implements ReferralInterface using GORM
+type ReferralRepository struct {
+	db *gorm.DB
+}
+
+// NewReferralRepository creates a new instance of ReferralRepository
+func NewReferralRepository(db *gorm.DB) ReferralInterface {
+	return &ReferralRepository{db: db}
+}
+
+// GetOrCreateCode retrieves the referral code of a user, generating one on first use
+func (r *ReferralRepository) GetOrCreateCode(ctx context.Context, userID uint) (*ReferralCode, error) {
+	var code ReferralCode
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&code).Error
+	if err == nil {
+		return &code, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	generated, err := generateReferralCode()
+	if err != nil {
+		return nil, err
+	}
+	code = ReferralCode{Code: generated, UserID: userID}
+	// A concurrent request may have created the code first
+	if err := r.db.WithContext(ctx).Where(ReferralCode{UserID: userID}).FirstOrCreate(&code).Error; err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+// GetByCode retrieves a referral code, ignoring case and surrounding spaces
+func (r *ReferralRepository) GetByCode(ctx context.Context, code string) (*ReferralCode, error) {
+	var referralCode ReferralCode
+	err := r.db.WithContext(ctx).Where("code = ?", strings.ToUpper(strings.TrimSpace(code))).First(&referralCode).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrReferralCodeInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &referralCode, nil
+}
+
+// Attribute records the referral of a new user
+func (r *ReferralRepository) Attribute(ctx context.Context, referral *Referral) error {
+	return r.db.WithContext(ctx).Create(referral).Error
+}
+
+// GetReferredUsers retrieves the users referred by a user, newest first
+func (r *ReferralRepository) GetReferredUsers(ctx context.Context, referrerID uint) ([]*ReferredUser, error) {
+	var referred []*ReferredUser
+	err := r.db.WithContext(ctx).Table("referrals").
+		Select("referrals.referred_user_id AS user_id, users.name, referrals.created_at AS joined_at, "+activeUserQuery+" AS active").
+		Joins("JOIN users ON users.id = referrals.referred_user_id").
+		Where("referrals.referrer_id = ? AND referrals.deleted_at IS NULL", referrerID).
+		Order("referrals.created_at DESC").
+		Scan(&referred).Error
+	return referred, err
+}
+
+// GetReferrers retrieves the users with referrals in a period, most referrals first. Nil bounds
+// are open.
+func (r *ReferralRepository) GetReferrers(ctx context.Context, from, to *time.Time) ([]*ReferrerStats, error) {
+	query := r.db.WithContext(ctx).Table("referrals").
+		Select("referrals.referrer_id AS user_id, users.name, users.email, COALESCE(users.phone, '') AS phone, referral_codes.code, " +
+			"COUNT(*) AS referred, COUNT(*) FILTER (WHERE " + activeUserQuery + ") AS active").
+		Joins("JOIN users ON users.id = referrals.referrer_id").
+		Joins("LEFT JOIN referral_codes ON referral_codes.user_id = referrals.referrer_id").
+		Where("referrals.deleted_at IS NULL")
+	if from != nil {
+		query = query.Where("referrals.created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("referrals.created_at < ?", *to)
+	}
+
+	var referrers []*ReferrerStats
+	err := query.Group("referrals.referrer_id, users.name, users.email, users.phone, referral_codes.code").
+		Order("referred DESC, active DESC").
+		Scan(&referrers).Error
+	return referrers, err
+}
+
+// generateReferralCode generates a random 8-character code
+func generateReferralCode() (string, error) {
+	b := make([]byte, 5)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b), nil
+}
@@ -0,0 +1,96 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrRefreshTokenInvalid is returned when a refresh token is unknown, expired, revoked or was
+// already used
+var ErrRefreshTokenInvalid = errors.New("invalid or expired refresh token")
+
+// RefreshTokenRepository implements RefreshTokenInterface using GORM
+type RefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewRefreshTokenRepository creates a new instance of RefreshTokenRepository
+func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenInterface {
+	return &RefreshTokenRepository{db: db}
+}
+
+// Insert stores a newly issued refresh token
+func (r *RefreshTokenRepository) Insert(ctx context.Context, token *RefreshToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+// Rotate uses the refresh token with the hash tokenHash, revoking it and storing replacement,
+// issued to the same user, in its place. It returns the used token. A token that was already
+// rotated being used again means it was copied, so every refresh token of its user is revoked
+// and the user has to sign in again.
+func (r *RefreshTokenRepository) Rotate(ctx context.Context, tokenHash string, replacement *RefreshToken) (*RefreshToken, error) {
+	var token RefreshToken
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRefreshTokenInvalid
+		}
+		return nil, err
+	}
+	if token.RevokedAt != nil {
+		if token.ReplacedByID != nil {
+			if err := r.RevokeAllForUser(ctx, token.UserID); err != nil {
+				return nil, err
+			}
+		}
+		return nil, ErrRefreshTokenInvalid
+	}
+	if !token.ExpiresAt.After(time.Now()) {
+		return nil, ErrRefreshTokenInvalid
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The revocation is conditional so two concurrent refreshes can't both use the token
+		result := tx.Model(&RefreshToken{}).Where("id = ? AND revoked_at IS NULL", token.ID).Update("revoked_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRefreshTokenInvalid
+		}
+
+		replacement.UserID = token.UserID
+		if err := tx.Create(replacement).Error; err != nil {
+			return err
+		}
+		return tx.Model(&RefreshToken{}).Where("id = ?", token.ID).Update("replaced_by_id", replacement.ID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// Revoke revokes a refresh token of a user, e.g. on logout. Unknown tokens are ignored.
+func (r *RefreshTokenRepository) Revoke(ctx context.Context, tokenHash string, userID uint) error {
+	return r.db.WithContext(ctx).Model(&RefreshToken{}).
+		Where("token_hash = ? AND user_id = ? AND revoked_at IS NULL", tokenHash, userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeAllForUser revokes every refresh token of a user, e.g. when their password is reset
+func (r *RefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Model(&RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// DeleteExpiredBefore deletes the refresh tokens that expired before t, used or not, returning
+// how many were deleted
+func (r *RefreshTokenRepository) DeleteExpiredBefore(ctx context.Context, t time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at < ?", t).Delete(&RefreshToken{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,39 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RevokedTokenRepository implements RevokedTokenInterface using GORM
+type RevokedTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewRevokedTokenRepository creates a new instance of RevokedTokenRepository
+func NewRevokedTokenRepository(db *gorm.DB) RevokedTokenInterface {
+	return &RevokedTokenRepository{db: db}
+}
+
+// Revoke records an access token as revoked. Revoking a token twice is not an error.
+func (r *RevokedTokenRepository) Revoke(ctx context.Context, token *RevokedToken) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "token_hash"}}, DoNothing: true}).
+		Create(token).Error
+}
+
+// IsRevoked reports whether the access token with the hash tokenHash was revoked
+func (r *RevokedTokenRepository) IsRevoked(ctx context.Context, tokenHash string) (bool, error) {
+	var count int64
+	result := r.db.WithContext(ctx).Model(&RevokedToken{}).Where("token_hash = ?", tokenHash).Count(&count)
+	return count > 0, result.Error
+}
+
+// DeleteExpiredBefore deletes the revoked tokens that expired before t, which are rejected as
+// expired anyway, returning how many were deleted
+func (r *RevokedTokenRepository) DeleteExpiredBefore(ctx context.Context, t time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at < ?", t).Delete(&RevokedToken{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,48 @@
+package data
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// nextDocumentNumber takes the next number of a user's documents of a kind and formats it for
+// date. It must be called in the transaction that stores the document: the sequence row stays
+// locked until it commits, and a rolled back document gives its number back.
+func nextDocumentNumber(tx *gorm.DB, userID uint, kind DocumentKind, date time.Time) (string, error) {
+	settings := DefaultOrganizationSettings(userID)
+	if err := tx.Where("user_id = ?", userID).First(settings).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", err
+	}
+
+	sequence, err := lockSequence(tx, userID, kind)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// The first document of the kind starts the sequence at the next number in settings
+		first := &DocumentSequence{Kind: kind, NextNumber: settings.FirstNumber(kind), UserID: userID}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(first).Error; err != nil {
+			return "", err
+		}
+		sequence, err = lockSequence(tx, userID, kind)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	number := settings.FormatDocumentNumber(kind, sequence.NextNumber, date)
+	if err := tx.Model(sequence).Update("next_number", sequence.NextNumber+1).Error; err != nil {
+		return "", err
+	}
+	return number, nil
+}
+
+// lockSequence retrieves the sequence of a user's documents of a kind, locked for update
+func lockSequence(tx *gorm.DB, userID uint, kind DocumentKind) (*DocumentSequence, error) {
+	var sequence DocumentSequence
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ? AND kind = ?", userID, kind).First(&sequence).Error
+	if err != nil {
+		return nil, err
+	}
+	return &sequence, nil
+}
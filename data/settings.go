@@ -0,0 +1,228 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// invoiceTokenPattern matches the placeholders supported in invoice, receipt and credit note number formats
+var invoiceTokenPattern = regexp.MustCompile(`\{(YYYY|YY|MM|SEQ)(?::(\d))?\}`)
+
+// DefaultOrganizationSettings returns the settings used until an organization saves its own
+func DefaultOrganizationSettings(userID uint) *OrganizationSettings {
+	return &OrganizationSettings{
+		FiscalYearStartMonth: 1,
+		DefaultCurrency:      "UGX",
+		DefaultUnits:         map[string]string{},
+		InvoiceNumberFormat:  "INV-{YYYY}-{SEQ:4}",
+		NextInvoiceNumber:    1,
+		ReceiptNumberFormat:  "RCT-{YYYY}-{SEQ:4}",
+		NextReceiptNumber:    1,
+		CreditNoteFormat:     "CN-{YYYY}-{SEQ:4}",
+		NextCreditNoteNumber: 1,
+		PriceVariancePercent: 10,
+		RainySeasonMonths:    slices.Clone(DefaultRainySeasonMonths),
+		UserID:               userID,
+	}
+}
+
+// PurchaseOrderNumberFormat is the number format of purchase orders, which number from 1
+const PurchaseOrderNumberFormat = "PO-{YYYY}-{SEQ:4}"
+
+// DefaultRainySeasonMonths are the months of the two rainy seasons in Uganda, March to May and
+// September to November
+var DefaultRainySeasonMonths = []int{3, 4, 5, 9, 10, 11}
+
+// IsRainyMonth reports whether a month is in a rainy season
+func (s *OrganizationSettings) IsRainyMonth(month time.Month) bool {
+	months := s.RainySeasonMonths
+	if len(months) == 0 {
+		months = DefaultRainySeasonMonths
+	}
+	return slices.Contains(months, int(month))
+}
+
+// RainySeasonLength returns how many months the rainy season continues from the month of start,
+// that month included, or 0 when it isn't in a rainy season. A season lasts at most a year.
+func (s *OrganizationSettings) RainySeasonLength(start time.Time) int {
+	months := 0
+	for months < 12 && s.IsRainyMonth(start.AddDate(0, months, 0).Month()) {
+		months++
+	}
+	return months
+}
+
+// NextRainySeason returns the first month of the next rainy season starting after the month of
+// t, or the month after t when every month is rainy
+func (s *OrganizationSettings) NextRainySeason(t time.Time) time.Time {
+	month := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	for i := 1; i <= 12; i++ {
+		start := month.AddDate(0, i, 0)
+		if s.IsRainyMonth(start.Month()) && !s.IsRainyMonth(start.AddDate(0, -1, 0).Month()) {
+			return start
+		}
+	}
+	return month.AddDate(0, 1, 0)
+}
+
+// DefaultUnit returns the configured unit for a mineral type, or an empty string
+func (s *OrganizationSettings) DefaultUnit(mineralType MineralType) string {
+	return s.DefaultUnits[string(mineralType)]
+}
+
+// FiscalYearStart returns the first day of the fiscal year containing t
+func (s *OrganizationSettings) FiscalYearStart(t time.Time) time.Time {
+	startMonth := time.Month(s.FiscalYearStartMonth)
+	if startMonth < time.January || startMonth > time.December {
+		startMonth = time.January
+	}
+	year := t.Year()
+	if t.Month() < startMonth {
+		year--
+	}
+	return time.Date(year, startMonth, 1, 0, 0, 0, 0, t.Location())
+}
+
+// FiscalYearLabel names the fiscal year starting at start, e.g. FY2025 or FY2025/26
+func (s *OrganizationSettings) FiscalYearLabel(start time.Time) string {
+	if start.Month() == time.January {
+		return fmt.Sprintf("FY%d", start.Year())
+	}
+	return fmt.Sprintf("FY%d/%02d", start.Year(), (start.Year()+1)%100)
+}
+
+// FormatInvoiceNumber renders the invoice number format for a sequence number and date.
+// Supported placeholders are {YYYY}, {YY}, {MM} and {SEQ}, with {SEQ:n} zero padding to n digits.
+func (s *OrganizationSettings) FormatInvoiceNumber(seq int, date time.Time) string {
+	return formatDocumentNumber(s.InvoiceNumberFormat, seq, date)
+}
+
+// FormatReceiptNumber renders the receipt number format for a sequence number and date,
+// with the same placeholders as invoice numbers
+func (s *OrganizationSettings) FormatReceiptNumber(seq int, date time.Time) string {
+	return formatDocumentNumber(s.ReceiptNumberFormat, seq, date)
+}
+
+// FormatCreditNoteNumber renders the credit note number format for a sequence number and date,
+// with the same placeholders as invoice numbers
+func (s *OrganizationSettings) FormatCreditNoteNumber(seq int, date time.Time) string {
+	return formatDocumentNumber(s.CreditNoteFormat, seq, date)
+}
+
+// FormatDocumentNumber renders the number format of a kind of document for a sequence number and date
+func (s *OrganizationSettings) FormatDocumentNumber(kind DocumentKind, seq int, date time.Time) string {
+	switch kind {
+	case DocumentReceipt:
+		return s.FormatReceiptNumber(seq, date)
+	case DocumentCreditNote:
+		return s.FormatCreditNoteNumber(seq, date)
+	case DocumentPurchaseOrder:
+		return formatDocumentNumber(PurchaseOrderNumberFormat, seq, date)
+	}
+	return s.FormatInvoiceNumber(seq, date)
+}
+
+// FirstNumber returns the number the sequence of a kind of document starts at
+func (s *OrganizationSettings) FirstNumber(kind DocumentKind) int {
+	switch kind {
+	case DocumentReceipt:
+		return s.NextReceiptNumber
+	case DocumentCreditNote:
+		return s.NextCreditNoteNumber
+	case DocumentPurchaseOrder:
+		return 1
+	}
+	return s.NextInvoiceNumber
+}
+
+// formatDocumentNumber renders a document number format for a sequence number and date
+func formatDocumentNumber(format string, seq int, date time.Time) string {
+	return invoiceTokenPattern.ReplaceAllStringFunc(format, func(token string) string {
+		parts := invoiceTokenPattern.FindStringSubmatch(token)
+		switch parts[1] {
+		case "YYYY":
+			return date.Format("2006")
+		case "YY":
+			return date.Format("06")
+		case "MM":
+			return date.Format("01")
+		}
+		if parts[2] != "" {
+			width, _ := strconv.Atoi(parts[2])
+			return fmt.Sprintf("%0*d", width, seq)
+		}
+		return strconv.Itoa(seq)
+	})
+}
+
+// ValidInvoiceNumberFormat reports whether an invoice, receipt or credit note number format contains a sequence placeholder
+func ValidInvoiceNumberFormat(format string) bool {
+	for _, parts := range invoiceTokenPattern.FindAllStringSubmatch(format, -1) {
+		if parts[1] == "SEQ" {
+			return true
+		}
+	}
+	return false
+}
+
+// SettingsRepository implements SettingsInterface using GORM
+type SettingsRepository struct {
+	db *gorm.DB
+}
+
+// NewSettingsRepository creates a new instance of SettingsRepository
+func NewSettingsRepository(db *gorm.DB) SettingsInterface {
+	return &SettingsRepository{db: db}
+}
+
+// GetByUserID retrieves the organization settings of a user, falling back to the defaults
+func (r *SettingsRepository) GetByUserID(ctx context.Context, userID uint) (*OrganizationSettings, error) {
+	var settings OrganizationSettings
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&settings)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return DefaultOrganizationSettings(userID), nil
+		}
+		return nil, result.Error
+	}
+	if settings.DefaultUnits == nil {
+		settings.DefaultUnits = map[string]string{}
+	}
+	return &settings, nil
+}
+
+// GetByCalendarToken retrieves the organization settings with a calendar feed token
+func (r *SettingsRepository) GetByCalendarToken(ctx context.Context, token string) (*OrganizationSettings, error) {
+	var settings OrganizationSettings
+	result := r.db.WithContext(ctx).Where("calendar_token = ?", token).First(&settings)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &settings, nil
+}
+
+// Save creates or updates the organization settings of a user
+func (r *SettingsRepository) Save(ctx context.Context, settings *OrganizationSettings) error {
+	result := r.db.WithContext(ctx).Save(settings)
+	return result.Error
+}
+
+// GetSequences returns the next number of each kind of document of a user that has been numbered
+// at least once; the other kinds start at the next number in settings
+func (r *SettingsRepository) GetSequences(ctx context.Context, userID uint) (map[DocumentKind]int, error) {
+	var sequences []*DocumentSequence
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&sequences).Error; err != nil {
+		return nil, err
+	}
+	next := make(map[DocumentKind]int, len(sequences))
+	for _, sequence := range sequences {
+		next[sequence.Kind] = sequence.NextNumber
+	}
+	return next, nil
+}
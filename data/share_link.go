@@ -0,0 +1,115 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrShareLinkUnavailable is returned when a public link is revoked or expired
+	ErrShareLinkUnavailable = errors.New("link is no longer available")
+	// ErrShareLinkConfirmed is returned when a document was already confirmed through its link
+	ErrShareLinkConfirmed = errors.New("document already confirmed")
+)
+
+// ShareLinkRepository implements ShareLinkInterface using GORM
+type ShareLinkRepository struct {
+	db *gorm.DB
+}
+
+// NewShareLinkRepository creates a new instance of ShareLinkRepository
+func NewShareLinkRepository(db *gorm.DB) ShareLinkInterface {
+	return &ShareLinkRepository{db: db}
+}
+
+// GetAll retrieves all share links for a user
+func (r *ShareLinkRepository) GetAll(ctx context.Context, userID uint) ([]*ShareLink, error) {
+	var links []*ShareLink
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&links)
+	return links, result.Error
+}
+
+// GetOne retrieves a share link by ID for a user
+func (r *ShareLinkRepository) GetOne(ctx context.Context, id uint, userID uint) (*ShareLink, error) {
+	var link ShareLink
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&link)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &link, nil
+}
+
+// GetActive retrieves a share link for public viewing, failing if it is revoked or expired
+func (r *ShareLinkRepository) GetActive(ctx context.Context, id uint) (*ShareLink, error) {
+	var link ShareLink
+	result := r.db.WithContext(ctx).First(&link, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if link.RevokedAt != nil || (link.ExpiresAt != nil && link.ExpiresAt.Before(time.Now())) {
+		return nil, ErrShareLinkUnavailable
+	}
+	return &link, nil
+}
+
+// Insert creates a new share link
+func (r *ShareLinkRepository) Insert(ctx context.Context, link *ShareLink) (uint, error) {
+	result := r.db.WithContext(ctx).Create(link)
+	return link.ID, result.Error
+}
+
+// Revoke revokes a share link so it can no longer be viewed
+func (r *ShareLinkRepository) Revoke(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Model(&ShareLink{}).Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// RecordView records a view of a share link and updates its view count
+func (r *ShareLinkRepository) RecordView(ctx context.Context, id uint, ipAddress string, userAgent *string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		view := ShareLinkView{ShareLinkID: id, IPAddress: ipAddress, UserAgent: userAgent}
+		if err := tx.Create(&view).Error; err != nil {
+			return err
+		}
+		return tx.Model(&ShareLink{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"view_count":     gorm.Expr("view_count + 1"),
+			"last_viewed_at": view.CreatedAt,
+		}).Error
+	})
+}
+
+// GetViews retrieves the views of a user's share link
+func (r *ShareLinkRepository) GetViews(ctx context.Context, id uint, userID uint) ([]*ShareLinkView, error) {
+	if _, err := r.GetOne(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	var views []*ShareLinkView
+	result := r.db.WithContext(ctx).Where("share_link_id = ?", id).Order("created_at DESC").Find(&views)
+	return views, result.Error
+}
+
+// Confirm records the customer's confirmation of the linked document
+func (r *ShareLinkRepository) Confirm(ctx context.Context, id uint, confirmedBy string) error {
+	result := r.db.WithContext(ctx).Model(&ShareLink{}).Where("id = ? AND confirmed_at IS NULL", id).Updates(map[string]interface{}{
+		"confirmed_at": time.Now(),
+		"confirmed_by": confirmedBy,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrShareLinkConfirmed
+	}
+	return nil
+}
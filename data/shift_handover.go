@@ -0,0 +1,101 @@
+package data
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrShiftHandoverPending is returned when handing over a till or station whose last handover
+	// hasn't been signed off
+	ErrShiftHandoverPending = errors.New("a handover of the till or station is waiting to be signed off")
+	// ErrShiftHandoverSignedOff is returned when signing off a handover that has been signed off
+	ErrShiftHandoverSignedOff = errors.New("the handover has already been signed off")
+)
+
+// ShiftHandoverRepository implements ShiftHandoverInterface using GORM
+type ShiftHandoverRepository struct {
+	db *gorm.DB
+}
+
+// NewShiftHandoverRepository creates a new instance of ShiftHandoverRepository
+func NewShiftHandoverRepository(db *gorm.DB) ShiftHandoverInterface {
+	return &ShiftHandoverRepository{db: db}
+}
+
+// GetAll retrieves the shift handovers of a user, newest first. Only the handovers of the station
+// siteID, of the till tillID and with status are included when they are set.
+func (r *ShiftHandoverRepository) GetAll(ctx context.Context, userID uint, siteID *uint, tillID *uint, status ShiftHandoverStatus) ([]*ShiftHandover, error) {
+	var handovers []*ShiftHandover
+	query := scopeToSite(r.db.WithContext(ctx).Where("user_id = ?", userID), siteID)
+	if tillID != nil {
+		query = query.Where("till_id = ?", *tillID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	result := query.Order("handed_over_at DESC, id DESC").Find(&handovers)
+	return handovers, result.Error
+}
+
+// GetOne retrieves a shift handover of a user
+func (r *ShiftHandoverRepository) GetOne(ctx context.Context, id uint, userID uint) (*ShiftHandover, error) {
+	var handover ShiftHandover
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&handover)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &handover, nil
+}
+
+// Insert records a shift handover signed by the outgoing clerk. It returns
+// ErrShiftHandoverPending when the last handover of the till or station hasn't been signed off.
+func (r *ShiftHandoverRepository) Insert(ctx context.Context, handover *ShiftHandover) (uint, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&ShiftHandover{}).Where("user_id = ? AND status = ?", handover.UserID, ShiftHandoverPending)
+		if handover.TillID != nil {
+			query = query.Where("till_id = ?", *handover.TillID)
+		} else {
+			query = scopeToStation(query, handover.MineSiteID).Where("till_id IS NULL")
+		}
+		var pending int64
+		if err := query.Count(&pending).Error; err != nil {
+			return err
+		}
+		if pending > 0 {
+			return ErrShiftHandoverPending
+		}
+		handover.Status = ShiftHandoverPending
+		return tx.Create(handover).Error
+	})
+	return handover.ID, err
+}
+
+// SignOff records the incoming clerk's sign-off of a shift handover, reassigning a handed over
+// till to them so the cash taken from then on is theirs. It returns ErrShiftHandoverSignedOff
+// when the handover has already been signed off.
+func (r *ShiftHandoverRepository) SignOff(ctx context.Context, handover *ShiftHandover) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		handover.Status = ShiftHandoverSignedOff
+		result := tx.Model(&ShiftHandover{}).
+			Where("id = ? AND user_id = ? AND status = ?", handover.ID, handover.UserID, ShiftHandoverPending).
+			Updates(map[string]interface{}{
+				"status":         handover.Status,
+				"signed_off_at":  handover.SignedOffAt,
+				"sign_off_notes": handover.SignOffNotes,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrShiftHandoverSignedOff
+		}
+		if handover.TillID == nil {
+			return nil
+		}
+		return tx.Model(&Till{}).Where("id = ? AND user_id = ?", *handover.TillID, handover.UserID).
+			Update("clerk_id", handover.IncomingClerkID).Error
+	})
+}
@@ -0,0 +1,262 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrStocktakeNotOpen is returned when a stocktake can no longer be changed
+var ErrStocktakeNotOpen = errors.New("stocktake is not open")
+
+// ErrStocktakeIncomplete is returned when approving a stocktake with uncounted lines
+var ErrStocktakeIncomplete = errors.New("all lines must be counted before approval")
+
+// ErrStocktakeItemMissing is returned when recording a count of an item that isn't in the stocktake
+var ErrStocktakeItemMissing = errors.New("item is not in the stocktake")
+
+// StocktakeRepository implements StocktakeInterface using GORM
+type StocktakeRepository struct {
+	db *gorm.DB
+}
+
+// NewStocktakeRepository creates a new instance of StocktakeRepository
+func NewStocktakeRepository(db *gorm.DB) StocktakeInterface {
+	return &StocktakeRepository{db: db}
+}
+
+// GetAll retrieves all stocktake sessions for a user
+func (r *StocktakeRepository) GetAll(ctx context.Context, userID uint) ([]*Stocktake, error) {
+	var stocktakes []*Stocktake
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("started_at DESC").Find(&stocktakes)
+	return stocktakes, result.Error
+}
+
+// GetOne retrieves a stocktake session with its lines
+func (r *StocktakeRepository) GetOne(ctx context.Context, id uint, userID uint) (*Stocktake, error) {
+	var stocktake Stocktake
+	result := r.db.WithContext(ctx).Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("item_name ASC")
+	}).Where("id = ? AND user_id = ?", id, userID).First(&stocktake)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &stocktake, nil
+}
+
+// Create starts a stocktake session, snapshotting the expected quantity of
+// the given items (or every item of the user when itemIDs is empty)
+func (r *StocktakeRepository) Create(ctx context.Context, stocktake *Stocktake, itemIDs []uint) (uint, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var items []*InventoryItem
+		query := tx.Where("user_id = ?", stocktake.UserID)
+		if len(itemIDs) > 0 {
+			query = query.Where("id IN ?", itemIDs)
+		}
+		if err := query.Order("name ASC").Find(&items).Error; err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		stocktake.Status = StocktakeOpen
+		stocktake.StartedAt = time.Now()
+		stocktake.Lines = nil
+		for _, item := range items {
+			var unitValue float64
+			if item.Quantity > 0 {
+				unitValue = item.CurrentValue / item.Quantity
+			}
+			stocktake.Lines = append(stocktake.Lines, StocktakeLine{
+				InventoryItemID:  item.ID,
+				ItemName:         item.Name,
+				Unit:             item.Unit,
+				ExpectedQuantity: item.Quantity,
+				UnitValue:        unitValue,
+			})
+		}
+
+		return tx.Create(stocktake).Error
+	})
+	return stocktake.ID, err
+}
+
+// RecordCounts records counted quantities keyed by inventory item ID and recomputes variances.
+// It returns ErrStocktakeItemMissing when an item isn't in the stocktake.
+func (r *StocktakeRepository) RecordCounts(ctx context.Context, id uint, userID uint, counts map[uint]float64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		stocktake, err := lockOpenStocktake(tx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		lines := make(map[uint]*StocktakeLine, len(stocktake.Lines))
+		for i := range stocktake.Lines {
+			lines[stocktake.Lines[i].InventoryItemID] = &stocktake.Lines[i]
+		}
+		for itemID := range counts {
+			if lines[itemID] == nil {
+				return ErrStocktakeItemMissing
+			}
+		}
+
+		for itemID, counted := range counts {
+			line := lines[itemID]
+			variance := counted - line.ExpectedQuantity
+			result := tx.Model(&StocktakeLine{}).Where("id = ?", line.ID).Updates(map[string]interface{}{
+				"counted_quantity": counted,
+				"variance":         variance,
+				"variance_value":   variance * line.UnitValue,
+			})
+			if result.Error != nil {
+				return result.Error
+			}
+		}
+		return nil
+	})
+}
+
+// Approve posts the variances counted to inventory as adjustment movements. Each variance is
+// applied to the item's current quantity, so stock used or received since the snapshot is kept;
+// stock can't go below zero.
+func (r *StocktakeRepository) Approve(ctx context.Context, id uint, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		stocktake, err := lockOpenStocktake(tx, id, userID)
+		if err != nil {
+			return err
+		}
+		for _, line := range stocktake.Lines {
+			if line.CountedQuantity == nil {
+				return ErrStocktakeIncomplete
+			}
+		}
+
+		// Approving only succeeds once, however many approvals race
+		now := time.Now()
+		result := tx.Model(&Stocktake{}).Where("id = ? AND status = ?", stocktake.ID, StocktakeOpen).Updates(map[string]interface{}{
+			"status":      StocktakeApproved,
+			"approved_at": now,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrStocktakeNotOpen
+		}
+
+		reason := "Stocktake: " + stocktake.Name
+		for _, line := range stocktake.Lines {
+			if line.Variance == 0 {
+				continue
+			}
+
+			var item InventoryItem
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("id = ? AND user_id = ?", line.InventoryItemID, userID).First(&item).Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					// Item deleted since the snapshot; nothing to adjust
+					continue
+				}
+				return err
+			}
+
+			after := math.Max(item.Quantity+line.Variance, 0)
+			if after == item.Quantity {
+				continue
+			}
+			movement := &StockMovement{
+				InventoryItemID: item.ID,
+				Type:            StockMovementAdjustment,
+				Quantity:        after - item.Quantity,
+				QuantityBefore:  item.Quantity,
+				QuantityAfter:   after,
+				StocktakeID:     &stocktake.ID,
+				Reason:          &reason,
+				UserID:          userID,
+			}
+			if err := tx.Create(movement).Error; err != nil {
+				return err
+			}
+
+			item.Quantity = after
+			item.LastUpdated = now
+			result := tx.Model(&InventoryItem{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+				"quantity":     item.Quantity,
+				"last_updated": item.LastUpdated,
+			})
+			if result.Error != nil {
+				return result.Error
+			}
+			if err := recordStock(tx, EventInventoryCounted, &item, movement.Quantity, &movement.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// lockOpenStocktake reads a stocktake with its lines, locking it until the transaction ends so
+// counts and approvals of the same stocktake are serialized. It returns ErrStocktakeNotOpen when
+// the stocktake has been approved or cancelled.
+func lockOpenStocktake(tx *gorm.DB, id uint, userID uint) (*Stocktake, error) {
+	var stocktake Stocktake
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND user_id = ?", id, userID).First(&stocktake).Error
+	if err != nil {
+		return nil, err
+	}
+	if stocktake.Status != StocktakeOpen {
+		return nil, ErrStocktakeNotOpen
+	}
+	if err := tx.Where("stocktake_id = ?", stocktake.ID).Order("item_name ASC").Find(&stocktake.Lines).Error; err != nil {
+		return nil, err
+	}
+	return &stocktake, nil
+}
+
+// Cancel cancels an open stocktake without touching inventory
+func (r *StocktakeRepository) Cancel(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Model(&Stocktake{}).
+		Where("id = ? AND user_id = ? AND status = ?", id, userID, StocktakeOpen).
+		Update("status", StocktakeCancelled)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrStocktakeNotOpen
+	}
+	return nil
+}
+
+// GetVarianceReport builds the variance report of a stocktake
+func (r *StocktakeRepository) GetVarianceReport(ctx context.Context, id uint, userID uint) (*VarianceReport, error) {
+	stocktake, err := r.GetOne(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &VarianceReport{
+		StocktakeID: stocktake.ID,
+		Status:      stocktake.Status,
+		TotalLines:  len(stocktake.Lines),
+		Lines:       []*StocktakeLine{},
+	}
+	for i := range stocktake.Lines {
+		line := &stocktake.Lines[i]
+		if line.CountedQuantity != nil {
+			report.CountedLines++
+		}
+		if line.Variance != 0 {
+			report.LinesWithVariance++
+			report.TotalVarianceValue += line.VarianceValue
+			report.Lines = append(report.Lines, line)
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,330 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"gorm.io/gorm"
+)
+
+// StreamRepository implements StreamInterface using GORM
+type StreamRepository struct {
+	db *gorm.DB
+}
+
+// NewStreamRepository creates a new instance of StreamRepository
+func NewStreamRepository(db *gorm.DB) StreamInterface {
+	return &StreamRepository{db: db}
+}
+
+// GetEvents retrieves up to limit events of a user's books after the event ID after, oldest
+// first, optionally limited to a stream or a single record's stream
+func (r *StreamRepository) GetEvents(ctx context.Context, userID uint, stream StreamType, streamID uint, after uint, limit int) ([]*StreamEvent, error) {
+	var events []*StreamEvent
+	query := r.db.WithContext(ctx).Where("user_id = ? AND id > ?", userID, after)
+	if stream != "" {
+		query = query.Where("stream = ?", stream)
+	}
+	if streamID != 0 {
+		query = query.Where("stream_id = ?", streamID)
+	}
+	result := query.Order("id").Limit(limit).Find(&events)
+	return events, result.Error
+}
+
+// Rebuild folds the events of a record's stream into its balance and compares it with the
+// record's current balance
+func (r *StreamRepository) Rebuild(ctx context.Context, userID uint, stream StreamType, streamID uint) (*RebuiltBalance, error) {
+	var events []*StreamEvent
+	err := r.db.WithContext(ctx).Where("user_id = ? AND stream = ? AND stream_id = ?", userID, stream, streamID).
+		Order("version").Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+
+	rebuilt := &RebuiltBalance{Stream: stream, StreamID: streamID}
+	for _, event := range events {
+		rebuilt.Version = event.Version
+		rebuilt.Deleted = isDeletion(event.Type)
+		if stock := event.Data.Stock; stock != nil {
+			if rebuilt.Rebuilt.Stock == nil {
+				rebuilt.Rebuilt.Stock = &StockBalance{}
+			}
+			folded := rebuilt.Rebuilt.Stock
+			folded.Quantity += stock.Change
+			folded.Unit = stock.Unit
+			folded.CurrentValue = stock.CurrentValue
+		}
+		if payment := event.Data.Payment; payment != nil {
+			if rebuilt.Rebuilt.Payment == nil {
+				rebuilt.Rebuilt.Payment = &PaymentBalance{}
+			}
+			folded := rebuilt.Rebuilt.Payment
+			folded.AmountPaid += payment.Paid
+			folded.Amount = payment.Amount
+			folded.AmountDue = payment.AmountDue
+			folded.PaymentStatus = payment.PaymentStatus
+		}
+	}
+
+	current, err := r.currentBalance(ctx, userID, stream, streamID)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil {
+		rebuilt.Current = *current
+		rebuilt.Matches = !rebuilt.Deleted && balancesMatch(rebuilt.Rebuilt, *current)
+	} else {
+		rebuilt.Matches = rebuilt.Deleted
+	}
+	return rebuilt, nil
+}
+
+// currentBalance returns the balance of a record in its table, or nil when it doesn't exist
+func (r *StreamRepository) currentBalance(ctx context.Context, userID uint, stream StreamType, streamID uint) (*StreamEventData, error) {
+	var data StreamEventData
+	var err error
+	switch stream {
+	case StreamInventory:
+		var item InventoryItem
+		if err = r.db.WithContext(ctx).Where("id = ? AND user_id = ?", streamID, userID).First(&item).Error; err == nil {
+			data.Stock = stockBalance(&item, 0, nil)
+		}
+	case StreamIncome:
+		// Archived sales keep their balance in the archive
+		var income ArchivedIncome
+		err = r.db.WithContext(ctx).Model(&Income{}).Where("id = ? AND user_id = ?", streamID, userID).First(&income.Income).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = r.db.WithContext(ctx).Where("id = ? AND user_id = ?", streamID, userID).First(&income).Error
+		}
+		if err == nil {
+			data.Payment = incomeBalance(&income.Income, 0)
+		}
+	case StreamExpense:
+		var expense ArchivedExpense
+		err = r.db.WithContext(ctx).Model(&Expense{}).Where("id = ? AND user_id = ?", streamID, userID).First(&expense.Expense).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = r.db.WithContext(ctx).Where("id = ? AND user_id = ?", streamID, userID).First(&expense).Error
+		}
+		if err == nil {
+			data.Payment = expenseBalance(&expense.Expense, 0)
+		}
+	default:
+		return nil, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// Baseline opens the streams of records that have no events yet with a baseline event holding
+// their current balance, so records from before event streams were kept can be rebuilt too. It
+// returns the number of streams opened and is safe to run on every migration.
+func (r *StreamRepository) Baseline(ctx context.Context) (int64, error) {
+	var opened int64
+
+	var items []*InventoryItem
+	err := r.db.WithContext(ctx).Where("NOT EXISTS (SELECT 1 FROM stream_events e WHERE e.stream = ? AND e.stream_id = inventory_items.id)", StreamInventory).
+		FindInBatches(&items, 500, func(_ *gorm.DB, batch int) error {
+			for _, item := range items {
+				if err := recordStock(r.db.WithContext(ctx), EventInventoryBaseline, item, item.Quantity, nil); err != nil {
+					return err
+				}
+			}
+			opened += int64(len(items))
+			return nil
+		}).Error
+	if err != nil {
+		return opened, err
+	}
+
+	var incomes []*Income
+	err = r.db.WithContext(ctx).Where("NOT EXISTS (SELECT 1 FROM stream_events e WHERE e.stream = ? AND e.stream_id = incomes.id)", StreamIncome).
+		FindInBatches(&incomes, 500, func(_ *gorm.DB, batch int) error {
+			for _, income := range incomes {
+				if err := recordIncome(r.db.WithContext(ctx), EventIncomeBaseline, income, nil); err != nil {
+					return err
+				}
+			}
+			opened += int64(len(incomes))
+			return nil
+		}).Error
+	if err != nil {
+		return opened, err
+	}
+
+	var expenses []*Expense
+	err = r.db.WithContext(ctx).Where("NOT EXISTS (SELECT 1 FROM stream_events e WHERE e.stream = ? AND e.stream_id = expenses.id)", StreamExpense).
+		FindInBatches(&expenses, 500, func(_ *gorm.DB, batch int) error {
+			for _, expense := range expenses {
+				if err := recordExpense(r.db.WithContext(ctx), EventExpenseBaseline, expense, nil); err != nil {
+					return err
+				}
+			}
+			opened += int64(len(expenses))
+			return nil
+		}).Error
+	return opened, err
+}
+
+// appendEvent appends an event to its stream as the stream's next version. Appending in the
+// transaction that makes the change keeps the stream and the table in step; two changes racing
+// on the same record make one of the transactions fail on the version's unique index.
+func appendEvent(tx *gorm.DB, event *StreamEvent) error {
+	var version int
+	err := tx.Model(&StreamEvent{}).Where("stream = ? AND stream_id = ?", event.Stream, event.StreamID).
+		Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	if err != nil {
+		return err
+	}
+	event.Version = version + 1
+	return tx.Create(event).Error
+}
+
+// recordStock appends an event with an inventory item's stock after a change. A change leaving
+// the item at or below its minimum stock level also stores a stock.low outbox message.
+func recordStock(tx *gorm.DB, eventType StreamEventType, item *InventoryItem, change float64, movementID *uint) error {
+	err := appendEvent(tx, &StreamEvent{
+		Stream:   StreamInventory,
+		StreamID: item.ID,
+		Type:     eventType,
+		Data:     StreamEventData{Stock: stockBalance(item, change, movementID)},
+		UserID:   item.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	switch eventType {
+	case EventInventoryCreated, EventInventoryUpdated, EventInventoryUsed, EventInventoryCounted, EventInventoryWrittenOff:
+		if item.Quantity <= item.MinStockLevel {
+			return storeOutbox(tx, OutboxStockLow, "inventory_item", item.ID, item.UserID, item)
+		}
+	}
+	return nil
+}
+
+// recordIncome appends an event with a sale's payment balance after a change from before, or
+// from nothing when before is nil. A new sale also stores an income.created outbox message, and
+// a payment received on a new or edited sale a payment.recorded one.
+func recordIncome(tx *gorm.DB, eventType StreamEventType, income *Income, before *Income) error {
+	paid := income.AmountPaid
+	if before != nil {
+		paid -= before.AmountPaid
+	}
+	err := appendEvent(tx, &StreamEvent{
+		Stream:   StreamIncome,
+		StreamID: income.ID,
+		Type:     eventType,
+		Data:     StreamEventData{Payment: incomeBalance(income, paid)},
+		UserID:   income.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if eventType == EventIncomeCreated {
+		if err := storeOutbox(tx, OutboxIncomeCreated, "income", income.ID, income.UserID, income); err != nil {
+			return err
+		}
+	}
+	if (eventType == EventIncomeCreated || eventType == EventIncomeUpdated || eventType == EventIncomePaid) && paid > 0 {
+		return storeOutbox(tx, OutboxPaymentRecorded, "income", income.ID, income.UserID, PaymentRecorded{
+			IncomeID:      income.ID,
+			CustomerName:  income.CustomerName,
+			Amount:        paid,
+			AmountPaid:    income.AmountPaid,
+			AmountDue:     income.AmountDue,
+			PaymentStatus: string(income.PaymentStatus),
+		})
+	}
+	return nil
+}
+
+// recordExpense appends an event with an expense's payment balance after a change from before,
+// or from nothing when before is nil
+func recordExpense(tx *gorm.DB, eventType StreamEventType, expense *Expense, before *Expense) error {
+	paid := expense.AmountPaid
+	if before != nil {
+		paid -= before.AmountPaid
+	}
+	return appendEvent(tx, &StreamEvent{
+		Stream:   StreamExpense,
+		StreamID: expense.ID,
+		Type:     eventType,
+		Data:     StreamEventData{Payment: expenseBalance(expense, paid)},
+		UserID:   expense.UserID,
+	})
+}
+
+func stockBalance(item *InventoryItem, change float64, movementID *uint) *StockBalance {
+	return &StockBalance{
+		Quantity:     item.Quantity,
+		Change:       change,
+		Unit:         item.Unit,
+		CurrentValue: item.CurrentValue,
+		MovementID:   movementID,
+	}
+}
+
+func incomeBalance(income *Income, paid float64) *PaymentBalance {
+	return &PaymentBalance{
+		Amount:        income.TotalAmount,
+		AmountPaid:    income.AmountPaid,
+		AmountDue:     income.AmountDue,
+		PaymentStatus: income.PaymentStatus,
+		Paid:          paid,
+	}
+}
+
+func expenseBalance(expense *Expense, paid float64) *PaymentBalance {
+	return &PaymentBalance{
+		Amount:        expense.Amount,
+		AmountPaid:    expense.AmountPaid,
+		AmountDue:     expense.AmountDue,
+		PaymentStatus: expense.PaymentStatus,
+		Paid:          paid,
+	}
+}
+
+// stockChanged reports whether an edit changed an item's quantity or value
+func stockChanged(before, after *InventoryItem) bool {
+	return before.Quantity != after.Quantity || before.CurrentValue != after.CurrentValue || before.Unit != after.Unit
+}
+
+// paymentChanged reports whether an edit changed a payment balance
+func paymentChanged(before, after *PaymentBalance) bool {
+	return before.Amount != after.Amount || before.AmountPaid != after.AmountPaid ||
+		before.AmountDue != after.AmountDue || before.PaymentStatus != after.PaymentStatus
+}
+
+// isDeletion reports whether an event closes its stream
+func isDeletion(eventType StreamEventType) bool {
+	return eventType == EventInventoryDeleted || eventType == EventIncomeDeleted || eventType == EventExpenseDeleted
+}
+
+// balancesMatch compares a rebuilt balance with a current one, allowing for float rounding in
+// the sum of changes
+func balancesMatch(rebuilt, current StreamEventData) bool {
+	const epsilon = 1e-6
+	if (rebuilt.Stock == nil) != (current.Stock == nil) || (rebuilt.Payment == nil) != (current.Payment == nil) {
+		return false
+	}
+	if s, c := rebuilt.Stock, current.Stock; s != nil {
+		if math.Abs(s.Quantity-c.Quantity) > epsilon || s.CurrentValue != c.CurrentValue || s.Unit != c.Unit {
+			return false
+		}
+	}
+	if p, c := rebuilt.Payment, current.Payment; p != nil {
+		if math.Abs(p.AmountPaid-c.AmountPaid) > epsilon || p.Amount != c.Amount || p.AmountDue != c.AmountDue ||
+			p.PaymentStatus != c.PaymentStatus {
+			return false
+		}
+	}
+	return true
+}
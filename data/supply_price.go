@@ -0,0 +1,61 @@
+package data
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+// SupplyPriceRepository implements SupplyPriceInterface using GORM
+type SupplyPriceRepository struct {
+	db *gorm.DB
+}
+
+// NewSupplyPriceRepository creates a new instance of SupplyPriceRepository
+func NewSupplyPriceRepository(db *gorm.DB) SupplyPriceInterface {
+	return &SupplyPriceRepository{db: db}
+}
+
+// GetAll retrieves the supply price list of a user's books by category and name
+func (r *SupplyPriceRepository) GetAll(ctx context.Context, userID uint) ([]*SupplyPrice, error) {
+	var prices []*SupplyPrice
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("category ASC, name ASC").Find(&prices)
+	return prices, result.Error
+}
+
+// GetOne retrieves an entry of the supply price list by ID for a user
+func (r *SupplyPriceRepository) GetOne(ctx context.Context, id uint, userID uint) (*SupplyPrice, error) {
+	var price SupplyPrice
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&price)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &price, nil
+}
+
+// Insert adds an entry to the supply price list
+func (r *SupplyPriceRepository) Insert(ctx context.Context, price *SupplyPrice) (uint, error) {
+	result := r.db.WithContext(ctx).Create(price)
+	return price.ID, result.Error
+}
+
+// Update updates an entry of the supply price list
+func (r *SupplyPriceRepository) Update(ctx context.Context, price *SupplyPrice) error {
+	result := r.db.WithContext(ctx).Save(price)
+	return result.Error
+}
+
+// Delete soft deletes an entry of the supply price list. Expenses already checked against it keep
+// their flags.
+func (r *SupplyPriceRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&SupplyPrice{})
+	return result.Error
+}
+
+// GetFlaggedExpenses retrieves the expenses of a user's books flagged as bought above the list
+// price, largest variance first
+func (r *SupplyPriceRepository) GetFlaggedExpenses(ctx context.Context, userID uint) ([]*Expense, error) {
+	var expenses []*Expense
+	result := r.db.WithContext(ctx).Where("user_id = ? AND price_variance IS NOT NULL", userID).
+		Order("price_variance DESC, date DESC").Find(&expenses)
+	return expenses, result.Error
+}
@@ -0,0 +1,75 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SupportRepository implements SupportInterface using GORM
+type SupportRepository struct {
+	db *gorm.DB
+}
+
+// NewSupportRepository creates a new instance of SupportRepository
+func NewSupportRepository(db *gorm.DB) SupportInterface {
+	return &SupportRepository{db: db}
+}
+
+// GetAll retrieves the support tickets a user raised, newest first
+func (r *SupportRepository) GetAll(ctx context.Context, userID uint) ([]*SupportTicket, error) {
+	var tickets []*SupportTicket
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&tickets)
+	return tickets, result.Error
+}
+
+// GetOne retrieves a support ticket a user raised
+func (r *SupportRepository) GetOne(ctx context.Context, id uint, userID uint) (*SupportTicket, error) {
+	var ticket SupportTicket
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&ticket)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &ticket, nil
+}
+
+// GetRecent retrieves the most recent support tickets of all users, optionally with a status
+func (r *SupportRepository) GetRecent(ctx context.Context, status string, limit int) ([]*SupportTicket, error) {
+	query := r.db.WithContext(ctx).Order("created_at DESC").Limit(limit)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var tickets []*SupportTicket
+	result := query.Find(&tickets)
+	return tickets, result.Error
+}
+
+// Insert creates a new support ticket
+func (r *SupportRepository) Insert(ctx context.Context, ticket *SupportTicket) (uint, error) {
+	result := r.db.WithContext(ctx).Create(ticket)
+	return ticket.ID, result.Error
+}
+
+// SetDelivery records the email that forwarded a support ticket
+func (r *SupportRepository) SetDelivery(ctx context.Context, id uint, deliveryID uint) error {
+	return r.db.WithContext(ctx).Model(&SupportTicket{}).Where("id = ?", id).Update("delivery_id", deliveryID).Error
+}
+
+// Resolve marks a support ticket as resolved
+func (r *SupportRepository) Resolve(ctx context.Context, id uint) (*SupportTicket, error) {
+	var ticket SupportTicket
+	if err := r.db.WithContext(ctx).First(&ticket, id).Error; err != nil {
+		return nil, err
+	}
+	if ticket.Status != SupportTicketResolved {
+		now := time.Now()
+		ticket.Status = SupportTicketResolved
+		ticket.ResolvedAt = &now
+		if err := r.db.WithContext(ctx).Save(&ticket).Error; err != nil {
+			return nil, err
+		}
+	}
+	return &ticket, nil
+}
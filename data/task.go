@@ -0,0 +1,126 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TaskRepository implements TaskInterface using GORM
+type TaskRepository struct {
+	db *gorm.DB
+}
+
+// NewTaskRepository creates a new instance of TaskRepository
+func NewTaskRepository(db *gorm.DB) TaskInterface {
+	return &TaskRepository{db: db}
+}
+
+// GetAll retrieves tasks for a user, open tasks first by due date
+func (r *TaskRepository) GetAll(ctx context.Context, userID uint, filter TaskFilter) ([]*Task, error) {
+	query := r.db.WithContext(ctx).Preload("Assignee").Where("user_id = ?", userID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.AssigneeID != 0 {
+		query = query.Where("assignee_id = ?", filter.AssigneeID)
+	}
+	if filter.Overdue {
+		query = query.Where("status = ? AND due_date < ?", TaskOpen, startOfToday())
+	}
+	if filter.LinkedType != "" {
+		query = query.Where("linked_type = ?", filter.LinkedType)
+		if filter.LinkedID != 0 {
+			query = query.Where("linked_id = ?", filter.LinkedID)
+		}
+	}
+
+	var tasks []*Task
+	result := query.Order("status DESC, due_date NULLS LAST, created_at").Find(&tasks)
+	return tasks, result.Error
+}
+
+// GetOne retrieves a task by ID for a user
+func (r *TaskRepository) GetOne(ctx context.Context, id uint, userID uint) (*Task, error) {
+	var task Task
+	result := r.db.WithContext(ctx).Preload("Assignee").Where("id = ? AND user_id = ?", id, userID).First(&task)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &task, nil
+}
+
+// Insert creates a new task
+func (r *TaskRepository) Insert(ctx context.Context, task *Task) (uint, error) {
+	if task.Status == "" {
+		task.Status = TaskOpen
+	}
+	result := r.db.WithContext(ctx).Create(task)
+	return task.ID, result.Error
+}
+
+// Update updates an existing task
+func (r *TaskRepository) Update(ctx context.Context, task *Task) error {
+	result := r.db.WithContext(ctx).Omit("Assignee").Save(task)
+	return result.Error
+}
+
+// Delete soft deletes a task
+func (r *TaskRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&Task{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Complete marks an open task as done
+func (r *TaskRepository) Complete(ctx context.Context, id uint, userID uint, completedByID uint) error {
+	result := r.db.WithContext(ctx).Model(&Task{}).Where("id = ? AND user_id = ? AND status = ?", id, userID, TaskOpen).
+		Updates(map[string]interface{}{
+			"status":          TaskDone,
+			"completed_at":    time.Now(),
+			"completed_by_id": completedByID,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Reopen marks a done task as open again
+func (r *TaskRepository) Reopen(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Model(&Task{}).Where("id = ? AND user_id = ? AND status = ?", id, userID, TaskDone).
+		Updates(map[string]interface{}{
+			"status":          TaskOpen,
+			"completed_at":    nil,
+			"completed_by_id": nil,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetOverdue retrieves open tasks of all users due before the given time
+func (r *TaskRepository) GetOverdue(ctx context.Context, before time.Time) ([]*Task, error) {
+	var tasks []*Task
+	result := r.db.WithContext(ctx).Where("status = ? AND due_date < ?", TaskOpen, before).Order("user_id, due_date").Find(&tasks)
+	return tasks, result.Error
+}
+
+// startOfToday returns midnight at the start of the current day
+func startOfToday() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
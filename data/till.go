@@ -0,0 +1,164 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrTillDayOpen is returned when deleting a till with an open cash day
+var ErrTillDayOpen = errors.New("the till has an open cash day")
+
+// TillRepository implements TillInterface using GORM
+type TillRepository struct {
+	db *gorm.DB
+}
+
+// NewTillRepository creates a new instance of TillRepository
+func NewTillRepository(db *gorm.DB) TillInterface {
+	return &TillRepository{db: db}
+}
+
+// GetAll retrieves the tills of a user by name. Only the tills of the station siteID are
+// included when it is set.
+func (r *TillRepository) GetAll(ctx context.Context, userID uint, siteID *uint) ([]*Till, error) {
+	var tills []*Till
+	result := scopeToSite(r.db.WithContext(ctx).Where("user_id = ?", userID), siteID).Order("name, id").Find(&tills)
+	return tills, result.Error
+}
+
+// GetOne retrieves a till of a user
+func (r *TillRepository) GetOne(ctx context.Context, id uint, userID uint) (*Till, error) {
+	var till Till
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&till)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &till, nil
+}
+
+// GetForClerk retrieves the active till assigned to a clerk, the first by name when they have
+// several. It returns gorm.ErrRecordNotFound when they have none.
+func (r *TillRepository) GetForClerk(ctx context.Context, userID uint, clerkID uint) (*Till, error) {
+	var till Till
+	result := r.db.WithContext(ctx).Where("user_id = ? AND clerk_id = ? AND active = ?", userID, clerkID, true).
+		Order("name, id").First(&till)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &till, nil
+}
+
+// Insert creates a till
+func (r *TillRepository) Insert(ctx context.Context, till *Till) (uint, error) {
+	result := r.db.WithContext(ctx).Create(till)
+	return till.ID, result.Error
+}
+
+// Update updates a till
+func (r *TillRepository) Update(ctx context.Context, till *Till) error {
+	return r.db.WithContext(ctx).Save(till).Error
+}
+
+// Delete soft deletes a till of a user. Payments keep the till they went through. It returns
+// ErrTillDayOpen when the till has an open cash day.
+func (r *TillRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var open int64
+		err := tx.Model(&CashDay{}).Where("user_id = ? AND till_id = ? AND status = ?", userID, id, CashDayOpen).
+			Count(&open).Error
+		if err != nil {
+			return err
+		}
+		if open > 0 {
+			return ErrTillDayOpen
+		}
+		return tx.Where("id = ? AND user_id = ?", id, userID).Delete(&Till{}).Error
+	})
+}
+
+// GetVarianceReport sums the discrepancies of the till cash days closed between from and to,
+// inclusive, by till and by the clerk of the till, largest shortage first. Only the tills of the
+// station siteID are included when it is set.
+func (r *TillRepository) GetVarianceReport(ctx context.Context, userID uint, siteID *uint, from, to time.Time) (*TillVarianceReport, error) {
+	var days []*CashDay
+	query := scopeToSite(r.db.WithContext(ctx).Where("user_id = ? AND status = ? AND till_id IS NOT NULL AND date >= ? AND date <= ?",
+		userID, CashDayClosed, from, to), siteID)
+	if err := query.Find(&days).Error; err != nil {
+		return nil, err
+	}
+
+	tills := map[uint]*CashVariance{}
+	clerks := map[uint]*CashVariance{}
+	for _, day := range days {
+		addVariance(tills, *day.TillID, day)
+		if day.ClerkID != nil {
+			addVariance(clerks, *day.ClerkID, day)
+		}
+	}
+
+	report := &TillVarianceReport{From: from, To: to}
+	var err error
+	if report.Tills, err = r.nameVariances(ctx, tills, &Till{}); err != nil {
+		return nil, err
+	}
+	if report.Clerks, err = r.nameVariances(ctx, clerks, &User{}); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// addVariance adds the discrepancy of a closed cash day to the variance of id
+func addVariance(variances map[uint]*CashVariance, id uint, day *CashDay) {
+	variance := variances[id]
+	if variance == nil {
+		variance = &CashVariance{ID: id}
+		variances[id] = variance
+	}
+	variance.DaysClosed++
+	if day.Discrepancy == nil || math.Abs(*day.Discrepancy) < paymentTolerance {
+		return
+	}
+	variance.Discrepant++
+	if *day.Discrepancy < 0 {
+		variance.Shortages -= *day.Discrepancy
+	} else {
+		variance.Overages += *day.Discrepancy
+	}
+	variance.Net = math.Round((variance.Overages-variance.Shortages)*100) / 100
+}
+
+// nameVariances names the variances of tills or clerks from model and sorts them by the
+// shortage, largest first. Deleted tills keep their name.
+func (r *TillRepository) nameVariances(ctx context.Context, variances map[uint]*CashVariance, model interface{}) ([]*CashVariance, error) {
+	sorted := make([]*CashVariance, 0, len(variances))
+	if len(variances) == 0 {
+		return sorted, nil
+	}
+	ids := make([]uint, 0, len(variances))
+	for id, variance := range variances {
+		ids = append(ids, id)
+		sorted = append(sorted, variance)
+	}
+	var names []struct {
+		ID   uint
+		Name string
+	}
+	if err := r.db.WithContext(ctx).Unscoped().Model(model).Select("id, name").Where("id IN ?", ids).Find(&names).Error; err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		variances[name.ID].Name = name.Name
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Shortages != sorted[j].Shortages {
+			return sorted[i].Shortages > sorted[j].Shortages
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted, nil
+}
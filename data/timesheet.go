@@ -0,0 +1,150 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrTimesheetReviewed is returned when changing a timesheet that was already approved or rejected
+var ErrTimesheetReviewed = errors.New("timesheet has already been reviewed")
+
+// TimesheetRepository implements TimesheetInterface using GORM
+type TimesheetRepository struct {
+	db *gorm.DB
+}
+
+// NewTimesheetRepository creates a new instance of TimesheetRepository
+func NewTimesheetRepository(db *gorm.DB) TimesheetInterface {
+	return &TimesheetRepository{db: db}
+}
+
+// GetAll retrieves timesheets for a user, optionally filtered by status and date range
+func (r *TimesheetRepository) GetAll(ctx context.Context, userID uint, status string, start, end *time.Time) ([]*Timesheet, error) {
+	var timesheets []*Timesheet
+	query := r.db.WithContext(ctx).Preload("Employee").Where("user_id = ?", userID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if start != nil && end != nil {
+		query = query.Where("date >= ? AND date < ?", *start, *end)
+	}
+	result := query.Order("date DESC").Find(&timesheets)
+	return timesheets, result.Error
+}
+
+// GetOne retrieves a specific timesheet by ID for a user
+func (r *TimesheetRepository) GetOne(ctx context.Context, id uint, userID uint) (*Timesheet, error) {
+	var timesheet Timesheet
+	result := r.db.WithContext(ctx).Preload("Employee").Where("id = ? AND user_id = ?", id, userID).First(&timesheet)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &timesheet, nil
+}
+
+// Insert submits a new timesheet
+func (r *TimesheetRepository) Insert(ctx context.Context, timesheet *Timesheet) (uint, error) {
+	timesheet.Status = TimesheetSubmitted
+	timesheet.Amount = timesheet.Hours * timesheet.HourlyRate
+	result := r.db.WithContext(ctx).Omit("Employee").Create(timesheet)
+	return timesheet.ID, result.Error
+}
+
+// Delete soft deletes a timesheet that has not been reviewed yet
+func (r *TimesheetRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ? AND status = ?", id, userID, TimesheetSubmitted).Delete(&Timesheet{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTimesheetReviewed
+	}
+	return nil
+}
+
+// Review approves or rejects a submitted timesheet
+func (r *TimesheetRepository) Review(ctx context.Context, id uint, userID uint, status TimesheetStatus, reason *string) error {
+	result := r.db.WithContext(ctx).Model(&Timesheet{}).
+		Where("id = ? AND user_id = ? AND status = ?", id, userID, TimesheetSubmitted).
+		Updates(map[string]interface{}{
+			"status":           status,
+			"rejection_reason": reason,
+			"reviewed_at":      time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTimesheetReviewed
+	}
+	return nil
+}
+
+// GetApprovedHours retrieves approved hours and pay per employee for a period
+func (r *TimesheetRepository) GetApprovedHours(ctx context.Context, userID uint, start, end time.Time) ([]*EmployeeHours, error) {
+	return approvedHours(r.db.WithContext(ctx), userID, start, end)
+}
+
+// approvedHours sums approved timesheets per employee, shared with payroll runs
+func approvedHours(db *gorm.DB, userID uint, start, end time.Time) ([]*EmployeeHours, error) {
+	var hours []*EmployeeHours
+
+	query := `
+		SELECT 
+			e.id as employee_id,
+			e.name,
+			COALESCE(SUM(t.hours), 0) as hours,
+			COALESCE(SUM(t.amount), 0) as amount
+		FROM timesheets t
+		JOIN employees e ON e.id = t.employee_id
+		WHERE t.user_id = ? AND t.status = ? AND t.date >= ? AND t.date < ? AND t.deleted_at IS NULL
+		GROUP BY e.id, e.name
+		ORDER BY e.name
+	`
+
+	result := db.Raw(query, userID, TimesheetApproved, start, end).Scan(&hours)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return hours, nil
+}
+
+// GetLaborCostByPit retrieves approved timesheet and contractor labor costs per pit for a period
+func (r *TimesheetRepository) GetLaborCostByPit(ctx context.Context, userID uint, start, end time.Time) ([]*PitLaborCost, error) {
+	var costs []*PitLaborCost
+
+	query := `
+		SELECT 
+			pit_number,
+			COALESCE(SUM(hours), 0) as hours,
+			COUNT(DISTINCT employee_id) as employees,
+			COALESCE(SUM(timesheet_cost), 0) as timesheet_cost,
+			COALESCE(SUM(contractor_cost), 0) as contractor_cost
+		FROM (
+			SELECT COALESCE(pit_number, '') as pit_number, hours, employee_id, amount as timesheet_cost, 0 as contractor_cost
+			FROM timesheets
+			WHERE user_id = ? AND status = ? AND date >= ? AND date < ? AND deleted_at IS NULL
+			UNION ALL
+			SELECT COALESCE(pit_number, '') as pit_number, 0, NULL, 0, amount
+			FROM work_records
+			WHERE user_id = ? AND date >= ? AND date < ? AND deleted_at IS NULL
+		) labor
+		GROUP BY pit_number
+		ORDER BY pit_number
+	`
+
+	result := r.db.WithContext(ctx).Raw(query, userID, TimesheetApproved, start, end, userID, start, end).Scan(&costs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, cost := range costs {
+		cost.TotalCost = cost.TimesheetCost + cost.ContractorCost
+	}
+
+	return costs, nil
+}
@@ -0,0 +1,362 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Errors returned by shared sale and dispute operations
+var (
+	ErrTradeAnswered   = errors.New("shared sale has already been answered")
+	ErrNotDisputable   = errors.New("only accepted shared sales can be disputed")
+	ErrNoOpenDispute   = errors.New("shared sale has no open dispute")
+	ErrNoProposal      = errors.New("dispute has no proposed resolution")
+	ErrOwnProposal     = errors.New("a proposal must be accepted by the other side")
+	ErrNotDisputeOwner = errors.New("only the side that opened a dispute can withdraw it")
+)
+
+// TradeRepository implements TradeInterface using GORM
+type TradeRepository struct {
+	db *gorm.DB
+}
+
+// NewTradeRepository creates a new instance of TradeRepository
+func NewTradeRepository(db *gorm.DB) TradeInterface {
+	return &TradeRepository{db: db}
+}
+
+// Share shares a sale with its buyer, or refreshes the details of a sale already shared and
+// still pending
+func (r *TradeRepository) Share(ctx context.Context, sale *SharedSale) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing SharedSale
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("income_id = ?", sale.IncomeID).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			sale.Status = TradePending
+			return tx.Create(sale).Error
+		}
+		if err != nil {
+			return err
+		}
+		if existing.Status != TradePending {
+			return ErrTradeAnswered
+		}
+
+		sale.ID = existing.ID
+		sale.CreatedAt = existing.CreatedAt
+		sale.Status = TradePending
+		return tx.Save(sale).Error
+	})
+}
+
+// GetIncoming retrieves the sales shared with a buyer, optionally with one status
+func (r *TradeRepository) GetIncoming(ctx context.Context, buyerID uint, status TradeStatus) ([]*SharedSale, error) {
+	var sales []*SharedSale
+	query := r.db.WithContext(ctx).Where("buyer_id = ?", buyerID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	result := query.Order("date DESC").Find(&sales)
+	return sales, result.Error
+}
+
+// GetPurchase retrieves a sale shared with a buyer
+func (r *TradeRepository) GetPurchase(ctx context.Context, id uint, buyerID uint) (*SharedSale, error) {
+	var sale SharedSale
+	result := r.db.WithContext(ctx).Where("id = ? AND buyer_id = ?", id, buyerID).First(&sale)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &sale, nil
+}
+
+// GetOutgoing retrieves the sales a seller has shared with buyers
+func (r *TradeRepository) GetOutgoing(ctx context.Context, sellerID uint) ([]*SharedSale, error) {
+	var sales []*SharedSale
+	result := r.db.WithContext(ctx).Where("seller_id = ?", sellerID).Order("date DESC").Find(&sales)
+	return sales, result.Error
+}
+
+// Accept records the expense of a pending shared sale for its buyer and links it to the sale
+func (r *TradeRepository) Accept(ctx context.Context, id uint, buyerID uint, expense *Expense) (*SharedSale, error) {
+	var sale SharedSale
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := pendingSharedSale(tx, id, buyerID, &sale); err != nil {
+			return err
+		}
+
+		expense.AmountDue = expense.Amount - expense.AmountPaid
+		if err := createExpense(tx, expense); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		sale.Status = TradeAccepted
+		sale.ExpenseID = &expense.ID
+		sale.RespondedAt = &now
+		return tx.Save(&sale).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sale, nil
+}
+
+// Decline marks a pending shared sale as declined by its buyer
+func (r *TradeRepository) Decline(ctx context.Context, id uint, buyerID uint) (*SharedSale, error) {
+	var sale SharedSale
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := pendingSharedSale(tx, id, buyerID, &sale); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		sale.Status = TradeDeclined
+		sale.RespondedAt = &now
+		return tx.Save(&sale).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sale, nil
+}
+
+// pendingSharedSale locks a sale shared with a buyer, returning ErrTradeAnswered unless it is
+// still pending
+func pendingSharedSale(tx *gorm.DB, id uint, buyerID uint, sale *SharedSale) error {
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND buyer_id = ?", id, buyerID).First(sale).Error
+	if err != nil {
+		return err
+	}
+	if sale.Status != TradePending {
+		return ErrTradeAnswered
+	}
+	return nil
+}
+
+// GetSharedSale retrieves a shared sale for its seller or buyer
+func (r *TradeRepository) GetSharedSale(ctx context.Context, id uint, userID uint) (*SharedSale, error) {
+	var sale SharedSale
+	result := r.db.WithContext(ctx).Where("id = ? AND (seller_id = ? OR buyer_id = ?)", id, userID, userID).First(&sale)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &sale, nil
+}
+
+// GetDisputes retrieves the disputes of a shared sale with their history, newest first
+func (r *TradeRepository) GetDisputes(ctx context.Context, sharedSaleID uint) ([]*TradeDispute, error) {
+	var disputes []*TradeDispute
+	result := r.db.WithContext(ctx).Preload("Events", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC")
+	}).Where("shared_sale_id = ?", sharedSaleID).Order("created_at DESC").Find(&disputes)
+	return disputes, result.Error
+}
+
+// OpenDispute opens a dispute on an accepted shared sale
+func (r *TradeRepository) OpenDispute(ctx context.Context, dispute *TradeDispute, event *TradeDisputeEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var sale SharedSale
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&sale, dispute.SharedSaleID).Error
+		if err != nil {
+			return err
+		}
+		if sale.Status != TradeAccepted {
+			return ErrNotDisputable
+		}
+
+		dispute.Status = DisputeOpen
+		if err := tx.Create(dispute).Error; err != nil {
+			return err
+		}
+		event.DisputeID = dispute.ID
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+		dispute.Events = []TradeDisputeEvent{*event}
+		return tx.Model(&sale).Update("status", TradeDisputed).Error
+	})
+}
+
+// AddDisputeEvent adds a message or proposal to the open dispute of a shared sale. A proposal
+// replaces any earlier one.
+func (r *TradeRepository) AddDisputeEvent(ctx context.Context, sharedSaleID uint, event *TradeDisputeEvent) (*TradeDispute, error) {
+	var dispute TradeDispute
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := openDispute(tx, sharedSaleID, &dispute); err != nil {
+			return err
+		}
+
+		event.DisputeID = dispute.ID
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+		if event.Kind != DisputeEventProposal {
+			return nil
+		}
+		dispute.ProposedAction = event.Action
+		dispute.ProposedAmount = event.Amount
+		dispute.ProposedBy = &event.Side
+		return tx.Save(&dispute).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dispute, nil
+}
+
+// ResolveDispute closes the open dispute of a shared sale. Accepting the other side's proposal
+// applies it to both the seller's income and the buyer's expense; withdrawing leaves them as
+// they are.
+func (r *TradeRepository) ResolveDispute(ctx context.Context, sharedSaleID uint, event *TradeDisputeEvent) (*TradeDispute, error) {
+	var dispute TradeDispute
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := openDispute(tx, sharedSaleID, &dispute); err != nil {
+			return err
+		}
+		var sale SharedSale
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&sale, sharedSaleID).Error; err != nil {
+			return err
+		}
+
+		switch event.Kind {
+		case DisputeEventAccepted:
+			if dispute.ProposedAction == nil {
+				return ErrNoProposal
+			}
+			if *dispute.ProposedBy == event.Side {
+				return ErrOwnProposal
+			}
+			event.Action = dispute.ProposedAction
+			event.Amount = dispute.ProposedAmount
+			if err := applyDisputeAction(tx, &sale, *dispute.ProposedAction, dispute.ProposedAmount); err != nil {
+				return err
+			}
+			dispute.Status = DisputeResolved
+			dispute.Resolution = dispute.ProposedAction
+		case DisputeEventWithdrawn:
+			if dispute.OpenedBy != event.Side {
+				return ErrNotDisputeOwner
+			}
+			if err := tx.Model(&sale).Update("status", TradeAccepted).Error; err != nil {
+				return err
+			}
+			dispute.Status = DisputeWithdrawn
+		default:
+			return fmt.Errorf("dispute event %s does not resolve a dispute", event.Kind)
+		}
+
+		event.DisputeID = dispute.ID
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+		now := time.Now()
+		dispute.ResolvedAt = &now
+		return tx.Save(&dispute).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dispute, nil
+}
+
+// openDispute locks the open dispute of a shared sale
+func openDispute(tx *gorm.DB, sharedSaleID uint, dispute *TradeDispute) error {
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("shared_sale_id = ? AND status = ?", sharedSaleID, DisputeOpen).First(dispute).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNoOpenDispute
+	}
+	return err
+}
+
+// applyDisputeAction applies an accepted resolution to a shared sale and the seller's income
+// and buyer's expense linked to it
+func applyDisputeAction(tx *gorm.DB, sale *SharedSale, action DisputeAction, amount *float64) error {
+	switch action {
+	case DisputeVoid:
+		if err := deleteIncome(tx, sale.IncomeID, sale.SellerID); err != nil {
+			return err
+		}
+		if sale.ExpenseID != nil {
+			if err := deleteExpense(tx, *sale.ExpenseID, sale.BuyerID); err != nil {
+				return err
+			}
+		}
+		return tx.Model(sale).Update("status", TradeVoided).Error
+	case DisputeAdjustAmount:
+		if amount == nil {
+			return ErrNoProposal
+		}
+		total := *amount
+		pricePerUnit := sale.PricePerUnit
+		if sale.Quantity > 0 {
+			pricePerUnit = total / sale.Quantity
+		}
+
+		var income Income
+		if err := tx.Where("id = ? AND user_id = ?", sale.IncomeID, sale.SellerID).First(&income).Error; err != nil {
+			return err
+		}
+		before := income
+		status, due := settlement(total, income.AmountPaid)
+		if err := tx.Model(&income).Updates(map[string]interface{}{
+			"total_amount":   total,
+			"price_per_unit": pricePerUnit,
+			"amount_due":     due,
+			"payment_status": status,
+		}).Error; err != nil {
+			return err
+		}
+		income.TotalAmount, income.PricePerUnit, income.AmountDue, income.PaymentStatus = total, pricePerUnit, due, status
+		if err := recordIncome(tx, EventIncomeUpdated, &income, &before); err != nil {
+			return err
+		}
+
+		if sale.ExpenseID != nil {
+			var expense Expense
+			if err := tx.Where("id = ? AND user_id = ?", *sale.ExpenseID, sale.BuyerID).First(&expense).Error; err != nil {
+				return err
+			}
+			before := expense
+			status, due := settlement(total, expense.AmountPaid)
+			if err := tx.Model(&expense).Updates(map[string]interface{}{
+				"amount":         total,
+				"amount_due":     due,
+				"payment_status": status,
+			}).Error; err != nil {
+				return err
+			}
+			expense.Amount, expense.AmountDue, expense.PaymentStatus = total, due, status
+			if err := recordExpense(tx, EventExpenseUpdated, &expense, &before); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(sale).Updates(map[string]interface{}{
+			"total_amount":   total,
+			"price_per_unit": pricePerUnit,
+			"status":         TradeAccepted,
+		}).Error
+	}
+	return fmt.Errorf("unknown dispute action %s", action)
+}
+
+// settlement returns the payment status and amount due of a total after a payment
+func settlement(total, paid float64) (PaymentStatus, float64) {
+	switch {
+	case paid >= total:
+		return PaymentPaid, 0
+	case paid > 0:
+		return PaymentPartial, total - paid
+	default:
+		return PaymentUnpaid, total
+	}
+}
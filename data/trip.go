@@ -0,0 +1,134 @@
+package data
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+// TripRepository implements TripInterface using GORM
+type TripRepository struct {
+	db *gorm.DB
+}
+
+// NewTripRepository creates a new instance of TripRepository
+func NewTripRepository(db *gorm.DB) TripInterface {
+	return &TripRepository{db: db}
+}
+
+// GetAll retrieves all trips for a user
+func (r *TripRepository) GetAll(ctx context.Context, userID uint) ([]*Trip, error) {
+	var trips []*Trip
+	result := r.db.WithContext(ctx).Preload("Vehicle").Where("user_id = ?", userID).Order("date DESC").Find(&trips)
+	return trips, result.Error
+}
+
+// GetOne retrieves a specific trip by ID for a user
+func (r *TripRepository) GetOne(ctx context.Context, id uint, userID uint) (*Trip, error) {
+	var trip Trip
+	result := r.db.WithContext(ctx).Preload("Vehicle").Where("id = ? AND user_id = ?", id, userID).First(&trip)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &trip, nil
+}
+
+// Insert creates a new trip
+func (r *TripRepository) Insert(ctx context.Context, trip *Trip) (uint, error) {
+	result := r.db.WithContext(ctx).Omit("Vehicle").Create(trip)
+	return trip.ID, result.Error
+}
+
+// Update updates an existing trip
+func (r *TripRepository) Update(ctx context.Context, trip *Trip) error {
+	result := r.db.WithContext(ctx).Omit("Vehicle").Save(trip)
+	return result.Error
+}
+
+// Delete soft deletes a trip and unlinks its expenses
+func (r *TripRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Expense{}).Where("trip_id = ? AND user_id = ?", id, userID).Update("trip_id", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		return tx.Where("id = ? AND user_id = ?", id, userID).Delete(&Trip{}).Error
+	})
+}
+
+// GetVehicleCosts retrieves trip counts, distance and linked expense totals per vehicle
+func (r *TripRepository) GetVehicleCosts(ctx context.Context, userID uint) ([]*VehicleCost, error) {
+	var costs []*VehicleCost
+
+	query := `
+		SELECT 
+			v.id as vehicle_id,
+			v.registration,
+			COUNT(t.id) as trips,
+			COUNT(t.income_id) as deliveries,
+			COALESCE(SUM(t.distance_km), 0) as total_distance,
+			COALESCE(SUM(tc.cost), 0) as total_cost
+		FROM vehicles v
+		LEFT JOIN trips t ON t.vehicle_id = v.id AND t.deleted_at IS NULL
+		LEFT JOIN (
+			SELECT trip_id, SUM(amount) as cost
+			FROM expenses
+			WHERE user_id = ? AND trip_id IS NOT NULL AND deleted_at IS NULL
+			GROUP BY trip_id
+		) tc ON tc.trip_id = t.id
+		WHERE v.user_id = ? AND v.deleted_at IS NULL
+		GROUP BY v.id, v.registration
+		ORDER BY total_cost DESC
+	`
+
+	result := r.db.WithContext(ctx).Raw(query, userID, userID).Scan(&costs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, cost := range costs {
+		if cost.TotalDistance > 0 {
+			cost.CostPerKm = cost.TotalCost / cost.TotalDistance
+		}
+		if cost.Deliveries > 0 {
+			cost.CostPerDelivery = cost.TotalCost / float64(cost.Deliveries)
+		}
+	}
+
+	return costs, nil
+}
+
+// GetDeliveryCosts retrieves the transport cost of each trip that delivered a sale
+func (r *TripRepository) GetDeliveryCosts(ctx context.Context, userID uint) ([]*DeliveryCost, error) {
+	var costs []*DeliveryCost
+
+	query := `
+		SELECT 
+			t.id as trip_id,
+			t.income_id,
+			t.date,
+			v.registration,
+			i.customer_name,
+			i.total_amount as sale_amount,
+			COALESCE(SUM(e.amount), 0) as transport_cost
+		FROM trips t
+		JOIN vehicles v ON v.id = t.vehicle_id
+		JOIN incomes i ON i.id = t.income_id AND i.deleted_at IS NULL
+		LEFT JOIN expenses e ON e.trip_id = t.id AND e.user_id = t.user_id AND e.deleted_at IS NULL
+		WHERE t.user_id = ? AND t.deleted_at IS NULL
+		GROUP BY t.id, t.income_id, t.date, v.registration, i.customer_name, i.total_amount
+		ORDER BY t.date DESC
+	`
+
+	result := r.db.WithContext(ctx).Raw(query, userID).Scan(&costs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, cost := range costs {
+		if cost.SaleAmount > 0 {
+			cost.CostRatio = (cost.TransportCost / cost.SaleAmount) * 100
+		}
+	}
+
+	return costs, nil
+}
@@ -0,0 +1,170 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Plans holds the usage limits of each plan
+var Plans = map[Plan]PlanLimits{
+	PlanFree: {
+		RecordsPerMonth: 300,
+		StorageBytes:    100 << 20,
+		SMSPerMonth:     100,
+	},
+	PlanPro: {
+		RecordsPerMonth: 5000,
+		StorageBytes:    2 << 30,
+		SMSPerMonth:     1000,
+	},
+	PlanUnlimited: {},
+}
+
+// Features lists the features plans gate
+var Features = []Feature{FeatureReports, FeatureSMS, FeatureTeam, FeatureWebhooks}
+
+// PlanFeatures holds the features each plan includes
+var PlanFeatures = map[Plan][]Feature{
+	PlanFree:      {},
+	PlanPro:       Features,
+	PlanUnlimited: Features,
+}
+
+// recordsQuery selects the owner of each metered record created since a time. Deleted records
+// still count towards the month they were created in.
+const recordsQuery = `
+	SELECT user_id FROM incomes WHERE created_at >= @since
+	UNION ALL SELECT user_id FROM expenses WHERE created_at >= @since
+	UNION ALL SELECT user_id FROM inventory_items WHERE created_at >= @since
+	UNION ALL SELECT user_id FROM trips WHERE created_at >= @since`
+
+// UsageRepository implements UsageInterface using GORM
+type UsageRepository struct {
+	db *gorm.DB
+}
+
+// NewUsageRepository creates a new instance of UsageRepository
+func NewUsageRepository(db *gorm.DB) UsageInterface {
+	return &UsageRepository{db: db}
+}
+
+// GetCounts meters the records and SMS of a user's books since a time and the storage they use
+func (r *UsageRepository) GetCounts(ctx context.Context, userID uint, since time.Time) (*UsageCounts, error) {
+	counts := &UsageCounts{UserID: userID}
+
+	err := r.db.WithContext(ctx).Raw(`SELECT COUNT(*) FROM (`+recordsQuery+`) AS records WHERE user_id = @user`,
+		map[string]interface{}{"since": since, "user": userID}).Scan(&counts.Records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.WithContext(ctx).Model(&EvidencePhoto{}).Where("user_id = ?", userID).
+		Select("COALESCE(SUM(size), 0)").Scan(&counts.StorageBytes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.WithContext(ctx).Model(&SMSCampaignRecipient{}).
+		Where("user_id = ? AND status = ? AND created_at >= ?", userID, SMSRecipientQueued, since).
+		Count(&counts.SMS).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// GetAllCounts meters every user's books with usage since a time or stored photos, ordered by user
+func (r *UsageRepository) GetAllCounts(ctx context.Context, since time.Time) ([]*UsageCounts, error) {
+	type total struct {
+		UserID uint
+		Total  int64
+	}
+	byUser := make(map[uint]*UsageCounts)
+	add := func(totals []total, set func(counts *UsageCounts, value int64)) {
+		for _, t := range totals {
+			counts, ok := byUser[t.UserID]
+			if !ok {
+				counts = &UsageCounts{UserID: t.UserID}
+				byUser[t.UserID] = counts
+			}
+			set(counts, t.Total)
+		}
+	}
+
+	var records []total
+	err := r.db.WithContext(ctx).Raw(`SELECT user_id, COUNT(*) AS total FROM (`+recordsQuery+`) AS records GROUP BY user_id`,
+		map[string]interface{}{"since": since}).Scan(&records).Error
+	if err != nil {
+		return nil, err
+	}
+	add(records, func(counts *UsageCounts, value int64) { counts.Records = value })
+
+	var storage []total
+	err = r.db.WithContext(ctx).Model(&EvidencePhoto{}).Select("user_id, SUM(size) AS total").
+		Group("user_id").Scan(&storage).Error
+	if err != nil {
+		return nil, err
+	}
+	add(storage, func(counts *UsageCounts, value int64) { counts.StorageBytes = value })
+
+	var sms []total
+	err = r.db.WithContext(ctx).Model(&SMSCampaignRecipient{}).Select("user_id, COUNT(*) AS total").
+		Where("status = ? AND created_at >= ?", SMSRecipientQueued, since).
+		Group("user_id").Scan(&sms).Error
+	if err != nil {
+		return nil, err
+	}
+	add(sms, func(counts *UsageCounts, value int64) { counts.SMS = value })
+
+	all := make([]*UsageCounts, 0, len(byUser))
+	for _, counts := range byUser {
+		all = append(all, counts)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].UserID < all[j].UserID })
+	return all, nil
+}
+
+// GetSubscription retrieves the subscription of a user's books, or nil when they have none
+func (r *UsageRepository) GetSubscription(ctx context.Context, userID uint) (*Subscription, error) {
+	var subscription Subscription
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&subscription).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// GetSubscriptions retrieves all subscriptions
+func (r *UsageRepository) GetSubscriptions(ctx context.Context) ([]*Subscription, error) {
+	var subscriptions []*Subscription
+	result := r.db.WithContext(ctx).Order("user_id ASC").Find(&subscriptions)
+	return subscriptions, result.Error
+}
+
+// GetTrials retrieves the subscriptions still in their trial
+func (r *UsageRepository) GetTrials(ctx context.Context) ([]*Subscription, error) {
+	var subscriptions []*Subscription
+	result := r.db.WithContext(ctx).Where("status = ?", SubscriptionTrialing).Order("trial_ends_at ASC").Find(&subscriptions)
+	return subscriptions, result.Error
+}
+
+// SaveSubscription creates or changes the plan of a user's books
+func (r *UsageRepository) SaveSubscription(ctx context.Context, subscription *Subscription) error {
+	existing, err := r.GetSubscription(ctx, subscription.UserID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		subscription.ID = existing.ID
+		subscription.CreatedAt = existing.CreatedAt
+	}
+	return r.db.WithContext(ctx).Save(subscription).Error
+}
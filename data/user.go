@@ -1,17 +1,37 @@
 package data
 
 import (
+	"context"
 	"crypto/rand"
+	"crypto/subtle"
+	"errors"
 	"fmt"
 	"math/big"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Note: User struct is now defined in models.go
 
+// MaxOTPAttempts is the number of failed verifications of an email's codes after which every
+// further failure invalidates the code, until a code is verified
+const MaxOTPAttempts = 5
+
+// maxOTPRetryDelay caps the wait between verifications, which doubles with each failure
+const maxOTPRetryDelay = time.Hour
+
+var (
+	// ErrOTPInvalid is returned when an OTP is wrong, expired or already used
+	ErrOTPInvalid = errors.New("invalid or expired OTP")
+	// ErrOTPThrottled is returned when an OTP is verified again too soon after a failure
+	ErrOTPThrottled = errors.New("too many OTP attempts, try again later")
+	// ErrOTPAttemptsExceeded is returned when an OTP was invalidated after too many failures
+	ErrOTPAttemptsExceeded = errors.New("too many failed OTP attempts, request a new code")
+)
+
 // UserRepository implements UserInterface using GORM.
 type UserRepository struct {
 	db *gorm.DB
@@ -32,16 +52,16 @@ func HashPassword(password string) (string, error) {
 }
 
 // GetAll retrieves all users
-func (u *UserRepository) GetAll() ([]*User, error) {
+func (u *UserRepository) GetAll(ctx context.Context) ([]*User, error) {
 	var users []*User
-	result := u.db.Find(&users)
+	result := u.db.WithContext(ctx).Find(&users)
 	return users, result.Error
 }
 
 // GetByEmail retrieves a user by email
-func (u *UserRepository) GetByEmail(email string) (*User, error) {
+func (u *UserRepository) GetByEmail(ctx context.Context, email string) (*User, error) {
 	var user User
-	result := u.db.Where("email = ?", email).First(&user)
+	result := u.db.WithContext(ctx).Where("email = ?", email).First(&user)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -49,9 +69,9 @@ func (u *UserRepository) GetByEmail(email string) (*User, error) {
 }
 
 // GetOne retrieves a user by ID
-func (u *UserRepository) GetOne(id uint) (*User, error) {
+func (u *UserRepository) GetOne(ctx context.Context, id uint) (*User, error) {
 	var user User
-	result := u.db.First(&user, id)
+	result := u.db.WithContext(ctx).First(&user, id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -59,7 +79,7 @@ func (u *UserRepository) GetOne(id uint) (*User, error) {
 }
 
 // Insert creates a new user
-func (u *UserRepository) Insert(user *User) (uint, error) {
+func (u *UserRepository) Insert(ctx context.Context, user *User) (uint, error) {
 	// Hash the password before saving
 	hashedPassword, err := HashPassword(user.Password)
 	if err != nil {
@@ -67,12 +87,12 @@ func (u *UserRepository) Insert(user *User) (uint, error) {
 	}
 	user.Password = hashedPassword
 
-	result := u.db.Create(user)
+	result := u.db.WithContext(ctx).Create(user)
 	return user.ID, result.Error
 }
 
 // Update updates an existing user
-func (u *UserRepository) Update(user *User) error {
+func (u *UserRepository) Update(ctx context.Context, user *User) error {
 	// If password is being updated, hash it
 	if user.Password != "" {
 		hashedPassword, err := HashPassword(user.Password)
@@ -82,33 +102,74 @@ func (u *UserRepository) Update(user *User) error {
 		user.Password = hashedPassword
 	}
 
-	result := u.db.Save(user)
+	result := u.db.WithContext(ctx).Save(user)
 	return result.Error
 }
 
 // Delete soft deletes a user
-func (u *UserRepository) Delete(user *User) error {
-	result := u.db.Delete(user)
+func (u *UserRepository) Delete(ctx context.Context, user *User) error {
+	result := u.db.WithContext(ctx).Delete(user)
 	return result.Error
 }
 
 // DeleteByID soft deletes a user by ID
-func (u *UserRepository) DeleteByID(id uint) error {
-	result := u.db.Delete(&User{}, id)
+func (u *UserRepository) DeleteByID(ctx context.Context, id uint) error {
+	result := u.db.WithContext(ctx).Delete(&User{}, id)
 	return result.Error
 }
 
 // ResetPassword resets a user's password
-func (u *UserRepository) ResetPassword(userID uint, newPassword string) error {
+func (u *UserRepository) ResetPassword(ctx context.Context, userID uint, newPassword string) error {
 	hashedPassword, err := HashPassword(newPassword)
 	if err != nil {
 		return err
 	}
 
-	result := u.db.Model(&User{}).Where("id = ?", userID).Update("password", hashedPassword)
+	result := u.db.WithContext(ctx).Model(&User{}).Where("id = ?", userID).Update("password", hashedPassword)
 	return result.Error
 }
 
+// Locked reports whether the user is locked out of password login after too many failed attempts
+func (user *User) Locked() bool {
+	return user.LockedUntil != nil && user.LockedUntil.After(time.Now())
+}
+
+// RecordFailedLogin counts a failed password login of a user, locking the account for lockout
+// once maxFailures consecutive logins have failed. It returns the end of the lockout when the
+// account was locked, and nil otherwise. The count starts over after a lockout.
+func (u *UserRepository) RecordFailedLogin(ctx context.Context, userID uint, maxFailures int, lockout time.Duration) (*time.Time, error) {
+	var lockedUntil *time.Time
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id, failed_logins").First(&user, userID).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{"failed_logins": user.FailedLogins + 1}
+		if user.FailedLogins+1 >= maxFailures {
+			until := time.Now().Add(lockout)
+			lockedUntil = &until
+			updates = map[string]interface{}{"failed_logins": 0, "locked_until": until}
+		}
+		return tx.Model(&User{}).Where("id = ?", userID).Updates(updates).Error
+	})
+	return lockedUntil, err
+}
+
+// Unlock clears the failed logins and the lockout of a user. It returns gorm.ErrRecordNotFound
+// when the user doesn't exist.
+func (u *UserRepository) Unlock(ctx context.Context, userID uint) error {
+	result := u.db.WithContext(ctx).Model(&User{}).Where("id = ?", userID).
+		Updates(map[string]interface{}{"failed_logins": 0, "locked_until": nil})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
 // PasswordMatches checks if the provided password matches the user's password
 func (u *UserRepository) PasswordMatches(user *User, plainText string) (bool, error) {
 	err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(plainText))
@@ -118,8 +179,9 @@ func (u *UserRepository) PasswordMatches(user *User, plainText string) (bool, er
 	return true, nil
 }
 
-// GenerateAndSaveOTP generates a 6-digit OTP and saves it to the user
-func (u *UserRepository) GenerateAndSaveOTP(email string) (string, error) {
+// GenerateAndSaveOTP generates a 6-digit OTP for purpose, one of the OTPPurpose constants, and
+// saves it to the user. It replaces any code sent before, whatever it was sent for.
+func (u *UserRepository) GenerateAndSaveOTP(ctx context.Context, email, purpose string) (string, error) {
 	// Generate 6-digit OTP
 	otp, err := generateOTP()
 	if err != nil {
@@ -129,10 +191,12 @@ func (u *UserRepository) GenerateAndSaveOTP(email string) (string, error) {
 	// Set expiration time (10 minutes from now)
 	expiresAt := time.Now().Add(10 * time.Minute)
 
-	// Update user with OTP and expiration
-	result := u.db.Model(&User{}).Where("email = ?", email).Updates(map[string]interface{}{
+	// Update user with OTP and expiration. The failed attempts and the wait they impose carry
+	// over, so requesting a new code doesn't buy more guesses.
+	result := u.db.WithContext(ctx).Model(&User{}).Where("email = ?", email).Updates(map[string]interface{}{
 		"otp_code":       otp,
 		"otp_expires_at": expiresAt,
+		"otp_purpose":    purpose,
 	})
 
 	if result.Error != nil {
@@ -142,47 +206,153 @@ func (u *UserRepository) GenerateAndSaveOTP(email string) (string, error) {
 	return otp, nil
 }
 
-// VerifyOTP verifies if the provided OTP is valid for the email
-func (u *UserRepository) VerifyOTP(email, otp string) (bool, error) {
-	var user User
-	result := u.db.Where("email = ? AND otp_code = ? AND otp_expires_at > ?",
-		email, otp, time.Now()).First(&user)
+// ResetPasswordWithOTP resets password using OTP verification. The OTP is consumed in the
+// same transaction so it cannot be reused.
+func (u *UserRepository) ResetPasswordWithOTP(ctx context.Context, email, otp, newPassword string) error {
+	// Hash the new password
+	hashedPassword, err := HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
 
-	if result.Error != nil {
-		if result.Error == gorm.ErrRecordNotFound {
-			return false, nil
-		}
-		return false, result.Error
+	// Resetting the password also lifts a lockout after failed logins
+	_, err = u.consumeOTP(ctx, email, otp, OTPPurposePasswordReset, map[string]interface{}{
+		"password":      hashedPassword,
+		"failed_logins": 0,
+		"locked_until":  nil,
+	})
+	return err
+}
+
+// LoginWithOTP verifies and consumes an OTP sent for phone login, returning the user
+func (u *UserRepository) LoginWithOTP(ctx context.Context, email, otp string) (*User, error) {
+	return u.consumeOTP(ctx, email, otp, OTPPurposePhoneLogin, nil)
+}
+
+// VerifyEmail verifies and consumes an OTP emailed to a new user, marking their email verified
+func (u *UserRepository) VerifyEmail(ctx context.Context, email, otp string) (*User, error) {
+	user, err := u.consumeOTP(ctx, email, otp, OTPPurposeEmailVerify, map[string]interface{}{"email_verified": true})
+	if err != nil {
+		return nil, err
 	}
+	user.EmailVerified = true
+	return user, nil
+}
 
-	return true, nil
+// SetPendingPhone stores a phone number to be linked once the user confirms it with an OTP
+func (u *UserRepository) SetPendingPhone(ctx context.Context, userID uint, phone string) error {
+	result := u.db.WithContext(ctx).Model(&User{}).Where("id = ?", userID).Update("pending_phone", phone)
+	return result.Error
 }
 
-// ResetPasswordWithOTP resets password using OTP verification
-func (u *UserRepository) ResetPasswordWithOTP(email, otp, newPassword string) error {
-	// First verify the OTP
-	valid, err := u.VerifyOTP(email, otp)
+// ConfirmPendingPhone verifies and consumes an OTP sent to the pending phone number,
+// returning the confirmed number
+func (u *UserRepository) ConfirmPendingPhone(ctx context.Context, email, otp string) (string, error) {
+	user, err := u.consumeOTP(ctx, email, otp, OTPPurposePhoneLink, map[string]interface{}{"pending_phone": nil})
 	if err != nil {
-		return err
+		return "", err
 	}
-	if !valid {
-		return fmt.Errorf("invalid or expired OTP")
+	if user.PendingPhone == nil || *user.PendingPhone == "" {
+		return "", ErrOTPInvalid
 	}
+	return *user.PendingPhone, nil
+}
 
-	// Hash the new password
-	hashedPassword, err := HashPassword(newPassword)
+// consumeOTP checks the OTP sent for purpose and, on a match, clears it together with any extra
+// updates in the same transaction. Failed attempts are committed so they stay counted.
+func (u *UserRepository) consumeOTP(ctx context.Context, email, otp, purpose string, updates map[string]interface{}) (*User, error) {
+	var matched *User
+	var otpErr error
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		user, err := checkOTP(tx, email, otp, purpose)
+		if err != nil && !isOTPError(err) {
+			return err
+		}
+		if user == nil {
+			// Commit so a failed attempt stays counted
+			otpErr = err
+			if otpErr == nil {
+				otpErr = ErrOTPInvalid
+			}
+			return nil
+		}
+
+		clear := map[string]interface{}{
+			"otp_code":       "",
+			"otp_expires_at": nil,
+			"otp_purpose":    "",
+			"otp_attempts":   0,
+			"otp_retry_at":   nil,
+		}
+		for column, value := range updates {
+			clear[column] = value
+		}
+		matched = user
+		return tx.Model(&User{}).Where("id = ?", user.ID).Updates(clear).Error
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
+	if otpErr != nil {
+		return nil, otpErr
+	}
+	return matched, nil
+}
 
-	// Update password and clear OTP
-	result := u.db.Model(&User{}).Where("email = ?", email).Updates(map[string]interface{}{
-		"password":       hashedPassword,
-		"otp_code":       "",
-		"otp_expires_at": nil,
-	})
+// checkOTP locks the user row and compares the OTP. It returns the user on a match and nil when
+// the code is wrong, expired or was sent for another purpose, e.g. a phone link code submitted to
+// verify the email. Failures are counted per email, across the codes sent to it,
+// until a code is verified: each doubles the wait before the next attempt, up to
+// maxOTPRetryDelay, and from MaxOTPAttempts failures on each failure invalidates the code.
+func checkOTP(tx *gorm.DB, email, otp, purpose string) (*User, error) {
+	var user User
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("email = ?", email).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
 
-	return result.Error
+	now := time.Now()
+	if user.OTPCode == "" || user.OTPExpiresAt == nil || !user.OTPExpiresAt.After(now) {
+		return nil, nil
+	}
+	if user.OTPRetryAt != nil && user.OTPRetryAt.After(now) {
+		return nil, ErrOTPThrottled
+	}
+
+	if subtle.ConstantTimeCompare([]byte(user.OTPCode), []byte(otp)) == 1 && user.OTPPurpose == purpose {
+		return &user, nil
+	}
+
+	attempts := user.OTPAttempts + 1
+	delay := maxOTPRetryDelay
+	if attempts <= 12 {
+		delay = min(time.Duration(1<<(attempts-1))*time.Second, maxOTPRetryDelay)
+	}
+	updates := map[string]interface{}{
+		"otp_attempts": attempts,
+		"otp_retry_at": now.Add(delay),
+	}
+	if attempts >= MaxOTPAttempts {
+		updates["otp_code"] = ""
+		updates["otp_expires_at"] = nil
+		updates["otp_purpose"] = ""
+	}
+	if err := tx.Model(&user).Updates(updates).Error; err != nil {
+		return nil, err
+	}
+
+	if attempts >= MaxOTPAttempts {
+		return nil, ErrOTPAttemptsExceeded
+	}
+	return nil, nil
+}
+
+// isOTPError reports whether err is a verification outcome rather than a database failure
+func isOTPError(err error) bool {
+	return errors.Is(err, ErrOTPInvalid) || errors.Is(err, ErrOTPThrottled) || errors.Is(err, ErrOTPAttemptsExceeded)
 }
 
 // generateOTP generates a random 6-digit OTP
@@ -0,0 +1,160 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// newOTPUser stores a user to send codes to and returns the repository
+func newOTPUser(t *testing.T, email string) (*UserRepository, *User) {
+	t.Helper()
+	db := newTestDB(t, &User{})
+	user := &User{Email: email, Name: "Test", Password: "hash"}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+	return &UserRepository{db: db}, user
+}
+
+// otpState reloads the OTP columns of a user
+func otpState(t *testing.T, repo *UserRepository, id uint) User {
+	t.Helper()
+	var user User
+	if err := repo.db.First(&user, id).Error; err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+func TestOTPAttempts(t *testing.T) {
+	repo, user := newOTPUser(t, "miner@example.com")
+	ctx := context.Background()
+	code, err := repo.GenerateAndSaveOTP(ctx, user.Email, OTPPurposePhoneLogin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+	allowRetry := func() {
+		if err := repo.db.Model(user).Update("otp_retry_at", nil).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for attempt := 1; attempt < MaxOTPAttempts; attempt++ {
+		before := time.Now()
+		if _, err := repo.LoginWithOTP(ctx, user.Email, wrong); !errors.Is(err, ErrOTPInvalid) {
+			t.Fatalf("attempt %d returned %v, want %v", attempt, err, ErrOTPInvalid)
+		}
+		state := otpState(t, repo, user.ID)
+		if state.OTPAttempts != attempt {
+			t.Errorf("attempt %d counted as %d", attempt, state.OTPAttempts)
+		}
+		// The wait doubles with each failure: 1s, 2s, 4s, ...
+		wait := time.Duration(1<<(attempt-1)) * time.Second
+		if state.OTPRetryAt == nil || state.OTPRetryAt.Before(before.Add(wait-time.Second)) || state.OTPRetryAt.After(time.Now().Add(wait)) {
+			t.Errorf("attempt %d: retry at %v, want %v after it", attempt, state.OTPRetryAt, wait)
+		}
+
+		// Even the right code is refused until the wait is over
+		if _, err := repo.LoginWithOTP(ctx, user.Email, code); !errors.Is(err, ErrOTPThrottled) {
+			t.Fatalf("attempt during the wait returned %v, want %v", err, ErrOTPThrottled)
+		}
+		allowRetry()
+	}
+
+	if _, err := repo.LoginWithOTP(ctx, user.Email, wrong); !errors.Is(err, ErrOTPAttemptsExceeded) {
+		t.Fatalf("attempt %d returned %v, want %v", MaxOTPAttempts, err, ErrOTPAttemptsExceeded)
+	}
+	allowRetry()
+	if _, err := repo.LoginWithOTP(ctx, user.Email, code); !errors.Is(err, ErrOTPInvalid) {
+		t.Fatalf("invalidated code returned %v, want %v", err, ErrOTPInvalid)
+	}
+
+	// A new code doesn't reset the count, so a single wrong guess invalidates it again
+	code, err = repo.GenerateAndSaveOTP(ctx, user.Email, OTPPurposePhoneLogin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.LoginWithOTP(ctx, user.Email, wrong); !errors.Is(err, ErrOTPAttemptsExceeded) {
+		t.Fatalf("wrong guess at a new code returned %v, want %v", err, ErrOTPAttemptsExceeded)
+	}
+
+	// Verifying a code clears the count
+	code, err = repo.GenerateAndSaveOTP(ctx, user.Email, OTPPurposePhoneLogin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	allowRetry()
+	if _, err := repo.LoginWithOTP(ctx, user.Email, code); err != nil {
+		t.Fatalf("right code returned %v", err)
+	}
+	if state := otpState(t, repo, user.ID); state.OTPAttempts != 0 || state.OTPRetryAt != nil {
+		t.Errorf("after a verified code: %d attempts, retry at %v; want 0, nil", state.OTPAttempts, state.OTPRetryAt)
+	}
+}
+
+func TestResetPasswordWithOTP(t *testing.T) {
+	repo, user := newOTPUser(t, "owner@example.com")
+	ctx := context.Background()
+	locked := time.Now().Add(time.Hour)
+	if err := repo.db.Model(user).Updates(map[string]interface{}{"failed_logins": 5, "locked_until": locked}).Error; err != nil {
+		t.Fatal(err)
+	}
+	code, err := repo.GenerateAndSaveOTP(ctx, user.Email, OTPPurposePasswordReset)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.ResetPasswordWithOTP(ctx, user.Email, code, "new-password"); err != nil {
+		t.Fatal(err)
+	}
+	state := otpState(t, repo, user.ID)
+	if matches, err := repo.PasswordMatches(&state, "new-password"); err != nil || !matches {
+		t.Errorf("new password doesn't match: %v", err)
+	}
+	if state.FailedLogins != 0 || state.LockedUntil != nil {
+		t.Errorf("reset left %d failed logins, locked until %v", state.FailedLogins, state.LockedUntil)
+	}
+
+	// The code is used up
+	if err := repo.ResetPasswordWithOTP(ctx, user.Email, code, "other-password"); !errors.Is(err, ErrOTPInvalid) {
+		t.Errorf("reusing the code returned %v, want %v", err, ErrOTPInvalid)
+	}
+}
+
+func TestOTPPurpose(t *testing.T) {
+	repo, user := newOTPUser(t, "clerk@example.com")
+	ctx := context.Background()
+	if err := repo.SetPendingPhone(ctx, user.ID, "+256700000001"); err != nil {
+		t.Fatal(err)
+	}
+	code, err := repo.GenerateAndSaveOTP(ctx, user.Email, OTPPurposePhoneLink)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A code texted to a phone the user chose proves nothing about their email
+	if _, err := repo.VerifyEmail(ctx, user.Email, code); !errors.Is(err, ErrOTPInvalid) {
+		t.Fatalf("verifying the email with a phone link code returned %v, want %v", err, ErrOTPInvalid)
+	}
+	state := otpState(t, repo, user.ID)
+	if state.EmailVerified {
+		t.Error("email verified with a phone link code")
+	}
+	if state.OTPAttempts != 1 {
+		t.Errorf("%d attempts counted, want 1", state.OTPAttempts)
+	}
+
+	if err := repo.db.Model(user).Update("otp_retry_at", nil).Error; err != nil {
+		t.Fatal(err)
+	}
+	phone, err := repo.ConfirmPendingPhone(ctx, user.Email, code)
+	if err != nil || phone != "+256700000001" {
+		t.Errorf("confirming the phone returned %q, %v", phone, err)
+	}
+}
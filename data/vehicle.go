@@ -0,0 +1,51 @@
+package data
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+// VehicleRepository implements VehicleInterface using GORM
+type VehicleRepository struct {
+	db *gorm.DB
+}
+
+// NewVehicleRepository creates a new instance of VehicleRepository
+func NewVehicleRepository(db *gorm.DB) VehicleInterface {
+	return &VehicleRepository{db: db}
+}
+
+// GetAll retrieves all vehicles for a user
+func (r *VehicleRepository) GetAll(ctx context.Context, userID uint) ([]*Vehicle, error) {
+	var vehicles []*Vehicle
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("registration ASC").Find(&vehicles)
+	return vehicles, result.Error
+}
+
+// GetOne retrieves a specific vehicle by ID for a user
+func (r *VehicleRepository) GetOne(ctx context.Context, id uint, userID uint) (*Vehicle, error) {
+	var vehicle Vehicle
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&vehicle)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &vehicle, nil
+}
+
+// Insert creates a new vehicle
+func (r *VehicleRepository) Insert(ctx context.Context, vehicle *Vehicle) (uint, error) {
+	result := r.db.WithContext(ctx).Create(vehicle)
+	return vehicle.ID, result.Error
+}
+
+// Update updates an existing vehicle
+func (r *VehicleRepository) Update(ctx context.Context, vehicle *Vehicle) error {
+	result := r.db.WithContext(ctx).Save(vehicle)
+	return result.Error
+}
+
+// Delete soft deletes a vehicle
+func (r *VehicleRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&Vehicle{})
+	return result.Error
+}
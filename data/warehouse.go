@@ -0,0 +1,93 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// warehouseTransactionsQuery selects a tenant's sales and expenses updated or deleted since
+// @from, soft deleted ones included, with the time they last changed
+const warehouseTransactionsQuery = `
+	SELECT 'income' AS transaction_type, i.id AS transaction_id, i.user_id AS tenant_id, i.date,
+		i.mineral_type AS category, i.item_name AS item, i.customer_name AS counterparty,
+		i.quantity, i.unit, i.total_amount AS amount, i.amount_paid, i.amount_due, i.payment_status,
+		i.created_at, i.updated_at, i.deleted_at,
+		CASE WHEN i.deleted_at > i.updated_at THEN i.deleted_at ELSE i.updated_at END AS changed_at
+	FROM incomes i
+	WHERE i.user_id = @tenant AND (i.updated_at >= @from OR i.deleted_at >= @from)
+	UNION ALL
+	SELECT 'expense', e.id, e.user_id, e.date,
+		e.category, e.description, e.supplier_name,
+		NULL, NULL, e.amount, e.amount_paid, e.amount_due, e.payment_status,
+		e.created_at, e.updated_at, e.deleted_at,
+		CASE WHEN e.deleted_at > e.updated_at THEN e.deleted_at ELSE e.updated_at END
+	FROM expenses e
+	WHERE e.user_id = @tenant AND (e.updated_at >= @from OR e.deleted_at >= @from)`
+
+// WarehouseRepository implements WarehouseInterface using GORM
+type WarehouseRepository struct {
+	db *gorm.DB
+}
+
+// NewWarehouseRepository creates a new instance of WarehouseRepository
+func NewWarehouseRepository(db *gorm.DB) WarehouseInterface {
+	return &WarehouseRepository{db: db}
+}
+
+// GetTenants retrieves the owners of books with sales or expenses
+func (r *WarehouseRepository) GetTenants(ctx context.Context) ([]uint, error) {
+	var tenants []uint
+	err := r.db.WithContext(ctx).Raw(`SELECT user_id FROM incomes UNION SELECT user_id FROM expenses ORDER BY user_id`).
+		Scan(&tenants).Error
+	return tenants, err
+}
+
+// GetWatermark retrieves the export position of a tenant's dataset in a schema version, or a new
+// unsaved watermark when nothing was exported yet
+func (r *WarehouseRepository) GetWatermark(ctx context.Context, tenantID uint, dataset string, schemaVersion int) (*WarehouseWatermark, error) {
+	var watermark WarehouseWatermark
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND dataset = ? AND schema_version = ?", tenantID, dataset, schemaVersion).
+		First(&watermark).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &WarehouseWatermark{TenantID: tenantID, Dataset: dataset, SchemaVersion: schemaVersion}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &watermark, nil
+}
+
+// SaveWatermark creates or updates a watermark
+func (r *WarehouseRepository) SaveWatermark(ctx context.Context, watermark *WarehouseWatermark) error {
+	return r.db.WithContext(ctx).Save(watermark).Error
+}
+
+// GetTransactions retrieves up to limit of a tenant's transactions that changed after the
+// watermark and before until, in change time, type and ID order. Soft deletes don't touch
+// updated_at, so a transaction's change time is the later of its update and deletion. Leaving the
+// last moments out lets transactions still being committed get a later export instead of being
+// skipped.
+func (r *WarehouseRepository) GetTransactions(ctx context.Context, tenantID uint, after *WarehouseWatermark, until time.Time, limit int) ([]*WarehouseTransaction, error) {
+	var transactions []*WarehouseTransaction
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT t.*, o.name AS organization, COALESCE(s.default_currency, 'UGX') AS currency
+		FROM (`+warehouseTransactionsQuery+`) AS t
+		LEFT JOIN organizations o ON o.owner_id = t.tenant_id AND o.deleted_at IS NULL
+		LEFT JOIN organization_settings s ON s.user_id = t.tenant_id AND s.deleted_at IS NULL
+		WHERE t.changed_at < @until AND (t.changed_at > @from OR (t.changed_at = @from
+			AND (t.transaction_type > @type OR (t.transaction_type = @type AND t.transaction_id > @id))))
+		ORDER BY t.changed_at, t.transaction_type, t.transaction_id
+		LIMIT @limit`,
+		map[string]interface{}{
+			"tenant": tenantID,
+			"from":   after.WatermarkAt,
+			"type":   after.WatermarkType,
+			"id":     after.WatermarkID,
+			"until":  until,
+			"limit":  limit,
+		}).Scan(&transactions).Error
+	return transactions, err
+}
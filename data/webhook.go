@@ -0,0 +1,72 @@
+package data
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+// WebhookRepository implements WebhookInterface using GORM
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new instance of WebhookRepository
+func NewWebhookRepository(db *gorm.DB) WebhookInterface {
+	return &WebhookRepository{db: db}
+}
+
+// GetAll retrieves the webhooks of a user
+func (r *WebhookRepository) GetAll(ctx context.Context, userID uint) ([]*Webhook, error) {
+	var webhooks []*Webhook
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at ASC").Find(&webhooks)
+	return webhooks, result.Error
+}
+
+// GetOne retrieves a webhook of a user
+func (r *WebhookRepository) GetOne(ctx context.Context, id uint, userID uint) (*Webhook, error) {
+	var webhook Webhook
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&webhook)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &webhook, nil
+}
+
+// GetSubscribed retrieves the active webhooks of a user subscribed to an event. Subscriptions
+// are matched here rather than in SQL so it works on every database.
+func (r *WebhookRepository) GetSubscribed(ctx context.Context, userID uint, event string) ([]*Webhook, error) {
+	var webhooks []*Webhook
+	result := r.db.WithContext(ctx).Where("user_id = ? AND active = ?", userID, true).Find(&webhooks)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var subscribed []*Webhook
+	for _, webhook := range webhooks {
+		for _, name := range webhook.Events {
+			if name == event {
+				subscribed = append(subscribed, webhook)
+				break
+			}
+		}
+	}
+	return subscribed, nil
+}
+
+// Insert creates a new webhook
+func (r *WebhookRepository) Insert(ctx context.Context, webhook *Webhook) (uint, error) {
+	result := r.db.WithContext(ctx).Create(webhook)
+	return webhook.ID, result.Error
+}
+
+// Delete removes a webhook of a user
+func (r *WebhookRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Where("id = ? AND user_id = ?", id, userID).Delete(&Webhook{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -1,30 +1,40 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"mineral/data"
 	"mineral/pkg/middleware"
+	"mineral/pkg/tracing"
 	"mineral/pkg/utils"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 )
 
-// AnalyticsHandler handles analytics-related requests
+// AnalyticsHandler handles analytics-related requests. Summaries cover the records in the books
+// only; archived sales and expenses are left out, and their yearly totals are at /archive.
 type AnalyticsHandler struct {
-	IncomeRepo  data.IncomeInterface
-	ExpenseRepo data.ExpenseInterface
+	IncomeRepo   data.IncomeInterface
+	ExpenseRepo  data.ExpenseInterface
+	SettingsRepo data.SettingsInterface
 }
 
 // NewAnalyticsHandler creates a new AnalyticsHandler
-func NewAnalyticsHandler(incomeRepo data.IncomeInterface, expenseRepo data.ExpenseInterface) *AnalyticsHandler {
+func NewAnalyticsHandler(incomeRepo data.IncomeInterface, expenseRepo data.ExpenseInterface, settingsRepo data.SettingsInterface) *AnalyticsHandler {
 	return &AnalyticsHandler{
-		IncomeRepo:  incomeRepo,
-		ExpenseRepo: expenseRepo,
+		IncomeRepo:   incomeRepo,
+		ExpenseRepo:  expenseRepo,
+		SettingsRepo: settingsRepo,
 	}
 }
 
-// GetFinancialSummary retrieves financial summary for the authenticated user
+// summaryPeriods are the preset periods of the financial summary
+var summaryPeriods = []string{"this_month", "last_month", "this_quarter", "last_quarter", "this_year", "last_year"}
+
+// GetFinancialSummary retrieves financial summary for the authenticated user, of all time or of a
+// preset period or the range between start_date and end_date
 func (h *AnalyticsHandler) GetFinancialSummary(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserIDFromRequest(r)
 	if userID == 0 {
@@ -32,8 +42,13 @@ func (h *AnalyticsHandler) GetFinancialSummary(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	period, start, end, ok := parseSummaryPeriod(w, r, time.Now())
+	if !ok {
+		return
+	}
+
 	// Get income summary
-	incomeSummary, err := h.IncomeRepo.GetFinancialSummary(userID)
+	incomeSummary, err := h.IncomeRepo.GetFinancialSummary(r.Context(), userID, start, end)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to retrieve income summary")
 		return
@@ -42,7 +57,7 @@ func (h *AnalyticsHandler) GetFinancialSummary(w http.ResponseWriter, r *http.Re
 		incomeSummary.TotalIncome, incomeSummary.TotalReceivables)
 
 	// Get expense summary
-	expenseSummary, err := h.ExpenseRepo.GetFinancialSummary(userID)
+	expenseSummary, err := h.ExpenseRepo.GetFinancialSummary(r.Context(), userID, start, end)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to retrieve expense summary")
 		return
@@ -67,11 +82,86 @@ func (h *AnalyticsHandler) GetFinancialSummary(w http.ResponseWriter, r *http.Re
 		TotalReceivables: incomeSummary.TotalReceivables,
 		TotalPayables:    expenseSummary.TotalPayables,
 		ProfitMargin:     profitMargin,
+		PrepaidBalance:   expenseSummary.PrepaidBalance,
+		Period:           period,
+		StartDate:        start,
+	}
+	if end != nil {
+		last := end.AddDate(0, 0, -1)
+		summary.EndDate = &last
 	}
 
 	utils.WriteSuccessResponse(w, "Financial summary retrieved successfully", summary)
 }
 
+// parseSummaryPeriod parses the period of a financial summary: a preset period relative to now, or
+// start_date and end_date, either of which may be left open. It returns the period's name and the
+// bounds of [start, end), both nil for all time, writing the error response and returning false
+// when they are invalid.
+func parseSummaryPeriod(w http.ResponseWriter, r *http.Request, now time.Time) (string, *time.Time, *time.Time, bool) {
+	query := r.URL.Query()
+	preset := query.Get("period")
+	startStr := query.Get("start_date")
+	endStr := query.Get("end_date")
+	if preset != "" && (startStr != "" || endStr != "") {
+		utils.WriteValidationError(w, "Use either a period or start and end dates")
+		return "", nil, nil, false
+	}
+
+	if preset != "" {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		month := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+		quarter := month.AddDate(0, -(int(month.Month()-1) % 3), 0)
+		year := time.Date(today.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		var start, end time.Time
+		switch preset {
+		case "this_month":
+			start, end = month, month.AddDate(0, 1, 0)
+		case "last_month":
+			start, end = month.AddDate(0, -1, 0), month
+		case "this_quarter":
+			start, end = quarter, quarter.AddDate(0, 3, 0)
+		case "last_quarter":
+			start, end = quarter.AddDate(0, -3, 0), quarter
+		case "this_year":
+			start, end = year, year.AddDate(1, 0, 0)
+		case "last_year":
+			start, end = year.AddDate(-1, 0, 0), year
+		default:
+			utils.WriteValidationError(w, "Period must be one of "+strings.Join(summaryPeriods, ", "))
+			return "", nil, nil, false
+		}
+		return preset, &start, &end, true
+	}
+
+	var start, end *time.Time
+	if startStr != "" {
+		parsed, err := time.Parse("2006-01-02", startStr)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid start date format. Use YYYY-MM-DD")
+			return "", nil, nil, false
+		}
+		start = &parsed
+	}
+	if endStr != "" {
+		parsed, err := time.Parse("2006-01-02", endStr)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid end date format. Use YYYY-MM-DD")
+			return "", nil, nil, false
+		}
+		if start != nil && parsed.Before(*start) {
+			utils.WriteValidationError(w, "End date must not be before start date")
+			return "", nil, nil, false
+		}
+		parsed = parsed.AddDate(0, 0, 1)
+		end = &parsed
+	}
+	if start == nil && end == nil {
+		return "", nil, nil, true
+	}
+	return "custom", start, end, true
+}
+
 // GetMonthlyData retrieves monthly financial data for a year
 func (h *AnalyticsHandler) GetMonthlyData(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserIDFromRequest(r)
@@ -95,14 +185,14 @@ func (h *AnalyticsHandler) GetMonthlyData(w http.ResponseWriter, r *http.Request
 	}
 
 	// Get monthly income data
-	incomeData, err := h.IncomeRepo.GetMonthlyData(userID, year)
+	incomeData, err := h.IncomeRepo.GetMonthlyData(r.Context(), userID, year)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to retrieve monthly income data")
 		return
 	}
 
 	// Get monthly expense data
-	expenseData, err := h.ExpenseRepo.GetMonthlyData(userID, year)
+	expenseData, err := h.ExpenseRepo.GetMonthlyData(r.Context(), userID, year)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to retrieve monthly expense data")
 		return
@@ -145,7 +235,7 @@ func (h *AnalyticsHandler) GetExpenseCategoryBreakdown(w http.ResponseWriter, r
 		return
 	}
 
-	breakdown, err := h.ExpenseRepo.GetCategoryBreakdown(userID)
+	breakdown, err := h.ExpenseRepo.GetCategoryBreakdown(r.Context(), userID)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to retrieve expense breakdown")
 		return
@@ -153,3 +243,190 @@ func (h *AnalyticsHandler) GetExpenseCategoryBreakdown(w http.ResponseWriter, r
 
 	utils.WriteSuccessResponse(w, "Expense breakdown retrieved successfully", breakdown)
 }
+
+// GetFiscalYearReport retrieves income, expenses and profit for a fiscal year by quarter and month
+func (h *AnalyticsHandler) GetFiscalYearReport(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	settings, err := h.SettingsRepo.GetByUserID(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve settings")
+		return
+	}
+
+	// Get fiscal year (the calendar year it starts in) from query parameter, default to the current one
+	start := settings.FiscalYearStart(time.Now())
+	if yearStr := r.URL.Query().Get("year"); yearStr != "" {
+		year, err := strconv.Atoi(yearStr)
+		if err != nil || year < 2000 || year > 3000 {
+			utils.WriteValidationError(w, "Invalid year")
+			return
+		}
+		start = time.Date(year, start.Month(), 1, 0, 0, 0, 0, start.Location())
+	}
+	end := start.AddDate(1, 0, 0)
+
+	months, err := h.getMonthlyBetween(r.Context(), userID, start, end)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve monthly data")
+		return
+	}
+
+	report := &data.FiscalYearReport{
+		Summary: summarizePeriod(settings.FiscalYearLabel(start), start, end, months),
+		Months:  months,
+	}
+	for q := 0; q < 4; q++ {
+		quarterStart := start.AddDate(0, 3*q, 0)
+		label := fmt.Sprintf("Q%d", q+1)
+		report.Quarters = append(report.Quarters, summarizePeriod(label, quarterStart, quarterStart.AddDate(0, 3, 0), months[3*q:3*q+3]))
+	}
+
+	utils.WriteSuccessResponse(w, "Fiscal year report retrieved successfully", report)
+}
+
+// GetFiscalYTDSummary retrieves fiscal year-to-date results compared with the prior fiscal year
+func (h *AnalyticsHandler) GetFiscalYTDSummary(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	settings, err := h.SettingsRepo.GetByUserID(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve settings")
+		return
+	}
+
+	now := time.Now()
+	start := settings.FiscalYearStart(now)
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
+
+	current, err := h.getMonthlyBetween(r.Context(), userID, start, end)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve monthly data")
+		return
+	}
+
+	priorStart, priorEnd := start.AddDate(-1, 0, 0), end.AddDate(-1, 0, 0)
+	prior, err := h.getMonthlyBetween(r.Context(), userID, priorStart, priorEnd)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve monthly data")
+		return
+	}
+
+	summary := &data.FiscalYTDSummary{
+		Current:   summarizePeriod(settings.FiscalYearLabel(start)+" YTD", start, end, current),
+		PriorYear: summarizePeriod(settings.FiscalYearLabel(priorStart)+" YTD", priorStart, priorEnd, prior),
+	}
+
+	utils.WriteSuccessResponse(w, "Fiscal year-to-date summary retrieved successfully", summary)
+}
+
+// GetPeriodSummary retrieves income, expenses and profit for a custom date range
+func (h *AnalyticsHandler) GetPeriodSummary(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	startStr := r.URL.Query().Get("start_date")
+	endStr := r.URL.Query().Get("end_date")
+	if !utils.ValidateRequired(startStr) || !utils.ValidateRequired(endStr) {
+		utils.WriteValidationError(w, "Start date and end date are required")
+		return
+	}
+
+	start, err := time.Parse("2006-01-02", startStr)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid start date format. Use YYYY-MM-DD")
+		return
+	}
+	end, err := time.Parse("2006-01-02", endStr)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid end date format. Use YYYY-MM-DD")
+		return
+	}
+	if end.Before(start) {
+		utils.WriteValidationError(w, "End date must not be before start date")
+		return
+	}
+	end = end.AddDate(0, 0, 1)
+
+	months, err := h.getMonthlyBetween(r.Context(), userID, start, end)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve monthly data")
+		return
+	}
+
+	report := &data.FiscalYearReport{
+		Summary: summarizePeriod(startStr+" to "+endStr, start, end, months),
+		Months:  months,
+	}
+
+	utils.WriteSuccessResponse(w, "Period summary retrieved successfully", report)
+}
+
+// getMonthlyBetween combines monthly income and expenses for [start, end), including empty months
+func (h *AnalyticsHandler) getMonthlyBetween(ctx context.Context, userID uint, start, end time.Time) ([]*data.MonthlyData, error) {
+	ctx, span := tracing.Start(ctx, "monthly income and expenses")
+	defer span.End()
+
+	incomeData, err := h.IncomeRepo.GetMonthlyDataBetween(ctx, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	expenseData, err := h.ExpenseRepo.GetMonthlyDataBetween(ctx, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var months []*data.MonthlyData
+	byMonth := make(map[string]*data.MonthlyData)
+	for month := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location()); month.Before(end); month = month.AddDate(0, 1, 0) {
+		item := &data.MonthlyData{Month: month.Format("2006-01")}
+		byMonth[item.Month] = item
+		months = append(months, item)
+	}
+
+	for _, item := range incomeData {
+		if month, ok := byMonth[item.Month]; ok {
+			month.Income = item.Income
+		}
+	}
+	for _, item := range expenseData {
+		if month, ok := byMonth[item.Month]; ok {
+			month.Expenses = item.Expenses
+		}
+	}
+	for _, month := range months {
+		month.Profit = month.Income - month.Expenses
+	}
+
+	return months, nil
+}
+
+// summarizePeriod totals monthly data into a period summary; end is exclusive
+func summarizePeriod(label string, start, end time.Time, months []*data.MonthlyData) *data.PeriodSummary {
+	summary := &data.PeriodSummary{
+		Label:     label,
+		StartDate: start,
+		EndDate:   end.AddDate(0, 0, -1),
+	}
+	for _, month := range months {
+		summary.Income += month.Income
+		summary.Expenses += month.Expenses
+	}
+	summary.NetProfit = summary.Income - summary.Expenses
+	if summary.Income > 0 {
+		summary.ProfitMargin = (summary.NetProfit / summary.Income) * 100
+	}
+	return summary
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+)
+
+// ArchiveHandler handles requests about archived transactions
+type ArchiveHandler struct {
+	ArchiveRepo data.ArchiveInterface
+}
+
+// NewArchiveHandler creates a new ArchiveHandler
+func NewArchiveHandler(archiveRepo data.ArchiveInterface) *ArchiveHandler {
+	return &ArchiveHandler{ArchiveRepo: archiveRepo}
+}
+
+// GetArchivedPeriods lists the years with archived sales or expenses and their totals. The
+// records of a year are listed by /income/range and /expense/range with archived=true.
+func (h *ArchiveHandler) GetArchivedPeriods(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	periods, err := h.ArchiveRepo.GetPeriods(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve archived periods")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Archived periods retrieved successfully", periods)
+}
@@ -0,0 +1,434 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// Period of sales in grade-versus-price analytics, in days
+const (
+	defaultGradePriceDays = 365
+	maxGradePriceDays     = 1095
+)
+
+// AssayHandler handles the laboratory assay results of production batches and sales
+type AssayHandler struct {
+	AssayRepo      data.AssayInterface
+	InventoryRepo  data.InventoryInterface
+	IncomeRepo     data.IncomeInterface
+	AttachmentRepo data.AttachmentInterface
+}
+
+// NewAssayHandler creates a new AssayHandler
+func NewAssayHandler(assayRepo data.AssayInterface, inventoryRepo data.InventoryInterface, incomeRepo data.IncomeInterface, attachmentRepo data.AttachmentInterface) *AssayHandler {
+	return &AssayHandler{
+		AssayRepo:      assayRepo,
+		InventoryRepo:  inventoryRepo,
+		IncomeRepo:     incomeRepo,
+		AttachmentRepo: attachmentRepo,
+	}
+}
+
+// AssayRequest represents the assay result of a production batch, a sale or both
+type AssayRequest struct {
+	InventoryItemID   *uint   `json:"inventory_item_id"`
+	IncomeID          *uint   `json:"income_id"`
+	Laboratory        string  `json:"laboratory"`
+	AssayDate         string  `json:"assay_date"`
+	MineralType       string  `json:"mineral_type"` // defaults to the sale's
+	Grade             float64 `json:"grade"`
+	GradeUnit         string  `json:"grade_unit"` // "percent" or "g_per_t"
+	CertificateNumber *string `json:"certificate_number"`
+	Notes             *string `json:"notes"`
+}
+
+// AssayResponse is an assay with its attached certificates
+type AssayResponse struct {
+	*data.Assay
+	Certificates []*data.Attachment `json:"certificates"`
+}
+
+// GradePriceSeries relates the grades and prices of the assayed sales of a mineral in one grade
+// unit and one sale unit. The fit is a least-squares line of price against grade; it is left
+// out when there are fewer than two distinct grades.
+type GradePriceSeries struct {
+	GradeUnit      data.AssayGradeUnit `json:"grade_unit"`
+	Unit           string              `json:"unit"`
+	Sales          int                 `json:"sales"`
+	AverageGrade   float64             `json:"average_grade"`
+	AveragePrice   float64             `json:"average_price"`
+	PricePerGrade  *float64            `json:"price_per_grade,omitempty"` // slope of the fit
+	Intercept      *float64            `json:"intercept,omitempty"`
+	Correlation    *float64            `json:"correlation,omitempty"`
+	EstimatedPrice *float64            `json:"estimated_price,omitempty"` // at the requested grade
+	Points         []*data.AssayedSale `json:"points"`
+}
+
+// GradePriceAnalysis is the grade-versus-price analysis of a mineral's assayed sales
+type GradePriceAnalysis struct {
+	MineralType data.MineralType    `json:"mineral_type"`
+	Days        int                 `json:"days"`
+	Series      []*GradePriceSeries `json:"series"`
+}
+
+// GetAssays returns the assays of the user, optionally of a batch, a sale or a mineral
+func (h *AssayHandler) GetAssays(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	query := r.URL.Query()
+	filter := data.AssayFilter{MineralType: data.MineralType(query.Get("mineral_type"))}
+	if idStr := query.Get("inventory_item_id"); idStr != "" {
+		id, err := strconv.ParseUint(idStr, 10, 32)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid inventory item ID")
+			return
+		}
+		itemID := uint(id)
+		filter.InventoryItemID = &itemID
+	}
+	if idStr := query.Get("income_id"); idStr != "" {
+		id, err := strconv.ParseUint(idStr, 10, 32)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid income ID")
+			return
+		}
+		incomeID := uint(id)
+		filter.IncomeID = &incomeID
+	}
+
+	assays, err := h.AssayRepo.GetAll(r.Context(), userID, filter)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve assays")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Assays retrieved successfully", assays)
+}
+
+// GetAssay returns an assay with its certificates
+func (h *AssayHandler) GetAssay(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	assay, ok := h.assay(w, r, userID)
+	if !ok {
+		return
+	}
+	certificates, err := h.AttachmentRepo.GetForRecord(r.Context(), userID, data.AttachmentRecordAssay, assay.ID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve certificates")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Assay retrieved successfully", AssayResponse{Assay: assay, Certificates: certificates})
+}
+
+// CreateAssay records the assay result of a production batch, a sale or both
+func (h *AssayHandler) CreateAssay(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req AssayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	actorID := middleware.GetActorIDFromRequest(r)
+	assay := &data.Assay{RecordedByID: &actorID, UserID: userID}
+	if !h.applyRequest(r.Context(), w, userID, &req, assay) {
+		return
+	}
+
+	id, err := h.AssayRepo.Insert(r.Context(), assay)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to create assay")
+		return
+	}
+	assay.ID = id
+
+	utils.WriteSuccessResponse(w, "Assay created successfully", assay)
+}
+
+// UpdateAssay updates an assay
+func (h *AssayHandler) UpdateAssay(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	assay, ok := h.assay(w, r, userID)
+	if !ok {
+		return
+	}
+
+	var req AssayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if !h.applyRequest(r.Context(), w, userID, &req, assay) {
+		return
+	}
+
+	if err := h.AssayRepo.Update(r.Context(), assay); err != nil {
+		utils.WriteInternalServerError(w, "Failed to update assay")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Assay updated successfully", assay)
+}
+
+// DeleteAssay deletes an assay
+func (h *AssayHandler) DeleteAssay(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	assay, ok := h.assay(w, r, userID)
+	if !ok {
+		return
+	}
+	if err := h.AssayRepo.Delete(r.Context(), assay.ID, userID); err != nil {
+		utils.WriteInternalServerError(w, "Failed to delete assay")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Assay deleted successfully", nil)
+}
+
+// GetGradePriceAnalysis relates the assayed grades of a mineral's sales to their prices, with
+// a least-squares fit per grade unit and sale unit. With grade, each fit estimates the price of
+// that grade, as a reference for negotiating and valuing lots of a verified grade.
+func (h *AssayHandler) GetGradePriceAnalysis(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	query := r.URL.Query()
+	mineralType := data.MineralType(query.Get("mineral_type"))
+	if !utils.ValidateRequired(string(mineralType)) {
+		utils.WriteValidationError(w, "Mineral type is required")
+		return
+	}
+	days := defaultGradePriceDays
+	if daysStr := query.Get("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed < 1 || parsed > maxGradePriceDays {
+			utils.WriteValidationError(w, fmt.Sprintf("Days must be between 1 and %d", maxGradePriceDays))
+			return
+		}
+		days = parsed
+	}
+	var grade *float64
+	if gradeStr := query.Get("grade"); gradeStr != "" {
+		parsed, err := strconv.ParseFloat(gradeStr, 64)
+		if err != nil || parsed <= 0 {
+			utils.WriteValidationError(w, "Grade must be a positive number")
+			return
+		}
+		grade = &parsed
+	}
+
+	sales, err := h.AssayRepo.GetAssayedSales(r.Context(), userID, mineralType, time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve assayed sales")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Grade-price analysis retrieved successfully", GradePriceAnalysis{
+		MineralType: mineralType,
+		Days:        days,
+		Series:      gradePriceSeries(sales, grade),
+	})
+}
+
+// applyRequest validates an assay request and applies it to assay, writing the error response
+// and returning false when it is invalid
+func (h *AssayHandler) applyRequest(ctx context.Context, w http.ResponseWriter, userID uint, req *AssayRequest, assay *data.Assay) bool {
+	if req.InventoryItemID == nil && req.IncomeID == nil {
+		utils.WriteValidationError(w, "An inventory item ID or an income ID is required")
+		return false
+	}
+	laboratory := strings.TrimSpace(req.Laboratory)
+	if !utils.ValidateRequired(laboratory) {
+		utils.WriteValidationError(w, "Laboratory is required")
+		return false
+	}
+	if len(laboratory) > 150 {
+		utils.WriteValidationError(w, "Laboratory must be at most 150 characters")
+		return false
+	}
+	assayDate, err := time.Parse("2006-01-02", req.AssayDate)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid assay date format. Use YYYY-MM-DD")
+		return false
+	}
+	if !utils.ValidatePositiveNumber(req.Grade) {
+		utils.WriteValidationError(w, "Grade must be positive")
+		return false
+	}
+	gradeUnit := data.AssayGradeUnit(req.GradeUnit)
+	switch gradeUnit {
+	case data.GradePercent:
+		if req.Grade > 100 {
+			utils.WriteValidationError(w, "Grade in percent must be at most 100")
+			return false
+		}
+	case data.GradeGramsPerTonne:
+	default:
+		utils.WriteValidationError(w, "Grade unit must be percent or g_per_t")
+		return false
+	}
+
+	mineralType := data.MineralType(strings.TrimSpace(req.MineralType))
+	if req.InventoryItemID != nil {
+		item, err := h.InventoryRepo.GetOne(ctx, *req.InventoryItemID, userID)
+		if err != nil {
+			utils.WriteNotFoundError(w, "Inventory item not found")
+			return false
+		}
+		if item.Type != "mineral" {
+			utils.WriteValidationError(w, "Only mineral lots can be assayed")
+			return false
+		}
+	}
+	if req.IncomeID != nil {
+		income, err := h.IncomeRepo.GetOne(ctx, *req.IncomeID, userID)
+		if err != nil {
+			utils.WriteNotFoundError(w, "Income record not found")
+			return false
+		}
+		if mineralType == "" {
+			mineralType = income.MineralType
+		} else if mineralType != income.MineralType {
+			utils.WriteValidationError(w, "Mineral type must be the sale's mineral type")
+			return false
+		}
+	}
+	if !utils.ValidateRequired(string(mineralType)) {
+		utils.WriteValidationError(w, "Mineral type is required")
+		return false
+	}
+
+	assay.InventoryItemID = req.InventoryItemID
+	assay.IncomeID = req.IncomeID
+	assay.Laboratory = laboratory
+	assay.AssayDate = assayDate
+	assay.MineralType = mineralType
+	assay.Grade = req.Grade
+	assay.GradeUnit = gradeUnit
+	assay.CertificateNumber = req.CertificateNumber
+	assay.Notes = req.Notes
+	return true
+}
+
+// assay returns the assay of a request, writing the error response and returning false when it
+// doesn't exist
+func (h *AssayHandler) assay(w http.ResponseWriter, r *http.Request, userID uint) (*data.Assay, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid assay ID")
+		return nil, false
+	}
+	assay, err := h.AssayRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Assay not found")
+			return nil, false
+		}
+		utils.WriteInternalServerError(w, "Failed to retrieve assay")
+		return nil, false
+	}
+	return assay, true
+}
+
+// gradePriceSeries groups assayed sales by grade unit and sale unit, in order of first sale,
+// and fits price against grade in each group
+func gradePriceSeries(sales []*data.AssayedSale, grade *float64) []*GradePriceSeries {
+	byKey := map[string]*GradePriceSeries{}
+	series := []*GradePriceSeries{}
+	for _, sale := range sales {
+		key := string(sale.GradeUnit) + "|" + sale.Unit
+		s, ok := byKey[key]
+		if !ok {
+			s = &GradePriceSeries{GradeUnit: sale.GradeUnit, Unit: sale.Unit}
+			byKey[key] = s
+			series = append(series, s)
+		}
+		s.Points = append(s.Points, sale)
+	}
+
+	for _, s := range series {
+		n := float64(len(s.Points))
+		var sumGrade, sumPrice float64
+		for _, point := range s.Points {
+			sumGrade += point.Grade
+			sumPrice += point.PricePerUnit
+		}
+		s.Sales = len(s.Points)
+		s.AverageGrade = roundTo(sumGrade/n, 4)
+		s.AveragePrice = roundTo(sumPrice/n, 2)
+
+		var covariance, gradeVariance, priceVariance float64
+		for _, point := range s.Points {
+			dGrade := point.Grade - sumGrade/n
+			dPrice := point.PricePerUnit - sumPrice/n
+			covariance += dGrade * dPrice
+			gradeVariance += dGrade * dGrade
+			priceVariance += dPrice * dPrice
+		}
+		if gradeVariance == 0 {
+			continue
+		}
+		slope := covariance / gradeVariance
+		intercept := sumPrice/n - slope*sumGrade/n
+		s.PricePerGrade = floatPtr(roundTo(slope, 2))
+		s.Intercept = floatPtr(roundTo(intercept, 2))
+		if priceVariance > 0 {
+			s.Correlation = floatPtr(roundTo(covariance/math.Sqrt(gradeVariance*priceVariance), 4))
+		}
+		if grade != nil {
+			s.EstimatedPrice = floatPtr(roundTo(intercept+slope*(*grade), 2))
+		}
+	}
+	return series
+}
+
+// roundTo rounds a value to a number of decimal places
+func roundTo(value float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(value*scale) / scale
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
@@ -0,0 +1,440 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/storage"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// AttachmentHandler handles the files attached to income and expense records, such as receipts
+// and weighbridge slips, the supporting documents of due-diligence assessments, assay
+// certificates, the ID documents and photos of registered miners and the receipts of
+// reimbursement claims
+type AttachmentHandler struct {
+	AttachmentRepo data.AttachmentInterface
+	IncomeRepo     data.IncomeInterface
+	ExpenseRepo    data.ExpenseInterface
+	Store          storage.Store
+
+	// DueDiligenceRepo enables attachments on due-diligence assessments when set
+	DueDiligenceRepo data.DueDiligenceInterface
+
+	// AssayRepo enables certificates on assays when set
+	AssayRepo data.AssayInterface
+
+	// MinerRepo enables ID document scans and photos of registered miners when set
+	MinerRepo data.MinerInterface
+
+	// ClaimRepo enables receipts on reimbursement claims when set
+	ClaimRepo data.ClaimInterface
+
+	// Quota limits the files uploaded to the attachment storage quota; uploads aren't limited
+	// when it is nil
+	Quota *AttachmentQuota
+}
+
+// NewAttachmentHandler creates a new AttachmentHandler
+func NewAttachmentHandler(attachmentRepo data.AttachmentInterface, incomeRepo data.IncomeInterface, expenseRepo data.ExpenseInterface, store storage.Store) *AttachmentHandler {
+	return &AttachmentHandler{
+		AttachmentRepo: attachmentRepo,
+		IncomeRepo:     incomeRepo,
+		ExpenseRepo:    expenseRepo,
+		Store:          store,
+	}
+}
+
+// AttachmentRequest represents a file attached to an income or expense record
+type AttachmentRequest struct {
+	Data     string `json:"data"` // base64 encoded JPEG, PNG, WebP or PDF, optionally as a data URL
+	FileName string `json:"file_name"`
+	Kind     string `json:"kind,omitempty"` // "receipt", "weighbridge_slip", "invoice", "certificate" or "other" (default, "receipt" for claims); "id_document" or "photo" for miners
+}
+
+// GetIncomeAttachments returns the files attached to an income record
+func (h *AttachmentHandler) GetIncomeAttachments(w http.ResponseWriter, r *http.Request) {
+	h.getAttachments(w, r, data.AttachmentRecordIncome)
+}
+
+// AddIncomeAttachment attaches a file, such as a weighbridge slip, to an income record
+func (h *AttachmentHandler) AddIncomeAttachment(w http.ResponseWriter, r *http.Request) {
+	h.addAttachment(w, r, data.AttachmentRecordIncome)
+}
+
+// DownloadIncomeAttachment downloads a file attached to an income record
+func (h *AttachmentHandler) DownloadIncomeAttachment(w http.ResponseWriter, r *http.Request) {
+	h.downloadAttachment(w, r, data.AttachmentRecordIncome)
+}
+
+// DeleteIncomeAttachment removes a file from an income record
+func (h *AttachmentHandler) DeleteIncomeAttachment(w http.ResponseWriter, r *http.Request) {
+	h.deleteAttachment(w, r, data.AttachmentRecordIncome)
+}
+
+// GetExpenseAttachments returns the files attached to an expense record
+func (h *AttachmentHandler) GetExpenseAttachments(w http.ResponseWriter, r *http.Request) {
+	h.getAttachments(w, r, data.AttachmentRecordExpense)
+}
+
+// AddExpenseAttachment attaches a file, such as a receipt, to an expense record
+func (h *AttachmentHandler) AddExpenseAttachment(w http.ResponseWriter, r *http.Request) {
+	h.addAttachment(w, r, data.AttachmentRecordExpense)
+}
+
+// DownloadExpenseAttachment downloads a file attached to an expense record
+func (h *AttachmentHandler) DownloadExpenseAttachment(w http.ResponseWriter, r *http.Request) {
+	h.downloadAttachment(w, r, data.AttachmentRecordExpense)
+}
+
+// DeleteExpenseAttachment removes a file from an expense record
+func (h *AttachmentHandler) DeleteExpenseAttachment(w http.ResponseWriter, r *http.Request) {
+	h.deleteAttachment(w, r, data.AttachmentRecordExpense)
+}
+
+// GetDueDiligenceAttachments returns the supporting documents of a due-diligence assessment
+func (h *AttachmentHandler) GetDueDiligenceAttachments(w http.ResponseWriter, r *http.Request) {
+	h.getAttachments(w, r, data.AttachmentRecordDueDiligence)
+}
+
+// AddDueDiligenceAttachment attaches a supporting document, such as a licence or a mitigation
+// plan, to a due-diligence assessment
+func (h *AttachmentHandler) AddDueDiligenceAttachment(w http.ResponseWriter, r *http.Request) {
+	h.addAttachment(w, r, data.AttachmentRecordDueDiligence)
+}
+
+// DownloadDueDiligenceAttachment downloads a supporting document of a due-diligence assessment
+func (h *AttachmentHandler) DownloadDueDiligenceAttachment(w http.ResponseWriter, r *http.Request) {
+	h.downloadAttachment(w, r, data.AttachmentRecordDueDiligence)
+}
+
+// DeleteDueDiligenceAttachment removes a supporting document from a due-diligence assessment
+func (h *AttachmentHandler) DeleteDueDiligenceAttachment(w http.ResponseWriter, r *http.Request) {
+	h.deleteAttachment(w, r, data.AttachmentRecordDueDiligence)
+}
+
+// GetAssayAttachments returns the certificates of an assay
+func (h *AttachmentHandler) GetAssayAttachments(w http.ResponseWriter, r *http.Request) {
+	h.getAttachments(w, r, data.AttachmentRecordAssay)
+}
+
+// AddAssayAttachment attaches a certificate to an assay
+func (h *AttachmentHandler) AddAssayAttachment(w http.ResponseWriter, r *http.Request) {
+	h.addAttachment(w, r, data.AttachmentRecordAssay)
+}
+
+// DownloadAssayAttachment downloads a certificate of an assay
+func (h *AttachmentHandler) DownloadAssayAttachment(w http.ResponseWriter, r *http.Request) {
+	h.downloadAttachment(w, r, data.AttachmentRecordAssay)
+}
+
+// DeleteAssayAttachment removes a certificate from an assay
+func (h *AttachmentHandler) DeleteAssayAttachment(w http.ResponseWriter, r *http.Request) {
+	h.deleteAttachment(w, r, data.AttachmentRecordAssay)
+}
+
+// GetMinerAttachments returns the ID document scans and photos of a registered miner
+func (h *AttachmentHandler) GetMinerAttachments(w http.ResponseWriter, r *http.Request) {
+	h.getAttachments(w, r, data.AttachmentRecordMiner)
+}
+
+// AddMinerAttachment attaches an ID document scan or a photo to a registered miner
+func (h *AttachmentHandler) AddMinerAttachment(w http.ResponseWriter, r *http.Request) {
+	h.addAttachment(w, r, data.AttachmentRecordMiner)
+}
+
+// DownloadMinerAttachment downloads a file of a registered miner
+func (h *AttachmentHandler) DownloadMinerAttachment(w http.ResponseWriter, r *http.Request) {
+	h.downloadAttachment(w, r, data.AttachmentRecordMiner)
+}
+
+// DeleteMinerAttachment removes a file from a registered miner
+func (h *AttachmentHandler) DeleteMinerAttachment(w http.ResponseWriter, r *http.Request) {
+	h.deleteAttachment(w, r, data.AttachmentRecordMiner)
+}
+
+// GetClaimAttachments returns the receipts of a reimbursement claim
+func (h *AttachmentHandler) GetClaimAttachments(w http.ResponseWriter, r *http.Request) {
+	h.getAttachments(w, r, data.AttachmentRecordClaim)
+}
+
+// AddClaimAttachment attaches a receipt to a reimbursement claim
+func (h *AttachmentHandler) AddClaimAttachment(w http.ResponseWriter, r *http.Request) {
+	h.addAttachment(w, r, data.AttachmentRecordClaim)
+}
+
+// DownloadClaimAttachment downloads a receipt of a reimbursement claim
+func (h *AttachmentHandler) DownloadClaimAttachment(w http.ResponseWriter, r *http.Request) {
+	h.downloadAttachment(w, r, data.AttachmentRecordClaim)
+}
+
+// DeleteClaimAttachment removes a receipt from a reimbursement claim
+func (h *AttachmentHandler) DeleteClaimAttachment(w http.ResponseWriter, r *http.Request) {
+	h.deleteAttachment(w, r, data.AttachmentRecordClaim)
+}
+
+// getAttachments returns the files attached to a record, without their contents
+func (h *AttachmentHandler) getAttachments(w http.ResponseWriter, r *http.Request, recordType data.AttachmentRecordType) {
+	userID, recordID, ok := h.attachmentRecord(w, r, recordType)
+	if !ok {
+		return
+	}
+
+	attachments, err := h.AttachmentRepo.GetForRecord(r.Context(), userID, recordType, recordID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve attachments")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Attachments retrieved successfully", attachments)
+}
+
+// addAttachment stores a file and attaches it to a record
+func (h *AttachmentHandler) addAttachment(w http.ResponseWriter, r *http.Request, recordType data.AttachmentRecordType) {
+	userID, recordID, ok := h.attachmentRecord(w, r, recordType)
+	if !ok {
+		return
+	}
+
+	var req AttachmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if !utils.ValidateRequired(req.Data) {
+		utils.WriteValidationError(w, "Data is required")
+		return
+	}
+	fileName := strings.TrimSpace(req.FileName)
+	if fileName == "" {
+		utils.WriteValidationError(w, "File name is required")
+		return
+	}
+	if len(fileName) > 255 {
+		utils.WriteValidationError(w, "File name must be at most 255 characters")
+		return
+	}
+	kind := data.AttachmentOther
+	if recordType == data.AttachmentRecordClaim {
+		kind = data.AttachmentReceipt
+	}
+	if req.Kind != "" {
+		kind = data.AttachmentKind(req.Kind)
+	}
+	// ID document scans and photos are only kept for registered miners
+	if recordType == data.AttachmentRecordMiner {
+		switch kind {
+		case data.AttachmentIDDocument, data.AttachmentPhoto, data.AttachmentOther:
+		default:
+			utils.WriteValidationError(w, "Kind must be id_document, photo or other")
+			return
+		}
+	} else {
+		switch kind {
+		case data.AttachmentReceipt, data.AttachmentWeighbridgeSlip, data.AttachmentInvoice, data.AttachmentCertificate, data.AttachmentOther:
+		default:
+			utils.WriteValidationError(w, "Kind must be receipt, weighbridge_slip, invoice, certificate or other")
+			return
+		}
+	}
+
+	file, contentType, ok := decodeUpload(w, req.Data, maxDocumentSize, "Attachment", "a JPEG, PNG or WebP image or a PDF",
+		photoContentTypes, documentContentTypes)
+	if !ok {
+		return
+	}
+	if !h.Quota.allowUpload(r.Context(), w, userID, int64(len(file))) {
+		return
+	}
+
+	key, err := newStorageKey(userID, recordType, recordID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to save attachment")
+		return
+	}
+	if err := h.Store.Upload(key, bytes.NewReader(file), int64(len(file))); err != nil {
+		log.Printf("Failed to store attachment %s: %v", key, err)
+		utils.WriteInternalServerError(w, "Failed to save attachment")
+		return
+	}
+
+	actorID := middleware.GetActorIDFromRequest(r)
+	attachment := &data.Attachment{
+		RecordType:   recordType,
+		RecordID:     recordID,
+		Kind:         kind,
+		FileName:     fileName,
+		ContentType:  contentType,
+		Size:         int64(len(file)),
+		StorageKey:   key,
+		UploadedByID: &actorID,
+		UserID:       userID,
+	}
+	if err := h.AttachmentRepo.Insert(r.Context(), attachment); err != nil {
+		h.removeFile(key)
+		utils.WriteInternalServerError(w, "Failed to save attachment")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Attachment added successfully", attachment)
+}
+
+// downloadAttachment writes the contents of a file attached to a record
+func (h *AttachmentHandler) downloadAttachment(w http.ResponseWriter, r *http.Request, recordType data.AttachmentRecordType) {
+	attachment, ok := h.recordAttachment(w, r, recordType)
+	if !ok {
+		return
+	}
+
+	file, err := h.Store.Open(attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			utils.WriteNotFoundError(w, "Attachment file not found")
+			return
+		}
+		log.Printf("Failed to open attachment %s: %v", attachment.StorageKey, err)
+		utils.WriteInternalServerError(w, "Failed to retrieve attachment")
+		return
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": attachment.FileName}))
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, file)
+}
+
+// deleteAttachment removes a file from a record and from the store
+func (h *AttachmentHandler) deleteAttachment(w http.ResponseWriter, r *http.Request, recordType data.AttachmentRecordType) {
+	attachment, ok := h.recordAttachment(w, r, recordType)
+	if !ok {
+		return
+	}
+
+	if err := h.AttachmentRepo.Delete(r.Context(), attachment.ID, attachment.UserID); err != nil {
+		utils.WriteInternalServerError(w, "Failed to delete attachment")
+		return
+	}
+	h.removeFile(attachment.StorageKey)
+
+	utils.WriteSuccessResponse(w, "Attachment deleted successfully", nil)
+}
+
+// attachmentRecord returns the user and the ID of the record of an attachment request, writing
+// the error response and returning false when the record doesn't exist
+func (h *AttachmentHandler) attachmentRecord(w http.ResponseWriter, r *http.Request, recordType data.AttachmentRecordType) (uint, uint, bool) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return 0, 0, false
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, fmt.Sprintf("Invalid %s ID", strings.ReplaceAll(string(recordType), "_", "-")))
+		return 0, 0, false
+	}
+
+	switch recordType {
+	case data.AttachmentRecordIncome:
+		if _, err := h.IncomeRepo.GetOne(r.Context(), uint(id), userID); err != nil {
+			utils.WriteNotFoundError(w, "Income record not found")
+			return 0, 0, false
+		}
+	case data.AttachmentRecordExpense:
+		if _, err := h.ExpenseRepo.GetOne(r.Context(), uint(id), userID); err != nil {
+			utils.WriteNotFoundError(w, "Expense record not found")
+			return 0, 0, false
+		}
+	case data.AttachmentRecordDueDiligence:
+		if h.DueDiligenceRepo == nil {
+			utils.WriteNotFoundError(w, "Due-diligence assessment not found")
+			return 0, 0, false
+		}
+		if _, err := h.DueDiligenceRepo.GetOne(r.Context(), uint(id), userID); err != nil {
+			utils.WriteNotFoundError(w, "Due-diligence assessment not found")
+			return 0, 0, false
+		}
+	case data.AttachmentRecordAssay:
+		if h.AssayRepo == nil {
+			utils.WriteNotFoundError(w, "Assay not found")
+			return 0, 0, false
+		}
+		if _, err := h.AssayRepo.GetOne(r.Context(), uint(id), userID); err != nil {
+			utils.WriteNotFoundError(w, "Assay not found")
+			return 0, 0, false
+		}
+	case data.AttachmentRecordMiner:
+		if h.MinerRepo == nil {
+			utils.WriteNotFoundError(w, "Miner not found")
+			return 0, 0, false
+		}
+		if _, err := h.MinerRepo.GetOne(r.Context(), uint(id), userID); err != nil {
+			utils.WriteNotFoundError(w, "Miner not found")
+			return 0, 0, false
+		}
+	case data.AttachmentRecordClaim:
+		if h.ClaimRepo == nil {
+			utils.WriteNotFoundError(w, "Claim not found")
+			return 0, 0, false
+		}
+		claim, err := h.ClaimRepo.GetOne(r.Context(), uint(id), userID)
+		if err != nil || !canSeeClaim(r, claim) {
+			utils.WriteNotFoundError(w, "Claim not found")
+			return 0, 0, false
+		}
+	}
+	return userID, uint(id), true
+}
+
+// recordAttachment returns the attachment of an attachment request, writing the error response
+// and returning false when it isn't attached to the request's record
+func (h *AttachmentHandler) recordAttachment(w http.ResponseWriter, r *http.Request, recordType data.AttachmentRecordType) (*data.Attachment, bool) {
+	userID, recordID, ok := h.attachmentRecord(w, r, recordType)
+	if !ok {
+		return nil, false
+	}
+
+	attachmentID, err := strconv.ParseUint(chi.URLParam(r, "attachmentId"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid attachment ID")
+		return nil, false
+	}
+	attachment, err := h.AttachmentRepo.GetOne(r.Context(), uint(attachmentID), userID)
+	if err != nil || attachment.RecordType != recordType || attachment.RecordID != recordID {
+		utils.WriteNotFoundError(w, "Attachment not found")
+		return nil, false
+	}
+	return attachment, true
+}
+
+// removeFile deletes a file from the store. Failures are logged, as its attachment is already
+// gone and the file is only left taking space.
+func (h *AttachmentHandler) removeFile(key string) {
+	if err := h.Store.Delete(key); err != nil {
+		log.Printf("Failed to delete attachment file %s: %v", key, err)
+	}
+}
+
+// newStorageKey returns a new, unguessable key for a file attached to a record
+func newStorageKey(userID uint, recordType data.AttachmentRecordType, recordID uint) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d/%s/%d/%s", userID, recordType, recordID, hex.EncodeToString(random)), nil
+}
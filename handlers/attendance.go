@@ -0,0 +1,364 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Anti-spoofing thresholds for check-ins
+const (
+	defaultGeofenceRadius = 500.0            // meters, when the mine site has no radius set
+	maxFixAccuracy        = 100.0            // meters; coarser fixes are flagged
+	maxClockSkew          = 5 * time.Minute  // between device and server time
+	maxTravelSpeed        = 150.0            // km/h implied between consecutive fixes
+	minTravelInterval     = 30 * time.Second // fixes closer in time are not checked for speed
+)
+
+// AttendanceHandler handles geofenced check-in and check-out requests
+type AttendanceHandler struct {
+	AttendanceRepo data.AttendanceInterface
+	EmployeeRepo   data.EmployeeInterface
+	MineSiteRepo   data.MineSiteInterface
+}
+
+// NewAttendanceHandler creates a new AttendanceHandler
+func NewAttendanceHandler(attendanceRepo data.AttendanceInterface, employeeRepo data.EmployeeInterface, mineSiteRepo data.MineSiteInterface) *AttendanceHandler {
+	return &AttendanceHandler{
+		AttendanceRepo: attendanceRepo,
+		EmployeeRepo:   employeeRepo,
+		MineSiteRepo:   mineSiteRepo,
+	}
+}
+
+// AttendanceCheckRequest represents a check-in or check-out from a mobile device
+type AttendanceCheckRequest struct {
+	EmployeeID   uint     `json:"employee_id"`
+	Latitude     *float64 `json:"latitude"`
+	Longitude    *float64 `json:"longitude"`
+	Accuracy     *float64 `json:"accuracy,omitempty"`      // meters, as reported by the device
+	DeviceTime   *string  `json:"device_time,omitempty"`   // RFC 3339 time of the fix on the device
+	MockLocation bool     `json:"mock_location,omitempty"` // the device OS reports a mock location provider
+	DeviceID     *string  `json:"device_id,omitempty"`
+}
+
+// GetAllAttendance retrieves attendance records, optionally filtered by employee, month (YYYY-MM),
+// flagged records or employees still on site
+func (h *AttendanceHandler) GetAllAttendance(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	query := r.URL.Query()
+	filter := data.AttendanceFilter{
+		Flagged: query.Get("flagged") == "true",
+		Open:    query.Get("open") == "true",
+	}
+	if employeeID := query.Get("employee_id"); employeeID != "" {
+		id, err := strconv.ParseUint(employeeID, 10, 32)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid employee ID")
+			return
+		}
+		filter.EmployeeID = uint(id)
+	}
+	if month := query.Get("month"); month != "" {
+		monthStart, monthEnd, err := parseMonth(month)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid month format. Use YYYY-MM")
+			return
+		}
+		filter.Start, filter.End = &monthStart, &monthEnd
+	}
+
+	records, err := h.AttendanceRepo.GetAll(r.Context(), userID, filter)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve attendance")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Attendance retrieved successfully", records)
+}
+
+// GetAttendance returns a specific attendance record
+func (h *AttendanceHandler) GetAttendance(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid attendance ID")
+		return
+	}
+
+	attendance, err := h.AttendanceRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Attendance record not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Attendance retrieved successfully", attendance)
+}
+
+// CheckIn checks an employee in at the mine site. The device location must be within the
+// site's geofence; anti-spoofing heuristics are recorded as flags on the record.
+func (h *AttendanceHandler) CheckIn(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	req, ok := decodeAttendanceRequest(w, r)
+	if !ok {
+		return
+	}
+
+	employee, err := h.EmployeeRepo.GetOne(r.Context(), req.EmployeeID, userID)
+	if err != nil {
+		utils.WriteValidationError(w, "Employee not found")
+		return
+	}
+	if !employee.Active {
+		utils.WriteValidationError(w, "Employee is not active")
+		return
+	}
+
+	site, ok := h.loadGeofencedSite(r.Context(), w, userID)
+	if !ok {
+		return
+	}
+	distance, radius := siteDistance(site, *req.Latitude, *req.Longitude)
+	if distance > radius {
+		log.Printf("Attendance: rejected check-in of employee %d for user %d, %.0fm from site (radius %.0fm)",
+			employee.ID, userID, distance, radius)
+		utils.WriteErrorResponse(w, fmt.Sprintf("Check-in location is %.0fm from the mine site, outside the %.0fm geofence", distance, radius), http.StatusForbidden)
+		return
+	}
+
+	previous, err := h.AttendanceRepo.GetLatest(r.Context(), employee.ID, userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to check in")
+		return
+	}
+
+	now := time.Now()
+	actorID := middleware.GetActorIDFromRequest(r)
+	attendance := &data.Attendance{
+		EmployeeID:       employee.ID,
+		CheckInAt:        now,
+		CheckInLatitude:  *req.Latitude,
+		CheckInLongitude: *req.Longitude,
+		CheckInAccuracy:  req.Accuracy,
+		CheckInDistance:  math.Round(distance),
+		DeviceID:         req.DeviceID,
+		Flags:            spoofingFlags(req, previous, now),
+		CheckedInByID:    &actorID,
+		UserID:           userID,
+	}
+	logAttendanceFlags("check-in", employee.ID, userID, attendance.Flags)
+
+	if _, err := h.AttendanceRepo.CheckIn(r.Context(), attendance); err != nil {
+		if errors.Is(err, data.ErrAlreadyCheckedIn) {
+			utils.WriteErrorResponse(w, "Employee is already checked in", http.StatusConflict)
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to check in")
+		return
+	}
+
+	attendance.Employee = *employee
+	utils.WriteSuccessResponse(w, "Checked in successfully", attendance)
+}
+
+// CheckOut checks an employee out and submits a timesheet for the hours on site. Checking out
+// outside the geofence is allowed but flagged.
+func (h *AttendanceHandler) CheckOut(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	req, ok := decodeAttendanceRequest(w, r)
+	if !ok {
+		return
+	}
+
+	attendance, err := h.AttendanceRepo.GetLatest(r.Context(), req.EmployeeID, userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to check out")
+		return
+	}
+	if attendance == nil || attendance.CheckOutAt != nil {
+		utils.WriteErrorResponse(w, "Employee is not checked in", http.StatusConflict)
+		return
+	}
+
+	site, ok := h.loadGeofencedSite(r.Context(), w, userID)
+	if !ok {
+		return
+	}
+	distance, radius := siteDistance(site, *req.Latitude, *req.Longitude)
+
+	now := time.Now()
+	flags := spoofingFlags(req, attendance, now)
+	if distance > radius {
+		flags = append(flags, data.AttendanceOutsideSite)
+	}
+	logAttendanceFlags("check-out", attendance.EmployeeID, userID, flags)
+
+	roundedDistance := math.Round(distance)
+	hours := math.Min(math.Round(now.Sub(attendance.CheckInAt).Hours()*100)/100, 24)
+	actorID := middleware.GetActorIDFromRequest(r)
+	attendance.CheckOutAt = &now
+	attendance.CheckOutLatitude = req.Latitude
+	attendance.CheckOutLongitude = req.Longitude
+	attendance.CheckOutAccuracy = req.Accuracy
+	attendance.CheckOutDistance = &roundedDistance
+	attendance.Hours = hours
+	for _, flag := range flags {
+		if !slices.Contains(attendance.Flags, flag) {
+			attendance.Flags = append(attendance.Flags, flag)
+		}
+	}
+	attendance.CheckedOutByID = &actorID
+
+	if err := h.AttendanceRepo.CheckOut(r.Context(), attendance); err != nil {
+		if errors.Is(err, data.ErrNotCheckedIn) {
+			utils.WriteErrorResponse(w, "Employee is not checked in", http.StatusConflict)
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to check out")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Checked out successfully", attendance)
+}
+
+// loadGeofencedSite loads the mine site of a user, writing the error response and returning
+// false when the site has no coordinates to check against
+func (h *AttendanceHandler) loadGeofencedSite(ctx context.Context, w http.ResponseWriter, userID uint) (*data.MineSiteInfo, bool) {
+	site, err := h.MineSiteRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve mine site information")
+		return nil, false
+	}
+	if site == nil || site.Latitude == nil || site.Longitude == nil {
+		utils.WriteValidationError(w, "Set the mine site coordinates before using check-in")
+		return nil, false
+	}
+	return site, true
+}
+
+// decodeAttendanceRequest decodes and validates a check-in or check-out request, writing the
+// error response and returning false when invalid
+func decodeAttendanceRequest(w http.ResponseWriter, r *http.Request) (*AttendanceCheckRequest, bool) {
+	var req AttendanceCheckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return nil, false
+	}
+
+	if req.EmployeeID == 0 {
+		utils.WriteValidationError(w, "Employee is required")
+		return nil, false
+	}
+	if req.Latitude == nil || req.Longitude == nil {
+		utils.WriteValidationError(w, "Latitude and longitude are required")
+		return nil, false
+	}
+	if !utils.ValidateCoordinates(*req.Latitude, *req.Longitude) {
+		utils.WriteValidationError(w, "Invalid coordinates")
+		return nil, false
+	}
+	if req.Accuracy != nil && !utils.ValidateNonNegativeNumber(*req.Accuracy) {
+		utils.WriteValidationError(w, "Accuracy cannot be negative")
+		return nil, false
+	}
+	return &req, true
+}
+
+// siteDistance returns the distance in meters from the site to a location and the site's geofence radius
+func siteDistance(site *data.MineSiteInfo, latitude, longitude float64) (float64, float64) {
+	radius := defaultGeofenceRadius
+	if site.GeofenceRadius != nil && *site.GeofenceRadius > 0 {
+		radius = *site.GeofenceRadius
+	}
+	return utils.DistanceMeters(*site.Latitude, *site.Longitude, latitude, longitude), radius
+}
+
+// spoofingFlags applies the anti-spoofing heuristics to a location fix, comparing it with the
+// employee's previous attendance record when there is one
+func spoofingFlags(req *AttendanceCheckRequest, previous *data.Attendance, now time.Time) []data.AttendanceFlag {
+	flags := []data.AttendanceFlag{}
+	if req.MockLocation {
+		flags = append(flags, data.AttendanceMockLocation)
+	}
+	if req.Accuracy == nil || *req.Accuracy == 0 {
+		flags = append(flags, data.AttendanceMissingAccuracy)
+	} else if *req.Accuracy > maxFixAccuracy {
+		flags = append(flags, data.AttendanceLowAccuracy)
+	}
+	if decimalPlaces(*req.Latitude) < 4 || decimalPlaces(*req.Longitude) < 4 {
+		flags = append(flags, data.AttendanceCoarseCoordinate)
+	}
+	if req.DeviceTime != nil && *req.DeviceTime != "" {
+		deviceTime, err := time.Parse(time.RFC3339, *req.DeviceTime)
+		if err != nil || math.Abs(now.Sub(deviceTime).Minutes()) > maxClockSkew.Minutes() {
+			flags = append(flags, data.AttendanceClockSkew)
+		}
+	}
+
+	if previous != nil {
+		lastAt, lastLat, lastLng := previous.CheckInAt, previous.CheckInLatitude, previous.CheckInLongitude
+		if previous.CheckOutAt != nil && previous.CheckOutLatitude != nil && previous.CheckOutLongitude != nil {
+			lastAt, lastLat, lastLng = *previous.CheckOutAt, *previous.CheckOutLatitude, *previous.CheckOutLongitude
+		}
+		if lastLat == *req.Latitude && lastLng == *req.Longitude {
+			flags = append(flags, data.AttendanceRepeatedLocation)
+		} else if elapsed := now.Sub(lastAt); elapsed >= minTravelInterval {
+			km := utils.DistanceMeters(lastLat, lastLng, *req.Latitude, *req.Longitude) / 1000
+			if km/elapsed.Hours() > maxTravelSpeed {
+				flags = append(flags, data.AttendanceImpossibleTravel)
+			}
+		}
+	}
+	return flags
+}
+
+// decimalPlaces returns the number of decimal places of a coordinate as sent by the device
+func decimalPlaces(value float64) int {
+	formatted := strconv.FormatFloat(value, 'f', -1, 64)
+	if _, decimals, found := strings.Cut(formatted, "."); found {
+		return len(decimals)
+	}
+	return 0
+}
+
+// logAttendanceFlags logs the anti-spoofing heuristics tripped by a check-in or check-out
+func logAttendanceFlags(action string, employeeID, userID uint, flags []data.AttendanceFlag) {
+	if len(flags) == 0 {
+		return
+	}
+	log.Printf("Attendance: %s of employee %d for user %d flagged: %v", action, employeeID, userID, flags)
+}
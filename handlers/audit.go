@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"log"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+)
+
+// AuditHandler handles audit log requests
+type AuditHandler struct {
+	AuditRepo data.AuditInterface
+}
+
+// NewAuditHandler creates a new AuditHandler
+func NewAuditHandler(auditRepo data.AuditInterface) *AuditHandler {
+	return &AuditHandler{
+		AuditRepo: auditRepo,
+	}
+}
+
+// GetAuditLogs retrieves the audit log of the organization's books, optionally filtered by action
+func (h *AuditHandler) GetAuditLogs(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	if !requirePermission(w, r, data.PermAuditView, "You do not have permission to view the audit log") {
+		return
+	}
+
+	entries, err := h.AuditRepo.GetAll(r.Context(), userID, r.URL.Query().Get("action"))
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve audit log")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Audit log retrieved successfully", entries)
+}
+
+// recordAudit writes an audit log entry for the request's books and acting user. Failures are
+// logged rather than returned so auditing never breaks the action itself.
+func recordAudit(auditRepo data.AuditInterface, r *http.Request, entry *data.AuditLog) {
+	entry.UserID = middleware.GetUserIDFromRequest(r)
+	entry.ActorID = middleware.GetActorIDFromRequest(r)
+	entry.IPAddress = middleware.GetClientIP(r)
+
+	if err := auditRepo.Insert(r.Context(), entry); err != nil {
+		log.Printf("Failed to record audit log entry %s %s: %v", entry.Action, entry.Resource, err)
+	}
+}
@@ -1,23 +1,71 @@
 package handlers
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log"
+	"math"
 	"mineral/data"
 	"mineral/pkg/middleware"
+	"mineral/pkg/oauth"
 	"mineral/pkg/utils"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
 )
 
 // AuthHandler handles authentication-related requests
 type AuthHandler struct {
-	UserRepo data.UserInterface
+	UserRepo     data.UserInterface
+	DeliveryRepo data.DeliveryInterface
+	JobRepo      data.JobInterface
+	InviteRepo   data.InviteCodeInterface
+	IdentityRepo data.IdentityInterface
+
+	// Google verifies Google Sign-In ID tokens; Google login is disabled when nil
+	Google oauth.GoogleVerifier
+	// RequireInviteCode rejects signups without a valid invite code when set
+	RequireInviteCode bool
+	// UsageRepo starts a trial of TrialPlan for TrialDays when an account is created; no trial is
+	// started when it is nil or TrialDays is zero
+	UsageRepo data.UsageInterface
+	TrialPlan data.Plan
+	TrialDays int
+	// ReferralRepo attributes new accounts to the referral code they sign up with
+	ReferralRepo data.ReferralInterface
+	// RefreshRepo stores the refresh tokens issued with access tokens, valid for RefreshTokenTTL;
+	// no refresh tokens are issued when it is nil or RefreshTokenTTL is zero
+	RefreshRepo     data.RefreshTokenInterface
+	RefreshTokenTTL time.Duration
+	// RevokedRepo keeps the access tokens revoked on logout until they expire
+	RevokedRepo data.RevokedTokenInterface
+	// MaxFailedLogins consecutive failed password logins lock an account for LockoutDuration;
+	// accounts aren't locked when it is zero
+	MaxFailedLogins int
+	LockoutDuration time.Duration
+	// VerifyEmails emails new accounts created with a password a code to verify their email with,
+	// and RequireVerifiedEmail refuses password logins to accounts until their email is verified
+	VerifyEmails         bool
+	RequireVerifiedEmail bool
 }
 
 // NewAuthHandler creates a new AuthHandler
-func NewAuthHandler(userRepo data.UserInterface) *AuthHandler {
+func NewAuthHandler(userRepo data.UserInterface, deliveryRepo data.DeliveryInterface, jobRepo data.JobInterface, inviteRepo data.InviteCodeInterface, identityRepo data.IdentityInterface) *AuthHandler {
 	return &AuthHandler{
-		UserRepo: userRepo,
+		UserRepo:     userRepo,
+		DeliveryRepo: deliveryRepo,
+		JobRepo:      jobRepo,
+		InviteRepo:   inviteRepo,
+		IdentityRepo: identityRepo,
 	}
 }
 
@@ -29,11 +77,40 @@ type LoginRequest struct {
 
 // SignupRequest represents a signup request
 type SignupRequest struct {
-	Email     string `json:"email"`
-	Name      string `json:"name"`
-	Phone     string `json:"phone,omitempty"`
-	Password  string `json:"password"`
-	AdminCode string `json:"admin_code,omitempty"`
+	Email        string `json:"email"`
+	Name         string `json:"name"`
+	Phone        string `json:"phone,omitempty"`
+	Password     string `json:"password"`
+	InviteCode   string `json:"invite_code,omitempty"`
+	AdminCode    string `json:"admin_code,omitempty"` // Deprecated: use invite_code
+	ReferralCode string `json:"referral_code,omitempty"`
+}
+
+// GoogleLoginRequest represents a Google Sign-In request with the ID token obtained by the client
+type GoogleLoginRequest struct {
+	IDToken      string `json:"id_token"`
+	InviteCode   string `json:"invite_code,omitempty"`   // used when a new account is created
+	ReferralCode string `json:"referral_code,omitempty"` // used when a new account is created
+}
+
+// PhoneOTPRequest represents a request for a login or verification code by SMS
+type PhoneOTPRequest struct {
+	Phone string `json:"phone"`
+}
+
+// PhoneLoginRequest represents a passwordless login with an SMS code
+type PhoneLoginRequest struct {
+	Phone string `json:"phone"`
+	OTP   string `json:"otp"`
+}
+
+// InviteCodeRequest represents a request to create an invite code
+type InviteCodeRequest struct {
+	Code      string  `json:"code,omitempty"`
+	Role      string  `json:"role"`
+	ExpiresAt *string `json:"expires_at,omitempty"`
+	MaxUses   int     `json:"max_uses"`
+	Note      *string `json:"note,omitempty"`
 }
 
 // ForgotPasswordRequest represents a forgot password request
@@ -41,6 +118,30 @@ type ForgotPasswordRequest struct {
 	Email string `json:"email"`
 }
 
+// VerifyEmailRequest represents the verification of an email with the code sent to it
+type VerifyEmailRequest struct {
+	Email string `json:"email"`
+	OTP   string `json:"otp"`
+}
+
+// ResendVerificationRequest represents a request for a new email verification code
+type ResendVerificationRequest struct {
+	Email string `json:"email"`
+}
+
+// RefreshRequest represents a request to exchange a refresh token for a new access token
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// LogoutRequest represents a logout. The refresh token is revoked with the access token; web
+// clients' is read from the refresh cookie. AllDevices revokes every refresh token of the user,
+// signing out other devices once their access tokens expire.
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty"`
+	AllDevices   bool   `json:"all_devices,omitempty"`
+}
+
 // ResetPasswordRequest represents a reset password request
 type ResetPasswordRequest struct {
 	Email       string `json:"email"`
@@ -67,39 +168,56 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get user by email
-	user, err := h.UserRepo.GetByEmail(req.Email)
+	user, err := h.UserRepo.GetByEmail(r.Context(), req.Email)
 	if err != nil {
 		utils.WriteUnauthorizedError(w, "Invalid email or password")
 		return
 	}
 
+	// Locked accounts are refused even with the right password
+	if h.MaxFailedLogins > 0 && user.Locked() {
+		writeLockedError(w, *user.LockedUntil)
+		return
+	}
+
 	// Check password
 	valid, err := h.UserRepo.PasswordMatches(user, req.Password)
 	if err != nil || !valid {
+		if h.MaxFailedLogins > 0 {
+			lockedUntil, err := h.UserRepo.RecordFailedLogin(r.Context(), user.ID, h.MaxFailedLogins, h.LockoutDuration)
+			if err != nil {
+				log.Printf("Failed to record failed login of user %d: %v", user.ID, err)
+			} else if lockedUntil != nil {
+				writeLockedError(w, *lockedUntil)
+				return
+			}
+		}
 		utils.WriteUnauthorizedError(w, "Invalid email or password")
 		return
 	}
 
-	// Generate JWT token
-	token, err := utils.GenerateToken(fmt.Sprintf("%d", user.ID), user.Email, string(user.Role))
-	if err != nil {
-		utils.WriteInternalServerError(w, "Failed to generate token")
-		return
+	if user.FailedLogins > 0 || user.LockedUntil != nil {
+		if err := h.UserRepo.Unlock(r.Context(), user.ID); err != nil {
+			log.Printf("Failed to clear failed logins of user %d: %v", user.ID, err)
+		}
 	}
 
-	// Return success response with token
-	response := map[string]interface{}{
-		"token": token,
-		"user": map[string]interface{}{
-			"id":    user.ID,
-			"email": user.Email,
-			"name":  user.Name,
-			"phone": user.Phone,
-			"role":  user.Role,
-		},
+	if h.RequireVerifiedEmail && !user.EmailVerified {
+		utils.WriteForbiddenError(w, "Email not verified. Enter the code sent to your email or request a new one")
+		return
 	}
 
-	utils.WriteSuccessResponse(w, "Login successful", response)
+	h.writeLoginResponse(w, r, "Login successful", user)
+}
+
+// writeLockedError writes the response to a login to an account locked until a time
+func writeLockedError(w http.ResponseWriter, until time.Time) {
+	minutes := int(math.Ceil(time.Until(until).Minutes()))
+	if minutes < 1 {
+		minutes = 1
+	}
+	message := fmt.Sprintf("Account locked after too many failed login attempts. Try again in %d minutes or reset your password", minutes)
+	utils.WriteErrorResponse(w, message, http.StatusLocked)
 }
 
 // Signup handles user registration
@@ -119,6 +237,9 @@ func (h *AuthHandler) Signup(w http.ResponseWriter, r *http.Request) {
 		req.Name = r.FormValue("name")
 		req.Password = r.FormValue("password")
 		req.Phone = r.FormValue("phone")
+		req.InviteCode = r.FormValue("invite_code")
+		req.AdminCode = r.FormValue("admin_code")
+		req.ReferralCode = r.FormValue("referral_code")
 	} else {
 		// Handle JSON
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -146,21 +267,25 @@ func (h *AuthHandler) Signup(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Check if user already exists
-	existingUser, _ := h.UserRepo.GetByEmail(req.Email)
+	existingUser, _ := h.UserRepo.GetByEmail(r.Context(), req.Email)
 	if existingUser != nil {
 		utils.WriteValidationError(w, "Email already registered")
 		return
 	}
 
-	// Determine user role
-	role := data.RoleStandard
-	if req.AdminCode != "" {
-		if req.AdminCode == "MINING2025ADMIN" {
-			role = data.RoleAdmin
-		} else {
-			utils.WriteValidationError(w, "Invalid admin code")
-			return
-		}
+	referral, ok := h.lookupReferralCode(r.Context(), w, req.ReferralCode)
+	if !ok {
+		return
+	}
+
+	// Determine user role from the invite code, if any
+	code := strings.TrimSpace(req.InviteCode)
+	if code == "" {
+		code = strings.TrimSpace(req.AdminCode)
+	}
+	role, invite, ok := h.redeemInviteCode(r.Context(), w, code)
+	if !ok {
+		return
 	}
 
 	// Create new user
@@ -176,32 +301,728 @@ func (h *AuthHandler) Signup(w http.ResponseWriter, r *http.Request) {
 
 	user.Password = req.Password // Will be hashed in repository
 
-	userID, err := h.UserRepo.Insert(user)
+	userID, err := h.UserRepo.Insert(r.Context(), user)
 	if err != nil {
+		if invite != nil {
+			// Give the use back so a failed signup does not burn the code
+			h.InviteRepo.Release(r.Context(), invite.ID)
+		}
 		utils.WriteInternalServerError(w, "Failed to create user")
 		return
 	}
+	h.startTrial(r.Context(), userID)
+	h.attributeReferral(r.Context(), referral, userID)
+
+	user.ID = userID
+	if h.VerifyEmails {
+		// A code that fails to send can be requested again rather than failing the signup
+		if err := h.sendEmailVerification(r.Context(), user); err != nil {
+			log.Printf("Failed to send email verification to user %d: %v", user.ID, err)
+		}
+	}
+	if h.RequireVerifiedEmail {
+		utils.WriteSuccessResponse(w, "User created successfully. Enter the code sent to your email to verify it", user)
+		return
+	}
+	h.writeLoginResponse(w, r, "User created successfully", user)
+}
+
+// redeemInviteCode redeems an optional signup invite code and returns the role it grants,
+// writing the error response and returning false when the code is invalid or required
+func (h *AuthHandler) redeemInviteCode(ctx context.Context, w http.ResponseWriter, code string) (data.UserRole, *data.InviteCode, bool) {
+	if code == "" {
+		if h.RequireInviteCode {
+			utils.WriteValidationError(w, "Invite code is required")
+			return "", nil, false
+		}
+		return data.RoleStandard, nil, true
+	}
+
+	invite, err := h.InviteRepo.Redeem(ctx, code)
+	if err != nil {
+		if errors.Is(err, data.ErrInviteCodeInvalid) {
+			utils.WriteValidationError(w, "Invalid or expired invite code")
+			return "", nil, false
+		}
+		utils.WriteInternalServerError(w, "Failed to verify invite code")
+		return "", nil, false
+	}
+	return invite.Role, invite, true
+}
+
+// ClientTypeHeader selects how a client is given its session. Browser clients send "web" to get
+// the token in an httpOnly session cookie, with a CSRF token to send back, instead of in the
+// response body; other clients, e.g. the mobile app, keep it and send it in the Authorization
+// header.
+const ClientTypeHeader = "X-Client-Type"
+
+// writeLoginResponse issues a token and a refresh token for the user and writes them with the
+// user's details, or sets them in cookies for web clients
+func (h *AuthHandler) writeLoginResponse(w http.ResponseWriter, r *http.Request, message string, user *data.User) {
+	var refreshToken string
+	var refresh *data.RefreshToken
+	if h.refreshEnabled() {
+		var err error
+		refreshToken, refresh, err = h.newRefreshToken(r)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to generate token")
+			return
+		}
+		refresh.UserID = user.ID
+		if err := h.RefreshRepo.Insert(r.Context(), refresh); err != nil {
+			utils.WriteInternalServerError(w, "Failed to generate token")
+			return
+		}
+	}
+
+	writeSession(w, r, message, user, refreshToken, refresh)
+}
 
-	// Generate JWT token
-	token, err := utils.GenerateToken(fmt.Sprintf("%d", userID), user.Email, string(user.Role))
+// writeSession issues an access token for the user and writes it with the refresh token, when
+// one was issued, and the user's details. Web clients are given both in httpOnly cookies.
+func writeSession(w http.ResponseWriter, r *http.Request, message string, user *data.User, refreshToken string, refresh *data.RefreshToken) {
+	token, err := utils.GenerateToken(fmt.Sprintf("%d", user.ID), user.Email, string(user.Role))
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to generate token")
 		return
 	}
 
-	// Return success response
 	response := map[string]interface{}{
-		"token": token,
 		"user": map[string]interface{}{
-			"id":    userID,
-			"email": user.Email,
-			"name":  user.Name,
-			"phone": user.Phone,
-			"role":  user.Role,
+			"id":             user.ID,
+			"email":          user.Email,
+			"name":           user.Name,
+			"phone":          user.Phone,
+			"role":           user.Role,
+			"email_verified": user.EmailVerified,
 		},
+		"expires_in": int(utils.AccessTokenTTL.Seconds()),
+	}
+	if r.Header.Get(ClientTypeHeader) == "web" {
+		response["csrf_token"] = utils.SetSessionCookies(w, token)
+		if refresh != nil {
+			utils.SetRefreshCookie(w, refreshToken, refresh.ExpiresAt)
+		}
+	} else {
+		response["token"] = token
+		if refresh != nil {
+			response["refresh_token"] = refreshToken
+			response["refresh_expires_at"] = refresh.ExpiresAt
+		}
+	}
+
+	utils.WriteSuccessResponse(w, message, response)
+}
+
+// refreshEnabled reports whether refresh tokens are issued
+func (h *AuthHandler) refreshEnabled() bool {
+	return h.RefreshRepo != nil && h.RefreshTokenTTL > 0
+}
+
+// newRefreshToken generates a refresh token for the client making the request, returning the
+// token and the record storing its hash; the record's user is set by the caller
+func (h *AuthHandler) newRefreshToken(r *http.Request) (string, *data.RefreshToken, error) {
+	token, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return "", nil, err
+	}
+	userAgent := r.UserAgent()
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+	return token, &data.RefreshToken{
+		TokenHash: utils.HashToken(token),
+		UserAgent: userAgent,
+		ExpiresAt: time.Now().Add(h.RefreshTokenTTL),
+	}, nil
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh token; the
+// refresh token used is revoked. Web clients send no body, their refresh token is read from the
+// refresh cookie.
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	if !h.refreshEnabled() {
+		utils.WriteNotFoundError(w, "Refresh tokens are not enabled")
+		return
+	}
+
+	var req RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if req.RefreshToken == "" {
+		if cookie, err := r.Cookie(utils.RefreshCookie); err == nil {
+			req.RefreshToken = cookie.Value
+		}
+	}
+	if !utils.ValidateRequired(req.RefreshToken) {
+		utils.WriteValidationError(w, "Refresh token is required")
+		return
+	}
+
+	refreshToken, replacement, err := h.newRefreshToken(r)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to generate token")
+		return
+	}
+	used, err := h.RefreshRepo.Rotate(r.Context(), utils.HashToken(req.RefreshToken), replacement)
+	if err != nil {
+		if errors.Is(err, data.ErrRefreshTokenInvalid) {
+			utils.WriteUnauthorizedError(w, "Invalid or expired refresh token")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to refresh token")
+		return
+	}
+
+	// The user is read again so a changed role or email is in the new token
+	user, err := h.UserRepo.GetOne(r.Context(), used.UserID)
+	if err != nil {
+		utils.WriteUnauthorizedError(w, "Invalid or expired refresh token")
+		return
+	}
+
+	writeSession(w, r, "Token refreshed", user, refreshToken, replacement)
+}
+
+// Logout revokes the access token the request is authenticated with, so it stops working before
+// it expires, and the client's refresh token, and clears the session cookies of web clients
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req LogoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	token := middleware.RequestToken(r)
+	claims, err := utils.ValidateJWT(token)
+	if err != nil {
+		utils.WriteUnauthorizedError(w, "Invalid token")
+		return
+	}
+	if h.RevokedRepo != nil {
+		expiresAt := time.Now().Add(utils.AccessTokenTTL)
+		if claims.ExpiresAt != nil {
+			expiresAt = claims.ExpiresAt.Time
+		}
+		revoked := &data.RevokedToken{
+			TokenHash: utils.HashToken(token),
+			UserID:    userID,
+			ExpiresAt: expiresAt,
+		}
+		if err := h.RevokedRepo.Revoke(r.Context(), revoked); err != nil {
+			utils.WriteInternalServerError(w, "Failed to log out")
+			return
+		}
+	}
+
+	if h.RefreshRepo != nil {
+		if req.RefreshToken == "" {
+			if cookie, err := r.Cookie(utils.RefreshCookie); err == nil {
+				req.RefreshToken = cookie.Value
+			}
+		}
+		if req.AllDevices {
+			err = h.RefreshRepo.RevokeAllForUser(r.Context(), userID)
+		} else if req.RefreshToken != "" {
+			err = h.RefreshRepo.Revoke(r.Context(), utils.HashToken(req.RefreshToken), userID)
+		}
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to log out")
+			return
+		}
+	}
+
+	utils.ClearSessionCookies(w)
+	utils.WriteSuccessResponse(w, "Logged out successfully", nil)
+}
+
+// GoogleLogin signs in with a Google ID token. A Google account that is not linked yet is
+// linked to the user with the same email when that user has verified it, or a new account is
+// created. An unverified account may have been registered by someone else before the owner of
+// the email signed in, so its owner has to sign in with the password and link Google from the
+// profile instead.
+func (h *AuthHandler) GoogleLogin(w http.ResponseWriter, r *http.Request) {
+	if h.Google == nil {
+		utils.WriteErrorResponse(w, "Google login is not enabled", http.StatusNotImplemented)
+		return
+	}
+
+	var req GoogleLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if !utils.ValidateRequired(req.IDToken) {
+		utils.WriteValidationError(w, "ID token is required")
+		return
+	}
+
+	identity, ok := h.verifyGoogleToken(w, req.IDToken)
+	if !ok {
+		return
+	}
+
+	// Already linked
+	linked, err := h.IdentityRepo.GetByProvider(r.Context(), data.IdentityGoogle, identity.Subject)
+	if err == nil {
+		user, err := h.UserRepo.GetOne(r.Context(), linked.UserID)
+		if err != nil {
+			utils.WriteUnauthorizedError(w, "Account not found")
+			return
+		}
+		h.writeLoginResponse(w, r, "Login successful", user)
+		return
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.WriteInternalServerError(w, "Failed to sign in with Google")
+		return
+	}
+
+	if !identity.EmailVerified || !utils.ValidateEmail(identity.Email) {
+		utils.WriteValidationError(w, "Google account has no verified email")
+		return
+	}
+
+	// Link to the existing account with the same email, or create one
+	user, err := h.UserRepo.GetByEmail(r.Context(), identity.Email)
+	message := "Login successful"
+	if err != nil {
+		referral, ok := h.lookupReferralCode(r.Context(), w, req.ReferralCode)
+		if !ok {
+			return
+		}
+		role, invite, ok := h.redeemInviteCode(r.Context(), w, strings.TrimSpace(req.InviteCode))
+		if !ok {
+			return
+		}
+
+		// The account has no usable password until the user resets it
+		password, err := randomPassword()
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to create user")
+			return
+		}
+		name := identity.Name
+		if name == "" {
+			name = identity.Email
+		}
+		user = &data.User{
+			Email:         identity.Email,
+			Name:          name,
+			Role:          role,
+			Password:      password,
+			EmailVerified: identity.EmailVerified,
+		}
+		if _, err := h.UserRepo.Insert(r.Context(), user); err != nil {
+			if invite != nil {
+				h.InviteRepo.Release(r.Context(), invite.ID)
+			}
+			utils.WriteInternalServerError(w, "Failed to create user")
+			return
+		}
+		h.startTrial(r.Context(), user.ID)
+		h.attributeReferral(r.Context(), referral, user.ID)
+		message = "User created successfully"
+	} else if !user.EmailVerified {
+		utils.WriteErrorResponse(w, "An account with this email exists but its email is not verified; sign in with your password and link Google from your profile", http.StatusConflict)
+		return
+	}
+
+	email := identity.Email
+	err = h.IdentityRepo.Link(r.Context(), &data.UserIdentity{
+		UserID:   user.ID,
+		Provider: data.IdentityGoogle,
+		Subject:  identity.Subject,
+		Email:    &email,
+	})
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to link Google account")
+		return
+	}
+
+	h.writeLoginResponse(w, r, message, user)
+}
+
+// RequestPhoneLoginOTP sends a login code by SMS to a linked phone number
+func (h *AuthHandler) RequestPhoneLoginOTP(w http.ResponseWriter, r *http.Request) {
+	var req PhoneOTPRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	phone := utils.NormalizePhone(req.Phone)
+	if phone == "" || !utils.ValidatePhone(phone) {
+		utils.WriteValidationError(w, "Invalid phone number format")
+		return
+	}
+
+	// Don't reveal if the phone number is linked or not
+	const message = "If the phone number is registered, a login code has been sent"
+	identity, err := h.IdentityRepo.GetByProvider(r.Context(), data.IdentityPhone, phone)
+	if err != nil {
+		utils.WriteSuccessResponse(w, message, nil)
+		return
+	}
+	user, err := h.UserRepo.GetOne(r.Context(), identity.UserID)
+	if err != nil {
+		utils.WriteSuccessResponse(w, message, nil)
+		return
+	}
+
+	if !h.sendPhoneOTP(r.Context(), w, user, phone, data.OTPPurposePhoneLogin) {
+		return
 	}
 
-	utils.WriteSuccessResponse(w, "User created successfully", response)
+	utils.WriteSuccessResponse(w, message, nil)
+}
+
+// PhoneLogin signs in with a linked phone number and the code sent to it
+func (h *AuthHandler) PhoneLogin(w http.ResponseWriter, r *http.Request) {
+	var req PhoneLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	phone := utils.NormalizePhone(req.Phone)
+	if phone == "" || !utils.ValidatePhone(phone) {
+		utils.WriteValidationError(w, "Invalid phone number format")
+		return
+	}
+	if !utils.ValidateRequired(req.OTP) {
+		utils.WriteValidationError(w, "OTP is required")
+		return
+	}
+
+	identity, err := h.IdentityRepo.GetByProvider(r.Context(), data.IdentityPhone, phone)
+	if err != nil {
+		utils.WriteUnauthorizedError(w, "Invalid or expired OTP")
+		return
+	}
+	account, err := h.UserRepo.GetOne(r.Context(), identity.UserID)
+	if err != nil {
+		utils.WriteUnauthorizedError(w, "Invalid or expired OTP")
+		return
+	}
+
+	user, err := h.UserRepo.LoginWithOTP(r.Context(), account.Email, req.OTP)
+	if err != nil {
+		writeOTPError(w, err)
+		return
+	}
+
+	h.writeLoginResponse(w, r, "Login successful", user)
+}
+
+// GetIdentities returns the sign-in methods linked to the current user
+func (h *AuthHandler) GetIdentities(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetActorIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	identities, err := h.IdentityRepo.GetByUserID(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve linked accounts")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Linked accounts retrieved successfully", identities)
+}
+
+// LinkGoogle links a Google account to the current user
+func (h *AuthHandler) LinkGoogle(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetActorIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+	if h.Google == nil {
+		utils.WriteErrorResponse(w, "Google login is not enabled", http.StatusNotImplemented)
+		return
+	}
+
+	var req GoogleLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if !utils.ValidateRequired(req.IDToken) {
+		utils.WriteValidationError(w, "ID token is required")
+		return
+	}
+
+	identity, ok := h.verifyGoogleToken(w, req.IDToken)
+	if !ok {
+		return
+	}
+
+	linked := &data.UserIdentity{
+		UserID:   userID,
+		Provider: data.IdentityGoogle,
+		Subject:  identity.Subject,
+	}
+	if identity.Email != "" {
+		linked.Email = &identity.Email
+	}
+	if err := h.IdentityRepo.Link(r.Context(), linked); err != nil {
+		writeLinkError(w, err)
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Google account linked successfully", linked)
+}
+
+// RequestPhoneLink sends a verification code by SMS to a phone number the current user wants to link
+func (h *AuthHandler) RequestPhoneLink(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetActorIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req PhoneOTPRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	phone := utils.NormalizePhone(req.Phone)
+	if phone == "" || !utils.ValidatePhone(phone) {
+		utils.WriteValidationError(w, "Invalid phone number format")
+		return
+	}
+
+	if existing, err := h.IdentityRepo.GetByProvider(r.Context(), data.IdentityPhone, phone); err == nil && existing.UserID != userID {
+		writeLinkError(w, data.ErrIdentityLinked)
+		return
+	}
+
+	user, err := h.UserRepo.GetOne(r.Context(), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "User not found")
+		return
+	}
+	if err := h.UserRepo.SetPendingPhone(r.Context(), userID, phone); err != nil {
+		utils.WriteInternalServerError(w, "Failed to send verification code")
+		return
+	}
+
+	if !h.sendPhoneOTP(r.Context(), w, user, phone, data.OTPPurposePhoneLink) {
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Verification code sent", nil)
+}
+
+// VerifyPhoneLink links the pending phone number to the current user once the code is confirmed
+func (h *AuthHandler) VerifyPhoneLink(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetActorIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req struct {
+		OTP string `json:"otp"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if !utils.ValidateRequired(req.OTP) {
+		utils.WriteValidationError(w, "OTP is required")
+		return
+	}
+
+	user, err := h.UserRepo.GetOne(r.Context(), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "User not found")
+		return
+	}
+
+	phone, err := h.UserRepo.ConfirmPendingPhone(r.Context(), user.Email, req.OTP)
+	if err != nil {
+		writeOTPError(w, err)
+		return
+	}
+
+	linked := &data.UserIdentity{
+		UserID:   userID,
+		Provider: data.IdentityPhone,
+		Subject:  phone,
+	}
+	if err := h.IdentityRepo.Link(r.Context(), linked); err != nil {
+		writeLinkError(w, err)
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Phone number linked successfully", linked)
+}
+
+// UnlinkIdentity removes a linked sign-in method from the current user
+func (h *AuthHandler) UnlinkIdentity(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetActorIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	provider := data.IdentityProvider(chi.URLParam(r, "provider"))
+	if provider != data.IdentityGoogle && provider != data.IdentityPhone {
+		utils.WriteValidationError(w, "Invalid provider")
+		return
+	}
+
+	if err := h.IdentityRepo.Unlink(r.Context(), userID, provider); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Linked account not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to unlink account")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Account unlinked successfully", nil)
+}
+
+// verifyGoogleToken verifies a Google ID token, writing the error response when it fails
+func (h *AuthHandler) verifyGoogleToken(w http.ResponseWriter, idToken string) (*oauth.GoogleIdentity, bool) {
+	identity, err := h.Google.Verify(idToken)
+	if err != nil {
+		if errors.Is(err, oauth.ErrInvalidToken) {
+			utils.WriteUnauthorizedError(w, "Invalid Google token")
+			return nil, false
+		}
+		utils.WriteErrorResponse(w, "Failed to verify Google token", http.StatusBadGateway)
+		return nil, false
+	}
+	return identity, true
+}
+
+// sendPhoneOTP generates an OTP for the user and queues it for delivery by SMS to phone,
+// writing the error response and returning false when it fails
+func (h *AuthHandler) sendPhoneOTP(ctx context.Context, w http.ResponseWriter, user *data.User, phone, purpose string) bool {
+	otp, err := h.UserRepo.GenerateAndSaveOTP(ctx, user.Email, purpose)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to generate OTP")
+		return false
+	}
+
+	deliveryID, err := h.DeliveryRepo.Insert(ctx, &data.MessageDelivery{
+		Purpose:   purpose,
+		Recipient: user.Email,
+		Phone:     &phone,
+		UserID:    &user.ID,
+	})
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to send OTP")
+		return false
+	}
+
+	_, err = h.JobRepo.Enqueue(ctx, data.JobTypeSendOTP, data.SendOTPPayload{
+		DeliveryID: deliveryID,
+		Purpose:    purpose,
+		Email:      user.Email,
+		Phone:      &phone,
+		OTP:        otp,
+	})
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to send OTP")
+		return false
+	}
+	return true
+}
+
+// writeOTPError writes the response for a failed OTP verification
+func writeOTPError(w http.ResponseWriter, err error) {
+	if errors.Is(err, data.ErrOTPThrottled) {
+		utils.WriteErrorResponse(w, "Too many attempts. Please wait before trying again", http.StatusTooManyRequests)
+		return
+	}
+	if errors.Is(err, data.ErrOTPAttemptsExceeded) {
+		utils.WriteErrorResponse(w, "Too many failed attempts. Please request a new OTP", http.StatusTooManyRequests)
+		return
+	}
+	if errors.Is(err, data.ErrOTPInvalid) {
+		utils.WriteValidationError(w, "Invalid or expired OTP")
+		return
+	}
+	utils.WriteInternalServerError(w, "Failed to verify OTP")
+}
+
+// writeLinkError writes the response for a failed identity link
+func writeLinkError(w http.ResponseWriter, err error) {
+	if errors.Is(err, data.ErrIdentityLinked) {
+		utils.WriteErrorResponse(w, "This account is already linked to another user", http.StatusConflict)
+		return
+	}
+	utils.WriteInternalServerError(w, "Failed to link account")
+}
+
+// lookupReferralCode finds the referral code a new account signs up with, writing a validation
+// error when it is unknown. No code is found when the code is empty or referrals are disabled.
+func (h *AuthHandler) lookupReferralCode(ctx context.Context, w http.ResponseWriter, code string) (*data.ReferralCode, bool) {
+	if h.ReferralRepo == nil || strings.TrimSpace(code) == "" {
+		return nil, true
+	}
+	referral, err := h.ReferralRepo.GetByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, data.ErrReferralCodeInvalid) {
+			utils.WriteValidationError(w, "Invalid referral code")
+			return nil, false
+		}
+		utils.WriteInternalServerError(w, "Failed to check referral code")
+		return nil, false
+	}
+	return referral, true
+}
+
+// attributeReferral records that a new account signed up with a referral code. A failed
+// attribution does not fail the signup.
+func (h *AuthHandler) attributeReferral(ctx context.Context, code *data.ReferralCode, userID uint) {
+	if code == nil {
+		return
+	}
+	h.ReferralRepo.Attribute(ctx, &data.Referral{
+		Code:           code.Code,
+		ReferrerID:     code.UserID,
+		ReferredUserID: userID,
+	})
+}
+
+// startTrial starts the trial of a new account. A trial that fails to start leaves the account
+// on the default plan rather than failing the signup.
+func (h *AuthHandler) startTrial(ctx context.Context, userID uint) {
+	if h.UsageRepo == nil || h.TrialDays <= 0 {
+		return
+	}
+	trialEndsAt := time.Now().AddDate(0, 0, h.TrialDays)
+	h.UsageRepo.SaveSubscription(ctx, &data.Subscription{
+		Plan:        h.TrialPlan,
+		Status:      data.SubscriptionTrialing,
+		TrialEndsAt: &trialEndsAt,
+		UserID:      userID,
+	})
+}
+
+// randomPassword generates a random password for accounts created through a sign-in provider
+func randomPassword() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
 }
 
 // ForgotPassword handles forgot password requests
@@ -219,7 +1040,7 @@ func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Check if user exists
-	_, err := h.UserRepo.GetByEmail(req.Email)
+	user, err := h.UserRepo.GetByEmail(r.Context(), req.Email)
 	if err != nil {
 		// Don't reveal if email exists or not for security
 		utils.WriteSuccessResponse(w, "If the email exists, an OTP has been sent", nil)
@@ -227,16 +1048,36 @@ func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Generate and save OTP
-	otp, err := h.UserRepo.GenerateAndSaveOTP(req.Email)
+	otp, err := h.UserRepo.GenerateAndSaveOTP(r.Context(), req.Email, data.OTPPurposePasswordReset)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to generate OTP")
 		return
 	}
 
-	// In a real application, you would send the OTP via email/SMS
-	// For now, we'll just log it (remove this in production)
-	// TODO: Replace with proper logging
-	_ = otp // Suppress unused variable warning
+	// Record the delivery and send it in the background (email with SMS fallback)
+	delivery := &data.MessageDelivery{
+		Purpose:   data.OTPPurposePasswordReset,
+		Recipient: user.Email,
+		Phone:     user.Phone,
+		UserID:    &user.ID,
+	}
+	deliveryID, err := h.DeliveryRepo.Insert(r.Context(), delivery)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to send OTP")
+		return
+	}
+
+	_, err = h.JobRepo.Enqueue(r.Context(), data.JobTypeSendOTP, data.SendOTPPayload{
+		DeliveryID: deliveryID,
+		Purpose:    data.OTPPurposePasswordReset,
+		Email:      user.Email,
+		Phone:      user.Phone,
+		OTP:        otp,
+	})
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to send OTP")
+		return
+	}
 
 	utils.WriteSuccessResponse(w, "If the email exists, an OTP has been sent", nil)
 }
@@ -264,24 +1105,125 @@ func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Reset password with OTP
-	err := h.UserRepo.ResetPasswordWithOTP(req.Email, req.OTP, req.NewPassword)
+	err := h.UserRepo.ResetPasswordWithOTP(r.Context(), req.Email, req.OTP, req.NewPassword)
 	if err != nil {
+		if errors.Is(err, data.ErrOTPThrottled) {
+			utils.WriteErrorResponse(w, "Too many attempts. Please wait before trying again", http.StatusTooManyRequests)
+			return
+		}
+		if errors.Is(err, data.ErrOTPAttemptsExceeded) {
+			utils.WriteErrorResponse(w, "Too many failed attempts. Please request a new OTP", http.StatusTooManyRequests)
+			return
+		}
 		utils.WriteValidationError(w, "Invalid or expired OTP")
 		return
 	}
 
+	// Devices signed in before the reset have to sign in again with the new password
+	if h.RefreshRepo != nil {
+		user, err := h.UserRepo.GetByEmail(r.Context(), req.Email)
+		if err == nil {
+			err = h.RefreshRepo.RevokeAllForUser(r.Context(), user.ID)
+		}
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to sign out other devices")
+			return
+		}
+	}
+
 	utils.WriteSuccessResponse(w, "Password reset successfully", nil)
 }
 
+// VerifyEmail verifies the email of an account with the code sent to it and logs the user in
+func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req VerifyEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	if !utils.ValidateEmail(req.Email) {
+		utils.WriteValidationError(w, "Invalid email format")
+		return
+	}
+	if !utils.ValidateRequired(req.OTP) {
+		utils.WriteValidationError(w, "OTP is required")
+		return
+	}
+
+	user, err := h.UserRepo.VerifyEmail(r.Context(), req.Email, req.OTP)
+	if err != nil {
+		writeOTPError(w, err)
+		return
+	}
+
+	h.writeLoginResponse(w, r, "Email verified successfully", user)
+}
+
+// ResendEmailVerification sends a new email verification code to an account whose email isn't
+// verified yet
+func (h *AuthHandler) ResendEmailVerification(w http.ResponseWriter, r *http.Request) {
+	var req ResendVerificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	if !utils.ValidateEmail(req.Email) {
+		utils.WriteValidationError(w, "Invalid email format")
+		return
+	}
+
+	// Don't reveal whether the email exists or is verified
+	user, err := h.UserRepo.GetByEmail(r.Context(), req.Email)
+	if err != nil || user.EmailVerified {
+		utils.WriteSuccessResponse(w, "If the email needs verification, a code has been sent", nil)
+		return
+	}
+
+	if err := h.sendEmailVerification(r.Context(), user); err != nil {
+		utils.WriteInternalServerError(w, "Failed to send verification code")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "If the email needs verification, a code has been sent", nil)
+}
+
+// sendEmailVerification generates an OTP for the user and queues it for delivery to their email
+func (h *AuthHandler) sendEmailVerification(ctx context.Context, user *data.User) error {
+	otp, err := h.UserRepo.GenerateAndSaveOTP(ctx, user.Email, data.OTPPurposeEmailVerify)
+	if err != nil {
+		return err
+	}
+
+	delivery := &data.MessageDelivery{
+		Purpose:   data.OTPPurposeEmailVerify,
+		Recipient: user.Email,
+		UserID:    &user.ID,
+	}
+	deliveryID, err := h.DeliveryRepo.Insert(ctx, delivery)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.JobRepo.Enqueue(ctx, data.JobTypeSendOTP, data.SendOTPPayload{
+		DeliveryID: deliveryID,
+		Purpose:    data.OTPPurposeEmailVerify,
+		Email:      user.Email,
+		OTP:        otp,
+	})
+	return err
+}
+
 // GetProfile returns the current user's profile
 func (h *AuthHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromRequest(r)
+	userID := middleware.GetActorIDFromRequest(r)
 	if userID == 0 {
 		utils.WriteUnauthorizedError(w, "User not authenticated")
 		return
 	}
 
-	user, err := h.UserRepo.GetOne(userID)
+	user, err := h.UserRepo.GetOne(r.Context(), userID)
 	if err != nil {
 		utils.WriteNotFoundError(w, "User not found")
 		return
@@ -301,7 +1243,7 @@ func (h *AuthHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 
 // UpdateProfile updates the current user's profile
 func (h *AuthHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromRequest(r)
+	userID := middleware.GetActorIDFromRequest(r)
 	if userID == 0 {
 		utils.WriteUnauthorizedError(w, "User not authenticated")
 		return
@@ -328,7 +1270,7 @@ func (h *AuthHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get current user
-	user, err := h.UserRepo.GetOne(userID)
+	user, err := h.UserRepo.GetOne(r.Context(), userID)
 	if err != nil {
 		utils.WriteNotFoundError(w, "User not found")
 		return
@@ -339,7 +1281,7 @@ func (h *AuthHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 	user.Phone = req.Phone
 	user.Location = req.Location
 
-	err = h.UserRepo.Update(user)
+	err = h.UserRepo.Update(r.Context(), user)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to update profile")
 		return
@@ -357,3 +1299,120 @@ func (h *AuthHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 
 	utils.WriteSuccessResponse(w, "Profile updated successfully", response)
 }
+
+// GetDeliveries returns recent email and SMS deliveries for support, optionally for one recipient
+func (h *AuthHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	deliveries, err := h.DeliveryRepo.GetAll(r.Context(), r.URL.Query().Get("recipient"))
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve deliveries")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Deliveries retrieved successfully", deliveries)
+}
+
+// UnlockUser lifts the lockout of an account after failed logins and clears its failed login
+// count (admin only)
+func (h *AuthHandler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseUint(chi.URLParam(r, "userId"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid user ID")
+		return
+	}
+
+	if err := h.UserRepo.Unlock(r.Context(), uint(userID)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "User not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to unlock user")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "User unlocked successfully", nil)
+}
+
+// GetInviteCodes returns all signup invite codes
+func (h *AuthHandler) GetInviteCodes(w http.ResponseWriter, r *http.Request) {
+	codes, err := h.InviteRepo.GetAll(r.Context())
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve invite codes")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Invite codes retrieved successfully", codes)
+}
+
+// CreateInviteCode creates a signup invite code; the code is generated unless provided
+func (h *AuthHandler) CreateInviteCode(w http.ResponseWriter, r *http.Request) {
+	var req InviteCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	role := data.UserRole(req.Role)
+	if role == "" {
+		role = data.RoleStandard
+	}
+	if role != data.RoleAdmin && role != data.RoleStandard {
+		utils.WriteValidationError(w, "Invalid role")
+		return
+	}
+	if req.MaxUses < 0 {
+		utils.WriteValidationError(w, "Max uses cannot be negative")
+		return
+	}
+	code := strings.TrimSpace(req.Code)
+	if code != "" && len(code) < 6 {
+		utils.WriteValidationError(w, "Code must be at least 6 characters")
+		return
+	}
+
+	actorID := middleware.GetActorIDFromRequest(r)
+	invite := &data.InviteCode{
+		Code:        code,
+		Role:        role,
+		MaxUses:     req.MaxUses,
+		Note:        req.Note,
+		CreatedByID: &actorID,
+	}
+	if req.ExpiresAt != nil && *req.ExpiresAt != "" {
+		expiresAt, err := time.Parse("2006-01-02", *req.ExpiresAt)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid date format. Use YYYY-MM-DD")
+			return
+		}
+		// Valid through the end of the given day
+		expiresAt = expiresAt.AddDate(0, 0, 1)
+		invite.ExpiresAt = &expiresAt
+	}
+
+	if _, err := h.InviteRepo.Insert(r.Context(), invite); err != nil {
+		utils.WriteInternalServerError(w, "Failed to create invite code")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Invite code created successfully", invite)
+}
+
+// RevokeInviteCode revokes a signup invite code
+func (h *AuthHandler) RevokeInviteCode(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid invite code ID")
+		return
+	}
+
+	if err := h.InviteRepo.Revoke(r.Context(), uint(id)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Invite code not found or already revoked")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to revoke invite code")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Invite code revoked successfully", nil)
+}
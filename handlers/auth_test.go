@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"mineral/data"
+	"mineral/data/mocks"
+	"mineral/pkg/oauth"
+	"net/http"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// stubGoogle verifies every ID token as the same Google identity
+type stubGoogle struct {
+	identity oauth.GoogleIdentity
+}
+
+func (s stubGoogle) Verify(idToken string) (*oauth.GoogleIdentity, error) {
+	identity := s.identity
+	return &identity, nil
+}
+
+func TestGoogleLoginExistingAccount(t *testing.T) {
+	tests := []struct {
+		name     string
+		verified bool
+		status   int
+		linked   bool
+	}{
+		// Someone who registered the email first must not be signed in as its owner later
+		{name: "unverified email", verified: false, status: http.StatusConflict},
+		{name: "verified email", verified: true, status: http.StatusOK, linked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userRepo := mocks.NewMockUserInterface(ctrl)
+			userRepo.EXPECT().GetByEmail(gomock.Any(), "owner@example.com").Return(&data.User{
+				Model:         gorm.Model{ID: 7},
+				Email:         "owner@example.com",
+				Role:          data.RoleStandard,
+				EmailVerified: tt.verified,
+			}, nil)
+			identityRepo := mocks.NewMockIdentityInterface(ctrl)
+			identityRepo.EXPECT().GetByProvider(gomock.Any(), data.IdentityGoogle, "google-subject").Return(nil, gorm.ErrRecordNotFound)
+			if tt.linked {
+				identityRepo.EXPECT().Link(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, identity *data.UserIdentity) error {
+					if identity.UserID != 7 || identity.Subject != "google-subject" {
+						t.Errorf("linked %+v, want user 7 and the Google subject", identity)
+					}
+					return nil
+				})
+			}
+			h := &AuthHandler{
+				UserRepo:     userRepo,
+				IdentityRepo: identityRepo,
+				Google: stubGoogle{identity: oauth.GoogleIdentity{
+					Subject:       "google-subject",
+					Email:         "owner@example.com",
+					EmailVerified: true,
+				}},
+			}
+
+			rr := serve(h.GoogleLogin, http.MethodPost, 0, `{"id_token":"token"}`, nil)
+			if rr.Code != tt.status {
+				t.Errorf("status %d, want %d: %s", rr.Code, tt.status, rr.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"mineral/data"
+	"mineral/pkg/utils"
+	"net/http"
+	"time"
+)
+
+// backdatedDays returns how many days before today a record is dated when that is further back
+// than the organization's backdating limit, or nil when it isn't or there is no limit. It writes
+// the error response and returns false when the settings can't be read.
+func backdatedDays(ctx context.Context, w http.ResponseWriter, settingsRepo data.SettingsInterface, userID uint, date time.Time) (*int, bool) {
+	if settingsRepo == nil {
+		return nil, true
+	}
+	settings, err := settingsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve settings")
+		return nil, false
+	}
+	if settings.BackdateApprovalDays <= 0 {
+		return nil, true
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	days := int(today.Sub(date.UTC().Truncate(24*time.Hour)).Hours() / 24)
+	if days <= settings.BackdateApprovalDays {
+		return nil, true
+	}
+	return &days, true
+}
+
+// auditBackdated flags a record dated further back than the backdating limit in the audit log.
+// Nothing is recorded when auditRepo is nil.
+func auditBackdated(auditRepo data.AuditInterface, r *http.Request, resource string, id uint, date time.Time, days int, status *data.SaleApproval) {
+	if auditRepo == nil {
+		return
+	}
+	outcome := "pending approval"
+	if status != nil && *status == data.SaleApproved {
+		outcome = "approved by the member who recorded it"
+	}
+	details := fmt.Sprintf("Dated %s, %d days before it was recorded; %s", date.Format("2006-01-02"), days, outcome)
+	recordAudit(auditRepo, r, &data.AuditLog{
+		Action:     data.AuditBackdatedEntry,
+		Resource:   resource,
+		ResourceID: &id,
+		Details:    &details,
+	})
+}
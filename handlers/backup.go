@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"mineral/data"
+	"mineral/pkg/utils"
+	"net/http"
+)
+
+// recentBackups is how many backups the admin listing returns
+const recentBackups = 30
+
+// BackupHandler handles database backup requests
+type BackupHandler struct {
+	BackupRepo data.BackupInterface
+}
+
+// NewBackupHandler creates a new BackupHandler
+func NewBackupHandler(backupRepo data.BackupInterface) *BackupHandler {
+	return &BackupHandler{
+		BackupRepo: backupRepo,
+	}
+}
+
+// GetBackups returns the most recent database backups with their size and status
+func (h *BackupHandler) GetBackups(w http.ResponseWriter, r *http.Request) {
+	backups, err := h.BackupRepo.GetRecent(r.Context(), recentBackups)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve backups")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Backups retrieved successfully", backups)
+}
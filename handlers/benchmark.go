@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Benchmark period limits in days
+const (
+	defaultBenchmarkDays = 90
+	maxBenchmarkDays     = 365
+)
+
+// BenchmarkHandler handles anonymous price benchmarking requests
+type BenchmarkHandler struct {
+	BenchmarkRepo data.BenchmarkInterface
+	SettingsRepo  data.SettingsInterface
+	MineSiteRepo  data.MineSiteInterface
+}
+
+// NewBenchmarkHandler creates a new BenchmarkHandler
+func NewBenchmarkHandler(benchmarkRepo data.BenchmarkInterface, settingsRepo data.SettingsInterface, mineSiteRepo data.MineSiteInterface) *BenchmarkHandler {
+	return &BenchmarkHandler{
+		BenchmarkRepo: benchmarkRepo,
+		SettingsRepo:  settingsRepo,
+		MineSiteRepo:  mineSiteRepo,
+	}
+}
+
+// GetPriceBenchmark compares the organization's average selling price of a mineral with the
+// anonymized regional and platform-wide prices of organizations that share benchmark data.
+// Only organizations that share their own data can see benchmarks.
+func (h *BenchmarkHandler) GetPriceBenchmark(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	query := r.URL.Query()
+	mineralType := data.MineralType(query.Get("mineral_type"))
+	if !utils.ValidateRequired(string(mineralType)) {
+		utils.WriteValidationError(w, "Mineral type is required")
+		return
+	}
+	days := defaultBenchmarkDays
+	if daysStr := query.Get("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed < 1 || parsed > maxBenchmarkDays {
+			utils.WriteValidationError(w, fmt.Sprintf("Days must be between 1 and %d", maxBenchmarkDays))
+			return
+		}
+		days = parsed
+	}
+
+	settings, err := h.SettingsRepo.GetByUserID(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve settings")
+		return
+	}
+	if !settings.ShareBenchmarkData {
+		utils.WriteForbiddenError(w, "Enable sharing of anonymous benchmark data in settings to see price benchmarks")
+		return
+	}
+
+	unit := strings.TrimSpace(query.Get("unit"))
+	if unit == "" {
+		unit = settings.DefaultUnit(mineralType)
+	}
+	if !utils.ValidateRequired(unit) {
+		utils.WriteValidationError(w, "Unit is required")
+		return
+	}
+
+	site, err := h.MineSiteRepo.GetByUserID(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve mine site information")
+		return
+	}
+
+	now := time.Now()
+	since := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, -days)
+	comparison := &data.PriceComparison{
+		MineralType: mineralType,
+		Unit:        unit,
+		Since:       since,
+	}
+
+	if site != nil && site.Region != nil && strings.TrimSpace(*site.Region) != "" {
+		comparison.Regional, err = h.BenchmarkRepo.GetPriceBenchmark(r.Context(), mineralType, unit, *site.Region, since)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to calculate price benchmark")
+			return
+		}
+	}
+	comparison.Platform, err = h.BenchmarkRepo.GetPriceBenchmark(r.Context(), mineralType, unit, "", since)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to calculate price benchmark")
+		return
+	}
+
+	average, sales, err := h.BenchmarkRepo.GetAveragePrice(r.Context(), userID, mineralType, unit, since)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to calculate your average price")
+		return
+	}
+	comparison.YourSales = sales
+	if sales > 0 {
+		comparison.YourAverage = &average
+	}
+
+	benchmark, scope := comparison.Regional, "regional"
+	if benchmark == nil {
+		benchmark, scope = comparison.Platform, "platform"
+	}
+	switch {
+	case benchmark == nil:
+		comparison.Message = fmt.Sprintf("Not enough sellers share %s prices yet to show a benchmark", mineralType)
+	case comparison.YourAverage == nil:
+		comparison.Message = fmt.Sprintf("You have no %s sales in the last %d days to compare", mineralType, days)
+	default:
+		below := average < benchmark.Median
+		diff := math.Round((average-benchmark.Median)/benchmark.Median*1000) / 10
+		comparison.BelowMedian = &below
+		comparison.DiffPercent = &diff
+		if below {
+			comparison.Message = fmt.Sprintf("Your average price is %.1f%% below the %s median", -diff, scope)
+		} else {
+			comparison.Message = fmt.Sprintf("Your average price is %.1f%% above the %s median", diff, scope)
+		}
+	}
+
+	utils.WriteSuccessResponse(w, "Price benchmark retrieved successfully", comparison)
+}
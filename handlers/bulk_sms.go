@@ -0,0 +1,506 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// smsOptOutSignature is the signing purpose of SMS opt-out link tokens
+const smsOptOutSignature = "sms-opt-out"
+
+// Limits of an SMS campaign
+const (
+	maxCampaignRecipients = 500
+	maxCampaignMessage    = 459 // three SMS segments
+)
+
+// BulkSMSHandler handles SMS campaigns to customers and suppliers
+type BulkSMSHandler struct {
+	BulkSMSRepo  data.BulkSMSInterface
+	DeliveryRepo data.DeliveryInterface
+	JobRepo      data.JobInterface
+	UserRepo     data.UserInterface
+	// BaseURL is prepended to opt-out link paths, e.g. https://api.example.com
+	BaseURL string
+	// MonthlyQuota is the number of campaign messages each organization may send per calendar month
+	MonthlyQuota int64
+}
+
+// NewBulkSMSHandler creates a new BulkSMSHandler
+func NewBulkSMSHandler(bulkSMSRepo data.BulkSMSInterface, deliveryRepo data.DeliveryInterface, jobRepo data.JobInterface, userRepo data.UserInterface) *BulkSMSHandler {
+	return &BulkSMSHandler{
+		BulkSMSRepo:  bulkSMSRepo,
+		DeliveryRepo: deliveryRepo,
+		JobRepo:      jobRepo,
+		UserRepo:     userRepo,
+		MonthlyQuota: 1000,
+	}
+}
+
+// SMSRecipientRequest represents an ad hoc campaign recipient
+type SMSRecipientRequest struct {
+	Name  string `json:"name"`
+	Phone string `json:"phone"`
+}
+
+// SMSCampaignRequest represents a request to send an SMS campaign
+type SMSCampaignRequest struct {
+	Message    string                `json:"message"`              // supports {name} and {seller}
+	Customers  []string              `json:"customers,omitempty"`  // customer names, sent to the contact of their latest sale
+	Suppliers  []string              `json:"suppliers,omitempty"`  // supplier names, sent to the contact of their latest expense
+	Recipients []SMSRecipientRequest `json:"recipients,omitempty"` // other phone numbers
+	OptOutLink *bool                 `json:"opt_out_link,omitempty"`
+}
+
+// SMSOptOutRequest represents a request to stop sending campaigns to a phone number
+type SMSOptOutRequest struct {
+	Phone  string  `json:"phone"`
+	Reason *string `json:"reason,omitempty"`
+}
+
+// GetContacts returns the customers and suppliers with a phone number, marking opted out numbers
+func (h *BulkSMSHandler) GetContacts(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	contacts, err := h.smsContacts(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve contacts")
+		return
+	}
+
+	if contactType := r.URL.Query().Get("type"); contactType != "" {
+		filtered := make([]*data.SMSContact, 0, len(contacts))
+		for _, contact := range contacts {
+			if contact.Type == contactType {
+				filtered = append(filtered, contact)
+			}
+		}
+		contacts = filtered
+	}
+
+	utils.WriteSuccessResponse(w, "Contacts retrieved successfully", contacts)
+}
+
+// GetUsage returns the campaign messages sent this month against the monthly quota
+func (h *BulkSMSHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	usage, err := h.usage(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve SMS usage")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "SMS usage retrieved successfully", usage)
+}
+
+// GetCampaigns returns the SMS campaigns
+func (h *BulkSMSHandler) GetCampaigns(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	campaigns, err := h.BulkSMSRepo.GetCampaigns(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve SMS campaigns")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "SMS campaigns retrieved successfully", campaigns)
+}
+
+// GetCampaign returns an SMS campaign with the delivery status of each recipient
+func (h *BulkSMSHandler) GetCampaign(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid campaign ID")
+		return
+	}
+
+	campaign, err := h.BulkSMSRepo.GetCampaign(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "SMS campaign not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "SMS campaign retrieved successfully", campaign)
+}
+
+// SendCampaign sends a templated SMS to selected customers, suppliers and other numbers
+// (owner/manager). Opted out, invalid and duplicate numbers are skipped; the campaign is
+// rejected when it would exceed the monthly quota.
+func (h *BulkSMSHandler) SendCampaign(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	if !requirePermission(w, r, data.PermSMSSend, "You do not have permission to send SMS campaigns") {
+		return
+	}
+
+	var req SMSCampaignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	message := strings.TrimSpace(req.Message)
+	if !utils.ValidateRequired(message) {
+		utils.WriteValidationError(w, "Message is required")
+		return
+	}
+	if len(message) > maxCampaignMessage {
+		utils.WriteValidationError(w, fmt.Sprintf("Message must be at most %d characters", maxCampaignMessage))
+		return
+	}
+
+	recipients, ok := h.resolveRecipients(r.Context(), w, userID, &req)
+	if !ok {
+		return
+	}
+
+	actorID := middleware.GetActorIDFromRequest(r)
+	campaign := &data.SMSCampaign{
+		Message:        message,
+		OptOutLink:     req.OptOutLink == nil || *req.OptOutLink,
+		RecipientCount: len(recipients),
+		CreatedByID:    &actorID,
+		UserID:         userID,
+	}
+	seller := h.sellerName(r.Context(), userID)
+	for i := range recipients {
+		recipient := &recipients[i]
+		recipient.UserID = userID
+		if recipient.Status == data.SMSRecipientQueued {
+			recipient.Body = renderCampaignMessage(message, recipient.Name, seller)
+			campaign.QueuedCount++
+		} else {
+			campaign.SkippedCount++
+		}
+	}
+	campaign.Recipients = recipients
+
+	usage, err := h.usage(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to send SMS campaign")
+		return
+	}
+	if int64(campaign.QueuedCount) > usage.Remaining {
+		utils.WriteErrorResponse(w, fmt.Sprintf("This campaign needs %d messages but only %d of the monthly quota of %d remain",
+			campaign.QueuedCount, usage.Remaining, usage.Quota), http.StatusTooManyRequests)
+		return
+	}
+
+	if _, err := h.BulkSMSRepo.InsertCampaign(r.Context(), campaign); err != nil {
+		utils.WriteInternalServerError(w, "Failed to send SMS campaign")
+		return
+	}
+
+	for i := range campaign.Recipients {
+		recipient := &campaign.Recipients[i]
+		if recipient.Status != data.SMSRecipientQueued {
+			continue
+		}
+		if campaign.OptOutLink {
+			recipient.Body += "\nStop messages: " + h.optOutURL(recipient.ID)
+		}
+		if err := h.queueMessage(r.Context(), recipient); err != nil {
+			log.Printf("Failed to queue SMS campaign %d message to %s: %v", campaign.ID, recipient.Phone, err)
+			utils.WriteInternalServerError(w, "Failed to queue all campaign messages")
+			return
+		}
+	}
+
+	utils.WriteSuccessResponse(w, "SMS campaign queued successfully", campaign)
+}
+
+// GetOptOuts returns the phone numbers opted out of SMS campaigns
+func (h *BulkSMSHandler) GetOptOuts(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	optOuts, err := h.BulkSMSRepo.GetOptOuts(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve opt-outs")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Opt-outs retrieved successfully", optOuts)
+}
+
+// CreateOptOut stops SMS campaigns to a phone number, e.g. when a customer asks by phone
+func (h *BulkSMSHandler) CreateOptOut(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req SMSOptOutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	phone := utils.NormalizePhone(req.Phone)
+	if phone == "" || !utils.ValidatePhone(phone) {
+		utils.WriteValidationError(w, "A valid phone number is required")
+		return
+	}
+
+	optOut := &data.SMSOptOut{Phone: phone, Source: "manual", Reason: req.Reason, UserID: userID}
+	if err := h.BulkSMSRepo.OptOut(r.Context(), optOut); err != nil {
+		utils.WriteInternalServerError(w, "Failed to save opt-out")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Phone number opted out successfully", optOut)
+}
+
+// DeleteOptOut lets a phone number receive SMS campaigns again
+func (h *BulkSMSHandler) DeleteOptOut(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid opt-out ID")
+		return
+	}
+
+	if err := h.BulkSMSRepo.RemoveOptOut(r.Context(), uint(id), userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Opt-out not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to remove opt-out")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Opt-out removed successfully", nil)
+}
+
+// PublicOptOut opts the recipient of a campaign message out of further campaigns through the
+// link in the message (no authentication)
+func (h *BulkSMSHandler) PublicOptOut(w http.ResponseWriter, r *http.Request) {
+	recipientID, err := utils.VerifySignedID(smsOptOutSignature, chi.URLParam(r, "token"))
+	if err != nil {
+		utils.WriteNotFoundError(w, "Link not found")
+		return
+	}
+
+	recipient, err := h.BulkSMSRepo.GetRecipient(r.Context(), recipientID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Link not found")
+		return
+	}
+
+	optOut := &data.SMSOptOut{Phone: recipient.Phone, Source: "link", UserID: recipient.UserID}
+	if err := h.BulkSMSRepo.OptOut(r.Context(), optOut); err != nil {
+		utils.WriteInternalServerError(w, "Failed to save opt-out")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "You will no longer receive these messages", nil)
+}
+
+// resolveRecipients builds the recipients of a campaign request, marking opted out, invalid
+// and duplicate numbers as skipped. It writes the error response and returns false when a
+// named customer or supplier has no contact or there are too many recipients.
+func (h *BulkSMSHandler) resolveRecipients(ctx context.Context, w http.ResponseWriter, userID uint, req *SMSCampaignRequest) ([]data.SMSCampaignRecipient, bool) {
+	var requested []SMSRecipientRequest
+	if len(req.Customers) > 0 || len(req.Suppliers) > 0 {
+		contacts, err := h.BulkSMSRepo.GetContacts(ctx, userID)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to retrieve contacts")
+			return nil, false
+		}
+		byName := map[string]*data.SMSContact{}
+		for _, contact := range contacts {
+			byName[contact.Type+":"+strings.ToLower(contact.Name)] = contact
+		}
+		for _, group := range []struct {
+			kind  string
+			names []string
+		}{{"customer", req.Customers}, {"supplier", req.Suppliers}} {
+			for _, name := range group.names {
+				contact, found := byName[group.kind+":"+strings.ToLower(strings.TrimSpace(name))]
+				if !found {
+					utils.WriteValidationError(w, fmt.Sprintf("No contact found for %s %s", group.kind, name))
+					return nil, false
+				}
+				requested = append(requested, SMSRecipientRequest{Name: contact.Name, Phone: contact.Phone})
+			}
+		}
+	}
+	requested = append(requested, req.Recipients...)
+
+	if len(requested) == 0 {
+		utils.WriteValidationError(w, "At least one recipient is required")
+		return nil, false
+	}
+	if len(requested) > maxCampaignRecipients {
+		utils.WriteValidationError(w, fmt.Sprintf("A campaign can have at most %d recipients", maxCampaignRecipients))
+		return nil, false
+	}
+
+	optedOut, err := h.BulkSMSRepo.GetOptedOutPhones(ctx, userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve opt-outs")
+		return nil, false
+	}
+
+	seen := map[string]bool{}
+	recipients := make([]data.SMSCampaignRecipient, 0, len(requested))
+	for _, request := range requested {
+		recipient := data.SMSCampaignRecipient{
+			Name:   strings.TrimSpace(request.Name),
+			Phone:  utils.NormalizePhone(request.Phone),
+			Status: data.SMSRecipientQueued,
+		}
+		switch {
+		case recipient.Phone == "" || !utils.ValidatePhone(recipient.Phone):
+			recipient.Status = data.SMSRecipientInvalid
+		case optedOut[recipient.Phone]:
+			recipient.Status = data.SMSRecipientOptedOut
+		case seen[recipient.Phone]:
+			recipient.Status = data.SMSRecipientDuplicate
+		}
+		if len(recipient.Phone) > 20 {
+			recipient.Phone = recipient.Phone[:20]
+		}
+		seen[recipient.Phone] = true
+		recipients = append(recipients, recipient)
+	}
+	return recipients, true
+}
+
+// queueMessage records the delivery of a campaign message and queues it for sending
+func (h *BulkSMSHandler) queueMessage(ctx context.Context, recipient *data.SMSCampaignRecipient) error {
+	deliveryID, err := h.DeliveryRepo.Insert(ctx, &data.MessageDelivery{
+		Purpose:   "sms_campaign",
+		Recipient: recipient.Phone,
+		Phone:     &recipient.Phone,
+		UserID:    &recipient.UserID,
+	})
+	if err != nil {
+		return err
+	}
+	if err := h.BulkSMSRepo.SetRecipientDelivery(ctx, recipient.ID, deliveryID, recipient.Body); err != nil {
+		return err
+	}
+	recipient.DeliveryID = &deliveryID
+
+	_, err = h.JobRepo.Enqueue(ctx, data.JobTypeSendMessage, data.SendMessagePayload{
+		DeliveryID: deliveryID,
+		Channel:    data.DeliverySMS,
+		To:         recipient.Phone,
+		Body:       recipient.Body,
+	})
+	return err
+}
+
+// smsContacts returns the customers and suppliers whose contact is a valid phone number
+func (h *BulkSMSHandler) smsContacts(ctx context.Context, userID uint) ([]*data.SMSContact, error) {
+	contacts, err := h.BulkSMSRepo.GetContacts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	optedOut, err := h.BulkSMSRepo.GetOptedOutPhones(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	phones := make([]*data.SMSContact, 0, len(contacts))
+	for _, contact := range contacts {
+		contact.Phone = utils.NormalizePhone(contact.Phone)
+		if contact.Phone == "" || !utils.ValidatePhone(contact.Phone) {
+			continue
+		}
+		contact.OptedOut = optedOut[contact.Phone]
+		phones = append(phones, contact)
+	}
+	return phones, nil
+}
+
+// usage returns the campaign messages a user sent this calendar month against the quota
+func (h *BulkSMSHandler) usage(ctx context.Context, userID uint) (*data.SMSUsage, error) {
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	used, err := h.BulkSMSRepo.CountSentSince(ctx, userID, monthStart)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := h.MonthlyQuota - used
+	if remaining < 0 {
+		remaining = 0
+	}
+	return &data.SMSUsage{
+		Month:     monthStart.Format("2006-01"),
+		Used:      used,
+		Quota:     h.MonthlyQuota,
+		Remaining: remaining,
+	}, nil
+}
+
+// sellerName returns the name of the campaign sender
+func (h *BulkSMSHandler) sellerName(ctx context.Context, userID uint) string {
+	user, err := h.UserRepo.GetOne(ctx, userID)
+	if err != nil {
+		return ""
+	}
+	return user.Name
+}
+
+// optOutURL returns the signed opt-out link of a campaign recipient
+func (h *BulkSMSHandler) optOutURL(recipientID uint) string {
+	return h.BaseURL + "/api/v1/public/sms/opt-out/" + utils.SignID(smsOptOutSignature, recipientID)
+}
+
+// renderCampaignMessage fills in the {name} and {seller} placeholders of a campaign message
+func renderCampaignMessage(message, name, seller string) string {
+	if name == "" {
+		name = "customer"
+	}
+	return strings.NewReplacer("{name}", name, "{seller}", seller).Replace(message)
+}
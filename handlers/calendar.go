@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"mineral/data"
+	"mineral/pkg/ical"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// CalendarHandler handles the calendar feed of due dates, expiries, maintenance and tasks
+type CalendarHandler struct {
+	IncomeRepo   data.IncomeInterface
+	MineSiteRepo data.MineSiteInterface
+	VehicleRepo  data.VehicleInterface
+	TaskRepo     data.TaskInterface
+	SettingsRepo data.SettingsInterface
+	// BaseURL is prepended to the feed path, e.g. https://api.example.com
+	BaseURL string
+}
+
+// NewCalendarHandler creates a new CalendarHandler
+func NewCalendarHandler(incomeRepo data.IncomeInterface, mineSiteRepo data.MineSiteInterface, vehicleRepo data.VehicleInterface, taskRepo data.TaskInterface, settingsRepo data.SettingsInterface) *CalendarHandler {
+	return &CalendarHandler{
+		IncomeRepo:   incomeRepo,
+		MineSiteRepo: mineSiteRepo,
+		VehicleRepo:  vehicleRepo,
+		TaskRepo:     taskRepo,
+		SettingsRepo: settingsRepo,
+	}
+}
+
+// CalendarFeedResponse represents the subscription URL of a calendar feed
+type CalendarFeedResponse struct {
+	URL string `json:"url"`
+}
+
+// GetFeedLink returns the private subscription URL of the calendar feed, creating it on first use
+func (h *CalendarHandler) GetFeedLink(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	settings, err := h.SettingsRepo.GetByUserID(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve calendar feed")
+		return
+	}
+	if settings.CalendarToken == nil {
+		if !h.saveFeedToken(r.Context(), w, settings) {
+			return
+		}
+	}
+
+	utils.WriteSuccessResponse(w, "Calendar feed retrieved successfully", &CalendarFeedResponse{
+		URL: h.feedURL(*settings.CalendarToken),
+	})
+}
+
+// ResetFeedLink replaces the subscription URL of the calendar feed, so the old URL stops working
+func (h *CalendarHandler) ResetFeedLink(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	settings, err := h.SettingsRepo.GetByUserID(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to reset calendar feed")
+		return
+	}
+	if !h.saveFeedToken(r.Context(), w, settings) {
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Calendar feed reset successfully", &CalendarFeedResponse{
+		URL: h.feedURL(*settings.CalendarToken),
+	})
+}
+
+// DownloadCalendar downloads the calendar as an iCalendar file
+func (h *CalendarHandler) DownloadCalendar(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	settings, err := h.SettingsRepo.GetByUserID(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to build calendar")
+		return
+	}
+
+	h.writeCalendar(r.Context(), w, settings)
+}
+
+// GetPublicFeed serves the calendar feed to calendar apps through its private URL (no authentication)
+func (h *CalendarHandler) GetPublicFeed(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimSuffix(chi.URLParam(r, "token"), ".ics")
+	if token == "" {
+		utils.WriteNotFoundError(w, "Calendar feed not found")
+		return
+	}
+
+	settings, err := h.SettingsRepo.GetByCalendarToken(r.Context(), token)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Calendar feed not found")
+		return
+	}
+
+	h.writeCalendar(r.Context(), w, settings)
+}
+
+// writeCalendar builds the calendar of the settings' user and writes it as an iCalendar file
+func (h *CalendarHandler) writeCalendar(ctx context.Context, w http.ResponseWriter, settings *data.OrganizationSettings) {
+	calendar, err := h.buildCalendar(ctx, settings)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to build calendar")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="calendar.ics"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(calendar.Bytes())
+}
+
+// buildCalendar collects invoice due dates of unpaid sales, the mining license expiry, scheduled
+// vehicle maintenance and open tasks into a calendar
+func (h *CalendarHandler) buildCalendar(ctx context.Context, settings *data.OrganizationSettings) (*ical.Calendar, error) {
+	userID := settings.UserID
+	calendar := ical.New("Mining obligations")
+
+	incomes, err := h.IncomeRepo.GetOutstanding(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, income := range incomes {
+		if income.DueDate == nil {
+			continue
+		}
+		invoice := fmt.Sprintf("sale #%d", income.ID)
+		if income.InvoiceNumber != nil {
+			invoice = *income.InvoiceNumber
+		}
+		calendar.Add(ical.Event{
+			UID:         fmt.Sprintf("income-%d-due@mineral", income.ID),
+			Date:        *income.DueDate,
+			Summary:     fmt.Sprintf("Payment due: %s (%s)", income.CustomerName, invoice),
+			Description: "Balance due " + utils.FormatMoney(settings.DefaultCurrency, income.AmountDue),
+		})
+	}
+
+	site, err := h.MineSiteRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if site != nil && site.LicenseExpiry != nil {
+		summary := "Mining license expires"
+		if site.License != nil && *site.License != "" {
+			summary += ": " + *site.License
+		}
+		calendar.Add(ical.Event{
+			UID:         fmt.Sprintf("minesite-%d-license@mineral", site.ID),
+			Date:        *site.LicenseExpiry,
+			Summary:     summary,
+			Description: site.Location,
+		})
+	}
+
+	vehicles, err := h.VehicleRepo.GetAll(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, vehicle := range vehicles {
+		if vehicle.NextServiceDate == nil {
+			continue
+		}
+		calendar.Add(ical.Event{
+			UID:     fmt.Sprintf("vehicle-%d-service@mineral", vehicle.ID),
+			Date:    *vehicle.NextServiceDate,
+			Summary: "Vehicle service: " + vehicle.Registration,
+		})
+	}
+
+	tasks, err := h.TaskRepo.GetAll(ctx, userID, data.TaskFilter{Status: data.TaskOpen})
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		if task.DueDate == nil {
+			continue
+		}
+		var description []string
+		if task.Description != nil {
+			description = append(description, *task.Description)
+		}
+		if task.Assignee != nil {
+			description = append(description, "Assigned to "+task.Assignee.Name)
+		}
+		calendar.Add(ical.Event{
+			UID:         fmt.Sprintf("task-%d@mineral", task.ID),
+			Date:        *task.DueDate,
+			Summary:     "Task: " + task.Title,
+			Description: strings.Join(description, "\n"),
+		})
+	}
+
+	return calendar, nil
+}
+
+// saveFeedToken gives the settings a new random calendar feed token, writing the error
+// response and returning false when it fails
+func (h *CalendarHandler) saveFeedToken(ctx context.Context, w http.ResponseWriter, settings *data.OrganizationSettings) bool {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		utils.WriteInternalServerError(w, "Failed to create calendar feed")
+		return false
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	settings.CalendarToken = &token
+
+	if err := h.SettingsRepo.Save(ctx, settings); err != nil {
+		utils.WriteInternalServerError(w, "Failed to create calendar feed")
+		return false
+	}
+	return true
+}
+
+// feedURL returns the subscription URL of a calendar feed token
+func (h *CalendarHandler) feedURL(token string) string {
+	return h.BaseURL + "/api/v1/public/calendar/" + token + ".ics"
+}
+
+// parseOptionalDate parses an optional YYYY-MM-DD date, returning nil when it is empty
+func parseOptionalDate(value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse("2006-01-02", *value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
@@ -0,0 +1,420 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// CashDayHandler handles the day-open and day-close workflow of the cash held at buying stations
+type CashDayHandler struct {
+	CashDayRepo      data.CashDayInterface
+	MineSiteRepo     data.MineSiteInterface
+	NotificationRepo data.NotificationInterface
+	// TillRepo enables opening the cash day of a till when set
+	TillRepo data.TillInterface
+}
+
+// NewCashDayHandler creates a new CashDayHandler
+func NewCashDayHandler(cashDayRepo data.CashDayInterface, mineSiteRepo data.MineSiteInterface, notificationRepo data.NotificationInterface) *CashDayHandler {
+	return &CashDayHandler{
+		CashDayRepo:      cashDayRepo,
+		MineSiteRepo:     mineSiteRepo,
+		NotificationRepo: notificationRepo,
+	}
+}
+
+// OpenCashDayRequest represents a request to open a cash day
+type OpenCashDayRequest struct {
+	Date         string  `json:"date"` // defaults to today
+	MineSiteID   *uint   `json:"mine_site_id,omitempty"`
+	TillID       *uint   `json:"till_id,omitempty"` // the till's station is used
+	OpeningFloat float64 `json:"opening_float"`
+	Notes        *string `json:"notes,omitempty"`
+}
+
+// CashMovementRequest represents a request to record cash put into or taken out of the till
+type CashMovementRequest struct {
+	Direction   string  `json:"direction"` // in or out
+	Amount      float64 `json:"amount"`
+	Description string  `json:"description"`
+}
+
+// CloseCashDayRequest represents a request to close a cash day
+type CloseCashDayRequest struct {
+	CountedCash       *float64 `json:"counted_cash"`
+	DiscrepancyReason string   `json:"discrepancy_reason,omitempty"` // required when the count doesn't match
+}
+
+// GetCashDays retrieves the cash days of the authenticated user, optionally of a station, of a
+// till and by status (open or closed)
+func (h *CashDayHandler) GetCashDays(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	siteID, ok := parseSiteFilter(w, r)
+	if !ok {
+		return
+	}
+	var tillID *uint
+	if tillStr := r.URL.Query().Get("till_id"); tillStr != "" {
+		id, err := strconv.ParseUint(tillStr, 10, 32)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid till ID")
+			return
+		}
+		till := uint(id)
+		tillID = &till
+	}
+	status := data.CashDayStatus(r.URL.Query().Get("status"))
+	if status != "" && status != data.CashDayOpen && status != data.CashDayClosed {
+		utils.WriteValidationError(w, "Status must be open or closed")
+		return
+	}
+
+	days, err := h.CashDayRepo.GetAll(r.Context(), userID, siteID, tillID, status)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve cash days")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Cash days retrieved successfully", days)
+}
+
+// GetCashDay retrieves a cash day with its cash movements and, while it is open, the cash the
+// till is expected to hold so far
+func (h *CashDayHandler) GetCashDay(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	day, ok := h.cashDay(w, r, userID)
+	if !ok {
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Cash day retrieved successfully", day)
+}
+
+// OpenCashDay opens the cash day of a station, or of one of its tills, with the opening float
+// declared. A till's day is accountable to the clerk the till is assigned to.
+func (h *CashDayHandler) OpenCashDay(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req OpenCashDayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if !utils.ValidateNonNegativeNumber(req.OpeningFloat) {
+		utils.WriteValidationError(w, "Opening float cannot be negative")
+		return
+	}
+	now := time.Now()
+	date := now.UTC().Truncate(24 * time.Hour)
+	if req.Date != "" {
+		parsed, err := time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid date format. Use YYYY-MM-DD")
+			return
+		}
+		date = parsed
+	}
+	if date.After(now) {
+		utils.WriteValidationError(w, "A cash day can't be opened for a future date")
+		return
+	}
+
+	day := &data.CashDay{
+		Date:         date,
+		MineSiteID:   req.MineSiteID,
+		OpeningFloat: req.OpeningFloat,
+		OpenedByID:   middleware.GetActorIDFromRequest(r),
+		OpenedAt:     now,
+		Notes:        req.Notes,
+		UserID:       userID,
+	}
+	scope := "station"
+	if req.TillID != nil {
+		tillID, ok := resolveTill(w, r, h.TillRepo, userID, req.TillID)
+		if !ok {
+			return
+		}
+		till, err := h.TillRepo.GetOne(r.Context(), *tillID, userID)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to retrieve till")
+			return
+		}
+		day.TillID = tillID
+		day.MineSiteID = till.MineSiteID
+		// A till assigned to no one is accountable to the member who opens it
+		day.ClerkID = till.ClerkID
+		if day.ClerkID == nil {
+			day.ClerkID = &day.OpenedByID
+		}
+		scope = "till"
+	} else if !checkMineSite(r.Context(), w, h.MineSiteRepo, userID, req.MineSiteID) {
+		// Cash days can only be opened for the user's own buying stations
+		return
+	}
+
+	if err := h.CashDayRepo.Open(r.Context(), day); err != nil {
+		switch {
+		case errors.Is(err, data.ErrCashDayOpen):
+			utils.WriteErrorResponse(w, "A cash day of this "+scope+" is already open; close it first", http.StatusConflict)
+		case errors.Is(err, data.ErrCashDayExists):
+			utils.WriteErrorResponse(w, "This "+scope+" already had a cash day on this date", http.StatusConflict)
+		default:
+			utils.WriteInternalServerError(w, "Failed to open cash day")
+		}
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Cash day opened successfully", day)
+}
+
+// AddCashMovement records cash put into or taken out of the till of an open cash day, other than
+// payments on sales, expenses and purchases
+func (h *CashDayHandler) AddCashMovement(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid cash day ID")
+		return
+	}
+
+	var req CashMovementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	direction := data.CashMovementDirection(req.Direction)
+	if direction != data.CashIn && direction != data.CashOut {
+		utils.WriteValidationError(w, "Direction must be in or out")
+		return
+	}
+	if !utils.ValidatePositiveNumber(req.Amount) {
+		utils.WriteValidationError(w, "Amount must be positive")
+		return
+	}
+	description := strings.TrimSpace(req.Description)
+	if !utils.ValidateRequired(description) {
+		utils.WriteValidationError(w, "Description is required")
+		return
+	}
+	if len(description) > 255 {
+		utils.WriteValidationError(w, "Description must be at most 255 characters")
+		return
+	}
+
+	movement := &data.CashMovement{
+		CashDayID:    uint(id),
+		Direction:    direction,
+		Amount:       req.Amount,
+		Description:  description,
+		RecordedByID: middleware.GetActorIDFromRequest(r),
+		UserID:       userID,
+	}
+	if err := h.CashDayRepo.AddMovement(r.Context(), movement); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.WriteNotFoundError(w, "Cash day not found")
+		case errors.Is(err, data.ErrCashDayClosed):
+			utils.WriteErrorResponse(w, "The cash day has been closed", http.StatusConflict)
+		default:
+			utils.WriteInternalServerError(w, "Failed to record cash movement")
+		}
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Cash movement recorded successfully", movement)
+}
+
+// CloseCashDay closes an open cash day with the cash counted in the till. A count that doesn't
+// match the cash expected needs a reason, and the owner is notified of it.
+func (h *CashDayHandler) CloseCashDay(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	day, ok := h.cashDay(w, r, userID)
+	if !ok {
+		return
+	}
+	if day.Status != data.CashDayOpen {
+		utils.WriteErrorResponse(w, "The cash day has already been closed", http.StatusConflict)
+		return
+	}
+
+	var req CloseCashDayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if req.CountedCash == nil {
+		utils.WriteValidationError(w, "Counted cash is required")
+		return
+	}
+	if !utils.ValidateNonNegativeNumber(*req.CountedCash) {
+		utils.WriteValidationError(w, "Counted cash cannot be negative")
+		return
+	}
+	reason := strings.TrimSpace(req.DiscrepancyReason)
+	if math.Abs(*req.CountedCash-day.ExpectedCash) >= 0.005 && reason == "" {
+		utils.WriteValidationError(w, fmt.Sprintf("Discrepancy reason is required when the cash counted doesn't match the cash expected (%.2f)", day.ExpectedCash))
+		return
+	}
+
+	closedByID := middleware.GetActorIDFromRequest(r)
+	closedAt := time.Now()
+	day.CountedCash = req.CountedCash
+	day.ClosedByID = &closedByID
+	day.ClosedAt = &closedAt
+	if reason != "" {
+		day.DiscrepancyReason = &reason
+	}
+	if err := h.CashDayRepo.Close(r.Context(), day); err != nil {
+		if errors.Is(err, data.ErrCashDayClosed) {
+			utils.WriteErrorResponse(w, "The cash day has already been closed", http.StatusConflict)
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to close cash day")
+		return
+	}
+
+	if math.Abs(*day.Discrepancy) >= 0.005 {
+		h.notifyDiscrepancy(r.Context(), day)
+	}
+
+	utils.WriteSuccessResponse(w, "Cash day closed successfully", day)
+}
+
+// GetCashDiscrepancies reports the discrepancies of the cash days closed between start_date and
+// end_date, the last 30 days by default, optionally of a station
+func (h *CashDayHandler) GetCashDiscrepancies(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	siteID, ok := parseSiteFilter(w, r)
+	if !ok {
+		return
+	}
+	start, end, ok := parseReportPeriod(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.CashDayRepo.GetDiscrepancyReport(r.Context(), userID, siteID, start, end)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve cash discrepancies")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Cash discrepancies retrieved successfully", report)
+}
+
+// notifyDiscrepancy notifies the owner of the books of a cash day closed with a discrepancy.
+// Failures are ignored so they never block closing the day.
+func (h *CashDayHandler) notifyDiscrepancy(ctx context.Context, day *data.CashDay) {
+	if h.NotificationRepo == nil {
+		return
+	}
+	kind := "over"
+	amount := *day.Discrepancy
+	if amount < 0 {
+		kind = "short"
+		amount = -amount
+	}
+	message := fmt.Sprintf("The cash day of %s closed %.2f %s", day.Date.Format("2006-01-02"), amount, kind)
+	if day.DiscrepancyReason != nil {
+		message += ": " + *day.DiscrepancyReason
+	}
+	dayID := day.ID
+	h.NotificationRepo.Insert(ctx, &data.Notification{
+		Kind:        data.NotificationCashMismatch,
+		Title:       "Cash count didn't match",
+		Message:     message,
+		ReferenceID: &dayID,
+		Key:         fmt.Sprintf("%s:%d", data.NotificationCashMismatch, day.ID),
+		UserID:      day.UserID,
+	})
+}
+
+// cashDay returns the cash day of a request, writing the error response and returning false
+// when it doesn't exist
+func (h *CashDayHandler) cashDay(w http.ResponseWriter, r *http.Request, userID uint) (*data.CashDay, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid cash day ID")
+		return nil, false
+	}
+	day, err := h.CashDayRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Cash day not found")
+			return nil, false
+		}
+		utils.WriteInternalServerError(w, "Failed to retrieve cash day")
+		return nil, false
+	}
+	return day, true
+}
+
+// parseReportPeriod parses the start_date and end_date of a report, the last 30 days by default,
+// writing a validation error and returning false when they are invalid
+func parseReportPeriod(w http.ResponseWriter, r *http.Request) (time.Time, time.Time, bool) {
+	end := time.Now().UTC().Truncate(24 * time.Hour)
+	if endStr := r.URL.Query().Get("end_date"); endStr != "" {
+		parsed, err := time.Parse("2006-01-02", endStr)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid end date format. Use YYYY-MM-DD")
+			return time.Time{}, time.Time{}, false
+		}
+		end = parsed
+	}
+	start := end.AddDate(0, 0, -29)
+	if startStr := r.URL.Query().Get("start_date"); startStr != "" {
+		parsed, err := time.Parse("2006-01-02", startStr)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid start date format. Use YYYY-MM-DD")
+			return time.Time{}, time.Time{}, false
+		}
+		start = parsed
+	}
+	if end.Before(start) {
+		utils.WriteValidationError(w, "End date must not be before start date")
+		return time.Time{}, time.Time{}, false
+	}
+	return start, end, true
+}
@@ -0,0 +1,466 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// ClaimHandler handles the reimbursement claims of staff who paid expenses personally. Members
+// who can approve expenses review everyone's claims; other members only see their own.
+type ClaimHandler struct {
+	ClaimRepo   data.ClaimInterface
+	PaymentRepo data.PaymentInterface
+
+	// MineSiteRepo checks the mine sites claims are assigned to; claims can't be assigned to a
+	// site when it is nil
+	MineSiteRepo data.MineSiteInterface
+
+	// TillRepo enables attributing the cash paid out to tills when set
+	TillRepo data.TillInterface
+
+	// SettingsRepo enables the sign-off of approved claims above the sign-off amount when set
+	SettingsRepo data.SettingsInterface
+}
+
+// NewClaimHandler creates a new ClaimHandler
+func NewClaimHandler(claimRepo data.ClaimInterface, paymentRepo data.PaymentInterface) *ClaimHandler {
+	return &ClaimHandler{
+		ClaimRepo:   claimRepo,
+		PaymentRepo: paymentRepo,
+	}
+}
+
+// ClaimRequest represents a reimbursement claim for an expense paid personally
+type ClaimRequest struct {
+	Date         string  `json:"date"`
+	Category     string  `json:"category"`
+	Description  string  `json:"description"`
+	Amount       float64 `json:"amount"`
+	SupplierName *string `json:"supplier_name,omitempty"` // where the expense was paid
+	Notes        *string `json:"notes,omitempty"`
+	MineSiteID   *uint   `json:"mine_site_id,omitempty"`
+}
+
+// RejectClaimRequest represents the reason a reimbursement claim is rejected
+type RejectClaimRequest struct {
+	Reason string `json:"reason"`
+}
+
+// GetClaims retrieves reimbursement claims, optionally filtered by claimant_id and status.
+// Members who can't approve expenses only get their own claims.
+func (h *ClaimHandler) GetClaims(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var claimantID *uint
+	if value := r.URL.Query().Get("claimant_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid claimant ID")
+			return
+		}
+		claimant := uint(id)
+		claimantID = &claimant
+	}
+	if !middleware.HasPermission(r, string(data.PermExpenseApprove)) {
+		actorID := middleware.GetActorIDFromRequest(r)
+		claimantID = &actorID
+	}
+
+	var status *data.ClaimStatus
+	if value := r.URL.Query().Get("status"); value != "" {
+		s := data.ClaimStatus(value)
+		if s != data.ClaimSubmitted && s != data.ClaimApproved && s != data.ClaimRejected {
+			utils.WriteValidationError(w, "Status must be submitted, approved or rejected")
+			return
+		}
+		status = &s
+	}
+
+	claims, err := h.ClaimRepo.GetAll(r.Context(), userID, claimantID, status)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve claims")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Claims retrieved successfully", claims)
+}
+
+// GetClaim retrieves a reimbursement claim
+func (h *ClaimHandler) GetClaim(w http.ResponseWriter, r *http.Request) {
+	claim, ok := h.requestClaim(w, r)
+	if !ok {
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Claim retrieved successfully", claim)
+}
+
+// CreateClaim submits a reimbursement claim for an expense the acting member paid personally.
+// Its receipts are attached through the attachments sub-resource.
+func (h *ClaimHandler) CreateClaim(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	claim := &data.ExpenseClaim{
+		ClaimantID: middleware.GetActorIDFromRequest(r),
+		UserID:     userID,
+	}
+	if !h.applyRequest(w, r, userID, claim) {
+		return
+	}
+
+	id, err := h.ClaimRepo.Insert(r.Context(), claim)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to submit claim")
+		return
+	}
+
+	claim.ID = id
+	utils.WriteSuccessResponse(w, "Claim submitted successfully", claim)
+}
+
+// UpdateClaim updates a reimbursement claim awaiting review. Only the claimant can change it.
+func (h *ClaimHandler) UpdateClaim(w http.ResponseWriter, r *http.Request) {
+	claim, ok := h.claimantClaim(w, r)
+	if !ok {
+		return
+	}
+	if !h.applyRequest(w, r, claim.UserID, claim) {
+		return
+	}
+
+	if err := h.ClaimRepo.Update(r.Context(), claim); err != nil {
+		if errors.Is(err, data.ErrClaimReviewed) {
+			utils.WriteValidationError(w, "Only claims awaiting review can be changed")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to update claim")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Claim updated successfully", claim)
+}
+
+// DeleteClaim withdraws a reimbursement claim awaiting review. Only the claimant can withdraw it.
+func (h *ClaimHandler) DeleteClaim(w http.ResponseWriter, r *http.Request) {
+	claim, ok := h.claimantClaim(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.ClaimRepo.Delete(r.Context(), claim.ID, claim.UserID); err != nil {
+		if errors.Is(err, data.ErrClaimReviewed) {
+			utils.WriteValidationError(w, "Only claims awaiting review can be withdrawn")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to withdraw claim")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Claim withdrawn successfully", nil)
+}
+
+// ApproveClaim approves a reimbursement claim with a receipt attached, booking it as an expense
+// owed to the claimant (owner/manager)
+func (h *ClaimHandler) ApproveClaim(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+	if !requirePermission(w, r, data.PermExpenseApprove, "You do not have permission to review claims") {
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid claim ID")
+		return
+	}
+	claim, err := h.ClaimRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Claim not found")
+		return
+	}
+
+	// Claims above the sign-off amount can't be paid out until they are signed off
+	signOff, ok := signOffStatus(r.Context(), w, h.SettingsRepo, userID, claim.Amount)
+	if !ok {
+		return
+	}
+
+	claim, err = h.ClaimRepo.Approve(r.Context(), claim.ID, userID, middleware.GetActorIDFromRequest(r), signOff)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.WriteNotFoundError(w, "Claim not found")
+		case errors.Is(err, data.ErrClaimReviewed):
+			utils.WriteValidationError(w, "Only claims awaiting review can be approved")
+		case errors.Is(err, data.ErrClaimNoReceipt):
+			utils.WriteValidationError(w, "A receipt must be attached before the claim is approved")
+		default:
+			utils.WriteInternalServerError(w, "Failed to approve claim")
+		}
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Claim approved successfully", claim)
+}
+
+// RejectClaim rejects a reimbursement claim with a reason (owner/manager)
+func (h *ClaimHandler) RejectClaim(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+	if !requirePermission(w, r, data.PermExpenseApprove, "You do not have permission to review claims") {
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid claim ID")
+		return
+	}
+
+	var req RejectClaimRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if !utils.ValidateRequired(req.Reason) {
+		utils.WriteValidationError(w, "Rejection reason is required")
+		return
+	}
+
+	if _, err := h.ClaimRepo.GetOne(r.Context(), uint(id), userID); err != nil {
+		utils.WriteNotFoundError(w, "Claim not found")
+		return
+	}
+	if err := h.ClaimRepo.Reject(r.Context(), uint(id), userID, middleware.GetActorIDFromRequest(r), strings.TrimSpace(req.Reason)); err != nil {
+		if errors.Is(err, data.ErrClaimReviewed) {
+			utils.WriteValidationError(w, "Only claims awaiting review can be rejected")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to reject claim")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Claim rejected successfully", nil)
+}
+
+// GetClaimPayments returns the payouts made on a reimbursement claim
+func (h *ClaimHandler) GetClaimPayments(w http.ResponseWriter, r *http.Request) {
+	claim, ok := h.requestClaim(w, r)
+	if !ok {
+		return
+	}
+
+	payments := []*data.Payment{}
+	if claim.ExpenseID != nil {
+		var err error
+		payments, err = h.PaymentRepo.GetForRecord(r.Context(), claim.UserID, data.TransactionExpense, *claim.ExpenseID)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to retrieve payments")
+			return
+		}
+	}
+
+	utils.WriteSuccessResponse(w, "Payments retrieved successfully", payments)
+}
+
+// AddClaimPayment records a payout reimbursing an approved claim as a payment of the expense it
+// is booked as, recomputing what is still owed to the claimant
+func (h *ClaimHandler) AddClaimPayment(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid claim ID")
+		return
+	}
+	claim, err := h.ClaimRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Claim not found")
+		return
+	}
+	if claim.Status != data.ClaimApproved || claim.ExpenseID == nil {
+		utils.WriteValidationError(w, "Only approved claims can be paid out")
+		return
+	}
+
+	payment, ok := paymentFromRequest(w, r, h.TillRepo, userID, *claim.ExpenseID)
+	if !ok {
+		return
+	}
+
+	expense, err := h.PaymentRepo.RecordExpensePayment(r.Context(), payment)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.WriteNotFoundError(w, "The expense of this claim has been deleted")
+		case errors.Is(err, data.ErrAwaitingSignOff):
+			utils.WriteValidationError(w, awaitingSignOffMessage)
+		case errors.Is(err, data.ErrOverpayment):
+			utils.WriteValidationError(w, "Payment is more than the amount due")
+		default:
+			utils.WriteInternalServerError(w, "Failed to record payment")
+		}
+		return
+	}
+
+	claim.Expense = expense
+	utils.WriteSuccessResponse(w, "Payment recorded successfully", PaymentResponse{Payment: payment, Record: claim})
+}
+
+// GetClaimantStatement returns the reimbursement statement of a staff member: their claims, what
+// is owed to them and the payouts made. It defaults to the acting member; members who can't
+// approve expenses only get their own.
+func (h *ClaimHandler) GetClaimantStatement(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	claimantID := middleware.GetActorIDFromRequest(r)
+	if value := r.URL.Query().Get("claimant_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid claimant ID")
+			return
+		}
+		if uint(id) != claimantID && !requirePermission(w, r, data.PermExpenseApprove, "You can only view your own reimbursement statement") {
+			return
+		}
+		claimantID = uint(id)
+	}
+
+	statement, err := h.ClaimRepo.GetStatement(r.Context(), userID, claimantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "No claims from this staff member")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to retrieve reimbursement statement")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Reimbursement statement retrieved successfully", statement)
+}
+
+// applyRequest validates a claim request and applies it to claim, writing the error response and
+// returning false when it is invalid
+func (h *ClaimHandler) applyRequest(w http.ResponseWriter, r *http.Request, userID uint, claim *data.ExpenseClaim) bool {
+	var req ClaimRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return false
+	}
+	if !utils.ValidateRequired(req.Date) {
+		utils.WriteValidationError(w, "Date is required")
+		return false
+	}
+	if !utils.ValidateRequired(req.Description) {
+		utils.WriteValidationError(w, "Description is required")
+		return false
+	}
+	if !utils.ValidatePositiveNumber(req.Amount) {
+		utils.WriteValidationError(w, "Amount must be positive")
+		return false
+	}
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid date format. Use YYYY-MM-DD")
+		return false
+	}
+
+	category := data.ExpenseCategory(req.Category)
+	if category == "" {
+		category = data.ExpenseOther
+	}
+	if category != data.ExpenseEquipment && category != data.ExpenseLabor &&
+		category != data.ExpenseChemicals && category != data.ExpenseFuel &&
+		category != data.ExpenseMaintenance && category != data.ExpenseTransport &&
+		category != data.ExpenseOther {
+		utils.WriteValidationError(w, "Invalid expense category")
+		return false
+	}
+
+	if !checkMineSite(r.Context(), w, h.MineSiteRepo, userID, req.MineSiteID) {
+		return false
+	}
+
+	claim.Date = date
+	claim.Category = category
+	claim.Description = strings.TrimSpace(req.Description)
+	claim.Amount = req.Amount
+	claim.SupplierName = optionalString(req.SupplierName)
+	claim.Notes = optionalString(req.Notes)
+	claim.MineSiteID = req.MineSiteID
+	return true
+}
+
+// requestClaim returns the claim of a request, writing the error response and returning false
+// when it doesn't exist or is another member's and the acting member can't review claims
+func (h *ClaimHandler) requestClaim(w http.ResponseWriter, r *http.Request) (*data.ExpenseClaim, bool) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return nil, false
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid claim ID")
+		return nil, false
+	}
+	claim, err := h.ClaimRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil || !canSeeClaim(r, claim) {
+		utils.WriteNotFoundError(w, "Claim not found")
+		return nil, false
+	}
+	return claim, true
+}
+
+// claimantClaim returns the claim of a request when the acting member submitted it, writing the
+// error response and returning false otherwise
+func (h *ClaimHandler) claimantClaim(w http.ResponseWriter, r *http.Request) (*data.ExpenseClaim, bool) {
+	claim, ok := h.requestClaim(w, r)
+	if !ok {
+		return nil, false
+	}
+	if claim.ClaimantID != middleware.GetActorIDFromRequest(r) {
+		utils.WriteForbiddenError(w, "Only the claimant can change a claim")
+		return nil, false
+	}
+	return claim, true
+}
+
+// canSeeClaim reports whether the acting member may see a claim: their own, or any when they can
+// review claims
+func canSeeClaim(r *http.Request, claim *data.ExpenseClaim) bool {
+	return claim.ClaimantID == middleware.GetActorIDFromRequest(r) || middleware.HasPermission(r, string(data.PermExpenseApprove))
+}
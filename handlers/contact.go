@@ -0,0 +1,477 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"mineral/pkg/vcard"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// maxContactImportSize is the maximum size of an imported vCard or CSV file in bytes
+const maxContactImportSize = 2 << 20
+
+// ContactHandler handles the customer and supplier contact book
+type ContactHandler struct {
+	ContactRepo data.ContactInterface
+	AuditRepo   data.AuditInterface
+}
+
+// NewContactHandler creates a new ContactHandler
+func NewContactHandler(contactRepo data.ContactInterface, auditRepo data.AuditInterface) *ContactHandler {
+	return &ContactHandler{
+		ContactRepo: contactRepo,
+		AuditRepo:   auditRepo,
+	}
+}
+
+// ContactRequest represents a create or update contact request
+type ContactRequest struct {
+	Name    string           `json:"name"`
+	Phone   string           `json:"phone"`
+	Email   *string          `json:"email,omitempty"`
+	Type    data.ContactType `json:"type"`
+	Company *string          `json:"company,omitempty"`
+	Notes   *string          `json:"notes,omitempty"`
+}
+
+// GetAllContacts returns the contact book, optionally only customers or suppliers
+func (h *ContactHandler) GetAllContacts(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	contactType, ok := contactTypeQuery(w, r)
+	if !ok {
+		return
+	}
+
+	contacts, err := h.ContactRepo.GetAll(r.Context(), userID, contactType)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve contacts")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Contacts retrieved successfully", contacts)
+}
+
+// GetContact returns a specific contact
+func (h *ContactHandler) GetContact(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid contact ID")
+		return
+	}
+
+	contact, err := h.ContactRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Contact not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Contact retrieved successfully", contact)
+}
+
+// CreateContact adds a contact to the contact book
+func (h *ContactHandler) CreateContact(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req ContactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	contact := &data.Contact{UserID: userID}
+	if msg := applyContactRequest(contact, &req); msg != "" {
+		utils.WriteValidationError(w, msg)
+		return
+	}
+
+	if _, err := h.ContactRepo.Insert(r.Context(), contact); err != nil {
+		if errors.Is(err, data.ErrDuplicatePhone) {
+			utils.WriteErrorResponse(w, "A contact with this phone number already exists", http.StatusConflict)
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to create contact")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Contact created successfully", contact)
+}
+
+// UpdateContact updates a contact
+func (h *ContactHandler) UpdateContact(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid contact ID")
+		return
+	}
+
+	contact, err := h.ContactRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Contact not found")
+		return
+	}
+
+	var req ContactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if msg := applyContactRequest(contact, &req); msg != "" {
+		utils.WriteValidationError(w, msg)
+		return
+	}
+
+	if err := h.ContactRepo.Update(r.Context(), contact); err != nil {
+		if errors.Is(err, data.ErrDuplicatePhone) {
+			utils.WriteErrorResponse(w, "A contact with this phone number already exists", http.StatusConflict)
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to update contact")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Contact updated successfully", contact)
+}
+
+// DeleteContact removes a contact
+func (h *ContactHandler) DeleteContact(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid contact ID")
+		return
+	}
+
+	if err := h.ContactRepo.Delete(r.Context(), uint(id), userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Contact not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to delete contact")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Contact deleted successfully", nil)
+}
+
+// ImportContacts imports a vCard or CSV file of phone contacts, deduplicating by phone number.
+// The format is taken from the format query parameter or the Content-Type header; contacts
+// without a type in the file get the type query parameter (default customer).
+func (h *ContactHandler) ImportContacts(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	defaultType, ok := contactTypeQuery(w, r)
+	if !ok {
+		return
+	}
+	if defaultType == "" {
+		defaultType = data.ContactCustomer
+	}
+
+	format := contactFormat(r)
+	if format == "" {
+		utils.WriteValidationError(w, "Format must be vcf or csv")
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxContactImportSize)
+	var contacts []*data.Contact
+	var result data.ContactImportResult
+	var err error
+	if format == "csv" {
+		contacts, err = parseContactsCSV(body, defaultType, &result)
+	} else {
+		contacts, err = parseContactsVCard(body, defaultType, &result)
+	}
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			utils.WriteValidationError(w, "File must be 2 MB or smaller")
+			return
+		}
+		utils.WriteValidationError(w, fmt.Sprintf("Invalid %s file: %v", format, err))
+		return
+	}
+
+	imported, err := h.ContactRepo.Import(r.Context(), userID, contacts)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to import contacts")
+		return
+	}
+	imported.Skipped = result.Skipped
+	imported.Errors = result.Errors
+
+	utils.WriteSuccessResponse(w, "Contacts imported successfully", imported)
+}
+
+// ExportContacts downloads the contact book as a vCard (default) or CSV file
+func (h *ContactHandler) ExportContacts(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	contactType, ok := contactTypeQuery(w, r)
+	if !ok {
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "vcf"
+	}
+	if format != "vcf" && format != "csv" {
+		utils.WriteValidationError(w, "Format must be vcf or csv")
+		return
+	}
+
+	contacts, err := h.ContactRepo.GetAll(r.Context(), userID, contactType)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve contacts")
+		return
+	}
+
+	filename := fmt.Sprintf("contacts-%s.%s", time.Now().Format("20060102"), format)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv")
+		writer := csv.NewWriter(w)
+		writer.Write([]string{"name", "phone", "email", "type", "company", "notes"})
+		for _, contact := range contacts {
+			writer.Write([]string{contact.Name, contact.Phone, stringValue(contact.Email), string(contact.Type),
+				stringValue(contact.Company), stringValue(contact.Notes)})
+		}
+		writer.Flush()
+	} else {
+		w.Header().Set("Content-Type", "text/vcard; charset=utf-8")
+		cards := make([]vcard.Card, 0, len(contacts))
+		for _, contact := range contacts {
+			cards = append(cards, vcard.Card{
+				Name:   contact.Name,
+				Phones: []string{contact.Phone},
+				Email:  stringValue(contact.Email),
+				Org:    stringValue(contact.Company),
+				Note:   stringValue(contact.Notes),
+			})
+		}
+		vcard.Write(w, cards)
+	}
+
+	rowCount := len(contacts)
+	entry := &data.AuditLog{
+		Action:   data.AuditExport,
+		Resource: "contacts",
+		RowCount: &rowCount,
+	}
+	if details := r.URL.RawQuery; details != "" {
+		entry.Details = &details
+	}
+	recordAudit(h.AuditRepo, r, entry)
+}
+
+// applyContactRequest validates a contact request and copies it onto a contact, returning a
+// validation message when the request is invalid
+func applyContactRequest(contact *data.Contact, req *ContactRequest) string {
+	name := strings.TrimSpace(req.Name)
+	if !utils.ValidateRequired(name) {
+		return "Name is required"
+	}
+	phone := utils.NormalizePhone(req.Phone)
+	if phone == "" || !utils.ValidatePhone(phone) {
+		return "A valid phone number is required"
+	}
+	if req.Type != data.ContactCustomer && req.Type != data.ContactSupplier {
+		return "Type must be customer or supplier"
+	}
+	if req.Email != nil && *req.Email != "" && !utils.ValidateEmail(*req.Email) {
+		return "Invalid email address"
+	}
+
+	contact.Name = name
+	contact.Phone = phone
+	contact.Type = req.Type
+	contact.Email = optionalString(req.Email)
+	contact.Company = optionalString(req.Company)
+	contact.Notes = optionalString(req.Notes)
+	return ""
+}
+
+// parseContactsVCard reads the contacts of a vCard file, one per card using its first valid
+// phone number. Cards without a name or valid phone number are skipped.
+func parseContactsVCard(r io.Reader, contactType data.ContactType, result *data.ContactImportResult) ([]*data.Contact, error) {
+	cards, err := vcard.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts := make([]*data.Contact, 0, len(cards))
+	for i, card := range cards {
+		req := ContactRequest{Name: card.Name, Type: contactType}
+		for _, phone := range card.Phones {
+			if normalized := utils.NormalizePhone(phone); normalized != "" && utils.ValidatePhone(normalized) {
+				req.Phone = normalized
+				break
+			}
+		}
+		if card.Email != "" && utils.ValidateEmail(card.Email) {
+			req.Email = &card.Email
+		}
+		req.Company = &card.Org
+		req.Notes = &card.Note
+
+		contact := &data.Contact{}
+		if msg := applyContactRequest(contact, &req); msg != "" {
+			result.Skipped++
+			result.Errors = append(result.Errors, fmt.Sprintf("Card %d (%s): %s", i+1, card.Name, msg))
+			continue
+		}
+		contacts = append(contacts, contact)
+	}
+	return contacts, nil
+}
+
+// parseContactsCSV reads the contacts of a CSV file with a header row. The name and phone
+// columns are required; email, type, company and notes are optional.
+func parseContactsCSV(r io.Reader, defaultType data.ContactType, result *data.ContactImportResult) ([]*data.Contact, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, errors.New("missing name column")
+	}
+	if _, ok := columns["phone"]; !ok {
+		return nil, errors.New("missing phone column")
+	}
+	field := func(record []string, column string) string {
+		if i, ok := columns[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var contacts []*data.Contact
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		req := ContactRequest{
+			Name:  field(record, "name"),
+			Phone: field(record, "phone"),
+			Type:  data.ContactType(strings.ToLower(field(record, "type"))),
+		}
+		if req.Type == "" {
+			req.Type = defaultType
+		}
+		email, company, notes := field(record, "email"), field(record, "company"), field(record, "notes")
+		req.Email, req.Company, req.Notes = &email, &company, &notes
+
+		contact := &data.Contact{}
+		if msg := applyContactRequest(contact, &req); msg != "" {
+			result.Skipped++
+			result.Errors = append(result.Errors, fmt.Sprintf("Line %d: %s", line, msg))
+			continue
+		}
+		contacts = append(contacts, contact)
+	}
+	return contacts, nil
+}
+
+// contactTypeQuery reads the optional type query parameter, writing a validation error and
+// returning false when it is not customer or supplier
+func contactTypeQuery(w http.ResponseWriter, r *http.Request) (data.ContactType, bool) {
+	contactType := data.ContactType(r.URL.Query().Get("type"))
+	if contactType != "" && contactType != data.ContactCustomer && contactType != data.ContactSupplier {
+		utils.WriteValidationError(w, "Type must be customer or supplier")
+		return "", false
+	}
+	return contactType, true
+}
+
+// contactFormat returns the import format ("vcf" or "csv") from the format query parameter or
+// the Content-Type header, or "" when it is neither
+func contactFormat(r *http.Request) string {
+	if format := r.URL.Query().Get("format"); format != "" {
+		if format == "vcf" || format == "csv" {
+			return format
+		}
+		return ""
+	}
+	contentType := strings.ToLower(r.Header.Get("Content-Type"))
+	switch {
+	case strings.HasPrefix(contentType, "text/vcard"), strings.HasPrefix(contentType, "text/x-vcard"):
+		return "vcf"
+	case strings.HasPrefix(contentType, "text/csv"):
+		return "csv"
+	}
+	return ""
+}
+
+// optionalString trims a string, returning nil when it is missing or empty
+func optionalString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*s)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
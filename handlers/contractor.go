@@ -0,0 +1,396 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ContractorHandler handles contractor and labor gang requests
+type ContractorHandler struct {
+	ContractorRepo data.ContractorInterface
+}
+
+// NewContractorHandler creates a new ContractorHandler
+func NewContractorHandler(contractorRepo data.ContractorInterface) *ContractorHandler {
+	return &ContractorHandler{
+		ContractorRepo: contractorRepo,
+	}
+}
+
+// ContractorRequest represents a create or update contractor request
+type ContractorRequest struct {
+	Name       string  `json:"name"`
+	LeaderName *string `json:"leader_name,omitempty"`
+	Phone      *string `json:"phone,omitempty"`
+	RateBasis  string  `json:"rate_basis"` // "per_tonne" or "per_day"
+	Rate       float64 `json:"rate"`
+	Notes      *string `json:"notes,omitempty"`
+}
+
+// WorkRecordRequest represents a request to record work done by a contractor
+type WorkRecordRequest struct {
+	Date        string  `json:"date"`
+	Quantity    float64 `json:"quantity"` // tonnes or days depending on rate basis
+	Description *string `json:"description,omitempty"`
+	PitNumber   *string `json:"pit_number,omitempty"`
+	AmountPaid  float64 `json:"amount_paid"`
+}
+
+// GetAllContractors retrieves all contractors for the authenticated user
+func (h *ContractorHandler) GetAllContractors(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	contractors, err := h.ContractorRepo.GetAll(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve contractors")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Contractors retrieved successfully", contractors)
+}
+
+// GetContractor retrieves a specific contractor
+func (h *ContractorHandler) GetContractor(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid contractor ID")
+		return
+	}
+
+	contractor, err := h.ContractorRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Contractor not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Contractor retrieved successfully", contractor)
+}
+
+// CreateContractor creates a new contractor or labor gang
+func (h *ContractorHandler) CreateContractor(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req ContractorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	if !validateContractorRequest(w, &req) {
+		return
+	}
+
+	contractor := &data.Contractor{
+		Name:       req.Name,
+		LeaderName: req.LeaderName,
+		Phone:      req.Phone,
+		RateBasis:  data.RateBasis(req.RateBasis),
+		Rate:       req.Rate,
+		Notes:      req.Notes,
+		UserID:     userID,
+	}
+
+	contractorID, err := h.ContractorRepo.Insert(r.Context(), contractor)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to create contractor")
+		return
+	}
+
+	contractor.ID = contractorID
+	utils.WriteSuccessResponse(w, "Contractor created successfully", contractor)
+}
+
+// UpdateContractor updates an existing contractor
+func (h *ContractorHandler) UpdateContractor(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid contractor ID")
+		return
+	}
+
+	var req ContractorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	if !validateContractorRequest(w, &req) {
+		return
+	}
+
+	contractor, err := h.ContractorRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Contractor not found")
+		return
+	}
+
+	// Rate changes only apply to work recorded from now on
+	contractor.Name = req.Name
+	contractor.LeaderName = req.LeaderName
+	contractor.Phone = req.Phone
+	contractor.RateBasis = data.RateBasis(req.RateBasis)
+	contractor.Rate = req.Rate
+	contractor.Notes = req.Notes
+
+	err = h.ContractorRepo.Update(r.Context(), contractor)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to update contractor")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Contractor updated successfully", contractor)
+}
+
+// DeleteContractor deletes a contractor
+func (h *ContractorHandler) DeleteContractor(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid contractor ID")
+		return
+	}
+
+	err = h.ContractorRepo.Delete(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to delete contractor")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Contractor deleted successfully", nil)
+}
+
+// RecordWork records work done by a contractor and generates the matching labor expense
+func (h *ContractorHandler) RecordWork(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid contractor ID")
+		return
+	}
+
+	var req WorkRecordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	if !utils.ValidateRequired(req.Date) {
+		utils.WriteValidationError(w, "Date is required")
+		return
+	}
+	if !utils.ValidatePositiveNumber(req.Quantity) {
+		utils.WriteValidationError(w, "Quantity must be positive")
+		return
+	}
+	if !utils.ValidateNonNegativeNumber(req.AmountPaid) {
+		utils.WriteValidationError(w, "Amount paid cannot be negative")
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid date format. Use YYYY-MM-DD")
+		return
+	}
+
+	contractor, err := h.ContractorRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Contractor not found")
+		return
+	}
+
+	amount := req.Quantity * contractor.Rate
+	if req.AmountPaid > amount {
+		utils.WriteValidationError(w, "Amount paid cannot exceed the amount earned")
+		return
+	}
+
+	unit := "tonnes"
+	if contractor.RateBasis == data.RatePerDay {
+		unit = "days"
+	}
+
+	paymentStatus := data.PaymentUnpaid
+	if req.AmountPaid >= amount {
+		paymentStatus = data.PaymentPaid
+	} else if req.AmountPaid > 0 {
+		paymentStatus = data.PaymentPartial
+	}
+
+	expense := &data.Expense{
+		Date:          date,
+		Category:      data.ExpenseLabor,
+		Description:   fmt.Sprintf("Contractor work: %.2f %s at %.2f", req.Quantity, unit, contractor.Rate),
+		Amount:        amount,
+		SupplierName:  contractor.Name,
+		PaymentStatus: paymentStatus,
+		AmountPaid:    req.AmountPaid,
+		UserID:        userID,
+	}
+	if contractor.Phone != nil && *contractor.Phone != "" {
+		expense.SupplierContact = contractor.Phone
+	}
+
+	work := &data.WorkRecord{
+		ContractorID: contractor.ID,
+		Date:         date,
+		Quantity:     req.Quantity,
+		RateBasis:    contractor.RateBasis,
+		Rate:         contractor.Rate,
+		Amount:       amount,
+		Description:  req.Description,
+		PitNumber:    req.PitNumber,
+		UserID:       userID,
+	}
+
+	_, err = h.ContractorRepo.RecordWork(r.Context(), work, expense)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to record work")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Work recorded successfully", work)
+}
+
+// GetWork retrieves the work records of a contractor
+func (h *ContractorHandler) GetWork(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid contractor ID")
+		return
+	}
+
+	work, err := h.ContractorRepo.GetWork(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve work records")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Work records retrieved successfully", work)
+}
+
+// DeleteWork deletes a work record together with its labor expense
+func (h *ContractorHandler) DeleteWork(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid contractor ID")
+		return
+	}
+
+	workIDStr := chi.URLParam(r, "workId")
+	workID, err := strconv.ParseUint(workIDStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid work record ID")
+		return
+	}
+
+	err = h.ContractorRepo.DeleteWork(r.Context(), uint(workID), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Work record not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Work record deleted successfully", nil)
+}
+
+// GetStatement retrieves the work done versus paid statement of a contractor
+func (h *ContractorHandler) GetStatement(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid contractor ID")
+		return
+	}
+
+	statement, err := h.ContractorRepo.GetStatement(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Contractor not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Contractor statement retrieved successfully", statement)
+}
+
+// validateContractorRequest validates a contractor request, writing the error response on failure
+func validateContractorRequest(w http.ResponseWriter, req *ContractorRequest) bool {
+	if !utils.ValidateRequired(req.Name) {
+		utils.WriteValidationError(w, "Name is required")
+		return false
+	}
+	if req.Phone != nil && *req.Phone != "" && !utils.ValidatePhone(*req.Phone) {
+		utils.WriteValidationError(w, "Invalid phone number format")
+		return false
+	}
+	rateBasis := data.RateBasis(req.RateBasis)
+	if rateBasis != data.RatePerTonne && rateBasis != data.RatePerDay {
+		utils.WriteValidationError(w, "Rate basis must be either 'per_tonne' or 'per_day'")
+		return false
+	}
+	if !utils.ValidatePositiveNumber(req.Rate) {
+		utils.WriteValidationError(w, "Rate must be positive")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,449 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/tracing"
+	"mineral/pkg/utils"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// CostAllocationHandler handles the rules splitting expenses shared by mine sites, such as
+// generator fuel or security, and the pit profitability reports they are applied in
+type CostAllocationHandler struct {
+	AllocationRepo data.CostAllocationInterface
+	MineSiteRepo   data.MineSiteInterface
+}
+
+// NewCostAllocationHandler creates a new CostAllocationHandler
+func NewCostAllocationHandler(allocationRepo data.CostAllocationInterface, mineSiteRepo data.MineSiteInterface) *CostAllocationHandler {
+	return &CostAllocationHandler{
+		AllocationRepo: allocationRepo,
+		MineSiteRepo:   mineSiteRepo,
+	}
+}
+
+// CostAllocationRequest represents a create or update cost allocation rule request
+type CostAllocationRequest struct {
+	Name     string                     `json:"name"`
+	Category *string                    `json:"category,omitempty"` // every category without a rule of its own when empty
+	Method   string                     `json:"method"`
+	Shares   []data.CostAllocationShare `json:"shares"`
+}
+
+// GetCostAllocations retrieves the cost allocation rules
+func (h *CostAllocationHandler) GetCostAllocations(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	rules, err := h.AllocationRepo.GetAll(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve cost allocation rules")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Cost allocation rules retrieved successfully", rules)
+}
+
+// CreateCostAllocation adds a rule splitting the shared expenses of a category across sites
+func (h *CostAllocationHandler) CreateCostAllocation(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req CostAllocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	rule := &data.CostAllocationRule{UserID: userID}
+	if !h.applyRequest(r.Context(), w, &req, rule) {
+		return
+	}
+
+	id, err := h.AllocationRepo.Insert(r.Context(), rule)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to create cost allocation rule")
+		return
+	}
+
+	rule.ID = id
+	utils.WriteSuccessResponse(w, "Cost allocation rule created successfully", rule)
+}
+
+// UpdateCostAllocation updates a cost allocation rule. Reports are worked out when they are
+// requested, so the change applies to past periods too.
+func (h *CostAllocationHandler) UpdateCostAllocation(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid cost allocation rule ID")
+		return
+	}
+
+	var req CostAllocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	rule, err := h.AllocationRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Cost allocation rule not found")
+		return
+	}
+	if !h.applyRequest(r.Context(), w, &req, rule) {
+		return
+	}
+
+	if err := h.AllocationRepo.Update(r.Context(), rule); err != nil {
+		utils.WriteInternalServerError(w, "Failed to update cost allocation rule")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Cost allocation rule updated successfully", rule)
+}
+
+// DeleteCostAllocation deletes a cost allocation rule
+func (h *CostAllocationHandler) DeleteCostAllocation(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid cost allocation rule ID")
+		return
+	}
+
+	if err := h.AllocationRepo.Delete(r.Context(), uint(id), userID); err != nil {
+		utils.WriteInternalServerError(w, "Failed to delete cost allocation rule")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Cost allocation rule deleted successfully", nil)
+}
+
+// GetSiteProfitability retrieves the income, expenses and profit of each mine site between
+// start_date and end_date, with the shared expenses split across sites by the cost allocation
+// rules
+func (h *CostAllocationHandler) GetSiteProfitability(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	startStr := r.URL.Query().Get("start_date")
+	endStr := r.URL.Query().Get("end_date")
+	if !utils.ValidateRequired(startStr) || !utils.ValidateRequired(endStr) {
+		utils.WriteValidationError(w, "Start date and end date are required")
+		return
+	}
+	start, err := time.Parse("2006-01-02", startStr)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid start date format. Use YYYY-MM-DD")
+		return
+	}
+	end, err := time.Parse("2006-01-02", endStr)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid end date format. Use YYYY-MM-DD")
+		return
+	}
+	if end.Before(start) {
+		utils.WriteValidationError(w, "End date must not be before start date")
+		return
+	}
+
+	report, ok := h.siteProfitability(r.Context(), w, userID, start, end.AddDate(0, 0, 1))
+	if !ok {
+		return
+	}
+	report.StartDate = startStr
+	report.EndDate = endStr
+	utils.WriteSuccessResponse(w, "Site profitability retrieved successfully", report)
+}
+
+// GetPitProfitability retrieves the revenue, direct and allocated costs, profit and margin of each
+// pit (mine site) in a preset period or between start_date and end_date, with the shared
+// expenses split across pits by the cost allocation rules
+func (h *CostAllocationHandler) GetPitProfitability(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	period, start, end, ok := parseSummaryPeriod(w, r, time.Now())
+	if !ok {
+		return
+	}
+	if start == nil || end == nil {
+		utils.WriteValidationError(w, "A period or start and end dates are required")
+		return
+	}
+
+	sites, ok := h.siteProfitability(r.Context(), w, userID, *start, *end)
+	if !ok {
+		return
+	}
+	report := pitProfitability(sites)
+	report.Period = period
+	report.StartDate = start.Format("2006-01-02")
+	report.EndDate = end.AddDate(0, 0, -1).Format("2006-01-02")
+	utils.WriteSuccessResponse(w, "Pit profitability retrieved successfully", report)
+}
+
+// siteProfitability works out the profitability of each mine site in the period [start, end). It
+// writes the error response and returns false when the records can't be read.
+func (h *CostAllocationHandler) siteProfitability(ctx context.Context, w http.ResponseWriter, userID uint, start, end time.Time) (*data.SiteProfitability, bool) {
+	ctx, span := tracing.Start(ctx, "site profitability")
+	defer span.End()
+
+	rules, err := h.AllocationRepo.GetAll(ctx, userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve cost allocation rules")
+		return nil, false
+	}
+	sites, err := h.MineSiteRepo.GetAll(ctx, userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve mine sites")
+		return nil, false
+	}
+	income, err := h.AllocationRepo.GetSiteIncome(ctx, userID, start, end)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve site income")
+		return nil, false
+	}
+	expenses, err := h.AllocationRepo.GetSiteExpenses(ctx, userID, start, end)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve site expenses")
+		return nil, false
+	}
+	return allocateSiteCosts(rules, sites, income, expenses), true
+}
+
+// applyRequest validates a cost allocation rule request and applies it to rule, writing the error
+// response and returning false when it is invalid. A category can only have one rule.
+func (h *CostAllocationHandler) applyRequest(ctx context.Context, w http.ResponseWriter, req *CostAllocationRequest, rule *data.CostAllocationRule) bool {
+	name := strings.TrimSpace(req.Name)
+	if !utils.ValidateRequired(name) {
+		utils.WriteValidationError(w, "Name is required")
+		return false
+	}
+	var category *data.ExpenseCategory
+	if req.Category != nil && *req.Category != "" {
+		c := data.ExpenseCategory(*req.Category)
+		if !slices.Contains(data.ExpenseCategories, c) {
+			utils.WriteValidationError(w, "Invalid expense category")
+			return false
+		}
+		category = &c
+	}
+	method := data.CostAllocationMethod(req.Method)
+	if method != data.AllocateByPercentage && method != data.AllocateByProduction {
+		utils.WriteValidationError(w, "Method must be percentage or production")
+		return false
+	}
+	if len(req.Shares) == 0 {
+		utils.WriteValidationError(w, "At least one site is required")
+		return false
+	}
+
+	total := 0.0
+	seen := map[uint]bool{}
+	for i, share := range req.Shares {
+		if seen[share.MineSiteID] {
+			utils.WriteValidationError(w, "Each site can only be listed once")
+			return false
+		}
+		seen[share.MineSiteID] = true
+		if _, err := h.MineSiteRepo.GetOne(ctx, share.MineSiteID, rule.UserID); err != nil {
+			utils.WriteValidationError(w, "Mine site not found")
+			return false
+		}
+		if method == data.AllocateByProduction {
+			req.Shares[i].Percent = 0
+			continue
+		}
+		if !utils.ValidatePositiveNumber(share.Percent) {
+			utils.WriteValidationError(w, "Percents must be positive")
+			return false
+		}
+		total += share.Percent
+	}
+	if method == data.AllocateByPercentage && math.Abs(total-100) > 0.01 {
+		utils.WriteValidationError(w, "Percents must add up to 100")
+		return false
+	}
+
+	rules, err := h.AllocationRepo.GetAll(ctx, rule.UserID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to check cost allocation rules")
+		return false
+	}
+	for _, other := range rules {
+		if other.ID != rule.ID && sameCategory(other.Category, category) {
+			utils.WriteValidationError(w, "This category already has a cost allocation rule")
+			return false
+		}
+	}
+
+	rule.Name = name
+	rule.Category = category
+	rule.Method = method
+	rule.Shares = req.Shares
+	return true
+}
+
+// sameCategory reports whether two optional categories are both unset or the same
+func sameCategory(a, b *data.ExpenseCategory) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// allocateSiteCosts works out the profitability of each mine site from its income and expenses,
+// splitting the expenses not assigned to a site by the rule for their category, or the rule
+// without a category. Sites that have since been deleted are kept with the records assigned to
+// them.
+func allocateSiteCosts(rules []*data.CostAllocationRule, sites []*data.MineSiteInfo, income []*data.SiteIncome, expenses []*data.SiteExpense) *data.SiteProfitability {
+	report := &data.SiteProfitability{Sites: []*data.SiteProfit{}}
+	profits := map[uint]*data.SiteProfit{}
+	site := func(id uint) *data.SiteProfit {
+		profit, ok := profits[id]
+		if !ok {
+			profit = &data.SiteProfit{MineSiteID: id}
+			profits[id] = profit
+			report.Sites = append(report.Sites, profit)
+		}
+		return profit
+	}
+	for _, s := range sites {
+		site(s.ID).Name = s.Name
+	}
+
+	mineralSales := map[uint]float64{}
+	for _, row := range income {
+		if row.MineSiteID == nil {
+			report.UnassignedIncome += row.Income
+			continue
+		}
+		profit := site(*row.MineSiteID)
+		profit.Income += row.Income
+		profit.MineralSales += row.MineralSales
+		mineralSales[*row.MineSiteID] += row.MineralSales
+	}
+
+	var fallback *data.CostAllocationRule
+	byCategory := map[data.ExpenseCategory]*data.CostAllocationRule{}
+	for _, rule := range rules {
+		if rule.Category == nil {
+			fallback = rule
+		} else {
+			byCategory[*rule.Category] = rule
+		}
+	}
+
+	for _, row := range expenses {
+		if row.MineSiteID != nil {
+			site(*row.MineSiteID).DirectExpenses += row.Amount
+			continue
+		}
+		rule, ok := byCategory[row.Category]
+		if !ok {
+			rule = fallback
+		}
+		if rule == nil {
+			report.Unallocated += row.Amount
+			continue
+		}
+
+		weights := make([]float64, len(rule.Shares))
+		totalWeight := 0.0
+		for i, share := range rule.Shares {
+			weights[i] = share.Percent
+			if rule.Method == data.AllocateByProduction {
+				weights[i] = mineralSales[share.MineSiteID]
+			}
+			totalWeight += weights[i]
+		}
+		if totalWeight <= 0 {
+			report.Unallocated += row.Amount
+			continue
+		}
+		// The last site takes what rounding leaves, so the parts add up to the expense
+		remaining := row.Amount
+		for i, share := range rule.Shares {
+			part := math.Round(row.Amount*weights[i]/totalWeight*100) / 100
+			if i == len(rule.Shares)-1 {
+				part = remaining
+			}
+			remaining -= part
+			site(share.MineSiteID).SharedExpenses += part
+		}
+	}
+
+	for _, profit := range report.Sites {
+		profit.Income = math.Round(profit.Income*100) / 100
+		profit.MineralSales = math.Round(profit.MineralSales*100) / 100
+		profit.DirectExpenses = math.Round(profit.DirectExpenses*100) / 100
+		profit.SharedExpenses = math.Round(profit.SharedExpenses*100) / 100
+		profit.Profit = math.Round((profit.Income-profit.DirectExpenses-profit.SharedExpenses)*100) / 100
+	}
+	report.UnassignedIncome = math.Round(report.UnassignedIncome*100) / 100
+	report.Unallocated = math.Round(report.Unallocated*100) / 100
+	return report
+}
+
+// pitProfitability reports the profitability of each mine site as that of a pit, in order of site,
+// with its margin
+func pitProfitability(sites *data.SiteProfitability) *data.PitProfitability {
+	report := &data.PitProfitability{
+		Pits:              make([]*data.PitProfit, 0, len(sites.Sites)),
+		UnassignedRevenue: sites.UnassignedIncome,
+		Unallocated:       sites.Unallocated,
+	}
+	for _, site := range sites.Sites {
+		pit := &data.PitProfit{
+			MineSiteID:     site.MineSiteID,
+			Name:           site.Name,
+			Revenue:        site.Income,
+			MineralSales:   site.MineralSales,
+			DirectCosts:    site.DirectExpenses,
+			AllocatedCosts: site.SharedExpenses,
+			Profit:         site.Profit,
+		}
+		if pit.Revenue > 0 {
+			pit.Margin = math.Round(pit.Profit/pit.Revenue*1000) / 10
+		}
+		report.Pits = append(report.Pits, pit)
+	}
+	slices.SortFunc(report.Pits, func(a, b *data.PitProfit) int {
+		return int(a.MineSiteID) - int(b.MineSiteID)
+	})
+	return report
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"mineral/data"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestAllocateSiteCosts(t *testing.T) {
+	north, south := "North pit", "South pit"
+	northID, southID := uint(1), uint(2)
+	sites := []*data.MineSiteInfo{
+		{Model: gorm.Model{ID: 1}, Name: &north},
+		{Model: gorm.Model{ID: 2}, Name: &south},
+	}
+	fuel, labor := data.ExpenseFuel, data.ExpenseLabor
+	rules := []*data.CostAllocationRule{
+		{Name: "Generator", Category: &fuel, Method: data.AllocateByProduction,
+			Shares: []data.CostAllocationShare{{MineSiteID: 1}, {MineSiteID: 2}}},
+		{Name: "Security", Category: &labor, Method: data.AllocateByPercentage,
+			Shares: []data.CostAllocationShare{{MineSiteID: 1, Percent: 66.67}, {MineSiteID: 2, Percent: 33.33}}},
+	}
+	income := []*data.SiteIncome{
+		{MineSiteID: &northID, Income: 320000, MineralSales: 300000},
+		{MineSiteID: &southID, Income: 100000, MineralSales: 100000},
+		{Income: 5000},
+	}
+	expenses := []*data.SiteExpense{
+		{MineSiteID: &northID, Category: data.ExpenseChemicals, Amount: 20000},
+		{Category: data.ExpenseFuel, Amount: 100000},
+		{Category: data.ExpenseLabor, Amount: 60000},
+		{Category: data.ExpenseOther, Amount: 5000}, // no rule and no fallback
+	}
+
+	report := allocateSiteCosts(rules, sites, income, expenses)
+	if report.UnassignedIncome != 5000 || report.Unallocated != 5000 {
+		t.Fatalf("unassigned income %g, unallocated %g; want 5000, 5000", report.UnassignedIncome, report.Unallocated)
+	}
+	want := []data.SiteProfit{
+		{MineSiteID: 1, Income: 320000, MineralSales: 300000, DirectExpenses: 20000, SharedExpenses: 115002, Profit: 184998},
+		{MineSiteID: 2, Income: 100000, MineralSales: 100000, DirectExpenses: 0, SharedExpenses: 44998, Profit: 55002},
+	}
+	if len(report.Sites) != len(want) {
+		t.Fatalf("got %d sites, want %d", len(report.Sites), len(want))
+	}
+	for i, site := range report.Sites {
+		if site.MineSiteID != want[i].MineSiteID || site.Income != want[i].Income || site.MineralSales != want[i].MineralSales ||
+			site.DirectExpenses != want[i].DirectExpenses || site.SharedExpenses != want[i].SharedExpenses || site.Profit != want[i].Profit {
+			t.Errorf("site %d = %+v, want %+v", site.MineSiteID, *site, want[i])
+		}
+	}
+
+	// With a rule for every other category, shared expenses split by production are left
+	// unallocated when none of its sites sold minerals
+	rules = append(rules, &data.CostAllocationRule{Name: "Everything else", Method: data.AllocateByPercentage,
+		Shares: []data.CostAllocationShare{{MineSiteID: 2, Percent: 100}}})
+	income = income[2:]
+	report = allocateSiteCosts(rules, sites, income, expenses)
+	if report.Unallocated != 100000 || report.Sites[1].SharedExpenses != 24998 {
+		t.Errorf("unallocated %g, south shared %g; want 100000, 24998", report.Unallocated, report.Sites[1].SharedExpenses)
+	}
+}
+
+func TestPitProfitability(t *testing.T) {
+	north := "North pit"
+	sites := &data.SiteProfitability{
+		Sites: []*data.SiteProfit{
+			{MineSiteID: 2, Income: 100000, MineralSales: 100000, SharedExpenses: 44998, Profit: 55002},
+			{MineSiteID: 1, Name: &north, Income: 320000, MineralSales: 300000, DirectExpenses: 20000, SharedExpenses: 115002, Profit: 184998},
+			{MineSiteID: 3, DirectExpenses: 1500, Profit: -1500}, // no sales, so no margin
+		},
+		UnassignedIncome: 5000,
+		Unallocated:      2500,
+	}
+
+	report := pitProfitability(sites)
+	if report.UnassignedRevenue != 5000 || report.Unallocated != 2500 {
+		t.Fatalf("unassigned revenue %g, unallocated %g; want 5000, 2500", report.UnassignedRevenue, report.Unallocated)
+	}
+	want := []data.PitProfit{
+		{MineSiteID: 1, Name: &north, Revenue: 320000, MineralSales: 300000, DirectCosts: 20000, AllocatedCosts: 115002, Profit: 184998, Margin: 57.8},
+		{MineSiteID: 2, Revenue: 100000, MineralSales: 100000, AllocatedCosts: 44998, Profit: 55002, Margin: 55},
+		{MineSiteID: 3, DirectCosts: 1500, Profit: -1500},
+	}
+	if len(report.Pits) != len(want) {
+		t.Fatalf("got %d pits, want %d", len(report.Pits), len(want))
+	}
+	for i, pit := range report.Pits {
+		if *pit != want[i] {
+			t.Errorf("pit %d = %+v, want %+v", i, *pit, want[i])
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// CreditLimitHandler handles customer credit limit requests
+type CreditLimitHandler struct {
+	CreditLimitRepo data.CreditLimitInterface
+}
+
+// NewCreditLimitHandler creates a new CreditLimitHandler
+func NewCreditLimitHandler(creditLimitRepo data.CreditLimitInterface) *CreditLimitHandler {
+	return &CreditLimitHandler{
+		CreditLimitRepo: creditLimitRepo,
+	}
+}
+
+// CreditLimitRequest represents a create or update customer credit limit request
+type CreditLimitRequest struct {
+	CustomerName string  `json:"customer_name"`
+	CreditLimit  float64 `json:"credit_limit"`
+}
+
+// GetCreditLimits returns the customer credit limits with each customer's outstanding balance
+func (h *CreditLimitHandler) GetCreditLimits(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	limits, err := h.CreditLimitRepo.GetAll(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve credit limits")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Credit limits retrieved successfully", limits)
+}
+
+// SaveCreditLimit creates or replaces the credit limit of a customer (owner/manager)
+func (h *CreditLimitHandler) SaveCreditLimit(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+	if !canManageCreditLimits(w, r) {
+		return
+	}
+
+	var req CreditLimitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	customerName := strings.TrimSpace(req.CustomerName)
+	if !utils.ValidateRequired(customerName) {
+		utils.WriteValidationError(w, "Customer name is required")
+		return
+	}
+	if !utils.ValidateNonNegativeNumber(req.CreditLimit) {
+		utils.WriteValidationError(w, "Credit limit cannot be negative")
+		return
+	}
+
+	limit := &data.CustomerCreditLimit{
+		CustomerName: customerName,
+		CreditLimit:  req.CreditLimit,
+		UserID:       userID,
+	}
+	if err := h.CreditLimitRepo.Save(r.Context(), limit); err != nil {
+		utils.WriteInternalServerError(w, "Failed to save credit limit")
+		return
+	}
+
+	outstanding, err := h.CreditLimitRepo.GetOutstanding(r.Context(), userID, customerName)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to calculate outstanding balance")
+		return
+	}
+	limit.Outstanding = outstanding
+
+	utils.WriteSuccessResponse(w, "Credit limit saved successfully", limit)
+}
+
+// DeleteCreditLimit removes the credit limit of a customer (owner/manager)
+func (h *CreditLimitHandler) DeleteCreditLimit(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+	if !canManageCreditLimits(w, r) {
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid credit limit ID")
+		return
+	}
+
+	if err := h.CreditLimitRepo.Delete(r.Context(), uint(id), userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Credit limit not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to delete credit limit")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Credit limit deleted successfully", nil)
+}
+
+// canManageCreditLimits writes a forbidden response and returns false unless the acting user
+// may manage customer risk
+func canManageCreditLimits(w http.ResponseWriter, r *http.Request) bool {
+	return requirePermission(w, r, data.PermRiskManage, "You do not have permission to change credit limits")
+}
+
+// checkCreditLimit checks whether a new sale pushes its customer's outstanding balance past
+// their credit limit. Depending on the organization's credit limit mode it returns a warning,
+// or writes a conflict response and returns false.
+func checkCreditLimit(ctx context.Context, w http.ResponseWriter, creditLimitRepo data.CreditLimitInterface, settingsRepo data.SettingsInterface, income *data.Income) (*data.CreditLimitWarning, bool) {
+	if income.PaymentStatus == data.PaymentPaid || income.AmountDue <= 0 {
+		return nil, true
+	}
+
+	limit, err := creditLimitRepo.GetByCustomer(ctx, income.UserID, income.CustomerName)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, true
+		}
+		utils.WriteInternalServerError(w, "Failed to check credit limit")
+		return nil, false
+	}
+	outstanding, err := creditLimitRepo.GetOutstanding(ctx, income.UserID, income.CustomerName)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to check credit limit")
+		return nil, false
+	}
+	newBalance := outstanding + income.AmountDue
+	if newBalance <= limit.CreditLimit {
+		return nil, true
+	}
+
+	settings, err := settingsRepo.GetByUserID(ctx, income.UserID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve settings")
+		return nil, false
+	}
+	message := fmt.Sprintf("This sale brings %s's outstanding balance to %s, over the credit limit of %s",
+		income.CustomerName, utils.FormatMoney(settings.DefaultCurrency, newBalance),
+		utils.FormatMoney(settings.DefaultCurrency, limit.CreditLimit))
+	if settings.CreditLimitMode == data.CreditLimitBlock {
+		utils.WriteErrorResponse(w, message, http.StatusConflict)
+		return nil, false
+	}
+
+	return &data.CreditLimitWarning{
+		CustomerName: income.CustomerName,
+		CreditLimit:  limit.CreditLimit,
+		Outstanding:  outstanding,
+		NewBalance:   newBalance,
+		Message:      message,
+	}, true
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// CreditNoteHandler handles credit notes issued against sales
+type CreditNoteHandler struct {
+	CreditNoteRepo data.CreditNoteInterface
+	SettingsRepo   data.SettingsInterface
+}
+
+// NewCreditNoteHandler creates a new CreditNoteHandler
+func NewCreditNoteHandler(creditNoteRepo data.CreditNoteInterface, settingsRepo data.SettingsInterface) *CreditNoteHandler {
+	return &CreditNoteHandler{
+		CreditNoteRepo: creditNoteRepo,
+		SettingsRepo:   settingsRepo,
+	}
+}
+
+// CreditNoteRequest represents a request to issue a credit note against a sale
+type CreditNoteRequest struct {
+	Amount float64 `json:"amount"`
+	Reason string  `json:"reason"`
+}
+
+// GetAllCreditNotes retrieves all credit notes of the authenticated user
+func (h *CreditNoteHandler) GetAllCreditNotes(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	notes, err := h.CreditNoteRepo.GetAll(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve credit notes")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Credit notes retrieved successfully", notes)
+}
+
+// GetCreditNote retrieves a credit note
+func (h *CreditNoteHandler) GetCreditNote(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid credit note ID")
+		return
+	}
+
+	note, err := h.CreditNoteRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Credit note not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to retrieve credit note")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Credit note retrieved successfully", note)
+}
+
+// GetIncomeCreditNotes retrieves the credit notes issued against a sale
+func (h *CreditNoteHandler) GetIncomeCreditNotes(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid income ID")
+		return
+	}
+
+	notes, err := h.CreditNoteRepo.GetByIncome(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve credit notes")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Credit notes retrieved successfully", notes)
+}
+
+// CreateCreditNote issues a numbered credit note against a sale, reducing its total and what the
+// customer owes by the amount credited
+func (h *CreditNoteHandler) CreateCreditNote(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid income ID")
+		return
+	}
+
+	var req CreditNoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if !utils.ValidatePositiveNumber(req.Amount) {
+		utils.WriteValidationError(w, "Amount must be greater than 0")
+		return
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if !utils.ValidateRequired(req.Reason) {
+		utils.WriteValidationError(w, "Reason is required")
+		return
+	}
+	if len(req.Reason) > 255 {
+		utils.WriteValidationError(w, "Reason must be at most 255 characters")
+		return
+	}
+
+	settings, err := h.SettingsRepo.GetByUserID(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve settings")
+		return
+	}
+
+	note := &data.CreditNote{
+		IncomeID:   uint(id),
+		Amount:     req.Amount,
+		Reason:     req.Reason,
+		Currency:   settings.DefaultCurrency,
+		IssuedAt:   time.Now(),
+		IssuedByID: middleware.GetActorIDFromRequest(r),
+		UserID:     userID,
+	}
+	if _, err := h.CreditNoteRepo.Insert(r.Context(), note); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Income record not found")
+			return
+		}
+		if errors.Is(err, data.ErrCreditExceedsDue) {
+			utils.WriteValidationError(w, "Credit note cannot be for more than the amount due on the sale")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to issue credit note")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Credit note issued successfully", note)
+}
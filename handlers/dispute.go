@@ -0,0 +1,308 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// OpenDisputeRequest represents a request to dispute an accepted shared sale
+type OpenDisputeRequest struct {
+	Reason string `json:"reason"`
+}
+
+// DisputeMessageRequest represents a message to the other side of a dispute
+type DisputeMessageRequest struct {
+	Message string `json:"message"`
+}
+
+// DisputeProposalRequest represents a proposed resolution of a dispute
+type DisputeProposalRequest struct {
+	Action  data.DisputeAction `json:"action"`           // "adjust_amount" or "void"
+	Amount  *float64           `json:"amount,omitempty"` // new total amount, required for adjust_amount
+	Message string             `json:"message,omitempty"`
+}
+
+// GetDisputes returns the disputes of a shared sale with their full history (seller or buyer)
+func (h *TradeHandler) GetDisputes(w http.ResponseWriter, r *http.Request) {
+	sale, _, ok := h.tradeParty(w, r)
+	if !ok {
+		return
+	}
+
+	disputes, err := h.TradeRepo.GetDisputes(r.Context(), sale.ID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve disputes")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Disputes retrieved successfully", disputes)
+}
+
+// OpenDispute disputes an accepted shared sale (seller or buyer)
+func (h *TradeHandler) OpenDispute(w http.ResponseWriter, r *http.Request) {
+	sale, side, ok := h.tradeParty(w, r)
+	if !ok {
+		return
+	}
+
+	var req OpenDisputeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if !utils.ValidateRequired(reason) {
+		utils.WriteValidationError(w, "Reason is required")
+		return
+	}
+
+	dispute := &data.TradeDispute{
+		SharedSaleID: sale.ID,
+		OpenedBy:     side,
+		Reason:       reason,
+	}
+	event := &data.TradeDisputeEvent{
+		Kind:    data.DisputeEventOpened,
+		Side:    side,
+		Message: &reason,
+		ActorID: middleware.GetActorIDFromRequest(r),
+	}
+	if err := h.TradeRepo.OpenDispute(r.Context(), dispute, event); err != nil {
+		writeDisputeError(w, err, "Failed to open dispute")
+		return
+	}
+
+	h.auditDispute(r, sale, data.AuditDisputeOpened, fmt.Sprintf("%s opened a dispute: %s", side, reason))
+	h.notifyDispute(r.Context(), sale, side, event, "Sale disputed",
+		fmt.Sprintf("The %s disputed the %s sale of %s: %s", side, sale.MineralType, sale.Date.Format("2006-01-02"), reason))
+
+	utils.WriteSuccessResponse(w, "Dispute opened successfully", dispute)
+}
+
+// AddDisputeMessage adds a message to the open dispute of a shared sale (seller or buyer)
+func (h *TradeHandler) AddDisputeMessage(w http.ResponseWriter, r *http.Request) {
+	sale, side, ok := h.tradeParty(w, r)
+	if !ok {
+		return
+	}
+
+	var req DisputeMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	message := strings.TrimSpace(req.Message)
+	if !utils.ValidateRequired(message) {
+		utils.WriteValidationError(w, "Message is required")
+		return
+	}
+
+	event := &data.TradeDisputeEvent{
+		Kind:    data.DisputeEventMessage,
+		Side:    side,
+		Message: &message,
+		ActorID: middleware.GetActorIDFromRequest(r),
+	}
+	if _, err := h.TradeRepo.AddDisputeEvent(r.Context(), sale.ID, event); err != nil {
+		writeDisputeError(w, err, "Failed to add message")
+		return
+	}
+
+	h.notifyDispute(r.Context(), sale, side, event, "New dispute message", fmt.Sprintf("The %s wrote: %s", side, message))
+
+	utils.WriteSuccessResponse(w, "Message added successfully", event)
+}
+
+// ProposeResolution proposes adjusting the amount of a disputed shared sale or voiding it. The
+// other side accepts the proposal to apply it to both books (seller or buyer).
+func (h *TradeHandler) ProposeResolution(w http.ResponseWriter, r *http.Request) {
+	sale, side, ok := h.tradeParty(w, r)
+	if !ok {
+		return
+	}
+
+	var req DisputeProposalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	switch req.Action {
+	case data.DisputeAdjustAmount:
+		if req.Amount == nil || !utils.ValidatePositiveNumber(*req.Amount) {
+			utils.WriteValidationError(w, "Amount must be positive")
+			return
+		}
+	case data.DisputeVoid:
+		req.Amount = nil
+	default:
+		utils.WriteValidationError(w, "Action must be adjust_amount or void")
+		return
+	}
+
+	event := &data.TradeDisputeEvent{
+		Kind:    data.DisputeEventProposal,
+		Side:    side,
+		Action:  &req.Action,
+		Amount:  req.Amount,
+		ActorID: middleware.GetActorIDFromRequest(r),
+	}
+	if message := strings.TrimSpace(req.Message); message != "" {
+		event.Message = &message
+	}
+	dispute, err := h.TradeRepo.AddDisputeEvent(r.Context(), sale.ID, event)
+	if err != nil {
+		writeDisputeError(w, err, "Failed to propose resolution")
+		return
+	}
+
+	proposal := "voiding the sale"
+	if req.Action == data.DisputeAdjustAmount {
+		proposal = "changing the total to " + utils.FormatMoney(sale.Currency, *req.Amount)
+	}
+	h.auditDispute(r, sale, data.AuditDisputeProposed, fmt.Sprintf("%s proposed %s", side, proposal))
+	h.notifyDispute(r.Context(), sale, side, event, "Dispute resolution proposed",
+		fmt.Sprintf("The %s proposed %s. Accept it to apply it to your records.", side, proposal))
+
+	utils.WriteSuccessResponse(w, "Resolution proposed successfully", dispute)
+}
+
+// AcceptResolution accepts the other side's proposal, adjusting or voiding the income and
+// expense on both books (seller or buyer)
+func (h *TradeHandler) AcceptResolution(w http.ResponseWriter, r *http.Request) {
+	h.resolveDispute(w, r, data.DisputeEventAccepted)
+}
+
+// WithdrawDispute closes a dispute without changes (side that opened it)
+func (h *TradeHandler) WithdrawDispute(w http.ResponseWriter, r *http.Request) {
+	h.resolveDispute(w, r, data.DisputeEventWithdrawn)
+}
+
+// resolveDispute closes the open dispute of a shared sale by accepting or withdrawing
+func (h *TradeHandler) resolveDispute(w http.ResponseWriter, r *http.Request, kind data.DisputeEventKind) {
+	sale, side, ok := h.tradeParty(w, r)
+	if !ok {
+		return
+	}
+
+	event := &data.TradeDisputeEvent{
+		Kind:    kind,
+		Side:    side,
+		ActorID: middleware.GetActorIDFromRequest(r),
+	}
+	dispute, err := h.TradeRepo.ResolveDispute(r.Context(), sale.ID, event)
+	if err != nil {
+		writeDisputeError(w, err, "Failed to resolve dispute")
+		return
+	}
+
+	if kind == data.DisputeEventWithdrawn {
+		h.auditDispute(r, sale, data.AuditDisputeWithdrawn, fmt.Sprintf("%s withdrew the dispute", side))
+		h.notifyDispute(r.Context(), sale, side, event, "Dispute withdrawn", fmt.Sprintf("The %s withdrew the dispute", side))
+		utils.WriteSuccessResponse(w, "Dispute withdrawn successfully", dispute)
+		return
+	}
+
+	resolution := "voided the sale"
+	if *dispute.Resolution == data.DisputeAdjustAmount {
+		resolution = "changed the total to " + utils.FormatMoney(sale.Currency, *dispute.ProposedAmount)
+	}
+	h.auditDispute(r, sale, data.AuditDisputeResolved, fmt.Sprintf("%s accepted the proposal and %s", side, resolution))
+	h.notifyDispute(r.Context(), sale, side, event, "Dispute resolved",
+		fmt.Sprintf("The %s accepted your proposal and %s", side, resolution))
+
+	utils.WriteSuccessResponse(w, "Dispute resolved successfully", dispute)
+}
+
+// tradeParty loads the shared sale in the URL and the user's side of it. It writes the error
+// response and returns false when the user is neither its seller nor its buyer.
+func (h *TradeHandler) tradeParty(w http.ResponseWriter, r *http.Request) (*data.SharedSale, data.TradeSide, bool) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return nil, "", false
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid shared sale ID")
+		return nil, "", false
+	}
+
+	sale, err := h.TradeRepo.GetSharedSale(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Shared sale not found")
+		return nil, "", false
+	}
+
+	if sale.SellerID == userID {
+		return sale, data.TradeSeller, true
+	}
+	return sale, data.TradeBuyer, true
+}
+
+// auditDispute records a dispute action in the audit logs of both the seller's and the buyer's
+// books
+func (h *TradeHandler) auditDispute(r *http.Request, sale *data.SharedSale, action data.AuditAction, details string) {
+	saleID := sale.ID
+	for _, userID := range []uint{sale.SellerID, sale.BuyerID} {
+		entry := &data.AuditLog{
+			Action:     action,
+			Resource:   "shared_sale",
+			ResourceID: &saleID,
+			Details:    &details,
+			ActorID:    middleware.GetActorIDFromRequest(r),
+			IPAddress:  middleware.GetClientIP(r),
+			UserID:     userID,
+		}
+		if err := h.AuditRepo.Insert(r.Context(), entry); err != nil {
+			log.Printf("Failed to record audit log entry %s for shared sale %d: %v", action, sale.ID, err)
+		}
+	}
+}
+
+// notifyDispute notifies the other side of a shared sale of a dispute event
+func (h *TradeHandler) notifyDispute(ctx context.Context, sale *data.SharedSale, side data.TradeSide, event *data.TradeDisputeEvent, title, message string) {
+	recipient := sale.SellerID
+	if side == data.TradeSeller {
+		recipient = sale.BuyerID
+	}
+	saleID := sale.ID
+	h.NotificationRepo.Insert(ctx, &data.Notification{
+		Kind:        data.NotificationTradeDispute,
+		Title:       title,
+		Message:     message,
+		ReferenceID: &saleID,
+		Key:         fmt.Sprintf("%s:%d", data.NotificationTradeDispute, event.ID),
+		UserID:      recipient,
+	})
+}
+
+// writeDisputeError writes the response for an error of a dispute operation
+func writeDisputeError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, data.ErrNotDisputable):
+		utils.WriteErrorResponse(w, "Only accepted purchases can be disputed", http.StatusConflict)
+	case errors.Is(err, data.ErrNoOpenDispute):
+		utils.WriteErrorResponse(w, "This sale has no open dispute", http.StatusConflict)
+	case errors.Is(err, data.ErrNoProposal):
+		utils.WriteErrorResponse(w, "No resolution has been proposed yet", http.StatusConflict)
+	case errors.Is(err, data.ErrOwnProposal):
+		utils.WriteErrorResponse(w, "The other side must accept your proposal", http.StatusConflict)
+	case errors.Is(err, data.ErrNotDisputeOwner):
+		utils.WriteForbiddenError(w, "Only the side that opened the dispute can withdraw it")
+	default:
+		utils.WriteInternalServerError(w, message)
+	}
+}
@@ -0,0 +1,395 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png" // logos may be uploaded as PNG
+	"io"
+	"log"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/pdf"
+	"mineral/pkg/storage"
+	"mineral/pkg/utils"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	// maxLogoSize is the maximum size of an uploaded logo in bytes
+	maxLogoSize = 2 << 20
+	// maxLogoPixels is the largest width or height a logo is stored at; larger logos are scaled down
+	maxLogoPixels = 600
+	// maxFooterLength is the maximum length of a template footer
+	maxFooterLength = 500
+)
+
+// logoContentTypes are the image formats accepted for logos, which are stored as JPEG
+var logoContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+}
+
+// documentLabels holds the translated labels of invoice and receipt PDFs by language. Labels
+// without a translation are printed in English.
+var documentLabels = map[string]map[string]string{
+	"fr": {
+		"RECEIPT": "REÇU", "INVOICE": "FACTURE", "STATEMENT": "RELEVÉ",
+		"Receipt No.": "Reçu n°", "Invoice No.": "Facture n°", "Date": "Date",
+		"Received from": "Reçu de", "Customer": "Client", "Invoice": "Facture", "For": "Objet",
+		"Amount received": "Montant reçu", "Balance due": "Solde dû", "Quantity": "Quantité",
+		"Price per unit": "Prix unitaire", "Total": "Total", "Amount paid": "Montant payé",
+		"Amount due": "Montant dû", "Status": "Statut", "Confirmed by": "Confirmé par",
+		"Chain-of-custody seal:": "Sceau de traçabilité :", "Verify the seal at:": "Vérifier le sceau :",
+		"Verify this receipt at:": "Vérifier ce reçu :",
+	},
+}
+
+// DocumentTemplateHandler handles the templates of invoice and receipt PDFs
+type DocumentTemplateHandler struct {
+	TemplateRepo data.DocumentTemplateInterface
+	Store        storage.Store
+}
+
+// NewDocumentTemplateHandler creates a new DocumentTemplateHandler
+func NewDocumentTemplateHandler(templateRepo data.DocumentTemplateInterface, store storage.Store) *DocumentTemplateHandler {
+	return &DocumentTemplateHandler{
+		TemplateRepo: templateRepo,
+		Store:        store,
+	}
+}
+
+// DocumentTemplateRequest represents a document template update; omitted fields are left unchanged
+type DocumentTemplateRequest struct {
+	Language     *string  `json:"language,omitempty"`
+	FooterText   *string  `json:"footer_text,omitempty"` // empty removes the footer
+	HiddenFields []string `json:"hidden_fields,omitempty"`
+}
+
+// DocumentLogoRequest represents a logo upload
+type DocumentLogoRequest struct {
+	Data string `json:"data"` // base64 JPEG or PNG image, optionally as a data URL
+}
+
+// GetDocumentTemplates retrieves the invoice and receipt templates of the authenticated user
+func (h *DocumentTemplateHandler) GetDocumentTemplates(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	templates := make([]*data.DocumentTemplate, 0, 2)
+	for _, kind := range []data.DocumentKind{data.DocumentInvoice, data.DocumentReceipt} {
+		template, err := h.TemplateRepo.Get(r.Context(), userID, kind)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to retrieve document templates")
+			return
+		}
+		templates = append(templates, template)
+	}
+
+	utils.WriteSuccessResponse(w, "Document templates retrieved successfully", templates)
+}
+
+// GetDocumentTemplate retrieves the invoice or receipt template of the authenticated user
+func (h *DocumentTemplateHandler) GetDocumentTemplate(w http.ResponseWriter, r *http.Request) {
+	template, ok := h.template(w, r)
+	if !ok {
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Document template retrieved successfully", template)
+}
+
+// UpdateDocumentTemplate updates the language, footer and hidden fields of the invoice or
+// receipt template
+func (h *DocumentTemplateHandler) UpdateDocumentTemplate(w http.ResponseWriter, r *http.Request) {
+	template, ok := h.template(w, r)
+	if !ok {
+		return
+	}
+
+	var req DocumentTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if req.Language != nil {
+		language := strings.ToLower(strings.TrimSpace(*req.Language))
+		if _, ok := referenceLabels[language]; !ok && language != defaultLanguage {
+			utils.WriteValidationError(w, "Language must be en or fr")
+			return
+		}
+		template.Language = language
+	}
+	if req.FooterText != nil {
+		if len(*req.FooterText) > maxFooterLength {
+			utils.WriteValidationError(w, fmt.Sprintf("Footer text must be at most %d characters", maxFooterLength))
+			return
+		}
+		template.FooterText = optionalString(req.FooterText)
+	}
+	if req.HiddenFields != nil {
+		allowed := data.TemplateFields[template.Kind]
+		hidden := make([]string, 0, len(req.HiddenFields))
+		for _, field := range req.HiddenFields {
+			if !slices.Contains(allowed, field) {
+				utils.WriteValidationError(w, "Hidden fields must be among "+strings.Join(allowed, ", "))
+				return
+			}
+			if !slices.Contains(hidden, field) {
+				hidden = append(hidden, field)
+			}
+		}
+		template.HiddenFields = hidden
+	}
+
+	if err := h.TemplateRepo.Save(r.Context(), template); err != nil {
+		utils.WriteInternalServerError(w, "Failed to update document template")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Document template updated successfully", template)
+}
+
+// UploadDocumentLogo sets the logo printed at the top of invoice or receipt PDFs. Logos are
+// scaled down to at most 600 pixels and stored as JPEG.
+func (h *DocumentTemplateHandler) UploadDocumentLogo(w http.ResponseWriter, r *http.Request) {
+	template, ok := h.template(w, r)
+	if !ok {
+		return
+	}
+
+	var req DocumentLogoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if !utils.ValidateRequired(req.Data) {
+		utils.WriteValidationError(w, "Data is required")
+		return
+	}
+	file, _, ok := decodeUpload(w, req.Data, maxLogoSize, "Logo", "a JPEG or PNG image", logoContentTypes)
+	if !ok {
+		return
+	}
+	logo, width, height, err := encodeLogo(file)
+	if err != nil {
+		utils.WriteValidationError(w, "Logo must be a readable JPEG or PNG image")
+		return
+	}
+
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		utils.WriteInternalServerError(w, "Failed to save logo")
+		return
+	}
+	key := fmt.Sprintf("%d/templates/%s/%s", template.UserID, template.Kind, hex.EncodeToString(random))
+	if err := h.Store.Upload(key, bytes.NewReader(logo), int64(len(logo))); err != nil {
+		log.Printf("Failed to store logo %s: %v", key, err)
+		utils.WriteInternalServerError(w, "Failed to save logo")
+		return
+	}
+
+	previous := template.LogoKey
+	template.LogoKey = &key
+	template.LogoWidth = width
+	template.LogoHeight = height
+	if err := h.TemplateRepo.Save(r.Context(), template); err != nil {
+		h.removeLogo(key)
+		utils.WriteInternalServerError(w, "Failed to save logo")
+		return
+	}
+	if previous != nil {
+		h.removeLogo(*previous)
+	}
+	template.HasLogo = true
+
+	utils.WriteSuccessResponse(w, "Logo uploaded successfully", template)
+}
+
+// GetDocumentLogo downloads the logo of the invoice or receipt template
+func (h *DocumentTemplateHandler) GetDocumentLogo(w http.ResponseWriter, r *http.Request) {
+	template, ok := h.template(w, r)
+	if !ok {
+		return
+	}
+	if template.LogoKey == nil {
+		utils.WriteNotFoundError(w, "The template has no logo")
+		return
+	}
+
+	file, err := h.Store.Open(*template.LogoKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			utils.WriteNotFoundError(w, "The template has no logo")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to retrieve logo")
+		return
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", "image/jpeg")
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, file)
+}
+
+// DeleteDocumentLogo removes the logo of the invoice or receipt template
+func (h *DocumentTemplateHandler) DeleteDocumentLogo(w http.ResponseWriter, r *http.Request) {
+	template, ok := h.template(w, r)
+	if !ok {
+		return
+	}
+	if template.LogoKey == nil {
+		utils.WriteNotFoundError(w, "The template has no logo")
+		return
+	}
+
+	key := *template.LogoKey
+	template.LogoKey = nil
+	template.LogoWidth = 0
+	template.LogoHeight = 0
+	if err := h.TemplateRepo.Save(r.Context(), template); err != nil {
+		utils.WriteInternalServerError(w, "Failed to remove logo")
+		return
+	}
+	h.removeLogo(key)
+	template.HasLogo = false
+
+	utils.WriteSuccessResponse(w, "Logo removed successfully", template)
+}
+
+// template returns the template of the kind in the URL for the authenticated user, writing the
+// error response and returning false when it fails
+func (h *DocumentTemplateHandler) template(w http.ResponseWriter, r *http.Request) (*data.DocumentTemplate, bool) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return nil, false
+	}
+
+	kind := data.DocumentKind(chi.URLParam(r, "kind"))
+	if _, ok := data.TemplateFields[kind]; !ok {
+		utils.WriteNotFoundError(w, "Templates are available for invoice and receipt documents")
+		return nil, false
+	}
+
+	template, err := h.TemplateRepo.Get(r.Context(), userID, kind)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve document template")
+		return nil, false
+	}
+	return template, true
+}
+
+// removeLogo deletes a stored logo, logging failures since the template no longer uses it
+func (h *DocumentTemplateHandler) removeLogo(key string) {
+	if err := h.Store.Delete(key); err != nil {
+		log.Printf("Failed to delete logo %s: %v", key, err)
+	}
+}
+
+// encodeLogo decodes an uploaded JPEG or PNG image and returns it as a JPEG on a white
+// background, scaled down to at most maxLogoPixels wide and high, with its size in pixels
+func encodeLogo(file []byte) ([]byte, int, int, error) {
+	src, _, err := image.Decode(bytes.NewReader(file))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return nil, 0, 0, errors.New("empty image")
+	}
+	if width > maxLogoPixels || height > maxLogoPixels {
+		scale := min(float64(maxLogoPixels)/float64(width), float64(maxLogoPixels)/float64(height))
+		width, height = max(1, int(float64(width)*scale)), max(1, int(float64(height)*scale))
+	}
+
+	// Nearest-neighbour scaling, blending transparent pixels onto white
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			r, g, b, a := src.At(bounds.Min.X+x*bounds.Dx()/width, bounds.Min.Y+y*bounds.Dy()/height).RGBA()
+			white := 0xffff - a
+			dst.Set(x, y, color.RGBA64{R: uint16(r + white), G: uint16(g + white), B: uint16(b + white), A: 0xffff})
+		}
+	}
+
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, dst, &jpeg.Options{Quality: 90}); err != nil {
+		return nil, 0, 0, err
+	}
+	return out.Bytes(), width, height, nil
+}
+
+// documentTemplate loads the template of a user's documents of a kind and its logo for a PDF.
+// The default template is used when templateRepo is nil or the template can't be loaded, and
+// the PDF is printed without a logo that can't be read.
+func documentTemplate(ctx context.Context, templateRepo data.DocumentTemplateInterface, store storage.Store, userID uint, kind data.DocumentKind) (*data.DocumentTemplate, []byte) {
+	if templateRepo == nil {
+		return data.DefaultDocumentTemplate(userID, kind), nil
+	}
+	template, err := templateRepo.Get(ctx, userID, kind)
+	if err != nil {
+		log.Printf("Failed to load the %s template of user %d: %v", kind, userID, err)
+		return data.DefaultDocumentTemplate(userID, kind), nil
+	}
+	if template.LogoKey == nil || store == nil {
+		return template, nil
+	}
+
+	file, err := store.Open(*template.LogoKey)
+	if err != nil {
+		log.Printf("Failed to open logo %s: %v", *template.LogoKey, err)
+		return template, nil
+	}
+	defer file.Close()
+	logo, err := io.ReadAll(io.LimitReader(file, maxLogoSize))
+	if err != nil {
+		log.Printf("Failed to read logo %s: %v", *template.LogoKey, err)
+		return template, nil
+	}
+	return template, logo
+}
+
+// newTemplatedPDF starts a PDF with the template's logo, if any
+func newTemplatedPDF(template *data.DocumentTemplate, logo []byte) *pdf.Document {
+	doc := pdf.New()
+	if logo != nil {
+		doc.Image(logo, template.LogoWidth, template.LogoHeight, 160, 60)
+	}
+	return doc
+}
+
+// writeTemplateFooter ends a PDF with the template's footer text, if any
+func writeTemplateFooter(doc *pdf.Document, template *data.DocumentTemplate) {
+	if template.FooterText == nil {
+		return
+	}
+	doc.Space()
+	for _, line := range strings.Split(*template.FooterText, "\n") {
+		doc.Small(line)
+	}
+}
+
+// documentLabel returns a label of invoice and receipt PDFs in a language, falling back to English
+func documentLabel(language, label string) string {
+	if translated, ok := documentLabels[language][label]; ok {
+		return translated
+	}
+	return label
+}
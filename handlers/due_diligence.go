@@ -0,0 +1,429 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/pdf"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// DueDiligenceHandler handles the OECD Annex II due-diligence self-assessments of mine sites
+type DueDiligenceHandler struct {
+	DueDiligenceRepo data.DueDiligenceInterface
+	MineSiteRepo     data.MineSiteInterface
+	AttachmentRepo   data.AttachmentInterface
+	UserRepo         data.UserInterface
+}
+
+// NewDueDiligenceHandler creates a new DueDiligenceHandler
+func NewDueDiligenceHandler(dueDiligenceRepo data.DueDiligenceInterface, mineSiteRepo data.MineSiteInterface, attachmentRepo data.AttachmentInterface, userRepo data.UserInterface) *DueDiligenceHandler {
+	return &DueDiligenceHandler{
+		DueDiligenceRepo: dueDiligenceRepo,
+		MineSiteRepo:     mineSiteRepo,
+		AttachmentRepo:   attachmentRepo,
+		UserRepo:         userRepo,
+	}
+}
+
+// DueDiligenceResponseRequest represents the answer to a checklist question
+type DueDiligenceResponseRequest struct {
+	QuestionID string  `json:"question_id"`
+	Answer     string  `json:"answer"` // "yes", "no" or "not_applicable"
+	Notes      *string `json:"notes,omitempty"`
+}
+
+// DueDiligenceRequest represents a create or update due-diligence assessment request. Responses
+// replace the assessment's answers; questions may be left unanswered until it is completed.
+type DueDiligenceRequest struct {
+	MineSiteID  uint                          `json:"mine_site_id"`
+	PeriodStart string                        `json:"period_start"` // YYYY-MM-DD
+	PeriodEnd   string                        `json:"period_end"`   // YYYY-MM-DD
+	Notes       *string                       `json:"notes,omitempty"`
+	Responses   []DueDiligenceResponseRequest `json:"responses"`
+}
+
+// GetQuestions returns the Annex II risk checklist
+func (h *DueDiligenceHandler) GetQuestions(w http.ResponseWriter, r *http.Request) {
+	utils.WriteSuccessResponse(w, "Due-diligence questions retrieved successfully", data.DueDiligenceQuestions)
+}
+
+// GetAssessments returns the due-diligence assessments of the user, or of one site with site_id
+func (h *DueDiligenceHandler) GetAssessments(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	siteID, ok := parseSiteFilter(w, r)
+	if !ok {
+		return
+	}
+
+	assessments, err := h.DueDiligenceRepo.GetAll(r.Context(), userID, siteID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve due-diligence assessments")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Due-diligence assessments retrieved successfully", assessments)
+}
+
+// GetAssessment returns a due-diligence assessment with its responses
+func (h *DueDiligenceHandler) GetAssessment(w http.ResponseWriter, r *http.Request) {
+	_, assessment, ok := h.assessment(w, r)
+	if !ok {
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Due-diligence assessment retrieved successfully", assessment)
+}
+
+// CreateAssessment starts a draft due-diligence assessment of a site for a period
+func (h *DueDiligenceHandler) CreateAssessment(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req DueDiligenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	assessment := &data.DueDiligenceAssessment{UserID: userID}
+	if !h.applyRequest(r.Context(), w, userID, assessment, &req) {
+		return
+	}
+
+	if _, err := h.DueDiligenceRepo.Insert(r.Context(), assessment); err != nil {
+		utils.WriteInternalServerError(w, "Failed to create due-diligence assessment")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Due-diligence assessment created successfully", assessment)
+}
+
+// UpdateAssessment updates a draft due-diligence assessment and replaces its responses
+func (h *DueDiligenceHandler) UpdateAssessment(w http.ResponseWriter, r *http.Request) {
+	userID, assessment, ok := h.assessment(w, r)
+	if !ok {
+		return
+	}
+	if assessment.Status != data.DueDiligenceDraft {
+		utils.WriteValidationError(w, "Completed assessments can no longer be changed")
+		return
+	}
+
+	var req DueDiligenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if !h.applyRequest(r.Context(), w, userID, assessment, &req) {
+		return
+	}
+
+	if err := h.DueDiligenceRepo.Update(r.Context(), assessment); err != nil {
+		if errors.Is(err, data.ErrAssessmentCompleted) {
+			utils.WriteValidationError(w, "Completed assessments can no longer be changed")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to update due-diligence assessment")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Due-diligence assessment updated successfully", assessment)
+}
+
+// CompleteAssessment completes a due-diligence assessment once every question is answered and
+// every red flag explained, after which it can no longer be changed
+func (h *DueDiligenceHandler) CompleteAssessment(w http.ResponseWriter, r *http.Request) {
+	userID, assessment, ok := h.assessment(w, r)
+	if !ok {
+		return
+	}
+	if assessment.Status != data.DueDiligenceDraft {
+		utils.WriteValidationError(w, "Assessment is already completed")
+		return
+	}
+
+	answered := map[string]*data.DueDiligenceResponse{}
+	for i := range assessment.Responses {
+		answered[assessment.Responses[i].QuestionID] = &assessment.Responses[i]
+	}
+	for _, question := range data.DueDiligenceQuestions {
+		response, ok := answered[question.ID]
+		if !ok {
+			utils.WriteValidationError(w, fmt.Sprintf("Question %s must be answered before completing", question.ID))
+			return
+		}
+		if response.Answer == data.DueDiligenceYes && (response.Notes == nil || strings.TrimSpace(*response.Notes) == "") {
+			utils.WriteValidationError(w, fmt.Sprintf("Question %s is a red flag and needs notes on how it is being addressed", question.ID))
+			return
+		}
+	}
+
+	if err := h.DueDiligenceRepo.Complete(r.Context(), assessment.ID, userID, middleware.GetActorIDFromRequest(r)); err != nil {
+		if errors.Is(err, data.ErrAssessmentCompleted) {
+			utils.WriteValidationError(w, "Assessment is already completed")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to complete due-diligence assessment")
+		return
+	}
+
+	assessment, err := h.DueDiligenceRepo.GetOne(r.Context(), assessment.ID, userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve due-diligence assessment")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Due-diligence assessment completed successfully", assessment)
+}
+
+// DeleteAssessment deletes a draft due-diligence assessment; completed ones are kept as a record
+func (h *DueDiligenceHandler) DeleteAssessment(w http.ResponseWriter, r *http.Request) {
+	userID, assessment, ok := h.assessment(w, r)
+	if !ok {
+		return
+	}
+	if assessment.Status != data.DueDiligenceDraft {
+		utils.WriteValidationError(w, "Completed assessments cannot be deleted")
+		return
+	}
+
+	if err := h.DueDiligenceRepo.Delete(r.Context(), assessment.ID, userID); err != nil {
+		utils.WriteInternalServerError(w, "Failed to delete due-diligence assessment")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Due-diligence assessment deleted successfully", nil)
+}
+
+// DownloadSummary renders the due-diligence summary of an assessment as a PDF for exporters.
+// Drafts are watermarked.
+func (h *DueDiligenceHandler) DownloadSummary(w http.ResponseWriter, r *http.Request) {
+	userID, assessment, ok := h.assessment(w, r)
+	if !ok {
+		return
+	}
+
+	site, err := h.MineSiteRepo.GetOne(r.Context(), assessment.MineSiteID, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.WriteInternalServerError(w, "Failed to retrieve mine site")
+		return
+	}
+	attachments, err := h.AttachmentRepo.GetForRecord(r.Context(), userID, data.AttachmentRecordDueDiligence, assessment.ID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve attachments")
+		return
+	}
+
+	answered := map[string]*data.DueDiligenceResponse{}
+	for i := range assessment.Responses {
+		answered[assessment.Responses[i].QuestionID] = &assessment.Responses[i]
+	}
+
+	doc := pdf.New()
+	if assessment.Status == data.DueDiligenceDraft {
+		doc.Watermark("DRAFT")
+	}
+	doc.Title("DUE DILIGENCE SUMMARY")
+	doc.Heading(h.operatorName(r.Context(), userID))
+	doc.Text("Self-assessment against the OECD Due Diligence Guidance, Annex II")
+	doc.Space()
+	if site != nil {
+		if site.Name != nil {
+			doc.Row("Site", *site.Name)
+		}
+		doc.Row("Location", site.Location)
+		if site.License != nil {
+			doc.Row("Licence No.", *site.License)
+		}
+	}
+	doc.Row("Period", assessment.PeriodStart.Format("2006-01-02")+" to "+assessment.PeriodEnd.Format("2006-01-02"))
+	if assessment.CompletedAt != nil {
+		doc.Row("Completed", assessment.CompletedAt.Format("2006-01-02"))
+	} else {
+		doc.Row("Status", "Draft, not completed")
+	}
+	if assessment.RedFlags == 0 {
+		doc.Row("Result", "No red flags identified")
+	} else {
+		doc.Row("Result", fmt.Sprintf("%d red flag(s) identified", assessment.RedFlags))
+	}
+	if assessment.Notes != nil {
+		doc.Space()
+		doc.Paragraph(*assessment.Notes)
+	}
+
+	var category data.DueDiligenceCategory
+	for i, question := range data.DueDiligenceQuestions {
+		if question.Category != category {
+			category = question.Category
+			doc.Space()
+			doc.Heading(dueDiligenceCategoryTitles[category])
+		}
+		doc.Paragraph(fmt.Sprintf("%d. %s", i+1, question.Text))
+		response, ok := answered[question.ID]
+		if !ok {
+			doc.Row("Answer", "Not answered")
+			continue
+		}
+		doc.Row("Answer", dueDiligenceAnswerLabels[response.Answer])
+		if response.Notes != nil {
+			doc.Paragraph("Notes: " + *response.Notes)
+		}
+	}
+
+	if len(attachments) > 0 {
+		doc.Space()
+		doc.Heading("Supporting documents")
+		for _, attachment := range attachments {
+			doc.Text(attachment.FileName)
+		}
+	}
+	doc.Space()
+	doc.Space()
+	doc.Small(fmt.Sprintf("Generated on %s from the operator's own self-assessment.", time.Now().Format("2006-01-02")))
+
+	filename := fmt.Sprintf("due-diligence-%d-%s.pdf", assessment.ID, assessment.PeriodEnd.Format("2006-01-02"))
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write(doc.Bytes())
+}
+
+// dueDiligenceCategoryTitles are the section headings of the summary
+var dueDiligenceCategoryTitles = map[data.DueDiligenceCategory]string{
+	data.DueDiligenceSeriousAbuses:   "Serious abuses",
+	data.DueDiligenceArmedGroups:     "Support to non-state armed groups",
+	data.DueDiligenceSecurityForces:  "Public or private security forces",
+	data.DueDiligenceBribery:         "Bribery and misrepresentation of origin",
+	data.DueDiligenceMoneyLaundering: "Money laundering",
+	data.DueDiligenceTaxes:           "Taxes, fees and royalties",
+}
+
+// dueDiligenceAnswerLabels are how answers read in the summary
+var dueDiligenceAnswerLabels = map[data.DueDiligenceAnswer]string{
+	data.DueDiligenceYes:           "Yes - red flag",
+	data.DueDiligenceNo:            "No",
+	data.DueDiligenceNotApplicable: "Not applicable",
+}
+
+// applyRequest validates a create or update request and applies it to an assessment. It writes
+// the error response and returns false when the request is invalid.
+func (h *DueDiligenceHandler) applyRequest(ctx context.Context, w http.ResponseWriter, userID uint, assessment *data.DueDiligenceAssessment, req *DueDiligenceRequest) bool {
+	if req.MineSiteID == 0 {
+		utils.WriteValidationError(w, "Mine site ID is required")
+		return false
+	}
+	if !checkMineSite(ctx, w, h.MineSiteRepo, userID, &req.MineSiteID) {
+		return false
+	}
+	if !utils.ValidateRequired(req.PeriodStart) || !utils.ValidateRequired(req.PeriodEnd) {
+		utils.WriteValidationError(w, "Period start and period end are required")
+		return false
+	}
+	start, err := time.Parse("2006-01-02", req.PeriodStart)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid period start format. Use YYYY-MM-DD")
+		return false
+	}
+	end, err := time.Parse("2006-01-02", req.PeriodEnd)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid period end format. Use YYYY-MM-DD")
+		return false
+	}
+	if end.Before(start) {
+		utils.WriteValidationError(w, "Period end must not be before period start")
+		return false
+	}
+
+	known := map[string]bool{}
+	for _, question := range data.DueDiligenceQuestions {
+		known[question.ID] = true
+	}
+	seen := map[string]bool{}
+	responses := make([]data.DueDiligenceResponse, 0, len(req.Responses))
+	redFlags := 0
+	for _, response := range req.Responses {
+		if !known[response.QuestionID] {
+			utils.WriteValidationError(w, fmt.Sprintf("Unknown question %q", response.QuestionID))
+			return false
+		}
+		if seen[response.QuestionID] {
+			utils.WriteValidationError(w, fmt.Sprintf("Question %s is answered more than once", response.QuestionID))
+			return false
+		}
+		seen[response.QuestionID] = true
+
+		answer := data.DueDiligenceAnswer(response.Answer)
+		switch answer {
+		case data.DueDiligenceYes:
+			redFlags++
+		case data.DueDiligenceNo, data.DueDiligenceNotApplicable:
+		default:
+			utils.WriteValidationError(w, "Answer must be yes, no or not_applicable")
+			return false
+		}
+		responses = append(responses, data.DueDiligenceResponse{
+			QuestionID: response.QuestionID,
+			Answer:     answer,
+			Notes:      response.Notes,
+		})
+	}
+
+	assessment.MineSiteID = req.MineSiteID
+	assessment.PeriodStart = start
+	assessment.PeriodEnd = end
+	assessment.Notes = req.Notes
+	assessment.Responses = responses
+	assessment.RedFlags = redFlags
+	return true
+}
+
+// assessment returns the user and the due-diligence assessment of a request, writing the error
+// response and returning false when it doesn't exist
+func (h *DueDiligenceHandler) assessment(w http.ResponseWriter, r *http.Request) (uint, *data.DueDiligenceAssessment, bool) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return 0, nil, false
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid assessment ID")
+		return 0, nil, false
+	}
+	assessment, err := h.DueDiligenceRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Due-diligence assessment not found")
+		return 0, nil, false
+	}
+	return userID, assessment, true
+}
+
+// operatorName returns the name of the operator the assessment is of
+func (h *DueDiligenceHandler) operatorName(ctx context.Context, userID uint) string {
+	user, err := h.UserRepo.GetOne(ctx, userID)
+	if err != nil {
+		return ""
+	}
+	return user.Name
+}
@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// maxDunningSteps is the maximum number of steps in a dunning schedule
+const maxDunningSteps = 10
+
+// DunningHandler handles payment reminder schedule requests
+type DunningHandler struct {
+	DunningRepo data.DunningInterface
+	IncomeRepo  data.IncomeInterface
+}
+
+// NewDunningHandler creates a new DunningHandler
+func NewDunningHandler(dunningRepo data.DunningInterface, incomeRepo data.IncomeInterface) *DunningHandler {
+	return &DunningHandler{
+		DunningRepo: dunningRepo,
+		IncomeRepo:  incomeRepo,
+	}
+}
+
+// DunningStepRequest represents a step in a dunning schedule request
+type DunningStepRequest struct {
+	DayOffset int     `json:"day_offset"`
+	Action    string  `json:"action"`
+	Message   *string `json:"message,omitempty"`
+}
+
+// DunningScheduleRequest represents a create or update dunning schedule request
+type DunningScheduleRequest struct {
+	Name         string               `json:"name"`
+	CustomerName *string              `json:"customer_name,omitempty"` // omit for the default schedule
+	Active       *bool                `json:"active,omitempty"`
+	Steps        []DunningStepRequest `json:"steps"`
+}
+
+// GetSchedules returns all dunning schedules for the authenticated user
+func (h *DunningHandler) GetSchedules(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	schedules, err := h.DunningRepo.GetSchedules(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve dunning schedules")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Dunning schedules retrieved successfully", schedules)
+}
+
+// GetSchedule returns a specific dunning schedule
+func (h *DunningHandler) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid schedule ID")
+		return
+	}
+
+	schedule, err := h.DunningRepo.GetSchedule(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Dunning schedule not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Dunning schedule retrieved successfully", schedule)
+}
+
+// CreateSchedule creates a dunning schedule for a customer or the default schedule
+func (h *DunningHandler) CreateSchedule(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req DunningScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	schedule := &data.DunningSchedule{Active: true, UserID: userID}
+	if !applyDunningScheduleRequest(w, schedule, &req) {
+		return
+	}
+
+	if _, err := h.DunningRepo.CreateSchedule(r.Context(), schedule); err != nil {
+		writeDunningScheduleError(w, err, "Failed to create dunning schedule")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Dunning schedule created successfully", schedule)
+}
+
+// UpdateSchedule updates a dunning schedule and replaces its steps
+func (h *DunningHandler) UpdateSchedule(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid schedule ID")
+		return
+	}
+
+	var req DunningScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	schedule, err := h.DunningRepo.GetSchedule(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Dunning schedule not found")
+		return
+	}
+	if !applyDunningScheduleRequest(w, schedule, &req) {
+		return
+	}
+
+	if err := h.DunningRepo.UpdateSchedule(r.Context(), schedule); err != nil {
+		writeDunningScheduleError(w, err, "Failed to update dunning schedule")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Dunning schedule updated successfully", schedule)
+}
+
+// DeleteSchedule deletes a dunning schedule
+func (h *DunningHandler) DeleteSchedule(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid schedule ID")
+		return
+	}
+
+	if err := h.DunningRepo.DeleteSchedule(r.Context(), uint(id), userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Dunning schedule not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to delete dunning schedule")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Dunning schedule deleted successfully", nil)
+}
+
+// GetIncomeDunningHistory returns the reminders sent for an invoice
+func (h *DunningHandler) GetIncomeDunningHistory(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid income ID")
+		return
+	}
+
+	if _, err := h.IncomeRepo.GetOne(r.Context(), uint(id), userID); err != nil {
+		utils.WriteNotFoundError(w, "Income record not found")
+		return
+	}
+
+	events, err := h.DunningRepo.GetHistory(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve dunning history")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Dunning history retrieved successfully", events)
+}
+
+// applyDunningScheduleRequest validates a schedule request and applies it to the schedule,
+// writing the error response and returning false when invalid
+func applyDunningScheduleRequest(w http.ResponseWriter, schedule *data.DunningSchedule, req *DunningScheduleRequest) bool {
+	name := strings.TrimSpace(req.Name)
+	if !utils.ValidateRequired(name) {
+		utils.WriteValidationError(w, "Name is required")
+		return false
+	}
+	if len(req.Steps) == 0 || len(req.Steps) > maxDunningSteps {
+		utils.WriteValidationError(w, "A schedule must have between 1 and 10 steps")
+		return false
+	}
+
+	steps := make([]data.DunningStep, 0, len(req.Steps))
+	seen := map[int]bool{}
+	for _, stepReq := range req.Steps {
+		if stepReq.DayOffset < 0 || stepReq.DayOffset > 365 {
+			utils.WriteValidationError(w, "Step days must be between 0 and 365")
+			return false
+		}
+		if seen[stepReq.DayOffset] {
+			utils.WriteValidationError(w, "Each step must be on a different day")
+			return false
+		}
+		seen[stepReq.DayOffset] = true
+
+		action := data.DunningAction(stepReq.Action)
+		if action != data.DunningSMS && action != data.DunningEmail && action != data.DunningCall {
+			utils.WriteValidationError(w, "Step action must be sms, email or call")
+			return false
+		}
+		steps = append(steps, data.DunningStep{
+			DayOffset: stepReq.DayOffset,
+			Action:    action,
+			Message:   stepReq.Message,
+		})
+	}
+
+	schedule.Name = name
+	schedule.CustomerName = nil
+	if req.CustomerName != nil && strings.TrimSpace(*req.CustomerName) != "" {
+		customerName := strings.TrimSpace(*req.CustomerName)
+		schedule.CustomerName = &customerName
+	}
+	if req.Active != nil {
+		schedule.Active = *req.Active
+	}
+	schedule.Steps = steps
+	return true
+}
+
+// writeDunningScheduleError writes the response for a failed schedule save
+func writeDunningScheduleError(w http.ResponseWriter, err error, message string) {
+	if errors.Is(err, data.ErrDunningScheduleExists) {
+		utils.WriteErrorResponse(w, "A dunning schedule already exists for this customer", http.StatusConflict)
+		return
+	}
+	utils.WriteInternalServerError(w, message)
+}
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"encoding/json"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// EmployeeHandler handles employee-related requests
+type EmployeeHandler struct {
+	EmployeeRepo data.EmployeeInterface
+}
+
+// NewEmployeeHandler creates a new EmployeeHandler
+func NewEmployeeHandler(employeeRepo data.EmployeeInterface) *EmployeeHandler {
+	return &EmployeeHandler{
+		EmployeeRepo: employeeRepo,
+	}
+}
+
+// EmployeeRequest represents a create or update employee request
+type EmployeeRequest struct {
+	Name       string  `json:"name"`
+	Phone      *string `json:"phone,omitempty"`
+	Position   *string `json:"position,omitempty"`
+	HourlyRate float64 `json:"hourly_rate"`
+	PitNumber  *string `json:"pit_number,omitempty"`
+	Active     *bool   `json:"active,omitempty"`
+}
+
+// GetAllEmployees retrieves all employees for the authenticated user
+func (h *EmployeeHandler) GetAllEmployees(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	employees, err := h.EmployeeRepo.GetAll(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve employees")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Employees retrieved successfully", employees)
+}
+
+// GetEmployee retrieves a specific employee
+func (h *EmployeeHandler) GetEmployee(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid employee ID")
+		return
+	}
+
+	employee, err := h.EmployeeRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Employee not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Employee retrieved successfully", employee)
+}
+
+// CreateEmployee creates a new employee
+func (h *EmployeeHandler) CreateEmployee(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req EmployeeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	if !validateEmployeeRequest(w, &req) {
+		return
+	}
+
+	employee := &data.Employee{
+		Name:       req.Name,
+		Phone:      req.Phone,
+		Position:   req.Position,
+		HourlyRate: req.HourlyRate,
+		PitNumber:  req.PitNumber,
+		Active:     true,
+		UserID:     userID,
+	}
+	if req.Active != nil {
+		employee.Active = *req.Active
+	}
+
+	employeeID, err := h.EmployeeRepo.Insert(r.Context(), employee)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to create employee")
+		return
+	}
+
+	employee.ID = employeeID
+	utils.WriteSuccessResponse(w, "Employee created successfully", employee)
+}
+
+// UpdateEmployee updates an existing employee
+func (h *EmployeeHandler) UpdateEmployee(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid employee ID")
+		return
+	}
+
+	var req EmployeeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	if !validateEmployeeRequest(w, &req) {
+		return
+	}
+
+	employee, err := h.EmployeeRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Employee not found")
+		return
+	}
+
+	employee.Name = req.Name
+	employee.Phone = req.Phone
+	employee.Position = req.Position
+	employee.HourlyRate = req.HourlyRate
+	employee.PitNumber = req.PitNumber
+	if req.Active != nil {
+		employee.Active = *req.Active
+	}
+
+	err = h.EmployeeRepo.Update(r.Context(), employee)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to update employee")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Employee updated successfully", employee)
+}
+
+// DeleteEmployee deletes an employee
+func (h *EmployeeHandler) DeleteEmployee(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid employee ID")
+		return
+	}
+
+	err = h.EmployeeRepo.Delete(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to delete employee")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Employee deleted successfully", nil)
+}
+
+// validateEmployeeRequest validates an employee request, writing the error response on failure
+func validateEmployeeRequest(w http.ResponseWriter, req *EmployeeRequest) bool {
+	if !utils.ValidateRequired(req.Name) {
+		utils.WriteValidationError(w, "Name is required")
+		return false
+	}
+	if req.Phone != nil && *req.Phone != "" && !utils.ValidatePhone(*req.Phone) {
+		utils.WriteValidationError(w, "Invalid phone number format")
+		return false
+	}
+	if !utils.ValidateNonNegativeNumber(req.HourlyRate) {
+		utils.WriteValidationError(w, "Hourly rate cannot be negative")
+		return false
+	}
+	return true
+}
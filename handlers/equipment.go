@@ -0,0 +1,438 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// maxUsefulLifeMonths is the longest useful life equipment can be depreciated over
+const maxUsefulLifeMonths = 600
+
+// EquipmentHandler handles the equipment asset register and its depreciation
+type EquipmentHandler struct {
+	EquipmentRepo data.EquipmentInterface
+	ExpenseRepo   data.ExpenseInterface
+
+	// MineSiteRepo checks the mine sites equipment is assigned to; equipment can't be assigned to
+	// a site when it is nil
+	MineSiteRepo data.MineSiteInterface
+
+	// AuditRepo records disposals in the audit log when set
+	AuditRepo data.AuditInterface
+}
+
+// NewEquipmentHandler creates a new EquipmentHandler
+func NewEquipmentHandler(equipmentRepo data.EquipmentInterface, expenseRepo data.ExpenseInterface) *EquipmentHandler {
+	return &EquipmentHandler{
+		EquipmentRepo: equipmentRepo,
+		ExpenseRepo:   expenseRepo,
+	}
+}
+
+// EquipmentRequest represents a create or update equipment request
+type EquipmentRequest struct {
+	Name              string  `json:"name"`
+	SerialNumber      *string `json:"serial_number,omitempty"`
+	PurchaseDate      string  `json:"purchase_date"`
+	Cost              float64 `json:"cost"`
+	SalvageValue      float64 `json:"salvage_value"`
+	UsefulLifeMonths  int     `json:"useful_life_months"`
+	DepreciationStart *string `json:"depreciation_start,omitempty"` // Date in the first month; defaults to the purchase date
+	ExpenseID         *uint   `json:"expense_id,omitempty"`         // Expense it was bought with
+	MineSiteID        *uint   `json:"mine_site_id,omitempty"`
+	Notes             *string `json:"notes,omitempty"`
+}
+
+// DisposeEquipmentRequest represents the sale or write-off of an equipment item
+type DisposeEquipmentRequest struct {
+	Date            string            `json:"date"`
+	Type            data.DisposalType `json:"type"`
+	Proceeds        float64           `json:"proceeds"`                   // Sale price; zero for write-offs
+	CustomerName    string            `json:"customer_name,omitempty"`    // Buyer, required for sales with proceeds
+	CustomerContact string            `json:"customer_contact,omitempty"` // Buyer contact
+	AmountPaid      *float64          `json:"amount_paid,omitempty"`      // Paid by the buyer; defaults to the proceeds
+	Reason          *string           `json:"reason,omitempty"`           // Required for write-offs
+}
+
+// GetAllEquipment retrieves the equipment items of the authenticated user
+func (h *EquipmentHandler) GetAllEquipment(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	equipment, err := h.EquipmentRepo.GetAll(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve equipment")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Equipment retrieved successfully", equipment)
+}
+
+// GetEquipment retrieves a specific equipment item
+func (h *EquipmentHandler) GetEquipment(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid equipment ID")
+		return
+	}
+
+	equipment, err := h.EquipmentRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Equipment not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Equipment retrieved successfully", equipment)
+}
+
+// CreateEquipment adds an equipment item to the asset register, generating its monthly
+// depreciation
+func (h *EquipmentHandler) CreateEquipment(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req EquipmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	equipment := &data.Equipment{UserID: userID}
+	if !h.applyRequest(r.Context(), w, userID, &req, equipment) {
+		return
+	}
+
+	equipmentID, err := h.EquipmentRepo.Insert(r.Context(), equipment)
+	if err != nil {
+		if errors.Is(err, data.ErrExpenseCapitalized) {
+			utils.WriteErrorResponse(w, "The expense is already linked to other equipment", http.StatusConflict)
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to create equipment")
+		return
+	}
+
+	equipment.ID = equipmentID
+	utils.WriteSuccessResponse(w, "Equipment created successfully", equipment)
+}
+
+// UpdateEquipment updates an equipment item, generating its depreciation again
+func (h *EquipmentHandler) UpdateEquipment(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid equipment ID")
+		return
+	}
+
+	var req EquipmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	equipment, err := h.EquipmentRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Equipment not found")
+		return
+	}
+	if equipment.DisposedAt != nil {
+		utils.WriteErrorResponse(w, "Disposed equipment can't be changed", http.StatusConflict)
+		return
+	}
+	if !h.applyRequest(r.Context(), w, userID, &req, equipment) {
+		return
+	}
+
+	if err := h.EquipmentRepo.Update(r.Context(), equipment); err != nil {
+		if errors.Is(err, data.ErrExpenseCapitalized) {
+			utils.WriteErrorResponse(w, "The expense is already linked to other equipment", http.StatusConflict)
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to update equipment")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Equipment updated successfully", equipment)
+}
+
+// DeleteEquipment removes an equipment item from the asset register with its depreciation
+func (h *EquipmentHandler) DeleteEquipment(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid equipment ID")
+		return
+	}
+
+	if err := h.EquipmentRepo.Delete(r.Context(), uint(id), userID); err != nil {
+		utils.WriteInternalServerError(w, "Failed to delete equipment")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Equipment deleted successfully", nil)
+}
+
+// DisposeEquipment sells or writes off an equipment item, removing it from the asset register.
+// Its remaining book value is expensed in the month of disposal and the proceeds of a sale are
+// booked as a sale, leaving the gain or loss in the P&L.
+func (h *EquipmentHandler) DisposeEquipment(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid equipment ID")
+		return
+	}
+
+	var req DisposeEquipmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	equipment, err := h.EquipmentRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Equipment not found")
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid date format. Use YYYY-MM-DD")
+		return
+	}
+	if date.Before(equipment.PurchaseDate) {
+		utils.WriteValidationError(w, "Disposal date can't be before the purchase date")
+		return
+	}
+	if !utils.ValidateNonNegativeNumber(req.Proceeds) {
+		utils.WriteValidationError(w, "Proceeds can't be negative")
+		return
+	}
+	hasReason := req.Reason != nil && utils.ValidateRequired(*req.Reason)
+
+	disposal := &data.EquipmentDisposal{Date: date, Type: req.Type, Proceeds: req.Proceeds, Reason: req.Reason}
+	switch req.Type {
+	case data.DisposalWriteOff:
+		if req.Proceeds != 0 {
+			utils.WriteValidationError(w, "Written off equipment has no proceeds; dispose of it as a sale instead")
+			return
+		}
+		if !hasReason {
+			utils.WriteValidationError(w, "Reason is required for write-offs")
+			return
+		}
+	case data.DisposalSale:
+		if req.Proceeds == 0 {
+			break
+		}
+		if !utils.ValidateRequired(req.CustomerName) {
+			utils.WriteValidationError(w, "Customer name is required for sales")
+			return
+		}
+		amountPaid := req.Proceeds
+		if req.AmountPaid != nil {
+			amountPaid = *req.AmountPaid
+		}
+		if !utils.ValidateNonNegativeNumber(amountPaid) || amountPaid > req.Proceeds {
+			utils.WriteValidationError(w, "Amount paid must be between zero and the proceeds")
+			return
+		}
+		notes := fmt.Sprintf("Disposal of equipment %d", equipment.ID)
+		disposal.Sale = &data.Income{
+			Date:            date,
+			ItemName:        &equipment.Name,
+			MineralType:     data.MineralOther,
+			SalesType:       data.SalesTypeAsset,
+			Quantity:        1,
+			Unit:            "unit",
+			PricePerUnit:    req.Proceeds,
+			CustomerName:    req.CustomerName,
+			CustomerContact: req.CustomerContact,
+			AmountPaid:      amountPaid,
+			Notes:           &notes,
+			MineSiteID:      equipment.MineSiteID,
+			UserID:          userID,
+		}
+	default:
+		utils.WriteValidationError(w, "Invalid disposal type. Use sale or write_off")
+		return
+	}
+
+	disposed, err := h.EquipmentRepo.Dispose(r.Context(), uint(id), userID, disposal)
+	if err != nil {
+		if errors.Is(err, data.ErrEquipmentDisposed) {
+			utils.WriteErrorResponse(w, "Equipment has already been disposed of", http.StatusConflict)
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to dispose of equipment")
+		return
+	}
+
+	if h.AuditRepo != nil {
+		details := fmt.Sprintf("Disposed of %s (%s) for %.2f, a gain of %.2f", disposed.Name, req.Type, req.Proceeds, *disposed.DisposalGain)
+		if hasReason {
+			details += ": " + *req.Reason
+		}
+		recordAudit(h.AuditRepo, r, &data.AuditLog{
+			Action:     data.AuditAssetDisposed,
+			Resource:   "equipment",
+			ResourceID: &disposed.ID,
+			Details:    &details,
+		})
+	}
+
+	utils.WriteSuccessResponse(w, "Equipment disposed of successfully", disposed)
+}
+
+// GetEquipmentDepreciation retrieves the monthly depreciation entries of an equipment item
+func (h *EquipmentHandler) GetEquipmentDepreciation(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid equipment ID")
+		return
+	}
+	if _, err := h.EquipmentRepo.GetOne(r.Context(), uint(id), userID); err != nil {
+		utils.WriteNotFoundError(w, "Equipment not found")
+		return
+	}
+
+	entries, err := h.EquipmentRepo.GetDepreciation(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve depreciation")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Depreciation retrieved successfully", entries)
+}
+
+// GetAssetRegister retrieves the book values of the equipment items at the as_of date, today by
+// default
+func (h *EquipmentHandler) GetAssetRegister(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	asOf := time.Now().UTC()
+	if value := r.URL.Query().Get("as_of"); value != "" {
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid as_of date format. Use YYYY-MM-DD")
+			return
+		}
+		asOf = date
+	}
+
+	register, err := h.EquipmentRepo.GetRegister(r.Context(), userID, asOf)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve asset register")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Asset register retrieved successfully", register)
+}
+
+// applyRequest validates a create or update equipment request and applies it to an equipment
+// item. It writes the error response and returns false when the request is invalid.
+func (h *EquipmentHandler) applyRequest(ctx context.Context, w http.ResponseWriter, userID uint, req *EquipmentRequest, equipment *data.Equipment) bool {
+	if !utils.ValidateRequired(req.Name) {
+		utils.WriteValidationError(w, "Name is required")
+		return false
+	}
+	purchaseDate, err := time.Parse("2006-01-02", req.PurchaseDate)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid purchase date format. Use YYYY-MM-DD")
+		return false
+	}
+	if !utils.ValidatePositiveNumber(req.Cost) {
+		utils.WriteValidationError(w, "Cost must be positive")
+		return false
+	}
+	if !utils.ValidateNonNegativeNumber(req.SalvageValue) || req.SalvageValue >= req.Cost {
+		utils.WriteValidationError(w, "Salvage value must be at least zero and less than the cost")
+		return false
+	}
+	if req.UsefulLifeMonths < 1 || req.UsefulLifeMonths > maxUsefulLifeMonths {
+		utils.WriteValidationError(w, fmt.Sprintf("Useful life must be between 1 and %d months", maxUsefulLifeMonths))
+		return false
+	}
+	start := purchaseDate
+	if req.DepreciationStart != nil {
+		if start, err = time.Parse("2006-01-02", *req.DepreciationStart); err != nil {
+			utils.WriteValidationError(w, "Invalid depreciation start format. Use YYYY-MM-DD")
+			return false
+		}
+	}
+
+	if !checkMineSite(ctx, w, h.MineSiteRepo, userID, req.MineSiteID) {
+		return false
+	}
+	if req.ExpenseID != nil {
+		if _, err := h.ExpenseRepo.GetOne(ctx, *req.ExpenseID, userID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				utils.WriteValidationError(w, "Expense not found")
+				return false
+			}
+			utils.WriteInternalServerError(w, "Failed to check expense")
+			return false
+		}
+	}
+
+	equipment.Name = req.Name
+	equipment.SerialNumber = req.SerialNumber
+	equipment.PurchaseDate = purchaseDate
+	equipment.Cost = req.Cost
+	equipment.SalvageValue = req.SalvageValue
+	equipment.UsefulLifeMonths = req.UsefulLifeMonths
+	equipment.DepreciationStart = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+	equipment.ExpenseID = req.ExpenseID
+	equipment.MineSiteID = req.MineSiteID
+	equipment.Notes = req.Notes
+	return true
+}
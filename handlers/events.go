@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"mineral/pkg/events"
+	"mineral/pkg/middleware"
+	"net/http"
+)
+
+// publishEvent publishes an event on the request's books and acting user
+func publishEvent(bus *events.Bus, r *http.Request, name events.Name, resource string, resourceID uint, payload interface{}) {
+	bus.Publish(r.Context(), events.Event{
+		Name:       name,
+		Resource:   resource,
+		ResourceID: resourceID,
+		Data:       payload,
+		UserID:     middleware.GetUserIDFromRequest(r),
+		ActorID:    middleware.GetActorIDFromRequest(r),
+		IPAddress:  middleware.GetClientIP(r),
+	})
+}
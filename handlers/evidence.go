@@ -0,0 +1,315 @@
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// maxPhotoSize is the maximum size of an evidence photo in bytes
+const maxPhotoSize = 5 << 20
+
+// photoContentTypes are the image formats accepted as evidence photos
+var photoContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+// EvidenceHandler handles photo evidence rule and photo requests
+type EvidenceHandler struct {
+	EvidenceRepo data.EvidenceInterface
+}
+
+// NewEvidenceHandler creates a new EvidenceHandler
+func NewEvidenceHandler(evidenceRepo data.EvidenceInterface) *EvidenceHandler {
+	return &EvidenceHandler{
+		EvidenceRepo: evidenceRepo,
+	}
+}
+
+// EvidenceRuleRequest represents a create or update photo evidence rule request
+type EvidenceRuleRequest struct {
+	MinAmount *float64 `json:"min_amount,omitempty"` // omit to always require a photo
+	Active    *bool    `json:"active,omitempty"`
+}
+
+// PhotoUpload represents a photo sent along with a record as evidence
+type PhotoUpload struct {
+	Data string `json:"data"` // base64 encoded JPEG, PNG or WebP, optionally as a data URL
+}
+
+// GetRules returns the photo evidence rules
+func (h *EvidenceHandler) GetRules(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	rules, err := h.EvidenceRepo.GetRules(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve evidence rules")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Evidence rules retrieved successfully", rules)
+}
+
+// SaveRule creates or replaces the photo evidence rule for an operation (owner/manager)
+func (h *EvidenceHandler) SaveRule(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+	if !canManageEvidenceRules(w, r) {
+		return
+	}
+
+	operation := data.EvidenceOperation(chi.URLParam(r, "operation"))
+	if !validEvidenceOperation(operation) {
+		utils.WriteValidationError(w, "Operation must be expense, stock_adjustment or stock_usage")
+		return
+	}
+
+	var req EvidenceRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if req.MinAmount != nil && !utils.ValidateNonNegativeNumber(*req.MinAmount) {
+		utils.WriteValidationError(w, "Minimum amount cannot be negative")
+		return
+	}
+
+	rule := &data.EvidenceRule{
+		Operation: operation,
+		MinAmount: req.MinAmount,
+		Active:    req.Active == nil || *req.Active,
+		UserID:    userID,
+	}
+	if err := h.EvidenceRepo.SaveRule(r.Context(), rule); err != nil {
+		utils.WriteInternalServerError(w, "Failed to save evidence rule")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Evidence rule saved successfully", rule)
+}
+
+// DeleteRule removes the photo evidence rule for an operation (owner/manager)
+func (h *EvidenceHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+	if !canManageEvidenceRules(w, r) {
+		return
+	}
+
+	operation := data.EvidenceOperation(chi.URLParam(r, "operation"))
+	if err := h.EvidenceRepo.DeleteRule(r.Context(), userID, operation); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Evidence rule not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to delete evidence rule")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Evidence rule deleted successfully", nil)
+}
+
+// GetPhotos returns the photos attached to a record
+func (h *EvidenceHandler) GetPhotos(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	recordType := data.EvidenceRecordType(r.URL.Query().Get("record_type"))
+	if recordType != data.EvidenceRecordExpense && recordType != data.EvidenceRecordInventoryItem &&
+		recordType != data.EvidenceRecordStockMovement {
+		utils.WriteValidationError(w, "Record type must be expense, inventory_item or stock_movement")
+		return
+	}
+	recordID, err := strconv.ParseUint(r.URL.Query().Get("record_id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid record ID")
+		return
+	}
+
+	photos, err := h.EvidenceRepo.GetPhotos(r.Context(), userID, recordType, uint(recordID))
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve photos")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Photos retrieved successfully", photos)
+}
+
+// DownloadPhoto returns the image of a photo, or the file of an inventory item document
+func (h *EvidenceHandler) DownloadPhoto(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid photo ID")
+		return
+	}
+
+	photo, err := h.EvidenceRepo.GetPhoto(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Photo not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", photo.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(photo.Data)))
+	if photo.FileName != nil {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": *photo.FileName}))
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(photo.Data)
+}
+
+// canManageEvidenceRules writes a forbidden response and returns false unless the acting user
+// may manage the settings
+func canManageEvidenceRules(w http.ResponseWriter, r *http.Request) bool {
+	return requirePermission(w, r, data.PermSettingsManage, "You do not have permission to change evidence rules")
+}
+
+// validEvidenceOperation reports whether an operation can have a photo evidence rule
+func validEvidenceOperation(operation data.EvidenceOperation) bool {
+	return operation == data.EvidenceExpense || operation == data.EvidenceStockAdjustment ||
+		operation == data.EvidenceStockUsage
+}
+
+// requirePhoto applies the photo evidence rules to an operation and saves the photo sent with
+// it, if any, to be linked once the record is saved. When the record already has a photo a
+// new one is optional. It writes the error response and returns false when a required photo
+// is missing, the photo is invalid or it doesn't fit in the attachment storage quota.
+func requirePhoto(w http.ResponseWriter, r *http.Request, evidenceRepo data.EvidenceInterface, quota *AttachmentQuota, operation data.EvidenceOperation, amount float64, upload *PhotoUpload, existing func() (bool, error)) (*data.EvidencePhoto, bool) {
+	userID := middleware.GetUserIDFromRequest(r)
+
+	if upload == nil || upload.Data == "" {
+		required, err := evidenceRepo.RequiresPhoto(r.Context(), userID, operation, amount)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to check evidence rules")
+			return nil, false
+		}
+		if required && existing != nil {
+			hasPhoto, err := existing()
+			if err != nil {
+				utils.WriteInternalServerError(w, "Failed to check evidence rules")
+				return nil, false
+			}
+			required = !hasPhoto
+		}
+		if required {
+			utils.WriteValidationError(w, fmt.Sprintf("A photo is required for this %s", strings.ReplaceAll(string(operation), "_", " ")))
+			return nil, false
+		}
+		return nil, true
+	}
+
+	image, contentType, ok := decodeUpload(w, upload.Data, maxPhotoSize, "Photo", "a JPEG, PNG or WebP image", photoContentTypes)
+	if !ok {
+		return nil, false
+	}
+	if !quota.allowUpload(r.Context(), w, userID, int64(len(image))) {
+		return nil, false
+	}
+
+	actorID := middleware.GetActorIDFromRequest(r)
+	photo := &data.EvidencePhoto{
+		Operation:    operation,
+		ContentType:  contentType,
+		Data:         image,
+		UploadedByID: &actorID,
+		UserID:       userID,
+	}
+	if _, err := evidenceRepo.InsertPhoto(r.Context(), photo); err != nil {
+		utils.WriteInternalServerError(w, "Failed to save photo")
+		return nil, false
+	}
+	return photo, true
+}
+
+// decodeUpload decodes a base64 upload, optionally sent as a data URL, of at most maxSize bytes
+// in one of the formats of contentTypes, returning the file and its content type. It writes a
+// validation error such as "Photo must be a JPEG, PNG or WebP image" and returns false when the
+// upload is too large, not base64 or in another format.
+func decodeUpload(w http.ResponseWriter, encoded string, maxSize int, what, formats string, contentTypes ...map[string]bool) ([]byte, string, bool) {
+	if strings.HasPrefix(encoded, "data:") {
+		if _, payload, found := strings.Cut(encoded, ","); found {
+			encoded = payload
+		}
+	}
+	tooLarge := fmt.Sprintf("%s must be %d MB or smaller", what, maxSize>>20)
+	if base64.StdEncoding.DecodedLen(len(encoded)) > maxSize+3 {
+		utils.WriteValidationError(w, tooLarge)
+		return nil, "", false
+	}
+	file, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		utils.WriteValidationError(w, what+" must be base64 encoded")
+		return nil, "", false
+	}
+	if len(file) > maxSize {
+		utils.WriteValidationError(w, tooLarge)
+		return nil, "", false
+	}
+	contentType := http.DetectContentType(file)
+	for _, accepted := range contentTypes {
+		if accepted[contentType] {
+			return file, contentType, true
+		}
+	}
+	utils.WriteValidationError(w, what+" must be "+formats)
+	return nil, "", false
+}
+
+// linkPhoto links a photo saved by requirePhoto to its record. Failures are logged, as the
+// record itself has been saved.
+func linkPhoto(ctx context.Context, evidenceRepo data.EvidenceInterface, photo *data.EvidencePhoto, recordType data.EvidenceRecordType, recordID uint) {
+	if photo == nil {
+		return
+	}
+	if err := evidenceRepo.LinkPhoto(ctx, photo.ID, photo.UserID, recordType, recordID); err != nil {
+		log.Printf("Failed to link photo %d to %s %d: %v", photo.ID, recordType, recordID, err)
+		return
+	}
+	photo.RecordType = &recordType
+	photo.RecordID = &recordID
+}
+
+// discardPhoto removes a photo saved by requirePhoto when its record failed to save
+func discardPhoto(ctx context.Context, evidenceRepo data.EvidenceInterface, photo *data.EvidencePhoto) {
+	if photo == nil {
+		return
+	}
+	if err := evidenceRepo.DeletePhoto(ctx, photo.ID, photo.UserID); err != nil {
+		log.Printf("Failed to discard photo %d: %v", photo.ID, err)
+	}
+}
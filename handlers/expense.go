@@ -2,55 +2,114 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"mineral/data"
 	"mineral/pkg/middleware"
 	"mineral/pkg/utils"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
 )
 
 // ExpenseHandler handles expense-related requests
 type ExpenseHandler struct {
-	ExpenseRepo data.ExpenseInterface
+	ExpenseRepo  data.ExpenseInterface
+	EvidenceRepo data.EvidenceInterface
+
+	// Quota limits the photos uploaded to the attachment storage quota; uploads aren't limited
+	// when it is nil
+	Quota *AttachmentQuota
+
+	// MineSiteRepo checks the mine sites records are assigned to; records can't be assigned to a
+	// site when it is nil
+	MineSiteRepo data.MineSiteInterface
+
+	// TripRepo checks the trips transport costs are assigned to; expenses can't be assigned to a
+	// trip when it is nil
+	TripRepo data.TripInterface
+
+	// PaymentRepo keeps the payments appended through the payments sub-resource
+	PaymentRepo data.PaymentInterface
+
+	// TillRepo enables attributing the cash taken to tills when set
+	TillRepo data.TillInterface
+
+	// SettingsRepo enables the approval of expenses dated further back than the backdating limit
+	// and the sign-off of expenses above the sign-off amount when set, and AuditRepo flags
+	// backdated expenses in the audit log
+	SettingsRepo data.SettingsInterface
+	AuditRepo    data.AuditInterface
+
+	// SupplyPriceRepo enables flagging supplies bought above the shared price list when set
+	SupplyPriceRepo data.SupplyPriceInterface
 }
 
 // NewExpenseHandler creates a new ExpenseHandler
-func NewExpenseHandler(expenseRepo data.ExpenseInterface) *ExpenseHandler {
+func NewExpenseHandler(expenseRepo data.ExpenseInterface, evidenceRepo data.EvidenceInterface) *ExpenseHandler {
 	return &ExpenseHandler{
-		ExpenseRepo: expenseRepo,
+		ExpenseRepo:  expenseRepo,
+		EvidenceRepo: evidenceRepo,
 	}
 }
 
 // CreateExpenseRequest represents a create expense request
 type CreateExpenseRequest struct {
-	Date            string  `json:"date"`
-	Category        string  `json:"category"`
-	Description     string  `json:"description"`
-	Amount          float64 `json:"amount"`
-	SupplierName    string  `json:"supplier_name"`
-	SupplierContact string  `json:"supplier_contact,omitempty"`
-	PaymentStatus   string  `json:"payment_status"`
-	AmountPaid      float64 `json:"amount_paid"`
-	Notes           string  `json:"notes,omitempty"`
+	Date            string       `json:"date"`
+	Category        string       `json:"category"`
+	Description     string       `json:"description"`
+	Amount          float64      `json:"amount"`
+	SupplierName    string       `json:"supplier_name"`
+	SupplierContact string       `json:"supplier_contact,omitempty"`
+	PaymentStatus   string       `json:"payment_status"`
+	AmountPaid      float64      `json:"amount_paid"`
+	Notes           string       `json:"notes,omitempty"`
+	MineSiteID      *uint        `json:"mine_site_id,omitempty"`
+	TripID          *uint        `json:"trip_id,omitempty"` // Trip this transport cost belongs to
+	Photo           *PhotoUpload `json:"photo,omitempty"`   // Required by the evidence rules above a threshold
+	TillID          *uint        `json:"till_id,omitempty"` // Till the amount paid went through; defaults to the member's till
+
+	PrepaidMonths     *int   `json:"prepaid_months,omitempty"`     // Months a prepaid expense is expensed over
+	AmortizationStart string `json:"amortization_start,omitempty"` // Date in the first month; defaults to the date
+
+	SupplyPriceID *uint    `json:"supply_price_id,omitempty"` // Supply on the shared price list bought
+	Quantity      *float64 `json:"quantity,omitempty"`        // Quantity bought, in the unit of the price list
 }
 
 // UpdateExpenseRequest represents an update expense request
 type UpdateExpenseRequest struct {
-	Date            string  `json:"date"`
-	Category        string  `json:"category"`
-	Description     string  `json:"description"`
-	Amount          float64 `json:"amount"`
-	SupplierName    string  `json:"supplier_name"`
-	SupplierContact string  `json:"supplier_contact,omitempty"`
-	PaymentStatus   string  `json:"payment_status"`
-	AmountPaid      float64 `json:"amount_paid"`
-	Notes           string  `json:"notes,omitempty"`
+	Date            string       `json:"date"`
+	Category        string       `json:"category"`
+	Description     string       `json:"description"`
+	Amount          float64      `json:"amount"`
+	SupplierName    string       `json:"supplier_name"`
+	SupplierContact string       `json:"supplier_contact,omitempty"`
+	PaymentStatus   string       `json:"payment_status"`
+	AmountPaid      float64      `json:"amount_paid"`
+	Notes           string       `json:"notes,omitempty"`
+	MineSiteID      *uint        `json:"mine_site_id,omitempty"`
+	TripID          *uint        `json:"trip_id,omitempty"` // Trip this transport cost belongs to
+	Photo           *PhotoUpload `json:"photo,omitempty"`   // Required by the evidence rules above a threshold
+
+	PrepaidMonths     *int   `json:"prepaid_months,omitempty"`     // Months a prepaid expense is expensed over
+	AmortizationStart string `json:"amortization_start,omitempty"` // Date in the first month; defaults to the date
+
+	SupplyPriceID *uint    `json:"supply_price_id,omitempty"` // Supply on the shared price list bought
+	Quantity      *float64 `json:"quantity,omitempty"`        // Quantity bought, in the unit of the price list
+}
+
+// RejectExpenseRequest represents the rejection of an expense pending approval
+type RejectExpenseRequest struct {
+	Reason string `json:"reason"`
 }
 
-// GetAllExpenses retrieves all expense records for the authenticated user
+// GetAllExpenses retrieves all expense records for the authenticated user, or a page of them
+// when the page or per_page query parameter is set. site_id, category, supplier and
+// payment_status narrow them down, and sort and order sort them.
 func (h *ExpenseHandler) GetAllExpenses(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserIDFromRequest(r)
 	if userID == 0 {
@@ -58,16 +117,55 @@ func (h *ExpenseHandler) GetAllExpenses(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	expenses, err := h.ExpenseRepo.GetAll(userID)
+	page, err := utils.ParsePage(r)
+	if err != nil {
+		utils.WriteValidationError(w, err.Error())
+		return
+	}
+	siteID, ok := parseSiteFilter(w, r)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	filter := data.ExpenseFilter{
+		SiteID:        siteID,
+		Category:      data.ExpenseCategory(query.Get("category")),
+		SupplierName:  strings.TrimSpace(query.Get("supplier")),
+		PaymentStatus: data.PaymentStatus(query.Get("payment_status")),
+	}
+	if filter.Category != "" && !slices.Contains(data.ExpenseCategories, filter.Category) {
+		utils.WriteValidationError(w, "Invalid expense category")
+		return
+	}
+	if !validPaymentStatusFilter(w, filter.PaymentStatus) {
+		return
+	}
+	filter.Sort, filter.Descending, err = utils.ParseSort(r, data.ExpenseSortColumns)
+	if err != nil {
+		utils.WriteValidationError(w, err.Error())
+		return
+	}
+	if page == nil && filter == (data.ExpenseFilter{}) {
+		expenses, err := h.ExpenseRepo.GetAll(r.Context(), userID)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to retrieve expense records")
+			return
+		}
+		utils.WriteSuccessResponse(w, "Expense records retrieved successfully", expenses)
+		return
+	}
+
+	expenses, total, err := h.ExpenseRepo.GetPage(r.Context(), userID, filter, page.Offset(), page.Limit())
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to retrieve expense records")
 		return
 	}
 
-	utils.WriteSuccessResponse(w, "Expense records retrieved successfully", expenses)
+	utils.WriteListResponse(w, "Expense records retrieved successfully", expenses, page, total)
 }
 
-// GetExpense retrieves a specific expense record
+// GetExpense retrieves a specific expense record, or an archived one by the ID it had with
+// archived=true
 func (h *ExpenseHandler) GetExpense(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserIDFromRequest(r)
 	if userID == 0 {
@@ -82,7 +180,17 @@ func (h *ExpenseHandler) GetExpense(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	expense, err := h.ExpenseRepo.GetOne(uint(id), userID)
+	if r.URL.Query().Get("archived") == "true" {
+		archived, err := h.ExpenseRepo.GetArchivedOne(r.Context(), uint(id), userID)
+		if err != nil {
+			utils.WriteNotFoundError(w, "Archived expense record not found")
+			return
+		}
+		utils.WriteSuccessResponse(w, "Archived expense record retrieved successfully", archived)
+		return
+	}
+
+	expense, err := h.ExpenseRepo.GetOne(r.Context(), uint(id), userID)
 	if err != nil {
 		utils.WriteNotFoundError(w, "Expense record not found")
 		return
@@ -156,6 +264,53 @@ func (h *ExpenseHandler) CreateExpense(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Prepaid expenses are expensed month by month from the month they start in
+	start, ok := amortizationStart(w, req.PrepaidMonths, req.AmortizationStart)
+	if !ok {
+		return
+	}
+
+	// Records can only be assigned to the user's own mine sites and trips
+	if !checkMineSite(r.Context(), w, h.MineSiteRepo, userID, req.MineSiteID) {
+		return
+	}
+	if !checkTrip(r.Context(), w, h.TripRepo, userID, req.TripID) {
+		return
+	}
+
+	// Attribute the amount paid on the spot to the till it went through
+	var tillID *uint
+	if req.AmountPaid > 0 {
+		var ok bool
+		if tillID, ok = resolveTill(w, r, h.TillRepo, userID, req.TillID); !ok {
+			return
+		}
+	}
+
+	// Expenses dated further back than the backdating limit need manager approval
+	backdated, ok := backdatedDays(r.Context(), w, h.SettingsRepo, userID, date)
+	if !ok {
+		return
+	}
+
+	// Expenses above the sign-off amount can't be paid until they are signed off
+	signOff, ok := signOffStatus(r.Context(), w, h.SettingsRepo, userID, req.Amount)
+	if !ok {
+		return
+	}
+
+	// Supplies bought well above the shared price list are flagged
+	variance, ok := priceVariance(r.Context(), w, h.SupplyPriceRepo, h.SettingsRepo, userID, req.SupplyPriceID, req.Quantity, req.Amount)
+	if !ok {
+		return
+	}
+
+	// Apply photo evidence rules
+	photo, ok := requirePhoto(w, r, h.EvidenceRepo, h.Quota, data.EvidenceExpense, req.Amount, req.Photo, nil)
+	if !ok {
+		return
+	}
+
 	// Create expense record
 	expense := &data.Expense{
 		Date:          date,
@@ -165,7 +320,19 @@ func (h *ExpenseHandler) CreateExpense(w http.ResponseWriter, r *http.Request) {
 		SupplierName:  req.SupplierName,
 		PaymentStatus: paymentStatus,
 		AmountPaid:    req.AmountPaid,
+		TripID:        req.TripID,
+		MineSiteID:    req.MineSiteID,
+		TillID:        tillID,
 		UserID:        userID,
+		BackdatedDays: backdated,
+		SignOffStatus: signOff,
+
+		PrepaidMonths:     req.PrepaidMonths,
+		AmortizationStart: start,
+
+		SupplyPriceID: req.SupplyPriceID,
+		Quantity:      req.Quantity,
+		PriceVariance: variance,
 	}
 	if req.SupplierContact != "" {
 		expense.SupplierContact = &req.SupplierContact
@@ -173,14 +340,27 @@ func (h *ExpenseHandler) CreateExpense(w http.ResponseWriter, r *http.Request) {
 	if req.Notes != "" {
 		expense.Notes = &req.Notes
 	}
+	if backdated != nil {
+		expense.ApprovalStatus, expense.ReviewedByID, expense.ReviewedAt = approval(r, data.PermExpenseApprove)
+	}
 
-	expenseID, err := h.ExpenseRepo.Insert(expense)
+	expenseID, err := h.ExpenseRepo.Insert(r.Context(), expense)
 	if err != nil {
+		discardPhoto(r.Context(), h.EvidenceRepo, photo)
+		if errors.Is(err, data.ErrAwaitingSignOff) {
+			utils.WriteValidationError(w, awaitingSignOffMessage)
+			return
+		}
 		utils.WriteInternalServerError(w, "Failed to create expense record")
 		return
 	}
+	linkPhoto(r.Context(), h.EvidenceRepo, photo, data.EvidenceRecordExpense, expenseID)
 
 	expense.ID = expenseID
+	if backdated != nil {
+		auditBackdated(h.AuditRepo, r, "expense", expense.ID, expense.Date, *backdated, expense.ApprovalStatus)
+	}
+	auditPriceVariance(h.AuditRepo, r, expense)
 	utils.WriteSuccessResponse(w, "Expense record created successfully", expense)
 }
 
@@ -206,7 +386,7 @@ func (h *ExpenseHandler) UpdateExpense(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get existing expense record
-	expense, err := h.ExpenseRepo.GetOne(uint(id), userID)
+	expense, err := h.ExpenseRepo.GetOne(r.Context(), uint(id), userID)
 	if err != nil {
 		utils.WriteNotFoundError(w, "Expense record not found")
 		return
@@ -263,6 +443,62 @@ func (h *ExpenseHandler) UpdateExpense(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Prepaid expenses are expensed month by month from the month they start in
+	start, ok := amortizationStart(w, req.PrepaidMonths, req.AmortizationStart)
+	if !ok {
+		return
+	}
+
+	// Records can only be assigned to the user's own mine sites and trips
+	if !checkMineSite(r.Context(), w, h.MineSiteRepo, userID, req.MineSiteID) {
+		return
+	}
+	if !checkTrip(r.Context(), w, h.TripRepo, userID, req.TripID) {
+		return
+	}
+
+	// An expense moved further back than the backdating limit needs approval again
+	newlyBackdated := false
+	if !date.Equal(expense.Date) {
+		backdated, ok := backdatedDays(r.Context(), w, h.SettingsRepo, userID, date)
+		if !ok {
+			return
+		}
+		if backdated != nil {
+			expense.ApprovalStatus, expense.ReviewedByID, expense.ReviewedAt = approval(r, data.PermExpenseApprove)
+			newlyBackdated = true
+		} else if expense.BackdatedDays != nil {
+			expense.ApprovalStatus = nil
+			expense.ReviewedByID = nil
+			expense.ReviewedAt = nil
+		}
+		expense.BackdatedDays = backdated
+	}
+
+	// An expense whose amount changes needs sign-off again when it is above the sign-off amount
+	if req.Amount != expense.Amount {
+		signOff, ok := signOffStatus(r.Context(), w, h.SettingsRepo, userID, req.Amount)
+		if !ok {
+			return
+		}
+		expense.SignOffStatus = signOff
+	}
+
+	// Supplies bought well above the shared price list are flagged
+	variance, ok := priceVariance(r.Context(), w, h.SupplyPriceRepo, h.SettingsRepo, userID, req.SupplyPriceID, req.Quantity, req.Amount)
+	if !ok {
+		return
+	}
+	newlyFlagged := variance != nil && (expense.PriceVariance == nil || *variance != *expense.PriceVariance)
+
+	// Apply photo evidence rules, unless the expense already has a photo
+	photo, ok := requirePhoto(w, r, h.EvidenceRepo, h.Quota, data.EvidenceExpense, req.Amount, req.Photo, func() (bool, error) {
+		return h.EvidenceRepo.HasPhoto(r.Context(), userID, data.EvidenceRecordExpense, expense.ID)
+	})
+	if !ok {
+		return
+	}
+
 	// Calculate amount due
 	amountDue := req.Amount - req.AmountPaid
 
@@ -275,6 +511,13 @@ func (h *ExpenseHandler) UpdateExpense(w http.ResponseWriter, r *http.Request) {
 	expense.PaymentStatus = paymentStatus
 	expense.AmountPaid = req.AmountPaid
 	expense.AmountDue = amountDue
+	expense.TripID = req.TripID
+	expense.MineSiteID = req.MineSiteID
+	expense.PrepaidMonths = req.PrepaidMonths
+	expense.AmortizationStart = start
+	expense.SupplyPriceID = req.SupplyPriceID
+	expense.Quantity = req.Quantity
+	expense.PriceVariance = variance
 	if req.SupplierContact != "" {
 		expense.SupplierContact = &req.SupplierContact
 	} else {
@@ -286,11 +529,23 @@ func (h *ExpenseHandler) UpdateExpense(w http.ResponseWriter, r *http.Request) {
 		expense.Notes = nil
 	}
 
-	err = h.ExpenseRepo.Update(expense)
+	err = h.ExpenseRepo.Update(r.Context(), expense)
 	if err != nil {
+		discardPhoto(r.Context(), h.EvidenceRepo, photo)
+		if errors.Is(err, data.ErrAwaitingSignOff) {
+			utils.WriteValidationError(w, awaitingSignOffMessage)
+			return
+		}
 		utils.WriteInternalServerError(w, "Failed to update expense record")
 		return
 	}
+	linkPhoto(r.Context(), h.EvidenceRepo, photo, data.EvidenceRecordExpense, expense.ID)
+	if newlyBackdated {
+		auditBackdated(h.AuditRepo, r, "expense", expense.ID, expense.Date, *expense.BackdatedDays, expense.ApprovalStatus)
+	}
+	if newlyFlagged {
+		auditPriceVariance(h.AuditRepo, r, expense)
+	}
 
 	utils.WriteSuccessResponse(w, "Expense record updated successfully", expense)
 }
@@ -310,7 +565,7 @@ func (h *ExpenseHandler) DeleteExpense(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.ExpenseRepo.Delete(uint(id), userID)
+	err = h.ExpenseRepo.Delete(r.Context(), uint(id), userID)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to delete expense record")
 		return
@@ -319,7 +574,165 @@ func (h *ExpenseHandler) DeleteExpense(w http.ResponseWriter, r *http.Request) {
 	utils.WriteSuccessResponse(w, "Expense record deleted successfully", nil)
 }
 
-// GetExpenseByDateRange retrieves expense records within a date range
+// GetExpenseTrash retrieves the expense records deleted and not yet permanently removed, most recently
+// deleted first
+func (h *ExpenseHandler) GetExpenseTrash(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	records, err := h.ExpenseRepo.GetDeleted(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve deleted expense records")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Deleted expense records retrieved successfully", records)
+}
+
+// RestoreExpense brings back a deleted expense record
+func (h *ExpenseHandler) RestoreExpense(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid expense ID")
+		return
+	}
+
+	record, err := h.ExpenseRepo.Restore(r.Context(), uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Deleted expense record not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to restore expense record")
+		return
+	}
+	auditTrash(h.AuditRepo, r, data.AuditRecordRestored, "expense", record.ID)
+
+	utils.WriteSuccessResponse(w, "Expense record restored successfully", record)
+}
+
+// PurgeExpense permanently deletes a expense record from the trash. Only deleted expense records can be purged.
+func (h *ExpenseHandler) PurgeExpense(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid expense ID")
+		return
+	}
+
+	if err := h.ExpenseRepo.Purge(r.Context(), uint(id), userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Deleted expense record not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to permanently delete expense record")
+		return
+	}
+	auditTrash(h.AuditRepo, r, data.AuditRecordPurged, "expense", uint(id))
+
+	utils.WriteSuccessResponse(w, "Expense record permanently deleted successfully", nil)
+}
+
+// GetPendingApprovals retrieves backdated expenses awaiting approval
+func (h *ExpenseHandler) GetPendingApprovals(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	expenses, err := h.ExpenseRepo.GetPendingApproval(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve expenses pending approval")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Expenses pending approval retrieved successfully", expenses)
+}
+
+// ApproveExpense approves a backdated expense (owner/manager)
+func (h *ExpenseHandler) ApproveExpense(w http.ResponseWriter, r *http.Request) {
+	h.reviewExpense(w, r, data.SaleApproved)
+}
+
+// RejectExpense rejects a backdated expense with a reason, removing it from the books
+// (owner/manager)
+func (h *ExpenseHandler) RejectExpense(w http.ResponseWriter, r *http.Request) {
+	h.reviewExpense(w, r, data.SaleRejected)
+}
+
+// reviewExpense approves or rejects an expense pending approval
+func (h *ExpenseHandler) reviewExpense(w http.ResponseWriter, r *http.Request, status data.SaleApproval) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+	if !requirePermission(w, r, data.PermExpenseApprove, "You do not have permission to review expenses pending approval") {
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid expense ID")
+		return
+	}
+
+	var reason *string
+	if status == data.SaleRejected {
+		var req RejectExpenseRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.WriteValidationError(w, "Invalid request body")
+			return
+		}
+		if !utils.ValidateRequired(req.Reason) {
+			utils.WriteValidationError(w, "Rejection reason is required")
+			return
+		}
+		reason = &req.Reason
+	}
+
+	err = h.ExpenseRepo.Review(r.Context(), uint(id), userID, status, middleware.GetActorIDFromRequest(r), reason)
+	if err != nil {
+		if errors.Is(err, data.ErrExpenseReviewed) {
+			utils.WriteValidationError(w, "Only expenses pending approval can be reviewed")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to review expense")
+		return
+	}
+
+	if status == data.SaleRejected {
+		utils.WriteSuccessResponse(w, "Expense rejected successfully", nil)
+		return
+	}
+
+	expense, err := h.ExpenseRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve approved expense")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Expense approved successfully", expense)
+}
+
+// GetExpenseByDateRange retrieves expense records within a date range, or the archived ones with
+// archived=true
 func (h *ExpenseHandler) GetExpenseByDateRange(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserIDFromRequest(r)
 	if userID == 0 {
@@ -335,7 +748,17 @@ func (h *ExpenseHandler) GetExpenseByDateRange(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	expenses, err := h.ExpenseRepo.GetByDateRange(userID, startDate, endDate)
+	if r.URL.Query().Get("archived") == "true" {
+		archived, err := h.ExpenseRepo.GetArchived(r.Context(), userID, startDate, endDate)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to retrieve archived expense records")
+			return
+		}
+		utils.WriteSuccessResponse(w, "Archived expense records retrieved successfully", archived)
+		return
+	}
+
+	expenses, err := h.ExpenseRepo.GetByDateRange(r.Context(), userID, startDate, endDate)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to retrieve expense records")
 		return
@@ -352,7 +775,7 @@ func (h *ExpenseHandler) GetExpenseCategoryBreakdown(w http.ResponseWriter, r *h
 		return
 	}
 
-	breakdown, err := h.ExpenseRepo.GetCategoryBreakdown(userID)
+	breakdown, err := h.ExpenseRepo.GetCategoryBreakdown(r.Context(), userID)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to retrieve expense breakdown")
 		return
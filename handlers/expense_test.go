@@ -0,0 +1,479 @@
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"mineral/data"
+	"mineral/data/mocks"
+	"mineral/pkg/middleware"
+	"mineral/pkg/storage"
+	"mineral/pkg/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// newTestExpenseHandler returns an ExpenseHandler whose inserts are passed to insert and that
+// never requires photos
+func newTestExpenseHandler(t *testing.T, insert func(expense *data.Expense)) *ExpenseHandler {
+	ctrl := gomock.NewController(t)
+	expenseRepo := mocks.NewMockExpenseInterface(ctrl)
+	expenseRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, expense *data.Expense) (uint, error) {
+		insert(expense)
+		return 3, nil
+	}).AnyTimes()
+	evidenceRepo := mocks.NewMockEvidenceInterface(ctrl)
+	evidenceRepo.EXPECT().RequiresPhoto(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	return NewExpenseHandler(expenseRepo, evidenceRepo)
+}
+
+func TestCreateExpense(t *testing.T) {
+	valid := `{"date":"2024-03-01","category":"fuel","description":"Diesel","amount":120,` +
+		`"supplier_name":"Total","payment_status":"paid","amount_paid":120}`
+
+	tests := []struct {
+		name          string
+		userID        uint
+		body          string
+		photoRequired bool
+		insertErr     error
+		status        int
+		inserted      bool
+	}{
+		{name: "unauthenticated", body: valid, status: http.StatusUnauthorized},
+		{name: "invalid body", userID: 1, body: `[]`, status: http.StatusBadRequest},
+		{name: "missing description", userID: 1, body: `{"date":"2024-03-01","category":"fuel","amount":1,"supplier_name":"Total","payment_status":"paid"}`, status: http.StatusBadRequest},
+		{name: "negative amount paid", userID: 1, body: `{"date":"2024-03-01","category":"fuel","description":"Diesel","amount":1,"supplier_name":"Total","payment_status":"paid","amount_paid":-1}`, status: http.StatusBadRequest},
+		{name: "unknown category", userID: 1, body: `{"date":"2024-03-01","category":"food","description":"Lunch","amount":1,"supplier_name":"Cafe","payment_status":"paid"}`, status: http.StatusBadRequest},
+		{name: "photo required", userID: 1, body: valid, photoRequired: true, status: http.StatusBadRequest},
+		{name: "other user's mine site", userID: 1, body: `{"date":"2024-03-01","category":"fuel","description":"Diesel","amount":1,"supplier_name":"Total","payment_status":"paid","mine_site_id":9}`, status: http.StatusBadRequest},
+		{name: "created at mine site", userID: 1, body: `{"date":"2024-03-01","category":"fuel","description":"Diesel","amount":1,"supplier_name":"Total","payment_status":"paid","mine_site_id":2}`, status: http.StatusOK, inserted: true},
+		{name: "other user's trip", userID: 1, body: `{"date":"2024-03-01","category":"fuel","description":"Diesel","amount":1,"supplier_name":"Total","payment_status":"paid","trip_id":9}`, status: http.StatusBadRequest},
+		{name: "created for trip", userID: 1, body: `{"date":"2024-03-01","category":"fuel","description":"Diesel","amount":1,"supplier_name":"Total","payment_status":"paid","trip_id":4}`, status: http.StatusOK, inserted: true},
+		{name: "insert fails", userID: 1, body: valid, insertErr: errors.New("db down"), status: http.StatusInternalServerError, inserted: true},
+		{name: "created", userID: 1, body: valid, status: http.StatusOK, inserted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			var got *data.Expense
+			expenseRepo := mocks.NewMockExpenseInterface(ctrl)
+			if tt.inserted {
+				expenseRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, expense *data.Expense) (uint, error) {
+					got = expense
+					return 3, tt.insertErr
+				})
+			}
+			evidenceRepo := mocks.NewMockEvidenceInterface(ctrl)
+			evidenceRepo.EXPECT().RequiresPhoto(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(tt.photoRequired, nil).AnyTimes()
+			h := NewExpenseHandler(expenseRepo, evidenceRepo)
+			mineSiteRepo := mocks.NewMockMineSiteInterface(ctrl)
+			mineSiteRepo.EXPECT().GetOne(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, id uint, userID uint) (*data.MineSiteInfo, error) {
+				if id != 2 {
+					return nil, gorm.ErrRecordNotFound
+				}
+				return &data.MineSiteInfo{UserID: userID}, nil
+			}).AnyTimes()
+			h.MineSiteRepo = mineSiteRepo
+			tripRepo := mocks.NewMockTripInterface(ctrl)
+			tripRepo.EXPECT().GetOne(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, id uint, userID uint) (*data.Trip, error) {
+				if id != 4 {
+					return nil, gorm.ErrRecordNotFound
+				}
+				return &data.Trip{UserID: userID}, nil
+			}).AnyTimes()
+			h.TripRepo = tripRepo
+
+			rr := serve(h.CreateExpense, http.MethodPost, tt.userID, tt.body, nil)
+
+			if rr.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.status, rr.Body.String())
+			}
+			if tt.status == http.StatusOK && (got.UserID != tt.userID || got.Category != data.ExpenseFuel) {
+				t.Errorf("inserted expense = user %d, category %s; want user %d, category fuel", got.UserID, got.Category, tt.userID)
+			}
+		})
+	}
+}
+
+func TestCreateExpenseSignOff(t *testing.T) {
+	tests := []struct {
+		name          string
+		amount        string
+		signOffAmount float64
+		pending       bool
+	}{
+		{name: "no sign-off amount", amount: "5000"},
+		{name: "at sign-off amount", amount: "1000", signOffAmount: 1000},
+		{name: "above sign-off amount", amount: "1000.5", signOffAmount: 1000, pending: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *data.Expense
+			h := newTestExpenseHandler(t, func(expense *data.Expense) { got = expense })
+			settingsRepo := mocks.NewMockSettingsInterface(gomock.NewController(t))
+			settingsRepo.EXPECT().GetByUserID(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, userID uint) (*data.OrganizationSettings, error) {
+				settings := data.DefaultOrganizationSettings(userID)
+				settings.SignOffAmount = tt.signOffAmount
+				return settings, nil
+			}).AnyTimes()
+			h.SettingsRepo = settingsRepo
+			body := `{"date":"` + time.Now().Format("2006-01-02") + `","category":"equipment","description":"Pump",` +
+				`"amount":` + tt.amount + `,"supplier_name":"Davis & Shirtliff","payment_status":"unpaid"}`
+
+			rr := serve(h.CreateExpense, http.MethodPost, 1, body, nil)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, http.StatusOK, rr.Body.String())
+			}
+			if pending := got.AwaitingSignOff(); pending != tt.pending {
+				t.Errorf("awaiting sign-off = %v, want %v", pending, tt.pending)
+			}
+		})
+	}
+}
+
+func TestCreateExpensePriceVariance(t *testing.T) {
+	tests := []struct {
+		name     string
+		fields   string
+		variance float64
+		status   int
+		flagged  bool
+	}{
+		{name: "not on the price list", fields: `"quantity":100`, status: http.StatusOK},
+		{name: "missing quantity", fields: `"supply_price_id":1`, status: http.StatusBadRequest},
+		{name: "unknown supply", fields: `"supply_price_id":9,"quantity":100`, status: http.StatusBadRequest},
+		{name: "within variance", fields: `"supply_price_id":1,"quantity":110`, variance: 10, status: http.StatusOK},
+		{name: "above variance", fields: `"supply_price_id":1,"quantity":100`, variance: 10, status: http.StatusOK, flagged: true},
+		{name: "variance flags off", fields: `"supply_price_id":1,"quantity":100`, status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			var got *data.Expense
+			h := newTestExpenseHandler(t, func(expense *data.Expense) { got = expense })
+			settingsRepo := mocks.NewMockSettingsInterface(ctrl)
+			settingsRepo.EXPECT().GetByUserID(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, userID uint) (*data.OrganizationSettings, error) {
+				settings := data.DefaultOrganizationSettings(userID)
+				settings.PriceVariancePercent = tt.variance
+				return settings, nil
+			}).AnyTimes()
+			h.SettingsRepo = settingsRepo
+			supplyPriceRepo := mocks.NewMockSupplyPriceInterface(ctrl)
+			supplyPriceRepo.EXPECT().GetOne(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, id uint, userID uint) (*data.SupplyPrice, error) {
+				if id != 1 {
+					return nil, gorm.ErrRecordNotFound
+				}
+				return &data.SupplyPrice{Name: "Diesel", Unit: "litre", Price: 5000, UserID: userID}, nil
+			}).AnyTimes()
+			h.SupplyPriceRepo = supplyPriceRepo
+			// 600,000 for 100 litres is 6,000 a litre, 20% above the list price
+			body := `{"date":"` + time.Now().Format("2006-01-02") + `","category":"fuel","description":"Diesel",` +
+				`"amount":600000,"supplier_name":"Total","payment_status":"paid","amount_paid":600000,` + tt.fields + `}`
+
+			rr := serve(h.CreateExpense, http.MethodPost, 1, body, nil)
+
+			if rr.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.status, rr.Body.String())
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			if flagged := got.PriceVariance != nil; flagged != tt.flagged {
+				t.Fatalf("flagged = %v, want %v", flagged, tt.flagged)
+			}
+			if tt.flagged && *got.PriceVariance != 20 {
+				t.Errorf("price variance = %v, want 20", *got.PriceVariance)
+			}
+		})
+	}
+}
+
+func TestGetExpense(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID uint
+		id     string
+		status int
+	}{
+		{name: "unauthenticated", id: "1", status: http.StatusUnauthorized},
+		{name: "invalid id", userID: 1, id: "x", status: http.StatusBadRequest},
+		{name: "other user's expense", userID: 2, id: "1", status: http.StatusNotFound},
+		{name: "found", userID: 1, id: "1", status: http.StatusOK},
+	}
+
+	ctrl := gomock.NewController(t)
+	expenseRepo := mocks.NewMockExpenseInterface(ctrl)
+	expenseRepo.EXPECT().GetOne(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, id uint, userID uint) (*data.Expense, error) {
+		if id != 1 || userID != 1 {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return &data.Expense{UserID: userID}, nil
+	}).AnyTimes()
+	h := NewExpenseHandler(expenseRepo, mocks.NewMockEvidenceInterface(ctrl))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := serve(h.GetExpense, http.MethodGet, tt.userID, "", map[string]string{"id": tt.id})
+			if rr.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.status, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetAllExpenses(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID uint
+		err    error
+		status int
+	}{
+		{name: "unauthenticated", status: http.StatusUnauthorized},
+		{name: "query fails", userID: 1, err: errors.New("db down"), status: http.StatusInternalServerError},
+		{name: "listed", userID: 1, status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			expenseRepo := mocks.NewMockExpenseInterface(ctrl)
+			expenseRepo.EXPECT().GetAll(gomock.Any(), gomock.Any()).Return([]*data.Expense{}, tt.err).AnyTimes()
+			h := NewExpenseHandler(expenseRepo, mocks.NewMockEvidenceInterface(ctrl))
+
+			rr := serve(h.GetAllExpenses, http.MethodGet, tt.userID, "", nil)
+			if rr.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.status, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetAllExpensesPaginated(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		status int
+		offset int
+		limit  int
+	}{
+		{name: "invalid page", query: "page=0", status: http.StatusBadRequest},
+		{name: "per page too large", query: "per_page=500", status: http.StatusBadRequest},
+		{name: "default page size", query: "page=1", status: http.StatusOK, limit: utils.DefaultPerPage},
+		{name: "later page", query: "page=3&per_page=10", status: http.StatusOK, offset: 20, limit: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			var offset, limit int
+			expenseRepo := mocks.NewMockExpenseInterface(ctrl)
+			expenseRepo.EXPECT().GetPage(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, userID uint, filter data.ExpenseFilter, o, l int) ([]*data.Expense, int64, error) {
+					offset, limit = o, l
+					return []*data.Expense{}, 25, nil
+				}).AnyTimes()
+			h := NewExpenseHandler(expenseRepo, mocks.NewMockEvidenceInterface(ctrl))
+
+			req := httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+			req = req.WithContext(middleware.ContextWithUser(req.Context(), middleware.AuthUser{ID: 1}))
+			rr := httptest.NewRecorder()
+			h.GetAllExpenses(rr, req)
+			if rr.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.status, rr.Body.String())
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			if offset != tt.offset || limit != tt.limit {
+				t.Errorf("page = offset %d limit %d, want offset %d limit %d", offset, limit, tt.offset, tt.limit)
+			}
+
+			var resp struct {
+				Pagination utils.Pagination `json:"pagination"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Pagination.Total != 25 || resp.Pagination.TotalPages != (25+limit-1)/limit {
+				t.Errorf("pagination = %+v", resp.Pagination)
+			}
+		})
+	}
+}
+
+func TestGetAllExpensesFiltered(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		status int
+		filter data.ExpenseFilter
+	}{
+		{name: "invalid category", query: "category=snacks", status: http.StatusBadRequest},
+		{name: "invalid payment status", query: "payment_status=overdue", status: http.StatusBadRequest},
+		{name: "invalid sort", query: "sort=description", status: http.StatusBadRequest},
+		{name: "invalid order", query: "sort=amount&order=up", status: http.StatusBadRequest},
+		{
+			name:   "filtered and sorted",
+			query:  "category=fuel&supplier=Shell&payment_status=unpaid&sort=amount&order=desc",
+			status: http.StatusOK,
+			filter: data.ExpenseFilter{Category: data.ExpenseFuel, SupplierName: "Shell", PaymentStatus: data.PaymentUnpaid, Sort: "amount", Descending: true},
+		},
+		{name: "sorted ascending", query: "sort=supplier_name", status: http.StatusOK, filter: data.ExpenseFilter{Sort: "supplier_name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			var got *data.ExpenseFilter
+			expenseRepo := mocks.NewMockExpenseInterface(ctrl)
+			expenseRepo.EXPECT().GetPage(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, userID uint, filter data.ExpenseFilter, o, l int) ([]*data.Expense, int64, error) {
+					got = &filter
+					return []*data.Expense{}, 0, nil
+				}).AnyTimes()
+			h := NewExpenseHandler(expenseRepo, mocks.NewMockEvidenceInterface(ctrl))
+
+			req := httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+			req = req.WithContext(middleware.ContextWithUser(req.Context(), middleware.AuthUser{ID: 1}))
+			rr := httptest.NewRecorder()
+			h.GetAllExpenses(rr, req)
+			if rr.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.status, rr.Body.String())
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			if got == nil || *got != tt.filter {
+				t.Errorf("filter = %+v, want %+v", got, tt.filter)
+			}
+		})
+	}
+}
+
+func TestAddExpenseAttachment(t *testing.T) {
+	pdf := base64.StdEncoding.EncodeToString([]byte("%PDF-1.7\n%fuel receipt"))
+
+	tests := []struct {
+		name      string
+		body      string
+		missing   bool // the expense doesn't exist
+		insertErr error
+		status    int
+	}{
+		{name: "expense not found", body: `{"data":"` + pdf + `","file_name":"receipt.pdf"}`, missing: true, status: http.StatusNotFound},
+		{name: "missing file name", body: `{"data":"` + pdf + `"}`, status: http.StatusBadRequest},
+		{name: "unknown kind", body: `{"data":"` + pdf + `","file_name":"receipt.pdf","kind":"photo"}`, status: http.StatusBadRequest},
+		{name: "unsupported format", body: `{"data":"` + base64.StdEncoding.EncodeToString([]byte("plain text")) + `","file_name":"receipt.txt"}`, status: http.StatusBadRequest},
+		{name: "insert fails", body: `{"data":"` + pdf + `","file_name":"receipt.pdf"}`, insertErr: errors.New("db down"), status: http.StatusInternalServerError},
+		{name: "stored", body: `{"data":"` + pdf + `","file_name":"receipt.pdf","kind":"receipt"}`, status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := storage.NewDiskStore(t.TempDir())
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctrl := gomock.NewController(t)
+			expenseRepo := mocks.NewMockExpenseInterface(ctrl)
+			expenseRepo.EXPECT().GetOne(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, id uint, userID uint) (*data.Expense, error) {
+				if tt.missing {
+					return nil, gorm.ErrRecordNotFound
+				}
+				return &data.Expense{UserID: userID}, nil
+			}).AnyTimes()
+			var inserted *data.Attachment
+			attachmentRepo := mocks.NewMockAttachmentInterface(ctrl)
+			attachmentRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, attachment *data.Attachment) error {
+				inserted = attachment
+				return tt.insertErr
+			}).AnyTimes()
+			h := NewAttachmentHandler(attachmentRepo, mocks.NewMockIncomeInterface(ctrl), expenseRepo, store)
+
+			rr := serve(h.AddExpenseAttachment, http.MethodPost, 1, tt.body, map[string]string{"id": "4"})
+
+			if rr.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.status, rr.Body.String())
+			}
+			if inserted == nil {
+				if tt.status == http.StatusOK {
+					t.Fatal("attachment not saved")
+				}
+				return
+			}
+			file, err := store.Open(inserted.StorageKey)
+			if tt.status != http.StatusOK {
+				if !errors.Is(err, storage.ErrNotFound) {
+					t.Fatalf("file of a failed attachment left in the store: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("file not stored: %v", err)
+			}
+			file.Close()
+			if inserted.RecordType != data.AttachmentRecordExpense || inserted.RecordID != 4 || inserted.Kind != data.AttachmentReceipt {
+				t.Errorf("attachment = %s %d %s, want expense 4 receipt", inserted.RecordType, inserted.RecordID, inserted.Kind)
+			}
+		})
+	}
+}
+
+func TestAddExpensePayment(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		recordErr error
+		status    int
+		recorded  bool
+	}{
+		{name: "invalid body", body: `[]`, status: http.StatusBadRequest},
+		{name: "zero amount", body: `{"amount":0}`, status: http.StatusBadRequest},
+		{name: "invalid date", body: `{"amount":10,"date":"01/03/2024"}`, status: http.StatusBadRequest},
+		{name: "expense not found", body: `{"amount":10}`, recordErr: gorm.ErrRecordNotFound, status: http.StatusNotFound, recorded: true},
+		{name: "overpayment", body: `{"amount":500}`, recordErr: data.ErrOverpayment, status: http.StatusBadRequest, recorded: true},
+		{name: "awaiting sign-off", body: `{"amount":10}`, recordErr: data.ErrAwaitingSignOff, status: http.StatusBadRequest, recorded: true},
+		{name: "record fails", body: `{"amount":10}`, recordErr: errors.New("db down"), status: http.StatusInternalServerError, recorded: true},
+		{name: "recorded", body: `{"amount":10,"date":"2024-03-05","method":"cash"}`, status: http.StatusOK, recorded: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			var got *data.Payment
+			paymentRepo := mocks.NewMockPaymentInterface(ctrl)
+			if tt.recorded {
+				paymentRepo.EXPECT().RecordExpensePayment(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, payment *data.Payment) (*data.Expense, error) {
+					got = payment
+					if tt.recordErr != nil {
+						return nil, tt.recordErr
+					}
+					return &data.Expense{Amount: 30, AmountPaid: 10, AmountDue: 20, PaymentStatus: data.PaymentPartial}, nil
+				})
+			}
+			h := NewExpenseHandler(mocks.NewMockExpenseInterface(ctrl), mocks.NewMockEvidenceInterface(ctrl))
+			h.PaymentRepo = paymentRepo
+
+			rr := serve(h.AddExpensePayment, http.MethodPost, 1, tt.body, map[string]string{"id": "4"})
+
+			if rr.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.status, rr.Body.String())
+			}
+			if tt.status == http.StatusOK && (got.RecordID != 4 || got.UserID != 1 || got.Amount != 10 || got.Date.Format("2006-01-02") != "2024-03-05") {
+				t.Errorf("payment = record %d, user %d, amount %v, date %s; want record 4, user 1, amount 10, date 2024-03-05",
+					got.RecordID, got.UserID, got.Amount, got.Date.Format("2006-01-02"))
+			}
+		})
+	}
+}
@@ -0,0 +1,332 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExportHandler handles CSV data exports
+type ExportHandler struct {
+	IncomeRepo    data.IncomeInterface
+	ExpenseRepo   data.ExpenseInterface
+	InventoryRepo data.InventoryInterface
+	AuditRepo     data.AuditInterface
+	FlagRepo      data.FlagInterface
+
+	// MinerRepo enables the export of the miner registry when set
+	MinerRepo data.MinerInterface
+}
+
+// NewExportHandler creates a new ExportHandler
+func NewExportHandler(incomeRepo data.IncomeInterface, expenseRepo data.ExpenseInterface, inventoryRepo data.InventoryInterface, auditRepo data.AuditInterface, flagRepo data.FlagInterface) *ExportHandler {
+	return &ExportHandler{
+		IncomeRepo:    incomeRepo,
+		ExpenseRepo:   expenseRepo,
+		InventoryRepo: inventoryRepo,
+		AuditRepo:     auditRepo,
+		FlagRepo:      flagRepo,
+	}
+}
+
+// ExportIncome downloads income records as CSV, optionally limited to a date range and with the
+// archived records when include_archived=true
+func (h *ExportHandler) ExportIncome(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	startDate, endDate, ok := exportDateRange(w, r)
+	if !ok {
+		return
+	}
+
+	var incomes []*data.Income
+	var err error
+	if startDate != "" {
+		incomes, err = h.IncomeRepo.GetByDateRange(r.Context(), userID, startDate, endDate)
+	} else {
+		incomes, err = h.IncomeRepo.GetAll(r.Context(), userID)
+	}
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve income records")
+		return
+	}
+	if includeArchived(r) {
+		archived, err := h.IncomeRepo.GetArchived(r.Context(), userID, startDate, endDate)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to retrieve archived income records")
+			return
+		}
+		for _, income := range archived {
+			incomes = append(incomes, &income.Income)
+		}
+		sort.SliceStable(incomes, func(i, j int) bool { return incomes[i].Date.After(incomes[j].Date) })
+	}
+	flags, err := riskFlags(r.Context(), h.FlagRepo, userID, data.ContactCustomer)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve customer flags")
+		return
+	}
+
+	rows := make([][]string, 0, len(incomes))
+	for _, income := range incomes {
+		rows = append(rows, []string{
+			income.Date.Format("2006-01-02"),
+			stringValue(income.ItemName),
+			string(income.MineralType),
+			string(income.SalesType),
+			formatAmount(income.Quantity),
+			income.Unit,
+			formatAmount(income.PricePerUnit),
+			formatAmount(income.TotalAmount),
+			income.CustomerName,
+			string(income.PaymentStatus),
+			formatAmount(income.AmountPaid),
+			formatAmount(income.AmountDue),
+			string(flags[income.CustomerName]),
+			approvalValue(income.ApprovalStatus),
+		})
+	}
+
+	h.writeExport(w, r, "income", []string{
+		"date", "item_name", "mineral_type", "sales_type", "quantity", "unit", "price_per_unit",
+		"total_amount", "customer_name", "payment_status", "amount_paid", "amount_due", "risk_flag",
+		"approval_status",
+	}, rows)
+}
+
+// ExportExpenses downloads expense records as CSV, optionally limited to a date range and with
+// the archived records when include_archived=true
+func (h *ExportHandler) ExportExpenses(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	startDate, endDate, ok := exportDateRange(w, r)
+	if !ok {
+		return
+	}
+
+	var expenses []*data.Expense
+	var err error
+	if startDate != "" {
+		expenses, err = h.ExpenseRepo.GetByDateRange(r.Context(), userID, startDate, endDate)
+	} else {
+		expenses, err = h.ExpenseRepo.GetAll(r.Context(), userID)
+	}
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve expense records")
+		return
+	}
+	if includeArchived(r) {
+		archived, err := h.ExpenseRepo.GetArchived(r.Context(), userID, startDate, endDate)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to retrieve archived expense records")
+			return
+		}
+		for _, expense := range archived {
+			expenses = append(expenses, &expense.Expense)
+		}
+		sort.SliceStable(expenses, func(i, j int) bool { return expenses[i].Date.After(expenses[j].Date) })
+	}
+	flags, err := riskFlags(r.Context(), h.FlagRepo, userID, data.ContactSupplier)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve supplier flags")
+		return
+	}
+
+	rows := make([][]string, 0, len(expenses))
+	for _, expense := range expenses {
+		rows = append(rows, []string{
+			expense.Date.Format("2006-01-02"),
+			string(expense.Category),
+			expense.Description,
+			formatAmount(expense.Amount),
+			expense.SupplierName,
+			string(expense.PaymentStatus),
+			formatAmount(expense.AmountPaid),
+			formatAmount(expense.AmountDue),
+			string(flags[expense.SupplierName]),
+		})
+	}
+
+	h.writeExport(w, r, "expenses", []string{
+		"date", "category", "description", "amount", "supplier_name", "payment_status", "amount_paid", "amount_due",
+		"risk_flag",
+	}, rows)
+}
+
+// ExportInventory downloads inventory items as CSV
+func (h *ExportHandler) ExportInventory(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	items, err := h.InventoryRepo.GetAll(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve inventory items")
+		return
+	}
+
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		expiry := ""
+		if item.ExpiryDate != nil {
+			expiry = item.ExpiryDate.Format("2006-01-02")
+		}
+		rows = append(rows, []string{
+			item.Name,
+			item.Type,
+			stringValue(item.PitNumber),
+			stringValue(item.BatchNumber),
+			formatAmount(item.Quantity),
+			item.Unit,
+			formatAmount(item.MinStockLevel),
+			formatAmount(item.CurrentValue),
+			expiry,
+			strconv.FormatBool(item.IsHazardous),
+		})
+	}
+
+	h.writeExport(w, r, "inventory", []string{
+		"name", "type", "pit_number", "batch_number", "quantity", "unit", "min_stock_level",
+		"current_value", "expiry_date", "is_hazardous",
+	}, rows)
+}
+
+// ExportMiners downloads the miner registry with the KYC completeness of each miner as CSV, for
+// compliance audits
+func (h *ExportHandler) ExportMiners(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+	if h.MinerRepo == nil {
+		utils.WriteNotFoundError(w, "Miner registry is not available")
+		return
+	}
+
+	miners, err := h.MinerRepo.GetAll(r.Context(), userID, nil)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve miners")
+		return
+	}
+
+	rows := make([][]string, 0, len(miners))
+	for _, miner := range miners {
+		site := ""
+		if miner.MineSiteID != nil {
+			site = strconv.FormatUint(uint64(*miner.MineSiteID), 10)
+		}
+		rows = append(rows, []string{
+			strconv.FormatUint(uint64(miner.ID), 10),
+			miner.Name,
+			stringValue(miner.Phone),
+			stringValue(miner.IDDocumentType),
+			stringValue(miner.IDNumber),
+			stringValue(miner.Village),
+			site,
+			stringValue(miner.PitNumber),
+			string(miner.PaymentMethod),
+			stringValue(miner.MobileMoneyNumber),
+			stringValue(miner.BankName),
+			stringValue(miner.BankAccountNumber),
+			strconv.FormatBool(miner.Active),
+			strconv.FormatBool(miner.KYCComplete),
+			strings.Join(miner.KYCMissing, ";"),
+			miner.CreatedAt.Format("2006-01-02"),
+		})
+	}
+
+	h.writeExport(w, r, "miners", []string{
+		"id", "name", "phone", "id_document_type", "id_number", "village", "mine_site_id", "pit_number",
+		"payment_method", "mobile_money_number", "bank_name", "bank_account_number", "active", "kyc_complete",
+		"kyc_missing", "registered",
+	}, rows)
+}
+
+// writeExport streams rows as a CSV attachment and records the export in the audit log
+func (h *ExportHandler) writeExport(w http.ResponseWriter, r *http.Request, resource string, header []string, rows [][]string) {
+	filename := fmt.Sprintf("%s-%s.csv", resource, time.Now().Format("20060102"))
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	writer := csv.NewWriter(w)
+	writer.Write(header)
+	writer.WriteAll(rows)
+
+	rowCount := len(rows)
+	details := r.URL.RawQuery
+	entry := &data.AuditLog{
+		Action:   data.AuditExport,
+		Resource: resource,
+		RowCount: &rowCount,
+	}
+	if details != "" {
+		entry.Details = &details
+	}
+	recordAudit(h.AuditRepo, r, entry)
+}
+
+// exportDateRange reads the optional start_date and end_date query parameters; both or neither must be set
+func exportDateRange(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	startDate := r.URL.Query().Get("start_date")
+	endDate := r.URL.Query().Get("end_date")
+	if startDate == "" && endDate == "" {
+		return "", "", true
+	}
+	if startDate == "" || endDate == "" {
+		utils.WriteValidationError(w, "Start date and end date must be given together")
+		return "", "", false
+	}
+	if _, err := time.Parse("2006-01-02", startDate); err != nil {
+		utils.WriteValidationError(w, "Invalid date format. Use YYYY-MM-DD")
+		return "", "", false
+	}
+	if _, err := time.Parse("2006-01-02", endDate); err != nil {
+		utils.WriteValidationError(w, "Invalid date format. Use YYYY-MM-DD")
+		return "", "", false
+	}
+	return startDate, endDate, true
+}
+
+// includeArchived reports whether an export includes archived records
+func includeArchived(r *http.Request) bool {
+	return r.URL.Query().Get("include_archived") == "true"
+}
+
+// formatAmount formats a number for CSV output
+func formatAmount(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+// approvalValue dereferences an optional sale approval status for CSV output
+func approvalValue(value *data.SaleApproval) string {
+	if value == nil {
+		return ""
+	}
+	return string(*value)
+}
+
+// stringValue dereferences an optional string for CSV output
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
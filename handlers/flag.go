@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// FlagHandler handles high-risk and blacklisted customer and supplier requests
+type FlagHandler struct {
+	FlagRepo data.FlagInterface
+}
+
+// NewFlagHandler creates a new FlagHandler
+func NewFlagHandler(flagRepo data.FlagInterface) *FlagHandler {
+	return &FlagHandler{
+		FlagRepo: flagRepo,
+	}
+}
+
+// FlagRequest represents a request to flag a customer or supplier
+type FlagRequest struct {
+	Name   string         `json:"name"`
+	Type   string         `json:"type"`  // "customer" or "supplier"
+	Level  data.RiskLevel `json:"level"` // "high_risk" or "blacklisted"
+	Reason string         `json:"reason"`
+}
+
+// GetFlags returns the flagged customers and suppliers, optionally of one type
+func (h *FlagHandler) GetFlags(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	flagType, ok := contactTypeQuery(w, r)
+	if !ok {
+		return
+	}
+
+	flags, err := h.FlagRepo.GetAll(r.Context(), userID, flagType)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve flags")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Flags retrieved successfully", flags)
+}
+
+// SaveFlag flags a customer or supplier as high-risk or blacklisted, replacing any existing
+// flag (owner/manager)
+func (h *FlagHandler) SaveFlag(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+	if !requirePermission(w, r, data.PermRiskManage, "You do not have permission to flag customers and suppliers") {
+		return
+	}
+
+	var req FlagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	reason := strings.TrimSpace(req.Reason)
+	flagType := data.ContactType(req.Type)
+	if !utils.ValidateRequired(name) {
+		utils.WriteValidationError(w, "Name is required")
+		return
+	}
+	if flagType != data.ContactCustomer && flagType != data.ContactSupplier {
+		utils.WriteValidationError(w, "Type must be customer or supplier")
+		return
+	}
+	if req.Level != data.RiskHigh && req.Level != data.RiskBlacklisted {
+		utils.WriteValidationError(w, "Level must be high_risk or blacklisted")
+		return
+	}
+	if !utils.ValidateRequired(reason) {
+		utils.WriteValidationError(w, "Reason is required")
+		return
+	}
+
+	actorID := middleware.GetActorIDFromRequest(r)
+	flag := &data.CounterpartyFlag{
+		Name:        name,
+		Type:        flagType,
+		Level:       req.Level,
+		Reason:      reason,
+		FlaggedByID: &actorID,
+		UserID:      userID,
+	}
+	if err := h.FlagRepo.Save(r.Context(), flag); err != nil {
+		utils.WriteInternalServerError(w, "Failed to save flag")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Flag saved successfully", flag)
+}
+
+// DeleteFlag removes the flag of a customer or supplier (owner/manager)
+func (h *FlagHandler) DeleteFlag(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+	if !requirePermission(w, r, data.PermRiskManage, "You do not have permission to flag customers and suppliers") {
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid flag ID")
+		return
+	}
+
+	if err := h.FlagRepo.Delete(r.Context(), uint(id), userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Flag not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to delete flag")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Flag deleted successfully", nil)
+}
+
+// requirePermission writes a forbidden response and returns false unless the acting user holds
+// a permission
+func requirePermission(w http.ResponseWriter, r *http.Request, permission data.Permission, message string) bool {
+	if !middleware.HasPermission(r, string(permission)) {
+		utils.WriteForbiddenError(w, message)
+		return false
+	}
+	return true
+}
+
+// applyRiskFlag records the flag of a sale's customer on the sale. Sales to flagged customers
+// recorded by members who can approve them are approved right away; others wait for approval.
+// It writes the error response and returns false when the flag cannot be checked.
+func applyRiskFlag(w http.ResponseWriter, r *http.Request, flagRepo data.FlagInterface, income *data.Income) bool {
+	flag, err := flagRepo.GetFlag(r.Context(), income.UserID, data.ContactCustomer, income.CustomerName)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return true
+		}
+		utils.WriteInternalServerError(w, "Failed to check customer flags")
+		return false
+	}
+
+	income.RiskFlag = &flag.Level
+	income.ApprovalStatus, income.ReviewedByID, income.ReviewedAt = approval(r, data.PermIncomeApprove)
+	return true
+}
+
+// approval returns the approval state of a record that needs the approval of a member holding a
+// permission: approved right away when the acting member holds it, otherwise pending, with who
+// reviewed it and when
+func approval(r *http.Request, permission data.Permission) (*data.SaleApproval, *uint, *time.Time) {
+	if !middleware.HasPermission(r, string(permission)) {
+		status := data.SalePendingApproval
+		return &status, nil, nil
+	}
+	status := data.SaleApproved
+	reviewerID := middleware.GetActorIDFromRequest(r)
+	now := time.Now()
+	return &status, &reviewerID, &now
+}
+
+// riskFlags returns the flag levels of a user's customers or suppliers by name, for highlighting
+// them in reports
+func riskFlags(ctx context.Context, flagRepo data.FlagInterface, userID uint, flagType data.ContactType) (map[string]data.RiskLevel, error) {
+	flags, err := flagRepo.GetAll(ctx, userID, flagType)
+	if err != nil {
+		return nil, err
+	}
+	levels := make(map[string]data.RiskLevel, len(flags))
+	for _, flag := range flags {
+		levels[flag.Name] = flag.Level
+	}
+	return levels, nil
+}
@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"fmt"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// FormHandler handles the form metadata clients build entry forms from
+type FormHandler struct {
+	SettingsRepo data.SettingsInterface
+	EvidenceRepo data.EvidenceInterface
+}
+
+// NewFormHandler creates a new FormHandler
+func NewFormHandler(settingsRepo data.SettingsInterface, evidenceRepo data.EvidenceInterface) *FormHandler {
+	return &FormHandler{
+		SettingsRepo: settingsRepo,
+		EvidenceRepo: evidenceRepo,
+	}
+}
+
+// GetForms returns the entry forms of all record types for the user's configuration
+func (h *FormHandler) GetForms(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	builder, ok := h.formBuilder(w, r, userID)
+	if !ok {
+		return
+	}
+
+	forms := make([]*data.FormMetadata, 0, len(data.FormRecordTypes))
+	for _, recordType := range data.FormRecordTypes {
+		forms = append(forms, builder.form(recordType))
+	}
+
+	utils.WriteSuccessResponse(w, "Forms retrieved successfully", forms)
+}
+
+// GetForm returns the entry form of a record type for the user's configuration
+func (h *FormHandler) GetForm(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	recordType := data.FormRecordType(chi.URLParam(r, "recordType"))
+	known := false
+	for _, formType := range data.FormRecordTypes {
+		if formType == recordType {
+			known = true
+			break
+		}
+	}
+	if !known {
+		utils.WriteNotFoundError(w, fmt.Sprintf("No form for record type %q", recordType))
+		return
+	}
+
+	builder, ok := h.formBuilder(w, r, userID)
+	if !ok {
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Form retrieved successfully", builder.form(recordType))
+}
+
+// formBuilder loads the settings and evidence rules the forms depend on. It writes the error
+// response and returns false when they cannot be loaded.
+func (h *FormHandler) formBuilder(w http.ResponseWriter, r *http.Request, userID uint) (*formBuilder, bool) {
+	settings, err := h.SettingsRepo.GetByUserID(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve settings")
+		return nil, false
+	}
+	rules, err := h.EvidenceRepo.GetRules(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve evidence rules")
+		return nil, false
+	}
+
+	builder := &formBuilder{
+		language: referenceLanguage(r),
+		settings: settings,
+		rules:    make(map[data.EvidenceOperation]*data.EvidenceRule, len(rules)),
+	}
+	for _, rule := range rules {
+		if rule.Active {
+			builder.rules[rule.Operation] = rule
+		}
+	}
+	return builder, true
+}
+
+// formBuilder builds entry forms that mirror the validation of the create handlers
+type formBuilder struct {
+	language string
+	settings *data.OrganizationSettings
+	rules    map[data.EvidenceOperation]*data.EvidenceRule
+}
+
+// nonNegative is the minimum of fields that cannot be negative
+var nonNegative = 0.0
+
+// minPrepaidMonths is the fewest months a prepaid expense can be spread over
+var minPrepaidMonths = 2.0
+
+// form returns the form of a record type
+func (b *formBuilder) form(recordType data.FormRecordType) *data.FormMetadata {
+	var fields []data.FormField
+	switch recordType {
+	case data.FormIncome:
+		fields = b.incomeFields()
+	case data.FormExpense:
+		fields = b.expenseFields()
+	case data.FormInventoryItem:
+		fields = b.inventoryFields()
+	case data.FormStockAdjustment:
+		fields = []data.FormField{
+			{Name: "quantity", Label: "New quantity", Type: data.FormFieldNumber, Required: true, Min: &nonNegative},
+			b.photoField(data.EvidenceStockAdjustment, "quantity"),
+		}
+	case data.FormStockUsage:
+		fields = []data.FormField{
+			{Name: "quantity", Label: "Quantity used", Type: data.FormFieldNumber, Required: true, Min: &nonNegative},
+			{Name: "reason", Label: "Reason", Type: data.FormFieldText},
+			b.photoField(data.EvidenceStockUsage, "quantity"),
+		}
+	}
+
+	return &data.FormMetadata{
+		RecordType: recordType,
+		Language:   b.language,
+		Fields:     fields,
+	}
+}
+
+// incomeFields mirrors the validation of CreateIncome
+func (b *formBuilder) incomeFields() []data.FormField {
+	return []data.FormField{
+		{Name: "date", Label: "Date", Type: data.FormFieldDate, Required: true},
+		{Name: "mineral_type", Label: "Mineral", Type: data.FormFieldSelect, Required: true,
+			Options: referenceOptions(b.language, data.MineralTypes)},
+		{Name: "gemstone_type", Label: "Gemstone type", Type: data.FormFieldSelect,
+			Options:     referenceOptions(b.language, data.GemstoneTypes),
+			VisibleWhen: &data.FormCondition{Field: "mineral_type", Values: []string{string(data.MineralGemstones)}}},
+		{Name: "item_name", Label: "Item name", Type: data.FormFieldText},
+		{Name: "sales_type", Label: "Sales type", Type: data.FormFieldSelect,
+			Options: referenceOptions(b.language, data.SalesTypes)},
+		{Name: "quantity", Label: "Quantity", Type: data.FormFieldNumber, Required: true, Min: &nonNegative},
+		b.unitField(),
+		{Name: "price_per_unit", Label: "Price per unit", Type: data.FormFieldNumber, Required: true, Min: &nonNegative,
+			Help: fmt.Sprintf("In %s", b.settings.DefaultCurrency)},
+		{Name: "customer_name", Label: "Customer", Type: data.FormFieldText, Required: true},
+		{Name: "customer_contact", Label: "Customer contact", Type: data.FormFieldPhone},
+		{Name: "payment_status", Label: "Payment status", Type: data.FormFieldSelect, Required: true,
+			Options: referenceOptions(b.language, data.PaymentStatuses)},
+		{Name: "amount_paid", Label: "Amount paid", Type: data.FormFieldNumber, Min: &nonNegative,
+			RequiredWhen: &data.FormCondition{Field: "payment_status", Values: []string{string(data.PaymentPartial)}},
+			VisibleWhen:  &data.FormCondition{Field: "payment_status", Values: []string{string(data.PaymentPartial)}}},
+		{Name: "due_date", Label: "Due date", Type: data.FormFieldDate,
+			VisibleWhen: &data.FormCondition{Field: "payment_status", Values: []string{string(data.PaymentUnpaid), string(data.PaymentPartial)}}},
+		{Name: "notes", Label: "Notes", Type: data.FormFieldText},
+	}
+}
+
+// expenseFields mirrors the validation of CreateExpense
+func (b *formBuilder) expenseFields() []data.FormField {
+	return []data.FormField{
+		{Name: "date", Label: "Date", Type: data.FormFieldDate, Required: true},
+		{Name: "category", Label: "Category", Type: data.FormFieldSelect, Required: true,
+			Options: referenceOptions(b.language, data.ExpenseCategories)},
+		{Name: "description", Label: "Description", Type: data.FormFieldText, Required: true},
+		{Name: "amount", Label: "Amount", Type: data.FormFieldNumber, Required: true, Min: &nonNegative,
+			Help: fmt.Sprintf("In %s", b.settings.DefaultCurrency)},
+		{Name: "supplier_name", Label: "Supplier", Type: data.FormFieldText, Required: true},
+		{Name: "supplier_contact", Label: "Supplier contact", Type: data.FormFieldPhone},
+		{Name: "payment_status", Label: "Payment status", Type: data.FormFieldSelect,
+			Options: referenceOptions(b.language, data.PaymentStatuses)},
+		{Name: "amount_paid", Label: "Amount paid", Type: data.FormFieldNumber, Min: &nonNegative},
+		{Name: "trip_id", Label: "Trip", Type: data.FormFieldNumber,
+			VisibleWhen: &data.FormCondition{Field: "category", Values: []string{string(data.ExpenseFuel), string(data.ExpenseTransport)}}},
+		{Name: "prepaid_months", Label: "Prepaid months", Type: data.FormFieldNumber, Min: &minPrepaidMonths,
+			Help: "Spread a prepayment such as annual insurance over this many months of the profit and loss"},
+		{Name: "amortization_start", Label: "Amortization start", Type: data.FormFieldDate,
+			Help: "A date in the first month; defaults to the date"},
+		{Name: "notes", Label: "Notes", Type: data.FormFieldText},
+		b.photoField(data.EvidenceExpense, "amount"),
+	}
+}
+
+// inventoryFields mirrors the validation of CreateInventoryItem
+func (b *formBuilder) inventoryFields() []data.FormField {
+	mineral := &data.FormCondition{Field: "type", Values: []string{"mineral"}}
+	hazardous := &data.FormCondition{Field: "is_hazardous", Values: []string{"true"}}
+	return []data.FormField{
+		{Name: "name", Label: "Name", Type: data.FormFieldText, Required: true},
+		{Name: "type", Label: "Type", Type: data.FormFieldSelect, Required: true,
+			Options: referenceOptions(b.language, data.InventoryTypes)},
+		{Name: "from", Label: "Source", Type: data.FormFieldSelect, VisibleWhen: mineral,
+			Options: referenceOptions(b.language, data.ProductionSources)},
+		{Name: "pit_number", Label: "Pit number", Type: data.FormFieldText, VisibleWhen: mineral},
+		{Name: "miner_name", Label: "Miner", Type: data.FormFieldText, VisibleWhen: mineral},
+		{Name: "batch_number", Label: "Batch number", Type: data.FormFieldText, VisibleWhen: mineral},
+		{Name: "processing_method", Label: "Processing method", Type: data.FormFieldSelect, VisibleWhen: mineral,
+			Options: referenceOptions(b.language, data.ProcessingMethods)},
+		{Name: "quantity", Label: "Quantity", Type: data.FormFieldNumber, Required: true, Min: &nonNegative},
+		{Name: "unit", Label: "Unit", Type: data.FormFieldSelect, Required: true, AllowOther: true,
+			Options: referenceOptions(b.language, data.Units)},
+		{Name: "min_stock_level", Label: "Minimum stock level", Type: data.FormFieldNumber, Min: &nonNegative},
+		{Name: "current_value", Label: "Current value", Type: data.FormFieldNumber, Min: &nonNegative,
+			Help: fmt.Sprintf("In %s", b.settings.DefaultCurrency)},
+		{Name: "expiry_date", Label: "Expiry date", Type: data.FormFieldDate,
+			VisibleWhen: &data.FormCondition{Field: "type", Values: []string{"supply"}}},
+		{Name: "is_hazardous", Label: "Hazardous", Type: data.FormFieldBoolean, Default: "false"},
+		{Name: "hazard_class", Label: "Hazard class", Type: data.FormFieldText, VisibleWhen: hazardous},
+		{Name: "permitted_quantity", Label: "Permitted quantity", Type: data.FormFieldNumber, Min: &nonNegative, VisibleWhen: hazardous},
+		{Name: "monthly_usage_limit", Label: "Monthly usage limit", Type: data.FormFieldNumber, Min: &nonNegative, VisibleWhen: hazardous},
+	}
+}
+
+// unitField returns the unit field of sales, defaulting to the unit configured for the mineral.
+// A sale without a unit takes that default, so the field is only required for minerals without one.
+func (b *formBuilder) unitField() data.FormField {
+	field := data.FormField{
+		Name:       "unit",
+		Label:      "Unit",
+		Type:       data.FormFieldSelect,
+		AllowOther: true,
+		Options:    referenceOptions(b.language, data.Units),
+	}
+	if len(b.settings.DefaultUnits) == 0 {
+		field.Required = true
+		return field
+	}
+
+	field.DefaultFrom = "mineral_type"
+	field.Defaults = b.settings.DefaultUnits
+	var without []string
+	for _, mineralType := range data.MineralTypes {
+		if b.settings.DefaultUnit(mineralType) == "" {
+			without = append(without, string(mineralType))
+		}
+	}
+	if len(without) > 0 {
+		field.RequiredWhen = &data.FormCondition{Field: "mineral_type", Values: without}
+	}
+	return field
+}
+
+// photoField returns the photo field of an operation, required as the evidence rules demand
+func (b *formBuilder) photoField(operation data.EvidenceOperation, amountField string) data.FormField {
+	field := data.FormField{Name: "photo", Label: "Photo", Type: data.FormFieldPhoto}
+	rule, ok := b.rules[operation]
+	if !ok {
+		return field
+	}
+	if rule.MinAmount == nil {
+		field.Required = true
+		return field
+	}
+
+	field.RequiredWhen = &data.FormCondition{Field: amountField, Min: rule.MinAmount}
+	if operation == data.EvidenceStockAdjustment {
+		field.Help = fmt.Sprintf("Required when the quantity changes by %g or more", *rule.MinAmount)
+	}
+	return field
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"context"
+	"mineral/pkg/middleware"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// serve calls a handler as the given user with a JSON body and the URL parameters chi would
+// set, returning the recorded response. A zero userID makes an unauthenticated request.
+func serve(handler http.HandlerFunc, method string, userID uint, body string, params map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if userID != 0 {
+		req = req.WithContext(middleware.ContextWithUser(req.Context(), middleware.AuthUser{ID: userID}))
+	}
+
+	routeContext := chi.NewRouteContext()
+	for key, value := range params {
+		routeContext.URLParams.Add(key, value)
+	}
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+
+	rr := httptest.NewRecorder()
+	handler(rr, req)
+	return rr
+}
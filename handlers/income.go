@@ -2,25 +2,52 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"mineral/data"
+	"mineral/pkg/events"
 	"mineral/pkg/middleware"
 	"mineral/pkg/utils"
 	"net/http"
+	"slices"
 	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
 )
 
 // IncomeHandler handles income-related requests
 type IncomeHandler struct {
-	IncomeRepo data.IncomeInterface
+	IncomeRepo      data.IncomeInterface
+	SettingsRepo    data.SettingsInterface
+	ReceiptRepo     data.ReceiptInterface
+	CreditLimitRepo data.CreditLimitInterface
+	FlagRepo        data.FlagInterface
+	Events          *events.Bus
+
+	// MineSiteRepo checks the mine sites records are assigned to; records can't be assigned to a
+	// site when it is nil
+	MineSiteRepo data.MineSiteInterface
+
+	// PaymentRepo keeps the payments appended through the payments sub-resource
+	PaymentRepo data.PaymentInterface
+
+	// TillRepo enables attributing the cash taken to tills when set
+	TillRepo data.TillInterface
+
+	// AuditRepo flags backdated sales in the audit log when set
+	AuditRepo data.AuditInterface
 }
 
 // NewIncomeHandler creates a new IncomeHandler
-func NewIncomeHandler(incomeRepo data.IncomeInterface) *IncomeHandler {
+func NewIncomeHandler(incomeRepo data.IncomeInterface, settingsRepo data.SettingsInterface, receiptRepo data.ReceiptInterface, creditLimitRepo data.CreditLimitInterface, flagRepo data.FlagInterface, bus *events.Bus) *IncomeHandler {
 	return &IncomeHandler{
-		IncomeRepo: incomeRepo,
+		IncomeRepo:      incomeRepo,
+		SettingsRepo:    settingsRepo,
+		ReceiptRepo:     receiptRepo,
+		CreditLimitRepo: creditLimitRepo,
+		FlagRepo:        flagRepo,
+		Events:          bus,
 	}
 }
 
@@ -40,7 +67,22 @@ type CreateIncomeRequest struct {
 	PaymentStatus   string   `json:"payment_status"`
 	AmountPaid      float64  `json:"amount_paid"`
 	AmountDue       *float64 `json:"amount_due,omitempty"`
+	DueDate         *string  `json:"due_date,omitempty"` // YYYY-MM-DD, payment due date of the invoice
 	Notes           *string  `json:"notes,omitempty"`
+	MineSiteID      *uint    `json:"mine_site_id,omitempty"` // site whose books the sale is in
+	TillID          *uint    `json:"till_id,omitempty"`      // till the amount paid went through; defaults to the member's till
+}
+
+// CreateIncomeResponse represents a created income record with a warning when the sale takes
+// the customer past their credit limit
+type CreateIncomeResponse struct {
+	*data.Income
+	CreditWarning *data.CreditLimitWarning `json:"credit_warning,omitempty"`
+}
+
+// RejectIncomeRequest represents the rejection of a sale pending approval
+type RejectIncomeRequest struct {
+	Reason string `json:"reason"`
 }
 
 // UpdateIncomeRequest represents an update income request
@@ -59,10 +101,14 @@ type UpdateIncomeRequest struct {
 	PaymentStatus   string   `json:"payment_status"`
 	AmountPaid      float64  `json:"amount_paid"`
 	AmountDue       *float64 `json:"amount_due,omitempty"`
+	DueDate         *string  `json:"due_date,omitempty"` // YYYY-MM-DD, payment due date of the invoice
 	Notes           *string  `json:"notes,omitempty"`
+	MineSiteID      *uint    `json:"mine_site_id,omitempty"` // site whose books the sale is in
 }
 
-// GetAllIncomes retrieves all income records for the authenticated user
+// GetAllIncomes retrieves all income records for the authenticated user, or a page of them
+// when the page or per_page query parameter is set. site_id, mineral_type and payment_status
+// narrow them down, and sort and order sort them.
 func (h *IncomeHandler) GetAllIncomes(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserIDFromRequest(r)
 	if userID == 0 {
@@ -70,16 +116,54 @@ func (h *IncomeHandler) GetAllIncomes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	incomes, err := h.IncomeRepo.GetAll(userID)
+	page, err := utils.ParsePage(r)
+	if err != nil {
+		utils.WriteValidationError(w, err.Error())
+		return
+	}
+	siteID, ok := parseSiteFilter(w, r)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	filter := data.IncomeFilter{
+		SiteID:        siteID,
+		MineralType:   data.MineralType(query.Get("mineral_type")),
+		PaymentStatus: data.PaymentStatus(query.Get("payment_status")),
+	}
+	if filter.MineralType != "" && !slices.Contains(data.MineralTypes, filter.MineralType) {
+		utils.WriteValidationError(w, "Invalid mineral type")
+		return
+	}
+	if !validPaymentStatusFilter(w, filter.PaymentStatus) {
+		return
+	}
+	filter.Sort, filter.Descending, err = utils.ParseSort(r, data.IncomeSortColumns)
+	if err != nil {
+		utils.WriteValidationError(w, err.Error())
+		return
+	}
+	if page == nil && filter == (data.IncomeFilter{}) {
+		incomes, err := h.IncomeRepo.GetAll(r.Context(), userID)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to retrieve income records")
+			return
+		}
+		utils.WriteSuccessResponse(w, "Income records retrieved successfully", incomes)
+		return
+	}
+
+	incomes, total, err := h.IncomeRepo.GetPage(r.Context(), userID, filter, page.Offset(), page.Limit())
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to retrieve income records")
 		return
 	}
 
-	utils.WriteSuccessResponse(w, "Income records retrieved successfully", incomes)
+	utils.WriteListResponse(w, "Income records retrieved successfully", incomes, page, total)
 }
 
-// GetIncome retrieves a specific income record
+// GetIncome retrieves a specific income record, or an archived one by the ID it had with
+// archived=true
 func (h *IncomeHandler) GetIncome(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserIDFromRequest(r)
 	if userID == 0 {
@@ -94,7 +178,17 @@ func (h *IncomeHandler) GetIncome(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	income, err := h.IncomeRepo.GetOne(uint(id), userID)
+	if r.URL.Query().Get("archived") == "true" {
+		archived, err := h.IncomeRepo.GetArchivedOne(r.Context(), uint(id), userID)
+		if err != nil {
+			utils.WriteNotFoundError(w, "Archived income record not found")
+			return
+		}
+		utils.WriteSuccessResponse(w, "Archived income record retrieved successfully", archived)
+		return
+	}
+
+	income, err := h.IncomeRepo.GetOne(r.Context(), uint(id), userID)
 	if err != nil {
 		utils.WriteNotFoundError(w, "Income record not found")
 		return
@@ -130,6 +224,15 @@ func (h *IncomeHandler) CreateIncome(w http.ResponseWriter, r *http.Request) {
 		utils.WriteValidationError(w, "Quantity must be positive")
 		return
 	}
+	if !utils.ValidateRequired(req.Unit) {
+		// Fall back to the organization's default unit for the mineral
+		settings, err := h.SettingsRepo.GetByUserID(r.Context(), userID)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to retrieve settings")
+			return
+		}
+		req.Unit = settings.DefaultUnit(data.MineralType(req.MineralType))
+	}
 	if !utils.ValidateRequired(req.Unit) {
 		utils.WriteValidationError(w, "Unit is required")
 		return
@@ -154,6 +257,13 @@ func (h *IncomeHandler) CreateIncome(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Parse due date if provided
+	dueDate, err := parseOptionalDate(req.DueDate)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid due date format. Use YYYY-MM-DD")
+		return
+	}
+
 	// Validate mineral type (allow all mineral types)
 	mineralType := data.MineralType(req.MineralType)
 
@@ -192,6 +302,20 @@ func (h *IncomeHandler) CreateIncome(w http.ResponseWriter, r *http.Request) {
 		amountDue = totalAmount - req.AmountPaid
 	}
 
+	// Records can only be assigned to the user's own mine sites
+	if !checkMineSite(r.Context(), w, h.MineSiteRepo, userID, req.MineSiteID) {
+		return
+	}
+
+	// Attribute the amount paid on the spot to the till it went through
+	var tillID *uint
+	if req.AmountPaid > 0 {
+		var ok bool
+		if tillID, ok = resolveTill(w, r, h.TillRepo, userID, req.TillID); !ok {
+			return
+		}
+	}
+
 	// Create income record
 	income := &data.Income{
 		Date:            date,
@@ -208,18 +332,54 @@ func (h *IncomeHandler) CreateIncome(w http.ResponseWriter, r *http.Request) {
 		PaymentStatus:   paymentStatus,
 		AmountPaid:      req.AmountPaid,
 		AmountDue:       amountDue,
+		DueDate:         dueDate,
 		Notes:           req.Notes,
+		MineSiteID:      req.MineSiteID,
+		TillID:          tillID,
 		UserID:          userID,
 	}
 
-	incomeID, err := h.IncomeRepo.Insert(income)
+	// Sales to flagged customers need manager approval
+	if !applyRiskFlag(w, r, h.FlagRepo, income) {
+		return
+	}
+
+	// So do sales dated further back than the backdating limit
+	backdated, ok := backdatedDays(r.Context(), w, h.SettingsRepo, userID, date)
+	if !ok {
+		return
+	}
+	if backdated != nil {
+		income.BackdatedDays = backdated
+		income.ApprovalStatus, income.ReviewedByID, income.ReviewedAt = approval(r, data.PermIncomeApprove)
+	}
+
+	// Warn about or block a sale that takes the customer past their credit limit
+	creditWarning, ok := checkCreditLimit(r.Context(), w, h.CreditLimitRepo, h.SettingsRepo, income)
+	if !ok {
+		return
+	}
+
+	incomeID, err := h.IncomeRepo.Insert(r.Context(), income)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to create income record")
 		return
 	}
 
 	income.ID = incomeID
-	utils.WriteSuccessResponse(w, "Income record created successfully", income)
+	if income.BackdatedDays != nil {
+		auditBackdated(h.AuditRepo, r, "income", income.ID, income.Date, *income.BackdatedDays, income.ApprovalStatus)
+	}
+
+	// Issue a receipt for any payment received with the sale
+	issuePaymentReceipt(r.Context(), h.SettingsRepo, h.ReceiptRepo, income, income.AmountPaid)
+	publishEvent(h.Events, r, events.IncomeCreated, "income", income.ID, income)
+	h.publishPayment(r, income, income.AmountPaid)
+
+	utils.WriteSuccessResponse(w, "Income record created successfully", &CreateIncomeResponse{
+		Income:        income,
+		CreditWarning: creditWarning,
+	})
 }
 
 // UpdateIncome updates an existing income record
@@ -244,7 +404,7 @@ func (h *IncomeHandler) UpdateIncome(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get existing income record
-	income, err := h.IncomeRepo.GetOne(uint(id), userID)
+	income, err := h.IncomeRepo.GetOne(r.Context(), uint(id), userID)
 	if err != nil {
 		utils.WriteNotFoundError(w, "Income record not found")
 		return
@@ -263,6 +423,15 @@ func (h *IncomeHandler) UpdateIncome(w http.ResponseWriter, r *http.Request) {
 		utils.WriteValidationError(w, "Quantity must be positive")
 		return
 	}
+	if !utils.ValidateRequired(req.Unit) {
+		// Fall back to the organization's default unit for the mineral
+		settings, err := h.SettingsRepo.GetByUserID(r.Context(), userID)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to retrieve settings")
+			return
+		}
+		req.Unit = settings.DefaultUnit(data.MineralType(req.MineralType))
+	}
 	if !utils.ValidateRequired(req.Unit) {
 		utils.WriteValidationError(w, "Unit is required")
 		return
@@ -287,6 +456,13 @@ func (h *IncomeHandler) UpdateIncome(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Parse due date if provided
+	dueDate, err := parseOptionalDate(req.DueDate)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid due date format. Use YYYY-MM-DD")
+		return
+	}
+
 	// Validate mineral type (allow all mineral types)
 	mineralType := data.MineralType(req.MineralType)
 
@@ -326,7 +502,46 @@ func (h *IncomeHandler) UpdateIncome(w http.ResponseWriter, r *http.Request) {
 		amountDue = totalAmount - req.AmountPaid
 	}
 
+	// Records can only be assigned to the user's own mine sites
+	if !checkMineSite(r.Context(), w, h.MineSiteRepo, userID, req.MineSiteID) {
+		return
+	}
+
+	// A sale moved further back than the backdating limit needs approval again
+	newlyBackdated := false
+	if !date.Equal(income.Date) {
+		backdated, ok := backdatedDays(r.Context(), w, h.SettingsRepo, userID, date)
+		if !ok {
+			return
+		}
+		if backdated != nil {
+			income.ApprovalStatus, income.ReviewedByID, income.ReviewedAt = approval(r, data.PermIncomeApprove)
+			newlyBackdated = true
+		} else if income.BackdatedDays != nil && income.RiskFlag == nil {
+			income.ApprovalStatus = nil
+			income.ReviewedByID = nil
+			income.ReviewedAt = nil
+		}
+		income.BackdatedDays = backdated
+	}
+
+	// Check the flag of a new customer; sales already reviewed keep their approval, and backdated
+	// sales stay subject to it
+	if req.CustomerName != income.CustomerName {
+		income.CustomerName = req.CustomerName
+		income.RiskFlag = nil
+		if income.BackdatedDays == nil {
+			income.ApprovalStatus = nil
+			income.ReviewedByID = nil
+			income.ReviewedAt = nil
+		}
+		if !applyRiskFlag(w, r, h.FlagRepo, income) {
+			return
+		}
+	}
+
 	// Update income record
+	previouslyPaid := income.AmountPaid
 	income.Date = date
 	income.ItemName = req.ItemName
 	income.MineralType = mineralType
@@ -340,14 +555,24 @@ func (h *IncomeHandler) UpdateIncome(w http.ResponseWriter, r *http.Request) {
 	income.PaymentStatus = paymentStatus
 	income.AmountPaid = req.AmountPaid
 	income.AmountDue = amountDue
+	income.DueDate = dueDate
 	income.Notes = req.Notes
+	income.MineSiteID = req.MineSiteID
 
-	err = h.IncomeRepo.Update(income)
+	err = h.IncomeRepo.Update(r.Context(), income)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to update income record")
 		return
 	}
 
+	if newlyBackdated {
+		auditBackdated(h.AuditRepo, r, "income", income.ID, income.Date, *income.BackdatedDays, income.ApprovalStatus)
+	}
+
+	// Issue a receipt for the newly received part of the payment
+	issuePaymentReceipt(r.Context(), h.SettingsRepo, h.ReceiptRepo, income, income.AmountPaid-previouslyPaid)
+	h.publishPayment(r, income, income.AmountPaid-previouslyPaid)
+
 	utils.WriteSuccessResponse(w, "Income record updated successfully", income)
 }
 
@@ -366,7 +591,7 @@ func (h *IncomeHandler) DeleteIncome(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.IncomeRepo.Delete(uint(id), userID)
+	err = h.IncomeRepo.Delete(r.Context(), uint(id), userID)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to delete income record")
 		return
@@ -375,7 +600,81 @@ func (h *IncomeHandler) DeleteIncome(w http.ResponseWriter, r *http.Request) {
 	utils.WriteSuccessResponse(w, "Income record deleted successfully", nil)
 }
 
-// GetIncomeByDateRange retrieves income records within a date range
+// GetIncomeTrash retrieves the income records deleted and not yet permanently removed, most recently
+// deleted first
+func (h *IncomeHandler) GetIncomeTrash(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	records, err := h.IncomeRepo.GetDeleted(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve deleted income records")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Deleted income records retrieved successfully", records)
+}
+
+// RestoreIncome brings back a deleted income record
+func (h *IncomeHandler) RestoreIncome(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid income ID")
+		return
+	}
+
+	record, err := h.IncomeRepo.Restore(r.Context(), uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Deleted income record not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to restore income record")
+		return
+	}
+	auditTrash(h.AuditRepo, r, data.AuditRecordRestored, "income", record.ID)
+
+	utils.WriteSuccessResponse(w, "Income record restored successfully", record)
+}
+
+// PurgeIncome permanently deletes a income record from the trash. Only deleted income records can be purged.
+func (h *IncomeHandler) PurgeIncome(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid income ID")
+		return
+	}
+
+	if err := h.IncomeRepo.Purge(r.Context(), uint(id), userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Deleted income record not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to permanently delete income record")
+		return
+	}
+	auditTrash(h.AuditRepo, r, data.AuditRecordPurged, "income", uint(id))
+
+	utils.WriteSuccessResponse(w, "Income record permanently deleted successfully", nil)
+}
+
+// GetIncomeByDateRange retrieves income records within a date range, or the archived ones with
+// archived=true
 func (h *IncomeHandler) GetIncomeByDateRange(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserIDFromRequest(r)
 	if userID == 0 {
@@ -391,7 +690,17 @@ func (h *IncomeHandler) GetIncomeByDateRange(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	incomes, err := h.IncomeRepo.GetByDateRange(userID, startDate, endDate)
+	if r.URL.Query().Get("archived") == "true" {
+		archived, err := h.IncomeRepo.GetArchived(r.Context(), userID, startDate, endDate)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to retrieve archived income records")
+			return
+		}
+		utils.WriteSuccessResponse(w, "Archived income records retrieved successfully", archived)
+		return
+	}
+
+	incomes, err := h.IncomeRepo.GetByDateRange(r.Context(), userID, startDate, endDate)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to retrieve income records")
 		return
@@ -399,3 +708,102 @@ func (h *IncomeHandler) GetIncomeByDateRange(w http.ResponseWriter, r *http.Requ
 
 	utils.WriteSuccessResponse(w, "Income records retrieved successfully", incomes)
 }
+
+// GetPendingApprovals retrieves sales to flagged customers and backdated sales awaiting approval
+func (h *IncomeHandler) GetPendingApprovals(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	incomes, err := h.IncomeRepo.GetPendingApproval(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve sales pending approval")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Sales pending approval retrieved successfully", incomes)
+}
+
+// ApproveIncome approves a sale pending approval (owner/manager)
+func (h *IncomeHandler) ApproveIncome(w http.ResponseWriter, r *http.Request) {
+	h.reviewIncome(w, r, data.SaleApproved)
+}
+
+// RejectIncome rejects a sale pending approval with a reason, removing it from the books
+// (owner/manager)
+func (h *IncomeHandler) RejectIncome(w http.ResponseWriter, r *http.Request) {
+	h.reviewIncome(w, r, data.SaleRejected)
+}
+
+// reviewIncome approves or rejects a sale pending approval
+func (h *IncomeHandler) reviewIncome(w http.ResponseWriter, r *http.Request, status data.SaleApproval) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+	if !requirePermission(w, r, data.PermIncomeApprove, "You do not have permission to review sales pending approval") {
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid income ID")
+		return
+	}
+
+	var reason *string
+	if status == data.SaleRejected {
+		var req RejectIncomeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.WriteValidationError(w, "Invalid request body")
+			return
+		}
+		if !utils.ValidateRequired(req.Reason) {
+			utils.WriteValidationError(w, "Rejection reason is required")
+			return
+		}
+		reason = &req.Reason
+	}
+
+	err = h.IncomeRepo.Review(r.Context(), uint(id), userID, status, middleware.GetActorIDFromRequest(r), reason)
+	if err != nil {
+		if errors.Is(err, data.ErrSaleReviewed) {
+			utils.WriteValidationError(w, "Only sales pending approval can be reviewed")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to review sale")
+		return
+	}
+
+	if status == data.SaleRejected {
+		utils.WriteSuccessResponse(w, "Sale rejected successfully", nil)
+		return
+	}
+
+	income, err := h.IncomeRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve approved sale")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Sale approved successfully", income)
+}
+
+// publishPayment publishes a payment received on an income record
+func (h *IncomeHandler) publishPayment(r *http.Request, income *data.Income, amount float64) {
+	if amount <= 0 {
+		return
+	}
+	publishEvent(h.Events, r, events.PaymentRecorded, "income", income.ID, events.Payment{
+		IncomeID:      income.ID,
+		CustomerName:  income.CustomerName,
+		Amount:        amount,
+		AmountPaid:    income.AmountPaid,
+		AmountDue:     income.AmountDue,
+		PaymentStatus: string(income.PaymentStatus),
+	})
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"mineral/data"
+	"mineral/data/mocks"
+	"mineral/pkg/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// newTestIncomeHandler returns an IncomeHandler with the default settings whose customers have no
+// flags or credit limits
+func newTestIncomeHandler(ctrl *gomock.Controller, incomeRepo *mocks.MockIncomeInterface) *IncomeHandler {
+	flagRepo := mocks.NewMockFlagInterface(ctrl)
+	flagRepo.EXPECT().GetFlag(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+	creditLimitRepo := mocks.NewMockCreditLimitInterface(ctrl)
+	creditLimitRepo.EXPECT().GetByCustomer(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+	settingsRepo := mocks.NewMockSettingsInterface(ctrl)
+	settingsRepo.EXPECT().GetByUserID(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, userID uint) (*data.OrganizationSettings, error) {
+		return data.DefaultOrganizationSettings(userID), nil
+	}).AnyTimes()
+	return NewIncomeHandler(incomeRepo, settingsRepo, nil, creditLimitRepo, flagRepo, nil)
+}
+
+func TestCreateIncome(t *testing.T) {
+	valid := `{"date":"2024-03-01","mineral_type":"gold","quantity":2,"unit":"g","price_per_unit":50,` +
+		`"customer_name":"Acme","payment_status":"paid","amount_paid":100}`
+
+	tests := []struct {
+		name      string
+		userID    uint
+		body      string
+		insertErr error
+		status    int
+		inserted  bool
+	}{
+		{name: "unauthenticated", body: valid, status: http.StatusUnauthorized},
+		{name: "invalid body", userID: 1, body: `{`, status: http.StatusBadRequest},
+		{name: "missing date", userID: 1, body: `{"mineral_type":"gold","quantity":1,"unit":"g","price_per_unit":1,"customer_name":"Acme","payment_status":"paid"}`, status: http.StatusBadRequest},
+		{name: "zero quantity", userID: 1, body: `{"date":"2024-03-01","mineral_type":"gold","unit":"g","price_per_unit":1,"customer_name":"Acme","payment_status":"paid"}`, status: http.StatusBadRequest},
+		{name: "invalid date", userID: 1, body: `{"date":"01/03/2024","mineral_type":"gold","quantity":1,"unit":"g","price_per_unit":1,"customer_name":"Acme","payment_status":"paid"}`, status: http.StatusBadRequest},
+		{name: "invalid payment status", userID: 1, body: `{"date":"2024-03-01","mineral_type":"gold","quantity":1,"unit":"g","price_per_unit":1,"customer_name":"Acme","payment_status":"later"}`, status: http.StatusBadRequest},
+		{name: "insert fails", userID: 1, body: valid, insertErr: errors.New("db down"), status: http.StatusInternalServerError, inserted: true},
+		{name: "created", userID: 1, body: valid, status: http.StatusOK, inserted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			var got *data.Income
+			incomeRepo := mocks.NewMockIncomeInterface(ctrl)
+			if tt.inserted {
+				incomeRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, income *data.Income) (uint, error) {
+					got = income
+					return 7, tt.insertErr
+				})
+			}
+			h := newTestIncomeHandler(ctrl, incomeRepo)
+
+			rr := serve(h.CreateIncome, http.MethodPost, tt.userID, tt.body, nil)
+
+			if rr.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.status, rr.Body.String())
+			}
+			if tt.status == http.StatusOK {
+				if got.UserID != tt.userID || got.TotalAmount != 100 || got.AmountDue != 0 {
+					t.Errorf("inserted income = user %d, total %v, due %v; want user %d, total 100, due 0",
+						got.UserID, got.TotalAmount, got.AmountDue, tt.userID)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateIncomeBackdated(t *testing.T) {
+	today := time.Now().Format("2006-01-02")
+	tests := []struct {
+		name    string
+		date    string
+		limit   int
+		pending bool
+	}{
+		{name: "no limit", date: "2024-03-01"},
+		{name: "within limit", date: today, limit: 30},
+		{name: "backdated", date: "2024-03-01", limit: 30, pending: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			var got *data.Income
+			incomeRepo := mocks.NewMockIncomeInterface(ctrl)
+			incomeRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, income *data.Income) (uint, error) {
+				got = income
+				return 7, nil
+			})
+			h := newTestIncomeHandler(ctrl, incomeRepo)
+			settingsRepo := mocks.NewMockSettingsInterface(ctrl)
+			settingsRepo.EXPECT().GetByUserID(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, userID uint) (*data.OrganizationSettings, error) {
+				settings := data.DefaultOrganizationSettings(userID)
+				settings.BackdateApprovalDays = tt.limit
+				return settings, nil
+			}).AnyTimes()
+			h.SettingsRepo = settingsRepo
+			body := `{"date":"` + tt.date + `","mineral_type":"gold","quantity":2,"unit":"g","price_per_unit":50,` +
+				`"customer_name":"Acme","payment_status":"paid","amount_paid":100}`
+
+			rr := serve(h.CreateIncome, http.MethodPost, 1, body, nil)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, http.StatusOK, rr.Body.String())
+			}
+			pending := got.ApprovalStatus != nil && *got.ApprovalStatus == data.SalePendingApproval
+			if pending != tt.pending || (got.BackdatedDays != nil) != tt.pending {
+				t.Errorf("pending = %v, backdated days = %v; want pending %v", pending, got.BackdatedDays, tt.pending)
+			}
+		})
+	}
+}
+
+func TestGetIncome(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID uint
+		id     string
+		status int
+	}{
+		{name: "unauthenticated", id: "1", status: http.StatusUnauthorized},
+		{name: "invalid id", userID: 1, id: "abc", status: http.StatusBadRequest},
+		{name: "not found", userID: 1, id: "2", status: http.StatusNotFound},
+		{name: "found", userID: 1, id: "1", status: http.StatusOK},
+	}
+
+	ctrl := gomock.NewController(t)
+	incomeRepo := mocks.NewMockIncomeInterface(ctrl)
+	incomeRepo.EXPECT().GetOne(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, id uint, userID uint) (*data.Income, error) {
+		if id != 1 || userID != 1 {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return &data.Income{UserID: userID}, nil
+	}).AnyTimes()
+	h := newTestIncomeHandler(ctrl, incomeRepo)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := serve(h.GetIncome, http.MethodGet, tt.userID, "", map[string]string{"id": tt.id})
+			if rr.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.status, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetAllIncomesFiltered(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		status int
+		filter data.IncomeFilter
+	}{
+		{name: "invalid mineral type", query: "mineral_type=gld", status: http.StatusBadRequest},
+		{name: "invalid payment status", query: "payment_status=overdue", status: http.StatusBadRequest},
+		{name: "invalid sort", query: "sort=notes", status: http.StatusBadRequest},
+		{name: "invalid order", query: "sort=total_amount&order=up", status: http.StatusBadRequest},
+		{
+			name:   "filtered and sorted",
+			query:  "mineral_type=gold&payment_status=partial&sort=total_amount&order=desc",
+			status: http.StatusOK,
+			filter: data.IncomeFilter{MineralType: data.MineralGold, PaymentStatus: data.PaymentPartial, Sort: "total_amount", Descending: true},
+		},
+		{name: "sorted ascending", query: "sort=customer_name", status: http.StatusOK, filter: data.IncomeFilter{Sort: "customer_name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			var got *data.IncomeFilter
+			incomeRepo := mocks.NewMockIncomeInterface(ctrl)
+			incomeRepo.EXPECT().GetPage(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, userID uint, filter data.IncomeFilter, o, l int) ([]*data.Income, int64, error) {
+					got = &filter
+					return []*data.Income{}, 0, nil
+				}).AnyTimes()
+			h := newTestIncomeHandler(ctrl, incomeRepo)
+
+			req := httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+			req = req.WithContext(middleware.ContextWithUser(req.Context(), middleware.AuthUser{ID: 1}))
+			rr := httptest.NewRecorder()
+			h.GetAllIncomes(rr, req)
+			if rr.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.status, rr.Body.String())
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			if got == nil || *got != tt.filter {
+				t.Errorf("filter = %+v, want %+v", got, tt.filter)
+			}
+		})
+	}
+}
+
+func TestDeleteIncome(t *testing.T) {
+	tests := []struct {
+		name      string
+		userID    uint
+		id        string
+		deleteErr error
+		status    int
+	}{
+		{name: "unauthenticated", id: "1", status: http.StatusUnauthorized},
+		{name: "invalid id", userID: 1, id: "-1", status: http.StatusBadRequest},
+		{name: "delete fails", userID: 1, id: "1", deleteErr: errors.New("db down"), status: http.StatusInternalServerError},
+		{name: "deleted", userID: 1, id: "1", status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			incomeRepo := mocks.NewMockIncomeInterface(ctrl)
+			incomeRepo.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(tt.deleteErr).AnyTimes()
+			h := newTestIncomeHandler(ctrl, incomeRepo)
+
+			rr := serve(h.DeleteIncome, http.MethodDelete, tt.userID, "", map[string]string{"id": tt.id})
+			if rr.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.status, rr.Body.String())
+			}
+		})
+	}
+}
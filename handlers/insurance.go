@@ -0,0 +1,590 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// InsuranceHandler handles insurance policies and the claims made on them
+type InsuranceHandler struct {
+	InsuranceRepo data.InsuranceInterface
+	EquipmentRepo data.EquipmentInterface
+	VehicleRepo   data.VehicleInterface
+
+	// MineSiteRepo checks the mine sites policies cover; policies can't cover a site when it is
+	// nil
+	MineSiteRepo data.MineSiteInterface
+}
+
+// NewInsuranceHandler creates a new InsuranceHandler
+func NewInsuranceHandler(insuranceRepo data.InsuranceInterface, equipmentRepo data.EquipmentInterface, vehicleRepo data.VehicleInterface) *InsuranceHandler {
+	return &InsuranceHandler{
+		InsuranceRepo: insuranceRepo,
+		EquipmentRepo: equipmentRepo,
+		VehicleRepo:   vehicleRepo,
+	}
+}
+
+// PolicyRequest represents a create or update insurance policy request
+type PolicyRequest struct {
+	Insurer      string  `json:"insurer"`
+	PolicyNumber string  `json:"policy_number"`
+	CoverageType string  `json:"coverage_type"` // equipment, vehicle, property, liability or other
+	SumInsured   float64 `json:"sum_insured"`
+	Premium      float64 `json:"premium"`
+	StartDate    string  `json:"start_date"`
+	ExpiryDate   string  `json:"expiry_date"`
+	EquipmentID  *uint   `json:"equipment_id,omitempty"`
+	VehicleID    *uint   `json:"vehicle_id,omitempty"`
+	MineSiteID   *uint   `json:"mine_site_id,omitempty"`
+	Notes        *string `json:"notes,omitempty"`
+}
+
+// InsuranceClaimRequest represents a create or update insurance claim request
+type InsuranceClaimRequest struct {
+	PolicyID            uint    `json:"policy_id"`
+	ClaimNumber         *string `json:"claim_number,omitempty"`
+	IncidentDate        string  `json:"incident_date"`
+	IncidentDescription string  `json:"incident_description"`
+	EquipmentID         *uint   `json:"equipment_id,omitempty"` // Equipment damaged or lost
+	AmountClaimed       float64 `json:"amount_claimed"`
+	Status              *string `json:"status,omitempty"` // open, approved or rejected; updates only
+	Notes               *string `json:"notes,omitempty"`
+}
+
+// PayoutRequest represents the payout received on an insurance claim
+type PayoutRequest struct {
+	Amount float64 `json:"amount"`
+	Date   string  `json:"date"`
+	Notes  *string `json:"notes,omitempty"`
+}
+
+// GetPolicies retrieves the insurance policies of the authenticated user
+func (h *InsuranceHandler) GetPolicies(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	policies, err := h.InsuranceRepo.GetPolicies(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve policies")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Policies retrieved successfully", policies)
+}
+
+// GetPolicy retrieves a specific insurance policy with its claims
+func (h *InsuranceHandler) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid policy ID")
+		return
+	}
+
+	policy, err := h.InsuranceRepo.GetPolicy(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Policy not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Policy retrieved successfully", policy)
+}
+
+// CreatePolicy records a new insurance policy
+func (h *InsuranceHandler) CreatePolicy(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req PolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	policy := &data.InsurancePolicy{UserID: userID}
+	if !h.applyPolicyRequest(r.Context(), w, userID, &req, policy) {
+		return
+	}
+
+	policyID, err := h.InsuranceRepo.InsertPolicy(r.Context(), policy)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to create policy")
+		return
+	}
+
+	policy.ID = policyID
+	utils.WriteSuccessResponse(w, "Policy created successfully", policy)
+}
+
+// UpdatePolicy updates an insurance policy, such as when it is renewed
+func (h *InsuranceHandler) UpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid policy ID")
+		return
+	}
+
+	var req PolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	policy, err := h.InsuranceRepo.GetPolicy(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Policy not found")
+		return
+	}
+	if !h.applyPolicyRequest(r.Context(), w, userID, &req, policy) {
+		return
+	}
+
+	if err := h.InsuranceRepo.UpdatePolicy(r.Context(), policy); err != nil {
+		utils.WriteInternalServerError(w, "Failed to update policy")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Policy updated successfully", policy)
+}
+
+// DeletePolicy deletes an insurance policy no claims have been made on
+func (h *InsuranceHandler) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid policy ID")
+		return
+	}
+
+	if err := h.InsuranceRepo.DeletePolicy(r.Context(), uint(id), userID); err != nil {
+		if errors.Is(err, data.ErrPolicyHasClaims) {
+			utils.WriteErrorResponse(w, "Policies with claims can't be deleted", http.StatusConflict)
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to delete policy")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Policy deleted successfully", nil)
+}
+
+// GetInsuranceClaims retrieves insurance claims, optionally filtered by policy_id and status
+func (h *InsuranceHandler) GetInsuranceClaims(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var policyID *uint
+	if value := r.URL.Query().Get("policy_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid policy ID")
+			return
+		}
+		policy := uint(id)
+		policyID = &policy
+	}
+
+	var status *data.InsuranceClaimStatus
+	if value := r.URL.Query().Get("status"); value != "" {
+		s := data.InsuranceClaimStatus(value)
+		if s != data.InsuranceClaimOpen && s != data.InsuranceClaimApproved && s != data.InsuranceClaimRejected && s != data.InsuranceClaimPaid {
+			utils.WriteValidationError(w, "Status must be open, approved, rejected or paid")
+			return
+		}
+		status = &s
+	}
+
+	claims, err := h.InsuranceRepo.GetClaims(r.Context(), userID, policyID, status)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve claims")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Claims retrieved successfully", claims)
+}
+
+// GetInsuranceClaim retrieves a specific insurance claim with its policy
+func (h *InsuranceHandler) GetInsuranceClaim(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid claim ID")
+		return
+	}
+
+	claim, err := h.InsuranceRepo.GetClaim(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Claim not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Claim retrieved successfully", claim)
+}
+
+// CreateInsuranceClaim opens a claim on an insurance policy for an incident
+func (h *InsuranceHandler) CreateInsuranceClaim(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req InsuranceClaimRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if req.Status != nil {
+		utils.WriteValidationError(w, "New claims are open; set the status when updating them")
+		return
+	}
+
+	claim := &data.InsuranceClaim{UserID: userID}
+	if !h.applyClaimRequest(r.Context(), w, userID, &req, claim) {
+		return
+	}
+
+	claimID, err := h.InsuranceRepo.InsertClaim(r.Context(), claim)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to create claim")
+		return
+	}
+
+	claim.ID = claimID
+	utils.WriteSuccessResponse(w, "Claim created successfully", claim)
+}
+
+// UpdateInsuranceClaim updates an insurance claim and where it is with the insurer. Paid out
+// claims can't be changed.
+func (h *InsuranceHandler) UpdateInsuranceClaim(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid claim ID")
+		return
+	}
+
+	var req InsuranceClaimRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	claim, err := h.InsuranceRepo.GetClaim(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Claim not found")
+		return
+	}
+	if claim.Status == data.InsuranceClaimPaid {
+		utils.WriteErrorResponse(w, "Paid out claims can't be changed", http.StatusConflict)
+		return
+	}
+	if req.Status != nil {
+		status := data.InsuranceClaimStatus(*req.Status)
+		if status != data.InsuranceClaimOpen && status != data.InsuranceClaimApproved && status != data.InsuranceClaimRejected {
+			utils.WriteValidationError(w, "Status must be open, approved or rejected; record the payout of paid claims")
+			return
+		}
+		claim.Status = status
+	}
+	if !h.applyClaimRequest(r.Context(), w, userID, &req, claim) {
+		return
+	}
+
+	if err := h.InsuranceRepo.UpdateClaim(r.Context(), claim); err != nil {
+		if errors.Is(err, data.ErrInsuranceClaimPaid) {
+			utils.WriteErrorResponse(w, "Paid out claims can't be changed", http.StatusConflict)
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to update claim")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Claim updated successfully", claim)
+}
+
+// DeleteInsuranceClaim deletes an insurance claim that hasn't been paid out
+func (h *InsuranceHandler) DeleteInsuranceClaim(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid claim ID")
+		return
+	}
+
+	if err := h.InsuranceRepo.DeleteClaim(r.Context(), uint(id), userID); err != nil {
+		if errors.Is(err, data.ErrInsuranceClaimPaid) {
+			utils.WriteErrorResponse(w, "Paid out claims can't be deleted", http.StatusConflict)
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to delete claim")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Claim deleted successfully", nil)
+}
+
+// RecordPayout records the payout received on an insurance claim, booking it as a sale to the
+// insurer paid in full
+func (h *InsuranceHandler) RecordPayout(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid claim ID")
+		return
+	}
+
+	var req PayoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	if !utils.ValidatePositiveNumber(req.Amount) {
+		utils.WriteValidationError(w, "Amount must be positive")
+		return
+	}
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid date format. Use YYYY-MM-DD")
+		return
+	}
+
+	claim, err := h.InsuranceRepo.GetClaim(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Claim not found")
+		return
+	}
+	if date.Before(claim.IncidentDate) {
+		utils.WriteValidationError(w, "Payout date can't be before the incident date")
+		return
+	}
+
+	itemName := fmt.Sprintf("Insurance claim %d", claim.ID)
+	if claim.ClaimNumber != nil && *claim.ClaimNumber != "" {
+		itemName = fmt.Sprintf("Insurance claim %s", *claim.ClaimNumber)
+	}
+	notes := req.Notes
+	if notes == nil {
+		notes = &claim.IncidentDescription
+	}
+	payout := &data.Income{
+		Date:         date,
+		ItemName:     &itemName,
+		MineralType:  data.MineralOther,
+		SalesType:    data.SalesTypeInsurance,
+		Quantity:     1,
+		Unit:         "unit",
+		PricePerUnit: req.Amount,
+		CustomerName: claim.Policy.Insurer,
+		Notes:        notes,
+		MineSiteID:   claim.Policy.MineSiteID,
+		UserID:       userID,
+	}
+
+	paid, err := h.InsuranceRepo.RecordPayout(r.Context(), uint(id), userID, payout)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrInsuranceClaimPaid):
+			utils.WriteErrorResponse(w, "The payout of the claim has already been recorded", http.StatusConflict)
+		case errors.Is(err, data.ErrInsuranceClaimRejected):
+			utils.WriteErrorResponse(w, "Rejected claims have no payout", http.StatusConflict)
+		default:
+			utils.WriteInternalServerError(w, "Failed to record payout")
+		}
+		return
+	}
+
+	response := map[string]interface{}{
+		"claim":  paid,
+		"income": payout,
+	}
+
+	utils.WriteSuccessResponse(w, "Payout recorded successfully", response)
+}
+
+// applyPolicyRequest validates a create or update insurance policy request and applies it to a
+// policy. It writes the error response and returns false when the request is invalid.
+func (h *InsuranceHandler) applyPolicyRequest(ctx context.Context, w http.ResponseWriter, userID uint, req *PolicyRequest, policy *data.InsurancePolicy) bool {
+	if !utils.ValidateRequired(req.Insurer) {
+		utils.WriteValidationError(w, "Insurer is required")
+		return false
+	}
+	if !utils.ValidateRequired(req.PolicyNumber) {
+		utils.WriteValidationError(w, "Policy number is required")
+		return false
+	}
+	coverage := data.CoverageType(req.CoverageType)
+	switch coverage {
+	case data.CoverageEquipment, data.CoverageVehicle, data.CoverageProperty, data.CoverageLiability, data.CoverageOther:
+	default:
+		utils.WriteValidationError(w, "Coverage type must be equipment, vehicle, property, liability or other")
+		return false
+	}
+	if !utils.ValidateNonNegativeNumber(req.SumInsured) || !utils.ValidateNonNegativeNumber(req.Premium) {
+		utils.WriteValidationError(w, "Sum insured and premium can't be negative")
+		return false
+	}
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid start date format. Use YYYY-MM-DD")
+		return false
+	}
+	expiryDate, err := time.Parse("2006-01-02", req.ExpiryDate)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid expiry date format. Use YYYY-MM-DD")
+		return false
+	}
+	if !expiryDate.After(startDate) {
+		utils.WriteValidationError(w, "Expiry date must be after the start date")
+		return false
+	}
+
+	if req.EquipmentID != nil {
+		if _, err := h.EquipmentRepo.GetOne(ctx, *req.EquipmentID, userID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				utils.WriteValidationError(w, "Equipment not found")
+				return false
+			}
+			utils.WriteInternalServerError(w, "Failed to check equipment")
+			return false
+		}
+	}
+	if req.VehicleID != nil {
+		if _, err := h.VehicleRepo.GetOne(ctx, *req.VehicleID, userID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				utils.WriteValidationError(w, "Vehicle not found")
+				return false
+			}
+			utils.WriteInternalServerError(w, "Failed to check vehicle")
+			return false
+		}
+	}
+	if !checkMineSite(ctx, w, h.MineSiteRepo, userID, req.MineSiteID) {
+		return false
+	}
+
+	policy.Insurer = req.Insurer
+	policy.PolicyNumber = req.PolicyNumber
+	policy.CoverageType = coverage
+	policy.SumInsured = req.SumInsured
+	policy.Premium = req.Premium
+	policy.StartDate = startDate
+	policy.ExpiryDate = expiryDate
+	policy.EquipmentID = req.EquipmentID
+	policy.VehicleID = req.VehicleID
+	policy.MineSiteID = req.MineSiteID
+	policy.Notes = req.Notes
+	return true
+}
+
+// applyClaimRequest validates a create or update insurance claim request and applies it to a
+// claim. The incident must fall within the period of the policy. It writes the error response
+// and returns false when the request is invalid.
+func (h *InsuranceHandler) applyClaimRequest(ctx context.Context, w http.ResponseWriter, userID uint, req *InsuranceClaimRequest, claim *data.InsuranceClaim) bool {
+	if !utils.ValidateRequired(req.IncidentDescription) {
+		utils.WriteValidationError(w, "Incident description is required")
+		return false
+	}
+	if !utils.ValidatePositiveNumber(req.AmountClaimed) {
+		utils.WriteValidationError(w, "Amount claimed must be positive")
+		return false
+	}
+	incidentDate, err := time.Parse("2006-01-02", req.IncidentDate)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid incident date format. Use YYYY-MM-DD")
+		return false
+	}
+
+	policy, err := h.InsuranceRepo.GetPolicy(ctx, req.PolicyID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteValidationError(w, "Policy not found")
+			return false
+		}
+		utils.WriteInternalServerError(w, "Failed to check policy")
+		return false
+	}
+	if incidentDate.Before(policy.StartDate) || incidentDate.After(policy.ExpiryDate) {
+		utils.WriteValidationError(w, "Incident date must be within the policy period")
+		return false
+	}
+	if req.EquipmentID != nil {
+		if _, err := h.EquipmentRepo.GetOne(ctx, *req.EquipmentID, userID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				utils.WriteValidationError(w, "Equipment not found")
+				return false
+			}
+			utils.WriteInternalServerError(w, "Failed to check equipment")
+			return false
+		}
+	}
+
+	policy.Claims = nil
+	claim.PolicyID = policy.ID
+	claim.Policy = policy
+	claim.ClaimNumber = req.ClaimNumber
+	claim.IncidentDate = incidentDate
+	claim.IncidentDescription = req.IncidentDescription
+	claim.EquipmentID = req.EquipmentID
+	claim.AmountClaimed = req.AmountClaimed
+	claim.Notes = req.Notes
+	return true
+}
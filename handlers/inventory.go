@@ -1,8 +1,13 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"mineral/data"
+	"mineral/pkg/events"
 	"mineral/pkg/middleware"
 	"mineral/pkg/utils"
 	"net/http"
@@ -10,58 +15,109 @@ import (
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
 )
 
 // InventoryHandler handles inventory-related requests
 type InventoryHandler struct {
-	InventoryRepo data.InventoryInterface
+	InventoryRepo    data.InventoryInterface
+	NotificationRepo data.NotificationInterface
+	EvidenceRepo     data.EvidenceInterface
+	Events           *events.Bus
+
+	// Quota limits the photos uploaded to the attachment storage quota; uploads aren't limited
+	// when it is nil
+	Quota *AttachmentQuota
+
+	// MineSiteRepo checks the mine sites records are assigned to; records can't be assigned to a
+	// site when it is nil
+	MineSiteRepo data.MineSiteInterface
+
+	// AuditRepo records stock write-offs in the audit log when set
+	AuditRepo data.AuditInterface
+
+	// SettingsRepo supplies the rainy season months of seasonal stock plans, which follow
+	// data.DefaultRainySeasonMonths when it is nil, and SupplyPriceRepo prices them from the supply
+	// price list when set
+	SettingsRepo    data.SettingsInterface
+	SupplyPriceRepo data.SupplyPriceInterface
 }
 
 // NewInventoryHandler creates a new InventoryHandler
-func NewInventoryHandler(inventoryRepo data.InventoryInterface) *InventoryHandler {
+func NewInventoryHandler(inventoryRepo data.InventoryInterface, notificationRepo data.NotificationInterface, evidenceRepo data.EvidenceInterface, bus *events.Bus) *InventoryHandler {
 	return &InventoryHandler{
-		InventoryRepo: inventoryRepo,
+		InventoryRepo:    inventoryRepo,
+		NotificationRepo: notificationRepo,
+		EvidenceRepo:     evidenceRepo,
+		Events:           bus,
 	}
 }
 
 // CreateInventoryRequest represents a create inventory request
 type CreateInventoryRequest struct {
-	Name             string  `json:"name"`
-	Type             string  `json:"type"`
-	From             *string `json:"from,omitempty"` // "mine" or "processing"
-	PitNumber        *string `json:"pit_number,omitempty"`
-	MinerName        *string `json:"miner_name,omitempty"`
-	BatchNumber      *string `json:"batch_number,omitempty"`
-	ProcessingMethod *string `json:"processing_method,omitempty"`
-	Quantity         float64 `json:"quantity"`
-	Unit             string  `json:"unit"`
-	MinStockLevel    float64 `json:"min_stock_level"`
-	CurrentValue     float64 `json:"current_value"`
-	LastUpdated      *string `json:"last_updated,omitempty"` // Date string for production records
+	Name              string   `json:"name"`
+	Type              string   `json:"type"`
+	From              *string  `json:"from,omitempty"` // "mine" or "processing"
+	PitNumber         *string  `json:"pit_number,omitempty"`
+	MinerName         *string  `json:"miner_name,omitempty"`
+	BatchNumber       *string  `json:"batch_number,omitempty"`
+	ProcessingMethod  *string  `json:"processing_method,omitempty"`
+	Quantity          float64  `json:"quantity"`
+	Unit              string   `json:"unit"`
+	MinStockLevel     float64  `json:"min_stock_level"`
+	CurrentValue      float64  `json:"current_value"`
+	LastUpdated       *string  `json:"last_updated,omitempty"` // Date string for production records
+	ExpiryDate        *string  `json:"expiry_date,omitempty"`  // YYYY-MM-DD, supply items only
+	IsHazardous       bool     `json:"is_hazardous"`
+	HazardClass       *string  `json:"hazard_class,omitempty"`
+	PermittedQuantity *float64 `json:"permitted_quantity,omitempty"`
+	MonthlyUsageLimit *float64 `json:"monthly_usage_limit,omitempty"`
+	MineSiteID        *uint    `json:"mine_site_id,omitempty"` // site the stock is kept at
 }
 
 // UpdateInventoryRequest represents an update inventory request
 type UpdateInventoryRequest struct {
-	Name             string  `json:"name"`
-	Type             string  `json:"type"`
-	From             *string `json:"from,omitempty"` // "mine" or "processing"
-	PitNumber        *string `json:"pit_number,omitempty"`
-	MinerName        *string `json:"miner_name,omitempty"`
-	BatchNumber      *string `json:"batch_number,omitempty"`
-	ProcessingMethod *string `json:"processing_method,omitempty"`
-	Quantity         float64 `json:"quantity"`
-	Unit             string  `json:"unit"`
-	MinStockLevel    float64 `json:"min_stock_level"`
-	CurrentValue     float64 `json:"current_value"`
-	LastUpdated      *string `json:"last_updated,omitempty"` // Date string for production records
+	Name              string   `json:"name"`
+	Type              string   `json:"type"`
+	From              *string  `json:"from,omitempty"` // "mine" or "processing"
+	PitNumber         *string  `json:"pit_number,omitempty"`
+	MinerName         *string  `json:"miner_name,omitempty"`
+	BatchNumber       *string  `json:"batch_number,omitempty"`
+	ProcessingMethod  *string  `json:"processing_method,omitempty"`
+	Quantity          float64  `json:"quantity"`
+	Unit              string   `json:"unit"`
+	MinStockLevel     float64  `json:"min_stock_level"`
+	CurrentValue      float64  `json:"current_value"`
+	LastUpdated       *string  `json:"last_updated,omitempty"` // Date string for production records
+	ExpiryDate        *string  `json:"expiry_date,omitempty"`  // YYYY-MM-DD, supply items only
+	IsHazardous       bool     `json:"is_hazardous"`
+	HazardClass       *string  `json:"hazard_class,omitempty"`
+	PermittedQuantity *float64 `json:"permitted_quantity,omitempty"`
+	MonthlyUsageLimit *float64 `json:"monthly_usage_limit,omitempty"`
+	MineSiteID        *uint    `json:"mine_site_id,omitempty"` // site the stock is kept at
 }
 
 // UpdateQuantityRequest represents an update quantity request
 type UpdateQuantityRequest struct {
+	Quantity float64      `json:"quantity"`
+	Photo    *PhotoUpload `json:"photo,omitempty"` // Required by the evidence rules for stock adjustments
+}
+
+// RecordUsageRequest represents a request to record consumption of an item
+type RecordUsageRequest struct {
+	Quantity float64      `json:"quantity"`
+	Reason   *string      `json:"reason,omitempty"`
+	Photo    *PhotoUpload `json:"photo,omitempty"` // Required by the evidence rules for stock usage
+}
+
+// WriteOffStockRequest represents the write-off of damaged, expired, lost or stolen stock
+type WriteOffStockRequest struct {
 	Quantity float64 `json:"quantity"`
+	Reason   string  `json:"reason"`
 }
 
-// GetAllInventory retrieves all inventory items for the authenticated user
+// GetAllInventory retrieves all inventory items for the authenticated user, or a page of them
+// when the page or per_page query parameter is set. site_id narrows them to a mine site.
 func (h *InventoryHandler) GetAllInventory(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserIDFromRequest(r)
 	if userID == 0 {
@@ -69,13 +125,32 @@ func (h *InventoryHandler) GetAllInventory(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	items, err := h.InventoryRepo.GetAll(userID)
+	page, err := utils.ParsePage(r)
+	if err != nil {
+		utils.WriteValidationError(w, err.Error())
+		return
+	}
+	siteID, ok := parseSiteFilter(w, r)
+	if !ok {
+		return
+	}
+	if page == nil && siteID == nil {
+		items, err := h.InventoryRepo.GetAll(r.Context(), userID)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to retrieve inventory items")
+			return
+		}
+		utils.WriteSuccessResponse(w, "Inventory items retrieved successfully", items)
+		return
+	}
+
+	items, total, err := h.InventoryRepo.GetPage(r.Context(), userID, siteID, page.Offset(), page.Limit())
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to retrieve inventory items")
 		return
 	}
 
-	utils.WriteSuccessResponse(w, "Inventory items retrieved successfully", items)
+	utils.WriteListResponse(w, "Inventory items retrieved successfully", items, page, total)
 }
 
 // GetInventoryItem retrieves a specific inventory item
@@ -93,7 +168,7 @@ func (h *InventoryHandler) GetInventoryItem(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	item, err := h.InventoryRepo.GetOne(uint(id), userID)
+	item, err := h.InventoryRepo.GetOne(r.Context(), uint(id), userID)
 	if err != nil {
 		utils.WriteNotFoundError(w, "Inventory item not found")
 		return
@@ -145,6 +220,21 @@ func (h *InventoryHandler) CreateInventoryItem(w http.ResponseWriter, r *http.Re
 		utils.WriteValidationError(w, "Current value cannot be negative")
 		return
 	}
+	if req.PermittedQuantity != nil && !utils.ValidateNonNegativeNumber(*req.PermittedQuantity) {
+		utils.WriteValidationError(w, "Permitted quantity cannot be negative")
+		return
+	}
+	if req.MonthlyUsageLimit != nil && !utils.ValidateNonNegativeNumber(*req.MonthlyUsageLimit) {
+		utils.WriteValidationError(w, "Monthly usage limit cannot be negative")
+		return
+	}
+
+	// Parse ExpiryDate if provided (only meaningful for supplies such as reagents)
+	expiryDate, err := parseExpiryDate(req.Type, req.ExpiryDate)
+	if err != nil {
+		utils.WriteValidationError(w, err.Error())
+		return
+	}
 
 	// Parse LastUpdated if provided
 	var lastUpdated time.Time
@@ -173,30 +263,43 @@ func (h *InventoryHandler) CreateInventoryItem(w http.ResponseWriter, r *http.Re
 		processingMethod = &methodVal
 	}
 
+	// Records can only be assigned to the user's own mine sites
+	if !checkMineSite(r.Context(), w, h.MineSiteRepo, userID, req.MineSiteID) {
+		return
+	}
+
 	// Create inventory item
 	item := &data.InventoryItem{
-		Name:             req.Name,
-		Type:             req.Type,
-		From:             from,
-		PitNumber:        req.PitNumber,
-		MinerName:        req.MinerName,
-		BatchNumber:      req.BatchNumber,
-		ProcessingMethod: processingMethod,
-		Quantity:         req.Quantity,
-		Unit:             req.Unit,
-		MinStockLevel:    req.MinStockLevel,
-		CurrentValue:     req.CurrentValue,
-		LastUpdated:      lastUpdated,
-		UserID:           userID,
-	}
-
-	itemID, err := h.InventoryRepo.Insert(item)
+		Name:              req.Name,
+		Type:              req.Type,
+		From:              from,
+		PitNumber:         req.PitNumber,
+		MinerName:         req.MinerName,
+		BatchNumber:       req.BatchNumber,
+		ProcessingMethod:  processingMethod,
+		Quantity:          req.Quantity,
+		Unit:              req.Unit,
+		MinStockLevel:     req.MinStockLevel,
+		CurrentValue:      req.CurrentValue,
+		LastUpdated:       lastUpdated,
+		ExpiryDate:        expiryDate,
+		IsHazardous:       req.IsHazardous,
+		HazardClass:       req.HazardClass,
+		PermittedQuantity: req.PermittedQuantity,
+		MonthlyUsageLimit: req.MonthlyUsageLimit,
+		MineSiteID:        req.MineSiteID,
+		UserID:            userID,
+	}
+
+	itemID, err := h.InventoryRepo.Insert(r.Context(), item)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to create inventory item")
 		return
 	}
 
 	item.ID = itemID
+	h.notifyComplianceWarnings(r.Context(), item)
+	h.publishStockLevel(r, item)
 	utils.WriteSuccessResponse(w, "Inventory item created successfully", item)
 }
 
@@ -222,7 +325,7 @@ func (h *InventoryHandler) UpdateInventoryItem(w http.ResponseWriter, r *http.Re
 	}
 
 	// Get existing inventory item
-	item, err := h.InventoryRepo.GetOne(uint(id), userID)
+	item, err := h.InventoryRepo.GetOne(r.Context(), uint(id), userID)
 	if err != nil {
 		utils.WriteNotFoundError(w, "Inventory item not found")
 		return
@@ -257,6 +360,22 @@ func (h *InventoryHandler) UpdateInventoryItem(w http.ResponseWriter, r *http.Re
 		utils.WriteValidationError(w, "Current value cannot be negative")
 		return
 	}
+	if req.PermittedQuantity != nil && !utils.ValidateNonNegativeNumber(*req.PermittedQuantity) {
+		utils.WriteValidationError(w, "Permitted quantity cannot be negative")
+		return
+	}
+	if req.MonthlyUsageLimit != nil && !utils.ValidateNonNegativeNumber(*req.MonthlyUsageLimit) {
+		utils.WriteValidationError(w, "Monthly usage limit cannot be negative")
+		return
+	}
+
+	// Parse ExpiryDate if provided (only meaningful for supplies such as reagents)
+	expiryDate, err := parseExpiryDate(req.Type, req.ExpiryDate)
+	if err != nil {
+		utils.WriteValidationError(w, err.Error())
+		return
+	}
+	item.ExpiryDate = expiryDate
 
 	// Parse LastUpdated if provided
 	if req.LastUpdated != nil && *req.LastUpdated != "" {
@@ -282,6 +401,11 @@ func (h *InventoryHandler) UpdateInventoryItem(w http.ResponseWriter, r *http.Re
 		item.ProcessingMethod = nil
 	}
 
+	// Records can only be assigned to the user's own mine sites
+	if !checkMineSite(r.Context(), w, h.MineSiteRepo, userID, req.MineSiteID) {
+		return
+	}
+
 	// Update inventory item
 	item.Name = req.Name
 	item.Type = req.Type
@@ -292,12 +416,19 @@ func (h *InventoryHandler) UpdateInventoryItem(w http.ResponseWriter, r *http.Re
 	item.Unit = req.Unit
 	item.MinStockLevel = req.MinStockLevel
 	item.CurrentValue = req.CurrentValue
+	item.IsHazardous = req.IsHazardous
+	item.HazardClass = req.HazardClass
+	item.PermittedQuantity = req.PermittedQuantity
+	item.MonthlyUsageLimit = req.MonthlyUsageLimit
+	item.MineSiteID = req.MineSiteID
 
-	err = h.InventoryRepo.Update(item)
+	err = h.InventoryRepo.Update(r.Context(), item)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to update inventory item")
 		return
 	}
+	h.notifyComplianceWarnings(r.Context(), item)
+	h.publishStockLevel(r, item)
 
 	utils.WriteSuccessResponse(w, "Inventory item updated successfully", item)
 }
@@ -317,7 +448,7 @@ func (h *InventoryHandler) DeleteInventoryItem(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	err = h.InventoryRepo.Delete(uint(id), userID)
+	err = h.InventoryRepo.Delete(r.Context(), uint(id), userID)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to delete inventory item")
 		return
@@ -326,6 +457,79 @@ func (h *InventoryHandler) DeleteInventoryItem(w http.ResponseWriter, r *http.Re
 	utils.WriteSuccessResponse(w, "Inventory item deleted successfully", nil)
 }
 
+// GetInventoryTrash retrieves the inventory items deleted and not yet permanently removed, most recently
+// deleted first
+func (h *InventoryHandler) GetInventoryTrash(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	records, err := h.InventoryRepo.GetDeleted(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve deleted inventory items")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Deleted inventory items retrieved successfully", records)
+}
+
+// RestoreInventoryItem brings back a deleted inventory item
+func (h *InventoryHandler) RestoreInventoryItem(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid inventory item ID")
+		return
+	}
+
+	record, err := h.InventoryRepo.Restore(r.Context(), uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Deleted inventory item not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to restore inventory item")
+		return
+	}
+	auditTrash(h.AuditRepo, r, data.AuditRecordRestored, "inventory", record.ID)
+
+	utils.WriteSuccessResponse(w, "Inventory item restored successfully", record)
+}
+
+// PurgeInventoryItem permanently deletes a inventory item from the trash. Only deleted inventory items can be purged.
+func (h *InventoryHandler) PurgeInventoryItem(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid inventory item ID")
+		return
+	}
+
+	if err := h.InventoryRepo.Purge(r.Context(), uint(id), userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Deleted inventory item not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to permanently delete inventory item")
+		return
+	}
+	auditTrash(h.AuditRepo, r, data.AuditRecordPurged, "inventory", uint(id))
+
+	utils.WriteSuccessResponse(w, "Inventory item permanently deleted successfully", nil)
+}
+
 // GetLowStockItems retrieves items that are below minimum stock level
 func (h *InventoryHandler) GetLowStockItems(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserIDFromRequest(r)
@@ -334,7 +538,7 @@ func (h *InventoryHandler) GetLowStockItems(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	items, err := h.InventoryRepo.GetLowStockItems(userID)
+	items, err := h.InventoryRepo.GetLowStockItems(r.Context(), userID)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to retrieve low stock items")
 		return
@@ -370,18 +574,351 @@ func (h *InventoryHandler) UpdateQuantity(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	err = h.InventoryRepo.UpdateQuantity(uint(id), userID, req.Quantity)
+	current, err := h.InventoryRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Inventory item not found")
+		return
+	}
+
+	// Apply photo evidence rules to the quantity changed
+	photo, ok := requirePhoto(w, r, h.EvidenceRepo, h.Quota, data.EvidenceStockAdjustment, math.Abs(req.Quantity-current.Quantity), req.Photo, nil)
+	if !ok {
+		return
+	}
+
+	err = h.InventoryRepo.UpdateQuantity(r.Context(), uint(id), userID, req.Quantity)
 	if err != nil {
+		discardPhoto(r.Context(), h.EvidenceRepo, photo)
 		utils.WriteInternalServerError(w, "Failed to update quantity")
 		return
 	}
+	linkPhoto(r.Context(), h.EvidenceRepo, photo, data.EvidenceRecordInventoryItem, current.ID)
 
 	// Get updated item
-	item, err := h.InventoryRepo.GetOne(uint(id), userID)
+	item, err := h.InventoryRepo.GetOne(r.Context(), uint(id), userID)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to retrieve updated item")
 		return
 	}
+	h.notifyComplianceWarnings(r.Context(), item)
+	h.publishStockLevel(r, item)
 
 	utils.WriteSuccessResponse(w, "Quantity updated successfully", item)
 }
+
+// GetStockMovements retrieves the stock movement history of an inventory item
+func (h *InventoryHandler) GetStockMovements(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid inventory item ID")
+		return
+	}
+
+	movements, err := h.InventoryRepo.GetMovements(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve stock movements")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Stock movements retrieved successfully", movements)
+}
+
+// GetExpiringItems retrieves supply items that have expired or expire within the given number of days
+func (h *InventoryHandler) GetExpiringItems(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	// Get window from query parameter, default to 30 days
+	days := 30
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		var err error
+		days, err = strconv.Atoi(daysStr)
+		if err != nil || days < 0 || days > 365 {
+			utils.WriteValidationError(w, "Days must be between 0 and 365")
+			return
+		}
+	}
+
+	items, err := h.InventoryRepo.GetExpiringItems(r.Context(), userID, time.Now().AddDate(0, 0, days))
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve expiring items")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Expiring items retrieved successfully", items)
+}
+
+// parseExpiryDate parses an optional expiry date, which is only allowed on supply items
+func parseExpiryDate(itemType string, value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	if itemType != "supply" {
+		return nil, errors.New("Expiry date is only supported for supply items")
+	}
+	expiry, err := time.Parse("2006-01-02", *value)
+	if err != nil {
+		return nil, errors.New("Invalid expiry date format. Use YYYY-MM-DD")
+	}
+	return &expiry, nil
+}
+
+// GetHazardousRegister retrieves the hazardous material register
+func (h *InventoryHandler) GetHazardousRegister(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	items, err := h.InventoryRepo.GetHazardousItems(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve hazardous material register")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Hazardous material register retrieved successfully", items)
+}
+
+// GetComplianceWarnings retrieves hazardous items exceeding their licensed stock or usage limits
+func (h *InventoryHandler) GetComplianceWarnings(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	items, err := h.InventoryRepo.GetHazardousItems(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve hazardous material register")
+		return
+	}
+
+	warnings := []data.ComplianceWarning{}
+	for _, item := range items {
+		itemWarnings, err := h.complianceWarnings(r.Context(), item)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to calculate usage")
+			return
+		}
+		warnings = append(warnings, itemWarnings...)
+	}
+
+	utils.WriteSuccessResponse(w, "Compliance warnings retrieved successfully", warnings)
+}
+
+// RecordUsage records consumption of an inventory item
+func (h *InventoryHandler) RecordUsage(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid inventory item ID")
+		return
+	}
+
+	var req RecordUsageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	if !utils.ValidatePositiveNumber(req.Quantity) {
+		utils.WriteValidationError(w, "Quantity must be positive")
+		return
+	}
+
+	// Apply photo evidence rules
+	photo, ok := requirePhoto(w, r, h.EvidenceRepo, h.Quota, data.EvidenceStockUsage, req.Quantity, req.Photo, nil)
+	if !ok {
+		return
+	}
+
+	movement, err := h.InventoryRepo.RecordUsage(r.Context(), uint(id), userID, req.Quantity, req.Reason)
+	if err != nil {
+		discardPhoto(r.Context(), h.EvidenceRepo, photo)
+		if errors.Is(err, data.ErrInsufficientStock) {
+			utils.WriteValidationError(w, "Usage exceeds quantity in stock")
+			return
+		}
+		utils.WriteNotFoundError(w, "Inventory item not found")
+		return
+	}
+
+	linkPhoto(r.Context(), h.EvidenceRepo, photo, data.EvidenceRecordStockMovement, movement.ID)
+
+	item, err := h.InventoryRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve updated item")
+		return
+	}
+
+	warnings, err := h.complianceWarnings(r.Context(), item)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to calculate usage")
+		return
+	}
+	h.notifyComplianceWarnings(r.Context(), item)
+	h.publishStockLevel(r, item)
+
+	response := map[string]interface{}{
+		"movement": movement,
+		"item":     item,
+		"warnings": warnings,
+	}
+
+	utils.WriteSuccessResponse(w, "Usage recorded successfully", response)
+}
+
+// WriteOffStock writes off damaged, expired, lost or stolen stock of an item, reducing its value
+// in proportion and recording the value lost in the audit log
+func (h *InventoryHandler) WriteOffStock(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid inventory item ID")
+		return
+	}
+
+	var req WriteOffStockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	if !utils.ValidatePositiveNumber(req.Quantity) {
+		utils.WriteValidationError(w, "Quantity must be positive")
+		return
+	}
+	if !utils.ValidateRequired(req.Reason) {
+		utils.WriteValidationError(w, "Reason is required")
+		return
+	}
+
+	movement, err := h.InventoryRepo.WriteOff(r.Context(), uint(id), userID, req.Quantity, req.Reason)
+	if err != nil {
+		if errors.Is(err, data.ErrInsufficientStock) {
+			utils.WriteValidationError(w, "Write-off exceeds quantity in stock")
+			return
+		}
+		utils.WriteNotFoundError(w, "Inventory item not found")
+		return
+	}
+
+	item, err := h.InventoryRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve updated item")
+		return
+	}
+	if h.AuditRepo != nil {
+		details := fmt.Sprintf("Wrote off %g %s of %s worth %.2f: %s", req.Quantity, item.Unit, item.Name, *movement.Value, req.Reason)
+		recordAudit(h.AuditRepo, r, &data.AuditLog{
+			Action:     data.AuditStockWrittenOff,
+			Resource:   "inventory",
+			ResourceID: &item.ID,
+			Details:    &details,
+		})
+	}
+	h.publishStockLevel(r, item)
+
+	response := map[string]interface{}{
+		"movement": movement,
+		"item":     item,
+	}
+
+	utils.WriteSuccessResponse(w, "Stock written off successfully", response)
+}
+
+// complianceWarnings checks a hazardous item against its licensed stock and monthly usage limits
+func (h *InventoryHandler) complianceWarnings(ctx context.Context, item *data.InventoryItem) ([]data.ComplianceWarning, error) {
+	warnings := []data.ComplianceWarning{}
+	if !item.IsHazardous {
+		return warnings, nil
+	}
+
+	if item.PermittedQuantity != nil && item.Quantity > *item.PermittedQuantity {
+		warnings = append(warnings, data.ComplianceWarning{
+			InventoryItemID: item.ID,
+			ItemName:        item.Name,
+			Type:            data.ComplianceStockLimit,
+			Limit:           *item.PermittedQuantity,
+			Actual:          item.Quantity,
+			Unit:            item.Unit,
+			Message: fmt.Sprintf("%s stock of %.2f %s exceeds the licensed limit of %.2f %s",
+				item.Name, item.Quantity, item.Unit, *item.PermittedQuantity, item.Unit),
+		})
+	}
+
+	if item.MonthlyUsageLimit != nil {
+		now := time.Now()
+		monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		used, err := h.InventoryRepo.GetUsageSince(ctx, item.ID, item.UserID, monthStart)
+		if err != nil {
+			return nil, err
+		}
+		if used > *item.MonthlyUsageLimit {
+			warnings = append(warnings, data.ComplianceWarning{
+				InventoryItemID: item.ID,
+				ItemName:        item.Name,
+				Type:            data.ComplianceUsageLimit,
+				Limit:           *item.MonthlyUsageLimit,
+				Actual:          used,
+				Unit:            item.Unit,
+				Message: fmt.Sprintf("%s usage of %.2f %s this month exceeds the licensed limit of %.2f %s",
+					item.Name, used, item.Unit, *item.MonthlyUsageLimit, item.Unit),
+			})
+		}
+	}
+
+	return warnings, nil
+}
+
+// notifyComplianceWarnings raises a notification for each licensed limit an item exceeds.
+// Failures are ignored so they never block the stock change that triggered the check.
+func (h *InventoryHandler) notifyComplianceWarnings(ctx context.Context, item *data.InventoryItem) {
+	warnings, err := h.complianceWarnings(ctx, item)
+	if err != nil {
+		return
+	}
+
+	period := time.Now().Format("2006-01")
+	for _, warning := range warnings {
+		itemID := item.ID
+		h.NotificationRepo.Insert(ctx, &data.Notification{
+			Kind:        data.NotificationHazardLimit,
+			Title:       "Hazardous material limit exceeded",
+			Message:     warning.Message,
+			ReferenceID: &itemID,
+			Key:         fmt.Sprintf("%s:%s:%d:%s", data.NotificationHazardLimit, warning.Type, item.ID, period),
+			UserID:      item.UserID,
+		})
+	}
+}
+
+// publishStockLevel publishes a stock.low event when an item is at or below its minimum stock level
+func (h *InventoryHandler) publishStockLevel(r *http.Request, item *data.InventoryItem) {
+	if item.Quantity > item.MinStockLevel {
+		return
+	}
+	publishEvent(h.Events, r, events.StockLow, "inventory_item", item.ID, item)
+}
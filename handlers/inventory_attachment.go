@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"encoding/json"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// maxDocumentSize is the maximum size of an inventory item document in bytes
+const maxDocumentSize = 10 << 20
+
+// documentContentTypes are the formats accepted as inventory item documents
+var documentContentTypes = map[string]bool{
+	"application/pdf": true,
+}
+
+// ItemAttachmentRequest represents a photo or document attached to an inventory item
+type ItemAttachmentRequest struct {
+	Data     string `json:"data"` // base64 encoded JPEG, PNG, WebP or PDF, optionally as a data URL
+	FileName string `json:"file_name,omitempty"`
+	Primary  bool   `json:"primary,omitempty"` // show this photo when browsing stock
+}
+
+// PrimaryImageRequest represents a request to choose the photo shown for an inventory item
+type PrimaryImageRequest struct {
+	AttachmentID uint `json:"attachment_id"`
+}
+
+// GetAttachments returns the photos and documents attached to an inventory item, without their
+// files, which are downloaded from the evidence photo route
+func (h *InventoryHandler) GetAttachments(w http.ResponseWriter, r *http.Request) {
+	userID, item, ok := h.attachmentItem(w, r)
+	if !ok {
+		return
+	}
+
+	photos, err := h.EvidenceRepo.GetPhotos(r.Context(), userID, data.EvidenceRecordInventoryItem, item.ID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve attachments")
+		return
+	}
+
+	attachments := make([]*data.EvidencePhoto, 0, len(photos))
+	for _, photo := range photos {
+		if isItemAttachment(photo) {
+			attachments = append(attachments, photo)
+		}
+	}
+
+	utils.WriteSuccessResponse(w, "Attachments retrieved successfully", attachments)
+}
+
+// AddAttachment attaches a photo or a PDF document, such as a spec sheet or certificate, to an
+// inventory item. The first photo of an item becomes its primary image.
+func (h *InventoryHandler) AddAttachment(w http.ResponseWriter, r *http.Request) {
+	userID, item, ok := h.attachmentItem(w, r)
+	if !ok {
+		return
+	}
+
+	var req ItemAttachmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if !utils.ValidateRequired(req.Data) {
+		utils.WriteValidationError(w, "Data is required")
+		return
+	}
+	fileName := strings.TrimSpace(req.FileName)
+	if len(fileName) > 255 {
+		utils.WriteValidationError(w, "File name must be at most 255 characters")
+		return
+	}
+
+	file, contentType, ok := decodeUpload(w, req.Data, maxDocumentSize, "Attachment", "a JPEG, PNG or WebP image or a PDF",
+		photoContentTypes, documentContentTypes)
+	if !ok {
+		return
+	}
+	operation := data.EvidenceItemDocument
+	if photoContentTypes[contentType] {
+		if len(file) > maxPhotoSize {
+			utils.WriteValidationError(w, "Photo must be 5 MB or smaller")
+			return
+		}
+		operation = data.EvidenceItemPhoto
+	}
+	if req.Primary && operation != data.EvidenceItemPhoto {
+		utils.WriteValidationError(w, "Only a photo can be the primary image")
+		return
+	}
+	if !h.Quota.allowUpload(r.Context(), w, userID, int64(len(file))) {
+		return
+	}
+
+	recordType := data.EvidenceRecordInventoryItem
+	actorID := middleware.GetActorIDFromRequest(r)
+	attachment := &data.EvidencePhoto{
+		RecordType:   &recordType,
+		RecordID:     &item.ID,
+		Operation:    operation,
+		ContentType:  contentType,
+		Data:         file,
+		UploadedByID: &actorID,
+		UserID:       userID,
+	}
+	if fileName != "" {
+		attachment.FileName = &fileName
+	}
+	if _, err := h.EvidenceRepo.InsertPhoto(r.Context(), attachment); err != nil {
+		utils.WriteInternalServerError(w, "Failed to save attachment")
+		return
+	}
+
+	if operation == data.EvidenceItemPhoto && (req.Primary || item.PrimaryImageID == nil) {
+		if err := h.InventoryRepo.SetPrimaryImage(r.Context(), item.ID, userID, &attachment.ID); err != nil {
+			utils.WriteInternalServerError(w, "Failed to set primary image")
+			return
+		}
+	}
+
+	utils.WriteSuccessResponse(w, "Attachment added successfully", attachment)
+}
+
+// SetPrimaryImage chooses which of an inventory item's photos is shown when browsing stock
+func (h *InventoryHandler) SetPrimaryImage(w http.ResponseWriter, r *http.Request) {
+	userID, item, ok := h.attachmentItem(w, r)
+	if !ok {
+		return
+	}
+
+	var req PrimaryImageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	photo, err := h.EvidenceRepo.GetPhoto(r.Context(), req.AttachmentID, userID)
+	if err != nil || !attachedToItem(photo, item.ID) {
+		utils.WriteNotFoundError(w, "Attachment not found")
+		return
+	}
+	if photo.Operation != data.EvidenceItemPhoto {
+		utils.WriteValidationError(w, "Only a photo can be the primary image")
+		return
+	}
+
+	if err := h.InventoryRepo.SetPrimaryImage(r.Context(), item.ID, userID, &photo.ID); err != nil {
+		utils.WriteInternalServerError(w, "Failed to set primary image")
+		return
+	}
+	item.PrimaryImageID = &photo.ID
+
+	utils.WriteSuccessResponse(w, "Primary image updated successfully", item)
+}
+
+// DeleteAttachment removes a photo or document from an inventory item. When it was the primary
+// image the item's next photo, if any, takes its place.
+func (h *InventoryHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	userID, item, ok := h.attachmentItem(w, r)
+	if !ok {
+		return
+	}
+
+	attachmentID, err := strconv.ParseUint(chi.URLParam(r, "attachmentId"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid attachment ID")
+		return
+	}
+	photo, err := h.EvidenceRepo.GetPhoto(r.Context(), uint(attachmentID), userID)
+	if err != nil || !attachedToItem(photo, item.ID) {
+		utils.WriteNotFoundError(w, "Attachment not found")
+		return
+	}
+
+	if err := h.EvidenceRepo.DeletePhoto(r.Context(), photo.ID, userID); err != nil {
+		utils.WriteInternalServerError(w, "Failed to delete attachment")
+		return
+	}
+
+	if item.PrimaryImageID != nil && *item.PrimaryImageID == photo.ID {
+		remaining, err := h.EvidenceRepo.GetPhotos(r.Context(), userID, data.EvidenceRecordInventoryItem, item.ID)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to update primary image")
+			return
+		}
+		var next *uint
+		for _, other := range remaining {
+			if other.Operation == data.EvidenceItemPhoto {
+				next = &other.ID
+				break
+			}
+		}
+		if err := h.InventoryRepo.SetPrimaryImage(r.Context(), item.ID, userID, next); err != nil {
+			utils.WriteInternalServerError(w, "Failed to update primary image")
+			return
+		}
+	}
+
+	utils.WriteSuccessResponse(w, "Attachment deleted successfully", nil)
+}
+
+// attachmentItem returns the inventory item of an attachment request, writing the error
+// response and returning false when it doesn't exist
+func (h *InventoryHandler) attachmentItem(w http.ResponseWriter, r *http.Request) (uint, *data.InventoryItem, bool) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return 0, nil, false
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid inventory item ID")
+		return 0, nil, false
+	}
+	item, err := h.InventoryRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Inventory item not found")
+		return 0, nil, false
+	}
+	return userID, item, true
+}
+
+// isItemAttachment reports whether a photo is an inventory item photo or document rather than
+// evidence of a stock adjustment
+func isItemAttachment(photo *data.EvidencePhoto) bool {
+	return photo.Operation == data.EvidenceItemPhoto || photo.Operation == data.EvidenceItemDocument
+}
+
+// attachedToItem reports whether a photo is a photo or document attached to an inventory item
+func attachedToItem(photo *data.EvidencePhoto, itemID uint) bool {
+	return isItemAttachment(photo) && photo.RecordType != nil && *photo.RecordType == data.EvidenceRecordInventoryItem &&
+		photo.RecordID != nil && *photo.RecordID == itemID
+}
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GetSeasonalStockPlan plans how much of each supply to buy before the next rainy season, or the
+// one continuing from season_start, so stock lasts it out. Consumption over the past twelve full
+// months is averaged over its rainy months, or over all of them for items only used in the dry
+// season. format=csv downloads the plan as a list.
+func (h *InventoryHandler) GetSeasonalStockPlan(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	query := r.URL.Query()
+	format := query.Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		utils.WriteValidationError(w, "Format must be json or csv")
+		return
+	}
+
+	settings := data.DefaultOrganizationSettings(userID)
+	if h.SettingsRepo != nil {
+		var err error
+		settings, err = h.SettingsRepo.GetByUserID(r.Context(), userID)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to retrieve settings")
+			return
+		}
+	}
+
+	now := time.Now().UTC()
+	start := settings.NextRainySeason(now)
+	if value := query.Get("season_start"); value != "" {
+		parsed, err := time.Parse("2006-01", value)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid season start. Use YYYY-MM")
+			return
+		}
+		start = parsed
+	}
+	months := settings.RainySeasonLength(start)
+	if months == 0 {
+		utils.WriteValidationError(w, "Season start must be a rainy season month")
+		return
+	}
+
+	historyTo := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	historyFrom := historyTo.AddDate(-1, 0, 0)
+	usage, err := h.InventoryRepo.GetMonthlyUsage(r.Context(), userID, historyFrom)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve stock usage")
+		return
+	}
+	items, err := h.InventoryRepo.GetAll(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve inventory items")
+		return
+	}
+	listPrices := map[string]float64{}
+	if h.SupplyPriceRepo != nil {
+		prices, err := h.SupplyPriceRepo.GetAll(r.Context(), userID)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to retrieve supply prices")
+			return
+		}
+		for _, price := range prices {
+			listPrices[strings.ToLower(price.Name)] = price.Price
+		}
+	}
+
+	plan := planSeasonalStock(settings, items, usage, listPrices, historyFrom, start, months)
+	if format != "csv" {
+		utils.WriteSuccessResponse(w, "Seasonal stock plan retrieved successfully", plan)
+		return
+	}
+
+	filename := fmt.Sprintf("stock-plan-%s.csv", plan.SeasonStart)
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"item", "unit", "basis", "monthly_usage", "season_usage", "min_stock_level", "in_stock", "to_buy", "unit_cost", "estimated_cost"})
+	for _, line := range plan.Items {
+		writer.Write([]string{line.ItemName, line.Unit, string(line.Basis), formatAmount(line.MonthlyUsage),
+			formatAmount(line.SeasonUsage), formatAmount(line.MinStockLevel), formatAmount(line.InStock),
+			formatAmount(line.ToBuy), optionalAmount(line.UnitCost), optionalAmount(line.EstimatedCost)})
+	}
+	writer.Flush()
+}
+
+// planSeasonalStock plans the stock of each supply for a rainy season of months starting at
+// start, from its monthly usage in the year from historyFrom. Items are priced at their list
+// price, by name, or the average cost of the stock on hand.
+func planSeasonalStock(settings *data.OrganizationSettings, items []*data.InventoryItem, usage []*data.MonthlyUsage, listPrices map[string]float64, historyFrom, start time.Time, months int) *data.SeasonalStockPlan {
+	rainyMonths := 0
+	for i := 0; i < 12; i++ {
+		if settings.IsRainyMonth(historyFrom.AddDate(0, i, 0).Month()) {
+			rainyMonths++
+		}
+	}
+
+	historyTo := historyFrom.AddDate(1, 0, 0).Format("2006-01")
+	rainyUsage := map[uint]float64{}
+	allUsage := map[uint]float64{}
+	for _, used := range usage {
+		month, err := time.Parse("2006-01", used.Month)
+		if err != nil || used.Month >= historyTo {
+			continue
+		}
+		allUsage[used.InventoryItemID] += used.Quantity
+		if settings.IsRainyMonth(month.Month()) {
+			rainyUsage[used.InventoryItemID] += used.Quantity
+		}
+	}
+
+	plan := &data.SeasonalStockPlan{
+		SeasonStart: start.Format("2006-01"),
+		SeasonEnd:   start.AddDate(0, months-1, 0).Format("2006-01"),
+		Months:      months,
+		HistoryFrom: historyFrom.Format("2006-01"),
+		Items:       []*data.SeasonalStockLine{},
+	}
+	for _, item := range items {
+		if item.Type != "supply" {
+			continue
+		}
+		line := &data.SeasonalStockLine{
+			InventoryItemID: item.ID,
+			ItemName:        item.Name,
+			Unit:            item.Unit,
+			Basis:           data.SeasonalBasisNoUsage,
+			MinStockLevel:   item.MinStockLevel,
+			InStock:         item.Quantity,
+		}
+		switch {
+		case rainyMonths > 0 && rainyUsage[item.ID] > 0:
+			line.Basis = data.SeasonalBasisRainy
+			line.MonthlyUsage = rainyUsage[item.ID] / float64(rainyMonths)
+		case allUsage[item.ID] > 0:
+			line.Basis = data.SeasonalBasisAll
+			line.MonthlyUsage = allUsage[item.ID] / 12
+		}
+		line.MonthlyUsage = roundQuantity(line.MonthlyUsage)
+		line.SeasonUsage = roundQuantity(line.MonthlyUsage * float64(months))
+		line.ToBuy = roundQuantity(math.Max(0, line.SeasonUsage+item.MinStockLevel-item.Quantity))
+
+		if price, ok := listPrices[strings.ToLower(item.Name)]; ok {
+			line.UnitCost = &price
+		} else if item.Quantity > 0 && item.CurrentValue > 0 {
+			cost := math.Round(item.CurrentValue/item.Quantity*100) / 100
+			line.UnitCost = &cost
+		}
+		if line.UnitCost != nil {
+			cost := math.Round(line.ToBuy**line.UnitCost*100) / 100
+			line.EstimatedCost = &cost
+			plan.EstimatedCost += cost
+		}
+		plan.Items = append(plan.Items, line)
+	}
+	plan.EstimatedCost = math.Round(plan.EstimatedCost*100) / 100
+	return plan
+}
+
+// roundQuantity rounds a planned quantity to two decimal places
+func roundQuantity(quantity float64) float64 {
+	return math.Round(quantity*100) / 100
+}
+
+// optionalAmount formats an optional number for CSV output, empty when it isn't set
+func optionalAmount(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return formatAmount(*value)
+}
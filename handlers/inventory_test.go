@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"mineral/data"
+	"mineral/data/mocks"
+	"mineral/pkg/events"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestCreateInventoryItem(t *testing.T) {
+	valid := `{"name":"Cyanide","type":"supply","quantity":10,"unit":"kg","min_stock_level":2,"current_value":500}`
+
+	tests := []struct {
+		name      string
+		userID    uint
+		body      string
+		insertErr error
+		status    int
+		inserted  bool
+	}{
+		{name: "unauthenticated", body: valid, status: http.StatusUnauthorized},
+		{name: "invalid body", userID: 1, body: `{"name":`, status: http.StatusBadRequest},
+		{name: "missing name", userID: 1, body: `{"type":"supply","quantity":1,"unit":"kg"}`, status: http.StatusBadRequest},
+		{name: "unknown type", userID: 1, body: `{"name":"Cyanide","type":"tool","quantity":1,"unit":"kg"}`, status: http.StatusBadRequest},
+		{name: "negative quantity", userID: 1, body: `{"name":"Cyanide","type":"supply","quantity":-1,"unit":"kg"}`, status: http.StatusBadRequest},
+		{name: "negative permitted quantity", userID: 1, body: `{"name":"Cyanide","type":"supply","quantity":1,"unit":"kg","permitted_quantity":-5}`, status: http.StatusBadRequest},
+		{name: "insert fails", userID: 1, body: valid, insertErr: errors.New("db down"), status: http.StatusInternalServerError, inserted: true},
+		{name: "created", userID: 1, body: valid, status: http.StatusOK, inserted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			inventoryRepo := mocks.NewMockInventoryInterface(ctrl)
+			if tt.inserted {
+				inventoryRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(5, tt.insertErr)
+			}
+			h := NewInventoryHandler(inventoryRepo, mocks.NewMockNotificationInterface(ctrl), mocks.NewMockEvidenceInterface(ctrl), nil)
+
+			rr := serve(h.CreateInventoryItem, http.MethodPost, tt.userID, tt.body, nil)
+
+			if rr.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.status, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestUpdateQuantity(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		body     string
+		quantity float64 // stored after the update
+		status   int
+		updated  bool
+		stockLow bool
+	}{
+		{name: "invalid id", id: "x", body: `{"quantity":1}`, status: http.StatusBadRequest},
+		{name: "negative quantity", id: "1", body: `{"quantity":-1}`, status: http.StatusBadRequest},
+		{name: "not found", id: "9", body: `{"quantity":1}`, status: http.StatusNotFound},
+		{name: "updated", id: "1", body: `{"quantity":8}`, quantity: 8, status: http.StatusOK, updated: true},
+		{name: "below minimum", id: "1", body: `{"quantity":1}`, quantity: 1, status: http.StatusOK, updated: true, stockLow: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &data.InventoryItem{Quantity: 10, MinStockLevel: 2, UserID: 1}
+			item.ID = 1
+			ctrl := gomock.NewController(t)
+			inventoryRepo := mocks.NewMockInventoryInterface(ctrl)
+			inventoryRepo.EXPECT().GetOne(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, id uint, userID uint) (*data.InventoryItem, error) {
+				if id != item.ID {
+					return nil, gorm.ErrRecordNotFound
+				}
+				return item, nil
+			}).AnyTimes()
+			if tt.updated {
+				inventoryRepo.EXPECT().UpdateQuantity(gomock.Any(), item.ID, uint(1), tt.quantity).DoAndReturn(func(ctx context.Context, id uint, userID uint, quantity float64) error {
+					item.Quantity = quantity
+					return nil
+				})
+			}
+
+			var published []events.Name
+			bus := events.NewBus(nil)
+			bus.SubscribeAll("test", func(ctx context.Context, event events.Event) error {
+				published = append(published, event.Name)
+				return nil
+			})
+			evidenceRepo := mocks.NewMockEvidenceInterface(ctrl)
+			evidenceRepo.EXPECT().RequiresPhoto(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+			h := NewInventoryHandler(inventoryRepo, mocks.NewMockNotificationInterface(ctrl), evidenceRepo, bus)
+
+			rr := serve(h.UpdateQuantity, http.MethodPut, 1, tt.body, map[string]string{"id": tt.id})
+
+			if rr.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.status, rr.Body.String())
+			}
+			if stockLow := len(published) == 1 && published[0] == events.StockLow; stockLow != tt.stockLow {
+				t.Errorf("published %v, want stock.low %v", published, tt.stockLow)
+			}
+		})
+	}
+}
+
+func TestAddAttachment(t *testing.T) {
+	png := base64.StdEncoding.EncodeToString([]byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"))
+	pdf := base64.StdEncoding.EncodeToString([]byte("%PDF-1.7\n%spec sheet"))
+
+	tests := []struct {
+		name         string
+		body         string
+		primaryImage *uint // of the item before the upload
+		status       int
+		operation    data.EvidenceOperation
+		primary      bool // the upload became the primary image
+	}{
+		{name: "missing data", body: `{}`, status: http.StatusBadRequest},
+		{name: "not base64", body: `{"data":"%%%"}`, status: http.StatusBadRequest},
+		{name: "unsupported format", body: `{"data":"` + base64.StdEncoding.EncodeToString([]byte("plain text")) + `"}`, status: http.StatusBadRequest},
+		{name: "document as primary", body: `{"data":"` + pdf + `","primary":true}`, status: http.StatusBadRequest},
+		{name: "first photo", body: `{"data":"` + png + `"}`, status: http.StatusOK, operation: data.EvidenceItemPhoto, primary: true},
+		{name: "another photo", body: `{"data":"` + png + `"}`, primaryImage: new(uint), status: http.StatusOK, operation: data.EvidenceItemPhoto},
+		{name: "chosen as primary", body: `{"data":"` + png + `","primary":true}`, primaryImage: new(uint), status: http.StatusOK, operation: data.EvidenceItemPhoto, primary: true},
+		{name: "document", body: `{"data":"data:application/pdf;base64,` + pdf + `","file_name":"spec.pdf"}`, status: http.StatusOK, operation: data.EvidenceItemDocument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &data.InventoryItem{UserID: 1, PrimaryImageID: tt.primaryImage}
+			item.ID = 1
+			ctrl := gomock.NewController(t)
+			inventoryRepo := mocks.NewMockInventoryInterface(ctrl)
+			inventoryRepo.EXPECT().GetOne(gomock.Any(), gomock.Any(), gomock.Any()).Return(item, nil).AnyTimes()
+			if tt.primary {
+				inventoryRepo.EXPECT().SetPrimaryImage(gomock.Any(), item.ID, uint(1), gomock.Any()).Return(nil)
+			}
+			var inserted *data.EvidencePhoto
+			evidenceRepo := mocks.NewMockEvidenceInterface(ctrl)
+			evidenceRepo.EXPECT().InsertPhoto(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, photo *data.EvidencePhoto) (uint, error) {
+				photo.ID = 7
+				inserted = photo
+				return photo.ID, nil
+			}).AnyTimes()
+			h := NewInventoryHandler(inventoryRepo, mocks.NewMockNotificationInterface(ctrl), evidenceRepo, nil)
+
+			rr := serve(h.AddAttachment, http.MethodPost, 1, tt.body, map[string]string{"id": "1"})
+
+			if rr.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.status, rr.Body.String())
+			}
+			if tt.status != http.StatusOK {
+				if inserted != nil {
+					t.Fatal("attachment saved for a rejected upload")
+				}
+				return
+			}
+			if inserted.Operation != tt.operation {
+				t.Errorf("operation = %s, want %s", inserted.Operation, tt.operation)
+			}
+			if inserted.RecordID == nil || *inserted.RecordID != item.ID {
+				t.Errorf("attachment not linked to the item")
+			}
+		})
+	}
+}
+
+func TestPlanSeasonalStock(t *testing.T) {
+	settings := data.DefaultOrganizationSettings(1)
+	items := []*data.InventoryItem{
+		{Model: gorm.Model{ID: 1}, Name: "Cyanide", Type: "supply", Unit: "kg", MinStockLevel: 10},
+		{Model: gorm.Model{ID: 2}, Name: "Diesel", Type: "supply", Unit: "l", Quantity: 100, MinStockLevel: 50, CurrentValue: 400000},
+		{Model: gorm.Model{ID: 3}, Name: "Gloves", Type: "supply", Unit: "pairs", Quantity: 20, MinStockLevel: 5},
+		{Model: gorm.Model{ID: 4}, Name: "Gold", Type: "mineral", Unit: "g", Quantity: 30},
+	}
+	usage := []*data.MonthlyUsage{
+		{InventoryItemID: 1, Month: "2026-01", Quantity: 120}, // dry season only
+		{InventoryItemID: 2, Month: "2026-04", Quantity: 300},
+		{InventoryItemID: 2, Month: "2026-07", Quantity: 120},
+		{InventoryItemID: 2, Month: "2026-10", Quantity: 999}, // current month, not history yet
+		{InventoryItemID: 4, Month: "2026-04", Quantity: 10},
+	}
+	listPrices := map[string]float64{"cyanide": 2500}
+	historyFrom := time.Date(2025, time.October, 1, 0, 0, 0, 0, time.UTC)
+	start := time.Date(2027, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	plan := planSeasonalStock(settings, items, usage, listPrices, historyFrom, start, settings.RainySeasonLength(start))
+	if plan.SeasonStart != "2027-03" || plan.SeasonEnd != "2027-05" || plan.Months != 3 {
+		t.Fatalf("season = %s to %s, %d months", plan.SeasonStart, plan.SeasonEnd, plan.Months)
+	}
+	if len(plan.Items) != 3 {
+		t.Fatalf("got %d items, want the 3 supplies", len(plan.Items))
+	}
+
+	want := []struct {
+		basis   data.SeasonalStockBasis
+		monthly float64
+		toBuy   float64
+		cost    *float64
+	}{
+		{basis: data.SeasonalBasisAll, monthly: 10, toBuy: 40, cost: floatPtr(100000)},
+		{basis: data.SeasonalBasisRainy, monthly: 50, toBuy: 100, cost: floatPtr(400000)},
+		{basis: data.SeasonalBasisNoUsage, monthly: 0, toBuy: 0},
+	}
+	for i, line := range plan.Items {
+		if line.Basis != want[i].basis || line.MonthlyUsage != want[i].monthly || line.ToBuy != want[i].toBuy {
+			t.Errorf("%s: basis %s, monthly %g, to buy %g; want %s, %g, %g", line.ItemName,
+				line.Basis, line.MonthlyUsage, line.ToBuy, want[i].basis, want[i].monthly, want[i].toBuy)
+		}
+		if (line.EstimatedCost == nil) != (want[i].cost == nil) || line.EstimatedCost != nil && *line.EstimatedCost != *want[i].cost {
+			t.Errorf("%s: estimated cost = %v, want %v", line.ItemName, line.EstimatedCost, want[i].cost)
+		}
+	}
+	if plan.EstimatedCost != 500000 {
+		t.Errorf("estimated cost = %g, want 500000", plan.EstimatedCost)
+	}
+}
@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// invitationTTL is how long an invitation to an organization can be accepted
+const invitationTTL = 7 * 24 * time.Hour
+
+// InvitationRequest represents a request to invite someone to an organization by email
+type InvitationRequest struct {
+	Email     string                `json:"email"`
+	Role      data.OrganizationRole `json:"role"`
+	CanExport bool                  `json:"can_export"`
+}
+
+// InvitationResponse is a new invitation with its token, which is only returned here so the
+// invitation can also be shared another way than by email
+type InvitationResponse struct {
+	Invitation *data.OrganizationInvitation `json:"invitation"`
+	Token      string                       `json:"token"`
+	URL        string                       `json:"url"` // public page of the invitation
+}
+
+// AcceptInvitationRequest represents a request to accept an invitation
+type AcceptInvitationRequest struct {
+	Token string `json:"token"`
+}
+
+// PublicInvitation is what an invitation shows before it is accepted
+type PublicInvitation struct {
+	Organization string                `json:"organization"`
+	Email        string                `json:"email"`
+	Role         data.OrganizationRole `json:"role"`
+	ExpiresAt    time.Time             `json:"expires_at"`
+}
+
+// CreateInvitation invites someone by email to join an organization with a role. They accept
+// it with their own account, which can be created after the invitation.
+func (h *OrganizationHandler) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	organizationID, ok := h.authorizeMember(w, r, true)
+	if !ok {
+		return
+	}
+
+	var req InvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	req.Email = strings.TrimSpace(req.Email)
+	if !utils.ValidateEmail(req.Email) {
+		utils.WriteValidationError(w, "Invalid email format")
+		return
+	}
+	if !validMemberRole(req.Role) {
+		utils.WriteValidationError(w, "Role must be manager, accountant, clerk, auditor or viewer")
+		return
+	}
+
+	organization, err := h.OrganizationRepo.GetOne(r.Context(), organizationID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Organization not found")
+		return
+	}
+	token, err := utils.GenerateRefreshToken()
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to create invitation")
+		return
+	}
+
+	invitation := &data.OrganizationInvitation{
+		OrganizationID: organizationID,
+		Email:          req.Email,
+		Role:           req.Role,
+		CanExport:      req.CanExport,
+		TokenHash:      utils.HashToken(token),
+		InvitedByID:    middleware.GetActorIDFromRequest(r),
+		ExpiresAt:      time.Now().Add(invitationTTL),
+	}
+	if _, err := h.OrganizationRepo.CreateInvitation(r.Context(), invitation); err != nil {
+		if errors.Is(err, data.ErrAlreadyMember) {
+			utils.WriteValidationError(w, "User is already a member of this organization")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to create invitation")
+		return
+	}
+
+	// The invitation stands when its email can't be queued; the token can still be shared
+	if err := h.sendInvitation(r, organization, invitation, token); err != nil {
+		log.Printf("Failed to queue the email of invitation %d: %v", invitation.ID, err)
+	}
+
+	utils.WriteSuccessResponse(w, "Invitation created successfully", InvitationResponse{
+		Invitation: invitation,
+		Token:      token,
+		URL:        h.invitationURL(token),
+	})
+}
+
+// GetInvitations retrieves the invitations of an organization that haven't been accepted
+func (h *OrganizationHandler) GetInvitations(w http.ResponseWriter, r *http.Request) {
+	organizationID, ok := h.authorizeMember(w, r, true)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.OrganizationRepo.GetInvitations(r.Context(), organizationID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve invitations")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Invitations retrieved successfully", invitations)
+}
+
+// DeleteInvitation revokes an invitation that hasn't been accepted
+func (h *OrganizationHandler) DeleteInvitation(w http.ResponseWriter, r *http.Request) {
+	organizationID, ok := h.authorizeMember(w, r, true)
+	if !ok {
+		return
+	}
+
+	invitationID, err := strconv.ParseUint(chi.URLParam(r, "invitationId"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid invitation ID")
+		return
+	}
+
+	if err := h.OrganizationRepo.DeleteInvitation(r.Context(), uint(invitationID), organizationID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Invitation not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to revoke invitation")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Invitation revoked successfully", nil)
+}
+
+// GetPublicInvitation shows an open invitation to the invitee (no authentication)
+func (h *OrganizationHandler) GetPublicInvitation(w http.ResponseWriter, r *http.Request) {
+	invitation, err := h.OrganizationRepo.GetInvitationByToken(r.Context(), utils.HashToken(chi.URLParam(r, "token")))
+	if err != nil {
+		if errors.Is(err, data.ErrInvitationInvalid) {
+			utils.WriteNotFoundError(w, "Invitation not found or expired")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to retrieve invitation")
+		return
+	}
+
+	name := ""
+	if invitation.Organization != nil {
+		name = invitation.Organization.Name
+	}
+	utils.WriteSuccessResponse(w, "Invitation retrieved successfully", PublicInvitation{
+		Organization: name,
+		Email:        invitation.Email,
+		Role:         invitation.Role,
+		ExpiresAt:    invitation.ExpiresAt,
+	})
+}
+
+// AcceptInvitation makes the authenticated user a member of the organization of an invitation
+func (h *OrganizationHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	actorID := middleware.GetActorIDFromRequest(r)
+	if actorID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req AcceptInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if !utils.ValidateRequired(req.Token) {
+		utils.WriteValidationError(w, "Token is required")
+		return
+	}
+
+	member, err := h.OrganizationRepo.AcceptInvitation(r.Context(), utils.HashToken(strings.TrimSpace(req.Token)), actorID)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrInvitationInvalid):
+			utils.WriteNotFoundError(w, "Invitation not found or expired")
+		case errors.Is(err, data.ErrAlreadyMember):
+			utils.WriteValidationError(w, "You are already a member of this organization")
+		default:
+			utils.WriteInternalServerError(w, "Failed to accept invitation")
+		}
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Invitation accepted successfully", member)
+}
+
+// sendInvitation queues the email of an invitation, when email delivery is set up
+func (h *OrganizationHandler) sendInvitation(r *http.Request, organization *data.Organization, invitation *data.OrganizationInvitation, token string) error {
+	if h.JobRepo == nil || h.DeliveryRepo == nil {
+		return nil
+	}
+
+	inviter := "A member"
+	if user, err := h.UserRepo.GetOne(r.Context(), middleware.GetActorIDFromRequest(r)); err == nil {
+		inviter = user.Name
+	}
+	deliveryID, err := h.DeliveryRepo.Insert(r.Context(), &data.MessageDelivery{
+		Purpose:   "organization_invitation",
+		Recipient: invitation.Email,
+		UserID:    &organization.OwnerID,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = h.JobRepo.Enqueue(r.Context(), data.JobTypeSendMessage, data.SendMessagePayload{
+		DeliveryID: deliveryID,
+		Channel:    data.DeliveryEmail,
+		To:         invitation.Email,
+		Subject:    fmt.Sprintf("%s invited you to %s", inviter, organization.Name),
+		Body: fmt.Sprintf("%s invited you to work on the books of %s as %s.\n\n"+
+			"Sign in, or sign up with your own account, and accept the invitation with this code:\n%s\n\n"+
+			"The invitation expires on %s.\n%s",
+			inviter, organization.Name, invitation.Role, token, invitation.ExpiresAt.Format("2 January 2006"), h.invitationURL(token)),
+	})
+	if err != nil {
+		return err
+	}
+	return h.OrganizationRepo.SetInvitationDelivery(r.Context(), invitation.ID, deliveryID)
+}
+
+// invitationURL returns the public page of an invitation
+func (h *OrganizationHandler) invitationURL(token string) string {
+	return h.BaseURL + "/api/v1/public/invitations/" + token
+}
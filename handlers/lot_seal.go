@@ -0,0 +1,289 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// Signing purposes of lot seal verification tokens and of seal digests
+const (
+	lotSealSignature = "lot-seal"
+	lotSealDigest    = "lot-seal-digest"
+)
+
+// LotSealHandler handles the chain-of-custody seals of lots sold in sales
+type LotSealHandler struct {
+	SealRepo      data.LotSealInterface
+	IncomeRepo    data.IncomeInterface
+	InventoryRepo data.InventoryInterface
+	MineSiteRepo  data.MineSiteInterface
+	UserRepo      data.UserInterface
+
+	// BaseURL is prepended to verification link paths, e.g. https://api.example.com
+	BaseURL string
+}
+
+// NewLotSealHandler creates a new LotSealHandler
+func NewLotSealHandler(sealRepo data.LotSealInterface, incomeRepo data.IncomeInterface, inventoryRepo data.InventoryInterface, mineSiteRepo data.MineSiteInterface, userRepo data.UserInterface) *LotSealHandler {
+	return &LotSealHandler{
+		SealRepo:      sealRepo,
+		IncomeRepo:    incomeRepo,
+		InventoryRepo: inventoryRepo,
+		MineSiteRepo:  mineSiteRepo,
+		UserRepo:      userRepo,
+	}
+}
+
+// SealLotRequest represents a request to seal the lot a sale was made from
+type SealLotRequest struct {
+	InventoryItemID uint `json:"inventory_item_id"`
+}
+
+// SealVerification is a lot seal with its lineage and whether it still matches its digest and
+// signature
+type SealVerification struct {
+	Valid     bool                `json:"valid"`
+	Digest    string              `json:"digest"`
+	Signature string              `json:"signature"`
+	SealedAt  time.Time           `json:"sealed_at"`
+	VerifyURL string              `json:"verify_url"`
+	Lineage   *data.SealedLineage `json:"lineage,omitempty"`
+}
+
+// SealLot seals the lineage of the lot a sale was made from: the site, the lot's origin and
+// stock movements, and the sale. A sale is sealed once.
+func (h *LotSealHandler) SealLot(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid income ID")
+		return
+	}
+
+	var req SealLotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if req.InventoryItemID == 0 {
+		utils.WriteValidationError(w, "Inventory item ID is required")
+		return
+	}
+
+	income, err := h.IncomeRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Income record not found")
+		return
+	}
+	if income.ApprovalStatus != nil && *income.ApprovalStatus != data.SaleApproved {
+		utils.WriteValidationError(w, "Only approved sales can be sealed")
+		return
+	}
+	item, err := h.InventoryRepo.GetOne(r.Context(), req.InventoryItemID, userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Inventory item not found")
+		return
+	}
+	if item.Type != "mineral" {
+		utils.WriteValidationError(w, "Only mineral lots can be sealed")
+		return
+	}
+
+	lineage, err := h.buildLineage(r.Context(), userID, income, item)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to build lot lineage")
+		return
+	}
+	encoded, err := json.Marshal(lineage)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to build lot lineage")
+		return
+	}
+	sum := sha256.Sum256(encoded)
+	digest := hex.EncodeToString(sum[:])
+
+	actorID := middleware.GetActorIDFromRequest(r)
+	seal := &data.LotSeal{
+		IncomeID:        income.ID,
+		InventoryItemID: item.ID,
+		Lineage:         string(encoded),
+		Digest:          digest,
+		Signature:       utils.SignDigest(lotSealDigest, digest),
+		SealedByID:      &actorID,
+		UserID:          userID,
+	}
+	if err := h.SealRepo.Insert(r.Context(), seal); err != nil {
+		if errors.Is(err, data.ErrLotSealExists) {
+			utils.WriteErrorResponse(w, "This sale is already sealed", http.StatusConflict)
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to seal lot")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Lot sealed successfully", verifySeal(seal, h.BaseURL))
+}
+
+// GetSeal returns the seal of a sale with its lineage
+func (h *LotSealHandler) GetSeal(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid income ID")
+		return
+	}
+
+	seal, err := h.SealRepo.GetByIncome(r.Context(), uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "This sale is not sealed")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to retrieve seal")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Seal retrieved successfully", verifySeal(seal, h.BaseURL))
+}
+
+// VerifySeal checks a seal through its verification link (no authentication). With digest, the
+// digest printed on a document must also be the sealed one.
+func (h *LotSealHandler) VerifySeal(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.VerifySignedID(lotSealSignature, chi.URLParam(r, "token"))
+	if err != nil {
+		utils.WriteNotFoundError(w, "Seal not found or not authentic")
+		return
+	}
+	seal, err := h.SealRepo.GetByID(r.Context(), id)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Seal not found or not authentic")
+		return
+	}
+
+	verification := verifySeal(seal, h.BaseURL)
+	if digest := r.URL.Query().Get("digest"); digest != "" && digest != seal.Digest {
+		verification.Valid = false
+	}
+	if !verification.Valid {
+		utils.WriteSuccessResponse(w, "Seal does not match its lineage", verification)
+		return
+	}
+	utils.WriteSuccessResponse(w, "Seal is valid", verification)
+}
+
+// buildLineage gathers the chain of custody of a lot up to its sale
+func (h *LotSealHandler) buildLineage(ctx context.Context, userID uint, income *data.Income, item *data.InventoryItem) (*data.SealedLineage, error) {
+	user, err := h.UserRepo.GetOne(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	movements, err := h.InventoryRepo.GetMovements(ctx, item.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	lineage := &data.SealedLineage{
+		Seller: user.Name,
+		Lot: data.SealedLot{
+			ID:               item.ID,
+			Name:             item.Name,
+			BatchNumber:      item.BatchNumber,
+			From:             item.From,
+			PitNumber:        item.PitNumber,
+			MinerName:        item.MinerName,
+			ProcessingMethod: item.ProcessingMethod,
+			Unit:             item.Unit,
+			RecordedAt:       item.CreatedAt.UTC(),
+		},
+		Movements: make([]data.SealedMovement, 0, len(movements)),
+		Sale: data.SealedSale{
+			IncomeID:      income.ID,
+			Date:          income.Date.UTC(),
+			MineralType:   income.MineralType,
+			Quantity:      income.Quantity,
+			Unit:          income.Unit,
+			CustomerName:  income.CustomerName,
+			InvoiceNumber: income.InvoiceNumber,
+		},
+		SealedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	for _, movement := range movements {
+		lineage.Movements = append(lineage.Movements, data.SealedMovement{
+			Date:     movement.CreatedAt.UTC(),
+			Type:     movement.Type,
+			Quantity: movement.Quantity,
+			Reason:   movement.Reason,
+		})
+	}
+
+	siteID := item.MineSiteID
+	if siteID == nil {
+		siteID = income.MineSiteID
+	}
+	var site *data.MineSiteInfo
+	if siteID != nil {
+		site, err = h.MineSiteRepo.GetOne(ctx, *siteID, userID)
+	} else {
+		site, err = h.MineSiteRepo.GetByUserID(ctx, userID)
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if site != nil {
+		lineage.Site = &data.SealedSite{
+			Name:      site.Name,
+			License:   site.License,
+			Location:  site.Location,
+			Latitude:  site.Latitude,
+			Longitude: site.Longitude,
+		}
+	}
+	return lineage, nil
+}
+
+// verifySeal checks that a seal's lineage still matches its digest and that the digest was
+// signed by this server
+func verifySeal(seal *data.LotSeal, baseURL string) *SealVerification {
+	sum := sha256.Sum256([]byte(seal.Lineage))
+	verification := &SealVerification{
+		Valid:     hex.EncodeToString(sum[:]) == seal.Digest && utils.VerifyDigest(lotSealDigest, seal.Digest, seal.Signature),
+		Digest:    seal.Digest,
+		Signature: seal.Signature,
+		SealedAt:  seal.CreatedAt,
+		VerifyURL: sealVerifyURL(baseURL, seal.ID),
+	}
+	var lineage data.SealedLineage
+	if err := json.Unmarshal([]byte(seal.Lineage), &lineage); err == nil {
+		verification.Lineage = &lineage
+	} else {
+		verification.Valid = false
+	}
+	return verification
+}
+
+// sealVerifyURL returns the signed public verification URL of a lot seal
+func sealVerifyURL(baseURL string, id uint) string {
+	return baseURL + "/api/v1/public/seals/" + utils.SignID(lotSealSignature, id)
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// MarketPriceHandler handles the market prices buying station purchases are priced from
+type MarketPriceHandler struct {
+	MarketPriceRepo data.MarketPriceInterface
+	MineSiteRepo    data.MineSiteInterface
+}
+
+// NewMarketPriceHandler creates a new MarketPriceHandler
+func NewMarketPriceHandler(marketPriceRepo data.MarketPriceInterface, mineSiteRepo data.MineSiteInterface) *MarketPriceHandler {
+	return &MarketPriceHandler{
+		MarketPriceRepo: marketPriceRepo,
+		MineSiteRepo:    mineSiteRepo,
+	}
+}
+
+// MarketPriceRequest represents a create market price request
+type MarketPriceRequest struct {
+	MineralType   string  `json:"mineral_type"`
+	Unit          string  `json:"unit"`
+	Price         float64 `json:"price"`          // per unit of pure mineral
+	EffectiveDate string  `json:"effective_date"` // defaults to today
+	Source        *string `json:"source,omitempty"`
+	MineSiteID    *uint   `json:"mine_site_id,omitempty"` // buying station the price applies to
+}
+
+// GetMarketPrices retrieves the market prices of the authenticated user, newest first,
+// optionally of a mineral
+func (h *MarketPriceHandler) GetMarketPrices(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	prices, err := h.MarketPriceRepo.GetAll(r.Context(), userID, data.MineralType(r.URL.Query().Get("mineral_type")))
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve market prices")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Market prices retrieved successfully", prices)
+}
+
+// GetCurrentMarketPrice retrieves the market price purchases of a mineral in a unit are priced
+// from on a date, at a buying station when site_id is set
+func (h *MarketPriceHandler) GetCurrentMarketPrice(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	query := r.URL.Query()
+	mineralType := query.Get("mineral_type")
+	unit := query.Get("unit")
+	if !utils.ValidateRequired(mineralType) || !utils.ValidateRequired(unit) {
+		utils.WriteValidationError(w, "Mineral type and unit are required")
+		return
+	}
+	siteID, ok := parseSiteFilter(w, r)
+	if !ok {
+		return
+	}
+	date := time.Now()
+	if value := query.Get("date"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid date format. Use YYYY-MM-DD")
+			return
+		}
+		date = parsed
+	}
+
+	price, err := h.MarketPriceRepo.GetCurrent(r.Context(), userID, data.MineralType(mineralType), unit, siteID, date)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "No market price on this date")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to retrieve market price")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Market price retrieved successfully", price)
+}
+
+// CreateMarketPrice records the market price of a mineral from a date on. Earlier prices are
+// kept so that the prices purchases were calculated from can be traced.
+func (h *MarketPriceHandler) CreateMarketPrice(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req MarketPriceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if !utils.ValidateRequired(req.MineralType) {
+		utils.WriteValidationError(w, "Mineral type is required")
+		return
+	}
+	unit := strings.TrimSpace(req.Unit)
+	if !utils.ValidateRequired(unit) {
+		utils.WriteValidationError(w, "Unit is required")
+		return
+	}
+	if !utils.ValidatePositiveNumber(req.Price) {
+		utils.WriteValidationError(w, "Price must be positive")
+		return
+	}
+	date := time.Now().Truncate(24 * time.Hour)
+	if req.EffectiveDate != "" {
+		parsed, err := time.Parse("2006-01-02", req.EffectiveDate)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid date format. Use YYYY-MM-DD")
+			return
+		}
+		date = parsed
+	}
+	if req.Source != nil && len(*req.Source) > 100 {
+		utils.WriteValidationError(w, "Source must be at most 100 characters")
+		return
+	}
+
+	// Prices can only be set for the user's own buying stations
+	if !checkMineSite(r.Context(), w, h.MineSiteRepo, userID, req.MineSiteID) {
+		return
+	}
+
+	price := &data.MarketPrice{
+		MineralType:   data.MineralType(req.MineralType),
+		Unit:          unit,
+		Price:         req.Price,
+		EffectiveDate: date,
+		Source:        req.Source,
+		MineSiteID:    req.MineSiteID,
+		UserID:        userID,
+	}
+	if _, err := h.MarketPriceRepo.Insert(r.Context(), price); err != nil {
+		utils.WriteInternalServerError(w, "Failed to create market price")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Market price created successfully", price)
+}
+
+// DeleteMarketPrice deletes a market price entered by mistake. Purchases keep the market price
+// they were calculated from.
+func (h *MarketPriceHandler) DeleteMarketPrice(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid market price ID")
+		return
+	}
+	if _, err := h.MarketPriceRepo.GetOne(r.Context(), uint(id), userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Market price not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to retrieve market price")
+		return
+	}
+
+	if err := h.MarketPriceRepo.Delete(r.Context(), uint(id), userID); err != nil {
+		utils.WriteInternalServerError(w, "Failed to delete market price")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Market price deleted successfully", nil)
+}
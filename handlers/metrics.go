@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"database/sql"
+	"mineral/pkg/utils"
+	"net/http"
+	"time"
+)
+
+// MetricsHandler handles operational metrics requests
+type MetricsHandler struct {
+	DBStats func() sql.DBStats
+	Queries func() (int64, time.Duration) // running total of queries and their duration
+}
+
+// NewMetricsHandler creates a new MetricsHandler
+func NewMetricsHandler(dbStats func() sql.DBStats, queries func() (int64, time.Duration)) *MetricsHandler {
+	return &MetricsHandler{
+		DBStats: dbStats,
+		Queries: queries,
+	}
+}
+
+// DatabaseMetrics represents the state of the database connection pool since startup
+type DatabaseMetrics struct {
+	MaxOpenConnections int     `json:"max_open_connections"`
+	OpenConnections    int     `json:"open_connections"`
+	InUse              int     `json:"in_use"`
+	Idle               int     `json:"idle"`
+	WaitCount          int64   `json:"wait_count"`       // times a query waited for a free connection
+	WaitDurationMs     float64 `json:"wait_duration_ms"` // total time spent waiting for connections
+	MaxIdleClosed      int64   `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64   `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64   `json:"max_lifetime_closed"`
+	Queries            int64   `json:"queries"`
+	QueryDurationMs    float64 `json:"query_duration_ms"`
+}
+
+// Metrics represents the operational metrics of the server
+type Metrics struct {
+	Database DatabaseMetrics `json:"database"`
+}
+
+// GetMetrics returns the database connection pool stats and query totals, e.g. to tune the
+// pool settings
+func (h *MetricsHandler) GetMetrics(w http.ResponseWriter, r *http.Request) {
+	stats := h.DBStats()
+	queries, elapsed := h.Queries()
+
+	utils.WriteSuccessResponse(w, "Metrics retrieved successfully", &Metrics{
+		Database: DatabaseMetrics{
+			MaxOpenConnections: stats.MaxOpenConnections,
+			OpenConnections:    stats.OpenConnections,
+			InUse:              stats.InUse,
+			Idle:               stats.Idle,
+			WaitCount:          stats.WaitCount,
+			WaitDurationMs:     milliseconds(stats.WaitDuration),
+			MaxIdleClosed:      stats.MaxIdleClosed,
+			MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+			MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+			Queries:            queries,
+			QueryDurationMs:    milliseconds(elapsed),
+		},
+	})
+}
+
+// milliseconds converts a duration to fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// MinerHandler handles the registry of individual miners supplying a buying station
+type MinerHandler struct {
+	MinerRepo    data.MinerInterface
+	PurchaseRepo data.PurchaseInterface
+	MineSiteRepo data.MineSiteInterface
+}
+
+// NewMinerHandler creates a new MinerHandler
+func NewMinerHandler(minerRepo data.MinerInterface, purchaseRepo data.PurchaseInterface, mineSiteRepo data.MineSiteInterface) *MinerHandler {
+	return &MinerHandler{
+		MinerRepo:    minerRepo,
+		PurchaseRepo: purchaseRepo,
+		MineSiteRepo: mineSiteRepo,
+	}
+}
+
+// MinerRequest represents a create or update miner request
+type MinerRequest struct {
+	Name              string  `json:"name"`
+	Phone             *string `json:"phone,omitempty"`
+	IDDocumentType    *string `json:"id_document_type,omitempty"`
+	IDNumber          *string `json:"id_number,omitempty"`
+	Village           *string `json:"village,omitempty"`
+	MineSiteID        *uint   `json:"mine_site_id,omitempty"`
+	PitNumber         *string `json:"pit_number,omitempty"`
+	PaymentMethod     string  `json:"payment_method"` // defaults to cash
+	MobileMoneyNumber *string `json:"mobile_money_number,omitempty"`
+	BankName          *string `json:"bank_name,omitempty"`
+	BankAccountNumber *string `json:"bank_account_number,omitempty"`
+	Active            *bool   `json:"active,omitempty"`
+	Notes             *string `json:"notes,omitempty"`
+}
+
+// GetAllMiners retrieves the registered miners of the authenticated user, optionally of a site
+// and by KYC completeness (kyc=complete or kyc=incomplete)
+func (h *MinerHandler) GetAllMiners(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	siteID, ok := parseSiteFilter(w, r)
+	if !ok {
+		return
+	}
+	kyc := r.URL.Query().Get("kyc")
+	if kyc != "" && kyc != "complete" && kyc != "incomplete" {
+		utils.WriteValidationError(w, "KYC filter must be complete or incomplete")
+		return
+	}
+
+	miners, err := h.MinerRepo.GetAll(r.Context(), userID, siteID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve miners")
+		return
+	}
+	if kyc != "" {
+		filtered := make([]*data.Miner, 0, len(miners))
+		for _, miner := range miners {
+			if miner.KYCComplete == (kyc == "complete") {
+				filtered = append(filtered, miner)
+			}
+		}
+		miners = filtered
+	}
+
+	utils.WriteSuccessResponse(w, "Miners retrieved successfully", miners)
+}
+
+// GetMiner retrieves a registered miner with their KYC completeness
+func (h *MinerHandler) GetMiner(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	miner, ok := h.miner(w, r, userID)
+	if !ok {
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Miner retrieved successfully", miner)
+}
+
+// CreateMiner registers a miner
+func (h *MinerHandler) CreateMiner(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req MinerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	miner := &data.Miner{Active: true, UserID: userID}
+	if !h.applyRequest(r.Context(), w, userID, &req, miner) {
+		return
+	}
+
+	if _, err := h.MinerRepo.Insert(r.Context(), miner); err != nil {
+		utils.WriteInternalServerError(w, "Failed to create miner")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Miner created successfully", miner)
+}
+
+// UpdateMiner updates a registered miner
+func (h *MinerHandler) UpdateMiner(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	miner, ok := h.miner(w, r, userID)
+	if !ok {
+		return
+	}
+
+	var req MinerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if !h.applyRequest(r.Context(), w, userID, &req, miner) {
+		return
+	}
+
+	if err := h.MinerRepo.Update(r.Context(), miner); err != nil {
+		utils.WriteInternalServerError(w, "Failed to update miner")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Miner updated successfully", miner)
+}
+
+// DeleteMiner deletes a registered miner that no purchases were made from
+func (h *MinerHandler) DeleteMiner(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	miner, ok := h.miner(w, r, userID)
+	if !ok {
+		return
+	}
+
+	if err := h.MinerRepo.Delete(r.Context(), miner.ID, userID); err != nil {
+		if errors.Is(err, data.ErrMinerHasPurchases) {
+			utils.WriteErrorResponse(w, "Purchases have been made from this miner; mark them inactive instead", http.StatusConflict)
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to delete miner")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Miner deleted successfully", nil)
+}
+
+// GetMinerPurchases retrieves the purchases made from a registered miner
+func (h *MinerHandler) GetMinerPurchases(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	miner, ok := h.miner(w, r, userID)
+	if !ok {
+		return
+	}
+
+	purchases, err := h.PurchaseRepo.GetForMiner(r.Context(), userID, miner.ID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve purchases")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Purchases retrieved successfully", purchases)
+}
+
+// applyRequest validates a miner request and applies it to miner, writing the error response
+// and returning false when it is invalid
+func (h *MinerHandler) applyRequest(ctx context.Context, w http.ResponseWriter, userID uint, req *MinerRequest, miner *data.Miner) bool {
+	name := strings.TrimSpace(req.Name)
+	if !utils.ValidateRequired(name) {
+		utils.WriteValidationError(w, "Name is required")
+		return false
+	}
+	if len(name) > 100 {
+		utils.WriteValidationError(w, "Name must be at most 100 characters")
+		return false
+	}
+	if req.Phone != nil && *req.Phone != "" && !utils.ValidatePhone(*req.Phone) {
+		utils.WriteValidationError(w, "Invalid phone number format")
+		return false
+	}
+	if req.MobileMoneyNumber != nil && *req.MobileMoneyNumber != "" && !utils.ValidatePhone(*req.MobileMoneyNumber) {
+		utils.WriteValidationError(w, "Invalid mobile money number format")
+		return false
+	}
+	if req.IDNumber != nil && len(*req.IDNumber) > 50 {
+		utils.WriteValidationError(w, "ID number must be at most 50 characters")
+		return false
+	}
+	paymentMethod := data.MinerPaidCash
+	if req.PaymentMethod != "" {
+		paymentMethod = data.MinerPaymentMethod(req.PaymentMethod)
+	}
+	switch paymentMethod {
+	case data.MinerPaidCash, data.MinerPaidMobileMoney, data.MinerPaidBank:
+	default:
+		utils.WriteValidationError(w, "Payment method must be cash, mobile_money or bank")
+		return false
+	}
+
+	// Miners can only be assigned to the user's own mine sites
+	if !checkMineSite(ctx, w, h.MineSiteRepo, userID, req.MineSiteID) {
+		return false
+	}
+
+	miner.Name = name
+	miner.Phone = req.Phone
+	miner.IDDocumentType = req.IDDocumentType
+	miner.IDNumber = req.IDNumber
+	miner.Village = req.Village
+	miner.MineSiteID = req.MineSiteID
+	miner.PitNumber = req.PitNumber
+	miner.PaymentMethod = paymentMethod
+	miner.MobileMoneyNumber = req.MobileMoneyNumber
+	miner.BankName = req.BankName
+	miner.BankAccountNumber = req.BankAccountNumber
+	miner.Notes = req.Notes
+	if req.Active != nil {
+		miner.Active = *req.Active
+	}
+	return true
+}
+
+// miner returns the registered miner of a request, writing the error response and returning
+// false when it doesn't exist
+func (h *MinerHandler) miner(w http.ResponseWriter, r *http.Request, userID uint) (*data.Miner, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid miner ID")
+		return nil, false
+	}
+	miner, err := h.MinerRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Miner not found")
+			return nil, false
+		}
+		utils.WriteInternalServerError(w, "Failed to retrieve miner")
+		return nil, false
+	}
+	return miner, true
+}
@@ -1,11 +1,18 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"mineral/data"
 	"mineral/pkg/middleware"
 	"mineral/pkg/utils"
 	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
 )
 
 // MineSiteHandler handles mine site information requests
@@ -22,9 +29,12 @@ func NewMineSiteHandler(mineSiteRepo data.MineSiteInterface) *MineSiteHandler {
 
 // MineSiteRequest represents a mine site information request
 type MineSiteRequest struct {
+	Name            *string  `json:"name,omitempty"` // tells an operator's sites apart
 	Owner           string   `json:"owner"`
 	License         *string  `json:"license,omitempty"`
+	LicenseExpiry   *string  `json:"license_expiry,omitempty"` // YYYY-MM-DD
 	Location        string   `json:"location"`
+	Region          *string  `json:"region,omitempty"` // district or region for price benchmarks
 	Size            *float64 `json:"size,omitempty"`
 	NumberOfPits    *int     `json:"number_of_pits,omitempty"`
 	Commodities     *string  `json:"commodities,omitempty"`
@@ -32,9 +42,12 @@ type MineSiteRequest struct {
 	Employees       *int     `json:"employees,omitempty"`
 	EstablishedYear *int     `json:"established_year,omitempty"`
 	Contact         *string  `json:"contact,omitempty"`
+	Latitude        *float64 `json:"latitude,omitempty"`
+	Longitude       *float64 `json:"longitude,omitempty"`
+	GeofenceRadius  *float64 `json:"geofence_radius,omitempty"` // meters, defaults to 500 for check-in
 }
 
-// GetMineSiteInfo retrieves mine site information for the authenticated user
+// GetMineSiteInfo retrieves the first mine site of the authenticated user
 func (h *MineSiteHandler) GetMineSiteInfo(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserIDFromRequest(r)
 	if userID == 0 {
@@ -42,7 +55,7 @@ func (h *MineSiteHandler) GetMineSiteInfo(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	info, err := h.MineSiteRepo.GetByUserID(userID)
+	info, err := h.MineSiteRepo.GetByUserID(r.Context(), userID)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to retrieve mine site information")
 		return
@@ -57,7 +70,7 @@ func (h *MineSiteHandler) GetMineSiteInfo(w http.ResponseWriter, r *http.Request
 	utils.WriteSuccessResponse(w, "Mine site information retrieved successfully", info)
 }
 
-// CreateOrUpdateMineSiteInfo creates or updates mine site information for the authenticated user
+// CreateOrUpdateMineSiteInfo creates or updates the first mine site of the authenticated user
 func (h *MineSiteHandler) CreateOrUpdateMineSiteInfo(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserIDFromRequest(r)
 	if userID == 0 {
@@ -71,18 +84,13 @@ func (h *MineSiteHandler) CreateOrUpdateMineSiteInfo(w http.ResponseWriter, r *h
 		return
 	}
 
-	// Validate required fields
-	if req.Owner == "" {
-		utils.WriteValidationError(w, "Owner is required")
-		return
-	}
-	if req.Location == "" {
-		utils.WriteValidationError(w, "Location is required")
+	licenseExpiry, ok := req.validate(w)
+	if !ok {
 		return
 	}
 
 	// Check if mine site info already exists
-	existingInfo, err := h.MineSiteRepo.GetByUserID(userID)
+	existingInfo, err := h.MineSiteRepo.GetByUserID(r.Context(), userID)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to check existing mine site information")
 		return
@@ -90,18 +98,9 @@ func (h *MineSiteHandler) CreateOrUpdateMineSiteInfo(w http.ResponseWriter, r *h
 
 	if existingInfo != nil {
 		// Update existing record
-		existingInfo.Owner = req.Owner
-		existingInfo.License = req.License
-		existingInfo.Location = req.Location
-		existingInfo.Size = req.Size
-		existingInfo.NumberOfPits = req.NumberOfPits
-		existingInfo.Commodities = req.Commodities
-		existingInfo.Equipment = req.Equipment
-		existingInfo.Employees = req.Employees
-		existingInfo.EstablishedYear = req.EstablishedYear
-		existingInfo.Contact = req.Contact
-
-		if err := h.MineSiteRepo.Update(existingInfo); err != nil {
+		req.apply(existingInfo, licenseExpiry)
+
+		if err := h.MineSiteRepo.Update(r.Context(), existingInfo); err != nil {
 			utils.WriteInternalServerError(w, "Failed to update mine site information")
 			return
 		}
@@ -111,21 +110,10 @@ func (h *MineSiteHandler) CreateOrUpdateMineSiteInfo(w http.ResponseWriter, r *h
 	}
 
 	// Create new record
-	newInfo := &data.MineSiteInfo{
-		Owner:           req.Owner,
-		License:         req.License,
-		Location:        req.Location,
-		Size:            req.Size,
-		NumberOfPits:    req.NumberOfPits,
-		Commodities:     req.Commodities,
-		Equipment:       req.Equipment,
-		Employees:       req.Employees,
-		EstablishedYear: req.EstablishedYear,
-		Contact:         req.Contact,
-		UserID:          userID,
-	}
-
-	id, err := h.MineSiteRepo.Insert(newInfo)
+	newInfo := &data.MineSiteInfo{UserID: userID}
+	req.apply(newInfo, licenseExpiry)
+
+	id, err := h.MineSiteRepo.Insert(r.Context(), newInfo)
 	if err != nil {
 		utils.WriteInternalServerError(w, "Failed to create mine site information")
 		return
@@ -134,3 +122,234 @@ func (h *MineSiteHandler) CreateOrUpdateMineSiteInfo(w http.ResponseWriter, r *h
 	newInfo.ID = id
 	utils.WriteSuccessResponse(w, "Mine site information created successfully", newInfo)
 }
+
+// GetMineSites retrieves all mine sites of the authenticated user
+func (h *MineSiteHandler) GetMineSites(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	sites, err := h.MineSiteRepo.GetAll(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve mine sites")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Mine sites retrieved successfully", sites)
+}
+
+// GetMineSite retrieves a specific mine site
+func (h *MineSiteHandler) GetMineSite(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid mine site ID")
+		return
+	}
+
+	site, err := h.MineSiteRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Mine site not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Mine site retrieved successfully", site)
+}
+
+// CreateMineSite adds a mine site, for operators running more than one pit
+func (h *MineSiteHandler) CreateMineSite(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req MineSiteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	licenseExpiry, ok := req.validate(w)
+	if !ok {
+		return
+	}
+
+	site := &data.MineSiteInfo{UserID: userID}
+	req.apply(site, licenseExpiry)
+	id, err := h.MineSiteRepo.Insert(r.Context(), site)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to create mine site")
+		return
+	}
+
+	site.ID = id
+	utils.WriteSuccessResponse(w, "Mine site created successfully", site)
+}
+
+// UpdateMineSite updates a specific mine site
+func (h *MineSiteHandler) UpdateMineSite(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid mine site ID")
+		return
+	}
+
+	var req MineSiteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	licenseExpiry, ok := req.validate(w)
+	if !ok {
+		return
+	}
+
+	site, err := h.MineSiteRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Mine site not found")
+		return
+	}
+
+	req.apply(site, licenseExpiry)
+	if err := h.MineSiteRepo.Update(r.Context(), site); err != nil {
+		utils.WriteInternalServerError(w, "Failed to update mine site")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Mine site updated successfully", site)
+}
+
+// DeleteMineSite deletes a specific mine site. Records assigned to it keep their site, so its
+// books can still be filtered.
+func (h *MineSiteHandler) DeleteMineSite(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid mine site ID")
+		return
+	}
+
+	if err := h.MineSiteRepo.Delete(r.Context(), uint(id), userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Mine site not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to delete mine site")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Mine site deleted successfully", nil)
+}
+
+// validate validates a mine site request, returning its license expiry. It writes the error
+// response and returns false when the request is invalid.
+func (req *MineSiteRequest) validate(w http.ResponseWriter) (*time.Time, bool) {
+	if req.Name != nil && len(*req.Name) > 100 {
+		utils.WriteValidationError(w, "Name must be at most 100 characters")
+		return nil, false
+	}
+	if req.Owner == "" {
+		utils.WriteValidationError(w, "Owner is required")
+		return nil, false
+	}
+	if req.Location == "" {
+		utils.WriteValidationError(w, "Location is required")
+		return nil, false
+	}
+
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		utils.WriteValidationError(w, "Latitude and longitude must be set together")
+		return nil, false
+	}
+	if req.Latitude != nil && !utils.ValidateCoordinates(*req.Latitude, *req.Longitude) {
+		utils.WriteValidationError(w, "Invalid coordinates")
+		return nil, false
+	}
+	if req.GeofenceRadius != nil && !utils.ValidatePositiveNumber(*req.GeofenceRadius) {
+		utils.WriteValidationError(w, "Geofence radius must be positive")
+		return nil, false
+	}
+
+	licenseExpiry, err := parseOptionalDate(req.LicenseExpiry)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid license expiry format. Use YYYY-MM-DD")
+		return nil, false
+	}
+
+	return licenseExpiry, true
+}
+
+// apply sets the fields of a mine site from a validated request
+func (req *MineSiteRequest) apply(site *data.MineSiteInfo, licenseExpiry *time.Time) {
+	site.Name = req.Name
+	site.Owner = req.Owner
+	site.License = req.License
+	site.LicenseExpiry = licenseExpiry
+	site.Location = req.Location
+	site.Region = req.Region
+	site.Size = req.Size
+	site.NumberOfPits = req.NumberOfPits
+	site.Commodities = req.Commodities
+	site.Equipment = req.Equipment
+	site.Employees = req.Employees
+	site.EstablishedYear = req.EstablishedYear
+	site.Contact = req.Contact
+	site.Latitude = req.Latitude
+	site.Longitude = req.Longitude
+	site.GeofenceRadius = req.GeofenceRadius
+}
+
+// parseSiteFilter parses the site_id query parameter narrowing a list to a mine site, returning
+// nil when it isn't set. It writes the error response and returns false when it is invalid.
+func parseSiteFilter(w http.ResponseWriter, r *http.Request) (*uint, bool) {
+	value := r.URL.Query().Get("site_id")
+	if value == "" {
+		return nil, true
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid site ID")
+		return nil, false
+	}
+	siteID := uint(id)
+	return &siteID, true
+}
+
+// checkMineSite checks that the mine site a record is assigned to, if any, is one of the user's
+// sites. It writes the error response and returns false when it isn't.
+func checkMineSite(ctx context.Context, w http.ResponseWriter, mineSiteRepo data.MineSiteInterface, userID uint, siteID *uint) bool {
+	if siteID == nil {
+		return true
+	}
+	if mineSiteRepo == nil {
+		utils.WriteValidationError(w, "Mine site not found")
+		return false
+	}
+	if _, err := mineSiteRepo.GetOne(ctx, *siteID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteValidationError(w, "Mine site not found")
+			return false
+		}
+		utils.WriteInternalServerError(w, "Failed to check mine site")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// NotificationHandler handles in-app notification requests
+type NotificationHandler struct {
+	NotificationRepo data.NotificationInterface
+}
+
+// NewNotificationHandler creates a new NotificationHandler
+func NewNotificationHandler(notificationRepo data.NotificationInterface) *NotificationHandler {
+	return &NotificationHandler{
+		NotificationRepo: notificationRepo,
+	}
+}
+
+// GetNotifications retrieves notifications for the authenticated user
+func (h *NotificationHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+
+	notifications, err := h.NotificationRepo.GetAll(r.Context(), userID, unreadOnly)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve notifications")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Notifications retrieved successfully", notifications)
+}
+
+// MarkNotificationRead marks a single notification as read
+func (h *NotificationHandler) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid notification ID")
+		return
+	}
+
+	err = h.NotificationRepo.MarkRead(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to update notification")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Notification marked as read", nil)
+}
+
+// MarkAllNotificationsRead marks all notifications of the authenticated user as read
+func (h *NotificationHandler) MarkAllNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	err := h.NotificationRepo.MarkAllRead(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to update notifications")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "All notifications marked as read", nil)
+}
@@ -0,0 +1,608 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// OrganizationHandler handles organization and membership requests
+type OrganizationHandler struct {
+	OrganizationRepo data.OrganizationInterface
+	UserRepo         data.UserInterface
+
+	// JobRepo and DeliveryRepo email invitations when both are set
+	JobRepo      data.JobInterface
+	DeliveryRepo data.DeliveryInterface
+
+	// BaseURL is prepended to the public page of invitations, e.g. https://api.example.com
+	BaseURL string
+}
+
+// NewOrganizationHandler creates a new OrganizationHandler
+func NewOrganizationHandler(organizationRepo data.OrganizationInterface, userRepo data.UserInterface) *OrganizationHandler {
+	return &OrganizationHandler{
+		OrganizationRepo: organizationRepo,
+		UserRepo:         userRepo,
+	}
+}
+
+// OrganizationRequest represents a create or update organization request
+type OrganizationRequest struct {
+	Name string `json:"name"`
+}
+
+// AddMemberRequest represents a request to add a user to an organization
+type AddMemberRequest struct {
+	Email     string                `json:"email"`
+	Role      data.OrganizationRole `json:"role"`
+	CanExport bool                  `json:"can_export"`
+}
+
+// UpdateMemberRequest represents a request to change a member's role or export permission;
+// omitted fields are left unchanged
+type UpdateMemberRequest struct {
+	Role      *data.OrganizationRole `json:"role,omitempty"`
+	CanExport *bool                  `json:"can_export,omitempty"`
+}
+
+// RolePermissionsRequest represents a request to replace the permissions of a role
+type RolePermissionsRequest struct {
+	Permissions []data.Permission `json:"permissions"`
+}
+
+// IPRuleRequest represents a request to add an IP allowlist rule
+type IPRuleRequest struct {
+	Role        data.OrganizationRole `json:"role"`
+	CIDR        string                `json:"cidr"` // a CIDR range or a single IP address
+	Description *string               `json:"description,omitempty"`
+}
+
+// ResolveMembership resolves the access of a user in an organization for the organization
+// context middleware
+func (h *OrganizationHandler) ResolveMembership(ctx context.Context, organizationID, userID uint) (*middleware.OrganizationAccess, error) {
+	membership, err := h.OrganizationRepo.GetMembership(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := h.OrganizationRepo.GetRolePermissions(ctx, organizationID, membership.Role)
+	if err != nil {
+		return nil, err
+	}
+	access := &middleware.OrganizationAccess{
+		OwnerID:   membership.Organization.OwnerID,
+		Role:      string(membership.Role),
+		CanExport: membership.CanExport || membership.Role == data.OrgRoleOwner || membership.Role == data.OrgRoleAuditor,
+		ReadOnly:  readOnlyRole(membership.Role),
+	}
+	for _, permission := range permissions.Permissions {
+		access.Permissions = append(access.Permissions, string(permission))
+	}
+
+	// Owners are never restricted so they cannot lock themselves out
+	if membership.Role != data.OrgRoleOwner {
+		rules, err := h.OrganizationRepo.GetIPRules(ctx, organizationID)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range rules {
+			if rule.Role == membership.Role {
+				access.AllowedIPs = append(access.AllowedIPs, rule.CIDR)
+			}
+		}
+	}
+
+	return access, nil
+}
+
+// GetMyOrganizations retrieves the organizations the authenticated user belongs to
+func (h *OrganizationHandler) GetMyOrganizations(w http.ResponseWriter, r *http.Request) {
+	actorID := middleware.GetActorIDFromRequest(r)
+	if actorID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	memberships, err := h.OrganizationRepo.GetMemberships(r.Context(), actorID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve organizations")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Organizations retrieved successfully", memberships)
+}
+
+// CreateOrganization creates an organization for the authenticated user's books
+func (h *OrganizationHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	actorID := middleware.GetActorIDFromRequest(r)
+	if actorID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req OrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	if !utils.ValidateRequired(req.Name) {
+		utils.WriteValidationError(w, "Name is required")
+		return
+	}
+
+	organization := &data.Organization{
+		Name:    req.Name,
+		OwnerID: actorID,
+	}
+
+	organizationID, err := h.OrganizationRepo.Create(r.Context(), organization)
+	if err != nil {
+		if errors.Is(err, data.ErrOrganizationExists) {
+			utils.WriteValidationError(w, "You already own an organization")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to create organization")
+		return
+	}
+
+	organization.ID = organizationID
+	utils.WriteSuccessResponse(w, "Organization created successfully", organization)
+}
+
+// GetOrganization retrieves an organization with its members
+func (h *OrganizationHandler) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	organizationID, ok := h.authorizeMember(w, r, false)
+	if !ok {
+		return
+	}
+
+	organization, err := h.OrganizationRepo.GetOne(r.Context(), organizationID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Organization not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Organization retrieved successfully", organization)
+}
+
+// UpdateOrganization renames an organization
+func (h *OrganizationHandler) UpdateOrganization(w http.ResponseWriter, r *http.Request) {
+	organizationID, ok := h.authorizeMember(w, r, true)
+	if !ok {
+		return
+	}
+
+	var req OrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	if !utils.ValidateRequired(req.Name) {
+		utils.WriteValidationError(w, "Name is required")
+		return
+	}
+
+	organization, err := h.OrganizationRepo.GetOne(r.Context(), organizationID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Organization not found")
+		return
+	}
+
+	organization.Name = req.Name
+	if err := h.OrganizationRepo.Update(r.Context(), organization); err != nil {
+		utils.WriteInternalServerError(w, "Failed to update organization")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Organization updated successfully", organization)
+}
+
+// AddMember adds an existing user to an organization
+func (h *OrganizationHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	organizationID, ok := h.authorizeMember(w, r, true)
+	if !ok {
+		return
+	}
+
+	var req AddMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	req.Email = strings.TrimSpace(req.Email)
+	if !utils.ValidateEmail(req.Email) {
+		utils.WriteValidationError(w, "Invalid email format")
+		return
+	}
+	if !validMemberRole(req.Role) {
+		utils.WriteValidationError(w, "Role must be manager, accountant, clerk, auditor or viewer")
+		return
+	}
+
+	user, err := h.UserRepo.GetByEmail(r.Context(), req.Email)
+	if err != nil {
+		utils.WriteNotFoundError(w, "No user with this email address")
+		return
+	}
+
+	member := &data.OrganizationMember{
+		OrganizationID: organizationID,
+		UserID:         user.ID,
+		Role:           req.Role,
+		CanExport:      req.CanExport,
+	}
+
+	memberID, err := h.OrganizationRepo.AddMember(r.Context(), member)
+	if err != nil {
+		if errors.Is(err, data.ErrAlreadyMember) {
+			utils.WriteValidationError(w, "User is already a member of this organization")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to add member")
+		return
+	}
+
+	member.ID = memberID
+	member.User = user
+	utils.WriteSuccessResponse(w, "Member added successfully", member)
+}
+
+// UpdateMember changes the role or export permission of a member
+func (h *OrganizationHandler) UpdateMember(w http.ResponseWriter, r *http.Request) {
+	organizationID, ok := h.authorizeMember(w, r, true)
+	if !ok {
+		return
+	}
+
+	userIDStr := chi.URLParam(r, "userId")
+	memberUserID, err := strconv.ParseUint(userIDStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid user ID")
+		return
+	}
+
+	var req UpdateMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	member, err := h.OrganizationRepo.GetMembership(r.Context(), organizationID, uint(memberUserID))
+	if err != nil {
+		utils.WriteNotFoundError(w, "Member not found")
+		return
+	}
+
+	role, canExport := member.Role, member.CanExport
+	if req.Role != nil {
+		if !validMemberRole(*req.Role) {
+			utils.WriteValidationError(w, "Role must be manager, accountant, clerk, auditor or viewer")
+			return
+		}
+		role = *req.Role
+	}
+	if req.CanExport != nil {
+		canExport = *req.CanExport
+	}
+
+	err = h.OrganizationRepo.UpdateMember(r.Context(), organizationID, uint(memberUserID), role, canExport)
+	if err != nil {
+		if errors.Is(err, data.ErrOwnerMembership) {
+			utils.WriteValidationError(w, "The organization owner cannot be changed")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to update member")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Member updated successfully", nil)
+}
+
+// RemoveMember removes a member from an organization; members may also remove themselves
+func (h *OrganizationHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userIDStr := chi.URLParam(r, "userId")
+	memberUserID, err := strconv.ParseUint(userIDStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid user ID")
+		return
+	}
+
+	leaving := uint(memberUserID) == middleware.GetActorIDFromRequest(r)
+	organizationID, ok := h.authorizeMember(w, r, !leaving)
+	if !ok {
+		return
+	}
+
+	err = h.OrganizationRepo.RemoveMember(r.Context(), organizationID, uint(memberUserID))
+	if err != nil {
+		if errors.Is(err, data.ErrOwnerMembership) {
+			utils.WriteValidationError(w, "Member not found or is the organization owner")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to remove member")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Member removed successfully", nil)
+}
+
+// GetIPRules retrieves the IP allowlist rules of an organization
+func (h *OrganizationHandler) GetIPRules(w http.ResponseWriter, r *http.Request) {
+	organizationID, ok := h.authorizeMember(w, r, true)
+	if !ok {
+		return
+	}
+
+	rules, err := h.OrganizationRepo.GetIPRules(r.Context(), organizationID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve IP allowlist")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "IP allowlist retrieved successfully", rules)
+}
+
+// AddIPRule restricts members with a role to an address range
+func (h *OrganizationHandler) AddIPRule(w http.ResponseWriter, r *http.Request) {
+	organizationID, ok := h.authorizeMember(w, r, true)
+	if !ok {
+		return
+	}
+
+	var req IPRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	if !validMemberRole(req.Role) {
+		utils.WriteValidationError(w, "Role must be manager, accountant, clerk, auditor or viewer")
+		return
+	}
+
+	cidr, ok := normalizeCIDR(req.CIDR)
+	if !ok {
+		utils.WriteValidationError(w, "Invalid IP address or CIDR range")
+		return
+	}
+
+	rule := &data.OrganizationIPRule{
+		OrganizationID: organizationID,
+		Role:           req.Role,
+		CIDR:           cidr,
+		Description:    req.Description,
+	}
+
+	ruleID, err := h.OrganizationRepo.AddIPRule(r.Context(), rule)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to add IP allowlist rule")
+		return
+	}
+
+	rule.ID = ruleID
+	utils.WriteSuccessResponse(w, "IP allowlist rule added successfully", rule)
+}
+
+// DeleteIPRule removes an IP allowlist rule
+func (h *OrganizationHandler) DeleteIPRule(w http.ResponseWriter, r *http.Request) {
+	organizationID, ok := h.authorizeMember(w, r, true)
+	if !ok {
+		return
+	}
+
+	ruleIDStr := chi.URLParam(r, "ruleId")
+	ruleID, err := strconv.ParseUint(ruleIDStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid rule ID")
+		return
+	}
+
+	err = h.OrganizationRepo.DeleteIPRule(r.Context(), uint(ruleID), organizationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "IP allowlist rule not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to delete IP allowlist rule")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "IP allowlist rule deleted successfully", nil)
+}
+
+// GetRolePermissions retrieves the permissions of each role in an organization
+func (h *OrganizationHandler) GetRolePermissions(w http.ResponseWriter, r *http.Request) {
+	organizationID, ok := h.authorizeMember(w, r, false)
+	if !ok {
+		return
+	}
+
+	roles := []data.OrganizationRole{
+		data.OrgRoleOwner, data.OrgRoleManager, data.OrgRoleAccountant, data.OrgRoleClerk, data.OrgRoleAuditor, data.OrgRoleViewer,
+	}
+	permissions := make([]*data.RolePermissions, 0, len(roles))
+	for _, role := range roles {
+		rolePermissions, err := h.OrganizationRepo.GetRolePermissions(r.Context(), organizationID, role)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to retrieve role permissions")
+			return
+		}
+		permissions = append(permissions, rolePermissions)
+	}
+
+	utils.WriteSuccessResponse(w, "Role permissions retrieved successfully", permissions)
+}
+
+// UpdateRolePermissions replaces the permissions of a role in an organization
+func (h *OrganizationHandler) UpdateRolePermissions(w http.ResponseWriter, r *http.Request) {
+	organizationID, role, ok := h.authorizeRoleChange(w, r)
+	if !ok {
+		return
+	}
+
+	var req RolePermissionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if len(req.Permissions) == 0 {
+		utils.WriteValidationError(w, "At least one permission is required; use the viewer role for read-only access")
+		return
+	}
+	seen := make(map[data.Permission]bool)
+	permissions := make([]data.Permission, 0, len(req.Permissions))
+	for _, permission := range req.Permissions {
+		if !validPermission(permission) {
+			utils.WriteValidationError(w, fmt.Sprintf("Unknown permission %q", permission))
+			return
+		}
+		if readOnlyRole(role) && permission != data.PermAuditView {
+			utils.WriteValidationError(w, fmt.Sprintf("The %s role is read-only and can only be given %s", role, data.PermAuditView))
+			return
+		}
+		if !seen[permission] {
+			seen[permission] = true
+			permissions = append(permissions, permission)
+		}
+	}
+
+	if err := h.OrganizationRepo.SetRolePermissions(r.Context(), organizationID, role, permissions); err != nil {
+		utils.WriteInternalServerError(w, "Failed to update role permissions")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Role permissions updated successfully", &data.RolePermissions{
+		Role:        role,
+		Permissions: permissions,
+		Customized:  true,
+	})
+}
+
+// ResetRolePermissions gives a role in an organization its default permissions again
+func (h *OrganizationHandler) ResetRolePermissions(w http.ResponseWriter, r *http.Request) {
+	organizationID, role, ok := h.authorizeRoleChange(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.OrganizationRepo.ResetRolePermissions(r.Context(), organizationID, role); err != nil {
+		utils.WriteInternalServerError(w, "Failed to reset role permissions")
+		return
+	}
+	permissions, err := h.OrganizationRepo.GetRolePermissions(r.Context(), organizationID, role)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve role permissions")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Role permissions reset successfully", permissions)
+}
+
+// authorizeRoleChange checks that the acting user owns the organization in the URL and that the
+// role in the URL can be changed, returning both. Only owners change permissions, so managers
+// cannot widen their own. It writes the error response on failure.
+func (h *OrganizationHandler) authorizeRoleChange(w http.ResponseWriter, r *http.Request) (uint, data.OrganizationRole, bool) {
+	organizationID, ok := h.authorizeMember(w, r, true)
+	if !ok {
+		return 0, "", false
+	}
+	organization, err := h.OrganizationRepo.GetOne(r.Context(), organizationID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Organization not found")
+		return 0, "", false
+	}
+	if organization.OwnerID != middleware.GetActorIDFromRequest(r) {
+		utils.WriteForbiddenError(w, "Only the owner can change role permissions")
+		return 0, "", false
+	}
+
+	role := data.OrganizationRole(chi.URLParam(r, "role"))
+	if !validMemberRole(role) {
+		utils.WriteValidationError(w, "Role must be manager, accountant, clerk, auditor or viewer")
+		return 0, "", false
+	}
+	return organizationID, role, true
+}
+
+// validPermission reports whether a permission exists
+func validPermission(permission data.Permission) bool {
+	for _, known := range data.AllPermissions {
+		if permission == known {
+			return true
+		}
+	}
+	return false
+}
+
+// authorizeMember checks that the acting user belongs to the organization in the URL, and
+// when manage is set that they are its owner or a manager. It writes the error response on failure.
+func (h *OrganizationHandler) authorizeMember(w http.ResponseWriter, r *http.Request, manage bool) (uint, bool) {
+	actorID := middleware.GetActorIDFromRequest(r)
+	if actorID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return 0, false
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid organization ID")
+		return 0, false
+	}
+
+	membership, err := h.OrganizationRepo.GetMembership(r.Context(), uint(id), actorID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Organization not found")
+		return 0, false
+	}
+	if manage && membership.Role != data.OrgRoleOwner && membership.Role != data.OrgRoleManager {
+		utils.WriteForbiddenError(w, "Only owners and managers can manage this organization")
+		return 0, false
+	}
+
+	return uint(id), true
+}
+
+// validMemberRole reports whether a role can be given to a member; there is only one owner
+func validMemberRole(role data.OrganizationRole) bool {
+	switch role {
+	case data.OrgRoleManager, data.OrgRoleAccountant, data.OrgRoleClerk, data.OrgRoleAuditor, data.OrgRoleViewer:
+		return true
+	}
+	return false
+}
+
+// readOnlyRole reports whether members with a role may only read the books
+func readOnlyRole(role data.OrganizationRole) bool {
+	return role == data.OrgRoleAuditor || role == data.OrgRoleViewer
+}
+
+// normalizeCIDR validates a CIDR range or single IP address and returns it in CIDR notation
+func normalizeCIDR(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if _, network, err := net.ParseCIDR(value); err == nil {
+		return network.String(), true
+	}
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return "", false
+	}
+	if ip.To4() != nil {
+		return ip.String() + "/32", true
+	}
+	return ip.String() + "/128", true
+}
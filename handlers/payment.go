@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// PaymentRequest represents a payment received on a sale or made on an expense
+type PaymentRequest struct {
+	Amount    float64 `json:"amount"`
+	Date      string  `json:"date"` // defaults to today
+	Method    *string `json:"method"`
+	Reference *string `json:"reference"`
+	Notes     *string `json:"notes"`
+	TillID    *uint   `json:"till_id"` // defaults to the member's till
+}
+
+// PaymentResponse is a recorded payment with the record it was applied to
+type PaymentResponse struct {
+	Payment *data.Payment `json:"payment"`
+	Record  interface{}   `json:"record"`
+}
+
+// GetIncomePayments returns the payments received on a sale, or on an archived one with archived=true
+func (h *IncomeHandler) GetIncomePayments(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid income ID")
+		return
+	}
+	if r.URL.Query().Get("archived") == "true" {
+		_, err = h.IncomeRepo.GetArchivedOne(r.Context(), uint(id), userID)
+	} else {
+		_, err = h.IncomeRepo.GetOne(r.Context(), uint(id), userID)
+	}
+	if err != nil {
+		utils.WriteNotFoundError(w, "Income record not found")
+		return
+	}
+
+	payments, err := h.PaymentRepo.GetForRecord(r.Context(), userID, data.TransactionIncome, uint(id))
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve payments")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Payments retrieved successfully", payments)
+}
+
+// AddIncomePayment appends a payment received on a sale, recomputing its amount due and payment
+// status, and issues a receipt for it
+func (h *IncomeHandler) AddIncomePayment(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid income ID")
+		return
+	}
+
+	payment, ok := paymentFromRequest(w, r, h.TillRepo, userID, uint(id))
+	if !ok {
+		return
+	}
+
+	income, err := h.PaymentRepo.RecordIncomePayment(r.Context(), payment)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.WriteNotFoundError(w, "Income record not found")
+		case errors.Is(err, data.ErrOverpayment):
+			utils.WriteValidationError(w, "Payment is more than the amount due")
+		default:
+			utils.WriteInternalServerError(w, "Failed to record payment")
+		}
+		return
+	}
+
+	issuePaymentReceipt(r.Context(), h.SettingsRepo, h.ReceiptRepo, income, payment.Amount)
+	h.publishPayment(r, income, payment.Amount)
+
+	utils.WriteSuccessResponse(w, "Payment recorded successfully", PaymentResponse{Payment: payment, Record: income})
+}
+
+// GetExpensePayments returns the payments made on an expense, or on an archived one with archived=true
+func (h *ExpenseHandler) GetExpensePayments(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid expense ID")
+		return
+	}
+	if r.URL.Query().Get("archived") == "true" {
+		_, err = h.ExpenseRepo.GetArchivedOne(r.Context(), uint(id), userID)
+	} else {
+		_, err = h.ExpenseRepo.GetOne(r.Context(), uint(id), userID)
+	}
+	if err != nil {
+		utils.WriteNotFoundError(w, "Expense record not found")
+		return
+	}
+
+	payments, err := h.PaymentRepo.GetForRecord(r.Context(), userID, data.TransactionExpense, uint(id))
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve payments")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Payments retrieved successfully", payments)
+}
+
+// AddExpensePayment appends a payment made on an expense, recomputing its amount due and payment
+// status
+func (h *ExpenseHandler) AddExpensePayment(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid expense ID")
+		return
+	}
+
+	payment, ok := paymentFromRequest(w, r, h.TillRepo, userID, uint(id))
+	if !ok {
+		return
+	}
+
+	expense, err := h.PaymentRepo.RecordExpensePayment(r.Context(), payment)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.WriteNotFoundError(w, "Expense record not found")
+		case errors.Is(err, data.ErrAwaitingSignOff):
+			utils.WriteValidationError(w, awaitingSignOffMessage)
+		case errors.Is(err, data.ErrOverpayment):
+			utils.WriteValidationError(w, "Payment is more than the amount due")
+		default:
+			utils.WriteInternalServerError(w, "Failed to record payment")
+		}
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Payment recorded successfully", PaymentResponse{Payment: payment, Record: expense})
+}
+
+// paymentFromRequest decodes and validates a payment on a record and the till it went through,
+// writing the error response and returning false when it is invalid
+func paymentFromRequest(w http.ResponseWriter, r *http.Request, tillRepo data.TillInterface, userID uint, recordID uint) (*data.Payment, bool) {
+	var req PaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return nil, false
+	}
+	if !utils.ValidatePositiveNumber(req.Amount) {
+		utils.WriteValidationError(w, "Amount must be positive")
+		return nil, false
+	}
+
+	date := time.Now()
+	if req.Date != "" {
+		parsed, err := time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid date format. Use YYYY-MM-DD")
+			return nil, false
+		}
+		date = parsed
+	}
+
+	tillID, ok := resolveTill(w, r, tillRepo, userID, req.TillID)
+	if !ok {
+		return nil, false
+	}
+
+	actorID := middleware.GetActorIDFromRequest(r)
+	return &data.Payment{
+		RecordID:     recordID,
+		Amount:       req.Amount,
+		Date:         date,
+		Method:       req.Method,
+		Reference:    req.Reference,
+		Notes:        req.Notes,
+		RecordedByID: &actorID,
+		TillID:       tillID,
+		UserID:       userID,
+	}, true
+}
+
+// validPaymentStatusFilter checks the payment status a list is narrowed down to, if any. It
+// writes the error response and returns false when it isn't a known status.
+func validPaymentStatusFilter(w http.ResponseWriter, status data.PaymentStatus) bool {
+	switch status {
+	case "", data.PaymentPaid, data.PaymentUnpaid, data.PaymentPartial:
+		return true
+	}
+	utils.WriteValidationError(w, "Payment status must be paid, unpaid or partial")
+	return false
+}
@@ -0,0 +1,320 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// PayrollHandler handles advances, deductions, payroll runs and worker statements
+type PayrollHandler struct {
+	PayrollRepo  data.PayrollInterface
+	EmployeeRepo data.EmployeeInterface
+}
+
+// NewPayrollHandler creates a new PayrollHandler
+func NewPayrollHandler(payrollRepo data.PayrollInterface, employeeRepo data.EmployeeInterface) *PayrollHandler {
+	return &PayrollHandler{
+		PayrollRepo:  payrollRepo,
+		EmployeeRepo: employeeRepo,
+	}
+}
+
+// AdjustmentRequest represents an advance or deduction request
+type AdjustmentRequest struct {
+	Type        data.AdjustmentType `json:"type"`
+	Amount      float64             `json:"amount"`
+	Date        string              `json:"date"`
+	Description string              `json:"description"`
+}
+
+// CreatePayrollRunRequest represents a payroll run request
+type CreatePayrollRunRequest struct {
+	Month string  `json:"month"` // YYYY-MM
+	Notes *string `json:"notes,omitempty"`
+}
+
+// GetAdjustments retrieves the advances and deductions of an employee
+func (h *PayrollHandler) GetAdjustments(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid employee ID")
+		return
+	}
+
+	adjustments, err := h.PayrollRepo.GetAdjustments(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve adjustments")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Adjustments retrieved successfully", adjustments)
+}
+
+// CreateAdjustment records a salary advance or deduction against an employee
+func (h *PayrollHandler) CreateAdjustment(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid employee ID")
+		return
+	}
+
+	var req AdjustmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	if req.Type != data.AdjustmentAdvance && req.Type != data.AdjustmentDeduction {
+		utils.WriteValidationError(w, "Type must be advance or deduction")
+		return
+	}
+	if !utils.ValidatePositiveNumber(req.Amount) {
+		utils.WriteValidationError(w, "Amount must be positive")
+		return
+	}
+	if !utils.ValidateRequired(req.Description) {
+		utils.WriteValidationError(w, "Description is required")
+		return
+	}
+
+	date := time.Now()
+	if req.Date != "" {
+		date, err = time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid date format. Use YYYY-MM-DD")
+			return
+		}
+	}
+
+	employee, err := h.EmployeeRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Employee not found")
+		return
+	}
+
+	adjustment := &data.EmployeeAdjustment{
+		EmployeeID:  employee.ID,
+		Type:        req.Type,
+		Amount:      req.Amount,
+		Date:        date,
+		Description: req.Description,
+		UserID:      userID,
+	}
+
+	adjustmentID, err := h.PayrollRepo.InsertAdjustment(r.Context(), adjustment)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to record adjustment")
+		return
+	}
+
+	adjustment.ID = adjustmentID
+	utils.WriteSuccessResponse(w, "Adjustment recorded successfully", adjustment)
+}
+
+// DeleteAdjustment deletes an adjustment that has not been netted off in a payroll run
+func (h *PayrollHandler) DeleteAdjustment(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid employee ID")
+		return
+	}
+
+	adjustmentIDStr := chi.URLParam(r, "adjustmentId")
+	adjustmentID, err := strconv.ParseUint(adjustmentIDStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid adjustment ID")
+		return
+	}
+
+	err = h.PayrollRepo.DeleteAdjustment(r.Context(), uint(adjustmentID), uint(id), userID)
+	if err != nil {
+		if errors.Is(err, data.ErrAdjustmentSettled) {
+			utils.WriteValidationError(w, "Adjustment not found or already settled by a payroll run")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to delete adjustment")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Adjustment deleted successfully", nil)
+}
+
+// GetEmployeeStatement retrieves the balance statement of an employee
+func (h *PayrollHandler) GetEmployeeStatement(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid employee ID")
+		return
+	}
+
+	statement, err := h.PayrollRepo.GetStatement(r.Context(), uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Employee not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to retrieve employee statement")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Employee statement retrieved successfully", statement)
+}
+
+// GetAllPayrollRuns retrieves all payroll runs
+func (h *PayrollHandler) GetAllPayrollRuns(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	runs, err := h.PayrollRepo.GetAllRuns(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve payroll runs")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Payroll runs retrieved successfully", runs)
+}
+
+// GetPayrollRun retrieves a payroll run with its payslips
+func (h *PayrollHandler) GetPayrollRun(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid payroll run ID")
+		return
+	}
+
+	run, err := h.PayrollRepo.GetRun(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Payroll run not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Payroll run retrieved successfully", run)
+}
+
+// CreatePayrollRun runs payroll for a month, netting advances and deductions off approved pay
+func (h *PayrollHandler) CreatePayrollRun(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req CreatePayrollRunRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	if !utils.ValidateRequired(req.Month) {
+		utils.WriteValidationError(w, "Month is required")
+		return
+	}
+	start, end, err := parseMonth(req.Month)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid month format. Use YYYY-MM")
+		return
+	}
+
+	run := &data.PayrollRun{
+		Month:       req.Month,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Notes:       req.Notes,
+		UserID:      userID,
+	}
+
+	err = h.PayrollRepo.CreateRun(r.Context(), run)
+	if err != nil {
+		if errors.Is(err, data.ErrPayrollRunExists) {
+			utils.WriteValidationError(w, "Payroll has already been run for this month")
+			return
+		}
+		if errors.Is(err, data.ErrPayrollRunEmpty) {
+			utils.WriteValidationError(w, "No approved timesheets or outstanding adjustments for this month")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to run payroll")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Payroll run created successfully", run)
+}
+
+// DeletePayrollRun reverses a payroll run so the month can be run again
+func (h *PayrollHandler) DeletePayrollRun(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid payroll run ID")
+		return
+	}
+
+	err = h.PayrollRepo.DeleteRun(r.Context(), uint(id), userID)
+	if err != nil {
+		if errors.Is(err, data.ErrPayrollRunLocked) {
+			utils.WriteValidationError(w, "A later payroll run has already recovered balances carried forward by this run")
+			return
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Payroll run not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to delete payroll run")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Payroll run deleted successfully", nil)
+}
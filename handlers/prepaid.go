@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// maxPrepaidMonths is the longest a prepaid expense can be amortized over
+const maxPrepaidMonths = 120
+
+// amortizationStart validates the amortization of a prepaid expense over months, returning the
+// first day of the month given by start, or nil when it starts in the month of the expense. It
+// writes the error response and returns false when it is invalid.
+func amortizationStart(w http.ResponseWriter, months *int, start string) (*time.Time, bool) {
+	if months == nil {
+		if start != "" {
+			utils.WriteValidationError(w, "Amortization start requires prepaid months")
+			return nil, false
+		}
+		return nil, true
+	}
+	if *months < int(minPrepaidMonths) || *months > maxPrepaidMonths {
+		utils.WriteValidationError(w, fmt.Sprintf("Prepaid months must be between %d and %d", int(minPrepaidMonths), maxPrepaidMonths))
+		return nil, false
+	}
+	if start == "" {
+		return nil, true
+	}
+	date, err := time.Parse("2006-01-02", start)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid amortization start format. Use YYYY-MM-DD")
+		return nil, false
+	}
+	first := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return &first, true
+}
+
+// GetPrepaidExpenses retrieves the amortization schedules of the prepaid expenses
+func (h *ExpenseHandler) GetPrepaidExpenses(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	schedules, err := h.ExpenseRepo.GetPrepaid(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve prepaid expenses")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Prepaid expenses retrieved successfully", schedules)
+}
+
+// GetExpenseAmortization retrieves the amortization schedule of a prepaid expense
+func (h *ExpenseHandler) GetExpenseAmortization(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid expense ID")
+		return
+	}
+
+	schedule, err := h.ExpenseRepo.GetAmortization(r.Context(), uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Prepaid expense not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to retrieve amortization schedule")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Amortization schedule retrieved successfully", schedule)
+}
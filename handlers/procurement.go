@@ -0,0 +1,614 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// ProcurementHandler handles the supplies site staff ask for and the purchase orders managers buy
+// them on. Members who can approve expenses review everyone's requests; other members only see
+// their own.
+type ProcurementHandler struct {
+	ProcurementRepo data.ProcurementInterface
+
+	// MineSiteRepo checks the mine sites requests and orders are assigned to; they can't be
+	// assigned to a site when it is nil
+	MineSiteRepo data.MineSiteInterface
+
+	// InventoryRepo checks the inventory items requests restock; requests can't be linked to an
+	// item when it is nil
+	InventoryRepo data.InventoryInterface
+
+	// SettingsRepo enables the sign-off of received orders above the sign-off amount when set
+	SettingsRepo data.SettingsInterface
+}
+
+// NewProcurementHandler creates a new ProcurementHandler
+func NewProcurementHandler(procurementRepo data.ProcurementInterface) *ProcurementHandler {
+	return &ProcurementHandler{
+		ProcurementRepo: procurementRepo,
+	}
+}
+
+// SubmitProcurementRequest represents supplies a member of site staff asks to be bought
+type SubmitProcurementRequest struct {
+	ItemName        string  `json:"item_name"`
+	Quantity        float64 `json:"quantity"`
+	Unit            string  `json:"unit"`
+	Justification   string  `json:"justification"`
+	InventoryItemID *uint   `json:"inventory_item_id,omitempty"` // restocked when the order is received
+	NeededBy        string  `json:"needed_by,omitempty"`
+	MineSiteID      *uint   `json:"mine_site_id,omitempty"`
+}
+
+// RejectProcurementRequest represents the reason a procurement request is rejected
+type RejectProcurementRequest struct {
+	Reason string `json:"reason"`
+}
+
+// PurchaseOrderRequest represents a purchase order for approved procurement requests
+type PurchaseOrderRequest struct {
+	SupplierName    string              `json:"supplier_name"`
+	SupplierContact *string             `json:"supplier_contact,omitempty"`
+	Category        string              `json:"category"`
+	OrderDate       string              `json:"order_date"` // defaults to today
+	ExpectedDate    string              `json:"expected_date,omitempty"`
+	Notes           *string             `json:"notes,omitempty"`
+	MineSiteID      *uint               `json:"mine_site_id,omitempty"`
+	Items           []PurchaseOrderItem `json:"items"`
+}
+
+// PurchaseOrderItem represents a procurement request put on a purchase order, at the unit price
+// agreed with the supplier
+type PurchaseOrderItem struct {
+	RequestID uint    `json:"request_id"`
+	UnitPrice float64 `json:"unit_price"`
+}
+
+// ReceiveOrderRequest represents the delivery of a purchase order
+type ReceiveOrderRequest struct {
+	Date   string   `json:"date"`             // defaults to today
+	Amount *float64 `json:"amount,omitempty"` // as invoiced; defaults to the order total
+	Notes  *string  `json:"notes,omitempty"`
+}
+
+// GetProcurementRequests retrieves procurement requests, optionally filtered by requester_id and
+// status. Members who can't approve expenses only get their own requests.
+func (h *ProcurementHandler) GetProcurementRequests(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var requesterID *uint
+	if value := r.URL.Query().Get("requester_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid requester ID")
+			return
+		}
+		requester := uint(id)
+		requesterID = &requester
+	}
+	if !middleware.HasPermission(r, string(data.PermExpenseApprove)) {
+		actorID := middleware.GetActorIDFromRequest(r)
+		requesterID = &actorID
+	}
+
+	var status *data.ProcurementStatus
+	if value := r.URL.Query().Get("status"); value != "" {
+		s := data.ProcurementStatus(value)
+		if s != data.ProcurementPending && s != data.ProcurementApproved && s != data.ProcurementRejected &&
+			s != data.ProcurementOrdered && s != data.ProcurementReceived {
+			utils.WriteValidationError(w, "Status must be pending, approved, rejected, ordered or received")
+			return
+		}
+		status = &s
+	}
+
+	requests, err := h.ProcurementRepo.GetRequests(r.Context(), userID, requesterID, status)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve procurement requests")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Procurement requests retrieved successfully", requests)
+}
+
+// GetProcurementRequest retrieves a procurement request
+func (h *ProcurementHandler) GetProcurementRequest(w http.ResponseWriter, r *http.Request) {
+	request, ok := h.requestedProcurement(w, r)
+	if !ok {
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Procurement request retrieved successfully", request)
+}
+
+// CreateProcurementRequest submits a request from the acting member for supplies to be bought
+func (h *ProcurementHandler) CreateProcurementRequest(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	request := &data.ProcurementRequest{
+		RequesterID: middleware.GetActorIDFromRequest(r),
+		UserID:      userID,
+	}
+	if !h.applyRequest(w, r, userID, request) {
+		return
+	}
+
+	id, err := h.ProcurementRepo.InsertRequest(r.Context(), request)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to submit procurement request")
+		return
+	}
+
+	request.ID = id
+	utils.WriteSuccessResponse(w, "Procurement request submitted successfully", request)
+}
+
+// UpdateProcurementRequest updates a procurement request awaiting review. Only the requester can
+// change it.
+func (h *ProcurementHandler) UpdateProcurementRequest(w http.ResponseWriter, r *http.Request) {
+	request, ok := h.requesterProcurement(w, r)
+	if !ok {
+		return
+	}
+	if !h.applyRequest(w, r, request.UserID, request) {
+		return
+	}
+
+	if err := h.ProcurementRepo.UpdateRequest(r.Context(), request); err != nil {
+		if errors.Is(err, data.ErrProcurementReviewed) {
+			utils.WriteValidationError(w, "Only requests awaiting review can be changed")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to update procurement request")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Procurement request updated successfully", request)
+}
+
+// DeleteProcurementRequest withdraws a procurement request awaiting review. Only the requester can
+// withdraw it.
+func (h *ProcurementHandler) DeleteProcurementRequest(w http.ResponseWriter, r *http.Request) {
+	request, ok := h.requesterProcurement(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.ProcurementRepo.DeleteRequest(r.Context(), request.ID, request.UserID); err != nil {
+		if errors.Is(err, data.ErrProcurementReviewed) {
+			utils.WriteValidationError(w, "Only requests awaiting review can be withdrawn")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to withdraw procurement request")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Procurement request withdrawn successfully", nil)
+}
+
+// ApproveProcurementRequest approves a procurement request, so it can be put on a purchase order
+// (owner/manager)
+func (h *ProcurementHandler) ApproveProcurementRequest(w http.ResponseWriter, r *http.Request) {
+	h.reviewRequest(w, r, data.ProcurementApproved, nil)
+}
+
+// RejectProcurementRequest rejects a procurement request with a reason (owner/manager)
+func (h *ProcurementHandler) RejectProcurementRequest(w http.ResponseWriter, r *http.Request) {
+	var req RejectProcurementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if !utils.ValidateRequired(req.Reason) {
+		utils.WriteValidationError(w, "Rejection reason is required")
+		return
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	h.reviewRequest(w, r, data.ProcurementRejected, &reason)
+}
+
+// GetPurchaseOrders retrieves purchase orders with their requests, optionally filtered by status
+func (h *ProcurementHandler) GetPurchaseOrders(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var status *data.PurchaseOrderStatus
+	if value := r.URL.Query().Get("status"); value != "" {
+		s := data.PurchaseOrderStatus(value)
+		if s != data.PurchaseOrderOpen && s != data.PurchaseOrderReceived && s != data.PurchaseOrderCancelled {
+			utils.WriteValidationError(w, "Status must be open, received or cancelled")
+			return
+		}
+		status = &s
+	}
+
+	orders, err := h.ProcurementRepo.GetOrders(r.Context(), userID, status)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve purchase orders")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Purchase orders retrieved successfully", orders)
+}
+
+// GetPurchaseOrder retrieves a purchase order with its requests and the expense it is booked as
+func (h *ProcurementHandler) GetPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid purchase order ID")
+		return
+	}
+
+	order, err := h.ProcurementRepo.GetOrder(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Purchase order not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Purchase order retrieved successfully", order)
+}
+
+// CreatePurchaseOrder puts approved procurement requests on a purchase order to a supplier, at the
+// unit prices agreed, and marks them ordered. The order is numbered in its own sequence.
+func (h *ProcurementHandler) CreatePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req PurchaseOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	supplier := strings.TrimSpace(req.SupplierName)
+	if !utils.ValidateRequired(supplier) {
+		utils.WriteValidationError(w, "Supplier name is required")
+		return
+	}
+	category := data.ExpenseCategory(req.Category)
+	if category == "" {
+		category = data.ExpenseOther
+	}
+	if !slices.Contains(data.ExpenseCategories, category) {
+		utils.WriteValidationError(w, "Invalid expense category")
+		return
+	}
+	orderDate := time.Now().Truncate(24 * time.Hour)
+	if req.OrderDate != "" {
+		parsed, err := time.Parse("2006-01-02", req.OrderDate)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid order date format. Use YYYY-MM-DD")
+			return
+		}
+		orderDate = parsed
+	}
+	var expectedDate *time.Time
+	if req.ExpectedDate != "" {
+		parsed, err := time.Parse("2006-01-02", req.ExpectedDate)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid expected date format. Use YYYY-MM-DD")
+			return
+		}
+		if parsed.Before(orderDate) {
+			utils.WriteValidationError(w, "Expected date can't be before the order date")
+			return
+		}
+		expectedDate = &parsed
+	}
+	if len(req.Items) == 0 {
+		utils.WriteValidationError(w, "At least one procurement request is required")
+		return
+	}
+	prices := make(map[uint]float64, len(req.Items))
+	for _, item := range req.Items {
+		if _, ok := prices[item.RequestID]; ok {
+			utils.WriteValidationError(w, "Each procurement request can only be ordered once")
+			return
+		}
+		if !utils.ValidatePositiveNumber(item.UnitPrice) {
+			utils.WriteValidationError(w, "Unit prices must be positive")
+			return
+		}
+		prices[item.RequestID] = item.UnitPrice
+	}
+	if !checkMineSite(r.Context(), w, h.MineSiteRepo, userID, req.MineSiteID) {
+		return
+	}
+
+	order := &data.PurchaseOrder{
+		SupplierName:    supplier,
+		SupplierContact: optionalString(req.SupplierContact),
+		Category:        category,
+		OrderDate:       orderDate,
+		ExpectedDate:    expectedDate,
+		Notes:           optionalString(req.Notes),
+		MineSiteID:      req.MineSiteID,
+		CreatedByID:     middleware.GetActorIDFromRequest(r),
+		UserID:          userID,
+	}
+	if _, err := h.ProcurementRepo.InsertOrder(r.Context(), order, prices); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.WriteValidationError(w, "Procurement request not found")
+		case errors.Is(err, data.ErrProcurementNotApproved):
+			utils.WriteValidationError(w, "Only approved requests that aren't on an order can be ordered")
+		default:
+			utils.WriteInternalServerError(w, "Failed to create purchase order")
+		}
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Purchase order created successfully", order)
+}
+
+// CancelPurchaseOrder cancels an open purchase order, returning its requests to approved so they
+// can be ordered again
+func (h *ProcurementHandler) CancelPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid purchase order ID")
+		return
+	}
+	if _, err := h.ProcurementRepo.GetOrder(r.Context(), uint(id), userID); err != nil {
+		utils.WriteNotFoundError(w, "Purchase order not found")
+		return
+	}
+
+	if err := h.ProcurementRepo.CancelOrder(r.Context(), uint(id), userID); err != nil {
+		if errors.Is(err, data.ErrPurchaseOrderClosed) {
+			utils.WriteValidationError(w, "Only open purchase orders can be cancelled")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to cancel purchase order")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Purchase order cancelled successfully", nil)
+}
+
+// ReceivePurchaseOrder receives the delivery of an open purchase order, booking it as an unpaid
+// expense owed to the supplier and restocking the inventory items its requests are for. Expenses
+// above the sign-off amount await sign-off before they are paid.
+func (h *ProcurementHandler) ReceivePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid purchase order ID")
+		return
+	}
+
+	var req ReceiveOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	date := time.Now().Truncate(24 * time.Hour)
+	if req.Date != "" {
+		parsed, err := time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid date format. Use YYYY-MM-DD")
+			return
+		}
+		date = parsed
+	}
+	if req.Amount != nil && !utils.ValidatePositiveNumber(*req.Amount) {
+		utils.WriteValidationError(w, "Amount must be positive")
+		return
+	}
+
+	order, err := h.ProcurementRepo.GetOrder(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Purchase order not found")
+		return
+	}
+	amount := order.TotalAmount
+	if req.Amount != nil {
+		amount = *req.Amount
+	}
+
+	signOff, ok := signOffStatus(r.Context(), w, h.SettingsRepo, userID, amount)
+	if !ok {
+		return
+	}
+
+	expense := &data.Expense{
+		Date:          date,
+		Amount:        amount,
+		PaymentStatus: data.PaymentUnpaid,
+		AmountDue:     amount,
+		Notes:         optionalString(req.Notes),
+		SignOffStatus: signOff,
+	}
+	order, err = h.ProcurementRepo.ReceiveOrder(r.Context(), order.ID, userID, expense)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.WriteNotFoundError(w, "Purchase order or an inventory item on it not found")
+		case errors.Is(err, data.ErrPurchaseOrderClosed):
+			utils.WriteValidationError(w, "Only open purchase orders can be received")
+		default:
+			utils.WriteInternalServerError(w, "Failed to receive purchase order")
+		}
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Purchase order received successfully", order)
+}
+
+// reviewRequest approves or rejects the procurement request of a request, writing the response
+func (h *ProcurementHandler) reviewRequest(w http.ResponseWriter, r *http.Request, status data.ProcurementStatus, reason *string) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+	if !requirePermission(w, r, data.PermExpenseApprove, "You do not have permission to review procurement requests") {
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid procurement request ID")
+		return
+	}
+	request, err := h.ProcurementRepo.GetRequest(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Procurement request not found")
+		return
+	}
+
+	if err := h.ProcurementRepo.ReviewRequest(r.Context(), request.ID, userID, status, middleware.GetActorIDFromRequest(r), reason); err != nil {
+		if errors.Is(err, data.ErrProcurementReviewed) {
+			utils.WriteValidationError(w, "Only requests awaiting review can be reviewed")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to review procurement request")
+		return
+	}
+
+	if status == data.ProcurementRejected {
+		utils.WriteSuccessResponse(w, "Procurement request rejected successfully", nil)
+		return
+	}
+	utils.WriteSuccessResponse(w, "Procurement request approved successfully", nil)
+}
+
+// applyRequest validates a procurement request and applies it to request, writing the error
+// response and returning false when it is invalid
+func (h *ProcurementHandler) applyRequest(w http.ResponseWriter, r *http.Request, userID uint, request *data.ProcurementRequest) bool {
+	var req SubmitProcurementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return false
+	}
+	itemName := strings.TrimSpace(req.ItemName)
+	if !utils.ValidateRequired(itemName) {
+		utils.WriteValidationError(w, "Item name is required")
+		return false
+	}
+	if !utils.ValidatePositiveNumber(req.Quantity) {
+		utils.WriteValidationError(w, "Quantity must be positive")
+		return false
+	}
+	unit := strings.TrimSpace(req.Unit)
+	if !utils.ValidateRequired(unit) {
+		utils.WriteValidationError(w, "Unit is required")
+		return false
+	}
+	justification := strings.TrimSpace(req.Justification)
+	if !utils.ValidateRequired(justification) {
+		utils.WriteValidationError(w, "Justification is required")
+		return false
+	}
+	var neededBy *time.Time
+	if req.NeededBy != "" {
+		parsed, err := time.Parse("2006-01-02", req.NeededBy)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid needed by date format. Use YYYY-MM-DD")
+			return false
+		}
+		neededBy = &parsed
+	}
+	if req.InventoryItemID != nil {
+		if h.InventoryRepo == nil {
+			utils.WriteValidationError(w, "Requests can't be linked to inventory items")
+			return false
+		}
+		if _, err := h.InventoryRepo.GetOne(r.Context(), *req.InventoryItemID, userID); err != nil {
+			utils.WriteValidationError(w, "Inventory item not found")
+			return false
+		}
+	}
+	if !checkMineSite(r.Context(), w, h.MineSiteRepo, userID, req.MineSiteID) {
+		return false
+	}
+
+	request.ItemName = itemName
+	request.Quantity = req.Quantity
+	request.Unit = unit
+	request.Justification = justification
+	request.InventoryItemID = req.InventoryItemID
+	request.NeededBy = neededBy
+	request.MineSiteID = req.MineSiteID
+	return true
+}
+
+// requestedProcurement returns the procurement request of a request, writing the error response
+// and returning false when it doesn't exist or is another member's and the acting member can't
+// review requests
+func (h *ProcurementHandler) requestedProcurement(w http.ResponseWriter, r *http.Request) (*data.ProcurementRequest, bool) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return nil, false
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid procurement request ID")
+		return nil, false
+	}
+	request, err := h.ProcurementRepo.GetRequest(r.Context(), uint(id), userID)
+	if err != nil || (request.RequesterID != middleware.GetActorIDFromRequest(r) && !middleware.HasPermission(r, string(data.PermExpenseApprove))) {
+		utils.WriteNotFoundError(w, "Procurement request not found")
+		return nil, false
+	}
+	return request, true
+}
+
+// requesterProcurement returns the procurement request of a request when the acting member
+// submitted it, writing the error response and returning false otherwise
+func (h *ProcurementHandler) requesterProcurement(w http.ResponseWriter, r *http.Request) (*data.ProcurementRequest, bool) {
+	request, ok := h.requestedProcurement(w, r)
+	if !ok {
+		return nil, false
+	}
+	if request.RequesterID != middleware.GetActorIDFromRequest(r) {
+		utils.WriteForbiddenError(w, "Only the requester can change a procurement request")
+		return nil, false
+	}
+	return request, true
+}
@@ -0,0 +1,710 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// PurchaseHandler handles the purchases of ore from individual miners at buying stations and the
+// payables to those miners
+type PurchaseHandler struct {
+	PurchaseRepo  data.PurchaseInterface
+	InventoryRepo data.InventoryInterface
+	PaymentRepo   data.PaymentInterface
+	MineSiteRepo  data.MineSiteInterface
+
+	// MinerRepo enables linking purchases to miners in the registry when set
+	MinerRepo data.MinerInterface
+	// MarketPriceRepo enables calculating the price of purchases from market prices when set
+	MarketPriceRepo data.MarketPriceInterface
+	// TillRepo enables attributing the cash paid to tills when set
+	TillRepo data.TillInterface
+}
+
+// NewPurchaseHandler creates a new PurchaseHandler
+func NewPurchaseHandler(purchaseRepo data.PurchaseInterface, inventoryRepo data.InventoryInterface, paymentRepo data.PaymentInterface, mineSiteRepo data.MineSiteInterface) *PurchaseHandler {
+	return &PurchaseHandler{
+		PurchaseRepo:  purchaseRepo,
+		InventoryRepo: inventoryRepo,
+		PaymentRepo:   paymentRepo,
+		MineSiteRepo:  mineSiteRepo,
+	}
+}
+
+// PurchaseRequest represents a create or update purchase request
+type PurchaseRequest struct {
+	Date            string  `json:"date"`
+	MinerID         *uint   `json:"miner_id,omitempty"` // registered miner; sets the miner name
+	MinerName       string  `json:"miner_name"`
+	MinerContact    *string `json:"miner_contact,omitempty"`
+	MineralType     string  `json:"mineral_type"`
+	Quantity        float64 `json:"quantity"`          // defaults to the net weight of the weighbridge readings
+	Unit            string  `json:"unit"`              // defaults to the inventory item's unit
+	PricePerUnit    float64 `json:"price_per_unit"`    // defaults to the calculated price when purity is set
+	AmountPaid      float64 `json:"amount_paid"`       // paid on the spot; create only
+	TillID          *uint   `json:"till_id,omitempty"` // till the amount paid went through; defaults to the member's till
+	InventoryItemID uint    `json:"inventory_item_id"` // stock the material is received into
+	PitNumber       *string `json:"pit_number,omitempty"`
+	Notes           *string `json:"notes,omitempty"`
+	MineSiteID      *uint   `json:"mine_site_id,omitempty"` // buying station whose books the purchase is in
+
+	GrossWeight       *float64 `json:"gross_weight,omitempty"`
+	TareWeight        *float64 `json:"tare_weight,omitempty"`
+	WeighbridgeTicket *string  `json:"weighbridge_ticket,omitempty"`
+	Purity            *float64 `json:"purity,omitempty"`           // percent; calculates the price from the market price
+	DiscountPercent   *float64 `json:"discount_percent,omitempty"` // agreed with the miner
+	OverrideReason    string   `json:"override_reason,omitempty"`  // required to price differently from the calculated price
+}
+
+// PurchaseQuote represents the calculated price of material on the weighbridge before it is
+// recorded as a purchase
+type PurchaseQuote struct {
+	Quantity        float64           `json:"quantity"`
+	Unit            string            `json:"unit"`
+	MarketPrice     *data.MarketPrice `json:"market_price"`
+	Purity          float64           `json:"purity"`
+	DiscountPercent float64           `json:"discount_percent"`
+	PricePerUnit    float64           `json:"price_per_unit"`
+	TotalAmount     float64           `json:"total_amount"`
+}
+
+// GetAllPurchases retrieves all purchases of the authenticated user, or a page of them
+func (h *PurchaseHandler) GetAllPurchases(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	page, err := utils.ParsePage(r)
+	if err != nil {
+		utils.WriteValidationError(w, err.Error())
+		return
+	}
+	siteID, ok := parseSiteFilter(w, r)
+	if !ok {
+		return
+	}
+	if page == nil && siteID == nil {
+		purchases, err := h.PurchaseRepo.GetAll(r.Context(), userID)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to retrieve purchases")
+			return
+		}
+		utils.WriteSuccessResponse(w, "Purchases retrieved successfully", purchases)
+		return
+	}
+
+	purchases, total, err := h.PurchaseRepo.GetPage(r.Context(), userID, siteID, page.Offset(), page.Limit())
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve purchases")
+		return
+	}
+
+	utils.WriteListResponse(w, "Purchases retrieved successfully", purchases, page, total)
+}
+
+// GetPurchase retrieves a purchase
+func (h *PurchaseHandler) GetPurchase(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	purchase, ok := h.purchase(w, r, userID)
+	if !ok {
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Purchase retrieved successfully", purchase)
+}
+
+// CreatePurchase records ore bought from a miner and receives it into inventory
+func (h *PurchaseHandler) CreatePurchase(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req PurchaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if !utils.ValidateNonNegativeNumber(req.AmountPaid) {
+		utils.WriteValidationError(w, "Amount paid cannot be negative")
+		return
+	}
+
+	purchase := &data.Purchase{AmountPaid: req.AmountPaid, UserID: userID}
+	if !h.applyRequest(w, r, userID, &req, purchase) {
+		return
+	}
+	if req.AmountPaid > purchase.Quantity*purchase.PricePerUnit {
+		utils.WriteValidationError(w, "Amount paid cannot be more than the total amount")
+		return
+	}
+	if req.AmountPaid > 0 {
+		var ok bool
+		if purchase.TillID, ok = resolveTill(w, r, h.TillRepo, userID, req.TillID); !ok {
+			return
+		}
+	}
+
+	if _, err := h.PurchaseRepo.Insert(r.Context(), purchase); err != nil {
+		utils.WriteInternalServerError(w, "Failed to create purchase")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Purchase created successfully", purchase)
+}
+
+// UpdatePurchase updates a purchase, adjusting the stock it was received into. Payments are
+// recorded through the payments of the purchase.
+func (h *PurchaseHandler) UpdatePurchase(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	purchase, ok := h.purchase(w, r, userID)
+	if !ok {
+		return
+	}
+
+	var req PurchaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if req.InventoryItemID != purchase.InventoryItemID {
+		utils.WriteValidationError(w, "The inventory item of a purchase can't be changed; delete the purchase and record it again")
+		return
+	}
+	if !h.applyRequest(w, r, userID, &req, purchase) {
+		return
+	}
+
+	if err := h.PurchaseRepo.Update(r.Context(), purchase); err != nil {
+		switch {
+		case errors.Is(err, data.ErrOverpayment):
+			utils.WriteValidationError(w, "Total amount cannot be less than the amount already paid")
+		case errors.Is(err, data.ErrInsufficientStock):
+			utils.WriteErrorResponse(w, "Not enough stock left in the inventory item to reduce the purchase", http.StatusConflict)
+		default:
+			utils.WriteInternalServerError(w, "Failed to update purchase")
+		}
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Purchase updated successfully", purchase)
+}
+
+// DeletePurchase deletes a purchase without payments, taking its material back out of stock
+func (h *PurchaseHandler) DeletePurchase(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	purchase, ok := h.purchase(w, r, userID)
+	if !ok {
+		return
+	}
+	if purchase.AmountPaid > 0 {
+		utils.WriteErrorResponse(w, "A purchase that has been paid for can't be deleted", http.StatusConflict)
+		return
+	}
+
+	if err := h.PurchaseRepo.Delete(r.Context(), purchase.ID, userID); err != nil {
+		if errors.Is(err, data.ErrInsufficientStock) {
+			utils.WriteErrorResponse(w, "Not enough stock left in the inventory item to remove the purchase", http.StatusConflict)
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to delete purchase")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Purchase deleted successfully", nil)
+}
+
+// QuotePurchase calculates what material on the weighbridge would be paid for from the current
+// market price, its purity and the agreed discount, without recording a purchase
+func (h *PurchaseHandler) QuotePurchase(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req PurchaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if !utils.ValidateRequired(req.MineralType) {
+		utils.WriteValidationError(w, "Mineral type is required")
+		return
+	}
+	if req.Purity == nil {
+		utils.WriteValidationError(w, "Purity is required to calculate the price")
+		return
+	}
+	if !applyWeighbridge(w, &req) {
+		return
+	}
+	if !utils.ValidatePositiveNumber(req.Quantity) {
+		utils.WriteValidationError(w, "Quantity must be positive")
+		return
+	}
+	date := time.Now()
+	if req.Date != "" {
+		parsed, err := time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid date format. Use YYYY-MM-DD")
+			return
+		}
+		date = parsed
+	} else {
+		req.Date = date.Format("2006-01-02")
+	}
+	unit := strings.TrimSpace(req.Unit)
+	if unit == "" && req.InventoryItemID != 0 {
+		item, err := h.InventoryRepo.GetOne(r.Context(), req.InventoryItemID, userID)
+		if err != nil {
+			utils.WriteNotFoundError(w, "Inventory item not found")
+			return
+		}
+		unit = item.Unit
+	}
+	if unit == "" {
+		utils.WriteValidationError(w, "Unit or inventory item ID is required")
+		return
+	}
+	if !checkMineSite(r.Context(), w, h.MineSiteRepo, userID, req.MineSiteID) {
+		return
+	}
+
+	market, price, ok := h.calculatePrice(r.Context(), w, userID, &req, date, unit)
+	if !ok {
+		return
+	}
+	quote := PurchaseQuote{
+		Quantity:     req.Quantity,
+		Unit:         unit,
+		MarketPrice:  market,
+		Purity:       *req.Purity,
+		PricePerUnit: price,
+		TotalAmount:  roundTo(req.Quantity*price, 2),
+	}
+	if req.DiscountPercent != nil {
+		quote.DiscountPercent = *req.DiscountPercent
+	}
+
+	utils.WriteSuccessResponse(w, "Purchase quoted successfully", quote)
+}
+
+// GetPriceOverrides returns the override trail of a purchase: each time it was priced
+// differently from its calculated price, by whom and why
+func (h *PurchaseHandler) GetPriceOverrides(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	purchase, ok := h.purchase(w, r, userID)
+	if !ok {
+		return
+	}
+
+	overrides, err := h.PurchaseRepo.GetPriceOverrides(r.Context(), userID, purchase.ID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve price overrides")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Price overrides retrieved successfully", overrides)
+}
+
+// GetPurchasePayments returns the payments made to the miner on a purchase
+func (h *PurchaseHandler) GetPurchasePayments(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	purchase, ok := h.purchase(w, r, userID)
+	if !ok {
+		return
+	}
+
+	payments, err := h.PaymentRepo.GetForRecord(r.Context(), userID, data.TransactionPurchase, purchase.ID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve payments")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Payments retrieved successfully", payments)
+}
+
+// AddPurchasePayment appends a payment made to the miner on a purchase, recomputing its amount
+// due and payment status
+func (h *PurchaseHandler) AddPurchasePayment(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid purchase ID")
+		return
+	}
+
+	payment, ok := paymentFromRequest(w, r, h.TillRepo, userID, uint(id))
+	if !ok {
+		return
+	}
+
+	purchase, err := h.PaymentRepo.RecordPurchasePayment(r.Context(), payment)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.WriteNotFoundError(w, "Purchase not found")
+		case errors.Is(err, data.ErrOverpayment):
+			utils.WriteValidationError(w, "Payment is more than the amount due")
+		default:
+			utils.WriteInternalServerError(w, "Failed to record payment")
+		}
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Payment recorded successfully", PaymentResponse{Payment: payment, Record: purchase})
+}
+
+// GetPayables returns what is owed to each miner, most owed first. With outstanding=true,
+// miners who have been paid in full are left out.
+func (h *PurchaseHandler) GetPayables(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	payables, err := h.PurchaseRepo.GetPayables(r.Context(), userID, r.URL.Query().Get("outstanding") == "true")
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve payables")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Payables retrieved successfully", payables)
+}
+
+// GetMinerStatement returns the statement of the purchases from a miner and the payments made
+// to them
+func (h *PurchaseHandler) GetMinerStatement(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	minerName := strings.TrimSpace(r.URL.Query().Get("miner_name"))
+	if !utils.ValidateRequired(minerName) {
+		utils.WriteValidationError(w, "Miner name is required")
+		return
+	}
+
+	statement, err := h.PurchaseRepo.GetStatement(r.Context(), userID, minerName)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "No purchases from this miner")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to retrieve miner statement")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Miner statement retrieved successfully", statement)
+}
+
+// applyRequest validates a purchase request and applies it to purchase, writing the error
+// response and returning false when it is invalid
+func (h *PurchaseHandler) applyRequest(w http.ResponseWriter, r *http.Request, userID uint, req *PurchaseRequest, purchase *data.Purchase) bool {
+	if !utils.ValidateRequired(req.Date) {
+		utils.WriteValidationError(w, "Date is required")
+		return false
+	}
+	minerName := strings.TrimSpace(req.MinerName)
+	minerContact := req.MinerContact
+	if req.MinerID != nil {
+		miner, ok := h.registeredMiner(r.Context(), w, userID, *req.MinerID)
+		if !ok {
+			return false
+		}
+		minerName = miner.Name
+		if minerContact == nil {
+			minerContact = miner.Phone
+		}
+	}
+	if !utils.ValidateRequired(minerName) {
+		utils.WriteValidationError(w, "Miner name is required")
+		return false
+	}
+	if len(minerName) > 100 {
+		utils.WriteValidationError(w, "Miner name must be at most 100 characters")
+		return false
+	}
+	if !utils.ValidateRequired(req.MineralType) {
+		utils.WriteValidationError(w, "Mineral type is required")
+		return false
+	}
+	if !applyWeighbridge(w, req) {
+		return false
+	}
+	if !utils.ValidatePositiveNumber(req.Quantity) {
+		utils.WriteValidationError(w, "Quantity must be positive")
+		return false
+	}
+	if req.Purity == nil && !utils.ValidatePositiveNumber(req.PricePerUnit) {
+		utils.WriteValidationError(w, "Price per unit must be positive")
+		return false
+	}
+	if req.InventoryItemID == 0 {
+		utils.WriteValidationError(w, "Inventory item ID is required")
+		return false
+	}
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid date format. Use YYYY-MM-DD")
+		return false
+	}
+
+	item, err := h.InventoryRepo.GetOne(r.Context(), req.InventoryItemID, userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Inventory item not found")
+		return false
+	}
+	if item.Type != "mineral" {
+		utils.WriteValidationError(w, "Purchases can only be received into mineral stock")
+		return false
+	}
+	unit := strings.TrimSpace(req.Unit)
+	if unit == "" {
+		unit = item.Unit
+	}
+	if unit != item.Unit {
+		utils.WriteValidationError(w, fmt.Sprintf("Unit must be the inventory item's unit (%s)", item.Unit))
+		return false
+	}
+
+	// Purchases can only be assigned to the user's own mine sites
+	if !checkMineSite(r.Context(), w, h.MineSiteRepo, userID, req.MineSiteID) {
+		return false
+	}
+	if !h.applyPrice(w, r, userID, req, date, unit, purchase) {
+		return false
+	}
+
+	purchase.Date = date
+	purchase.MinerID = req.MinerID
+	purchase.MinerName = minerName
+	purchase.MinerContact = minerContact
+	purchase.MineralType = data.MineralType(req.MineralType)
+	purchase.Quantity = req.Quantity
+	purchase.Unit = unit
+	purchase.InventoryItemID = item.ID
+	purchase.GrossWeight = req.GrossWeight
+	purchase.TareWeight = req.TareWeight
+	purchase.WeighbridgeTicket = req.WeighbridgeTicket
+	purchase.PitNumber = req.PitNumber
+	purchase.Notes = req.Notes
+	purchase.MineSiteID = req.MineSiteID
+	return true
+}
+
+// applyWeighbridge validates the weighbridge readings of a purchase request and sets its
+// quantity to their net weight, writing the error response and returning false when they are
+// invalid
+func applyWeighbridge(w http.ResponseWriter, req *PurchaseRequest) bool {
+	if req.GrossWeight == nil {
+		if req.TareWeight != nil {
+			utils.WriteValidationError(w, "Gross weight is required with the tare weight")
+			return false
+		}
+		return true
+	}
+	if !utils.ValidatePositiveNumber(*req.GrossWeight) {
+		utils.WriteValidationError(w, "Gross weight must be positive")
+		return false
+	}
+	net := *req.GrossWeight
+	if req.TareWeight != nil {
+		if !utils.ValidateNonNegativeNumber(*req.TareWeight) {
+			utils.WriteValidationError(w, "Tare weight cannot be negative")
+			return false
+		}
+		net -= *req.TareWeight
+	}
+	if net <= 0 {
+		utils.WriteValidationError(w, "Tare weight must be less than the gross weight")
+		return false
+	}
+	if req.Quantity != 0 && math.Abs(req.Quantity-net) > 1e-9 {
+		utils.WriteValidationError(w, "Quantity must be the net weight of the weighbridge readings")
+		return false
+	}
+	req.Quantity = net
+	return true
+}
+
+// applyPrice prices purchase from a request. With a purity, the price per unit is calculated
+// from the current market price less the agreed discount; a different price per unit is an
+// override, which needs a reason and the price.override permission and goes in the override
+// trail. It writes the error response and returns false when the price can't be applied.
+func (h *PurchaseHandler) applyPrice(w http.ResponseWriter, r *http.Request, userID uint, req *PurchaseRequest, date time.Time, unit string, purchase *data.Purchase) bool {
+	if req.Purity == nil {
+		if req.DiscountPercent != nil {
+			utils.WriteValidationError(w, "Purity is required to calculate the price")
+			return false
+		}
+		purchase.PricePerUnit = req.PricePerUnit
+		purchase.MarketPrice = nil
+		purchase.Purity = nil
+		purchase.DiscountPercent = nil
+		purchase.CalculatedPricePerUnit = nil
+		purchase.PriceOverridden = false
+		return true
+	}
+
+	if !utils.ValidateNonNegativeNumber(req.PricePerUnit) {
+		utils.WriteValidationError(w, "Price per unit cannot be negative")
+		return false
+	}
+	market, calculated, ok := h.calculatePrice(r.Context(), w, userID, req, date, unit)
+	if !ok {
+		return false
+	}
+
+	price := calculated
+	overridden := req.PricePerUnit != 0 && math.Abs(req.PricePerUnit-calculated) >= 0.005
+	if overridden {
+		price = req.PricePerUnit
+		// An override already in the trail isn't recorded again when the purchase is edited
+		recorded := purchase.PriceOverridden && purchase.PricePerUnit == price &&
+			purchase.CalculatedPricePerUnit != nil && *purchase.CalculatedPricePerUnit == calculated
+		if !recorded {
+			if !requirePermission(w, r, data.PermPriceOverride, "You don't have permission to override the calculated price") {
+				return false
+			}
+			reason := strings.TrimSpace(req.OverrideReason)
+			if !utils.ValidateRequired(reason) {
+				utils.WriteValidationError(w, fmt.Sprintf("Override reason is required to price differently from the calculated price (%.2f)", calculated))
+				return false
+			}
+			purchase.Override = &data.PurchasePriceOverride{
+				Reason:         reason,
+				OverriddenByID: middleware.GetActorIDFromRequest(r),
+			}
+		}
+	}
+
+	purchase.PricePerUnit = price
+	purchase.MarketPrice = &market.Price
+	purchase.Purity = req.Purity
+	purchase.DiscountPercent = req.DiscountPercent
+	purchase.CalculatedPricePerUnit = &calculated
+	purchase.PriceOverridden = overridden
+	return true
+}
+
+// calculatePrice returns the current market price for a purchase request with a purity and the
+// price per unit calculated from it, writing the error response and returning false when it
+// can't be calculated
+func (h *PurchaseHandler) calculatePrice(ctx context.Context, w http.ResponseWriter, userID uint, req *PurchaseRequest, date time.Time, unit string) (*data.MarketPrice, float64, bool) {
+	if *req.Purity <= 0 || *req.Purity > 100 {
+		utils.WriteValidationError(w, "Purity must be more than 0 and at most 100 percent")
+		return nil, 0, false
+	}
+	discount := 0.0
+	if req.DiscountPercent != nil {
+		discount = *req.DiscountPercent
+		if discount < 0 || discount >= 100 {
+			utils.WriteValidationError(w, "Discount must be at least 0 and less than 100 percent")
+			return nil, 0, false
+		}
+	}
+	if h.MarketPriceRepo == nil {
+		utils.WriteValidationError(w, "No market price to calculate the price from")
+		return nil, 0, false
+	}
+	market, err := h.MarketPriceRepo.GetCurrent(ctx, userID, data.MineralType(req.MineralType), unit, req.MineSiteID, date)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteValidationError(w, fmt.Sprintf("No market price of %s per %s on %s to calculate the price from", req.MineralType, unit, req.Date))
+			return nil, 0, false
+		}
+		utils.WriteInternalServerError(w, "Failed to retrieve market price")
+		return nil, 0, false
+	}
+	return market, roundTo(market.Price*(*req.Purity/100)*(1-discount/100), 2), true
+}
+
+// registeredMiner returns an active miner of the registry, writing the error response and
+// returning false when there isn't one
+func (h *PurchaseHandler) registeredMiner(ctx context.Context, w http.ResponseWriter, userID uint, minerID uint) (*data.Miner, bool) {
+	if h.MinerRepo == nil {
+		utils.WriteNotFoundError(w, "Miner not found")
+		return nil, false
+	}
+	miner, err := h.MinerRepo.GetOne(ctx, minerID, userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Miner not found")
+		return nil, false
+	}
+	if !miner.Active {
+		utils.WriteValidationError(w, "Miner is inactive")
+		return nil, false
+	}
+	return miner, true
+}
+
+// purchase returns the purchase of a request, writing the error response and returning false
+// when it doesn't exist
+func (h *PurchaseHandler) purchase(w http.ResponseWriter, r *http.Request, userID uint) (*data.Purchase, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid purchase ID")
+		return nil, false
+	}
+	purchase, err := h.PurchaseRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Purchase not found")
+			return nil, false
+		}
+		utils.WriteInternalServerError(w, "Failed to retrieve purchase")
+		return nil, false
+	}
+	return purchase, true
+}
@@ -0,0 +1,397 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/storage"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// receiptSignature is the signing purpose of receipt verification tokens
+const receiptSignature = "receipt"
+
+// ReceiptHandler handles payment receipt requests
+type ReceiptHandler struct {
+	ReceiptRepo  data.ReceiptInterface
+	UserRepo     data.UserInterface
+	DeliveryRepo data.DeliveryInterface
+	JobRepo      data.JobInterface
+
+	// SealRepo adds the chain-of-custody seal of a sealed sale to its receipts when set
+	SealRepo data.LotSealInterface
+
+	// TemplateRepo applies the organization's receipt template to PDFs when set, with the logo
+	// read from Store
+	TemplateRepo data.DocumentTemplateInterface
+	Store        storage.Store
+
+	// BaseURL is prepended to verification link paths, e.g. https://api.example.com
+	BaseURL string
+}
+
+// NewReceiptHandler creates a new ReceiptHandler
+func NewReceiptHandler(receiptRepo data.ReceiptInterface, userRepo data.UserInterface, deliveryRepo data.DeliveryInterface, jobRepo data.JobInterface) *ReceiptHandler {
+	return &ReceiptHandler{
+		ReceiptRepo:  receiptRepo,
+		UserRepo:     userRepo,
+		DeliveryRepo: deliveryRepo,
+		JobRepo:      jobRepo,
+	}
+}
+
+// SendReceiptRequest represents a request to send a receipt to the customer
+type SendReceiptRequest struct {
+	Channel string `json:"channel"`      // "sms" or "email"
+	To      string `json:"to,omitempty"` // defaults to the customer contact
+}
+
+// ReceiptVerification is the public view of a receipt used to check its authenticity
+type ReceiptVerification struct {
+	Valid         bool      `json:"valid"`
+	ReceiptNumber string    `json:"receipt_number"`
+	IssuedBy      string    `json:"issued_by"`
+	CustomerName  string    `json:"customer_name"`
+	InvoiceNumber *string   `json:"invoice_number,omitempty"`
+	Amount        float64   `json:"amount"`
+	BalanceDue    float64   `json:"balance_due"`
+	Currency      string    `json:"currency"`
+	PaidAt        time.Time `json:"paid_at"`
+}
+
+// GetAllReceipts returns all receipts for the authenticated user
+func (h *ReceiptHandler) GetAllReceipts(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	receipts, err := h.ReceiptRepo.GetAll(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve receipts")
+		return
+	}
+	for _, receipt := range receipts {
+		receipt.VerifyURL = h.verifyURL(receipt.ID)
+	}
+
+	utils.WriteSuccessResponse(w, "Receipts retrieved successfully", receipts)
+}
+
+// GetIncomeReceipts returns the receipts issued for an income record
+func (h *ReceiptHandler) GetIncomeReceipts(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid income ID")
+		return
+	}
+
+	receipts, err := h.ReceiptRepo.GetByIncome(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve receipts")
+		return
+	}
+	for _, receipt := range receipts {
+		receipt.VerifyURL = h.verifyURL(receipt.ID)
+	}
+
+	utils.WriteSuccessResponse(w, "Receipts retrieved successfully", receipts)
+}
+
+// GetReceipt returns a specific receipt
+func (h *ReceiptHandler) GetReceipt(w http.ResponseWriter, r *http.Request) {
+	receipt, ok := h.loadReceipt(w, r)
+	if !ok {
+		return
+	}
+	receipt.VerifyURL = h.verifyURL(receipt.ID)
+
+	utils.WriteSuccessResponse(w, "Receipt retrieved successfully", receipt)
+}
+
+// DownloadReceiptPDF downloads a receipt as PDF
+func (h *ReceiptHandler) DownloadReceiptPDF(w http.ResponseWriter, r *http.Request) {
+	receipt, ok := h.loadReceipt(w, r)
+	if !ok {
+		return
+	}
+
+	watermark := ""
+	if middleware.IsReadOnlyRequest(r) {
+		watermark = "AUDIT COPY"
+	}
+	h.writePDF(r.Context(), w, receipt, watermark)
+}
+
+// SendReceipt sends a receipt to the customer by SMS, or by email with a link to the PDF
+func (h *ReceiptHandler) SendReceipt(w http.ResponseWriter, r *http.Request) {
+	receipt, ok := h.loadReceipt(w, r)
+	if !ok {
+		return
+	}
+
+	var req SendReceiptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	to := strings.TrimSpace(req.To)
+	if to == "" {
+		to = strings.TrimSpace(receipt.CustomerContact)
+	}
+
+	seller := h.sellerName(r.Context(), receipt.UserID)
+	payload := data.SendMessagePayload{
+		Channel: data.DeliveryChannel(req.Channel),
+		To:      to,
+	}
+	switch payload.Channel {
+	case data.DeliverySMS:
+		payload.To = utils.NormalizePhone(to)
+		if payload.To == "" || !utils.ValidatePhone(payload.To) {
+			utils.WriteValidationError(w, "A valid phone number is required")
+			return
+		}
+		payload.Body = receiptSMSText(receipt, seller, h.verifyURL(receipt.ID))
+	case data.DeliveryEmail:
+		if !utils.ValidateEmail(to) {
+			utils.WriteValidationError(w, "A valid email address is required")
+			return
+		}
+		payload.Subject = fmt.Sprintf("Receipt %s from %s", receipt.ReceiptNumber, seller)
+		payload.Body = fmt.Sprintf("%s\n\nDownload your receipt: %s/pdf", receiptSMSText(receipt, seller, h.verifyURL(receipt.ID)), h.verifyURL(receipt.ID))
+	default:
+		utils.WriteValidationError(w, "Channel must be sms or email")
+		return
+	}
+
+	deliveryID, err := h.DeliveryRepo.Insert(r.Context(), &data.MessageDelivery{
+		Purpose:   "receipt",
+		Recipient: payload.To,
+		UserID:    &receipt.UserID,
+	})
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to send receipt")
+		return
+	}
+	payload.DeliveryID = deliveryID
+
+	if _, err := h.JobRepo.Enqueue(r.Context(), data.JobTypeSendMessage, payload); err != nil {
+		utils.WriteInternalServerError(w, "Failed to send receipt")
+		return
+	}
+	if err := h.ReceiptRepo.MarkSent(r.Context(), receipt.ID, receipt.UserID); err != nil {
+		log.Printf("Failed to mark receipt %d as sent: %v", receipt.ID, err)
+	}
+
+	utils.WriteSuccessResponse(w, "Receipt queued for delivery", map[string]interface{}{
+		"delivery_id": deliveryID,
+	})
+}
+
+// VerifyReceipt lets a customer check that a receipt is authentic (no authentication)
+func (h *ReceiptHandler) VerifyReceipt(w http.ResponseWriter, r *http.Request) {
+	receipt, ok := h.resolveReceipt(w, r)
+	if !ok {
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Receipt is valid", &ReceiptVerification{
+		Valid:         true,
+		ReceiptNumber: receipt.ReceiptNumber,
+		IssuedBy:      h.sellerName(r.Context(), receipt.UserID),
+		CustomerName:  receipt.CustomerName,
+		InvoiceNumber: receipt.InvoiceNumber,
+		Amount:        receipt.Amount,
+		BalanceDue:    receipt.BalanceDue,
+		Currency:      receipt.Currency,
+		PaidAt:        receipt.PaidAt,
+	})
+}
+
+// DownloadPublicReceiptPDF downloads a receipt as PDF through its verification link (no authentication)
+func (h *ReceiptHandler) DownloadPublicReceiptPDF(w http.ResponseWriter, r *http.Request) {
+	receipt, ok := h.resolveReceipt(w, r)
+	if !ok {
+		return
+	}
+
+	h.writePDF(r.Context(), w, receipt, "")
+}
+
+// loadReceipt loads the receipt in the URL for the authenticated user, writing the error
+// response and returning false when it fails
+func (h *ReceiptHandler) loadReceipt(w http.ResponseWriter, r *http.Request) (*data.Receipt, bool) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return nil, false
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid receipt ID")
+		return nil, false
+	}
+
+	receipt, err := h.ReceiptRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Receipt not found")
+		return nil, false
+	}
+	return receipt, true
+}
+
+// resolveReceipt verifies the signed token in the URL and loads the receipt, writing the
+// error response and returning false when it fails
+func (h *ReceiptHandler) resolveReceipt(w http.ResponseWriter, r *http.Request) (*data.Receipt, bool) {
+	id, err := utils.VerifySignedID(receiptSignature, chi.URLParam(r, "token"))
+	if err != nil {
+		utils.WriteNotFoundError(w, "Receipt not found or not authentic")
+		return nil, false
+	}
+
+	receipt, err := h.ReceiptRepo.GetByID(r.Context(), id)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Receipt not found or not authentic")
+		return nil, false
+	}
+	return receipt, true
+}
+
+// writePDF renders a receipt as a PDF download in the organization's receipt template
+func (h *ReceiptHandler) writePDF(ctx context.Context, w http.ResponseWriter, receipt *data.Receipt, watermark string) {
+	template, logo := documentTemplate(ctx, h.TemplateRepo, h.Store, receipt.UserID, data.DocumentReceipt)
+	label := func(text string) string { return documentLabel(template.Language, text) }
+
+	doc := newTemplatedPDF(template, logo)
+	if watermark != "" {
+		doc.Watermark(watermark)
+	}
+	doc.Title(label("RECEIPT"))
+	doc.Heading(h.sellerName(ctx, receipt.UserID))
+	doc.Space()
+	doc.Row(label("Receipt No."), receipt.ReceiptNumber)
+	doc.Row(label("Date"), receipt.PaidAt.Format("2006-01-02"))
+	doc.Row(label("Received from"), receipt.CustomerName)
+	if receipt.InvoiceNumber != nil && !template.Hides(data.TemplateFieldInvoiceNumber) {
+		doc.Row(label("Invoice"), *receipt.InvoiceNumber)
+	}
+	if !template.Hides(data.TemplateFieldDescription) {
+		doc.Row(label("For"), receipt.Description)
+	}
+	doc.Space()
+	doc.Row(label("Amount received"), utils.FormatMoney(receipt.Currency, receipt.Amount))
+	if !template.Hides(data.TemplateFieldBalanceDue) {
+		doc.Row(label("Balance due"), utils.FormatMoney(receipt.Currency, receipt.BalanceDue))
+	}
+	if h.SealRepo != nil && !template.Hides(data.TemplateFieldSeal) {
+		if seal, err := h.SealRepo.GetByIncome(ctx, receipt.IncomeID, receipt.UserID); err == nil {
+			doc.Space()
+			doc.Small(label("Chain-of-custody seal:") + " " + seal.Digest)
+			doc.Small(label("Verify the seal at:") + " " + sealVerifyURL(h.BaseURL, seal.ID))
+		}
+	}
+	if !template.Hides(data.TemplateFieldVerifyLink) {
+		doc.Space()
+		doc.Space()
+		doc.Small(label("Verify this receipt at:"))
+		doc.Small(h.verifyURL(receipt.ID))
+	}
+	writeTemplateFooter(doc, template)
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", receipt.ReceiptNumber+".pdf"))
+	w.WriteHeader(http.StatusOK)
+	w.Write(doc.Bytes())
+}
+
+// verifyURL returns the signed public verification URL of a receipt
+func (h *ReceiptHandler) verifyURL(id uint) string {
+	return h.BaseURL + "/api/v1/public/receipts/" + utils.SignID(receiptSignature, id)
+}
+
+// sellerName returns the name of the receipt issuer
+func (h *ReceiptHandler) sellerName(ctx context.Context, userID uint) string {
+	user, err := h.UserRepo.GetOne(ctx, userID)
+	if err != nil {
+		return ""
+	}
+	return user.Name
+}
+
+// issueReceipt numbers and saves a receipt for a payment received against an income record
+func issueReceipt(ctx context.Context, settingsRepo data.SettingsInterface, receiptRepo data.ReceiptInterface, income *data.Income, amount float64) (*data.Receipt, error) {
+	if amount <= 0 {
+		return nil, errors.New("receipt amount must be positive")
+	}
+
+	settings, err := settingsRepo.GetByUserID(ctx, income.UserID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	description := string(income.MineralType)
+	if income.ItemName != nil && *income.ItemName != "" {
+		description = *income.ItemName
+	}
+	receipt := &data.Receipt{
+		IncomeID:        income.ID,
+		InvoiceNumber:   income.InvoiceNumber,
+		CustomerName:    income.CustomerName,
+		CustomerContact: income.CustomerContact,
+		Description:     fmt.Sprintf("%s, %.2f %s", description, income.Quantity, income.Unit),
+		Amount:          amount,
+		BalanceDue:      income.AmountDue,
+		Currency:        settings.DefaultCurrency,
+		PaidAt:          now,
+		UserID:          income.UserID,
+	}
+	if _, err := receiptRepo.Insert(ctx, receipt); err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// issuePaymentReceipt issues a receipt when a payment was recorded on an income record. Failures
+// are logged rather than returned so the payment itself is never lost.
+func issuePaymentReceipt(ctx context.Context, settingsRepo data.SettingsInterface, receiptRepo data.ReceiptInterface, income *data.Income, amount float64) {
+	if receiptRepo == nil || amount <= 0 {
+		return
+	}
+	if _, err := issueReceipt(ctx, settingsRepo, receiptRepo, income, amount); err != nil {
+		log.Printf("Failed to issue receipt for income %d: %v", income.ID, err)
+	}
+}
+
+// receiptSMSText is the short text form of a receipt
+func receiptSMSText(receipt *data.Receipt, seller, verifyURL string) string {
+	text := fmt.Sprintf("Receipt %s: %s received %s from %s", receipt.ReceiptNumber, seller,
+		utils.FormatMoney(receipt.Currency, receipt.Amount), receipt.CustomerName)
+	if receipt.InvoiceNumber != nil {
+		text += " for invoice " + *receipt.InvoiceNumber
+	}
+	return fmt.Sprintf("%s on %s. Balance: %s. Verify: %s", text, receipt.PaidAt.Format("2006-01-02"),
+		utils.FormatMoney(receipt.Currency, receipt.BalanceDue), verifyURL)
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"mineral/data"
+	"mineral/pkg/utils"
+	"net/http"
+	"strings"
+)
+
+// defaultLanguage is the language of reference labels when the client asks for none or an
+// unsupported one
+const defaultLanguage = "en"
+
+// englishLabels overrides labels that cannot be derived from the value
+var englishLabels = map[string]string{
+	"flourite": "Fluorite",
+}
+
+// referenceLabels holds the translated labels of reference values by language. Values without
+// a translation fall back to English.
+var referenceLabels = map[string]map[string]string{
+	"fr": {
+		// Minerals
+		"gold": "Or", "copper": "Cuivre", "cobalt": "Cobalt", "diamond": "Diamant",
+		"iron_ore": "Minerai de fer", "lead": "Plomb", "zinc": "Zinc", "lithium": "Lithium",
+		"nickel": "Nickel", "coltan": "Coltan", "tin": "Étain", "wolfram": "Wolfram",
+		"titanium": "Titane", "manganese": "Manganèse", "rare_earth_elements": "Terres rares",
+		"uranium": "Uranium", "bentonite": "Bentonite", "diatomite": "Diatomite",
+		"graphite": "Graphite", "gypsum": "Gypse", "feldspar": "Feldspath", "limestone": "Calcaire",
+		"marble": "Marbre", "kaolin": "Kaolin", "phosphates": "Phosphates", "pozzolana": "Pouzzolane",
+		"salt": "Sel", "sand": "Sable", "vermiculite": "Vermiculite", "silver": "Argent",
+		"granite": "Granite", "chromite": "Chromite", "gemstones": "Pierres précieuses", "other": "Autre",
+		// Gemstones
+		"apatite": "Apatite", "beryl": "Béryl", "aquamarine": "Aigue-marine", "ruby": "Rubis",
+		"sapphire": "Saphir", "flourite": "Fluorine", "garnet": "Grenat", "opal": "Opale",
+		"quartz": "Quartz", "topaz": "Topaze", "tourmaline": "Tourmaline", "zircon": "Zircon",
+		// Sales types
+		"mineral": "Minerai", "supply": "Fournitures", "concentrates": "Concentrés", "tailings": "Résidus miniers",
+		// Expense categories
+		"equipment": "Équipement", "labor": "Main-d'œuvre", "chemicals": "Produits chimiques",
+		"fuel": "Carburant", "maintenance": "Entretien", "transport": "Transport",
+		// Payment statuses
+		"paid": "Payé", "unpaid": "Impayé", "partial": "Partiel",
+		// Production sources
+		"mine": "Mine", "processing": "Traitement",
+		// Processing methods
+		"crushing": "Concassage", "milling": "Broyage", "sieving": "Tamisage", "grading": "Calibrage",
+		"sorting": "Tri", "cutting": "Taille", "dressing": "Enrichissement", "leaching": "Lixiviation",
+		"elution": "Élution", "refining": "Affinage", "floatation": "Flottation", "grinding": "Meulage",
+		"screening": "Criblage", "drying": "Séchage", "exfoliation": "Exfoliation",
+		"polishing": "Polissage", "washing": "Lavage",
+		// Units
+		"grams": "Grammes", "kilograms": "Kilogrammes", "tonnes": "Tonnes", "carats": "Carats",
+		"troy_ounces": "Onces troy", "pieces": "Pièces", "liters": "Litres", "bags": "Sacs",
+	},
+}
+
+// ReferenceHandler handles the reference data clients render pickers from
+type ReferenceHandler struct{}
+
+// NewReferenceHandler creates a new ReferenceHandler
+func NewReferenceHandler() *ReferenceHandler {
+	return &ReferenceHandler{}
+}
+
+// GetReferenceData returns the minerals, gemstone types, sales types, expense categories, units,
+// payment statuses, production sources and processing methods with labels in the language from the lang query parameter or the
+// Accept-Language header (no authentication)
+func (h *ReferenceHandler) GetReferenceData(w http.ResponseWriter, r *http.Request) {
+	language := referenceLanguage(r)
+
+	reference := &data.ReferenceData{
+		Language:          language,
+		Minerals:          referenceOptions(language, data.MineralTypes),
+		GemstoneTypes:     referenceOptions(language, data.GemstoneTypes),
+		SalesTypes:        referenceOptions(language, data.SalesTypes),
+		ExpenseCategories: referenceOptions(language, data.ExpenseCategories),
+		Units:             referenceOptions(language, data.Units),
+		PaymentStatuses:   referenceOptions(language, data.PaymentStatuses),
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.Header().Set("Vary", "Accept-Language")
+	utils.WriteSuccessResponse(w, "Reference data retrieved successfully", reference)
+}
+
+// referenceLanguage picks the first supported language from the lang query parameter or the
+// Accept-Language header
+func referenceLanguage(r *http.Request) string {
+	candidates := []string{r.URL.Query().Get("lang")}
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, _, _ := strings.Cut(part, ";")
+		candidates = append(candidates, tag)
+	}
+
+	for _, candidate := range candidates {
+		primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(candidate)), "-")
+		if primary == defaultLanguage {
+			return primary
+		}
+		if _, ok := referenceLabels[primary]; ok {
+			return primary
+		}
+	}
+	return defaultLanguage
+}
+
+// referenceOptions labels reference values in a language
+func referenceOptions[T ~string](language string, values []T) []data.ReferenceOption {
+	options := make([]data.ReferenceOption, 0, len(values))
+	for _, value := range values {
+		options = append(options, data.ReferenceOption{
+			Value: string(value),
+			Label: referenceLabel(language, string(value)),
+		})
+	}
+	return options
+}
+
+// referenceLabel returns the label of a value in a language, falling back to English
+func referenceLabel(language, value string) string {
+	if label, ok := referenceLabels[language][value]; ok {
+		return label
+	}
+	if label, ok := englishLabels[value]; ok {
+		return label
+	}
+	label := strings.ReplaceAll(value, "_", " ")
+	return strings.ToUpper(label[:1]) + label[1:]
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"time"
+)
+
+// ReferralHandler handles referral code and referral stats requests
+type ReferralHandler struct {
+	ReferralRepo data.ReferralInterface
+}
+
+// NewReferralHandler creates a new ReferralHandler
+func NewReferralHandler(referralRepo data.ReferralInterface) *ReferralHandler {
+	return &ReferralHandler{
+		ReferralRepo: referralRepo,
+	}
+}
+
+// GetReferrals returns the acting user's referral code, generated on first use, with the peers
+// who signed up with it
+func (h *ReferralHandler) GetReferrals(w http.ResponseWriter, r *http.Request) {
+	actorID := middleware.GetActorIDFromRequest(r)
+	if actorID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	code, err := h.ReferralRepo.GetOrCreateCode(r.Context(), actorID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve referral code")
+		return
+	}
+	referred, err := h.ReferralRepo.GetReferredUsers(r.Context(), actorID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve referrals")
+		return
+	}
+
+	stats := &data.ReferralStats{
+		Code:      code.Code,
+		Referred:  len(referred),
+		Referrals: referred,
+	}
+	for _, user := range referred {
+		if user.Active {
+			stats.Active++
+		}
+	}
+
+	utils.WriteSuccessResponse(w, "Referrals retrieved successfully", stats)
+}
+
+// GetReferrers returns the users with referrals between the optional start_date and end_date,
+// most referrals first, e.g. to reward the miners who onboarded most peers in a campaign
+func (h *ReferralHandler) GetReferrers(w http.ResponseWriter, r *http.Request) {
+	startDate, endDate, ok := exportDateRange(w, r)
+	if !ok {
+		return
+	}
+
+	var from, to *time.Time
+	if startDate != "" {
+		start, _ := time.Parse("2006-01-02", startDate)
+		end, _ := time.Parse("2006-01-02", endDate)
+		end = end.AddDate(0, 0, 1)
+		from, to = &start, &end
+	}
+
+	referrers, err := h.ReferralRepo.GetReferrers(r.Context(), from, to)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve referrers")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Referrers retrieved successfully", referrers)
+}
@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/pdf"
+	"mineral/pkg/tracing"
+	"mineral/pkg/utils"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// RegulatorReportHandler builds the formalization report pack of a mine site, assembled from
+// the site, workforce, sales, inventory and settings records
+type RegulatorReportHandler struct {
+	MineSiteRepo   data.MineSiteInterface
+	IncomeRepo     data.IncomeInterface
+	InventoryRepo  data.InventoryInterface
+	EmployeeRepo   data.EmployeeInterface
+	ContractorRepo data.ContractorInterface
+	SettingsRepo   data.SettingsInterface
+	UserRepo       data.UserInterface
+}
+
+// NewRegulatorReportHandler creates a new RegulatorReportHandler
+func NewRegulatorReportHandler(mineSiteRepo data.MineSiteInterface, incomeRepo data.IncomeInterface, inventoryRepo data.InventoryInterface,
+	employeeRepo data.EmployeeInterface, contractorRepo data.ContractorInterface, settingsRepo data.SettingsInterface, userRepo data.UserInterface) *RegulatorReportHandler {
+	return &RegulatorReportHandler{
+		MineSiteRepo:   mineSiteRepo,
+		IncomeRepo:     incomeRepo,
+		InventoryRepo:  inventoryRepo,
+		EmployeeRepo:   employeeRepo,
+		ContractorRepo: contractorRepo,
+		SettingsRepo:   settingsRepo,
+		UserRepo:       userRepo,
+	}
+}
+
+// productionTotal is the quantity and value of one mineral sold in one unit during the period
+type productionTotal struct {
+	MineralType data.MineralType
+	Unit        string
+	Quantity    float64
+	Value       float64
+}
+
+// DownloadRegulatorReport renders the report pack of a mine site for a period as a PDF:
+// site and licence details, registered workers, production totals, royalty estimates and
+// hazardous materials on site
+func (h *RegulatorReportHandler) DownloadRegulatorReport(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid mine site ID")
+		return
+	}
+
+	startStr := r.URL.Query().Get("start_date")
+	endStr := r.URL.Query().Get("end_date")
+	if !utils.ValidateRequired(startStr) || !utils.ValidateRequired(endStr) {
+		utils.WriteValidationError(w, "Start date and end date are required")
+		return
+	}
+	start, err := time.Parse("2006-01-02", startStr)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid start date format. Use YYYY-MM-DD")
+		return
+	}
+	end, err := time.Parse("2006-01-02", endStr)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid end date format. Use YYYY-MM-DD")
+		return
+	}
+	if end.Before(start) {
+		utils.WriteValidationError(w, "End date must not be before start date")
+		return
+	}
+
+	ctx, span := tracing.Start(r.Context(), "regulator report", attribute.Int("mine_site.id", int(id)))
+	defer span.End()
+
+	site, err := h.MineSiteRepo.GetOne(ctx, uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Mine site not found")
+		return
+	}
+	// Records from before an operator added more sites have no site; they belong to the first one
+	includeUnassigned := false
+	if first, err := h.MineSiteRepo.GetByUserID(ctx, userID); err == nil && first != nil {
+		includeUnassigned = first.ID == site.ID
+	}
+
+	settings, err := h.SettingsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve settings")
+		return
+	}
+	employees, err := h.EmployeeRepo.GetAll(ctx, userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve employees")
+		return
+	}
+	contractors, err := h.ContractorRepo.GetAll(ctx, userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve contractors")
+		return
+	}
+	incomes, err := h.IncomeRepo.GetByDateRange(ctx, userID, startStr, endStr)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve income records")
+		return
+	}
+	items, err := h.InventoryRepo.GetAll(ctx, userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve inventory")
+		return
+	}
+
+	atSite := func(siteID *uint) bool {
+		if siteID == nil {
+			return includeUnassigned
+		}
+		return *siteID == site.ID
+	}
+	var siteIncomes []*data.Income
+	for _, income := range incomes {
+		if atSite(income.MineSiteID) {
+			siteIncomes = append(siteIncomes, income)
+		}
+	}
+	var siteItems []*data.InventoryItem
+	for _, item := range items {
+		if atSite(item.MineSiteID) {
+			siteItems = append(siteItems, item)
+		}
+	}
+
+	doc := pdf.New()
+	doc.Title("MINE SITE REPORT")
+	doc.Heading(h.operatorName(ctx, userID))
+	doc.Text(fmt.Sprintf("Reporting period: %s to %s", startStr, endStr))
+	doc.Space()
+
+	doc.Heading("1. Site details")
+	if site.Name != nil {
+		doc.Row("Site", *site.Name)
+	}
+	doc.Row("Owner", site.Owner)
+	doc.Row("Location", site.Location)
+	if site.Region != nil {
+		doc.Row("Region", *site.Region)
+	}
+	if site.License != nil {
+		doc.Row("Licence No.", *site.License)
+	}
+	if site.LicenseExpiry != nil {
+		doc.Row("Licence expiry", site.LicenseExpiry.Format("2006-01-02"))
+	}
+	if site.Size != nil {
+		doc.Row("Area", strconv.FormatFloat(*site.Size, 'f', -1, 64)+" ha")
+	}
+	if site.NumberOfPits != nil {
+		doc.Row("Pits", strconv.Itoa(*site.NumberOfPits))
+	}
+	if site.Latitude != nil && site.Longitude != nil {
+		doc.Row("Coordinates", fmt.Sprintf("%.6f, %.6f", *site.Latitude, *site.Longitude))
+	}
+	if site.Commodities != nil {
+		doc.Row("Commodities", *site.Commodities)
+	}
+	doc.Space()
+
+	doc.Heading("2. Registered workers")
+	active := 0
+	for _, employee := range employees {
+		if !employee.Active {
+			continue
+		}
+		active++
+		position := "-"
+		if employee.Position != nil {
+			position = *employee.Position
+		}
+		doc.Row(employee.Name, position)
+	}
+	doc.Row("Employees", strconv.Itoa(active))
+	for _, contractor := range contractors {
+		leader := "Contractor"
+		if contractor.LeaderName != nil {
+			leader = "Contractor, led by " + *contractor.LeaderName
+		}
+		doc.Row(contractor.Name, leader)
+	}
+	doc.Row("Contractors", strconv.Itoa(len(contractors)))
+	doc.Space()
+
+	doc.Heading("3. Production totals")
+	totals := summarizeProduction(siteIncomes)
+	if len(totals) == 0 {
+		doc.Text("No production sold in the period.")
+	}
+	for _, total := range totals {
+		doc.Row(string(total.MineralType), fmt.Sprintf("%s %s sold for %s",
+			strconv.FormatFloat(total.Quantity, 'f', -1, 64), total.Unit, utils.FormatMoney(settings.DefaultCurrency, total.Value)))
+	}
+	for _, item := range siteItems {
+		if item.Type == "mineral" && item.Quantity > 0 {
+			doc.Row(item.Name, fmt.Sprintf("%s %s in stock", strconv.FormatFloat(item.Quantity, 'f', -1, 64), item.Unit))
+		}
+	}
+	doc.Space()
+
+	doc.Heading("4. Royalty estimates")
+	royalties := 0.0
+	for _, total := range totals {
+		rate, ok := settings.RoyaltyRates[string(total.MineralType)]
+		if !ok {
+			doc.Row(string(total.MineralType), "Rate not set")
+			continue
+		}
+		royalty := total.Value * rate / 100
+		royalties += royalty
+		doc.Row(string(total.MineralType), fmt.Sprintf("%s at %s%% of %s", utils.FormatMoney(settings.DefaultCurrency, royalty),
+			strconv.FormatFloat(rate, 'f', -1, 64), utils.FormatMoney(settings.DefaultCurrency, total.Value)))
+	}
+	doc.Row("Total royalties", utils.FormatMoney(settings.DefaultCurrency, royalties))
+	doc.Space()
+
+	doc.Heading("5. Hazardous materials")
+	hazardous := 0
+	for _, item := range siteItems {
+		if !item.IsHazardous {
+			continue
+		}
+		hazardous++
+		stock := strconv.FormatFloat(item.Quantity, 'f', -1, 64) + " " + item.Unit
+		if item.PermittedQuantity != nil {
+			stock += " of " + strconv.FormatFloat(*item.PermittedQuantity, 'f', -1, 64) + " permitted"
+		}
+		if item.HazardClass != nil {
+			stock += " (" + *item.HazardClass + ")"
+		}
+		doc.Row(item.Name, stock)
+	}
+	if hazardous == 0 {
+		doc.Text("No hazardous materials held on site.")
+	}
+	doc.Space()
+	doc.Space()
+	doc.Small(fmt.Sprintf("Generated on %s. Royalty figures are estimates from the configured rates and the", time.Now().Format("2006-01-02")))
+	doc.Small("recorded sale values; confirm them against the assessment of the mining authority.")
+
+	filename := fmt.Sprintf("site-%d-report-%s-%s.pdf", site.ID, startStr, endStr)
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write(doc.Bytes())
+}
+
+// operatorName returns the name of the operator filing the report
+func (h *RegulatorReportHandler) operatorName(ctx context.Context, userID uint) string {
+	user, err := h.UserRepo.GetOne(ctx, userID)
+	if err != nil {
+		return ""
+	}
+	return user.Name
+}
+
+// summarizeProduction totals the minerals sold by mineral type and unit, leaving out resold
+// supplies and sales to flagged customers that a manager hasn't approved
+func summarizeProduction(incomes []*data.Income) []*productionTotal {
+	byKey := map[string]*productionTotal{}
+	var totals []*productionTotal
+	for _, income := range incomes {
+		if income.SalesType == data.SalesTypeSupply {
+			continue
+		}
+		if income.ApprovalStatus != nil && *income.ApprovalStatus != data.SaleApproved {
+			continue
+		}
+		key := string(income.MineralType) + "|" + income.Unit
+		total, ok := byKey[key]
+		if !ok {
+			total = &productionTotal{MineralType: income.MineralType, Unit: income.Unit}
+			byKey[key] = total
+			totals = append(totals, total)
+		}
+		total.Quantity += income.Quantity
+		total.Value += income.TotalAmount
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].MineralType != totals[j].MineralType {
+			return totals[i].MineralType < totals[j].MineralType
+		}
+		return totals[i].Unit < totals[j].Unit
+	})
+	return totals
+}
@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// currencyPattern matches ISO 4217 currency codes
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// SettingsHandler handles organization settings requests
+type SettingsHandler struct {
+	SettingsRepo data.SettingsInterface
+
+	// Quota adds the attachment storage used against the organization's quota to the settings;
+	// it is left out when nil
+	Quota *AttachmentQuota
+}
+
+// NewSettingsHandler creates a new SettingsHandler
+func NewSettingsHandler(settingsRepo data.SettingsInterface) *SettingsHandler {
+	return &SettingsHandler{
+		SettingsRepo: settingsRepo,
+	}
+}
+
+// SettingsRequest represents an organization settings update; omitted fields are left unchanged
+type SettingsRequest struct {
+	FiscalYearStartMonth *int               `json:"fiscal_year_start_month,omitempty"`
+	DefaultCurrency      *string            `json:"default_currency,omitempty"`
+	DefaultUnits         map[string]string  `json:"default_units,omitempty"`
+	InvoiceNumberFormat  *string            `json:"invoice_number_format,omitempty"`
+	NextInvoiceNumber    *int               `json:"next_invoice_number,omitempty"`
+	ReceiptNumberFormat  *string            `json:"receipt_number_format,omitempty"`
+	NextReceiptNumber    *int               `json:"next_receipt_number,omitempty"`
+	CreditNoteFormat     *string            `json:"credit_note_number_format,omitempty"`
+	NextCreditNoteNumber *int               `json:"next_credit_note_number,omitempty"`
+	CreditLimitMode      *string            `json:"credit_limit_mode,omitempty"` // "warn" or "block"
+	ShareBenchmarkData   *bool              `json:"share_benchmark_data,omitempty"`
+	RoyaltyRates         map[string]float64 `json:"royalty_rates,omitempty"` // percent of sale value by mineral type
+	BackdateApprovalDays *int               `json:"backdate_approval_days,omitempty"`
+	SignOffAmount        *float64           `json:"sign_off_amount,omitempty"`
+	PriceVariancePercent *float64           `json:"price_variance_percent,omitempty"` // 0 turns variance flags off
+	RainySeasonMonths    []int              `json:"rainy_season_months,omitempty"`    // 1 = January
+}
+
+// SettingsResponse represents organization settings with derived values
+type SettingsResponse struct {
+	*data.OrganizationSettings
+	CurrentFiscalYearStart time.Time `json:"current_fiscal_year_start"`
+	NextInvoicePreview     string    `json:"next_invoice_preview"`
+	NextReceiptPreview     string    `json:"next_receipt_preview"`
+	NextCreditNotePreview  string    `json:"next_credit_note_preview"`
+
+	AttachmentStorage *data.AttachmentStorage `json:"attachment_storage,omitempty"`
+}
+
+// GetSettings retrieves the organization settings for the authenticated user
+func (h *SettingsHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	settings, err := h.SettingsRepo.GetByUserID(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve settings")
+		return
+	}
+
+	response, err := h.settingsResponse(r.Context(), settings)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve settings")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Settings retrieved successfully", response)
+}
+
+// UpdateSettings updates the organization settings for the authenticated user
+func (h *SettingsHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req SettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	settings, err := h.SettingsRepo.GetByUserID(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve settings")
+		return
+	}
+	sequences, err := h.SettingsRepo.GetSequences(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve settings")
+		return
+	}
+
+	if req.FiscalYearStartMonth != nil {
+		if *req.FiscalYearStartMonth < 1 || *req.FiscalYearStartMonth > 12 {
+			utils.WriteValidationError(w, "Fiscal year start month must be between 1 and 12")
+			return
+		}
+		settings.FiscalYearStartMonth = *req.FiscalYearStartMonth
+	}
+	if req.DefaultCurrency != nil {
+		currency := strings.ToUpper(strings.TrimSpace(*req.DefaultCurrency))
+		if !currencyPattern.MatchString(currency) {
+			utils.WriteValidationError(w, "Default currency must be a 3-letter currency code")
+			return
+		}
+		settings.DefaultCurrency = currency
+	}
+	if req.DefaultUnits != nil {
+		units := make(map[string]string, len(req.DefaultUnits))
+		for mineralType, unit := range req.DefaultUnits {
+			unit = strings.TrimSpace(unit)
+			if !utils.ValidateRequired(mineralType) || !utils.ValidateRequired(unit) {
+				utils.WriteValidationError(w, "Default units must map a mineral type to a unit")
+				return
+			}
+			units[mineralType] = unit
+		}
+		settings.DefaultUnits = units
+	}
+	if req.RoyaltyRates != nil {
+		rates := make(map[string]float64, len(req.RoyaltyRates))
+		for mineralType, rate := range req.RoyaltyRates {
+			if !utils.ValidateRequired(mineralType) || rate < 0 || rate > 100 {
+				utils.WriteValidationError(w, "Royalty rates must map a mineral type to a percentage between 0 and 100")
+				return
+			}
+			rates[mineralType] = rate
+		}
+		settings.RoyaltyRates = rates
+	}
+	if req.InvoiceNumberFormat != nil {
+		if !data.ValidInvoiceNumberFormat(*req.InvoiceNumberFormat) {
+			utils.WriteValidationError(w, "Invoice number format must contain a {SEQ} placeholder")
+			return
+		}
+		settings.InvoiceNumberFormat = *req.InvoiceNumberFormat
+	}
+	if req.NextInvoiceNumber != nil {
+		if !setFirstNumber(w, sequences, data.DocumentInvoice, *req.NextInvoiceNumber, &settings.NextInvoiceNumber) {
+			return
+		}
+	}
+	if req.ReceiptNumberFormat != nil {
+		if !data.ValidInvoiceNumberFormat(*req.ReceiptNumberFormat) {
+			utils.WriteValidationError(w, "Receipt number format must contain a {SEQ} placeholder")
+			return
+		}
+		settings.ReceiptNumberFormat = *req.ReceiptNumberFormat
+	}
+	if req.NextReceiptNumber != nil {
+		if !setFirstNumber(w, sequences, data.DocumentReceipt, *req.NextReceiptNumber, &settings.NextReceiptNumber) {
+			return
+		}
+	}
+	if req.CreditNoteFormat != nil {
+		if !data.ValidInvoiceNumberFormat(*req.CreditNoteFormat) {
+			utils.WriteValidationError(w, "Credit note number format must contain a {SEQ} placeholder")
+			return
+		}
+		settings.CreditNoteFormat = *req.CreditNoteFormat
+	}
+	if req.NextCreditNoteNumber != nil {
+		if !setFirstNumber(w, sequences, data.DocumentCreditNote, *req.NextCreditNoteNumber, &settings.NextCreditNoteNumber) {
+			return
+		}
+	}
+	if req.CreditLimitMode != nil {
+		mode := data.CreditLimitMode(*req.CreditLimitMode)
+		if mode != data.CreditLimitWarn && mode != data.CreditLimitBlock {
+			utils.WriteValidationError(w, "Credit limit mode must be warn or block")
+			return
+		}
+		settings.CreditLimitMode = mode
+	}
+	if req.ShareBenchmarkData != nil {
+		settings.ShareBenchmarkData = *req.ShareBenchmarkData
+	}
+	if req.BackdateApprovalDays != nil {
+		if *req.BackdateApprovalDays < 0 {
+			utils.WriteValidationError(w, "Backdate approval days cannot be negative")
+			return
+		}
+		settings.BackdateApprovalDays = *req.BackdateApprovalDays
+	}
+	if req.SignOffAmount != nil {
+		if *req.SignOffAmount < 0 {
+			utils.WriteValidationError(w, "Sign-off amount cannot be negative")
+			return
+		}
+		settings.SignOffAmount = *req.SignOffAmount
+	}
+	if req.PriceVariancePercent != nil {
+		if *req.PriceVariancePercent < 0 {
+			utils.WriteValidationError(w, "Price variance percent cannot be negative")
+			return
+		}
+		settings.PriceVariancePercent = *req.PriceVariancePercent
+	}
+	if req.RainySeasonMonths != nil {
+		months := slices.Clone(req.RainySeasonMonths)
+		slices.Sort(months)
+		months = slices.Compact(months)
+		if len(months) == 0 || months[0] < 1 || months[len(months)-1] > 12 {
+			utils.WriteValidationError(w, "Rainy season months must be months of the year between 1 and 12")
+			return
+		}
+		settings.RainySeasonMonths = months
+	}
+
+	if err := h.SettingsRepo.Save(r.Context(), settings); err != nil {
+		utils.WriteInternalServerError(w, "Failed to update settings")
+		return
+	}
+
+	response, err := h.settingsResponse(r.Context(), settings)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve settings")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Settings updated successfully", response)
+}
+
+// settingsResponse adds the derived values and the attachment storage used to the settings, with
+// the next numbers of the document sequences already started
+func (h *SettingsHandler) settingsResponse(ctx context.Context, settings *data.OrganizationSettings) (*SettingsResponse, error) {
+	sequences, err := h.SettingsRepo.GetSequences(ctx, settings.UserID)
+	if err != nil {
+		return nil, err
+	}
+	for kind, next := range sequences {
+		switch kind {
+		case data.DocumentInvoice:
+			settings.NextInvoiceNumber = next
+		case data.DocumentReceipt:
+			settings.NextReceiptNumber = next
+		case data.DocumentCreditNote:
+			settings.NextCreditNoteNumber = next
+		}
+	}
+
+	response := newSettingsResponse(settings)
+	if h.Quota != nil {
+		storage, err := h.Quota.Usage(ctx, settings.UserID)
+		if err != nil {
+			return nil, err
+		}
+		response.AttachmentStorage = storage
+	}
+	return response, nil
+}
+
+// newSettingsResponse adds the current fiscal year and next invoice, receipt and credit note numbers to the settings
+func newSettingsResponse(settings *data.OrganizationSettings) *SettingsResponse {
+	now := time.Now()
+	return &SettingsResponse{
+		OrganizationSettings:   settings,
+		CurrentFiscalYearStart: settings.FiscalYearStart(now),
+		NextInvoicePreview:     settings.FormatInvoiceNumber(settings.NextInvoiceNumber, now),
+		NextReceiptPreview:     settings.FormatReceiptNumber(settings.NextReceiptNumber, now),
+		NextCreditNotePreview:  settings.FormatCreditNoteNumber(settings.NextCreditNoteNumber, now),
+	}
+}
+
+// setFirstNumber sets the number the sequence of a kind of document starts at, which can't change
+// once a document of the kind has been numbered so numbers are never skipped or reused. It writes
+// the error response and returns false when the number can't be set.
+func setFirstNumber(w http.ResponseWriter, sequences map[data.DocumentKind]int, kind data.DocumentKind, number int, first *int) bool {
+	name := strings.ReplaceAll(string(kind), "_", " ")
+	if number < 1 {
+		utils.WriteValidationError(w, fmt.Sprintf("Next %s number must be at least 1", name))
+		return false
+	}
+	if next, ok := sequences[kind]; ok && next != number {
+		utils.WriteErrorResponse(w, fmt.Sprintf("Next %s number can't change once %ss have been numbered", name, name), http.StatusConflict)
+		return false
+	}
+	*first = number
+	return true
+}
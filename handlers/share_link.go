@@ -0,0 +1,546 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/storage"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// shareLinkSignature is the signing purpose of share link tokens
+const shareLinkSignature = "share-link"
+
+// defaultShareLinkDays is how long a share link stays valid unless specified
+const defaultShareLinkDays = 30
+
+// ShareLinkHandler handles public invoice and statement links
+type ShareLinkHandler struct {
+	ShareRepo    data.ShareLinkInterface
+	IncomeRepo   data.IncomeInterface
+	SettingsRepo data.SettingsInterface
+	UserRepo     data.UserInterface
+
+	// SealRepo adds the chain-of-custody seals of sealed sales to their lines when set
+	SealRepo data.LotSealInterface
+
+	// TemplateRepo applies the organization's invoice template to PDFs when set, with the logo
+	// read from Store
+	TemplateRepo data.DocumentTemplateInterface
+	Store        storage.Store
+
+	// BaseURL is prepended to public link paths, e.g. https://api.example.com
+	BaseURL string
+}
+
+// NewShareLinkHandler creates a new ShareLinkHandler
+func NewShareLinkHandler(shareRepo data.ShareLinkInterface, incomeRepo data.IncomeInterface, settingsRepo data.SettingsInterface, userRepo data.UserInterface) *ShareLinkHandler {
+	return &ShareLinkHandler{
+		ShareRepo:    shareRepo,
+		IncomeRepo:   incomeRepo,
+		SettingsRepo: settingsRepo,
+		UserRepo:     userRepo,
+	}
+}
+
+// ShareLinkRequest represents a request to create a share link
+type ShareLinkRequest struct {
+	CustomerName  string `json:"customer_name,omitempty"` // statements only
+	ExpiresInDays *int   `json:"expires_in_days,omitempty"`
+}
+
+// ConfirmShareLinkRequest represents a customer's confirmation of a shared document
+type ConfirmShareLinkRequest struct {
+	Name string `json:"name"`
+}
+
+// PublicInvoiceLine represents a sale on a public invoice or statement
+type PublicInvoiceLine struct {
+	InvoiceNumber *string            `json:"invoice_number,omitempty"`
+	Date          string             `json:"date"`
+	Description   string             `json:"description"`
+	Quantity      float64            `json:"quantity"`
+	Unit          string             `json:"unit"`
+	PricePerUnit  float64            `json:"price_per_unit"`
+	TotalAmount   float64            `json:"total_amount"`
+	AmountPaid    float64            `json:"amount_paid"`
+	AmountDue     float64            `json:"amount_due"`
+	PaymentStatus data.PaymentStatus `json:"payment_status"`
+	Seal          *PublicSeal        `json:"seal,omitempty"` // set when the lot sold is sealed
+}
+
+// PublicSeal represents the chain-of-custody seal of a sale on a public invoice or statement
+type PublicSeal struct {
+	Digest    string `json:"digest"`
+	VerifyURL string `json:"verify_url"`
+}
+
+// PublicDocument represents an invoice or statement as shown to the customer
+type PublicDocument struct {
+	Kind         data.ShareLinkKind  `json:"kind"`
+	Seller       string              `json:"seller"`
+	CustomerName string              `json:"customer_name"`
+	Currency     string              `json:"currency"`
+	Lines        []PublicInvoiceLine `json:"lines"`
+	TotalAmount  float64             `json:"total_amount"`
+	AmountPaid   float64             `json:"amount_paid"`
+	AmountDue    float64             `json:"amount_due"`
+	ConfirmedAt  *time.Time          `json:"confirmed_at,omitempty"`
+	ConfirmedBy  *string             `json:"confirmed_by,omitempty"`
+	GeneratedAt  time.Time           `json:"generated_at"`
+}
+
+// GetAllShareLinks returns the current user's share links with their view counts
+func (h *ShareLinkHandler) GetAllShareLinks(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	links, err := h.ShareRepo.GetAll(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve share links")
+		return
+	}
+	for _, link := range links {
+		link.URL = h.linkURL(link.ID)
+	}
+
+	utils.WriteSuccessResponse(w, "Share links retrieved successfully", links)
+}
+
+// ShareInvoice creates a public link to an income record's invoice, assigning an invoice number if needed
+func (h *ShareLinkHandler) ShareInvoice(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid income ID")
+		return
+	}
+
+	var req ShareLinkRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.WriteValidationError(w, "Invalid request body")
+			return
+		}
+	}
+	expiresAt, ok := shareLinkExpiry(w, req.ExpiresInDays)
+	if !ok {
+		return
+	}
+
+	income, err := h.IncomeRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Income record not found")
+		return
+	}
+
+	if income.InvoiceNumber == nil {
+		if _, err := h.IncomeRepo.AssignInvoiceNumber(r.Context(), income.ID, userID); err != nil {
+			utils.WriteInternalServerError(w, "Failed to assign invoice number")
+			return
+		}
+	}
+
+	incomeID := income.ID
+	h.createLink(r.Context(), w, &data.ShareLink{
+		Kind:      data.ShareInvoice,
+		IncomeID:  &incomeID,
+		ExpiresAt: expiresAt,
+		UserID:    userID,
+	})
+}
+
+// ShareStatement creates a public link to a customer's statement of all their sales
+func (h *ShareLinkHandler) ShareStatement(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req ShareLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	customerName := strings.TrimSpace(req.CustomerName)
+	if !utils.ValidateRequired(customerName) {
+		utils.WriteValidationError(w, "Customer name is required")
+		return
+	}
+	expiresAt, ok := shareLinkExpiry(w, req.ExpiresInDays)
+	if !ok {
+		return
+	}
+
+	incomes, err := h.IncomeRepo.GetByCustomer(r.Context(), userID, customerName)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve income records")
+		return
+	}
+	if len(incomes) == 0 {
+		utils.WriteNotFoundError(w, "No sales found for this customer")
+		return
+	}
+
+	h.createLink(r.Context(), w, &data.ShareLink{
+		Kind:         data.ShareStatement,
+		CustomerName: &customerName,
+		ExpiresAt:    expiresAt,
+		UserID:       userID,
+	})
+}
+
+// GetShareLinkViews returns the view history of a share link
+func (h *ShareLinkHandler) GetShareLinkViews(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid share link ID")
+		return
+	}
+
+	views, err := h.ShareRepo.GetViews(r.Context(), uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Share link not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to retrieve share link views")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Share link views retrieved successfully", views)
+}
+
+// RevokeShareLink revokes a share link
+func (h *ShareLinkHandler) RevokeShareLink(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid share link ID")
+		return
+	}
+
+	if err := h.ShareRepo.Revoke(r.Context(), uint(id), userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Share link not found or already revoked")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to revoke share link")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Share link revoked successfully", nil)
+}
+
+// ViewSharedDocument shows the document behind a public link and records the view (no authentication)
+func (h *ShareLinkHandler) ViewSharedDocument(w http.ResponseWriter, r *http.Request) {
+	link, ok := h.resolveLink(w, r)
+	if !ok {
+		return
+	}
+
+	document, err := h.buildDocument(r.Context(), link)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Document not found")
+		return
+	}
+	if !h.recordView(w, r, link) {
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Document retrieved successfully", document)
+}
+
+// DownloadSharedDocumentPDF downloads the invoice or statement behind a share link as a PDF in
+// the organization's invoice template (no authentication)
+func (h *ShareLinkHandler) DownloadSharedDocumentPDF(w http.ResponseWriter, r *http.Request) {
+	link, ok := h.resolveLink(w, r)
+	if !ok {
+		return
+	}
+
+	document, err := h.buildDocument(r.Context(), link)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Document not found")
+		return
+	}
+	if !h.recordView(w, r, link) {
+		return
+	}
+
+	template, logo := documentTemplate(r.Context(), h.TemplateRepo, h.Store, link.UserID, data.DocumentInvoice)
+	label := func(text string) string { return documentLabel(template.Language, text) }
+	money := func(amount float64) string { return utils.FormatMoney(document.Currency, amount) }
+
+	doc := newTemplatedPDF(template, logo)
+	fileName := "statement.pdf"
+	if document.Kind == data.ShareInvoice {
+		doc.Title(label("INVOICE"))
+		if number := document.Lines[0].InvoiceNumber; number != nil {
+			fileName = *number + ".pdf"
+		}
+	} else {
+		doc.Title(label("STATEMENT"))
+	}
+	doc.Heading(document.Seller)
+	doc.Space()
+	doc.Row(label("Customer"), document.CustomerName)
+	doc.Space()
+	for _, line := range document.Lines {
+		doc.Heading(line.Description)
+		if line.InvoiceNumber != nil {
+			doc.Row(label("Invoice No."), *line.InvoiceNumber)
+		}
+		doc.Row(label("Date"), line.Date)
+		doc.Row(label("Quantity"), fmt.Sprintf("%.2f %s", line.Quantity, line.Unit))
+		if !template.Hides(data.TemplateFieldPricePerUnit) {
+			doc.Row(label("Price per unit"), money(line.PricePerUnit))
+		}
+		doc.Row(label("Total"), money(line.TotalAmount))
+		if !template.Hides(data.TemplateFieldAmountPaid) {
+			doc.Row(label("Amount paid"), money(line.AmountPaid))
+		}
+		if !template.Hides(data.TemplateFieldPaymentStatus) {
+			doc.Row(label("Status"), referenceLabel(template.Language, string(line.PaymentStatus)))
+		}
+		if line.Seal != nil && !template.Hides(data.TemplateFieldSeal) {
+			doc.Small(label("Chain-of-custody seal:") + " " + line.Seal.Digest)
+			doc.Small(label("Verify the seal at:") + " " + line.Seal.VerifyURL)
+		}
+		doc.Space()
+	}
+	if len(document.Lines) > 1 {
+		doc.Row(label("Total"), money(document.TotalAmount))
+		if !template.Hides(data.TemplateFieldAmountPaid) {
+			doc.Row(label("Amount paid"), money(document.AmountPaid))
+		}
+	}
+	doc.Row(label("Amount due"), money(document.AmountDue))
+	if document.ConfirmedBy != nil && document.ConfirmedAt != nil {
+		doc.Space()
+		doc.Small(fmt.Sprintf("%s %s, %s", label("Confirmed by"), *document.ConfirmedBy, document.ConfirmedAt.Format("2006-01-02")))
+	}
+	writeTemplateFooter(doc, template)
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	w.WriteHeader(http.StatusOK)
+	w.Write(doc.Bytes())
+}
+
+// ConfirmSharedDocument records the customer's confirmation of the document behind a public link (no authentication)
+func (h *ShareLinkHandler) ConfirmSharedDocument(w http.ResponseWriter, r *http.Request) {
+	link, ok := h.resolveLink(w, r)
+	if !ok {
+		return
+	}
+
+	var req ConfirmShareLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	name := strings.TrimSpace(req.Name)
+	if !utils.ValidateRequired(name) {
+		utils.WriteValidationError(w, "Name is required")
+		return
+	}
+	if len(name) > 100 {
+		utils.WriteValidationError(w, "Name must be at most 100 characters")
+		return
+	}
+
+	if err := h.ShareRepo.Confirm(r.Context(), link.ID, name); err != nil {
+		if errors.Is(err, data.ErrShareLinkConfirmed) {
+			utils.WriteErrorResponse(w, "This document has already been confirmed", http.StatusConflict)
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to confirm document")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Document confirmed successfully", nil)
+}
+
+// createLink saves a share link and writes it with its public URL
+func (h *ShareLinkHandler) createLink(ctx context.Context, w http.ResponseWriter, link *data.ShareLink) {
+	if _, err := h.ShareRepo.Insert(ctx, link); err != nil {
+		utils.WriteInternalServerError(w, "Failed to create share link")
+		return
+	}
+	link.URL = h.linkURL(link.ID)
+
+	utils.WriteSuccessResponse(w, "Share link created successfully", link)
+}
+
+// linkURL returns the signed public URL of a share link
+func (h *ShareLinkHandler) linkURL(id uint) string {
+	return h.BaseURL + "/api/v1/public/links/" + utils.SignID(shareLinkSignature, id)
+}
+
+// resolveLink verifies the signed token in the URL and loads the active share link,
+// writing the error response and returning false when it fails
+func (h *ShareLinkHandler) resolveLink(w http.ResponseWriter, r *http.Request) (*data.ShareLink, bool) {
+	id, err := utils.VerifySignedID(shareLinkSignature, chi.URLParam(r, "token"))
+	if err != nil {
+		utils.WriteNotFoundError(w, "Link not found")
+		return nil, false
+	}
+
+	link, err := h.ShareRepo.GetActive(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, data.ErrShareLinkUnavailable) {
+			utils.WriteErrorResponse(w, "This link has expired or been revoked", http.StatusGone)
+			return nil, false
+		}
+		utils.WriteNotFoundError(w, "Link not found")
+		return nil, false
+	}
+	return link, true
+}
+
+// recordView records a view of a shared document, writing the error response and returning
+// false when it fails
+func (h *ShareLinkHandler) recordView(w http.ResponseWriter, r *http.Request, link *data.ShareLink) bool {
+	var userAgent *string
+	if ua := r.UserAgent(); ua != "" {
+		if len(ua) > 255 {
+			ua = ua[:255]
+		}
+		userAgent = &ua
+	}
+	if err := h.ShareRepo.RecordView(r.Context(), link.ID, middleware.GetClientIP(r), userAgent); err != nil {
+		utils.WriteInternalServerError(w, "Failed to load document")
+		return false
+	}
+	return true
+}
+
+// buildDocument loads the invoice or statement a share link points to, without internal notes or contacts
+func (h *ShareLinkHandler) buildDocument(ctx context.Context, link *data.ShareLink) (*PublicDocument, error) {
+	var incomes []*data.Income
+	switch link.Kind {
+	case data.ShareInvoice:
+		if link.IncomeID == nil {
+			return nil, gorm.ErrRecordNotFound
+		}
+		income, err := h.IncomeRepo.GetOne(ctx, *link.IncomeID, link.UserID)
+		if err != nil {
+			return nil, err
+		}
+		incomes = []*data.Income{income}
+	case data.ShareStatement:
+		if link.CustomerName == nil {
+			return nil, gorm.ErrRecordNotFound
+		}
+		var err error
+		incomes, err = h.IncomeRepo.GetByCustomer(ctx, link.UserID, *link.CustomerName)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, gorm.ErrRecordNotFound
+	}
+	if len(incomes) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	seller, err := h.UserRepo.GetOne(ctx, link.UserID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := h.SettingsRepo.GetByUserID(ctx, link.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	document := &PublicDocument{
+		Kind:         link.Kind,
+		Seller:       seller.Name,
+		CustomerName: incomes[0].CustomerName,
+		Currency:     settings.DefaultCurrency,
+		Lines:        make([]PublicInvoiceLine, 0, len(incomes)),
+		ConfirmedAt:  link.ConfirmedAt,
+		ConfirmedBy:  link.ConfirmedBy,
+		GeneratedAt:  time.Now(),
+	}
+	for _, income := range incomes {
+		description := string(income.MineralType)
+		if income.ItemName != nil && *income.ItemName != "" {
+			description = *income.ItemName
+		}
+		document.Lines = append(document.Lines, PublicInvoiceLine{
+			InvoiceNumber: income.InvoiceNumber,
+			Date:          income.Date.Format("2006-01-02"),
+			Description:   description,
+			Quantity:      income.Quantity,
+			Unit:          income.Unit,
+			PricePerUnit:  income.PricePerUnit,
+			TotalAmount:   income.TotalAmount,
+			AmountPaid:    income.AmountPaid,
+			AmountDue:     income.AmountDue,
+			PaymentStatus: income.PaymentStatus,
+		})
+		if h.SealRepo != nil {
+			if seal, err := h.SealRepo.GetByIncome(ctx, income.ID, link.UserID); err == nil {
+				document.Lines[len(document.Lines)-1].Seal = &PublicSeal{Digest: seal.Digest, VerifyURL: sealVerifyURL(h.BaseURL, seal.ID)}
+			}
+		}
+		document.TotalAmount += income.TotalAmount
+		document.AmountPaid += income.AmountPaid
+		document.AmountDue += income.AmountDue
+	}
+
+	return document, nil
+}
+
+// shareLinkExpiry returns the expiry time for a share link valid for the given number of days
+// (default 30, 0 for no expiry), writing the error response and returning false when invalid
+func shareLinkExpiry(w http.ResponseWriter, days *int) (*time.Time, bool) {
+	n := defaultShareLinkDays
+	if days != nil {
+		n = *days
+	}
+	if n < 0 || n > 365 {
+		utils.WriteValidationError(w, "Expiry must be between 0 and 365 days")
+		return nil, false
+	}
+	if n == 0 {
+		return nil, true
+	}
+	expiresAt := time.Now().AddDate(0, 0, n)
+	return &expiresAt, true
+}
@@ -0,0 +1,304 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// ShiftHandoverHandler handles the handing over of a till or station's cash between clerks
+type ShiftHandoverHandler struct {
+	HandoverRepo     data.ShiftHandoverInterface
+	CashDayRepo      data.CashDayInterface
+	TillRepo         data.TillInterface
+	MineSiteRepo     data.MineSiteInterface
+	OrganizationRepo data.OrganizationInterface
+	AuditRepo        data.AuditInterface
+}
+
+// NewShiftHandoverHandler creates a new ShiftHandoverHandler
+func NewShiftHandoverHandler(handoverRepo data.ShiftHandoverInterface, cashDayRepo data.CashDayInterface, tillRepo data.TillInterface,
+	mineSiteRepo data.MineSiteInterface, organizationRepo data.OrganizationInterface, auditRepo data.AuditInterface) *ShiftHandoverHandler {
+	return &ShiftHandoverHandler{
+		HandoverRepo:     handoverRepo,
+		CashDayRepo:      cashDayRepo,
+		TillRepo:         tillRepo,
+		MineSiteRepo:     mineSiteRepo,
+		OrganizationRepo: organizationRepo,
+		AuditRepo:        auditRepo,
+	}
+}
+
+// ShiftHandoverRequest represents a request to hand a till or station's cash over to another clerk
+type ShiftHandoverRequest struct {
+	TillID          *uint    `json:"till_id,omitempty"`
+	MineSiteID      *uint    `json:"mine_site_id,omitempty"` // when handing over a station's cash not in a till
+	IncomingClerkID uint     `json:"incoming_clerk_id"`
+	CountedCash     *float64 `json:"counted_cash"`
+	PendingIssues   *string  `json:"pending_issues,omitempty"`
+	Notes           *string  `json:"notes,omitempty"`
+}
+
+// SignOffShiftHandoverRequest represents the incoming clerk's sign-off of a shift handover
+type SignOffShiftHandoverRequest struct {
+	Notes *string `json:"notes,omitempty"`
+}
+
+// GetShiftHandovers retrieves the shift handovers of the authenticated user, optionally of a
+// station, of a till and by status (pending or signed_off)
+func (h *ShiftHandoverHandler) GetShiftHandovers(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	siteID, ok := parseSiteFilter(w, r)
+	if !ok {
+		return
+	}
+	var tillID *uint
+	if tillStr := r.URL.Query().Get("till_id"); tillStr != "" {
+		id, err := strconv.ParseUint(tillStr, 10, 32)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid till ID")
+			return
+		}
+		till := uint(id)
+		tillID = &till
+	}
+	status := data.ShiftHandoverStatus(r.URL.Query().Get("status"))
+	if status != "" && status != data.ShiftHandoverPending && status != data.ShiftHandoverSignedOff {
+		utils.WriteValidationError(w, "Status must be pending or signed_off")
+		return
+	}
+
+	handovers, err := h.HandoverRepo.GetAll(r.Context(), userID, siteID, tillID, status)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve shift handovers")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Shift handovers retrieved successfully", handovers)
+}
+
+// GetShiftHandover retrieves a shift handover
+func (h *ShiftHandoverHandler) GetShiftHandover(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	handover, ok := h.handover(w, r, userID)
+	if !ok {
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Shift handover retrieved successfully", handover)
+}
+
+// CreateShiftHandover hands the cash of a till, or of a station's cash not in a till, over to the
+// incoming clerk with the cash counted, pending issues and notes, signed by the acting member as
+// the outgoing clerk. The count is compared with the cash expected by the open cash day, if any.
+func (h *ShiftHandoverHandler) CreateShiftHandover(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req ShiftHandoverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if req.CountedCash == nil {
+		utils.WriteValidationError(w, "Counted cash is required")
+		return
+	}
+	if !utils.ValidateNonNegativeNumber(*req.CountedCash) {
+		utils.WriteValidationError(w, "Counted cash cannot be negative")
+		return
+	}
+	if req.IncomingClerkID == 0 {
+		utils.WriteValidationError(w, "Incoming clerk is required")
+		return
+	}
+	actorID := middleware.GetActorIDFromRequest(r)
+	if req.IncomingClerkID == actorID {
+		utils.WriteValidationError(w, "The incoming clerk must be someone else")
+		return
+	}
+	if !checkClerk(r.Context(), w, h.OrganizationRepo, userID, req.IncomingClerkID) {
+		return
+	}
+
+	handover := &data.ShiftHandover{
+		MineSiteID:      req.MineSiteID,
+		OutgoingClerkID: actorID,
+		IncomingClerkID: req.IncomingClerkID,
+		CountedCash:     *req.CountedCash,
+		PendingIssues:   optionalString(req.PendingIssues),
+		Notes:           optionalString(req.Notes),
+		HandedOverAt:    time.Now(),
+		UserID:          userID,
+	}
+	if req.TillID != nil {
+		// Members can only hand over the tills they may use
+		tillID, ok := resolveTill(w, r, h.TillRepo, userID, req.TillID)
+		if !ok {
+			return
+		}
+		till, err := h.TillRepo.GetOne(r.Context(), *tillID, userID)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to retrieve till")
+			return
+		}
+		handover.TillID = tillID
+		handover.MineSiteID = till.MineSiteID
+	} else if !checkMineSite(r.Context(), w, h.MineSiteRepo, userID, req.MineSiteID) {
+		return
+	}
+
+	day, err := h.openCashDay(r.Context(), userID, handover)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve cash day")
+		return
+	}
+	if day != nil {
+		expected := day.ExpectedCash
+		difference := math.Round((handover.CountedCash-expected)*100) / 100
+		handover.CashDayID = &day.ID
+		handover.ExpectedCash = &expected
+		handover.Difference = &difference
+	}
+
+	if _, err := h.HandoverRepo.Insert(r.Context(), handover); err != nil {
+		if errors.Is(err, data.ErrShiftHandoverPending) {
+			utils.WriteErrorResponse(w, "The last handover of this till or station hasn't been signed off yet", http.StatusConflict)
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to record shift handover")
+		return
+	}
+
+	details := fmt.Sprintf("Handed over %.2f counted to user %d", handover.CountedCash, handover.IncomingClerkID)
+	if handover.Difference != nil {
+		details += fmt.Sprintf(", %.2f against the cash expected", *handover.Difference)
+	}
+	recordAudit(h.AuditRepo, r, &data.AuditLog{
+		Action:     data.AuditShiftHandedOver,
+		Resource:   "shift_handover",
+		ResourceID: &handover.ID,
+		Details:    &details,
+	})
+
+	utils.WriteSuccessResponse(w, "Shift handover recorded successfully", handover)
+}
+
+// SignOffShiftHandover records the incoming clerk's sign-off of a shift handover. A handed over
+// till is reassigned to them.
+func (h *ShiftHandoverHandler) SignOffShiftHandover(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	handover, ok := h.handover(w, r, userID)
+	if !ok {
+		return
+	}
+	if handover.IncomingClerkID != middleware.GetActorIDFromRequest(r) {
+		utils.WriteForbiddenError(w, "Only the incoming clerk can sign off the handover")
+		return
+	}
+	if handover.Status != data.ShiftHandoverPending {
+		utils.WriteErrorResponse(w, "The handover has already been signed off", http.StatusConflict)
+		return
+	}
+
+	var req SignOffShiftHandoverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	signedOffAt := time.Now()
+	handover.SignedOffAt = &signedOffAt
+	handover.SignOffNotes = optionalString(req.Notes)
+	if err := h.HandoverRepo.SignOff(r.Context(), handover); err != nil {
+		if errors.Is(err, data.ErrShiftHandoverSignedOff) {
+			utils.WriteErrorResponse(w, "The handover has already been signed off", http.StatusConflict)
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to sign off shift handover")
+		return
+	}
+
+	details := fmt.Sprintf("Signed off the handover of %.2f from user %d", handover.CountedCash, handover.OutgoingClerkID)
+	recordAudit(h.AuditRepo, r, &data.AuditLog{
+		Action:     data.AuditShiftSignedOff,
+		Resource:   "shift_handover",
+		ResourceID: &handover.ID,
+		Details:    &details,
+	})
+
+	utils.WriteSuccessResponse(w, "Shift handover signed off successfully", handover)
+}
+
+// openCashDay returns the open cash day of the till or station handed over, with the cash it
+// expects so far, or nil when none is open
+func (h *ShiftHandoverHandler) openCashDay(ctx context.Context, userID uint, handover *data.ShiftHandover) (*data.CashDay, error) {
+	days, err := h.CashDayRepo.GetAll(ctx, userID, handover.MineSiteID, handover.TillID, data.CashDayOpen)
+	if err != nil {
+		return nil, err
+	}
+	for _, day := range days {
+		if handover.TillID == nil && (day.TillID != nil || !sameSite(day.MineSiteID, handover.MineSiteID)) {
+			continue
+		}
+		return h.CashDayRepo.GetOne(ctx, day.ID, userID)
+	}
+	return nil, nil
+}
+
+// handover returns the shift handover of a request, writing the error response and returning
+// false when it doesn't exist
+func (h *ShiftHandoverHandler) handover(w http.ResponseWriter, r *http.Request, userID uint) (*data.ShiftHandover, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid shift handover ID")
+		return nil, false
+	}
+	handover, err := h.HandoverRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Shift handover not found")
+			return nil, false
+		}
+		utils.WriteInternalServerError(w, "Failed to retrieve shift handover")
+		return nil, false
+	}
+	return handover, true
+}
+
+// sameSite reports whether two optional mine site IDs are the same, both nil included
+func sameSite(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
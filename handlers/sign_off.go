@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// signOffStatus returns the sign-off status of an expense of an amount: pending when it is above
+// the organization's sign-off amount, or nil when it isn't or there is no sign-off amount. It
+// writes the error response and returns false when the settings can't be read.
+func signOffStatus(ctx context.Context, w http.ResponseWriter, settingsRepo data.SettingsInterface, userID uint, amount float64) (*data.SignOffStatus, bool) {
+	if settingsRepo == nil {
+		return nil, true
+	}
+	settings, err := settingsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve settings")
+		return nil, false
+	}
+	if settings.SignOffAmount <= 0 || amount <= settings.SignOffAmount {
+		return nil, true
+	}
+	status := data.SignOffPending
+	return &status, true
+}
+
+// awaitingSignOffMessage explains why an expense awaiting sign-off can't be paid
+var awaitingSignOffMessage = fmt.Sprintf("Expenses above the sign-off amount must be signed off by %d approvers before they are paid", data.RequiredSignOffs)
+
+// GetAwaitingSignOff retrieves the expenses above the sign-off amount awaiting sign-off
+func (h *ExpenseHandler) GetAwaitingSignOff(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	expenses, err := h.ExpenseRepo.GetAwaitingSignOff(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve expenses awaiting sign-off")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Expenses awaiting sign-off retrieved successfully", expenses)
+}
+
+// GetExpenseSignOffs retrieves the sign-offs of an expense
+func (h *ExpenseHandler) GetExpenseSignOffs(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid expense ID")
+		return
+	}
+	if _, err := h.ExpenseRepo.GetOne(r.Context(), uint(id), userID); err != nil {
+		utils.WriteNotFoundError(w, "Expense record not found")
+		return
+	}
+
+	signOffs, err := h.ExpenseRepo.GetSignOffs(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve sign-offs")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Sign-offs retrieved successfully", signOffs)
+}
+
+// SignOffExpense signs off an expense above the sign-off amount as the acting approver. It can
+// be paid once two distinct approvers have signed it off.
+func (h *ExpenseHandler) SignOffExpense(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+	if !requirePermission(w, r, data.PermExpenseApprove, "You do not have permission to sign off expenses") {
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid expense ID")
+		return
+	}
+
+	expense, err := h.ExpenseRepo.SignOff(r.Context(), uint(id), userID, middleware.GetActorIDFromRequest(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.WriteNotFoundError(w, "Expense record not found")
+		case errors.Is(err, data.ErrNoSignOffPending):
+			utils.WriteValidationError(w, "Only expenses awaiting sign-off can be signed off")
+		case errors.Is(err, data.ErrAlreadySignedOff):
+			utils.WriteErrorResponse(w, "You have already signed off this expense", http.StatusConflict)
+		default:
+			utils.WriteInternalServerError(w, "Failed to sign off expense")
+		}
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Expense signed off successfully", expense)
+}
@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// StocktakeHandler handles stocktake/cycle count requests
+type StocktakeHandler struct {
+	StocktakeRepo data.StocktakeInterface
+}
+
+// NewStocktakeHandler creates a new StocktakeHandler
+func NewStocktakeHandler(stocktakeRepo data.StocktakeInterface) *StocktakeHandler {
+	return &StocktakeHandler{
+		StocktakeRepo: stocktakeRepo,
+	}
+}
+
+// CreateStocktakeRequest represents a request to start a stocktake session
+type CreateStocktakeRequest struct {
+	Name    string  `json:"name"`
+	Notes   *string `json:"notes,omitempty"`
+	ItemIDs []uint  `json:"item_ids,omitempty"` // Empty means all inventory items
+}
+
+// StocktakeCount represents the counted quantity of a single item
+type StocktakeCount struct {
+	InventoryItemID uint    `json:"inventory_item_id"`
+	CountedQuantity float64 `json:"counted_quantity"`
+}
+
+// RecordCountsRequest represents a request to record counted quantities
+type RecordCountsRequest struct {
+	Counts []StocktakeCount `json:"counts"`
+}
+
+// GetAllStocktakes retrieves all stocktake sessions for the authenticated user
+func (h *StocktakeHandler) GetAllStocktakes(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	stocktakes, err := h.StocktakeRepo.GetAll(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve stocktakes")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Stocktakes retrieved successfully", stocktakes)
+}
+
+// GetStocktake retrieves a specific stocktake session with its lines
+func (h *StocktakeHandler) GetStocktake(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid stocktake ID")
+		return
+	}
+
+	stocktake, err := h.StocktakeRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Stocktake not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Stocktake retrieved successfully", stocktake)
+}
+
+// CreateStocktake starts a stocktake session by snapshotting expected quantities
+func (h *StocktakeHandler) CreateStocktake(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req CreateStocktakeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	if !utils.ValidateRequired(req.Name) {
+		utils.WriteValidationError(w, "Name is required")
+		return
+	}
+
+	stocktake := &data.Stocktake{
+		Name:   req.Name,
+		Notes:  req.Notes,
+		UserID: userID,
+	}
+
+	_, err := h.StocktakeRepo.Create(r.Context(), stocktake, req.ItemIDs)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteValidationError(w, "No inventory items to count")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to create stocktake")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Stocktake created successfully", stocktake)
+}
+
+// RecordCounts records counted quantities for items in an open stocktake
+func (h *StocktakeHandler) RecordCounts(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid stocktake ID")
+		return
+	}
+
+	var req RecordCountsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	if len(req.Counts) == 0 {
+		utils.WriteValidationError(w, "At least one count is required")
+		return
+	}
+
+	counts := make(map[uint]float64, len(req.Counts))
+	for _, count := range req.Counts {
+		if !utils.ValidateNonNegativeNumber(count.CountedQuantity) {
+			utils.WriteValidationError(w, "Counted quantity cannot be negative")
+			return
+		}
+		counts[count.InventoryItemID] = count.CountedQuantity
+	}
+
+	err = h.StocktakeRepo.RecordCounts(r.Context(), uint(id), userID, counts)
+	if err != nil {
+		if errors.Is(err, data.ErrStocktakeNotOpen) {
+			utils.WriteValidationError(w, "Stocktake is no longer open")
+			return
+		}
+		if errors.Is(err, data.ErrStocktakeItemMissing) {
+			utils.WriteValidationError(w, "Counts can only be recorded for items in the stocktake")
+			return
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Stocktake not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to record counts")
+		return
+	}
+
+	stocktake, err := h.StocktakeRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve updated stocktake")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Counts recorded successfully", stocktake)
+}
+
+// GetVarianceReport retrieves the variance report of a stocktake
+func (h *StocktakeHandler) GetVarianceReport(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid stocktake ID")
+		return
+	}
+
+	report, err := h.StocktakeRepo.GetVarianceReport(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Stocktake not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Variance report retrieved successfully", report)
+}
+
+// ApproveStocktake posts the counted quantities as stock adjustments
+func (h *StocktakeHandler) ApproveStocktake(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid stocktake ID")
+		return
+	}
+
+	err = h.StocktakeRepo.Approve(r.Context(), uint(id), userID)
+	if err != nil {
+		if errors.Is(err, data.ErrStocktakeNotOpen) {
+			utils.WriteValidationError(w, "Stocktake is no longer open")
+			return
+		}
+		if errors.Is(err, data.ErrStocktakeIncomplete) {
+			utils.WriteValidationError(w, "All items must be counted before approval")
+			return
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Stocktake not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to approve stocktake")
+		return
+	}
+
+	report, err := h.StocktakeRepo.GetVarianceReport(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve variance report")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Stocktake approved successfully", report)
+}
+
+// CancelStocktake cancels an open stocktake
+func (h *StocktakeHandler) CancelStocktake(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid stocktake ID")
+		return
+	}
+
+	err = h.StocktakeRepo.Cancel(r.Context(), uint(id), userID)
+	if err != nil {
+		if errors.Is(err, data.ErrStocktakeNotOpen) {
+			utils.WriteValidationError(w, "Stocktake is no longer open")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to cancel stocktake")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Stocktake cancelled successfully", nil)
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mineral/data"
+	"mineral/pkg/utils"
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+// AttachmentQuotaWarningHeader is set on responses to uploads that took the organization's
+// attachment storage past its quota
+const AttachmentQuotaWarningHeader = "X-Attachment-Quota-Warning"
+
+// AttachmentQuota enforces the soft quota on the attachment storage of each organization's books.
+// The quota is the storage limit of the books' plan unless an admin set one for the organization.
+// Uploads may take storage past the quota by up to GracePercent of it, with a warning, so a
+// photo taken in the field isn't lost over a few megabytes; uploads beyond that are rejected.
+type AttachmentQuota struct {
+	EvidenceRepo   data.EvidenceInterface
+	AttachmentRepo data.AttachmentInterface
+	UsageRepo      data.UsageInterface
+	SettingsRepo   data.SettingsInterface
+
+	// DefaultPlan is the plan of books without an active subscription
+	DefaultPlan  data.Plan
+	GracePercent int
+}
+
+// NewAttachmentQuota creates a new AttachmentQuota
+func NewAttachmentQuota(evidenceRepo data.EvidenceInterface, attachmentRepo data.AttachmentInterface, usageRepo data.UsageInterface, settingsRepo data.SettingsInterface) *AttachmentQuota {
+	return &AttachmentQuota{
+		EvidenceRepo:   evidenceRepo,
+		AttachmentRepo: attachmentRepo,
+		UsageRepo:      usageRepo,
+		SettingsRepo:   settingsRepo,
+		DefaultPlan:    data.PlanUnlimited,
+		GracePercent:   10,
+	}
+}
+
+// Usage returns the attachment storage used by a user's books, photos and attached files
+// together, against their quota
+func (q *AttachmentQuota) Usage(ctx context.Context, userID uint) (*data.AttachmentStorage, error) {
+	storage, err := q.EvidenceRepo.GetStorage(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	files, err := q.AttachmentRepo.GetStorage(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	storage.UsedBytes += files.UsedBytes
+	storage.Files += files.Files
+
+	settings, err := q.SettingsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.AttachmentQuotaMB != nil {
+		storage.QuotaBytes = int64(*settings.AttachmentQuotaMB) << 20
+	} else {
+		plan, _, err := activePlan(ctx, q.UsageRepo, userID, q.DefaultPlan)
+		if err != nil {
+			return nil, err
+		}
+		storage.QuotaBytes = data.Plans[plan].StorageBytes
+	}
+
+	if storage.QuotaBytes > 0 {
+		storage.LimitBytes = storage.QuotaBytes + storage.QuotaBytes*int64(q.GracePercent)/100
+		storage.OverQuota = storage.UsedBytes > storage.QuotaBytes
+	}
+	return storage, nil
+}
+
+// allowUpload checks an upload of size bytes to a user's books against their quota. It writes
+// the error response and returns false when the upload would take storage past the limit, and
+// sets AttachmentQuotaWarningHeader when it takes storage past the quota. A nil quota allows
+// every upload.
+func (q *AttachmentQuota) allowUpload(ctx context.Context, w http.ResponseWriter, userID uint, size int64) bool {
+	if q == nil {
+		return true
+	}
+
+	storage, err := q.Usage(ctx, userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to check attachment storage")
+		return false
+	}
+	if storage.QuotaBytes == 0 {
+		return true
+	}
+
+	after := storage.UsedBytes + size
+	if after > storage.LimitBytes {
+		utils.WriteErrorResponseWithData(w,
+			fmt.Sprintf("This file (%s) would take attachment storage to %s, over this organization's %s quota. Delete old attachments or upgrade the plan to upload more",
+				formatBytes(size), formatBytes(after), formatBytes(storage.QuotaBytes)),
+			http.StatusPaymentRequired, storage)
+		return false
+	}
+	if after > storage.QuotaBytes {
+		w.Header().Set(AttachmentQuotaWarningHeader,
+			fmt.Sprintf("Attachment storage is at %s, over the %s quota", formatBytes(after), formatBytes(storage.QuotaBytes)))
+	}
+	return true
+}
+
+// formatBytes formats a size in MB, or KB when it is under a megabyte
+func formatBytes(size int64) string {
+	if size < 1<<20 {
+		return fmt.Sprintf("%.0f KB", float64(size)/(1<<10))
+	}
+	return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+}
+
+// AttachmentQuotaRequest represents a request to set an organization's attachment storage quota;
+// a null quota_mb goes back to the plan's storage limit
+type AttachmentQuotaRequest struct {
+	QuotaMB *int `json:"quota_mb"`
+}
+
+// SetAttachmentQuota sets the attachment storage quota of a user's books, overriding their plan
+func (h *UsageHandler) SetAttachmentQuota(w http.ResponseWriter, r *http.Request) {
+	userID, ok := parseUserIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	var req AttachmentQuotaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if req.QuotaMB != nil && *req.QuotaMB < 1 {
+		utils.WriteValidationError(w, "Quota must be at least 1 MB")
+		return
+	}
+
+	if _, err := h.UserRepo.GetOne(r.Context(), userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "User not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to update attachment quota")
+		return
+	}
+
+	settings, err := h.Quota.SettingsRepo.GetByUserID(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to update attachment quota")
+		return
+	}
+	settings.AttachmentQuotaMB = req.QuotaMB
+	if err := h.Quota.SettingsRepo.Save(r.Context(), settings); err != nil {
+		utils.WriteInternalServerError(w, "Failed to update attachment quota")
+		return
+	}
+
+	storage, err := h.Quota.Usage(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve attachment storage")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Attachment quota updated successfully", storage)
+}
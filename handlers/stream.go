@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	defaultStreamEventLimit = 100
+	maxStreamEventLimit     = 1000
+)
+
+// StreamHandler handles requests about the event streams of inventory and payment changes
+type StreamHandler struct {
+	StreamRepo data.StreamInterface
+}
+
+// NewStreamHandler creates a new StreamHandler
+func NewStreamHandler(streamRepo data.StreamInterface) *StreamHandler {
+	return &StreamHandler{StreamRepo: streamRepo}
+}
+
+// StreamEventsResponse is a page of stream events; the next page is requested with after set
+// to next_after
+type StreamEventsResponse struct {
+	Events    []*data.StreamEvent `json:"events"`
+	NextAfter uint                `json:"next_after"`
+}
+
+// GetEvents lists the events of the organization's books oldest first, optionally limited to a
+// stream (stream=inventory_item, income or expense) and a record (stream_id). Pages continue
+// after the event ID given as after.
+func (h *StreamHandler) GetEvents(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+	if !canViewStreams(r) {
+		utils.WriteForbiddenError(w, "You do not have permission to view event streams")
+		return
+	}
+
+	query := r.URL.Query()
+	stream := data.StreamType(query.Get("stream"))
+	if stream != "" && !validStream(stream) {
+		utils.WriteValidationError(w, "Stream must be inventory_item, income or expense")
+		return
+	}
+	var streamID uint64
+	if s := query.Get("stream_id"); s != "" {
+		var err error
+		if streamID, err = strconv.ParseUint(s, 10, 32); err != nil || stream == "" {
+			utils.WriteValidationError(w, "stream_id must be a record ID and requires stream")
+			return
+		}
+	}
+	var after uint64
+	if s := query.Get("after"); s != "" {
+		var err error
+		if after, err = strconv.ParseUint(s, 10, 32); err != nil {
+			utils.WriteValidationError(w, "Invalid after event ID")
+			return
+		}
+	}
+	limit := defaultStreamEventLimit
+	if s := query.Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > maxStreamEventLimit {
+			utils.WriteValidationError(w, "limit must be between 1 and 1000")
+			return
+		}
+		limit = n
+	}
+
+	events, err := h.StreamRepo.GetEvents(r.Context(), userID, stream, uint(streamID), uint(after), limit)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve events")
+		return
+	}
+
+	response := StreamEventsResponse{Events: events, NextAfter: uint(after)}
+	if len(events) > 0 {
+		response.NextAfter = events[len(events)-1].ID
+	}
+	utils.WriteSuccessResponse(w, "Events retrieved successfully", response)
+}
+
+// RebuildBalance replays the event stream of a record into its balance and compares it with the
+// record's current balance, e.g. to settle a dispute about a stock level or a payment
+func (h *StreamHandler) RebuildBalance(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+	if !canViewStreams(r) {
+		utils.WriteForbiddenError(w, "You do not have permission to view event streams")
+		return
+	}
+
+	stream := data.StreamType(chi.URLParam(r, "stream"))
+	if !validStream(stream) {
+		utils.WriteValidationError(w, "Stream must be inventory_item, income or expense")
+		return
+	}
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid record ID")
+		return
+	}
+
+	rebuilt, err := h.StreamRepo.Rebuild(r.Context(), userID, stream, uint(id))
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to rebuild balance")
+		return
+	}
+	if rebuilt.Version == 0 {
+		utils.WriteNotFoundError(w, "No events recorded for this record")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Balance rebuilt successfully", rebuilt)
+}
+
+// canViewStreams reports whether the request's member may read the event streams, which show
+// every change to the books like the audit log does
+func canViewStreams(r *http.Request) bool {
+	return middleware.HasPermission(r, string(data.PermAuditView))
+}
+
+func validStream(stream data.StreamType) bool {
+	return stream == data.StreamInventory || stream == data.StreamIncome || stream == data.StreamExpense
+}
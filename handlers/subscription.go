@@ -0,0 +1,414 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mineral/data"
+	"mineral/pkg/billing"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// subscriptionGrace is how long a subscription outlives its paid period when the payment
+// provider reports neither a renewal nor a cancellation
+const subscriptionGrace = 7 * 24 * time.Hour
+
+// paidPlans lists the plans organizations can subscribe to through the payment provider
+var paidPlans = []data.Plan{data.PlanPro}
+
+// SubscriptionHandler handles plan, subscription and feature flag requests
+type SubscriptionHandler struct {
+	UsageRepo   data.UsageInterface
+	FeatureRepo data.FeatureInterface
+	UserRepo    data.UserInterface
+	Billing     billing.Provider
+
+	// DefaultPlan is the plan of books without an active subscription
+	DefaultPlan data.Plan
+	// ReadOnlyAfterTrial makes books read-only once their trial ends, rather than moving them to
+	// the free plan
+	ReadOnlyAfterTrial bool
+}
+
+// NewSubscriptionHandler creates a new SubscriptionHandler
+func NewSubscriptionHandler(usageRepo data.UsageInterface, featureRepo data.FeatureInterface, userRepo data.UserInterface, provider billing.Provider) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		UsageRepo:   usageRepo,
+		FeatureRepo: featureRepo,
+		UserRepo:    userRepo,
+		Billing:     provider,
+		DefaultPlan: data.PlanUnlimited,
+	}
+}
+
+// CheckoutRequest represents a request to subscribe to a paid plan
+type CheckoutRequest struct {
+	Plan data.Plan `json:"plan"`
+}
+
+// CheckoutResponse represents the payment page that activates a subscription once paid
+type CheckoutResponse struct {
+	Plan        data.Plan `json:"plan"`
+	CheckoutURL string    `json:"checkout_url"`
+}
+
+// FeatureFlagRequest represents a request to enable or disable a feature regardless of plan
+type FeatureFlagRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// GetPlans returns the plans organizations can be on, with their limits and features
+func (h *SubscriptionHandler) GetPlans(w http.ResponseWriter, r *http.Request) {
+	plans := make([]*data.PlanInfo, 0, len(paidPlans)+1)
+	for _, plan := range append([]data.Plan{data.PlanFree}, paidPlans...) {
+		plans = append(plans, &data.PlanInfo{
+			Plan:     plan,
+			Limits:   data.Plans[plan],
+			Features: data.PlanFeatures[plan],
+		})
+	}
+
+	utils.WriteSuccessResponse(w, "Plans retrieved successfully", plans)
+}
+
+// GetSubscription returns the plan of the organization's books and the features it has
+func (h *SubscriptionHandler) GetSubscription(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	info, err := h.subscriptionInfo(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve subscription")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Subscription retrieved successfully", info)
+}
+
+// Checkout starts a subscription to a paid plan, returning the payment provider's checkout page.
+// The plan is activated when the provider reports the payment. Only owners can subscribe.
+func (h *SubscriptionHandler) Checkout(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+	if middleware.GetOrgRoleFromRequest(r) != string(data.OrgRoleOwner) {
+		utils.WriteForbiddenError(w, "Only owners can manage the subscription")
+		return
+	}
+
+	var req CheckoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if !paidPlan(req.Plan) {
+		utils.WriteValidationError(w, "Plan must be pro")
+		return
+	}
+
+	user, err := h.UserRepo.GetOne(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to start checkout")
+		return
+	}
+	checkoutURL, err := h.Billing.Checkout(string(req.Plan), strconv.FormatUint(uint64(userID), 10), user.Email)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to start checkout")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Checkout started successfully", &CheckoutResponse{
+		Plan:        req.Plan,
+		CheckoutURL: checkoutURL,
+	})
+}
+
+// BillingWebhook applies a subscription change reported by the payment provider
+func (h *SubscriptionHandler) BillingWebhook(w http.ResponseWriter, r *http.Request) {
+	event, err := h.Billing.ParseEvent(r)
+	if err != nil {
+		switch {
+		case errors.Is(err, billing.ErrNotConfigured):
+			utils.WriteErrorResponse(w, "Billing is not configured", http.StatusServiceUnavailable)
+		case errors.Is(err, billing.ErrInvalidSignature):
+			utils.WriteUnauthorizedError(w, "Invalid signature")
+		default:
+			utils.WriteValidationError(w, "Invalid event")
+		}
+		return
+	}
+
+	userID, err := strconv.ParseUint(event.Reference, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid reference")
+		return
+	}
+	existing, err := h.UsageRepo.GetSubscription(r.Context(), uint(userID))
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to update subscription")
+		return
+	}
+
+	var subscription *data.Subscription
+	switch event.Type {
+	case billing.SubscriptionActivated:
+		if !paidPlan(data.Plan(event.Plan)) {
+			utils.WriteValidationError(w, "Invalid plan")
+			return
+		}
+		subscription = &data.Subscription{
+			Plan:                   data.Plan(event.Plan),
+			Status:                 data.SubscriptionActive,
+			ProviderSubscriptionID: &event.SubscriptionID,
+			CurrentPeriodEnd:       event.CurrentPeriodEnd,
+			UserID:                 uint(userID),
+		}
+	case billing.PaymentFailed, billing.SubscriptionCanceled:
+		// Only changes to the subscription the provider activated apply
+		if existing == nil || existing.ProviderSubscriptionID == nil || *existing.ProviderSubscriptionID != event.SubscriptionID {
+			utils.WriteSuccessResponse(w, "Event ignored", nil)
+			return
+		}
+		subscription = existing
+		subscription.Status = data.SubscriptionPastDue
+		if event.Type == billing.SubscriptionCanceled {
+			subscription.Status = data.SubscriptionCanceled
+		}
+	default:
+		utils.WriteSuccessResponse(w, "Event ignored", nil)
+		return
+	}
+
+	if err := h.UsageRepo.SaveSubscription(r.Context(), subscription); err != nil {
+		utils.WriteInternalServerError(w, "Failed to update subscription")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Subscription updated successfully", subscription)
+}
+
+// GetFeatures returns the plan, features and feature flags of a user's books
+func (h *SubscriptionHandler) GetFeatures(w http.ResponseWriter, r *http.Request) {
+	userID, ok := parseUserIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	info, err := h.subscriptionInfo(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve features")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Features retrieved successfully", info)
+}
+
+// SetFeatureFlag enables or disables a feature for a user's books regardless of their plan
+func (h *SubscriptionHandler) SetFeatureFlag(w http.ResponseWriter, r *http.Request) {
+	actorID := middleware.GetActorIDFromRequest(r)
+	userID, ok := parseUserIDParam(w, r)
+	if !ok {
+		return
+	}
+	feature, ok := parseFeatureParam(w, r)
+	if !ok {
+		return
+	}
+
+	var req FeatureFlagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	if _, err := h.UserRepo.GetOne(r.Context(), userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "User not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to save feature flag")
+		return
+	}
+
+	flag := &data.FeatureFlag{
+		Feature:     feature,
+		Enabled:     req.Enabled,
+		UpdatedByID: &actorID,
+		UserID:      userID,
+	}
+	if err := h.FeatureRepo.SaveFlag(r.Context(), flag); err != nil {
+		utils.WriteInternalServerError(w, "Failed to save feature flag")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Feature flag saved successfully", flag)
+}
+
+// DeleteFeatureFlag removes a feature flag, returning the feature to what the plan includes
+func (h *SubscriptionHandler) DeleteFeatureFlag(w http.ResponseWriter, r *http.Request) {
+	userID, ok := parseUserIDParam(w, r)
+	if !ok {
+		return
+	}
+	feature, ok := parseFeatureParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.FeatureRepo.DeleteFlag(r.Context(), userID, feature); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Feature flag not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to delete feature flag")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Feature flag deleted successfully", nil)
+}
+
+// HasFeature reports whether the books of a user have a feature through a feature flag or their
+// plan. It is used by the feature middleware.
+func (h *SubscriptionHandler) HasFeature(ctx context.Context, userID uint, feature string) (bool, error) {
+	info, err := h.subscriptionInfo(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, enabled := range info.Features {
+		if enabled == data.Feature(feature) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// IsReadOnly reports whether the books of a user can only be viewed because their trial ended.
+// It is used by the read-only middleware.
+func (h *SubscriptionHandler) IsReadOnly(ctx context.Context, userID uint) (bool, error) {
+	if !h.ReadOnlyAfterTrial {
+		return false, nil
+	}
+	subscription, err := h.UsageRepo.GetSubscription(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return trialEnded(subscription), nil
+}
+
+// subscriptionInfo returns the plan of a user's books with the features enabled by the plan and
+// feature flags
+func (h *SubscriptionHandler) subscriptionInfo(ctx context.Context, userID uint) (*data.SubscriptionInfo, error) {
+	plan, subscription, err := activePlan(ctx, h.UsageRepo, userID, h.DefaultPlan)
+	if err != nil {
+		return nil, err
+	}
+	flags, err := h.FeatureRepo.GetFlags(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make(map[data.Feature]bool)
+	for _, feature := range data.PlanFeatures[plan] {
+		enabled[feature] = true
+	}
+	for _, flag := range flags {
+		enabled[flag.Feature] = flag.Enabled
+	}
+
+	info := &data.SubscriptionInfo{
+		Plan:         plan,
+		Subscription: subscription,
+		Limits:       data.Plans[plan],
+		Features:     []data.Feature{},
+		Flags:        flags,
+		ReadOnly:     h.ReadOnlyAfterTrial && trialEnded(subscription),
+	}
+	for _, feature := range data.Features {
+		if enabled[feature] {
+			info.Features = append(info.Features, feature)
+		}
+	}
+	return info, nil
+}
+
+// activePlan returns the plan of a user's books with their subscription. Books are on the default
+// plan without a subscription, or once it is canceled or lapsed past the grace period, and on the
+// free plan once their trial ends.
+func activePlan(ctx context.Context, usageRepo data.UsageInterface, userID uint, defaultPlan data.Plan) (data.Plan, *data.Subscription, error) {
+	subscription, err := usageRepo.GetSubscription(ctx, userID)
+	if err != nil {
+		return "", nil, err
+	}
+	return subscriptionPlan(subscription, defaultPlan), subscription, nil
+}
+
+// subscriptionPlan returns the plan a subscription puts books on
+func subscriptionPlan(subscription *data.Subscription, defaultPlan data.Plan) data.Plan {
+	if subscription == nil || subscription.Status == data.SubscriptionCanceled {
+		return defaultPlan
+	}
+	if trialEnded(subscription) {
+		return data.PlanFree
+	}
+	if subscription.CurrentPeriodEnd != nil && time.Since(*subscription.CurrentPeriodEnd) > subscriptionGrace {
+		return defaultPlan
+	}
+	return subscription.Plan
+}
+
+// trialEnded reports whether a subscription is a trial that ended, which the trial job may not
+// have marked as expired yet
+func trialEnded(subscription *data.Subscription) bool {
+	if subscription == nil {
+		return false
+	}
+	if subscription.Status == data.SubscriptionExpired {
+		return true
+	}
+	return subscription.Status == data.SubscriptionTrialing &&
+		subscription.TrialEndsAt != nil && !time.Now().Before(*subscription.TrialEndsAt)
+}
+
+// paidPlan reports whether organizations can subscribe to a plan through the payment provider
+func paidPlan(plan data.Plan) bool {
+	for _, paid := range paidPlans {
+		if plan == paid {
+			return true
+		}
+	}
+	return false
+}
+
+// parseUserIDParam parses the userId URL parameter, writing a validation error when invalid
+func parseUserIDParam(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	userID, err := strconv.ParseUint(chi.URLParam(r, "userId"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid user ID")
+		return 0, false
+	}
+	return uint(userID), true
+}
+
+// parseFeatureParam parses the feature URL parameter, writing a validation error when unknown
+func parseFeatureParam(w http.ResponseWriter, r *http.Request) (data.Feature, bool) {
+	feature := data.Feature(chi.URLParam(r, "feature"))
+	for _, known := range data.Features {
+		if feature == known {
+			return feature, true
+		}
+	}
+	utils.WriteValidationError(w, "Feature must be reports, sms, team or webhooks")
+	return "", false
+}
@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// SupplyPriceHandler handles the reference price list of common supplies a cooperative's admins
+// publish to its members
+type SupplyPriceHandler struct {
+	SupplyPriceRepo data.SupplyPriceInterface
+}
+
+// NewSupplyPriceHandler creates a new SupplyPriceHandler
+func NewSupplyPriceHandler(supplyPriceRepo data.SupplyPriceInterface) *SupplyPriceHandler {
+	return &SupplyPriceHandler{
+		SupplyPriceRepo: supplyPriceRepo,
+	}
+}
+
+// SupplyPriceRequest represents a create or update supply price request
+type SupplyPriceRequest struct {
+	Name          string  `json:"name"`
+	Category      string  `json:"category"`
+	Unit          string  `json:"unit"`
+	Price         float64 `json:"price"`          // per unit
+	EffectiveDate string  `json:"effective_date"` // defaults to today
+	Supplier      *string `json:"supplier,omitempty"`
+	Notes         *string `json:"notes,omitempty"`
+}
+
+// GetSupplyPrices retrieves the supply price list
+func (h *SupplyPriceHandler) GetSupplyPrices(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	prices, err := h.SupplyPriceRepo.GetAll(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve supply prices")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Supply prices retrieved successfully", prices)
+}
+
+// CreateSupplyPrice adds a supply to the price list
+func (h *SupplyPriceHandler) CreateSupplyPrice(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req SupplyPriceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	price := &data.SupplyPrice{UserID: userID}
+	if !applySupplyPriceRequest(w, &req, price) {
+		return
+	}
+
+	priceID, err := h.SupplyPriceRepo.Insert(r.Context(), price)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to create supply price")
+		return
+	}
+
+	price.ID = priceID
+	utils.WriteSuccessResponse(w, "Supply price created successfully", price)
+}
+
+// UpdateSupplyPrice updates a supply on the price list. Expenses already recorded keep the flags
+// they were given against the earlier price.
+func (h *SupplyPriceHandler) UpdateSupplyPrice(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid supply price ID")
+		return
+	}
+
+	var req SupplyPriceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	price, err := h.SupplyPriceRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Supply price not found")
+		return
+	}
+	if !applySupplyPriceRequest(w, &req, price) {
+		return
+	}
+
+	if err := h.SupplyPriceRepo.Update(r.Context(), price); err != nil {
+		utils.WriteInternalServerError(w, "Failed to update supply price")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Supply price updated successfully", price)
+}
+
+// DeleteSupplyPrice removes a supply from the price list
+func (h *SupplyPriceHandler) DeleteSupplyPrice(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid supply price ID")
+		return
+	}
+
+	if err := h.SupplyPriceRepo.Delete(r.Context(), uint(id), userID); err != nil {
+		utils.WriteInternalServerError(w, "Failed to delete supply price")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Supply price deleted successfully", nil)
+}
+
+// GetPriceVariances retrieves the expenses flagged as bought above the list price, largest
+// variance first
+func (h *SupplyPriceHandler) GetPriceVariances(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	expenses, err := h.SupplyPriceRepo.GetFlaggedExpenses(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve price variances")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Price variances retrieved successfully", expenses)
+}
+
+// applySupplyPriceRequest validates a create or update supply price request and applies it to a
+// supply price. It writes the error response and returns false when the request is invalid.
+func applySupplyPriceRequest(w http.ResponseWriter, req *SupplyPriceRequest, price *data.SupplyPrice) bool {
+	name := strings.TrimSpace(req.Name)
+	if !utils.ValidateRequired(name) {
+		utils.WriteValidationError(w, "Name is required")
+		return false
+	}
+	category := data.ExpenseCategory(req.Category)
+	valid := false
+	for _, known := range data.ExpenseCategories {
+		if category == known {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		utils.WriteValidationError(w, "Invalid expense category")
+		return false
+	}
+	unit := strings.TrimSpace(req.Unit)
+	if !utils.ValidateRequired(unit) {
+		utils.WriteValidationError(w, "Unit is required")
+		return false
+	}
+	if !utils.ValidatePositiveNumber(req.Price) {
+		utils.WriteValidationError(w, "Price must be positive")
+		return false
+	}
+	date := time.Now().Truncate(24 * time.Hour)
+	if req.EffectiveDate != "" {
+		parsed, err := time.Parse("2006-01-02", req.EffectiveDate)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid date format. Use YYYY-MM-DD")
+			return false
+		}
+		date = parsed
+	}
+
+	price.Name = name
+	price.Category = category
+	price.Unit = unit
+	price.Price = req.Price
+	price.EffectiveDate = date
+	price.Supplier = req.Supplier
+	price.Notes = req.Notes
+	return true
+}
+
+// priceVariance checks the unit price of a supply bought from the price list against its list
+// price. It returns the percent it is above the list price when that is more than the variance
+// set in settings, or nil when it isn't or no supply is set. It writes the error response and
+// returns false when the supply or the quantity is invalid.
+func priceVariance(ctx context.Context, w http.ResponseWriter, supplyPriceRepo data.SupplyPriceInterface, settingsRepo data.SettingsInterface, userID uint, supplyPriceID *uint, quantity *float64, amount float64) (*float64, bool) {
+	if supplyPriceID == nil {
+		if quantity != nil && !utils.ValidatePositiveNumber(*quantity) {
+			utils.WriteValidationError(w, "Quantity must be positive")
+			return nil, false
+		}
+		return nil, true
+	}
+	if supplyPriceRepo == nil {
+		utils.WriteValidationError(w, "Supply price list is not available")
+		return nil, false
+	}
+	if quantity == nil || !utils.ValidatePositiveNumber(*quantity) {
+		utils.WriteValidationError(w, "Quantity must be positive for supplies on the price list")
+		return nil, false
+	}
+	price, err := supplyPriceRepo.GetOne(ctx, *supplyPriceID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteValidationError(w, "Supply price not found")
+			return nil, false
+		}
+		utils.WriteInternalServerError(w, "Failed to check supply price")
+		return nil, false
+	}
+	if settingsRepo == nil {
+		return nil, true
+	}
+	settings, err := settingsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve settings")
+		return nil, false
+	}
+
+	variance := math.Round(((amount / *quantity)-price.Price)/price.Price*1000) / 10
+	if settings.PriceVariancePercent <= 0 || variance <= settings.PriceVariancePercent {
+		return nil, true
+	}
+	return &variance, true
+}
+
+// auditPriceVariance records an expense flagged as bought above the list price in the audit log
+func auditPriceVariance(auditRepo data.AuditInterface, r *http.Request, expense *data.Expense) {
+	if auditRepo == nil || expense.PriceVariance == nil {
+		return
+	}
+	details := fmt.Sprintf("Bought %g at %.2f a unit, %.1f%% above the list price", *expense.Quantity, expense.Amount / *expense.Quantity, *expense.PriceVariance)
+	recordAudit(auditRepo, r, &data.AuditLog{
+		Action:     data.AuditPriceVariance,
+		Resource:   "expense",
+		ResourceID: &expense.ID,
+		Details:    &details,
+	})
+}
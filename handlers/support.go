@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// Support ticket limits
+const (
+	maxSupportSubject    = 200
+	maxSupportMessage    = 5000
+	maxSupportRequestIDs = 20
+	maxRequestIDLength   = 64
+	recentSupportTickets = 100
+)
+
+// SupportHandler handles support ticket requests
+type SupportHandler struct {
+	SupportRepo  data.SupportInterface
+	UserRepo     data.UserInterface
+	DeliveryRepo data.DeliveryInterface
+	JobRepo      data.JobInterface
+
+	// SupportEmail receives new tickets; tickets are only stored when it is empty
+	SupportEmail string
+}
+
+// NewSupportHandler creates a new SupportHandler
+func NewSupportHandler(supportRepo data.SupportInterface, userRepo data.UserInterface, deliveryRepo data.DeliveryInterface, jobRepo data.JobInterface) *SupportHandler {
+	return &SupportHandler{
+		SupportRepo:  supportRepo,
+		UserRepo:     userRepo,
+		DeliveryRepo: deliveryRepo,
+		JobRepo:      jobRepo,
+	}
+}
+
+// SupportTicketRequest represents a request to raise a support ticket
+type SupportTicketRequest struct {
+	Subject     string                   `json:"subject"`
+	Message     string                   `json:"message"`
+	Diagnostics *data.SupportDiagnostics `json:"diagnostics,omitempty"`
+}
+
+// CreateTicket raises a support ticket with an optional diagnostic bundle and forwards it to the
+// support email
+func (h *SupportHandler) CreateTicket(w http.ResponseWriter, r *http.Request) {
+	actorID := middleware.GetActorIDFromRequest(r)
+	if actorID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req SupportTicketRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	req.Subject = strings.TrimSpace(req.Subject)
+	req.Message = strings.TrimSpace(req.Message)
+	if !utils.ValidateRequired(req.Subject) || len(req.Subject) > maxSupportSubject {
+		utils.WriteValidationError(w, fmt.Sprintf("Subject is required and must be at most %d characters", maxSupportSubject))
+		return
+	}
+	if !utils.ValidateRequired(req.Message) || len(req.Message) > maxSupportMessage {
+		utils.WriteValidationError(w, fmt.Sprintf("Message is required and must be at most %d characters", maxSupportMessage))
+		return
+	}
+	if req.Diagnostics != nil {
+		if len(req.Diagnostics.RequestIDs) > maxSupportRequestIDs {
+			utils.WriteValidationError(w, fmt.Sprintf("At most %d request IDs can be attached", maxSupportRequestIDs))
+			return
+		}
+		for _, id := range req.Diagnostics.RequestIDs {
+			if len(id) > maxRequestIDLength {
+				utils.WriteValidationError(w, "Invalid request ID")
+				return
+			}
+		}
+		if len(req.Diagnostics.AppVersion) > 50 || len(req.Diagnostics.Platform) > 100 {
+			utils.WriteValidationError(w, "App version or platform is too long")
+			return
+		}
+	}
+
+	user, err := h.UserRepo.GetOne(r.Context(), actorID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to create support ticket")
+		return
+	}
+
+	ticket := &data.SupportTicket{
+		Subject:     req.Subject,
+		Message:     req.Message,
+		Status:      data.SupportTicketOpen,
+		Diagnostics: req.Diagnostics,
+		BooksUserID: middleware.GetUserIDFromRequest(r),
+		UserID:      actorID,
+	}
+	if _, err := h.SupportRepo.Insert(r.Context(), ticket); err != nil {
+		utils.WriteInternalServerError(w, "Failed to create support ticket")
+		return
+	}
+
+	if h.SupportEmail != "" {
+		if err := h.forward(r.Context(), ticket, user); err != nil {
+			// The ticket is stored, so support can still find it
+			log.Printf("Failed to forward support ticket %d: %v", ticket.ID, err)
+		}
+	}
+
+	utils.WriteSuccessResponse(w, "Support ticket created successfully", ticket)
+}
+
+// GetTickets returns the support tickets the acting user raised
+func (h *SupportHandler) GetTickets(w http.ResponseWriter, r *http.Request) {
+	actorID := middleware.GetActorIDFromRequest(r)
+	if actorID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	tickets, err := h.SupportRepo.GetAll(r.Context(), actorID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve support tickets")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Support tickets retrieved successfully", tickets)
+}
+
+// GetTicket returns a support ticket the acting user raised
+func (h *SupportHandler) GetTicket(w http.ResponseWriter, r *http.Request) {
+	actorID := middleware.GetActorIDFromRequest(r)
+	if actorID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid ticket ID")
+		return
+	}
+
+	ticket, err := h.SupportRepo.GetOne(r.Context(), uint(id), actorID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Support ticket not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Support ticket retrieved successfully", ticket)
+}
+
+// GetAllTickets returns the most recent support tickets of all users, optionally with a status
+func (h *SupportHandler) GetAllTickets(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	if status != "" && status != string(data.SupportTicketOpen) && status != string(data.SupportTicketResolved) {
+		utils.WriteValidationError(w, "Status must be open or resolved")
+		return
+	}
+
+	tickets, err := h.SupportRepo.GetRecent(r.Context(), status, recentSupportTickets)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve support tickets")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Support tickets retrieved successfully", tickets)
+}
+
+// ResolveTicket marks a support ticket as resolved
+func (h *SupportHandler) ResolveTicket(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid ticket ID")
+		return
+	}
+
+	ticket, err := h.SupportRepo.Resolve(r.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Support ticket not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to resolve support ticket")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Support ticket resolved successfully", ticket)
+}
+
+// forward queues an email of a ticket to the support address
+func (h *SupportHandler) forward(ctx context.Context, ticket *data.SupportTicket, user *data.User) error {
+	deliveryID, err := h.DeliveryRepo.Insert(ctx, &data.MessageDelivery{
+		Purpose:   "support_ticket",
+		Recipient: h.SupportEmail,
+		UserID:    &ticket.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = h.JobRepo.Enqueue(ctx, data.JobTypeSendMessage, data.SendMessagePayload{
+		DeliveryID: deliveryID,
+		Channel:    data.DeliveryEmail,
+		To:         h.SupportEmail,
+		Subject:    fmt.Sprintf("[Ticket #%d] %s", ticket.ID, ticket.Subject),
+		Body:       supportEmailBody(ticket, user),
+	})
+	if err != nil {
+		return err
+	}
+	return h.SupportRepo.SetDelivery(ctx, ticket.ID, deliveryID)
+}
+
+// supportEmailBody renders a ticket with the contact details of the user who raised it
+func supportEmailBody(ticket *data.SupportTicket, user *data.User) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Ticket #%d from %s <%s>\n", ticket.ID, user.Name, user.Email)
+	if user.Phone != nil && *user.Phone != "" {
+		fmt.Fprintf(&b, "Phone: %s\n", *user.Phone)
+	}
+	fmt.Fprintf(&b, "User ID: %d, books user ID: %d\n\n", ticket.UserID, ticket.BooksUserID)
+	b.WriteString(ticket.Message)
+	b.WriteString("\n")
+
+	if d := ticket.Diagnostics; d != nil {
+		b.WriteString("\nDiagnostics\n")
+		if d.AppVersion != "" {
+			fmt.Fprintf(&b, "App version: %s\n", d.AppVersion)
+		}
+		if d.Platform != "" {
+			fmt.Fprintf(&b, "Platform: %s\n", d.Platform)
+		}
+		if len(d.RequestIDs) > 0 {
+			fmt.Fprintf(&b, "Recent request IDs: %s\n", strings.Join(d.RequestIDs, ", "))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,325 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// TaskHandler handles task requests
+type TaskHandler struct {
+	TaskRepo         data.TaskInterface
+	OrganizationRepo data.OrganizationInterface
+}
+
+// NewTaskHandler creates a new TaskHandler
+func NewTaskHandler(taskRepo data.TaskInterface, organizationRepo data.OrganizationInterface) *TaskHandler {
+	return &TaskHandler{
+		TaskRepo:         taskRepo,
+		OrganizationRepo: organizationRepo,
+	}
+}
+
+// TaskRequest represents a create or update task request
+type TaskRequest struct {
+	Title       string  `json:"title"`
+	Description *string `json:"description,omitempty"`
+	DueDate     *string `json:"due_date,omitempty"`
+	AssigneeID  *uint   `json:"assignee_id,omitempty"` // the owner or a member of the organization
+	LinkedType  *string `json:"linked_type,omitempty"`
+	LinkedID    *uint   `json:"linked_id,omitempty"`
+}
+
+// GetAllTasks returns tasks, optionally filtered by status, assignee, overdue or linked record
+func (h *TaskHandler) GetAllTasks(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	query := r.URL.Query()
+	filter := data.TaskFilter{
+		Status:     data.TaskStatus(query.Get("status")),
+		Overdue:    query.Get("overdue") == "true",
+		LinkedType: data.TaskLinkType(query.Get("linked_type")),
+	}
+	if filter.Status != "" && filter.Status != data.TaskOpen && filter.Status != data.TaskDone {
+		utils.WriteValidationError(w, "Status must be open or done")
+		return
+	}
+	if assignee := query.Get("assignee"); assignee != "" {
+		if assignee == "me" {
+			filter.AssigneeID = middleware.GetActorIDFromRequest(r)
+		} else {
+			id, err := strconv.ParseUint(assignee, 10, 32)
+			if err != nil {
+				utils.WriteValidationError(w, "Invalid assignee")
+				return
+			}
+			filter.AssigneeID = uint(id)
+		}
+	}
+	if linkedID := query.Get("linked_id"); linkedID != "" {
+		id, err := strconv.ParseUint(linkedID, 10, 32)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid linked ID")
+			return
+		}
+		filter.LinkedID = uint(id)
+	}
+
+	tasks, err := h.TaskRepo.GetAll(r.Context(), userID, filter)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve tasks")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Tasks retrieved successfully", tasks)
+}
+
+// GetTask returns a specific task
+func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid task ID")
+		return
+	}
+
+	task, err := h.TaskRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Task not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Task retrieved successfully", task)
+}
+
+// CreateTask creates a new task
+func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req TaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	actorID := middleware.GetActorIDFromRequest(r)
+	task := &data.Task{
+		Status:      data.TaskOpen,
+		CreatedByID: &actorID,
+		UserID:      userID,
+	}
+	if !h.applyTaskRequest(r.Context(), w, task, &req) {
+		return
+	}
+
+	if _, err := h.TaskRepo.Insert(r.Context(), task); err != nil {
+		utils.WriteInternalServerError(w, "Failed to create task")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Task created successfully", task)
+}
+
+// UpdateTask updates an existing task
+func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid task ID")
+		return
+	}
+
+	var req TaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	task, err := h.TaskRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Task not found")
+		return
+	}
+	if !h.applyTaskRequest(r.Context(), w, task, &req) {
+		return
+	}
+
+	if err := h.TaskRepo.Update(r.Context(), task); err != nil {
+		utils.WriteInternalServerError(w, "Failed to update task")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Task updated successfully", task)
+}
+
+// DeleteTask deletes a task
+func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid task ID")
+		return
+	}
+
+	if err := h.TaskRepo.Delete(r.Context(), uint(id), userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Task not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to delete task")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Task deleted successfully", nil)
+}
+
+// CompleteTask marks a task as done
+func (h *TaskHandler) CompleteTask(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid task ID")
+		return
+	}
+
+	if err := h.TaskRepo.Complete(r.Context(), uint(id), userID, middleware.GetActorIDFromRequest(r)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Open task not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to complete task")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Task completed successfully", nil)
+}
+
+// ReopenTask marks a done task as open again
+func (h *TaskHandler) ReopenTask(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid task ID")
+		return
+	}
+
+	if err := h.TaskRepo.Reopen(r.Context(), uint(id), userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Completed task not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to reopen task")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Task reopened successfully", nil)
+}
+
+// applyTaskRequest validates a task request and applies it to the task, writing the error
+// response and returning false when invalid
+func (h *TaskHandler) applyTaskRequest(ctx context.Context, w http.ResponseWriter, task *data.Task, req *TaskRequest) bool {
+	title := strings.TrimSpace(req.Title)
+	if !utils.ValidateRequired(title) {
+		utils.WriteValidationError(w, "Title is required")
+		return false
+	}
+
+	var dueDate *time.Time
+	if req.DueDate != nil && *req.DueDate != "" {
+		date, err := time.Parse("2006-01-02", *req.DueDate)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid date format. Use YYYY-MM-DD")
+			return false
+		}
+		dueDate = &date
+	}
+
+	var linkedType *data.TaskLinkType
+	if req.LinkedType != nil && *req.LinkedType != "" {
+		lt := data.TaskLinkType(*req.LinkedType)
+		switch lt {
+		case data.TaskLinkIncome, data.TaskLinkExpense, data.TaskLinkInventory, data.TaskLinkVehicle,
+			data.TaskLinkEmployee, data.TaskLinkContractor, data.TaskLinkMineSite:
+		default:
+			utils.WriteValidationError(w, "Invalid linked record type")
+			return false
+		}
+		if req.LinkedID == nil || *req.LinkedID == 0 {
+			utils.WriteValidationError(w, "Linked record ID is required")
+			return false
+		}
+		linkedType = &lt
+	}
+
+	if req.AssigneeID != nil && *req.AssigneeID != task.UserID {
+		member, err := h.OrganizationRepo.HasMember(ctx, task.UserID, *req.AssigneeID)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to verify assignee")
+			return false
+		}
+		if !member {
+			utils.WriteValidationError(w, "Assignee must be a member of the organization")
+			return false
+		}
+	}
+
+	task.Title = title
+	task.Description = req.Description
+	task.DueDate = dueDate
+	task.AssigneeID = req.AssigneeID
+	task.Assignee = nil
+	task.LinkedType = linkedType
+	task.LinkedID = nil
+	if linkedType != nil {
+		task.LinkedID = req.LinkedID
+	}
+	return true
+}
@@ -0,0 +1,304 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// TillHandler handles the tills of buying stations and the clerks accountable for them
+type TillHandler struct {
+	TillRepo         data.TillInterface
+	MineSiteRepo     data.MineSiteInterface
+	OrganizationRepo data.OrganizationInterface
+}
+
+// NewTillHandler creates a new TillHandler
+func NewTillHandler(tillRepo data.TillInterface, mineSiteRepo data.MineSiteInterface, organizationRepo data.OrganizationInterface) *TillHandler {
+	return &TillHandler{
+		TillRepo:         tillRepo,
+		MineSiteRepo:     mineSiteRepo,
+		OrganizationRepo: organizationRepo,
+	}
+}
+
+// TillRequest represents a create or update till request
+type TillRequest struct {
+	Name       string `json:"name"`
+	MineSiteID *uint  `json:"mine_site_id,omitempty"`
+	ClerkID    *uint  `json:"clerk_id,omitempty"` // the owner or a member of the organization
+	Active     *bool  `json:"active,omitempty"`
+}
+
+// GetTills retrieves the tills of the authenticated user, optionally of a station
+func (h *TillHandler) GetTills(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	siteID, ok := parseSiteFilter(w, r)
+	if !ok {
+		return
+	}
+
+	tills, err := h.TillRepo.GetAll(r.Context(), userID, siteID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve tills")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Tills retrieved successfully", tills)
+}
+
+// GetTill retrieves a till
+func (h *TillHandler) GetTill(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	till, ok := h.till(w, r, userID)
+	if !ok {
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Till retrieved successfully", till)
+}
+
+// CreateTill creates a till, optionally assigned to a clerk
+func (h *TillHandler) CreateTill(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req TillRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	till := &data.Till{Active: true, UserID: userID}
+	if !h.applyRequest(r.Context(), w, userID, &req, till) {
+		return
+	}
+
+	if _, err := h.TillRepo.Insert(r.Context(), till); err != nil {
+		utils.WriteInternalServerError(w, "Failed to create till")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Till created successfully", till)
+}
+
+// UpdateTill updates a till, reassigning it to another clerk when clerk_id changes. Cash days
+// already opened keep the clerk they were opened with.
+func (h *TillHandler) UpdateTill(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	till, ok := h.till(w, r, userID)
+	if !ok {
+		return
+	}
+
+	var req TillRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if !h.applyRequest(r.Context(), w, userID, &req, till) {
+		return
+	}
+
+	if err := h.TillRepo.Update(r.Context(), till); err != nil {
+		utils.WriteInternalServerError(w, "Failed to update till")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Till updated successfully", till)
+}
+
+// DeleteTill deletes a till without an open cash day
+func (h *TillHandler) DeleteTill(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	till, ok := h.till(w, r, userID)
+	if !ok {
+		return
+	}
+
+	if err := h.TillRepo.Delete(r.Context(), till.ID, userID); err != nil {
+		if errors.Is(err, data.ErrTillDayOpen) {
+			utils.WriteErrorResponse(w, "The till has an open cash day; close it first", http.StatusConflict)
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to delete till")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Till deleted successfully", nil)
+}
+
+// GetTillVariances reports the cash variances of each till and each clerk from the till cash
+// days closed between start_date and end_date, the last 30 days by default, optionally of a
+// station
+func (h *TillHandler) GetTillVariances(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	siteID, ok := parseSiteFilter(w, r)
+	if !ok {
+		return
+	}
+	start, end, ok := parseReportPeriod(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.TillRepo.GetVarianceReport(r.Context(), userID, siteID, start, end)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve till variances")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Till variances retrieved successfully", report)
+}
+
+// applyRequest validates a till request and applies it to till, writing the error response and
+// returning false when it is invalid
+func (h *TillHandler) applyRequest(ctx context.Context, w http.ResponseWriter, userID uint, req *TillRequest, till *data.Till) bool {
+	name := strings.TrimSpace(req.Name)
+	if !utils.ValidateRequired(name) {
+		utils.WriteValidationError(w, "Name is required")
+		return false
+	}
+	if len(name) > 100 {
+		utils.WriteValidationError(w, "Name must be at most 100 characters")
+		return false
+	}
+
+	// Tills can only be placed at the user's own buying stations
+	if !checkMineSite(ctx, w, h.MineSiteRepo, userID, req.MineSiteID) {
+		return false
+	}
+
+	if req.ClerkID != nil && !checkClerk(ctx, w, h.OrganizationRepo, userID, *req.ClerkID) {
+		return false
+	}
+
+	till.Name = name
+	till.MineSiteID = req.MineSiteID
+	till.ClerkID = req.ClerkID
+	if req.Active != nil {
+		till.Active = *req.Active
+	}
+	return true
+}
+
+// till returns the till of a request, writing the error response and returning false when it
+// doesn't exist
+func (h *TillHandler) till(w http.ResponseWriter, r *http.Request, userID uint) (*data.Till, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid till ID")
+		return nil, false
+	}
+	till, err := h.TillRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Till not found")
+			return nil, false
+		}
+		utils.WriteInternalServerError(w, "Failed to retrieve till")
+		return nil, false
+	}
+	return till, true
+}
+
+// checkClerk checks that a clerk is the owner of the books or a member of their organization,
+// writing the error response and returning false when they aren't
+func checkClerk(ctx context.Context, w http.ResponseWriter, organizationRepo data.OrganizationInterface, userID uint, clerkID uint) bool {
+	if clerkID == userID {
+		return true
+	}
+	member, err := organizationRepo.HasMember(ctx, userID, clerkID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to verify clerk")
+		return false
+	}
+	if !member {
+		utils.WriteValidationError(w, "Clerk must be a member of the organization")
+		return false
+	}
+	return true
+}
+
+// resolveTill returns the till cash taken by a request goes through: the till requested, or else
+// the active till assigned to the acting member, if they have one. Members can only use tills
+// assigned to them or to no one; the owner can use any. It writes the error response and returns
+// false when the till can't be used.
+func resolveTill(w http.ResponseWriter, r *http.Request, tillRepo data.TillInterface, userID uint, tillID *uint) (*uint, bool) {
+	if tillRepo == nil {
+		if tillID != nil {
+			utils.WriteValidationError(w, "Till not found")
+			return nil, false
+		}
+		return nil, true
+	}
+
+	actorID := middleware.GetActorIDFromRequest(r)
+	if tillID == nil {
+		till, err := tillRepo.GetForClerk(r.Context(), userID, actorID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, true
+			}
+			utils.WriteInternalServerError(w, "Failed to retrieve till")
+			return nil, false
+		}
+		return &till.ID, true
+	}
+
+	till, err := tillRepo.GetOne(r.Context(), *tillID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteValidationError(w, "Till not found")
+			return nil, false
+		}
+		utils.WriteInternalServerError(w, "Failed to retrieve till")
+		return nil, false
+	}
+	if !till.Active {
+		utils.WriteValidationError(w, "Till is inactive")
+		return nil, false
+	}
+	if till.ClerkID != nil && *till.ClerkID != actorID && actorID != userID {
+		utils.WriteForbiddenError(w, "This till is assigned to another clerk")
+		return nil, false
+	}
+	return &till.ID, true
+}
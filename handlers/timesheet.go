@@ -0,0 +1,283 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TimesheetHandler handles timesheet submission, approval and labor cost requests
+type TimesheetHandler struct {
+	TimesheetRepo data.TimesheetInterface
+	EmployeeRepo  data.EmployeeInterface
+}
+
+// NewTimesheetHandler creates a new TimesheetHandler
+func NewTimesheetHandler(timesheetRepo data.TimesheetInterface, employeeRepo data.EmployeeInterface) *TimesheetHandler {
+	return &TimesheetHandler{
+		TimesheetRepo: timesheetRepo,
+		EmployeeRepo:  employeeRepo,
+	}
+}
+
+// SubmitTimesheetRequest represents a timesheet submission
+type SubmitTimesheetRequest struct {
+	EmployeeID uint    `json:"employee_id"`
+	Date       string  `json:"date"`
+	Hours      float64 `json:"hours"`
+	PitNumber  *string `json:"pit_number,omitempty"` // Defaults to the employee's pit
+	Notes      *string `json:"notes,omitempty"`
+}
+
+// RejectTimesheetRequest represents a timesheet rejection
+type RejectTimesheetRequest struct {
+	Reason string `json:"reason"`
+}
+
+// GetAllTimesheets retrieves timesheets, optionally filtered by status and month (YYYY-MM)
+func (h *TimesheetHandler) GetAllTimesheets(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	if status != "" && status != string(data.TimesheetSubmitted) &&
+		status != string(data.TimesheetApproved) && status != string(data.TimesheetRejected) {
+		utils.WriteValidationError(w, "Invalid timesheet status")
+		return
+	}
+
+	var start, end *time.Time
+	if month := r.URL.Query().Get("month"); month != "" {
+		monthStart, monthEnd, err := parseMonth(month)
+		if err != nil {
+			utils.WriteValidationError(w, "Invalid month format. Use YYYY-MM")
+			return
+		}
+		start, end = &monthStart, &monthEnd
+	}
+
+	timesheets, err := h.TimesheetRepo.GetAll(r.Context(), userID, status, start, end)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve timesheets")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Timesheets retrieved successfully", timesheets)
+}
+
+// SubmitTimesheet submits hours worked by an employee for approval
+func (h *TimesheetHandler) SubmitTimesheet(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req SubmitTimesheetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	if req.EmployeeID == 0 {
+		utils.WriteValidationError(w, "Employee is required")
+		return
+	}
+	if !utils.ValidateRequired(req.Date) {
+		utils.WriteValidationError(w, "Date is required")
+		return
+	}
+	if !utils.ValidatePositiveNumber(req.Hours) || req.Hours > 24 {
+		utils.WriteValidationError(w, "Hours must be between 0 and 24")
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid date format. Use YYYY-MM-DD")
+		return
+	}
+
+	employee, err := h.EmployeeRepo.GetOne(r.Context(), req.EmployeeID, userID)
+	if err != nil {
+		utils.WriteValidationError(w, "Employee not found")
+		return
+	}
+	if !employee.Active {
+		utils.WriteValidationError(w, "Employee is not active")
+		return
+	}
+
+	pitNumber := req.PitNumber
+	if pitNumber == nil || *pitNumber == "" {
+		pitNumber = employee.PitNumber
+	}
+
+	timesheet := &data.Timesheet{
+		EmployeeID: employee.ID,
+		Date:       date,
+		Hours:      req.Hours,
+		HourlyRate: employee.HourlyRate,
+		PitNumber:  pitNumber,
+		Notes:      req.Notes,
+		UserID:     userID,
+	}
+
+	timesheetID, err := h.TimesheetRepo.Insert(r.Context(), timesheet)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to submit timesheet")
+		return
+	}
+
+	timesheet.ID = timesheetID
+	utils.WriteSuccessResponse(w, "Timesheet submitted successfully", timesheet)
+}
+
+// ApproveTimesheet approves a submitted timesheet so it feeds payroll
+func (h *TimesheetHandler) ApproveTimesheet(w http.ResponseWriter, r *http.Request) {
+	h.reviewTimesheet(w, r, data.TimesheetApproved)
+}
+
+// RejectTimesheet rejects a submitted timesheet with a reason
+func (h *TimesheetHandler) RejectTimesheet(w http.ResponseWriter, r *http.Request) {
+	h.reviewTimesheet(w, r, data.TimesheetRejected)
+}
+
+// DeleteTimesheet deletes a timesheet that has not been reviewed
+func (h *TimesheetHandler) DeleteTimesheet(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid timesheet ID")
+		return
+	}
+
+	err = h.TimesheetRepo.Delete(r.Context(), uint(id), userID)
+	if err != nil {
+		if errors.Is(err, data.ErrTimesheetReviewed) {
+			utils.WriteValidationError(w, "Only submitted timesheets can be deleted")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to delete timesheet")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Timesheet deleted successfully", nil)
+}
+
+// GetLaborCost retrieves the monthly labor cost roll-up per pit and employee
+func (h *TimesheetHandler) GetLaborCost(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	// Get month from query parameter, default to current month
+	month := r.URL.Query().Get("month")
+	if month == "" {
+		month = time.Now().Format("2006-01")
+	}
+	start, end, err := parseMonth(month)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid month format. Use YYYY-MM")
+		return
+	}
+
+	byPit, err := h.TimesheetRepo.GetLaborCostByPit(r.Context(), userID, start, end)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve labor cost by pit")
+		return
+	}
+
+	byEmployee, err := h.TimesheetRepo.GetApprovedHours(r.Context(), userID, start, end)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve approved hours")
+		return
+	}
+
+	summary := &data.LaborCostSummary{
+		Month:      month,
+		ByPit:      byPit,
+		ByEmployee: byEmployee,
+	}
+	for _, pit := range byPit {
+		summary.TotalHours += pit.Hours
+		summary.TotalCost += pit.TotalCost
+	}
+
+	utils.WriteSuccessResponse(w, "Labor cost retrieved successfully", summary)
+}
+
+// reviewTimesheet approves or rejects a submitted timesheet
+func (h *TimesheetHandler) reviewTimesheet(w http.ResponseWriter, r *http.Request, status data.TimesheetStatus) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid timesheet ID")
+		return
+	}
+
+	var reason *string
+	if status == data.TimesheetRejected {
+		var req RejectTimesheetRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.WriteValidationError(w, "Invalid request body")
+			return
+		}
+		if !utils.ValidateRequired(req.Reason) {
+			utils.WriteValidationError(w, "Rejection reason is required")
+			return
+		}
+		reason = &req.Reason
+	}
+
+	err = h.TimesheetRepo.Review(r.Context(), uint(id), userID, status, reason)
+	if err != nil {
+		if errors.Is(err, data.ErrTimesheetReviewed) {
+			utils.WriteValidationError(w, "Timesheet not found or already reviewed")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to review timesheet")
+		return
+	}
+
+	timesheet, err := h.TimesheetRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve timesheet")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Timesheet "+string(status)+" successfully", timesheet)
+}
+
+// parseMonth parses a YYYY-MM string into the first instant of the month and of the next month
+func parseMonth(month string) (time.Time, time.Time, error) {
+	start, err := time.Parse("2006-01", month)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return start, start.AddDate(0, 1, 0), nil
+}
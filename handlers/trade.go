@@ -0,0 +1,345 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// TradeHandler handles sales shared between trading partners who both use the platform
+type TradeHandler struct {
+	TradeRepo        data.TradeInterface
+	IncomeRepo       data.IncomeInterface
+	IdentityRepo     data.IdentityInterface
+	UserRepo         data.UserInterface
+	SettingsRepo     data.SettingsInterface
+	NotificationRepo data.NotificationInterface
+	EvidenceRepo     data.EvidenceInterface
+	AuditRepo        data.AuditInterface
+
+	// Quota limits the photos uploaded to the attachment storage quota; uploads aren't limited
+	// when it is nil
+	Quota *AttachmentQuota
+}
+
+// NewTradeHandler creates a new TradeHandler
+func NewTradeHandler(tradeRepo data.TradeInterface, incomeRepo data.IncomeInterface, identityRepo data.IdentityInterface, userRepo data.UserInterface, settingsRepo data.SettingsInterface, notificationRepo data.NotificationInterface, evidenceRepo data.EvidenceInterface, auditRepo data.AuditInterface) *TradeHandler {
+	return &TradeHandler{
+		TradeRepo:        tradeRepo,
+		IncomeRepo:       incomeRepo,
+		IdentityRepo:     identityRepo,
+		UserRepo:         userRepo,
+		SettingsRepo:     settingsRepo,
+		NotificationRepo: notificationRepo,
+		EvidenceRepo:     evidenceRepo,
+		AuditRepo:        auditRepo,
+	}
+}
+
+// ShareSaleRequest represents a request to share a sale with its buyer
+type ShareSaleRequest struct {
+	Phone string `json:"phone,omitempty"` // buyer's linked phone number, defaults to the customer contact
+}
+
+// AcceptPurchaseRequest represents a buyer's acceptance of a shared sale
+type AcceptPurchaseRequest struct {
+	Category    string       `json:"category,omitempty"`    // defaults to "other"
+	Description string       `json:"description,omitempty"` // defaults to the quantity and mineral bought
+	Notes       string       `json:"notes,omitempty"`
+	Photo       *PhotoUpload `json:"photo,omitempty"` // Required by the evidence rules for expenses
+}
+
+// ShareSale shares a sale with a buyer who signs in with the customer's phone number, so it
+// appears as a pending purchase for them. Sharing again refreshes a purchase still pending.
+func (h *TradeHandler) ShareSale(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid income ID")
+		return
+	}
+
+	var req ShareSaleRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.WriteValidationError(w, "Invalid request body")
+			return
+		}
+	}
+
+	income, err := h.IncomeRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Income record not found")
+		return
+	}
+	if income.ApprovalStatus != nil && *income.ApprovalStatus == data.SalePendingApproval {
+		utils.WriteErrorResponse(w, "Sales pending approval cannot be shared", http.StatusConflict)
+		return
+	}
+
+	phone := strings.TrimSpace(req.Phone)
+	if phone == "" {
+		phone = income.CustomerContact
+	}
+	phone = utils.NormalizePhone(phone)
+	if phone == "" || !utils.ValidatePhone(phone) {
+		utils.WriteValidationError(w, "A valid buyer phone number is required")
+		return
+	}
+
+	identity, err := h.IdentityRepo.GetByProvider(r.Context(), data.IdentityPhone, phone)
+	if err != nil {
+		utils.WriteNotFoundError(w, "No platform user has linked this phone number")
+		return
+	}
+	if identity.UserID == userID {
+		utils.WriteValidationError(w, "You cannot share a sale with yourself")
+		return
+	}
+
+	settings, err := h.SettingsRepo.GetByUserID(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve settings")
+		return
+	}
+
+	sale := &data.SharedSale{
+		IncomeID:      income.ID,
+		SellerID:      userID,
+		SellerName:    h.sellerName(r.Context(), userID),
+		BuyerID:       identity.UserID,
+		Date:          income.Date,
+		MineralType:   income.MineralType,
+		Quantity:      income.Quantity,
+		Unit:          income.Unit,
+		PricePerUnit:  income.PricePerUnit,
+		TotalAmount:   income.TotalAmount,
+		Currency:      settings.DefaultCurrency,
+		PaymentStatus: income.PaymentStatus,
+		AmountPaid:    income.AmountPaid,
+	}
+	if err := h.TradeRepo.Share(r.Context(), sale); err != nil {
+		if errors.Is(err, data.ErrTradeAnswered) {
+			utils.WriteErrorResponse(w, "The buyer has already answered this sale", http.StatusConflict)
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to share sale")
+		return
+	}
+
+	saleID := sale.ID
+	h.NotificationRepo.Insert(r.Context(), &data.Notification{
+		Kind:  data.NotificationSharedSale,
+		Title: "New purchase to confirm",
+		Message: fmt.Sprintf("%s recorded a sale of %g %s of %s to you for %s. Accept it to add it to your expenses.",
+			sale.SellerName, sale.Quantity, sale.Unit, sale.MineralType, utils.FormatMoney(sale.Currency, sale.TotalAmount)),
+		ReferenceID: &saleID,
+		Key:         fmt.Sprintf("%s:%d", data.NotificationSharedSale, sale.ID),
+		UserID:      sale.BuyerID,
+	})
+
+	utils.WriteSuccessResponse(w, "Sale shared successfully", sale)
+}
+
+// GetSharedSales returns the sales the user has shared with buyers and their answers
+func (h *TradeHandler) GetSharedSales(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	sales, err := h.TradeRepo.GetOutgoing(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve shared sales")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Shared sales retrieved successfully", sales)
+}
+
+// GetPurchases returns the sales shared with the user, optionally with one status
+func (h *TradeHandler) GetPurchases(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	status := data.TradeStatus(r.URL.Query().Get("status"))
+	if status != "" && status != data.TradePending && status != data.TradeAccepted && status != data.TradeDeclined &&
+		status != data.TradeDisputed && status != data.TradeVoided {
+		utils.WriteValidationError(w, "Status must be pending, accepted, declined, disputed or voided")
+		return
+	}
+
+	sales, err := h.TradeRepo.GetIncoming(r.Context(), userID, status)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve purchases")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Purchases retrieved successfully", sales)
+}
+
+// AcceptPurchase accepts a sale shared with the user, recording it as an expense
+func (h *TradeHandler) AcceptPurchase(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid purchase ID")
+		return
+	}
+
+	var req AcceptPurchaseRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.WriteValidationError(w, "Invalid request body")
+			return
+		}
+	}
+
+	category := data.ExpenseOther
+	if req.Category != "" {
+		category = data.ExpenseCategory(req.Category)
+		valid := false
+		for _, known := range data.ExpenseCategories {
+			if category == known {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			utils.WriteValidationError(w, "Invalid expense category")
+			return
+		}
+	}
+
+	pending, err := h.TradeRepo.GetPurchase(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Purchase not found")
+		return
+	}
+	if pending.Status != data.TradePending {
+		utils.WriteErrorResponse(w, "This purchase has already been answered", http.StatusConflict)
+		return
+	}
+
+	// Apply photo evidence rules
+	photo, ok := requirePhoto(w, r, h.EvidenceRepo, h.Quota, data.EvidenceExpense, pending.TotalAmount, req.Photo, nil)
+	if !ok {
+		return
+	}
+
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		description = fmt.Sprintf("Purchase of %g %s of %s", pending.Quantity, pending.Unit, pending.MineralType)
+	}
+	expense := &data.Expense{
+		Date:          pending.Date,
+		Category:      category,
+		Description:   description,
+		Amount:        pending.TotalAmount,
+		SupplierName:  pending.SellerName,
+		PaymentStatus: pending.PaymentStatus,
+		AmountPaid:    pending.AmountPaid,
+		UserID:        userID,
+	}
+	if notes := strings.TrimSpace(req.Notes); notes != "" {
+		expense.Notes = &notes
+	}
+
+	sale, err := h.TradeRepo.Accept(r.Context(), uint(id), userID, expense)
+	if err != nil {
+		discardPhoto(r.Context(), h.EvidenceRepo, photo)
+		if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, data.ErrTradeAnswered) {
+			utils.WriteErrorResponse(w, "This purchase has already been answered", http.StatusConflict)
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to accept purchase")
+		return
+	}
+	linkPhoto(r.Context(), h.EvidenceRepo, photo, data.EvidenceRecordExpense, expense.ID)
+	h.notifySeller(r.Context(), sale)
+
+	utils.WriteSuccessResponse(w, "Purchase accepted successfully", sale)
+}
+
+// DeclinePurchase declines a sale shared with the user without recording an expense
+func (h *TradeHandler) DeclinePurchase(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid purchase ID")
+		return
+	}
+
+	sale, err := h.TradeRepo.Decline(r.Context(), uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Purchase not found")
+			return
+		}
+		if errors.Is(err, data.ErrTradeAnswered) {
+			utils.WriteErrorResponse(w, "This purchase has already been answered", http.StatusConflict)
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to decline purchase")
+		return
+	}
+
+	h.notifySeller(r.Context(), sale)
+
+	utils.WriteSuccessResponse(w, "Purchase declined successfully", sale)
+}
+
+// sellerName returns the name buyers see on sales shared with them
+func (h *TradeHandler) sellerName(ctx context.Context, userID uint) string {
+	user, err := h.UserRepo.GetOne(ctx, userID)
+	if err != nil {
+		return ""
+	}
+	return user.Name
+}
+
+// notifySeller tells the seller of a shared sale that the buyer accepted or declined it
+func (h *TradeHandler) notifySeller(ctx context.Context, sale *data.SharedSale) {
+	saleID := sale.ID
+	h.NotificationRepo.Insert(ctx, &data.Notification{
+		Kind:  data.NotificationSharedSale,
+		Title: fmt.Sprintf("Shared sale %s", sale.Status),
+		Message: fmt.Sprintf("Your buyer %s your sale of %g %s of %s on %s",
+			sale.Status, sale.Quantity, sale.Unit, sale.MineralType, sale.Date.Format("2006-01-02")),
+		ReferenceID: &saleID,
+		Key:         fmt.Sprintf("%s:%d:%s", data.NotificationSharedSale, sale.ID, sale.Status),
+		UserID:      sale.SellerID,
+	})
+}
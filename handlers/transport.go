@@ -0,0 +1,451 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// TransportHandler handles vehicle and trip logging requests
+type TransportHandler struct {
+	VehicleRepo data.VehicleInterface
+	TripRepo    data.TripInterface
+	IncomeRepo  data.IncomeInterface
+}
+
+// NewTransportHandler creates a new TransportHandler
+func NewTransportHandler(vehicleRepo data.VehicleInterface, tripRepo data.TripInterface, incomeRepo data.IncomeInterface) *TransportHandler {
+	return &TransportHandler{
+		VehicleRepo: vehicleRepo,
+		TripRepo:    tripRepo,
+		IncomeRepo:  incomeRepo,
+	}
+}
+
+// VehicleRequest represents a create or update vehicle request
+type VehicleRequest struct {
+	Registration    string  `json:"registration"`
+	Description     *string `json:"description,omitempty"`
+	Type            *string `json:"type,omitempty"`
+	NextServiceDate *string `json:"next_service_date,omitempty"` // YYYY-MM-DD, scheduled maintenance
+	Notes           *string `json:"notes,omitempty"`
+}
+
+// TripRequest represents a create or update trip request
+type TripRequest struct {
+	VehicleID     uint     `json:"vehicle_id"`
+	Date          string   `json:"date"`
+	Origin        string   `json:"origin"`
+	Destination   string   `json:"destination"`
+	Cargo         *string  `json:"cargo,omitempty"`
+	CargoQuantity *float64 `json:"cargo_quantity,omitempty"`
+	CargoUnit     *string  `json:"cargo_unit,omitempty"`
+	DistanceKm    float64  `json:"distance_km"`
+	IncomeID      *uint    `json:"income_id,omitempty"` // Sale delivered by this trip
+	Notes         *string  `json:"notes,omitempty"`
+}
+
+// GetAllVehicles retrieves all vehicles for the authenticated user
+func (h *TransportHandler) GetAllVehicles(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	vehicles, err := h.VehicleRepo.GetAll(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve vehicles")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Vehicles retrieved successfully", vehicles)
+}
+
+// CreateVehicle creates a new vehicle
+func (h *TransportHandler) CreateVehicle(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req VehicleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	if !utils.ValidateRequired(req.Registration) {
+		utils.WriteValidationError(w, "Registration is required")
+		return
+	}
+
+	nextServiceDate, err := parseOptionalDate(req.NextServiceDate)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid service date format. Use YYYY-MM-DD")
+		return
+	}
+
+	vehicle := &data.Vehicle{
+		Registration:    req.Registration,
+		Description:     req.Description,
+		Type:            req.Type,
+		NextServiceDate: nextServiceDate,
+		Notes:           req.Notes,
+		UserID:          userID,
+	}
+
+	vehicleID, err := h.VehicleRepo.Insert(r.Context(), vehicle)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to create vehicle")
+		return
+	}
+
+	vehicle.ID = vehicleID
+	utils.WriteSuccessResponse(w, "Vehicle created successfully", vehicle)
+}
+
+// UpdateVehicle updates an existing vehicle
+func (h *TransportHandler) UpdateVehicle(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid vehicle ID")
+		return
+	}
+
+	var req VehicleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	if !utils.ValidateRequired(req.Registration) {
+		utils.WriteValidationError(w, "Registration is required")
+		return
+	}
+
+	nextServiceDate, err := parseOptionalDate(req.NextServiceDate)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid service date format. Use YYYY-MM-DD")
+		return
+	}
+
+	vehicle, err := h.VehicleRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Vehicle not found")
+		return
+	}
+
+	vehicle.Registration = req.Registration
+	vehicle.Description = req.Description
+	vehicle.Type = req.Type
+	vehicle.NextServiceDate = nextServiceDate
+	vehicle.Notes = req.Notes
+
+	err = h.VehicleRepo.Update(r.Context(), vehicle)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to update vehicle")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Vehicle updated successfully", vehicle)
+}
+
+// DeleteVehicle deletes a vehicle
+func (h *TransportHandler) DeleteVehicle(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid vehicle ID")
+		return
+	}
+
+	err = h.VehicleRepo.Delete(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to delete vehicle")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Vehicle deleted successfully", nil)
+}
+
+// GetAllTrips retrieves all trips for the authenticated user
+func (h *TransportHandler) GetAllTrips(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	trips, err := h.TripRepo.GetAll(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve trips")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Trips retrieved successfully", trips)
+}
+
+// GetTrip retrieves a specific trip
+func (h *TransportHandler) GetTrip(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid trip ID")
+		return
+	}
+
+	trip, err := h.TripRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Trip not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Trip retrieved successfully", trip)
+}
+
+// CreateTrip logs a new trip
+func (h *TransportHandler) CreateTrip(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	var req TripRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	date, ok := h.validateTrip(r.Context(), w, userID, &req)
+	if !ok {
+		return
+	}
+
+	trip := &data.Trip{
+		VehicleID:     req.VehicleID,
+		Date:          date,
+		Origin:        req.Origin,
+		Destination:   req.Destination,
+		Cargo:         req.Cargo,
+		CargoQuantity: req.CargoQuantity,
+		CargoUnit:     req.CargoUnit,
+		DistanceKm:    req.DistanceKm,
+		IncomeID:      req.IncomeID,
+		Notes:         req.Notes,
+		UserID:        userID,
+	}
+
+	tripID, err := h.TripRepo.Insert(r.Context(), trip)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to create trip")
+		return
+	}
+
+	trip.ID = tripID
+	utils.WriteSuccessResponse(w, "Trip created successfully", trip)
+}
+
+// UpdateTrip updates an existing trip
+func (h *TransportHandler) UpdateTrip(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid trip ID")
+		return
+	}
+
+	var req TripRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	trip, err := h.TripRepo.GetOne(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteNotFoundError(w, "Trip not found")
+		return
+	}
+
+	date, ok := h.validateTrip(r.Context(), w, userID, &req)
+	if !ok {
+		return
+	}
+
+	trip.VehicleID = req.VehicleID
+	trip.Date = date
+	trip.Origin = req.Origin
+	trip.Destination = req.Destination
+	trip.Cargo = req.Cargo
+	trip.CargoQuantity = req.CargoQuantity
+	trip.CargoUnit = req.CargoUnit
+	trip.DistanceKm = req.DistanceKm
+	trip.IncomeID = req.IncomeID
+	trip.Notes = req.Notes
+
+	err = h.TripRepo.Update(r.Context(), trip)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to update trip")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Trip updated successfully", trip)
+}
+
+// DeleteTrip deletes a trip
+func (h *TransportHandler) DeleteTrip(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid trip ID")
+		return
+	}
+
+	err = h.TripRepo.Delete(r.Context(), uint(id), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to delete trip")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Trip deleted successfully", nil)
+}
+
+// GetVehicleCosts retrieves per-vehicle transport cost analytics
+func (h *TransportHandler) GetVehicleCosts(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	costs, err := h.TripRepo.GetVehicleCosts(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve vehicle costs")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Vehicle costs retrieved successfully", costs)
+}
+
+// GetDeliveryCosts retrieves the transport cost of each sale delivery
+func (h *TransportHandler) GetDeliveryCosts(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	costs, err := h.TripRepo.GetDeliveryCosts(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve delivery costs")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Delivery costs retrieved successfully", costs)
+}
+
+// validateTrip validates a trip request, writing the error response on failure
+func (h *TransportHandler) validateTrip(ctx context.Context, w http.ResponseWriter, userID uint, req *TripRequest) (time.Time, bool) {
+	if req.VehicleID == 0 {
+		utils.WriteValidationError(w, "Vehicle is required")
+		return time.Time{}, false
+	}
+	if !utils.ValidateRequired(req.Date) {
+		utils.WriteValidationError(w, "Date is required")
+		return time.Time{}, false
+	}
+	if !utils.ValidateRequired(req.Origin) {
+		utils.WriteValidationError(w, "Origin is required")
+		return time.Time{}, false
+	}
+	if !utils.ValidateRequired(req.Destination) {
+		utils.WriteValidationError(w, "Destination is required")
+		return time.Time{}, false
+	}
+	if !utils.ValidateNonNegativeNumber(req.DistanceKm) {
+		utils.WriteValidationError(w, "Distance cannot be negative")
+		return time.Time{}, false
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid date format. Use YYYY-MM-DD")
+		return time.Time{}, false
+	}
+
+	if _, err := h.VehicleRepo.GetOne(ctx, req.VehicleID, userID); err != nil {
+		utils.WriteValidationError(w, "Vehicle not found")
+		return time.Time{}, false
+	}
+	if req.IncomeID != nil {
+		if _, err := h.IncomeRepo.GetOne(ctx, *req.IncomeID, userID); err != nil {
+			utils.WriteValidationError(w, "Income record not found")
+			return time.Time{}, false
+		}
+	}
+
+	return date, true
+}
+
+// checkTrip checks that the trip an expense is assigned to, if any, is one of the user's trips. It
+// writes the error response and returns false when it isn't.
+func checkTrip(ctx context.Context, w http.ResponseWriter, tripRepo data.TripInterface, userID uint, tripID *uint) bool {
+	if tripID == nil {
+		return true
+	}
+	if tripRepo == nil {
+		utils.WriteValidationError(w, "Trip not found")
+		return false
+	}
+	if _, err := tripRepo.GetOne(ctx, *tripID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteValidationError(w, "Trip not found")
+			return false
+		}
+		utils.WriteInternalServerError(w, "Failed to check trip")
+		return false
+	}
+	return true
+}
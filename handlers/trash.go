@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"mineral/data"
+	"net/http"
+)
+
+// auditTrash records a record restored from the trash or permanently deleted in the audit log
+func auditTrash(auditRepo data.AuditInterface, r *http.Request, action data.AuditAction, resource string, id uint) {
+	if auditRepo == nil {
+		return
+	}
+	recordAudit(auditRepo, r, &data.AuditLog{
+		Action:     action,
+		Resource:   resource,
+		ResourceID: &id,
+	})
+}
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mineral/data"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UsageHandler handles usage metering and plan requests
+type UsageHandler struct {
+	UsageRepo data.UsageInterface
+	UserRepo  data.UserInterface
+
+	// DefaultPlan is the plan of books without an active subscription
+	DefaultPlan data.Plan
+	// Quota is the attachment storage quota admins override per organization
+	Quota *AttachmentQuota
+}
+
+// NewUsageHandler creates a new UsageHandler
+func NewUsageHandler(usageRepo data.UsageInterface, userRepo data.UserInterface) *UsageHandler {
+	return &UsageHandler{
+		UsageRepo:   usageRepo,
+		UserRepo:    userRepo,
+		DefaultPlan: data.PlanUnlimited,
+	}
+}
+
+// PlanRequest represents a request to change the plan of a user's books
+type PlanRequest struct {
+	Plan data.Plan `json:"plan"`
+}
+
+// GetUsage returns the usage of the organization's books this month against its plan limits
+func (h *UsageHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	usage, err := h.usage(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve usage")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Usage retrieved successfully", usage)
+}
+
+// GetAllUsage returns the usage of every organization's books this month, for billing
+func (h *UsageHandler) GetAllUsage(w http.ResponseWriter, r *http.Request) {
+	monthStart := startOfMonth(time.Now())
+	counts, err := h.UsageRepo.GetAllCounts(r.Context(), monthStart)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve usage")
+		return
+	}
+	subscriptions, err := h.UsageRepo.GetSubscriptions(r.Context())
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve usage")
+		return
+	}
+
+	plans := make(map[uint]data.Plan)
+	for _, subscription := range subscriptions {
+		plans[subscription.UserID] = subscriptionPlan(subscription, h.DefaultPlan)
+	}
+
+	usages := make([]*data.Usage, 0, len(counts))
+	for _, c := range counts {
+		plan, ok := plans[c.UserID]
+		if !ok {
+			plan = h.DefaultPlan
+		}
+		delete(plans, c.UserID)
+		usages = append(usages, newUsage(c, monthStart, plan))
+	}
+	// Subscribed books without usage this month
+	for _, subscription := range subscriptions {
+		if plan, ok := plans[subscription.UserID]; ok {
+			usages = append(usages, newUsage(&data.UsageCounts{UserID: subscription.UserID}, monthStart, plan))
+		}
+	}
+
+	utils.WriteSuccessResponse(w, "Usage retrieved successfully", usages)
+}
+
+// SetPlan changes the plan of a user's books, replacing any subscription through the payment
+// provider
+func (h *UsageHandler) SetPlan(w http.ResponseWriter, r *http.Request) {
+	actorID := middleware.GetActorIDFromRequest(r)
+	userID, ok := parseUserIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	var req PlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+	if _, ok := data.Plans[req.Plan]; !ok {
+		utils.WriteValidationError(w, "Plan must be free, pro or unlimited")
+		return
+	}
+
+	if _, err := h.UserRepo.GetOne(r.Context(), userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "User not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to change plan")
+		return
+	}
+
+	subscription := &data.Subscription{
+		Plan:        req.Plan,
+		Status:      data.SubscriptionActive,
+		UpdatedByID: &actorID,
+		UserID:      userID,
+	}
+	if err := h.UsageRepo.SaveSubscription(r.Context(), subscription); err != nil {
+		utils.WriteInternalServerError(w, "Failed to change plan")
+		return
+	}
+
+	usage, err := h.usage(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve usage")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Plan changed successfully", usage)
+}
+
+// CheckLimits returns why the books of a user have reached their plan limit for one of the
+// metrics, or an empty string when they may use more. It is used by the usage limit middleware.
+func (h *UsageHandler) CheckLimits(ctx context.Context, userID uint, metrics ...string) (string, error) {
+	usage, err := h.usage(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	for _, metric := range metrics {
+		switch data.UsageMetric(metric) {
+		case data.UsageRecords:
+			if reached(usage.Records, usage.Limits.RecordsPerMonth) {
+				return fmt.Sprintf("This organization has reached the limit of %d records per month on the %s plan",
+					usage.Limits.RecordsPerMonth, usage.Plan), nil
+			}
+		case data.UsageStorage:
+			if reached(usage.StorageBytes, usage.Limits.StorageBytes) {
+				return fmt.Sprintf("This organization has used its %d MB of photo storage on the %s plan",
+					usage.Limits.StorageBytes>>20, usage.Plan), nil
+			}
+		case data.UsageSMS:
+			if reached(usage.SMS, usage.Limits.SMSPerMonth) {
+				return fmt.Sprintf("This organization has reached the limit of %d SMS per month on the %s plan",
+					usage.Limits.SMSPerMonth, usage.Plan), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// usage returns the usage of a user's books this month against their plan limits
+func (h *UsageHandler) usage(ctx context.Context, userID uint) (*data.Usage, error) {
+	monthStart := startOfMonth(time.Now())
+	counts, err := h.UsageRepo.GetCounts(ctx, userID, monthStart)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, _, err := activePlan(ctx, h.UsageRepo, userID, h.DefaultPlan)
+	if err != nil {
+		return nil, err
+	}
+
+	return newUsage(counts, monthStart, plan), nil
+}
+
+// newUsage compares usage counts with the limits of a plan
+func newUsage(counts *data.UsageCounts, monthStart time.Time, plan data.Plan) *data.Usage {
+	limits := data.Plans[plan]
+	usage := &data.Usage{
+		UsageCounts: *counts,
+		Month:       monthStart.Format("2006-01"),
+		Plan:        plan,
+		Limits:      limits,
+	}
+	if reached(counts.Records, limits.RecordsPerMonth) {
+		usage.Exceeded = append(usage.Exceeded, string(data.UsageRecords))
+	}
+	if reached(counts.StorageBytes, limits.StorageBytes) {
+		usage.Exceeded = append(usage.Exceeded, string(data.UsageStorage))
+	}
+	if reached(counts.SMS, limits.SMSPerMonth) {
+		usage.Exceeded = append(usage.Exceeded, string(data.UsageSMS))
+	}
+	return usage
+}
+
+// reached reports whether usage is at a limit; a zero limit is unlimited
+func reached(used, limit int64) bool {
+	return limit > 0 && used >= limit
+}
+
+// startOfMonth returns midnight on the first day of the month of t
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
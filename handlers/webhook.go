@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mineral/data"
+	"mineral/pkg/events"
+	"mineral/pkg/middleware"
+	"mineral/pkg/utils"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// WebhookHandler handles webhook endpoint requests
+type WebhookHandler struct {
+	WebhookRepo data.WebhookInterface
+}
+
+// NewWebhookHandler creates a new WebhookHandler
+func NewWebhookHandler(webhookRepo data.WebhookInterface) *WebhookHandler {
+	return &WebhookHandler{
+		WebhookRepo: webhookRepo,
+	}
+}
+
+// WebhookRequest represents a request to register a webhook endpoint
+type WebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+// WebhookResponse represents a created webhook with the secret that signs its deliveries,
+// which is only shown once
+type WebhookResponse struct {
+	*data.Webhook
+	Secret string `json:"secret"`
+}
+
+// GetWebhooks returns the webhook endpoints
+func (h *WebhookHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+
+	webhooks, err := h.WebhookRepo.GetAll(r.Context(), userID)
+	if err != nil {
+		utils.WriteInternalServerError(w, "Failed to retrieve webhooks")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Webhooks retrieved successfully", webhooks)
+}
+
+// CreateWebhook registers an HTTPS endpoint for events (owner/manager)
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+	if !canManageWebhooks(w, r) {
+		return
+	}
+
+	var req WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteValidationError(w, "Invalid request body")
+		return
+	}
+
+	endpoint, err := url.Parse(strings.TrimSpace(req.URL))
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		utils.WriteValidationError(w, "URL must be an https URL")
+		return
+	}
+	if len(req.Events) == 0 {
+		utils.WriteValidationError(w, "At least one event is required")
+		return
+	}
+	for _, name := range req.Events {
+		if !knownEvent(name) {
+			utils.WriteValidationError(w, fmt.Sprintf("Unknown event %q", name))
+			return
+		}
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		utils.WriteInternalServerError(w, "Failed to create webhook")
+		return
+	}
+	webhook := &data.Webhook{
+		URL:    endpoint.String(),
+		Events: req.Events,
+		Secret: hex.EncodeToString(b),
+		Active: true,
+		UserID: userID,
+	}
+	if _, err := h.WebhookRepo.Insert(r.Context(), webhook); err != nil {
+		utils.WriteInternalServerError(w, "Failed to create webhook")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Webhook created successfully", &WebhookResponse{
+		Webhook: webhook,
+		Secret:  webhook.Secret,
+	})
+}
+
+// DeleteWebhook removes a webhook endpoint (owner/manager)
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromRequest(r)
+	if userID == 0 {
+		utils.WriteUnauthorizedError(w, "User not authenticated")
+		return
+	}
+	if !canManageWebhooks(w, r) {
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.WriteValidationError(w, "Invalid webhook ID")
+		return
+	}
+
+	if err := h.WebhookRepo.Delete(r.Context(), uint(id), userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.WriteNotFoundError(w, "Webhook not found")
+			return
+		}
+		utils.WriteInternalServerError(w, "Failed to delete webhook")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Webhook deleted successfully", nil)
+}
+
+// canManageWebhooks writes a forbidden response and returns false unless the acting user may
+// manage webhooks
+func canManageWebhooks(w http.ResponseWriter, r *http.Request) bool {
+	return requirePermission(w, r, data.PermWebhookManage, "You do not have permission to manage webhooks")
+}
+
+// knownEvent reports whether an event name is published
+func knownEvent(name string) bool {
+	for _, known := range events.Names {
+		if events.Name(name) == known {
+			return true
+		}
+	}
+	return false
+}
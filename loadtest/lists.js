@@ -0,0 +1,74 @@
+// Load profile for the list and analytics endpoints, run with k6 (https://k6.io):
+//
+//   k6 run -e BASE_URL=http://localhost:9006 -e EMAIL=load@example.com -e PASSWORD=... loadtest/lists.js
+//
+// Run it against a database with realistic volumes and compare the p95 latencies with the last
+// release before shipping. The thresholds fail the run when an endpoint regresses badly.
+import http from 'k6/http';
+import { check, group, sleep } from 'k6';
+
+const BASE_URL = __ENV.BASE_URL || 'http://localhost:9006';
+const year = new Date().getFullYear();
+
+export const options = {
+  scenarios: {
+    lists: {
+      executor: 'ramping-vus',
+      startVUs: 1,
+      stages: [
+        { duration: '30s', target: 20 },
+        { duration: '2m', target: 20 },
+        { duration: '30s', target: 0 },
+      ],
+    },
+  },
+  thresholds: {
+    http_req_failed: ['rate<0.01'],
+    'http_req_duration{kind:list}': ['p(95)<500'],
+    'http_req_duration{kind:analytics}': ['p(95)<1000'],
+  },
+};
+
+const endpoints = [
+  { name: 'income', path: '/api/v1/income', kind: 'list' },
+  { name: 'expenses', path: '/api/v1/expense', kind: 'list' },
+  { name: 'inventory', path: '/api/v1/inventory', kind: 'list' },
+  { name: 'low stock', path: '/api/v1/inventory/low-stock', kind: 'list' },
+  { name: 'notifications', path: '/api/v1/notifications', kind: 'list' },
+  { name: 'summary', path: '/api/v1/analytics/summary', kind: 'analytics' },
+  { name: 'monthly', path: `/api/v1/analytics/monthly?year=${year}`, kind: 'analytics' },
+  { name: 'expense breakdown', path: '/api/v1/analytics/expense-breakdown', kind: 'analytics' },
+];
+
+// setup logs in once and shares the token with every virtual user
+export function setup() {
+  if (__ENV.TOKEN) {
+    return { token: __ENV.TOKEN };
+  }
+  const res = http.post(`${BASE_URL}/api/v1/auth/login`, JSON.stringify({
+    email: __ENV.EMAIL,
+    password: __ENV.PASSWORD,
+  }), { headers: { 'Content-Type': 'application/json' } });
+  if (res.status !== 200) {
+    throw new Error(`login failed: ${res.status} ${res.body}`);
+  }
+  return { token: res.json('data.token') };
+}
+
+export default function (data) {
+  const headers = { Authorization: `Bearer ${data.token}` };
+  if (__ENV.ORGANIZATION_ID) {
+    headers['X-Organization-ID'] = __ENV.ORGANIZATION_ID;
+  }
+
+  for (const endpoint of endpoints) {
+    group(endpoint.name, () => {
+      const res = http.get(`${BASE_URL}${endpoint.path}`, {
+        headers,
+        tags: { kind: endpoint.kind, name: endpoint.name },
+      });
+      check(res, { 'status is 200': (r) => r.status === 200 });
+    });
+  }
+  sleep(1);
+}
@@ -0,0 +1,159 @@
+package backup
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Encrypted backups start with magic followed by a random nonce prefix, then a sequence of
+// chunks, each a 4-byte big-endian length and an AES-256-GCM sealed chunk. Chunk nonces are
+// the prefix and a chunk counter, and the last chunk is authenticated as such so truncated
+// backups are detected.
+const (
+	magic       = "MBK1"
+	prefixSize  = 8
+	chunkSize   = 64 * 1024
+	maxChunkLen = chunkSize + 16 // chunk plus GCM tag
+)
+
+// ErrTruncated is returned when decrypting a backup that ends before its last chunk
+var ErrTruncated = errors.New("backup is truncated")
+
+// ParseKey decodes a base64 encoded 32-byte encryption key
+func ParseKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("backup key must be base64 encoded: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("backup key must be 32 bytes, got %d", len(key))
+	}
+	return key, nil
+}
+
+// Encrypt encrypts src to dst with a 32-byte key
+func Encrypt(dst io.Writer, src io.Reader, key []byte) error {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+
+	prefix := make([]byte, prefixSize)
+	if _, err := rand.Read(prefix); err != nil {
+		return err
+	}
+	if _, err := dst.Write(append([]byte(magic), prefix...)); err != nil {
+		return err
+	}
+
+	buf := make([]byte, chunkSize)
+	next := make([]byte, chunkSize)
+	n, err := io.ReadFull(src, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return err
+	}
+	for counter := uint32(0); ; counter++ {
+		last := n < chunkSize
+		m := 0
+		if !last {
+			m, err = io.ReadFull(src, next)
+			if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+				return err
+			}
+			last = m == 0
+		}
+
+		sealed := aead.Seal(nil, nonce(prefix, counter), buf[:n], chunkData(last))
+		header := make([]byte, 4)
+		binary.BigEndian.PutUint32(header, uint32(len(sealed)))
+		if _, err := dst.Write(append(header, sealed...)); err != nil {
+			return err
+		}
+		if last {
+			return nil
+		}
+		buf, next, n = next, buf, m
+	}
+}
+
+// Decrypt decrypts a backup written by Encrypt from src to dst
+func Decrypt(dst io.Writer, src io.Reader, key []byte) error {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+
+	header := make([]byte, len(magic)+prefixSize)
+	if _, err := io.ReadFull(src, header); err != nil {
+		return ErrTruncated
+	}
+	if string(header[:len(magic)]) != magic {
+		return errors.New("not an encrypted backup")
+	}
+	prefix := header[len(magic):]
+
+	length := make([]byte, 4)
+	sealed := make([]byte, maxChunkLen)
+	for counter := uint32(0); ; counter++ {
+		if _, err := io.ReadFull(src, length); err != nil {
+			return ErrTruncated
+		}
+		size := binary.BigEndian.Uint32(length)
+		if size > maxChunkLen {
+			return fmt.Errorf("chunk %d is too large", counter)
+		}
+		if _, err := io.ReadFull(src, sealed[:size]); err != nil {
+			return ErrTruncated
+		}
+
+		last := false
+		plain, err := aead.Open(nil, nonce(prefix, counter), sealed[:size], chunkData(false))
+		if err != nil {
+			plain, err = aead.Open(nil, nonce(prefix, counter), sealed[:size], chunkData(true))
+			if err != nil {
+				return fmt.Errorf("chunk %d: %w", counter, err)
+			}
+			last = true
+		}
+		if _, err := dst.Write(plain); err != nil {
+			return err
+		}
+		if last {
+			// Nothing may follow the last chunk
+			if n, _ := io.ReadFull(src, length[:1]); n > 0 {
+				return errors.New("backup has data after its last chunk")
+			}
+			return nil
+		}
+	}
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// nonce returns the nonce of a chunk
+func nonce(prefix []byte, counter uint32) []byte {
+	n := make([]byte, prefixSize+4)
+	copy(n, prefix)
+	binary.BigEndian.PutUint32(n[prefixSize:], counter)
+	return n
+}
+
+// chunkData returns the additional data that marks whether a chunk is the last one
+func chunkData(last bool) []byte {
+	if last {
+		return []byte{1}
+	}
+	return []byte{0}
+}
@@ -0,0 +1,121 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func testKey(t *testing.T) []byte {
+	t.Helper()
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// encrypt encrypts plain, failing the test on error
+func encrypt(t *testing.T, plain, key []byte) []byte {
+	t.Helper()
+	var encrypted bytes.Buffer
+	if err := Encrypt(&encrypted, bytes.NewReader(plain), key); err != nil {
+		t.Fatal(err)
+	}
+	return encrypted.Bytes()
+}
+
+// chunks splits an encrypted backup into its header and its chunks, each with its length
+func chunks(t *testing.T, encrypted []byte) ([]byte, [][]byte) {
+	t.Helper()
+	header := encrypted[:len(magic)+prefixSize]
+	var parts [][]byte
+	for rest := encrypted[len(header):]; len(rest) > 0; {
+		size := 4 + int(binary.BigEndian.Uint32(rest))
+		parts = append(parts, rest[:size])
+		rest = rest[size:]
+	}
+	return header, parts
+}
+
+func TestEncryptRoundTrip(t *testing.T) {
+	key := testKey(t)
+	tests := []struct {
+		name   string
+		size   int
+		chunks int
+	}{
+		{name: "empty", size: 0, chunks: 1},
+		{name: "one byte", size: 1, chunks: 1},
+		{name: "one byte short of a chunk", size: chunkSize - 1, chunks: 1},
+		{name: "exactly a chunk", size: chunkSize, chunks: 1},
+		{name: "a chunk and a byte", size: chunkSize + 1, chunks: 2},
+		{name: "exactly three chunks", size: 3 * chunkSize, chunks: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plain := make([]byte, tt.size)
+			if _, err := rand.Read(plain); err != nil {
+				t.Fatal(err)
+			}
+			encrypted := encrypt(t, plain, key)
+			if _, parts := chunks(t, encrypted); len(parts) != tt.chunks {
+				t.Errorf("got %d chunks, want %d", len(parts), tt.chunks)
+			}
+
+			var decrypted bytes.Buffer
+			if err := Decrypt(&decrypted, bytes.NewReader(encrypted), key); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(decrypted.Bytes(), plain) {
+				t.Errorf("decrypted %d bytes, not the %d encrypted", decrypted.Len(), len(plain))
+			}
+		})
+	}
+}
+
+func TestDecryptTampered(t *testing.T) {
+	key := testKey(t)
+	plain := make([]byte, 2*chunkSize+100)
+	encrypted := encrypt(t, plain, key)
+	header, parts := chunks(t, encrypted)
+	if len(parts) != 3 {
+		t.Fatalf("got %d chunks, want 3", len(parts))
+	}
+	join := func(parts ...[]byte) []byte {
+		return bytes.Join(append([][]byte{header}, parts...), nil)
+	}
+
+	tests := []struct {
+		name      string
+		encrypted []byte
+		truncated bool
+	}{
+		{name: "header only", encrypted: join(), truncated: true},
+		{name: "cut after a full chunk", encrypted: join(parts[0]), truncated: true},
+		{name: "cut after two full chunks", encrypted: join(parts[0], parts[1]), truncated: true},
+		{name: "cut inside a chunk", encrypted: join(parts[0], parts[1][:100]), truncated: true},
+		{name: "cut inside the header", encrypted: header[:5], truncated: true},
+		{name: "chunks reordered", encrypted: join(parts[1], parts[0], parts[2])},
+		{name: "chunk dropped", encrypted: join(parts[0], parts[2])},
+		{name: "chunk repeated", encrypted: join(parts[0], parts[0], parts[1], parts[2])},
+		{name: "data after the last chunk", encrypted: join(parts[0], parts[1], parts[2], parts[0])},
+		{name: "other key", encrypted: encrypt(t, plain, testKey(t))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var decrypted bytes.Buffer
+			err := Decrypt(&decrypted, bytes.NewReader(tt.encrypted), key)
+			if err == nil {
+				t.Fatal("decrypted a tampered backup")
+			}
+			if truncated := errors.Is(err, ErrTruncated); truncated != tt.truncated {
+				t.Errorf("err = %v, want truncated %v", err, tt.truncated)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package billing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// EventType identifies a subscription change reported by the payment provider
+type EventType string
+
+const (
+	SubscriptionActivated EventType = "subscription.activated" // paid for a new or renewed period
+	PaymentFailed         EventType = "subscription.payment_failed"
+	SubscriptionCanceled  EventType = "subscription.canceled"
+)
+
+// Event is a subscription change reported by the payment provider
+type Event struct {
+	Type             EventType  `json:"type"`
+	Reference        string     `json:"reference"` // passed to Checkout, identifies the books
+	Plan             string     `json:"plan"`
+	SubscriptionID   string     `json:"subscription_id"`
+	CurrentPeriodEnd *time.Time `json:"current_period_end,omitempty"`
+}
+
+// Provider collects subscription payments
+type Provider interface {
+	// Checkout returns the URL of a payment page for subscribing to a plan
+	Checkout(plan, reference, email string) (string, error)
+	// ParseEvent verifies and decodes a webhook request sent by the provider
+	ParseEvent(r *http.Request) (*Event, error)
+}
+
+var (
+	// ErrNotConfigured is returned by the mock provider for webhooks, which cannot be verified
+	ErrNotConfigured = errors.New("payment provider is not configured")
+	// ErrInvalidSignature is returned for webhooks that were not signed by the provider
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
+
+// maxEventSize limits the size of webhook bodies
+const maxEventSize = 64 * 1024
+
+// MockProvider is a mock implementation for development
+type MockProvider struct{}
+
+// Checkout logs the checkout and returns a placeholder URL (mock implementation)
+func (m *MockProvider) Checkout(plan, reference, email string) (string, error) {
+	log.Printf("Mock checkout of plan %s for %s (%s)", plan, reference, email)
+	return "https://billing.example.com/checkout?reference=" + url.QueryEscape(reference), nil
+}
+
+// ParseEvent rejects webhooks, since unsigned events would let anyone activate a plan
+func (m *MockProvider) ParseEvent(r *http.Request) (*Event, error) {
+	return nil, ErrNotConfigured
+}
+
+// HostedProvider integrates a payment provider through a hosted checkout page and webhooks
+// signed with HMAC-SHA256 of the body in the X-Billing-Signature header ("sha256=<hex>"). A small
+// adapter in front of Flutterwave, Paystack, Stripe or mobile money translates their callbacks
+// into Events.
+type HostedProvider struct {
+	CheckoutURL   string // receives plan, reference and email query parameters
+	WebhookSecret string
+}
+
+// NewHostedProvider creates a new HostedProvider
+func NewHostedProvider(checkoutURL, webhookSecret string) *HostedProvider {
+	return &HostedProvider{
+		CheckoutURL:   checkoutURL,
+		WebhookSecret: webhookSecret,
+	}
+}
+
+// Checkout returns the checkout URL for a plan
+func (p *HostedProvider) Checkout(plan, reference, email string) (string, error) {
+	checkout, err := url.Parse(p.CheckoutURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid checkout URL: %w", err)
+	}
+	query := checkout.Query()
+	query.Set("plan", plan)
+	query.Set("reference", reference)
+	if email != "" {
+		query.Set("email", email)
+	}
+	checkout.RawQuery = query.Encode()
+	return checkout.String(), nil
+}
+
+// ParseEvent verifies the signature of a webhook and decodes its event
+func (p *HostedProvider) ParseEvent(r *http.Request) (*Event, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxEventSize))
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := hex.DecodeString(strings.TrimPrefix(r.Header.Get("X-Billing-Signature"), "sha256="))
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	mac := hmac.New(sha256.New, []byte(p.WebhookSecret))
+	mac.Write(body)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("invalid event: %w", err)
+	}
+	return &event, nil
+}
@@ -0,0 +1,80 @@
+// Package dbstats instruments database queries: it logs slow queries and counts every query so
+// N+1 patterns can be spotted while profiling
+package dbstats
+
+import (
+	"errors"
+	"log"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// startKey stores when a statement started on the statement's instance
+const startKey = "dbstats:start"
+
+// Counter is a running total of the queries run and the time spent in them. It is a GORM
+// plugin counting the statements run through the database it is used on.
+type Counter struct {
+	queries atomic.Int64
+	nanos   atomic.Int64
+}
+
+// Add counts a query that took elapsed
+func (c *Counter) Add(elapsed time.Duration) {
+	c.queries.Add(1)
+	c.nanos.Add(int64(elapsed))
+}
+
+// Snapshot returns the queries counted so far and their total duration
+func (c *Counter) Snapshot() (int64, time.Duration) {
+	return c.queries.Load(), time.Duration(c.nanos.Load())
+}
+
+// Name implements gorm.Plugin
+func (c *Counter) Name() string {
+	return "dbstats"
+}
+
+// Initialize implements gorm.Plugin, timing every create, query, update, delete, row and raw
+// statement
+func (c *Counter) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("gorm:create").Register("dbstats:start", c.start),
+		callbacks.Create().After("gorm:create").Register("dbstats:finish", c.finish),
+		callbacks.Query().Before("gorm:query").Register("dbstats:start", c.start),
+		callbacks.Query().After("gorm:query").Register("dbstats:finish", c.finish),
+		callbacks.Update().Before("gorm:update").Register("dbstats:start", c.start),
+		callbacks.Update().After("gorm:update").Register("dbstats:finish", c.finish),
+		callbacks.Delete().Before("gorm:delete").Register("dbstats:start", c.start),
+		callbacks.Delete().After("gorm:delete").Register("dbstats:finish", c.finish),
+		callbacks.Row().Before("gorm:row").Register("dbstats:start", c.start),
+		callbacks.Row().After("gorm:row").Register("dbstats:finish", c.finish),
+		callbacks.Raw().Before("gorm:raw").Register("dbstats:start", c.start),
+		callbacks.Raw().After("gorm:raw").Register("dbstats:finish", c.finish),
+	)
+}
+
+func (c *Counter) start(db *gorm.DB) {
+	db.InstanceSet(startKey, time.Now())
+}
+
+func (c *Counter) finish(db *gorm.DB) {
+	if start, ok := db.InstanceGet(startKey); ok {
+		c.Add(time.Since(start.(time.Time)))
+	}
+}
+
+// NewLogger returns a GORM logger that logs failed queries and queries slower than
+// slowThreshold (0 disables slow query logging) with their SQL
+func NewLogger(slowThreshold time.Duration) logger.Interface {
+	return logger.New(log.New(os.Stdout, "\r\n", log.LstdFlags), logger.Config{
+		SlowThreshold:             slowThreshold,
+		LogLevel:                  logger.Warn,
+		IgnoreRecordNotFoundError: true,
+	})
+}
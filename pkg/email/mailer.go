@@ -3,11 +3,14 @@ package email
 import (
 	"fmt"
 	"log"
+	"net/smtp"
+	"strings"
 )
 
 // Mailer interface for sending emails
 type Mailer interface {
 	SendOTP(email, otp string) error
+	Send(to, subject, body string) error
 }
 
 // MockMailer is a mock implementation for development
@@ -19,3 +22,43 @@ func (m *MockMailer) SendOTP(email, otp string) error {
 	fmt.Printf("📧 Mock Email to %s: Your OTP is %s\n", email, otp)
 	return nil
 }
+
+// Send sends an email (mock implementation)
+func (m *MockMailer) Send(to, subject, body string) error {
+	log.Printf("Mock email sent to %s: %s", to, subject)
+	return nil
+}
+
+// SMTPMailer sends emails through an SMTP server
+type SMTPMailer struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// SendOTP sends a password reset OTP email
+func (m *SMTPMailer) SendOTP(email, otp string) error {
+	body := fmt.Sprintf("Your password reset code is %s.\r\n\r\nIt expires in 10 minutes. If you did not request it, you can ignore this email.", otp)
+	return m.Send(email, "Your password reset code", body)
+}
+
+// Send sends a plain text email
+func (m *SMTPMailer) Send(to, subject, body string) error {
+	msg := strings.Join([]string{
+		"From: " + m.From,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=\"utf-8\"",
+		"",
+		body,
+	}, "\r\n")
+
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+	return smtp.SendMail(m.Host+":"+m.Port, auth, m.From, []string{to}, []byte(msg))
+}
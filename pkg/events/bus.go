@@ -0,0 +1,126 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"mineral/data"
+	"sync"
+	"time"
+)
+
+// Name identifies a kind of event
+type Name string
+
+// Events published by the handlers. The outbox publishes the same events with Data as the JSON
+// encoded json.RawMessage of the same value.
+const (
+	IncomeCreated   Name = "income.created"   // Data is the *data.Income
+	PaymentRecorded Name = "payment.recorded" // Data is a Payment
+	StockLow        Name = "stock.low"        // Data is the *data.InventoryItem
+
+	// Published through the outbox only, with Data the JSON of a data.ExpenseSignOffEvent
+	SignOffRequired  Name = "expense.sign_off_required"
+	ExpenseSignedOff Name = "expense.signed_off"
+)
+
+// Names lists the published events, e.g. for validating webhook subscriptions
+var Names = []Name{IncomeCreated, PaymentRecorded, StockLow, SignOffRequired, ExpenseSignedOff}
+
+// Event is something that happened to an organization's books
+type Event struct {
+	Name       Name        `json:"event"`
+	Resource   string      `json:"resource"`
+	ResourceID uint        `json:"resource_id"`
+	Data       interface{} `json:"data"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	UserID     uint        `json:"-"` // owner of the books
+	ActorID    uint        `json:"-"` // user who caused the event
+	IPAddress  string      `json:"-"`
+}
+
+// Payment is the data of a PaymentRecorded event, shared with the payload of payment.recorded
+// outbox messages
+type Payment = data.PaymentRecorded
+
+// Subscriber handles a published event with the context it was published with. Subscribers run
+// on the publishing request, so slow work such as HTTP calls should be queued as a job.
+type Subscriber func(ctx context.Context, event Event) error
+
+type subscription struct {
+	name    string
+	handler Subscriber
+}
+
+// Bus delivers published events to the subscribers registered for them
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[Name][]subscription
+	all         []subscription
+	errorLog    *log.Logger
+}
+
+// NewBus creates a new Bus that logs subscriber failures to errorLog
+func NewBus(errorLog *log.Logger) *Bus {
+	return &Bus{
+		subscribers: make(map[Name][]subscription),
+		errorLog:    errorLog,
+	}
+}
+
+// Subscribe registers a named subscriber for an event
+func (b *Bus) Subscribe(event Name, name string, handler Subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[event] = append(b.subscribers[event], subscription{name: name, handler: handler})
+}
+
+// SubscribeAll registers a named subscriber for every event
+func (b *Bus) SubscribeAll(name string, handler Subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.all = append(b.all, subscription{name: name, handler: handler})
+}
+
+// Publish delivers an event to its subscribers in registration order, then to the subscribers
+// of all events. Failures are logged rather than returned so a subscriber never breaks the
+// action that published the event. Publishing on a nil Bus does nothing.
+func (b *Bus) Publish(ctx context.Context, event Event) {
+	if b == nil {
+		return
+	}
+	b.Dispatch(ctx, event)
+}
+
+// Dispatch delivers an event like Publish and also returns the subscribers' failures, so the
+// caller can deliver it again. Subscribers that succeeded see the event again too.
+func (b *Bus) Dispatch(ctx context.Context, event Event) error {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	b.mu.RLock()
+	subscribers := append(append([]subscription{}, b.subscribers[event.Name]...), b.all...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, subscriber := range subscribers {
+		if err := deliver(ctx, subscriber, event); err != nil {
+			b.errorLog.Printf("event subscriber %s failed on %s %d: %v", subscriber.name, event.Name, event.ResourceID, err)
+			errs = append(errs, fmt.Errorf("%s: %w", subscriber.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deliver invokes a subscriber, turning panics into errors
+func deliver(ctx context.Context, subscriber subscription, event Event) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("subscriber panicked: %v", p)
+		}
+	}()
+
+	return subscriber.handler(ctx, event)
+}
@@ -0,0 +1,85 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"time"
+)
+
+// maxLineLength is the maximum length of a content line in octets before it is folded
+const maxLineLength = 75
+
+// Event is an all-day calendar event
+type Event struct {
+	UID         string // stable identifier, so calendar apps update the event instead of duplicating it
+	Date        time.Time
+	Summary     string
+	Description string
+}
+
+// Calendar is a minimal iCalendar (RFC 5545) feed of all-day events
+type Calendar struct {
+	name   string
+	events []Event
+}
+
+// New creates an empty calendar with a display name
+func New(name string) *Calendar {
+	return &Calendar{name: name}
+}
+
+// Add adds an event to the calendar
+func (c *Calendar) Add(event Event) {
+	c.events = append(c.events, event)
+}
+
+// Bytes renders the calendar as an iCalendar file
+func (c *Calendar) Bytes() []byte {
+	var buf bytes.Buffer
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	writeLine(&buf, "BEGIN:VCALENDAR")
+	writeLine(&buf, "VERSION:2.0")
+	writeLine(&buf, "PRODID:-//Mineral//Mining Finance//EN")
+	writeLine(&buf, "CALSCALE:GREGORIAN")
+	writeLine(&buf, "METHOD:PUBLISH")
+	writeLine(&buf, "X-WR-CALNAME:"+escape(c.name))
+	for _, event := range c.events {
+		writeLine(&buf, "BEGIN:VEVENT")
+		writeLine(&buf, "UID:"+escape(event.UID))
+		writeLine(&buf, "DTSTAMP:"+stamp)
+		writeLine(&buf, "DTSTART;VALUE=DATE:"+event.Date.Format("20060102"))
+		writeLine(&buf, "DTEND;VALUE=DATE:"+event.Date.AddDate(0, 0, 1).Format("20060102"))
+		writeLine(&buf, "SUMMARY:"+escape(event.Summary))
+		if event.Description != "" {
+			writeLine(&buf, "DESCRIPTION:"+escape(event.Description))
+		}
+		writeLine(&buf, "TRANSP:TRANSPARENT")
+		writeLine(&buf, "END:VEVENT")
+	}
+	writeLine(&buf, "END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// escape escapes text values as required by RFC 5545
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// writeLine writes a content line, folding it onto continuation lines that start with a space
+// when it is longer than 75 octets. Lines are only folded between UTF-8 characters.
+func writeLine(buf *bytes.Buffer, line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineLength - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
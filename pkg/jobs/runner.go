@@ -0,0 +1,93 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"mineral/data"
+	"mineral/pkg/tracing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// HandlerFunc processes the JSON payload of a job. ctx carries the job's trace span.
+type HandlerFunc func(ctx context.Context, payload []byte) error
+
+// Runner dispatches queued jobs to the handler registered for their type
+type Runner struct {
+	queue    data.JobInterface
+	handlers map[string]HandlerFunc
+	errorLog *log.Logger
+}
+
+// NewRunner creates a new Runner reading from queue
+func NewRunner(queue data.JobInterface, errorLog *log.Logger) *Runner {
+	return &Runner{
+		queue:    queue,
+		handlers: make(map[string]HandlerFunc),
+		errorLog: errorLog,
+	}
+}
+
+// Register sets the handler for a job type
+func (r *Runner) Register(jobType string, handler HandlerFunc) {
+	r.handlers[jobType] = handler
+}
+
+// RunPending processes due jobs until the queue is empty. Failed jobs are retried with
+// exponential backoff until they reach their maximum attempts.
+func (r *Runner) RunPending(ctx context.Context) error {
+	for {
+		job, err := r.queue.ClaimNext(ctx)
+		if err != nil {
+			return err
+		}
+		if job == nil {
+			return nil
+		}
+
+		if err := r.run(ctx, job); err != nil {
+			r.errorLog.Printf("job %d (%s) attempt %d failed: %v", job.ID, job.Type, job.Attempts, err)
+
+			var retryAt *time.Time
+			if job.Attempts < job.MaxAttempts {
+				next := time.Now().Add(backoff(job.Attempts))
+				retryAt = &next
+			}
+			if err := r.queue.Fail(ctx, job.ID, err.Error(), retryAt); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := r.queue.Complete(ctx, job.ID); err != nil {
+			return err
+		}
+	}
+}
+
+// run invokes the handler for a job in a span, turning panics into errors
+func (r *Runner) run(ctx context.Context, job *data.Job) (err error) {
+	handler, ok := r.handlers[job.Type]
+	if !ok {
+		return fmt.Errorf("no handler registered for job type %s", job.Type)
+	}
+
+	ctx, span := tracing.Start(ctx, "job "+job.Type,
+		attribute.Int("job.id", int(job.ID)),
+		attribute.Int("job.attempt", job.Attempts))
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("job panicked: %v", p)
+		}
+		tracing.End(span, err)
+	}()
+
+	return handler(ctx, []byte(job.Payload))
+}
+
+// backoff returns the wait before retrying after the given number of attempts: 30s, 1m, 2m, ...
+func backoff(attempts int) time.Duration {
+	return time.Duration(1<<(attempts-1)) * 30 * time.Second
+}
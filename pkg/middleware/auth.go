@@ -1,49 +1,113 @@
 package middleware
 
 import (
+	"context"
 	"mineral/pkg/utils"
 	"net/http"
 	"strconv"
 	"strings"
 )
 
-// AuthMiddleware validates JWT tokens
+// TokenRevocationChecker reports whether the access token with a hash was revoked before it
+// expired, e.g. on logout
+type TokenRevocationChecker func(ctx context.Context, tokenHash string) (bool, error)
+
+// tokenRevoked checks tokens against the revoked tokens; nil accepts every valid token
+var tokenRevoked TokenRevocationChecker
+
+// SetTokenRevocation makes AuthMiddleware reject the tokens check reports revoked. Keep revoked
+// tokens in storage shared by every replica, such as the database, so a logout holds on all.
+func SetTokenRevocation(check TokenRevocationChecker) {
+	tokenRevoked = check
+}
+
+// AuthMiddleware validates JWT tokens, from the Authorization header or, for browser clients
+// using cookie sessions, the session cookie. Requests authenticated by the cookie other than
+// GET, HEAD and OPTIONS must carry the session's CSRF token in the X-CSRF-Token header. Tokens
+// revoked before they expire are rejected.
 func AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			utils.WriteErrorResponse(w, "Authorization header required", http.StatusUnauthorized)
+		token, ok := requestToken(w, r)
+		if !ok {
 			return
 		}
 
-		// Extract token from "Bearer <token>"
-		tokenParts := strings.Split(authHeader, " ")
-		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			utils.WriteErrorResponse(w, "Invalid authorization header format", http.StatusUnauthorized)
+		claims, err := utils.ValidateJWT(token)
+		if err != nil {
+			utils.WriteErrorResponse(w, "Invalid token", http.StatusUnauthorized)
 			return
 		}
+		if tokenRevoked != nil {
+			revoked, err := tokenRevoked(r.Context(), utils.HashToken(token))
+			if err != nil {
+				utils.WriteInternalServerError(w, "Failed to validate token")
+				return
+			}
+			if revoked {
+				utils.WriteErrorResponse(w, "Token has been revoked", http.StatusUnauthorized)
+				return
+			}
+		}
 
-		token := tokenParts[1]
-		claims, err := utils.ValidateJWT(token)
+		userID, err := strconv.ParseUint(claims.UserID, 10, 64)
 		if err != nil {
 			utils.WriteErrorResponse(w, "Invalid token", http.StatusUnauthorized)
 			return
 		}
 
 		// Add user info to request context
-		r.Header.Set("X-User-ID", claims.UserID)
-		r.Header.Set("X-User-Email", claims.Email)
-		r.Header.Set("X-User-Role", claims.Role)
-
-		next.ServeHTTP(w, r)
+		ctx := ContextWithUser(r.Context(), AuthUser{ID: uint(userID), Email: claims.Email, Role: claims.Role})
+		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// requestToken returns the token a request is authenticated with, writing the error response
+// and returning false when it has none or fails the CSRF check
+func requestToken(w http.ResponseWriter, r *http.Request) (string, bool) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		cookie, err := r.Cookie(utils.SessionCookie)
+		if err != nil || cookie.Value == "" {
+			utils.WriteErrorResponse(w, "Authorization header required", http.StatusUnauthorized)
+			return "", false
+		}
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			if !utils.VerifyCSRFToken(cookie.Value, r.Header.Get(utils.CSRFHeader)) {
+				utils.WriteForbiddenError(w, "Invalid or missing CSRF token")
+				return "", false
+			}
+		}
+		return cookie.Value, true
+	}
+
+	// Extract token from "Bearer <token>"
+	tokenParts := strings.Split(authHeader, " ")
+	if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
+		utils.WriteErrorResponse(w, "Invalid authorization header format", http.StatusUnauthorized)
+		return "", false
+	}
+	return tokenParts[1], true
+}
+
+// RequestToken returns the token an authenticated request carries, from the Authorization header
+// or the session cookie, e.g. to revoke it
+func RequestToken(r *http.Request) string {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return token
+	}
+	if cookie, err := r.Cookie(utils.SessionCookie); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
 // AdminMiddleware checks if user has admin role
 func AdminMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		userRole := r.Header.Get("X-User-Role")
-		if userRole != "admin" {
+		user, _ := UserFromRequest(r)
+		if user.Role != "admin" {
 			utils.WriteErrorResponse(w, "Admin access required", http.StatusForbidden)
 			return
 		}
@@ -52,16 +116,12 @@ func AdminMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-// GetUserIDFromRequest extracts user ID from request headers
+// GetUserIDFromRequest returns the ID of the user whose books the request works on: the
+// authenticated user, or the owner of the organization they work in
 func GetUserIDFromRequest(r *http.Request) uint {
-	userIDStr := r.Header.Get("X-User-ID")
-	if userIDStr == "" {
-		return 0
-	}
-
-	userID, err := strconv.ParseUint(userIDStr, 10, 64)
-	if err != nil {
-		return 0
+	if access := accessFromRequest(r); access != nil {
+		return access.OwnerID
 	}
-	return uint(userID)
+	user, _ := UserFromRequest(r)
+	return user.ID
 }
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+)
+
+// contextKey keys what the authentication middleware keeps in a request's context. Unlike request
+// headers, clients can't set these values.
+type contextKey int
+
+const (
+	userKey   contextKey = iota // the authenticated user, an AuthUser
+	accessKey                   // the books the request works on, an *OrganizationAccess
+	logKey                      // what LoggingMiddleware logs about the request, a *loggedRequest
+)
+
+// AuthUser is the user a request is authenticated as, from the claims of its token
+type AuthUser struct {
+	ID    uint
+	Email string
+	Role  string
+}
+
+// ContextWithUser returns a copy of ctx authenticated as user, who is logged with the request
+func ContextWithUser(ctx context.Context, user AuthUser) context.Context {
+	if logged, ok := ctx.Value(logKey).(*loggedRequest); ok {
+		logged.userID = user.ID
+	}
+	return context.WithValue(ctx, userKey, user)
+}
+
+// UserFromRequest returns the user a request is authenticated as, and false when it isn't
+func UserFromRequest(r *http.Request) (AuthUser, bool) {
+	user, ok := r.Context().Value(userKey).(AuthUser)
+	return user, ok
+}
+
+// contextWithAccess returns a copy of ctx working on the books of access.OwnerID
+func contextWithAccess(ctx context.Context, access *OrganizationAccess) context.Context {
+	return context.WithValue(ctx, accessKey, access)
+}
+
+// accessFromRequest returns the books a request works on and what the acting user may do with
+// them, or nil before OrganizationContext has run
+func accessFromRequest(r *http.Request) *OrganizationAccess {
+	access, _ := r.Context().Value(accessKey).(*OrganizationAccess)
+	return access
+}
@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+)
+
+// IdempotentResponse is the stored outcome of a request made with an Idempotency-Key
+type IdempotentResponse struct {
+	Fingerprint string // hash of the method, path, books and body of the request
+	StatusCode  int    // 0 while the request is still being processed
+	ContentType string
+	Body        []byte
+}
+
+// IdempotencyStore keeps the requests made with an Idempotency-Key
+type IdempotencyStore interface {
+	// Begin records a request, returning nil when the key is new or the earlier request made with it
+	Begin(ctx context.Context, actorID uint, key, fingerprint string) (*IdempotentResponse, error)
+	Complete(ctx context.Context, actorID uint, key string, statusCode int, contentType string, body []byte) error
+	Release(ctx context.Context, actorID uint, key string) error
+}
+
+// idempotencyStore keeps the requests in storage shared by every replica; nil disables
+// idempotency keys
+var idempotencyStore IdempotencyStore
+
+// maxIdempotencyKeyLength is the longest Idempotency-Key accepted
+const maxIdempotencyKeyLength = 255
+
+// SetIdempotencyStore enables idempotency keys with requests kept in store. Keep them in storage
+// shared by every replica, such as the database, so a retry reaching another replica is
+// recognized.
+func SetIdempotencyStore(store IdempotencyStore) {
+	idempotencyStore = store
+}
+
+// Idempotency makes POST and PATCH requests with an Idempotency-Key header safe to retry, e.g.
+// after a connection dropped before the response arrived. A retry gets the response of the
+// first request, marked with Idempotent-Replayed, instead of running again. Keys are scoped to
+// the acting user; reusing one for a different request is rejected, and so is a retry while the
+// first request is still being processed. Requests that fail with a server error are forgotten
+// so they can be retried.
+func Idempotency(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("Idempotency-Key")
+		if idempotencyStore == nil || key == "" || (r.Method != http.MethodPost && r.Method != http.MethodPatch) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			utils.WriteValidationError(w, "Idempotency-Key must be at most 255 characters")
+			return
+		}
+		actorID := GetActorIDFromRequest(r)
+		if actorID == 0 {
+			utils.WriteUnauthorizedError(w, "User not authenticated")
+			return
+		}
+
+		body, err := bufferBody(r)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			utils.WriteErrorResponse(w, "Request body is too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err != nil {
+			utils.WriteValidationError(w, "Failed to read request body")
+			return
+		}
+		fingerprint := requestFingerprint(r, body)
+
+		earlier, err := idempotencyStore.Begin(r.Context(), actorID, key, fingerprint)
+		if err != nil {
+			utils.WriteInternalServerError(w, "Failed to check Idempotency-Key")
+			return
+		}
+		if earlier != nil {
+			switch {
+			case earlier.Fingerprint != fingerprint:
+				utils.WriteErrorResponse(w, "Idempotency-Key was already used for a different request", http.StatusUnprocessableEntity)
+			case earlier.StatusCode == 0:
+				utils.WriteErrorResponse(w, "A request with this Idempotency-Key is still being processed", http.StatusConflict)
+			default:
+				if earlier.ContentType != "" {
+					w.Header().Set("Content-Type", earlier.ContentType)
+				}
+				w.Header().Set("Idempotent-Replayed", "true")
+				w.WriteHeader(earlier.StatusCode)
+				w.Write(earlier.Body)
+			}
+			return
+		}
+
+		recorder := &idempotencyRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		completed := false
+		defer func() {
+			// Forget requests that failed with a server error or panicked
+			if !completed {
+				if err := idempotencyStore.Release(r.Context(), actorID, key); err != nil {
+					log.Printf("Failed to release Idempotency-Key: %v", err)
+				}
+			}
+		}()
+
+		next.ServeHTTP(recorder, r)
+
+		if recorder.statusCode >= http.StatusInternalServerError {
+			return
+		}
+		completed = true
+		if err := idempotencyStore.Complete(r.Context(), actorID, key, recorder.statusCode, recorder.Header().Get("Content-Type"), recorder.body.Bytes()); err != nil {
+			log.Printf("Failed to store response for Idempotency-Key: %v", err)
+		}
+	})
+}
+
+// requestFingerprint hashes what makes a request the same request: its method, path, the books
+// it works on and its body
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	io.WriteString(hash, r.Method+" "+r.URL.Path+"\n"+strconv.FormatUint(uint64(GetUserIDFromRequest(r)), 10)+"\n")
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// idempotencyRecorder passes a response through while keeping a copy to replay
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (w *idempotencyRecorder) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.statusCode = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *idempotencyRecorder) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
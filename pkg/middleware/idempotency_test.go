@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// memoryIdempotencyStore keeps the requests made with an Idempotency-Key in memory
+type memoryIdempotencyStore struct {
+	mu        sync.Mutex
+	responses map[string]*IdempotentResponse
+}
+
+func (s *memoryIdempotencyStore) Begin(ctx context.Context, actorID uint, key, fingerprint string) (*IdempotentResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if earlier, ok := s.responses[key]; ok {
+		return earlier, nil
+	}
+	s.responses[key] = &IdempotentResponse{Fingerprint: fingerprint}
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(ctx context.Context, actorID uint, key string, statusCode int, contentType string, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	response := s.responses[key]
+	response.StatusCode, response.ContentType, response.Body = statusCode, contentType, body
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(ctx context.Context, actorID uint, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.responses, key)
+	return nil
+}
+
+func TestIdempotencyUpload(t *testing.T) {
+	SetIdempotencyStore(&memoryIdempotencyStore{responses: make(map[string]*IdempotentResponse)})
+	defer SetIdempotencyStore(nil)
+
+	// The handler reports how much of the body it could read, as the upload handlers would
+	received := func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(strconv.Itoa(len(body))))
+	}
+	// As routed: LimitBody on every request, Idempotency on the authenticated group and
+	// AllowUpload on the upload routes
+	route := func(handler http.Handler) http.Handler {
+		return LimitBody(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r = r.WithContext(ContextWithUser(r.Context(), AuthUser{ID: 1}))
+			Idempotency(handler).ServeHTTP(w, r)
+		}))
+	}
+	upload := route(AllowUpload(http.HandlerFunc(received)))
+	form := route(http.HandlerFunc(received))
+
+	photo := bytes.Repeat([]byte("x"), 2<<20)
+	tests := []struct {
+		name     string
+		handler  http.Handler
+		key      string
+		status   int
+		replayed bool
+	}{
+		{"upload", upload, "photo-1", http.StatusCreated, false},
+		{"upload retried", upload, "photo-1", http.StatusCreated, true},
+		{"upload without a key", upload, "", http.StatusCreated, false},
+		{"body over the limit of a route without uploads", form, "form-1", http.StatusRequestEntityTooLarge, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/attachments", bytes.NewReader(photo))
+			if tt.key != "" {
+				req.Header.Set("Idempotency-Key", tt.key)
+			}
+			rr := httptest.NewRecorder()
+			tt.handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.status {
+				t.Fatalf("status %d, want %d: %s", rr.Code, tt.status, rr.Body.String())
+			}
+			if tt.status == http.StatusCreated && rr.Body.String() != strconv.Itoa(len(photo)) {
+				t.Errorf("handler read %s bytes, want %d", rr.Body.String(), len(photo))
+			}
+			if replayed := rr.Header().Get("Idempotent-Replayed") == "true"; replayed != tt.replayed {
+				t.Errorf("replayed %v, want %v", replayed, tt.replayed)
+			}
+		})
+	}
+}
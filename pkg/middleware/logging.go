@@ -1,25 +1,80 @@
 package middleware
 
 import (
-	"log"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
 	"net/http"
+	"os"
 	"time"
 )
 
-// LoggingMiddleware logs HTTP requests
+// requestLogger writes one JSON line per request to stdout
+var requestLogger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+// loggedRequest collects what the middleware after LoggingMiddleware learns about a request for
+// its log line
+type loggedRequest struct {
+	userID uint // the authenticated user, 0 when the request isn't authenticated
+}
+
+// LoggingMiddleware logs HTTP requests as JSON with the method, path, status, latency, the
+// authenticated user and a request ID, taken from the X-Request-ID header or generated, which is
+// echoed in the response and in error responses so clients can quote it in support tickets. With
+// SetBodyLogging the request bodies are logged too, after redaction.
 func LoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
+		requestID := r.Header.Get("X-Request-ID")
+		if requestID == "" || len(requestID) > 64 {
+			requestID = newRequestID()
+			r.Header.Set("X-Request-ID", requestID)
+		}
+		w.Header().Set("X-Request-ID", requestID)
+
+		var body string
+		if bodyLogging != nil {
+			body = readLoggedBody(r)
+		}
+
 		// Create a response writer wrapper to capture status code
 		wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		logged := &loggedRequest{}
 
-		next.ServeHTTP(wrapped, r)
+		next.ServeHTTP(wrapped, r.WithContext(context.WithValue(r.Context(), logKey, logged)))
 
-		log.Printf("%s %s %d %v", r.Method, r.URL.Path, wrapped.statusCode, time.Since(start))
+		attrs := []slog.Attr{
+			slog.String("request_id", requestID),
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", wrapped.statusCode),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+		}
+		if logged.userID != 0 {
+			attrs = append(attrs, slog.Uint64("user_id", uint64(logged.userID)))
+		}
+		if body != "" {
+			attrs = append(attrs, slog.String("body", body))
+		}
+		level := slog.LevelInfo
+		if wrapped.statusCode >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		requestLogger.LogAttrs(r.Context(), level, "request", attrs...)
 	})
 }
 
+// newRequestID generates a random request ID
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
 type responseWriter struct {
 	http.ResponseWriter
 	statusCode int
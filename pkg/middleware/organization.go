@@ -0,0 +1,178 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"mineral/pkg/utils"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// OrganizationAccess describes what a user may do within an organization
+type OrganizationAccess struct {
+	OwnerID     uint
+	Role        string
+	CanExport   bool
+	ReadOnly    bool     // the user may only read, e.g. an auditor
+	Permissions []string // what the user may do with the books; owners may do anything
+	AllowedIPs  []string // CIDR ranges the user may connect from; empty means any
+}
+
+// OrganizationResolver returns the access of a user within an organization
+type OrganizationResolver func(ctx context.Context, organizationID, userID uint) (*OrganizationAccess, error)
+
+// OrganizationContext switches the request to the organization named in the X-Organization-ID
+// header. The authenticated user stays the actor while the organization owner becomes the user
+// of the request, so every handler scopes its queries to the organization's books.
+func OrganizationContext(resolve OrganizationResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, _ := UserFromRequest(r)
+			orgIDStr := r.Header.Get("X-Organization-ID")
+			if orgIDStr == "" {
+				// Without an organization users work on their own books
+				own := &OrganizationAccess{OwnerID: user.ID, Role: "owner", CanExport: true}
+				next.ServeHTTP(w, r.WithContext(contextWithAccess(r.Context(), own)))
+				return
+			}
+
+			orgID, err := strconv.ParseUint(orgIDStr, 10, 32)
+			if err != nil {
+				utils.WriteValidationError(w, "Invalid organization ID")
+				return
+			}
+
+			access, err := resolve(r.Context(), uint(orgID), user.ID)
+			if err != nil {
+				utils.WriteForbiddenError(w, "You are not a member of this organization")
+				return
+			}
+			if !ipAllowed(GetClientIP(r), access.AllowedIPs) {
+				utils.WriteForbiddenError(w, "Access to this organization is not allowed from your IP address")
+				return
+			}
+			if access.ReadOnly && r.Method != http.MethodGet && r.Method != http.MethodHead && r.Method != http.MethodOptions {
+				utils.WriteForbiddenError(w, "You have read-only access to this organization")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(contextWithAccess(r.Context(), access)))
+		})
+	}
+}
+
+// GetActorIDFromRequest extracts the ID of the authenticated user acting on the request,
+// which differs from GetUserIDFromRequest when working in another user's organization
+func GetActorIDFromRequest(r *http.Request) uint {
+	user, ok := UserFromRequest(r)
+	if !ok {
+		return GetUserIDFromRequest(r)
+	}
+	return user.ID
+}
+
+// GetOrgRoleFromRequest extracts the organization role of the acting user
+func GetOrgRoleFromRequest(r *http.Request) string {
+	if access := accessFromRequest(r); access != nil {
+		return access.Role
+	}
+	return ""
+}
+
+// IsReadOnlyRequest reports whether the acting user has read-only access to the books, e.g. so
+// generated documents can be watermarked
+func IsReadOnlyRequest(r *http.Request) bool {
+	access := accessFromRequest(r)
+	return access != nil && access.ReadOnly
+}
+
+// HasPermission reports whether the acting user holds a permission in the books they work on.
+// Owners, including users working on their own books, hold every permission.
+func HasPermission(r *http.Request, permission string) bool {
+	access := accessFromRequest(r)
+	if access == nil {
+		return false
+	}
+	if access.Role == "owner" {
+		return true
+	}
+	for _, granted := range access.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionMiddleware rejects requests from members without a permission
+func PermissionMiddleware(permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !HasPermission(r, permission) {
+				utils.WriteForbiddenError(w, fmt.Sprintf("You do not have the %s permission in this organization", permission))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireExportPermission rejects export and download requests from members without the export permission
+func RequireExportPermission(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if access := accessFromRequest(r); access == nil || !access.CanExport {
+			utils.WriteForbiddenError(w, "You do not have permission to export data from this organization")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// trustProxyHeaders controls whether client IPs are taken from proxy headers
+var trustProxyHeaders = false
+
+// SetTrustProxyHeaders enables reading client IPs from X-Forwarded-For and X-Real-IP. Only
+// enable it behind a reverse proxy that overwrites these headers, otherwise clients can spoof them.
+func SetTrustProxyHeaders(trust bool) {
+	trustProxyHeaders = trust
+}
+
+// GetClientIP extracts the client IP address
+func GetClientIP(r *http.Request) string {
+	if trustProxyHeaders {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+		}
+		if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
+			return strings.TrimSpace(realIP)
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// ipAllowed reports whether ip falls within one of the CIDR ranges; an empty list allows any address
+func ipAllowed(ip string, cidrs []string) bool {
+	if len(cidrs) == 0 {
+		return true
+	}
+
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err == nil && network.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// queryStats returns the running total of database queries and their duration; nil disables
+// the query debug headers
+var queryStats func() (int64, time.Duration)
+
+// queryWarnAt is the number of queries above which a request is logged as a likely N+1
+var queryWarnAt int64
+
+// queryStatsMu serves one request at a time while query stats are enabled
+var queryStatsMu sync.Mutex
+
+// SetQueryStats enables the X-DB-Queries and X-DB-Time debug headers, computed from a running
+// total of the queries run. Requests that run more than warnAt queries (0 disables) are logged.
+// Requests are served one at a time so the counts are each request's own, so enable it to find
+// N+1 queries during development, not in production or while load testing.
+func SetQueryStats(stats func() (int64, time.Duration), warnAt int) {
+	queryStats = stats
+	queryWarnAt = int64(warnAt)
+}
+
+// QueryStats adds the number of database queries a request ran and the time spent in them to
+// its response headers when enabled with SetQueryStats
+func QueryStats(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if queryStats == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		queryStatsMu.Lock()
+		defer queryStatsMu.Unlock()
+
+		wrapped := &queryStatsWriter{ResponseWriter: w}
+		wrapped.queries, wrapped.elapsed = queryStats()
+		next.ServeHTTP(wrapped, r)
+
+		queries, elapsed := wrapped.counts()
+		if queryWarnAt > 0 && queries > queryWarnAt {
+			log.Printf("%s %s ran %d queries in %v request_id=%s", r.Method, r.URL.Path, queries, elapsed, r.Header.Get("X-Request-ID"))
+		}
+	})
+}
+
+// queryStatsWriter sets the query headers when the response is written
+type queryStatsWriter struct {
+	http.ResponseWriter
+	queries     int64         // total when the request started
+	elapsed     time.Duration // total when the request started
+	wroteHeader bool
+}
+
+// counts returns the queries run and time spent since the request started
+func (w *queryStatsWriter) counts() (int64, time.Duration) {
+	queries, elapsed := queryStats()
+	return queries - w.queries, elapsed - w.elapsed
+}
+
+func (w *queryStatsWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		queries, elapsed := w.counts()
+		w.Header().Set("X-DB-Queries", strconv.FormatInt(queries, 10))
+		w.Header().Set("X-DB-Time", strconv.FormatFloat(float64(elapsed)/float64(time.Millisecond), 'f', 1, 64)+"ms")
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *queryStatsWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimitCounter adds a hit to the counter of key for the window starting at the unix time
+// start and returns the hits counted in that window so far
+type RateLimitCounter func(ctx context.Context, key string, start int64) (int64, error)
+
+// rateLimitCounter counts hits in storage shared by every replica; nil disables rate limiting
+var rateLimitCounter RateLimitCounter
+
+// rateLimits is the number of requests per minute each named limit allows
+var rateLimits map[string]int
+
+// SetRateLimits enables rate limiting with counters kept by counter, and sets the requests per
+// minute each named limit allows (0 disables a limit). Keep the counters in storage shared by
+// every replica, such as the database, so limits hold behind a load balancer.
+func SetRateLimits(counter RateLimitCounter, perMinute map[string]int) {
+	rateLimitCounter = counter
+	rateLimits = perMinute
+}
+
+// RateLimit limits the requests each client IP makes to the routes it wraps to the per-minute
+// rate set for name with SetRateLimits. Requests over the limit get 429 Too Many Requests with
+// a Retry-After header. Requests are let through when the counter can't be reached.
+func RateLimit(name string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limit := rateLimits[name]
+			if rateLimitCounter == nil || limit <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			now := time.Now().Unix()
+			start := now - now%60
+			hits, err := rateLimitCounter(r.Context(), name+":"+GetClientIP(r), start)
+			if err != nil {
+				log.Printf("rate limit %s failed: %v", name, err)
+				next.ServeHTTP(w, r)
+				return
+			}
+			if hits > int64(limit) {
+				w.Header().Set("Retry-After", strconv.FormatInt(start+60-now, 10))
+				utils.WriteErrorResponse(w, "Too many requests. Please try again later", http.StatusTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
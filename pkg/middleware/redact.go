@@ -0,0 +1,193 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// redactedValue replaces the values of sensitive fields in logged bodies
+const redactedValue = "[REDACTED]"
+
+// DefaultRedactedFields are masked in every logged body, whatever else is configured
+var DefaultRedactedFields = []string{
+	"password", "new_password", "current_password", "otp", "token", "id_token", "refresh_token",
+	"csrf_token", "secret", "api_key", "access_key", "secret_key", "code", "invite_code", "admin_code",
+}
+
+// Redactor masks sensitive fields in request bodies before they are logged. Fields are matched
+// by name, case-insensitively, at any depth of a JSON body or in a form body. Bodies that are
+// neither, or can't be parsed, are not logged at all.
+type Redactor struct {
+	fields map[string]bool
+	routes []routeRedaction
+}
+
+// routeRedaction masks more fields, or the whole body, of the requests to a path prefix
+type routeRedaction struct {
+	prefix string
+	fields map[string]bool
+	all    bool
+}
+
+// NewRedactor creates a Redactor masking the default fields and those in spec, a comma
+// separated list of field names masked on every endpoint and path:field entries masked on the
+// endpoints under a path, e.g. "phone,/api/v1/contacts:email,/api/v1/support:*". A field of *
+// leaves the bodies of the endpoints under the path out of the logs entirely.
+func NewRedactor(spec string) (*Redactor, error) {
+	redactor := &Redactor{fields: make(map[string]bool)}
+	for _, field := range DefaultRedactedFields {
+		redactor.fields[field] = true
+	}
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.HasPrefix(entry, "/") {
+			redactor.fields[strings.ToLower(entry)] = true
+			continue
+		}
+
+		path, field, ok := strings.Cut(entry, ":")
+		if !ok || field == "" {
+			return nil, fmt.Errorf("invalid redaction %q, want path:field", entry)
+		}
+		route := redactor.route(path)
+		if field == "*" {
+			route.all = true
+		} else {
+			route.fields[strings.ToLower(field)] = true
+		}
+	}
+
+	return redactor, nil
+}
+
+// route returns the redaction of a path prefix, adding it when it is new
+func (rd *Redactor) route(prefix string) *routeRedaction {
+	for i := range rd.routes {
+		if rd.routes[i].prefix == prefix {
+			return &rd.routes[i]
+		}
+	}
+	rd.routes = append(rd.routes, routeRedaction{prefix: prefix, fields: make(map[string]bool)})
+	return &rd.routes[len(rd.routes)-1]
+}
+
+// Redact returns the body of a request to path as it may be logged, or a placeholder saying why
+// it isn't logged
+func (rd *Redactor) Redact(path, contentType string, body []byte) string {
+	masked := func(field string) bool {
+		field = strings.ToLower(field)
+		if rd.fields[field] {
+			return true
+		}
+		for _, route := range rd.routes {
+			if strings.HasPrefix(path, route.prefix) && route.fields[field] {
+				return true
+			}
+		}
+		return false
+	}
+	for _, route := range rd.routes {
+		if route.all && strings.HasPrefix(path, route.prefix) {
+			return fmt.Sprintf("[%d bytes, not logged]", len(body))
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(contentType, "application/json"):
+		var value interface{}
+		if err := json.Unmarshal(body, &value); err != nil {
+			return fmt.Sprintf("[%d bytes of invalid JSON, not logged]", len(body))
+		}
+		redacted, err := json.Marshal(redactJSON(value, masked))
+		if err != nil {
+			return fmt.Sprintf("[%d bytes, not logged]", len(body))
+		}
+		return string(redacted)
+	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return fmt.Sprintf("[%d bytes of invalid form data, not logged]", len(body))
+		}
+		for field := range values {
+			if masked(field) {
+				values[field] = []string{redactedValue}
+			}
+		}
+		return values.Encode()
+	default:
+		return fmt.Sprintf("[%d bytes of %s, not logged]", len(body), contentType)
+	}
+}
+
+// redactJSON masks the values of the masked fields of a decoded JSON value, at any depth
+func redactJSON(value interface{}, masked func(field string) bool) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for field, nested := range value {
+			if masked(field) {
+				value[field] = redactedValue
+			} else {
+				value[field] = redactJSON(nested, masked)
+			}
+		}
+	case []interface{}:
+		for i, nested := range value {
+			value[i] = redactJSON(nested, masked)
+		}
+	}
+	return value
+}
+
+// bodyLogging redacts the request bodies logged by LoggingMiddleware; nil disables body logging
+var bodyLogging *Redactor
+
+// maxLoggedBody is the largest request body logged; larger bodies are left out, since a
+// truncated body can't be parsed to redact it
+var maxLoggedBody int64
+
+// SetBodyLogging enables logging the bodies of requests up to maxBytes with LoggingMiddleware,
+// redacted by redactor
+func SetBodyLogging(redactor *Redactor, maxBytes int64) {
+	bodyLogging = redactor
+	maxLoggedBody = maxBytes
+}
+
+// readLoggedBody returns the redacted body of a request for the log, leaving the body for the
+// handler to read, or "" when the request has no body to log
+func readLoggedBody(r *http.Request) string {
+	if r.Body == nil || r.Body == http.NoBody || r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return ""
+	}
+
+	head, err := io.ReadAll(io.LimitReader(r.Body, maxLoggedBody+1))
+	r.Body = &replayedBody{Reader: io.MultiReader(bytes.NewReader(head), r.Body), body: r.Body}
+	if err != nil {
+		return "[unreadable body]"
+	}
+	if int64(len(head)) > maxLoggedBody {
+		return fmt.Sprintf("[over %d bytes, not logged]", maxLoggedBody)
+	}
+	if len(head) == 0 {
+		return ""
+	}
+	return bodyLogging.Redact(r.URL.Path, r.Header.Get("Content-Type"), head)
+}
+
+// replayedBody is a request body whose start was read for the log, read again by the handler
+type replayedBody struct {
+	io.Reader
+	body io.ReadCloser
+}
+
+func (b *replayedBody) Close() error {
+	return b.body.Close()
+}
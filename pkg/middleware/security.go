@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"mineral/pkg/utils"
+	"net/http"
+	"strconv"
+)
+
+// hstsMaxAge is the max-age of the Strict-Transport-Security header in seconds; 0 leaves it out
+var hstsMaxAge int
+
+// Request body limits in bytes, set with SetBodyLimits
+var (
+	maxBodySize   int64 = 1 << 20  // JSON bodies
+	maxUploadSize int64 = 16 << 20 // bodies of the upload routes, e.g. photos and file imports
+)
+
+// SetHSTS sets the max-age in seconds of the Strict-Transport-Security header sent on HTTPS
+// requests; 0 disables it
+func SetHSTS(maxAge int) {
+	hstsMaxAge = maxAge
+}
+
+// SetBodyLimits sets the largest request body accepted by most routes and by the upload routes
+func SetBodyLimits(body, upload int64) {
+	maxBodySize = body
+	maxUploadSize = upload
+}
+
+// SecurityHeaders sets headers that stop browsers sniffing content types and framing
+// responses, and Strict-Transport-Security on HTTPS requests, either terminated here or by a
+// trusted reverse proxy
+func SecurityHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("X-Frame-Options", "DENY")
+		w.Header().Set("Referrer-Policy", "no-referrer")
+		if hstsMaxAge > 0 && (r.TLS != nil || (trustProxyHeaders && r.Header.Get("X-Forwarded-Proto") == "https")) {
+			w.Header().Set("Strict-Transport-Security", "max-age="+strconv.Itoa(hstsMaxAge)+"; includeSubDomains")
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// LimitBody limits request bodies to the body limit, or the upload limit on routes using
+// AllowUpload. Bodies declared larger than the upload limit are rejected with 413 before they
+// are read; reading past the route's limit fails with an *http.MaxBytesError.
+func LimitBody(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > maxUploadSize {
+			utils.WriteErrorResponse(w, "Request body is too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		if r.Body != nil && r.Body != http.NoBody {
+			r.Body = &limitedBody{body: r.Body, limit: maxBodySize}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// AllowUpload raises the body limit of a route to the upload limit, for photos and file
+// imports. It must run after LimitBody.
+func AllowUpload(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if body, ok := r.Body.(*limitedBody); ok {
+			body.limit = maxUploadSize
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// bufferBody reads the whole request body for middleware that runs before the route is known,
+// such as Idempotency, and replaces it with the bytes read. Routes using AllowUpload only raise
+// the limit later, so the body is read up to the upload limit; the copy handed on keeps the
+// limit set so far, and reading it fails past that limit as the original body would.
+func bufferBody(r *http.Request) ([]byte, error) {
+	limited, ok := r.Body.(*limitedBody)
+	if !ok {
+		body, err := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		return body, err
+	}
+
+	limit := limited.limit
+	limited.limit = maxUploadSize
+	body, err := io.ReadAll(limited)
+	r.Body = &limitedBody{body: io.NopCloser(bytes.NewReader(body)), limit: limit}
+	return body, err
+}
+
+// limitedBody is a request body failing with an *http.MaxBytesError once more than limit bytes
+// are read. Unlike http.MaxBytesReader its limit can be raised by the route after it is set.
+type limitedBody struct {
+	body  io.ReadCloser
+	limit int64
+	read  int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.read > b.limit {
+		return 0, &http.MaxBytesError{Limit: b.limit}
+	}
+	// Read one byte past the limit to tell a body of exactly limit bytes from a larger one
+	if remaining := b.limit - b.read + 1; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	n, err := b.body.Read(p)
+	b.read += int64(n)
+	if b.read > b.limit {
+		return n - int(b.read-b.limit), &http.MaxBytesError{Limit: b.limit}
+	}
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.body.Close()
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing serves each request in an OpenTelemetry server span, continuing the caller's trace
+// from the traceparent header. The request context carries the span, so the spans of the
+// service calls, repository queries and provider calls made with it are its children. Once
+// the request is routed, the span is renamed after the matched route, e.g.
+// GET /api/incomes/{id}, so requests for different records are grouped.
+func Tracing(next http.Handler) http.Handler {
+	return otelhttp.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		span := trace.SpanFromContext(r.Context())
+		span.SetAttributes(attribute.String("http.request_id", r.Header.Get("X-Request-ID")))
+
+		next.ServeHTTP(w, r)
+
+		if routeContext := chi.RouteContext(r.Context()); routeContext != nil {
+			if pattern := routeContext.RoutePattern(); pattern != "" {
+				span.SetName(r.Method + " " + pattern)
+				span.SetAttributes(attribute.String("http.route", pattern))
+			}
+		}
+	}), "", otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		return r.Method
+	}))
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"mineral/pkg/utils"
+	"net/http"
+	"strings"
+)
+
+// UsageChecker returns why the books of a user have reached their plan limit for one of the
+// metrics, or an empty string when they may use more
+type UsageChecker func(ctx context.Context, userID uint, metrics ...string) (string, error)
+
+// EnforceUsageLimits rejects requests with 402 Payment Required once the organization whose books
+// the request works on has reached its plan limit for one of the metrics
+func EnforceUsageLimits(check UsageChecker, metrics ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID := GetUserIDFromRequest(r)
+			if userID == 0 {
+				utils.WriteUnauthorizedError(w, "User not authenticated")
+				return
+			}
+
+			message, err := check(r.Context(), userID, metrics...)
+			if err != nil {
+				utils.WriteInternalServerError(w, "Failed to check usage limits")
+				return
+			}
+			if message != "" {
+				utils.WriteErrorResponse(w, message, http.StatusPaymentRequired)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// FeatureChecker reports whether the books of a user have a feature, through their plan or a
+// feature flag
+type FeatureChecker func(ctx context.Context, userID uint, feature string) (bool, error)
+
+// RequireFeature rejects requests with 402 Payment Required unless the organization whose books
+// the request works on has the feature
+func RequireFeature(check FeatureChecker, feature string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID := GetUserIDFromRequest(r)
+			if userID == 0 {
+				utils.WriteUnauthorizedError(w, "User not authenticated")
+				return
+			}
+
+			enabled, err := check(r.Context(), userID, feature)
+			if err != nil {
+				utils.WriteInternalServerError(w, "Failed to check plan features")
+				return
+			}
+			if !enabled {
+				utils.WriteErrorResponse(w, "This feature is not included in your organization's plan", http.StatusPaymentRequired)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ReadOnlyChecker reports whether the books of a user can only be viewed
+type ReadOnlyChecker func(ctx context.Context, userID uint) (bool, error)
+
+// ReadOnlyBooks rejects requests that change data with 402 Payment Required while the books the
+// request works on are read-only, e.g. after a trial ends. Paths starting with one of the exempt
+// prefixes, such as the subscription routes, stay writable.
+func ReadOnlyBooks(check ReadOnlyChecker, exempt ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+			for _, prefix := range exempt {
+				if strings.HasPrefix(r.URL.Path, prefix) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			readOnly, err := check(r.Context(), GetUserIDFromRequest(r))
+			if err != nil {
+				utils.WriteInternalServerError(w, "Failed to check subscription")
+				return
+			}
+			if readOnly {
+				utils.WriteErrorResponse(w, "Your trial has ended; subscribe to a plan to make changes", http.StatusPaymentRequired)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
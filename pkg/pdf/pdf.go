@@ -0,0 +1,206 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Page size (A4) and layout in points
+const (
+	pageWidth   = 595.0
+	pageHeight  = 842.0
+	margin      = 56.0
+	valueOffset = 170.0
+)
+
+// Document is a minimal PDF made of text lines in the standard Helvetica fonts and JPEG
+// images. Lines flow down the page and continue on a new page when the bottom margin is reached.
+type Document struct {
+	pages     []*bytes.Buffer
+	images    []jpegImage // images drawn, named Im1, Im2, ...
+	y         float64
+	watermark string
+}
+
+// jpegImage is a JPEG image drawn in a document
+type jpegImage struct {
+	data          []byte
+	width, height int
+}
+
+// New creates an empty document
+func New() *Document {
+	d := &Document{}
+	d.newPage()
+	return d
+}
+
+// Title adds a large bold heading
+func (d *Document) Title(text string) {
+	d.write(margin, "F2", 18, text)
+	d.y -= 10
+}
+
+// Heading adds a bold line
+func (d *Document) Heading(text string) {
+	d.write(margin, "F2", 12, text)
+}
+
+// Text adds a regular line
+func (d *Document) Text(text string) {
+	d.write(margin, "F1", 11, text)
+}
+
+// Paragraph adds regular text wrapped at word boundaries to the width of the page
+func (d *Document) Paragraph(text string) {
+	// Helvetica averages about half an em per character, so about 85 fit across the page at 11 pt
+	const width = 85
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > width {
+			d.Text(line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		d.Text(line)
+	}
+}
+
+// Small adds a line in small type, e.g. a footer
+func (d *Document) Small(text string) {
+	d.write(margin, "F1", 8, text)
+}
+
+// Row adds a bold label with its value aligned in a second column
+func (d *Document) Row(label, value string) {
+	d.text(margin, "F2", 11, label)
+	d.write(margin+valueOffset, "F1", 11, value)
+}
+
+// Image draws an RGB JPEG image of width by height pixels, scaled down to fit within maxWidth by
+// maxHeight points keeping its aspect ratio
+func (d *Document) Image(jpeg []byte, width, height int, maxWidth, maxHeight float64) {
+	if width <= 0 || height <= 0 {
+		return
+	}
+	w, h := float64(width), float64(height)
+	scale := min(maxWidth/w, maxHeight/h, 1)
+	w, h = w*scale, h*scale
+	if d.y-h < margin {
+		d.newPage()
+	}
+
+	d.images = append(d.images, jpegImage{data: jpeg, width: width, height: height})
+	page := d.pages[len(d.pages)-1]
+	fmt.Fprintf(page, "q %.2f 0 0 %.2f %.2f %.2f cm /Im%d Do Q\n", w, h, margin, d.y-h, len(d.images))
+	d.y -= h + 10
+}
+
+// Watermark prints text diagonally in light gray behind the content of every page
+func (d *Document) Watermark(text string) {
+	d.watermark = text
+}
+
+// Space adds vertical space
+func (d *Document) Space() {
+	d.y -= 10
+}
+
+// Bytes renders the document
+func (d *Document) Bytes() []byte {
+	var out bytes.Buffer
+	offsets := []int{}
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// Objects: 1 catalog, 2 page tree, 3-4 fonts, the images, then a page and content stream per page
+	firstPage := 5 + len(d.images)
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+i*2)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	xObjects := ""
+	for i, image := range d.images {
+		object(fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB "+
+			"/BitsPerComponent 8 /Filter /DCTDecode /Length %d >>\nstream\n%s\nendstream",
+			image.width, image.height, len(image.data), image.data))
+		xObjects += fmt.Sprintf(" /Im%d %d 0 R", i+1, 5+i)
+	}
+	if xObjects != "" {
+		xObjects = " /XObject <<" + xObjects + " >>"
+	}
+	watermark := ""
+	if d.watermark != "" {
+		// Rotated 45 degrees across the middle of the page, drawn first so content stays on top
+		watermark = fmt.Sprintf("q 0.85 g BT /F2 48 Tf 0.7071 0.7071 -0.7071 0.7071 %.2f %.2f Tm (%s) Tj ET Q\n",
+			pageWidth/2-150, pageHeight/2-200, escape(d.watermark))
+	}
+	for i, content := range d.pages {
+		stream := watermark + content.String()
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >>%s >> /Contents %d 0 R >>", pageWidth, pageHeight, xObjects, firstPage+1+i*2))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(stream), stream))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// write adds text at x on the current line and moves to the next line
+func (d *Document) write(x float64, font string, size float64, text string) {
+	d.text(x, font, size, text)
+	d.y -= size * 1.5
+}
+
+// text adds text at x on the current line, starting a new page when the page is full
+func (d *Document) text(x float64, font string, size float64, text string) {
+	if d.y-size < margin {
+		d.newPage()
+	}
+	page := d.pages[len(d.pages)-1]
+	fmt.Fprintf(page, "BT /%s %.0f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, d.y-size, escape(text))
+}
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pageHeight - margin
+}
+
+// escape escapes a string for a PDF literal. Latin-1 letters such as é are written as octal
+// escapes, which WinAnsiEncoding maps to the same characters; other characters become ?.
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(&b, "\\%03o", r)
+		case r < 32 || r > 126:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,68 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// Lease is a named lock shared by every instance that expires unless its holder renews it,
+// e.g. a database row
+type Lease interface {
+	Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, name, holder string) error
+}
+
+// Election picks one leader among the instances running the same scheduler: the one holding
+// the lease. The leader renews the lease well before it expires; when the leader stops, another
+// instance takes over once the lease expires.
+type Election struct {
+	lease    Lease
+	name     string
+	holder   string
+	ttl      time.Duration
+	leader   atomic.Bool
+	errorLog *log.Logger
+}
+
+// NewElection creates an Election for the lease name, campaigning as holder
+func NewElection(lease Lease, name, holder string, ttl time.Duration, errorLog *log.Logger) *Election {
+	return &Election{
+		lease:    lease,
+		name:     name,
+		holder:   holder,
+		ttl:      ttl,
+		errorLog: errorLog,
+	}
+}
+
+// Campaign acquires or renews the lease and records whether this instance is the leader. An
+// instance that can't reach the lease steps down, since another may take over meanwhile.
+func (e *Election) Campaign() {
+	acquired, err := e.lease.Acquire(context.Background(), e.name, e.holder, e.ttl)
+	if err != nil {
+		e.errorLog.Printf("leader election %s failed: %v", e.name, err)
+		acquired = false
+	}
+	if was := e.leader.Swap(acquired); was != acquired {
+		if acquired {
+			log.Printf("%s is now the %s leader", e.holder, e.name)
+		} else {
+			log.Printf("%s is no longer the %s leader", e.holder, e.name)
+		}
+	}
+}
+
+// IsLeader reports whether this instance held the lease at its last campaign
+func (e *Election) IsLeader() bool {
+	return e.leader.Load()
+}
+
+// Resign releases the lease if this instance holds it, so another instance can take over at once
+func (e *Election) Resign() error {
+	if !e.leader.Swap(false) {
+		return nil
+	}
+	return e.lease.Release(context.Background(), e.name, e.holder)
+}
@@ -0,0 +1,124 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Task is a named function run periodically by the Scheduler
+type Task struct {
+	Name       string
+	Interval   time.Duration
+	Run        func(ctx context.Context) error
+	LeaderOnly bool // skipped unless this instance leads the election
+}
+
+// Scheduler runs registered tasks in the background at fixed intervals
+type Scheduler struct {
+	tasks    []Task
+	wait     *sync.WaitGroup
+	errorLog *log.Logger
+	quit     chan struct{}
+	election *Election
+	running  sync.WaitGroup // task goroutines, which the election outlives
+}
+
+// New creates a new Scheduler that tracks its goroutines in wait
+func New(wait *sync.WaitGroup, errorLog *log.Logger) *Scheduler {
+	return &Scheduler{
+		wait:     wait,
+		errorLog: errorLog,
+		quit:     make(chan struct{}),
+	}
+}
+
+// Every registers a task that runs once at start-up and then every interval
+func (s *Scheduler) Every(name string, interval time.Duration, run func(ctx context.Context) error) {
+	s.tasks = append(s.tasks, Task{Name: name, Interval: interval, Run: run})
+}
+
+// EveryOnLeader registers a task like Every that only runs on the leader, for tasks that must
+// not run once per instance, such as sending reminders
+func (s *Scheduler) EveryOnLeader(name string, interval time.Duration, run func(ctx context.Context) error) {
+	s.tasks = append(s.tasks, Task{Name: name, Interval: interval, Run: run, LeaderOnly: true})
+}
+
+// Elect sets the election that decides whether this instance runs the leader-only tasks.
+// Without one, every instance runs them.
+func (s *Scheduler) Elect(election *Election) {
+	s.election = election
+}
+
+// Start launches one goroutine per registered task, after a first campaign when there is an
+// election so the leader runs its tasks at start-up
+func (s *Scheduler) Start() {
+	if s.election != nil {
+		s.election.Campaign()
+		s.wait.Add(1)
+		go s.campaign()
+	}
+	for _, task := range s.tasks {
+		s.wait.Add(1)
+		s.running.Add(1)
+		go s.loop(task)
+	}
+}
+
+// Stop signals all tasks to stop after their current run
+func (s *Scheduler) Stop() {
+	close(s.quit)
+}
+
+func (s *Scheduler) loop(task Task) {
+	defer s.wait.Done()
+	defer s.running.Done()
+
+	ticker := time.NewTicker(task.Interval)
+	defer ticker.Stop()
+
+	for {
+		if !task.LeaderOnly || s.election == nil || s.election.IsLeader() {
+			if err := task.Run(context.Background()); err != nil {
+				s.errorLog.Printf("scheduled task %s failed: %v", task.Name, err)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// campaign renews the election lease three times per TTL. Once the scheduler stops and the
+// tasks have finished their current run, it resigns so another instance takes over without
+// waiting for the lease to expire.
+func (s *Scheduler) campaign() {
+	defer s.wait.Done()
+
+	ticker := time.NewTicker(s.election.ttl / 3)
+	defer ticker.Stop()
+
+	quit := s.quit
+	stopped := make(chan struct{})
+	for {
+		select {
+		case <-ticker.C:
+			s.election.Campaign()
+		case <-quit:
+			quit = nil
+			go func() {
+				s.running.Wait()
+				close(stopped)
+			}()
+		case <-stopped:
+			if err := s.election.Resign(); err != nil {
+				s.errorLog.Printf("leader election %s: failed to resign: %v", s.election.name, err)
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package sms
+
+import (
+	"log"
+)
+
+// Sender interface for sending SMS messages
+type Sender interface {
+	Send(phone, message string) error
+}
+
+// MockSender is a mock implementation for development
+type MockSender struct{}
+
+// Send sends an SMS (mock implementation)
+func (m *MockSender) Send(phone, message string) error {
+	log.Printf("Mock SMS sent to %s: %s", phone, message)
+	return nil
+}
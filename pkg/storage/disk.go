@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// DiskStore stores objects as files under a directory, for deployments without object storage
+type DiskStore struct {
+	Dir string
+}
+
+// NewDiskStore creates a DiskStore, creating its directory when it doesn't exist
+func NewDiskStore(dir string) (*DiskStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &DiskStore{Dir: dir}, nil
+}
+
+// Upload writes an object. It is written to a temporary file first so a failed upload doesn't
+// leave a partial object behind.
+func (d *DiskStore) Upload(key string, body io.Reader, size int64) error {
+	path, err := d.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	written, err := io.Copy(file, body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if written != size {
+		return fmt.Errorf("upload of %s wrote %d bytes, want %d", key, written, size)
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// Open opens an object; the caller closes it
+func (d *DiskStore) Open(key string) (io.ReadCloser, error) {
+	path, err := d.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+// Delete removes an object; deleting a missing object succeeds
+func (d *DiskStore) Delete(key string) error {
+	path, err := d.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path returns the file of an object, refusing keys that would leave the directory
+func (d *DiskStore) path(key string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	return filepath.Join(d.Dir, filepath.FromSlash(key)), nil
+}
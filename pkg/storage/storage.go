@@ -0,0 +1,183 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when an object doesn't exist
+var ErrNotFound = errors.New("object not found")
+
+// Uploader stores objects off-site
+type Uploader interface {
+	Upload(key string, body io.Reader, size int64) error
+}
+
+// Store stores objects that are read back later, such as uploaded files
+type Store interface {
+	Uploader
+	Open(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+// MockUploader is a mock implementation for development
+type MockUploader struct{}
+
+// Upload discards the object (mock implementation)
+func (m *MockUploader) Upload(key string, body io.Reader, size int64) error {
+	log.Printf("Mock upload of %s (%d bytes)", key, size)
+	return nil
+}
+
+// S3Uploader uploads objects to S3-compatible storage (AWS S3, MinIO, Backblaze B2, ...) with
+// path-style URLs and Signature Version 4
+type S3Uploader struct {
+	Endpoint  string // e.g. https://s3.eu-west-1.amazonaws.com
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	Client    *http.Client
+}
+
+// NewS3Uploader creates a new S3Uploader
+func NewS3Uploader(endpoint, region, bucket, accessKey, secretKey string) *S3Uploader {
+	return &S3Uploader{
+		Endpoint:  strings.TrimRight(endpoint, "/"),
+		Region:    region,
+		Bucket:    bucket,
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+		Client:    &http.Client{Timeout: 30 * time.Minute},
+	}
+}
+
+// Upload puts an object in the bucket. The payload is not hashed so large files can be
+// streamed; the endpoint must use https.
+func (u *S3Uploader) Upload(key string, body io.Reader, size int64) error {
+	req, err := u.newRequest(http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+
+	resp, err := u.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("upload of %s failed: %s %s", key, resp.Status, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// Open gets an object from the bucket; the caller closes it
+func (u *S3Uploader) Open(key string) (io.ReadCloser, error) {
+	req, err := u.newRequest(http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := u.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("download of %s failed: %s %s", key, resp.Status, strings.TrimSpace(string(message)))
+	}
+	return resp.Body, nil
+}
+
+// Delete removes an object from the bucket; deleting a missing object succeeds
+func (u *S3Uploader) Delete(key string) error {
+	req, err := u.newRequest(http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := u.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound && (resp.StatusCode < 200 || resp.StatusCode > 299) {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("delete of %s failed: %s %s", key, resp.Status, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// newRequest creates a signed request for an object of the bucket
+func (u *S3Uploader) newRequest(method, key string, body io.Reader) (*http.Request, error) {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	path := "/" + url.PathEscape(u.Bucket) + "/" + strings.Join(segments, "/")
+
+	req, err := http.NewRequest(method, u.Endpoint+path, body)
+	if err != nil {
+		return nil, err
+	}
+	u.sign(req, path, time.Now().UTC())
+	return req, nil
+}
+
+// sign adds the Signature Version 4 headers for an unsigned payload
+func (u *S3Uploader) sign(req *http.Request, path string, now time.Time) {
+	const payloadHash = "UNSIGNED-PAYLOAD"
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		"",
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + u.Region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+u.SecretKey), date)
+	key = hmacSHA256(key, u.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		u.AccessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, value string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,23 @@
+package tracing
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewClient returns a copy of client whose requests are traced as calls to service, e.g. webhook
+// or google. Each request gets a client span, a child of the span carried by the request's
+// context, and the trace is propagated to the provider in the traceparent header.
+func NewClient(client *http.Client, service string) *http.Client {
+	traced := *client
+	traced.Transport = otelhttp.NewTransport(client.Transport,
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return "HTTP " + r.Method + " " + service
+		}),
+		otelhttp.WithSpanOptions(trace.WithAttributes(attribute.String("peer.service", service))),
+	)
+	return &traced
+}
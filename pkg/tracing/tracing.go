@@ -0,0 +1,43 @@
+// Package tracing records OpenTelemetry spans of the service layer and of the calls made to
+// external providers. Spans are started with the global tracer provider, so they are dropped
+// until cmd/api installs an exporting one.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer starts the spans of this module. It follows the global tracer provider, even one set
+// after it is created.
+var tracer = otel.Tracer("mineral")
+
+// Start starts an internal span as a child of the span carried by ctx and returns a context
+// carrying the new span, for work such as building a report that spans several queries
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End marks the span failed with err, unless err is nil, and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Call runs fn, a call to an external provider such as the SMS gateway, in a client span that
+// is a child of the span carried by ctx, and returns its error
+func Call(ctx context.Context, service, operation string, fn func() error) error {
+	_, span := tracer.Start(ctx, service+" "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("peer.service", service)))
+	err := fn()
+	End(span, err)
+	return err
+}
@@ -0,0 +1,22 @@
+package utils
+
+import "math"
+
+// earthRadius is the mean radius of the earth in meters
+const earthRadius = 6371000.0
+
+// ValidateCoordinates validates a latitude and longitude in degrees
+func ValidateCoordinates(latitude, longitude float64) bool {
+	return latitude >= -90 && latitude <= 90 && longitude >= -180 && longitude <= 180
+}
+
+// DistanceMeters returns the great-circle distance in meters between two points in degrees
+func DistanceMeters(lat1, lng1, lat2, lng2 float64) float64 {
+	phi1 := lat1 * math.Pi / 180
+	phi2 := lat2 * math.Pi / 180
+	dPhi := (lat2 - lat1) * math.Pi / 180
+	dLambda := (lng2 - lng1) * math.Pi / 180
+
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) + math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	return 2 * earthRadius * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
@@ -1,6 +1,10 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"time"
 
@@ -9,6 +13,10 @@ import (
 
 var jwtSecret = []byte("your-super-secret-jwt-key-change-this-in-production")
 
+// AccessTokenTTL is the lifetime of the tokens issued by GenerateJWT. Clients keep signed in past
+// it by exchanging a refresh token for a new one.
+const AccessTokenTTL = 24 * time.Hour
+
 // SetJWTSecret sets the JWT secret key
 func SetJWTSecret(secret string) {
 	jwtSecret = []byte(secret)
@@ -29,7 +37,7 @@ func GenerateJWT(userID, email, role string) (string, error) {
 		Email:  email,
 		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
@@ -59,3 +67,19 @@ func ValidateJWT(tokenString string) (*Claims, error) {
 func GenerateToken(userID, email, role string) (string, error) {
 	return GenerateJWT(userID, email, role)
 }
+
+// GenerateRefreshToken creates a random refresh token. Refresh tokens are opaque rather than
+// JWTs so they can be revoked; only their hash is stored.
+func GenerateRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the hash a refresh token or a revoked access token is stored and looked up by
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
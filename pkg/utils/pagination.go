@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultPerPage is the page size of paginated lists that don't set per_page
+	DefaultPerPage = 50
+	// MaxPerPage is the largest page size a client may request
+	MaxPerPage = 200
+)
+
+// Page is the page of a list requested with the page and per_page query parameters
+type Page struct {
+	Number  int
+	PerPage int
+}
+
+// ParsePage parses the page and per_page query parameters of a list request. It returns nil
+// when the request sets neither, so lists stay whole for clients that don't paginate.
+func ParsePage(r *http.Request) (*Page, error) {
+	query := r.URL.Query()
+	if query.Get("page") == "" && query.Get("per_page") == "" {
+		return nil, nil
+	}
+
+	page := &Page{Number: 1, PerPage: DefaultPerPage}
+	if value := query.Get("page"); value != "" {
+		number, err := strconv.Atoi(value)
+		if err != nil || number < 1 {
+			return nil, errors.New("Page must be a positive integer")
+		}
+		page.Number = number
+	}
+	if value := query.Get("per_page"); value != "" {
+		perPage, err := strconv.Atoi(value)
+		if err != nil || perPage < 1 || perPage > MaxPerPage {
+			return nil, errors.New("Per page must be between 1 and " + strconv.Itoa(MaxPerPage))
+		}
+		page.PerPage = perPage
+	}
+	return page, nil
+}
+
+// ParseSort parses the sort and order query parameters of a list request, returning the column
+// to sort by, one of columns or empty when sort isn't set, and whether to sort it descending.
+// The order is ascending unless order=desc.
+func ParseSort(r *http.Request, columns []string) (string, bool, error) {
+	query := r.URL.Query()
+	column := query.Get("sort")
+	if column != "" && !slices.Contains(columns, column) {
+		return "", false, errors.New("Sort must be one of " + strings.Join(columns, ", "))
+	}
+	switch query.Get("order") {
+	case "", "asc":
+		return column, false, nil
+	case "desc":
+		return column, true, nil
+	default:
+		return "", false, errors.New("Order must be asc or desc")
+	}
+}
+
+// Offset returns the number of rows before the page; a nil page is the whole list
+func (p *Page) Offset() int {
+	if p == nil {
+		return 0
+	}
+	return (p.Number - 1) * p.PerPage
+}
+
+// Limit returns the number of rows in the page, or 0 for the whole list when the page is nil
+func (p *Page) Limit() int {
+	if p == nil {
+		return 0
+	}
+	return p.PerPage
+}
+
+// Pagination describes a page of a list in a paginated response
+type Pagination struct {
+	Page       int   `json:"page"`
+	PerPage    int   `json:"per_page"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// Pagination returns the description of the page in a list of total rows
+func (p *Page) Pagination(total int64) *Pagination {
+	return &Pagination{
+		Page:       p.Number,
+		PerPage:    p.PerPage,
+		Total:      total,
+		TotalPages: int((total + int64(p.PerPage) - 1) / int64(p.PerPage)),
+	}
+}
+
+// WritePaginatedResponse writes a success response with a page of a list as its data
+func WritePaginatedResponse(w http.ResponseWriter, message string, data interface{}, pagination *Pagination) {
+	response := map[string]interface{}{
+		"success":    true,
+		"message":    message,
+		"data":       data,
+		"pagination": pagination,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// WriteListResponse writes a success response with a list, as a page of total rows when page is
+// set
+func WriteListResponse(w http.ResponseWriter, message string, data interface{}, page *Page, total int64) {
+	if page == nil {
+		WriteSuccessResponse(w, message, data)
+		return
+	}
+	WritePaginatedResponse(w, message, data, page.Pagination(total))
+}
@@ -20,10 +20,7 @@ func WriteSuccessResponse(w http.ResponseWriter, message string, data interface{
 
 // WriteErrorResponse writes an error response
 func WriteErrorResponse(w http.ResponseWriter, message string, statusCode int) {
-	response := map[string]interface{}{
-		"success": false,
-		"error":   message,
-	}
+	response := errorResponse(w, message)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -40,6 +37,11 @@ func WriteUnauthorizedError(w http.ResponseWriter, message string) {
 	WriteErrorResponse(w, message, http.StatusUnauthorized)
 }
 
+// WriteForbiddenError writes a forbidden error response
+func WriteForbiddenError(w http.ResponseWriter, message string) {
+	WriteErrorResponse(w, message, http.StatusForbidden)
+}
+
 // WriteNotFoundError writes a not found error response
 func WriteNotFoundError(w http.ResponseWriter, message string) {
 	WriteErrorResponse(w, message, http.StatusNotFound)
@@ -49,3 +51,26 @@ func WriteNotFoundError(w http.ResponseWriter, message string) {
 func WriteInternalServerError(w http.ResponseWriter, message string) {
 	WriteErrorResponse(w, message, http.StatusInternalServerError)
 }
+
+// WriteErrorResponseWithData writes an error response with details the client can act on
+func WriteErrorResponseWithData(w http.ResponseWriter, message string, statusCode int, data interface{}) {
+	response := errorResponse(w, message)
+	response["data"] = data
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(response)
+}
+
+// errorResponse returns the body of an error response, with the request ID set by the logging
+// middleware so support can find the request in the logs
+func errorResponse(w http.ResponseWriter, message string) map[string]interface{} {
+	response := map[string]interface{}{
+		"success": false,
+		"error":   message,
+	}
+	if requestID := w.Header().Get("X-Request-ID"); requestID != "" {
+		response["request_id"] = requestID
+	}
+	return response
+}
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"time"
+)
+
+// Cookie sessions are an alternative to the Authorization header for browser clients, which
+// keep the token in an httpOnly cookie out of reach of scripts. Requests authenticated by the
+// cookie that change something must carry the session's CSRF token in the X-CSRF-Token header.
+const (
+	SessionCookie = "session"
+	CSRFCookie    = "csrf_token" // readable by the web client, which echoes it in CSRFHeader
+	CSRFHeader    = "X-CSRF-Token"
+)
+
+// RefreshCookie carries the refresh token of a web client. It is only sent to the auth routes,
+// where it is exchanged for a new session.
+const (
+	RefreshCookie     = "refresh_token"
+	refreshCookiePath = "/api/v1/auth"
+)
+
+// Session cookie attributes, set with SetSessionCookieOptions
+var (
+	sessionCookieSecure = true
+	sessionCookieDomain string
+)
+
+// SetSessionCookieOptions sets whether session cookies are only sent over HTTPS, which should
+// only be disabled for local development, and the domain they are shared with, e.g.
+// example.com for a web client on app.example.com; empty limits them to the API's host
+func SetSessionCookieOptions(secure bool, domain string) {
+	sessionCookieSecure = secure
+	sessionCookieDomain = domain
+}
+
+// CSRFToken returns the CSRF token of a session token. It is derived from the session token,
+// so it changes with every login and can't be reused with another session.
+func CSRFToken(sessionToken string) string {
+	mac := hmac.New(sha256.New, jwtSecret)
+	mac.Write([]byte("csrf:" + sessionToken))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyCSRFToken reports whether token is the CSRF token of a session token
+func VerifyCSRFToken(sessionToken, token string) bool {
+	return token != "" && hmac.Equal([]byte(token), []byte(CSRFToken(sessionToken)))
+}
+
+// SetSessionCookies sets the session cookie carrying token and the cookie carrying its CSRF
+// token, and returns the CSRF token
+func SetSessionCookies(w http.ResponseWriter, token string) string {
+	csrf := CSRFToken(token)
+	expires := time.Now().Add(AccessTokenTTL)
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookie,
+		Value:    token,
+		Path:     "/",
+		Domain:   sessionCookieDomain,
+		Expires:  expires,
+		Secure:   sessionCookieSecure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.SetCookie(w, &http.Cookie{
+		Name:     CSRFCookie,
+		Value:    csrf,
+		Path:     "/",
+		Domain:   sessionCookieDomain,
+		Expires:  expires,
+		Secure:   sessionCookieSecure,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return csrf
+}
+
+// SetRefreshCookie sets the httpOnly cookie carrying a web client's refresh token until it expires
+func SetRefreshCookie(w http.ResponseWriter, token string, expires time.Time) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     RefreshCookie,
+		Value:    token,
+		Path:     refreshCookiePath,
+		Domain:   sessionCookieDomain,
+		Expires:  expires,
+		Secure:   sessionCookieSecure,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+// ClearSessionCookies expires the session, CSRF and refresh cookies, e.g. on logout
+func ClearSessionCookies(w http.ResponseWriter) {
+	for _, cookie := range []struct{ name, path string }{
+		{SessionCookie, "/"},
+		{CSRFCookie, "/"},
+		{RefreshCookie, refreshCookiePath},
+	} {
+		http.SetCookie(w, &http.Cookie{
+			Name:     cookie.name,
+			Value:    "",
+			Path:     cookie.path,
+			Domain:   sessionCookieDomain,
+			MaxAge:   -1,
+			Secure:   sessionCookieSecure,
+			HttpOnly: cookie.name != CSRFCookie,
+		})
+	}
+}
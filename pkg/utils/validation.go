@@ -30,6 +30,11 @@ func ValidatePhone(phone string) bool {
 	return phoneRegex.MatchString(phone)
 }
 
+// NormalizePhone strips spaces, dashes and parentheses from a phone number
+func NormalizePhone(phone string) string {
+	return strings.NewReplacer(" ", "", "-", "", "(", "", ")", "").Replace(strings.TrimSpace(phone))
+}
+
 // ValidatePositiveNumber validates that a number is positive
 func ValidatePositiveNumber(value float64) bool {
 	return value > 0
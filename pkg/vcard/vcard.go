@@ -0,0 +1,233 @@
+package vcard
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+)
+
+// maxLineLength is the maximum length of a content line in octets before it is folded
+const maxLineLength = 75
+
+// ErrNoCards is returned when a file contains no vCards
+var ErrNoCards = errors.New("no vCards found")
+
+// Card is a contact with the vCard properties used for customers and suppliers
+type Card struct {
+	Name   string
+	Phones []string
+	Email  string
+	Org    string
+	Note   string
+}
+
+// Parse reads the vCards (versions 2.1, 3.0 and 4.0) in a file, as exported by phone address
+// books. Properties other than the name, phone numbers, email, organization and note are ignored.
+func Parse(r io.Reader) ([]Card, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var cards []Card
+	var card *Card
+	var structuredName string
+	for _, line := range lines {
+		name, params, value, found := splitLine(line)
+		if !found {
+			continue
+		}
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCARD"):
+			card = &Card{}
+			structuredName = ""
+			continue
+		case name == "END" && strings.EqualFold(value, "VCARD"):
+			if card != nil {
+				if card.Name == "" {
+					card.Name = structuredName
+				}
+				cards = append(cards, *card)
+			}
+			card = nil
+			continue
+		}
+		if card == nil {
+			continue
+		}
+
+		if strings.Contains(strings.ToUpper(params), "ENCODING=QUOTED-PRINTABLE") {
+			value = decodeQuotedPrintable(value)
+		}
+		switch name {
+		case "FN":
+			card.Name = unescape(value)
+		case "N":
+			structuredName = joinName(value)
+		case "TEL":
+			if phone := strings.TrimPrefix(unescape(value), "tel:"); phone != "" {
+				card.Phones = append(card.Phones, phone)
+			}
+		case "EMAIL":
+			if card.Email == "" {
+				card.Email = strings.TrimPrefix(unescape(value), "mailto:")
+			}
+		case "ORG":
+			card.Org = strings.TrimRight(strings.ReplaceAll(unescape(value), ";", " "), " ")
+		case "NOTE":
+			card.Note = unescape(value)
+		}
+	}
+
+	if len(cards) == 0 {
+		return nil, ErrNoCards
+	}
+	return cards, nil
+}
+
+// Write renders cards as a vCard 3.0 file
+func Write(w io.Writer, cards []Card) error {
+	var buf bytes.Buffer
+	for _, card := range cards {
+		writeLine(&buf, "BEGIN:VCARD")
+		writeLine(&buf, "VERSION:3.0")
+		writeLine(&buf, "FN:"+escape(card.Name))
+		writeLine(&buf, "N:"+escape(card.Name)+";;;;")
+		for _, phone := range card.Phones {
+			writeLine(&buf, "TEL;TYPE=CELL:"+escape(phone))
+		}
+		if card.Email != "" {
+			writeLine(&buf, "EMAIL;TYPE=INTERNET:"+escape(card.Email))
+		}
+		if card.Org != "" {
+			writeLine(&buf, "ORG:"+escape(card.Org))
+		}
+		if card.Note != "" {
+			writeLine(&buf, "NOTE:"+escape(card.Note))
+		}
+		writeLine(&buf, "END:VCARD")
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// unfold reads the content lines of a file, joining folded continuation lines (starting with
+// a space or tab) and quoted-printable soft line breaks
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if n := len(lines); n > 0 && line != "" && (line[0] == ' ' || line[0] == '\t') {
+			lines[n-1] += line[1:]
+			continue
+		}
+		if n := len(lines); n > 0 && strings.HasSuffix(lines[n-1], "=") &&
+			strings.Contains(strings.ToUpper(lines[n-1]), "QUOTED-PRINTABLE") {
+			lines[n-1] = strings.TrimSuffix(lines[n-1], "=") + line
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// splitLine splits a content line into its upper-cased property name (without group prefix),
+// parameters and value
+func splitLine(line string) (name, params, value string, found bool) {
+	head, value, found := strings.Cut(line, ":")
+	if !found {
+		return "", "", "", false
+	}
+	name, params, _ = strings.Cut(head, ";")
+	if _, property, grouped := strings.Cut(name, "."); grouped {
+		name = property
+	}
+	return strings.ToUpper(name), params, strings.TrimSpace(value), true
+}
+
+// joinName turns a structured name (family;given;additional;prefix;suffix) into a display name
+func joinName(value string) string {
+	parts := strings.Split(value, ";")
+	order := []int{3, 1, 2, 0, 4}
+	var names []string
+	for _, i := range order {
+		if i < len(parts) {
+			if part := strings.TrimSpace(unescape(parts[i])); part != "" {
+				names = append(names, part)
+			}
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+// decodeQuotedPrintable decodes the quoted-printable values written by vCard 2.1 exporters,
+// leaving malformed escapes as they are
+func decodeQuotedPrintable(value string) string {
+	var out []byte
+	for i := 0; i < len(value); i++ {
+		if value[i] == '=' && i+2 < len(value) {
+			if b, ok := hexByte(value[i+1], value[i+2]); ok {
+				out = append(out, b)
+				i += 2
+				continue
+			}
+		}
+		out = append(out, value[i])
+	}
+	return string(out)
+}
+
+// hexByte decodes two hexadecimal digits
+func hexByte(hi, lo byte) (byte, bool) {
+	h, ok1 := hexDigit(hi)
+	l, ok2 := hexDigit(lo)
+	return h<<4 | l, ok1 && ok2
+}
+
+// hexDigit decodes a hexadecimal digit
+func hexDigit(c byte) (byte, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', true
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10, true
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10, true
+	}
+	return 0, false
+}
+
+// escape escapes text values as required by RFC 6350
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// unescape reverses escape
+func unescape(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(s)
+}
+
+// writeLine writes a content line, folding it onto continuation lines that start with a space
+// when it is longer than 75 octets. Lines are only folded between UTF-8 characters.
+func writeLine(buf *bytes.Buffer, line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineLength - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,45 @@
+package routes
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+//go:generate go run ../cmd/genopenapi -out openapi.json
+
+// openAPIDocument is the OpenAPI 3 document of the API, generated from the routes and handlers
+//
+//go:embed openapi.json
+var openAPIDocument []byte
+
+// swaggerUI is the page browsing the OpenAPI document with Swagger UI
+const swaggerUI = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Mining Finance System API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/api/v1/openapi.json", dom_id: "#swagger-ui", persistAuthorization: true });
+  </script>
+</body>
+</html>
+`
+
+// serveOpenAPI serves the OpenAPI document of the API
+func serveOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(openAPIDocument)
+}
+
+// serveSwaggerUI serves Swagger UI for browsing and trying out the API
+func serveSwaggerUI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(swaggerUI))
+}
@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"mineral/data"
 	"mineral/handlers"
 	"mineral/pkg/middleware"
 	"net/http"
@@ -9,23 +10,81 @@ import (
 	"github.com/go-chi/cors"
 )
 
+// Handlers are the handlers of the API routes. Routes of a nil handler are still registered,
+// so the router can be built in tests with only the handlers they exercise.
+type Handlers struct {
+	Auth             *handlers.AuthHandler
+	Income           *handlers.IncomeHandler
+	Expense          *handlers.ExpenseHandler
+	Inventory        *handlers.InventoryHandler
+	Analytics        *handlers.AnalyticsHandler
+	MineSite         *handlers.MineSiteHandler
+	Stocktake        *handlers.StocktakeHandler
+	Notification     *handlers.NotificationHandler
+	Transport        *handlers.TransportHandler
+	Contractor       *handlers.ContractorHandler
+	Employee         *handlers.EmployeeHandler
+	Timesheet        *handlers.TimesheetHandler
+	Payroll          *handlers.PayrollHandler
+	Settings         *handlers.SettingsHandler
+	Organization     *handlers.OrganizationHandler
+	Export           *handlers.ExportHandler
+	Audit            *handlers.AuditHandler
+	ShareLink        *handlers.ShareLinkHandler
+	Receipt          *handlers.ReceiptHandler
+	Dunning          *handlers.DunningHandler
+	Task             *handlers.TaskHandler
+	Calendar         *handlers.CalendarHandler
+	Attendance       *handlers.AttendanceHandler
+	Evidence         *handlers.EvidenceHandler
+	BulkSMS          *handlers.BulkSMSHandler
+	Contact          *handlers.ContactHandler
+	CreditLimit      *handlers.CreditLimitHandler
+	Flag             *handlers.FlagHandler
+	Benchmark        *handlers.BenchmarkHandler
+	Reference        *handlers.ReferenceHandler
+	Form             *handlers.FormHandler
+	Trade            *handlers.TradeHandler
+	Webhook          *handlers.WebhookHandler
+	Backup           *handlers.BackupHandler
+	Usage            *handlers.UsageHandler
+	Subscription     *handlers.SubscriptionHandler
+	Referral         *handlers.ReferralHandler
+	Support          *handlers.SupportHandler
+	Metrics          *handlers.MetricsHandler
+	Archive          *handlers.ArchiveHandler
+	Stream           *handlers.StreamHandler
+	Attachment       *handlers.AttachmentHandler
+	RegulatorReport  *handlers.RegulatorReportHandler
+	DueDiligence     *handlers.DueDiligenceHandler
+	LotSeal          *handlers.LotSealHandler
+	Assay            *handlers.AssayHandler
+	Purchase         *handlers.PurchaseHandler
+	Miner            *handlers.MinerHandler
+	MarketPrice      *handlers.MarketPriceHandler
+	CashDay          *handlers.CashDayHandler
+	Till             *handlers.TillHandler
+	ShiftHandover    *handlers.ShiftHandoverHandler
+	CreditNote       *handlers.CreditNoteHandler
+	DocumentTemplate *handlers.DocumentTemplateHandler
+	Claim            *handlers.ClaimHandler
+	Equipment        *handlers.EquipmentHandler
+	Insurance        *handlers.InsuranceHandler
+	SupplyPrice      *handlers.SupplyPriceHandler
+	Procurement      *handlers.ProcurementHandler
+	CostAllocation   *handlers.CostAllocationHandler
+}
+
 // SetupRoutes configures all API routes using chi router
-func SetupRoutes(
-	authHandler *handlers.AuthHandler,
-	incomeHandler *handlers.IncomeHandler,
-	expenseHandler *handlers.ExpenseHandler,
-	inventoryHandler *handlers.InventoryHandler,
-	analyticsHandler *handlers.AnalyticsHandler,
-	mineSiteHandler *handlers.MineSiteHandler,
-) http.Handler {
+func SetupRoutes(h Handlers) http.Handler {
 	r := chi.NewRouter()
 
 	// CORS configuration using chi's built-in CORS
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:3000", "http://localhost:3001", "http://localhost:3002", "http://localhost:8086"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "X-Requested-With"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "X-Requested-With", "X-Organization-ID", "X-Request-ID", "X-App-Version", "X-Client-Type", "Idempotency-Key"},
+		ExposedHeaders:   []string{"Link", "X-Request-ID", "X-DB-Queries", "X-DB-Time", "Retry-After", "Idempotent-Replayed"},
 		AllowCredentials: true,
 		MaxAge:           300, // Maximum value not ignored by any of major browsers
 	}))
@@ -33,6 +92,19 @@ func SetupRoutes(
 	// Logging middleware
 	r.Use(middleware.LoggingMiddleware)
 
+	// Security headers and request body limits; upload routes allow larger bodies
+	r.Use(middleware.SecurityHeaders)
+	r.Use(middleware.LimitBody)
+
+	// A trace span for every request, exported when tracing is enabled
+	r.Use(middleware.Tracing)
+
+	// Database query counts in debug headers, when enabled
+	r.Use(middleware.QueryStats)
+
+	// Reject outdated apps with a structured upgrade response
+	r.Use(middleware.RequireClientVersion)
+
 	// Health check endpoint
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -43,70 +115,674 @@ func SetupRoutes(
 	r.Route("/api/v1", func(r chi.Router) {
 		// Authentication routes (no auth required)
 		r.Route("/auth", func(r chi.Router) {
-			r.Post("/login", authHandler.Login)
-			r.Post("/signup", authHandler.Signup)
-			r.Post("/forgot-password", authHandler.ForgotPassword)
-			r.Post("/reset-password", authHandler.ResetPassword)
+			r.Use(middleware.RateLimit("auth"))
+			r.Post("/login", h.Auth.Login)
+			r.Post("/signup", h.Auth.Signup)
+			r.Post("/refresh", h.Auth.Refresh)
+			r.With(middleware.AuthMiddleware).Post("/logout", h.Auth.Logout)
+			r.Post("/forgot-password", h.Auth.ForgotPassword)
+			r.Post("/reset-password", h.Auth.ResetPassword)
+			r.Post("/verify-email", h.Auth.VerifyEmail)
+			r.Post("/resend-verification", h.Auth.ResendEmailVerification)
+			r.Post("/google", h.Auth.GoogleLogin)
+			r.Post("/phone/request-otp", h.Auth.RequestPhoneLoginOTP)
+			r.Post("/phone/verify", h.Auth.PhoneLogin)
 		})
 
+		// Public routes, rate limited per client IP
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RateLimit("public"))
+
+			// OpenAPI document and Swagger UI (no auth required)
+			r.Get("/openapi.json", serveOpenAPI)
+			r.Get("/docs", serveSwaggerUI)
+
+			// Reference data for client pickers (no auth required)
+			r.Get("/reference", h.Reference.GetReferenceData)
+
+			// Public document links (no auth required, signed token)
+			r.Route("/public/links/{token}", func(r chi.Router) {
+				r.Get("/", h.ShareLink.ViewSharedDocument)
+				r.Get("/pdf", h.ShareLink.DownloadSharedDocumentPDF)
+				r.Post("/confirm", h.ShareLink.ConfirmSharedDocument)
+			})
+
+			// Public receipt verification (no auth required, signed token)
+			r.Route("/public/receipts/{token}", func(r chi.Router) {
+				r.Get("/", h.Receipt.VerifyReceipt)
+				r.Get("/pdf", h.Receipt.DownloadPublicReceiptPDF)
+			})
+
+			// Public lot seal verification (no auth required, signed token)
+			r.Get("/public/seals/{token}", h.LotSeal.VerifySeal)
+
+			// Public calendar feed (no auth required, secret token)
+			r.Get("/public/calendar/{token}", h.Calendar.GetPublicFeed)
+
+			// Public SMS campaign opt-out (no auth required, signed token)
+			r.Get("/public/sms/opt-out/{token}", h.BulkSMS.PublicOptOut)
+
+			// Public organization invitations (no auth required, secret token)
+			r.Get("/public/invitations/{token}", h.Organization.GetPublicInvitation)
+		})
+
+		// Payment provider webhook (no auth required, signed body)
+		r.Post("/billing/webhook", h.Subscription.BillingWebhook)
+
 		// Protected routes (require authentication)
 		r.Group(func(r chi.Router) {
 			r.Use(middleware.AuthMiddleware)
+			r.Use(middleware.OrganizationContext(h.Organization.ResolveMembership))
+			r.Use(middleware.ReadOnlyBooks(h.Subscription.IsReadOnly, "/api/v1/subscription", "/api/v1/profile", "/api/v1/notifications", "/api/v1/support", "/api/v1/admin"))
+			r.Use(middleware.Idempotency)
+
+			// Plan limits on creating records and sending SMS. Photos are checked against the attachment
+			// storage quota as they are saved.
+			recordLimit := middleware.EnforceUsageLimits(h.Usage.CheckLimits, string(data.UsageRecords))
+			smsLimit := middleware.EnforceUsageLimits(h.Usage.CheckLimits, string(data.UsageSMS))
+
+			// Permissions members of an organization need to change its books
+			can := func(permission data.Permission) func(http.Handler) http.Handler {
+				return middleware.PermissionMiddleware(string(permission))
+			}
+
+			// Features gated by plan and feature flags
+			requireReports := middleware.RequireFeature(h.Subscription.HasFeature, string(data.FeatureReports))
+			requireSMS := middleware.RequireFeature(h.Subscription.HasFeature, string(data.FeatureSMS))
+			requireTeam := middleware.RequireFeature(h.Subscription.HasFeature, string(data.FeatureTeam))
+			requireWebhooks := middleware.RequireFeature(h.Subscription.HasFeature, string(data.FeatureWebhooks))
+
+			// Usage against the plan limits
+			r.Get("/usage", h.Usage.GetUsage)
+
+			// Support ticket routes
+			r.Route("/support", func(r chi.Router) {
+				r.Get("/", h.Support.GetTickets)
+				r.Post("/", h.Support.CreateTicket)
+				r.Get("/{id}", h.Support.GetTicket)
+			})
+
+			// Plan and subscription routes
+			r.Get("/plans", h.Subscription.GetPlans)
+			r.Get("/subscription", h.Subscription.GetSubscription)
+			r.Post("/subscription/checkout", h.Subscription.Checkout)
 
 			// User profile routes
-			r.Get("/profile", authHandler.GetProfile)
-			r.Put("/profile", authHandler.UpdateProfile)
+			r.Get("/profile", h.Auth.GetProfile)
+			r.Put("/profile", h.Auth.UpdateProfile)
+			r.Get("/profile/identities", h.Auth.GetIdentities)
+			r.Post("/profile/identities/google", h.Auth.LinkGoogle)
+			r.Post("/profile/identities/phone", h.Auth.RequestPhoneLink)
+			r.Post("/profile/identities/phone/verify", h.Auth.VerifyPhoneLink)
+			r.Delete("/profile/identities/{provider}", h.Auth.UnlinkIdentity)
+			r.Get("/profile/referrals", h.Referral.GetReferrals)
+
+			// Organization routes
+			r.Route("/organizations", func(r chi.Router) {
+				r.Get("/", h.Organization.GetMyOrganizations)
+				r.Post("/", h.Organization.CreateOrganization)
+				r.Get("/{id}", h.Organization.GetOrganization)
+				r.Put("/{id}", h.Organization.UpdateOrganization)
+				r.With(requireTeam).Post("/{id}/members", h.Organization.AddMember)
+				r.Put("/{id}/members/{userId}", h.Organization.UpdateMember)
+				r.Delete("/{id}/members/{userId}", h.Organization.RemoveMember)
+				r.Get("/{id}/ip-allowlist", h.Organization.GetIPRules)
+				r.Post("/{id}/ip-allowlist", h.Organization.AddIPRule)
+				r.Delete("/{id}/ip-allowlist/{ruleId}", h.Organization.DeleteIPRule)
+				r.Get("/{id}/roles", h.Organization.GetRolePermissions)
+				r.Put("/{id}/roles/{role}", h.Organization.UpdateRolePermissions)
+				r.Delete("/{id}/roles/{role}", h.Organization.ResetRolePermissions)
+				r.With(requireTeam).Post("/{id}/invitations", h.Organization.CreateInvitation)
+				r.Get("/{id}/invitations", h.Organization.GetInvitations)
+				r.Delete("/{id}/invitations/{invitationId}", h.Organization.DeleteInvitation)
+				r.Post("/invitations/accept", h.Organization.AcceptInvitation)
+			})
 
 			// Income routes
 			r.Route("/income", func(r chi.Router) {
-				r.Get("/", incomeHandler.GetAllIncomes)
-				r.Post("/", incomeHandler.CreateIncome)
-				r.Get("/range", incomeHandler.GetIncomeByDateRange)
-				r.Get("/{id}", incomeHandler.GetIncome)
-				r.Put("/{id}", incomeHandler.UpdateIncome)
-				r.Delete("/{id}", incomeHandler.DeleteIncome)
+				r.Get("/", h.Income.GetAllIncomes)
+				r.With(can(data.PermIncomeCreate), recordLimit).Post("/", h.Income.CreateIncome)
+				r.Get("/range", h.Income.GetIncomeByDateRange)
+				r.Get("/pending-approval", h.Income.GetPendingApprovals)
+				r.Get("/trash", h.Income.GetIncomeTrash)
+				r.Get("/{id}", h.Income.GetIncome)
+				r.With(can(data.PermIncomeUpdate)).Put("/{id}", h.Income.UpdateIncome)
+				r.With(can(data.PermIncomeDelete)).Delete("/{id}", h.Income.DeleteIncome)
+				r.With(can(data.PermIncomeDelete)).Post("/{id}/restore", h.Income.RestoreIncome)
+				r.With(can(data.PermSettingsManage)).Delete("/{id}/permanent", h.Income.PurgeIncome)
+				r.With(can(data.PermIncomeUpdate)).Post("/{id}/share", h.ShareLink.ShareInvoice)
+				r.Get("/{id}/payments", h.Income.GetIncomePayments)
+				r.With(can(data.PermPaymentRecord)).Post("/{id}/payments", h.Income.AddIncomePayment)
+				r.Get("/{id}/receipts", h.Receipt.GetIncomeReceipts)
+				r.Get("/{id}/credit-notes", h.CreditNote.GetIncomeCreditNotes)
+				r.With(can(data.PermIncomeUpdate)).Post("/{id}/credit-notes", h.CreditNote.CreateCreditNote)
+				r.Get("/{id}/dunning", h.Dunning.GetIncomeDunningHistory)
+				r.Post("/{id}/approve", h.Income.ApproveIncome)
+				r.Post("/{id}/reject", h.Income.RejectIncome)
+				r.With(can(data.PermIncomeUpdate)).Post("/{id}/send-to-buyer", h.Trade.ShareSale)
+				r.Get("/{id}/seal", h.LotSeal.GetSeal)
+				r.With(can(data.PermIncomeUpdate)).Post("/{id}/seal", h.LotSeal.SealLot)
+				r.Get("/{id}/attachments", h.Attachment.GetIncomeAttachments)
+				r.With(can(data.PermIncomeUpdate), middleware.AllowUpload).Post("/{id}/attachments", h.Attachment.AddIncomeAttachment)
+				r.Get("/{id}/attachments/{attachmentId}", h.Attachment.DownloadIncomeAttachment)
+				r.With(can(data.PermIncomeUpdate)).Delete("/{id}/attachments/{attachmentId}", h.Attachment.DeleteIncomeAttachment)
 			})
 
 			// Expense routes
 			r.Route("/expense", func(r chi.Router) {
-				r.Get("/", expenseHandler.GetAllExpenses)
-				r.Post("/", expenseHandler.CreateExpense)
-				r.Get("/range", expenseHandler.GetExpenseByDateRange)
-				r.Get("/breakdown", expenseHandler.GetExpenseCategoryBreakdown)
-				r.Get("/{id}", expenseHandler.GetExpense)
-				r.Put("/{id}", expenseHandler.UpdateExpense)
-				r.Delete("/{id}", expenseHandler.DeleteExpense)
+				r.Get("/", h.Expense.GetAllExpenses)
+				r.With(can(data.PermExpenseCreate), middleware.AllowUpload, recordLimit).Post("/", h.Expense.CreateExpense)
+				r.Get("/range", h.Expense.GetExpenseByDateRange)
+				r.Get("/breakdown", h.Expense.GetExpenseCategoryBreakdown)
+				r.Get("/pending-approval", h.Expense.GetPendingApprovals)
+				r.Get("/pending-sign-off", h.Expense.GetAwaitingSignOff)
+				r.Get("/prepaid", h.Expense.GetPrepaidExpenses)
+				r.Get("/trash", h.Expense.GetExpenseTrash)
+				r.Get("/{id}", h.Expense.GetExpense)
+				r.With(can(data.PermExpenseUpdate), middleware.AllowUpload).Put("/{id}", h.Expense.UpdateExpense)
+				r.With(can(data.PermExpenseDelete)).Delete("/{id}", h.Expense.DeleteExpense)
+				r.With(can(data.PermExpenseDelete)).Post("/{id}/restore", h.Expense.RestoreExpense)
+				r.With(can(data.PermSettingsManage)).Delete("/{id}/permanent", h.Expense.PurgeExpense)
+				r.Post("/{id}/approve", h.Expense.ApproveExpense)
+				r.Post("/{id}/reject", h.Expense.RejectExpense)
+				r.Get("/{id}/sign-offs", h.Expense.GetExpenseSignOffs)
+				r.Post("/{id}/sign-off", h.Expense.SignOffExpense)
+				r.Get("/{id}/amortization", h.Expense.GetExpenseAmortization)
+				r.Get("/{id}/payments", h.Expense.GetExpensePayments)
+				r.With(can(data.PermPaymentRecord)).Post("/{id}/payments", h.Expense.AddExpensePayment)
+				r.Get("/{id}/attachments", h.Attachment.GetExpenseAttachments)
+				r.With(can(data.PermExpenseUpdate), middleware.AllowUpload).Post("/{id}/attachments", h.Attachment.AddExpenseAttachment)
+				r.Get("/{id}/attachments/{attachmentId}", h.Attachment.DownloadExpenseAttachment)
+				r.With(can(data.PermExpenseUpdate)).Delete("/{id}/attachments/{attachmentId}", h.Attachment.DeleteExpenseAttachment)
+			})
+
+			// Reimbursement claims of staff who paid expenses personally; members who can't approve
+			// expenses only see their own
+			r.Route("/claims", func(r chi.Router) {
+				r.Get("/", h.Claim.GetClaims)
+				r.Post("/", h.Claim.CreateClaim)
+				r.Get("/statement", h.Claim.GetClaimantStatement)
+				r.Get("/{id}", h.Claim.GetClaim)
+				r.Put("/{id}", h.Claim.UpdateClaim)
+				r.Delete("/{id}", h.Claim.DeleteClaim)
+				r.Post("/{id}/approve", h.Claim.ApproveClaim)
+				r.Post("/{id}/reject", h.Claim.RejectClaim)
+				r.Get("/{id}/payments", h.Claim.GetClaimPayments)
+				r.With(can(data.PermPaymentRecord)).Post("/{id}/payments", h.Claim.AddClaimPayment)
+				r.Get("/{id}/attachments", h.Attachment.GetClaimAttachments)
+				r.With(middleware.AllowUpload).Post("/{id}/attachments", h.Attachment.AddClaimAttachment)
+				r.Get("/{id}/attachments/{attachmentId}", h.Attachment.DownloadClaimAttachment)
+				r.Delete("/{id}/attachments/{attachmentId}", h.Attachment.DeleteClaimAttachment)
 			})
 
 			// Inventory routes
 			r.Route("/inventory", func(r chi.Router) {
-				r.Get("/", inventoryHandler.GetAllInventory)
-				r.Post("/", inventoryHandler.CreateInventoryItem)
-				r.Get("/low-stock", inventoryHandler.GetLowStockItems)
-				r.Get("/{id}", inventoryHandler.GetInventoryItem)
-				r.Put("/{id}", inventoryHandler.UpdateInventoryItem)
-				r.Delete("/{id}", inventoryHandler.DeleteInventoryItem)
-				r.Patch("/{id}/quantity", inventoryHandler.UpdateQuantity)
+				r.Get("/", h.Inventory.GetAllInventory)
+				r.With(can(data.PermInventoryCreate), recordLimit).Post("/", h.Inventory.CreateInventoryItem)
+				r.Get("/low-stock", h.Inventory.GetLowStockItems)
+				r.Get("/expiring", h.Inventory.GetExpiringItems)
+				r.Get("/hazardous", h.Inventory.GetHazardousRegister)
+				r.Get("/compliance", h.Inventory.GetComplianceWarnings)
+				r.Get("/season-plan", h.Inventory.GetSeasonalStockPlan)
+				r.Get("/trash", h.Inventory.GetInventoryTrash)
+				r.Get("/{id}", h.Inventory.GetInventoryItem)
+				r.With(can(data.PermInventoryUpdate)).Put("/{id}", h.Inventory.UpdateInventoryItem)
+				r.With(can(data.PermInventoryDelete)).Delete("/{id}", h.Inventory.DeleteInventoryItem)
+				r.With(can(data.PermInventoryDelete)).Post("/{id}/restore", h.Inventory.RestoreInventoryItem)
+				r.With(can(data.PermSettingsManage)).Delete("/{id}/permanent", h.Inventory.PurgeInventoryItem)
+				r.With(can(data.PermInventoryUpdate), middleware.AllowUpload).Patch("/{id}/quantity", h.Inventory.UpdateQuantity)
+				r.Get("/{id}/movements", h.Inventory.GetStockMovements)
+				r.With(can(data.PermInventoryUpdate), middleware.AllowUpload).Post("/{id}/usage", h.Inventory.RecordUsage)
+				r.With(can(data.PermInventoryUpdate)).Post("/{id}/write-off", h.Inventory.WriteOffStock)
+				r.Get("/{id}/attachments", h.Inventory.GetAttachments)
+				r.With(can(data.PermInventoryUpdate), middleware.AllowUpload).Post("/{id}/attachments", h.Inventory.AddAttachment)
+				r.With(can(data.PermInventoryUpdate)).Delete("/{id}/attachments/{attachmentId}", h.Inventory.DeleteAttachment)
+				r.With(can(data.PermInventoryUpdate)).Put("/{id}/primary-image", h.Inventory.SetPrimaryImage)
+			})
+
+			// Stocktake routes
+			r.Route("/stocktakes", func(r chi.Router) {
+				r.Get("/", h.Stocktake.GetAllStocktakes)
+				r.With(can(data.PermInventoryUpdate)).Post("/", h.Stocktake.CreateStocktake)
+				r.Get("/{id}", h.Stocktake.GetStocktake)
+				r.With(can(data.PermInventoryUpdate)).Put("/{id}/counts", h.Stocktake.RecordCounts)
+				r.Get("/{id}/variance", h.Stocktake.GetVarianceReport)
+				r.With(can(data.PermInventoryUpdate)).Post("/{id}/approve", h.Stocktake.ApproveStocktake)
+				r.With(can(data.PermInventoryUpdate)).Post("/{id}/cancel", h.Stocktake.CancelStocktake)
+			})
+
+			// Vehicle and trip routes
+			r.Route("/vehicles", func(r chi.Router) {
+				r.Get("/", h.Transport.GetAllVehicles)
+				r.Post("/", h.Transport.CreateVehicle)
+				r.Get("/costs", h.Transport.GetVehicleCosts)
+				r.Put("/{id}", h.Transport.UpdateVehicle)
+				r.Delete("/{id}", h.Transport.DeleteVehicle)
+			})
+			// Equipment asset register, depreciated monthly into the profit and loss
+			r.Route("/equipment", func(r chi.Router) {
+				r.Get("/", h.Equipment.GetAllEquipment)
+				r.With(can(data.PermExpenseCreate)).Post("/", h.Equipment.CreateEquipment)
+				r.Get("/register", h.Equipment.GetAssetRegister)
+				r.Get("/{id}", h.Equipment.GetEquipment)
+				r.With(can(data.PermExpenseUpdate)).Put("/{id}", h.Equipment.UpdateEquipment)
+				r.With(can(data.PermExpenseDelete)).Delete("/{id}", h.Equipment.DeleteEquipment)
+				r.Get("/{id}/depreciation", h.Equipment.GetEquipmentDepreciation)
+				r.With(can(data.PermExpenseUpdate)).Post("/{id}/dispose", h.Equipment.DisposeEquipment)
+			})
+			// Insurance policies and claims, with payouts booked as sales
+			r.Route("/insurance", func(r chi.Router) {
+				r.Get("/policies", h.Insurance.GetPolicies)
+				r.With(can(data.PermExpenseCreate)).Post("/policies", h.Insurance.CreatePolicy)
+				r.Get("/policies/{id}", h.Insurance.GetPolicy)
+				r.With(can(data.PermExpenseUpdate)).Put("/policies/{id}", h.Insurance.UpdatePolicy)
+				r.With(can(data.PermExpenseDelete)).Delete("/policies/{id}", h.Insurance.DeletePolicy)
+				r.Get("/claims", h.Insurance.GetInsuranceClaims)
+				r.With(can(data.PermExpenseCreate)).Post("/claims", h.Insurance.CreateInsuranceClaim)
+				r.Get("/claims/{id}", h.Insurance.GetInsuranceClaim)
+				r.With(can(data.PermExpenseUpdate)).Put("/claims/{id}", h.Insurance.UpdateInsuranceClaim)
+				r.With(can(data.PermExpenseDelete)).Delete("/claims/{id}", h.Insurance.DeleteInsuranceClaim)
+				r.With(can(data.PermIncomeCreate)).Post("/claims/{id}/payout", h.Insurance.RecordPayout)
+			})
+			// Supplies site staff ask for, approved by managers and bought on purchase orders that are
+			// booked as expenses when received; members who can't approve expenses see their own requests
+			r.Route("/procurement", func(r chi.Router) {
+				r.Get("/requests", h.Procurement.GetProcurementRequests)
+				r.Post("/requests", h.Procurement.CreateProcurementRequest)
+				r.Get("/requests/{id}", h.Procurement.GetProcurementRequest)
+				r.Put("/requests/{id}", h.Procurement.UpdateProcurementRequest)
+				r.Delete("/requests/{id}", h.Procurement.DeleteProcurementRequest)
+				r.Post("/requests/{id}/approve", h.Procurement.ApproveProcurementRequest)
+				r.Post("/requests/{id}/reject", h.Procurement.RejectProcurementRequest)
+				r.Get("/orders", h.Procurement.GetPurchaseOrders)
+				r.With(can(data.PermExpenseApprove)).Post("/orders", h.Procurement.CreatePurchaseOrder)
+				r.Get("/orders/{id}", h.Procurement.GetPurchaseOrder)
+				r.With(can(data.PermExpenseApprove)).Post("/orders/{id}/cancel", h.Procurement.CancelPurchaseOrder)
+				r.With(can(data.PermExpenseCreate), recordLimit).Post("/orders/{id}/receive", h.Procurement.ReceivePurchaseOrder)
+			})
+			r.Route("/trips", func(r chi.Router) {
+				r.Get("/", h.Transport.GetAllTrips)
+				r.With(recordLimit).Post("/", h.Transport.CreateTrip)
+				r.Get("/delivery-costs", h.Transport.GetDeliveryCosts)
+				r.Get("/{id}", h.Transport.GetTrip)
+				r.Put("/{id}", h.Transport.UpdateTrip)
+				r.Delete("/{id}", h.Transport.DeleteTrip)
+			})
+
+			// Contractor and labor gang routes
+			r.Route("/contractors", func(r chi.Router) {
+				r.Get("/", h.Contractor.GetAllContractors)
+				r.With(can(data.PermExpenseCreate)).Post("/", h.Contractor.CreateContractor)
+				r.Get("/{id}", h.Contractor.GetContractor)
+				r.With(can(data.PermExpenseUpdate)).Put("/{id}", h.Contractor.UpdateContractor)
+				r.With(can(data.PermExpenseDelete)).Delete("/{id}", h.Contractor.DeleteContractor)
+				r.Get("/{id}/work", h.Contractor.GetWork)
+				r.With(can(data.PermExpenseCreate)).Post("/{id}/work", h.Contractor.RecordWork)
+				r.With(can(data.PermExpenseDelete)).Delete("/{id}/work/{workId}", h.Contractor.DeleteWork)
+				r.Get("/{id}/statement", h.Contractor.GetStatement)
+			})
+
+			// Employee and timesheet routes
+			r.Route("/employees", func(r chi.Router) {
+				r.Get("/", h.Employee.GetAllEmployees)
+				r.With(can(data.PermPayrollApprove)).Post("/", h.Employee.CreateEmployee)
+				r.Get("/{id}", h.Employee.GetEmployee)
+				r.With(can(data.PermPayrollApprove)).Put("/{id}", h.Employee.UpdateEmployee)
+				r.With(can(data.PermPayrollApprove)).Delete("/{id}", h.Employee.DeleteEmployee)
+				r.Get("/{id}/adjustments", h.Payroll.GetAdjustments)
+				r.With(can(data.PermPayrollApprove)).Post("/{id}/adjustments", h.Payroll.CreateAdjustment)
+				r.With(can(data.PermPayrollApprove)).Delete("/{id}/adjustments/{adjustmentId}", h.Payroll.DeleteAdjustment)
+				r.Get("/{id}/statement", h.Payroll.GetEmployeeStatement)
+			})
+			r.Route("/attendance", func(r chi.Router) {
+				r.Get("/", h.Attendance.GetAllAttendance)
+				r.Post("/check-in", h.Attendance.CheckIn)
+				r.Post("/check-out", h.Attendance.CheckOut)
+				r.Get("/{id}", h.Attendance.GetAttendance)
+			})
+			r.Route("/timesheets", func(r chi.Router) {
+				r.Get("/", h.Timesheet.GetAllTimesheets)
+				r.Post("/", h.Timesheet.SubmitTimesheet)
+				r.Get("/labor-cost", h.Timesheet.GetLaborCost)
+				r.With(can(data.PermPayrollApprove)).Post("/{id}/approve", h.Timesheet.ApproveTimesheet)
+				r.With(can(data.PermPayrollApprove)).Post("/{id}/reject", h.Timesheet.RejectTimesheet)
+				r.Delete("/{id}", h.Timesheet.DeleteTimesheet)
+			})
+
+			// Payroll routes
+			r.Route("/payroll", func(r chi.Router) {
+				r.Get("/", h.Payroll.GetAllPayrollRuns)
+				r.With(can(data.PermPayrollApprove)).Post("/", h.Payroll.CreatePayrollRun)
+				r.Get("/{id}", h.Payroll.GetPayrollRun)
+				r.With(can(data.PermPayrollApprove)).Delete("/{id}", h.Payroll.DeletePayrollRun)
+			})
+
+			// Export routes (require export permission)
+			r.Route("/exports", func(r chi.Router) {
+				r.Use(middleware.RequireExportPermission)
+				r.Use(requireReports)
+				r.Get("/income", h.Export.ExportIncome)
+				r.Get("/expenses", h.Export.ExportExpenses)
+				r.Get("/inventory", h.Export.ExportInventory)
+				r.Get("/miners", h.Export.ExportMiners)
+			})
+
+			// Archived transaction periods
+			r.Get("/archive", h.Archive.GetArchivedPeriods)
+
+			// Event streams of inventory and payment changes
+			r.Route("/events", func(r chi.Router) {
+				r.Get("/", h.Stream.GetEvents)
+				r.Get("/{stream}/{id}/rebuild", h.Stream.RebuildBalance)
+			})
+
+			// Audit log routes
+			r.Get("/audit-logs", h.Audit.GetAuditLogs)
+
+			// Receipt routes
+			r.Route("/receipts", func(r chi.Router) {
+				r.Get("/", h.Receipt.GetAllReceipts)
+				r.Get("/{id}", h.Receipt.GetReceipt)
+				r.Get("/{id}/pdf", h.Receipt.DownloadReceiptPDF)
+				r.With(can(data.PermPaymentRecord)).Post("/{id}/send", h.Receipt.SendReceipt)
+			})
+
+			// Credit note routes
+			r.Route("/credit-notes", func(r chi.Router) {
+				r.Get("/", h.CreditNote.GetAllCreditNotes)
+				r.Get("/{id}", h.CreditNote.GetCreditNote)
+			})
+
+			// Task routes
+			r.Route("/tasks", func(r chi.Router) {
+				r.Get("/", h.Task.GetAllTasks)
+				r.Post("/", h.Task.CreateTask)
+				r.Get("/{id}", h.Task.GetTask)
+				r.Put("/{id}", h.Task.UpdateTask)
+				r.Delete("/{id}", h.Task.DeleteTask)
+				r.Post("/{id}/complete", h.Task.CompleteTask)
+				r.Post("/{id}/reopen", h.Task.ReopenTask)
+			})
+
+			// Photo evidence routes
+			r.Route("/evidence", func(r chi.Router) {
+				r.Get("/rules", h.Evidence.GetRules)
+				r.Put("/rules/{operation}", h.Evidence.SaveRule)
+				r.Delete("/rules/{operation}", h.Evidence.DeleteRule)
+				r.Get("/photos", h.Evidence.GetPhotos)
+				r.Get("/photos/{id}", h.Evidence.DownloadPhoto)
+			})
+
+			// Customer credit limit routes
+			r.Route("/credit-limits", func(r chi.Router) {
+				r.Get("/", h.CreditLimit.GetCreditLimits)
+				r.Post("/", h.CreditLimit.SaveCreditLimit)
+				r.Delete("/{id}", h.CreditLimit.DeleteCreditLimit)
+			})
+
+			// Price benchmark routes
+			r.Get("/benchmarks/prices", h.Benchmark.GetPriceBenchmark)
+
+			// Shared sale routes between trading partners
+			r.Route("/trades", func(r chi.Router) {
+				r.Get("/shared", h.Trade.GetSharedSales)
+				r.Get("/purchases", h.Trade.GetPurchases)
+				r.With(can(data.PermExpenseCreate), middleware.AllowUpload, recordLimit).Post("/purchases/{id}/accept", h.Trade.AcceptPurchase)
+				r.With(can(data.PermExpenseCreate)).Post("/purchases/{id}/decline", h.Trade.DeclinePurchase)
+				r.Get("/{id}/disputes", h.Trade.GetDisputes)
+				r.Post("/{id}/disputes", h.Trade.OpenDispute)
+				r.Post("/{id}/disputes/messages", h.Trade.AddDisputeMessage)
+				r.Post("/{id}/disputes/proposal", h.Trade.ProposeResolution)
+				r.With(can(data.PermIncomeUpdate), can(data.PermIncomeDelete)).Post("/{id}/disputes/accept", h.Trade.AcceptResolution)
+				r.Post("/{id}/disputes/withdraw", h.Trade.WithdrawDispute)
+			})
+
+			// Webhook routes
+			r.Route("/webhooks", func(r chi.Router) {
+				r.Get("/", h.Webhook.GetWebhooks)
+				r.With(requireWebhooks).Post("/", h.Webhook.CreateWebhook)
+				r.Delete("/{id}", h.Webhook.DeleteWebhook)
+			})
+
+			// Form metadata routes
+			r.Route("/forms", func(r chi.Router) {
+				r.Get("/", h.Form.GetForms)
+				r.Get("/{recordType}", h.Form.GetForm)
+			})
+
+			// Counterparty risk flag routes
+			r.Route("/flags", func(r chi.Router) {
+				r.Get("/", h.Flag.GetFlags)
+				r.Post("/", h.Flag.SaveFlag)
+				r.Delete("/{id}", h.Flag.DeleteFlag)
+			})
+
+			// Contact book routes
+			r.Route("/contacts", func(r chi.Router) {
+				r.Get("/", h.Contact.GetAllContacts)
+				r.Post("/", h.Contact.CreateContact)
+				r.With(middleware.AllowUpload).Post("/import", h.Contact.ImportContacts)
+				r.Get("/export", h.Contact.ExportContacts)
+				r.Get("/{id}", h.Contact.GetContact)
+				r.Put("/{id}", h.Contact.UpdateContact)
+				r.Delete("/{id}", h.Contact.DeleteContact)
+			})
+
+			// Bulk SMS routes
+			r.Route("/sms", func(r chi.Router) {
+				r.Get("/contacts", h.BulkSMS.GetContacts)
+				r.Get("/usage", h.BulkSMS.GetUsage)
+				r.Get("/campaigns", h.BulkSMS.GetCampaigns)
+				r.With(requireSMS, smsLimit).Post("/campaigns", h.BulkSMS.SendCampaign)
+				r.Get("/campaigns/{id}", h.BulkSMS.GetCampaign)
+				r.Get("/opt-outs", h.BulkSMS.GetOptOuts)
+				r.Post("/opt-outs", h.BulkSMS.CreateOptOut)
+				r.Delete("/opt-outs/{id}", h.BulkSMS.DeleteOptOut)
+			})
+
+			// Calendar routes
+			r.Route("/calendar", func(r chi.Router) {
+				r.Get("/feed", h.Calendar.GetFeedLink)
+				r.Post("/feed/reset", h.Calendar.ResetFeedLink)
+				r.Get("/events.ics", h.Calendar.DownloadCalendar)
+			})
+
+			// Dunning routes
+			r.Route("/dunning/schedules", func(r chi.Router) {
+				r.Get("/", h.Dunning.GetSchedules)
+				r.With(can(data.PermSettingsManage), requireSMS).Post("/", h.Dunning.CreateSchedule)
+				r.Get("/{id}", h.Dunning.GetSchedule)
+				r.With(can(data.PermSettingsManage)).Put("/{id}", h.Dunning.UpdateSchedule)
+				r.With(can(data.PermSettingsManage)).Delete("/{id}", h.Dunning.DeleteSchedule)
+			})
+
+			// Share link routes
+			r.Route("/share-links", func(r chi.Router) {
+				r.Get("/", h.ShareLink.GetAllShareLinks)
+				r.With(can(data.PermIncomeUpdate)).Post("/statement", h.ShareLink.ShareStatement)
+				r.Get("/{id}/views", h.ShareLink.GetShareLinkViews)
+				r.With(can(data.PermIncomeUpdate)).Delete("/{id}", h.ShareLink.RevokeShareLink)
+			})
+
+			// Notification routes
+			r.Route("/notifications", func(r chi.Router) {
+				r.Get("/", h.Notification.GetNotifications)
+				r.Post("/read-all", h.Notification.MarkAllNotificationsRead)
+				r.Patch("/{id}/read", h.Notification.MarkNotificationRead)
 			})
 
 			// Analytics routes
 			r.Route("/analytics", func(r chi.Router) {
-				r.Get("/summary", analyticsHandler.GetFinancialSummary)
-				r.Get("/monthly", analyticsHandler.GetMonthlyData)
-				r.Get("/expense-breakdown", analyticsHandler.GetExpenseCategoryBreakdown)
+				r.Get("/summary", h.Analytics.GetFinancialSummary)
+				r.Get("/monthly", h.Analytics.GetMonthlyData)
+				r.Get("/expense-breakdown", h.Analytics.GetExpenseCategoryBreakdown)
+				r.With(requireReports).Get("/fiscal-year", h.Analytics.GetFiscalYearReport)
+				r.With(requireReports).Get("/fiscal-ytd", h.Analytics.GetFiscalYTDSummary)
+				r.With(requireReports).Get("/period", h.Analytics.GetPeriodSummary)
+				r.With(requireReports).Get("/sites", h.CostAllocation.GetSiteProfitability)
+				r.With(requireReports).Get("/pit-profitability", h.CostAllocation.GetPitProfitability)
 			})
 
 			// Mine site info routes
 			r.Route("/minesite", func(r chi.Router) {
-				r.Get("/", mineSiteHandler.GetMineSiteInfo)
-				r.Post("/", mineSiteHandler.CreateOrUpdateMineSiteInfo)
-				r.Put("/", mineSiteHandler.CreateOrUpdateMineSiteInfo)
+				r.Get("/", h.MineSite.GetMineSiteInfo)
+				r.With(can(data.PermSettingsManage)).Post("/", h.MineSite.CreateOrUpdateMineSiteInfo)
+				r.With(can(data.PermSettingsManage)).Put("/", h.MineSite.CreateOrUpdateMineSiteInfo)
+				r.Get("/sites", h.MineSite.GetMineSites)
+				r.With(can(data.PermSettingsManage)).Post("/sites", h.MineSite.CreateMineSite)
+				r.Get("/sites/{id}", h.MineSite.GetMineSite)
+				r.With(can(data.PermSettingsManage)).Put("/sites/{id}", h.MineSite.UpdateMineSite)
+				r.With(can(data.PermSettingsManage)).Delete("/sites/{id}", h.MineSite.DeleteMineSite)
+				r.With(requireReports).Get("/sites/{id}/regulator-report", h.RegulatorReport.DownloadRegulatorReport)
+			})
+
+			// Due-diligence self-assessment routes
+			r.Route("/due-diligence", func(r chi.Router) {
+				r.Get("/questions", h.DueDiligence.GetQuestions)
+				r.Get("/", h.DueDiligence.GetAssessments)
+				r.Post("/", h.DueDiligence.CreateAssessment)
+				r.Get("/{id}", h.DueDiligence.GetAssessment)
+				r.Put("/{id}", h.DueDiligence.UpdateAssessment)
+				r.Delete("/{id}", h.DueDiligence.DeleteAssessment)
+				r.Post("/{id}/complete", h.DueDiligence.CompleteAssessment)
+				r.Get("/{id}/summary", h.DueDiligence.DownloadSummary)
+				r.Get("/{id}/attachments", h.Attachment.GetDueDiligenceAttachments)
+				r.With(middleware.AllowUpload).Post("/{id}/attachments", h.Attachment.AddDueDiligenceAttachment)
+				r.Get("/{id}/attachments/{attachmentId}", h.Attachment.DownloadDueDiligenceAttachment)
+				r.Delete("/{id}/attachments/{attachmentId}", h.Attachment.DeleteDueDiligenceAttachment)
+			})
+
+			// Assay result routes
+			r.Route("/assays", func(r chi.Router) {
+				r.Get("/", h.Assay.GetAssays)
+				r.Post("/", h.Assay.CreateAssay)
+				r.Get("/grade-price", h.Assay.GetGradePriceAnalysis)
+				r.Get("/{id}", h.Assay.GetAssay)
+				r.Put("/{id}", h.Assay.UpdateAssay)
+				r.Delete("/{id}", h.Assay.DeleteAssay)
+				r.Get("/{id}/attachments", h.Attachment.GetAssayAttachments)
+				r.With(middleware.AllowUpload).Post("/{id}/attachments", h.Attachment.AddAssayAttachment)
+				r.Get("/{id}/attachments/{attachmentId}", h.Attachment.DownloadAssayAttachment)
+				r.Delete("/{id}/attachments/{attachmentId}", h.Attachment.DeleteAssayAttachment)
+			})
+
+			// Buying station purchase routes
+			r.Route("/purchases", func(r chi.Router) {
+				r.Get("/", h.Purchase.GetAllPurchases)
+				r.With(can(data.PermPurchaseCreate), recordLimit).Post("/", h.Purchase.CreatePurchase)
+				r.Post("/quote", h.Purchase.QuotePurchase)
+				r.Get("/payables", h.Purchase.GetPayables)
+				r.Get("/statement", h.Purchase.GetMinerStatement)
+				r.Get("/{id}", h.Purchase.GetPurchase)
+				r.With(can(data.PermPurchaseUpdate)).Put("/{id}", h.Purchase.UpdatePurchase)
+				r.With(can(data.PermPurchaseDelete)).Delete("/{id}", h.Purchase.DeletePurchase)
+				r.Get("/{id}/payments", h.Purchase.GetPurchasePayments)
+				r.With(can(data.PermPaymentRecord)).Post("/{id}/payments", h.Purchase.AddPurchasePayment)
+				r.Get("/{id}/price-overrides", h.Purchase.GetPriceOverrides)
+			})
+
+			// Market prices purchases are priced from
+			r.Route("/market-prices", func(r chi.Router) {
+				r.Get("/", h.MarketPrice.GetMarketPrices)
+				r.With(can(data.PermPriceManage)).Post("/", h.MarketPrice.CreateMarketPrice)
+				r.Get("/current", h.MarketPrice.GetCurrentMarketPrice)
+				r.With(can(data.PermPriceManage)).Delete("/{id}", h.MarketPrice.DeleteMarketPrice)
+			})
+
+			// Reference prices of common supplies published to the members of the books
+			r.Route("/supply-prices", func(r chi.Router) {
+				r.Get("/", h.SupplyPrice.GetSupplyPrices)
+				r.With(can(data.PermPriceManage)).Post("/", h.SupplyPrice.CreateSupplyPrice)
+				r.With(can(data.PermExpenseApprove)).Get("/variances", h.SupplyPrice.GetPriceVariances)
+				r.With(can(data.PermPriceManage)).Put("/{id}", h.SupplyPrice.UpdateSupplyPrice)
+				r.With(can(data.PermPriceManage)).Delete("/{id}", h.SupplyPrice.DeleteSupplyPrice)
+			})
+
+			// Rules splitting the expenses shared by mine sites in site and pit profitability reports
+			r.Route("/cost-allocations", func(r chi.Router) {
+				r.Get("/", h.CostAllocation.GetCostAllocations)
+				r.With(can(data.PermSettingsManage)).Post("/", h.CostAllocation.CreateCostAllocation)
+				r.With(can(data.PermSettingsManage)).Put("/{id}", h.CostAllocation.UpdateCostAllocation)
+				r.With(can(data.PermSettingsManage)).Delete("/{id}", h.CostAllocation.DeleteCostAllocation)
+			})
+
+			// Daily cash position of buying stations
+			r.Route("/cash-days", func(r chi.Router) {
+				r.Get("/", h.CashDay.GetCashDays)
+				r.With(can(data.PermCashManage)).Post("/", h.CashDay.OpenCashDay)
+				r.Get("/discrepancies", h.CashDay.GetCashDiscrepancies)
+				r.Get("/{id}", h.CashDay.GetCashDay)
+				r.With(can(data.PermCashManage)).Post("/{id}/movements", h.CashDay.AddCashMovement)
+				r.With(can(data.PermCashManage)).Post("/{id}/close", h.CashDay.CloseCashDay)
+			})
+
+			// Tills of buying stations and the clerks accountable for them
+			r.Route("/tills", func(r chi.Router) {
+				r.Get("/", h.Till.GetTills)
+				r.With(can(data.PermTillManage)).Post("/", h.Till.CreateTill)
+				r.Get("/variances", h.Till.GetTillVariances)
+				r.Get("/{id}", h.Till.GetTill)
+				r.With(can(data.PermTillManage)).Put("/{id}", h.Till.UpdateTill)
+				r.With(can(data.PermTillManage)).Delete("/{id}", h.Till.DeleteTill)
+			})
+
+			// Handing a till or station's cash over between clerks
+			r.Route("/shift-handovers", func(r chi.Router) {
+				r.Get("/", h.ShiftHandover.GetShiftHandovers)
+				r.With(can(data.PermCashManage)).Post("/", h.ShiftHandover.CreateShiftHandover)
+				r.Get("/{id}", h.ShiftHandover.GetShiftHandover)
+				r.With(can(data.PermCashManage)).Post("/{id}/sign-off", h.ShiftHandover.SignOffShiftHandover)
+			})
+
+			// Miner registry routes
+			r.Route("/miners", func(r chi.Router) {
+				r.Get("/", h.Miner.GetAllMiners)
+				r.With(can(data.PermPurchaseCreate)).Post("/", h.Miner.CreateMiner)
+				r.Get("/{id}", h.Miner.GetMiner)
+				r.With(can(data.PermPurchaseUpdate)).Put("/{id}", h.Miner.UpdateMiner)
+				r.With(can(data.PermPurchaseDelete)).Delete("/{id}", h.Miner.DeleteMiner)
+				r.Get("/{id}/purchases", h.Miner.GetMinerPurchases)
+				r.Get("/{id}/attachments", h.Attachment.GetMinerAttachments)
+				r.With(can(data.PermPurchaseUpdate), middleware.AllowUpload).Post("/{id}/attachments", h.Attachment.AddMinerAttachment)
+				r.Get("/{id}/attachments/{attachmentId}", h.Attachment.DownloadMinerAttachment)
+				r.With(can(data.PermPurchaseUpdate)).Delete("/{id}/attachments/{attachmentId}", h.Attachment.DeleteMinerAttachment)
+			})
+
+			// Organization settings routes
+			r.Route("/settings", func(r chi.Router) {
+				r.Get("/", h.Settings.GetSettings)
+				r.With(can(data.PermSettingsManage)).Put("/", h.Settings.UpdateSettings)
+			})
+
+			// Invoice and receipt template routes
+			r.Route("/document-templates", func(r chi.Router) {
+				r.Get("/", h.DocumentTemplate.GetDocumentTemplates)
+				r.Get("/{kind}", h.DocumentTemplate.GetDocumentTemplate)
+				r.With(can(data.PermSettingsManage)).Put("/{kind}", h.DocumentTemplate.UpdateDocumentTemplate)
+				r.Get("/{kind}/logo", h.DocumentTemplate.GetDocumentLogo)
+				r.With(can(data.PermSettingsManage), middleware.AllowUpload).Put("/{kind}/logo", h.DocumentTemplate.UploadDocumentLogo)
+				r.With(can(data.PermSettingsManage)).Delete("/{kind}/logo", h.DocumentTemplate.DeleteDocumentLogo)
 			})
 
 			// Admin routes (require admin role)
 			r.Group(func(r chi.Router) {
 				r.Use(middleware.AdminMiddleware)
-				// Add admin-specific routes here if needed
+				r.Get("/admin/deliveries", h.Auth.GetDeliveries)
+				r.Get("/admin/backups", h.Backup.GetBackups)
+				r.Get("/admin/metrics", h.Metrics.GetMetrics)
+				r.Get("/admin/usage", h.Usage.GetAllUsage)
+				r.Put("/admin/usage/{userId}/plan", h.Usage.SetPlan)
+				r.Put("/admin/usage/{userId}/attachment-quota", h.Usage.SetAttachmentQuota)
+				r.Get("/admin/referrals", h.Referral.GetReferrers)
+				r.Get("/admin/support", h.Support.GetAllTickets)
+				r.Post("/admin/support/{id}/resolve", h.Support.ResolveTicket)
+				r.Get("/admin/features/{userId}", h.Subscription.GetFeatures)
+				r.Put("/admin/features/{userId}/{feature}", h.Subscription.SetFeatureFlag)
+				r.Delete("/admin/features/{userId}/{feature}", h.Subscription.DeleteFeatureFlag)
+				r.Get("/admin/invite-codes", h.Auth.GetInviteCodes)
+				r.Post("/admin/invite-codes", h.Auth.CreateInviteCode)
+				r.Delete("/admin/invite-codes/{id}", h.Auth.RevokeInviteCode)
+				r.Post("/admin/users/{userId}/unlock", h.Auth.UnlockUser)
 			})
 		})
 	})
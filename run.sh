@@ -29,7 +29,7 @@ go mod tidy
 
 # Run the application
 echo "Starting server on port $PORT..."
-go run cmd/api/main.go
+go run ./cmd/api
 
 
 